@@ -0,0 +1,511 @@
+// Beads gRPC API, served by `bd serve --grpc`.
+//
+// Regenerate the Go bindings with `make proto` after editing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: beadsv1/beads.proto
+
+package beadsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Beads_GetIssue_FullMethodName         = "/beads.v1.Beads/GetIssue"
+	Beads_ListIssues_FullMethodName       = "/beads.v1.Beads/ListIssues"
+	Beads_ReadyWork_FullMethodName        = "/beads.v1.Beads/ReadyWork"
+	Beads_CreateIssue_FullMethodName      = "/beads.v1.Beads/CreateIssue"
+	Beads_UpdateIssue_FullMethodName      = "/beads.v1.Beads/UpdateIssue"
+	Beads_CloseIssue_FullMethodName       = "/beads.v1.Beads/CloseIssue"
+	Beads_AddDependency_FullMethodName    = "/beads.v1.Beads/AddDependency"
+	Beads_RemoveDependency_FullMethodName = "/beads.v1.Beads/RemoveDependency"
+	Beads_ListDependencies_FullMethodName = "/beads.v1.Beads/ListDependencies"
+	Beads_WatchEvents_FullMethodName      = "/beads.v1.Beads/WatchEvents"
+)
+
+// BeadsClient is the client API for Beads service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Beads exposes one beads database to orchestrators and agents.
+//
+// Writes are attributed to the request's actor (falling back to the server's
+// actor) and are committed the same way bd commits them. Missing issues fail
+// with NOT_FOUND and malformed requests with INVALID_ARGUMENT.
+type BeadsClient interface {
+	// GetIssue returns one issue with its labels and dependencies.
+	GetIssue(ctx context.Context, in *GetIssueRequest, opts ...grpc.CallOption) (*Issue, error)
+	// ListIssues returns the issues matching a filter, like bd list.
+	ListIssues(ctx context.Context, in *ListIssuesRequest, opts ...grpc.CallOption) (*ListIssuesResponse, error)
+	// ReadyWork returns open issues with no open blockers, like bd ready.
+	ReadyWork(ctx context.Context, in *ReadyWorkRequest, opts ...grpc.CallOption) (*ListIssuesResponse, error)
+	// CreateIssue creates an issue and returns it with its assigned ID.
+	CreateIssue(ctx context.Context, in *CreateIssueRequest, opts ...grpc.CallOption) (*Issue, error)
+	// UpdateIssue changes the fields set in the request and returns the
+	// updated issue.
+	UpdateIssue(ctx context.Context, in *UpdateIssueRequest, opts ...grpc.CallOption) (*Issue, error)
+	// CloseIssue closes an issue and returns it.
+	CloseIssue(ctx context.Context, in *CloseIssueRequest, opts ...grpc.CallOption) (*Issue, error)
+	// AddDependency records that issue_id depends on depends_on_id.
+	AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*Dependency, error)
+	// RemoveDependency removes the dependency of issue_id on depends_on_id.
+	RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error)
+	// ListDependencies returns the dependencies of one issue.
+	ListDependencies(ctx context.Context, in *ListDependenciesRequest, opts ...grpc.CallOption) (*ListDependenciesResponse, error)
+	// WatchEvents streams audit events (created, status_changed, closed, ...)
+	// as they are recorded, so clients can react to changes instead of
+	// polling. The stream stays open until the client cancels it or the
+	// server shuts down.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type beadsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBeadsClient(cc grpc.ClientConnInterface) BeadsClient {
+	return &beadsClient{cc}
+}
+
+func (c *beadsClient) GetIssue(ctx context.Context, in *GetIssueRequest, opts ...grpc.CallOption) (*Issue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Issue)
+	err := c.cc.Invoke(ctx, Beads_GetIssue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) ListIssues(ctx context.Context, in *ListIssuesRequest, opts ...grpc.CallOption) (*ListIssuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIssuesResponse)
+	err := c.cc.Invoke(ctx, Beads_ListIssues_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) ReadyWork(ctx context.Context, in *ReadyWorkRequest, opts ...grpc.CallOption) (*ListIssuesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIssuesResponse)
+	err := c.cc.Invoke(ctx, Beads_ReadyWork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) CreateIssue(ctx context.Context, in *CreateIssueRequest, opts ...grpc.CallOption) (*Issue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Issue)
+	err := c.cc.Invoke(ctx, Beads_CreateIssue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) UpdateIssue(ctx context.Context, in *UpdateIssueRequest, opts ...grpc.CallOption) (*Issue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Issue)
+	err := c.cc.Invoke(ctx, Beads_UpdateIssue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) CloseIssue(ctx context.Context, in *CloseIssueRequest, opts ...grpc.CallOption) (*Issue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Issue)
+	err := c.cc.Invoke(ctx, Beads_CloseIssue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) AddDependency(ctx context.Context, in *AddDependencyRequest, opts ...grpc.CallOption) (*Dependency, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dependency)
+	err := c.cc.Invoke(ctx, Beads_AddDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) RemoveDependency(ctx context.Context, in *RemoveDependencyRequest, opts ...grpc.CallOption) (*RemoveDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDependencyResponse)
+	err := c.cc.Invoke(ctx, Beads_RemoveDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) ListDependencies(ctx context.Context, in *ListDependenciesRequest, opts ...grpc.CallOption) (*ListDependenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDependenciesResponse)
+	err := c.cc.Invoke(ctx, Beads_ListDependencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beadsClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Beads_ServiceDesc.Streams[0], Beads_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Beads_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// BeadsServer is the server API for Beads service.
+// All implementations must embed UnimplementedBeadsServer
+// for forward compatibility.
+//
+// Beads exposes one beads database to orchestrators and agents.
+//
+// Writes are attributed to the request's actor (falling back to the server's
+// actor) and are committed the same way bd commits them. Missing issues fail
+// with NOT_FOUND and malformed requests with INVALID_ARGUMENT.
+type BeadsServer interface {
+	// GetIssue returns one issue with its labels and dependencies.
+	GetIssue(context.Context, *GetIssueRequest) (*Issue, error)
+	// ListIssues returns the issues matching a filter, like bd list.
+	ListIssues(context.Context, *ListIssuesRequest) (*ListIssuesResponse, error)
+	// ReadyWork returns open issues with no open blockers, like bd ready.
+	ReadyWork(context.Context, *ReadyWorkRequest) (*ListIssuesResponse, error)
+	// CreateIssue creates an issue and returns it with its assigned ID.
+	CreateIssue(context.Context, *CreateIssueRequest) (*Issue, error)
+	// UpdateIssue changes the fields set in the request and returns the
+	// updated issue.
+	UpdateIssue(context.Context, *UpdateIssueRequest) (*Issue, error)
+	// CloseIssue closes an issue and returns it.
+	CloseIssue(context.Context, *CloseIssueRequest) (*Issue, error)
+	// AddDependency records that issue_id depends on depends_on_id.
+	AddDependency(context.Context, *AddDependencyRequest) (*Dependency, error)
+	// RemoveDependency removes the dependency of issue_id on depends_on_id.
+	RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error)
+	// ListDependencies returns the dependencies of one issue.
+	ListDependencies(context.Context, *ListDependenciesRequest) (*ListDependenciesResponse, error)
+	// WatchEvents streams audit events (created, status_changed, closed, ...)
+	// as they are recorded, so clients can react to changes instead of
+	// polling. The stream stays open until the client cancels it or the
+	// server shuts down.
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedBeadsServer()
+}
+
+// UnimplementedBeadsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBeadsServer struct{}
+
+func (UnimplementedBeadsServer) GetIssue(context.Context, *GetIssueRequest) (*Issue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIssue not implemented")
+}
+func (UnimplementedBeadsServer) ListIssues(context.Context, *ListIssuesRequest) (*ListIssuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIssues not implemented")
+}
+func (UnimplementedBeadsServer) ReadyWork(context.Context, *ReadyWorkRequest) (*ListIssuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadyWork not implemented")
+}
+func (UnimplementedBeadsServer) CreateIssue(context.Context, *CreateIssueRequest) (*Issue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIssue not implemented")
+}
+func (UnimplementedBeadsServer) UpdateIssue(context.Context, *UpdateIssueRequest) (*Issue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateIssue not implemented")
+}
+func (UnimplementedBeadsServer) CloseIssue(context.Context, *CloseIssueRequest) (*Issue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseIssue not implemented")
+}
+func (UnimplementedBeadsServer) AddDependency(context.Context, *AddDependencyRequest) (*Dependency, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDependency not implemented")
+}
+func (UnimplementedBeadsServer) RemoveDependency(context.Context, *RemoveDependencyRequest) (*RemoveDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDependency not implemented")
+}
+func (UnimplementedBeadsServer) ListDependencies(context.Context, *ListDependenciesRequest) (*ListDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDependencies not implemented")
+}
+func (UnimplementedBeadsServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedBeadsServer) mustEmbedUnimplementedBeadsServer() {}
+func (UnimplementedBeadsServer) testEmbeddedByValue()               {}
+
+// UnsafeBeadsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BeadsServer will
+// result in compilation errors.
+type UnsafeBeadsServer interface {
+	mustEmbedUnimplementedBeadsServer()
+}
+
+func RegisterBeadsServer(s grpc.ServiceRegistrar, srv BeadsServer) {
+	// If the following call pancis, it indicates UnimplementedBeadsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Beads_ServiceDesc, srv)
+}
+
+func _Beads_GetIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).GetIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_GetIssue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).GetIssue(ctx, req.(*GetIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_ListIssues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).ListIssues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_ListIssues_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).ListIssues(ctx, req.(*ListIssuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_ReadyWork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadyWorkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).ReadyWork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_ReadyWork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).ReadyWork(ctx, req.(*ReadyWorkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_CreateIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).CreateIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_CreateIssue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).CreateIssue(ctx, req.(*CreateIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_UpdateIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).UpdateIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_UpdateIssue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).UpdateIssue(ctx, req.(*UpdateIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_CloseIssue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseIssueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).CloseIssue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_CloseIssue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).CloseIssue(ctx, req.(*CloseIssueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_AddDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).AddDependency(ctx, req.(*AddDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_RemoveDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).RemoveDependency(ctx, req.(*RemoveDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_ListDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeadsServer).ListDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Beads_ListDependencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeadsServer).ListDependencies(ctx, req.(*ListDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Beads_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BeadsServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Beads_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// Beads_ServiceDesc is the grpc.ServiceDesc for Beads service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Beads_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "beads.v1.Beads",
+	HandlerType: (*BeadsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetIssue",
+			Handler:    _Beads_GetIssue_Handler,
+		},
+		{
+			MethodName: "ListIssues",
+			Handler:    _Beads_ListIssues_Handler,
+		},
+		{
+			MethodName: "ReadyWork",
+			Handler:    _Beads_ReadyWork_Handler,
+		},
+		{
+			MethodName: "CreateIssue",
+			Handler:    _Beads_CreateIssue_Handler,
+		},
+		{
+			MethodName: "UpdateIssue",
+			Handler:    _Beads_UpdateIssue_Handler,
+		},
+		{
+			MethodName: "CloseIssue",
+			Handler:    _Beads_CloseIssue_Handler,
+		},
+		{
+			MethodName: "AddDependency",
+			Handler:    _Beads_AddDependency_Handler,
+		},
+		{
+			MethodName: "RemoveDependency",
+			Handler:    _Beads_RemoveDependency_Handler,
+		},
+		{
+			MethodName: "ListDependencies",
+			Handler:    _Beads_ListDependencies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Beads_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "beadsv1/beads.proto",
+}
@@ -1,8 +1,8 @@
 // Package beads provides a minimal public API for extending bd with custom orchestration.
 //
-// Most extensions should use direct SQL queries against bd's database.
-// This package exports only the essential types and functions needed for
-// Go-based extensions that want to use bd's storage layer programmatically.
+// Client is the high-level entry point: it creates, updates, and links
+// issues with the same attribution and Dolt commit behavior as the bd CLI.
+// Storage exposes bd's storage layer directly for everything else.
 //
 // For a working extension example, see examples/bd-example-extension-go.
 package beads
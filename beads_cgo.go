@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
 )
@@ -40,3 +41,13 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	}
 	return store, nil
 }
+
+// isEmbeddedStore reports whether s is (or wraps) an embedded Dolt store.
+func isEmbeddedStore(s Storage) bool {
+	ds, ok := s.(storage.DoltStorage)
+	if !ok {
+		return false
+	}
+	_, ok = storage.UnwrapStore(ds).(*embeddeddolt.EmbeddedDoltStore)
+	return ok
+}
@@ -26,3 +26,9 @@ func OpenBestAvailable(ctx context.Context, beadsDir string) (Storage, error) {
 	}
 	return nil, fmt.Errorf("embedded Dolt requires CGO; use server mode (bd init --server)")
 }
+
+// isEmbeddedStore reports whether s is an embedded Dolt store, which
+// non-CGO builds cannot open.
+func isEmbeddedStore(Storage) bool {
+	return false
+}
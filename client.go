@@ -0,0 +1,247 @@
+package beads
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// Client is a high-level handle on a beads database for Go programs that
+// want to drive beads the way bd does without exec'ing the CLI.
+//
+// Writes are attributed to the client's actor and, against an embedded
+// database, each one is recorded as its own Dolt commit, matching bd's
+// default dolt.auto-commit behavior. Against a Dolt server, writes land in
+// the server's working set exactly as bd's do.
+//
+// For operations the client does not cover, Storage returns the underlying
+// store.
+type Client struct {
+	store      Storage
+	actor      string
+	autoCommit bool
+}
+
+// ClientOptions configures a Client.
+type ClientOptions struct {
+	// Actor is recorded as the author of every write. Defaults to
+	// $BEADS_ACTOR, then $USER, then "unknown".
+	Actor string
+
+	// NoAutoCommit leaves writes to an embedded database in the Dolt working
+	// set instead of committing each one, like dolt.auto-commit=batch. Use
+	// Commit to record them.
+	NoAutoCommit bool
+}
+
+// NewClient wraps an open store. The caller keeps ownership of store;
+// Client.Close closes it.
+func NewClient(store Storage, opts ClientOptions) *Client {
+	actor := opts.Actor
+	if actor == "" {
+		actor = os.Getenv("BEADS_ACTOR")
+	}
+	if actor == "" {
+		actor = os.Getenv("USER")
+	}
+	if actor == "" {
+		actor = "unknown"
+	}
+	return &Client{store: store, actor: actor, autoCommit: !opts.NoAutoCommit}
+}
+
+// OpenClient opens the database for the given .beads directory with
+// OpenBestAvailable and returns a Client for it. Close the client when done.
+func OpenClient(ctx context.Context, beadsDir string, opts ClientOptions) (*Client, error) {
+	store, err := OpenBestAvailable(ctx, beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(store, opts), nil
+}
+
+// Storage returns the underlying store.
+func (c *Client) Storage() Storage {
+	return c.store
+}
+
+// Actor returns the name writes are attributed to.
+func (c *Client) Actor() string {
+	return c.actor
+}
+
+// Close closes the underlying store.
+func (c *Client) Close() error {
+	return c.store.Close()
+}
+
+// CreateIssue creates issue and returns it with its ID and timestamps
+// filled in. An empty ID is generated from the database's prefix, an empty
+// status becomes open, and an empty type becomes task. Priority is used as
+// given, so leaving it zero creates a P0 issue.
+func (c *Client) CreateIssue(ctx context.Context, issue *Issue) (*Issue, error) {
+	if issue == nil {
+		return nil, fmt.Errorf("issue must not be nil")
+	}
+	if strings.TrimSpace(issue.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if issue.Status == "" {
+		issue.Status = StatusOpen
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = TypeTask
+	}
+	if issue.CreatedBy == "" {
+		issue.CreatedBy = c.actor
+	}
+	if err := c.store.CreateIssue(ctx, issue, c.actor); err != nil {
+		return nil, err
+	}
+	if err := c.commit(ctx, "create", issue.ID); err != nil {
+		return issue, err
+	}
+	return issue, nil
+}
+
+// GetIssue returns the issue with the given ID.
+func (c *Client) GetIssue(ctx context.Context, id string) (*Issue, error) {
+	return c.store.GetIssue(ctx, id)
+}
+
+// ListIssues returns the issues matching filter. A zero IssueFilter
+// matches every issue.
+func (c *Client) ListIssues(ctx context.Context, filter IssueFilter) ([]*Issue, error) {
+	return c.store.SearchIssues(ctx, "", filter)
+}
+
+// SearchIssues returns the issues whose text matches query and filter.
+func (c *Client) SearchIssues(ctx context.Context, query string, filter IssueFilter) ([]*Issue, error) {
+	return c.store.SearchIssues(ctx, query, filter)
+}
+
+// ReadyWork returns open issues with no open blockers, as bd ready does.
+func (c *Client) ReadyWork(ctx context.Context, filter WorkFilter) ([]*Issue, error) {
+	return c.store.GetReadyWork(ctx, filter)
+}
+
+// BlockedIssues returns issues waiting on open blockers, as bd blocked does.
+func (c *Client) BlockedIssues(ctx context.Context, filter WorkFilter) ([]*BlockedIssue, error) {
+	return c.store.GetBlockedIssues(ctx, filter)
+}
+
+// UpdateIssue applies updates (column name -> value, e.g. "status",
+// "priority", "assignee") to the issue with the given ID.
+func (c *Client) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := c.store.UpdateIssue(ctx, id, updates, c.actor); err != nil {
+		return err
+	}
+	return c.commit(ctx, "update", id)
+}
+
+// CloseIssue closes the issue with the given ID.
+func (c *Client) CloseIssue(ctx context.Context, id, reason string) error {
+	if err := c.store.CloseIssue(ctx, id, reason, c.actor, ""); err != nil {
+		return err
+	}
+	return c.commit(ctx, "close", id)
+}
+
+// AddDependency records that issueID depends on dependsOnID. An empty
+// depType means DepBlocks.
+func (c *Client) AddDependency(ctx context.Context, issueID, dependsOnID string, depType DependencyType) error {
+	if depType == "" {
+		depType = DepBlocks
+	}
+	dep := &Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+	if err := c.store.AddDependency(ctx, dep, c.actor); err != nil {
+		return err
+	}
+	return c.commit(ctx, "dep add", issueID, dependsOnID)
+}
+
+// RemoveDependency removes the dependency of issueID on dependsOnID.
+func (c *Client) RemoveDependency(ctx context.Context, issueID, dependsOnID string) error {
+	if err := c.store.RemoveDependency(ctx, issueID, dependsOnID, c.actor); err != nil {
+		return err
+	}
+	return c.commit(ctx, "dep remove", issueID, dependsOnID)
+}
+
+// AddLabel adds label to the issue with the given ID.
+func (c *Client) AddLabel(ctx context.Context, id, label string) error {
+	if err := c.store.AddLabel(ctx, id, label, c.actor); err != nil {
+		return err
+	}
+	return c.commit(ctx, "label add", id)
+}
+
+// RemoveLabel removes label from the issue with the given ID.
+func (c *Client) RemoveLabel(ctx context.Context, id, label string) error {
+	if err := c.store.RemoveLabel(ctx, id, label, c.actor); err != nil {
+		return err
+	}
+	return c.commit(ctx, "label remove", id)
+}
+
+// AddComment adds a comment by the client's actor to the issue with the
+// given ID.
+func (c *Client) AddComment(ctx context.Context, id, text string) (*Comment, error) {
+	comment, err := c.store.AddIssueComment(ctx, id, c.actor, text)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.commit(ctx, "comment", id); err != nil {
+		return comment, err
+	}
+	return comment, nil
+}
+
+// RunInTransaction runs fn in a single database transaction and, against an
+// embedded database, records everything it wrote as one Dolt commit with
+// the given message. Nothing is written if fn returns an error.
+func (c *Client) RunInTransaction(ctx context.Context, message string, fn func(tx Transaction) error) error {
+	if err := c.store.RunInTransaction(ctx, message, fn); err != nil {
+		return err
+	}
+	if !c.autoCommit || !c.embedded() {
+		return nil
+	}
+	return c.Commit(ctx, message)
+}
+
+// Commit records pending writes as a Dolt commit. It is a no-op when
+// there is nothing to commit.
+func (c *Client) Commit(ctx context.Context, message string) error {
+	vc, ok := c.store.(storage.VersionControl)
+	if !ok {
+		return fmt.Errorf("storage backend does not support commits")
+	}
+	if err := vc.Commit(ctx, message); err != nil && !issueops.IsNothingToCommitError(err) {
+		return err
+	}
+	return nil
+}
+
+// commit records a write the way bd's auto-commit does, using the same
+// message format ("bd: <command> (auto-commit) by <actor> [<ids>]").
+func (c *Client) commit(ctx context.Context, command string, ids ...string) error {
+	if !c.autoCommit || !c.embedded() {
+		return nil
+	}
+	msg := fmt.Sprintf("bd: %s (auto-commit) by %s", command, c.actor)
+	if len(ids) > 0 {
+		msg += " [" + strings.Join(ids, ", ") + "]"
+	}
+	return c.Commit(ctx, msg)
+}
+
+// embedded reports whether the client writes to an embedded database, the
+// only mode in which bd commits after every write.
+func (c *Client) embedded() bool {
+	return isEmbeddedStore(c.store)
+}
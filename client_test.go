@@ -0,0 +1,141 @@
+//go:build cgo
+
+package beads_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads"
+)
+
+func openTestClient(t *testing.T, opts beads.ClientOptions) *beads.Client {
+	t.Helper()
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt tests")
+	}
+	ctx := t.Context()
+	client, err := beads.OpenClient(ctx, filepath.Join(t.TempDir(), ".beads"), opts)
+	if err != nil {
+		t.Fatalf("OpenClient: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Storage().SetConfig(ctx, "issue_prefix", "cl"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix): %v", err)
+	}
+	return client
+}
+
+func lastCommitMessage(t *testing.T, client *beads.Client) string {
+	t.Helper()
+	vc, ok := client.Storage().(beads.VersionControlReader)
+	if !ok {
+		t.Fatal("store does not implement VersionControlReader")
+	}
+	commits, err := vc.Log(t.Context(), 1)
+	if err != nil || len(commits) == 0 {
+		t.Fatalf("Log: %v (%d commits)", err, len(commits))
+	}
+	return commits[0].Message
+}
+
+func TestClient(t *testing.T) {
+	client := openTestClient(t, beads.ClientOptions{Actor: "tester"})
+	ctx := t.Context()
+
+	blocker, err := client.CreateIssue(ctx, &beads.Issue{Title: "Blocker", Priority: 1})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if !strings.HasPrefix(blocker.ID, "cl-") || blocker.Status != beads.StatusOpen || blocker.IssueType != beads.TypeTask {
+		t.Errorf("unexpected defaults: %+v", blocker)
+	}
+	if msg := lastCommitMessage(t, client); msg != "bd: create (auto-commit) by tester ["+blocker.ID+"]" {
+		t.Errorf("commit message after create = %q", msg)
+	}
+
+	task, err := client.CreateIssue(ctx, &beads.Issue{Title: "Task", Priority: 2})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if err := client.AddDependency(ctx, task.ID, blocker.ID, ""); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	if err := client.AddLabel(ctx, task.ID, "backend"); err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+	if _, err := client.AddComment(ctx, task.ID, "waiting on the blocker"); err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+
+	ready, err := client.ReadyWork(ctx, beads.WorkFilter{})
+	if err != nil {
+		t.Fatalf("ReadyWork: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != blocker.ID {
+		t.Errorf("ReadyWork = %v, want only %s", issueIDs(ready), blocker.ID)
+	}
+
+	if err := client.CloseIssue(ctx, blocker.ID, "done"); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+	if msg := lastCommitMessage(t, client); msg != "bd: close (auto-commit) by tester ["+blocker.ID+"]" {
+		t.Errorf("commit message after close = %q", msg)
+	}
+	ready, err = client.ReadyWork(ctx, beads.WorkFilter{})
+	if err != nil {
+		t.Fatalf("ReadyWork: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != task.ID {
+		t.Errorf("ReadyWork after close = %v, want only %s", issueIDs(ready), task.ID)
+	}
+
+	labeled, err := client.ListIssues(ctx, beads.IssueFilter{Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(labeled) != 1 || labeled[0].ID != task.ID {
+		t.Errorf("ListIssues(label=backend) = %v, want only %s", issueIDs(labeled), task.ID)
+	}
+
+	if _, err := client.CreateIssue(ctx, &beads.Issue{Title: "  "}); err == nil {
+		t.Error("CreateIssue with a blank title should fail")
+	}
+}
+
+func TestClient_NoAutoCommit(t *testing.T) {
+	client := openTestClient(t, beads.ClientOptions{Actor: "tester", NoAutoCommit: true})
+	ctx := t.Context()
+	before := lastCommitMessage(t, client)
+
+	issue, err := client.CreateIssue(ctx, &beads.Issue{Title: "Uncommitted", Priority: 2})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if msg := lastCommitMessage(t, client); msg != before {
+		t.Errorf("NoAutoCommit created commit %q", msg)
+	}
+	if err := client.Commit(ctx, "agent: checkpoint"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if msg := lastCommitMessage(t, client); msg != "agent: checkpoint" {
+		t.Errorf("commit message = %q", msg)
+	}
+	if got, err := client.GetIssue(ctx, issue.ID); err != nil || got.Title != "Uncommitted" {
+		t.Errorf("GetIssue = %v, %v", got, err)
+	}
+	// Nothing left to commit is not an error.
+	if err := client.Commit(ctx, "agent: empty"); err != nil {
+		t.Errorf("empty Commit: %v", err)
+	}
+}
+
+func issueIDs(issues []*beads.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
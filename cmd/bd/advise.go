@@ -0,0 +1,351 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/schema"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Thresholds used by adviseWorkspace. They are deliberately conservative:
+// a recommendation should only fire when acting on it is clearly worthwhile.
+const (
+	adviseArchiveAgeDays       = 90
+	adviseArchiveMinIssues     = 500
+	adviseDuplicateRate        = 0.05
+	adviseDuplicateMinIssues   = 5
+	adviseOrchestratorMinDupes = 50
+	adviseLargeExportBytes     = 5 * 1024 * 1024
+	adviseLargeExportInterval  = 10 * time.Minute
+	adviseDenseDependencies    = 3.0
+	adviseStalePushAge         = 7 * 24 * time.Hour
+)
+
+// WorkspaceProfile is the set of measurements `bd advise` bases its
+// recommendations on.
+type WorkspaceProfile struct {
+	Backend           string        `json:"backend"`
+	ServerMode        bool          `json:"server_mode"`
+	Orchestrator      bool          `json:"orchestrator"`
+	TotalIssues       int           `json:"total_issues"`
+	OpenIssues        int           `json:"open_issues"`
+	ClosedIssues      int           `json:"closed_issues"`
+	ArchivableIssues  int           `json:"archivable_issues"` // closed more than adviseArchiveAgeDays ago
+	DuplicateGroups   int           `json:"duplicate_groups"`
+	DuplicateIssues   int           `json:"duplicate_issues"` // redundant copies, excluding each group's keeper
+	DuplicateRate     float64       `json:"duplicate_rate"`
+	Dependencies      int           `json:"dependencies"`
+	DependencyDensity float64       `json:"dependency_density"` // dependencies per issue
+	PendingMigrations int           `json:"pending_migrations"`
+	ExportAuto        bool          `json:"export_auto"`
+	ExportInterval    time.Duration `json:"export_interval"`
+	ExportPath        string        `json:"export_path"`
+	ExportBytes       int64         `json:"export_bytes"`
+	SyncRemote        string        `json:"sync_remote,omitempty"`
+	AutoPush          bool          `json:"auto_push"`
+	LastPush          *time.Time    `json:"last_push,omitempty"`
+}
+
+// Recommendation is one concrete tuning step produced by `bd advise`.
+type Recommendation struct {
+	ID       string `json:"id"`
+	Priority string `json:"priority"` // high, medium, low
+	Finding  string `json:"finding"`
+	Action   string `json:"action"`
+	Command  string `json:"command,omitempty"`
+}
+
+// AdviceReport is the JSON document emitted by `bd advise`.
+type AdviceReport struct {
+	Profile         *WorkspaceProfile `json:"profile"`
+	Recommendations []Recommendation  `json:"recommendations"`
+}
+
+var adviseCmd = &cobra.Command{
+	Use:     "advise",
+	GroupID: "maint",
+	Short:   "Recommend configuration tuning for this workspace",
+	Long: `Inspect the workspace and suggest concrete configuration changes.
+
+Where 'bd doctor' reports problems, 'bd advise' turns measurements into a
+tuning plan. It looks at:
+  - Workspace size and backend (embedded or server)
+  - Duplicate rate among open issues
+  - Dependency density and pending schema migrations (indexes)
+  - Auto-export file size and cadence
+  - Dolt sync cadence (auto-push, last push)
+  - Closed issues old enough to archive
+
+Each recommendation names the finding, the action to take, and the command
+or config key that applies it. Nothing is changed.
+
+Examples:
+  bd advise          # Human-readable tuning plan
+  bd advise --json   # Machine-readable profile and recommendations`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		profile, err := collectWorkspaceProfile(time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		recs := adviseWorkspace(profile, time.Now())
+
+		if jsonOutput {
+			outputJSON(AdviceReport{Profile: profile, Recommendations: recs})
+			return
+		}
+		displayAdvice(profile, recs)
+	},
+}
+
+// collectWorkspaceProfile measures the current workspace. Measurements that
+// cannot be taken (no export file, no raw DB access) are left at zero.
+func collectWorkspaceProfile(now time.Time) (*WorkspaceProfile, error) {
+	ctx := rootCtx
+	p := &WorkspaceProfile{ServerMode: serverMode}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statistics: %w", err)
+	}
+	p.TotalIssues = stats.TotalIssues
+	p.OpenIssues = stats.TotalIssues - stats.ClosedIssues
+	p.ClosedIssues = stats.ClosedIssues
+
+	cutoff := now.AddDate(0, 0, -adviseArchiveAgeDays)
+	closedStatus := types.StatusClosed
+	archivable, err := store.CountIssues(ctx, "", types.IssueFilter{Status: &closedStatus, ClosedBefore: &cutoff})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count archivable issues: %w", err)
+	}
+	p.ArchivableIssues = int(archivable)
+
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	openIssues := make([]*types.Issue, 0, len(allIssues))
+	for _, issue := range allIssues {
+		if issue.Status != types.StatusClosed {
+			openIssues = append(openIssues, issue)
+		}
+	}
+	groups := findDuplicateGroups(openIssues)
+	p.DuplicateGroups = len(groups)
+	for _, group := range groups {
+		p.DuplicateIssues += len(group) - 1
+	}
+	p.DuplicateRate = ratio(p.DuplicateIssues, len(openIssues))
+
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependencies: %w", err)
+	}
+	for _, list := range deps {
+		p.Dependencies += len(list)
+	}
+	if p.TotalIssues > 0 {
+		p.DependencyDensity = float64(p.Dependencies) / float64(p.TotalIssues)
+	}
+
+	if accessor, ok := storage.UnwrapStore(store).(storage.RawDBAccessor); ok {
+		if db := accessor.UnderlyingDB(); db != nil {
+			if pending, err := schema.PendingVersions(ctx, db); err == nil {
+				p.PendingMigrations = len(pending)
+			}
+		}
+	}
+
+	p.ExportAuto = config.GetBool("export.auto")
+	p.ExportInterval = config.GetDuration("export.interval")
+	if p.ExportInterval == 0 {
+		p.ExportInterval = 60 * time.Second
+	}
+	p.ExportPath = config.GetString("export.path")
+	if p.ExportPath == "" {
+		p.ExportPath = "issues.jsonl"
+	}
+	p.AutoPush = config.GetBool("dolt.auto-push")
+
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+			p.Backend = cfg.GetDoltMode()
+		}
+		if info, err := os.Stat(filepath.Join(beadsDir, p.ExportPath)); err == nil {
+			p.ExportBytes = info.Size()
+		}
+		p.SyncRemote = resolveSyncRemoteFromDir(beadsDir)
+		p.Orchestrator = isOrchestratorRoot(filepath.Dir(beadsDir))
+	}
+	if p.Backend == "" {
+		p.Backend = configfile.DefaultConfig().GetDoltMode()
+	}
+
+	if ps, err := loadPushState(); err == nil && ps != nil && ps.LastPush != "" {
+		if t, err := time.Parse(time.RFC3339, ps.LastPush); err == nil {
+			p.LastPush = &t
+		}
+	}
+	return p, nil
+}
+
+// adviseWorkspace turns a workspace profile into recommendations, most
+// urgent first. It is pure so the rules can be tested without a store.
+func adviseWorkspace(p *WorkspaceProfile, now time.Time) []Recommendation {
+	var recs []Recommendation
+
+	if p.PendingMigrations > 0 {
+		priority := "medium"
+		if p.DependencyDensity >= adviseDenseDependencies {
+			priority = "high"
+		}
+		recs = append(recs, Recommendation{
+			ID:       "apply-migrations",
+			Priority: priority,
+			Finding: fmt.Sprintf("%d schema migration(s) pending; dependency density is %.1f per issue",
+				p.PendingMigrations, p.DependencyDensity),
+			Action:  "Apply pending migrations to pick up the latest indexes",
+			Command: "bd migrate schema",
+		})
+	}
+
+	switch {
+	case p.Orchestrator && p.DuplicateIssues >= adviseOrchestratorMinDupes:
+		recs = append(recs, Recommendation{
+			ID:       "orchestrator-thresholds",
+			Priority: "medium",
+			Finding: fmt.Sprintf("orchestrator workspace with %d duplicate open issues (wisps repeat by design)",
+				p.DuplicateIssues),
+			Action:  "Run doctor in orchestrator mode with a duplicate threshold above the current count",
+			Command: fmt.Sprintf("bd doctor --orchestrator --orchestrator-duplicates-threshold %d", roundUpThreshold(p.DuplicateIssues)),
+		})
+	case !p.Orchestrator && p.DuplicateIssues >= adviseDuplicateMinIssues && p.DuplicateRate >= adviseDuplicateRate:
+		recs = append(recs, Recommendation{
+			ID:       "merge-duplicates",
+			Priority: "medium",
+			Finding: fmt.Sprintf("%.0f%% of open issues are duplicates (%d in %d groups)",
+				p.DuplicateRate*100, p.DuplicateIssues, p.DuplicateGroups),
+			Action:  "Review the duplicate groups and merge them",
+			Command: "bd duplicates --dry-run",
+		})
+	}
+
+	if p.ExportAuto {
+		switch {
+		case p.ServerMode:
+			recs = append(recs, Recommendation{
+				ID:       "disable-auto-export",
+				Priority: "low",
+				Finding:  "export.auto is enabled but auto-export never runs in server mode",
+				Action:   "Disable export.auto and run 'bd export' explicitly when a JSONL snapshot is needed",
+				Command:  "bd config set export.auto false",
+			})
+		case p.ExportBytes >= adviseLargeExportBytes && p.ExportInterval < adviseLargeExportInterval:
+			recs = append(recs, Recommendation{
+				ID:       "throttle-auto-export",
+				Priority: "medium",
+				Finding: fmt.Sprintf("%s is %s and rewritten every %s",
+					p.ExportPath, formatBytes(p.ExportBytes), p.ExportInterval),
+				Action:  "Export less often; unchanged commits are already skipped, so only the rewrite cadence matters",
+				Command: fmt.Sprintf("bd config set export.interval %s", adviseLargeExportInterval),
+			})
+		}
+	}
+
+	if p.SyncRemote != "" && !p.AutoPush && (p.LastPush == nil || now.Sub(*p.LastPush) > adviseStalePushAge) {
+		finding := fmt.Sprintf("sync remote %q has never been pushed from this clone", p.SyncRemote)
+		if p.LastPush != nil {
+			finding = fmt.Sprintf("last push to %q was %s ago", p.SyncRemote, formatDurationShort(now.Sub(*p.LastPush)))
+		}
+		recs = append(recs, Recommendation{
+			ID:       "sync-cadence",
+			Priority: "low",
+			Finding:  finding,
+			Action:   "Push regularly; enable dolt.auto-push only if this is the sole writer",
+			Command:  "bd dolt push",
+		})
+	}
+
+	if p.ArchivableIssues >= adviseArchiveMinIssues {
+		recs = append(recs, Recommendation{
+			ID:       "archive-closed",
+			Priority: "low",
+			Finding: fmt.Sprintf("%d issues were closed more than %d days ago",
+				p.ArchivableIssues, adviseArchiveAgeDays),
+			Action:  "Decay old closed issues and compact history",
+			Command: fmt.Sprintf("bd gc --older-than %d --dry-run", adviseArchiveAgeDays),
+		})
+	}
+
+	return recs
+}
+
+// roundUpThreshold returns a round number comfortably above n so a threshold
+// set today still holds as the workspace grows.
+func roundUpThreshold(n int) int {
+	step := 100
+	if n >= 1000 {
+		step = 1000
+	}
+	return (n*3/2/step + 1) * step
+}
+
+// formatDurationShort renders d in whole days, or hours when under a day.
+func formatDurationShort(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return d.Round(time.Hour).String()
+}
+
+func displayAdvice(p *WorkspaceProfile, recs []Recommendation) {
+	fmt.Printf("\n%s Workspace profile\n\n", ui.RenderAccent("🔎"))
+	backend := p.Backend
+	if p.ServerMode {
+		backend += " (server)"
+	}
+	fmt.Printf("  Backend:            %s\n", backend)
+	fmt.Printf("  Issues:             %d (%d open, %d closed, %d archivable)\n",
+		p.TotalIssues, p.OpenIssues, p.ClosedIssues, p.ArchivableIssues)
+	fmt.Printf("  Duplicates:         %d in %d groups (%.1f%% of open)\n",
+		p.DuplicateIssues, p.DuplicateGroups, p.DuplicateRate*100)
+	fmt.Printf("  Dependencies:       %d (%.1f per issue)\n", p.Dependencies, p.DependencyDensity)
+	if p.ExportAuto {
+		fmt.Printf("  Auto-export:        %s every %s (%s)\n", p.ExportPath, p.ExportInterval, formatBytes(p.ExportBytes))
+	} else {
+		fmt.Printf("  Auto-export:        off\n")
+	}
+	if p.SyncRemote != "" {
+		push := "never"
+		if p.LastPush != nil {
+			push = p.LastPush.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Printf("  Sync remote:        %s (auto-push %v, last push %s)\n", p.SyncRemote, p.AutoPush, push)
+	}
+
+	if len(recs) == 0 {
+		fmt.Printf("\n%s No tuning needed\n\n", ui.RenderPass("✓"))
+		return
+	}
+	fmt.Printf("\n%s Recommendations\n\n", ui.RenderAccent("💡"))
+	for i, r := range recs {
+		fmt.Printf("  %d. [%s] %s\n", i+1, r.Priority, r.Finding)
+		fmt.Printf("     %s\n", r.Action)
+		if r.Command != "" {
+			fmt.Printf("     $ %s\n", r.Command)
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(adviseCmd)
+}
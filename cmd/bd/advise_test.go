@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdviseWorkspace(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+
+	ids := func(recs []Recommendation) []string {
+		out := make([]string, 0, len(recs))
+		for _, r := range recs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		profile WorkspaceProfile
+		want    []string
+	}{
+		{
+			name:    "healthy workspace",
+			profile: WorkspaceProfile{TotalIssues: 100, OpenIssues: 40, ExportInterval: time.Minute},
+			want:    []string{},
+		},
+		{
+			name:    "pending migrations",
+			profile: WorkspaceProfile{PendingMigrations: 2, DependencyDensity: 4},
+			want:    []string{"apply-migrations"},
+		},
+		{
+			name:    "duplicates in a project repo",
+			profile: WorkspaceProfile{OpenIssues: 100, DuplicateIssues: 10, DuplicateGroups: 4, DuplicateRate: 0.1},
+			want:    []string{"merge-duplicates"},
+		},
+		{
+			name:    "few duplicates ignored",
+			profile: WorkspaceProfile{OpenIssues: 20, DuplicateIssues: 2, DuplicateGroups: 1, DuplicateRate: 0.1},
+			want:    []string{},
+		},
+		{
+			name:    "duplicates in an orchestrator root",
+			profile: WorkspaceProfile{Orchestrator: true, DuplicateIssues: 400, DuplicateRate: 0.5},
+			want:    []string{"orchestrator-thresholds"},
+		},
+		{
+			name:    "large frequent export",
+			profile: WorkspaceProfile{ExportAuto: true, ExportPath: "issues.jsonl", ExportBytes: 8 << 20, ExportInterval: time.Minute},
+			want:    []string{"throttle-auto-export"},
+		},
+		{
+			name:    "auto-export in server mode",
+			profile: WorkspaceProfile{ExportAuto: true, ServerMode: true, ExportBytes: 8 << 20, ExportInterval: time.Minute},
+			want:    []string{"disable-auto-export"},
+		},
+		{
+			name:    "stale push",
+			profile: WorkspaceProfile{SyncRemote: "origin", LastPush: &old},
+			want:    []string{"sync-cadence"},
+		},
+		{
+			name:    "recent push",
+			profile: WorkspaceProfile{SyncRemote: "origin", LastPush: &recent},
+			want:    []string{},
+		},
+		{
+			name:    "auto-push enabled",
+			profile: WorkspaceProfile{SyncRemote: "origin", AutoPush: true},
+			want:    []string{},
+		},
+		{
+			name:    "old closed issues",
+			profile: WorkspaceProfile{TotalIssues: 2000, ClosedIssues: 1500, ArchivableIssues: 1200},
+			want:    []string{"archive-closed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(adviseWorkspace(&tt.profile, now))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAdviseMigrationPriority(t *testing.T) {
+	sparse := adviseWorkspace(&WorkspaceProfile{PendingMigrations: 1, DependencyDensity: 0.5}, time.Now())
+	dense := adviseWorkspace(&WorkspaceProfile{PendingMigrations: 1, DependencyDensity: 5}, time.Now())
+	if sparse[0].Priority != "medium" || dense[0].Priority != "high" {
+		t.Errorf("priorities = %q/%q, want medium/high", sparse[0].Priority, dense[0].Priority)
+	}
+}
+
+func TestRoundUpThreshold(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{50, 100},
+		{400, 700},
+		{999, 1500},
+		{4000, 7000},
+	}
+	for _, tt := range tests {
+		if got := roundUpThreshold(tt.n); got != tt.want || got <= tt.n {
+			t.Errorf("roundUpThreshold(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var ageCmd = &cobra.Command{
+	Use:     "age",
+	GroupID: "maint",
+	Short:   "Raise the priority of issues left open too long",
+	Long: `Apply the priority aging policy: raise the priority of open issues that have
+waited at the same priority longer than their type's threshold, so old P3s
+eventually surface in 'bd ready'.
+
+Aging is opt-in and off until a threshold is configured:
+
+  aging.after-days.<type>   Days at one priority before an issue of this
+                            type is raised (0 = never age this type)
+  aging.after-days.default  Threshold for types not listed
+  aging.highest-priority    Aging never raises an issue above this
+                            priority (default: 1)
+  aging.exempt-labels       Issues with any of these labels are never aged
+
+Each pass raises an issue by at most one level. The clock restarts whenever
+the priority changes, by hand or by aging, so a P4 bug with a 14-day
+threshold becomes P3 after 14 days and P2 after 28. Closed, pinned,
+deferred, and template issues are left alone.
+
+Every bump is recorded as a priority_aged event naming the threshold, and
+shows in 'bd history' as a priority change. Aging does not touch updated_at
+and does not count as activity for 'bd stale sweep'.
+
+Run it once, on a schedule from cron or CI, or keep it running with --every
+alongside a Dolt server.
+
+Examples:
+  bd config set aging.after-days.default 30
+  bd config set aging.after-days.bug 14
+  bd age --dry-run     # Show what would be raised
+  bd age               # Apply the policy now
+  bd age --every 6h    # Age every six hours until interrupted`,
+	Args: cobra.NoArgs,
+	Run:  runAge,
+}
+
+// AgedIssue is one issue raised by the aging policy.
+type AgedIssue struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	IssueType  string `json:"issue_type"`
+	From       int    `json:"from"`
+	To         int    `json:"to"`
+	WaitedDays int    `json:"waited_days"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AgingResult is the JSON output of one aging pass.
+type AgingResult struct {
+	Aged    []*AgedIssue `json:"aged"`
+	Failed  []*AgedIssue `json:"failed,omitempty"`
+	Checked int          `json:"checked"`
+	DryRun  bool         `json:"dry_run,omitempty"`
+	AgedAt  time.Time    `json:"aged_at"`
+	Policy  struct {
+		AfterDays       map[string]int `json:"after_days"`
+		HighestPriority int            `json:"highest_priority"`
+		ExemptLabels    []string       `json:"exempt_labels,omitempty"`
+	} `json:"policy"`
+}
+
+func runAge(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	every, _ := cmd.Flags().GetDuration("every")
+
+	if !dryRun {
+		CheckReadonly("age")
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+	if every < 0 {
+		FatalErrorRespectJSON("--every must be positive")
+	}
+	policy := config.GetPriorityAgingPolicy()
+	if !policy.Enabled() {
+		FatalErrorRespectJSON("priority aging is disabled (set aging.after-days.<type> or aging.after-days.default)")
+	}
+	ager, ok := storage.UnwrapStore(store).(storage.PriorityAger)
+	if !ok {
+		FatalErrorRespectJSON("priority aging is not supported by this storage backend")
+	}
+
+	ctx := rootCtx
+	for {
+		result, err := ageIssuePriorities(ctx, store, ager, policy, actor, dryRun, time.Now())
+		if err != nil {
+			if every == 0 {
+				FatalErrorRespectJSON("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: priority aging failed: %v\n", err)
+		} else {
+			if !dryRun && len(result.Aged) > 0 {
+				ids := make([]string, len(result.Aged))
+				for i, it := range result.Aged {
+					ids[i] = it.ID
+				}
+				if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+					Command:  "age",
+					IssueIDs: ids,
+				}); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to commit priority aging: %v\n", err)
+				}
+			}
+			printAgingResult(result)
+		}
+
+		if every == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(every):
+		}
+	}
+}
+
+// ageIssuePriorities applies policy to every open issue below the highest
+// agable priority. Issues created within their threshold are skipped
+// without loading their events.
+func ageIssuePriorities(ctx context.Context, s storage.DoltStorage, ager storage.PriorityAger, policy config.PriorityAgingPolicy, actorName string, dryRun bool, now time.Time) (*AgingResult, error) {
+	result := &AgingResult{Aged: []*AgedIssue{}, DryRun: dryRun, AgedAt: now}
+	result.Policy.AfterDays = policy.AfterDays
+	result.Policy.HighestPriority = policy.HighestPriority
+	result.Policy.ExemptLabels = policy.ExemptLabels
+
+	minPriority := policy.HighestPriority + 1
+	notEphemeral, notTemplate := false, false
+	candidates, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned, types.StatusDeferred},
+		ExcludeLabels: policy.ExemptLabels,
+		PriorityMin:   &minPriority,
+		Ephemeral:     &notEphemeral,
+		IsTemplate:    &notTemplate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing open issues: %w", err)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+
+	for _, issue := range candidates {
+		threshold := agingThreshold(policy, issue)
+		if threshold == 0 || now.Sub(issue.CreatedAt) < threshold {
+			continue
+		}
+		result.Checked++
+		events, err := s.GetEvents(ctx, issue.ID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("loading events for %s: %w", issue.ID, err)
+		}
+		to, since, ok := decidePriorityAging(policy, issue, events, now)
+		if !ok {
+			continue
+		}
+		aged := &AgedIssue{
+			ID:         issue.ID,
+			Title:      issue.Title,
+			IssueType:  string(issue.IssueType),
+			From:       issue.Priority,
+			To:         to,
+			WaitedDays: int(now.Sub(since).Hours() / 24),
+		}
+		if !dryRun {
+			reason := fmt.Sprintf("aged: P%d for %d days (%s threshold %d days)",
+				issue.Priority, aged.WaitedDays, issue.IssueType, int(threshold.Hours()/24))
+			if err := ager.AgeIssuePriority(ctx, issue.ID, issue.Priority, to, reason, actorName); err != nil {
+				aged.Error = err.Error()
+				result.Failed = append(result.Failed, aged)
+				continue
+			}
+		}
+		result.Aged = append(result.Aged, aged)
+	}
+	return result, nil
+}
+
+// decidePriorityAging returns the priority issue ages to at now and the
+// time its current priority was set: the latest of its creation, priority
+// edits, and earlier aging. ok is false when the issue does not age.
+func decidePriorityAging(policy config.PriorityAgingPolicy, issue *types.Issue, events []*types.Event, now time.Time) (to int, since time.Time, ok bool) {
+	since = issue.CreatedAt
+	for _, e := range events {
+		if eventSetsPriority(e) && e.CreatedAt.After(since) {
+			since = e.CreatedAt
+		}
+	}
+	threshold := agingThreshold(policy, issue)
+	if threshold == 0 || issue.Priority <= policy.HighestPriority || now.Sub(since) < threshold {
+		return 0, since, false
+	}
+	return issue.Priority - 1, since, true
+}
+
+// agingThreshold is how long issue may sit at one priority, or 0 if it
+// never ages.
+func agingThreshold(policy config.PriorityAgingPolicy, issue *types.Issue) time.Duration {
+	if issue.Status == types.StatusClosed || issue.Status == types.StatusPinned || issue.Status == types.StatusDeferred {
+		return 0
+	}
+	if issue.IsTemplate || slices.Contains(issue.Labels, BeadsTemplateLabel) ||
+		slices.ContainsFunc(issue.Labels, func(l string) bool { return slices.Contains(policy.ExemptLabels, l) }) {
+		return 0
+	}
+	return time.Duration(policy.Threshold(string(issue.IssueType))) * 24 * time.Hour
+}
+
+// eventSetsPriority reports whether e changed the issue's priority: an
+// aging event, or an update whose field map includes priority.
+func eventSetsPriority(e *types.Event) bool {
+	switch e.EventType {
+	case types.EventPriorityAged:
+		return true
+	case types.EventUpdated, types.EventStatusChanged:
+		if e.NewValue == nil {
+			return false
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal([]byte(*e.NewValue), &fields) != nil {
+			return false
+		}
+		_, ok := fields["priority"]
+		return ok
+	}
+	return false
+}
+
+func printAgingResult(result *AgingResult) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	if isQuiet() && len(result.Failed) == 0 {
+		return
+	}
+	stamp := result.AgedAt.Format("15:04:05")
+	if len(result.Aged)+len(result.Failed) == 0 {
+		fmt.Printf("[%s] No issues to age (%d checked)\n", stamp, result.Checked)
+		return
+	}
+	verb := "Raised"
+	if result.DryRun {
+		verb = "Would raise"
+	}
+	if len(result.Aged) > 0 {
+		fmt.Printf("[%s] %s %d issue(s):\n", stamp, verb, len(result.Aged))
+		for _, it := range result.Aged {
+			fmt.Printf("  %s %s P%d → P%d (waited %d days)\n",
+				ui.RenderWarn("↑"), formatFeedbackID(it.ID, it.Title), it.From, it.To, it.WaitedDays)
+		}
+	}
+	for _, it := range result.Failed {
+		fmt.Printf("  %s %s: %s\n", ui.RenderFail("✗"), it.ID, it.Error)
+	}
+}
+
+func init() {
+	ageCmd.Flags().Bool("dry-run", false, "Show what would be raised without changing anything")
+	ageCmd.Flags().Duration("every", 0, "Keep running and age at this interval (e.g. 6h)")
+
+	rootCmd.AddCommand(ageCmd)
+}
@@ -0,0 +1,93 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedAge(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "ag")
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+	age := func(args ...string) AgingResult {
+		t.Helper()
+		out := run(append([]string{"age", "--json"}, args...)...)
+		var res AgingResult
+		if err := json.Unmarshal([]byte(out), &res); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		return res
+	}
+
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "age"); err == nil {
+		t.Fatalf("age with no policy should fail\n%s", out)
+	}
+
+	// Import issues with backdated creation times; bd create stamps now.
+	old := time.Now().UTC().AddDate(0, 0, -20)
+	jsonlPath := filepath.Join(t.TempDir(), "old.jsonl")
+	writeJSONLFile(t, jsonlPath, []types.Issue{
+		{ID: "ag-bug", Title: "Old bug", Status: types.StatusOpen, IssueType: types.TypeBug, Priority: 3, CreatedAt: old, UpdatedAt: old},
+		{ID: "ag-task", Title: "Old task", Status: types.StatusOpen, IssueType: types.TypeTask, Priority: 3, CreatedAt: old, UpdatedAt: old},
+	})
+	bdImport(t, bd, dir, jsonlPath)
+	run("config", "set", "aging.after-days.bug", "14")
+	run("config", "set", "aging.after-days.default", "30")
+
+	dry := age("--dry-run")
+	if !dry.DryRun || len(dry.Aged) != 1 || dry.Aged[0].ID != "ag-bug" || dry.Aged[0].To != 2 {
+		t.Fatalf("dry run aged = %+v, want ag-bug P3 → P2", dry.Aged)
+	}
+	if got := bdShow(t, bd, dir, "ag-bug"); got.Priority != 3 {
+		t.Errorf("dry run changed priority to P%d", got.Priority)
+	}
+
+	res := age()
+	if len(res.Aged) != 1 || res.Aged[0].ID != "ag-bug" {
+		t.Fatalf("aged = %+v, want ag-bug", res.Aged)
+	}
+	if got := bdShow(t, bd, dir, "ag-bug"); got.Priority != 2 {
+		t.Errorf("ag-bug priority = P%d, want P2", got.Priority)
+	}
+	// The aging event restarts the clock, so a second pass is a no-op.
+	if again := age(); len(again.Aged) != 0 {
+		t.Errorf("second pass aged %+v, want none", again.Aged)
+	}
+
+	db, cleanup, err := embeddeddolt.OpenSQL(t.Context(), filepath.Join(beadsDir, "embeddeddolt"), "ag", "main")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	defer cleanup()
+	var oldValue, newValue, comment string
+	if err := db.QueryRowContext(t.Context(),
+		"SELECT old_value, new_value, comment FROM events AS OF 'HEAD' WHERE issue_id = ? AND event_type = ?",
+		"ag-bug", types.EventPriorityAged).Scan(&oldValue, &newValue, &comment); err != nil {
+		t.Fatalf("query priority_aged event: %v", err)
+	}
+	if oldValue != "3" || newValue != "2" || !strings.Contains(comment, "bug threshold 14 days") {
+		t.Errorf("event = %s → %s (%q)", oldValue, newValue, comment)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDecidePriorityAging(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	strPtr := func(s string) *string { return &s }
+	policy := config.PriorityAgingPolicy{
+		AfterDays:       map[string]int{"bug": 14, "default": 30, "epic": 0},
+		HighestPriority: 1,
+		ExemptLabels:    []string{"icebox"},
+	}
+	issue := func(issueType types.IssueType, priority, createdDaysAgo int) *types.Issue {
+		return &types.Issue{Status: types.StatusOpen, IssueType: issueType, Priority: priority, CreatedAt: daysAgo(createdDaysAgo)}
+	}
+
+	tests := []struct {
+		name   string
+		issue  *types.Issue
+		events []*types.Event
+		wantOK bool
+		wantTo int
+	}{
+		{name: "bug past its threshold", issue: issue(types.TypeBug, 3, 15), wantOK: true, wantTo: 2},
+		{name: "task within default threshold", issue: issue(types.TypeTask, 3, 15)},
+		{name: "task past default threshold", issue: issue(types.TypeTask, 4, 31), wantOK: true, wantTo: 3},
+		{name: "epic opted out", issue: issue(types.TypeEpic, 3, 90)},
+		{name: "already at highest priority", issue: issue(types.TypeBug, 1, 90)},
+		{
+			name:   "earlier aging restarts the clock",
+			issue:  issue(types.TypeBug, 2, 20),
+			events: []*types.Event{{EventType: types.EventPriorityAged, CreatedAt: daysAgo(6)}},
+		},
+		{
+			name:   "manual priority edit restarts the clock",
+			issue:  issue(types.TypeBug, 3, 60),
+			events: []*types.Event{{EventType: types.EventUpdated, NewValue: strPtr(`{"priority":3}`), CreatedAt: daysAgo(10)}},
+		},
+		{
+			name:   "other edits do not",
+			issue:  issue(types.TypeBug, 3, 60),
+			events: []*types.Event{{EventType: types.EventUpdated, NewValue: strPtr(`{"title":"x"}`), CreatedAt: daysAgo(1)}},
+			wantOK: true, wantTo: 2,
+		},
+		{
+			name:  "exempt label",
+			issue: &types.Issue{Status: types.StatusOpen, IssueType: types.TypeBug, Priority: 3, CreatedAt: daysAgo(60), Labels: []string{"icebox"}},
+		},
+		{
+			name:  "deferred",
+			issue: &types.Issue{Status: types.StatusDeferred, IssueType: types.TypeBug, Priority: 3, CreatedAt: daysAgo(60)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to, _, ok := decidePriorityAging(policy, tt.issue, tt.events, now)
+			if ok != tt.wantOK || (ok && to != tt.wantTo) {
+				t.Errorf("decidePriorityAging() = P%d, %v; want P%d, %v", to, ok, tt.wantTo, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// AgentScore is one agent's scorecard over a scoring window.
+// Rates are fractions in [0,1]; nil means there was nothing to measure.
+type AgentScore struct {
+	Agent          string   `json:"agent"`
+	Closed         int      `json:"closed"`
+	Handled        int      `json:"handled"`
+	Reopened       int      `json:"reopened"`
+	ReopenRate     *float64 `json:"reopen_rate,omitempty"`
+	Rejected       int      `json:"rejected"`
+	RejectionRate  *float64 `json:"rejection_rate,omitempty"`
+	CostedBeads    int      `json:"costed_beads"`
+	AvgCost        *float64 `json:"avg_cost,omitempty"`
+	SLATracked     int      `json:"sla_tracked"`
+	SLAMet         int      `json:"sla_met"`
+	SLAAdherence   *float64 `json:"sla_adherence,omitempty"`
+	ThroughputWeek float64  `json:"throughput_per_week"`
+}
+
+// AgentScoreReport is the JSON document emitted by `bd agents score`.
+type AgentScoreReport struct {
+	Since         time.Time     `json:"since"`
+	Until         time.Time     `json:"until"`
+	RejectedLabel string        `json:"rejected_label"`
+	CostKey       string        `json:"cost_key"`
+	Agents        []*AgentScore `json:"agents"`
+}
+
+var agentsCmd = &cobra.Command{
+	Use:     "agents",
+	GroupID: "views",
+	Short:   "Agent fleet reports",
+}
+
+var agentsScoreCmd = &cobra.Command{
+	Use:   "score",
+	Short: "Per-agent performance scorecards",
+	Long: `Score each assignee over a time window for fleet-management tooling.
+
+Metrics per agent:
+  closed               Issues the agent closed in the window (throughput)
+  reopen_rate          Share of handled issues reopened in the window
+  rejection_rate       Share of handled issues carrying the review-rejected label
+  avg_cost             Mean of the numeric metadata cost key over closed issues
+  sla_adherence        Share of closed issues with a due date closed on time
+
+An issue is "handled" by an agent when it is assigned to them and was closed
+or reopened inside the window.
+
+Examples:
+  bd agents score                       # last 30 days, human-readable
+  bd agents score --window 7d --json    # scorecards for fleet tooling
+  bd agents score --agent claude-1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		window, _ := cmd.Flags().GetString("window")
+		agent, _ := cmd.Flags().GetString("agent")
+		rejectedLabel, _ := cmd.Flags().GetString("rejected-label")
+		costKey, _ := cmd.Flags().GetString("cost-key")
+
+		now := time.Now()
+		since, err := parseWindowFlag(window, now)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --window: %v", err)
+		}
+
+		ctx := rootCtx
+		closed, err := store.SearchIssues(ctx, "", types.IssueFilter{ClosedAfter: &since})
+		if err != nil {
+			FatalErrorRespectJSON("failed to load closed issues: %v", err)
+		}
+		events, err := store.GetAllEventsSince(ctx, since)
+		if err != nil {
+			FatalErrorRespectJSON("failed to load events: %v", err)
+		}
+
+		// Reopened issues may no longer be closed; load any we don't have yet.
+		byID := make(map[string]*types.Issue, len(closed))
+		for _, issue := range closed {
+			byID[issue.ID] = issue
+		}
+		var missing []string
+		for _, e := range events {
+			if e.EventType == types.EventReopened && byID[e.IssueID] == nil {
+				missing = append(missing, e.IssueID)
+				byID[e.IssueID] = &types.Issue{} // placeholder to dedupe
+			}
+		}
+		issues := closed
+		if len(missing) > 0 {
+			reopened, err := store.GetIssuesByIDs(ctx, missing)
+			if err != nil {
+				FatalErrorRespectJSON("failed to load reopened issues: %v", err)
+			}
+			issues = append(issues, reopened...)
+		}
+
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("failed to load labels: %v", err)
+		}
+		for _, issue := range issues {
+			issue.Labels = labels[issue.ID]
+		}
+
+		scores := computeAgentScores(issues, events, since, now, rejectedLabel, costKey)
+		if agent != "" {
+			filtered := scores[:0]
+			for _, s := range scores {
+				if s.Agent == agent {
+					filtered = append(filtered, s)
+				}
+			}
+			scores = filtered
+		}
+
+		if jsonOutput {
+			outputJSON(AgentScoreReport{
+				Since:         since,
+				Until:         now,
+				RejectedLabel: rejectedLabel,
+				CostKey:       costKey,
+				Agents:        scores,
+			})
+			return
+		}
+		displayAgentScores(scores, since)
+	},
+}
+
+// parseWindowFlag converts a lookback window such as "30d" or "2w" into the
+// window's start time. Absolute dates and natural language are also accepted.
+func parseWindowFlag(window string, now time.Time) (time.Time, error) {
+	window = strings.TrimSpace(window)
+	if window == "" {
+		return time.Time{}, fmt.Errorf("window cannot be empty")
+	}
+	if !strings.HasPrefix(window, "-") && !strings.HasPrefix(window, "+") {
+		if t, err := timeparsing.ParseCompactDuration("-"+window, now); err == nil {
+			return t, nil
+		}
+	}
+	t, err := timeparsing.ParseRelativeTime(window, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.After(now) {
+		return time.Time{}, fmt.Errorf("window start %s is in the future", t.Format(time.RFC3339))
+	}
+	return t, nil
+}
+
+// computeAgentScores builds per-assignee scorecards from issues closed or
+// reopened in [since, now] and the events recorded in that window. Issues
+// without an assignee are ignored. Results are sorted by closed count, then
+// agent name.
+func computeAgentScores(issues []*types.Issue, events []*types.Event, since, now time.Time, rejectedLabel, costKey string) []*AgentScore {
+	reopens := make(map[string]int)
+	for _, e := range events {
+		if e.EventType == types.EventReopened && !e.CreatedAt.Before(since) {
+			reopens[e.IssueID]++
+		}
+	}
+
+	weeks := now.Sub(since).Hours() / (24 * 7)
+	byAgent := make(map[string]*AgentScore)
+	costTotals := make(map[string]float64)
+	for _, issue := range issues {
+		if issue.Assignee == "" {
+			continue
+		}
+		closedInWindow := issue.ClosedAt != nil && !issue.ClosedAt.Before(since)
+		if !closedInWindow && reopens[issue.ID] == 0 {
+			continue
+		}
+		s := byAgent[issue.Assignee]
+		if s == nil {
+			s = &AgentScore{Agent: issue.Assignee}
+			byAgent[issue.Assignee] = s
+		}
+		s.Handled++
+		if reopens[issue.ID] > 0 {
+			s.Reopened++
+		}
+		if rejectedLabel != "" && slices.Contains(issue.Labels, rejectedLabel) {
+			s.Rejected++
+		}
+		if !closedInWindow || issue.Status != types.StatusClosed {
+			continue
+		}
+		s.Closed++
+		if cost, ok := metadataNumber(issue.Metadata, costKey); ok {
+			s.CostedBeads++
+			costTotals[issue.Assignee] += cost
+		}
+		if issue.DueAt != nil {
+			s.SLATracked++
+			if !issue.ClosedAt.After(*issue.DueAt) {
+				s.SLAMet++
+			}
+		}
+	}
+
+	scores := make([]*AgentScore, 0, len(byAgent))
+	for agent, s := range byAgent {
+		s.ReopenRate = sampleRatio(s.Reopened, s.Handled)
+		s.RejectionRate = sampleRatio(s.Rejected, s.Handled)
+		if s.CostedBeads > 0 {
+			avg := costTotals[agent] / float64(s.CostedBeads)
+			s.AvgCost = &avg
+		}
+		s.SLAAdherence = sampleRatio(s.SLAMet, s.SLATracked)
+		if weeks > 0 {
+			s.ThroughputWeek = float64(s.Closed) / weeks
+		}
+		scores = append(scores, s)
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Closed != scores[j].Closed {
+			return scores[i].Closed > scores[j].Closed
+		}
+		return scores[i].Agent < scores[j].Agent
+	})
+	return scores
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// sampleRatio returns n/d, or nil when there are no samples (d == 0).
+func sampleRatio(n, d int) *float64 {
+	if d == 0 {
+		return nil
+	}
+	r := float64(n) / float64(d)
+	return &r
+}
+
+// formatPercent renders a rate for the scorecard table, "-" when nil.
+func formatPercent(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", *rate*100)
+}
+
+// metadataNumber reads a top-level numeric field from issue metadata.
+func metadataNumber(metadata json.RawMessage, key string) (float64, bool) {
+	if len(metadata) == 0 || key == "" {
+		return 0, false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return 0, false
+	}
+	switch v := fields[key].(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func displayAgentScores(scores []*AgentScore, since time.Time) {
+	if len(scores) == 0 {
+		fmt.Printf("\nNo agent activity since %s\n\n", since.Format("2006-01-02"))
+		return
+	}
+	fmt.Printf("\n%s Agent scorecards since %s\n\n", ui.RenderAccent("📊"), since.Format("2006-01-02"))
+	fmt.Printf("%-24s %7s %8s %8s %9s %9s\n", "AGENT", "CLOSED", "REOPEN", "REJECT", "AVG COST", "SLA")
+	for _, s := range scores {
+		cost := "-"
+		if s.AvgCost != nil {
+			cost = fmt.Sprintf("%.2f", *s.AvgCost)
+		}
+		fmt.Printf("%-24s %7d %8s %8s %9s %9s\n",
+			truncateTitle(s.Agent, 24), s.Closed, formatPercent(s.ReopenRate), formatPercent(s.RejectionRate),
+			cost, formatPercent(s.SLAAdherence))
+	}
+	fmt.Println()
+}
+
+func init() {
+	agentsScoreCmd.Flags().String("window", "30d", "Lookback window (e.g. 7d, 2w, 3m) or start date")
+	agentsScoreCmd.Flags().String("agent", "", "Only show this agent")
+	agentsScoreCmd.Flags().String("rejected-label", "review-rejected", "Label marking issues rejected in review")
+	agentsScoreCmd.Flags().String("cost-key", "cost_usd", "Numeric metadata key holding a bead's cost")
+	agentsCmd.AddCommand(agentsScoreCmd)
+	rootCmd.AddCommand(agentsCmd)
+}
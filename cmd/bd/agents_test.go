@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestComputeAgentScores(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-14 * 24 * time.Hour)
+	at := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Assignee: "alpha", Status: types.StatusClosed, ClosedAt: at(time.Hour),
+			DueAt: at(-time.Hour), Metadata: json.RawMessage(`{"cost_usd": 2.5}`)},
+		{ID: "bd-2", Assignee: "alpha", Status: types.StatusClosed, ClosedAt: at(2 * time.Hour),
+			DueAt: at(3 * time.Hour), Metadata: json.RawMessage(`{"cost_usd": 1.5}`), Labels: []string{"review-rejected"}},
+		{ID: "bd-3", Assignee: "alpha", Status: types.StatusOpen},
+		{ID: "bd-4", Assignee: "beta", Status: types.StatusClosed, ClosedAt: at(time.Hour)},
+		{ID: "bd-5", Status: types.StatusClosed, ClosedAt: at(time.Hour)},
+	}
+	events := []*types.Event{
+		{IssueID: "bd-3", EventType: types.EventReopened, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	scores := computeAgentScores(issues, events, since, now, "review-rejected", "cost_usd")
+	if len(scores) != 2 {
+		t.Fatalf("got %d scorecards, want 2", len(scores))
+	}
+	alpha := scores[0]
+	if alpha.Agent != "alpha" || alpha.Closed != 2 || alpha.Handled != 3 {
+		t.Fatalf("alpha = %+v", alpha)
+	}
+	if alpha.Reopened != 1 || alpha.Rejected != 1 {
+		t.Errorf("alpha reopened=%d rejected=%d, want 1/1", alpha.Reopened, alpha.Rejected)
+	}
+	if alpha.ReopenRate == nil || *alpha.ReopenRate != 1.0/3 || alpha.RejectionRate == nil || *alpha.RejectionRate != 1.0/3 {
+		t.Errorf("alpha reopen/rejection rates = %v/%v, want 1/3 each", alpha.ReopenRate, alpha.RejectionRate)
+	}
+	if alpha.AvgCost == nil || *alpha.AvgCost != 2.0 {
+		t.Errorf("alpha avg cost = %v, want 2.0", alpha.AvgCost)
+	}
+	if alpha.SLAAdherence == nil || *alpha.SLAAdherence != 0.5 {
+		t.Errorf("alpha SLA adherence = %v, want 0.5", alpha.SLAAdherence)
+	}
+	if alpha.ThroughputWeek != 1 {
+		t.Errorf("alpha throughput/week = %v, want 1", alpha.ThroughputWeek)
+	}
+	if beta := scores[1]; beta.AvgCost != nil || beta.SLAAdherence != nil {
+		t.Errorf("beta should have no cost or SLA data: %+v", beta)
+	}
+}
+
+func TestAgentScoreZeroSamples(t *testing.T) {
+	if r := sampleRatio(0, 0); r != nil {
+		t.Errorf("sampleRatio(0, 0) = %v, want nil", *r)
+	}
+	if r := sampleRatio(0, 4); r == nil || *r != 0 {
+		t.Errorf("sampleRatio(0, 4) = %v, want 0", r)
+	}
+
+	// A scorecard with nothing to measure omits its rates rather than
+	// reporting a misleading 0%.
+	data, err := json.Marshal(&AgentScore{Agent: "idle"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"reopen_rate", "rejection_rate", "avg_cost", "sla_adherence"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("zero-sample scorecard has %s: %s", key, data)
+		}
+	}
+	if got := formatPercent(nil); got != "-" {
+		t.Errorf("formatPercent(nil) = %q, want -", got)
+	}
+}
+
+func TestParseWindowFlag(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	got, err := parseWindowFlag("7d", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.AddDate(0, 0, -7); !got.Equal(want) {
+		t.Errorf("7d = %v, want %v", got, want)
+	}
+	if _, err := parseWindowFlag("", now); err == nil {
+		t.Error("expected error for empty window")
+	}
+	if _, err := parseWindowFlag("+2d", now); err == nil {
+		t.Error("expected error for future window start")
+	}
+}
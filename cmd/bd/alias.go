@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:     "alias",
+	GroupID: "issues",
+	Short:   "Give issues memorable names",
+	Long: `Manage issue aliases: memorable names such as infra-login-bug that
+resolve to an issue's hash ID anywhere an ID is accepted (bd show, bd update,
+bd dep add, ...).
+
+An alias is lowercase words joined by hyphens and must not start with the
+issue prefix or an allowed prefix, so it can never collide with a generated
+ID. Each alias names one issue; an issue may have several. Aliases follow
+their issue through renames and are removed when it is deleted.
+
+'bd create --id <name>' with a name outside the issue prefixes creates the
+issue with a hash ID and adds the name as its alias.
+
+Examples:
+  bd create "Login fails behind proxy" --id infra-login-bug
+  bd alias add login-epic bd-a3f8
+  bd show infra-login-bug
+  bd alias list
+  bd alias remove login-epic`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <alias> <issue-id>",
+	Short: "Add an alias for an issue",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alias add")
+		ctx := rootCtx
+		alias := args[0]
+
+		dbPrefix, allowedPrefixes := issueIDPrefixes(ctx, store)
+		if err := validation.ValidateAlias(alias, dbPrefix, allowedPrefixes); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issueID, err := utils.ResolvePartialID(ctx, store, args[1])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[1], err)
+		}
+		if err := aliasStore(store).AddAlias(ctx, alias, issueID, actor); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(&types.IssueAlias{Alias: alias, IssueID: issueID, CreatedBy: actor})
+			return
+		}
+		fmt.Printf("%s %s -> %s\n", ui.RenderPass("✓"), ui.RenderAccent(alias), issueID)
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:     "remove <alias>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an alias",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alias remove")
+		if err := aliasStore(store).RemoveAlias(rootCtx, args[0]); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				FatalErrorRespectJSON("no alias named %s", args[0])
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": args[0]})
+			return
+		}
+		fmt.Printf("%s Removed alias %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list [issue-id]",
+	Short: "List aliases, optionally for one issue",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		issueID := ""
+		if len(args) == 1 {
+			var err error
+			issueID, err = utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+			}
+		}
+		aliases, err := aliasStore(store).GetAliases(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("failed to list aliases: %v", err)
+		}
+
+		if jsonOutput {
+			if aliases == nil {
+				aliases = []*types.IssueAlias{}
+			}
+			outputJSON(aliases)
+			return
+		}
+		if len(aliases) == 0 {
+			fmt.Println("No aliases")
+			return
+		}
+		for _, a := range aliases {
+			fmt.Printf("  %-32s %s\n", ui.RenderAccent(a.Alias), a.IssueID)
+		}
+	},
+}
+
+// aliasStore returns the store's alias support, exiting when the backend
+// has none.
+func aliasStore(s storage.DoltStorage) storage.AliasStore {
+	as, ok := storage.UnwrapStore(s).(storage.AliasStore)
+	if !ok {
+		FatalErrorRespectJSON("aliases are not supported by this storage backend")
+	}
+	return as
+}
+
+func init() {
+	aliasAddCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return issueIDCompletion(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	aliasListCmd.ValidArgsFunction = issueIDCompletion
+	aliasCmd.AddCommand(aliasAddCmd, aliasRemoveCmd, aliasListCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
@@ -0,0 +1,69 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedAlias(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "al")
+
+	t.Run("create_vanity_id_adds_alias", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Login fails behind proxy", "--id", "infra-login-bug")
+		if !strings.HasPrefix(issue.ID, "al-") {
+			t.Fatalf("expected a generated al- ID, got %s", issue.ID)
+		}
+		if got := bdShow(t, bd, dir, "infra-login-bug"); got.ID != issue.ID {
+			t.Errorf("alias resolved to %s, want %s", got.ID, issue.ID)
+		}
+		out := bdCreateFail(t, bd, dir, "Again", "--id", "infra-login-bug")
+		if !strings.Contains(out, "already an alias") {
+			t.Errorf("expected alias collision error, got:\n%s", out)
+		}
+	})
+
+	t.Run("explicit_id_collision_rejected", func(t *testing.T) {
+		existing := bdCreate(t, bd, dir, "Original")
+		out := bdCreateFail(t, bd, dir, "Overwrite", "--id", existing.ID)
+		if !strings.Contains(out, "already exists") {
+			t.Errorf("expected collision error, got:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, existing.ID).Title; got != "Original" {
+			t.Errorf("existing issue was overwritten: title %q", got)
+		}
+	})
+
+	t.Run("add_list_remove", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Epic")
+		bdCommand(t, bd, dir, "alias", "add", "login-epic", issue.ID)
+		bdCommand(t, bd, dir, "update", "login-epic", "--priority", "1")
+		if got := bdShow(t, bd, dir, issue.ID).Priority; got != 1 {
+			t.Errorf("update via alias: priority %d, want 1", got)
+		}
+
+		out := bdCommand(t, bd, dir, "alias", "list", issue.ID)
+		if !strings.Contains(out, "login-epic") {
+			t.Errorf("alias list missing login-epic:\n%s", out)
+		}
+
+		failOut, err := bdRunWithFlockRetry(t, bd, dir, "alias", "add", "al-vanity", issue.ID)
+		if err == nil || !strings.Contains(string(failOut), "issue ID prefix") {
+			t.Errorf("expected prefix error, got %v:\n%s", err, failOut)
+		}
+
+		bdCommand(t, bd, dir, "alias", "remove", "login-epic")
+		out = bdCommand(t, bd, dir, "alias", "list")
+		if strings.Contains(out, "login-epic") {
+			t.Errorf("alias still listed after remove:\n%s", out)
+		}
+	})
+}
@@ -0,0 +1,601 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// ApplySpec is the desired-state document read by bd plan and bd apply. It
+// uses the --from-file issue schema, plus a name that identifies the issues
+// the spec owns across runs.
+type ApplySpec struct {
+	Name   string             `json:"name" yaml:"name"`
+	Issues []BatchCreateIssue `json:"issues" yaml:"issues"`
+}
+
+// Metadata keys stamped on every issue created by bd apply. They are how a
+// later run finds the issue again, so renaming a spec or a key orphans it.
+const (
+	applySpecMetaKey = "bd_spec"
+	applyKeyMetaKey  = "bd_spec_key"
+)
+
+// ApplyAction is one planned change.
+type ApplyAction struct {
+	Action  string   `json:"action"` // create, update, or close
+	Key     string   `json:"key"`
+	ID      string   `json:"id,omitempty"`
+	Title   string   `json:"title"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// ApplyPlanResult is the JSON output of bd plan and bd apply.
+type ApplyPlanResult struct {
+	Spec      string            `json:"spec"`
+	Actions   []ApplyAction     `json:"actions"`
+	Unchanged int               `json:"unchanged"`
+	Applied   bool              `json:"applied"`
+	IDs       map[string]string `json:"ids,omitempty"` // key -> ID for every spec issue, after apply
+}
+
+// specDep is a dependency as written in a spec: a type and a key or ID.
+type specDep struct {
+	depType string
+	ref     string
+}
+
+// specIssueUpdate holds the writes that bring one existing issue in line
+// with its spec entry.
+type specIssueUpdate struct {
+	key          string
+	id           string
+	fields       map[string]interface{}
+	addLabels    []string
+	removeLabels []string
+	oldParent    string // current parent ID to unlink, if the parent changes
+	newParent    string // spec ref (key or ID) of the new parent
+	removeDeps   []string
+	addDeps      []specDep
+}
+
+// specDiff is the difference between a spec and the issues it owns.
+type specDiff struct {
+	creates   []BatchCreateIssue
+	updates   []specIssueUpdate
+	closes    []*types.Issue
+	actions   []ApplyAction
+	unchanged int
+}
+
+func (d *specDiff) empty() bool {
+	return len(d.actions) == 0
+}
+
+// loadApplySpec reads and validates a spec file.
+func loadApplySpec(path string) (*ApplySpec, error) {
+	var spec ApplySpec
+	if err := decodeIssueSpecFile(path, &spec); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(spec.Name) == "" {
+		return nil, fmt.Errorf("%s: spec needs a name (it identifies the issues this spec manages)", path)
+	}
+	for i, issue := range spec.Issues {
+		if issue.Key == "" {
+			return nil, fmt.Errorf("%s: issue %d has no key (keys match spec entries to issues between runs)", path, i+1)
+		}
+	}
+	plan, err := batchCreateToGraphPlan(&BatchCreateFile{Issues: spec.Issues})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := validateGraphApplyPlan(plan, loadEmbeddedCustomTypes()); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// loadSpecIssues returns the issues previously created by the named spec,
+// keyed by their spec key.
+func loadSpecIssues(ctx context.Context, name string) (map[string]*types.Issue, error) {
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		MetadataFields:      map[string]string{applySpecMetaKey: name},
+		IncludeDependencies: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading issues for spec %q: %w", name, err)
+	}
+	byKey := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		key := specIssueKey(issue)
+		if key == "" {
+			continue
+		}
+		if prev, ok := byKey[key]; ok {
+			return nil, fmt.Errorf("spec %q key %q is claimed by both %s and %s; clear %s from one of them", name, key, prev.ID, issue.ID, applyKeyMetaKey)
+		}
+		byKey[key] = issue
+	}
+	return byKey, nil
+}
+
+func specIssueKey(issue *types.Issue) string {
+	var meta map[string]interface{}
+	if len(issue.Metadata) == 0 || json.Unmarshal(issue.Metadata, &meta) != nil {
+		return ""
+	}
+	key, _ := meta[applyKeyMetaKey].(string)
+	return key
+}
+
+// computeSpecDiff compares spec against the issues it already owns. Optional
+// fields left out of a spec entry are not managed: an omitted description or
+// label list never clears what is in the database. When labels or
+// depends_on are given, they are authoritative for that issue.
+func computeSpecDiff(spec *ApplySpec, existing map[string]*types.Issue) *specDiff {
+	diff := &specDiff{}
+	inSpec := make(map[string]bool, len(spec.Issues))
+	for _, issue := range spec.Issues {
+		inSpec[issue.Key] = true
+	}
+
+	// resolve maps a spec reference to an issue ID. known is false for keys
+	// of issues that do not exist yet.
+	resolve := func(ref string) (id string, known bool) {
+		if !inSpec[ref] {
+			return ref, true
+		}
+		if cur, ok := existing[ref]; ok {
+			return cur.ID, true
+		}
+		return "", false
+	}
+
+	for _, want := range spec.Issues {
+		cur, ok := existing[want.Key]
+		if !ok {
+			diff.creates = append(diff.creates, want)
+			diff.actions = append(diff.actions, ApplyAction{Action: "create", Key: want.Key, Title: want.Title})
+			continue
+		}
+
+		u := specIssueUpdate{key: want.Key, id: cur.ID, fields: map[string]interface{}{}}
+		var changes []string
+		if cur.Title != want.Title {
+			u.fields["title"] = want.Title
+			changes = append(changes, fmt.Sprintf("title: %q → %q", cur.Title, want.Title))
+		}
+		if want.Type != "" && string(cur.IssueType) != want.Type {
+			u.fields["issue_type"] = want.Type
+			changes = append(changes, fmt.Sprintf("type: %s → %s", cur.IssueType, want.Type))
+		}
+		if want.Priority != nil && cur.Priority != *want.Priority {
+			u.fields["priority"] = *want.Priority
+			changes = append(changes, fmt.Sprintf("priority: P%d → P%d", cur.Priority, *want.Priority))
+		}
+		if want.Description != "" && cur.Description != want.Description {
+			u.fields["description"] = want.Description
+			changes = append(changes, "description")
+		}
+		if want.Assignee != "" && cur.Assignee != want.Assignee {
+			u.fields["assignee"] = want.Assignee
+			changes = append(changes, fmt.Sprintf("assignee: %q → %q", cur.Assignee, want.Assignee))
+		}
+		if len(want.Metadata) > 0 {
+			merged := map[string]interface{}{}
+			if len(cur.Metadata) > 0 {
+				_ = json.Unmarshal(cur.Metadata, &merged)
+			}
+			changed := false
+			for k, v := range want.Metadata {
+				if s, _ := merged[k].(string); s != v || merged[k] == nil {
+					merged[k] = v
+					changed = true
+				}
+			}
+			if changed {
+				raw, _ := json.Marshal(merged)
+				u.fields["metadata"] = json.RawMessage(raw)
+				changes = append(changes, "metadata")
+			}
+		}
+
+		if want.Labels != nil {
+			u.addLabels, u.removeLabels = diffStringSets(cur.Labels, want.Labels)
+			if len(u.addLabels)+len(u.removeLabels) > 0 {
+				changes = append(changes, "labels: "+formatSetChange(u.addLabels, u.removeLabels))
+			}
+		}
+
+		curParent := ""
+		for _, dep := range cur.Dependencies {
+			if dep.Type == types.DepParentChild {
+				curParent = dep.DependsOnID
+			}
+		}
+		if want.Parent != "" {
+			if id, known := resolve(want.Parent); !known || id != curParent {
+				u.oldParent, u.newParent = curParent, want.Parent
+				changes = append(changes, fmt.Sprintf("parent: %s → %s", orNone(curParent), want.Parent))
+			}
+		}
+
+		if want.DependsOn != nil {
+			keep := make(map[string]bool)
+			var added []string
+			for _, raw := range want.DependsOn {
+				depType, ref, _ := parseBatchDependency(raw) // validated by loadApplySpec
+				id, known := resolve(ref)
+				matched := false
+				if known {
+					for _, dep := range cur.Dependencies {
+						if dep.DependsOnID == id && string(dep.Type) == depType {
+							keep[id] = true
+							matched = true
+						}
+					}
+				}
+				if !matched {
+					u.addDeps = append(u.addDeps, specDep{depType: depType, ref: ref})
+					added = append(added, ref)
+				}
+			}
+			var removed []string
+			for _, dep := range cur.Dependencies {
+				if dep.Type != types.DepParentChild && !keep[dep.DependsOnID] {
+					u.removeDeps = append(u.removeDeps, dep.DependsOnID)
+					removed = append(removed, dep.DependsOnID)
+				}
+			}
+			if len(added)+len(removed) > 0 {
+				changes = append(changes, "depends_on: "+formatSetChange(added, removed))
+			}
+		}
+
+		if len(changes) == 0 {
+			diff.unchanged++
+			continue
+		}
+		diff.updates = append(diff.updates, u)
+		diff.actions = append(diff.actions, ApplyAction{Action: "update", Key: want.Key, ID: cur.ID, Title: want.Title, Changes: changes})
+	}
+
+	orphaned := make([]string, 0)
+	for key, cur := range existing {
+		if !inSpec[key] && cur.Status != types.StatusClosed {
+			orphaned = append(orphaned, key)
+		}
+	}
+	sort.Strings(orphaned)
+	for _, key := range orphaned {
+		cur := existing[key]
+		diff.closes = append(diff.closes, cur)
+		diff.actions = append(diff.actions, ApplyAction{Action: "close", Key: key, ID: cur.ID, Title: cur.Title})
+	}
+	return diff
+}
+
+// executeSpecDiff writes diff in a single transaction and returns the ID of
+// every issue in the spec.
+func executeSpecDiff(ctx context.Context, spec *ApplySpec, existing map[string]*types.Issue, diff *specDiff) (map[string]string, error) {
+	keyToID := make(map[string]string, len(spec.Issues))
+	for key, issue := range existing {
+		keyToID[key] = issue.ID
+	}
+
+	commitMsg := fmt.Sprintf("bd: apply spec %s (%d created, %d updated, %d closed)",
+		spec.Name, len(diff.creates), len(diff.updates), len(diff.closes))
+	err := store.RunInTransaction(ctx, commitMsg, func(tx storage.Transaction) error {
+		if len(diff.creates) > 0 {
+			plan := specCreatePlan(spec.Name, diff.creates, keyToID)
+			created, err := applyGraphPlanInTx(ctx, tx, plan, GraphApplyOptions{})
+			if err != nil {
+				return err
+			}
+			for key, id := range created {
+				keyToID[key] = id
+			}
+		}
+
+		resolve := func(ref string) string {
+			if id, ok := keyToID[ref]; ok {
+				return id
+			}
+			return ref
+		}
+		for _, u := range diff.updates {
+			if len(u.fields) > 0 {
+				if err := tx.UpdateIssue(ctx, u.id, u.fields, actor); err != nil {
+					return fmt.Errorf("updating %s (%s): %w", u.key, u.id, err)
+				}
+			}
+			for _, label := range u.removeLabels {
+				if err := tx.RemoveLabel(ctx, u.id, label, actor); err != nil {
+					return fmt.Errorf("removing label %q from %s: %w", label, u.id, err)
+				}
+			}
+			for _, label := range u.addLabels {
+				if err := tx.AddLabel(ctx, u.id, label, actor); err != nil {
+					return fmt.Errorf("adding label %q to %s: %w", label, u.id, err)
+				}
+			}
+			if u.newParent != "" {
+				if u.oldParent != "" {
+					if err := tx.RemoveDependency(ctx, u.id, u.oldParent, actor); err != nil {
+						return fmt.Errorf("unlinking %s from parent %s: %w", u.id, u.oldParent, err)
+					}
+				}
+				dep := &types.Dependency{IssueID: u.id, DependsOnID: resolve(u.newParent), Type: types.DepParentChild}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("setting parent of %s: %w", u.id, err)
+				}
+			}
+			for _, target := range u.removeDeps {
+				if err := tx.RemoveDependency(ctx, u.id, target, actor); err != nil {
+					return fmt.Errorf("removing dependency %s -> %s: %w", u.id, target, err)
+				}
+			}
+			for _, d := range u.addDeps {
+				dep := &types.Dependency{IssueID: u.id, DependsOnID: resolve(d.ref), Type: graphApplyDependencyType(d.depType)}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("adding dependency %s -> %s: %w", u.id, dep.DependsOnID, err)
+				}
+			}
+		}
+
+		for _, issue := range diff.closes {
+			reason := fmt.Sprintf("Removed from spec %s", spec.Name)
+			if err := tx.CloseIssue(ctx, issue.ID, reason, actor, ""); err != nil {
+				return fmt.Errorf("closing %s: %w", issue.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keyToID, nil
+}
+
+// specCreatePlan builds the graph plan for the issues a spec still needs.
+// References to spec issues that already exist become IDs; references to
+// other new issues stay keys so the graph apply resolves them.
+func specCreatePlan(name string, creates []BatchCreateIssue, existingIDs map[string]string) *GraphApplyPlan {
+	issues := make([]BatchCreateIssue, len(creates))
+	for i, issue := range creates {
+		issue.Metadata = make(map[string]string, len(creates[i].Metadata)+2)
+		for k, v := range creates[i].Metadata {
+			issue.Metadata[k] = v
+		}
+		issue.Metadata[applySpecMetaKey] = name
+		issue.Metadata[applyKeyMetaKey] = issue.Key
+		if id, ok := existingIDs[issue.Parent]; ok {
+			issue.Parent = id
+		}
+		if len(issue.DependsOn) > 0 {
+			deps := make([]string, len(issue.DependsOn))
+			for j, raw := range issue.DependsOn {
+				depType, ref, _ := parseBatchDependency(raw)
+				if id, ok := existingIDs[ref]; ok {
+					ref = id
+				}
+				deps[j] = depType + ":" + ref
+			}
+			issue.DependsOn = deps
+		}
+		issues[i] = issue
+	}
+	// Keys and dependencies were validated by loadApplySpec.
+	plan, _ := batchCreateToGraphPlan(&BatchCreateFile{Issues: issues})
+	return plan
+}
+
+// diffStringSets returns the entries of want missing from have, and the
+// entries of have missing from want, each sorted.
+func diffStringSets(have, want []string) (add, remove []string) {
+	haveSet := make(map[string]bool, len(have))
+	for _, s := range have {
+		haveSet[s] = true
+	}
+	wantSet := make(map[string]bool, len(want))
+	for _, s := range want {
+		wantSet[s] = true
+		if !haveSet[s] {
+			add = append(add, s)
+		}
+	}
+	for _, s := range have {
+		if !wantSet[s] {
+			remove = append(remove, s)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+func formatSetChange(add, remove []string) string {
+	parts := make([]string, 0, len(add)+len(remove))
+	for _, s := range add {
+		parts = append(parts, "+"+s)
+	}
+	for _, s := range remove {
+		parts = append(parts, "-"+s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// planSpec loads a spec file and diffs it against the database.
+func planSpec(ctx context.Context, path string) (*ApplySpec, map[string]*types.Issue, *specDiff) {
+	spec, err := loadApplySpec(path)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	existing, err := loadSpecIssues(ctx, spec.Name)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return spec, existing, computeSpecDiff(spec, existing)
+}
+
+func printSpecPlan(spec *ApplySpec, diff *specDiff) {
+	if diff.empty() {
+		fmt.Printf("No changes. Spec %q matches the database (%d issues).\n", spec.Name, diff.unchanged)
+		return
+	}
+	fmt.Printf("Spec %q: %d to create, %d to update, %d to close, %d unchanged\n\n",
+		spec.Name, len(diff.creates), len(diff.updates), len(diff.closes), diff.unchanged)
+	for _, a := range diff.actions {
+		switch a.Action {
+		case "create":
+			fmt.Printf("  %s %s %q\n", ui.RenderPass("+ create"), a.Key, a.Title)
+		case "update":
+			fmt.Printf("  %s %s (%s)\n", ui.RenderWarn("~ update"), a.Key, a.ID)
+			for _, c := range a.Changes {
+				fmt.Printf("      %s\n", c)
+			}
+		case "close":
+			fmt.Printf("  %s %s (%s) %q\n", ui.RenderFail("- close "), a.Key, a.ID, a.Title)
+		}
+	}
+}
+
+func specPlanResult(spec *ApplySpec, diff *specDiff) ApplyPlanResult {
+	actions := diff.actions
+	if actions == nil {
+		actions = []ApplyAction{}
+	}
+	return ApplyPlanResult{Spec: spec.Name, Actions: actions, Unchanged: diff.unchanged}
+}
+
+var planCmd = &cobra.Command{
+	Use:     "plan <spec-file>",
+	GroupID: "issues",
+	Short:   "Show what bd apply would change for a spec file",
+	Long: `Compare a declarative spec file with the database and show the issues
+that 'bd apply' would create, update, or close. Nothing is written.
+
+See 'bd apply --help' for the spec format.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, _, diff := planSpec(rootCtx, args[0])
+		if jsonOutput {
+			outputJSON(specPlanResult(spec, diff))
+			return
+		}
+		printSpecPlan(spec, diff)
+		if !diff.empty() {
+			fmt.Printf("\nRun 'bd apply %s' to make these changes.\n", args[0])
+		}
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:     "apply <spec-file>",
+	GroupID: "issues",
+	Short:   "Create, update, and close issues to match a spec file",
+	Long: `Bring the database in line with a declarative YAML or JSON spec.
+
+A spec names a set of issues. The first apply creates them. Later applies
+update issues whose spec entry changed, create new entries, and close issues
+whose entries were removed. Re-applying an unchanged spec does nothing, so an
+agent can regenerate the spec and apply it as often as it likes. All changes
+land in one transaction and one Dolt commit.
+
+Issues are matched to spec entries by the spec name and each entry's key,
+which are stored in the issue's metadata (bd_spec, bd_spec_key). Renaming
+either one makes apply treat the entry as new.
+
+Spec format (same issue fields as 'bd create --from-file'):
+
+  name: auth-rollout
+  issues:
+    - key: epic
+      title: Roll out SSO
+      type: epic
+    - key: api
+      title: Add SAML endpoints
+      parent: epic
+      priority: 1
+      labels: [backend]
+    - key: ui
+      title: Login page
+      parent: epic
+      depends_on: [api, related:bd-42]
+
+parent and depends_on take another entry's key or an existing issue ID;
+dependencies default to blocks. Fields left out of an entry are not
+managed: omitting description never clears it. When labels or depends_on
+are listed they are authoritative, and extra labels or dependencies on that
+issue are removed (parent-child links excepted). Closed issues that are
+still in the spec stay closed.
+
+Examples:
+  bd plan spec.yaml          # Preview the diff
+  bd apply spec.yaml         # Apply it
+  bd apply spec.yaml --json  # Actions taken and the ID for every key`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("apply")
+		ctx := rootCtx
+		spec, existing, diff := planSpec(ctx, args[0])
+		result := specPlanResult(spec, diff)
+
+		if diff.empty() {
+			result.IDs = make(map[string]string, len(existing))
+			for key, issue := range existing {
+				result.IDs[key] = issue.ID
+			}
+			if jsonOutput {
+				outputJSON(result)
+				return
+			}
+			printSpecPlan(spec, diff)
+			return
+		}
+
+		ids, err := executeSpecDiff(ctx, spec, existing, diff)
+		if err != nil {
+			FatalErrorRespectJSON("apply %s: %v", spec.Name, err)
+		}
+		result.Applied = true
+		result.IDs = ids
+		for i := range result.Actions {
+			if result.Actions[i].ID == "" {
+				result.Actions[i].ID = ids[result.Actions[i].Key]
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		printSpecPlan(spec, diff)
+		fmt.Printf("\n%s Applied spec %q\n", ui.RenderPass("✓"), spec.Name)
+		for _, a := range result.Actions {
+			if a.Action == "create" {
+				fmt.Printf("  %s -> %s\n", a.Key, a.ID)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+}
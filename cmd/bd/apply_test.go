@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func specTestIssue(id, key, title string, deps ...*types.Dependency) *types.Issue {
+	meta, _ := json.Marshal(map[string]string{applySpecMetaKey: "s", applyKeyMetaKey: key})
+	return &types.Issue{
+		ID:           id,
+		Title:        title,
+		Status:       types.StatusOpen,
+		Priority:     2,
+		IssueType:    types.TypeTask,
+		Metadata:     meta,
+		Dependencies: deps,
+	}
+}
+
+func TestComputeSpecDiff(t *testing.T) {
+	p1 := 1
+	existing := map[string]*types.Issue{
+		"epic": specTestIssue("bd-1", "epic", "Epic"),
+		"api": specTestIssue("bd-2", "api", "API",
+			&types.Dependency{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}),
+		"ui": specTestIssue("bd-3", "ui", "UI",
+			&types.Dependency{IssueID: "bd-3", DependsOnID: "bd-2", Type: types.DepBlocks},
+			&types.Dependency{IssueID: "bd-3", DependsOnID: "bd-99", Type: types.DepRelated}),
+		"gone":   specTestIssue("bd-4", "gone", "Gone"),
+		"closed": specTestIssue("bd-5", "closed", "Closed"),
+	}
+	existing["api"].Labels = []string{"backend", "stale"}
+	existing["closed"].Status = types.StatusClosed
+
+	spec := &ApplySpec{Name: "s", Issues: []BatchCreateIssue{
+		{Key: "epic", Title: "Epic"},
+		{Key: "api", Title: "API", Parent: "epic", Priority: &p1, Labels: []string{"backend", "security"}},
+		{Key: "ui", Title: "Web UI", DependsOn: []string{"api", "docs"}},
+		{Key: "docs", Title: "Docs", Parent: "epic"},
+	}}
+
+	diff := computeSpecDiff(spec, existing)
+
+	if diff.unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", diff.unchanged)
+	}
+	if len(diff.creates) != 1 || diff.creates[0].Key != "docs" {
+		t.Errorf("creates = %+v, want docs", diff.creates)
+	}
+	if len(diff.closes) != 1 || diff.closes[0].ID != "bd-4" {
+		t.Errorf("closes = %+v, want only bd-4 (already-closed issues are left alone)", diff.closes)
+	}
+	if len(diff.updates) != 2 {
+		t.Fatalf("updates = %+v, want api and ui", diff.updates)
+	}
+
+	api := diff.updates[0]
+	if !reflect.DeepEqual(api.fields, map[string]interface{}{"priority": 1}) {
+		t.Errorf("api fields = %v", api.fields)
+	}
+	if !reflect.DeepEqual(api.addLabels, []string{"security"}) || !reflect.DeepEqual(api.removeLabels, []string{"stale"}) {
+		t.Errorf("api labels +%v -%v", api.addLabels, api.removeLabels)
+	}
+	if api.newParent != "" {
+		t.Errorf("api parent unchanged but newParent = %q", api.newParent)
+	}
+
+	ui := diff.updates[1]
+	if ui.fields["title"] != "Web UI" {
+		t.Errorf("ui fields = %v", ui.fields)
+	}
+	if !reflect.DeepEqual(ui.addDeps, []specDep{{depType: "blocks", ref: "docs"}}) {
+		t.Errorf("ui addDeps = %+v", ui.addDeps)
+	}
+	if !reflect.DeepEqual(ui.removeDeps, []string{"bd-99"}) {
+		t.Errorf("ui removeDeps = %v", ui.removeDeps)
+	}
+
+	var order []string
+	for _, a := range diff.actions {
+		order = append(order, a.Action+":"+a.Key)
+	}
+	want := []string{"update:api", "update:ui", "create:docs", "close:gone"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("actions = %v, want %v", order, want)
+	}
+}
+
+func TestComputeSpecDiffUnmanagedFields(t *testing.T) {
+	cur := specTestIssue("bd-1", "a", "A")
+	cur.Description = "written by hand"
+	cur.Labels = []string{"triage"}
+	cur.Assignee = "alice"
+
+	spec := &ApplySpec{Name: "s", Issues: []BatchCreateIssue{{Key: "a", Title: "A"}}}
+	diff := computeSpecDiff(spec, map[string]*types.Issue{"a": cur})
+	if !diff.empty() || diff.unchanged != 1 {
+		t.Fatalf("omitted fields must not produce changes, got %+v", diff.actions)
+	}
+}
+
+func TestSpecCreatePlanResolvesExistingKeys(t *testing.T) {
+	creates := []BatchCreateIssue{
+		{Key: "b", Title: "B", Parent: "epic", DependsOn: []string{"a", "related:c"}},
+		{Key: "c", Title: "C"},
+	}
+	plan := specCreatePlan("s", creates, map[string]string{"epic": "bd-1", "a": "bd-2"})
+
+	if plan.Nodes[0].ParentID != "bd-1" {
+		t.Errorf("parent = %+v, want ParentID bd-1", plan.Nodes[0])
+	}
+	wantEdges := []GraphApplyEdge{
+		{FromKey: "b", ToID: "bd-2", Type: "blocks"},
+		{FromKey: "b", ToKey: "c", Type: "related"},
+	}
+	if !reflect.DeepEqual(plan.Edges, wantEdges) {
+		t.Errorf("edges = %+v, want %+v", plan.Edges, wantEdges)
+	}
+	for _, node := range plan.Nodes {
+		if node.Metadata[applySpecMetaKey] != "s" || node.Metadata[applyKeyMetaKey] != node.Key {
+			t.Errorf("node %s metadata = %v, want spec markers", node.Key, node.Metadata)
+		}
+	}
+	if creates[0].Metadata != nil || creates[0].Parent != "epic" {
+		t.Error("specCreatePlan modified its input")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// resolveAsOfRef turns an --as-of value into a Dolt ref. Dates and relative
+// times ("2026-01-05", "-7d", "last monday") resolve to the latest commit
+// made at or before that moment; anything else is used as a commit hash or
+// branch name.
+func resolveAsOfRef(ctx context.Context, s storage.DoltStorage, value string) (string, error) {
+	t, err := parseTimeFlag(value)
+	if err != nil {
+		return value, nil
+	}
+	ref, err := s.CommitAsOf(ctx, t)
+	if err != nil {
+		return "", fmt.Errorf("resolving --as-of %s: %w", value, err)
+	}
+	return ref, nil
+}
+
+// listIssuesAsOf renders bd list --as-of. Filters apply to the historical
+// rows; blocker and progress annotations are omitted because they describe
+// the current graph, not the one at ref.
+func listIssuesAsOf(ctx context.Context, s storage.DoltStorage, in listInput, filter types.IssueFilter) {
+	ref, err := resolveAsOfRef(ctx, s, in.asOf)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	issues, err := s.SearchIssuesAsOf(ctx, ref, "", withFetchOneExtra(filter))
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	sortIssues(issues, in.sortBy, in.reverse)
+	truncated := in.effectiveLimit > 0 && len(issues) > in.effectiveLimit
+	if truncated {
+		issues = issues[:in.effectiveLimit]
+	}
+
+	if jsonOutput {
+		iwc := make([]*types.IssueWithCounts, 0, len(issues))
+		for _, issue := range issues {
+			iwc = append(iwc, &types.IssueWithCounts{Issue: issue})
+		}
+		outputJSON(iwc)
+		printTruncationHint(truncated, in.effectiveLimit)
+		return
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s\n", ui.RenderMuted(fmt.Sprintf("As of %s (%d issues)", in.asOf, len(issues))))
+	for _, issue := range issues {
+		if in.longFormat {
+			formatIssueLongWithProgress(&buf, issue, issue.Labels, in.skipLabels, nil)
+		} else {
+			formatIssueCompactWithProgress(&buf, issue, issue.Labels, nil, nil, "", nil, 0)
+		}
+	}
+	fmt.Print(buf.String())
+	printTruncationHint(truncated, in.effectiveLimit)
+}
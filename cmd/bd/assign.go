@@ -8,18 +8,62 @@ import (
 )
 
 var assignCmd = &cobra.Command{
-	Use:     "assign <id> <name>",
+	Use:     "assign <id> <name> | --balance | --auto [<id>...]",
 	GroupID: "issues",
 	Short:   "Assign an issue to someone",
 	Long: `Assign an issue to someone.
 
 Shorthand for 'bd update <id> --assignee <name>'.
 
+With --balance, propose owners for unassigned ready work instead: each
+issue, in ready order, goes to the matching agent with the fewest open and
+in-progress issues (see 'bd workload'). Agents come from --agents, else
+assign.agents, else everyone who already holds active work. An agent only
+takes issues whose "skill:<name>" labels it offers in assign.skills (see
+'bd skills'):
+
+  assign:
+    agents: [claude-1, claude-2, alice]
+    skills:
+      claude-1: [go, sql]
+      alice: [frontend, design]
+
+Nothing changes until you pass --apply.
+
+With --auto, apply the auto-assignment rules (see 'bd assign rule') to the
+given issues, or to every open, unassigned issue when none are given. The
+same rules run when 'bd create' is given no --assignee.
+
 Examples:
   bd assign bd-123 alice
-  bd assign bd-123 ""      # unassign`,
-	Args: cobra.ExactArgs(2),
+  bd assign bd-123 ""                     # unassign
+  bd assign --balance                     # show proposed assignments
+  bd assign --balance --apply --limit 5   # assign the top 5 ready issues
+  bd assign --auto --dry-run              # show what the rules would assign
+  bd assign --auto bd-123 bd-124          # apply the rules to two issues`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		balance, _ := cmd.Flags().GetBool("balance")
+		auto, _ := cmd.Flags().GetBool("auto")
+		if balance && auto {
+			return fmt.Errorf("--balance and --auto cannot be combined")
+		}
+		if balance {
+			return cobra.NoArgs(cmd, args)
+		}
+		if auto {
+			return nil
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if balance, _ := cmd.Flags().GetBool("balance"); balance {
+			runAssignBalance(cmd)
+			return
+		}
+		if auto, _ := cmd.Flags().GetBool("auto"); auto {
+			runAssignAuto(cmd, args)
+			return
+		}
 		CheckReadonly("assign")
 
 		id := args[0]
@@ -85,6 +129,12 @@ Examples:
 }
 
 func init() {
+	assignCmd.Flags().Bool("balance", false, "Propose owners for unassigned ready work, least-loaded agent first")
+	assignCmd.Flags().StringSlice("agents", nil, "Agents to balance across (overrides assign.agents)")
+	assignCmd.Flags().Bool("apply", false, "With --balance, make the proposed assignments")
+	assignCmd.Flags().Int("limit", 0, "With --balance, consider at most this many ready issues (0 = all)")
+	assignCmd.Flags().Bool("auto", false, "Assign issues with the auto-assignment rules (see 'bd assign rule')")
+	assignCmd.Flags().Bool("dry-run", false, "With --auto, show the assignments without making them")
 	assignCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(assignCmd)
 }
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// AutoAssignment is one assignment made (or proposed) by 'bd assign --auto'.
+type AutoAssignment struct {
+	IssueID  string `json:"issue_id"`
+	Title    string `json:"title"`
+	Assignee string `json:"assignee"`
+	Rule     string `json:"rule"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AutoAssignResult is the JSON output of 'bd assign --auto'.
+type AutoAssignResult struct {
+	Assigned  []*AutoAssignment `json:"assigned"`
+	Unmatched []string          `json:"unmatched,omitempty"` // issues no rule matched
+	DryRun    bool              `json:"dry_run"`
+}
+
+var assignRuleCmd = &cobra.Command{
+	Use:   "rule",
+	Short: "Manage auto-assignment rules",
+	Long: `Manage the rules that pick an assignee for new and unassigned issues.
+
+Rules are tried in priority order (lowest first, then name). The first rule
+whose conditions all match picks the assignee with its strategy:
+
+  fixed         always the first listed assignee
+  round-robin   each listed assignee in turn
+  least-loaded  the listed assignee with the fewest open and in-progress issues
+
+Conditions are optional; a rule without any is a catch-all:
+
+  --label        the issue carries this label
+  --type         the issue has this type
+  --path-prefix  the issue's path is under this directory. At create time the
+                 path is the working directory relative to the repository
+                 root; for 'bd assign --auto' it is the monorepo sub-project
+                 directory of the issue's prefix (see the projects config).
+
+Rules apply when 'bd create' runs without --assignee, and to existing
+unassigned issues with 'bd assign --auto'.`,
+}
+
+var assignRuleAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add an auto-assignment rule",
+	Long: `Add an auto-assignment rule.
+
+Examples:
+  bd assign rule add web --path-prefix apps/web --strategy round-robin --assignees alice,bob
+  bd assign rule add bugs --type bug --strategy least-loaded --assignees claude-1,claude-2
+  bd assign rule add security --label security --strategy fixed --assignees carol --priority -1
+  bd assign rule add default --strategy round-robin --assignees claude-1,claude-2 --priority 100`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("assign rule add")
+		strategy, _ := cmd.Flags().GetString("strategy")
+		assignees, _ := cmd.Flags().GetStringSlice("assignees")
+		label, _ := cmd.Flags().GetString("label")
+		pathPrefix, _ := cmd.Flags().GetString("path-prefix")
+		issueType, _ := cmd.Flags().GetString("type")
+		priority, _ := cmd.Flags().GetInt("priority")
+
+		ars := assignmentRuleStore(store)
+		rule := &types.AssignmentRule{
+			Name:       strings.TrimSpace(args[0]),
+			Priority:   priority,
+			Label:      strings.TrimSpace(label),
+			PathPrefix: strings.Trim(strings.TrimSpace(pathPrefix), "/"),
+			IssueType:  utils.NormalizeIssueType(issueType),
+			Strategy:   strings.ToLower(strings.TrimSpace(strategy)),
+			Assignees:  utils.NormalizeLabels(assignees),
+			CreatedBy:  actor,
+		}
+		if err := ars.AddAssignmentRule(rootCtx, rule); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(rule)
+			return
+		}
+		fmt.Printf("%s Added assignment rule %s: %s\n", ui.RenderPass("✓"), ui.RenderAccent(rule.Name), describeAssignmentRule(rule))
+	},
+}
+
+var assignRuleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List auto-assignment rules in the order they are tried",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, err := assignmentRuleStore(store).GetAssignmentRules(rootCtx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if rules == nil {
+				rules = []*types.AssignmentRule{}
+			}
+			outputJSON(rules)
+			return
+		}
+		if len(rules) == 0 {
+			fmt.Printf("\nNo assignment rules. Add one with 'bd assign rule add'.\n\n")
+			return
+		}
+		fmt.Printf("\n%s Assignment rules (%d):\n\n", ui.RenderAccent("📋"), len(rules))
+		for _, r := range rules {
+			fmt.Printf("  %s %s %s\n", ui.RenderMuted(fmt.Sprintf("%4d", r.Priority)), ui.RenderAccent(r.Name), describeAssignmentRule(r))
+		}
+		fmt.Println()
+	},
+}
+
+var assignRuleRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an auto-assignment rule",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("assign rule remove")
+		name := args[0]
+		if err := assignmentRuleStore(store).RemoveAssignmentRule(rootCtx, name); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				FatalErrorRespectJSON("no assignment rule named %q", name)
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": name})
+			return
+		}
+		fmt.Printf("%s Removed assignment rule %s\n", ui.RenderPass("✓"), name)
+	},
+}
+
+// assignmentRuleStore returns s as an AssignmentRuleStore, exiting when the
+// backend does not support rules.
+func assignmentRuleStore(s storage.DoltStorage) storage.AssignmentRuleStore {
+	ars, ok := storage.UnwrapStore(s).(storage.AssignmentRuleStore)
+	if !ok {
+		FatalErrorRespectJSON("assignment rules are not supported by this storage backend")
+	}
+	return ars
+}
+
+// describeAssignmentRule renders a rule's conditions and strategy on one line.
+func describeAssignmentRule(r *types.AssignmentRule) string {
+	var conds []string
+	if r.Label != "" {
+		conds = append(conds, "label="+r.Label)
+	}
+	if r.IssueType != "" {
+		conds = append(conds, "type="+r.IssueType)
+	}
+	if r.PathPrefix != "" {
+		conds = append(conds, "path="+r.PathPrefix+"/")
+	}
+	match := "any issue"
+	if len(conds) > 0 {
+		match = strings.Join(conds, " ")
+	}
+	return fmt.Sprintf("%s → %s %s", match, r.Strategy, strings.Join(r.Assignees, ","))
+}
+
+// ruleAssigner applies assignment rules to a batch of issues, tracking
+// round-robin cursors and least-loaded counts across the batch.
+type ruleAssigner struct {
+	ctx    context.Context
+	store  storage.DoltStorage
+	ars    storage.AssignmentRuleStore
+	rules  []*types.AssignmentRule
+	dryRun bool // propose only: round-robin cursors are not advanced
+
+	loads map[string]int // active issues per assignee, loaded on first use
+}
+
+// newRuleAssigner loads the rules of s. It returns nil when there are none
+// or the backend does not support them.
+func newRuleAssigner(ctx context.Context, s storage.DoltStorage, dryRun bool) (*ruleAssigner, error) {
+	ars, ok := storage.UnwrapStore(s).(storage.AssignmentRuleStore)
+	if !ok {
+		return nil, nil
+	}
+	rules, err := ars.GetAssignmentRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return &ruleAssigner{ctx: ctx, store: s, ars: ars, rules: rules, dryRun: dryRun}, nil
+}
+
+// assign picks an assignee for issue, whose repository-relative path is
+// issuePath, from the first matching rule. It returns a nil rule when none
+// matches.
+func (a *ruleAssigner) assign(issue *types.Issue, issuePath string) (string, *types.AssignmentRule, error) {
+	for _, rule := range a.rules {
+		if !rule.Matches(issue, issuePath) || len(rule.Assignees) == 0 {
+			continue
+		}
+		assignee, err := a.pick(rule)
+		if err != nil {
+			return "", rule, err
+		}
+		if a.loads != nil {
+			a.loads[assignee]++
+		}
+		return assignee, rule, nil
+	}
+	return "", nil, nil
+}
+
+func (a *ruleAssigner) pick(rule *types.AssignmentRule) (string, error) {
+	switch rule.Strategy {
+	case types.AssignStrategyRoundRobin:
+		next := rule.NextIndex
+		if !a.dryRun {
+			var err error
+			if next, err = a.ars.AdvanceAssignmentRule(a.ctx, rule.Name); err != nil {
+				return "", err
+			}
+		}
+		rule.NextIndex = next + 1
+		return rule.Assignees[next%len(rule.Assignees)], nil
+	case types.AssignStrategyLeastLoaded:
+		if a.loads == nil {
+			report, err := loadWorkload(a.ctx, a.store)
+			if err != nil {
+				return "", err
+			}
+			a.loads = map[string]int{}
+			for _, w := range report.Assignees {
+				a.loads[w.Assignee] = w.Active()
+			}
+		}
+		best := rule.Assignees[0]
+		for _, candidate := range rule.Assignees[1:] {
+			if a.loads[candidate] < a.loads[best] {
+				best = candidate
+			}
+		}
+		return best, nil
+	default:
+		return rule.Assignees[0], nil
+	}
+}
+
+// autoAssignOnCreate sets issue's assignee from the assignment rules, using
+// the working directory as the issue's path. Failures only warn: the issue
+// is still created, unassigned.
+func autoAssignOnCreate(ctx context.Context, s storage.DoltStorage, issue *types.Issue) *types.AssignmentRule {
+	assigner, err := newRuleAssigner(ctx, s, false)
+	if err != nil {
+		WarnError("loading assignment rules: %v", err)
+		return nil
+	}
+	if assigner == nil {
+		return nil
+	}
+	issuePath := ""
+	if root, cwd := repoRootAndCwd(); root != "" && cwd != "" {
+		issuePath = repoRelativeDir(root, cwd)
+	}
+	assignee, rule, err := assigner.assign(issue, issuePath)
+	if err != nil {
+		WarnError("applying assignment rule %s: %v", rule.Name, err)
+		return nil
+	}
+	issue.Assignee = assignee
+	return rule
+}
+
+// runAssignAuto implements 'bd assign --auto': apply the rules to the given
+// issues, or to every open, unassigned issue when none are given.
+func runAssignAuto(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("assign --auto")
+	}
+	ctx := rootCtx
+
+	assigner, err := newRuleAssigner(ctx, store, dryRun)
+	if err != nil {
+		FatalErrorRespectJSON("loading assignment rules: %v", err)
+	}
+	if assigner == nil {
+		FatalErrorRespectJSON("no assignment rules (add one with 'bd assign rule add')")
+	}
+
+	var issues []*types.Issue
+	if len(args) > 0 {
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			issue, err := store.GetIssue(ctx, id)
+			if err != nil {
+				FatalErrorRespectJSON("getting %s: %v", id, err)
+			}
+			issues = append(issues, issue)
+		}
+	} else {
+		ephemeral, template := false, false
+		issues, err = store.SearchIssues(ctx, "", types.IssueFilter{
+			NoAssignee:    true,
+			Ephemeral:     &ephemeral,
+			IsTemplate:    &template,
+			ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+			ExcludeLabels: []string{BeadsTemplateLabel},
+		})
+		if err != nil {
+			FatalErrorRespectJSON("listing unassigned issues: %v", err)
+		}
+	}
+	if len(issues) > 0 {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("loading labels: %v", err)
+		}
+		for _, issue := range issues {
+			issue.Labels = labels[issue.ID]
+		}
+	}
+	projects, err := loadProjectPrefixes(ctx, store)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	result := &AutoAssignResult{Assigned: []*AutoAssignment{}, DryRun: dryRun}
+	var written []string
+	for _, issue := range issues {
+		if issue.Assignee != "" {
+			continue // explicitly named issues keep their owner
+		}
+		assignee, rule, err := assigner.assign(issue, projectDirForID(projects, issue.ID))
+		if rule == nil {
+			result.Unmatched = append(result.Unmatched, issue.ID)
+			continue
+		}
+		a := &AutoAssignment{IssueID: issue.ID, Title: issue.Title, Assignee: assignee, Rule: rule.Name}
+		result.Assigned = append(result.Assigned, a)
+		if err != nil {
+			a.Error = err.Error()
+			continue
+		}
+		if dryRun {
+			continue
+		}
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"assignee": assignee}, actor); err != nil {
+			a.Error = err.Error()
+			continue
+		}
+		written = append(written, issue.ID)
+	}
+	if len(written) > 0 {
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:  "assign",
+			IssueIDs: written,
+		}); err != nil {
+			FatalErrorRespectJSON("failed to commit: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	displayAutoAssignResult(result)
+}
+
+func displayAutoAssignResult(result *AutoAssignResult) {
+	if len(result.Assigned) == 0 && len(result.Unmatched) == 0 {
+		fmt.Printf("\n%s No unassigned issues\n\n", ui.RenderPass("✨"))
+		return
+	}
+	verb := "Assigned"
+	if result.DryRun {
+		verb = "Would assign"
+	}
+	fmt.Printf("\n%s %s %d issue(s):\n\n", ui.RenderAccent("📋"), verb, len(result.Assigned))
+	for _, a := range result.Assigned {
+		if a.Error != "" {
+			fmt.Printf("  %s %s → %s: %s\n", ui.RenderFail("✗"), a.IssueID, a.Assignee, a.Error)
+			continue
+		}
+		fmt.Printf("  %s → %s %s\n", formatFeedbackID(a.IssueID, a.Title), ui.RenderAccent(a.Assignee),
+			ui.RenderMuted("(rule "+a.Rule+")"))
+	}
+	if len(result.Unmatched) > 0 {
+		fmt.Printf("\n  %s No rule matches: %v\n", ui.RenderWarn("⚠"), result.Unmatched)
+	}
+	fmt.Println()
+}
+
+func init() {
+	assignRuleAddCmd.Flags().String("strategy", "", "Assignment strategy: fixed, round-robin, or least-loaded (required)")
+	assignRuleAddCmd.Flags().StringSlice("assignees", nil, "Assignees to choose from, comma-separated (required)")
+	assignRuleAddCmd.Flags().String("label", "", "Match issues carrying this label")
+	assignRuleAddCmd.Flags().String("path-prefix", "", "Match issues whose path is under this directory")
+	assignRuleAddCmd.Flags().StringP("type", "t", "", "Match issues of this type")
+	assignRuleAddCmd.Flags().Int("priority", 0, "Order in which rules are tried (lowest first)")
+	_ = assignRuleAddCmd.MarkFlagRequired("strategy")
+	_ = assignRuleAddCmd.MarkFlagRequired("assignees")
+
+	assignRuleCmd.AddCommand(assignRuleAddCmd, assignRuleListCmd, assignRuleRemoveCmd)
+	assignCmd.AddCommand(assignRuleCmd)
+}
@@ -0,0 +1,82 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedAssignmentRules(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ar")
+	existing := bdCreate(t, bd, dir, "Created before any rules", "--labels", "frontend")
+
+	run := func(args ...string) []byte {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+
+	run("assign", "rule", "add", "frontend", "--label", "frontend", "--strategy", "round-robin", "--assignees", "alice,bob")
+	run("assign", "rule", "add", "bugs", "--type", "bug", "--strategy", "fixed", "--assignees", "carol", "--priority", "5")
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "assign", "rule", "add", "bugs", "--strategy", "fixed", "--assignees", "dave"); err == nil {
+		t.Errorf("duplicate rule name should fail\n%s", out)
+	}
+
+	var rules []*types.AssignmentRule
+	if raw := run("assign", "rule", "list", "--json"); json.Unmarshal(raw, &rules) != nil {
+		t.Fatalf("parse rules: %s", raw)
+	}
+	if len(rules) != 2 || rules[0].Name != "frontend" || rules[1].Name != "bugs" {
+		t.Fatalf("rules = %+v, want frontend then bugs", rules)
+	}
+
+	// Round-robin on create; --assignee wins over the rules.
+	first := bdCreate(t, bd, dir, "First frontend task", "--labels", "frontend")
+	second := bdCreate(t, bd, dir, "Second frontend task", "--labels", "frontend")
+	bug := bdCreate(t, bd, dir, "A bug", "--type", "bug")
+	explicit := bdCreate(t, bd, dir, "Explicit owner", "--labels", "frontend", "--assignee", "zoe")
+	other := bdCreate(t, bd, dir, "Matches nothing")
+	for id, want := range map[string]string{first.ID: "alice", second.ID: "bob", bug.ID: "carol", explicit.ID: "zoe", other.ID: ""} {
+		if got := bdShow(t, bd, dir, id); got.Assignee != want {
+			t.Errorf("%s assignee = %q, want %q", id, got.Assignee, want)
+		}
+	}
+
+	var dry AutoAssignResult
+	if raw := run("assign", "--auto", "--dry-run", "--json"); json.Unmarshal(raw, &dry) != nil {
+		t.Fatalf("parse auto: %s", raw)
+	}
+	if !dry.DryRun || len(dry.Assigned) != 1 || dry.Assigned[0].IssueID != existing.ID || dry.Assigned[0].Assignee != "alice" {
+		t.Errorf("dry run = %+v, want %s → alice", dry.Assigned, existing.ID)
+	}
+	if len(dry.Unmatched) != 1 || dry.Unmatched[0] != other.ID {
+		t.Errorf("dry run unmatched = %v, want [%s]", dry.Unmatched, other.ID)
+	}
+	if got := bdShow(t, bd, dir, existing.ID); got.Assignee != "" {
+		t.Errorf("dry run assigned %s to %q", existing.ID, got.Assignee)
+	}
+
+	run("assign", "--auto", existing.ID)
+	if got := bdShow(t, bd, dir, existing.ID); got.Assignee != "alice" {
+		t.Errorf("%s assignee = %q, want alice", existing.ID, got.Assignee)
+	}
+
+	run("assign", "rule", "remove", "frontend")
+	if raw := run("assign", "rule", "list", "--json"); json.Unmarshal(raw, &rules) != nil || len(rules) != 1 {
+		t.Errorf("rules after remove = %s", raw)
+	}
+}
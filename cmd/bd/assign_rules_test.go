@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRuleAssigner(t *testing.T) {
+	rules := []*types.AssignmentRule{
+		{Name: "security", Label: "security", Strategy: types.AssignStrategyFixed, Assignees: []string{"carol"}},
+		{Name: "bugs", IssueType: "bug", Strategy: types.AssignStrategyLeastLoaded, Assignees: []string{"alice", "bob"}},
+		{Name: "web", PathPrefix: "apps/web", Strategy: types.AssignStrategyRoundRobin, Assignees: []string{"dave", "erin"}, NextIndex: 1},
+	}
+	a := &ruleAssigner{rules: rules, dryRun: true, loads: map[string]int{"alice": 2, "bob": 1}}
+
+	tests := []struct {
+		issue    *types.Issue
+		path     string
+		want     string
+		wantRule string
+	}{
+		{&types.Issue{IssueType: types.TypeBug, Labels: []string{"security"}}, "", "carol", "security"},
+		{&types.Issue{IssueType: types.TypeBug}, "", "bob", "bugs"},
+		{&types.Issue{IssueType: types.TypeBug}, "", "alice", "bugs"}, // bob now has 2, tie goes to list order
+		{&types.Issue{IssueType: types.TypeTask}, "apps/web/src", "erin", "web"},
+		{&types.Issue{IssueType: types.TypeTask}, "apps/web", "dave", "web"},
+		{&types.Issue{IssueType: types.TypeTask}, "apps/api", "", ""},
+	}
+	for i, tt := range tests {
+		got, rule, err := a.assign(tt.issue, tt.path)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		gotRule := ""
+		if rule != nil {
+			gotRule = rule.Name
+		}
+		if got != tt.want || gotRule != tt.wantRule {
+			t.Errorf("case %d: assign() = %q (rule %q), want %q (rule %q)", i, got, gotRule, tt.want, tt.wantRule)
+		}
+	}
+}
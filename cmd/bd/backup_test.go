@@ -165,13 +165,21 @@ func TestBackupDir_UsesWorktreeFallback(t *testing.T) {
 	}
 }
 
-// splitJSONL splits JSONL data into individual JSON lines, skipping empty lines.
+// splitJSONL splits JSONL data into individual JSON lines, skipping empty
+// lines and the export schema header.
 func splitJSONL(data []byte) []json.RawMessage {
 	var result []json.RawMessage
 	for _, line := range splitLines(data) {
-		if len(line) > 0 {
-			result = append(result, json.RawMessage(line))
+		if len(line) == 0 {
+			continue
 		}
+		var header struct {
+			Schema string `json:"_schema"`
+		}
+		if json.Unmarshal(line, &header) == nil && header.Schema != "" {
+			continue
+		}
+		result = append(result, json.RawMessage(line))
 	}
 	return result
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
@@ -48,8 +49,16 @@ Grammar (one command per line):
   create <type> <priority> <title...>
   dep add <from-id> <to-id> [type]
   dep remove <from-id> <to-id>
+  label add <id> <label> [label...]
+  label remove <id> <label> [label...]
+  $name = create <type> <priority> <title...>
   #comment  (blank lines and '# ...' comments are ignored)
 
+'$name = create ...' binds the new issue's ID to $name, which later lines
+may use wherever an issue ID is expected. This lets a script create issues
+and wire them together without knowing their IDs in advance. A name must be
+bound before it is used and cannot be rebound.
+
 Supported 'update' keys: status, priority, title, assignee
 Supported dependency types: see 'bd dep add --help' (default: blocks)
 
@@ -66,6 +75,14 @@ Examples:
   # Inline
   printf 'close bd-1 done\nupdate bd-2 status=in_progress\n' | bd batch
 
+  # Create and wire up new issues; all of it lands or none of it does
+  bd batch <<'EOF'
+  $epic = create epic 1 "Auth rewrite"
+  $task = create task 2 "Token refresh"
+  dep add $task $epic parent-child
+  label add $task backend security
+  EOF
+
 On success, exits 0 and prints a summary (or JSON with --json). On any error,
 rolls back the entire transaction and exits non-zero with the failing line.
 
@@ -145,11 +162,18 @@ normal 'bd' subcommands for interactive/read operations.`,
 
 		results := make([]batchOpResult, 0, len(ops))
 		err = transact(ctx, store, commitMsg, func(tx storage.Transaction) error {
+			// transact may retry fn, so bindings start over on each attempt.
+			results = results[:0]
+			bound := make(map[string]string)
 			for _, op := range ops {
-				res, rerr := runBatchOp(ctx, tx, op)
+				res, rerr := runBatchOp(ctx, tx, op.withBindings(bound))
 				if rerr != nil {
 					return fmt.Errorf("line %d (%s): %w", op.line, op.raw, rerr)
 				}
+				if op.bind != "" {
+					bound[op.bind] = res.Target
+					res.Bind = op.bind
+				}
 				results = append(results, res)
 			}
 			return nil
@@ -172,6 +196,10 @@ normal 'bd' subcommands for interactive/read operations.`,
 		} else {
 			fmt.Fprintf(cmd.OutOrStdout(), "batch: %d operations committed\n", len(results))
 			for _, r := range results {
+				if r.Bind != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "  line %d: %s %s = %s\n", r.Line, r.Op, r.Bind, r.Target)
+					continue
+				}
 				fmt.Fprintf(cmd.OutOrStdout(), "  line %d: %s %s\n", r.Line, r.Op, r.Target)
 			}
 		}
@@ -192,6 +220,36 @@ type batchOp struct {
 	raw  string   // original source line (for error messages)
 	cmd  string   // canonical command name, e.g. "close", "update", "dep.add"
 	args []string // remaining tokens
+	bind string   // "$name" that receives a created issue's ID, if any
+}
+
+// batchIDArgs is how many leading args of each command are issue IDs, and
+// so may be "$name" references to IDs bound earlier in the script. Other
+// args (reasons, titles, labels) are taken literally.
+var batchIDArgs = map[string]int{
+	"close":        1,
+	"update":       1,
+	"dep.add":      2,
+	"dep.remove":   2,
+	"label.add":    1,
+	"label.remove": 1,
+}
+
+// withBindings returns op with "$name" ID arguments replaced by the IDs
+// bound to them. parseBatchScript has already checked every reference.
+func (op batchOp) withBindings(bound map[string]string) batchOp {
+	n := min(batchIDArgs[op.cmd], len(op.args))
+	if n == 0 {
+		return op
+	}
+	args := append([]string(nil), op.args...)
+	for i := 0; i < n; i++ {
+		if id, ok := bound[args[i]]; ok {
+			args[i] = id
+		}
+	}
+	op.args = args
+	return op
 }
 
 // batchOpResult is emitted per executed op for JSON reporting.
@@ -199,17 +257,19 @@ type batchOpResult struct {
 	Line   int    `json:"line"`
 	Op     string `json:"op"`
 	Target string `json:"target,omitempty"`
+	Bind   string `json:"bind,omitempty"`
 }
 
 // parseBatchScript reads the whole input and tokenizes each non-empty,
-// non-comment line. It rejects unknown commands immediately so a bad script
-// fails before any writes.
+// non-comment line. It rejects unknown commands and unbound "$name"
+// references immediately so a bad script fails before any writes.
 func parseBatchScript(r io.Reader) ([]batchOp, error) {
 	scanner := bufio.NewScanner(r)
 	// Allow long lines (descriptions, multi-token updates).
 	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
 
 	var ops []batchOp
+	bound := make(map[string]bool)
 	lineNo := 0
 	for scanner.Scan() {
 		lineNo++
@@ -226,6 +286,19 @@ func parseBatchScript(r io.Reader) ([]batchOp, error) {
 			continue
 		}
 		op := batchOp{line: lineNo, raw: trimmed}
+		if strings.HasPrefix(tokens[0], "$") && len(tokens) > 1 && tokens[1] == "=" {
+			if !isBatchBindingName(tokens[0]) {
+				return nil, fmt.Errorf("line %d: invalid name %q (use $ followed by letters, digits, '_' or '-')", lineNo, tokens[0])
+			}
+			if bound[tokens[0]] {
+				return nil, fmt.Errorf("line %d: %s is already bound", lineNo, tokens[0])
+			}
+			if len(tokens) < 3 || tokens[2] != "create" {
+				return nil, fmt.Errorf("line %d: only 'create' can be bound to a name", lineNo)
+			}
+			op.bind = tokens[0]
+			tokens = tokens[2:]
+		}
 		switch tokens[0] {
 		case "close":
 			op.cmd = "close"
@@ -249,8 +322,29 @@ func parseBatchScript(r io.Reader) ([]batchOp, error) {
 				return nil, fmt.Errorf("line %d: unknown dep subcommand %q (want add|remove)", lineNo, tokens[1])
 			}
 			op.args = tokens[2:]
+		case "label":
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: 'label' requires a subcommand (add|remove)", lineNo)
+			}
+			switch tokens[1] {
+			case "add":
+				op.cmd = "label.add"
+			case "remove", "rm":
+				op.cmd = "label.remove"
+			default:
+				return nil, fmt.Errorf("line %d: unknown label subcommand %q (want add|remove)", lineNo, tokens[1])
+			}
+			op.args = tokens[2:]
 		default:
-			return nil, fmt.Errorf("line %d: unsupported batch command %q (supported: close, update, create, dep add, dep remove)", lineNo, tokens[0])
+			return nil, fmt.Errorf("line %d: unsupported batch command %q (supported: close, update, create, dep add, dep remove, label add, label remove)", lineNo, tokens[0])
+		}
+		for _, arg := range op.args[:min(batchIDArgs[op.cmd], len(op.args))] {
+			if strings.HasPrefix(arg, "$") && !bound[arg] {
+				return nil, fmt.Errorf("line %d: %s is not bound by an earlier create", lineNo, arg)
+			}
+		}
+		if op.bind != "" {
+			bound[op.bind] = true
 		}
 		ops = append(ops, op)
 	}
@@ -260,6 +354,21 @@ func parseBatchScript(r io.Reader) ([]batchOp, error) {
 	return ops, nil
 }
 
+// isBatchBindingName reports whether name is "$" followed by one or more
+// letters, digits, underscores, or hyphens.
+func isBatchBindingName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "$")
+	if !ok || rest == "" {
+		return false
+	}
+	for _, r := range rest {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
 // tokenizeBatchLine splits a line into whitespace-separated tokens with
 // support for double-quoted strings. Escape sequences inside quotes: \" and
 // \\. Anything else after a backslash inside quotes is treated literally.
@@ -325,7 +434,7 @@ func runBatchOp(ctx context.Context, tx storage.Transaction, op batchOp) (batchO
 			return result, fmt.Errorf("close requires <id>")
 		}
 		id := op.args[0]
-		reason := "Closed"
+		reason := types.DefaultCloseReason
 		if len(op.args) > 1 {
 			reason = strings.Join(op.args[1:], " ")
 		}
@@ -415,6 +524,25 @@ func runBatchOp(ctx context.Context, tx storage.Transaction, op batchOp) (batchO
 		}
 		result.Target = fmt.Sprintf("%s->%s", from, to)
 		return result, nil
+
+	case "label.add", "label.remove":
+		if len(op.args) < 2 {
+			return result, fmt.Errorf("%s requires <id> <label>", strings.Replace(op.cmd, ".", " ", 1))
+		}
+		id := op.args[0]
+		for _, label := range op.args[1:] {
+			var err error
+			if op.cmd == "label.add" {
+				err = tx.AddLabel(ctx, id, label, actorName)
+			} else {
+				err = tx.RemoveLabel(ctx, id, label, actorName)
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+		result.Target = id
+		return result, nil
 	}
 	return result, fmt.Errorf("internal: unhandled batch op %q", op.cmd)
 }
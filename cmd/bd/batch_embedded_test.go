@@ -22,10 +22,15 @@ func runBatchScriptInTx(t *testing.T, ctx context.Context, st storage.DoltStorag
 		return err
 	}
 	return st.RunInTransaction(ctx, "test: bd batch", func(tx storage.Transaction) error {
+		bound := make(map[string]string)
 		for _, op := range ops {
-			if _, err := runBatchOp(ctx, tx, op); err != nil {
+			res, err := runBatchOp(ctx, tx, op.withBindings(bound))
+			if err != nil {
 				return err
 			}
+			if op.bind != "" {
+				bound[op.bind] = res.Target
+			}
 		}
 		return nil
 	})
@@ -247,3 +252,65 @@ func TestBatch_RollbackTriggerStillFailsAtStorageLayer(t *testing.T) {
 		t.Fatal("expected AddDependency on missing IDs to fail; if not, rewrite TestBatch_RollbackOnError")
 	}
 }
+
+// TestBatch_BindingsAndLabels verifies that issues created earlier in a
+// script can be referenced by name and labeled in the same transaction.
+func TestBatch_BindingsAndLabels(t *testing.T) {
+	tmpDir := t.TempDir()
+	st := newTestStoreWithPrefix(t, filepath.Join(tmpDir, ".beads", "beads.db"), "tbb")
+	ctx := context.Background()
+
+	script := `$epic = create epic 1 "Auth rewrite"
+$task = create task 2 "Token refresh"
+dep add $task $epic parent-child
+label add $task backend security
+label remove $task security
+`
+	if err := runBatchScriptInTx(t, ctx, st, script); err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+
+	tasks, err := st.SearchIssues(ctx, "Token refresh", types.IssueFilter{})
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("SearchIssues: got %d issues, err %v", len(tasks), err)
+	}
+	task := tasks[0]
+	labels, err := st.GetLabels(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetLabels: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("labels = %v, want [backend]", labels)
+	}
+	deps, err := st.GetDependencies(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetDependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].Title != "Auth rewrite" {
+		t.Errorf("expected %s to depend on the new epic, got %v", task.ID, deps)
+	}
+}
+
+// TestBatch_BindingsRollBackOnError verifies that issues created and bound
+// earlier in a failing script do not survive the rollback.
+func TestBatch_BindingsRollBackOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	st := newTestStoreWithPrefix(t, filepath.Join(tmpDir, ".beads", "beads.db"), "tbr")
+	ctx := context.Background()
+
+	script := `$task = create task 2 "Orphan candidate"
+label add $task backend
+dep add $task tbr-missing
+`
+	if err := runBatchScriptInTx(t, ctx, st, script); err == nil {
+		t.Fatal("expected batch to fail on the missing dependency target")
+	}
+
+	issues, err := st.SearchIssues(ctx, "Orphan candidate", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected rollback to discard the created issue, found %d", len(issues))
+	}
+}
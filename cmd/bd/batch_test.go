@@ -190,6 +190,68 @@ func TestParseBatchScript_DepUnknownSubcommand(t *testing.T) {
 	}
 }
 
+func TestParseBatchScript_LabelOps(t *testing.T) {
+	ops, err := parseBatchScript(strings.NewReader("label add bd-1 a b\nlabel rm bd-1 c\n"))
+	if err != nil {
+		t.Fatalf("parseBatchScript: %v", err)
+	}
+	if len(ops) != 2 || ops[0].cmd != "label.add" || ops[1].cmd != "label.remove" {
+		t.Fatalf("unexpected ops: %+v", ops)
+	}
+	if got := strings.Join(ops[0].args, " "); got != "bd-1 a b" {
+		t.Errorf("label add args = %q", got)
+	}
+}
+
+func TestParseBatchScript_Bindings(t *testing.T) {
+	script := "$epic = create epic 1 Epic\n$t = create task 2 \"Costs $5\"\ndep add $t $epic parent-child\nclose $t \"done, saved $epic\"\n"
+	ops, err := parseBatchScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatalf("parseBatchScript: %v", err)
+	}
+	if ops[0].bind != "$epic" || ops[1].bind != "$t" || ops[2].bind != "" {
+		t.Errorf("unexpected bindings: %q %q %q", ops[0].bind, ops[1].bind, ops[2].bind)
+	}
+	if ops[0].cmd != "create" || ops[0].args[0] != "epic" {
+		t.Errorf("bound create not parsed as create: %+v", ops[0])
+	}
+
+	bound := map[string]string{"$epic": "bd-9", "$t": "bd-10"}
+	dep := ops[2].withBindings(bound)
+	if got := strings.Join(dep.args, " "); got != "bd-10 bd-9 parent-child" {
+		t.Errorf("dep args after binding = %q", got)
+	}
+	if ops[2].args[0] != "$t" {
+		t.Error("withBindings modified the original op")
+	}
+	closeOp := ops[3].withBindings(bound)
+	if closeOp.args[0] != "bd-10" || closeOp.args[1] != "done, saved $epic" {
+		t.Errorf("close args after binding = %q", closeOp.args)
+	}
+}
+
+func TestParseBatchScript_BindingErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"unbound", "close $x\n", "not bound"},
+		{"used before bound", "label add $x a\n$x = create task 1 X\n", "not bound"},
+		{"rebound", "$x = create task 1 X\n$x = create task 1 Y\n", "already bound"},
+		{"non-create", "$x = close bd-1\n", "only 'create'"},
+		{"bad name", "$a.b = create task 1 X\n", "invalid name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBatchScript(strings.NewReader(tt.script))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseBatchScript_UnterminatedQuote(t *testing.T) {
 	_, err := parseBatchScript(strings.NewReader(`create task 1 "oops`))
 	if err == nil {
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// blameFields are the issue fields reported by bd blame, in display order.
+var blameFields = []string{"title", "description", "design", "acceptance_criteria", "notes", "priority", "assignee"}
+
+// Sources of a field's blame.
+const (
+	blameSourceEvent   = "event"   // an audit event set the current value
+	blameSourceHistory = "history" // a Dolt commit changed it (e.g. sync, import, SQL)
+)
+
+// fieldBlame is the last change to one field.
+type fieldBlame struct {
+	Field  string    `json:"field"`
+	Actor  string    `json:"actor"`
+	At     time.Time `json:"at"`
+	Source string    `json:"source"`
+	Commit string    `json:"commit,omitempty"`
+	Event  string    `json:"event_type,omitempty"`
+}
+
+var blameCmd = &cobra.Command{
+	Use:     "blame <id>",
+	GroupID: "views",
+	Short:   "Show who last changed each field of an issue",
+	Long: `Show, for each field of an issue, the actor and time of its last change.
+
+Blame combines two sources:
+  event    The audit trail recorded by bd itself (create, update, edit)
+  history  Dolt commit history, for changes made without an event, such as
+           pulls from other clones, imports, or direct SQL
+
+An event wins when the value it set is still the current value; otherwise
+the field is blamed on the commit that last changed it. This untangles
+edits when several agents work on the same issue.
+
+Fields: title, description, design, acceptance_criteria, notes, priority,
+assignee.
+
+Examples:
+  bd blame bd-123
+  bd blame bd-123 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("failed to get issue %s: %v", id, err)
+		}
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			FatalErrorRespectJSON("failed to get events for %s: %v", id, err)
+		}
+		// Wisps and fresh databases have no Dolt history; events alone
+		// still give a useful answer.
+		history, err := store.History(ctx, id)
+		if err != nil {
+			history = nil
+		}
+
+		blame := blameIssueFields(issue, events, history)
+		if jsonOutput {
+			outputJSON(blame)
+			return
+		}
+
+		fmt.Printf("\n%s Blame for %s: %s\n\n", ui.RenderAccent("🔎"), issue.ID, issue.Title)
+		for _, b := range blame {
+			if b.Actor == "" && b.At.IsZero() {
+				fmt.Printf("  %-20s %s\n", b.Field, ui.RenderMuted("(unknown)"))
+				continue
+			}
+			origin := b.Event
+			if b.Source == blameSourceHistory {
+				origin = "commit " + truncateHash(b.Commit)
+			}
+			fmt.Printf("  %-20s %-16s %s  %s\n", b.Field, b.Actor,
+				b.At.Local().Format("2006-01-02 15:04:05"), ui.RenderMuted(origin))
+		}
+		fmt.Println()
+	},
+}
+
+// blameIssueFields attributes each blame field of issue to its last change.
+// events may be in any order; history is as returned by store.History.
+func blameIssueFields(issue *types.Issue, events []*types.Event, history []*storage.HistoryEntry) []fieldBlame {
+	fromEvents := blameFromEvents(events)
+	fromHistory := blameFromHistory(history)
+
+	result := make([]fieldBlame, 0, len(blameFields))
+	for _, field := range blameFields {
+		current := blameFieldValue(issue, field)
+		ev, hasEvent := fromEvents[field]
+		hist, hasHistory := fromHistory[field]
+
+		var b fieldBlame
+		switch {
+		// An event that set the current value explains it.
+		case hasEvent && ev.setValue && ev.value == current:
+			b = ev.fieldBlame
+		// Creation explains values no later commit changed.
+		case hasEvent && !ev.setValue && (!hasHistory || hist.first):
+			b = ev.fieldBlame
+		case hasHistory:
+			b = hist.fieldBlame
+		case hasEvent:
+			b = ev.fieldBlame
+		}
+		b.Field = field
+		result = append(result, b)
+	}
+	return result
+}
+
+// blameCandidate is one source's view of a field's last change.
+type blameCandidate struct {
+	fieldBlame
+	value    string
+	setValue bool // the event records the value it set
+	first    bool // the history change is the issue's first commit
+}
+
+// blameFromEvents returns, per field, the last event that touched it.
+// A created event touches every field without recording values.
+func blameFromEvents(events []*types.Event) map[string]blameCandidate {
+	// Walk oldest first so later events win. Timestamps have second
+	// precision, so ties put creation first and then fall back to the
+	// time-ordered event ID.
+	ordered := make([]*types.Event, len(events))
+	copy(ordered, events)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if (a.EventType == types.EventCreated) != (b.EventType == types.EventCreated) {
+			return a.EventType == types.EventCreated
+		}
+		return a.ID < b.ID
+	})
+
+	result := make(map[string]blameCandidate)
+	for _, e := range ordered {
+		base := fieldBlame{Actor: e.Actor, At: e.CreatedAt, Source: blameSourceEvent, Event: string(e.EventType)}
+		if e.EventType == types.EventCreated {
+			for _, field := range blameFields {
+				result[field] = blameCandidate{fieldBlame: base}
+			}
+			continue
+		}
+		if e.NewValue == nil || *e.NewValue == "" {
+			continue
+		}
+		var updates map[string]interface{}
+		if err := json.Unmarshal([]byte(*e.NewValue), &updates); err != nil {
+			continue
+		}
+		for _, field := range blameFields {
+			if v, ok := updates[field]; ok {
+				result[field] = blameCandidate{fieldBlame: base, value: blameEventValue(v), setValue: true}
+			}
+		}
+	}
+	return result
+}
+
+// blameFromHistory returns, per field, the commit where it last took a new
+// value. history is newest first, as returned by store.History.
+func blameFromHistory(history []*storage.HistoryEntry) map[string]blameCandidate {
+	result := make(map[string]blameCandidate)
+	var prev *types.Issue
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		if entry.Issue == nil {
+			continue
+		}
+		for _, field := range blameFields {
+			value := blameFieldValue(entry.Issue, field)
+			if prev != nil && blameFieldValue(prev, field) == value {
+				continue
+			}
+			result[field] = blameCandidate{
+				fieldBlame: fieldBlame{
+					Actor:  entry.Committer,
+					At:     entry.CommitDate,
+					Source: blameSourceHistory,
+					Commit: entry.CommitHash,
+				},
+				value: value,
+				first: prev == nil,
+			}
+		}
+		prev = entry.Issue
+	}
+	return result
+}
+
+// blameFieldValue renders one blame field of issue as text.
+func blameFieldValue(issue *types.Issue, field string) string {
+	switch field {
+	case "title":
+		return issue.Title
+	case "description":
+		return issue.Description
+	case "design":
+		return issue.Design
+	case "acceptance_criteria":
+		return issue.AcceptanceCriteria
+	case "notes":
+		return issue.Notes
+	case "priority":
+		return strconv.Itoa(issue.Priority)
+	case "assignee":
+		return issue.Assignee
+	}
+	return ""
+}
+
+// blameEventValue renders a value decoded from an event's update map.
+func blameEventValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func init() {
+	blameCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(blameCmd)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBlameIssueFields(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+
+	current := &types.Issue{ID: "bd-1", Title: "Synced title", Description: "desc", Priority: 0, Notes: "agent notes"}
+	events := []*types.Event{
+		// Newest first, as GetEvents returns them; the first two share a second.
+		{ID: "e3", EventType: types.EventUpdated, Actor: "bob", CreatedAt: t0.Add(time.Minute), NewValue: str(`{"priority":0,"notes":"agent notes"}`)},
+		{ID: "e2", EventType: types.EventUpdated, Actor: "alice", CreatedAt: t0, NewValue: str(`{"title":"Alice title"}`)},
+		{ID: "e1", EventType: types.EventCreated, Actor: "carol", CreatedAt: t0},
+	}
+	history := []*storage.HistoryEntry{
+		{CommitHash: "c3", Committer: "sync", CommitDate: t0.Add(2 * time.Minute), Issue: &types.Issue{Title: "Synced title", Description: "desc", Notes: "agent notes"}},
+		{CommitHash: "c2", Committer: "root", CommitDate: t0.Add(time.Minute), Issue: &types.Issue{Title: "Alice title", Description: "desc", Notes: "agent notes"}},
+		{CommitHash: "c1", Committer: "root", CommitDate: t0, Issue: &types.Issue{Title: "First", Description: "desc", Priority: 2}},
+	}
+
+	got := make(map[string]fieldBlame)
+	for _, b := range blameIssueFields(current, events, history) {
+		got[b.Field] = b
+	}
+
+	tests := []struct {
+		field, actor, source string
+	}{
+		{"title", "sync", blameSourceHistory},      // changed after alice's event, without one
+		{"description", "carol", blameSourceEvent}, // unchanged since creation
+		{"notes", "bob", blameSourceEvent},
+		{"priority", "bob", blameSourceEvent}, // numeric value decoded from JSON
+		{"design", "carol", blameSourceEvent},
+	}
+	for _, tt := range tests {
+		b := got[tt.field]
+		if b.Actor != tt.actor || b.Source != tt.source {
+			t.Errorf("%s: blamed on %s (%s), want %s (%s)", tt.field, b.Actor, b.Source, tt.actor, tt.source)
+		}
+	}
+	if got["title"].Commit != "c3" {
+		t.Errorf("title commit = %q, want c3", got["title"].Commit)
+	}
+}
+
+func TestBlameIssueFields_EventsOnly(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	title := `{"title":"Renamed"}`
+	events := []*types.Event{
+		{ID: "e1", EventType: types.EventCreated, Actor: "carol", CreatedAt: t0},
+		{ID: "e2", EventType: types.EventUpdated, Actor: "alice", CreatedAt: t0, NewValue: &title},
+	}
+
+	blame := blameIssueFields(&types.Issue{Title: "Renamed"}, events, nil)
+	if blame[0].Field != "title" || blame[0].Actor != "alice" {
+		t.Errorf("title blamed on %q, want alice (creation must not win a same-second tie)", blame[0].Actor)
+	}
+}
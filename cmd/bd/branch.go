@@ -1,78 +1,436 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
+// doltBranchFile records the Dolt branch this clone works on when it is not
+// main. Like the database itself it is per-clone state, so it is gitignored.
+const doltBranchFile = "dolt-branch"
+
+const defaultDoltBranch = "main"
+
 var branchCmd = &cobra.Command{
 	Use:     "branch [name]",
 	GroupID: "sync",
-	Short:   "List or create branches",
-	Long: `List all branches or create a new branch.
+	Short:   "List, create, switch, diff, and merge branches",
+	Long: `Work with Dolt branches of the issue database.
+
+Branches let you stage a large reorganization — re-parenting, bulk
+closes, priority sweeps — without touching main, review it with
+'bd branch diff', and land it with 'bd branch merge'.
 
-This command requires the Dolt storage backend. Without arguments,
-it lists all branches. With an argument, it creates a new branch.
+Without arguments, lists all branches. With a single argument, creates a
+new branch (same as 'bd branch create').
+
+'bd branch switch' makes every later bd command in this clone work on the
+chosen branch, including auto-export and 'bd dolt push'. Switching is
+per-clone and only available in embedded mode; a shared Dolt server
+always works on main.
+
+This command requires the Dolt storage backend.
 
 Examples:
-  bd branch                    # List all branches
-  bd branch feature-xyz        # Create a new branch named feature-xyz`,
+  bd branch                      # List all branches
+  bd branch create reorg         # Create branch reorg from the current branch
+  bd branch switch reorg         # Work on reorg from now on
+  bd branch diff reorg           # Compare the current branch with reorg
+  bd branch merge reorg          # Merge reorg after conflict and doctor checks
+  bd branch switch main          # Go back to main`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		ctx := rootCtx
-
-		// If no args, list branches
 		if len(args) == 0 {
-			branches, err := store.ListBranches(ctx)
-			if err != nil {
-				FatalErrorRespectJSON("failed to list branches: %v", err)
-			}
-
-			currentBranch, err := store.CurrentBranch(ctx)
-			if err != nil {
-				// Non-fatal, just don't show current marker
-				currentBranch = ""
-			}
-
-			if jsonOutput {
-				outputJSON(map[string]interface{}{
-					"current":  currentBranch,
-					"branches": branches,
-				})
-				return
-			}
-
-			fmt.Printf("\n%s Branches:\n\n", ui.RenderAccent("🌿"))
-			for _, branch := range branches {
-				if branch == currentBranch {
-					fmt.Printf("  * %s\n", ui.StatusInProgressStyle.Render(branch))
-				} else {
-					fmt.Printf("    %s\n", branch)
-				}
-			}
-			fmt.Println()
+			runBranchList()
 			return
 		}
+		runBranchCreate(args[0])
+	},
+}
+
+var branchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all branches",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runBranchList()
+	},
+}
+
+var branchCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a branch from the current branch",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runBranchCreate(args[0])
+	},
+}
+
+var branchSwitchCmd = &cobra.Command{
+	Use:   "switch <name>",
+	Short: "Work on another branch",
+	Long: `Make later bd commands in this clone read and write the named branch.
+
+The choice is saved in .beads/dolt-branch (gitignored). Switch back with
+'bd branch switch main'. Only available in embedded mode.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		name := args[0]
+		if usesSQLServer() {
+			FatalErrorWithHintRespectJSON("switching branches is only supported in embedded mode",
+				"a shared Dolt server always works on main; use 'bd branch diff' and 'bd branch merge' from there")
+		}
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			FatalErrorRespectJSON("%s", activeWorkspaceNotFoundError())
+		}
 
-		// Create new branch
-		branchName := args[0]
-		if err := store.Branch(ctx, branchName); err != nil {
-			FatalErrorRespectJSON("failed to create branch: %v", err)
+		branches, err := store.ListBranches(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("failed to list branches: %v", err)
+		}
+		if !slices.Contains(branches, name) {
+			FatalErrorWithHintRespectJSON(fmt.Sprintf("branch %q does not exist", name),
+				fmt.Sprintf("create it with 'bd branch create %s'", name))
+		}
+		previous, _ := store.CurrentBranch(ctx)
+		if err := setActiveDoltBranch(beadsDir, name); err != nil {
+			FatalErrorRespectJSON("failed to record active branch: %v", err)
 		}
 
 		if jsonOutput {
 			outputJSON(map[string]interface{}{
-				"created": branchName,
+				"previous": previous,
+				"current":  name,
 			})
 			return
 		}
+		if previous == name {
+			fmt.Printf("Already on branch: %s\n", ui.RenderAccent(name))
+			return
+		}
+		fmt.Printf("Switched to branch: %s\n", ui.RenderAccent(name))
+	},
+}
 
-		fmt.Printf("Created branch: %s\n", ui.RenderAccent(branchName))
+var branchDiffCmd = &cobra.Command{
+	Use:   "diff <branch>",
+	Short: "Show how a branch differs from the current branch",
+	Long: `Show the issues added, modified, or removed on <branch> relative to the
+current branch. Equivalent to 'bd diff <current> <branch>'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		current, err := store.CurrentBranch(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("failed to get current branch: %v", err)
+		}
+		entries, err := store.Diff(ctx, current, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("failed to get diff: %v", err)
+		}
+		printIssueDiff(current, args[0], entries)
 	},
 }
 
+var (
+	branchMergeStrategy string
+	branchMergeDryRun   bool
+)
+
+var branchMergeCmd = &cobra.Command{
+	Use:   "merge <branch>",
+	Short: "Merge a branch into the current branch after conflict and doctor checks",
+	Long: `Merge <branch> into the current branch.
+
+The merge is staged first and committed only if it passes:
+
+  1. Conflict detection. Conflicts bd can settle on its own (machine-local
+     metadata, audit-only dependency rows) are resolved automatically. Any
+     other conflict aborts the merge unless --strategy picks a side.
+  2. A doctor pass over the merged result. The merge must not introduce a
+     dependency cycle, and every issue it touched must still be valid
+     (title, priority, status and type, closed_at consistency).
+
+If either check fails the merge is aborted and the current branch is left
+exactly as it was. Use --dry-run to run both checks without committing.
+For a raw merge without the checks, use 'bd vc merge'.
+
+Examples:
+  bd branch merge reorg                    # Merge reorg into the current branch
+  bd branch merge reorg --dry-run          # Check that reorg would merge cleanly
+  bd branch merge reorg --strategy theirs  # Take reorg's side of any conflict`,
+	Args: cobra.ExactArgs(1),
+	Run:  runBranchMerge,
+}
+
 func init() {
+	branchMergeCmd.Flags().StringVar(&branchMergeStrategy, "strategy", "", "Resolve conflicts with 'ours' or 'theirs' instead of aborting")
+	branchMergeCmd.Flags().BoolVar(&branchMergeDryRun, "dry-run", false, "Run conflict detection and the doctor pass, then abort")
+
+	branchCmd.AddCommand(branchListCmd)
+	branchCmd.AddCommand(branchCreateCmd)
+	branchCmd.AddCommand(branchSwitchCmd)
+	branchCmd.AddCommand(branchDiffCmd)
+	branchCmd.AddCommand(branchMergeCmd)
 	rootCmd.AddCommand(branchCmd)
 }
+
+func runBranchList() {
+	ctx := rootCtx
+	branches, err := store.ListBranches(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to list branches: %v", err)
+	}
+
+	currentBranch, err := store.CurrentBranch(ctx)
+	if err != nil {
+		// Non-fatal, just don't show current marker
+		currentBranch = ""
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"current":  currentBranch,
+			"branches": branches,
+		})
+		return
+	}
+
+	fmt.Printf("\n%s Branches:\n\n", ui.RenderAccent("🌿"))
+	for _, branch := range branches {
+		if branch == currentBranch {
+			fmt.Printf("  * %s\n", ui.StatusInProgressStyle.Render(branch))
+		} else {
+			fmt.Printf("    %s\n", branch)
+		}
+	}
+	fmt.Println()
+}
+
+func runBranchCreate(branchName string) {
+	if err := store.Branch(rootCtx, branchName); err != nil {
+		FatalErrorRespectJSON("failed to create branch: %v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"created": branchName,
+		})
+		return
+	}
+
+	fmt.Printf("Created branch: %s\n", ui.RenderAccent(branchName))
+}
+
+func runBranchMerge(cmd *cobra.Command, args []string) {
+	CheckReadonly("branch merge")
+	ctx := rootCtx
+	source := args[0]
+	if branchMergeStrategy != "" && branchMergeStrategy != "ours" && branchMergeStrategy != "theirs" {
+		FatalErrorRespectJSON("invalid --strategy %q (valid: ours, theirs)", branchMergeStrategy)
+	}
+	merger, ok := storage.UnwrapStore(store).(storage.StagedMerger)
+	if !ok {
+		FatalErrorWithHintRespectJSON("this storage backend cannot stage a merge", "use 'bd vc merge' for a merge without checks")
+	}
+	current, err := store.CurrentBranch(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to get current branch: %v", err)
+	}
+	if source == current {
+		FatalErrorRespectJSON("cannot merge branch %s into itself", source)
+	}
+
+	// Cycles already present are reported by bd doctor; the merge is only
+	// held to not adding new ones.
+	cyclesBefore, err := store.DetectCycles(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to check dependency cycles: %v", err)
+	}
+	// Pre-merge HEAD scopes the post-merge is_blocked recompute (bd-578h9.11).
+	preHead, _ := store.GetCurrentCommit(ctx)
+
+	err = merger.StageMerge(ctx, source, branchMergeStrategy)
+	if errors.Is(err, storage.ErrNothingToMerge) {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"merged": false, "branch": source, "into": current, "up_to_date": true})
+			return
+		}
+		fmt.Printf("Already up to date with %s\n", ui.RenderAccent(source))
+		return
+	}
+	var conflictErr *versioncontrolops.MergeConflictsError
+	if errors.As(err, &conflictErr) {
+		tables := make([]string, len(conflictErr.Conflicts))
+		for i, c := range conflictErr.Conflicts {
+			tables[i] = c.Field
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"merged": false, "branch": source, "into": current, "conflicts": tables})
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Merge of %s aborted: conflicts in %s\n", source, strings.Join(tables, ", "))
+		fmt.Fprintf(os.Stderr, "Nothing was changed. Retry with --strategy ours or --strategy theirs to pick a side.\n")
+		os.Exit(1)
+	}
+	if err != nil {
+		FatalErrorRespectJSON("failed to merge %s: %v", source, err)
+	}
+
+	problems, err := branchMergeChecks(ctx, store, cyclesBefore)
+	if err != nil || len(problems) > 0 || branchMergeDryRun {
+		if aerr := merger.AbortMerge(ctx); aerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to abort merge: %v\n", aerr)
+		}
+	}
+	if err != nil {
+		FatalErrorRespectJSON("merge check failed: %v", err)
+	}
+	if len(problems) > 0 {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"merged": false, "branch": source, "into": current, "problems": problems})
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Merge of %s aborted: the merged result failed %d check(s):\n", source, len(problems))
+		for _, p := range problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", p)
+		}
+		fmt.Fprintf(os.Stderr, "Nothing was changed. Fix these on %s, then merge again.\n", source)
+		os.Exit(1)
+	}
+	if branchMergeDryRun {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"merged": false, "branch": source, "into": current, "dry_run": true})
+			return
+		}
+		fmt.Printf("%s would merge cleanly into %s (dry run, nothing committed)\n", ui.RenderAccent(source), current)
+		return
+	}
+
+	if err := store.Commit(ctx, fmt.Sprintf("bd branch merge: %s into %s", source, current)); err != nil {
+		if aerr := merger.AbortMerge(ctx); aerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to abort merge: %v\n", aerr)
+		}
+		FatalErrorRespectJSON("failed to commit merge: %v", err)
+	}
+	// The merged-in writes bypassed every is_blocked hook (bd-578h9.11).
+	if rs, ok := storage.UnwrapStore(store).(interface {
+		RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
+	}); ok {
+		if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
+			FatalErrorRespectJSON("merge committed but is_blocked recompute failed: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"merged": true, "branch": source, "into": current})
+		return
+	}
+	fmt.Printf("Merged %s into %s\n", ui.RenderAccent(source), current)
+}
+
+// branchMergeChecks is the doctor pass a staged merge must clear before it is
+// committed: it may not add a dependency cycle, and every issue it touched
+// must still validate.
+func branchMergeChecks(ctx context.Context, s storage.DoltStorage, cyclesBefore [][]*types.Issue) ([]string, error) {
+	cyclesAfter, err := s.DetectCycles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("detect cycles: %w", err)
+	}
+	problems := newDependencyCycles(cyclesBefore, cyclesAfter)
+
+	entries, err := s.Diff(ctx, "HEAD", "WORKING")
+	if err != nil {
+		return nil, fmt.Errorf("list merged issues: %w", err)
+	}
+	customStatuses, _ := s.GetCustomStatuses(ctx)
+	customTypes, _ := s.GetCustomTypes(ctx)
+	for _, entry := range entries {
+		if entry.DiffType == "removed" {
+			continue
+		}
+		issue, err := s.GetIssue(ctx, entry.IssueID)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.IssueID, err))
+			continue
+		}
+		if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", entry.IssueID, err))
+		}
+	}
+	return problems, nil
+}
+
+// newDependencyCycles describes the cycles in after that were not in before.
+// A cycle is identified by its set of issues, wherever DetectCycles starts it.
+func newDependencyCycles(before, after [][]*types.Issue) []string {
+	cycleKey := func(cycle []*types.Issue) string {
+		ids := make([]string, len(cycle))
+		for i, issue := range cycle {
+			ids[i] = issue.ID
+		}
+		sort.Strings(ids)
+		return strings.Join(ids, ",")
+	}
+	seen := make(map[string]bool, len(before))
+	for _, cycle := range before {
+		seen[cycleKey(cycle)] = true
+	}
+	var problems []string
+	for _, cycle := range after {
+		key := cycleKey(cycle)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ids := make([]string, 0, len(cycle)+1)
+		for _, issue := range cycle {
+			ids = append(ids, issue.ID)
+		}
+		if len(cycle) > 0 {
+			ids = append(ids, cycle[0].ID)
+		}
+		problems = append(problems, "new dependency cycle: "+strings.Join(ids, " → "))
+	}
+	return problems
+}
+
+// activeDoltBranch returns the branch embedded-mode stores open on.
+func activeDoltBranch(beadsDir string) string {
+	data, err := os.ReadFile(filepath.Join(beadsDir, doltBranchFile)) //nolint:gosec // path is constructed internally
+	if err != nil {
+		return defaultDoltBranch
+	}
+	if branch := strings.TrimSpace(string(data)); branch != "" {
+		return branch
+	}
+	return defaultDoltBranch
+}
+
+// setActiveDoltBranch records branch as the one later commands open on.
+// Switching back to main removes the file.
+func setActiveDoltBranch(beadsDir, branch string) error {
+	path := filepath.Join(beadsDir, doltBranchFile)
+	if branch == defaultDoltBranch {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(branch+"\n"), 0o600)
+}
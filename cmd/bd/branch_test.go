@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestActiveDoltBranch(t *testing.T) {
+	beadsDir := t.TempDir()
+	if got := activeDoltBranch(beadsDir); got != "main" {
+		t.Fatalf("default branch = %q, want main", got)
+	}
+	if err := setActiveDoltBranch(beadsDir, "reorg"); err != nil {
+		t.Fatal(err)
+	}
+	if got := activeDoltBranch(beadsDir); got != "reorg" {
+		t.Errorf("after switch = %q, want reorg", got)
+	}
+	if err := setActiveDoltBranch(beadsDir, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if got := activeDoltBranch(beadsDir); got != "main" {
+		t.Errorf("after switching back = %q, want main", got)
+	}
+	// Switching to main twice is not an error.
+	if err := setActiveDoltBranch(beadsDir, "main"); err != nil {
+		t.Errorf("second switch to main: %v", err)
+	}
+}
+
+func TestNewDependencyCycles(t *testing.T) {
+	cycle := func(ids ...string) []*types.Issue {
+		issues := make([]*types.Issue, len(ids))
+		for i, id := range ids {
+			issues[i] = &types.Issue{ID: id}
+		}
+		return issues
+	}
+	before := [][]*types.Issue{cycle("bd-1", "bd-2")}
+	after := [][]*types.Issue{
+		cycle("bd-2", "bd-1"), // same cycle, different start
+		cycle("bd-3", "bd-4", "bd-5"),
+		cycle("bd-4", "bd-5", "bd-3"), // reported once
+	}
+	want := []string{"new dependency cycle: bd-3 → bd-4 → bd-5 → bd-3"}
+	if got := newDependencyCycles(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("newDependencyCycles = %q, want %q", got, want)
+	}
+	if got := newDependencyCycles(before, before); len(got) != 0 {
+		t.Errorf("unchanged cycles reported: %q", got)
+	}
+}
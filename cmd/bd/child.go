@@ -0,0 +1,454 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var childCmd = &cobra.Command{
+	Use:     "child",
+	GroupID: "issues",
+	Short:   "Manage hierarchical child IDs (parent.N)",
+	Long: `Manage hierarchical children, whose IDs extend their parent's
+(bd-a3f8 -> bd-a3f8.1 -> bd-a3f8.1.1).
+
+Child numbers come from the child_counters table, which is advanced in the
+same transaction that hands a number out, so concurrent agents never receive
+the same ID. Nesting is limited by hierarchy.max-depth (default 3).
+
+Examples:
+  bd child add bd-a3f8 "Write migration"
+  bd child reorder bd-a3f8 bd-a3f8.3 bd-a3f8.1
+  bd child counters --repair`,
+}
+
+var childAddCmd = &cobra.Command{
+	Use:   "add <parent-id> <title>",
+	Short: "Create the next child of a parent",
+	Long: `Create an issue with the parent's next child ID (<parent>.N) and a
+parent-child dependency on the parent.
+
+The parent's labels are inherited unless --no-inherit-labels is set. This is
+equivalent to 'bd create --parent' with a smaller set of flags.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("child add")
+		ctx := rootCtx
+
+		parentID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		title := strings.Join(args[1:], " ")
+
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		priority, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issueType, _ := cmd.Flags().GetString("type")
+		description, _ := cmd.Flags().GetString("description")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+
+		if err := checkChildDepth(parentID); err != nil {
+			FatalErrorWithHint(err.Error(),
+				fmt.Sprintf("Raise hierarchy.max-depth (currently %d) or choose a shallower parent", maxHierarchyDepth()))
+		}
+		if _, err := store.GetIssue(ctx, parentID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				FatalErrorRespectJSON("parent issue %s not found", parentID)
+			}
+			FatalErrorRespectJSON("failed to check parent issue: %v", err)
+		}
+		if noInherit, _ := cmd.Flags().GetBool("no-inherit-labels"); !noInherit {
+			inherited, _ := store.GetLabels(ctx, parentID)
+			labels = mergeCreateLabels(labels, inherited)
+		} else {
+			labels = mergeCreateLabels(labels, nil)
+		}
+
+		childID, err := store.GetNextChildID(ctx, parentID)
+		if err != nil {
+			FatalErrorRespectJSON("failed to allocate child ID: %v", err)
+		}
+		createCtx := storage.WithReservedChildCounter(ctx, parentID, childID)
+
+		issue := &types.Issue{
+			ID:          childID,
+			Title:       title,
+			Description: description,
+			Status:      types.StatusOpen,
+			Priority:    priority,
+			IssueType:   types.IssueType(issueType).Normalize(),
+			Assignee:    assignee,
+			Labels:      labels,
+		}
+		err = transactHonoringAutoCommit(createCtx, store, fmt.Sprintf("bd: create %s", childID), func(tx storage.Transaction) error {
+			if err := tx.CreateIssue(createCtx, issue, actor); err != nil {
+				return err
+			}
+			return tx.AddDependency(createCtx, &types.Dependency{
+				IssueID:     childID,
+				DependsOnID: parentID,
+				Type:        types.DepParentChild,
+			}, actor)
+		})
+		if err != nil {
+			FatalErrorRespectJSON("failed to create %s: %v", childID, err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(issue)
+			return
+		}
+		fmt.Printf("%s Created issue: %s\n", ui.RenderPass("✓"), formatFeedbackID(issue.ID, issue.Title))
+	},
+}
+
+var childReorderCmd = &cobra.Command{
+	Use:   "reorder <parent-id> [child-id...]",
+	Short: "Renumber a parent's children",
+	Long: `Renumber a parent's direct children to <parent>.1 .. <parent>.N.
+
+Children named on the command line come first, in the order given; the rest
+follow in their current order. With no children named, reorder just closes
+gaps left by deleted children. Grandchildren move with their parent
+(bd-a3f8.3.1 -> bd-a3f8.1.1).
+
+All renames happen in one transaction. Dependencies, references, and
+mentions of the old IDs in issue text are updated, and each renamed issue
+records a 'renamed' event. The child counter is never lowered, so numbers
+that are freed are not handed out again.
+
+Renumbering changes IDs that may appear in commit messages, branch names,
+or other clones; use --dry-run to preview.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("child reorder")
+		}
+		ctx := rootCtx
+
+		parentID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		order := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			order = append(order, id)
+		}
+
+		descendants, err := store.SearchIssues(ctx, "", types.IssueFilter{IDPrefix: parentID + "."})
+		if err != nil {
+			FatalErrorRespectJSON("failed to list children of %s: %v", parentID, err)
+		}
+		ids := make([]string, 0, len(descendants))
+		for _, issue := range descendants {
+			ids = append(ids, issue.ID)
+		}
+		renames, lastChild, err := planChildReorder(parentID, ids, order)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		changes := childRenameList(renames)
+
+		if !dryRun && len(changes) > 0 {
+			if err := childCounterStore(store).RenumberChildren(ctx, parentID, renames, lastChild, actor); err != nil {
+				FatalErrorRespectJSON("failed to renumber children of %s: %v", parentID, err)
+			}
+			commandDidWrite.Store(true)
+			if err := rewriteIDReferences(ctx, store, renames, actor); err != nil {
+				WarnError("failed to update some references: %v", err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"parent":  parentID,
+				"dry_run": dryRun,
+				"renames": changes,
+			})
+			return
+		}
+		if len(changes) == 0 {
+			fmt.Printf("%s Children of %s are already in order\n", ui.RenderPass("✓"), parentID)
+			return
+		}
+		for _, c := range changes {
+			fmt.Printf("  %s -> %s\n", ui.RenderWarn(c.Old), ui.RenderAccent(c.New))
+		}
+		if dryRun {
+			fmt.Printf("\nWould rename %d issue(s); run without --dry-run to apply\n", len(changes))
+			return
+		}
+		fmt.Printf("\n%s Renamed %d issue(s) under %s\n", ui.RenderPass("✓"), len(changes), parentID)
+	},
+}
+
+var childCountersCmd = &cobra.Command{
+	Use:   "counters",
+	Short: "Check child counters against existing children",
+	Long: `Compare the child_counters table with the children that exist.
+
+A counter has drifted when it trails an existing child (for example after
+an import or a direct SQL insert with an explicit ID) or when its parent no
+longer exists. Allocation still skips taken numbers, but drift means the
+table can no longer be trusted on its own. 'bd doctor' reports the same
+drift.
+
+--repair raises trailing counters to the highest existing child and deletes
+counters of missing parents. Counters ahead of the existing children are
+normal (children were deleted) and are left alone.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		repair, _ := cmd.Flags().GetBool("repair")
+		showAll, _ := cmd.Flags().GetBool("all")
+		if repair {
+			CheckReadonly("child counters --repair")
+		}
+		ctx := rootCtx
+		cs := childCounterStore(store)
+
+		statuses, err := cs.ChildCounterStatus(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("failed to read child counters: %v", err)
+		}
+		drifted := make([]*types.ChildCounterStatus, 0)
+		for _, s := range statuses {
+			if s.Behind() || s.Orphaned() {
+				drifted = append(drifted, s)
+			}
+		}
+		repaired := 0
+		if repair && len(drifted) > 0 {
+			repaired, err = cs.RepairChildCounters(ctx)
+			if err != nil {
+				FatalErrorRespectJSON("failed to repair child counters: %v", err)
+			}
+			commandDidWrite.Store(true)
+		}
+
+		listed := drifted
+		if showAll {
+			listed = statuses
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"counters": listed,
+				"drifted":  len(drifted),
+				"repaired": repaired,
+			})
+			return
+		}
+		for _, s := range listed {
+			fmt.Printf("  %-24s counter %-4d max child %-4d %s\n", s.ParentID, s.LastChild, s.MaxChild, describeChildCounter(s))
+		}
+		switch {
+		case len(drifted) == 0:
+			fmt.Printf("%s %d child counter(s), no drift\n", ui.RenderPass("✓"), len(statuses))
+		case repair:
+			fmt.Printf("%s Repaired %d child counter(s)\n", ui.RenderPass("✓"), repaired)
+		default:
+			fmt.Printf("%s %d child counter(s) drifted; run 'bd child counters --repair'\n", ui.RenderWarn("!"), len(drifted))
+		}
+	},
+}
+
+// maxHierarchyDepth returns the configured hierarchy.max-depth.
+func maxHierarchyDepth() int {
+	return config.GetInt("hierarchy.max-depth")
+}
+
+// checkChildDepth rejects a new child of parentID that would nest deeper
+// than hierarchy.max-depth.
+func checkChildDepth(parentID string) error {
+	return types.CheckHierarchyDepth(parentID, maxHierarchyDepth())
+}
+
+// childCounterStore returns the store's child counter support, exiting when
+// the backend has none.
+func childCounterStore(s storage.DoltStorage) storage.ChildCounterStore {
+	cs, ok := storage.UnwrapStore(s).(storage.ChildCounterStore)
+	if !ok {
+		FatalErrorRespectJSON("child counters are not supported by this storage backend")
+	}
+	return cs
+}
+
+// describeChildCounter explains a counter's state for bd child counters.
+func describeChildCounter(s *types.ChildCounterStatus) string {
+	switch {
+	case s.Orphaned():
+		return ui.RenderWarn("parent missing")
+	case s.Behind() && !s.HasCounter:
+		return ui.RenderWarn("no counter")
+	case s.Behind():
+		return ui.RenderWarn("behind")
+	}
+	return ui.RenderMuted("ok")
+}
+
+// planChildReorder maps the descendants of parentID (ids, at any depth) to
+// their IDs after renumbering the direct children 1..N: those in order
+// first, then the rest by current number. Unchanged IDs are omitted. It
+// also returns N. Direct children with non-numeric suffixes keep their IDs.
+func planChildReorder(parentID string, ids, order []string) (map[string]string, int, error) {
+	prefix := parentID + "."
+	number := make(map[string]int)
+	for _, id := range ids {
+		suffix, ok := strings.CutPrefix(id, prefix)
+		if !ok || strings.Contains(suffix, ".") {
+			continue
+		}
+		if n, err := strconv.Atoi(suffix); err == nil {
+			number[id] = n
+		}
+	}
+
+	seen := make(map[string]bool, len(order))
+	sequence := make([]string, 0, len(number))
+	for _, id := range order {
+		if _, ok := number[id]; !ok {
+			return nil, 0, fmt.Errorf("%s is not a numbered child of %s", id, parentID)
+		}
+		if seen[id] {
+			return nil, 0, fmt.Errorf("%s is listed more than once", id)
+		}
+		seen[id] = true
+		sequence = append(sequence, id)
+	}
+	rest := make([]string, 0, len(number)-len(sequence))
+	for id := range number {
+		if !seen[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return number[rest[i]] < number[rest[j]] })
+	sequence = append(sequence, rest...)
+
+	renames := make(map[string]string)
+	for i, child := range sequence {
+		newChild := fmt.Sprintf("%s%d", prefix, i+1)
+		if newChild == child {
+			continue
+		}
+		for _, id := range ids {
+			if id == child || strings.HasPrefix(id, child+".") {
+				renames[id] = newChild + id[len(child):]
+			}
+		}
+	}
+	return renames, len(sequence), nil
+}
+
+// childRename is one entry of a reorder plan.
+type childRename struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// childRenameList returns renames sorted by old ID.
+func childRenameList(renames map[string]string) []childRename {
+	list := make([]childRename, 0, len(renames))
+	for oldID, newID := range renames {
+		list = append(list, childRename{Old: oldID, New: newID})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Old < list[j].Old })
+	return list
+}
+
+// rewriteIDReferences replaces mentions of renamed IDs in the text fields of
+// every issue. All renames apply in a single pass, so IDs that were swapped
+// are not rewritten twice.
+func rewriteIDReferences(ctx context.Context, s storage.DoltStorage, renames map[string]string, actor string) error {
+	pattern := idReferencePattern(renames)
+	if pattern == nil {
+		return nil
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to list issues: %w", err)
+	}
+	replace := func(text string) string {
+		return pattern.ReplaceAllStringFunc(text, func(id string) string { return renames[id] })
+	}
+	for _, issue := range issues {
+		updates := make(map[string]interface{})
+		for field, text := range map[string]string{
+			"title":               issue.Title,
+			"description":         issue.Description,
+			"design":              issue.Design,
+			"notes":               issue.Notes,
+			"acceptance_criteria": issue.AcceptanceCriteria,
+		} {
+			if updated := replace(text); updated != text {
+				updates[field] = updated
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if err := s.UpdateIssue(ctx, issue.ID, updates, actor); err != nil {
+			return fmt.Errorf("failed to update references in %s: %w", issue.ID, err)
+		}
+	}
+	return nil
+}
+
+// idReferencePattern matches any old ID in renames as a whole word, longest
+// first so bd-a.1.2 is not read as bd-a.1 followed by ".2".
+func idReferencePattern(renames map[string]string) *regexp.Regexp {
+	if len(renames) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(renames))
+	for id := range renames {
+		ids = append(ids, regexp.QuoteMeta(id))
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) > len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+	return regexp.MustCompile(`\b(?:` + strings.Join(ids, "|") + `)\b`)
+}
+
+func init() {
+	childAddCmd.Flags().StringP("priority", "p", "2", "Priority (0-4 or P0-P4)")
+	childAddCmd.Flags().StringP("type", "t", "task", "Issue type")
+	childAddCmd.Flags().StringP("description", "d", "", "Issue description")
+	childAddCmd.Flags().StringP("assignee", "a", "", "Assignee")
+	childAddCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels")
+	childAddCmd.Flags().Bool("no-inherit-labels", false, "Don't inherit labels from the parent issue")
+	childAddCmd.ValidArgsFunction = issueIDCompletion
+
+	childReorderCmd.Flags().Bool("dry-run", false, "Show the renames without applying them")
+	childReorderCmd.ValidArgsFunction = issueIDCompletion
+
+	childCountersCmd.Flags().Bool("repair", false, "Fix drifted counters")
+	childCountersCmd.Flags().Bool("all", false, "List every counter, not just drifted ones")
+
+	childCmd.AddCommand(childAddCmd, childReorderCmd, childCountersCmd)
+	rootCmd.AddCommand(childCmd)
+}
@@ -0,0 +1,76 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedChild(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ch")
+
+	parent := bdCreate(t, bd, dir, "Parent", "--type", "epic")
+
+	t.Run("add_allocates_sequential_children", func(t *testing.T) {
+		for i, title := range []string{"First", "Second", "Third"} {
+			out := bdCommand(t, bd, dir, "child", "add", parent.ID, title)
+			want := parent.ID + "." + string(rune('1'+i))
+			if !strings.Contains(out, want) {
+				t.Fatalf("child add %q: expected %s in output:\n%s", title, want, out)
+			}
+		}
+		bdCommand(t, bd, dir, "child", "add", parent.ID+".3", "Grandchild")
+
+		out := bdCommand(t, bd, dir, "children", parent.ID)
+		if !strings.Contains(out, parent.ID+".2") {
+			t.Errorf("expected %s.2 among children of %s:\n%s", parent.ID, parent.ID, out)
+		}
+	})
+
+	t.Run("reorder_moves_children_and_descendants", func(t *testing.T) {
+		bdCommand(t, bd, dir, "update", parent.ID+".1", "--description", "after "+parent.ID+".3")
+		bdCommand(t, bd, dir, "child", "reorder", parent.ID, parent.ID+".3")
+
+		for id, title := range map[string]string{
+			parent.ID + ".1":   "Third",
+			parent.ID + ".1.1": "Grandchild",
+			parent.ID + ".2":   "First",
+			parent.ID + ".3":   "Second",
+		} {
+			if got := bdShow(t, bd, dir, id).Title; got != title {
+				t.Errorf("%s title = %q, want %q", id, got, title)
+			}
+		}
+		if got := bdShow(t, bd, dir, parent.ID+".2").Description; got != "after "+parent.ID+".1" {
+			t.Errorf("reference not rewritten: %q", got)
+		}
+
+		out := bdCommand(t, bd, dir, "child", "add", parent.ID, "Fourth")
+		if !strings.Contains(out, parent.ID+".4") {
+			t.Errorf("expected next child %s.4 after reorder:\n%s", parent.ID, out)
+		}
+	})
+
+	t.Run("max_depth_enforced", func(t *testing.T) {
+		bdCommand(t, bd, dir, "child", "add", parent.ID+".1.1", "Depth three")
+		out := bdCreateFail(t, bd, dir, "Too deep", "--parent", parent.ID+".1.1.1")
+		if !strings.Contains(out, "maximum hierarchy depth") {
+			t.Errorf("expected depth error, got:\n%s", out)
+		}
+	})
+
+	t.Run("counters_report_no_drift", func(t *testing.T) {
+		out := bdCommand(t, bd, dir, "child", "counters")
+		if !strings.Contains(out, "no drift") {
+			t.Errorf("expected no drift:\n%s", out)
+		}
+	})
+}
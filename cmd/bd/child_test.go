@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanChildReorder(t *testing.T) {
+	ids := []string{"bd-p.1", "bd-p.2", "bd-p.2.1", "bd-p.2.1.1", "bd-p.5", "bd-p.notes"}
+
+	t.Run("explicit_order_first", func(t *testing.T) {
+		renames, last, err := planChildReorder("bd-p", ids, []string{"bd-p.5"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"bd-p.5":     "bd-p.1",
+			"bd-p.1":     "bd-p.2",
+			"bd-p.2":     "bd-p.3",
+			"bd-p.2.1":   "bd-p.3.1",
+			"bd-p.2.1.1": "bd-p.3.1.1",
+		}
+		if !reflect.DeepEqual(renames, want) {
+			t.Errorf("renames = %v, want %v", renames, want)
+		}
+		if last != 3 {
+			t.Errorf("last child = %d, want 3", last)
+		}
+	})
+
+	t.Run("no_order_closes_gaps", func(t *testing.T) {
+		renames, last, err := planChildReorder("bd-p", ids, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"bd-p.5": "bd-p.3"}; !reflect.DeepEqual(renames, want) {
+			t.Errorf("renames = %v, want %v", renames, want)
+		}
+		if last != 3 {
+			t.Errorf("last child = %d, want 3", last)
+		}
+	})
+
+	t.Run("rejects_grandchild", func(t *testing.T) {
+		if _, _, err := planChildReorder("bd-p", ids, []string{"bd-p.2.1"}); err == nil {
+			t.Error("expected error for a grandchild in the order")
+		}
+	})
+
+	t.Run("rejects_repeat", func(t *testing.T) {
+		if _, _, err := planChildReorder("bd-p", ids, []string{"bd-p.2", "bd-p.2"}); err == nil {
+			t.Error("expected error for a repeated child")
+		}
+	})
+}
+
+func TestIDReferencePattern(t *testing.T) {
+	renames := map[string]string{
+		"bd-p.1":   "bd-p.2",
+		"bd-p.2":   "bd-p.1",
+		"bd-p.2.1": "bd-p.1.1",
+	}
+	pattern := idReferencePattern(renames)
+	got := pattern.ReplaceAllStringFunc("see bd-p.1, bd-p.2.1 and bd-p.2; not bd-p.10",
+		func(id string) string { return renames[id] })
+	if want := "see bd-p.2, bd-p.1.1 and bd-p.1; not bd-p.10"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if idReferencePattern(nil) != nil {
+		t.Error("expected nil pattern for no renames")
+	}
+}
+
+func TestCheckChildDepth(t *testing.T) {
+	if err := checkChildDepth("bd-p.1.1"); err != nil {
+		t.Errorf("depth 3 child should be allowed: %v", err)
+	}
+	err := checkChildDepth("bd-p.1.1.1")
+	if err == nil || !strings.Contains(err.Error(), "maximum hierarchy depth") {
+		t.Errorf("expected depth error, got %v", err)
+	}
+}
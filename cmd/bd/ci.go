@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var ciCmd = &cobra.Command{
+	Use:     "ci",
+	GroupID: "advanced",
+	Short:   "Record CI results against issues",
+	Long: `Record CI results against issues.
+
+CI workflows call 'bd ci report' to attach a run's result to the issues
+linked to the commit or branch under test. 'bd show' then lists the runs,
+and 'bd ready' flags issues whose latest commit is failing CI.`,
+}
+
+var ciReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Record a CI run result on the issues for a commit or branch",
+	Long: `Record a CI run result on the issues for a commit or branch.
+
+The run is recorded on every issue that either has the commit in its commit
+links (bd git scan, bd link commit) or was started on the branch (bd start).
+Use --issue to name issues explicitly instead. Each issue keeps one result
+per workflow name and commit, so reporting pending and then success updates
+the same run. Every report is also added to the issue's event history.
+
+Under GitHub Actions the commit, branch, workflow name, and run URL default
+from GITHUB_SHA, GITHUB_HEAD_REF/GITHUB_REF_NAME, GITHUB_WORKFLOW, and the
+run ID. Elsewhere they default to the current git HEAD and branch.
+
+A commit or branch with no matching issues is not an error, so the step can
+run on every build.
+
+EXAMPLES:
+  bd ci report --status success
+  bd ci report --status failure --name build --commit 3f2a9c1
+  bd ci report --status pending --issue bd-a1b2
+
+GitHub Actions step (runs whether the job passed or failed):
+  - if: always()
+    run: bd ci report --status ${{ job.status }}`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("ci report")
+		status, _ := cmd.Flags().GetString("status")
+		commit, _ := cmd.Flags().GetString("commit")
+		branch, _ := cmd.Flags().GetString("branch")
+		name, _ := cmd.Flags().GetString("name")
+		url, _ := cmd.Flags().GetString("url")
+		issueArgs, _ := cmd.Flags().GetStringArray("issue")
+		ctx := rootCtx
+
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !types.IsValidCIStatus(status) {
+			FatalErrorRespectJSON("invalid --status %q: must be success, failure, pending, or cancelled", status)
+		}
+		run, err := ciRunFromEnv(commit, branch, name, url)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		run.Status = status
+
+		crs, ok := storage.UnwrapStore(store).(storage.CIRunStore)
+		if !ok {
+			FatalErrorRespectJSON("CI runs are not supported by this storage backend")
+		}
+		ids, err := ciReportTargets(ctx, store, issueArgs, run.CommitSHA, run.Branch)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		for _, id := range ids {
+			r := *run
+			r.IssueID = id
+			if err := crs.RecordCIRun(ctx, &r, actor); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		if len(ids) > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			if ids == nil {
+				ids = []string{}
+			}
+			outputJSON(map[string]interface{}{
+				"name":       run.Name,
+				"status":     run.Status,
+				"commit_sha": run.CommitSHA,
+				"branch":     run.Branch,
+				"url":        run.URL,
+				"issues":     ids,
+			})
+			return
+		}
+		if len(ids) == 0 {
+			fmt.Printf("No issues linked to commit %s or branch %q; nothing recorded\n", shortSHA(run.CommitSHA), run.Branch)
+			return
+		}
+		fmt.Printf("%s Recorded %s %s @ %s on %s\n", ciStatusIcon(run.Status), run.Name, run.Status,
+			shortSHA(run.CommitSHA), strings.Join(ids, ", "))
+	},
+}
+
+// ciRunFromEnv fills in a CI run from flags, falling back to GitHub Actions
+// variables and then to the local git checkout.
+func ciRunFromEnv(commit, branch, name, url string) (*types.CIRun, error) {
+	if commit == "" {
+		commit = os.Getenv("GITHUB_SHA")
+	}
+	if commit == "" {
+		commit = "HEAD"
+	}
+	sha, _, err := resolveCommit(commit)
+	if err != nil {
+		// CI may report a SHA the local clone does not have (shallow
+		// checkouts); accept any hex string as given.
+		if lower := strings.ToLower(commit); lower != "" && strings.Trim(lower, "0123456789abcdef") == "" {
+			sha = lower
+		} else {
+			return nil, err
+		}
+	}
+
+	if branch == "" {
+		branch = os.Getenv("GITHUB_HEAD_REF") // set for pull_request events
+	}
+	if branch == "" {
+		branch = os.Getenv("GITHUB_REF_NAME")
+	}
+	if branch == "" {
+		if out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output(); err == nil {
+			if b := strings.TrimSpace(string(out)); b != "HEAD" {
+				branch = b
+			}
+		}
+	}
+
+	if name == "" {
+		name = os.Getenv("GITHUB_WORKFLOW")
+	}
+	if name == "" {
+		name = "ci"
+	}
+	if url == "" {
+		server, repo, runID := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")
+		if server != "" && repo != "" && runID != "" {
+			url = fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, runID)
+		}
+	}
+	return &types.CIRun{Name: name, CommitSHA: sha, Branch: branch, URL: url}, nil
+}
+
+// ciReportTargets returns the issues a CI run is recorded on: issueArgs
+// when given, otherwise the issues linked to sha plus those started on
+// branch. Sorted and deduplicated.
+func ciReportTargets(ctx context.Context, s storage.DoltStorage, issueArgs []string, sha, branch string) ([]string, error) {
+	seen := map[string]bool{}
+	if len(issueArgs) > 0 {
+		for _, arg := range issueArgs {
+			id, err := utils.ResolvePartialID(ctx, s, arg)
+			if err != nil {
+				return nil, err
+			}
+			seen[id] = true
+		}
+	} else {
+		if cls, ok := storage.UnwrapStore(s).(storage.CommitLinkStore); ok {
+			links, err := cls.GetCommitLinksForCommit(ctx, sha)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range links {
+				seen[l.IssueID] = true
+			}
+		}
+		if branch != "" {
+			issues, err := s.SearchIssues(ctx, "", types.IssueFilter{MetadataFields: map[string]string{branchMetadataKey: branch}})
+			if err != nil {
+				return nil, fmt.Errorf("find issues on branch %s: %w", branch, err)
+			}
+			for _, issue := range issues {
+				seen[issue.ID] = true
+			}
+		}
+	}
+	var ids []string
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// loadCIRuns returns the CI runs for issueIDs, or nil when the backend has
+// none. Best effort, for display alongside issues.
+func loadCIRuns(ctx context.Context, s storage.DoltStorage, issueIDs []string) map[string][]*types.CIRun {
+	crs, ok := storage.UnwrapStore(s).(storage.CIRunStore)
+	if !ok || len(issueIDs) == 0 {
+		return nil
+	}
+	runs, _ := crs.GetCIRuns(ctx, issueIDs)
+	return runs
+}
+
+// ciStatusIcon returns the status glyph used for CI results.
+func ciStatusIcon(status string) string {
+	switch status {
+	case types.CIStatusSuccess:
+		return ui.RenderPass("✓")
+	case types.CIStatusFailure:
+		return ui.RenderFail("✗")
+	case types.CIStatusPending:
+		return ui.RenderWarn("◐")
+	default:
+		return ui.RenderMuted("○")
+	}
+}
+
+// formatCIRunLine renders one CI run for bd show.
+func formatCIRunLine(r *types.CIRun) string {
+	line := fmt.Sprintf("  %s %s %s @ %s", ciStatusIcon(r.Status), r.Name, r.Status, shortSHA(r.CommitSHA))
+	if r.Branch != "" {
+		line += " " + ui.RenderMuted("("+r.Branch+")")
+	}
+	if r.URL != "" {
+		line += "\n    " + ui.RenderMuted(r.URL)
+	}
+	return line
+}
+
+func init() {
+	ciReportCmd.Flags().String("status", "", "Run result: success, failure, pending, or cancelled (required)")
+	ciReportCmd.Flags().String("commit", "", "Commit the run tested (default: $GITHUB_SHA or HEAD)")
+	ciReportCmd.Flags().String("branch", "", "Branch the run tested (default: from GitHub Actions or git)")
+	ciReportCmd.Flags().String("name", "", "Workflow or job name (default: $GITHUB_WORKFLOW or \"ci\")")
+	ciReportCmd.Flags().String("url", "", "Link to the run (default: the GitHub Actions run URL)")
+	ciReportCmd.Flags().StringArray("issue", nil, "Record on this issue instead of looking up linked issues (repeatable)")
+	_ = ciReportCmd.MarkFlagRequired("status")
+	ciCmd.AddCommand(ciReportCmd)
+	rootCmd.AddCommand(ciCmd)
+}
@@ -0,0 +1,119 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedCIReport(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "ci")
+	linked := bdCreate(t, bd, dir, "Fix parser")
+	started := bdCreate(t, bd, dir, "Add exporter")
+
+	gitCommit := exec.Command("git", "commit", "--allow-empty", "--no-verify", "-m", "parser fix")
+	gitCommit.Dir = dir
+	if out, err := gitCommit.CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v\n%s", err, out)
+	}
+
+	// Keep the test independent of a GitHub Actions runner's environment.
+	env := append(bdEnv(dir), "GITHUB_SHA=", "GITHUB_HEAD_REF=", "GITHUB_REF_NAME=", "GITHUB_WORKFLOW=", "GITHUB_RUN_ID=")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = env
+		out, err := cmd.Output()
+		if err != nil {
+			var stderr []byte
+			if ee, ok := err.(*exec.ExitError); ok {
+				stderr = ee.Stderr
+			}
+			t.Fatalf("bd %s: %v\n%s%s", strings.Join(args, " "), err, out, stderr)
+		}
+		return string(out)
+	}
+	report := func(args ...string) []string {
+		t.Helper()
+		var res struct {
+			Issues []string `json:"issues"`
+		}
+		out := run(append([]string{"ci", "report", "--json"}, args...)...)
+		if err := json.Unmarshal([]byte(out), &res); err != nil {
+			t.Fatalf("parse report: %v\n%s", err, out)
+		}
+		return res.Issues
+	}
+	show := func(id string) *types.IssueDetails {
+		t.Helper()
+		var details []*types.IssueDetails
+		out := run("show", id, "--json")
+		if err := json.Unmarshal([]byte(out), &details); err != nil || len(details) != 1 {
+			t.Fatalf("parse show: %v\n%s", err, out)
+		}
+		return details[0]
+	}
+
+	run("link", "commit", "HEAD", linked.ID)
+	run("start", started.ID)
+
+	// HEAD is linked to one issue and the branch belongs to the other. The
+	// run is a write, so it must land in a Dolt commit even though its leaf
+	// name matches the read-only bd report.
+	before := embeddedCurrentCommit(t, beadsDir, "ci")
+	got := report("--status", "failure", "--name", "build", "--url", "https://ci.example/1")
+	want := []string{linked.ID, started.ID}
+	sort.Strings(want)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("report issues = %v, want both", got)
+	}
+	assertEmbeddedHeadAdvanced(t, beadsDir, "ci", before, "ci report")
+	d := show(linked.ID)
+	if d.CIStatus != types.CIStatusFailure || len(d.CIRuns) != 1 || d.CIRuns[0].URL != "https://ci.example/1" {
+		t.Errorf("show after failure: status=%q runs=%+v", d.CIStatus, d.CIRuns)
+	}
+
+	var ready []*types.IssueWithCounts
+	if err := json.Unmarshal([]byte(run("ready", "--json")), &ready); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range ready {
+		if r.ID == linked.ID && r.CIStatus != types.CIStatusFailure {
+			t.Errorf("ready ci_status = %q, want failure", r.CIStatus)
+		}
+	}
+	if out := run("ready"); !strings.Contains(out, "CI failing") {
+		t.Errorf("ready output should flag failing CI:\n%s", out)
+	}
+
+	// A rerun of the same workflow on the same commit replaces the result.
+	report("--status", "success", "--name", "build")
+	if d := show(linked.ID); d.CIStatus != types.CIStatusSuccess || len(d.CIRuns) != 1 {
+		t.Errorf("show after rerun: status=%q runs=%d", d.CIStatus, len(d.CIRuns))
+	}
+
+	if got := report("--status", "pending", "--name", "lint", "--issue", linked.ID); len(got) != 1 || got[0] != linked.ID {
+		t.Errorf("explicit --issue = %v", got)
+	}
+	if d := show(linked.ID); d.CIStatus != types.CIStatusPending || len(d.CIRuns) != 2 {
+		t.Errorf("show with lint pending: status=%q runs=%d", d.CIStatus, len(d.CIRuns))
+	}
+
+	if got := report("--status", "success", "--commit", strings.Repeat("ab", 20), "--branch", "unrelated"); len(got) != 0 {
+		t.Errorf("unrelated commit matched %v", got)
+	}
+}
@@ -16,6 +16,16 @@ import (
 	"github.com/steveyegge/beads/internal/validation"
 )
 
+// closeFollowUpJSON is the --json output of bd close when --suggest-next,
+// --continue, or --claim-next adds to the closed issues. Without them, close
+// prints the closed issues as a plain array.
+type closeFollowUpJSON struct {
+	Closed    []*types.Issue  `json:"closed"`
+	Unblocked []*types.Issue  `json:"unblocked,omitempty"`
+	Continue  *ContinueResult `json:"continue,omitempty"`
+	Claimed   *types.Issue    `json:"claimed,omitempty"`
+}
+
 var closeCmd = &cobra.Command{
 	Use:     "close [id...]",
 	Aliases: []string{"done"},
@@ -29,7 +39,17 @@ create, update, show, or close operation).
 When closing multiple issues, provide one --reason for all IDs or repeat
 --reason once per ID. Reasons map positionally: the first --reason applies
 to the first ID, the second --reason to the second ID, regardless of where
-the flags appear in the command line.`,
+the flags appear in the command line.
+
+When molecule.auto-advance is "actor" or "agent", closing a molecule step
+assigns the next unblocked step to the same actor or to the molecule root's
+assignee (the molecule's agent).
+
+--cascade closes an issue (typically an abandoned epic or molecule) together
+with all of its still-open descendants in one transaction, recording a
+'closed' event for each. The issues are listed and confirmation is asked
+first; use --dry-run to only list them, or --yes to skip the prompt. Open
+blockers do not stop a cascade; pins and locks do unless --force is given.`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("close")
@@ -66,6 +86,9 @@ the flags appear in the command line.`,
 		}
 
 		ctx := rootCtx
+		if force {
+			ctx = storage.WithLockOverride(ctx)
+		}
 
 		// --continue only works with a single issue
 		if continueFlag && len(args) > 1 {
@@ -77,12 +100,26 @@ the flags appear in the command line.`,
 			FatalErrorRespectJSON("--suggest-next only works when closing a single issue")
 		}
 
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		if cascade && (len(args) > 1 || continueFlag || suggestNext || claimNext) {
+			FatalErrorRespectJSON("--cascade closes a single issue and cannot be combined with --continue, --suggest-next, or --claim-next")
+		}
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun && !cascade {
+			FatalErrorRespectJSON("--dry-run only works with --cascade")
+		}
+
 		// Resolve partial IDs with routing fallback (beads-0km).
 		results, cleanup, resolveErr := resolveCloseTargets(ctx, store, args)
 		defer cleanup()
 		if resolveErr != nil {
 			FatalErrorRespectJSON("%v", resolveErr)
 		}
+		if cascade {
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			yes, _ := cmd.Flags().GetBool("yes")
+			closeCascade(ctx, results[0], reasonForCloseIndex(reasons, 0), session, force, dryRun, yes)
+			return
+		}
 		resolvedIDs := make([]string, 0, len(results))
 		for _, r := range results {
 			resolvedIDs = append(resolvedIDs, r.ResolvedID)
@@ -108,6 +145,13 @@ the flags appear in the command line.`,
 				continue
 			}
 
+			if !force {
+				if err := checkIssueLock(ctx, activeStore, id); err != nil {
+					fmt.Fprintf(os.Stderr, "cannot close %s: %v (use --force to override)\n", id, err)
+					continue
+				}
+			}
+
 			// Epic close guard: prevent closing epics with open children (mw-local-4so.5.2)
 			if !force && issue != nil && issue.IssueType == types.TypeEpic {
 				openChildren := countEpicOpenChildren(ctx, activeStore, id)
@@ -125,6 +169,13 @@ the flags appear in the command line.`,
 				}
 			}
 
+			if !force && issue != nil {
+				if err := checkStatusTransition(ctx, activeStore, issue, types.StatusClosed); err != nil {
+					fmt.Fprintf(os.Stderr, "%s (use --force to override)\n", err)
+					continue
+				}
+			}
+
 			// Check if issue has open blockers (GH#962)
 			if !force {
 				blocked, blockers, err := activeStore.IsBlocked(ctx, id)
@@ -157,6 +208,21 @@ the flags appear in the command line.`,
 			// Runs against the same store the step was closed in.
 			autoCloseCompletedMolecule(ctx, activeStore, id, actor, session)
 
+			// Opt-in (molecule.auto-advance): hand the next ready step to
+			// the same actor or the molecule's agent. --continue claims
+			// the next step itself, so skip it there.
+			if !continueFlag {
+				advanced, err := autoAdvanceMolecule(ctx, activeStore, issue, actor)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: could not auto-advance molecule: %v\n", err)
+				} else if advanced != nil {
+					mutatedStores[activeStore] = append(mutatedStores[activeStore], advanced.StepID)
+					if !jsonOutput {
+						fmt.Printf("%s Assigned next step %s to %s\n", ui.RenderAccent("→"), formatFeedbackID(advanced.StepID, advanced.StepTitle), advanced.Assignee)
+					}
+				}
+			}
+
 			// Re-fetch for display
 			closedIssue, _ := activeStore.GetIssue(ctx, id)
 
@@ -183,10 +249,7 @@ the flags appear in the command line.`,
 			unblocked, err := postCloseStore.GetNewlyUnblockedByClose(ctx, resolvedIDs[0])
 			if err == nil && len(unblocked) > 0 {
 				if jsonOutput {
-					outputJSON(map[string]interface{}{
-						"closed":    closedIssues,
-						"unblocked": unblocked,
-					})
+					outputJSON(closeFollowUpJSON{Closed: closedIssues, Unblocked: unblocked})
 					return
 				}
 				fmt.Printf("\nNewly unblocked:\n")
@@ -205,10 +268,7 @@ the flags appear in the command line.`,
 			} else if result != nil {
 				if jsonOutput {
 					// Include continue result in JSON output
-					outputJSON(map[string]interface{}{
-						"closed":   closedIssues,
-						"continue": result,
-					})
+					outputJSON(closeFollowUpJSON{Closed: closedIssues, Continue: result})
 					return
 				}
 				PrintContinueResult(result)
@@ -247,10 +307,7 @@ the flags appear in the command line.`,
 
 		if jsonOutput && len(closedIssues) > 0 {
 			if claimedNextIssue != nil {
-				outputJSON(map[string]interface{}{
-					"closed":  closedIssues,
-					"claimed": claimedNextIssue,
-				})
+				outputJSON(closeFollowUpJSON{Closed: closedIssues, Claimed: claimedNextIssue})
 			} else {
 				outputJSON(closedIssues)
 			}
@@ -288,11 +345,14 @@ func init() {
 	closeCmd.Flags().String("comment", "", "Alias for --reason")
 	_ = closeCmd.Flags().MarkHidden("comment") // Hidden alias for agent/CLI ergonomics
 	closeCmd.Flags().String("reason-file", "", "Read close reason from file (use - for stdin)")
-	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues or unsatisfied gates")
+	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned or locked issues or unsatisfied gates")
 	closeCmd.Flags().Bool("continue", false, "Auto-advance to next step in molecule")
 	closeCmd.Flags().Bool("no-auto", false, "With --continue, show next step but don't claim it")
 	closeCmd.Flags().Bool("suggest-next", false, "Show newly unblocked issues after closing")
 	closeCmd.Flags().Bool("claim-next", false, "Automatically claim the next highest priority available issue")
+	closeCmd.Flags().Bool("cascade", false, "Also close every open descendant of the issue, in one transaction")
+	closeCmd.Flags().Bool("dry-run", false, "With --cascade, preview the issues that would be closed")
+	closeCmd.Flags().BoolP("yes", "y", false, "With --cascade, skip the confirmation prompt")
 	closeCmd.Flags().String("session", "", "Claude Code session ID (or set CLAUDE_SESSION_ID env var)")
 	closeCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(closeCmd)
@@ -348,7 +408,7 @@ func resolveCloseReasons(cmd *cobra.Command, args []string) ([]string, []string,
 	}
 
 	if len(reasons) == 0 {
-		reasons = []string{"Closed"}
+		reasons = []string{types.DefaultCloseReason}
 	}
 	if len(reasons) > 1 && len(reasons) != len(args) {
 		return nil, args, fmt.Errorf("got %d close reasons for %d issue IDs; provide exactly one shared reason or one reason per issue", len(reasons), len(args))
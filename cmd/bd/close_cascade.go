@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// closeCascadeJSON is the --json output of bd close --cascade. With
+// --dry-run, Closed lists the issues that would be closed.
+type closeCascadeJSON struct {
+	Root   string         `json:"root"`
+	DryRun bool           `json:"dry_run,omitempty"`
+	Closed []*types.Issue `json:"closed"`
+}
+
+// closeCascade closes root and its open descendants after a preview and
+// confirmation. Per-issue guards other than blockers (templates, pins,
+// locks) still apply to every issue unless force is set; blockers are
+// ignored because the whole subtree is being abandoned together.
+func closeCascade(ctx context.Context, target *RoutedResult, reason, session string, force, dryRun, yes bool) {
+	activeStore := target.Store
+	root := target.Issue
+	if root == nil {
+		FatalErrorRespectJSON("issue %s not found", target.ResolvedID)
+	}
+
+	found := make(map[string]*types.Issue)
+	if err := findAllDescendants(ctx, activeStore, "", root.ID, types.IssueFilter{}, found); err != nil {
+		FatalErrorRespectJSON("failed to list descendants of %s: %v", root.ID, err)
+	}
+	pending := make([]*types.Issue, 0, len(found)+1)
+	for _, issue := range found {
+		if issue.Status != types.StatusClosed {
+			pending = append(pending, issue)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	if root.Status != types.StatusClosed {
+		pending = append([]*types.Issue{root}, pending...)
+	}
+	if len(pending) == 0 {
+		if jsonOutput {
+			outputJSON(closeCascadeJSON{Root: root.ID, DryRun: dryRun, Closed: []*types.Issue{}})
+			return
+		}
+		fmt.Printf("%s %s and its descendants are already closed\n", ui.RenderPass("✓"), root.ID)
+		return
+	}
+
+	if !force {
+		for _, issue := range pending {
+			if err := validateIssueClosable(issue.ID, issue, false); err != nil {
+				FatalErrorRespectJSON("%v (use --force to override)", err)
+			}
+			if err := checkIssueLock(ctx, activeStore, issue.ID); err != nil {
+				FatalErrorRespectJSON("cannot close %s: %v (use --force to override)", issue.ID, err)
+			}
+		}
+	}
+
+	if dryRun {
+		if jsonOutput {
+			outputJSON(closeCascadeJSON{Root: root.ID, DryRun: true, Closed: pending})
+			return
+		}
+		printCloseCascadePreview(root, pending)
+		fmt.Printf("\nDry run: nothing closed\n")
+		return
+	}
+	if !yes && !jsonOutput {
+		printCloseCascadePreview(root, pending)
+		fmt.Printf("\nContinue? [y/N] ")
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Canceled.")
+			return
+		}
+	}
+
+	closedIDs, err := cascadeCloser(activeStore).CloseCascade(ctx, root.ID, reason, actor, session)
+	if err != nil {
+		FatalErrorRespectJSON("failed to close %s: %v", root.ID, err)
+	}
+	commandDidWrite.Store(true)
+
+	oldStatus := make(map[string]types.Status, len(pending))
+	for _, issue := range pending {
+		oldStatus[issue.ID] = issue.Status
+	}
+	closed := make([]*types.Issue, 0, len(closedIDs))
+	for _, id := range closedIDs {
+		audit.LogFieldChange(id, "status", string(oldStatus[id]), "closed", actor, reason)
+		if issue, err := activeStore.GetIssue(ctx, id); err == nil {
+			closed = append(closed, issue)
+		}
+	}
+	if len(closedIDs) > 0 {
+		if err := commitPendingIfEmbedded(ctx, activeStore, actor, doltAutoCommitParams{
+			Command:  "close",
+			IssueIDs: closedIDs,
+		}); err != nil {
+			FatalErrorRespectJSON("failed to commit: %v", err)
+		}
+		autoCloseCompletedMolecule(ctx, activeStore, root.ID, actor, session)
+	}
+
+	if jsonOutput {
+		outputJSON(closeCascadeJSON{Root: root.ID, Closed: closed})
+		return
+	}
+	for _, id := range closedIDs {
+		fmt.Printf("%s Closed %s\n", ui.RenderPass("✓"), id)
+	}
+	fmt.Printf("\n%d issue(s) closed with %s: %s\n", len(closedIDs), formatFeedbackID(root.ID, root.Title), reason)
+}
+
+func printCloseCascadePreview(root *types.Issue, pending []*types.Issue) {
+	fmt.Printf("Closing %s and its open descendants (%d issue(s)):\n", formatFeedbackID(root.ID, root.Title), len(pending))
+	for _, issue := range pending {
+		fmt.Printf("  [%s] %s\n", issue.Status, formatFeedbackID(issue.ID, issue.Title))
+	}
+}
+
+// cascadeCloser returns the store's cascade close support, exiting when the
+// backend has none. The hook-firing decorator is preferred over the concrete
+// store so on_close hooks still run.
+func cascadeCloser(s storage.DoltStorage) storage.CascadeCloser {
+	if cc, ok := s.(storage.CascadeCloser); ok {
+		return cc
+	}
+	cc, ok := storage.UnwrapStore(s).(storage.CascadeCloser)
+	if !ok {
+		FatalErrorRespectJSON("cascade close is not supported by this storage backend")
+	}
+	return cc
+}
@@ -293,6 +293,68 @@ func TestEmbeddedClose(t *testing.T) {
 		}
 	})
 
+	t.Run("close_cascade_closes_open_descendants", func(t *testing.T) {
+		epic := bdCreate(t, bd, dir, "Cascade epic", "--type", "epic")
+		child := bdCreate(t, bd, dir, "Cascade child", "--type", "task")
+		grandchild := bdCreate(t, bd, dir, "Cascade grandchild", "--type", "task")
+		done := bdCreate(t, bd, dir, "Cascade done", "--type", "task")
+		blocker := bdCreate(t, bd, dir, "Cascade blocker", "--type", "task")
+		bdDepAdd(t, bd, dir, child.ID, epic.ID, "--type", "parent-child")
+		bdDepAdd(t, bd, dir, grandchild.ID, child.ID, "--type", "parent-child")
+		bdDepAdd(t, bd, dir, done.ID, epic.ID, "--type", "parent-child")
+		bdDepAdd(t, bd, dir, grandchild.ID, blocker.ID)
+		bdClose(t, bd, dir, done.ID, "--reason", "shipped")
+
+		out := bdClose(t, bd, dir, epic.ID, "--cascade", "--dry-run")
+		if !strings.Contains(out, grandchild.ID) || strings.Contains(out, done.ID) {
+			t.Errorf("preview should list open descendants only:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, epic.ID); got.Status == types.StatusClosed {
+			t.Fatal("--dry-run closed the epic")
+		}
+
+		bdClose(t, bd, dir, epic.ID, "--cascade", "--yes", "--reason", "abandoned")
+		for _, id := range []string{epic.ID, child.ID, grandchild.ID} {
+			if got := bdShow(t, bd, dir, id); got.Status != types.StatusClosed {
+				t.Errorf("expected %s closed by cascade, got %s", id, got.Status)
+			}
+		}
+		if got := bdShow(t, bd, dir, grandchild.ID).CloseReason; got != "abandoned (closed with "+epic.ID+")" {
+			t.Errorf("unexpected descendant close_reason %q", got)
+		}
+		if got := bdShow(t, bd, dir, done.ID).CloseReason; got != "shipped" {
+			t.Errorf("already-closed child was re-closed: close_reason %q", got)
+		}
+
+		db, cleanup, err := embeddeddolt.OpenSQL(t.Context(), filepath.Join(beadsDir, "embeddeddolt"), "tc", "main")
+		if err != nil {
+			t.Fatalf("OpenSQL: %v", err)
+		}
+		defer cleanup()
+		for _, id := range []string{epic.ID, child.ID, grandchild.ID} {
+			var n int
+			if err := db.QueryRowContext(t.Context(),
+				"SELECT COUNT(*) FROM events WHERE issue_id = ? AND event_type = 'closed'", id).Scan(&n); err != nil {
+				t.Fatalf("count close events: %v", err)
+			}
+			if n != 1 {
+				t.Errorf("expected one close event for %s, got %d", id, n)
+			}
+		}
+	})
+
+	t.Run("close_cascade_pinned_descendant_refuses", func(t *testing.T) {
+		epic := bdCreate(t, bd, dir, "Cascade pinned epic", "--type", "epic")
+		child := bdCreate(t, bd, dir, "Cascade pinned child", "--type", "task")
+		bdDepAdd(t, bd, dir, child.ID, epic.ID, "--type", "parent-child")
+		bdUpdate(t, bd, dir, child.ID, "--status", "pinned")
+
+		bdCloseFail(t, bd, dir, epic.ID, "--cascade", "--yes")
+		if got := bdShow(t, bd, dir, epic.ID); got.Status == types.StatusClosed {
+			t.Error("cascade closed the epic despite a pinned descendant")
+		}
+	})
+
 	// ===== Blocker and Suggest-Next Behavior =====
 
 	t.Run("close_unblocks_dependent", func(t *testing.T) {
@@ -708,6 +708,14 @@ func runCompactApply(ctx context.Context, store storage.DoltStorage) {
 		"acceptance_criteria": "",
 	}
 
+	// Keep the original so 'bd compact restore' can undo this.
+	if snapshots, ok := storage.UnwrapStore(store).(storage.CompactionSnapshotStore); ok {
+		if err := snapshots.SaveCompactionSnapshot(ctx, issue, compactTier); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to save snapshot: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if err := store.UpdateIssue(ctx, compactID, updates, actor); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to update issue: %v\n", err)
 		os.Exit(1)
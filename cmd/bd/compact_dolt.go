@@ -38,7 +38,14 @@ Examples:
   bd compact --dry-run               # Preview: show commit breakdown
   bd compact --force                 # Squash commits older than 30 days
   bd compact --days 7 --force        # Keep only last 7 days of history
-  bd compact --days 90 --force       # Conservative: squash 90+ day old commits`,
+  bd compact --days 90 --force       # Conservative: squash 90+ day old commits
+
+Policy-driven issue compaction lives in the subcommands:
+  bd compact policy set|list|remove  # Configure which closed issues to compact
+  bd compact run                     # Apply the policies (--if-due for schedulers)
+  bd compact status                  # Policies, candidates, and schedule
+  bd compact restore <id>            # Undo compaction from its snapshot`,
+	Args: cobra.NoArgs,
 	Run: func(_ *cobra.Command, _ []string) {
 		if !compactDoltDryRun {
 			CheckReadonly("compact")
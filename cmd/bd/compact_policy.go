@@ -0,0 +1,587 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/compact"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/town"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// compactPolicyResult is one issue's outcome in bd compact run.
+type compactPolicyResult struct {
+	IssueID       string `json:"issue_id"`
+	Policy        string `json:"policy"`
+	Tier          int    `json:"tier"`
+	OriginalSize  int    `json:"original_size"`
+	CompactedSize int    `json:"compacted_size"`
+	Error         string `json:"error,omitempty"`
+}
+
+// compactPolicyStatus is one policy's row in bd compact status.
+type compactPolicyStatus struct {
+	*compact.Policy
+	Candidates int `json:"candidates"`
+	TotalSize  int `json:"total_size"`
+}
+
+// compactScheduleState is the scheduler's view of compaction.
+type compactScheduleState struct {
+	Schedule string     `json:"schedule,omitempty"`
+	LastRun  *time.Time `json:"last_run,omitempty"`
+	NextDue  *time.Time `json:"next_due,omitempty"`
+	Due      bool       `json:"due"`
+	Error    string     `json:"error,omitempty"`
+}
+
+var compactPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage compaction policies",
+	Long: `Manage the policies that 'bd compact run' applies to closed issues.
+
+A policy compacts closed issues of the given types (all types by default) that
+have been closed for at least --days and hold at least --min-size bytes of
+description, design, notes, and acceptance criteria. Tier 1 applies to
+uncompacted issues, tier 2 to issues already at tier 1.
+
+Policies are stored in the database config under compact.policy.<name>, so
+every clone shares them.
+
+Examples:
+  bd compact policy set closed-30d --days 30
+  bd compact policy set old-bugs --tier 2 --days 180 --type bug --min-size 2000
+  bd compact policy list
+  bd compact policy remove old-bugs`,
+}
+
+var compactPolicySetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Add or replace a compaction policy",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("compact policy set")
+		ctx := rootCtx
+		tier, _ := cmd.Flags().GetInt("tier")
+		days, _ := cmd.Flags().GetInt("days")
+		minSize, _ := cmd.Flags().GetInt("min-size")
+		issueTypes, _ := cmd.Flags().GetStringSlice("type")
+
+		p := &compact.Policy{Name: strings.TrimSpace(args[0]), Tier: tier, OlderThanDays: days, MinSize: minSize}
+		if len(issueTypes) > 0 {
+			customTypes, err := store.GetCustomTypes(ctx)
+			if err != nil {
+				FatalErrorRespectJSON("failed to load custom types: %v", err)
+			}
+			for _, t := range issueTypes {
+				it := types.IssueType(strings.TrimSpace(t)).Normalize()
+				if !it.IsValidWithCustom(customTypes) {
+					FatalErrorRespectJSON("invalid issue type %q", t)
+				}
+				p.Types = append(p.Types, string(it))
+			}
+		}
+		if err := p.Validate(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		value, err := p.Encode()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := store.SetConfig(ctx, compact.PolicyPrefix+p.Name, value); err != nil {
+			FatalErrorRespectJSON("failed to save policy: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(p)
+			return
+		}
+		fmt.Printf("%s Set policy %s: %s\n", ui.RenderPass("✓"), ui.RenderAccent(p.Name), describeCompactPolicy(p))
+	},
+}
+
+var compactPolicyRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a compaction policy",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("compact policy remove")
+		ctx := rootCtx
+		name := args[0]
+		all, err := store.GetAllConfig(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if _, ok := all[compact.PolicyPrefix+name]; !ok {
+			FatalErrorRespectJSON("no compaction policy named %q", name)
+		}
+		if err := store.DeleteConfig(ctx, compact.PolicyPrefix+name); err != nil {
+			FatalErrorRespectJSON("failed to remove policy: %v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": name})
+			return
+		}
+		fmt.Printf("%s Removed policy %s\n", ui.RenderPass("✓"), name)
+	},
+}
+
+var compactPolicyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List compaction policies",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		policies := loadCompactPolicies(rootCtx)
+		if jsonOutput {
+			if policies == nil {
+				policies = []*compact.Policy{}
+			}
+			outputJSON(policies)
+			return
+		}
+		if len(policies) == 0 {
+			fmt.Printf("\nNo compaction policies. Add one with 'bd compact policy set'.\n\n")
+			return
+		}
+		fmt.Printf("\n%s Compaction policies (%d):\n\n", ui.RenderAccent("🗜"), len(policies))
+		for _, p := range policies {
+			fmt.Printf("  %s  %s\n", ui.RenderAccent(p.Name), describeCompactPolicy(p))
+		}
+		fmt.Println()
+	},
+}
+
+var compactRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Compact closed issues matching the compaction policies",
+	Long: `Compact every closed issue that matches a compaction policy.
+
+Each issue is snapshotted before it is compacted, then its description is
+cut to its first paragraph (500 characters at tier 1, 120 at tier 2) and its
+design, notes, and acceptance criteria are cleared. No API key is needed.
+Use 'bd compact restore <id>' to bring the original back.
+
+Scheduling: set compact.schedule to an interval or cron expression (the
+same forms as 'bd patrol add') and call 'bd compact run --if-due' from cron,
+a systemd timer, or an orchestrator loop. It runs only when the schedule
+says compaction is due.
+
+Examples:
+  bd compact run --dry-run
+  bd compact run --policy closed-30d
+  bd config set compact.schedule @weekly
+  bd compact run --if-due`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		ifDue, _ := cmd.Flags().GetBool("if-due")
+		only, _ := cmd.Flags().GetString("policy")
+		if !dryRun {
+			CheckReadonly("compact run")
+		}
+		ctx := rootCtx
+		now := time.Now()
+
+		if ifDue {
+			sched := compactSchedule(ctx, now)
+			if sched.Error != "" {
+				FatalErrorRespectJSON("%s", sched.Error)
+			}
+			if !sched.Due {
+				if jsonOutput {
+					outputJSON(map[string]interface{}{"due": false, "schedule": sched})
+					return
+				}
+				switch {
+				case sched.Schedule == "":
+					fmt.Printf("No compaction schedule. Set one with 'bd config set %s <schedule>'.\n", compact.ScheduleKey)
+				case sched.NextDue == nil:
+					fmt.Printf("Compaction schedule %q never comes due again.\n", sched.Schedule)
+				default:
+					fmt.Printf("Compaction not due until %s.\n", sched.NextDue.Local().Format("2006-01-02 15:04"))
+				}
+				return
+			}
+		}
+
+		policies := loadCompactPolicies(ctx)
+		if only != "" {
+			var selected []*compact.Policy
+			for _, p := range policies {
+				if p.Name == only {
+					selected = append(selected, p)
+				}
+			}
+			if len(selected) == 0 {
+				FatalErrorRespectJSON("no compaction policy named %q", only)
+			}
+			policies = selected
+		}
+		if len(policies) == 0 {
+			FatalErrorWithHint("no compaction policies", "add one with 'bd compact policy set <name> --days 30'")
+		}
+
+		issues, err := loadClosedIssuesForCompaction(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		plan := planCompaction(policies, issues, now)
+
+		results := make([]compactPolicyResult, 0, len(plan))
+		failed := false
+		for _, item := range plan {
+			r := compactPolicyResult{
+				IssueID:       item.issue.ID,
+				Policy:        item.policy.Name,
+				Tier:          item.policy.Tier,
+				OriginalSize:  compact.ContentSize(item.issue),
+				CompactedSize: len(compact.Truncate(item.issue, item.policy.Tier)["description"].(string)),
+			}
+			if !dryRun {
+				if err := compactIssueByPolicy(ctx, item.issue.ID, item.policy); err != nil {
+					r.Error = err.Error()
+					failed = true
+				}
+			}
+			results = append(results, r)
+		}
+		if !dryRun {
+			if err := store.SetConfig(ctx, compact.LastRunKey, now.UTC().Format(time.RFC3339)); err != nil {
+				FatalErrorRespectJSON("failed to record run: %v", err)
+			}
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		} else {
+			displayCompactRun(results, dryRun)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+var compactStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show compaction policies, candidates, and schedule",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		now := time.Now()
+		policies := loadCompactPolicies(ctx)
+		issues, err := loadClosedIssuesForCompaction(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		rows := make([]compactPolicyStatus, 0, len(policies))
+		for _, p := range policies {
+			row := compactPolicyStatus{Policy: p}
+			for _, issue := range issues {
+				if p.Matches(issue, now) {
+					row.Candidates++
+					row.TotalSize += compact.ContentSize(issue)
+				}
+			}
+			rows = append(rows, row)
+		}
+		levels := map[int]int{}
+		for _, issue := range issues {
+			if issue.CompactionLevel > 0 {
+				levels[issue.CompactionLevel]++
+			}
+		}
+		sched := compactSchedule(ctx, now)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"policies":  rows,
+				"compacted": map[string]int{"tier1": levels[1], "tier2": levels[2]},
+				"schedule":  sched,
+			})
+			return
+		}
+
+		fmt.Printf("\n%s Compaction status\n\n", ui.RenderAccent("🗜"))
+		fmt.Printf("  Compacted issues: %d at tier 1, %d at tier 2\n", levels[1], levels[2])
+		switch {
+		case sched.Error != "":
+			fmt.Printf("  Schedule: %s\n", ui.RenderFail(sched.Error))
+		case sched.Schedule == "":
+			fmt.Printf("  Schedule: %s\n", ui.RenderMuted("none (set "+compact.ScheduleKey+")"))
+		default:
+			line := "  Schedule: " + sched.Schedule
+			if sched.Due {
+				line += " · " + ui.RenderWarn("due now")
+			} else if sched.NextDue != nil {
+				line += " · next " + sched.NextDue.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Println(line)
+		}
+		if sched.LastRun != nil {
+			fmt.Printf("  Last run: %s\n", sched.LastRun.Local().Format("2006-01-02 15:04"))
+		}
+		fmt.Println()
+		if len(rows) == 0 {
+			fmt.Printf("  No compaction policies. Add one with 'bd compact policy set'.\n\n")
+			return
+		}
+		for _, r := range rows {
+			fmt.Printf("  %s  %s\n", ui.RenderAccent(r.Name), describeCompactPolicy(r.Policy))
+			fmt.Printf("      %s\n", ui.RenderMuted(fmt.Sprintf("%d candidate(s), %d bytes", r.Candidates, r.TotalSize)))
+		}
+		fmt.Println()
+	},
+}
+
+var compactRestoreCmd = &cobra.Command{
+	Use:   "restore <issue-id>",
+	Short: "Restore a compacted issue's original content from its snapshot",
+	Long: `Write a compacted issue's original description, design, notes, and
+acceptance criteria back from the snapshot taken when it was compacted, and
+clear its compaction level.
+
+Snapshots are taken by 'bd compact run' and 'bd admin compact --apply'. For
+issues compacted before snapshots existed, 'bd restore <id>' can still show
+the pre-compaction version from Dolt history.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("compact restore")
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("issue '%s' not found", args[0])
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if issue.CompactionLevel == 0 {
+			FatalErrorRespectJSON("issue %s is not compacted", id)
+		}
+		ss := compactionSnapshotStore(store)
+		snapshots, err := ss.GetCompactionSnapshots(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		original := originalFromSnapshots(snapshots)
+		if original == nil {
+			FatalErrorWithHint(fmt.Sprintf("no compaction snapshot for %s", id),
+				fmt.Sprintf("run 'bd restore %s' to look for the original in Dolt history", id))
+		}
+
+		updates := map[string]interface{}{
+			"description":         original.Description,
+			"design":              original.Design,
+			"notes":               original.Notes,
+			"acceptance_criteria": original.AcceptanceCriteria,
+		}
+		if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
+			FatalErrorRespectJSON("failed to restore %s: %v", id, err)
+		}
+		if err := ss.ClearCompaction(ctx, id); err != nil {
+			FatalErrorRespectJSON("failed to clear compaction of %s: %v", id, err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id":      id,
+				"restored_size": compact.ContentSize(original),
+				"from_level":    issue.CompactionLevel,
+			})
+			return
+		}
+		fmt.Printf("%s Restored %s (%d → %d bytes)\n", ui.RenderPass("✓"), ui.RenderAccent(id),
+			compact.ContentSize(issue), compact.ContentSize(original))
+	},
+}
+
+func init() {
+	compactPolicySetCmd.Flags().Int("tier", 1, "Compaction tier (1 or 2)")
+	compactPolicySetCmd.Flags().Int("days", 30, "Compact issues closed at least N days ago")
+	compactPolicySetCmd.Flags().Int("min-size", 0, "Compact only issues with at least N bytes of text")
+	compactPolicySetCmd.Flags().StringSlice("type", nil, "Compact only issues of these types (repeatable; default all)")
+	compactRunCmd.Flags().Bool("dry-run", false, "Show what would be compacted without changing anything")
+	compactRunCmd.Flags().Bool("if-due", false, "Run only when compact.schedule says compaction is due")
+	compactRunCmd.Flags().String("policy", "", "Apply only the named policy")
+
+	compactPolicyCmd.AddCommand(compactPolicySetCmd, compactPolicyRemoveCmd, compactPolicyListCmd)
+	compactDoltCmd.AddCommand(compactPolicyCmd, compactRunCmd, compactStatusCmd, compactRestoreCmd)
+}
+
+// compactionSnapshotStore returns s as a CompactionSnapshotStore, exiting
+// when the backend does not keep compaction snapshots.
+func compactionSnapshotStore(s storage.DoltStorage) storage.CompactionSnapshotStore {
+	ss, ok := storage.UnwrapStore(s).(storage.CompactionSnapshotStore)
+	if !ok {
+		FatalErrorRespectJSON("compaction snapshots are not supported by this storage backend")
+	}
+	return ss
+}
+
+// loadCompactPolicies reads the policies from the database config, warning
+// about (and skipping) any that no longer parse.
+func loadCompactPolicies(ctx context.Context) []*compact.Policy {
+	all, err := store.GetAllConfig(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	policies, errs := compact.PoliciesFromConfig(all)
+	for _, err := range errs {
+		WarnError("skipping invalid compaction %v", err)
+	}
+	return policies
+}
+
+// loadClosedIssuesForCompaction returns every closed, non-wisp issue.
+func loadClosedIssuesForCompaction(ctx context.Context) ([]*types.Issue, error) {
+	closed := types.StatusClosed
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &closed, SkipWisps: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load closed issues: %w", err)
+	}
+	return issues, nil
+}
+
+// compactPlanItem is an issue chosen for compaction and the policy that chose it.
+type compactPlanItem struct {
+	issue  *types.Issue
+	policy *compact.Policy
+}
+
+// planCompaction picks, for each issue, the first policy (by name) that
+// matches it. An issue is compacted at most once per run.
+func planCompaction(policies []*compact.Policy, issues []*types.Issue, now time.Time) []compactPlanItem {
+	var plan []compactPlanItem
+	for _, issue := range issues {
+		for _, p := range policies {
+			if p.Matches(issue, now) {
+				plan = append(plan, compactPlanItem{issue: issue, policy: p})
+				break
+			}
+		}
+	}
+	return plan
+}
+
+// compactIssueByPolicy snapshots an issue, truncates its text, and records
+// the compaction, like 'bd admin compact --apply' with a generated summary.
+func compactIssueByPolicy(ctx context.Context, id string, p *compact.Policy) error {
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := compactionSnapshotStore(store).SaveCompactionSnapshot(ctx, issue, p.Tier); err != nil {
+		return err
+	}
+	updates := compact.Truncate(issue, p.Tier)
+	if err := store.UpdateIssue(ctx, id, updates, "compactor"); err != nil {
+		return fmt.Errorf("update %s: %w", id, err)
+	}
+	originalSize := compact.ContentSize(issue)
+	compactedSize := len(updates["description"].(string))
+	if err := store.ApplyCompaction(ctx, id, p.Tier, originalSize, compactedSize, compact.GetCurrentCommitHash()); err != nil {
+		return fmt.Errorf("record compaction of %s: %w", id, err)
+	}
+	comment := fmt.Sprintf("Tier %d compaction (policy %s): %d → %d bytes", p.Tier, p.Name, originalSize, compactedSize)
+	if err := store.AddComment(ctx, id, "compactor", comment); err != nil {
+		return fmt.Errorf("comment on %s: %w", id, err)
+	}
+	return nil
+}
+
+// compactSchedule evaluates compact.schedule against compact.last_run.
+// Compaction is due when a schedule is set and it has never run, or the
+// schedule's next time after the last run has passed.
+func compactSchedule(ctx context.Context, now time.Time) compactScheduleState {
+	var st compactScheduleState
+	spec, err := store.GetConfig(ctx, compact.ScheduleKey)
+	if err != nil {
+		st.Error = err.Error()
+		return st
+	}
+	st.Schedule = strings.TrimSpace(spec)
+	if last, err := store.GetConfig(ctx, compact.LastRunKey); err == nil && last != "" {
+		if t, err := time.Parse(time.RFC3339, last); err == nil {
+			st.LastRun = &t
+		}
+	}
+	if st.Schedule == "" {
+		return st
+	}
+	sched, err := town.ParseSchedule(st.Schedule)
+	if err != nil {
+		st.Error = fmt.Sprintf("%s: %v", compact.ScheduleKey, err)
+		return st
+	}
+	if st.LastRun == nil {
+		st.Due = true
+		return st
+	}
+	st.NextDue = nonZeroTime(sched.Next(*st.LastRun))
+	st.Due = st.NextDue != nil && !now.Before(*st.NextDue)
+	return st
+}
+
+// originalFromSnapshots returns the issue as it was before its current
+// compaction: the newest tier 1 snapshot, since tier 1 starts from
+// uncompacted content. Falls back to the oldest snapshot.
+func originalFromSnapshots(snapshots []*types.CompactionSnapshot) *types.Issue {
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].Level == 1 && snapshots[i].Issue != nil {
+			return snapshots[i].Issue
+		}
+	}
+	if len(snapshots) > 0 {
+		return snapshots[0].Issue
+	}
+	return nil
+}
+
+func describeCompactPolicy(p *compact.Policy) string {
+	parts := []string{fmt.Sprintf("tier %d", p.Tier), fmt.Sprintf("closed %d+ days", p.OlderThanDays)}
+	if p.MinSize > 0 {
+		parts = append(parts, fmt.Sprintf("%d+ bytes", p.MinSize))
+	}
+	if len(p.Types) > 0 {
+		parts = append(parts, "types "+strings.Join(p.Types, ","))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func displayCompactRun(results []compactPolicyResult, dryRun bool) {
+	if len(results) == 0 {
+		fmt.Println("No issues match the compaction policies.")
+		return
+	}
+	verb := "Compacted"
+	if dryRun {
+		verb = "Would compact"
+	}
+	saved, done := 0, 0
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Printf("  %s %s: %s\n", ui.RenderFail("✗"), r.IssueID, r.Error)
+			continue
+		}
+		done++
+		saved += r.OriginalSize - r.CompactedSize
+		fmt.Printf("  %s %s  tier %d (%s)  %d → %d bytes\n", ui.RenderPass("✓"), ui.RenderAccent(r.IssueID),
+			r.Tier, r.Policy, r.OriginalSize, r.CompactedSize)
+	}
+	fmt.Printf("\n%s %d issue(s), %d bytes saved\n", verb, done, saved)
+}
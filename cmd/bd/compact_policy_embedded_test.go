@@ -0,0 +1,79 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedCompactPolicy(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt compact policy tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "cpol")
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+
+	description := "Short summary.\n\n" + strings.Repeat("Long investigation notes. ", 60)
+	task := bdCreateSilent(t, bd, dir, "Old task", "--type", "task", "--description", description, "--design", "Design to drop")
+	bug := bdCreateSilent(t, bd, dir, "Old bug", "--type", "bug", "--description", description)
+	open := bdCreateSilent(t, bd, dir, "Still open", "--type", "task", "--description", description)
+	bdClose(t, bd, dir, task, bug)
+
+	run("compact", "policy", "set", "tasks", "--days", "0", "--type", "task")
+
+	var planned []compactPolicyResult
+	if err := json.Unmarshal([]byte(run("compact", "run", "--dry-run", "--json")), &planned); err != nil {
+		t.Fatalf("parse dry run: %v", err)
+	}
+	if len(planned) != 1 || planned[0].IssueID != task {
+		t.Fatalf("dry run planned %+v, want only %s", planned, task)
+	}
+	if got := bdShow(t, bd, dir, task); got.CompactionLevel != 0 {
+		t.Fatalf("dry run compacted %s", task)
+	}
+
+	run("compact", "run")
+	got := bdShow(t, bd, dir, task)
+	if got.CompactionLevel != 1 || got.Description != "Short summary." || got.Design != "" {
+		t.Fatalf("after run: level=%d description=%q design=%q", got.CompactionLevel, got.Description, got.Design)
+	}
+	for _, id := range []string{bug, open} {
+		if bdShow(t, bd, dir, id).CompactionLevel != 0 {
+			t.Errorf("%s should not be compacted", id)
+		}
+	}
+
+	if out := run("restore", task); !strings.Contains(out, "compaction snapshot") || !strings.Contains(out, "Long investigation notes.") {
+		t.Errorf("bd restore should show the snapshot, got:\n%s", out)
+	}
+
+	run("compact", "restore", task)
+	got = bdShow(t, bd, dir, task)
+	if got.CompactionLevel != 0 || got.Description != description || got.Design != "Design to drop" {
+		t.Fatalf("after restore: level=%d description=%q design=%q", got.CompactionLevel, got.Description, got.Design)
+	}
+
+	t.Run("schedule", func(t *testing.T) {
+		run("config", "set", "compact.schedule", "24h")
+		if out := run("compact", "run", "--if-due"); !strings.Contains(out, "not due") {
+			t.Errorf("run just happened, so compaction should not be due:\n%s", out)
+		}
+		if out := run("compact", "status"); !strings.Contains(out, "Schedule: 24h") {
+			t.Errorf("status should show the schedule:\n%s", out)
+		}
+	})
+}
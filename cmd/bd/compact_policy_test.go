@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/compact"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPlanCompaction(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	closed := now.AddDate(0, 0, -100)
+	long := strings.Repeat("detail ", 200)
+	issues := []*types.Issue{
+		{ID: "bd-1", Status: types.StatusClosed, ClosedAt: &closed, IssueType: types.TypeBug, Description: long},
+		{ID: "bd-2", Status: types.StatusClosed, ClosedAt: &closed, IssueType: types.TypeTask, Description: long},
+		{ID: "bd-3", Status: types.StatusClosed, ClosedAt: &closed, IssueType: types.TypeTask, Description: long, CompactionLevel: 1},
+	}
+	policies := []*compact.Policy{
+		{Name: "a-bugs", Tier: 1, OlderThanDays: 30, Types: []string{"bug"}},
+		{Name: "b-all", Tier: 1, OlderThanDays: 30},
+		{Name: "c-deep", Tier: 2, OlderThanDays: 90},
+	}
+
+	plan := planCompaction(policies, issues, now)
+	got := map[string]string{}
+	for _, item := range plan {
+		got[item.issue.ID] = item.policy.Name
+	}
+	want := map[string]string{"bd-1": "a-bugs", "bd-2": "b-all", "bd-3": "c-deep"}
+	if len(got) != len(want) {
+		t.Fatalf("plan = %v, want %v", got, want)
+	}
+	for id, name := range want {
+		if got[id] != name {
+			t.Errorf("%s planned by %q, want %q", id, got[id], name)
+		}
+	}
+}
+
+func TestOriginalFromSnapshots(t *testing.T) {
+	t.Parallel()
+	if originalFromSnapshots(nil) != nil {
+		t.Error("no snapshots should give nil")
+	}
+	first := &types.Issue{Description: "original"}
+	tier1 := &types.Issue{Description: "summary"}
+	again := &types.Issue{Description: "edited after restore"}
+	snaps := []*types.CompactionSnapshot{
+		{Level: 1, Issue: first},
+		{Level: 2, Issue: tier1},
+		{Level: 1, Issue: again},
+	}
+	if got := originalFromSnapshots(snaps); got != again {
+		t.Errorf("want newest tier 1 snapshot, got %q", got.Description)
+	}
+	if got := originalFromSnapshots(snaps[1:2]); got != tier1 {
+		t.Errorf("without tier 1 snapshots, want the oldest, got %q", got.Description)
+	}
+}
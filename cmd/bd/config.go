@@ -49,6 +49,15 @@ Auto-Export (config.yaml):
     export.interval   Minimum time between exports (default: 60s)
     export.git-add    Auto-stage the export file (default: false)
 
+Auto-Mirror (config.yaml):
+  Optional read-only SQLite snapshot (bd mirror) refreshed after write
+  commands when the database changed (throttled). Needs sqlite3 on PATH.
+
+  Keys:
+    mirror.auto       Enable/disable auto-mirror (default: false)
+    mirror.path       Mirror filename relative to .beads/ (default: mirror.sqlite)
+    mirror.interval   Minimum time between mirrors (default: 15m)
+
 Auto-Import (config.yaml):
   Reads .beads/issues.jsonl by default when a JSONL import path is implied.
   Use a relative filename/path so the import stays within the project .beads/
@@ -67,6 +76,29 @@ Custom Status States:
   This enables issues to use statuses like 'awaiting_review' in addition to
   the built-in statuses (open, in_progress, blocked, deferred, closed).
 
+  Restrict which status changes are allowed with from->to pairs ("*" matches
+  any status). bd update, bd close, and bd reopen enforce them:
+    bd config set status.transitions "open->in_progress,in_progress->awaiting_review,awaiting_review->closed,*->open"
+
+  Rename statuses in JSONL from or for other trackers with from:to pairs:
+    bd config set status.import-map "todo:open,doing:in_progress,done:closed"
+    bd config set status.export-map "awaiting_review:in_progress"
+
+  Require fields before an issue of a type ("*" for any) enters a status:
+    bd config set status.policy.bug.closed "close_reason,commit"
+    bd config set status.policy.*.in_progress "assignee"
+  Fields: assignee, description, design, acceptance_criteria, notes,
+  close_reason, external_ref, estimate, due, commit. "commit" is met by a
+  commit hash in the close reason or notes, or a "commit" metadata key.
+
+Monorepo Sub-Projects:
+  Map sub-project directories to their own ID prefixes with prefix:dir pairs
+  (directories are relative to the repository root):
+    bd config set projects "web:apps/web,api:services/api"
+  bd create picks the prefix of the directory it runs in (the deepest match
+  wins), bd list --project web filters by prefix, and bd doctor checks each
+  prefix's directory and ID counter.
+
 Suppressing Doctor Warnings:
   Suppress specific bd doctor warnings by check name slug:
     bd config set doctor.suppress.pending-migrations true
@@ -202,6 +234,16 @@ var configSetCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		if key == "projects" {
+			if _, err := types.ParseProjectPrefixes(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid projects value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if err := validateStatusWorkflowConfig(key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid %s value: %v\n", key, err)
+			os.Exit(1)
+		}
 
 		if err := store.SetConfig(ctx, key, value); err != nil {
 			fmt.Fprintf(os.Stderr, "Error setting config: %v\n", err)
@@ -687,6 +729,16 @@ Examples:
 					os.Exit(1)
 				}
 			}
+			if p.key == "projects" {
+				if _, err := types.ParseProjectPrefixes(p.value); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: invalid projects value: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if err := validateStatusWorkflowConfig(p.key, p.value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid %s value: %v\n", p.key, err)
+				os.Exit(1)
+			}
 		}
 
 		// Phase 3: Separate into categories
@@ -782,7 +834,8 @@ var recognizedConfigPrefixes = []string{
 	"export.", "import.", "dolt.", "jira.", "linear.", "github.", "custom.",
 	"status.", "doctor.suppress.", "routing.", "sync.", "git.",
 	"directory.", "repos.", "external_projects.", "validation.",
-	"hierarchy.", "ai.", "backup.", "federation.",
+	"hierarchy.", "ai.", "backup.", "federation.", "aging.", "compact.",
+	"snapshots.", "mirror.", "digest.",
 }
 
 // recognizedConfigKeys lists valid non-namespaced config keys.
@@ -791,7 +844,7 @@ var recognizedConfigKeys = map[string]bool{
 	"identity": true, "no-push": true, "no-git-ops": true,
 	"create.require-description": true, "beads.role": true,
 	"auto_compact_enabled": true, "schema_version": true,
-	"output.title-length": true,
+	"output.title-length": true, "projects": true,
 }
 
 func isRecognizedConfigKey(key string) bool {
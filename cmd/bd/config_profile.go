@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+// configProfileFlag is the --profile flag. It selects a profile from
+// metadata.json by setting BD_PROFILE for this process.
+var configProfileFlag string
+
+// applyConfigProfile applies the config.yaml overrides of the active profile
+// in beadsDir. metadata.json overrides are applied by configfile.Load; this
+// covers the profile's "config" object. BD_* environment variables still win
+// over profile values, matching their precedence over config.yaml.
+func applyConfigProfile(beadsDir string) error {
+	if beadsDir == "" || configfile.ActiveProfileName() == "" {
+		return nil
+	}
+	cfg, err := configfile.Load(beadsDir)
+	if err != nil || cfg == nil {
+		return err
+	}
+	p, err := cfg.GetProfile(cfg.ActiveProfile())
+	if err != nil {
+		return err
+	}
+	for key, value := range p.Config {
+		if env := config.EnvVarName(key); env != "" {
+			debug.Logf("profile %s: %s overridden by %s\n", cfg.ActiveProfile(), key, env)
+			continue
+		}
+		config.Set(key, value)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedConfigProfiles(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "pf")
+	issue := bdCreate(t, bd, dir, "Profiled")
+
+	metadataPath := filepath.Join(beadsDir, "metadata.json")
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	metadata["profiles"] = map[string]interface{}{
+		"ci": map[string]interface{}{"config": map[string]interface{}{"json": true}},
+	}
+	data, _ = json.Marshal(metadata)
+	if err := os.WriteFile(metadataPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(env []string, args ...string) (string, error) {
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), env...)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		out, err := run(nil, "--profile", "ci", "list")
+		if err != nil {
+			t.Fatalf("bd --profile ci list: %v\n%s", err, out)
+		}
+		if !strings.HasPrefix(strings.TrimSpace(out), "[") || !strings.Contains(out, issue.ID) {
+			t.Errorf("profile json=true not applied:\n%s", out)
+		}
+	})
+
+	t.Run("env", func(t *testing.T) {
+		out, err := run([]string{"BD_PROFILE=ci"}, "list")
+		if err != nil || !strings.HasPrefix(strings.TrimSpace(out), "[") {
+			t.Errorf("BD_PROFILE=ci not applied (%v):\n%s", err, out)
+		}
+		// BD_* variables win over the profile.
+		out, err = run([]string{"BD_PROFILE=ci", "BD_JSON=false"}, "list")
+		if err != nil || strings.HasPrefix(strings.TrimSpace(out), "[") {
+			t.Errorf("BD_JSON=false should override the profile (%v):\n%s", err, out)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		out, err := run(nil, "--profile", "missing", "list")
+		if err == nil || !strings.Contains(out, `profile "missing" not found`) {
+			t.Errorf("expected unknown profile error, got %v:\n%s", err, out)
+		}
+	})
+}
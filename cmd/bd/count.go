@@ -18,7 +18,10 @@ var countCmd = &cobra.Command{
 	Long: `Count issues matching the specified filters.
 
 By default, returns the total count of issues matching the filters.
-Use --by-* flags to group counts by different attributes.
+Use --by-* flags to group counts by different attributes, or --group-by to
+group by one dimension or pivot by two (rows,columns). --group-by dimensions
+are status, type, label, assignee, priority, and week (the Monday, UTC, of
+the week each issue was created).
 
 Examples:
   bd count                          # Count all issues
@@ -29,6 +32,9 @@ Examples:
   bd count --by-assignee            # Group count by assignee
   bd count --by-label               # Group count by label
   bd count --assignee alice --by-status  # Count alice's issues by status
+  bd count --group-by week --status closed  # Closed issues by creation week
+  bd count --group-by assignee,status    # Pivot: assignees × statuses
+  bd count --group-by label,type --json  # Pivot as JSON
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		status, _ := cmd.Flags().GetString("status")
@@ -67,6 +73,7 @@ Examples:
 		byType, _ := cmd.Flags().GetBool("by-type")
 		byAssignee, _ := cmd.Flags().GetBool("by-assignee")
 		byLabel, _ := cmd.Flags().GetBool("by-label")
+		groupBySpec, _ := cmd.Flags().GetString("group-by")
 
 		// Determine groupBy value
 		groupBy := ""
@@ -95,6 +102,17 @@ Examples:
 		if groupCount > 1 {
 			FatalError("only one --by-* flag can be specified")
 		}
+		var groupDims []string
+		if groupBySpec != "" {
+			if groupCount > 0 {
+				FatalError("--group-by cannot be combined with --by-* flags")
+			}
+			dims, err := parseCountGroupBy(groupBySpec)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			groupDims = dims
+		}
 
 		// Normalize labels
 		labels = utils.NormalizeLabels(labels)
@@ -199,6 +217,11 @@ Examples:
 
 		filter.SkipWisps = true // bd count never needs ephemeral wisp results
 
+		if len(groupDims) > 0 {
+			runCountGroupBy(ctx, filter, groupDims)
+			return
+		}
+
 		// Q1: SQL COUNT(*) aggregate — avoids materializing all rows.
 		if groupBy == "" {
 			count, err := store.CountIssues(ctx, "", filter)
@@ -300,6 +323,7 @@ func init() {
 	countCmd.Flags().Bool("by-type", false, "Group count by issue type")
 	countCmd.Flags().Bool("by-assignee", false, "Group count by assignee")
 	countCmd.Flags().Bool("by-label", false, "Group count by label")
+	countCmd.Flags().String("group-by", "", "Group by status|type|label|assignee|priority|week, or pivot by two (e.g. assignee,status)")
 
 	rootCmd.AddCommand(countCmd)
 }
@@ -417,6 +417,39 @@ func TestEmbeddedCount(t *testing.T) {
 		}
 	})
 
+	// ===== --group-by pivots =====
+
+	t.Run("group_by_pivot", func(t *testing.T) {
+		m := bdCountJSON(t, bd, dir, "--group-by", "assignee,status", "--type", "bug")
+		raw, _ := json.Marshal(m)
+		var pivot CountPivot
+		if err := json.Unmarshal(raw, &pivot); err != nil {
+			t.Fatalf("parse pivot: %v\n%s", err, raw)
+		}
+		if pivot.Total != 2 || len(pivot.Rows) != 2 {
+			t.Fatalf("pivot = %s, want 2 bugs in 2 assignee rows", raw)
+		}
+		for _, row := range pivot.Rows {
+			if row.Counts["open"] != 1 || row.Total != 1 {
+				t.Errorf("row %s = %+v, want one open bug", row.Group, row)
+			}
+		}
+	})
+
+	t.Run("group_by_week_text", func(t *testing.T) {
+		out := bdCount(t, bd, dir, "--group-by", "week")
+		if !strings.Contains(out, "Total:") || !strings.Contains(out, "week") {
+			t.Errorf("expected a week table, got: %s", out)
+		}
+	})
+
+	t.Run("error_group_by_with_by_flag", func(t *testing.T) {
+		out := bdCountFail(t, bd, dir, "--group-by", "status", "--by-type")
+		if !strings.Contains(out, "cannot be combined") {
+			t.Errorf("expected combination error, got: %s", out)
+		}
+	})
+
 	// ===== Combined filters =====
 
 	t.Run("combined_filters", func(t *testing.T) {
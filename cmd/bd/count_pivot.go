@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Dimensions accepted by --group-by on bd count and bd stats.
+var countGroupDimensions = []string{"status", "type", "label", "assignee", "priority", "week"}
+
+// CountPivotRow is one row of a --group-by table. With a second dimension,
+// Counts holds the row's count per column.
+type CountPivotRow struct {
+	Group  string         `json:"group"`
+	Total  int            `json:"total"`
+	Counts map[string]int `json:"counts,omitempty"`
+}
+
+// CountPivot is the JSON document emitted by --group-by. Totals count
+// distinct issues, so an issue with two labels adds one to Total but
+// appears under both labels.
+type CountPivot struct {
+	GroupBy      []string         `json:"group_by"`
+	Total        int              `json:"total"`
+	Columns      []string         `json:"columns,omitempty"`
+	ColumnTotals map[string]int   `json:"column_totals,omitempty"`
+	Rows         []*CountPivotRow `json:"rows"`
+}
+
+// parseCountGroupBy parses "dim" or "row,column" for --group-by.
+func parseCountGroupBy(spec string) ([]string, error) {
+	var dims []string
+	for _, d := range strings.Split(spec, ",") {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if !slices.Contains(countGroupDimensions, d) {
+			return nil, fmt.Errorf("invalid --group-by %q (want %s, or two of them separated by a comma)", d, strings.Join(countGroupDimensions, ", "))
+		}
+		if slices.Contains(dims, d) {
+			return nil, fmt.Errorf("--group-by repeats %q", d)
+		}
+		dims = append(dims, d)
+	}
+	if len(dims) > 2 {
+		return nil, fmt.Errorf("--group-by takes at most two dimensions (rows,columns), got %d", len(dims))
+	}
+	return dims, nil
+}
+
+// countGroupKeys returns the groups an issue counts toward in dimension dim.
+// Weeks are the Monday (UTC) of the ISO week the issue was created in.
+func countGroupKeys(issue *types.Issue, dim string) []string {
+	switch dim {
+	case "status":
+		return []string{string(issue.Status)}
+	case "type":
+		return []string{string(issue.IssueType)}
+	case "label":
+		if len(issue.Labels) == 0 {
+			return []string{"(no labels)"}
+		}
+		return issue.Labels
+	case "assignee":
+		if issue.Assignee == "" {
+			return []string{"(unassigned)"}
+		}
+		return []string{issue.Assignee}
+	case "priority":
+		return []string{fmt.Sprintf("P%d", issue.Priority)}
+	case "week":
+		return []string{flowWeekStart(issue.CreatedAt).Format("2006-01-02")}
+	}
+	return nil
+}
+
+// pivotIssueCounts groups issues by one dimension, or pivots them by two.
+func pivotIssueCounts(issues []*types.Issue, dims []string) *CountPivot {
+	pivot := &CountPivot{GroupBy: dims, Total: len(issues), Rows: []*CountPivotRow{}}
+	rows := make(map[string]*CountPivotRow)
+	if len(dims) == 2 {
+		pivot.ColumnTotals = make(map[string]int)
+	}
+	for _, issue := range issues {
+		for _, rk := range countGroupKeys(issue, dims[0]) {
+			row, ok := rows[rk]
+			if !ok {
+				row = &CountPivotRow{Group: rk}
+				if len(dims) == 2 {
+					row.Counts = make(map[string]int)
+				}
+				rows[rk] = row
+				pivot.Rows = append(pivot.Rows, row)
+			}
+			row.Total++
+			if len(dims) == 2 {
+				for _, ck := range countGroupKeys(issue, dims[1]) {
+					row.Counts[ck]++
+				}
+			}
+		}
+		if len(dims) == 2 {
+			for _, ck := range countGroupKeys(issue, dims[1]) {
+				pivot.ColumnTotals[ck]++
+			}
+		}
+	}
+	slices.SortFunc(pivot.Rows, func(a, b *CountPivotRow) int { return strings.Compare(a.Group, b.Group) })
+	for col := range pivot.ColumnTotals {
+		pivot.Columns = append(pivot.Columns, col)
+	}
+	slices.Sort(pivot.Columns)
+	return pivot
+}
+
+// runCountGroupBy loads the issues matching filter and prints them grouped
+// by dims, for 'bd count --group-by' and 'bd stats --group-by'.
+func runCountGroupBy(ctx context.Context, filter types.IssueFilter, dims []string) {
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if slices.Contains(dims, "label") {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("failed to load labels: %v", err)
+		}
+		for _, issue := range issues {
+			issue.Labels = labels[issue.ID]
+		}
+	}
+
+	pivot := pivotIssueCounts(issues, dims)
+	if jsonOutput {
+		outputJSON(pivot)
+		return
+	}
+	printCountPivot(pivot)
+}
+
+// printCountPivot renders a pivot as an aligned table with totals.
+func printCountPivot(p *CountPivot) {
+	fmt.Printf("Total: %d\n\n", p.Total)
+	if len(p.Rows) == 0 {
+		return
+	}
+
+	header := p.GroupBy[0]
+	cols := []string{"count"}
+	if len(p.GroupBy) == 2 {
+		header += " \\ " + p.GroupBy[1]
+		cols = append(slices.Clone(p.Columns), "total")
+	}
+	labelWidth := len(header)
+	for _, row := range p.Rows {
+		labelWidth = max(labelWidth, len(row.Group))
+	}
+	widths := make([]int, len(cols))
+	for i, c := range cols {
+		widths[i] = max(len(c), len(fmt.Sprint(p.Total)))
+	}
+
+	line := func(label string, cells []string) string {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%-*s", labelWidth, label)
+		for i, c := range cells {
+			fmt.Fprintf(&b, "  %*s", widths[i], c)
+		}
+		return b.String()
+	}
+
+	fmt.Println(ui.RenderMuted(line(header, cols)))
+	for _, row := range p.Rows {
+		cells := []string{fmt.Sprint(row.Total)}
+		if len(p.GroupBy) == 2 {
+			cells = cells[:0]
+			for _, col := range p.Columns {
+				cells = append(cells, fmt.Sprint(row.Counts[col]))
+			}
+			cells = append(cells, fmt.Sprint(row.Total))
+		}
+		fmt.Println(line(row.Group, cells))
+	}
+	if len(p.GroupBy) == 2 {
+		cells := make([]string, 0, len(cols))
+		for _, col := range p.Columns {
+			cells = append(cells, fmt.Sprint(p.ColumnTotals[col]))
+		}
+		cells = append(cells, fmt.Sprint(p.Total))
+		fmt.Println(ui.RenderMuted(line("total", cells)))
+	}
+}
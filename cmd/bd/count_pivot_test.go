@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseCountGroupBy(t *testing.T) {
+	if dims, err := parseCountGroupBy(" Assignee , status"); err != nil || !reflect.DeepEqual(dims, []string{"assignee", "status"}) {
+		t.Errorf("parseCountGroupBy = %v, %v", dims, err)
+	}
+	for _, bad := range []string{"", "owner", "status,status", "status,type,label"} {
+		if _, err := parseCountGroupBy(bad); err == nil {
+			t.Errorf("parseCountGroupBy(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestPivotIssueCounts(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-1", Status: types.StatusOpen, Assignee: "alice", Labels: []string{"api", "ui"}, CreatedAt: monday},
+		{ID: "bd-2", Status: types.StatusClosed, Assignee: "alice", CreatedAt: monday.AddDate(0, 0, 3)},
+		{ID: "bd-3", Status: types.StatusOpen, Labels: []string{"api"}, Priority: 1, CreatedAt: monday.AddDate(0, 0, 7)},
+	}
+
+	byWeek := pivotIssueCounts(issues, []string{"week"})
+	if byWeek.Total != 3 || len(byWeek.Rows) != 2 ||
+		!reflect.DeepEqual(*byWeek.Rows[0], CountPivotRow{Group: "2026-03-02", Total: 2}) ||
+		!reflect.DeepEqual(*byWeek.Rows[1], CountPivotRow{Group: "2026-03-09", Total: 1}) {
+		t.Errorf("week rows = %+v %+v", byWeek.Rows[0], byWeek.Rows[1])
+	}
+
+	pivot := pivotIssueCounts(issues, []string{"label", "assignee"})
+	if pivot.Total != 3 {
+		t.Errorf("Total = %d, want 3 distinct issues", pivot.Total)
+	}
+	if want := []string{"(unassigned)", "alice"}; !reflect.DeepEqual(pivot.Columns, want) {
+		t.Errorf("Columns = %v, want %v", pivot.Columns, want)
+	}
+	if want := map[string]int{"(unassigned)": 1, "alice": 2}; !reflect.DeepEqual(pivot.ColumnTotals, want) {
+		t.Errorf("ColumnTotals = %v, want %v", pivot.ColumnTotals, want)
+	}
+	want := []CountPivotRow{
+		{Group: "(no labels)", Total: 1, Counts: map[string]int{"alice": 1}},
+		{Group: "api", Total: 2, Counts: map[string]int{"alice": 1, "(unassigned)": 1}},
+		{Group: "ui", Total: 1, Counts: map[string]int{"alice": 1}},
+	}
+	if len(pivot.Rows) != len(want) {
+		t.Fatalf("Rows = %d, want %d", len(pivot.Rows), len(want))
+	}
+	for i, row := range pivot.Rows {
+		if !reflect.DeepEqual(*row, want[i]) {
+			t.Errorf("Rows[%d] = %+v, want %+v", i, *row, want[i])
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -29,10 +30,11 @@ var createCmd = &cobra.Command{
 	Use:     "create [title]",
 	GroupID: "issues",
 	Aliases: []string{"new"},
-	Short:   "Create a new issue (or batch from markdown/graph JSON)",
+	Short:   "Create a new issue (or batch from markdown, YAML, or graph JSON)",
 	Args:    cobra.MinimumNArgs(0), // Changed to allow no args when using -f
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("create")
+		args = prepareInteractiveCreate(cmd, args)
 		if usesProxiedServer() {
 			in := gatherCreateInput(cmd, args)
 			runCreateProxiedServer(cmd, rootCtx, in)
@@ -40,12 +42,11 @@ var createCmd = &cobra.Command{
 		}
 		file, _ := cmd.Flags().GetString("file")
 		graphFile, _ := cmd.Flags().GetString("graph")
+		fromFile, _ := cmd.Flags().GetString("from-file")
+		checkBatchCreateSources(file, graphFile, fromFile)
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
-			if graphFile != "" {
-				FatalError("cannot specify both --file and --graph")
-			}
 			if len(args) > 0 {
 				FatalError("cannot specify both title and --file flag")
 			}
@@ -58,10 +59,10 @@ var createCmd = &cobra.Command{
 			return
 		}
 
-		// If graph flag is provided, batch-create a graph of issues atomically
-		if graphFile != "" {
+		// If graph or from-file flag is provided, batch-create a graph of issues atomically
+		if graphFile != "" || fromFile != "" {
 			if len(args) > 0 {
-				FatalError("cannot specify both title and --graph flag")
+				FatalError("cannot specify both title and --%s flag", batchCreateFlagName(graphFile))
 			}
 			graphDryRun, _ := cmd.Flags().GetBool("dry-run")
 			wisp, _ := cmd.Flags().GetBool("ephemeral")
@@ -73,6 +74,14 @@ var createCmd = &cobra.Command{
 			if err := graphOpts.Validate(); err != nil {
 				FatalError("invalid graph options: %v", err)
 			}
+			if fromFile != "" {
+				plan, err := readBatchCreateFile(fromFile)
+				if err != nil {
+					FatalError("%v", err)
+				}
+				applyGraphPlan(plan, graphDryRun, graphOpts)
+				return
+			}
 			createIssuesFromGraph(graphFile, graphDryRun, graphOpts)
 			return
 		}
@@ -443,6 +452,10 @@ var createCmd = &cobra.Command{
 		var inheritedLabels []string
 		if parentID != "" {
 			ctx := rootCtx
+			if err := checkChildDepth(parentID); err != nil {
+				FatalErrorWithHint(err.Error(),
+					fmt.Sprintf("Raise hierarchy.max-depth (currently %d) or choose a shallower parent", maxHierarchyDepth()))
+			}
 			_, err := parentLookupStore.GetIssue(ctx, parentID)
 			if err != nil {
 				if errors.Is(err, storage.ErrNotFound) {
@@ -476,6 +489,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// Validate explicit ID format if provided
+		var vanityAlias string
 		if explicitID != "" {
 			// Basic format validation for all issue types.
 			// Note: Orchestrator-specific agent ID validation (mayor, polecat, witness, etc.)
@@ -488,24 +502,26 @@ var createCmd = &cobra.Command{
 			// Validate prefix matches database prefix
 			ctx := createCtx
 
-			// Get database prefix and allowed prefixes from config.
-			// YAML config takes precedence over DB — in shared-server mode the DB
-			// may belong to a different project (GH#2469).
-			var dbPrefix, allowedPrefixes string
-			if yamlPrefix := config.GetString("issue-prefix"); yamlPrefix != "" {
-				dbPrefix = yamlPrefix
-			} else {
-				dbPrefix, _ = store.GetConfig(ctx, "issue_prefix") // Best effort: empty prefix is a valid fallback
-			}
-			allowedPrefixes, _ = store.GetConfig(ctx, "allowed_prefixes") // Best effort: empty means no prefix restriction
+			dbPrefix, allowedPrefixes := issueIDPrefixes(ctx, store)
 
 			// Use ValidateIDPrefixAllowed which handles multi-hyphen prefixes correctly (GH#1135)
 			// This checks if the ID starts with an allowed prefix, rather than extracting
 			// the prefix first (which can fail for IDs like "hq-cv-test" where "test" looks like a word)
 			if err := validation.ValidateIDPrefixAllowed(explicitID, dbPrefix, allowedPrefixes, forceCreate); err != nil {
-				FatalError("%v", err)
+				// A vanity name outside the issue prefixes becomes an alias
+				// of the generated hash ID instead.
+				if parentID != "" || wisp || validation.ValidateAlias(explicitID, dbPrefix, allowedPrefixes) != nil {
+					FatalError("%v", err)
+				}
+				vanityAlias, explicitID = explicitID, ""
 			}
 		}
+		if explicitID != "" && parentID == "" {
+			checkExplicitIDFree(createCtx, explicitID)
+		}
+		if vanityAlias != "" {
+			checkExplicitIDFree(createCtx, vanityAlias)
+		}
 
 		issue := buildCreateIssue(createIssueParams{
 			ID:                 explicitID,
@@ -538,6 +554,16 @@ var createCmd = &cobra.Command{
 
 		ctx := createCtx
 
+		// In a monorepo, issues created inside a sub-project directory take
+		// that sub-project's prefix.
+		if explicitID == "" && repoPath == "." && !wisp {
+			projects, err := loadProjectPrefixes(ctx, store)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			issue.PrefixOverride = projectPrefixForCwd(projects)
+		}
+
 		// Check if any dependencies are discovered-from type
 		// If so, inherit source_repo from the parent issue
 		var discoveredFromParentID string
@@ -573,6 +599,15 @@ var createCmd = &cobra.Command{
 			// If error getting parent or parent has no source_repo, continue with default
 		}
 
+		allowDuplicate, _ := cmd.Flags().GetBool("allow-duplicate")
+		guardCreateDuplicates(ctx, store, issue, allowDuplicate)
+
+		// Auto-assignment rules pick an owner when none was given.
+		var assignRule *types.AssignmentRule
+		if issue.Assignee == "" && !wisp && repoPath == "." && !slices.Contains(labels, BeadsTemplateLabel) {
+			assignRule = autoAssignOnCreate(ctx, store, issue)
+		}
+
 		if err := store.CreateIssue(ctx, issue, actor); err != nil {
 			FatalError("%v", err)
 		}
@@ -597,6 +632,15 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		if vanityAlias != "" {
+			if err := aliasStore(store).AddAlias(ctx, vanityAlias, issue.ID, actor); err != nil {
+				WarnError("failed to add alias %s -> %s: %v", vanityAlias, issue.ID, err)
+				vanityAlias = ""
+			} else {
+				postCreateWrites = true
+			}
+		}
+
 		// Add dependencies if specified (format: type:id or just id for default "blocks" type)
 		for _, depSpec := range deps {
 			depSpec = strings.TrimSpace(depSpec)
@@ -731,8 +775,14 @@ var createCmd = &cobra.Command{
 			fmt.Println(issue.ID)
 		} else {
 			fmt.Printf("%s Created issue: %s\n", ui.RenderPass("✓"), formatFeedbackID(issue.ID, issue.Title))
+			if vanityAlias != "" {
+				fmt.Printf("  Alias: %s\n", vanityAlias)
+			}
 			fmt.Printf("  Priority: P%d\n", issue.Priority)
 			fmt.Printf("  Status: %s\n", issue.Status)
+			if assignRule != nil {
+				fmt.Printf("  Assignee: %s %s\n", issue.Assignee, ui.RenderMuted("(rule "+assignRule.Name+")"))
+			}
 
 			// Show tip after successful create (direct mode only)
 			maybeShowTip(store)
@@ -743,6 +793,42 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// issueIDPrefixes returns the database prefix and the comma-separated
+// allowed prefixes (including monorepo project prefixes) that explicit IDs
+// are validated against. YAML config takes precedence over DB — in
+// shared-server mode the DB may belong to a different project (GH#2469).
+func issueIDPrefixes(ctx context.Context, s storage.DoltStorage) (dbPrefix, allowedPrefixes string) {
+	if yamlPrefix := config.GetString("issue-prefix"); yamlPrefix != "" {
+		dbPrefix = yamlPrefix
+	} else {
+		dbPrefix, _ = s.GetConfig(ctx, "issue_prefix") // Best effort: empty prefix is a valid fallback
+	}
+	allowedPrefixes, _ = s.GetConfig(ctx, "allowed_prefixes") // Best effort: empty means no prefix restriction
+	if projects, err := loadProjectPrefixes(ctx, s); err == nil && len(projects) > 0 {
+		if allowedPrefixes != "" {
+			allowedPrefixes += ","
+		}
+		allowedPrefixes += types.ProjectPrefixList(projects)
+	}
+	return dbPrefix, allowedPrefixes
+}
+
+// checkExplicitIDFree exits when id is already an issue ID or an alias.
+// CreateIssue would otherwise overwrite an existing issue with that ID.
+func checkExplicitIDFree(ctx context.Context, id string) {
+	if _, err := store.GetIssue(ctx, id); err == nil {
+		FatalErrorWithHint(fmt.Sprintf("issue %s already exists", id),
+			"Pick another ID, or use 'bd update "+id+"' to change the existing issue")
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		FatalError("failed to check for existing issue %s: %v", id, err)
+	}
+	if as, ok := storage.UnwrapStore(store).(storage.AliasStore); ok {
+		if target, err := as.ResolveAlias(ctx, id); err == nil {
+			FatalError("%s is already an alias of %s", id, target)
+		}
+	}
+}
+
 type createIssueParams struct {
 	ID                 string
 	Title              string
@@ -891,9 +977,12 @@ func createDepsAcceptedTypeList() string {
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
 	createCmd.Flags().String("graph", "", "Create a graph of issues with dependencies from JSON plan file")
+	createCmd.Flags().String("from-file", "", "Create many issues with labels and dependencies from a YAML/JSON file in one transaction")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
 	createCmd.Flags().Bool("silent", false, "Output only the issue ID (for scripting)")
 	createCmd.Flags().Bool("dry-run", false, "Preview what would be created without actually creating")
+	createCmd.Flags().BoolP("interactive", "i", false, "Prompt for title, type, priority, labels, and parent before creating")
+	createCmd.Flags().Bool("edit", false, "Write the title, description, design, and acceptance criteria in $EDITOR as Markdown")
 	registerPriorityFlag(createCmd, "2")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore|decision); custom types require types.custom config; aliases: enhancement/feat→feature, dec/adr→decision")
 	registerCommonIssueFlags(createCmd)
@@ -903,13 +992,14 @@ func init() {
 	createCmd.Flags().String("context", "", "Additional context for the issue")
 	createCmd.Flags().StringSlice("label", []string{}, "Alias for --labels")
 	_ = createCmd.Flags().MarkHidden("label") // Only fails if flag missing (caught in tests)
-	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'bd-42' for partitioning)")
+	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'bd-42' for partitioning); a name without the issue prefix (e.g., 'infra-login-bug') becomes an alias of the generated ID")
 	createCmd.Flags().String("parent", "", "Parent issue ID for hierarchical child (e.g., 'bd-a3f8e9')")
 	createCmd.Flags().Bool("no-inherit-labels", false, "Don't inherit labels from parent issue")
 	createCmd.Flags().StringSlice("deps", []string{}, "Dependencies in format 'type:id' or 'id' (e.g., 'discovered-from:bd-20,blocks:bd-15' or 'bd-20')")
 	createCmd.Flags().String("waits-for", "", "Spawner issue ID to wait for (creates waits-for dependency for fanout gate)")
 	createCmd.Flags().String("waits-for-gate", "all-children", "Gate type: all-children (wait for all) or any-children (wait for first)")
 	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
+	createCmd.Flags().Bool("allow-duplicate", false, "Create even if create.duplicate-check finds a duplicate open issue")
 	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing)")
 	createCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
 	createCmd.Flags().Bool("ephemeral", false, "Create as ephemeral (short-lived, subject to TTL compaction)")
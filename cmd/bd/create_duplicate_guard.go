@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// createDuplicateMatch is an existing open issue that a new issue would
+// duplicate.
+type createDuplicateMatch struct {
+	Issue      *types.Issue
+	Similarity float64 // 1 for exact matches
+	Exact      bool
+}
+
+// createDuplicateKey is the content key bd doctor's duplicate check groups
+// by: two non-closed issues with the same key are duplicates.
+func createDuplicateKey(issue *types.Issue) contentKey {
+	return contentKey{
+		title:              issue.Title,
+		description:        issue.Description,
+		design:             issue.Design,
+		acceptanceCriteria: issue.AcceptanceCriteria,
+		status:             string(issue.Status),
+	}
+}
+
+// findCreateDuplicates returns the non-closed issues that issue would
+// duplicate, most similar first: exact matches on bd doctor's content key
+// and, when threshold > 0, issues whose find-duplicates mechanical text
+// similarity is at least threshold.
+func findCreateDuplicates(ctx context.Context, s storage.DoltStorage, issue *types.Issue, threshold float64) ([]createDuplicateMatch, error) {
+	filter := types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}}
+	if threshold <= 0 {
+		// Exact matches share the title, so let the database narrow the
+		// candidates instead of loading every open issue.
+		filter.TitleContains = issue.Title
+	}
+	candidates, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	key := createDuplicateKey(issue)
+	var tokens map[string]int
+	if threshold > 0 {
+		tokens = tokenize(issueText(issue))
+	}
+
+	var matches []createDuplicateMatch
+	for _, candidate := range candidates {
+		if createDuplicateKey(candidate) == key {
+			matches = append(matches, createDuplicateMatch{Issue: candidate, Similarity: 1, Exact: true})
+			continue
+		}
+		if threshold <= 0 {
+			continue
+		}
+		other := tokenize(issueText(candidate))
+		similarity := (jaccardSimilarity(tokens, other) + cosineSimilarity(tokens, other)) / 2
+		if similarity >= threshold {
+			matches = append(matches, createDuplicateMatch{Issue: candidate, Similarity: similarity})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	return matches, nil
+}
+
+// guardCreateDuplicates applies create.duplicate-check before a single issue
+// is created: "warn" prints the open issues it would duplicate and proceeds,
+// "error" refuses to create it. --allow-duplicate skips the check, as do
+// ephemeral issues, which are expected to repeat.
+func guardCreateDuplicates(ctx context.Context, s storage.DoltStorage, issue *types.Issue, allowDuplicate bool) {
+	mode := config.GetString("create.duplicate-check")
+	if allowDuplicate || issue.Ephemeral || (mode != "warn" && mode != "error") {
+		return
+	}
+
+	matches, err := findCreateDuplicates(ctx, s, issue, config.GetFloat64("create.duplicate-threshold"))
+	if err != nil {
+		// The guard is advisory; never block a create on a failed lookup.
+		fmt.Fprintf(os.Stderr, "%s duplicate check failed: %v\n", ui.RenderWarn("⚠"), err)
+		return
+	}
+	if len(matches) == 0 {
+		return
+	}
+
+	lines := make([]string, 0, len(matches))
+	for _, m := range matches {
+		how := "exact match"
+		if !m.Exact {
+			how = fmt.Sprintf("%.0f%% similar", m.Similarity*100)
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s (%s)", m.Issue.ID, m.Issue.Title, how))
+	}
+	msg := fmt.Sprintf("%q duplicates %d open issue(s):\n%s", issue.Title, len(matches), strings.Join(lines, "\n"))
+
+	if mode == "error" {
+		FatalErrorWithHint(msg, "use --allow-duplicate to create it anyway")
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", ui.RenderWarn("⚠"), msg)
+}
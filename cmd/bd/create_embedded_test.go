@@ -1313,3 +1313,73 @@ func TestEmbeddedCreateConcurrent(t *testing.T) {
 
 	t.Logf("created %d issues across %d concurrent workers (%d succeeded), %d in DB", len(allIDs), numWorkers, successes, stats.TotalIssues)
 }
+
+func TestEmbeddedCreateDuplicateGuard(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "dg")
+
+	existing := bdCreate(t, bd, dir, "Fix flaky login test", "--description", "login_test.go times out on CI")
+
+	// createWith runs bd create with the duplicate guard configured by env.
+	createWith := func(mode, threshold string, args ...string) (string, error) {
+		cmd := exec.Command(bd, append([]string{"create"}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), "BD_CREATE_DUPLICATE_CHECK="+mode, "BD_CREATE_DUPLICATE_THRESHOLD="+threshold)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	dup := []string{"Fix flaky login test", "--description", "login_test.go times out on CI"}
+
+	t.Run("off_by_default", func(t *testing.T) {
+		out, err := createWith("none", "0", dup...)
+		if err != nil {
+			t.Fatalf("create failed with the guard off: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("error_refuses_exact_duplicate", func(t *testing.T) {
+		out, err := createWith("error", "0", dup...)
+		if err == nil {
+			t.Fatalf("create succeeded, want refusal:\n%s", out)
+		}
+		if !strings.Contains(out, existing.ID) || !strings.Contains(out, "--allow-duplicate") {
+			t.Errorf("output should name %s and suggest --allow-duplicate:\n%s", existing.ID, out)
+		}
+	})
+
+	t.Run("allow_duplicate_overrides", func(t *testing.T) {
+		out, err := createWith("error", "0", append(dup, "--allow-duplicate")...)
+		if err != nil {
+			t.Fatalf("create --allow-duplicate failed: %v\n%s", err, out)
+		}
+	})
+
+	t.Run("warn_proceeds", func(t *testing.T) {
+		out, err := createWith("warn", "0", dup...)
+		if err != nil {
+			t.Fatalf("create failed in warn mode: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, "duplicates") {
+			t.Errorf("warn mode should print a warning:\n%s", out)
+		}
+	})
+
+	t.Run("fuzzy_threshold", func(t *testing.T) {
+		near := []string{"Fix the flaky login test", "--description", "login_test.go times out on CI runs"}
+		if out, err := createWith("error", "0", near...); err != nil {
+			t.Fatalf("exact-only guard refused a near duplicate: %v\n%s", err, out)
+		}
+		out, err := createWith("error", "0.6", near...)
+		if err == nil {
+			t.Fatalf("create succeeded, want fuzzy refusal:\n%s", out)
+		}
+		if !strings.Contains(out, "% similar") {
+			t.Errorf("fuzzy match should report similarity:\n%s", out)
+		}
+	})
+}
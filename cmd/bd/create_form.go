@@ -101,6 +101,9 @@ func CreateIssueFromFormValues(ctx context.Context, s storage.DoltStorage, fv *c
 	var explicitID string
 	var inheritedLabels []string
 	if fv.ParentID != "" {
+		if err := checkChildDepth(fv.ParentID); err != nil {
+			return nil, err
+		}
 		_, err := s.GetIssue(ctx, fv.ParentID)
 		if err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
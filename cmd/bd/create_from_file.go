@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BatchCreateFile is the YAML/JSON document accepted by
+// `bd create --from-file`. It is a flatter, hand-writable alternative to a
+// graph plan: dependencies and parents are listed on each issue and may name
+// either another issue's key in the same file or an existing issue ID.
+type BatchCreateFile struct {
+	CommitMessage string             `json:"commit_message,omitempty" yaml:"commit_message,omitempty"`
+	Issues        []BatchCreateIssue `json:"issues" yaml:"issues"`
+}
+
+// BatchCreateIssue describes one issue in a BatchCreateFile.
+type BatchCreateIssue struct {
+	Key         string            `json:"key,omitempty" yaml:"key,omitempty"` // defaults to "issue-<n>"
+	Title       string            `json:"title" yaml:"title"`
+	Type        string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Assignee    string            `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	Priority    *int              `json:"priority,omitempty" yaml:"priority,omitempty"` // nil defaults to P2
+	Labels      []string          `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Parent      string            `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// DependsOn entries use the --deps syntax: "ref" or "type:ref", where
+	// ref is a key in this file or an existing issue ID. Type defaults to blocks.
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+}
+
+// readBatchCreateFile parses a --from-file document and converts it to a
+// graph plan. Unknown fields are rejected so typos don't silently drop data.
+func readBatchCreateFile(path string) (*GraphApplyPlan, error) {
+	var file BatchCreateFile
+	if err := decodeIssueSpecFile(path, &file); err != nil {
+		return nil, err
+	}
+
+	plan, err := batchCreateToGraphPlan(&file)
+	if err != nil {
+		return nil, err
+	}
+	if plan.CommitMessage == "" {
+		plan.CommitMessage = fmt.Sprintf("bd: create %d issue(s) from %s", len(plan.Nodes), filepath.Base(path))
+	}
+	return plan, nil
+}
+
+// decodeIssueSpecFile strictly decodes a YAML or JSON issue document into v.
+// Files ending in .json are parsed as JSON; anything else as YAML.
+func decodeIssueSpecFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-provided path is intentional
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(v)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(v)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// batchCreateToGraphPlan converts a BatchCreateFile into the equivalent graph
+// plan. References that match a key in the file resolve to that issue; any
+// other reference is treated as an existing issue ID.
+func batchCreateToGraphPlan(file *BatchCreateFile) (*GraphApplyPlan, error) {
+	if len(file.Issues) == 0 {
+		return nil, fmt.Errorf("no issues to create")
+	}
+
+	keys := make(map[string]bool, len(file.Issues))
+	nodes := make([]GraphApplyNode, 0, len(file.Issues))
+	for i, issue := range file.Issues {
+		key := issue.Key
+		if key == "" {
+			key = fmt.Sprintf("issue-%d", i+1)
+		}
+		if keys[key] {
+			return nil, fmt.Errorf("issue %d: duplicate key %q", i+1, key)
+		}
+		keys[key] = true
+		nodes = append(nodes, GraphApplyNode{
+			Key:         key,
+			Title:       issue.Title,
+			Type:        issue.Type,
+			Description: issue.Description,
+			Assignee:    issue.Assignee,
+			Priority:    issue.Priority,
+			Labels:      issue.Labels,
+			Metadata:    issue.Metadata,
+		})
+	}
+
+	plan := &GraphApplyPlan{CommitMessage: file.CommitMessage, Nodes: nodes}
+	for i, issue := range file.Issues {
+		node := &plan.Nodes[i]
+		if issue.Parent != "" {
+			if keys[issue.Parent] {
+				node.ParentKey = issue.Parent
+			} else {
+				node.ParentID = issue.Parent
+			}
+		}
+		for _, spec := range issue.DependsOn {
+			depType, ref, err := parseBatchDependency(spec)
+			if err != nil {
+				return nil, fmt.Errorf("issue %q: %w", node.Key, err)
+			}
+			edge := GraphApplyEdge{FromKey: node.Key, Type: depType}
+			if keys[ref] {
+				edge.ToKey = ref
+			} else {
+				edge.ToID = ref
+			}
+			plan.Edges = append(plan.Edges, edge)
+		}
+	}
+	return plan, nil
+}
+
+// parseBatchDependency splits a depends_on entry ("ref" or "type:ref") into
+// its dependency type, defaulting to blocks, and reference.
+func parseBatchDependency(spec string) (depType, ref string, err error) {
+	depType, ref = "blocks", strings.TrimSpace(spec)
+	if t, r, ok := strings.Cut(ref, ":"); ok {
+		depType, ref = strings.TrimSpace(t), strings.TrimSpace(r)
+	}
+	if ref == "" {
+		return "", "", fmt.Errorf("empty dependency in %q", spec)
+	}
+	return depType, ref, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBatchCreateToGraphPlan(t *testing.T) {
+	p1 := 1
+	file := &BatchCreateFile{
+		Issues: []BatchCreateIssue{
+			{Key: "epic", Title: "Epic", Type: "epic", Labels: []string{"q3"}},
+			{Key: "api", Title: "API", Parent: "epic", Priority: &p1, DependsOn: []string{"bd-existing"}},
+			{Title: "UI", Parent: "bd-old-epic", DependsOn: []string{"api", "related: epic"}},
+		},
+	}
+
+	plan, err := batchCreateToGraphPlan(file)
+	if err != nil {
+		t.Fatalf("batchCreateToGraphPlan: %v", err)
+	}
+
+	if got := plan.Nodes[2].Key; got != "issue-3" {
+		t.Errorf("default key = %q, want issue-3", got)
+	}
+	if plan.Nodes[1].ParentKey != "epic" || plan.Nodes[1].ParentID != "" {
+		t.Errorf("api parent = key %q id %q, want key epic", plan.Nodes[1].ParentKey, plan.Nodes[1].ParentID)
+	}
+	if plan.Nodes[2].ParentID != "bd-old-epic" || plan.Nodes[2].ParentKey != "" {
+		t.Errorf("ui parent = key %q id %q, want id bd-old-epic", plan.Nodes[2].ParentKey, plan.Nodes[2].ParentID)
+	}
+	if !reflect.DeepEqual(plan.Nodes[0].Labels, []string{"q3"}) {
+		t.Errorf("labels = %v, want [q3]", plan.Nodes[0].Labels)
+	}
+
+	wantEdges := []GraphApplyEdge{
+		{FromKey: "api", ToID: "bd-existing", Type: "blocks"},
+		{FromKey: "issue-3", ToKey: "api", Type: "blocks"},
+		{FromKey: "issue-3", ToKey: "epic", Type: "related"},
+	}
+	if !reflect.DeepEqual(plan.Edges, wantEdges) {
+		t.Errorf("edges = %+v\nwant %+v", plan.Edges, wantEdges)
+	}
+	if err := validateGraphApplyPlan(plan, nil); err != nil {
+		t.Errorf("converted plan does not validate: %v", err)
+	}
+}
+
+func TestBatchCreateToGraphPlanErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		file BatchCreateFile
+		want string
+	}{
+		{name: "empty", file: BatchCreateFile{}, want: "no issues"},
+		{
+			name: "duplicate key",
+			file: BatchCreateFile{Issues: []BatchCreateIssue{{Key: "a", Title: "A"}, {Key: "a", Title: "B"}}},
+			want: `duplicate key "a"`,
+		},
+		{
+			name: "empty dependency",
+			file: BatchCreateFile{Issues: []BatchCreateIssue{{Key: "a", Title: "A", DependsOn: []string{"blocks:"}}}},
+			want: "empty dependency",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := batchCreateToGraphPlan(&tt.file)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadBatchCreateFile(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "issues.yaml")
+	yamlDoc := `issues:
+  - key: a
+    title: First
+    labels: [backend]
+  - key: b
+    title: Second
+    depends_on: [a]
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	jsonPath := filepath.Join(dir, "issues.json")
+	jsonDoc := `{"commit_message": "seed", "issues": [{"key": "a", "title": "First", "labels": ["backend"]}, {"key": "b", "title": "Second", "depends_on": ["a"]}]}`
+	if err := os.WriteFile(jsonPath, []byte(jsonDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fromYAML, err := readBatchCreateFile(yamlPath)
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if fromYAML.CommitMessage != "bd: create 2 issue(s) from issues.yaml" {
+		t.Errorf("default commit message = %q", fromYAML.CommitMessage)
+	}
+	fromJSON, err := readBatchCreateFile(jsonPath)
+	if err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if fromJSON.CommitMessage != "seed" {
+		t.Errorf("commit message = %q, want seed", fromJSON.CommitMessage)
+	}
+	if !reflect.DeepEqual(fromYAML.Nodes, fromJSON.Nodes) || !reflect.DeepEqual(fromYAML.Edges, fromJSON.Edges) {
+		t.Errorf("YAML and JSON produced different plans:\n%+v\n%+v", fromYAML, fromJSON)
+	}
+
+	typoPath := filepath.Join(dir, "typo.yml")
+	if err := os.WriteFile(typoPath, []byte("issues:\n  - title: A\n    dependson: [b]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readBatchCreateFile(typoPath); err == nil {
+		t.Error("expected unknown field to be rejected")
+	}
+}
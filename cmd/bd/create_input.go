@@ -19,6 +19,7 @@ import (
 type createInput struct {
 	markdownFile       string
 	graphFile          string
+	fromFile           string
 	title              string
 	explicitID         string
 	parentID           string
@@ -67,11 +68,10 @@ func gatherCreateInput(cmd *cobra.Command, args []string) createInput {
 
 	in.markdownFile, _ = cmd.Flags().GetString("file")
 	in.graphFile, _ = cmd.Flags().GetString("graph")
+	in.fromFile, _ = cmd.Flags().GetString("from-file")
 	in.dryRun, _ = cmd.Flags().GetBool("dry-run")
 
-	if in.markdownFile != "" && in.graphFile != "" {
-		FatalError("cannot specify both --file and --graph")
-	}
+	checkBatchCreateSources(in.markdownFile, in.graphFile, in.fromFile)
 	if in.markdownFile != "" {
 		if len(args) > 0 {
 			FatalError("cannot specify both title and --file flag")
@@ -81,9 +81,9 @@ func gatherCreateInput(cmd *cobra.Command, args []string) createInput {
 		}
 		rejectSingleIssueFlagsForMarkdown(cmd)
 	}
-	if in.graphFile != "" {
+	if in.graphFile != "" || in.fromFile != "" {
 		if len(args) > 0 {
-			FatalError("cannot specify both title and --graph flag")
+			FatalError("cannot specify both title and --%s flag", batchCreateFlagName(in.graphFile))
 		}
 		rejectSingleIssueFlagsForGraph(cmd)
 	}
@@ -100,7 +100,7 @@ func gatherCreateInput(cmd *cobra.Command, args []string) createInput {
 	}
 
 	titleFlag, _ := cmd.Flags().GetString("title")
-	in.title = resolveTitle(args, titleFlag, in.markdownFile, in.graphFile)
+	in.title = resolveTitle(args, titleFlag, in.markdownFile, in.graphFile+in.fromFile)
 
 	in.description, _ = getDescriptionFlag(cmd)
 	skills, _ := cmd.Flags().GetString("skills")
@@ -124,7 +124,7 @@ func gatherCreateInput(cmd *cobra.Command, args []string) createInput {
 	in.appendNotes, _ = cmd.Flags().GetString("append-notes")
 	in.specID, _ = cmd.Flags().GetString("spec-id")
 
-	if in.markdownFile == "" && in.graphFile == "" {
+	if in.markdownFile == "" && in.graphFile == "" && in.fromFile == "" {
 		if in.description == "" && !isTestIssue(in.title) {
 			if config.GetBool("create.require-description") {
 				FatalError("description is required (set create.require-description: false in config.yaml to disable)")
@@ -257,7 +257,7 @@ var singleIssueOnlyFlags = []string{
 	"labels", "label", "skills", "context",
 	"event-category", "event-actor", "event-target", "event-payload",
 	"due", "defer",
-	"metadata", "estimate", "force", "wisp-type",
+	"metadata", "estimate", "force", "allow-duplicate", "wisp-type",
 }
 
 func rejectSingleIssueFlagsForMarkdown(cmd *cobra.Command) {
@@ -268,6 +268,30 @@ func rejectSingleIssueFlagsForMarkdown(cmd *cobra.Command) {
 	}
 }
 
+// checkBatchCreateSources rejects combining the batch input flags; each
+// describes the whole set of issues to create.
+func checkBatchCreateSources(markdownFile, graphFile, fromFile string) {
+	set := make([]string, 0, 3)
+	for _, f := range []struct{ name, value string }{
+		{"--file", markdownFile}, {"--graph", graphFile}, {"--from-file", fromFile},
+	} {
+		if f.value != "" {
+			set = append(set, f.name)
+		}
+	}
+	if len(set) > 1 {
+		FatalError("cannot specify both %s and %s", set[0], set[1])
+	}
+}
+
+// batchCreateFlagName names the graph-style flag in use for error messages.
+func batchCreateFlagName(graphFile string) string {
+	if graphFile != "" {
+		return "graph"
+	}
+	return "from-file"
+}
+
 func rejectSingleIssueFlagsForGraph(cmd *cobra.Command) {
 	for _, name := range singleIssueOnlyFlags {
 		if cmd.Flags().Changed(name) {
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// createWizardValues are the fields bd create -i asks for.
+type createWizardValues struct {
+	Title     string
+	IssueType string
+	Priority  string
+	Labels    []string
+	ParentID  string
+}
+
+// createWizard prompts for createWizardValues one line at a time. Each answer
+// is validated before moving on; an invalid answer repeats the prompt.
+type createWizard struct {
+	ctx         context.Context
+	in          *bufio.Reader
+	closer      io.Closer
+	out         io.Writer
+	customTypes []string
+	// checkParent reports whether a parent ID exists. Nil skips the check
+	// (proxied mode validates the parent when the issue is created).
+	checkParent func(id string) error
+}
+
+// run prompts for every field, offering defaults as the current answers.
+func (w *createWizard) run(defaults createWizardValues) (createWizardValues, error) {
+	var v createWizardValues
+	var err error
+
+	if v.Title, err = w.ask("Title", defaults.Title, validateWizardTitle); err != nil {
+		return v, err
+	}
+	if v.IssueType, err = w.ask("Type", defaults.IssueType, w.validateType); err != nil {
+		return v, err
+	}
+	v.IssueType = string(types.IssueType(v.IssueType).Normalize())
+	if v.Priority, err = w.ask("Priority (0-4)", defaults.Priority, func(s string) error {
+		_, err := validation.ValidatePriority(s)
+		return err
+	}); err != nil {
+		return v, err
+	}
+	labels, err := w.ask("Labels (comma-separated)", strings.Join(defaults.Labels, ","), validateWizardLabels)
+	if err != nil {
+		return v, err
+	}
+	v.Labels = splitWizardList(labels)
+	if v.ParentID, err = w.ask("Parent epic or molecule ID", defaults.ParentID, w.validateParent); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// ask prints one prompt and reads answers until validate accepts one. An
+// empty answer takes the default.
+func (w *createWizard) ask(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", label)
+		}
+		line, err := readLineWithContext(w.ctx, w.in, w.closer)
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			if errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("input ended before %s was answered", strings.ToLower(label))
+			}
+			return "", err
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if verr := validate(answer); verr != nil {
+			fmt.Fprintf(w.out, "  %s %v\n", ui.RenderFail("✗"), verr)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+func validateWizardTitle(s string) error {
+	if s == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(s) > 500 {
+		return fmt.Errorf("title must be 500 characters or less")
+	}
+	return nil
+}
+
+func (w *createWizard) validateType(s string) error {
+	if s == "" {
+		return fmt.Errorf("type is required")
+	}
+	if !types.IssueType(s).Normalize().IsValidWithCustom(w.customTypes) {
+		return fmt.Errorf("invalid type %q (allowed: bug, feature, task, epic, chore, decision, or a configured custom type)", s)
+	}
+	return nil
+}
+
+func validateWizardLabels(s string) error {
+	for _, label := range splitWizardList(s) {
+		if strings.ContainsAny(label, " \t") {
+			return fmt.Errorf("label %q contains whitespace", label)
+		}
+	}
+	return nil
+}
+
+func (w *createWizard) validateParent(s string) error {
+	if s == "" || w.checkParent == nil {
+		return nil
+	}
+	return w.checkParent(s)
+}
+
+// splitWizardList splits a comma-separated answer, dropping empty entries.
+func splitWizardList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// createEditorFields are the fields bd create --edit reads back from the
+// Markdown buffer.
+type createEditorFields struct {
+	Title              string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+}
+
+const createEditorHelp = `<!--
+Write the title after "# " and each field under its heading; leave a
+section empty to skip it. Other "##" headings stay part of the section
+above them. Delete the title to cancel. HTML comments are ignored.
+-->
+`
+
+// renderCreateEditorBuffer renders f as the Markdown buffer opened by
+// bd create --edit.
+func renderCreateEditorBuffer(f createEditorFields) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", f.Title)
+	for _, section := range []struct{ heading, body string }{
+		{"Description", f.Description},
+		{"Design", f.Design},
+		{"Acceptance Criteria", f.AcceptanceCriteria},
+	} {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.heading)
+		if section.body != "" {
+			b.WriteString(section.body)
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(createEditorHelp)
+	return b.String()
+}
+
+// parseCreateEditorBuffer reads the title and sections back from an edited
+// buffer. Text between the title and the first section heading belongs to
+// the description.
+func parseCreateEditorBuffer(buf string) (createEditorFields, error) {
+	var f createEditorFields
+	sections := map[string]*strings.Builder{
+		"description":         {},
+		"design":              {},
+		"acceptance criteria": {},
+	}
+	current := sections["description"]
+	titleSeen := false
+
+	for _, line := range strings.Split(stripHTMLComments(buf), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !titleSeen && (trimmed == "#" || strings.HasPrefix(trimmed, "# ")) {
+			f.Title = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			titleSeen = true
+			continue
+		}
+		if strings.HasPrefix(trimmed, "## ") {
+			heading := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "## ")))
+			if heading == "acceptance" {
+				heading = "acceptance criteria"
+			}
+			if sb, ok := sections[heading]; ok {
+				current = sb
+				continue
+			}
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+
+	f.Description = strings.TrimSpace(sections["description"].String())
+	f.Design = strings.TrimSpace(sections["design"].String())
+	f.AcceptanceCriteria = strings.TrimSpace(sections["acceptance criteria"].String())
+	if f.Title == "" {
+		return f, fmt.Errorf("title is empty; issue not created")
+	}
+	if err := validateWizardTitle(f.Title); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// stripHTMLComments removes <!-- ... --> blocks, including unterminated ones.
+func stripHTMLComments(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "<!--")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:start])
+		end := strings.Index(s[start:], "-->")
+		if end < 0 {
+			return b.String()
+		}
+		s = s[start+end+len("-->"):]
+	}
+}
+
+// editCreateFields opens f in $EDITOR and returns the fields read back.
+func editCreateFields(f createEditorFields) (createEditorFields, error) {
+	editor := findEditor()
+	if editor == "" {
+		return f, fmt.Errorf("no editor found. Set $EDITOR or $VISUAL environment variable")
+	}
+	tmpFile, err := os.CreateTemp("", "bd-create-*.md")
+	if err != nil {
+		return f, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmpFile.WriteString(renderCreateEditorBuffer(f)); err != nil {
+		_ = tmpFile.Close()
+		return f, fmt.Errorf("writing temp file: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	if err := runEditor(editor, tmpPath); err != nil {
+		return f, fmt.Errorf("running editor: %w", err)
+	}
+	// #nosec G304 -- tmpPath was created above
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return f, fmt.Errorf("reading edited file: %w", err)
+	}
+	return parseCreateEditorBuffer(string(edited))
+}
+
+// prepareInteractiveCreate runs bd create -i and --edit, writing the answers
+// back into the command's flags so the direct and proxied create paths read
+// them like any other flag. It returns the positional args to create with.
+func prepareInteractiveCreate(cmd *cobra.Command, args []string) []string {
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	edit, _ := cmd.Flags().GetBool("edit")
+	if !interactive && !edit {
+		return args
+	}
+	for _, batch := range []string{"file", "graph", "from-file"} {
+		if cmd.Flags().Changed(batch) {
+			FatalError("-i/--edit create a single issue and cannot be combined with --%s", batch)
+		}
+	}
+
+	title, _ := cmd.Flags().GetString("title")
+	if len(args) > 0 {
+		title = args[0]
+	}
+
+	if interactive {
+		issueType, _ := cmd.Flags().GetString("type")
+		priority, _ := cmd.Flags().GetString("priority")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		parent, _ := cmd.Flags().GetString("parent")
+
+		w := &createWizard{
+			ctx:         rootCtx,
+			in:          bufio.NewReader(os.Stdin),
+			closer:      os.Stdin,
+			out:         os.Stderr,
+			customTypes: loadEmbeddedCustomTypes(),
+		}
+		if store != nil {
+			w.checkParent = func(id string) error {
+				if _, err := store.GetIssue(rootCtx, id); err != nil {
+					if errors.Is(err, storage.ErrNotFound) {
+						return fmt.Errorf("parent issue %s not found", id)
+					}
+					return fmt.Errorf("checking parent issue: %w", err)
+				}
+				return nil
+			}
+		}
+		v, err := w.run(createWizardValues{
+			Title:     title,
+			IssueType: issueType,
+			Priority:  priority,
+			Labels:    labels,
+			ParentID:  parent,
+		})
+		if err != nil {
+			if isCanceled(err) {
+				exitCanceled()
+			}
+			FatalError("%v", err)
+		}
+		title = v.Title
+		setCreateFlag(cmd, "type", v.IssueType)
+		setCreateFlag(cmd, "priority", v.Priority)
+		setCreateFlag(cmd, "parent", v.ParentID)
+		if lv, ok := cmd.Flags().Lookup("labels").Value.(interface{ Replace([]string) error }); ok {
+			if err := lv.Replace(v.Labels); err != nil {
+				FatalError("setting labels: %v", err)
+			}
+			cmd.Flags().Lookup("labels").Changed = len(v.Labels) > 0
+		}
+	}
+
+	if edit {
+		description, _ := getDescriptionFlag(cmd)
+		design, _ := getDesignFlag(cmd)
+		acceptance, _ := cmd.Flags().GetString("acceptance")
+		f, err := editCreateFields(createEditorFields{
+			Title:              title,
+			Description:        description,
+			Design:             design,
+			AcceptanceCriteria: acceptance,
+		})
+		if err != nil {
+			FatalError("%v", err)
+		}
+		title = f.Title
+		// The buffer replaces whichever file or alias flags seeded it.
+		for _, name := range []string{"body-file", "description-file", "stdin", "body", "message", "design-file"} {
+			if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+				_ = flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			}
+		}
+		setCreateFlag(cmd, "description", f.Description)
+		setCreateFlag(cmd, "design", f.Design)
+		setCreateFlag(cmd, "acceptance", f.AcceptanceCriteria)
+	}
+
+	setCreateFlag(cmd, "title", "")
+	return []string{title}
+}
+
+// setCreateFlag sets a create flag from an interactive answer.
+func setCreateFlag(cmd *cobra.Command, name, value string) {
+	if err := cmd.Flags().Set(name, value); err != nil {
+		FatalError("setting --%s: %v", name, err)
+	}
+}
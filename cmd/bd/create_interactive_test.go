@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateWizardRepromptsInvalidAnswers(t *testing.T) {
+	input := strings.Join([]string{
+		"",              // title: required, no default
+		"Fix login",     // title
+		"bogus",         // type: invalid
+		"",              // type: default
+		"high",          // priority: invalid
+		"P1",            // priority
+		"ui, has space", // labels: invalid
+		"ui,,auth",      // labels
+		"bd-missing",    // parent: not found
+		"bd-epic",       // parent
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	w := &createWizard{
+		ctx: context.Background(),
+		in:  bufio.NewReader(strings.NewReader(input)),
+		out: &out,
+		checkParent: func(id string) error {
+			if id != "bd-epic" {
+				return fmt.Errorf("parent issue %s not found", id)
+			}
+			return nil
+		},
+	}
+	got, err := w.run(createWizardValues{IssueType: "task", Priority: "2"})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	want := createWizardValues{
+		Title:     "Fix login",
+		IssueType: "task",
+		Priority:  "P1",
+		Labels:    []string{"ui", "auth"},
+		ParentID:  "bd-epic",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("run = %+v, want %+v", got, want)
+	}
+	for _, msg := range []string{"title is required", `invalid type "bogus"`, `invalid priority "high"`, "contains whitespace", "bd-missing not found"} {
+		if !strings.Contains(out.String(), msg) {
+			t.Errorf("output missing %q:\n%s", msg, out.String())
+		}
+	}
+}
+
+func TestCreateWizardInputEnds(t *testing.T) {
+	w := &createWizard{
+		ctx: context.Background(),
+		in:  bufio.NewReader(strings.NewReader("Title only\n")),
+		out: &bytes.Buffer{},
+	}
+	_, err := w.run(createWizardValues{})
+	if err == nil || !strings.Contains(err.Error(), "input ended before type") {
+		t.Fatalf("run error = %v, want input ended before type", err)
+	}
+}
+
+func TestCreateEditorBufferRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := createEditorFields{
+		Title:              "Add retries",
+		Description:        "Calls fail.\n\n## Required Skills\nGo",
+		AcceptanceCriteria: "- retries 3 times",
+	}
+	got, err := parseCreateEditorBuffer(renderCreateEditorBuffer(in))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got != in {
+		t.Errorf("round trip = %+v, want %+v", got, in)
+	}
+}
+
+func TestParseCreateEditorBuffer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		buf     string
+		want    createEditorFields
+		wantErr string
+	}{
+		{
+			name: "text before first heading is description",
+			buf:  "# T\nintro\n## design\nD\n## Acceptance\nA\n",
+			want: createEditorFields{Title: "T", Description: "intro", Design: "D", AcceptanceCriteria: "A"},
+		},
+		{
+			name: "comments ignored",
+			buf:  "<!-- # Not a title -->\n# T\n## Description\nkeep<!-- drop -->\n<!-- unterminated",
+			want: createEditorFields{Title: "T", Description: "keep"},
+		},
+		{name: "title deleted", buf: "#\n## Description\nx\n", wantErr: "title is empty"},
+		{name: "empty buffer", buf: "", wantErr: "title is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCreateEditorBuffer(tt.buf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parse = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ func runCreateProxiedServer(cmd *cobra.Command, ctx context.Context, in createIn
 		FatalError("--repo is not supported with --proxied-server")
 	}
 	switch {
-	case in.graphFile != "":
+	case in.graphFile != "" || in.fromFile != "":
 		runCreateProxiedGraph(cmd, ctx, in)
 	case in.markdownFile != "":
 		runCreateProxiedMarkdown(cmd, ctx, in)
@@ -342,7 +342,16 @@ func parseMarkdownDepSpecs(deps []string, templateTitle string) ([]domain.Depend
 	return out, nil
 }
 
-func runCreateProxiedGraph(_ *cobra.Command, ctx context.Context, in createInput) {
+// loadProxiedGraphPlan reads the plan named by --graph or --from-file.
+func loadProxiedGraphPlan(in createInput) GraphApplyPlan {
+	if in.fromFile != "" {
+		plan, err := readBatchCreateFile(in.fromFile)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		return *plan
+	}
+
 	data, err := os.ReadFile(in.graphFile) // #nosec G304 -- user-provided path is intentional
 	if err != nil {
 		FatalError("reading graph plan: %v", err)
@@ -355,6 +364,11 @@ func runCreateProxiedGraph(_ *cobra.Command, ctx context.Context, in createInput
 	if err := json.Unmarshal(data, &plan); err != nil {
 		FatalError("parsing graph plan: %v", err)
 	}
+	return plan
+}
+
+func runCreateProxiedGraph(_ *cobra.Command, ctx context.Context, in createInput) {
+	plan := loadProxiedGraphPlan(in)
 
 	if in.dryRun {
 		if uowProvider == nil {
@@ -386,6 +400,7 @@ func runCreateProxiedGraph(_ *cobra.Command, ctx context.Context, in createInput
 	domainPlan := buildDomainGraphPlan(plan, in)
 
 	var result domain.GraphApplyResult
+	var err error
 	if in.ephemeral {
 		result, err = uw.IssueUseCase().ApplyWispGraph(ctx, domainPlan, in.createdBy)
 	} else {
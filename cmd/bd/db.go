@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+var dbCmd = &cobra.Command{
+	Use:     "db",
+	GroupID: "maint",
+	Short:   "Database maintenance commands",
+}
+
+var dbOptimizeDryRun bool
+
+var dbOptimizeCmd = &cobra.Command{
+	Use:   "optimize",
+	Short: "Reclaim disk space and refresh index statistics",
+	Long: `Compact the database on disk and refresh the statistics the query
+planner uses.
+
+Runs Dolt garbage collection, which drops chunks no longer referenced by
+any commit or working set (burned wisps and rewritten issues leave these
+behind), then ANALYZE TABLE on every table so index statistics reflect the
+current data. Commit history is kept; use 'bd flatten' to squash it.
+
+Index statistics are only collected by a Dolt sql-server; in embedded mode
+that step is skipped.
+
+'bd doctor' warns when the database directory has grown far beyond its
+table data and suggests this command.
+
+Examples:
+  bd db optimize             # GC and refresh statistics, report space reclaimed
+  bd db optimize --dry-run   # Show the current on-disk size only
+  bd db optimize --json      # Machine-readable result`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		if !dbOptimizeDryRun {
+			CheckReadonly("db optimize")
+		}
+		ctx := rootCtx
+		start := time.Now()
+
+		sizeBefore, err := databaseDirSize()
+		if err != nil {
+			WarnError("could not measure database size: %v", err)
+		}
+
+		if dbOptimizeDryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"dry_run":     true,
+					"size_before": sizeBefore,
+				})
+				return
+			}
+			fmt.Printf("Database size: %s\n", formatBytes(sizeBefore))
+			fmt.Printf("Run without --dry-run to garbage collect and refresh statistics.\n")
+			return
+		}
+
+		gc, ok := storage.UnwrapStore(store).(storage.GarbageCollector)
+		if !ok {
+			FatalErrorRespectJSON("storage backend does not support garbage collection")
+		}
+		if !jsonOutput {
+			fmt.Println("Running Dolt garbage collection...")
+		}
+		if err := gc.DoltGC(ctx); err != nil {
+			FatalErrorRespectJSON("dolt gc failed: %v", err)
+		}
+
+		var analyzed []string
+		statsRefreshed := false
+		refresher, statsSupported := storage.UnwrapStore(store).(storage.StatisticsRefresher)
+		if statsSupported {
+			if !jsonOutput {
+				fmt.Println("Refreshing index statistics...")
+			}
+			analyzed, err = refresher.AnalyzeTables(ctx)
+			if err != nil {
+				WarnError("refreshing index statistics failed: %v", err)
+			} else {
+				statsRefreshed = true
+			}
+		}
+
+		sizeAfter, err := databaseDirSize()
+		if err != nil {
+			WarnError("could not measure database size after gc: %v", err)
+		}
+		freed := sizeBefore - sizeAfter
+		if freed < 0 {
+			freed = 0 // GC may not always reduce size
+		}
+		elapsed := time.Since(start)
+
+		if jsonOutput {
+			if analyzed == nil {
+				analyzed = []string{}
+			}
+			outputJSON(map[string]interface{}{
+				"size_before":     sizeBefore,
+				"size_after":      sizeAfter,
+				"freed_bytes":     freed,
+				"stats_refreshed": statsRefreshed,
+				"tables_analyzed": analyzed,
+				"elapsed_ms":      elapsed.Milliseconds(),
+			})
+			return
+		}
+
+		fmt.Printf("\n✓ Database optimized (%v)\n", elapsed.Round(time.Millisecond))
+		fmt.Printf("  %s → %s (freed %s)\n", formatBytes(sizeBefore), formatBytes(sizeAfter), formatBytes(freed))
+		switch {
+		case statsRefreshed:
+			fmt.Printf("  Index statistics refreshed for %d tables\n", len(analyzed))
+		case statsSupported:
+			fmt.Printf("  Index statistics: failed (see warning above)\n")
+		default:
+			fmt.Printf("  Index statistics: skipped (not collected in embedded mode)\n")
+		}
+	},
+}
+
+// databaseDirSize returns the on-disk size of the open database's directory,
+// in either server or embedded mode.
+func databaseDirSize() (int64, error) {
+	locator, ok := storage.UnwrapStore(store).(storage.StoreLocator)
+	if !ok || locator.CLIDir() == "" {
+		return 0, fmt.Errorf("storage backend does not report its location")
+	}
+	return dirSize(locator.CLIDir())
+}
+
+func init() {
+	dbOptimizeCmd.Flags().BoolVar(&dbOptimizeDryRun, "dry-run", false, "Report the current size without changing anything")
+	dbCmd.AddCommand(dbOptimizeCmd)
+	rootCmd.AddCommand(dbCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -165,6 +166,7 @@ Force: Delete and orphan dependents
 			fmt.Printf("To proceed, run: %s\n\n", ui.RenderWarn("bd delete "+issueID+" --force"))
 			return
 		}
+		requireUnlockedForDelete(ctx, activeStore, []string{issueID})
 		// Actually delete — all writes in a single transaction
 		updatedIssueCount := 0
 		totalDepsRemoved := 0
@@ -308,6 +310,12 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 		}
 		return
 	}
+	lockIDs := make([]string, 0, len(issues))
+	for id := range issues {
+		lockIDs = append(lockIDs, id)
+	}
+	sort.Strings(lockIDs)
+	requireUnlockedForDelete(ctx, batchStore, lockIDs)
 	// Pre-collect connected issues before deletion (so we can update their text references)
 	connectedIssues := make(map[string]*types.Issue)
 	idSet := make(map[string]bool)
@@ -369,6 +377,22 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 	}
 }
 
+// requireUnlockedForDelete exits when any of ids is locked by another actor
+// (bd lock). Unlike update and close, delete's --force only confirms the
+// deletion, so a lock must be released explicitly before deleting.
+func requireUnlockedForDelete(ctx context.Context, s storage.DoltStorage, ids []string) {
+	var locked []string
+	for _, id := range ids {
+		if err := checkIssueLock(ctx, s, id); err != nil {
+			locked = append(locked, id+": "+err.Error())
+		}
+	}
+	if len(locked) > 0 {
+		FatalError("cannot delete locked issues:\n  %s\nRelease the locks first with: bd unlock <id> --force",
+			strings.Join(locked, "\n  "))
+	}
+}
+
 // deleteBatchFallback handles batch deletion for non-SQLite storage (e.g., MemoryStorage in --no-db mode)
 // It iterates through issues one by one, deleting each.
 func deleteBatchFallback(issueIDs []string, force bool, dryRun bool, cascade bool, jsonOutput bool) {
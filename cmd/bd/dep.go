@@ -86,6 +86,26 @@ func warnIfCyclesExist(s storage.DoltStorage) {
 	fmt.Fprintf(os.Stderr, "\nRun 'bd dep cycles' for detailed analysis.\n\n")
 }
 
+// depChangeJSON is the --json output for one added or removed dependency.
+type depChangeJSON struct {
+	Status      string `json:"status"` // added or removed
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type,omitempty"`
+	// BlockerID and BlockedID are set only by the 'bd dep <blocker> --blocks
+	// <blocked>' shorthand, which reported the edge under these names before
+	// issue_id/depends_on_id were added to it.
+	BlockerID string `json:"blocker_id,omitempty"`
+	BlockedID string `json:"blocked_id,omitempty"`
+}
+
+// depBulkAddJSON is the --json output of bd dep add --file.
+type depBulkAddJSON struct {
+	Status       string          `json:"status"`
+	Count        int             `json:"count"`
+	Dependencies []depChangeJSON `json:"dependencies"`
+}
+
 var depCmd = &cobra.Command{
 	Use:     "dep [issue-id]",
 	GroupID: "deps",
@@ -166,11 +186,13 @@ Examples:
 			}
 
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
-					"status":     "added",
-					"blocker_id": toID,
-					"blocked_id": fromID,
-					"type":       depType,
+				outputJSON(depChangeJSON{
+					Status:      "added",
+					IssueID:     fromID,
+					DependsOnID: toID,
+					Type:        string(depType),
+					BlockerID:   toID,
+					BlockedID:   fromID,
 				})
 				return
 			}
@@ -200,7 +222,9 @@ depends on (is blocked by) the specified issue."
 
 The depends-on-id can be:
   - A local issue ID (e.g., bd-xyz)
+  - An issue in a federation peer: <peer>/<issue-id>
   - An external reference: external:<project>:<capability>
+  - An external URL: https://github.com/org/repo/issues/42
 
 For bulk wiring, pass newline-delimited JSON with --file. Each line must be an
 object with "from" and "to" fields, and may include "type". The aliases
@@ -210,11 +234,23 @@ External references are stored as-is and resolved at query time using
 the external_projects config. They block the issue until the capability
 is "shipped" in the target project.
 
+Peer references (<peer>/<issue-id>) block the issue in bd ready and bd blocked
+until the peer's copy of the issue is closed, as of the last
+'bd federation fetch' or 'bd federation sync'. Unfetched or missing peer
+issues count as open.
+
+External URLs (a GitHub issue, a Jira ticket) are outside what bd can see, so
+their state is unknown: a blocking URL dependency holds the issue out of
+bd ready until the URL is marked with 'bd dep resolve <url>'. bd show lists
+each URL with its state.
+
 Examples:
   bd dep add bd-42 bd-41                              # Positional args
   bd dep add bd-42 --blocked-by bd-41                 # Flag syntax (same effect)
   bd dep add bd-42 --depends-on bd-41                 # Alias (same effect)
   bd dep add gt-xyz external:beads:mol-run-assignee   # Cross-project dependency
+  bd dep add bd-42 platform/pf-a1b2                   # Blocked by an issue in peer "platform"
+  bd dep add bd-42 https://github.com/org/repo/issues/7  # Blocked by an external URL
   bd dep add bd-42 bd-41 --no-cycle-check             # Skip cycle check (bulk wiring)
   bd dep add --file deps.jsonl                        # Bulk JSONL: {"from":"bd-42","to":"bd-41"}`,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -293,6 +329,20 @@ Examples:
 			if err := validateExternalRef(toID); err != nil {
 				FatalErrorRespectJSON("%v", err)
 			}
+		} else if types.IsExternalURLRef(dependsOnArg) {
+			// URLs outside beads are stored as-is; their status is unknown
+			// until marked with bd dep resolve.
+			toID = dependsOnArg
+			if err := types.ValidateExternalURLRef(toID); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		} else if types.IsFederatedRef(dependsOnArg) {
+			// Issues in federation peers are stored as-is and resolved
+			// against the peer's last-fetched branch at query time.
+			toID = dependsOnArg
+			if err := validateFederatedRef(ctx, fromStore, toID); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
 		} else {
 			var toCleanup func()
 			toID, _, toCleanup, err = resolveIDWithRouting(ctx, store, dependsOnArg)
@@ -349,11 +399,11 @@ Examples:
 		}
 
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
-				"status":        "added",
-				"issue_id":      fromID,
-				"depends_on_id": toID,
-				"type":          depType,
+			outputJSON(depChangeJSON{
+				Status:      "added",
+				IssueID:     fromID,
+				DependsOnID: toID,
+				Type:        string(depType),
 			})
 			return
 		}
@@ -470,18 +520,19 @@ func addBulkDependencies(cmd *cobra.Command, file string, defaultType string) {
 	}
 
 	if jsonOutput {
-		out := make([]map[string]interface{}, 0, len(resolved))
+		out := make([]depChangeJSON, 0, len(resolved))
 		for _, edge := range resolved {
-			out = append(out, map[string]interface{}{
-				"issue_id":      edge.IssueID,
-				"depends_on_id": edge.DependsOnID,
-				"type":          string(edge.Type),
+			out = append(out, depChangeJSON{
+				Status:      "added",
+				IssueID:     edge.IssueID,
+				DependsOnID: edge.DependsOnID,
+				Type:        string(edge.Type),
 			})
 		}
-		outputJSON(map[string]interface{}{
-			"status":       "added",
-			"count":        len(resolved),
-			"dependencies": out,
+		outputJSON(depBulkAddJSON{
+			Status:       "added",
+			Count:        len(resolved),
+			Dependencies: out,
 		})
 		return
 	}
@@ -588,6 +639,20 @@ func validateBulkDepEdges(ctx context.Context, edges []bulkDepEdge) ([]bulkDepEd
 				continue
 			}
 			current.DependsOnID = edge.DependsOnID
+		} else if types.IsExternalURLRef(edge.DependsOnID) {
+			if err := types.ValidateExternalURLRef(edge.DependsOnID); err != nil {
+				errs = append(errs, fmt.Sprintf("line %d: %v", edge.Line, err))
+				resolved = append(resolved, current)
+				continue
+			}
+			current.DependsOnID = edge.DependsOnID
+		} else if types.IsFederatedRef(edge.DependsOnID) {
+			if err := validateFederatedRef(ctx, fromStore, edge.DependsOnID); err != nil {
+				errs = append(errs, fmt.Sprintf("line %d: %v", edge.Line, err))
+				resolved = append(resolved, current)
+				continue
+			}
+			current.DependsOnID = edge.DependsOnID
 		} else {
 			toID, _, toCleanup, err := resolveIDWithRouting(ctx, store, edge.DependsOnID)
 			if err != nil {
@@ -875,6 +940,8 @@ var depRemoveCmd = &cobra.Command{
 			if err := validateExternalRef(toID); err != nil {
 				FatalErrorRespectJSON("%v", err)
 			}
+		} else if types.IsFederatedRef(args[1]) || types.IsExternalURLRef(args[1]) {
+			toID = args[1]
 		} else {
 			var toCleanup func()
 			toID, _, toCleanup, err = resolveIDWithRouting(ctx, store, args[1])
@@ -909,10 +976,10 @@ var depRemoveCmd = &cobra.Command{
 		}
 
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
-				"status":        "removed",
-				"issue_id":      fullFromID,
-				"depends_on_id": fullToID,
+			outputJSON(depChangeJSON{
+				Status:      "removed",
+				IssueID:     fullFromID,
+				DependsOnID: fullToID,
 			})
 			return
 		}
@@ -1435,6 +1502,20 @@ func validateExternalRef(ref string) error {
 	return nil
 }
 
+// validateFederatedRef checks that a "<peer>/<issue-id>" reference names a
+// configured federation peer.
+func validateFederatedRef(ctx context.Context, s storage.DoltStorage, ref string) error {
+	peer, _, _ := types.ParseFederatedRef(ref)
+	ok, err := s.HasRemote(ctx, peer)
+	if err != nil {
+		return fmt.Errorf("checking federation peer %s: %w", peer, err)
+	}
+	if !ok {
+		return fmt.Errorf("unknown federation peer %q in %s (add it with: bd federation add-peer %s <url>)", peer, ref, peer)
+	}
+	return nil
+}
+
 // IsExternalRef returns true if the dependency reference is an external reference.
 func IsExternalRef(ref string) bool {
 	return strings.HasPrefix(ref, "external:")
@@ -1458,7 +1539,7 @@ func init() {
 	depCmd.Flags().StringP("blocks", "b", "", "Issue ID that this issue blocks (shorthand for: bd dep add <blocked> <blocker>)")
 	depCmd.Flags().Bool("no-cycle-check", false, "Skip per-edge cycle checks for speed (bulk wiring); bulk --file adds still run one final whole-graph check before commit")
 
-	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|tracks|related|parent-child|discovered-from|until|caused-by|validates|relates-to|supersedes)")
+	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|tracks|related|parent-child|discovered-from|until|caused-by|validates|relates-to|supersedes|suggests)")
 	depAddCmd.Flags().String("blocked-by", "", "Issue ID that blocks the first issue (alternative to positional arg)")
 	depAddCmd.Flags().String("depends-on", "", "Issue ID that the first issue depends on (alias for --blocked-by)")
 	depAddCmd.Flags().String("file", "", "Read dependency edges from JSONL file, or '-' for stdin")
@@ -1487,5 +1568,7 @@ func init() {
 	depCmd.AddCommand(depListCmd)
 	depCmd.AddCommand(depTreeCmd)
 	depCmd.AddCommand(depCyclesCmd)
+	depCmd.AddCommand(depResolveCmd)
+	depCmd.AddCommand(depUnresolveCmd)
 	rootCmd.AddCommand(depCmd)
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var depResolveCmd = &cobra.Command{
+	Use:   "resolve <url>",
+	Short: "Mark an external URL dependency as resolved",
+	Long: `Mark an external URL (a GitHub issue, a Jira ticket) that issues depend on
+as resolved. bd cannot see the state of a URL, so a blocking dependency on one
+holds its issue out of bd ready until the URL is resolved here. The mark
+applies to every issue that depends on the URL.
+
+Examples:
+  bd dep add bd-42 https://github.com/org/repo/issues/7
+  bd dep resolve https://github.com/org/repo/issues/7
+  bd dep unresolve https://github.com/org/repo/issues/7   # It was reopened`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setExternalRefResolved(args[0], true)
+	},
+}
+
+var depUnresolveCmd = &cobra.Command{
+	Use:   "unresolve <url>",
+	Short: "Mark an external URL dependency as unresolved again",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setExternalRefResolved(args[0], false)
+	},
+}
+
+// depExternalRefJSON is the --json output of bd dep resolve and unresolve.
+type depExternalRefJSON struct {
+	URL        string   `json:"url"`
+	Resolved   bool     `json:"resolved"`
+	Dependents []string `json:"dependents"`
+}
+
+func setExternalRefResolved(url string, resolved bool) {
+	command := "dep unresolve"
+	if resolved {
+		command = "dep resolve"
+	}
+	CheckReadonly(command)
+	ctx := rootCtx
+
+	if err := types.ValidateExternalURLRef(url); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	ers, ok := storage.UnwrapStore(store).(storage.ExternalRefStore)
+	if !ok {
+		FatalErrorRespectJSON("external URL dependencies are not supported by this storage backend")
+	}
+	if err := ers.SetExternalRefResolved(ctx, url, resolved, actor); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	commandDidWrite.Store(true)
+
+	dependents, _ := store.GetDependentsWithMetadata(ctx, url) // Best effort: only used for the report
+	ids := make([]string, 0, len(dependents))
+	for _, d := range dependents {
+		ids = append(ids, d.ID)
+	}
+
+	if jsonOutput {
+		outputJSON(depExternalRefJSON{URL: url, Resolved: resolved, Dependents: ids})
+		return
+	}
+	state := "unresolved"
+	if resolved {
+		state = "resolved"
+	}
+	fmt.Printf("%s Marked %s %s\n", ui.RenderPass("✓"), url, state)
+	if len(ids) == 0 {
+		fmt.Printf("  %s\n", ui.RenderMuted("No issues depend on this URL yet"))
+		return
+	}
+	for _, d := range dependents {
+		fmt.Printf("  %s\n", formatFeedbackIDParen(d.ID, d.Title))
+	}
+}
+
+// loadExternalDeps returns issueID's dependencies on external URLs with
+// their resolved state, for bd show.
+func loadExternalDeps(ctx context.Context, s storage.DoltStorage, issueID string) []*types.ExternalDependency {
+	records, _ := s.GetDependencyRecords(ctx, issueID) // Best effort: show issue even if deps unavailable
+	var deps []*types.ExternalDependency
+	var urls []string
+	for _, rec := range records {
+		if types.IsExternalURLRef(rec.DependsOnID) {
+			deps = append(deps, &types.ExternalDependency{ExternalRef: types.ExternalRef{URL: rec.DependsOnID}, Type: rec.Type})
+			urls = append(urls, rec.DependsOnID)
+		}
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	if ers, ok := storage.UnwrapStore(s).(storage.ExternalRefStore); ok {
+		refs, _ := ers.GetExternalRefs(ctx, urls)
+		for _, d := range deps {
+			if ref, ok := refs[d.URL]; ok {
+				d.ExternalRef = *ref
+			}
+		}
+	}
+	return deps
+}
+
+// formatExternalDepLine renders one external URL dependency for bd show.
+func formatExternalDepLine(d *types.ExternalDependency) string {
+	if d.Resolved {
+		by := ""
+		if d.ResolvedBy != "" {
+			by = " by " + d.ResolvedBy
+		}
+		return fmt.Sprintf("  → %s %s %s", ui.RenderPass("✓"), ui.RenderMuted(d.URL), ui.RenderMuted(fmt.Sprintf("(%s, resolved%s)", d.Type, by)))
+	}
+	return fmt.Sprintf("  → %s %s %s", ui.RenderWarn("?"), d.URL, ui.RenderMuted(fmt.Sprintf("(%s, unknown — bd dep resolve <url> when done)", d.Type)))
+}
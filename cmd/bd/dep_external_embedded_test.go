@@ -0,0 +1,72 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedExternalURLDependencies(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "xu")
+	feature := bdCreate(t, bd, dir, "Needs upstream fix")
+	url := "https://github.com/org/repo/issues/7"
+
+	if out := bdDepAddFail(t, bd, dir, feature.ID, "https://"); !strings.Contains(out, "invalid external URL") {
+		t.Errorf("unexpected error for a URL without a host:\n%s", out)
+	}
+	bdDepAdd(t, bd, dir, feature.ID, url)
+
+	isReady := func() bool {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, "ready", "--json")
+		if err != nil {
+			t.Fatalf("bd ready failed: %v\n%s", err, out)
+		}
+		return strings.Contains(string(out), feature.ID)
+	}
+	resolved := func() bool {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, "show", feature.ID, "--json")
+		if err != nil {
+			t.Fatalf("bd show failed: %v\n%s", err, out)
+		}
+		var details []struct {
+			ExternalDeps []struct {
+				URL      string `json:"url"`
+				Resolved bool   `json:"resolved"`
+			} `json:"external_dependencies"`
+		}
+		if err := json.Unmarshal(out[strings.Index(string(out), "["):], &details); err != nil {
+			t.Fatalf("parse show: %v\n%s", err, out)
+		}
+		if len(details) != 1 || len(details[0].ExternalDeps) != 1 || details[0].ExternalDeps[0].URL != url {
+			t.Fatalf("external_dependencies = %+v, want %s", details, url)
+		}
+		return details[0].ExternalDeps[0].Resolved
+	}
+
+	if isReady() || resolved() {
+		t.Error("an unresolved URL dependency should block and show as unresolved")
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "dep", "resolve", url); err != nil {
+		t.Fatalf("bd dep resolve failed: %v\n%s", err, out)
+	}
+	if !isReady() || !resolved() {
+		t.Error("a resolved URL dependency should no longer block")
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "dep", "unresolve", url); err != nil {
+		t.Fatalf("bd dep unresolve failed: %v\n%s", err, out)
+	}
+	if isReady() {
+		t.Error("unresolving the URL should block the issue again")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -14,6 +15,12 @@ var diffCmd = &cobra.Command{
 	Short:   "Show changes between two commits or branches",
 	Long: `Show the differences in issues between two commits or branches.
 
+Issues are grouped as added, closed, modified, or removed. Modified and
+closed issues list each changed field, and any dependency that was added,
+removed, or retyped is shown under the issue that owns it. Use --json for
+machine-readable output, e.g. to review what a federation sync or an agent
+session changed.
+
 The refs can be:
 - Commit hashes (e.g., abc123def)
 - Branch names (e.g., main, feature-branch)
@@ -35,97 +42,150 @@ Examples:
 			FatalErrorRespectJSON("failed to get diff: %v", err)
 		}
 
-		if len(entries) == 0 {
-			fmt.Printf("No changes between %s and %s\n", fromRef, toRef)
-			return
+		printIssueDiff(fromRef, toRef, entries)
+	},
+}
+
+// printIssueDiff renders the issue changes between two refs as text or JSON.
+func printIssueDiff(fromRef, toRef string, entries []*storage.DiffEntry) {
+	if jsonOutput {
+		if entries == nil {
+			entries = []*storage.DiffEntry{}
 		}
+		outputJSON(entries)
+		return
+	}
 
-		if jsonOutput {
-			outputJSON(entries)
-			return
+	if len(entries) == 0 {
+		fmt.Printf("No changes between %s and %s\n", fromRef, toRef)
+		return
+	}
+
+	// Display diff in human-readable format
+	fmt.Printf("\n%s Changes from %s to %s (%d issues affected)\n\n",
+		ui.RenderAccent("📊"),
+		ui.RenderMuted(fromRef),
+		ui.RenderMuted(toRef),
+		len(entries))
+
+	// Group by diff type; issues that moved to closed get their own section.
+	var added, closed, modified, removed []*storage.DiffEntry
+	for _, entry := range entries {
+		switch {
+		case entry.DiffType == "added":
+			added = append(added, entry)
+		case entry.DiffType == "modified" && entry.Closed():
+			closed = append(closed, entry)
+		case entry.DiffType == "modified":
+			modified = append(modified, entry)
+		case entry.DiffType == "removed":
+			removed = append(removed, entry)
 		}
+	}
 
-		// Display diff in human-readable format
-		fmt.Printf("\n%s Changes from %s to %s (%d issues affected)\n\n",
-			ui.RenderAccent("📊"),
-			ui.RenderMuted(fromRef),
-			ui.RenderMuted(toRef),
-			len(entries))
-
-		// Group by diff type
-		var added, modified, removed []*storage.DiffEntry
-		for _, entry := range entries {
-			switch entry.DiffType {
-			case "added":
-				added = append(added, entry)
-			case "modified":
-				modified = append(modified, entry)
-			case "removed":
-				removed = append(removed, entry)
-			}
+	// Display added issues
+	if len(added) > 0 {
+		fmt.Printf("%s Added (%d):\n", ui.RenderAccent("+"), len(added))
+		for _, entry := range added {
+			fmt.Printf("  + %s\n", diffEntryLabel(ui.StatusOpenStyle.Render(entry.IssueID), entry.NewValue))
+			printDependencyChanges(entry.DependencyChanges)
 		}
+		fmt.Println()
+	}
 
-		// Display added issues
-		if len(added) > 0 {
-			fmt.Printf("%s Added (%d):\n", ui.RenderAccent("+"), len(added))
-			for _, entry := range added {
-				if entry.NewValue != nil {
-					fmt.Printf("  + %s: %s\n",
-						ui.StatusOpenStyle.Render(entry.IssueID),
-						entry.NewValue.Title)
-				} else {
-					fmt.Printf("  + %s\n", ui.StatusOpenStyle.Render(entry.IssueID))
-				}
-			}
-			fmt.Println()
+	// Display closed issues
+	if len(closed) > 0 {
+		fmt.Printf("%s Closed (%d):\n", ui.RenderAccent("✓"), len(closed))
+		for _, entry := range closed {
+			fmt.Printf("  ✓ %s\n", diffEntryLabel(ui.StatusClosedStyle.Render(entry.IssueID), entry.NewValue))
+			printFieldChanges(entry.Changes)
+			printDependencyChanges(entry.DependencyChanges)
 		}
+		fmt.Println()
+	}
 
-		// Display modified issues
-		if len(modified) > 0 {
-			fmt.Printf("%s Modified (%d):\n", ui.RenderAccent("~"), len(modified))
-			for _, entry := range modified {
-				fmt.Printf("  ~ %s", ui.StatusInProgressStyle.Render(entry.IssueID))
-				if entry.OldValue != nil && entry.NewValue != nil {
-					// Show what changed
-					changes := []string{}
-					if entry.OldValue.Title != entry.NewValue.Title {
-						changes = append(changes, "title")
-					}
-					if entry.OldValue.Status != entry.NewValue.Status {
-						changes = append(changes, fmt.Sprintf("status: %s -> %s",
-							entry.OldValue.Status, entry.NewValue.Status))
-					}
-					if entry.OldValue.Priority != entry.NewValue.Priority {
-						changes = append(changes, fmt.Sprintf("priority: P%d -> P%d",
-							entry.OldValue.Priority, entry.NewValue.Priority))
-					}
-					if entry.OldValue.Description != entry.NewValue.Description {
-						changes = append(changes, "description")
-					}
-					if len(changes) > 0 {
-						fmt.Printf(" (%s)", ui.RenderMuted(joinStrings(changes, ", ")))
-					}
-				}
-				fmt.Println()
-			}
-			fmt.Println()
+	// Display modified issues
+	if len(modified) > 0 {
+		fmt.Printf("%s Modified (%d):\n", ui.RenderAccent("~"), len(modified))
+		for _, entry := range modified {
+			fmt.Printf("  ~ %s\n", diffEntryLabel(ui.StatusInProgressStyle.Render(entry.IssueID), entry.NewValue))
+			printFieldChanges(entry.Changes)
+			printDependencyChanges(entry.DependencyChanges)
 		}
+		fmt.Println()
+	}
 
-		// Display removed issues
-		if len(removed) > 0 {
-			fmt.Printf("%s Removed (%d):\n", ui.RenderAccent("-"), len(removed))
-			for _, entry := range removed {
-				if entry.OldValue != nil {
-					fmt.Printf("  - %s: %s\n",
-						ui.RenderMuted(entry.IssueID),
-						ui.RenderMuted(entry.OldValue.Title))
-				} else {
-					fmt.Printf("  - %s\n", ui.RenderMuted(entry.IssueID))
-				}
+	// Display removed issues
+	if len(removed) > 0 {
+		fmt.Printf("%s Removed (%d):\n", ui.RenderAccent("-"), len(removed))
+		for _, entry := range removed {
+			if entry.OldValue != nil {
+				fmt.Printf("  - %s: %s\n",
+					ui.RenderMuted(entry.IssueID),
+					ui.RenderMuted(entry.OldValue.Title))
+			} else {
+				fmt.Printf("  - %s\n", ui.RenderMuted(entry.IssueID))
 			}
-			fmt.Println()
 		}
-	},
+		fmt.Println()
+	}
+}
+
+// diffEntryLabel renders "id: title", or just the id when the issue row
+// itself did not change.
+func diffEntryLabel(id string, issue *types.Issue) string {
+	if issue == nil {
+		return id
+	}
+	return id + ": " + issue.Title
+}
+
+// diffTextFields are long-form fields whose changes are summarized rather
+// than printed inline.
+var diffTextFields = map[string]bool{
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+}
+
+// printFieldChanges prints one indented line per changed field.
+func printFieldChanges(changes []storage.FieldChange) {
+	for _, c := range changes {
+		switch {
+		case diffTextFields[c.Field]:
+			fmt.Printf("      %s\n", ui.RenderMuted(c.Field+" changed"))
+		case c.Field == "priority":
+			fmt.Printf("      priority: P%s → P%s\n", c.Old, c.New)
+		case c.Field == "title":
+			fmt.Printf("      title: %q → %q\n", c.Old, c.New)
+		default:
+			fmt.Printf("      %s: %s → %s\n", c.Field, diffValue(c.Old), diffValue(c.New))
+		}
+	}
+}
+
+// printDependencyChanges prints one indented line per dependency edge that
+// was added, removed, or retyped.
+func printDependencyChanges(changes []storage.DependencyChange) {
+	for _, c := range changes {
+		switch c.DiffType {
+		case "added":
+			fmt.Printf("      %s depends on %s (%s)\n", ui.RenderAccent("+"), c.DependsOnID, c.NewType)
+		case "removed":
+			fmt.Printf("      %s depends on %s (%s)\n", ui.RenderMuted("-"), c.DependsOnID, c.OldType)
+		default:
+			fmt.Printf("      ~ depends on %s (%s → %s)\n", c.DependsOnID, c.OldType, c.NewType)
+		}
+	}
+}
+
+// diffValue renders an empty field value visibly.
+func diffValue(v string) string {
+	if v == "" {
+		return ui.RenderMuted("(none)")
+	}
+	return v
 }
 
 // joinStrings joins strings with a separator (simple helper to avoid importing strings)
@@ -0,0 +1,648 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+const (
+	digestDeliverStdout  = "stdout"
+	digestDeliverEmail   = "email"
+	digestDeliverWebhook = "webhook"
+
+	digestWebhookTimeout = 30 * time.Second
+	// digestCommentPreview is how much of a comment a digest quotes.
+	digestCommentPreview = 120
+)
+
+var digestCmd = &cobra.Command{
+	Use:     "digest",
+	GroupID: "views",
+	Short:   "Send each actor a digest of what changed for them",
+	Long: `Build a per-actor notification digest for a time window and deliver it to
+stdout, by email, or to a webhook.
+
+Each actor's digest lists:
+  new assignments      issues assigned to them by someone else in the window
+  newly unblocked      their ready issues whose last blocker closed in the window
+  comments             comments by others on issues they watch
+
+An actor watches the issues assigned to them, the issues they created, and
+the issues they have commented on.
+
+--actor limits the digest to the named actors ("me" for the current actor).
+Without it, digest.actors from config.yaml is used, and if that is empty
+every actor with news gets a digest.
+
+Delivery (--deliver, default digest.deliver) is configured in config.yaml:
+  digest.emails          Map of actor to email address; an actor name that
+                         is itself an address needs no entry
+  digest.smtp.host       SMTP server (port digest.smtp.port, default 587)
+  digest.smtp.username   SMTP login; digest.smtp.password, best set through
+                         BD_DIGEST_SMTP_PASSWORD
+  digest.smtp.from       Sender address
+  digest.webhook-url     URL each digest is POSTed to as JSON
+
+Email and webhook delivery skip actors with nothing new. To send digests
+on a schedule, run 'bd digest daemon'.
+
+Examples:
+  bd digest --daily --actor me              # Your digest for the last 24h
+  bd digest --since 7d --actor alice
+  bd digest --daily --deliver email         # Email everyone with news
+  bd digest --daily --deliver webhook --json`,
+	Args: cobra.NoArgs,
+	Run:  runDigest,
+}
+
+var digestDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Send digests on a daily schedule",
+	Long: `Run a long-lived loop that delivers digests once a day at digest.send-at
+(local time, default 08:00).
+
+Each run covers the time since the previous successful run, or the last 24
+hours on the first run. A failed delivery is retried after 15 minutes
+without moving the window forward.
+
+The daemon runs in the foreground; start it under a process supervisor or
+with '&' to keep it in the background. Only one digest daemon runs per
+workspace. State is written to .beads/digest-daemon.json.
+
+Examples:
+  bd digest daemon --deliver email          # Email digests every morning
+  bd digest daemon --deliver webhook --once # Send now and exit`,
+	Args: cobra.NoArgs,
+	Run:  runDigestDaemon,
+}
+
+// DigestComment is a comment on an issue the digest's actor watches.
+type DigestComment struct {
+	IssueID string    `json:"issue_id"`
+	Title   string    `json:"title"`
+	Author  string    `json:"author"`
+	At      time.Time `json:"at"`
+	Text    string    `json:"text"`
+}
+
+// Digest is one actor's notification digest, and the JSON payload of
+// bd digest and its webhook delivery.
+type Digest struct {
+	Actor     string           `json:"actor"`
+	Since     time.Time        `json:"since"`
+	Until     time.Time        `json:"until"`
+	Assigned  []*StandupItem   `json:"assigned,omitempty"`
+	Unblocked []*StandupItem   `json:"unblocked,omitempty"`
+	Comments  []*DigestComment `json:"comments,omitempty"`
+}
+
+// Empty reports whether the digest has no news.
+func (d *Digest) Empty() bool {
+	return len(d.Assigned) == 0 && len(d.Unblocked) == 0 && len(d.Comments) == 0
+}
+
+// digestDelivery is the --json result of delivering one digest by email or
+// webhook.
+type digestDelivery struct {
+	Actor   string `json:"actor"`
+	Via     string `json:"via"`
+	To      string `json:"to,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// digestInputs is everything buildDigests needs, loaded up front so the
+// digests themselves are computed without touching the store.
+type digestInputs struct {
+	Events   []*types.Event
+	Issues   map[string]*types.Issue        // open issues and issues referenced by events
+	Ready    []*types.Issue                 // currently ready issues
+	Deps     map[string][]*types.Dependency // dependency records of ready issues
+	Comments map[string][]*types.Comment    // all comments on Issues
+}
+
+func runDigest(cmd *cobra.Command, args []string) {
+	daily, _ := cmd.Flags().GetBool("daily")
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	cfg := config.GetDigestConfig()
+	deliver := digestDeliverFlag(cmd, cfg)
+	actors := digestActorsFlag(cmd, cfg)
+
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	if !daily && sinceFlag != "" {
+		var err error
+		if since, err = parseWindowFlag(sinceFlag, now); err != nil {
+			FatalErrorRespectJSON("invalid --since: %v", err)
+		}
+	}
+	if err := validateDigestDelivery(deliver, cfg); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+
+	in, err := loadDigestInputs(rootCtx, store, since)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	digests := buildDigests(in, actors, since, now)
+
+	if deliver == digestDeliverStdout {
+		if jsonOutput {
+			outputJSON(digests)
+			return
+		}
+		writeDigestsMarkdown(os.Stdout, digests, since, now)
+		return
+	}
+
+	results := deliverDigests(rootCtx, digests, deliver, cfg)
+	if jsonOutput {
+		outputJSON(results)
+	} else {
+		printDigestDeliveries(results)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			os.Exit(1)
+		}
+	}
+}
+
+// digestDeliverFlag returns --deliver, else digest.deliver, else stdout.
+func digestDeliverFlag(cmd *cobra.Command, cfg config.DigestConfig) string {
+	deliver, _ := cmd.Flags().GetString("deliver")
+	if deliver == "" {
+		deliver = cfg.Deliver
+	}
+	if deliver == "" {
+		deliver = digestDeliverStdout
+	}
+	return deliver
+}
+
+// digestActorsFlag returns --actor, else digest.actors, with "me" resolved
+// to the current actor. Empty means every actor with news.
+func digestActorsFlag(cmd *cobra.Command, cfg config.DigestConfig) []string {
+	actors, _ := cmd.Flags().GetStringSlice("actor")
+	if len(actors) == 0 {
+		actors = cfg.Actors
+	}
+	for i, a := range actors {
+		if a == "me" {
+			actors[i] = actor
+		}
+	}
+	return actors
+}
+
+// validateDigestDelivery checks that deliver is a known mode and that the
+// settings it needs are configured.
+func validateDigestDelivery(deliver string, cfg config.DigestConfig) error {
+	switch deliver {
+	case digestDeliverStdout:
+		return nil
+	case digestDeliverEmail:
+		if cfg.SMTPHost == "" {
+			return fmt.Errorf("email delivery needs digest.smtp.host in config.yaml")
+		}
+		if _, err := mail.ParseAddress(cfg.SMTPFrom); err != nil {
+			return fmt.Errorf("email delivery needs a valid digest.smtp.from address: %v", err)
+		}
+		return nil
+	case digestDeliverWebhook:
+		if cfg.WebhookURL == "" {
+			return fmt.Errorf("webhook delivery needs digest.webhook-url in config.yaml")
+		}
+		if !types.IsExternalURLRef(cfg.WebhookURL) {
+			return fmt.Errorf("invalid digest.webhook-url %q: expected an http(s) URL", cfg.WebhookURL)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid delivery %q: must be stdout, email, or webhook", deliver)
+	}
+}
+
+// loadDigestInputs reads the window's events, the open issues, the ready
+// queue, and the comments that buildDigests works from.
+func loadDigestInputs(ctx context.Context, s storage.DoltStorage, since time.Time) (*digestInputs, error) {
+	in := &digestInputs{Issues: make(map[string]*types.Issue)}
+	var err error
+	if in.Events, err = s.GetAllEventsSince(ctx, since); err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	if in.Ready, err = s.GetReadyWork(ctx, types.WorkFilter{}); err != nil {
+		return nil, fmt.Errorf("failed to load ready work: %w", err)
+	}
+	open, err := s.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load open issues: %w", err)
+	}
+	for _, issue := range open {
+		in.Issues[issue.ID] = issue
+	}
+	for _, issue := range in.Ready {
+		in.Issues[issue.ID] = issue
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, e := range in.Events {
+		if in.Issues[e.IssueID] == nil && !seen[e.IssueID] {
+			seen[e.IssueID] = true
+			missing = append(missing, e.IssueID)
+		}
+	}
+	if len(missing) > 0 {
+		issues, err := s.GetIssuesByIDs(ctx, missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load issues: %w", err)
+		}
+		for _, issue := range issues {
+			in.Issues[issue.ID] = issue
+		}
+	}
+
+	if len(in.Ready) > 0 {
+		readyIDs := make([]string, len(in.Ready))
+		for i, issue := range in.Ready {
+			readyIDs[i] = issue.ID
+		}
+		if in.Deps, err = s.GetDependencyRecordsForIssues(ctx, readyIDs); err != nil {
+			return nil, fmt.Errorf("failed to load dependencies: %w", err)
+		}
+	}
+	if len(in.Issues) > 0 {
+		ids := make([]string, 0, len(in.Issues))
+		for id := range in.Issues {
+			ids = append(ids, id)
+		}
+		if in.Comments, err = s.GetCommentsForIssues(ctx, ids); err != nil {
+			return nil, fmt.Errorf("failed to load comments: %w", err)
+		}
+	}
+	return in, nil
+}
+
+// buildDigests computes one digest per actor for [since, now]. With actors
+// given, each of them gets a digest, even an empty one; otherwise every
+// actor with news does. Digests are sorted by actor.
+func buildDigests(in *digestInputs, actors []string, since, now time.Time) []*Digest {
+	digests := make(map[string]*Digest)
+	wanted := make(map[string]bool, len(actors))
+	for _, a := range actors {
+		wanted[a] = true
+		digests[a] = &Digest{Actor: a, Since: since, Until: now}
+	}
+	digestFor := func(name string) *Digest {
+		if name == "" || (len(actors) > 0 && !wanted[name]) {
+			return nil
+		}
+		d := digests[name]
+		if d == nil {
+			d = &Digest{Actor: name, Since: since, Until: now}
+			digests[name] = d
+		}
+		return d
+	}
+	item := func(id string, e *types.Event) *StandupItem {
+		it := &StandupItem{ID: id, Actor: e.Actor, At: e.CreatedAt}
+		if issue := in.Issues[id]; issue != nil {
+			it.Title = issue.Title
+			it.Status = string(issue.Status)
+			it.Assignee = issue.Assignee
+		}
+		return it
+	}
+	inWindow := func(t time.Time) bool { return !t.Before(since) && !t.After(now) }
+
+	// New assignments: the latest assignment per actor and issue, made by
+	// someone else, that still stands.
+	assigned := make(map[string]map[string]*StandupItem)
+	assign := func(to string, it *StandupItem) {
+		issue := in.Issues[it.ID]
+		if to == "" || to == it.Actor || (issue != nil && issue.Assignee != to) {
+			return
+		}
+		if digestFor(to) == nil {
+			return
+		}
+		if assigned[to] == nil {
+			assigned[to] = make(map[string]*StandupItem)
+		}
+		if prev := assigned[to][it.ID]; prev == nil || !it.At.Before(prev.At) {
+			assigned[to][it.ID] = it
+		}
+	}
+	closedAt := make(map[string]time.Time)
+	for _, e := range in.Events {
+		if !inWindow(e.CreatedAt) {
+			continue
+		}
+		switch e.EventType {
+		case types.EventCreated:
+			if issue := in.Issues[e.IssueID]; issue != nil && issue.Assignee != "" {
+				it := item(e.IssueID, e)
+				it.Detail = "assigned at creation"
+				assign(issue.Assignee, it)
+			}
+		case types.EventUpdated, types.EventStatusChanged:
+			newAssignee, ok := standupEventFields(e.NewValue)["assignee"]
+			if !ok {
+				continue
+			}
+			oldAssignee := standupEventFields(e.OldValue)["assignee"]
+			if oldAssignee != newAssignee {
+				it := item(e.IssueID, e)
+				if oldAssignee != "" {
+					it.Detail = "from " + oldAssignee
+				}
+				assign(newAssignee, it)
+			}
+		case types.EventClosed:
+			if e.CreatedAt.After(closedAt[e.IssueID]) {
+				closedAt[e.IssueID] = e.CreatedAt
+			}
+		}
+	}
+	for name, section := range assigned {
+		digests[name].Assigned = sortedStandupItems(section)
+	}
+
+	// Newly unblocked: ready issues with a blocker that closed in the window.
+	unblocked := make(map[string]map[string]*StandupItem)
+	for _, issue := range in.Ready {
+		d := digestFor(issue.Assignee)
+		if d == nil {
+			continue
+		}
+		var by []string
+		var last time.Time
+		for _, dep := range in.Deps[issue.ID] {
+			at, ok := closedAt[dep.DependsOnID]
+			if !ok || !dep.Type.IsBlockingEdge() {
+				continue
+			}
+			by = append(by, dep.DependsOnID)
+			if at.After(last) {
+				last = at
+			}
+		}
+		if len(by) == 0 {
+			continue
+		}
+		sort.Strings(by)
+		if unblocked[d.Actor] == nil {
+			unblocked[d.Actor] = make(map[string]*StandupItem)
+		}
+		unblocked[d.Actor][issue.ID] = &StandupItem{
+			ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Assignee: issue.Assignee,
+			At: last, Detail: "unblocked by " + strings.Join(by, ", "),
+		}
+	}
+	for name, section := range unblocked {
+		digests[name].Unblocked = sortedStandupItems(section)
+	}
+
+	// Comments by others on watched issues.
+	for id, comments := range in.Comments {
+		issue := in.Issues[id]
+		if issue == nil {
+			continue
+		}
+		watchers := map[string]bool{issue.Assignee: true, issue.CreatedBy: true}
+		for _, c := range comments {
+			watchers[c.Author] = true
+		}
+		for _, c := range comments {
+			if !inWindow(c.CreatedAt) {
+				continue
+			}
+			for name := range watchers {
+				if name == c.Author {
+					continue
+				}
+				if d := digestFor(name); d != nil {
+					d.Comments = append(d.Comments, &DigestComment{
+						IssueID: id, Title: issue.Title, Author: c.Author, At: c.CreatedAt, Text: c.Text,
+					})
+				}
+			}
+		}
+	}
+
+	result := make([]*Digest, 0, len(digests))
+	for _, d := range digests {
+		if d.Empty() && !wanted[d.Actor] {
+			continue
+		}
+		sort.Slice(d.Comments, func(i, j int) bool {
+			if !d.Comments[i].At.Equal(d.Comments[j].At) {
+				return d.Comments[i].At.Before(d.Comments[j].At)
+			}
+			return d.Comments[i].IssueID < d.Comments[j].IssueID
+		})
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Actor < result[j].Actor })
+	return result
+}
+
+func writeDigestsMarkdown(w io.Writer, digests []*Digest, since, now time.Time) {
+	if len(digests) == 0 {
+		fmt.Fprintf(w, "# Digest\n\n_%s → %s_\n\nNothing new in this window.\n",
+			since.Local().Format("2006-01-02 15:04"), now.Local().Format("2006-01-02 15:04"))
+		return
+	}
+	for i, d := range digests {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		writeDigestMarkdown(w, d)
+	}
+}
+
+func writeDigestMarkdown(w io.Writer, d *Digest) {
+	fmt.Fprintf(w, "# Digest: %s\n\n_%s → %s_\n", d.Actor,
+		d.Since.Local().Format("2006-01-02 15:04"), d.Until.Local().Format("2006-01-02 15:04"))
+	if d.Empty() {
+		fmt.Fprintf(w, "\nNothing new for %s.\n", d.Actor)
+		return
+	}
+	writeStandupSection(w, "New assignments", d.Assigned)
+	writeStandupSection(w, "Newly unblocked", d.Unblocked)
+	if len(d.Comments) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n**Comments on watched issues**\n\n")
+	for _, c := range d.Comments {
+		line := "- `" + c.IssueID + "`"
+		if c.Title != "" {
+			line += " " + c.Title
+		}
+		fmt.Fprintf(w, "%s: %s wrote %q\n", line, c.Author, digestPreview(c.Text))
+	}
+}
+
+// digestPreview is the first line of text, cut to digestCommentPreview runes.
+func digestPreview(text string) string {
+	text, _, cut := strings.Cut(strings.TrimSpace(text), "\n")
+	if r := []rune(text); len(r) > digestCommentPreview {
+		text, cut = string(r[:digestCommentPreview]), true
+	}
+	if cut {
+		text += "…"
+	}
+	return text
+}
+
+// deliverDigests sends each digest with news by email or webhook. Failures
+// are recorded per actor rather than stopping the remaining deliveries.
+func deliverDigests(ctx context.Context, digests []*Digest, deliver string, cfg config.DigestConfig) []digestDelivery {
+	var results []digestDelivery
+	for _, d := range digests {
+		r := digestDelivery{Actor: d.Actor, Via: deliver}
+		switch {
+		case d.Empty():
+			r.Skipped = "nothing new"
+		case deliver == digestDeliverEmail:
+			to, ok := digestEmailAddress(d.Actor, cfg)
+			if !ok {
+				r.Skipped = "no address in digest.emails"
+				break
+			}
+			r.To = to
+			if err := sendDigestEmail(cfg, to, d); err != nil {
+				r.Error = err.Error()
+			}
+		case deliver == digestDeliverWebhook:
+			r.To = cfg.WebhookURL
+			if err := postDigestWebhook(ctx, cfg.WebhookURL, d); err != nil {
+				r.Error = err.Error()
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func printDigestDeliveries(results []digestDelivery) {
+	if len(results) == 0 {
+		fmt.Println("No digests to deliver.")
+		return
+	}
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Printf("%s %s: %s\n", ui.RenderFail("✗"), r.Actor, r.Error)
+		case r.Skipped != "":
+			fmt.Printf("%s %s: skipped (%s)\n", ui.RenderMuted("○"), r.Actor, r.Skipped)
+		default:
+			fmt.Printf("%s %s: sent by %s to %s\n", ui.RenderPass("✓"), r.Actor, r.Via, r.To)
+		}
+	}
+}
+
+// digestEmailAddress returns the address for actor: its digest.emails entry,
+// or the actor name itself when that is an email address.
+func digestEmailAddress(actor string, cfg config.DigestConfig) (string, bool) {
+	if addr := cfg.Emails[strings.ToLower(actor)]; addr != "" {
+		return addr, true
+	}
+	if a, err := mail.ParseAddress(actor); err == nil {
+		return a.Address, true
+	}
+	return "", false
+}
+
+// buildDigestEmail renders d as a plain-text email message.
+func buildDigestEmail(from, to string, d *Digest) []byte {
+	var body bytes.Buffer
+	writeDigestMarkdown(&body, d)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: bd digest for %s (%s)\r\n", d.Actor, d.Until.Local().Format("2006-01-02"))
+	fmt.Fprintf(&msg, "Date: %s\r\n", d.Until.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+	return msg.Bytes()
+}
+
+func sendDigestEmail(cfg config.DigestConfig, to string, d *Digest) error {
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+	from, err := mail.ParseAddress(cfg.SMTPFrom)
+	if err != nil {
+		return fmt.Errorf("invalid digest.smtp.from: %w", err)
+	}
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	addr := net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(port))
+	if err := smtp.SendMail(addr, auth, from.Address, []string{to}, buildDigestEmail(cfg.SMTPFrom, to, d)); err != nil {
+		return fmt.Errorf("sending email to %s: %w", to, err)
+	}
+	return nil
+}
+
+func postDigestWebhook(ctx context.Context, url string, d *Digest) error {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, digestWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting digest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("posting digest: webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func init() {
+	digestCmd.Flags().Bool("daily", false, "Cover the last 24 hours (the default window)")
+	digestCmd.Flags().String("since", "", "Start of the window instead of --daily (e.g. 24h, 7d, 2026-01-15)")
+	digestCmd.MarkFlagsMutuallyExclusive("daily", "since")
+	for _, c := range []*cobra.Command{digestCmd, digestDaemonCmd} {
+		c.Flags().StringSlice("actor", nil, `Only these actors ("me" for the current actor; default digest.actors)`)
+		c.Flags().String("deliver", "", "Delivery: stdout, email, or webhook (default digest.deliver)")
+	}
+	digestDaemonCmd.Flags().Bool("once", false, "Send digests now, then exit")
+	digestCmd.AddCommand(digestDaemonCmd)
+	rootCmd.AddCommand(digestCmd)
+}
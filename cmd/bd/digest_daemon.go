@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/lockfile"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+const (
+	digestDaemonStateFile = "digest-daemon.json"
+	digestDaemonLockFile  = "digest-daemon.lock"
+
+	defaultDigestSendAt = "08:00"
+
+	// digestDaemonRetryDelay is how long the daemon waits after a failed
+	// delivery before trying again.
+	digestDaemonRetryDelay = 15 * time.Minute
+	// digestDaemonMaxSleep bounds how long the daemon sleeps between
+	// checks, so a changed digest.send-at is noticed without a restart.
+	digestDaemonMaxSleep = time.Minute
+)
+
+// digestDaemonState is persisted to .beads/digest-daemon.json after every
+// run. LastSent is the end of the last window delivered successfully.
+type digestDaemonState struct {
+	PID         int        `json:"pid"`
+	StartedAt   time.Time  `json:"started_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Running     bool       `json:"running"`
+	LastSent    *time.Time `json:"last_sent,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	NextSend    time.Time  `json:"next_send"`
+}
+
+func runDigestDaemon(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+	once, _ := cmd.Flags().GetBool("once")
+
+	cfg := config.GetDigestConfig()
+	if err := validateDigestDelivery(digestDeliverFlag(cmd, cfg), cfg); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if _, err := nextDigestSend(digestSendAt(), time.Now()); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("%s", activeWorkspaceNotFoundError())
+	}
+
+	lockPath := filepath.Join(beadsDir, digestDaemonLockFile)
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600) //nolint:gosec // path is constructed internally
+	if err != nil {
+		FatalErrorRespectJSON("failed to open daemon lock: %v", err)
+	}
+	defer lock.Close()
+	if err := lockfile.FlockExclusiveNonBlocking(lock); err != nil {
+		if lockfile.IsLocked(err) {
+			FatalErrorRespectJSON("digest daemon is already running for this workspace")
+		}
+		FatalErrorRespectJSON("failed to lock daemon: %v", err)
+	}
+	defer func() { _ = lockfile.FlockUnlock(lock) }()
+
+	state, err := loadDigestDaemonState(beadsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring unreadable daemon state: %v\n", err)
+	}
+	if state == nil {
+		state = &digestDaemonState{}
+	}
+	state.PID = os.Getpid()
+	state.StartedAt = time.Now()
+	state.Running = true
+	// A send time missed while the daemon was down is caught up at once.
+	if once {
+		state.NextSend = time.Now()
+	} else if state.NextSend.IsZero() {
+		state.NextSend, _ = nextDigestSend(digestSendAt(), time.Now())
+	}
+	defer func() {
+		state.Running = false
+		state.UpdatedAt = time.Now()
+		if err := saveDigestDaemonState(beadsDir, state); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save daemon state: %v\n", err)
+		}
+	}()
+
+	if !once {
+		fmt.Fprintf(os.Stderr, "%s Digest daemon started (pid %d), next digest at %s. Press Ctrl+C to stop.\n",
+			ui.RenderAccent("📬"), state.PID, state.NextSend.Format("2006-01-02 15:04"))
+	}
+
+	for {
+		var sendErr error
+		if now := time.Now(); !state.NextSend.After(now) {
+			sendErr = sendScheduledDigests(cmd, config.GetDigestConfig(), digestWindowStart(state, now), now)
+			recordDigestRun(state, now, digestSendAt(), sendErr)
+			if sendErr != nil {
+				logDigestDaemon("digest delivery failed (retry at %s): %v", state.NextSend.Format(time.TimeOnly), sendErr)
+			} else {
+				logDigestDaemon("digests delivered (next at %s)", state.NextSend.Format("2006-01-02 15:04"))
+			}
+			state.UpdatedAt = time.Now()
+			if err := saveDigestDaemonState(beadsDir, state); err != nil {
+				logDigestDaemon("failed to save state: %v", err)
+			}
+		}
+
+		if once {
+			if sendErr != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		sleep := digestDaemonMaxSleep
+		if d := time.Until(state.NextSend); d < sleep {
+			sleep = max(d, time.Second)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(os.Stderr, "\nDigest daemon stopped.\n")
+			return
+		case <-time.After(sleep):
+		}
+	}
+}
+
+// sendScheduledDigests builds and delivers the digests for [since, now]. An
+// error from any delivery fails the run so the window is retried.
+func sendScheduledDigests(cmd *cobra.Command, cfg config.DigestConfig, since, now time.Time) error {
+	in, err := loadDigestInputs(rootCtx, store, since)
+	if err != nil {
+		return err
+	}
+	digests := buildDigests(in, digestActorsFlag(cmd, cfg), since, now)
+	deliver := digestDeliverFlag(cmd, cfg)
+	if deliver == digestDeliverStdout {
+		writeDigestsMarkdown(os.Stdout, digests, since, now)
+		return nil
+	}
+	var failed int
+	for _, r := range deliverDigests(rootCtx, digests, deliver, cfg) {
+		if r.Error != "" {
+			failed++
+			logDigestDaemon("%s: %s", r.Actor, r.Error)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d digests failed", failed, len(digests))
+	}
+	return nil
+}
+
+func logDigestDaemon(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format(time.DateTime), fmt.Sprintf(format, args...))
+}
+
+// digestSendAt returns digest.send-at, or 08:00 when unset.
+func digestSendAt() string {
+	if sendAt := config.GetDigestConfig().SendAt; sendAt != "" {
+		return sendAt
+	}
+	return defaultDigestSendAt
+}
+
+// nextDigestSend returns the first time of day sendAt (HH:MM, local time)
+// strictly after after.
+func nextDigestSend(sendAt string, after time.Time) (time.Time, error) {
+	t, err := time.Parse("15:04", sendAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid digest.send-at %q: expected HH:MM", sendAt)
+	}
+	after = after.Local()
+	next := time.Date(after.Year(), after.Month(), after.Day(), t.Hour(), t.Minute(), 0, 0, time.Local)
+	if !next.After(after) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
+
+// digestWindowStart is the end of the last delivered window, or 24 hours
+// before now on the first run.
+func digestWindowStart(state *digestDaemonState, now time.Time) time.Time {
+	if state.LastSent != nil && state.LastSent.Before(now) {
+		return *state.LastSent
+	}
+	return now.Add(-24 * time.Hour)
+}
+
+// recordDigestRun updates state after a run at now and schedules the next:
+// the following sendAt after a success, a retry after a failure.
+func recordDigestRun(state *digestDaemonState, now time.Time, sendAt string, sendErr error) {
+	if sendErr != nil {
+		state.LastError = sendErr.Error()
+		state.LastErrorAt = &now
+		state.NextSend = now.Add(digestDaemonRetryDelay)
+		return
+	}
+	state.LastSent = &now
+	next, err := nextDigestSend(sendAt, now)
+	if err != nil {
+		next = now.Add(24 * time.Hour)
+	}
+	state.NextSend = next
+}
+
+func loadDigestDaemonState(beadsDir string) (*digestDaemonState, error) {
+	data, err := os.ReadFile(filepath.Join(beadsDir, digestDaemonStateFile)) //nolint:gosec // path is constructed internally
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state digestDaemonState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func saveDigestDaemonState(beadsDir string, state *digestDaemonState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(filepath.Join(beadsDir, digestDaemonStateFile), data)
+}
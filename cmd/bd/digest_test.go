@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildDigests(t *testing.T) {
+	now := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	at := func(h int) time.Time { return now.Add(time.Duration(h) * time.Hour) }
+	str := func(s string) *string { return &s }
+
+	in := &digestInputs{
+		Issues: map[string]*types.Issue{
+			"bd-1": {ID: "bd-1", Title: "Build", Status: types.StatusOpen, Assignee: "alice"},
+			"bd-2": {ID: "bd-2", Title: "Tag", Status: types.StatusOpen, Assignee: "bob", CreatedBy: "carol"},
+			"bd-3": {ID: "bd-3", Title: "Blocker", Status: types.StatusClosed},
+			"bd-4": {ID: "bd-4", Title: "Self", Status: types.StatusOpen, Assignee: "carol"},
+		},
+		Events: []*types.Event{
+			{IssueID: "bd-1", EventType: types.EventUpdated, Actor: "carol", CreatedAt: at(-3),
+				OldValue: str(`{"id":"bd-1","assignee":"bob"}`), NewValue: str(`{"assignee":"alice"}`)},
+			{IssueID: "bd-2", EventType: types.EventCreated, Actor: "carol", CreatedAt: at(-5)},
+			{IssueID: "bd-3", EventType: types.EventClosed, Actor: "dave", CreatedAt: at(-2)},
+			// Self-assignment is not news.
+			{IssueID: "bd-4", EventType: types.EventUpdated, Actor: "carol", CreatedAt: at(-1),
+				NewValue: str(`{"assignee":"carol"}`)},
+			// Outside the window.
+			{IssueID: "bd-1", EventType: types.EventCreated, Actor: "carol", CreatedAt: at(-30)},
+		},
+		Ready: []*types.Issue{
+			{ID: "bd-2", Title: "Tag", Status: types.StatusOpen, Assignee: "bob"},
+		},
+		Deps: map[string][]*types.Dependency{
+			"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks}},
+		},
+		Comments: map[string][]*types.Comment{
+			"bd-2": {
+				{IssueID: "bd-2", Author: "erin", Text: "older", CreatedAt: at(-40)},
+				{IssueID: "bd-2", Author: "bob", Text: "Ready to tag\nsee log", CreatedAt: at(-1)},
+			},
+		},
+	}
+
+	digests := buildDigests(in, nil, since, now)
+	byActor := make(map[string]*Digest)
+	for _, d := range digests {
+		byActor[d.Actor] = d
+	}
+	if len(digests) != 4 || digests[0].Actor != "alice" {
+		t.Fatalf("digests = %v", digestActors(digests))
+	}
+
+	alice := byActor["alice"]
+	if len(alice.Assigned) != 1 || alice.Assigned[0].ID != "bd-1" || alice.Assigned[0].Detail != "from bob" {
+		t.Errorf("alice assigned = %+v", alice.Assigned)
+	}
+	bob := byActor["bob"]
+	if len(bob.Assigned) != 1 || bob.Assigned[0].Detail != "assigned at creation" {
+		t.Errorf("bob assigned = %+v", bob.Assigned)
+	}
+	if len(bob.Unblocked) != 1 || bob.Unblocked[0].Detail != "unblocked by bd-3" {
+		t.Errorf("bob unblocked = %+v", bob.Unblocked)
+	}
+	if len(bob.Comments) != 0 {
+		t.Errorf("bob should not be notified of his own comment: %+v", bob.Comments)
+	}
+	// Carol created bd-2 and erin commented on it earlier: both watch it.
+	for _, name := range []string{"carol", "erin"} {
+		d := byActor[name]
+		if d == nil || len(d.Comments) != 1 || d.Comments[0].Author != "bob" {
+			t.Errorf("%s comments = %+v", name, d)
+		}
+		if d != nil && len(d.Assigned) != 0 {
+			t.Errorf("%s assigned = %+v", name, d.Assigned)
+		}
+	}
+
+	// Named actors get a digest even when empty, and nobody else does.
+	named := buildDigests(in, []string{"dave", "alice"}, since, now)
+	if got := digestActors(named); strings.Join(got, ",") != "alice,dave" || !named[1].Empty() {
+		t.Errorf("named digests = %v", got)
+	}
+}
+
+func digestActors(digests []*Digest) []string {
+	names := make([]string, len(digests))
+	for i, d := range digests {
+		names[i] = d.Actor
+	}
+	return names
+}
+
+func TestWriteDigestMarkdown(t *testing.T) {
+	d := &Digest{
+		Actor:    "alice",
+		Since:    time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC),
+		Until:    time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC),
+		Assigned: []*StandupItem{{ID: "bd-1", Title: "Build", Actor: "carol", Detail: "from bob"}},
+		Comments: []*DigestComment{{IssueID: "bd-2", Title: "Tag", Author: "bob", Text: "Ready to tag\nsee log"}},
+	}
+	var buf strings.Builder
+	writeDigestMarkdown(&buf, d)
+	out := buf.String()
+	for _, want := range []string{
+		"# Digest: alice",
+		"**New assignments**",
+		"- `bd-1` Build (from bob, by carol)",
+		"**Comments on watched issues**",
+		"- `bd-2` Tag: bob wrote \"Ready to tag…\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Newly unblocked") {
+		t.Errorf("empty section rendered:\n%s", out)
+	}
+
+	var empty strings.Builder
+	writeDigestsMarkdown(&empty, nil, d.Since, d.Until)
+	if !strings.Contains(empty.String(), "Nothing new") {
+		t.Errorf("empty digest = %q", empty.String())
+	}
+}
+
+func TestValidateDigestDelivery(t *testing.T) {
+	tests := []struct {
+		deliver string
+		cfg     config.DigestConfig
+		wantErr string
+	}{
+		{deliver: "stdout"},
+		{deliver: "email", wantErr: "digest.smtp.host"},
+		{deliver: "email", cfg: config.DigestConfig{SMTPHost: "mail", SMTPFrom: "nope"}, wantErr: "digest.smtp.from"},
+		{deliver: "email", cfg: config.DigestConfig{SMTPHost: "mail", SMTPFrom: "Beads <bd@example.com>"}},
+		{deliver: "webhook", wantErr: "digest.webhook-url"},
+		{deliver: "webhook", cfg: config.DigestConfig{WebhookURL: "ftp://x"}, wantErr: "http(s)"},
+		{deliver: "webhook", cfg: config.DigestConfig{WebhookURL: "https://hooks.example.com/bd"}},
+		{deliver: "pager", wantErr: "must be stdout, email, or webhook"},
+	}
+	for _, tt := range tests {
+		err := validateDigestDelivery(tt.deliver, tt.cfg)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s %+v: unexpected error %v", tt.deliver, tt.cfg, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s %+v: error = %v, want %q", tt.deliver, tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDeliverDigests(t *testing.T) {
+	var got []Digest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var d Digest
+		if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+		got = append(got, d)
+		if d.Actor == "bob" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	news := []*StandupItem{{ID: "bd-1"}}
+	digests := []*Digest{
+		{Actor: "alice", Assigned: news},
+		{Actor: "bob", Assigned: news},
+		{Actor: "carol"},
+	}
+	results := deliverDigests(context.Background(), digests, digestDeliverWebhook, config.DigestConfig{WebhookURL: srv.URL})
+	if len(got) != 2 || got[0].Actor != "alice" || len(got[0].Assigned) != 1 {
+		t.Fatalf("webhook received %+v", got)
+	}
+	if len(results) != 3 || results[0].Error != "" || !strings.Contains(results[1].Error, "502") || results[2].Skipped == "" {
+		t.Errorf("results = %+v", results)
+	}
+
+	// Email: an actor needs an address, from digest.emails or its own name.
+	cfg := config.DigestConfig{Emails: map[string]string{"alice": "alice@example.com"}}
+	if to, ok := digestEmailAddress("Alice", cfg); !ok || to != "alice@example.com" {
+		t.Errorf("digestEmailAddress(Alice) = %q, %v", to, ok)
+	}
+	if to, ok := digestEmailAddress("bob@example.com", cfg); !ok || to != "bob@example.com" {
+		t.Errorf("digestEmailAddress(bob@example.com) = %q, %v", to, ok)
+	}
+	if _, ok := digestEmailAddress("carol", cfg); ok {
+		t.Error("carol has no address")
+	}
+	msg := string(buildDigestEmail("Beads <bd@example.com>", "alice@example.com", digests[0]))
+	for _, want := range []string{"To: alice@example.com\r\n", "Subject: bd digest for alice", "\r\n\r\n# Digest: alice"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("email missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestDigestDaemonSchedule(t *testing.T) {
+	base := time.Date(2026, 3, 18, 7, 30, 0, 0, time.Local)
+	next, err := nextDigestSend("08:00", base)
+	if err != nil || !next.Equal(time.Date(2026, 3, 18, 8, 0, 0, 0, time.Local)) {
+		t.Errorf("nextDigestSend before send time = %v, %v", next, err)
+	}
+	next, _ = nextDigestSend("08:00", next)
+	if !next.Equal(time.Date(2026, 3, 19, 8, 0, 0, 0, time.Local)) {
+		t.Errorf("nextDigestSend at send time = %v", next)
+	}
+	if _, err := nextDigestSend("8am", base); err == nil {
+		t.Error("expected error for invalid send-at")
+	}
+
+	state := &digestDaemonState{}
+	if got := digestWindowStart(state, base); !got.Equal(base.Add(-24 * time.Hour)) {
+		t.Errorf("first window starts %v", got)
+	}
+	recordDigestRun(state, base, "08:00", errors.New("smtp down"))
+	if state.LastSent != nil || !state.NextSend.Equal(base.Add(digestDaemonRetryDelay)) || state.LastError != "smtp down" {
+		t.Errorf("after failure: %+v", state)
+	}
+	recordDigestRun(state, base, "08:00", nil)
+	if state.LastSent == nil || !state.NextSend.Equal(time.Date(2026, 3, 18, 8, 0, 0, 0, time.Local)) {
+		t.Errorf("after success: %+v", state)
+	}
+	if got := digestWindowStart(state, base.Add(time.Hour)); !got.Equal(base) {
+		t.Errorf("next window starts %v, want %v", got, base)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/cmd/bd/doctor"
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/town"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -56,6 +57,7 @@ var (
 	doctorDeep                      bool   // full graph integrity validation
 	doctorOrchestrator              bool   // running in orchestrator multi-workspace mode
 	orchestratorDuplicatesThreshold int    // duplicate tolerance threshold for orchestrator mode
+	orchestratorDuplicatesFlagSet   bool   // --orchestrator-duplicates-threshold given (else town.duplicates_threshold)
 	doctorServer                    bool   // run server mode health checks
 	doctorMigration                 string // migration validation mode: "pre" or "post"
 	doctorAgent                     bool   // agent-facing diagnostic mode (ZFC-compliant)
@@ -101,6 +103,9 @@ Specific Check Mode (--check):
   - conventions: Check for convention drift (lint warnings, stale
     issues, orphaned issues). Advisory only - warns, never blocks.
   - pollution: Detect and optionally clean test issues from database
+  - schema: Compare columns, indexes, and foreign keys of the core tables
+    with what this bd version expects. Use with --fix to add what is
+    missing (never drops or alters existing columns).
   - validate: Run focused data-integrity checks (duplicates, orphaned
     deps, test pollution, git conflicts). Use with --fix to auto-repair.
 
@@ -176,6 +181,7 @@ Examples:
   bd doctor --check=conventions        # Convention drift check (lint, stale, orphans)
   bd doctor --check=pollution          # Show potential test issues
   bd doctor --check=pollution --clean  # Delete test issues (with confirmation)
+  bd doctor --check=schema --fix     # Add missing columns/indexes
   bd doctor --check=validate         # Data-integrity checks only
   bd doctor --check=validate --fix   # Auto-fix data-integrity issues
   bd doctor --deep             # Full graph integrity validation
@@ -184,6 +190,7 @@ Examples:
   bd doctor --migration=post   # Validate Dolt migration completed
   bd doctor --migration=pre --json  # Machine-parseable migration validation`,
 	Run: func(cmd *cobra.Command, args []string) {
+		orchestratorDuplicatesFlagSet = cmd.Flags().Changed("orchestrator-duplicates-threshold")
 		if !usesSQLServer() {
 			fmt.Fprintln(os.Stderr, "Note: 'bd doctor' is not yet supported in embedded mode.")
 			fmt.Fprintln(os.Stderr, "")
@@ -257,8 +264,11 @@ Examples:
 			case "conventions":
 				runConventionsCheck(absPath)
 				return
+			case "schema":
+				runSchemaCheck(absPath)
+				return
 			default:
-				FatalErrorWithHint(fmt.Sprintf("unknown check %q", doctorCheckFlag), "Available checks: artifacts, conventions, pollution, validate")
+				FatalErrorWithHint(fmt.Sprintf("unknown check %q", doctorCheckFlag), "Available checks: artifacts, conventions, pollution, schema, validate")
 			}
 		}
 
@@ -336,7 +346,7 @@ func init() {
 	doctorCmd.Flags().BoolVar(&doctorFixChildParent, "fix-child-parent", false, "Remove child→parent dependencies (opt-in)")
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show all checks (default shows only warnings/errors)")
 	doctorCmd.Flags().BoolVar(&doctorOrchestrator, "orchestrator", false, "Running in orchestrator multi-workspace mode (routes.jsonl is expected, higher duplicate tolerance)")
-	doctorCmd.Flags().IntVar(&orchestratorDuplicatesThreshold, "orchestrator-duplicates-threshold", 1000, "Duplicate tolerance threshold for orchestrator mode (wisps are ephemeral)")
+	doctorCmd.Flags().IntVar(&orchestratorDuplicatesThreshold, "orchestrator-duplicates-threshold", 1000, "Duplicate tolerance threshold for orchestrator mode (overrides town.duplicates_threshold)")
 	doctorCmd.Flags().BoolVar(&doctorServer, "server", false, "Run Dolt server mode health checks (connectivity, version, schema)")
 	doctorCmd.Flags().StringVar(&doctorMigration, "migration", "", "Run Dolt migration validation: 'pre' (before migration) or 'post' (after migration)")
 	doctorCmd.Flags().BoolVar(&doctorAgent, "agent", false, "Agent-facing diagnostic mode: rich context for AI agents (ZFC-compliant)")
@@ -346,6 +356,15 @@ func shouldSkipDoctorNetworkChecks() bool {
 	return jsonOutput || !ui.IsTerminal()
 }
 
+// doctorDuplicatesThreshold is --orchestrator-duplicates-threshold when given,
+// and town.duplicates_threshold (see bd town) otherwise.
+func doctorDuplicatesThreshold(townCfg town.Config) int {
+	if orchestratorDuplicatesFlagSet {
+		return orchestratorDuplicatesThreshold
+	}
+	return townCfg.DuplicatesThreshold
+}
+
 func runDiagnostics(path string) doctorResult {
 	result := doctorResult{
 		Path:       path,
@@ -459,6 +478,7 @@ func runDiagnostics(path string) doctorResult {
 	// open restarts it). The shared store stays alive for the entire doctor run.
 	sharedStore := doctor.NewSharedStore(path)
 	defer sharedStore.Close()
+	townCfg := doctor.GetTownConfigWithStore(sharedStore)
 
 	// Check 2: Database version
 	dbCheck := convertWithCategory(doctor.CheckDatabaseVersionWithStore(sharedStore, Version), doctor.CategoryCore)
@@ -474,6 +494,13 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 2a2: Schema integrity (columns, indexes, FKs vs. this binary)
+	schemaIntegrityCheck := convertWithCategory(doctor.CheckSchemaIntegrityWithStore(sharedStore), doctor.CategoryCore)
+	result.Checks = append(result.Checks, schemaIntegrityCheck)
+	if schemaIntegrityCheck.Status == statusError {
+		result.OverallOK = false
+	}
+
 	// Check 2b: Repo fingerprint (detects wrong database or URL change)
 	fingerprintCheck := convertWithCategory(doctor.CheckRepoFingerprintWithStore(sharedStore, path), doctor.CategoryCore)
 	result.Checks = append(result.Checks, fingerprintCheck)
@@ -533,6 +560,11 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 7a2: Monorepo sub-project prefixes
+	projectPrefixCheck := convertWithCategory(doctor.CheckProjectPrefixesWithStore(sharedStore, path), doctor.CategoryData)
+	result.Checks = append(result.Checks, projectPrefixCheck)
+	// Don't fail overall check for prefix mapping warnings, just warn
+
 	// Check 7b: Multi-repo custom types discovery (bd-9ji4z)
 	multiRepoTypesCheck := convertWithCategory(doctor.CheckMultiRepoTypes(path), doctor.CategoryData)
 	result.Checks = append(result.Checks, multiRepoTypesCheck)
@@ -591,6 +623,14 @@ func runDiagnostics(path string) doctorResult {
 	syncStalenessCheck := convertWithCategory(doctor.CheckFederationSyncStaleness(path), doctor.CategoryFederation)
 	result.Checks = append(result.Checks, syncStalenessCheck)
 
+	// Check 8f2: Per-peer federation health (URL, credentials, last_sync age)
+	peerHealthCheck := convertWithCategory(doctor.CheckFederationPeerHealth(path), doctor.CategoryFederation)
+	result.Checks = append(result.Checks, peerHealthCheck)
+
+	// Check 8f3: Credential key file hygiene (location, mode, size, gitignore, decrypts peers)
+	credKeyCheck := convertWithCategory(doctor.CheckCredentialKeyFile(path), doctor.CategoryFederation)
+	result.Checks = append(result.Checks, credKeyCheck)
+
 	// Check 8g: Federation conflict detection
 	fedConflictsCheck := convertWithCategory(doctor.CheckFederationConflicts(path), doctor.CategoryFederation)
 	result.Checks = append(result.Checks, fedConflictsCheck)
@@ -598,6 +638,10 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false // Unresolved conflicts are a real problem
 	}
 
+	// Check 8g2: Wisps in data fetched from peers
+	peerWispsCheck := convertWithCategory(doctor.CheckFederationPeerWisps(path), doctor.CategoryFederation)
+	result.Checks = append(result.Checks, peerWispsCheck)
+
 	// Check 8h: Dolt server mode configuration check
 	doltModeCheck := convertWithCategory(doctor.CheckDoltServerModeMismatch(path), doctor.CategoryFederation)
 	result.Checks = append(result.Checks, doltModeCheck)
@@ -624,6 +668,14 @@ func runDiagnostics(path string) doctorResult {
 		result.OverallOK = false
 	}
 
+	// Check 10c: Wisp/issue cross-table drift — wisp aux rows whose ID has no
+	// wisps row, and IDs present in both wisps and issues.
+	wispDriftCheck := convertWithCategory(doctor.CheckWispDriftWithStore(sharedStore), doctor.CategoryMetadata)
+	result.Checks = append(result.Checks, wispDriftCheck)
+	if wispDriftCheck.Status == statusError || wispDriftCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
 	// Check 11: Claude integration
 	claudeCheck := convertWithCategory(doctor.CheckClaude(path), doctor.CategoryIntegration)
 	result.Checks = append(result.Checks, claudeCheck)
@@ -748,6 +800,11 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, untrackedCheck)
 	// Don't fail overall check for untracked files, just warn
 
+	// Check 20a: JSONL exports written with a newer schema than this CLI
+	jsonlSchemaCheck := convertWithCategory(doctor.CheckJSONLSchemaVersion(path), doctor.CategoryData)
+	result.Checks = append(result.Checks, jsonlSchemaCheck)
+	// Don't fail overall check for a newer JSONL schema, just warn
+
 	// Check 21: Orphaned dependencies (from bd repair-deps, bd validate)
 	orphanedDepsCheck := convertDoctorCheck(doctor.CheckOrphanedDependencies(path))
 	result.Checks = append(result.Checks, orphanedDepsCheck)
@@ -758,8 +815,12 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, childParentDepsCheck)
 	// Don't fail overall check for child→parent deps, just warn
 
+	// Check 22b: Child counter drift (fix: bd child counters --repair)
+	childCountersCheck := convertDoctorCheck(doctor.CheckChildCountersWithStore(sharedStore))
+	result.Checks = append(result.Checks, childCountersCheck)
+
 	// Check 23: Duplicate issues (from bd validate)
-	duplicatesCheck := convertDoctorCheck(doctor.CheckDuplicateIssues(path, doctorOrchestrator, orchestratorDuplicatesThreshold))
+	duplicatesCheck := convertDoctorCheck(doctor.CheckDuplicateIssues(path, doctorOrchestrator, doctorDuplicatesThreshold(townCfg)))
 	result.Checks = append(result.Checks, duplicatesCheck)
 	// Don't fail overall check for duplicates, just warn
 
@@ -789,16 +850,29 @@ func runDiagnostics(path string) doctorResult {
 	// Don't fail overall check for legacy MQ files, just warn
 
 	// Check 26d: Patrol pollution (patrol digests, session beads)
-	patrolPollutionCheck := convertDoctorCheck(doctor.CheckPatrolPollution(path))
+	patrolPollutionCheck := convertDoctorCheck(doctor.CheckPatrolPollution(path, townCfg))
 	result.Checks = append(result.Checks, patrolPollutionCheck)
 	// Don't fail overall check for patrol pollution, just warn
 
+	// Check 26e: Stale issue policy (labeled stale or about to be)
+	staleIssuesCheck := convertDoctorCheck(doctor.CheckStaleIssues(path))
+	result.Checks = append(result.Checks, staleIssuesCheck)
+	// Don't fail overall check for stale issues, just warn
+
 	// Check 29: Database size (pruning suggestion)
 	// Note: This check has no auto-fix - pruning is destructive and user-controlled
 	sizeCheck := convertDoctorCheck(doctor.CheckDatabaseSizeWithStore(sharedStore))
 	result.Checks = append(result.Checks, sizeCheck)
 	// Don't fail overall check for size warning, just inform
 
+	// Check 29a: On-disk bloat vs. live table data (fix: bd db optimize)
+	bloatCheck := convertDoctorCheck(doctor.CheckDatabaseBloatWithStore(sharedStore))
+	result.Checks = append(result.Checks, bloatCheck)
+
+	// Check 29b: Snapshot tables dominating the database (fix: bd snapshots prune)
+	snapshotTablesCheck := convertDoctorCheck(doctor.CheckSnapshotTablesWithStore(sharedStore))
+	result.Checks = append(result.Checks, snapshotTablesCheck)
+
 	// Check 30: Pending migrations (summarizes all available migrations)
 	migrationsCheck := convertDoctorCheck(doctor.CheckPendingMigrations(path))
 	result.Checks = append(result.Checks, migrationsCheck)
@@ -2,6 +2,8 @@
 
 package doctor
 
+import "github.com/steveyegge/beads/internal/town"
+
 // Non-CGO stubs for doctor checks that require Dolt database access.
 // These checks are skipped in non-CGO builds.
 
@@ -33,6 +35,10 @@ func CheckStaleMolecules(_ string) DoctorCheck {
 	return DoctorCheck{Name: "Stale Molecules", Status: StatusWarning, Message: "Skipped: requires CGO"}
 }
 
+func CheckStaleIssues(_ string) DoctorCheck {
+	return DoctorCheck{Name: "Stale Issues", Status: StatusWarning, Message: "Skipped: requires CGO"}
+}
+
 func CheckPersistentMolIssues(_ string) DoctorCheck {
 	return DoctorCheck{Name: "Persistent Mol Issues", Status: StatusWarning, Message: "Skipped: requires CGO"}
 }
@@ -41,7 +47,7 @@ func CheckStaleMQFiles(_ string) DoctorCheck {
 	return DoctorCheck{Name: "Stale MQ Files", Status: StatusWarning, Message: "Skipped: requires CGO"}
 }
 
-func CheckPatrolPollution(_ string) DoctorCheck {
+func CheckPatrolPollution(_ string, _ town.Config) DoctorCheck {
 	return DoctorCheck{Name: "Patrol Pollution", Status: StatusWarning, Message: "Skipped: requires CGO"}
 }
 
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // validRoutingModes are the allowed values for routing.mode
@@ -425,7 +427,8 @@ func checkDatabaseConfigValuesWithStore(store *dolt.DoltStore) []string {
 	if err == nil && statusCustom != "" {
 		statuses := strings.Split(statusCustom, ",")
 		for _, status := range statuses {
-			status = strings.TrimSpace(status)
+			// Drop the optional ":category" annotation (e.g. "review:wip").
+			status, _, _ = strings.Cut(strings.TrimSpace(status), ":")
 			if status == "" {
 				continue
 			}
@@ -440,5 +443,87 @@ func checkDatabaseConfigValuesWithStore(store *dolt.DoltStore) []string {
 		}
 	}
 
+	issues = append(issues, checkStatusWorkflowConfig(ctx, store)...)
+	return issues
+}
+
+// checkStatusWorkflowConfig validates status.transitions, status.import-map,
+// status.export-map, and status.policy.* against the defined statuses, and reports issues
+// whose status is no longer defined (e.g. after a custom status was removed).
+func checkStatusWorkflowConfig(ctx context.Context, store *dolt.DoltStore) []string {
+	var issues []string
+
+	known := make(map[types.Status]bool)
+	for _, s := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked,
+		types.StatusDeferred, types.StatusClosed, types.StatusPinned, types.StatusHooked} {
+		known[s] = true
+	}
+	if custom, err := store.GetCustomStatusesDetailed(ctx); err == nil {
+		for _, cs := range custom {
+			known[types.Status(cs.Name)] = true
+		}
+	}
+
+	if value, err := store.GetConfig(ctx, "status.transitions"); err == nil && value != "" {
+		transitions, err := types.ParseStatusTransitions(value)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("status.transitions: %v", err))
+		} else {
+			for _, s := range transitions.Statuses() {
+				if !known[s] {
+					issues = append(issues, fmt.Sprintf("status.transitions: %q is not a defined status", s))
+				}
+			}
+		}
+	}
+
+	for _, key := range []string{"status.import-map", "status.export-map"} {
+		value, err := store.GetConfig(ctx, key)
+		if err != nil || value == "" {
+			continue
+		}
+		m, err := types.ParseStatusMap(value)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		for from, to := range m {
+			// Imports must land on a status this project defines; exports
+			// must start from one.
+			s := to
+			if key == "status.export-map" {
+				s = from
+			}
+			if !known[s] {
+				issues = append(issues, fmt.Sprintf("%s: %q is not a defined status", key, s))
+			}
+		}
+	}
+
+	if all, err := store.GetAllConfig(ctx); err == nil {
+		for key, value := range all {
+			if !strings.HasPrefix(key, types.StatusPolicyPrefix) {
+				continue
+			}
+			if _, err := types.ParseStatusPolicy(key, value); err != nil {
+				issues = append(issues, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			_, status, _ := strings.Cut(strings.TrimPrefix(key, types.StatusPolicyPrefix), ".")
+			if !known[types.Status(status)] {
+				issues = append(issues, fmt.Sprintf("%s: %q is not a defined status", key, status))
+			}
+		}
+	}
+
+	if counts, err := store.CountIssuesByGroup(ctx, types.IssueFilter{}, "status"); err == nil {
+		for status, n := range counts {
+			if !known[types.Status(status)] {
+				issues = append(issues, fmt.Sprintf("issues: %d issue(s) have undefined status %q (add it to status.custom or bd update them)", n, status))
+			}
+		}
+	}
+
+	sort.Strings(issues)
 	return issues
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/doltserver"
 	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
 	"gopkg.in/yaml.v3"
 )
 
@@ -592,3 +593,139 @@ func checkDatabaseSizeWithStore(store *dolt.DoltStore) DoctorCheck {
 		Message: fmt.Sprintf("%d closed issues (threshold: %d)", stats.ClosedIssues, threshold),
 	}
 }
+
+// Bloat thresholds: warn once the on-disk database is both large in absolute
+// terms and many times bigger than the rows it holds. Burned wisps and
+// rewritten issues leave unreferenced chunks behind until a GC runs.
+const (
+	bloatMinBytes = 100 * 1024 * 1024
+	bloatRatio    = 10
+)
+
+// CheckDatabaseBloatWithStore compares the on-disk size of the database
+// directory with the engine's estimate of its live rows and indexes.
+func CheckDatabaseBloatWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil || store.Path() == "" {
+		return DoctorCheck{
+			Name:    "Database Bloat",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+	if _, err := os.Stat(store.Path()); err != nil {
+		return DoctorCheck{
+			Name:    "Database Bloat",
+			Status:  StatusOK,
+			Message: "N/A (database directory not local)",
+		}
+	}
+
+	onDisk, err := doltDirBytes(store.Path())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Database Bloat",
+			Status:  StatusOK,
+			Message: "N/A (unable to measure database directory)",
+		}
+	}
+	logical, err := versioncontrolops.LogicalSize(context.Background(), store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Database Bloat",
+			Status:  StatusOK,
+			Message: "N/A (unable to estimate table sizes)",
+		}
+	}
+	return databaseBloatCheck(onDisk, logical)
+}
+
+// databaseBloatCheck turns the two sizes into a doctor result.
+func databaseBloatCheck(onDisk, logical int64) DoctorCheck {
+	msg := fmt.Sprintf("%s on disk, ~%s of table data", formatByteSize(onDisk), formatByteSize(logical))
+	if onDisk >= bloatMinBytes && onDisk > logical*bloatRatio {
+		return DoctorCheck{
+			Name:    "Database Bloat",
+			Status:  StatusWarning,
+			Message: msg,
+			Detail:  "The directory holds far more than the live rows: unreferenced chunks (e.g. from burned wisps) or long commit history",
+			Fix:     "Run 'bd db optimize' to garbage collect and refresh index statistics",
+		}
+	}
+	return DoctorCheck{
+		Name:    "Database Bloat",
+		Status:  StatusOK,
+		Message: msg,
+	}
+}
+
+// Snapshot thresholds: warn once the snapshot payload is large in absolute
+// terms and makes up most of the database. Snapshots are never pruned
+// unless retention is configured.
+const (
+	snapshotMinBytes = 10 * 1024 * 1024
+	snapshotMaxShare = 0.5
+)
+
+// CheckSnapshotTablesWithStore warns when issue_snapshots and
+// compaction_snapshots dominate the database size.
+func CheckSnapshotTablesWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Snapshot Tables",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+	ctx := context.Background()
+	usage, err := store.SnapshotUsage(ctx)
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Snapshot Tables",
+			Status:  StatusOK,
+			Message: "N/A (unable to measure snapshot tables)",
+		}
+	}
+	logical, err := versioncontrolops.LogicalSize(ctx, store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Snapshot Tables",
+			Status:  StatusOK,
+			Message: "N/A (unable to estimate table sizes)",
+		}
+	}
+	var snapshots int64
+	rows := 0
+	for _, u := range usage {
+		snapshots += u.Bytes
+		rows += u.Rows
+	}
+	return snapshotTablesCheck(rows, snapshots, logical)
+}
+
+// snapshotTablesCheck turns the snapshot payload and database sizes into a
+// doctor result. The size estimate can lag the payload, so the database is
+// never taken to be smaller than its snapshots.
+func snapshotTablesCheck(rows int, snapshots, logical int64) DoctorCheck {
+	total := max(logical, snapshots)
+	share := 0.0
+	if total > 0 {
+		share = float64(snapshots) / float64(total)
+	}
+	msg := fmt.Sprintf("%d snapshot(s), %s (%.0f%% of ~%s)", rows, formatByteSize(snapshots), share*100, formatByteSize(total))
+	if snapshots >= snapshotMinBytes && share > snapshotMaxShare {
+		return DoctorCheck{
+			Name:    "Snapshot Tables",
+			Status:  StatusWarning,
+			Message: msg,
+			Detail:  "issue_snapshots and compaction_snapshots hold most of the database; they grow with every compaction and are never pruned by default",
+			Fix:     "Set snapshots.keep_last or snapshots.keep_days and run 'bd snapshots prune'",
+		}
+	}
+	return DoctorCheck{
+		Name:    "Snapshot Tables",
+		Status:  StatusOK,
+		Message: msg,
+	}
+}
@@ -210,3 +210,55 @@ func setupDoctorBareParentWorktree(t *testing.T) (string, string) {
 
 	return bareDir, featureWorktreeDir
 }
+
+func TestDatabaseBloatCheck(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		name    string
+		onDisk  int64
+		logical int64
+		want    string
+	}{
+		{"small database", 50 * mb, 1 * mb, StatusOK},
+		{"large but dense", 500 * mb, 200 * mb, StatusOK},
+		{"large and bloated", 500 * mb, 10 * mb, StatusWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := databaseBloatCheck(tt.onDisk, tt.logical)
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", check.Status, tt.want, check.Message)
+			}
+			if tt.want == StatusWarning && !strings.Contains(check.Fix, "bd db optimize") {
+				t.Errorf("fix = %q, want bd db optimize hint", check.Fix)
+			}
+		})
+	}
+}
+
+func TestSnapshotTablesCheck(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		name      string
+		snapshots int64
+		logical   int64
+		want      string
+	}{
+		{"no snapshots", 0, 50 * mb, StatusOK},
+		{"small but dominant", 2 * mb, 3 * mb, StatusOK},
+		{"large minority", 20 * mb, 100 * mb, StatusOK},
+		{"large and dominant", 80 * mb, 100 * mb, StatusWarning},
+		{"estimate lags payload", 30 * mb, 5 * mb, StatusWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := snapshotTablesCheck(10, tt.snapshots, tt.logical)
+			if check.Status != tt.want {
+				t.Errorf("status = %q, want %q (%s)", check.Status, tt.want, check.Message)
+			}
+			if tt.want == StatusWarning && !strings.Contains(check.Fix, "bd snapshots prune") {
+				t.Errorf("fix = %q, want bd snapshots prune hint", check.Fix)
+			}
+		})
+	}
+}
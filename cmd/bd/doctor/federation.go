@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/doltserver"
 	"github.com/steveyegge/beads/internal/storage/dolt"
@@ -502,6 +506,205 @@ func CheckFederationSyncStaleness(path string) DoctorCheck {
 	}
 }
 
+// defaultPeerStaleThreshold is used when federation.stale-threshold is unset.
+const defaultPeerStaleThreshold = 24 * time.Hour
+
+// peerProbeTimeout bounds the authenticated fetch used to verify each peer.
+const peerProbeTimeout = 30 * time.Second
+
+// CheckFederationPeerHealth checks each stored federation peer: the remote URL
+// resolves, the stored credentials authenticate, and last_sync is within
+// federation.stale-threshold. Results are reported per peer in Detail.
+func CheckFederationPeerHealth(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+
+	// Only relevant for Dolt backend
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryFederation,
+		}
+	}
+
+	// Check if dolt directory exists
+	doltPath := getDatabasePath(beadsDir)
+	if _, err := os.Stat(doltPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusOK,
+			Message:  "N/A (no dolt database)",
+			Category: CategoryFederation,
+		}
+	}
+
+	ctx := context.Background()
+	store, err := dolt.New(ctx, doltServerConfig(beadsDir, doltPath))
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusWarning,
+			Message:  "Unable to open database",
+			Detail:   err.Error(),
+			Category: CategoryFederation,
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	peers, err := store.ListFederationPeers(ctx)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusWarning,
+			Message:  "Unable to list federation peers",
+			Detail:   err.Error(),
+			Category: CategoryFederation,
+		}
+	}
+	if len(peers) == 0 {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusOK,
+			Message:  "No federation peers configured",
+			Category: CategoryFederation,
+		}
+	}
+
+	threshold := config.GetFederationConfig().StaleThreshold
+	if threshold <= 0 {
+		threshold = defaultPeerStaleThreshold
+	}
+
+	var details []string
+	var unhealthy int
+	now := time.Now()
+	for _, peer := range peers {
+		var problems []string
+		if err := resolvePeerURL(ctx, peer.RemoteURL); err != nil {
+			problems = append(problems, fmt.Sprintf("url does not resolve: %v", err))
+		} else {
+			probeCtx, cancel := context.WithTimeout(ctx, peerProbeTimeout)
+			err := store.ProbePeer(probeCtx, peer.Name)
+			cancel()
+			if err != nil {
+				if isPeerAuthError(err) {
+					problems = append(problems, "credentials rejected")
+				} else {
+					problems = append(problems, fmt.Sprintf("fetch failed: %v", err))
+				}
+			}
+		}
+		if msg := peerSyncAgeProblem(peer.LastSync, now, threshold); msg != "" {
+			problems = append(problems, msg)
+		}
+
+		if len(problems) == 0 {
+			details = append(details, fmt.Sprintf("%s: ok (last sync %s ago)", peer.Name, now.Sub(*peer.LastSync).Round(time.Minute)))
+			continue
+		}
+		unhealthy++
+		details = append(details, fmt.Sprintf("%s: %s", peer.Name, strings.Join(problems, "; ")))
+	}
+
+	if unhealthy == 0 {
+		return DoctorCheck{
+			Name:     "Peer Health",
+			Status:   StatusOK,
+			Message:  fmt.Sprintf("%d peers healthy", len(peers)),
+			Detail:   strings.Join(details, "\n"),
+			Category: CategoryFederation,
+		}
+	}
+
+	return DoctorCheck{
+		Name:     "Peer Health",
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d/%d peers unhealthy", unhealthy, len(peers)),
+		Detail:   strings.Join(details, "\n"),
+		Fix:      "Check peer URLs with 'bd federation list-peers', re-add credentials with 'bd federation add-peer', then run 'bd federation sync'",
+		Category: CategoryFederation,
+	}
+}
+
+// resolvePeerURL verifies that a peer remote URL points somewhere reachable
+// by name: network hosts must resolve in DNS and file:// paths must exist.
+// Cloud storage schemes (s3://, gs://, ...) have no host to resolve and pass.
+func resolvePeerURL(ctx context.Context, rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("empty remote URL")
+	}
+	// Scheme-less remotes are either scp-style SSH (git@host:org/repo) or a
+	// bare SQL server address (host:port/db).
+	if !strings.Contains(rawURL, "://") {
+		if at := strings.Index(rawURL, "@"); at >= 0 {
+			if colon := strings.Index(rawURL[at:], ":"); colon > 0 {
+				return lookupPeerHost(ctx, rawURL[at+1:at+colon])
+			}
+		}
+		host, _, _ := strings.Cut(rawURL, "/")
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		return lookupPeerHost(ctx, host)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "file", "git+file":
+		if _, err := os.Stat(u.Path); err != nil {
+			return err
+		}
+		return nil
+	case "dolthub":
+		return lookupPeerHost(ctx, "doltremoteapi.dolthub.com")
+	case "http", "https", "git", "ssh", "git+ssh", "git+http", "git+https":
+		return lookupPeerHost(ctx, u.Hostname())
+	default:
+		return nil
+	}
+}
+
+// lookupPeerHost resolves host, treating IP literals as already resolved.
+func lookupPeerHost(ctx context.Context, host string) error {
+	if host == "" {
+		return fmt.Errorf("remote URL has no host")
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+	return err
+}
+
+// isPeerAuthError reports whether a fetch failure looks like rejected credentials.
+func isPeerAuthError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"unauthenticated", "permission denied", "access denied", "authentication", "401", "403"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerSyncAgeProblem describes a peer whose last_sync is missing or older
+// than threshold, or returns "" when the sync is recent enough.
+func peerSyncAgeProblem(lastSync *time.Time, now time.Time, threshold time.Duration) string {
+	if lastSync == nil {
+		return "never synced"
+	}
+	if age := now.Sub(*lastSync); age > threshold {
+		return fmt.Sprintf("last sync %s ago (threshold %s)", age.Round(time.Minute), threshold)
+	}
+	return ""
+}
+
 // CheckFederationConflicts checks for unresolved merge conflicts.
 func CheckFederationConflicts(path string) DoctorCheck {
 	backend, beadsDir := getBackendAndBeadsDir(path)
@@ -540,7 +743,7 @@ func CheckFederationConflicts(path string) DoctorCheck {
 	}
 	defer func() { _ = store.Close() }()
 
-	conflicts, err := store.GetConflicts(ctx)
+	conflicts, err := store.GetConflictDetails(ctx)
 	if err != nil {
 		// Some errors are expected (e.g., no conflicts table)
 		if strings.Contains(err.Error(), "no such table") || strings.Contains(err.Error(), "doesn't exist") {
@@ -572,7 +775,11 @@ func CheckFederationConflicts(path string) DoctorCheck {
 	// Group conflicts by issue ID
 	issueConflicts := make(map[string][]string)
 	for _, c := range conflicts {
-		issueConflicts[c.IssueID] = append(issueConflicts[c.IssueID], c.Field)
+		field := c.Table
+		if c.Field != "" {
+			field += "." + c.Field
+		}
+		issueConflicts[c.IssueID] = append(issueConflicts[c.IssueID], field)
 	}
 
 	var details []string
@@ -585,11 +792,131 @@ func CheckFederationConflicts(path string) DoctorCheck {
 		Status:   StatusError,
 		Message:  fmt.Sprintf("%d unresolved conflicts in %d issues", len(conflicts), len(issueConflicts)),
 		Detail:   strings.Join(details, "\n"),
-		Fix:      "Run 'bd federation sync --strategy ours|theirs' to resolve conflicts",
+		Fix:      "Run 'bd federation conflicts' to review, then 'bd federation conflicts --ours|--theirs' to resolve",
 		Category: CategoryFederation,
 	}
 }
 
+// CheckFederationPeerWisps flags wisps in data fetched from federation peers:
+// ephemeral issues or wisp table rows on a peer's remote-tracking branch mean
+// the peer is pushing wisps, which should not cross federation boundaries.
+func CheckFederationPeerWisps(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+
+	// Only relevant for Dolt backend
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryFederation,
+		}
+	}
+
+	// Check if dolt directory exists
+	doltPath := getDatabasePath(beadsDir)
+	if _, err := os.Stat(doltPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusOK,
+			Message:  "N/A (no dolt database)",
+			Category: CategoryFederation,
+		}
+	}
+
+	ctx := context.Background()
+	store, err := dolt.New(ctx, doltServerConfig(beadsDir, doltPath))
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusWarning,
+			Message:  "Unable to open database",
+			Detail:   err.Error(),
+			Category: CategoryFederation,
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	remotes, err := store.ListRemotes(ctx)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusWarning,
+			Message:  "Unable to list remotes",
+			Detail:   err.Error(),
+			Category: CategoryFederation,
+		}
+	}
+
+	var details []string
+	var checked, leaking int
+	for _, remote := range remotes {
+		// Skip origin - it's typically the DoltHub remote, not a peer
+		if remote.Name == "origin" {
+			continue
+		}
+		counts, err := store.PeerWispCounts(ctx, remote.Name)
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: unable to check: %v", remote.Name, err))
+			continue
+		}
+		if counts == nil {
+			continue // never fetched
+		}
+		checked++
+		if len(counts) > 0 {
+			leaking++
+			details = append(details, fmt.Sprintf("%s: %s", remote.Name, formatPeerWispCounts(counts)))
+		}
+	}
+
+	if leaking > 0 {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusWarning,
+			Message:  fmt.Sprintf("%d/%d peers have wisps in synced data", leaking, checked),
+			Detail:   strings.Join(details, "\n"),
+			Fix:      "On the peer, unset allow_wisps (federation.allow_wisps or its federation.peer-filters entry for this town) and sync again",
+			Category: CategoryFederation,
+		}
+	}
+	if checked == 0 {
+		return DoctorCheck{
+			Name:     "Peer Wisps",
+			Status:   StatusOK,
+			Message:  "No fetched peer data to check",
+			Detail:   strings.Join(details, "\n"),
+			Category: CategoryFederation,
+		}
+	}
+	return DoctorCheck{
+		Name:     "Peer Wisps",
+		Status:   StatusOK,
+		Message:  fmt.Sprintf("No wisps in data from %d peers", checked),
+		Detail:   strings.Join(details, "\n"),
+		Category: CategoryFederation,
+	}
+}
+
+// formatPeerWispCounts renders per-table wisp counts, e.g.
+// "2 ephemeral issues, 5 rows in wisps".
+func formatPeerWispCounts(counts map[string]int) string {
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	parts := make([]string, 0, len(tables))
+	for _, table := range tables {
+		if table == "issues" {
+			parts = append(parts, fmt.Sprintf("%d ephemeral issues", counts[table]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d rows in %s", counts[table], table))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // CheckDoltServerModeMismatch checks for mismatch between Dolt init and server mode.
 // This detects cases where:
 // - Server mode is expected but no server is running
@@ -696,3 +1023,124 @@ func CheckDoltServerModeMismatch(path string) DoctorCheck {
 		Category: CategoryFederation,
 	}
 }
+
+// credentialKeyFile is the federation credential encryption key, kept in
+// .beads/ by the dolt store (older versions wrote it to .beads/dolt/).
+const credentialKeyFile = ".beads-credential-key" //nolint:gosec // G101: filename, not a credential
+
+// credentialKeyFileProblems reports hygiene problems with the credential key
+// file that can be seen without opening the database. A missing key is not a
+// problem by itself: the store creates one when a peer password is first saved.
+func credentialKeyFileProblems(beadsDir, doltPath string) []string {
+	keyPath := filepath.Join(beadsDir, credentialKeyFile)
+	info, err := os.Stat(keyPath)
+	if os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(doltPath, credentialKeyFile)); err == nil {
+			return []string{fmt.Sprintf("key is in the legacy location %s, not %s", filepath.Join(doltPath, credentialKeyFile), keyPath)}
+		}
+		return nil
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("cannot stat %s: %v", keyPath, err)}
+	}
+
+	var problems []string
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		problems = append(problems, fmt.Sprintf("permissions are %#o, want 0600", info.Mode().Perm()))
+	}
+	if info.Size() != 32 {
+		problems = append(problems, fmt.Sprintf("key is %d bytes, want 32", info.Size()))
+	}
+	gitignore, _ := os.ReadFile(filepath.Join(beadsDir, ".gitignore")) // #nosec G304 -- path is constructed from known parts
+	if !containsGitignorePattern(string(gitignore), credentialKeyFile) {
+		problems = append(problems, credentialKeyFile+" is not listed in .beads/.gitignore")
+	}
+	return problems
+}
+
+// CheckCredentialKeyFile checks the federation credential key file: it should
+// live in .beads/, be owner-only (0600), hold a 32-byte AES-256 key, be
+// gitignored, and decrypt every password stored in federation_peers.
+func CheckCredentialKeyFile(path string) DoctorCheck {
+	backend, beadsDir := getBackendAndBeadsDir(path)
+	if backend != configfile.BackendDolt {
+		return DoctorCheck{
+			Name:     "Credential Key",
+			Status:   StatusOK,
+			Message:  "N/A (non-Dolt backend)",
+			Category: CategoryFederation,
+		}
+	}
+
+	doltPath := getDatabasePath(beadsDir)
+	problems := credentialKeyFileProblems(beadsDir, doltPath)
+
+	if _, err := os.Stat(doltPath); err == nil {
+		ctx := context.Background()
+		cfg := doltServerConfig(beadsDir, doltPath)
+		cfg.BeadsDir = beadsDir
+		store, err := dolt.New(ctx, cfg)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("stored passwords not verified (database unavailable: %v)", err))
+		} else {
+			bad, err := store.UndecryptableFederationPeers(ctx)
+			_ = store.Close()
+			switch {
+			case err != nil:
+				problems = append(problems, fmt.Sprintf("stored passwords not verified: %v", err))
+			case len(bad) > 0:
+				problems = append(problems, fmt.Sprintf("passwords for %s do not decrypt with this key", strings.Join(bad, ", ")))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return DoctorCheck{
+			Name:     "Credential Key",
+			Status:   StatusOK,
+			Message:  "Credential key file is in order",
+			Category: CategoryFederation,
+		}
+	}
+	return DoctorCheck{
+		Name:     "Credential Key",
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d credential key problem(s)", len(problems)),
+		Detail:   strings.Join(problems, "\n"),
+		Fix:      "Run 'bd doctor --fix'; passwords that still do not decrypt must be re-entered with 'bd federation add-peer'",
+		Category: CategoryFederation,
+	}
+}
+
+// FixCredentialKeyFile gitignores the credential key, re-runs the store's key
+// migration (legacy location and legacy derived key), and resets the key
+// file to 0600. A key of the wrong size is replaced: it cannot decrypt
+// anything, so the passwords it guarded must be re-entered either way.
+func FixCredentialKeyFile(path string) error {
+	_, beadsDir := getBackendAndBeadsDir(path)
+	if err := EnsureGitignoreForBeadsDir(beadsDir); err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(beadsDir, credentialKeyFile)
+	if info, err := os.Stat(keyPath); err == nil && info.Size() != 32 {
+		if err := os.Remove(keyPath); err != nil {
+			return fmt.Errorf("remove malformed credential key: %w", err)
+		}
+	}
+
+	doltPath := getDatabasePath(beadsDir)
+	if _, err := os.Stat(doltPath); err != nil {
+		return nil
+	}
+	ctx := context.Background()
+	cfg := doltServerConfig(beadsDir, doltPath)
+	cfg.BeadsDir = beadsDir
+	cfg.ReadOnly = false
+	store, err := dolt.New(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+	return store.MigrateCredentialKey(ctx)
+}
@@ -3,12 +3,16 @@
 package doctor
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/configfile"
 )
@@ -436,9 +440,12 @@ func TestCheckFederationChecks_CategoryIsFederation(t *testing.T) {
 		{"RemotesAPI", CheckFederationRemotesAPI},
 		{"PeerConnectivity", CheckFederationPeerConnectivity},
 		{"SyncStaleness", CheckFederationSyncStaleness},
+		{"PeerHealth", CheckFederationPeerHealth},
 		{"Conflicts", CheckFederationConflicts},
+		{"PeerWisps", CheckFederationPeerWisps},
 		{"LegacyCLIRemotes", CheckLegacyCLIRemotes},
 		{"ServerModeMismatch", CheckDoltServerModeMismatch},
+		{"CredentialKey", CheckCredentialKeyFile},
 	}
 
 	for _, tc := range checks {
@@ -678,9 +685,12 @@ func TestCheckFederationRemotesAPI_AllCheckNames(t *testing.T) {
 		{CheckFederationRemotesAPI, "Federation remotesapi"},
 		{CheckFederationPeerConnectivity, "Peer Connectivity"},
 		{CheckFederationSyncStaleness, "Sync Staleness"},
+		{CheckFederationPeerHealth, "Peer Health"},
 		{CheckFederationConflicts, "Federation Conflicts"},
+		{CheckFederationPeerWisps, "Peer Wisps"},
 		{CheckLegacyCLIRemotes, "Dolt Remote Migration"},
 		{CheckDoltServerModeMismatch, "Dolt Mode"},
+		{CheckCredentialKeyFile, "Credential Key"},
 	}
 
 	for _, tc := range checks {
@@ -690,3 +700,108 @@ func TestCheckFederationRemotesAPI_AllCheckNames(t *testing.T) {
 		}
 	}
 }
+
+func TestPeerSyncAgeProblem(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	old := now.Add(-48 * time.Hour)
+
+	if got := peerSyncAgeProblem(nil, now, 24*time.Hour); got != "never synced" {
+		t.Errorf("nil last sync: got %q", got)
+	}
+	if got := peerSyncAgeProblem(&recent, now, 24*time.Hour); got != "" {
+		t.Errorf("recent sync should be healthy, got %q", got)
+	}
+	if got := peerSyncAgeProblem(&old, now, 24*time.Hour); !strings.Contains(got, "48h0m0s") {
+		t.Errorf("stale sync: got %q", got)
+	}
+}
+
+func TestFormatPeerWispCounts(t *testing.T) {
+	got := formatPeerWispCounts(map[string]int{"wisps": 5, "issues": 2, "wisp_labels": 1})
+	want := "2 ephemeral issues, 1 rows in wisp_labels, 5 rows in wisps"
+	if got != want {
+		t.Errorf("formatPeerWispCounts = %q, want %q", got, want)
+	}
+}
+
+func TestResolvePeerURL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"file://" + dir, false},
+		{"file://" + filepath.Join(dir, "missing"), true},
+		{"https://127.0.0.1/org/db", false},
+		{"127.0.0.1:3306/beads", false},
+		{"git@127.0.0.1:org/repo.git", false},
+		{"s3://bucket/beads", false},
+		{"https:///no-host", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := resolvePeerURL(ctx, tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolvePeerURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestIsPeerAuthError(t *testing.T) {
+	if !isPeerAuthError(fmt.Errorf("rpc error: code = Unauthenticated desc = bad password")) {
+		t.Error("expected Unauthenticated to be an auth error")
+	}
+	if isPeerAuthError(fmt.Errorf("dial tcp: connection refused")) {
+		t.Error("connection refused is not an auth error")
+	}
+}
+
+func TestCredentialKeyFileProblems(t *testing.T) {
+	beadsDir := t.TempDir()
+	doltPath := filepath.Join(beadsDir, "dolt")
+	if err := os.MkdirAll(doltPath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(beadsDir, credentialKeyFile)
+
+	if got := credentialKeyFileProblems(beadsDir, doltPath); len(got) != 0 {
+		t.Errorf("no key file: problems = %v, want none", got)
+	}
+
+	legacy := filepath.Join(doltPath, credentialKeyFile)
+	if err := os.WriteFile(legacy, make([]byte, 32), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := credentialKeyFileProblems(beadsDir, doltPath); len(got) != 1 || !strings.Contains(got[0], "legacy location") {
+		t.Errorf("legacy key: problems = %v", got)
+	}
+
+	if err := os.WriteFile(keyPath, make([]byte, 16), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(credentialKeyFileProblems(beadsDir, doltPath), "\n")
+	for _, want := range []string{"16 bytes", "not listed in .beads/.gitignore"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("problems %q missing %q", got, want)
+		}
+	}
+	if runtime.GOOS != "windows" && !strings.Contains(got, "0644") {
+		t.Errorf("problems %q should report mode 0644", got)
+	}
+
+	if err := os.WriteFile(keyPath, make([]byte, 32), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(keyPath, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnsureGitignoreForBeadsDir(beadsDir); err != nil {
+		t.Fatal(err)
+	}
+	if got := credentialKeyFileProblems(beadsDir, doltPath); len(got) != 0 {
+		t.Errorf("healthy key: problems = %v, want none", got)
+	}
+}
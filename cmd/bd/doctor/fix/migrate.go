@@ -1,16 +1,16 @@
 package fix
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/types"
@@ -185,25 +185,28 @@ func importJSONLIntoStore(ctx context.Context, store storage.DoltStorage, jsonlP
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024) // 64MB max line
+	// The reader skips the schema header and migrates older exports.
+	r := jsonl.NewReader(f)
 	var issues []*types.Issue
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if rec.Type() == "memory" {
 			continue
 		}
 		var issue types.Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+		if err := rec.Decode(&issue); err != nil {
 			return 0, fmt.Errorf("failed to parse issue: %w", err)
 		}
 		issue.SetDefaults()
 		issues = append(issues, &issue)
 	}
-	if err := scanner.Err(); err != nil {
-		return 0, fmt.Errorf("failed to scan JSONL: %w", err)
-	}
 
 	if len(issues) == 0 {
 		return 0, nil
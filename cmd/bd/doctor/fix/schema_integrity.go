@@ -0,0 +1,57 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/schema"
+)
+
+// SchemaIntegrity adds the columns and indexes the core tables are missing
+// relative to the binary's expected schema. It never drops or alters
+// anything that exists; missing tables and foreign keys are reported by the
+// check but left for migrations or manual repair.
+func SchemaIntegrity(path string) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		fmt.Printf("  Schema integrity fix skipped (%v)\n", err)
+		return nil
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	drift, err := schema.ScanDrift(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to scan schema: %w", err)
+	}
+	tables := schema.RepairedTables(drift)
+	if len(tables) == 0 {
+		fmt.Println("  No missing columns or indexes to add")
+		return nil
+	}
+
+	applied, err := schema.RepairDrift(ctx, db, drift)
+	for _, stmt := range applied {
+		fmt.Printf("  %s\n", stmt)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Commit the versioned tables only; wisps are dolt_ignore'd. Best
+	// effort: the DDL is already applied to the working set.
+	for _, table := range tables {
+		if table != "wisps" {
+			_, _ = db.Exec("CALL DOLT_ADD(?)", table)
+		}
+	}
+	_, _ = db.Exec("CALL DOLT_COMMIT('-m', 'doctor: restore missing schema columns and indexes')")
+
+	fmt.Printf("  Applied %d schema change(s) to %v\n", len(applied), tables)
+	return nil
+}
@@ -0,0 +1,309 @@
+package fix
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// wispAuxTable describes a wisp-side table keyed by wisp ID and how to move
+// its rows to the issue-side counterpart once the ID lives in issues.
+type wispAuxTable struct {
+	Wisp, Issue string
+	Key         string   // column holding the wisp ID
+	Move        []string // copy the rows of one ID (?) to Issue
+}
+
+// wispAuxTables are the wisp tables that can drift from wisps. Their FKs to
+// wisps are clone-local (ignored migration 0004) and were added with
+// FOREIGN_KEY_CHECKS off, so rows orphaned before then — or written by a
+// clone without them — survive. wisp_dependencies has always had its FK.
+var wispAuxTables = []wispAuxTable{
+	{"wisp_labels", "labels", "issue_id", []string{`
+		INSERT IGNORE INTO labels (issue_id, label)
+		SELECT issue_id, label FROM wisp_labels WHERE issue_id = ?`}},
+	{"wisp_events", "events", "issue_id", []string{`
+		INSERT IGNORE INTO events (id, issue_id, event_type, actor, old_value, new_value, comment, created_at)
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM wisp_events WHERE issue_id = ?`}},
+	{"wisp_comments", "comments", "issue_id", []string{`
+		INSERT IGNORE INTO comments (id, issue_id, author, text, created_at)
+		SELECT id, issue_id, author, text, created_at
+		FROM wisp_comments WHERE issue_id = ?`}},
+	// Counters never go down: an existing issue-side counter keeps the
+	// larger of the two values.
+	{"wisp_child_counters", "child_counters", "parent_id", []string{`
+		INSERT IGNORE INTO child_counters (parent_id, last_child)
+		SELECT parent_id, last_child FROM wisp_child_counters WHERE parent_id = ?`, `
+		UPDATE child_counters c JOIN wisp_child_counters w ON w.parent_id = c.parent_id
+		SET c.last_child = GREATEST(c.last_child, w.last_child)
+		WHERE c.parent_id = ?`}},
+}
+
+// WispTableDrift lists the IDs in one wisp table that have no wisps row.
+type WispTableDrift struct {
+	Table    string
+	Stranded []string // IDs now in issues: rows left behind by a promotion
+	Orphaned []string // IDs in neither wisps nor issues
+}
+
+// WispDriftReport summarizes cross-table drift between the wisp and issue
+// tables.
+type WispDriftReport struct {
+	Duplicated []string // IDs with a row in both wisps and issues
+	Tables     []WispTableDrift
+	// MisplacedCounters are child_counters parents that are wisps, whose
+	// counter belongs in wisp_child_counters.
+	MisplacedCounters []string
+}
+
+// Empty reports whether no drift was found.
+func (d *WispDriftReport) Empty() bool {
+	return len(d.Duplicated) == 0 && len(d.Tables) == 0 && len(d.MisplacedCounters) == 0
+}
+
+// ScanWispDrift finds IDs whose wisp table rows have no wisps row, IDs
+// present in both wisps and issues, and issue-side child counters of wisps.
+// Tables missing from older schemas are skipped.
+func ScanWispDrift(ctx context.Context, db *sql.DB) (*WispDriftReport, error) {
+	drift := &WispDriftReport{}
+	dup, err := queryIDs(ctx, db, `SELECT w.id FROM wisps w JOIN issues i ON i.id = w.id ORDER BY w.id`)
+	if err != nil {
+		if isExpectedProbeError(err) {
+			return drift, nil
+		}
+		return nil, fmt.Errorf("wisps: %w", err)
+	}
+	drift.Duplicated = dup
+
+	for _, t := range wispAuxTables {
+		//nolint:gosec // G201: table and column are hardcoded constants, never user input.
+		rows, err := db.QueryContext(ctx, fmt.Sprintf(`
+			SELECT DISTINCT a.%[2]s, i.id IS NOT NULL
+			FROM %[1]s a
+			LEFT JOIN wisps w ON w.id = a.%[2]s
+			LEFT JOIN issues i ON i.id = a.%[2]s
+			WHERE w.id IS NULL
+			ORDER BY a.%[2]s`, t.Wisp, t.Key))
+		if err != nil {
+			if isExpectedProbeError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", t.Wisp, err)
+		}
+		td := WispTableDrift{Table: t.Wisp}
+		for rows.Next() {
+			var id string
+			var inIssues bool
+			if err := rows.Scan(&id, &inIssues); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("%s: %w", t.Wisp, err)
+			}
+			if inIssues {
+				td.Stranded = append(td.Stranded, id)
+			} else {
+				td.Orphaned = append(td.Orphaned, id)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Wisp, err)
+		}
+		if len(td.Stranded)+len(td.Orphaned) > 0 {
+			drift.Tables = append(drift.Tables, td)
+		}
+	}
+
+	misplaced, err := queryIDs(ctx, db, `
+		SELECT c.parent_id FROM child_counters c
+		JOIN wisps w ON w.id = c.parent_id
+		LEFT JOIN issues i ON i.id = c.parent_id
+		WHERE i.id IS NULL
+		ORDER BY c.parent_id`)
+	if err != nil && !isExpectedProbeError(err) {
+		return nil, fmt.Errorf("child_counters: %w", err)
+	}
+	drift.MisplacedCounters = misplaced
+	return drift, nil
+}
+
+// WispDrift reconciles wisp/issue cross-table drift. An ID in both wisps and
+// issues is treated as an interrupted promotion: the issues row is kept, the
+// wisp's rows are copied to the issue tables, and the wisps row is removed.
+// Stranded wisp rows of promoted issues are moved the same way, orphaned rows
+// whose ID exists nowhere are deleted, and child counters of wisps move to
+// wisp_child_counters.
+// If verbose is true, prints each repaired ID; otherwise shows only a summary.
+func WispDrift(path string, verbose bool) error {
+	beadsDir, err := resolvedWorkspaceBeadsDir(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDoltDB(beadsDir)
+	if err != nil {
+		fmt.Printf("  Wisp drift fix skipped (%v)\n", err)
+		return nil
+	}
+	defer db.Close()
+
+	return repairWispDrift(context.Background(), db, verbose)
+}
+
+// repairWispDrift scans and repairs drift on an open connection. Split from
+// WispDrift so the repair logic is testable against an existing store handle.
+func repairWispDrift(ctx context.Context, db *sql.DB, verbose bool) error {
+	drift, err := ScanWispDrift(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to scan wisp drift: %w", err)
+	}
+	if drift.Empty() {
+		fmt.Println("  No wisp drift to fix")
+		return nil
+	}
+
+	// Uses explicit transaction so writes persist when @@autocommit is OFF
+	// (e.g. Dolt server started with --no-auto-commit).
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var moved, removed int
+	repairedTables := make(map[string]bool)
+	for _, t := range wispAuxTables {
+		// Rows of a duplicated wisp are stranded like those of any other
+		// promoted issue; move them before the wisps row (and its cascade)
+		// goes away.
+		stranded := append([]string(nil), drift.Duplicated...)
+		var orphaned []string
+		for _, td := range drift.Tables {
+			if td.Table == t.Wisp {
+				stranded = append(stranded, td.Stranded...)
+				orphaned = td.Orphaned
+			}
+		}
+		for _, id := range stranded {
+			if err := moveWispRows(ctx, tx, t, id); err != nil {
+				if isExpectedProbeError(err) {
+					break
+				}
+				return fmt.Errorf("move %s rows of %s: %w", t.Wisp, id, err)
+			}
+			n, err := deleteWispRows(ctx, tx, t, id)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				moved += n
+				repairedTables[t.Issue] = true
+				if verbose {
+					fmt.Printf("  Moved %d %s row(s) of %s to %s\n", n, t.Wisp, id, t.Issue)
+				}
+			}
+		}
+		for _, id := range orphaned {
+			n, err := deleteWispRows(ctx, tx, t, id)
+			if err != nil {
+				return err
+			}
+			removed += n
+			if verbose {
+				fmt.Printf("  Removed %d orphaned %s row(s) of %s\n", n, t.Wisp, id)
+			}
+		}
+	}
+
+	for _, id := range drift.Duplicated {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO dependencies (id, issue_id, depends_on_issue_id, depends_on_wisp_id, depends_on_external, type, created_at, created_by, metadata, thread_id)
+			SELECT id, issue_id, depends_on_issue_id, depends_on_wisp_id, depends_on_external, type, created_at, created_by, metadata, thread_id
+			FROM wisp_dependencies WHERE issue_id = ?`, id); err != nil {
+			return fmt.Errorf("copy dependencies of %s: %w", id, err)
+		}
+		repairedTables["dependencies"] = true
+		// Deleting the wisps row cascades to its wisp_dependencies.
+		if _, err := tx.ExecContext(ctx, `DELETE FROM wisps WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("remove duplicate wisp %s: %w", id, err)
+		}
+		if verbose {
+			fmt.Printf("  Removed wisp copy of %s (issue row kept)\n", id)
+		}
+	}
+
+	for _, id := range drift.MisplacedCounters {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO wisp_child_counters (parent_id, last_child)
+			SELECT parent_id, last_child FROM child_counters WHERE parent_id = ?`, id); err != nil {
+			return fmt.Errorf("move child counter of %s: %w", id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE wisp_child_counters w JOIN child_counters c ON c.parent_id = w.parent_id
+			SET w.last_child = GREATEST(w.last_child, c.last_child)
+			WHERE w.parent_id = ?`, id); err != nil {
+			return fmt.Errorf("move child counter of %s: %w", id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM child_counters WHERE parent_id = ?`, id); err != nil {
+			return fmt.Errorf("delete child counter of %s: %w", id, err)
+		}
+		moved++
+		repairedTables["child_counters"] = true
+		if verbose {
+			fmt.Printf("  Moved child counter of wisp %s to wisp_child_counters\n", id)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wisp drift repairs: %w", err)
+	}
+
+	// Commit changes in Dolt, staging only the issue-side tables touched (the
+	// wisp tables are dolt-ignored). Best effort: repair already applied.
+	if len(repairedTables) > 0 {
+		for table := range repairedTables {
+			_, _ = db.Exec("CALL DOLT_ADD(?)", table)
+		}
+		_, _ = db.Exec("CALL DOLT_COMMIT('-m', 'doctor: reconcile wisp/issue table drift')")
+	}
+
+	fmt.Printf("  Fixed wisp drift: %d duplicate wisp(s) removed, %d row(s) moved, %d orphaned row(s) removed\n",
+		len(drift.Duplicated), moved, removed)
+	return nil
+}
+
+func moveWispRows(ctx context.Context, tx *sql.Tx, t wispAuxTable, id string) error {
+	for _, stmt := range t.Move {
+		if _, err := tx.ExecContext(ctx, stmt, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteWispRows(ctx context.Context, tx *sql.Tx, t wispAuxTable, id string) (int, error) {
+	//nolint:gosec // G201: table and column are hardcoded constants, never user input.
+	res, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, t.Wisp, t.Key), id)
+	if err != nil {
+		return 0, fmt.Errorf("delete %s rows of %s: %w", t.Wisp, id, err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+func queryIDs(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,169 @@
+//go:build cgo
+
+package fix
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/testutil"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TestWispDrift_MovesStrandedAndRemovesOrphans seeds each kind of wisp/issue
+// drift — a wisp label left behind by a promotion, wisp rows for an ID that
+// exists nowhere, an ID in both wisps and issues, and a wisp's counter in
+// child_counters — and checks that the repair reconciles all of them.
+func TestWispDrift_MovesStrandedAndRemovesOrphans(t *testing.T) {
+	testutil.RequireDoltBinary(t)
+
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("create .beads: %v", err)
+	}
+	cfg := &configfile.Config{
+		Database: "dolt",
+		Backend:  configfile.BackendDolt,
+	}
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	// Unique database name: the test Dolt container may outlive a single run.
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatalf("rand: %v", err)
+	}
+	dbName := "fixwispdrift_" + hex.EncodeToString(buf)
+
+	ctx := context.Background()
+	store, err := dolt.New(ctx, &dolt.Config{
+		Path:            filepath.Join(beadsDir, "dolt"),
+		Database:        dbName,
+		CreateIfMissing: true,
+		MaxOpenConns:    1,
+	})
+	if err != nil {
+		t.Skipf("skipping: Dolt server not available: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "tst"); err != nil {
+		t.Fatalf("SetConfig(issue_prefix): %v", err)
+	}
+
+	for _, id := range []string{"tst-1", "tst-2"} {
+		issue := &types.Issue{
+			ID:        id,
+			Title:     "wisp drift test " + id,
+			Priority:  2,
+			Status:    types.StatusOpen,
+			IssueType: types.TypeTask,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", id, err)
+		}
+	}
+
+	wisp := &types.Issue{
+		ID:        "tst-w1",
+		Title:     "wisp drift test wisp",
+		Priority:  2,
+		Status:    types.StatusOpen,
+		IssueType: types.TypeTask,
+		Ephemeral: true,
+	}
+	if err := store.CreateIssue(ctx, wisp, "test"); err != nil {
+		t.Fatalf("CreateIssue(tst-w1): %v", err)
+	}
+
+	db := store.UnderlyingDB()
+	// FK checks off reproduces rows written before the clone-local wisp FKs
+	// existed. MaxOpenConns is 1, so the session setting sticks.
+	seed := []string{
+		`SET FOREIGN_KEY_CHECKS = 0`,
+		// Stranded: tst-1 lives in issues, its label was left in wisp_labels.
+		`INSERT INTO wisp_labels (issue_id, label) VALUES ('tst-1', 'stranded')`,
+		// Orphaned: tst-gone exists in neither table.
+		`INSERT INTO wisp_labels (issue_id, label) VALUES ('tst-gone', 'orphan')`,
+		`INSERT INTO wisp_comments (id, issue_id, author, text) VALUES (UUID(), 'tst-gone', 'test', 'orphan')`,
+		// Duplicated: tst-w1 also has an issues row, as after an interrupted promotion.
+		`INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at)
+		 SELECT id, title, status, priority, issue_type, created_at, updated_at FROM wisps WHERE id = 'tst-w1'`,
+		`INSERT INTO wisp_labels (issue_id, label) VALUES ('tst-w1', 'promoted')`,
+		`INSERT INTO wisp_child_counters (parent_id, last_child) VALUES ('tst-w1', 4)`,
+		// Misplaced: a wisp's counter in the issue-side table.
+		`INSERT INTO wisps (id, title, status, priority, issue_type) VALUES ('tst-w2', 'w2', 'open', 2, 'task')`,
+		`INSERT INTO child_counters (parent_id, last_child) VALUES ('tst-w2', 3)`,
+		`SET FOREIGN_KEY_CHECKS = 1`,
+	}
+	for _, q := range seed {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("seed %q: %v", q, err)
+		}
+	}
+
+	report, err := ScanWispDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("ScanWispDrift: %v", err)
+	}
+	if len(report.Duplicated) != 1 || report.Duplicated[0] != "tst-w1" {
+		t.Errorf("Duplicated = %v, want [tst-w1]", report.Duplicated)
+	}
+	tables := make(map[string]WispTableDrift)
+	for _, td := range report.Tables {
+		tables[td.Table] = td
+	}
+	if got := tables["wisp_labels"]; len(got.Stranded) != 1 || got.Stranded[0] != "tst-1" ||
+		len(got.Orphaned) != 1 || got.Orphaned[0] != "tst-gone" {
+		t.Errorf("wisp_labels drift = %+v, want stranded [tst-1], orphaned [tst-gone]", got)
+	}
+	if len(report.MisplacedCounters) != 1 || report.MisplacedCounters[0] != "tst-w2" {
+		t.Errorf("MisplacedCounters = %v, want [tst-w2]", report.MisplacedCounters)
+	}
+	if got := tables["wisp_comments"]; len(got.Orphaned) != 1 || got.Orphaned[0] != "tst-gone" {
+		t.Errorf("wisp_comments drift = %+v, want orphaned [tst-gone]", got)
+	}
+
+	if err := repairWispDrift(ctx, db, true); err != nil {
+		t.Fatalf("repairWispDrift: %v", err)
+	}
+
+	report, err = ScanWispDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("ScanWispDrift after fix: %v", err)
+	}
+	if !report.Empty() {
+		t.Errorf("expected no drift after fix, got %+v", report)
+	}
+
+	for _, tc := range []struct {
+		query string
+		want  int
+	}{
+		{`SELECT COUNT(*) FROM labels WHERE issue_id = 'tst-1' AND label = 'stranded'`, 1},
+		{`SELECT COUNT(*) FROM labels WHERE issue_id = 'tst-w1' AND label = 'promoted'`, 1},
+		{`SELECT COUNT(*) FROM wisps WHERE id = 'tst-w1'`, 0},
+		{`SELECT COUNT(*) FROM issues WHERE id = 'tst-w1'`, 1},
+		{`SELECT COUNT(*) FROM wisp_labels`, 0},
+		{`SELECT COUNT(*) FROM wisp_comments WHERE issue_id = 'tst-gone'`, 0},
+		{`SELECT last_child FROM child_counters WHERE parent_id = 'tst-w1'`, 4},
+		{`SELECT last_child FROM wisp_child_counters WHERE parent_id = 'tst-w2'`, 3},
+		{`SELECT COUNT(*) FROM child_counters WHERE parent_id = 'tst-w2'`, 0},
+	} {
+		var count int
+		if err := db.QueryRowContext(ctx, tc.query).Scan(&count); err != nil {
+			t.Fatalf("%s: %v", tc.query, err)
+		}
+		if count != tc.want {
+			t.Errorf("%s = %d, want %d", tc.query, count, tc.want)
+		}
+	}
+}
@@ -90,7 +90,7 @@ func CheckGitHooks(cliVersion string) DoctorCheck {
 						oldest,
 						cliVersion,
 					),
-					Fix: "Run 'bd hooks install --force' to update hooks",
+					Fix: "Run 'bd hooks upgrade' to update hooks in place",
 				}
 			}
 			return DoctorCheck{
@@ -158,7 +158,7 @@ func CheckGitHooks(cliVersion string) DoctorCheck {
 					oldest,
 					cliVersion,
 				),
-				Fix: "Run 'bd hooks install --force' to update hooks",
+				Fix: "Run 'bd hooks upgrade' to update hooks in place",
 			}
 		}
 		return DoctorCheck{
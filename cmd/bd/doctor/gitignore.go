@@ -27,6 +27,12 @@ daemon.*
 # Push state (runtime, per-machine)
 push-state.json
 
+# Federation sync daemon state (runtime, per-machine)
+federation-daemon.json
+
+# Digest daemon state (runtime, per-machine)
+digest-daemon.json
+
 # Lock files (various runtime locks)
 *.lock
 
@@ -47,7 +53,9 @@ redirect
 .sync.lock
 export-state/
 export-state.json
+import-checkpoint.json
 last_pull
+dolt-branch
 
 # Ephemeral store (SQLite - wisps/molecules, intentionally not versioned)
 ephemeral.sqlite3
@@ -71,6 +79,13 @@ dolt-pprof/
 # Backup data (auto-exported JSONL, local-only)
 backup/
 
+# Writes queued while the Dolt server was unreachable (bd sync --flush)
+offline-queue.jsonl
+
+# Read-only SQLite mirror (bd mirror) and its refresh state
+mirror.sqlite
+mirror-state.json
+
 # Per-project environment file (Dolt connection config, GH#2520)
 .env
 
@@ -110,7 +125,9 @@ var requiredPatterns = []string{
 	".sync.lock",
 	"export-state/",
 	"export-state.json",
+	"import-checkpoint.json",
 	"last_pull",
+	"dolt-branch",
 	"dolt/",
 	"embeddeddolt/",
 	"proxieddb/",
@@ -127,6 +144,9 @@ var requiredPatterns = []string{
 	"proxied_server_client_info.json",
 	".local_version",
 	"backup/",
+	"offline-queue.jsonl",
+	"mirror.sqlite",
+	"mirror-state.json",
 }
 
 // CheckGitignore checks if .beads/.gitignore is up to date.
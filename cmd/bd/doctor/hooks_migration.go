@@ -20,6 +20,7 @@ const (
 
 var managedHookNames = []string{
 	"pre-commit",
+	"post-commit",
 	"post-merge",
 	"pre-push",
 	"post-checkout",
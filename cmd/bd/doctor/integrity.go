@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // CheckIDFormat checks whether issues use hash-based or sequential IDs.
@@ -561,3 +562,57 @@ func isHashID(id string) bool {
 	// Sequential IDs are purely numeric
 	return regexp.MustCompile(`[a-z]`).MatchString(baseSuffix)
 }
+
+// CheckChildCountersWithStore reports child_counters rows that trail an
+// existing hierarchical child or belong to a parent that no longer exists.
+func CheckChildCountersWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Child Counters",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+	statuses, err := store.ChildCounterStatus(context.Background())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Child Counters",
+			Status:  StatusOK,
+			Message: "N/A (unable to read child counters)",
+		}
+	}
+	return childCountersCheck(statuses)
+}
+
+// childCountersCheck turns counter statuses into a doctor result.
+func childCountersCheck(statuses []*types.ChildCounterStatus) DoctorCheck {
+	var details []string
+	for _, s := range statuses {
+		switch {
+		case s.Orphaned():
+			details = append(details, fmt.Sprintf("%s: counter %d, parent missing", s.ParentID, s.LastChild))
+		case s.Behind():
+			details = append(details, fmt.Sprintf("%s: counter %d, highest child .%d", s.ParentID, s.LastChild, s.MaxChild))
+		}
+	}
+	if len(details) == 0 {
+		return DoctorCheck{
+			Name:    "Child Counters",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d parent(s), no drift", len(statuses)),
+		}
+	}
+	drifted := len(details)
+	const maxShown = 10
+	if len(details) > maxShown {
+		details = append(details[:maxShown], fmt.Sprintf("... and %d more", len(details)-maxShown))
+	}
+	return DoctorCheck{
+		Name:    "Child Counters",
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d child counter(s) drifted from existing children", drifted),
+		Detail:  strings.Join(details, "\n"),
+		Fix:     "Run 'bd child counters --repair'",
+	}
+}
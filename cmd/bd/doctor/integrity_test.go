@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // TestIntegrityChecks_NoBeadsDir verifies all integrity check functions handle
@@ -196,3 +198,29 @@ func TestCheckDependencyCycles_NoDatabase(t *testing.T) {
 		t.Errorf("Status = %q, want %q", check.Status, StatusOK)
 	}
 }
+
+func TestChildCountersCheck(t *testing.T) {
+	ok := &types.ChildCounterStatus{ParentID: "bd-a", LastChild: 5, HasCounter: true, MaxChild: 3, Children: 2, ParentExists: true}
+	if got := childCountersCheck([]*types.ChildCounterStatus{ok}); got.Status != StatusOK {
+		t.Errorf("counter ahead of children should be OK, got %s: %s", got.Status, got.Message)
+	}
+
+	behind := &types.ChildCounterStatus{ParentID: "bd-b", LastChild: 1, HasCounter: true, MaxChild: 4, Children: 2, ParentExists: true}
+	missing := &types.ChildCounterStatus{ParentID: "bd-c", MaxChild: 2, Children: 1, ParentExists: true}
+	orphan := &types.ChildCounterStatus{ParentID: "bd-gone", LastChild: 3, HasCounter: true}
+	got := childCountersCheck([]*types.ChildCounterStatus{ok, behind, missing, orphan})
+	if got.Status != StatusWarning {
+		t.Fatalf("expected warning, got %s", got.Status)
+	}
+	if !strings.HasPrefix(got.Message, "3 ") {
+		t.Errorf("message = %q, want 3 drifted counters", got.Message)
+	}
+	for _, want := range []string{"bd-b: counter 1, highest child .4", "bd-c: counter 0", "bd-gone: counter 3, parent missing"} {
+		if !strings.Contains(got.Detail, want) {
+			t.Errorf("detail missing %q:\n%s", want, got.Detail)
+		}
+	}
+	if !strings.Contains(got.Fix, "bd child counters --repair") {
+		t.Errorf("fix = %q", got.Fix)
+	}
+}
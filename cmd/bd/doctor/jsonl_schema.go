@@ -0,0 +1,80 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/jsonl"
+)
+
+const jsonlSchemaCheckName = "JSONL Schema"
+
+// CheckJSONLSchemaVersion warns when a JSONL export in .beads/ was written
+// with a newer schema than this bd understands, e.g. by a teammate on a newer
+// release. Such files still import best-effort, but fields this build does
+// not know about are silently dropped. Headerless files are unversioned
+// exports that the importer migrates, so they pass.
+func CheckJSONLSchemaVersion(path string) DoctorCheck {
+	beadsDir := ResolveBeadsDirForRepo(path)
+	entries, err := os.ReadDir(beadsDir)
+	if err != nil {
+		return DoctorCheck{
+			Name:     jsonlSchemaCheckName,
+			Status:   StatusOK,
+			Message:  "N/A (no .beads directory)",
+			Category: CategoryData,
+		}
+	}
+
+	var checked int
+	var ahead []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		// Unreadable or non-beads JSONL (routes, interactions) is not this
+		// check's concern; other checks report corruption.
+		header, version, err := jsonl.ReadFileHeader(filepath.Join(beadsDir, entry.Name()))
+		if err != nil || header == nil {
+			continue
+		}
+		checked++
+		if version > jsonl.SchemaVersion {
+			desc := fmt.Sprintf("%s (%s", entry.Name(), header.Schema)
+			if header.BDVersion != "" {
+				desc += ", bd " + header.BDVersion
+			}
+			ahead = append(ahead, desc+")")
+		}
+	}
+	sort.Strings(ahead)
+
+	if len(ahead) > 0 {
+		return DoctorCheck{
+			Name:   jsonlSchemaCheckName,
+			Status: StatusWarning,
+			Message: fmt.Sprintf("%d JSONL file(s) use a newer schema than this bd (reads up to %s/%d)",
+				len(ahead), jsonl.SchemaName, jsonl.SchemaVersion),
+			Detail:   strings.Join(ahead, "\n"),
+			Fix:      "Upgrade bd before importing or re-exporting these files; older versions drop fields they do not understand",
+			Category: CategoryData,
+		}
+	}
+	if checked == 0 {
+		return DoctorCheck{
+			Name:     jsonlSchemaCheckName,
+			Status:   StatusOK,
+			Message:  "N/A (no versioned JSONL files)",
+			Category: CategoryData,
+		}
+	}
+	return DoctorCheck{
+		Name:     jsonlSchemaCheckName,
+		Status:   StatusOK,
+		Message:  fmt.Sprintf("%d JSONL file(s) readable by this bd", checked),
+		Category: CategoryData,
+	}
+}
@@ -0,0 +1,57 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckJSONLSchemaVersion(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newBeadsDir := func(t *testing.T) (string, string) {
+		t.Helper()
+		tmpDir := t.TempDir()
+		beadsDir := filepath.Join(tmpDir, ".beads")
+		if err := os.Mkdir(beadsDir, 0750); err != nil {
+			t.Fatal(err)
+		}
+		return tmpDir, beadsDir
+	}
+
+	t.Run("current and legacy files pass", func(t *testing.T) {
+		tmpDir, beadsDir := newBeadsDir(t)
+		write(t, beadsDir, "issues.jsonl", `{"_schema":"beads-jsonl/1","_bd_version":"1.0.5"}`+"\n"+`{"id":"bd-1"}`+"\n")
+		write(t, beadsDir, "old.jsonl", `{"id":"bd-2","wisp":true}`+"\n")
+		write(t, beadsDir, "routes.jsonl", `{"prefix":"bd-"}`+"\n")
+
+		check := CheckJSONLSchemaVersion(tmpDir)
+		if check.Status != StatusOK || !strings.Contains(check.Message, "1 JSONL file") {
+			t.Fatalf("got %s: %s", check.Status, check.Message)
+		}
+	})
+
+	t.Run("newer schema warns", func(t *testing.T) {
+		tmpDir, beadsDir := newBeadsDir(t)
+		write(t, beadsDir, "issues.jsonl", `{"_schema":"beads-jsonl/7","_bd_version":"3.0.0"}`+"\n"+`{"id":"bd-1"}`+"\n")
+
+		check := CheckJSONLSchemaVersion(tmpDir)
+		if check.Status != StatusWarning {
+			t.Fatalf("expected warning, got %s: %s", check.Status, check.Message)
+		}
+		if !strings.Contains(check.Detail, "issues.jsonl (beads-jsonl/7, bd 3.0.0)") {
+			t.Errorf("detail = %q", check.Detail)
+		}
+	})
+
+	t.Run("no beads dir", func(t *testing.T) {
+		if check := CheckJSONLSchemaVersion(t.TempDir()); check.Status != StatusOK {
+			t.Fatalf("got %s: %s", check.Status, check.Message)
+		}
+	})
+}
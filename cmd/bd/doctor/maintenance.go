@@ -5,16 +5,19 @@ package doctor
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/town"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -194,6 +197,113 @@ func CheckStaleMolecules(path string) DoctorCheck {
 	}
 }
 
+// staleWarningWindowDays is how far ahead CheckStaleIssues looks for issues
+// about to be labeled stale.
+const staleWarningWindowDays = 7
+
+// CheckStaleIssues summarizes the stale issue policy (bd stale sweep):
+// issues already labeled stale and awaiting close, and issues that will be
+// labeled within the next week. It uses updated_at alone, so issues kept
+// alive by recent comments may be over-counted.
+func CheckStaleIssues(path string) DoctorCheck {
+	policy := config.GetStalePolicy()
+	if policy.LabelAfterDays <= 0 {
+		return DoctorCheck{
+			Name:     "Stale Issues",
+			Status:   StatusOK,
+			Message:  "N/A (stale policy disabled)",
+			Category: CategoryMaintenance,
+		}
+	}
+
+	_, beadsDir := getBackendAndBeadsDir(path)
+	ctx := context.Background()
+	store, err := dolt.New(ctx, doltServerConfig(beadsDir, getDatabasePath(beadsDir)))
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Stale Issues",
+			Status:   StatusOK,
+			Message:  "N/A (unable to open database)",
+			Category: CategoryMaintenance,
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	ephemeral := false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		Ephemeral:     &ephemeral,
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+	})
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Stale Issues",
+			Status:   StatusOK,
+			Message:  "N/A (query failed)",
+			Category: CategoryMaintenance,
+		}
+	}
+
+	now := time.Now()
+	labelCutoff := now.AddDate(0, 0, -policy.LabelAfterDays)
+	warnCutoff := labelCutoff.AddDate(0, 0, staleWarningWindowDays)
+	var labeled, due, approaching int
+	var exampleIDs []string
+	for _, issue := range issues {
+		if issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress {
+			continue
+		}
+		if policy.Exempt(issue.Labels, issue.Priority) || slices.Contains(issue.Labels, "template") {
+			continue
+		}
+		switch {
+		case slices.Contains(issue.Labels, policy.Label):
+			labeled++
+			continue
+		case issue.UpdatedAt.Before(labelCutoff):
+			due++
+		case issue.UpdatedAt.Before(warnCutoff):
+			approaching++
+		default:
+			continue
+		}
+		if len(exampleIDs) < 3 {
+			exampleIDs = append(exampleIDs, issue.ID)
+		}
+	}
+
+	if labeled+due+approaching == 0 {
+		return DoctorCheck{
+			Name:     "Stale Issues",
+			Status:   StatusOK,
+			Message:  "No issues approaching staleness",
+			Category: CategoryMaintenance,
+		}
+	}
+
+	var parts []string
+	if labeled > 0 {
+		parts = append(parts, fmt.Sprintf("%d labeled %s", labeled, policy.Label))
+	}
+	if due > 0 {
+		parts = append(parts, fmt.Sprintf("%d due to be labeled", due))
+	}
+	if approaching > 0 {
+		parts = append(parts, fmt.Sprintf("%d going stale within %d days", approaching, staleWarningWindowDays))
+	}
+	detail := ""
+	if len(exampleIDs) > 0 {
+		detail = fmt.Sprintf("Example: %v", exampleIDs)
+	}
+	return DoctorCheck{
+		Name:     "Stale Issues",
+		Status:   StatusWarning,
+		Message:  strings.Join(parts, ", "),
+		Detail:   detail,
+		Fix:      "Run 'bd stale sweep --dry-run' to preview the stale policy",
+		Category: CategoryMaintenance,
+	}
+}
+
 // CheckPersistentMolIssues detects mol- prefixed issues that should have been ephemeral.
 // When users run "bd mol pour" on formulas that should use "bd mol wisp", the resulting
 // issues get the "mol-" prefix but persist in the issue store. These should be cleaned up.
@@ -367,12 +477,6 @@ func checkMisclassifiedWispsForIssues(issues []*types.Issue) DoctorCheck {
 	}
 }
 
-// PatrolPollutionThresholds defines when to warn about patrol pollution
-const (
-	PatrolDigestThreshold = 10 // Warn if patrol digests > 10
-	SessionBeadThreshold  = 50 // Warn if session beads > 50
-)
-
 const maintenanceIssuesUnavailableMessage = "N/A (unable to load issues from database)"
 
 type patrolIssueKind int
@@ -397,7 +501,10 @@ type patrolPollutionResult struct {
 // Patterns detected:
 // - Patrol digests: titles matching "Digest: mol-*-patrol"
 // - Session ended beads: titles matching "Session ended: *"
-func CheckPatrolPollution(path string) DoctorCheck {
+//
+// It warns once either count exceeds its town threshold
+// (town.patrol_digest_threshold, town.session_bead_threshold).
+func CheckPatrolPollution(path string, townCfg town.Config) DoctorCheck {
 	issues, err := loadMaintenanceIssues(path)
 	if err != nil {
 		return DoctorCheck{
@@ -408,17 +515,17 @@ func CheckPatrolPollution(path string) DoctorCheck {
 		}
 	}
 
-	return checkPatrolPollutionForIssues(issues)
+	return checkPatrolPollutionForIssues(issues, townCfg)
 }
 
 // checkPatrolPollutionForIssues is the core logic for CheckPatrolPollution,
 // operating on a slice of issues directly.
-func checkPatrolPollutionForIssues(issues []*types.Issue) DoctorCheck {
+func checkPatrolPollutionForIssues(issues []*types.Issue, townCfg town.Config) DoctorCheck {
 	result := detectPatrolPollution(issues)
 
 	// Check thresholds
-	hasPatrolPollution := result.PatrolDigestCount > PatrolDigestThreshold
-	hasSessionPollution := result.SessionBeadCount > SessionBeadThreshold
+	hasPatrolPollution := result.PatrolDigestCount > townCfg.PatrolDigestThreshold
+	hasSessionPollution := result.SessionBeadCount > townCfg.SessionBeadThreshold
 
 	if !hasPatrolPollution && !hasSessionPollution {
 		return DoctorCheck{
@@ -543,15 +650,22 @@ func loadMaintenanceIssuesFromJSONL(beadsDir string) ([]*types.Issue, error) {
 	defer file.Close()
 
 	var issues []*types.Issue
-	decoder := json.NewDecoder(file)
+	r := jsonl.NewReader(file)
 	for {
-		issue := &types.Issue{}
-		if err := decoder.Decode(issue); err != nil {
-			if err == io.EOF {
-				break
-			}
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
 			return nil, err
 		}
+		if rec.Type() == "memory" {
+			continue
+		}
+		issue := &types.Issue{}
+		if err := rec.Decode(issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.Line(), err)
+		}
 		issues = append(issues, issue)
 	}
 
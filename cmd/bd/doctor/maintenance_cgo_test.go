@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/town"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -336,7 +337,7 @@ func TestCheckMisclassifiedWisps_UsesDoltWithoutJSONL(t *testing.T) {
 func TestCheckPatrolPollution_UsesDoltWithoutJSONL(t *testing.T) {
 	// Create issues directly for testing the core logic
 	var issues []*types.Issue
-	for i := 0; i < PatrolDigestThreshold+1; i++ {
+	for i := 0; i < town.DefaultPatrolDigestThreshold+1; i++ {
 		issues = append(issues, &types.Issue{
 			ID:        fmt.Sprintf("bd-%04d", i),
 			Title:     fmt.Sprintf("Digest: mol-%02d-patrol", i),
@@ -346,7 +347,7 @@ func TestCheckPatrolPollution_UsesDoltWithoutJSONL(t *testing.T) {
 		})
 	}
 
-	check := checkPatrolPollutionForIssues(issues)
+	check := checkPatrolPollutionForIssues(issues, town.Defaults())
 	if check.Status != StatusWarning {
 		t.Fatalf("status = %q, want %q", check.Status, StatusWarning)
 	}
@@ -355,6 +356,21 @@ func TestCheckPatrolPollution_UsesDoltWithoutJSONL(t *testing.T) {
 	}
 }
 
+func TestCheckPatrolPollution_TownThreshold(t *testing.T) {
+	var issues []*types.Issue
+	for i := 0; i < town.DefaultPatrolDigestThreshold+1; i++ {
+		issues = append(issues, &types.Issue{
+			ID:    fmt.Sprintf("bd-%04d", i),
+			Title: fmt.Sprintf("Digest: mol-%02d-patrol", i),
+		})
+	}
+
+	townCfg := town.FromConfig(map[string]string{"town.patrol_digest_threshold": "20"})
+	if check := checkPatrolPollutionForIssues(issues, townCfg); check.Status != StatusOK {
+		t.Fatalf("status = %q with raised threshold, want %q", check.Status, StatusOK)
+	}
+}
+
 func TestCheckPatrolPollution_IgnoresEphemeralWisps(t *testing.T) {
 	// Ephemeral issues are filtered out by loadMaintenanceIssues at the DB layer
 	// (SearchIssues with Ephemeral=false filter). When the check logic receives
@@ -362,7 +378,7 @@ func TestCheckPatrolPollution_IgnoresEphemeralWisps(t *testing.T) {
 	// list (all ephemeral, all filtered) produces OK.
 	var issues []*types.Issue // empty — all ephemeral issues were filtered at load time
 
-	check := checkPatrolPollutionForIssues(issues)
+	check := checkPatrolPollutionForIssues(issues, town.Defaults())
 	if check.Status != StatusOK {
 		t.Fatalf("status = %q, want %q", check.Status, StatusOK)
 	}
@@ -406,7 +406,8 @@ func validateJSONLForMigration(jsonlPath string) (int, int, map[string]bool, err
 		}
 
 		var issue struct {
-			ID string `json:"id"`
+			ID     string `json:"id"`
+			Schema string `json:"_schema"`
 		}
 		if err := json.Unmarshal(line, &issue); err != nil {
 			malformed++
@@ -415,6 +416,9 @@ func validateJSONLForMigration(jsonlPath string) (int, int, map[string]bool, err
 			}
 			continue
 		}
+		if issue.Schema != "" {
+			continue // schema header line
+		}
 
 		if issue.ID == "" {
 			malformed++
@@ -264,8 +264,16 @@ func isDoltServerRunning(host string, port int) bool {
 
 // getDoltDatabaseSize returns the total size of the Dolt database directory
 func getDoltDatabaseSize(doltDir string) string {
-	var totalSize int64
+	totalSize, err := doltDirBytes(doltDir)
+	if err != nil {
+		return "unknown"
+	}
+	return formatByteSize(totalSize)
+}
 
+// doltDirBytes sums the sizes of all files under doltDir.
+func doltDirBytes(doltDir string) (int64, error) {
+	var totalSize int64
 	err := filepath.WalkDir(doltDir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil // Skip errors
@@ -277,12 +285,11 @@ func getDoltDatabaseSize(doltDir string) string {
 		}
 		return nil
 	})
+	return totalSize, err
+}
 
-	if err != nil {
-		return "unknown"
-	}
-
-	// Format size
+// formatByteSize renders a byte count with a binary unit suffix.
+func formatByteSize(totalSize int64) string {
 	const (
 		KB = 1024
 		MB = 1024 * KB
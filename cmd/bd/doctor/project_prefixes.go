@@ -0,0 +1,130 @@
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CheckProjectPrefixesWithStore verifies the monorepo sub-project prefixes
+// in the projects config: each directory exists, no prefix shadows another
+// or the main prefix, and each prefix's issue counter is ahead of its
+// highest sequential ID.
+func CheckProjectPrefixesWithStore(ss *SharedStore, path string) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:     "Project Prefixes",
+			Status:   StatusOK,
+			Message:  "N/A (no database)",
+			Category: CategoryData,
+		}
+	}
+	return checkProjectPrefixesWithStore(store, path)
+}
+
+func checkProjectPrefixesWithStore(store *dolt.DoltStore, path string) DoctorCheck {
+	ctx := context.Background()
+	value, err := store.GetConfig(ctx, "projects")
+	if err != nil || strings.TrimSpace(value) == "" {
+		return DoctorCheck{
+			Name:     "Project Prefixes",
+			Status:   StatusOK,
+			Message:  "N/A (no sub-project prefixes)",
+			Category: CategoryData,
+		}
+	}
+	projects, err := types.ParseProjectPrefixes(value)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Project Prefixes",
+			Status:   StatusError,
+			Message:  "Invalid projects config",
+			Detail:   err.Error(),
+			Fix:      "Fix it with 'bd config set projects <prefix:dir,...>'",
+			Category: CategoryData,
+		}
+	}
+
+	var problems, summary []string
+	mainPrefix, _ := store.GetConfig(ctx, "issue_prefix")
+	prefixes := []string{mainPrefix}
+	for _, p := range projects {
+		prefixes = append(prefixes, p.Prefix)
+	}
+	for _, p := range projects {
+		if p.Prefix == mainPrefix {
+			problems = append(problems, fmt.Sprintf("%s: same as the main issue prefix", p.Prefix))
+		}
+		for _, other := range prefixes {
+			if other != "" && strings.HasPrefix(other, p.Prefix+"-") {
+				problems = append(problems, fmt.Sprintf("%s: shadows %s (%s-* IDs match both)", p.Prefix, other, other))
+			}
+		}
+
+		if info, err := os.Stat(filepath.Join(path, filepath.FromSlash(p.Dir))); err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("%s: directory %s not found", p.Prefix, p.Dir))
+		}
+
+		count, maxNum, err := projectPrefixIDStats(ctx, store.UnderlyingDB(), p.Prefix)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", p.Prefix, err))
+			continue
+		}
+		var lastID int
+		err = store.UnderlyingDB().QueryRowContext(ctx, "SELECT last_id FROM issue_counter WHERE prefix = ?", p.Prefix).Scan(&lastID)
+		switch {
+		case err == nil && lastID < maxNum:
+			problems = append(problems, fmt.Sprintf("%s: counter at %d but %s-%d exists (next ID would collide)", p.Prefix, lastID, p.Prefix, maxNum))
+		case err != nil && err != sql.ErrNoRows:
+			problems = append(problems, fmt.Sprintf("%s: unable to read counter: %v", p.Prefix, err))
+		}
+		summary = append(summary, fmt.Sprintf("%s → %s (%d issues)", p.Prefix, p.Dir, count))
+	}
+
+	if len(problems) > 0 {
+		return DoctorCheck{
+			Name:     "Project Prefixes",
+			Status:   StatusWarning,
+			Message:  fmt.Sprintf("%d problem(s) with sub-project prefixes", len(problems)),
+			Detail:   strings.Join(problems, "\n"),
+			Fix:      "Update the mapping with 'bd config set projects <prefix:dir,...>'",
+			Category: CategoryData,
+		}
+	}
+	return DoctorCheck{
+		Name:     "Project Prefixes",
+		Status:   StatusOK,
+		Message:  fmt.Sprintf("%d sub-project prefix(es) verified", len(projects)),
+		Detail:   strings.Join(summary, "\n"),
+		Category: CategoryData,
+	}
+}
+
+// projectPrefixIDStats counts the issues under prefix and returns the
+// highest sequential number among IDs of the form <prefix>-<n>.
+func projectPrefixIDStats(ctx context.Context, db *sql.DB, prefix string) (count, maxNum int, err error) {
+	rows, err := db.QueryContext(ctx, "SELECT id FROM issues WHERE id LIKE ?", prefix+"-%")
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to query issues: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+		count++
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, prefix+"-")); err == nil && n > maxNum {
+			maxNum = n
+		}
+	}
+	return count, maxNum, rows.Err()
+}
@@ -0,0 +1,56 @@
+//go:build cgo
+
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckProjectPrefixes(t *testing.T) {
+	store := newTestDoltStore(t, "mono")
+	ctx := context.Background()
+	repoDir := t.TempDir()
+
+	check := checkProjectPrefixesWithStore(store, repoDir)
+	if check.Status != StatusOK || !strings.Contains(check.Message, "N/A") {
+		t.Fatalf("no projects config: got %s %q", check.Status, check.Message)
+	}
+
+	if err := os.MkdirAll(filepath.Join(repoDir, "apps", "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "projects", "web:apps/web"); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{ID: "web-3", Title: "Web", Status: types.StatusOpen, IssueType: types.TypeTask, CreatedAt: time.Now()}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	check = checkProjectPrefixesWithStore(store, repoDir)
+	if check.Status != StatusOK {
+		t.Fatalf("valid mapping: got %s %q\n%s", check.Status, check.Message, check.Detail)
+	}
+
+	if _, err := store.UnderlyingDB().ExecContext(ctx, "INSERT INTO issue_counter (prefix, last_id) VALUES ('web', 1)"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "projects", "web:apps/web,api:services/api,web-ui:apps/web/ui"); err != nil {
+		t.Fatal(err)
+	}
+	check = checkProjectPrefixesWithStore(store, repoDir)
+	if check.Status != StatusWarning {
+		t.Fatalf("broken mapping: got %s %q", check.Status, check.Message)
+	}
+	for _, want := range []string{"api: directory services/api not found", "web: shadows web-ui", "web: counter at 1 but web-3 exists"} {
+		if !strings.Contains(check.Detail, want) {
+			t.Errorf("detail missing %q:\n%s", want, check.Detail)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/schema"
+)
+
+// CheckSchemaIntegrityWithStore compares the live columns, indexes, and
+// foreign keys of the core tables (issues, dependencies, wisps,
+// federation_peers) against the definition this binary expects. A
+// migration that half-applied, or a hand-edited schema, otherwise surfaces
+// later as "column could not be found" from an unrelated command.
+func CheckSchemaIntegrityWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Schema Integrity",
+			Status:  StatusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	drift, err := schema.ScanDrift(context.Background(), store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Schema Integrity",
+			Status:  StatusWarning,
+			Message: "Unable to read database schema",
+			Detail:  err.Error(),
+		}
+	}
+	return schemaIntegrityCheck(drift)
+}
+
+// schemaIntegrityCheck turns a drift scan into a doctor result.
+func schemaIntegrityCheck(drift []schema.SchemaDrift) DoctorCheck {
+	if len(drift) == 0 {
+		return DoctorCheck{
+			Name:    "Schema Integrity",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("Core tables match schema v%d", schema.LatestVersion()),
+		}
+	}
+
+	var lines []string
+	var missingTables []string
+	repairable := false
+	for _, d := range drift {
+		lines = append(lines, d.Summary())
+		if d.MissingTable {
+			missingTables = append(missingTables, d.Table)
+		}
+		if len(d.MissingColumns)+len(d.MissingIndexes) > 0 {
+			repairable = true
+		}
+	}
+
+	check := DoctorCheck{
+		Name:    "Schema Integrity",
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d table(s) differ from schema v%d", len(drift), schema.LatestVersion()),
+		Detail:  strings.Join(lines, "\n"),
+	}
+	switch {
+	case len(missingTables) > 0:
+		check.Status = StatusError
+		check.Fix = "Run any bd write command to re-run migrations, or 'bd init' if the database is new"
+	case repairable:
+		check.Fix = "Run 'bd doctor --fix' (or 'bd doctor --check=schema --fix') to add missing columns and indexes"
+	default:
+		check.Fix = "Restore the missing foreign keys manually; 'bd doctor --fix' only adds columns and indexes"
+	}
+	return check
+}
@@ -0,0 +1,35 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/schema"
+)
+
+func TestSchemaIntegrityCheck(t *testing.T) {
+	if got := schemaIntegrityCheck(nil); got.Status != StatusOK {
+		t.Errorf("no drift: status = %s, want ok", got.Status)
+	}
+
+	columns := schemaIntegrityCheck([]schema.SchemaDrift{{
+		Table:          "issues",
+		MissingColumns: []schema.ExpectedColumn{{Name: "spec_id", Definition: "varchar(1024)"}},
+	}})
+	if columns.Status != StatusWarning || !strings.Contains(columns.Fix, "--fix") {
+		t.Errorf("missing column: got %s / %q, want warning with --fix hint", columns.Status, columns.Fix)
+	}
+	if columns.Detail != "issues: missing column spec_id" {
+		t.Errorf("detail = %q", columns.Detail)
+	}
+
+	fkOnly := schemaIntegrityCheck([]schema.SchemaDrift{{Table: "dependencies", MissingForeignKeys: []string{"fk_dep_issue"}}})
+	if fkOnly.Status != StatusWarning || !strings.Contains(fkOnly.Fix, "manually") {
+		t.Errorf("missing fk: got %s / %q, want manual-fix warning", fkOnly.Status, fkOnly.Fix)
+	}
+
+	table := schemaIntegrityCheck([]schema.SchemaDrift{{Table: "federation_peers", MissingTable: true}})
+	if table.Status != StatusError {
+		t.Errorf("missing table: status = %s, want error", table.Status)
+	}
+}
@@ -140,7 +140,7 @@ func RunServerHealthChecks(path string) ServerHealthResult {
 	}
 
 	// Check 5: Connection pool health
-	poolCheck := checkConnectionPool(db)
+	poolCheck := checkConnectionPool(db, cfg)
 	result.Checks = append(result.Checks, poolCheck)
 	if poolCheck.Status == StatusError {
 		result.OverallOK = false
@@ -548,16 +548,49 @@ func checkSchemaCompatible(db *sql.DB, database string) DoctorCheck {
 	}
 }
 
-// checkConnectionPool checks the connection pool health
-func checkConnectionPool(db *sql.DB) DoctorCheck {
-	stats := db.Stats()
+// serverConnStats is the server's view of connection load, read from
+// @@max_connections and Threads_connected. Zero values mean unknown.
+type serverConnStats struct {
+	maxConnections   int
+	threadsConnected int
+}
+
+// Thresholds past which the pool or server is reported as saturated.
+const (
+	poolChurnThreshold      = 10
+	serverSaturationPercent = 80
+)
+
+// checkConnectionPool checks the connection pool health and the server's
+// connection headroom, with tuning recommendations when either is saturated.
+func checkConnectionPool(db *sql.DB, cfg *configfile.Config) DoctorCheck {
+	return connectionPoolCheck(db.Stats(), readServerConnStats(db), cfg)
+}
+
+// readServerConnStats queries the server's connection limit and current
+// connection count. Servers that don't expose either leave it zero.
+func readServerConnStats(db *sql.DB) serverConnStats {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var stats serverConnStats
+	_ = db.QueryRowContext(ctx, "SELECT @@max_connections").Scan(&stats.maxConnections)
+	var name string
+	_ = db.QueryRowContext(ctx, "SHOW STATUS LIKE 'Threads_connected'").Scan(&name, &stats.threadsConnected)
+	return stats
+}
 
-	// Report pool statistics
-	detail := fmt.Sprintf("open: %d, in_use: %d, idle: %d",
+// connectionPoolCheck turns pool and server statistics into a doctor result.
+func connectionPoolCheck(stats sql.DBStats, server serverConnStats, cfg *configfile.Config) DoctorCheck {
+	detail := fmt.Sprintf("open: %d, in_use: %d, idle: %d, max_open: %d",
 		stats.OpenConnections,
 		stats.InUse,
 		stats.Idle,
+		stats.MaxOpenConnections,
 	)
+	if stats.WaitCount > 0 {
+		detail += fmt.Sprintf("\nwaits: %d (total %v)", stats.WaitCount, stats.WaitDuration.Round(time.Millisecond))
+	}
 
 	// Check for connection errors
 	if stats.MaxIdleClosed > 0 || stats.MaxLifetimeClosed > 0 {
@@ -566,7 +599,43 @@ func checkConnectionPool(db *sql.DB) DoctorCheck {
 			stats.MaxLifetimeClosed,
 		)
 	}
+	if cfg != nil && (cfg.DoltMaxOpenConns > 0 || cfg.DoltMaxIdleConns > 0 || cfg.DoltConnMaxLifetime != "") {
+		detail += fmt.Sprintf("\nmetadata.json: dolt_max_open_conns=%d, dolt_max_idle_conns=%d, dolt_conn_max_lifetime=%q",
+			cfg.DoltMaxOpenConns, cfg.DoltMaxIdleConns, cfg.DoltConnMaxLifetime)
+	}
 
+	var problems, fixes []string
+	if stats.WaitCount > 0 {
+		problems = append(problems, fmt.Sprintf("%d waits for a free connection", stats.WaitCount))
+		fixes = append(fixes, fmt.Sprintf("Raise dolt_max_open_conns in metadata.json (pool max is %d)", stats.MaxOpenConnections))
+	}
+	if stats.MaxIdleClosed > poolChurnThreshold {
+		problems = append(problems, fmt.Sprintf("%d idle connections discarded", stats.MaxIdleClosed))
+		fixes = append(fixes, "Raise dolt_max_idle_conns in metadata.json to keep more connections warm")
+	}
+	if stats.MaxLifetimeClosed > poolChurnThreshold {
+		problems = append(problems, fmt.Sprintf("%d connections retired by lifetime", stats.MaxLifetimeClosed))
+		fixes = append(fixes, "Raise dolt_conn_max_lifetime in metadata.json (e.g. \"1h\") to reduce reconnects")
+	}
+	if server.maxConnections > 0 {
+		pct := server.threadsConnected * 100 / server.maxConnections
+		detail += fmt.Sprintf("\nserver: %d of %d connections in use (%d%%)", server.threadsConnected, server.maxConnections, pct)
+		if pct >= serverSaturationPercent {
+			problems = append(problems, fmt.Sprintf("server at %d%% of max_connections", pct))
+			fixes = append(fixes, "Raise max_connections in the Dolt server config, or lower dolt_max_open_conns on clients sharing the server")
+		}
+	}
+
+	if len(problems) > 0 {
+		return DoctorCheck{
+			Name:     "Connection Pool",
+			Status:   StatusWarning,
+			Message:  "Pool saturated: " + strings.Join(problems, ", "),
+			Detail:   detail,
+			Fix:      strings.Join(fixes, "; "),
+			Category: CategoryFederation,
+		}
+	}
 	return DoctorCheck{
 		Name:     "Connection Pool",
 		Status:   StatusOK,
@@ -0,0 +1,46 @@
+package doctor
+
+import (
+	"context"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/town"
+)
+
+// GetTownConfig reads the town.* settings (see bd town) from the database.
+// Returns the defaults if the database can't be opened.
+// Opens its own store; prefer GetTownConfigWithStore when a shared store is available.
+func GetTownConfig(path string) town.Config {
+	beadsDir := ResolveBeadsDirForRepo(path)
+
+	if _, err := os.Stat(beadsDir); os.IsNotExist(err) {
+		return town.Defaults()
+	}
+
+	ctx := context.Background()
+	store, err := dolt.NewFromConfigWithCLIOptions(ctx, beadsDir, &dolt.Config{ReadOnly: true})
+	if err != nil {
+		return town.Defaults()
+	}
+	defer func() { _ = store.Close() }()
+
+	return getTownConfigFromStore(store)
+}
+
+// GetTownConfigWithStore reads the town.* settings using a shared store.
+func GetTownConfigWithStore(ss *SharedStore) town.Config {
+	store := ss.Store()
+	if store == nil {
+		return town.Defaults()
+	}
+	return getTownConfigFromStore(store)
+}
+
+func getTownConfigFromStore(store *dolt.DoltStore) town.Config {
+	allConfig, err := store.GetAllConfig(context.Background())
+	if err != nil {
+		return town.Defaults()
+	}
+	return town.FromConfig(allConfig)
+}
@@ -38,7 +38,12 @@ var trackedRuntimePatterns = []string{
 
 	// Runtime state
 	"push-state.json",
+	"federation-daemon.json",
+	"digest-daemon.json",
 	"export-state.json",
+	"import-checkpoint.json",
+	"import.lock",
+	"dolt-branch",
 	"sync-state.json",
 	"last-touched",
 	"last_pull", // bd-578h9.6: gitignored since 7ebf4df6a, but gitignore cannot untrack already-committed copies
@@ -183,7 +183,7 @@ func checkDuplicateIssuesDB(db *sql.DB, orchestratorMode bool, orchestratorThres
 			Status:  "warning",
 			Message: fmt.Sprintf("%d duplicate issue(s) in %d group(s)", totalDuplicates, duplicateGroups),
 			Detail:  "Duplicates cannot be auto-fixed",
-			Fix:     "Run 'bd duplicates' to review and merge duplicates",
+			Fix:     "Run 'bd duplicates' to review, then 'bd duplicates resolve' to merge them",
 		}
 	}
 
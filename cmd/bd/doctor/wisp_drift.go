@@ -0,0 +1,96 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+// CheckWispDriftWithStore reports rows that drifted between the wisp and
+// issue tables: wisp labels, events, comments and child counters whose ID
+// has no wisps row (their FKs are clone-local and were added without
+// validating existing rows), IDs with a row in both wisps and issues (an
+// interrupted promotion), and child_counters rows whose parent is a wisp.
+func CheckWispDriftWithStore(ss *SharedStore) DoctorCheck {
+	store := ss.Store()
+	if store == nil {
+		return DoctorCheck{
+			Name:    "Wisp Table Drift",
+			Status:  StatusOK,
+			Message: "No database yet",
+		}
+	}
+	report, err := fix.ScanWispDrift(context.Background(), store.UnderlyingDB())
+	if err != nil {
+		return DoctorCheck{
+			Name:    "Wisp Table Drift",
+			Status:  StatusWarning,
+			Message: "Unable to scan wisp tables",
+			Detail:  err.Error(),
+		}
+	}
+	return wispDriftCheck(report)
+}
+
+func wispDriftCheck(report *fix.WispDriftReport) DoctorCheck {
+	if report.Empty() {
+		return DoctorCheck{
+			Name:    "Wisp Table Drift",
+			Status:  StatusOK,
+			Message: "Wisp and issue tables consistent",
+		}
+	}
+
+	var parts []string
+	var stranded, orphaned int
+	if len(report.Duplicated) > 0 {
+		parts = append(parts, fmt.Sprintf("in both wisps and issues: %s", sampleIDs(report.Duplicated)))
+	}
+	for _, t := range report.Tables {
+		if n := len(t.Stranded); n > 0 {
+			stranded += n
+			parts = append(parts, fmt.Sprintf("%s: %d promoted issue(s) (%s)", t.Table, n, sampleIDs(t.Stranded)))
+		}
+		if n := len(t.Orphaned); n > 0 {
+			orphaned += n
+			parts = append(parts, fmt.Sprintf("%s: %d missing ID(s) (%s)", t.Table, n, sampleIDs(t.Orphaned)))
+		}
+	}
+
+	if n := len(report.MisplacedCounters); n > 0 {
+		parts = append(parts, fmt.Sprintf("child_counters: %d wisp parent(s) (%s)", n, sampleIDs(report.MisplacedCounters)))
+	}
+
+	var counts []string
+	if n := len(report.Duplicated); n > 0 {
+		counts = append(counts, fmt.Sprintf("%d ID(s) in both wisps and issues", n))
+	}
+	if stranded > 0 {
+		counts = append(counts, fmt.Sprintf("%d wisp row set(s) left behind by promotion", stranded))
+	}
+	if orphaned > 0 {
+		counts = append(counts, fmt.Sprintf("%d wisp row set(s) for missing IDs", orphaned))
+	}
+	if n := len(report.MisplacedCounters); n > 0 {
+		counts = append(counts, fmt.Sprintf("%d wisp child counter(s) in child_counters", n))
+	}
+
+	return DoctorCheck{
+		Name:    "Wisp Table Drift",
+		Status:  StatusWarning,
+		Message: strings.Join(counts, ", "),
+		Detail:  strings.Join(parts, "; "),
+		Fix:     "Run: bd doctor --fix (moves promoted rows to issue tables, removes orphaned rows)",
+	}
+}
+
+// sampleIDs joins up to five IDs, noting how many were left out.
+func sampleIDs(ids []string) string {
+	const limit = 5
+	if len(ids) <= limit {
+		return strings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s, ... +%d more", strings.Join(ids[:limit], ", "), len(ids)-limit)
+}
@@ -0,0 +1,40 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+)
+
+func TestWispDriftCheck(t *testing.T) {
+	ok := wispDriftCheck(&fix.WispDriftReport{})
+	if ok.Status != StatusOK {
+		t.Errorf("empty report: status = %s, want %s", ok.Status, StatusOK)
+	}
+
+	check := wispDriftCheck(&fix.WispDriftReport{
+		Duplicated: []string{"bd-w1"},
+		Tables: []fix.WispTableDrift{
+			{Table: "wisp_labels", Stranded: []string{"bd-1", "bd-2"}, Orphaned: []string{"bd-x"}},
+			{Table: "wisp_events", Orphaned: []string{"a", "b", "c", "d", "e", "f", "g"}},
+		},
+		MisplacedCounters: []string{"bd-w2"},
+	})
+	if check.Status != StatusWarning {
+		t.Fatalf("status = %s, want %s", check.Status, StatusWarning)
+	}
+	for _, want := range []string{"1 ID(s) in both wisps and issues", "2 wisp row set(s) left behind", "8 wisp row set(s) for missing IDs", "1 wisp child counter(s)"} {
+		if !strings.Contains(check.Message, want) {
+			t.Errorf("message %q missing %q", check.Message, want)
+		}
+	}
+	for _, want := range []string{"wisp_labels: 2 promoted issue(s) (bd-1, bd-2)", "wisp_events: 7 missing ID(s) (a, b, c, d, e, ... +2 more)", "child_counters: 1 wisp parent(s) (bd-w2)"} {
+		if !strings.Contains(check.Detail, want) {
+			t.Errorf("detail %q missing %q", check.Detail, want)
+		}
+	}
+	if check.Fix == "" {
+		t.Error("expected a fix hint")
+	}
+}
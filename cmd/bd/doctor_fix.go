@@ -212,12 +212,14 @@ func applyFixList(path string, fixes []doctorCheck) {
 		"Lock Files",
 		"Circuit Breaker",
 		"Permissions",
+		"Credential Key",
 		"Database Config",
 		"Config Values",
 		"Database Integrity",
 		"Database",
 		"Fresh Clone",
 		"Schema Compatibility",
+		"Schema Integrity",
 		"Project Identity",
 	}
 	priority := make(map[string]int, len(order))
@@ -280,6 +282,8 @@ func applyFixList(path string, fixes []doctorCheck) {
 			err = fix.DatabaseIntegrity(path)
 		case "Schema Compatibility":
 			err = fix.SchemaCompatibility(path)
+		case "Schema Integrity":
+			err = fix.SchemaIntegrity(path)
 		case "Repo Fingerprint":
 			err = fix.RepoFingerprint(path, doctorYes)
 			// Also repair any other missing metadata fields (bd_version, repo_id, clone_id)
@@ -298,6 +302,8 @@ func applyFixList(path string, fixes []doctorCheck) {
 			err = fix.OrphanedDependencies(path, doctorVerbose)
 		case "Dependency Keys":
 			err = fix.DependencyKeys(path, doctorVerbose)
+		case "Wisp Table Drift":
+			err = fix.WispDrift(path, doctorVerbose)
 		case "Child-Parent Dependencies":
 			// Requires explicit opt-in flag (destructive, may remove intentional deps)
 			if !doctorFixChildParent {
@@ -307,7 +313,7 @@ func applyFixList(path string, fixes []doctorCheck) {
 			err = fix.ChildParentDependencies(path, doctorVerbose)
 		case "Duplicate Issues":
 			// No auto-fix: duplicates require user review
-			fmt.Printf("  ⚠ Run 'bd duplicates' to review and merge duplicates\n")
+			fmt.Printf("  ⚠ Run 'bd duplicates resolve' to review and merge duplicates\n")
 			continue
 		case "Test Pollution":
 			// No auto-fix: test cleanup requires user review
@@ -337,6 +343,8 @@ func applyFixList(path string, fixes []doctorCheck) {
 		case "Circuit Breaker":
 			dolt.CleanStaleCircuitBreakerFiles()
 			fmt.Printf("  %s Cleared stale circuit breaker files\n", ui.RenderPass("✓"))
+		case "Credential Key":
+			err = doctor.FixCredentialKeyFile(path)
 		case "Fresh Clone":
 			err = fix.FreshCloneImport(path, Version)
 		case "Pending Migrations":
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// runSchemaCheck runs the schema-integrity check on its own and, with --fix,
+// adds any missing columns and indexes. The repair is additive, so it runs
+// without the confirmation prompt the destructive fixes use.
+func runSchemaCheck(path string) {
+	check := collectSchemaCheck(path)
+	if doctorFix && check.Status != statusOK && check.Fix != "" {
+		fmt.Println("Applying fixes...")
+		applyFixList(path, []doctorCheck{check})
+		check = collectSchemaCheck(path)
+	}
+
+	if jsonOutput {
+		outputJSON(check)
+	} else {
+		printValidateChecks([]validateCheckResult{{check: check, fixable: true}})
+		if check.Status == statusOK {
+			fmt.Printf("\n%s\n", ui.RenderPass("✓ Schema matches this bd version"))
+		} else if !doctorFix && check.Fix != "" {
+			fmt.Printf("\n%s\n", ui.RenderMuted("Fix: "+check.Fix))
+		}
+	}
+	if check.Status != statusOK {
+		os.Exit(1)
+	}
+}
+
+func collectSchemaCheck(path string) doctorCheck {
+	sharedStore := doctor.NewSharedStore(path)
+	defer sharedStore.Close()
+	return convertWithCategory(doctor.CheckSchemaIntegrityWithStore(sharedStore), doctor.CategoryCore)
+}
@@ -77,8 +77,12 @@ func runValidateCheckInner(path string) bool {
 
 // collectValidateChecks runs the four data-integrity checks.
 func collectValidateChecks(path string) []validateCheckResult {
+	threshold := orchestratorDuplicatesThreshold
+	if doctorOrchestrator {
+		threshold = doctorDuplicatesThreshold(doctor.GetTownConfig(path))
+	}
 	return []validateCheckResult{
-		{check: convertDoctorCheck(doctor.CheckDuplicateIssues(path, doctorOrchestrator, orchestratorDuplicatesThreshold))},
+		{check: convertDoctorCheck(doctor.CheckDuplicateIssues(path, doctorOrchestrator, threshold))},
 		{check: convertDoctorCheck(doctor.CheckOrphanedDependencies(path)), fixable: true},
 		{check: convertDoctorCheck(doctor.CheckTestPollution(path))},
 		{check: convertDoctorCheck(doctor.CheckGitConflicts(path))},
@@ -56,6 +56,7 @@ Remote management:
   bd dolt remote remove <name>       Remove a Dolt remote
 
 Configuration keys for 'bd dolt set':
+  mode      'embedded' (in-process, no server; default) or 'server'
   database  Database name (default: issue prefix or "beads")
   host      Server host (default: 127.0.0.1)
   port      Server port (auto-detected; override with bd dolt set port <N>)
@@ -86,6 +87,7 @@ var doltSetCmd = &cobra.Command{
 	Long: `Set a Dolt configuration value in metadata.json.
 
 Keys:
+  mode      'embedded' (in-process, no server; default) or 'server'
   database  Database name (default: issue prefix or "beads")
   host      Server host (default: 127.0.0.1)
   port      Server port (auto-detected; override with bd dolt set port <N>)
@@ -98,11 +100,13 @@ Examples:
   bd dolt set database myproject
   bd dolt set host 192.168.1.100
   bd dolt set port 3307 --update-config
-  bd dolt set data-dir /home/user/.beads-dolt/myproject`,
+  bd dolt set data-dir /home/user/.beads-dolt/myproject
+  bd dolt set mode embedded   # single-user laptop: no sql-server to run`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		if !usesSQLServer() {
+		if !usesSQLServer() && args[0] != "mode" {
 			fmt.Fprintln(os.Stderr, "Error: 'bd dolt set' is not supported in embedded mode (no Dolt server)")
+			fmt.Fprintln(os.Stderr, "Use 'bd dolt set mode server' to switch to a Dolt sql-server first.")
 			os.Exit(1)
 		}
 		key := args[0]
@@ -616,6 +620,9 @@ reachability, server version, and database.`,
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if state.Running {
+			state.Health = localServerHealth(serverDir, cfg, state.Port)
+		}
 		renderLocalDoltStatus(state, serverDir)
 	},
 }
@@ -641,6 +648,14 @@ func renderLocalDoltStatus(state *doltserver.State, serverDir string) {
 	fmt.Printf("  Port: %d\n", state.Port)
 	fmt.Printf("  Data: %s\n", state.DataDir)
 	fmt.Printf("  Logs: %s\n", doltserver.LogPath(serverDir))
+	if h := state.Health; h != nil {
+		if h.OK {
+			fmt.Printf("  Health: ok (SELECT 1 in %dms)\n", h.LatencyMS)
+		} else {
+			fmt.Printf("  Health: %s\n", ui.RenderWarn("not answering queries: "+h.Error))
+			fmt.Println("  Hint: 'bd server restart' replaces a wedged server")
+		}
+	}
 	if doltserver.IsSharedServerMode() {
 		fmt.Println("  Mode: shared server")
 	}
@@ -650,6 +665,16 @@ func renderLocalDoltStatus(state *doltserver.State, serverDir string) {
 	}
 }
 
+// localServerHealth probes a bd-managed server with the credentials the
+// store would use. cfg may be nil when metadata.json could not be read.
+func localServerHealth(serverDir string, cfg *configfile.Config, port int) *doltserver.Health {
+	if cfg == nil {
+		cfg = configfile.DefaultConfig()
+	}
+	host := doltserver.DefaultConfig(serverDir).Host
+	return doltserver.CheckHealth(host, port, cfg.GetDoltServerUser(), cfg.GetDoltServerPasswordForPort(port))
+}
+
 // shouldUseExternalDoltStatus reports whether bd dolt status should treat
 // the server as externally-managed and probe via SQL instead of consulting
 // the local PID file. Returns true when:
@@ -1382,10 +1407,37 @@ func setDoltConfig(key, value string, updateConfig bool) {
 
 	switch key {
 	case "mode":
-		// Mode will be configurable again when embedded Dolt support returns.
-		// For now, server mode is required (embedded driver not yet re-integrated).
-		fmt.Fprintf(os.Stderr, "Error: mode is not yet configurable; embedded mode is coming soon\n")
-		os.Exit(1)
+		value = strings.ToLower(value)
+		if value != configfile.DoltModeEmbedded && value != configfile.DoltModeServer {
+			fmt.Fprintf(os.Stderr, "Error: mode must be '%s' or '%s'\n", configfile.DoltModeEmbedded, configfile.DoltModeServer)
+			os.Exit(1)
+		}
+		if value == cfg.GetDoltMode() {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"key":       key,
+					"value":     value,
+					"location":  "metadata.json",
+					"unchanged": true,
+				})
+				return
+			}
+			fmt.Printf("Dolt mode is already %s; nothing changed\n", value)
+			return
+		}
+		// The two modes keep their data in different directories; switching
+		// does not copy issues across, so say where the new mode will look.
+		dataDir := filepath.Join(beadsDir, "embeddeddolt")
+		if value == configfile.DoltModeServer {
+			dataDir = cfg.DatabasePath(beadsDir)
+		}
+		remoteServer := value == configfile.DoltModeServer && !isLocalHost(cfg.GetDoltServerHost())
+		if _, err := os.Stat(dataDir); os.IsNotExist(err) && !remoteServer {
+			fmt.Fprintf(os.Stderr, "Note: no %s data at %s yet.\n", value, dataDir)
+			fmt.Fprintf(os.Stderr, "Issues are not copied between modes; export with 'bd export' before switching\n")
+			fmt.Fprintf(os.Stderr, "and load them with 'bd import' afterwards.\n")
+		}
+		cfg.DoltMode = value
 
 	case "database":
 		if value == "" {
@@ -1487,7 +1539,7 @@ func setDoltConfig(key, value string, updateConfig bool) {
 
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown key '%s'\n", key)
-		fmt.Fprintf(os.Stderr, "Valid keys: database, host, port, socket, user, data-dir, shared-server\n")
+		fmt.Fprintf(os.Stderr, "Valid keys: mode, database, host, port, socket, user, data-dir, shared-server\n")
 		os.Exit(1)
 	}
 
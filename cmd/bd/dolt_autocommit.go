@@ -133,7 +133,7 @@ var autoCommitSweepExemptPaths = map[string]bool{
 // commit would fail with errReadOnly and turn a successful read into a fatal
 // error. Explicitly flagged writes (commandDidWrite) still auto-commit.
 func autoCommitSweepExempt(cmd *cobra.Command) bool {
-	return isReadOnlyCommand(cmd.Name()) || autoCommitSweepExemptPaths[cmd.CommandPath()]
+	return isReadOnlyCmd(cmd) || autoCommitSweepExemptPaths[cmd.CommandPath()]
 }
 
 // formatDoltSweepCommitMessage attributes a sweep commit distinctly from a
@@ -61,8 +61,10 @@ func TestAutoCommitSweepExempt(t *testing.T) {
 		{[]string{"vc", "status"}, true},
 		{[]string{"diff"}, true},
 		{[]string{"history"}, true},
-		{[]string{"list"}, true},  // readOnlyCommands
-		{[]string{"ready"}, true}, // readOnlyCommands
+		{[]string{"list"}, true},   // readOnlyCommands
+		{[]string{"ready"}, true},  // readOnlyCommands
+		{[]string{"report"}, true}, // readOnlyCommandPaths
+		{[]string{"ci", "report"}, false},
 		{[]string{"create"}, false},
 		{[]string{"update"}, false},
 		{[]string{"dolt", "commit"}, false},
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/configfile"
 )
 
 // bdDolt runs "bd dolt" with the given args and returns stdout.
@@ -75,6 +77,42 @@ func TestEmbeddedDolt(t *testing.T) {
 		})
 	}
 
+	// ===== Switching modes =====
+
+	t.Run("set_mode", func(t *testing.T) {
+		modeDir, beadsDir, _ := bdInit(t, bd, "--prefix", "tdmode")
+		mode := func() string {
+			t.Helper()
+			cfg, err := configfile.Load(beadsDir)
+			if err != nil || cfg == nil {
+				t.Fatalf("load metadata.json: %v", err)
+			}
+			return cfg.GetDoltMode()
+		}
+
+		if out := bdDolt(t, bd, modeDir, "set", "mode", "embedded"); !strings.Contains(out, "already embedded") {
+			t.Errorf("expected no-op for the current mode: %s", out)
+		}
+		out := bdDoltFail(t, bd, modeDir, "set", "mode", "bogus")
+		if !strings.Contains(out, "mode must be 'embedded' or 'server'") {
+			t.Errorf("expected invalid-mode error: %s", out)
+		}
+		if got := mode(); got != configfile.DoltModeEmbedded {
+			t.Errorf("invalid mode changed dolt_mode to %q", got)
+		}
+
+		bdDolt(t, bd, modeDir, "set", "mode", "server")
+		if got := mode(); got != configfile.DoltModeServer {
+			t.Fatalf("dolt_mode = %q after set mode server", got)
+		}
+		bdDolt(t, bd, modeDir, "set", "mode", "embedded")
+		if got := mode(); got != configfile.DoltModeEmbedded {
+			t.Fatalf("dolt_mode = %q after set mode embedded", got)
+		}
+		// Back in embedded mode the original data is used again.
+		bdCreate(t, bd, modeDir, "Mode round trip issue", "--type", "task")
+	})
+
 	// ===== Embedded-mode inspection commands succeed with embedded-mode output =====
 
 	t.Run("embedded_status", func(t *testing.T) {
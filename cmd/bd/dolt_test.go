@@ -277,6 +277,82 @@ func TestDoltSetConfigValidation(t *testing.T) {
 	})
 }
 
+func TestDoltSetConfigMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("failed to create .beads dir: %v", err)
+	}
+
+	cfg := configfile.DefaultConfig()
+	cfg.Backend = configfile.BackendDolt
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	// Override BEADS_DIR so FindBeadsDir() returns our temp .beads,
+	// not the rig's .beads (which happens in worktree environments).
+	t.Setenv("BEADS_DIR", beadsDir)
+
+	oldCwd, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldCwd) }()
+
+	origJsonOutput := jsonOutput
+	defer func() { jsonOutput = origJsonOutput }()
+	jsonOutput = false
+
+	loadMode := func(t *testing.T) string {
+		t.Helper()
+		loadedCfg, err := configfile.Load(beadsDir)
+		if err != nil {
+			t.Fatalf("failed to load config: %v", err)
+		}
+		return loadedCfg.GetDoltMode()
+	}
+
+	t.Run("embedded to server", func(t *testing.T) {
+		output := captureDoltSetOutput(t, "mode", "SERVER", false)
+		if got := loadMode(t); got != configfile.DoltModeServer {
+			t.Errorf("expected mode %q, got %q", configfile.DoltModeServer, got)
+		}
+		if !strings.Contains(output, "Set mode = server") {
+			t.Errorf("expected confirmation, got: %s", output)
+		}
+	})
+
+	t.Run("server to embedded", func(t *testing.T) {
+		output := captureDoltSetOutput(t, "mode", "embedded", false)
+		if got := loadMode(t); got != configfile.DoltModeEmbedded {
+			t.Errorf("expected mode %q, got %q", configfile.DoltModeEmbedded, got)
+		}
+		if !strings.Contains(output, "no embedded data") {
+			t.Errorf("expected a note that the embedded data dir is empty, got: %s", output)
+		}
+	})
+
+	t.Run("no-op", func(t *testing.T) {
+		metadataPath := filepath.Join(beadsDir, "metadata.json")
+		before, err := os.ReadFile(metadataPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := captureDoltSetOutput(t, "mode", "embedded", false)
+		if !strings.Contains(output, "already embedded") {
+			t.Errorf("expected 'already embedded', got: %s", output)
+		}
+		after, err := os.ReadFile(metadataPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(before, after) {
+			t.Errorf("no-op mode change rewrote metadata.json:\nbefore: %s\nafter: %s", before, after)
+		}
+	})
+}
+
 func TestDoltSetConfigJSONOutput(t *testing.T) {
 	tmpDir := t.TempDir()
 	beadsDir := filepath.Join(tmpDir, ".beads")
@@ -1545,6 +1621,29 @@ func TestRenderLocalDoltStatus(t *testing.T) {
 		}
 	})
 
+	t.Run("unhealthy server prints health and restart hint", func(t *testing.T) {
+		orig := jsonOutput
+		defer func() { jsonOutput = orig }()
+		jsonOutput = false
+
+		state := &doltserver.State{
+			Running: true,
+			PID:     12345,
+			Port:    28231,
+			DataDir: "/tmp/data",
+			Health:  &doltserver.Health{Error: "i/o timeout"},
+		}
+		out := captureStdout(t, func() error {
+			renderLocalDoltStatus(state, t.TempDir())
+			return nil
+		})
+		for _, want := range []string{"not answering queries: i/o timeout", "bd server restart"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
 	t.Run("Running:true under shared-server mode adds Mode line", func(t *testing.T) {
 		t.Setenv("BEADS_DOLT_SHARED_SERVER", "1")
 		orig := jsonOutput
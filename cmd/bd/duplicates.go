@@ -23,7 +23,8 @@ Only groups issues with matching status (open with open, closed with closed).
 Example:
   bd duplicates                    # Show all duplicate groups
   bd duplicates --auto-merge       # Automatically merge all duplicates
-  bd duplicates --dry-run          # Show what would be merged`,
+  bd duplicates --dry-run          # Show what would be merged
+  bd duplicates resolve            # Walk groups and pick a keeper for each`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		autoMerge, _ := cmd.Flags().GetBool("auto-merge")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 // bdDuplicates runs "bd duplicates" with the given args and returns raw stdout.
@@ -142,6 +143,32 @@ func TestEmbeddedDuplicates(t *testing.T) {
 		}
 	})
 
+	// ===== Resolve with keep-oldest =====
+
+	t.Run("resolve_keep_oldest", func(t *testing.T) {
+		dir6, _, _ := bdInit(t, bd, "--prefix", "ds6")
+		keeper := bdCreate(t, bd, dir6, "Resolve dup", "--type", "task", "--description", "Same")
+		time.Sleep(1100 * time.Millisecond) // created_at has second precision
+		loser := bdCreate(t, bd, dir6, "Resolve dup", "--type", "task", "--description", "Same")
+		child := bdCreate(t, bd, dir6, "Child of loser", "--type", "task")
+		bdDep(t, bd, dir6, "add", child.ID, loser.ID, "--type", "parent-child")
+
+		bdDuplicates(t, bd, dir6, "resolve", "--strategy", "keep-oldest")
+
+		if got := bdShow(t, bd, dir6, loser.ID); got.Status != "closed" {
+			t.Errorf("loser %s status = %s, want closed", loser.ID, got.Status)
+		}
+		if got := bdShow(t, bd, dir6, keeper.ID); got.Status == "closed" {
+			t.Errorf("keeper %s was closed", keeper.ID)
+		}
+		if out := bdDep(t, bd, dir6, "list", child.ID); !strings.Contains(out, keeper.ID) || strings.Contains(out, loser.ID) {
+			t.Errorf("child should be re-parented to %s:\n%s", keeper.ID, out)
+		}
+		if out := bdDep(t, bd, dir6, "list", loser.ID); !strings.Contains(out, keeper.ID) {
+			t.Errorf("loser should be linked to keeper %s:\n%s", keeper.ID, out)
+		}
+	})
+
 	// ===== Dry run =====
 
 	t.Run("dry_run", func(t *testing.T) {
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Keeper strategies for bd duplicates resolve.
+const (
+	dupStrategyInteractive = "interactive"
+	dupStrategyOldest      = "keep-oldest"
+	dupStrategyNewest      = "keep-newest"
+	dupStrategySuggested   = "keep-suggested"
+)
+
+var dupStrategies = []string{dupStrategyInteractive, dupStrategyOldest, dupStrategyNewest, dupStrategySuggested}
+
+// dupResolution is one group's outcome in bd duplicates resolve.
+type dupResolution struct {
+	Keeper      string   `json:"keeper"`
+	Duplicates  []string `json:"duplicates"`
+	Transferred []string `json:"transferred,omitempty"` // edges moved or copied, as "from → to (type)"
+	Action      string   `json:"action"`                // resolved, would-resolve, skipped, error
+	Error       string   `json:"error,omitempty"`
+}
+
+var duplicatesResolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Walk duplicate groups and mark losers as duplicate-of a keeper",
+	Long: `Resolve the duplicate groups reported by 'bd duplicates'.
+
+For each group, one issue is kept and every other issue is:
+  - linked to the keeper with a 'duplicates' dependency
+  - closed with reason "Duplicate of <keeper>"
+  - stripped of its dependents, which are re-pointed at the keeper
+    (children are re-parented, blocked issues wait on the keeper)
+and the keeper inherits the duplicate's own dependencies it lacks. Each
+group is resolved in one transaction.
+
+Strategies:
+  interactive      Ask which issue to keep for each group (default on a terminal)
+  keep-oldest      Keep the earliest-created issue
+  keep-newest      Keep the most recently created issue
+  keep-suggested   Keep the issue 'bd duplicates' suggests (most connected)
+
+Examples:
+  bd duplicates resolve
+  bd duplicates resolve --strategy keep-oldest --dry-run
+  bd duplicates resolve --strategy keep-suggested --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		strategy, _ := cmd.Flags().GetString("strategy")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !cmd.Flags().Changed("strategy") {
+			strategy = dupStrategyInteractive
+		}
+		if !slices.Contains(dupStrategies, strategy) {
+			FatalErrorWithHint(fmt.Sprintf("unknown strategy %q", strategy),
+				"Use one of: "+strings.Join(dupStrategies, ", "))
+		}
+		interactive := strategy == dupStrategyInteractive
+		if interactive && (jsonOutput || !term.IsTerminal(int(os.Stdin.Fd()))) {
+			FatalErrorWithHint("interactive resolution needs a terminal",
+				"Pass --strategy keep-oldest, keep-newest, or keep-suggested")
+		}
+		if !dryRun {
+			CheckReadonly("duplicates resolve")
+		}
+		ctx := rootCtx
+
+		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalErrorRespectJSON("fetching issues: %v", err)
+		}
+		openIssues := make([]*types.Issue, 0, len(allIssues))
+		for _, issue := range allIssues {
+			if issue.Status != types.StatusClosed {
+				openIssues = append(openIssues, issue)
+			}
+		}
+		groups := sortDuplicateGroups(findDuplicateGroups(openIssues))
+		if len(groups) == 0 {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"groups": []dupResolution{}})
+				return
+			}
+			fmt.Println("No duplicates found!")
+			return
+		}
+		refCounts := countReferences(allIssues)
+		scores := countStructuralRelationships(groups)
+
+		var reader *bufio.Reader
+		if interactive {
+			reader = bufio.NewReader(os.Stdin)
+		}
+		var results []dupResolution
+		for i, group := range groups {
+			suggested := chooseMergeTarget(group, refCounts, scores)
+			keeper := chooseDuplicateKeeper(group, strategy, suggested)
+			if interactive {
+				var quit bool
+				keeper, quit = promptDuplicateKeeper(reader, i+1, len(groups), group, suggested, scores)
+				if quit {
+					break
+				}
+			}
+
+			res := dupResolution{Action: "skipped"}
+			if keeper != nil {
+				res = resolveDuplicateGroup(group, keeper, dryRun)
+			} else {
+				for _, issue := range group {
+					res.Duplicates = append(res.Duplicates, issue.ID)
+				}
+			}
+			results = append(results, res)
+			if !jsonOutput {
+				printDupResolution(res)
+			}
+		}
+		for _, r := range results {
+			if r.Action == "resolved" {
+				commandDidWrite.Store(true)
+				break
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"dry_run": dryRun, "strategy": strategy, "groups": results})
+			return
+		}
+		resolved := 0
+		for _, r := range results {
+			if r.Action == "resolved" || r.Action == "would-resolve" {
+				resolved++
+			}
+		}
+		if dryRun {
+			fmt.Printf("\n%s Dry run - would resolve %d of %d group(s)\n", ui.RenderWarn("⚠"), resolved, len(groups))
+		} else {
+			fmt.Printf("\n%s Resolved %d of %d group(s)\n", ui.RenderPass("✓"), resolved, len(groups))
+		}
+	},
+}
+
+// sortDuplicateGroups orders each group by creation time and the groups by
+// title, so runs are repeatable.
+func sortDuplicateGroups(groups [][]*types.Issue) [][]*types.Issue {
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			if !group[i].CreatedAt.Equal(group[j].CreatedAt) {
+				return group[i].CreatedAt.Before(group[j].CreatedAt)
+			}
+			return group[i].ID < group[j].ID
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i][0].Title != groups[j][0].Title {
+			return groups[i][0].Title < groups[j][0].Title
+		}
+		return groups[i][0].ID < groups[j][0].ID
+	})
+	return groups
+}
+
+// chooseDuplicateKeeper picks the issue to keep for a non-interactive
+// strategy. group must be sorted oldest first.
+func chooseDuplicateKeeper(group []*types.Issue, strategy string, suggested *types.Issue) *types.Issue {
+	switch strategy {
+	case dupStrategyOldest:
+		return group[0]
+	case dupStrategyNewest:
+		return group[len(group)-1]
+	default:
+		return suggested
+	}
+}
+
+// promptDuplicateKeeper asks which issue of a group to keep. It returns nil
+// to skip the group, and quit when the user stops the walk.
+func promptDuplicateKeeper(reader *bufio.Reader, n, total int, group []*types.Issue, suggested *types.Issue, scores map[string]*issueScore) (keeper *types.Issue, quit bool) {
+	fmt.Printf("\n%s Group %d/%d: %s\n", ui.RenderAccent("━━"), n, total, group[0].Title)
+	def := 1
+	for i, issue := range group {
+		weight := 0
+		if s, ok := scores[issue.ID]; ok {
+			weight = s.dependentCount*3 + s.dependsOnCount
+		}
+		note := ""
+		if issue.ID == suggested.ID {
+			note = ui.RenderPass(" (suggested)")
+			def = i + 1
+		}
+		fmt.Printf("  %d) %s  created %s  P%d %s  weight=%d%s\n", i+1, issue.ID,
+			issue.CreatedAt.Local().Format("2006-01-02"), issue.Priority, issue.Status, weight, note)
+	}
+	for {
+		fmt.Printf("Keep which? [1-%d, s=skip, q=quit] (%d): ", len(group), def)
+		line, err := readLineWithContext(getRootContext(), reader, os.Stdin)
+		if err != nil {
+			return nil, true
+		}
+		switch answer := strings.TrimSpace(line); answer {
+		case "":
+			return group[def-1], false
+		case "s", "skip":
+			return nil, false
+		case "q", "quit":
+			return nil, true
+		default:
+			if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(group) {
+				return group[i-1], false
+			}
+		}
+	}
+}
+
+// dupEdgeMove is one dependency change made when folding a duplicate into
+// its keeper. Remove is the duplicate's edge to drop (nil to keep it).
+type dupEdgeMove struct {
+	Remove *types.Dependency
+	Add    *types.Dependency
+}
+
+func (m dupEdgeMove) String() string {
+	return fmt.Sprintf("%s → %s (%s)", m.Add.IssueID, m.Add.DependsOnID, m.Add.Type)
+}
+
+// planDuplicateTransfer works out how a duplicate's edges move to keeper.
+// Incoming edges (dependents of the duplicate) are re-pointed at the keeper;
+// outgoing edges are copied when the keeper lacks them. Edges within the
+// group are dropped, and the keeper never gains a second parent.
+func planDuplicateTransfer(dupID, keeperID string, incoming, outgoing, keeperOut []*types.Dependency, groupIDs map[string]bool) []dupEdgeMove {
+	has := make(map[string]bool)
+	keeperHasParent := false
+	for _, d := range keeperOut {
+		has[d.DependsOnID] = true
+		if d.Type == types.DepParentChild {
+			keeperHasParent = true
+		}
+	}
+
+	var moves []dupEdgeMove
+	for _, d := range incoming {
+		if groupIDs[d.IssueID] {
+			continue
+		}
+		add := *d
+		add.DependsOnID = keeperID
+		moves = append(moves, dupEdgeMove{Remove: d, Add: &add})
+	}
+	for _, d := range outgoing {
+		if groupIDs[d.DependsOnID] || has[d.DependsOnID] {
+			continue
+		}
+		if d.Type == types.DepParentChild {
+			if keeperHasParent {
+				continue
+			}
+			keeperHasParent = true
+		}
+		add := *d
+		add.IssueID = keeperID
+		has[d.DependsOnID] = true
+		moves = append(moves, dupEdgeMove{Add: &add})
+	}
+	return moves
+}
+
+// resolveDuplicateGroup folds every other issue of group into keeper.
+func resolveDuplicateGroup(group []*types.Issue, keeper *types.Issue, dryRun bool) dupResolution {
+	ctx := rootCtx
+	res := dupResolution{Keeper: keeper.ID, Action: "resolved"}
+	if dryRun {
+		res.Action = "would-resolve"
+	}
+	groupIDs := make(map[string]bool, len(group))
+	for _, issue := range group {
+		groupIDs[issue.ID] = true
+		if issue.ID != keeper.ID {
+			res.Duplicates = append(res.Duplicates, issue.ID)
+		}
+	}
+
+	fail := func(err error) dupResolution {
+		res.Action, res.Error, res.Transferred = "error", err.Error(), nil
+		return res
+	}
+
+	// Read the edges up front; the transaction has no dependents query.
+	incoming := make(map[string][]*types.Dependency)
+	for _, dupID := range res.Duplicates {
+		dependents, err := store.GetDependentsWithMetadata(ctx, dupID)
+		if err != nil {
+			return fail(fmt.Errorf("reading dependents of %s: %w", dupID, err))
+		}
+		for _, d := range dependents {
+			incoming[dupID] = append(incoming[dupID], &types.Dependency{IssueID: d.ID, DependsOnID: dupID, Type: d.DependencyType})
+		}
+	}
+
+	// walk plans each duplicate's transfer, reading edges through getDeps,
+	// and applies it through tx unless tx is nil (dry run).
+	walk := func(getDeps func(context.Context, string) ([]*types.Dependency, error), tx storage.Transaction) error {
+		res.Transferred = nil // a retried transaction replans from scratch
+		for _, dupID := range res.Duplicates {
+			outgoing, err := getDeps(ctx, dupID)
+			if err != nil {
+				return fmt.Errorf("reading dependencies of %s: %w", dupID, err)
+			}
+			keeperOut, err := getDeps(ctx, keeper.ID)
+			if err != nil {
+				return fmt.Errorf("reading dependencies of %s: %w", keeper.ID, err)
+			}
+			for _, m := range planDuplicateTransfer(dupID, keeper.ID, incoming[dupID], outgoing, keeperOut, groupIDs) {
+				res.Transferred = append(res.Transferred, m.String())
+				if tx == nil {
+					continue
+				}
+				if m.Remove != nil {
+					if err := tx.RemoveDependency(ctx, m.Remove.IssueID, m.Remove.DependsOnID, actor); err != nil {
+						return fmt.Errorf("removing %s → %s: %w", m.Remove.IssueID, m.Remove.DependsOnID, err)
+					}
+				}
+				if err := tx.AddDependency(ctx, m.Add, actor); err != nil {
+					return fmt.Errorf("adding %s: %w", m, err)
+				}
+			}
+			if tx == nil {
+				continue
+			}
+			dep := &types.Dependency{IssueID: dupID, DependsOnID: keeper.ID, Type: types.DepDuplicates}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return fmt.Errorf("linking %s to %s: %w", dupID, keeper.ID, err)
+			}
+			if err := tx.CloseIssue(ctx, dupID, fmt.Sprintf("Duplicate of %s", keeper.ID), actor, ""); err != nil {
+				return fmt.Errorf("closing %s: %w", dupID, err)
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if dryRun {
+		err = walk(store.GetDependencyRecords, nil)
+	} else {
+		err = transact(ctx, store, fmt.Sprintf("bd: resolve duplicates of %s", keeper.ID), func(tx storage.Transaction) error {
+			return walk(tx.GetDependencyRecords, tx)
+		})
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return res
+}
+
+func printDupResolution(r dupResolution) {
+	switch r.Action {
+	case "skipped":
+		fmt.Printf("  %s skipped %s\n", ui.RenderMuted("–"), strings.Join(r.Duplicates, " "))
+	case "error":
+		fmt.Printf("  %s %s: %s\n", ui.RenderFail("✗"), r.Keeper, r.Error)
+	default:
+		verb := "Marked"
+		if r.Action == "would-resolve" {
+			verb = "Would mark"
+		}
+		fmt.Printf("  %s %s %s as duplicate of %s\n", ui.RenderPass("✓"), verb, strings.Join(r.Duplicates, " "), r.Keeper)
+		for _, t := range r.Transferred {
+			fmt.Printf("      %s\n", ui.RenderMuted(t))
+		}
+	}
+}
+
+func init() {
+	duplicatesResolveCmd.Flags().String("strategy", dupStrategyInteractive,
+		"How to pick the keeper: "+strings.Join(dupStrategies, ", "))
+	duplicatesResolveCmd.Flags().Bool("dry-run", false, "Show what would change without writing")
+	duplicatesCmd.AddCommand(duplicatesResolveCmd)
+}
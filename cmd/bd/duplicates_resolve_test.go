@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestChooseDuplicateKeeper(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	group := sortDuplicateGroups([][]*types.Issue{{
+		{ID: "bd-c", CreatedAt: t0.Add(2 * time.Hour)},
+		{ID: "bd-a", CreatedAt: t0},
+		{ID: "bd-b", CreatedAt: t0.Add(time.Hour)},
+	}})[0]
+	suggested := group[1]
+
+	tests := []struct {
+		strategy string
+		want     string
+	}{
+		{dupStrategyOldest, "bd-a"},
+		{dupStrategyNewest, "bd-c"},
+		{dupStrategySuggested, "bd-b"},
+	}
+	for _, tt := range tests {
+		if got := chooseDuplicateKeeper(group, tt.strategy, suggested); got.ID != tt.want {
+			t.Errorf("%s: keeper = %s, want %s", tt.strategy, got.ID, tt.want)
+		}
+	}
+}
+
+func TestPlanDuplicateTransfer(t *testing.T) {
+	dep := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	incoming := []*types.Dependency{
+		dep("bd-child", "bd-dup", types.DepParentChild),
+		dep("bd-blocked", "bd-dup", types.DepBlocks),
+		dep("bd-dup2", "bd-dup", types.DepRelated), // within the group: dropped
+	}
+	outgoing := []*types.Dependency{
+		dep("bd-dup", "bd-blocker", types.DepBlocks),
+		dep("bd-dup", "bd-shared", types.DepBlocks),     // keeper already has it
+		dep("bd-dup", "bd-epic2", types.DepParentChild), // keeper already has a parent
+		dep("bd-dup", "bd-keep", types.DepRelated),      // points at the keeper
+	}
+	keeperOut := []*types.Dependency{
+		dep("bd-keep", "bd-shared", types.DepBlocks),
+		dep("bd-keep", "bd-epic", types.DepParentChild),
+	}
+	group := map[string]bool{"bd-keep": true, "bd-dup": true, "bd-dup2": true}
+
+	var got []string
+	for _, m := range planDuplicateTransfer("bd-dup", "bd-keep", incoming, outgoing, keeperOut, group) {
+		if m.Remove != nil {
+			got = append(got, "move "+m.String())
+		} else {
+			got = append(got, "copy "+m.String())
+		}
+	}
+	want := []string{
+		"move bd-child → bd-keep (parent-child)",
+		"move bd-blocked → bd-keep (blocks)",
+		"copy bd-keep → bd-blocker (blocks)",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("transfer =\n  %v\nwant\n  %v", got, want)
+	}
+}
@@ -52,20 +52,7 @@ Examples:
 			fieldToEdit = "acceptance_criteria"
 		}
 
-		// Get the editor from environment
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = os.Getenv("VISUAL")
-		}
-		if editor == "" {
-			// Try common defaults
-			for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
-				if _, err := exec.LookPath(defaultEditor); err == nil {
-					editor = defaultEditor
-					break
-				}
-			}
-		}
+		editor := findEditor()
 		if editor == "" {
 			FatalErrorRespectJSON("no editor found. Set $EDITOR or $VISUAL environment variable")
 		}
@@ -107,15 +94,7 @@ Examples:
 		}
 		_ = tmpFile.Close()
 
-		// Open the editor - parse command and args (handles "vim -w" or "zeditor --wait")
-		editorParts := strings.Fields(editor)
-		editorArgs := append(editorParts[1:], tmpPath)
-		editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
-		editorCmd.Stdin = os.Stdin
-		editorCmd.Stdout = os.Stdout
-		editorCmd.Stderr = os.Stderr
-
-		if err := editorCmd.Run(); err != nil {
+		if err := runEditor(editor, tmpPath); err != nil {
 			FatalErrorRespectJSON("running editor: %v", err)
 		}
 
@@ -183,6 +162,35 @@ Examples:
 	},
 }
 
+// findEditor returns $EDITOR, then $VISUAL, then the first common editor on
+// PATH, or "" when none is available.
+func findEditor() string {
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
+		if _, err := exec.LookPath(defaultEditor); err == nil {
+			return defaultEditor
+		}
+	}
+	return ""
+}
+
+// runEditor opens path in editor attached to the terminal. The editor string
+// may carry arguments ("vim -w", "zeditor --wait") (GH#987).
+func runEditor(editor, path string) error {
+	editorParts := strings.Fields(editor)
+	editorArgs := append(editorParts[1:], path)
+	editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	return editorCmd.Run()
+}
+
 func init() {
 	editCmd.Flags().Bool("title", false, "Edit the title")
 	editCmd.Flags().Bool("description", false, "Edit the description (default)")
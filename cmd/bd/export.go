@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,9 +11,14 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/age"
 	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage/domain"
+	"github.com/steveyegge/beads/internal/telemetry"
 	"github.com/steveyegge/beads/internal/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var exportCmd = &cobra.Command{
@@ -23,6 +29,13 @@ var exportCmd = &cobra.Command{
 Each line is a complete JSON object representing one issue, including its
 labels, dependencies, and comments.
 
+With --schema-header (or export.schema-header: true in config.yaml), the
+first line is a schema header such as
+{"_schema":"beads-jsonl/1","_bd_version":"1.0.5"} that lets 'bd import'
+migrate the file and warn when it comes from a newer bd. The header is off
+by default because line-oriented consumers (jq, scripts) expect every line
+to be an issue.
+
 This command is for issue export, migration, and interoperability. It exports
 records from the issues table; it is not a full database backup and does not
 capture Dolt branches, commit history, working-set state, or non-issue tables.
@@ -36,12 +49,31 @@ Memories (from 'bd remember') are excluded by default because they may
 contain sensitive agent context. Use --include-memories or --all to
 include them.
 
+With --sign (or export.sign: true in config.yaml), the file is signed with
+an SSH key and the signature written next to it as <file>.sig. Consumers can
+then refuse unsigned or tampered files with 'bd import --verify'. The key
+comes from --signing-key or export.signing-key; keys held in ssh-agent are
+used through SSH_AUTH_SOCK.
+
+With --encrypt-to (or export.encrypt-to in config.yaml), the output is
+encrypted with age (age-encryption.org) to each recipient: an age1... public
+key from age-keygen, or a file listing such keys. --encrypt-passphrase
+encrypts to the passphrase in BEADS_AGE_PASSPHRASE instead. 'bd import'
+decrypts such files with the key in BEADS_AGE_IDENTITY or the key file named
+by BEADS_AGE_IDENTITY_FILE, or with BEADS_AGE_PASSPHRASE; 'age -d' opens them
+too. A signature made with --sign covers the encrypted file. Auto-export is
+never encrypted.
+
 EXAMPLES:
   bd export                              # Export issues to stdout
   bd export -o issues.jsonl              # Export issues to file
   bd export --include-memories           # Export issues + memories
   bd export --all -o full.jsonl          # Include infra + templates + gates + memories
-  bd export --scrub -o clean.jsonl       # Exclude test/pollution records`,
+  bd export --scrub -o clean.jsonl       # Exclude test/pollution records
+  bd export -o issues.jsonl --sign --signing-key ~/.ssh/id_ed25519
+  bd export --schema-header -o issues.jsonl   # Versioned file for bd import
+  bd export -o issues.jsonl.age --encrypt-to age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+  BEADS_AGE_PASSPHRASE=... bd export -o issues.jsonl.age --encrypt-passphrase`,
 	GroupID: "sync",
 	RunE:    runExport,
 }
@@ -53,6 +85,11 @@ var (
 	exportScrub           bool
 	exportNoMemories      bool
 	exportIncludeMemories bool
+	exportSign            bool
+	exportSigningKey      string
+	exportSchemaHeader    bool
+	exportEncryptTo       []string
+	exportEncryptPass     bool
 )
 
 func init() {
@@ -63,11 +100,34 @@ func init() {
 	exportCmd.Flags().BoolVar(&exportIncludeMemories, "include-memories", false, "Include persistent memories (from 'bd remember') in the export")
 	exportCmd.Flags().BoolVar(&exportNoMemories, "no-memories", false, "Exclude persistent memories (deprecated: now the default)")
 	_ = exportCmd.Flags().MarkHidden("no-memories")
+	exportCmd.Flags().BoolVar(&exportSign, "sign", false, "Sign the output file with an SSH key, writing <file>.sig (requires -o)")
+	exportCmd.Flags().StringVar(&exportSigningKey, "signing-key", "", "SSH key used by --sign (default: export.signing-key)")
+	exportCmd.Flags().BoolVar(&exportSchemaHeader, "schema-header", false, "Write a schema header as the first line (default: export.schema-header)")
+	exportCmd.Flags().StringArrayVar(&exportEncryptTo, "encrypt-to", nil, "Encrypt the output with age to this recipient (age1... key or recipients file; repeatable; default: export.encrypt-to)")
+	exportCmd.Flags().BoolVar(&exportEncryptPass, "encrypt-passphrase", false, "Encrypt the output with age to the passphrase in "+envAgePassphrase)
 	rootCmd.AddCommand(exportCmd)
 }
 
-func runExport(cmd *cobra.Command, args []string) error {
-	ctx := rootCtx
+func runExport(cmd *cobra.Command, args []string) (retErr error) {
+	ctx, span := telemetry.Tracer("github.com/steveyegge/beads/export").Start(rootCtx, "bd.export")
+	defer func() { telemetry.EndSpan(span, retErr) }()
+
+	sign := exportSign || config.GetBool("export.sign")
+	signingKey := exportSigningKey
+	if signingKey == "" {
+		signingKey = config.GetString("export.signing-key")
+	}
+	if sign && exportOutput == "" {
+		return fmt.Errorf("--sign requires --output: signatures are written next to the export file")
+	}
+	encryptTo := exportEncryptTo
+	if len(encryptTo) == 0 && !exportEncryptPass {
+		encryptTo = config.GetStringSlice("export.encrypt-to")
+	}
+	recipients, err := exportRecipients(encryptTo, exportEncryptPass)
+	if err != nil {
+		return err
+	}
 
 	// Determine output destination. File output uses atomic writes
 	// (temp file + rename) so concurrent exports and crashes never
@@ -88,47 +148,21 @@ func runExport(cmd *cobra.Command, args []string) error {
 	} else {
 		w = os.Stdout
 	}
-
-	// Build filter for issues table. Export all statuses by default.
-	filter := types.IssueFilter{Limit: 0}
-
-	// Exclude infra types by default (agents, rigs, roles, messages)
-	if !exportAll && !exportIncludeInfra {
-		var infraTypes []string
-		if store != nil {
-			infraSet := store.GetInfraTypes(ctx)
-			if len(infraSet) > 0 {
-				for t := range infraSet {
-					infraTypes = append(infraTypes, t)
-				}
-			}
-		}
-		if len(infraTypes) == 0 {
-			infraTypes = domain.DefaultInfraTypes()
-		}
-		for _, t := range infraTypes {
-			filter.ExcludeTypes = append(filter.ExcludeTypes, types.IssueType(t))
+	var enc io.WriteCloser
+	if len(recipients) > 0 {
+		if enc, err = age.Encrypt(w, recipients...); err != nil {
+			return fmt.Errorf("failed to start encryption: %w", err)
 		}
+		w = enc
 	}
 
-	// Exclude templates by default
-	if !exportAll {
-		isTemplate := false
-		filter.IsTemplate = &isTemplate
-	}
-
-	// Exclude ephemeral wisps by default — they are private/transient and
-	// must not reach git history or external integrations (GH#3649).
-	// --all overrides to include everything.
-	if !exportAll {
-		persistentOnly := false
-		filter.Ephemeral = &persistentOnly
-	}
+	filter := exportIssueFilter(ctx, exportAll, exportIncludeInfra)
 
 	issues, err := store.SearchIssues(ctx, "", filter)
 	if err != nil {
 		return fmt.Errorf("failed to search issues: %w", err)
 	}
+	span.SetAttributes(attribute.Int("bd.issue.count", len(issues)))
 
 	// Scrub test/pollution records if requested
 	if exportScrub {
@@ -161,7 +195,22 @@ func runExport(cmd *cobra.Command, args []string) error {
 		issue.Comments = commentsMap[issue.ID]
 	}
 
-	// Write JSONL: one JSON object per line
+	// status.export-map renames statuses for consumers with a different
+	// workflow. Auto-export skips it so its JSONL round-trips unchanged.
+	statusMap, err := loadStatusMap(ctx, store, "status.export-map")
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		issue.Status = statusMap.Apply(issue.Status)
+	}
+
+	// Write JSONL: an optional schema header, then one JSON object per line
+	if exportSchemaHeader || config.GetBool("export.schema-header") {
+		if err := jsonl.WriteHeader(w, Version); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+	}
 	count := 0
 	for _, issue := range issues {
 		counts := depCounts[issue.ID]
@@ -236,6 +285,13 @@ func runExport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Write the last encrypted chunk before the file is finalized.
+	if enc != nil {
+		if err := enc.Close(); err != nil {
+			return fmt.Errorf("failed to finish encryption: %w", err)
+		}
+	}
+
 	// Finalize atomic write if writing to file (fsync + rename).
 	if aw != nil {
 		if err := aw.Close(); err != nil {
@@ -243,6 +299,15 @@ func runExport(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var signedBy string
+	if sign {
+		fp, err := signExportFile(exportOutput, signingKey)
+		if err != nil {
+			return fmt.Errorf("failed to sign export: %w", err)
+		}
+		signedBy = fp
+	}
+
 	// Print summary to stderr (not stdout, to avoid mixing with JSONL)
 	if exportOutput != "" {
 		if memoryCount > 0 {
@@ -250,6 +315,12 @@ func runExport(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Fprintf(os.Stderr, "Exported %d issues to %s\n", count, exportOutput)
 		}
+		if len(recipients) > 0 {
+			fmt.Fprintf(os.Stderr, "Encrypted %s with age\n", exportOutput)
+		}
+		if signedBy != "" {
+			fmt.Fprintf(os.Stderr, "Signed %s%s with %s\n", exportOutput, signatureSuffix, signedBy)
+		}
 	}
 
 	return nil
@@ -286,3 +357,44 @@ func filterOutPollution(issues []*types.Issue) []*types.Issue {
 	}
 	return clean
 }
+
+// exportIssueFilter selects the issues bd export writes: every status, but
+// without infra types, templates, and ephemeral wisps unless all (or, for
+// infra types, includeInfra) is set.
+func exportIssueFilter(ctx context.Context, all, includeInfra bool) types.IssueFilter {
+	filter := types.IssueFilter{Limit: 0}
+
+	// Exclude infra types by default (agents, rigs, roles, messages)
+	if !all && !includeInfra {
+		var infraTypes []string
+		if store != nil {
+			infraSet := store.GetInfraTypes(ctx)
+			if len(infraSet) > 0 {
+				for t := range infraSet {
+					infraTypes = append(infraTypes, t)
+				}
+			}
+		}
+		if len(infraTypes) == 0 {
+			infraTypes = domain.DefaultInfraTypes()
+		}
+		for _, t := range infraTypes {
+			filter.ExcludeTypes = append(filter.ExcludeTypes, types.IssueType(t))
+		}
+	}
+
+	// Exclude templates by default
+	if !all {
+		isTemplate := false
+		filter.IsTemplate = &isTemplate
+	}
+
+	// Exclude ephemeral wisps by default — they are private/transient and
+	// must not reach git history or external integrations (GH#3649).
+	// --all overrides to include everything.
+	if !all {
+		persistentOnly := false
+		filter.Ephemeral = &persistentOnly
+	}
+	return filter
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/types"
@@ -145,12 +146,29 @@ func maybeAutoExport(ctx context.Context, serverMode, allowEmptyOverwrite bool)
 	}
 	warnJSONLWithoutDoltRemote("auto-export")
 
+	// Optional signing. A stale signature would make every verified import
+	// fail as "tampered", so drop it when signing this export fails.
+	signed := false
+	if config.GetBool("export.sign") {
+		if _, err := signExportFile(fullPath, config.GetString("export.signing-key")); err != nil {
+			_ = os.Remove(fullPath + signatureSuffix)
+			fmt.Fprintf(os.Stderr, "Warning: auto-export signing failed: %v\n", err)
+		} else {
+			signed = true
+		}
+	}
+
 	// Optional git add — skip when no-git-ops is set (GH#3314), when not in a
 	// git repo (standalone BEADS_DIR flow), or when export.git-add is false.
 	if config.GetBool("export.git-add") && !config.GetBool("no-git-ops") && isGitRepo() {
 		if err := gitAddFile(fullPath); err != nil {
 			return fmt.Errorf("auto-export: git add failed: %w", err)
 		}
+		if signed {
+			if err := gitAddFile(fullPath + signatureSuffix); err != nil {
+				return fmt.Errorf("auto-export: git add failed: %w", err)
+			}
+		}
 	}
 
 	// Save state
@@ -362,6 +380,12 @@ func exportToFile(ctx context.Context, path string, includeMemories bool) (issue
 		return 0, 0, err
 	}
 
+	if config.GetBool("export.schema-header") {
+		if err := jsonl.WriteHeader(w, Version); err != nil {
+			return 0, 0, fmt.Errorf("failed to write header: %w", err)
+		}
+	}
+
 	// Bulk-load relational data
 	if len(issues) > 0 {
 		issueIDs := make([]string, len(issues))
@@ -495,10 +519,14 @@ func classifyExistingAutoExportRecord(line []byte, infraTypes map[string]bool, i
 		IsTemplate bool            `json:"is_template"`
 		Ephemeral  bool            `json:"ephemeral"`
 		ID         string          `json:"id"`
+		Schema     string          `json:"_schema"`
 	}
 	if err := json.Unmarshal(line, &record); err != nil {
 		return err
 	}
+	if record.Schema != "" {
+		return nil // schema header, rewritten on every export
+	}
 
 	switch record.Type {
 	case "memory":
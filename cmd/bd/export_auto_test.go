@@ -216,6 +216,7 @@ func TestMaybeAutoExportSkipsServerModeBeforeStoreAccess(t *testing.T) {
 func TestGuardAutoExportOverwriteAllowsViewerScopedJSONL(t *testing.T) {
 	path := filepath.Join(t.TempDir(), "issues.jsonl")
 	writeJSONLLines(t, path,
+		map[string]any{"_schema": "beads-jsonl/1", "_bd_version": "1.0.5"},
 		map[string]any{"_type": "issue", "id": "bd-1", "issue_type": "task", "title": "kept"},
 		map[string]any{"id": "bd-legacy", "issue_type": "bug", "title": "legacy issue record"},
 	)
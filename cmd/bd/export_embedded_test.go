@@ -53,6 +53,26 @@ func TestEmbeddedExport(t *testing.T) {
 		}
 	})
 
+	t.Run("schema_header", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "exhdr")
+		bdCreateSilent(t, bd, dir, "schema header issue")
+
+		for _, enable := range [][]string{{"--schema-header"}, nil} {
+			if enable == nil {
+				if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "export.schema-header", "true"); err != nil {
+					t.Fatalf("config set: %v\n%s", err, out)
+				}
+			}
+			lines := strings.Split(strings.TrimSpace(bdExport(t, bd, dir, enable...)), "\n")
+			if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"_schema":"beads-jsonl/`) {
+				t.Fatalf("export %v: want schema header then one issue, got:\n%s", enable, strings.Join(lines, "\n"))
+			}
+			if !strings.Contains(lines[1], "schema header issue") {
+				t.Errorf("export %v: second line is not the issue: %s", enable, lines[1])
+			}
+		}
+	})
+
 	t.Run("type_discriminator", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "extyp")
 		bdCreateSilent(t, bd, dir, "type discriminator test")
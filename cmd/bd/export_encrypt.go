@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/age"
+)
+
+// Environment variables holding age secrets. Keys are read from the
+// environment rather than config.yaml so they never end up committed; to
+// keep them in an OS keychain, fill the variable from it, e.g.
+//
+//	BEADS_AGE_PASSPHRASE=$(security find-generic-password -w -s beads) bd export ...
+//	BEADS_AGE_PASSPHRASE=$(secret-tool lookup service beads) bd import ...
+const (
+	envAgePassphrase   = "BEADS_AGE_PASSPHRASE"
+	envAgeIdentity     = "BEADS_AGE_IDENTITY"
+	envAgeIdentityFile = "BEADS_AGE_IDENTITY_FILE"
+)
+
+// exportRecipients resolves the age recipients for bd export. Each value is
+// an age1... public key or the path of a recipients file (one key per
+// line). With passphrase the file is encrypted to BEADS_AGE_PASSPHRASE
+// instead, which age does not allow to be mixed with keys.
+func exportRecipients(values []string, passphrase bool) ([]age.Recipient, error) {
+	if passphrase {
+		if len(values) > 0 {
+			return nil, errors.New("--encrypt-passphrase cannot be combined with --encrypt-to or export.encrypt-to")
+		}
+		pass := os.Getenv(envAgePassphrase)
+		if pass == "" {
+			return nil, fmt.Errorf("--encrypt-passphrase needs the passphrase in %s", envAgePassphrase)
+		}
+		r, err := age.NewScryptRecipient(pass)
+		if err != nil {
+			return nil, err
+		}
+		return []age.Recipient{r}, nil
+	}
+
+	var recipients []age.Recipient
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if strings.HasPrefix(v, "age1") {
+			r, err := age.ParseX25519Recipient(v)
+			if err != nil {
+				return nil, err
+			}
+			recipients = append(recipients, r)
+			continue
+		}
+		f, err := os.Open(v) //nolint:gosec // G304: recipients file chosen by the user
+		if err != nil {
+			return nil, fmt.Errorf("encryption recipient %q is neither an age1... key nor a readable file: %w", v, err)
+		}
+		rs, err := age.ParseRecipients(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("recipients file %s: %w", v, err)
+		}
+		recipients = append(recipients, rs...)
+	}
+	return recipients, nil
+}
+
+// importIdentities collects the identities that may open an encrypted
+// import: the keys in BEADS_AGE_IDENTITY and BEADS_AGE_IDENTITY_FILE (as
+// written by age-keygen) and the passphrase in BEADS_AGE_PASSPHRASE.
+func importIdentities() ([]age.Identity, error) {
+	var ids []age.Identity
+	if v := os.Getenv(envAgeIdentity); v != "" {
+		parsed, err := age.ParseIdentities(strings.NewReader(v))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envAgeIdentity, err)
+		}
+		ids = append(ids, parsed...)
+	}
+	if path := os.Getenv(envAgeIdentityFile); path != "" {
+		f, err := os.Open(path) //nolint:gosec // G304: identity file chosen by the user
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envAgeIdentityFile, err)
+		}
+		parsed, err := age.ParseIdentities(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envAgeIdentityFile, err)
+		}
+		ids = append(ids, parsed...)
+	}
+	if pass := os.Getenv(envAgePassphrase); pass != "" {
+		id, err := age.NewScryptIdentity(pass)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("file is age-encrypted; set %s, %s, or %s to decrypt it",
+			envAgeIdentity, envAgeIdentityFile, envAgePassphrase)
+	}
+	return ids, nil
+}
+
+// maybeDecryptImport returns a reader of r's plaintext and true when r is
+// age-encrypted, or a reader of r unchanged and false otherwise.
+func maybeDecryptImport(r io.Reader) (io.Reader, bool, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(age.Magic) + 1)
+	if !age.IsEncrypted(head) {
+		return br, false, nil
+	}
+	ids, err := importIdentities()
+	if err != nil {
+		return nil, true, err
+	}
+	pr, err := age.Decrypt(br, ids...)
+	if err != nil {
+		return nil, true, fmt.Errorf("decrypt: %w", err)
+	}
+	return pr, true, nil
+}
@@ -0,0 +1,100 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/age"
+)
+
+func TestEmbeddedExportEncrypted(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	src, _, _ := bdInit(t, bd, "--prefix", "ec")
+	issue := bdCreate(t, bd, src, "Secret roadmap item")
+
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(dir string, env []string, args ...string) (string, error) {
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), env...)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	// importInto imports path into a fresh repository and reports whether
+	// the issue arrived.
+	importInto := func(path string, env ...string) (string, error) {
+		t.Helper()
+		dst, _, _ := bdInit(t, bd, "--prefix", "ec")
+		if out, err := run(dst, env, "import", path); err != nil {
+			return out, err
+		}
+		return run(dst, nil, "show", issue.ID)
+	}
+
+	t.Run("recipient key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl.age")
+		if out, err := run(src, nil, "export", "-o", path, "--encrypt-to", id.Recipient().String()); err != nil {
+			t.Fatalf("bd export --encrypt-to: %v\n%s", err, out)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !age.IsEncrypted(data) || strings.Contains(string(data), "Secret roadmap item") {
+			t.Fatalf("export is not age-encrypted:\n%s", data)
+		}
+
+		keyFile := filepath.Join(t.TempDir(), "key.txt")
+		if err := os.WriteFile(keyFile, []byte("# test key\n"+id.String()+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		out, err := importInto(path, "BEADS_AGE_IDENTITY_FILE="+keyFile)
+		if err != nil || !strings.Contains(out, "Secret roadmap item") {
+			t.Errorf("import with key file (%v):\n%s", err, out)
+		}
+
+		out, err = importInto(path)
+		if err == nil || !strings.Contains(out, "BEADS_AGE_IDENTITY") {
+			t.Errorf("import without a key should fail (%v):\n%s", err, out)
+		}
+
+		other, err := age.GenerateX25519Identity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err = importInto(path, "BEADS_AGE_IDENTITY="+other.String())
+		if err == nil || !strings.Contains(out, "decrypt") {
+			t.Errorf("import with the wrong key should fail (%v):\n%s", err, out)
+		}
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl.age")
+		env := []string{"BEADS_AGE_PASSPHRASE=correct horse battery staple"}
+		if out, err := run(src, env, "export", "-o", path, "--encrypt-passphrase"); err != nil {
+			t.Fatalf("bd export --encrypt-passphrase: %v\n%s", err, out)
+		}
+		out, err := importInto(path, env...)
+		if err != nil || !strings.Contains(out, "Secret roadmap item") {
+			t.Errorf("import with passphrase (%v):\n%s", err, out)
+		}
+		out, err = run(src, env, "export", "-o", path, "--encrypt-passphrase", "--encrypt-to", id.Recipient().String())
+		if err == nil || !strings.Contains(out, "cannot be combined") {
+			t.Errorf("passphrase with recipients should fail (%v):\n%s", err, out)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// renderMarkdownIssue renders issue in the format bd import markdown reads.
+// issue.Labels and issue.Dependencies must be populated.
+func renderMarkdownIssue(issue *types.Issue) ([]byte, error) {
+	front := markdownFrontMatter{
+		ID:     issue.ID,
+		Status: string(issue.Status),
+		Type:   string(issue.IssueType),
+	}
+	priority := issue.Priority
+	front.Priority = &priority
+	if issue.Assignee != "" {
+		assignee := issue.Assignee
+		front.Assignee = &assignee
+	}
+	if len(issue.Labels) > 0 {
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		front.Labels = &labels
+	}
+	var deps []string
+	for _, dep := range issue.Dependencies {
+		switch dep.Type {
+		case types.DepParentChild:
+			parent := dep.DependsOnID
+			front.Parent = &parent
+		case types.DepBlocks:
+			deps = append(deps, dep.DependsOnID)
+		default:
+			deps = append(deps, string(dep.Type)+":"+dep.DependsOnID)
+		}
+	}
+	if len(deps) > 0 {
+		sort.Strings(deps)
+		front.Deps = &deps
+	}
+	if issue.ExternalRef != nil {
+		front.ExternalRef = *issue.ExternalRef
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&front); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("---\n")
+	fmt.Fprintf(&buf, "# %s\n", issue.Title)
+	if d := strings.TrimSpace(issue.Description); d != "" {
+		fmt.Fprintf(&buf, "\n%s\n", d)
+	}
+	for _, s := range []struct{ heading, text string }{
+		{"Design", issue.Design},
+		{"Acceptance Criteria", issue.AcceptanceCriteria},
+		{"Notes", issue.Notes},
+	} {
+		if text := strings.TrimSpace(s.text); text != "" {
+			fmt.Fprintf(&buf, "\n## %s\n\n%s\n", s.heading, text)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// markdownIssueFiles maps issue IDs to the files under dir that declare them,
+// so an export rewrites a renamed or nested file instead of adding a copy.
+// Files that do not parse are ignored.
+func markdownIssueFiles(dir string) map[string]string {
+	files := map[string]string{}
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- walking the export directory
+		if err != nil {
+			return nil
+		}
+		if issue, err := parseMarkdownIssue(path, data); err == nil && issue.Front.ID != "" {
+			files[issue.Front.ID] = path
+		}
+		return nil
+	})
+	return files
+}
+
+var (
+	exportMarkdownAll          bool
+	exportMarkdownIncludeInfra bool
+)
+
+var exportMarkdownCmd = &cobra.Command{
+	Use:   "markdown <dir>",
+	Short: "Export issues as one Markdown file per issue",
+	Long: `Export issues to a directory of Markdown files, one per issue, in the
+format 'bd import markdown' reads: YAML front matter (id, status, priority,
+type, assignee, labels, parent, deps, external_ref), a "# Title" heading,
+the description, and Design / Acceptance Criteria / Notes sections.
+
+New issues are written to <dir>/<id>.md. A file anywhere under <dir> whose
+front matter already names an issue is rewritten in place, so files can be
+renamed and moved into subdirectories between exports. Other files are left
+alone, and files for issues that no longer exist are not deleted.
+
+The same issues are exported as by 'bd export': infra types, templates, and
+ephemeral wisps are left out unless --all (or --include-infra) is given.
+
+Examples:
+  bd export markdown docs/issues/
+  bd export markdown docs/issues/ && bd import markdown docs/issues/   # no-op round trip`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExportMarkdown,
+}
+
+func init() {
+	exportMarkdownCmd.Flags().BoolVar(&exportMarkdownAll, "all", false, "Include all issues (infra, templates, ephemeral)")
+	exportMarkdownCmd.Flags().BoolVar(&exportMarkdownIncludeInfra, "include-infra", false, "Include infrastructure beads (agents, rigs, roles, messages)")
+	exportCmd.AddCommand(exportMarkdownCmd)
+}
+
+func runExportMarkdown(cmd *cobra.Command, args []string) error {
+	ctx := rootCtx
+	dir := args[0]
+	if store == nil {
+		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
+	}
+
+	issues, err := store.SearchIssues(ctx, "", exportIssueFilter(ctx, exportMarkdownAll, exportMarkdownIncludeInfra))
+	if err != nil {
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labelsMap, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load labels: %w", err)
+	}
+	depsMap, err := store.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load dependencies: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	existing := markdownIssueFiles(dir)
+
+	var files []string
+	for _, issue := range issues {
+		issue.Labels = labelsMap[issue.ID]
+		issue.Dependencies = depsMap[issue.ID]
+		data, err := renderMarkdownIssue(issue)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", issue.ID, err)
+		}
+		path, ok := existing[issue.ID]
+		if !ok {
+			path = filepath.Join(dir, issue.ID+".md")
+		}
+		if err := atomicfile.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"dir":   dir,
+			"count": len(files),
+			"files": files,
+		})
+		return nil
+	}
+	fmt.Printf("Exported %d issue(s) to %s\n", len(files), dir)
+	return nil
+}
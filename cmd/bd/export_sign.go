@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/sshsig"
+	"golang.org/x/crypto/ssh"
+)
+
+// exportSignatureNamespace is the SSHSIG namespace for JSONL exports. Verify
+// by hand with:
+//
+//	ssh-keygen -Y verify -f allowed_signers -I <principal> -n beads-export \
+//	    -s issues.jsonl.sig < issues.jsonl
+const exportSignatureNamespace = "beads-export"
+
+// signatureSuffix is appended to an export's path to name its signature.
+const signatureSuffix = ".sig"
+
+// signExportFile writes an SSH signature of path to path+".sig" using the
+// key at keyPath and returns the signing key's fingerprint.
+func signExportFile(path, keyPath string) (string, error) {
+	if keyPath == "" {
+		return "", errors.New("no signing key: set export.signing-key or pass --signing-key")
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: export path chosen by the user
+	if err != nil {
+		return "", fmt.Errorf("open export for signing: %w", err)
+	}
+	defer f.Close()
+	sig, err := sshsig.SignFile(keyPath, exportSignatureNamespace, f)
+	if err != nil {
+		return "", err
+	}
+	if err := atomicfile.WriteFile(path+signatureSuffix, sig, 0o644); err != nil {
+		return "", fmt.Errorf("write signature: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("rewind export: %w", err)
+	}
+	pub, err := sshsig.Verify(sig, exportSignatureNamespace, f)
+	if err != nil {
+		return "", fmt.Errorf("signature self-check failed: %w", err)
+	}
+	return ssh.FingerprintSHA256(pub), nil
+}
+
+// allowedSignersPath resolves the allowed_signers file for import
+// verification: the flag value as given, else import.allowed-signers
+// relative to .beads/, else .beads/allowed_signers.
+func allowedSignersPath(flagValue, beadsDir string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	p := config.GetString("import.allowed-signers")
+	if p == "" {
+		p = "allowed_signers"
+	}
+	if !filepath.IsAbs(p) && beadsDir != "" {
+		p = filepath.Join(beadsDir, p)
+	}
+	return p
+}
+
+// verifyImportFile checks f against path+".sig" and the allowed signers file
+// and returns the trusted principal. f is rewound to the start afterwards.
+func verifyImportFile(f *os.File, path, allowedSigners string) (string, error) {
+	sig, err := os.ReadFile(path + signatureSuffix) //nolint:gosec // G304: next to the import file
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("refusing unsigned file: %s%s not found", path, signatureSuffix)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read signature: %w", err)
+	}
+	sf, err := os.Open(allowedSigners) //nolint:gosec // G304: configured trust file
+	if err != nil {
+		return "", fmt.Errorf("read allowed signers (import.allowed-signers): %w", err)
+	}
+	signers, err := sshsig.ParseAllowedSigners(sf)
+	_ = sf.Close()
+	if err != nil {
+		return "", err
+	}
+
+	pub, err := sshsig.Verify(sig, exportSignatureNamespace, f)
+	if _, seekErr := f.Seek(0, io.SeekStart); seekErr != nil && err == nil {
+		err = fmt.Errorf("rewind after verification: %w", seekErr)
+	}
+	if err != nil {
+		return "", fmt.Errorf("signature check failed for %s (tampered or corrupt): %w", path, err)
+	}
+	principal := sshsig.FindPrincipal(signers, pub, exportSignatureNamespace)
+	if principal == "" {
+		return "", fmt.Errorf("%s is signed by untrusted key %s (not in %s)", path, ssh.FingerprintSHA256(pub), allowedSigners)
+	}
+	return principal, nil
+}
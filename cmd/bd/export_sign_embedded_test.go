@@ -0,0 +1,124 @@
+//go:build cgo
+
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// writeTestSSHKey writes an unencrypted ed25519 private key to path and
+// returns its allowed_signers line for principal.
+func writeTestSSHKey(t *testing.T, path, principal string) string {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return principal + " " + strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub)))
+}
+
+func TestEmbeddedExportSignAndVerify(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "sg")
+	bdCreate(t, bd, dir, "Signed issue")
+
+	keys := t.TempDir()
+	trustedKey := filepath.Join(keys, "trusted")
+	otherKey := filepath.Join(keys, "other")
+	line := writeTestSSHKey(t, trustedKey, "release@example.com")
+	writeTestSSHKey(t, otherKey, "mallory@example.com")
+	if err := os.WriteFile(filepath.Join(beadsDir, "allowed_signers"), []byte(line+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), "SSH_AUTH_SOCK=")
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	export := func(path, key string) {
+		t.Helper()
+		if out, err := run("export", "-o", path, "--sign", "--signing-key", key); err != nil {
+			t.Fatalf("bd export --sign: %v\n%s", err, out)
+		}
+	}
+
+	t.Run("valid signature imports", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl")
+		export(path, trustedKey)
+		if _, err := os.Stat(path + ".sig"); err != nil {
+			t.Fatalf("signature not written: %v", err)
+		}
+		out, err := run("import", "--verify", "--json", path)
+		if err != nil {
+			t.Fatalf("bd import --verify: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, `"signed_by": "release@example.com"`) {
+			t.Errorf("signed_by missing from result:\n%s", out)
+		}
+	})
+
+	t.Run("tampered file refused", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl")
+		export(path, trustedKey)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tampered := strings.Replace(string(data), "Signed issue", "Signed issuE", 1)
+		if err := os.WriteFile(path, []byte(tampered), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := run("import", "--verify", path)
+		if err == nil || !strings.Contains(out, "signature check failed") {
+			t.Errorf("tampered import should fail (%v):\n%s", err, out)
+		}
+	})
+
+	t.Run("unsigned file refused", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl")
+		if out, err := run("export", "-o", path); err != nil {
+			t.Fatalf("bd export: %v\n%s", err, out)
+		}
+		out, err := run("import", "--verify", path)
+		if err == nil || !strings.Contains(out, "refusing unsigned file") {
+			t.Errorf("unsigned import should fail (%v):\n%s", err, out)
+		}
+	})
+
+	t.Run("untrusted key refused", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "issues.jsonl")
+		export(path, otherKey)
+		out, err := run("import", "--verify", path)
+		if err == nil || !strings.Contains(out, "untrusted key") {
+			t.Errorf("untrusted import should fail (%v):\n%s", err, out)
+		}
+	})
+}
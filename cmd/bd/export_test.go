@@ -173,36 +173,41 @@ func TestExportToStdout(t *testing.T) {
 		t.Fatalf("insert issue: %v", err)
 	}
 
-	// Capture stdout
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
 	exportOutput = ""
 	exportAll = false
 	exportIncludeInfra = false
 	exportScrub = false
+	t.Cleanup(func() { exportSchemaHeader = false })
 
-	err := runExport(nil, nil)
+	// exportLines runs the export and returns the lines written to stdout.
+	exportLines := func() []string {
+		t.Helper()
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
 
-	w.Close()
-	os.Stdout = oldStdout
+		err := runExport(nil, nil)
 
-	if err != nil {
-		t.Fatalf("runExport: %v", err)
-	}
+		w.Close()
+		os.Stdout = oldStdout
 
-	// Read captured output
-	scanner := bufio.NewScanner(r)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+		if err != nil {
+			t.Fatalf("runExport: %v", err)
+		}
+
+		scanner := bufio.NewScanner(r)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		return lines
 	}
 
+	// By default every line is an issue, so `bd export | jq .id` works.
+	lines := exportLines()
 	if len(lines) != 1 {
-		t.Fatalf("expected 1 line on stdout, got %d", len(lines))
+		t.Fatalf("expected 1 line on stdout, got %d: %v", len(lines), lines)
 	}
-
 	var issue map[string]interface{}
 	if err := json.Unmarshal([]byte(lines[0]), &issue); err != nil {
 		t.Fatalf("parse stdout line: %v", err)
@@ -210,6 +215,18 @@ func TestExportToStdout(t *testing.T) {
 	if issue["title"] != "Stdout Export" {
 		t.Errorf("expected title 'Stdout Export', got %v", issue["title"])
 	}
+
+	exportSchemaHeader = true
+	lines = exportLines()
+	if len(lines) != 2 {
+		t.Fatalf("expected header + 1 issue line with --schema-header, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], `{"_schema":"beads-jsonl/`) {
+		t.Errorf("expected schema header first, got %s", lines[0])
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &issue); err != nil || issue["id"] != "exp-3" {
+		t.Errorf("expected exp-3 after the header, got %s", lines[1])
+	}
 }
 
 func TestExportScrub(t *testing.T) {
@@ -374,6 +391,9 @@ func TestExportImportRoundTrip(t *testing.T) {
 		if err := json.Unmarshal([]byte(line), &issue); err != nil {
 			t.Fatalf("parse exported JSONL line %d: %v", count, err)
 		}
+		if issue["_schema"] != nil {
+			continue
+		}
 		if issue["id"] == nil || issue["title"] == nil {
 			t.Errorf("line %d missing required fields: %v", count, issue)
 		}
@@ -0,0 +1,91 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedFederatedDependencies(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	remoteURL := "file://" + t.TempDir()
+
+	platformDir, _, _ := bdInit(t, bd, "--prefix", "pf")
+	bdDolt(t, bd, platformDir, "remote", "add", "origin", remoteURL)
+	blocker := bdCreate(t, bd, platformDir, "Platform API")
+	bdDolt(t, bd, platformDir, "commit", "-m", "platform issue")
+	bdDolt(t, bd, platformDir, "push")
+
+	productDir, _, _ := bdInit(t, bd, "--prefix", "pd")
+	bdFederation(t, bd, productDir, "add-peer", "platform", remoteURL)
+	feature := bdCreate(t, bd, productDir, "Product feature")
+	ref := "platform/" + blocker.ID
+
+	if out := bdDepAddFail(t, bd, productDir, feature.ID, "nowhere/"+blocker.ID); !strings.Contains(out, `unknown federation peer "nowhere"`) {
+		t.Errorf("unexpected error for unknown peer:\n%s", out)
+	}
+	bdDepAdd(t, bd, productDir, feature.ID, ref)
+
+	isReady := func() bool {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, productDir, "ready", "--json")
+		if err != nil {
+			t.Fatalf("bd ready failed: %v\n%s", err, out)
+		}
+		return strings.Contains(string(out), feature.ID)
+	}
+
+	t.Run("unfetched_peer_blocks", func(t *testing.T) {
+		if isReady() {
+			t.Error("feature should not be ready before the peer is fetched")
+		}
+	})
+
+	t.Run("open_peer_issue_blocks", func(t *testing.T) {
+		bdFederation(t, bd, productDir, "fetch", "--peer", "platform")
+		if isReady() {
+			t.Error("feature should not be ready while the peer issue is open")
+		}
+		out, err := bdRunWithFlockRetry(t, bd, productDir, "blocked", "--json")
+		if err != nil {
+			t.Fatalf("bd blocked failed: %v\n%s", err, out)
+		}
+		var blocked []*types.BlockedIssue
+		if err := json.Unmarshal(out[strings.Index(string(out), "["):], &blocked); err != nil {
+			t.Fatalf("parse blocked: %v\n%s", err, out)
+		}
+		if len(blocked) != 1 || blocked[0].ID != feature.ID || len(blocked[0].BlockedBy) != 1 || blocked[0].BlockedBy[0] != ref {
+			t.Errorf("blocked = %+v, want %s blocked by %s", blocked, feature.ID, ref)
+		}
+	})
+
+	t.Run("closed_peer_issue_unblocks", func(t *testing.T) {
+		bdClose(t, bd, platformDir, blocker.ID)
+		bdDolt(t, bd, platformDir, "commit", "-m", "close platform issue")
+		bdDolt(t, bd, platformDir, "push")
+		bdFederation(t, bd, productDir, "fetch")
+		if !isReady() {
+			t.Error("feature should be ready once the fetched peer issue is closed")
+		}
+	})
+}
+
+// bdDepAddFail runs "bd dep add" expecting failure.
+func bdDepAddFail(t *testing.T, bd, dir string, args ...string) string {
+	t.Helper()
+	out, err := bdRunWithFlockRetry(t, bd, dir, append([]string{"dep", "add"}, args...)...)
+	if err == nil {
+		t.Fatalf("expected bd dep add %s to fail:\n%s", strings.Join(args, " "), out)
+	}
+	return string(out)
+}
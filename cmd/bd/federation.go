@@ -3,9 +3,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
@@ -14,11 +17,12 @@ import (
 )
 
 var (
-	federationPeer     string
-	federationStrategy string
-	federationUser     string
-	federationPassword string
-	federationSov      string
+	federationPeer      string
+	federationStrategy  string
+	federationUser      string
+	federationPassword  string
+	federationSov       string
+	federationDirection string
 )
 
 var federationCmd = &cobra.Command{
@@ -46,7 +50,12 @@ Handles merge conflicts using the configured strategy:
   --strategy theirs  Accept remote changes on conflict
 
 If no strategy is specified and conflicts occur, the sync will pause
-and report which tables have conflicts for manual resolution.
+and report which tables have conflicts; review and resolve them with
+'bd federation conflicts'.
+
+Pushes withhold the issue types and tables listed in federation.exclude_types
+and federation.exclude_tables, or in federation.peer-filters.<peer> for a
+single peer. Wisps are always withheld unless allow_wisps is set.
 
 Examples:
   bd federation sync                      # Sync with all peers
@@ -55,6 +64,22 @@ Examples:
 	Run: runFederationSync,
 }
 
+var federationFetchCmd = &cobra.Command{
+	Use:   "fetch [--peer name]",
+	Short: "Fetch peer refs without merging",
+	Long: `Fetch from peer towns without merging or pushing.
+
+Dependencies on issues in a peer (bd dep add bd-42 <peer>/<issue-id>) are
+resolved against the peer's last-fetched branch, so fetching refreshes which
+cross-repo blockers are still open without touching local data. Unlike sync,
+fetch works with peers whose history is unrelated to this workspace.
+
+Examples:
+  bd federation fetch                     # Fetch all peers
+  bd federation fetch --peer platform     # Fetch one peer`,
+	Run: runFederationFetch,
+}
+
 var federationStatusCmd = &cobra.Command{
 	Use:   "status [--peer name]",
 	Short: "Show federation sync status",
@@ -85,10 +110,17 @@ Credentials are encrypted and stored locally. They are used automatically
 when syncing with the peer. If --user is provided without --password,
 you will be prompted for the password interactively.
 
+--direction limits which way the peer syncs:
+  bidirectional  Merge from and push to the peer (default)
+  pull-only      Merge from the peer but never push to it, for consuming an
+                 upstream tracker without leaking local noise into it
+  push-only      Push to the peer but never merge from it
+
 Examples:
   bd federation add-peer town-beta dolthub://acme/town-beta-beads
   bd federation add-peer town-gamma 192.168.1.100:3306/beads --user sync-bot
-  bd federation add-peer partner https://partner.example.com/beads --user admin --password secret`,
+  bd federation add-peer partner https://partner.example.com/beads --user admin --password secret
+  bd federation add-peer upstream dolthub://acme/platform-beads --direction pull-only`,
 	Args: cobra.ExactArgs(2),
 	Run:  runFederationAddPeer,
 }
@@ -100,6 +132,25 @@ var federationRemovePeerCmd = &cobra.Command{
 	Run:   runFederationRemovePeer,
 }
 
+var federationSetDirectionCmd = &cobra.Command{
+	Use:   "set-direction <name> <bidirectional|pull-only|push-only>",
+	Short: "Change which way a federation peer syncs",
+	Long: `Change a peer's sync direction, keeping its URL and stored credentials.
+
+  bidirectional  Merge from and push to the peer
+  pull-only      Merge from the peer but never push to it
+  push-only      Push to the peer but never merge from it
+
+Sync, the federation daemon, and direct pushes and pulls to the peer all
+honor the direction.
+
+Examples:
+  bd federation set-direction upstream pull-only
+  bd federation set-direction town-beta bidirectional`,
+	Args: cobra.ExactArgs(2),
+	Run:  runFederationSetDirection,
+}
+
 var federationListPeersCmd = &cobra.Command{
 	Use:   "list-peers",
 	Short: "List configured federation peers",
@@ -109,15 +160,20 @@ var federationListPeersCmd = &cobra.Command{
 func init() {
 	// Add subcommands
 	federationCmd.AddCommand(federationSyncCmd)
+	federationCmd.AddCommand(federationFetchCmd)
 	federationCmd.AddCommand(federationStatusCmd)
 	federationCmd.AddCommand(federationAddPeerCmd)
 	federationCmd.AddCommand(federationRemovePeerCmd)
+	federationCmd.AddCommand(federationSetDirectionCmd)
 	federationCmd.AddCommand(federationListPeersCmd)
 
 	// Flags for sync
 	federationSyncCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to sync with")
 	federationSyncCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs)")
 
+	// Flags for fetch
+	federationFetchCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to fetch from")
+
 	// Flags for status
 	federationStatusCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to check")
 
@@ -125,6 +181,7 @@ func init() {
 	federationAddPeerCmd.Flags().StringVarP(&federationUser, "user", "u", "", "SQL username for authentication")
 	federationAddPeerCmd.Flags().StringVarP(&federationPassword, "password", "p", "", "SQL password (prompted if --user set without --password)")
 	federationAddPeerCmd.Flags().StringVar(&federationSov, "sovereignty", "", "Sovereignty tier (T1, T2, T3, T4)")
+	federationAddPeerCmd.Flags().StringVar(&federationDirection, "direction", "", "Sync direction: bidirectional, pull-only, or push-only (default bidirectional)")
 
 	rootCmd.AddCommand(federationCmd)
 }
@@ -150,29 +207,11 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 	}
 
 	// Get peers to sync with
-	var peers []string
-	if federationPeer != "" {
-		peers = []string{federationPeer}
-	} else {
-		// Get all configured remotes
-		remotes, err := ds.ListRemotes(ctx)
-		if err != nil {
-			FatalErrorRespectJSON("failed to list peers: %v", err)
-		}
-		for _, r := range remotes {
-			// Skip 'origin' which is typically the backup remote, not a peer
-			if r.Name != "origin" {
-				peers = append(peers, r.Name)
-			}
-		}
-	}
-
-	if len(peers) == 0 {
-		FatalErrorRespectJSON("no federation peers configured (use 'bd federation add-peer' to add peers)")
-	}
+	peers := selectFederationPeers(ds)
 
 	// Sync with each peer
 	var results []*storage.SyncResult
+	jsonResults := make([]federationSyncResultJSON, 0, len(peers))
 	for _, peer := range peers {
 		if !jsonOutput {
 			fmt.Printf("%s Syncing with %s...\n", ui.RenderAccent("🔄"), peer)
@@ -180,6 +219,7 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 
 		result, err := ds.Sync(ctx, peer, federationStrategy)
 		results = append(results, result)
+		jsonResults = append(jsonResults, newFederationSyncResultJSON(peer, result, err))
 
 		if err != nil {
 			if !jsonOutput {
@@ -209,10 +249,13 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 					for _, c := range result.Conflicts {
 						fmt.Printf("    - %s\n", c.Field)
 					}
+					fmt.Printf("    Run 'bd federation conflicts' to review and resolve\n")
 				}
 			}
 			if result.Pushed {
 				fmt.Printf("  %s Pushed\n", ui.RenderPass("✓"))
+			} else if result.Direction == storage.PeerDirectionPullOnly {
+				fmt.Printf("  %s Push skipped: peer is pull-only\n", ui.RenderMuted("○"))
 			} else if result.PushError != nil {
 				fmt.Printf("  %s Push skipped: %v\n", ui.RenderMuted("○"), result.PushError)
 			}
@@ -220,10 +263,66 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 	}
 
 	if jsonOutput {
-		outputJSON(map[string]interface{}{
-			"peers":   peers,
-			"results": results,
-		})
+		outputJSON(federationSyncJSON{Peers: peers, Results: jsonResults})
+	}
+}
+
+// selectFederationPeers returns --peer, or every configured remote except
+// origin. It exits when there are none.
+func selectFederationPeers(ds storage.DoltStorage) []string {
+	if federationPeer != "" {
+		return []string{federationPeer}
+	}
+	remotes, err := ds.ListRemotes(rootCtx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to list peers: %v", err)
+	}
+	var peers []string
+	for _, r := range remotes {
+		// Skip 'origin' which is typically the backup remote, not a peer
+		if r.Name != "origin" {
+			peers = append(peers, r.Name)
+		}
+	}
+	if len(peers) == 0 {
+		FatalErrorRespectJSON("no federation peers configured (use 'bd federation add-peer' to add peers)")
+	}
+	return peers
+}
+
+func runFederationFetch(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	results := make([]federationFetchResultJSON, 0)
+	failed := false
+	for _, peer := range selectFederationPeers(ds) {
+		result := federationFetchResultJSON{Peer: peer, Fetched: true}
+		if err := ds.Fetch(ctx, peer); err != nil {
+			result.Fetched = false
+			result.Error = err.Error()
+			failed = true
+		}
+		results = append(results, result)
+
+		if !jsonOutput {
+			if result.Fetched {
+				fmt.Printf("%s Fetched %s\n", ui.RenderPass("✓"), peer)
+			} else {
+				fmt.Printf("%s %s: %s\n", ui.RenderFail("✗"), peer, result.Error)
+			}
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(results)
+	}
+	if failed {
+		os.Exit(1)
 	}
 }
 
@@ -257,10 +356,7 @@ func runFederationStatus(cmd *cobra.Command, args []string) {
 
 	if len(peers) == 0 {
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
-				"peers":          []string{},
-				"pendingChanges": 0,
-			})
+			outputJSON(federationStatusJSON{Peers: []federationPeerStatus{}})
 		} else {
 			fmt.Println("No federation peers configured.")
 		}
@@ -275,16 +371,10 @@ func runFederationStatus(cmd *cobra.Command, args []string) {
 	}
 
 	// Collect status for each peer
-	type peerStatus struct {
-		Status     *storage.SyncStatus
-		URL        string
-		Reachable  bool
-		ReachError string
-	}
-	var peerStatuses []peerStatus
+	var peerStatuses []federationPeerStatus
 
 	for _, peer := range peers {
-		ps := peerStatus{
+		ps := federationPeerStatus{
 			URL: remoteURLs[peer],
 		}
 
@@ -307,10 +397,7 @@ func runFederationStatus(cmd *cobra.Command, args []string) {
 	}
 
 	if jsonOutput {
-		outputJSON(map[string]interface{}{
-			"peers":          peerStatuses,
-			"pendingChanges": pendingChanges,
-		})
+		outputJSON(federationStatusJSON{Peers: peerStatuses, PendingChanges: pendingChanges})
 		return
 	}
 
@@ -347,7 +434,7 @@ func runFederationStatus(cmd *cobra.Command, args []string) {
 
 		// Conflicts
 		if status.HasConflicts {
-			fmt.Printf("    %s Unresolved conflicts\n", ui.RenderWarn("⚠"))
+			fmt.Printf("    %s Unresolved conflicts (see 'bd federation conflicts')\n", ui.RenderWarn("⚠"))
 		}
 		fmt.Println()
 	}
@@ -380,14 +467,20 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// If credentials provided, use AddFederationPeer to store them
-	if federationUser != "" {
+	if err := storage.ValidatePeerDirection(federationDirection); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	// Credentials, sovereignty, and direction live in federation_peers;
+	// a bare peer is just a Dolt remote.
+	if federationUser != "" || sov != "" || federationDirection != "" {
 		peer := &storage.FederationPeer{
 			Name:        name,
 			RemoteURL:   url,
 			Username:    federationUser,
 			Password:    password,
 			Sovereignty: sov,
+			Direction:   federationDirection,
 		}
 		if err := store.AddFederationPeer(ctx, peer); err != nil {
 			FatalErrorRespectJSON("failed to add peer: %v", err)
@@ -400,12 +493,7 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) {
 	}
 
 	if jsonOutput {
-		outputJSON(map[string]interface{}{
-			"added":       name,
-			"url":         url,
-			"has_auth":    federationUser != "",
-			"sovereignty": sov,
-		})
+		outputJSON(federationAddPeerJSON{Added: name, URL: url, HasAuth: federationUser != "", Sovereignty: sov, Direction: federationDirection})
 		return
 	}
 
@@ -416,6 +504,46 @@ func runFederationAddPeer(cmd *cobra.Command, args []string) {
 	if sov != "" {
 		fmt.Printf("  Sovereignty: %s\n", sov)
 	}
+	if federationDirection != "" {
+		fmt.Printf("  Direction: %s\n", federationDirection)
+	}
+}
+
+func runFederationSetDirection(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+	name, direction := args[0], args[1]
+	if err := storage.ValidatePeerDirection(direction); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	peer, err := store.GetFederationPeer(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		// A plain remote: promote it to a federation peer record.
+		remotes, listErr := store.ListRemotes(ctx)
+		if listErr != nil {
+			FatalErrorRespectJSON("failed to list peers: %v", listErr)
+		}
+		for _, r := range remotes {
+			if r.Name == name {
+				peer = &storage.FederationPeer{Name: name, RemoteURL: r.URL}
+			}
+		}
+		if peer == nil {
+			FatalErrorRespectJSON("no federation peer named %s (see 'bd federation list-peers')", name)
+		}
+	} else if err != nil {
+		FatalErrorRespectJSON("failed to get peer: %v", err)
+	}
+	peer.Direction = direction
+	if err := store.AddFederationPeer(ctx, peer); err != nil {
+		FatalErrorRespectJSON("failed to update peer: %v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(federationSetDirectionJSON{Peer: name, Direction: direction})
+		return
+	}
+	fmt.Printf("Peer %s is now %s\n", ui.RenderAccent(name), direction)
 }
 
 func runFederationRemovePeer(cmd *cobra.Command, args []string) {
@@ -428,9 +556,7 @@ func runFederationRemovePeer(cmd *cobra.Command, args []string) {
 	}
 
 	if jsonOutput {
-		outputJSON(map[string]interface{}{
-			"removed": name,
-		})
+		outputJSON(federationRemovePeerJSON{Removed: name})
 		return
 	}
 
@@ -445,8 +571,9 @@ func runFederationListPeers(cmd *cobra.Command, args []string) {
 		FatalErrorRespectJSON("failed to list peers: %v", err)
 	}
 
+	directions := federationPeerDirections(ctx)
 	if jsonOutput {
-		outputJSON(formatFederationPeerListJSON(remotes))
+		outputJSON(formatFederationPeerListJSON(remotes, directions))
 		return
 	}
 
@@ -457,23 +584,161 @@ func runFederationListPeers(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("\n%s Federation Peers:\n\n", ui.RenderAccent("🌐"))
 	for _, r := range remotes {
-		fmt.Printf("  %s  %s\n", ui.RenderAccent(r.Name), ui.RenderMuted(r.URL))
+		line := fmt.Sprintf("  %s  %s", ui.RenderAccent(r.Name), ui.RenderMuted(r.URL))
+		if d := directions[r.Name]; d != "" && d != storage.PeerDirectionBidirectional {
+			line += "  " + ui.RenderWarn(d)
+		}
+		fmt.Println(line)
 	}
 	fmt.Println()
 }
 
+// federationPeerDirections maps peer names to their configured direction.
+// Best effort: peers without a record, or an unreadable table, are omitted.
+func federationPeerDirections(ctx context.Context) map[string]string {
+	directions := map[string]string{}
+	peers, err := store.ListFederationPeers(ctx)
+	if err != nil {
+		return directions
+	}
+	for _, p := range peers {
+		if p.Direction != "" {
+			directions[p.Name] = p.Direction
+		}
+	}
+	return directions
+}
+
 type federationPeerListJSON struct {
-	Name string `json:"Name"`
-	URL  string `json:"URL"`
+	Name      string `json:"Name"`
+	URL       string `json:"URL"`
+	Direction string `json:"Direction"`
 }
 
-func formatFederationPeerListJSON(remotes []storage.RemoteInfo) []federationPeerListJSON {
+func formatFederationPeerListJSON(remotes []storage.RemoteInfo, directions map[string]string) []federationPeerListJSON {
 	out := make([]federationPeerListJSON, 0, len(remotes))
 	for _, r := range remotes {
+		direction := directions[r.Name]
+		if direction == "" {
+			direction = storage.PeerDirectionBidirectional
+		}
 		out = append(out, federationPeerListJSON{
-			Name: r.Name,
-			URL:  r.URL,
+			Name:      r.Name,
+			URL:       r.URL,
+			Direction: direction,
 		})
 	}
 	return out
 }
+
+// federationSyncJSON is the --json output of bd federation sync.
+type federationSyncJSON struct {
+	Peers   []string                   `json:"peers"`
+	Results []federationSyncResultJSON `json:"results"`
+}
+
+// federationSyncResultJSON is storage.SyncResult with its errors as strings;
+// error values marshal to {} and lose the message. Field names match the
+// untagged SyncResult this output used to marshal directly.
+type federationSyncResultJSON struct {
+	Peer              string
+	Direction         string `json:",omitempty"`
+	StartTime         time.Time
+	EndTime           time.Time
+	Fetched           bool
+	Merged            bool
+	Pushed            bool
+	PulledCommits     int
+	PushedCommits     int
+	Conflicts         []storage.Conflict
+	ConflictsResolved bool
+	Error             string `json:",omitempty"`
+	PushError         string `json:",omitempty"`
+}
+
+func newFederationSyncResultJSON(peer string, result *storage.SyncResult, err error) federationSyncResultJSON {
+	out := federationSyncResultJSON{Peer: peer}
+	if result != nil {
+		out = federationSyncResultJSON{
+			Peer:              result.Peer,
+			Direction:         result.Direction,
+			StartTime:         result.StartTime,
+			EndTime:           result.EndTime,
+			Fetched:           result.Fetched,
+			Merged:            result.Merged,
+			Pushed:            result.Pushed,
+			PulledCommits:     result.PulledCommits,
+			PushedCommits:     result.PushedCommits,
+			Conflicts:         result.Conflicts,
+			ConflictsResolved: result.ConflictsResolved,
+		}
+		if result.Error != nil {
+			out.Error = result.Error.Error()
+		}
+		if result.PushError != nil {
+			out.PushError = result.PushError.Error()
+		}
+	}
+	if out.Error == "" && err != nil {
+		out.Error = err.Error()
+	}
+	return out
+}
+
+// federationFetchResultJSON is one peer in the --json output of bd federation fetch.
+type federationFetchResultJSON struct {
+	Peer    string `json:"peer"`
+	Fetched bool   `json:"fetched"`
+	Error   string `json:"error,omitempty"`
+}
+
+// federationStatusJSON is the --json output of bd federation status.
+type federationStatusJSON struct {
+	Peers          []federationPeerStatus `json:"peers"`
+	PendingChanges int                    `json:"pendingChanges"`
+}
+
+// federationPeerStatus is one peer in bd federation status.
+type federationPeerStatus struct {
+	Status     *storage.SyncStatus
+	URL        string
+	Reachable  bool
+	ReachError string
+}
+
+// federationAddPeerJSON is the --json output of bd federation add-peer.
+type federationAddPeerJSON struct {
+	Added       string `json:"added"`
+	URL         string `json:"url"`
+	HasAuth     bool   `json:"has_auth"`
+	Sovereignty string `json:"sovereignty"`
+	Direction   string `json:"direction,omitempty"`
+}
+
+// federationSetDirectionJSON is the --json output of bd federation set-direction.
+type federationSetDirectionJSON struct {
+	Peer      string `json:"peer"`
+	Direction string `json:"direction"`
+}
+
+// federationRemovePeerJSON is the --json output of bd federation remove-peer.
+type federationRemovePeerJSON struct {
+	Removed string `json:"removed"`
+}
+
+// federationOutputSchemas lists the federation --json payloads for bd schema
+// output.
+func federationOutputSchemas() []outputSchema {
+	return []outputSchema{
+		{"federation add-peer", "The added peer", federationAddPeerJSON{}},
+		{"federation conflicts", "Unresolved merge conflicts, and the tables resolved with --ours/--theirs", federationConflictsJSON{}},
+		{"federation discover", "Discovered towns and sources that could not be searched", federationDiscoverJSON{}},
+		{"federation fetch", "Per-peer fetch results", []federationFetchResultJSON{}},
+		{"federation list-peers", "Configured peers", []federationPeerListJSON{}},
+		{"federation query", "Issues from this workspace and each peer matching a query, with their origin", federationQueryJSON{}},
+		{"federation remove-peer", "The removed peer", federationRemovePeerJSON{}},
+		{"federation set-direction", "The peer and its new sync direction", federationSetDirectionJSON{}},
+		{"federation status", "Per-peer sync status and pending local changes", federationStatusJSON{}},
+		{"federation sync", "Per-peer sync results", federationSyncJSON{}},
+	}
+}
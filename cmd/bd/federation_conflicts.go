@@ -0,0 +1,241 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	federationConflictsOurs   bool
+	federationConflictsTheirs bool
+	federationConflictsTable  string
+)
+
+var federationConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List and resolve merge conflicts left by a peer sync",
+	Long: `List the unresolved merge conflicts a peer sync left in the working set,
+grouped by issue, with each conflicting field's local (ours) and peer
+(theirs) value.
+
+Resolve them in one shot with --ours or --theirs. Dolt resolves conflicts a
+table at a time, so the choice applies to every conflicting row in the table;
+use --table to resolve one table and review the rest. The resolution is
+committed and blocked state is recomputed, as 'bd federation sync --strategy'
+does.
+
+Examples:
+  bd federation conflicts                        # Show what conflicts
+  bd federation conflicts --theirs               # Accept the peer's values
+  bd federation conflicts --ours --table labels  # Keep local labels only`,
+	Args: cobra.NoArgs,
+	Run:  runFederationConflicts,
+}
+
+func init() {
+	federationConflictsCmd.Flags().BoolVar(&federationConflictsOurs, "ours", false, "Resolve by keeping local values")
+	federationConflictsCmd.Flags().BoolVar(&federationConflictsTheirs, "theirs", false, "Resolve by accepting the peer's values")
+	federationConflictsCmd.Flags().StringVar(&federationConflictsTable, "table", "", "Only resolve conflicts in this table")
+	federationConflictsCmd.MarkFlagsMutuallyExclusive("ours", "theirs")
+	federationCmd.AddCommand(federationConflictsCmd)
+}
+
+func runFederationConflicts(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	strategy := ""
+	switch {
+	case federationConflictsOurs:
+		strategy = "ours"
+	case federationConflictsTheirs:
+		strategy = "theirs"
+	case federationConflictsTable != "":
+		FatalErrorRespectJSON("--table requires --ours or --theirs")
+	}
+
+	conflicts, err := ds.GetConflictDetails(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to get conflicts: %v", err)
+	}
+
+	if strategy == "" {
+		if jsonOutput {
+			outputJSON(federationConflictsJSON{Conflicts: conflictsToJSON(conflicts)})
+			return
+		}
+		displayFederationConflicts(conflicts)
+		return
+	}
+
+	CheckReadonly("federation conflicts")
+	tables := conflictTables(conflicts)
+	if federationConflictsTable != "" {
+		if !slices.Contains(tables, federationConflictsTable) {
+			FatalErrorRespectJSON("no conflicts in table %q", federationConflictsTable)
+		}
+		tables = []string{federationConflictsTable}
+	}
+	if err := resolveFederationConflicts(ctx, ds, tables, strategy); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	remaining, err := ds.GetConflictDetails(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("conflicts resolved but re-check failed: %v", err)
+	}
+	if jsonOutput {
+		outputJSON(federationConflictsJSON{
+			Conflicts:    conflictsToJSON(remaining),
+			Resolved:     tables,
+			ResolvedWith: strategy,
+		})
+		return
+	}
+	fmt.Printf("\n%s Resolved conflicts in %s using %s strategy\n",
+		ui.RenderPass("✓"), strings.Join(tables, ", "), strategy)
+	if len(remaining) > 0 {
+		displayFederationConflicts(remaining)
+		return
+	}
+	fmt.Println()
+}
+
+// resolveFederationConflicts resolves each table with strategy, commits, and
+// recomputes blocked state. The merge that produced the conflicts skipped the
+// is_blocked recompute, so it covers the whole graph.
+func resolveFederationConflicts(ctx context.Context, ds storage.DoltStorage, tables []string, strategy string) error {
+	for _, table := range tables {
+		if err := ds.ResolveConflicts(ctx, table, strategy); err != nil {
+			return fmt.Errorf("failed to resolve conflicts in %s: %w", table, err)
+		}
+	}
+	if err := ds.Commit(ctx, fmt.Sprintf("Resolve conflicts in %s using %s strategy", strings.Join(tables, ", "), strategy)); err != nil {
+		return fmt.Errorf("conflicts resolved but commit failed: %w", err)
+	}
+	if rs, ok := storage.UnwrapStore(ds).(interface {
+		RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
+	}); ok {
+		if err := rs.RecomputeBlockedAfterMerge(ctx, ""); err != nil {
+			return fmt.Errorf("conflicts resolved but is_blocked recompute failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// conflictTables returns the distinct tables with conflicts, sorted.
+func conflictTables(conflicts []storage.Conflict) []string {
+	seen := make(map[string]bool)
+	var tables []string
+	for _, c := range conflicts {
+		if !seen[c.Table] {
+			seen[c.Table] = true
+			tables = append(tables, c.Table)
+		}
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// groupConflictsByIssue groups conflicts by issue ID, in first-seen order.
+func groupConflictsByIssue(conflicts []storage.Conflict) ([]string, map[string][]storage.Conflict) {
+	var order []string
+	groups := make(map[string][]storage.Conflict)
+	for _, c := range conflicts {
+		if _, ok := groups[c.IssueID]; !ok {
+			order = append(order, c.IssueID)
+		}
+		groups[c.IssueID] = append(groups[c.IssueID], c)
+	}
+	return order, groups
+}
+
+func displayFederationConflicts(conflicts []storage.Conflict) {
+	if len(conflicts) == 0 {
+		fmt.Printf("\n%s No unresolved conflicts\n\n", ui.RenderPass("✓"))
+		return
+	}
+
+	order, groups := groupConflictsByIssue(conflicts)
+	fmt.Printf("\n%s %d conflicts in %d issues:\n\n", ui.RenderWarn("⚠"), len(conflicts), len(order))
+	for _, id := range order {
+		label := id
+		if label == "" {
+			label = "(no issue)"
+		}
+		fmt.Printf("  %s\n", ui.RenderAccent(label))
+		for _, c := range groups[id] {
+			if c.Field == "" {
+				fmt.Printf("    %s row: ours %s, theirs %s\n",
+					ui.RenderMuted(c.Table), formatConflictValue(c.OursValue), formatConflictValue(c.TheirsValue))
+				continue
+			}
+			fmt.Printf("    %s.%s\n", ui.RenderMuted(c.Table), c.Field)
+			fmt.Printf("      ours:   %s\n", formatConflictValue(c.OursValue))
+			fmt.Printf("      theirs: %s\n", formatConflictValue(c.TheirsValue))
+		}
+	}
+	fmt.Printf("\nTables: %s\n", strings.Join(conflictTables(conflicts), ", "))
+	fmt.Printf("Resolve with --ours or --theirs (add --table <name> for one table).\n\n")
+}
+
+// formatConflictValue renders a conflict value on one line.
+func formatConflictValue(v interface{}) string {
+	if v == nil {
+		return ui.RenderMuted("(null)")
+	}
+	s := fmt.Sprint(v)
+	if s == "" {
+		return ui.RenderMuted("(empty)")
+	}
+	s = strings.ReplaceAll(s, "\n", "⏎")
+	const maxLen = 100
+	if r := []rune(s); len(r) > maxLen {
+		s = string(r[:maxLen-3]) + "..."
+	}
+	return s
+}
+
+func conflictsToJSON(conflicts []storage.Conflict) []federationConflictJSON {
+	out := make([]federationConflictJSON, 0, len(conflicts))
+	for _, c := range conflicts {
+		out = append(out, federationConflictJSON{
+			Table:   c.Table,
+			IssueID: c.IssueID,
+			Field:   c.Field,
+			Ours:    c.OursValue,
+			Theirs:  c.TheirsValue,
+		})
+	}
+	return out
+}
+
+// federationConflictsJSON is the --json output of bd federation conflicts.
+type federationConflictsJSON struct {
+	Conflicts    []federationConflictJSON `json:"conflicts"`               // Unresolved conflicts (after resolving, those left)
+	Resolved     []string                 `json:"resolved,omitempty"`      // Tables resolved by --ours/--theirs
+	ResolvedWith string                   `json:"resolved_with,omitempty"` // "ours" or "theirs"
+}
+
+// federationConflictJSON is one conflicting field; Field is empty when the
+// row was deleted on one side, and Ours/Theirs then hold the diff types.
+type federationConflictJSON struct {
+	Table   string      `json:"table"`
+	IssueID string      `json:"issue_id"`
+	Field   string      `json:"field,omitempty"`
+	Ours    interface{} `json:"ours"`
+	Theirs  interface{} `json:"theirs"`
+}
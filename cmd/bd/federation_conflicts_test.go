@@ -0,0 +1,43 @@
+//go:build cgo
+
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestGroupConflicts(t *testing.T) {
+	conflicts := []storage.Conflict{
+		{Table: "issues", IssueID: "bd-2", Field: "title", OursValue: "a", TheirsValue: "b"},
+		{Table: "labels", IssueID: "bd-1", OursValue: "removed", TheirsValue: "modified"},
+		{Table: "issues", IssueID: "bd-2", Field: "priority", OursValue: "1", TheirsValue: "2"},
+	}
+
+	order, groups := groupConflictsByIssue(conflicts)
+	if !slices.Equal(order, []string{"bd-2", "bd-1"}) {
+		t.Errorf("order = %v, want [bd-2 bd-1]", order)
+	}
+	if len(groups["bd-2"]) != 2 || len(groups["bd-1"]) != 1 {
+		t.Errorf("groups = %v", groups)
+	}
+	if got := conflictTables(conflicts); !slices.Equal(got, []string{"issues", "labels"}) {
+		t.Errorf("conflictTables = %v, want [issues labels]", got)
+	}
+}
+
+func TestFormatConflictValue(t *testing.T) {
+	if got := formatConflictValue("line one\nline two"); got != "line one⏎line two" {
+		t.Errorf("multi-line value = %q", got)
+	}
+	long := formatConflictValue(strings.Repeat("x", 150))
+	if len([]rune(long)) != 100 || !strings.HasSuffix(long, "...") {
+		t.Errorf("long value not truncated to 100 runes: %q", long)
+	}
+	if got := formatConflictValue(nil); !strings.Contains(got, "null") {
+		t.Errorf("nil value = %q, want (null)", got)
+	}
+}
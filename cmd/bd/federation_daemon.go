@@ -0,0 +1,443 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/lockfile"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+const (
+	federationDaemonStateFile = "federation-daemon.json"
+	federationDaemonLockFile  = "federation-daemon.lock"
+
+	defaultFederationSyncInterval = 15 * time.Minute
+	defaultFederationMaxBackoff   = time.Hour
+
+	// federationDaemonMaxSleep bounds how long the daemon sleeps between
+	// passes, so added or removed peers are noticed without a restart.
+	federationDaemonMaxSleep = 30 * time.Second
+)
+
+var (
+	federationDaemonOnce    bool
+	federationDaemonTimeout time.Duration
+)
+
+// federationPeerState is the daemon's per-peer scheduling and health record.
+type federationPeerState struct {
+	Peer                string     `json:"peer"`
+	Interval            string     `json:"interval"`
+	LastAttempt         *time.Time `json:"last_attempt,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	NextAttempt         time.Time  `json:"next_attempt"`
+	PendingCommits      int        `json:"pending_commits"`
+	BehindCommits       int        `json:"behind_commits"`
+}
+
+// federationDaemonState is persisted to .beads/federation-daemon.json after
+// every sync attempt so `bd federation daemon status` can report on it.
+type federationDaemonState struct {
+	PID       int                             `json:"pid"`
+	StartedAt time.Time                       `json:"started_at"`
+	UpdatedAt time.Time                       `json:"updated_at"`
+	Running   bool                            `json:"running"`
+	Peers     map[string]*federationPeerState `json:"peers"`
+}
+
+var federationDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Sync each peer on its own schedule",
+	Long: `Run a long-lived loop that syncs every federation peer on its own interval.
+
+Each peer is synced (fetch, merge, push) when its interval elapses. A
+successful sync updates the peer's last_sync. Failed syncs back off
+exponentially, doubling the delay after each consecutive failure up to
+federation.max-backoff, and reset on the next success.
+
+Intervals come from config.yaml:
+  federation.sync-interval     Default interval for all peers (default 15m)
+  federation.peer-intervals    Per-peer overrides, e.g. {town-beta: 5m}
+  federation.max-backoff       Cap on the retry delay (default 1h)
+
+The daemon runs in the foreground; start it under a process supervisor or
+with '&' to keep it in the background. Only one daemon runs per workspace.
+State is written to .beads/federation-daemon.json.
+
+Examples:
+  bd federation daemon                      # Run until interrupted
+  bd federation daemon --strategy theirs    # Auto-resolve conflicts
+  bd federation daemon --once               # Sync due peers once and exit
+  bd federation daemon status               # Per-peer health`,
+	Run: runFederationDaemon,
+}
+
+var federationDaemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show per-peer daemon sync health",
+	Long: `Show what the federation daemon last recorded for each peer.
+
+Displays, per peer:
+  - Last successful sync
+  - Last error and when it happened
+  - Local commits not yet pushed to the peer
+  - When the next attempt is scheduled`,
+	Run: runFederationDaemonStatus,
+}
+
+func init() {
+	federationDaemonCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs)")
+	federationDaemonCmd.Flags().BoolVar(&federationDaemonOnce, "once", false, "Sync all due peers once, then exit")
+	federationDaemonCmd.Flags().DurationVar(&federationDaemonTimeout, "timeout", 10*time.Minute, "Maximum time for a single peer sync")
+	federationDaemonCmd.AddCommand(federationDaemonStatusCmd)
+	federationCmd.AddCommand(federationDaemonCmd)
+}
+
+func runFederationDaemon(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if federationStrategy != "" && federationStrategy != "ours" && federationStrategy != "theirs" {
+		FatalErrorRespectJSON("invalid strategy %q: must be 'ours' or 'theirs'", federationStrategy)
+	}
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("%s", activeWorkspaceNotFoundError())
+	}
+
+	lockPath := filepath.Join(beadsDir, federationDaemonLockFile)
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600) //nolint:gosec // path is constructed internally
+	if err != nil {
+		FatalErrorRespectJSON("failed to open daemon lock: %v", err)
+	}
+	defer lock.Close()
+	if err := lockfile.FlockExclusiveNonBlocking(lock); err != nil {
+		if lockfile.IsLocked(err) {
+			FatalErrorRespectJSON("federation daemon is already running for this workspace")
+		}
+		FatalErrorRespectJSON("failed to lock daemon: %v", err)
+	}
+	defer func() { _ = lockfile.FlockUnlock(lock) }()
+
+	fedCfg := config.GetFederationConfig()
+	maxBackoff := fedCfg.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultFederationMaxBackoff
+	}
+
+	state, err := loadFederationDaemonState(beadsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring unreadable daemon state: %v\n", err)
+	}
+	if state == nil {
+		state = &federationDaemonState{}
+	}
+	state.PID = os.Getpid()
+	state.StartedAt = time.Now()
+	state.Running = true
+	if state.Peers == nil {
+		state.Peers = make(map[string]*federationPeerState)
+	}
+	defer func() {
+		state.Running = false
+		state.UpdatedAt = time.Now()
+		if err := saveFederationDaemonState(beadsDir, state); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save daemon state: %v\n", err)
+		}
+	}()
+
+	if !federationDaemonOnce {
+		fmt.Fprintf(os.Stderr, "%s Federation daemon started (pid %d). Press Ctrl+C to stop.\n",
+			ui.RenderAccent("🌐"), state.PID)
+	}
+
+	warned := make(map[string]bool) // peers whose bad interval override was already reported
+	for {
+		remotes, err := ds.ListRemotes(ctx)
+		if err != nil {
+			logFederationDaemon("failed to list peers: %v", err)
+		} else {
+			var peers []string
+			for _, r := range remotes {
+				// Skip 'origin' which is typically the backup remote, not a peer
+				if r.Name != "origin" {
+					peers = append(peers, r.Name)
+				}
+			}
+			intervals := make(map[string]time.Duration, len(peers))
+			for _, peer := range peers {
+				interval, err := federationPeerInterval(peer, fedCfg)
+				if err != nil && !warned[peer] {
+					logFederationDaemon("%v; using %s", err, interval)
+					warned[peer] = true
+				}
+				intervals[peer] = interval
+			}
+			reconcileFederationPeers(state, intervals)
+
+			for _, peer := range dueFederationPeers(state, time.Now()) {
+				if ctx.Err() != nil {
+					break
+				}
+				ps := state.Peers[peer]
+				syncCtx, cancel := context.WithTimeout(ctx, federationDaemonTimeout)
+				_, syncErr := ds.Sync(syncCtx, peer, federationStrategy)
+				cancel()
+				if ctx.Err() != nil {
+					break
+				}
+				recordFederationSync(ps, time.Now(), intervals[peer], maxBackoff, syncErr)
+				if status, err := ds.SyncStatus(ctx, peer); err == nil && status != nil && status.LocalAhead >= 0 {
+					ps.PendingCommits = status.LocalAhead
+					ps.BehindCommits = status.LocalBehind
+				}
+				if syncErr != nil {
+					logFederationDaemon("%s: sync failed (attempt %d, retry at %s): %v",
+						peer, ps.ConsecutiveFailures, ps.NextAttempt.Format(time.TimeOnly), syncErr)
+				} else {
+					logFederationDaemon("%s: synced (%d pending, next at %s)",
+						peer, ps.PendingCommits, ps.NextAttempt.Format(time.TimeOnly))
+				}
+				state.UpdatedAt = time.Now()
+				if err := saveFederationDaemonState(beadsDir, state); err != nil {
+					logFederationDaemon("failed to save state: %v", err)
+				}
+			}
+		}
+
+		if federationDaemonOnce {
+			return
+		}
+		sleep := federationDaemonMaxSleep
+		if next, ok := nextFederationWake(state); ok {
+			if d := time.Until(next); d < sleep {
+				sleep = max(d, time.Second)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(os.Stderr, "\nFederation daemon stopped.\n")
+			return
+		case <-time.After(sleep):
+		}
+	}
+}
+
+func runFederationDaemonStatus(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		FatalErrorRespectJSON("%s", activeWorkspaceNotFoundError())
+	}
+	state, err := loadFederationDaemonState(beadsDir)
+	if err != nil {
+		FatalErrorRespectJSON("failed to read daemon state: %v", err)
+	}
+	if state == nil {
+		state = &federationDaemonState{Peers: map[string]*federationPeerState{}}
+	}
+	state.Running = federationDaemonRunning(beadsDir)
+
+	// Pending commits are cheap to compute locally; prefer the live value
+	// over whatever the daemon recorded after its last attempt.
+	if ds, err := getFederatedStore(); err == nil {
+		for name, ps := range state.Peers {
+			if status, err := ds.SyncStatus(ctx, name); err == nil && status != nil && status.LocalAhead >= 0 {
+				ps.PendingCommits = status.LocalAhead
+				ps.BehindCommits = status.LocalBehind
+			}
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(state)
+		return
+	}
+
+	fmt.Printf("\n%s Federation Daemon:\n\n", ui.RenderAccent("🌐"))
+	if state.Running {
+		fmt.Printf("  %s Running (pid %d, since %s)\n\n", ui.RenderPass("✓"), state.PID, state.StartedAt.Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Printf("  %s Not running\n\n", ui.RenderMuted("○"))
+	}
+	if len(state.Peers) == 0 {
+		fmt.Println("  No peers synced yet.")
+		fmt.Println()
+		return
+	}
+
+	names := make([]string, 0, len(state.Peers))
+	for name := range state.Peers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ps := state.Peers[name]
+		fmt.Printf("  %s  %s\n", ui.RenderAccent(name), ui.RenderMuted("every "+ps.Interval))
+		if ps.LastSuccess != nil {
+			fmt.Printf("    Last success: %s\n", ps.LastSuccess.Format("2006-01-02 15:04:05"))
+		} else {
+			fmt.Printf("    Last success: %s\n", ui.RenderMuted("never"))
+		}
+		if ps.LastError != "" && ps.LastErrorAt != nil {
+			fmt.Printf("    %s Last error (%s): %s\n", ui.RenderFail("✗"), ps.LastErrorAt.Format("2006-01-02 15:04:05"), ps.LastError)
+			if ps.ConsecutiveFailures > 0 {
+				fmt.Printf("    Failing:      %d consecutive attempts\n", ps.ConsecutiveFailures)
+			}
+		}
+		fmt.Printf("    Pending:      %d commits\n", ps.PendingCommits)
+		if !ps.NextAttempt.IsZero() {
+			fmt.Printf("    Next attempt: %s\n", ps.NextAttempt.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Println()
+	}
+}
+
+func logFederationDaemon(format string, args ...any) {
+	fmt.Printf("%s %s\n", time.Now().Format(time.DateTime), fmt.Sprintf(format, args...))
+}
+
+// federationPeerInterval returns the sync interval for peer: its entry in
+// federation.peer-intervals, else federation.sync-interval, else 15m. An
+// unparseable override falls back to the default and is reported.
+func federationPeerInterval(peer string, cfg config.FederationConfig) (time.Duration, error) {
+	interval := cfg.SyncInterval
+	if interval <= 0 {
+		interval = defaultFederationSyncInterval
+	}
+	raw, ok := cfg.PeerIntervals[peer]
+	if !ok {
+		return interval, nil
+	}
+	override, err := time.ParseDuration(raw)
+	if err != nil || override <= 0 {
+		return interval, fmt.Errorf("invalid federation.peer-intervals.%s %q", peer, raw)
+	}
+	return override, nil
+}
+
+// reconcileFederationPeers adds state for new peers (due immediately),
+// drops peers that are no longer configured, and records each interval.
+func reconcileFederationPeers(state *federationDaemonState, intervals map[string]time.Duration) {
+	for name := range state.Peers {
+		if _, ok := intervals[name]; !ok {
+			delete(state.Peers, name)
+		}
+	}
+	for name, interval := range intervals {
+		ps := state.Peers[name]
+		if ps == nil {
+			ps = &federationPeerState{Peer: name}
+			state.Peers[name] = ps
+		}
+		ps.Interval = interval.String()
+	}
+}
+
+// dueFederationPeers returns the peers whose next attempt is at or before
+// now, sorted by name for a stable sync order.
+func dueFederationPeers(state *federationDaemonState, now time.Time) []string {
+	var due []string
+	for name, ps := range state.Peers {
+		if !ps.NextAttempt.After(now) {
+			due = append(due, name)
+		}
+	}
+	sort.Strings(due)
+	return due
+}
+
+// nextFederationWake returns the earliest scheduled attempt across peers.
+func nextFederationWake(state *federationDaemonState) (time.Time, bool) {
+	var next time.Time
+	for _, ps := range state.Peers {
+		if next.IsZero() || ps.NextAttempt.Before(next) {
+			next = ps.NextAttempt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// recordFederationSync updates a peer's record after a sync attempt and
+// schedules the next one.
+func recordFederationSync(ps *federationPeerState, now time.Time, interval, maxBackoff time.Duration, syncErr error) {
+	ps.LastAttempt = &now
+	if syncErr == nil {
+		ps.LastSuccess = &now
+		ps.ConsecutiveFailures = 0
+	} else {
+		ps.LastError = syncErr.Error()
+		ps.LastErrorAt = &now
+		ps.ConsecutiveFailures++
+	}
+	ps.NextAttempt = now.Add(federationRetryDelay(interval, maxBackoff, ps.ConsecutiveFailures))
+}
+
+// federationRetryDelay is interval after a success, and interval doubled per
+// consecutive failure, capped at maxBackoff, after failures. The delay never
+// drops below interval, even when maxBackoff is smaller.
+func federationRetryDelay(interval, maxBackoff time.Duration, failures int) time.Duration {
+	delay := interval
+	for i := 0; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = max(maxBackoff, interval)
+	}
+	return delay
+}
+
+func federationDaemonRunning(beadsDir string) bool {
+	f, err := os.OpenFile(filepath.Join(beadsDir, federationDaemonLockFile), os.O_RDWR, 0600) //nolint:gosec // path is constructed internally
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	if err := lockfile.FlockExclusiveNonBlocking(f); err != nil {
+		return lockfile.IsLocked(err)
+	}
+	_ = lockfile.FlockUnlock(f)
+	return false
+}
+
+func loadFederationDaemonState(beadsDir string) (*federationDaemonState, error) {
+	data, err := os.ReadFile(filepath.Join(beadsDir, federationDaemonStateFile)) //nolint:gosec // path is constructed internally
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state federationDaemonState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func saveFederationDaemonState(beadsDir string, state *federationDaemonState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(filepath.Join(beadsDir, federationDaemonStateFile), data)
+}
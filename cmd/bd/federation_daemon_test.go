@@ -0,0 +1,108 @@
+//go:build cgo
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestFederationRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		maxBackoff time.Duration
+		failures   int
+		want       time.Duration
+	}{
+		{"success uses interval", 5 * time.Minute, time.Hour, 0, 5 * time.Minute},
+		{"first failure doubles", 5 * time.Minute, time.Hour, 1, 10 * time.Minute},
+		{"third failure", 5 * time.Minute, time.Hour, 3, 40 * time.Minute},
+		{"capped at max backoff", 5 * time.Minute, time.Hour, 10, time.Hour},
+		{"interval above cap wins", 2 * time.Hour, time.Hour, 3, 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := federationRetryDelay(tt.interval, tt.maxBackoff, tt.failures); got != tt.want {
+				t.Errorf("federationRetryDelay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordFederationSync(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	ps := &federationPeerState{Peer: "town-beta"}
+
+	recordFederationSync(ps, now, 10*time.Minute, time.Hour, errors.New("fetch failed"))
+	recordFederationSync(ps, now, 10*time.Minute, time.Hour, errors.New("fetch failed"))
+	if ps.ConsecutiveFailures != 2 || ps.LastSuccess != nil {
+		t.Fatalf("after failures: %+v", ps)
+	}
+	if want := now.Add(40 * time.Minute); !ps.NextAttempt.Equal(want) {
+		t.Errorf("NextAttempt = %s, want %s", ps.NextAttempt, want)
+	}
+
+	later := now.Add(time.Hour)
+	recordFederationSync(ps, later, 10*time.Minute, time.Hour, nil)
+	if ps.ConsecutiveFailures != 0 || ps.LastSuccess == nil || !ps.LastSuccess.Equal(later) {
+		t.Fatalf("after success: %+v", ps)
+	}
+	if ps.LastError != "fetch failed" {
+		t.Errorf("LastError = %q, want previous error kept for status", ps.LastError)
+	}
+	if want := later.Add(10 * time.Minute); !ps.NextAttempt.Equal(want) {
+		t.Errorf("NextAttempt = %s, want %s", ps.NextAttempt, want)
+	}
+}
+
+func TestFederationPeerInterval(t *testing.T) {
+	cfg := config.FederationConfig{
+		SyncInterval:  20 * time.Minute,
+		PeerIntervals: map[string]string{"fast": "2m", "broken": "soon"},
+	}
+	if got, err := federationPeerInterval("fast", cfg); err != nil || got != 2*time.Minute {
+		t.Errorf("fast = %s, %v", got, err)
+	}
+	if got, err := federationPeerInterval("other", cfg); err != nil || got != 20*time.Minute {
+		t.Errorf("other = %s, %v", got, err)
+	}
+	if got, err := federationPeerInterval("broken", cfg); err == nil || got != 20*time.Minute {
+		t.Errorf("broken = %s, %v; want default with error", got, err)
+	}
+	if got, _ := federationPeerInterval("other", config.FederationConfig{}); got != defaultFederationSyncInterval {
+		t.Errorf("unset = %s, want %s", got, defaultFederationSyncInterval)
+	}
+}
+
+func TestFederationDaemonScheduling(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	state := &federationDaemonState{Peers: map[string]*federationPeerState{
+		"gone":  {Peer: "gone"},
+		"later": {Peer: "later", NextAttempt: now.Add(5 * time.Minute)},
+	}}
+	reconcileFederationPeers(state, map[string]time.Duration{
+		"later": 10 * time.Minute,
+		"new":   time.Minute,
+	})
+	if _, ok := state.Peers["gone"]; ok {
+		t.Error("removed peer should be dropped")
+	}
+	if state.Peers["later"].Interval != "10m0s" {
+		t.Errorf("interval = %q", state.Peers["later"].Interval)
+	}
+
+	due := dueFederationPeers(state, now)
+	if len(due) != 1 || due[0] != "new" {
+		t.Fatalf("due = %v, want [new]", due)
+	}
+
+	state.Peers["new"].NextAttempt = now.Add(time.Minute)
+	next, ok := nextFederationWake(state)
+	if !ok || !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("nextFederationWake = %s, %v", next, ok)
+	}
+}
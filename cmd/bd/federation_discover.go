@@ -0,0 +1,242 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/peerdiscovery"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	federationRegistries      []string
+	federationNoMDNS          bool
+	federationDiscoverTimeout time.Duration
+	federationDiscoverAdd     bool
+)
+
+var federationDiscoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find peer towns on the local network and in registries",
+	Long: `Find peer towns without copying URLs by hand.
+
+Towns are found two ways:
+  - mDNS: towns running 'bd federation advertise' on the local network
+  - Registries: static YAML/JSON files or http(s) URLs listing towns
+
+A registry looks like:
+
+  peers:
+    - name: town-beta
+      url: 192.168.1.100:8080/beads
+      sovereignty: T2
+
+Registries come from --registry, or from federation.registry in config.yaml
+when the flag is not given. A town found in several places is reported once,
+registries first.
+
+With --add, towns that are not configured yet are added as peers with their
+name, URL, and sovereignty. Add credentials afterwards with
+'bd federation add-peer <name> <url> --user <user>'.
+
+Examples:
+  bd federation discover                              # mDNS and configured registries
+  bd federation discover --registry towns.yaml --no-mdns
+  bd federation discover --registry https://example.com/towns.yaml --add`,
+	Args: cobra.NoArgs,
+	Run:  runFederationDiscover,
+}
+
+var federationAdvertiseCmd = &cobra.Command{
+	Use:   "advertise <name> <url>",
+	Short: "Advertise this town on the local network",
+	Long: `Answer mDNS queries from 'bd federation discover' with this town's name,
+URL, and sovereignty, until interrupted.
+
+The URL is the one peers should use to reach this town, typically the
+remotesapi address (host:8080/database). Sovereignty defaults to
+federation.sovereignty.
+
+Examples:
+  bd federation advertise town-alpha 192.168.1.10:8080/beads
+  bd federation advertise town-alpha 192.168.1.10:8080/beads --sovereignty T2`,
+	Args: cobra.ExactArgs(2),
+	Run:  runFederationAdvertise,
+}
+
+func init() {
+	federationDiscoverCmd.Flags().StringSliceVar(&federationRegistries, "registry", nil, "Registry file or http(s) URL (repeatable; default federation.registry)")
+	federationDiscoverCmd.Flags().BoolVar(&federationNoMDNS, "no-mdns", false, "Skip mDNS discovery on the local network")
+	federationDiscoverCmd.Flags().DurationVar(&federationDiscoverTimeout, "timeout", 3*time.Second, "How long to wait for mDNS answers")
+	federationDiscoverCmd.Flags().BoolVar(&federationDiscoverAdd, "add", false, "Add discovered towns that are not configured yet")
+	federationAdvertiseCmd.Flags().StringVar(&federationSov, "sovereignty", "", "Sovereignty tier (T1, T2, T3, T4; default federation.sovereignty)")
+	federationCmd.AddCommand(federationDiscoverCmd)
+	federationCmd.AddCommand(federationAdvertiseCmd)
+}
+
+func runFederationDiscover(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if federationDiscoverAdd {
+		CheckReadonly("federation discover --add")
+	}
+
+	registries := federationRegistries
+	if !cmd.Flags().Changed("registry") {
+		registries = config.GetStringSlice("federation.registry")
+	}
+	if len(registries) == 0 && federationNoMDNS {
+		FatalErrorRespectJSON("nothing to search: pass --registry or drop --no-mdns")
+	}
+
+	var found []peerdiscovery.Peer
+	var sourceErrors []string
+	for _, src := range registries {
+		peers, err := peerdiscovery.LoadRegistry(ctx, src)
+		if err != nil {
+			sourceErrors = append(sourceErrors, err.Error())
+			continue
+		}
+		found = append(found, peers...)
+	}
+	if !federationNoMDNS {
+		peers, err := peerdiscovery.Browse(ctx, federationDiscoverTimeout)
+		if err != nil {
+			sourceErrors = append(sourceErrors, err.Error())
+		}
+		found = append(found, peers...)
+	}
+
+	remotes, err := ds.ListRemotes(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to list peers: %v", err)
+	}
+	results := classifyDiscoveredPeers(found, remotes)
+
+	if federationDiscoverAdd {
+		for i := range results {
+			r := &results[i]
+			if r.Configured {
+				continue
+			}
+			if !config.IsValidSovereignty(r.Sovereignty) {
+				r.Error = fmt.Sprintf("invalid sovereignty tier %q", r.Sovereignty)
+				continue
+			}
+			peer := &storage.FederationPeer{Name: r.Name, RemoteURL: r.URL, Sovereignty: r.Sovereignty}
+			if err := ds.AddFederationPeer(ctx, peer); err != nil {
+				r.Error = err.Error()
+				continue
+			}
+			r.Added = true
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(federationDiscoverJSON{Peers: results, Errors: sourceErrors})
+		return
+	}
+	displayDiscoveredPeers(results, sourceErrors)
+}
+
+// classifyDiscoveredPeers drops repeat sightings of a town (by name, first
+// wins) and marks towns that match a configured remote by name or URL.
+func classifyDiscoveredPeers(found []peerdiscovery.Peer, remotes []storage.RemoteInfo) []federationDiscoveredPeer {
+	names := make(map[string]bool, len(remotes))
+	urls := make(map[string]bool, len(remotes))
+	for _, r := range remotes {
+		names[r.Name] = true
+		urls[r.URL] = true
+	}
+	results := []federationDiscoveredPeer{}
+	seen := make(map[string]bool)
+	for _, p := range found {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		results = append(results, federationDiscoveredPeer{
+			Peer:       p,
+			Configured: names[p.Name] || urls[p.URL],
+		})
+	}
+	return results
+}
+
+func displayDiscoveredPeers(results []federationDiscoveredPeer, sourceErrors []string) {
+	for _, e := range sourceErrors {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.RenderWarn("⚠"), e)
+	}
+	if len(results) == 0 {
+		fmt.Printf("\n%s No towns found\n\n", ui.RenderMuted("○"))
+		return
+	}
+
+	fmt.Printf("\n%s Discovered towns:\n\n", ui.RenderAccent("🌐"))
+	var fresh int
+	for _, r := range results {
+		line := fmt.Sprintf("  %s  %s", ui.RenderAccent(r.Name), r.URL)
+		if r.Sovereignty != "" {
+			line += "  " + r.Sovereignty
+		}
+		line += "  " + ui.RenderMuted("("+r.Source+")")
+		switch {
+		case r.Added:
+			line += "  " + ui.RenderPass("added")
+		case r.Error != "":
+			line += "  " + ui.RenderFail("not added: "+r.Error)
+		case r.Configured:
+			line += "  " + ui.RenderMuted("configured")
+		default:
+			fresh++
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	if fresh > 0 {
+		fmt.Printf("Run with --add to add %d new town(s) as peers.\n\n", fresh)
+	}
+}
+
+func runFederationAdvertise(cmd *cobra.Command, args []string) {
+	sov := strings.ToUpper(strings.TrimSpace(federationSov))
+	if sov == "" {
+		sov = string(config.GetSovereignty())
+	}
+	if !config.IsValidSovereignty(sov) {
+		FatalErrorRespectJSON("invalid sovereignty tier: %s (must be T1, T2, T3, or T4)", federationSov)
+	}
+	self := peerdiscovery.Peer{Name: args[0], URL: args[1], Sovereignty: sov}
+
+	ctx := rootCtx
+	fmt.Printf("%s Advertising %s (%s) on the local network; Ctrl+C to stop\n",
+		ui.RenderAccent("📡"), ui.RenderAccent(self.Name), self.URL)
+	if err := peerdiscovery.Advertise(ctx, self); err != nil && ctx.Err() == nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+}
+
+// federationDiscoverJSON is the --json output of bd federation discover.
+type federationDiscoverJSON struct {
+	Peers  []federationDiscoveredPeer `json:"peers"`
+	Errors []string                   `json:"errors,omitempty"` // registries or mDNS that could not be searched
+}
+
+// federationDiscoveredPeer is one town reported by bd federation discover.
+type federationDiscoveredPeer struct {
+	peerdiscovery.Peer
+	Configured bool   `json:"configured"`
+	Added      bool   `json:"added,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
@@ -0,0 +1,44 @@
+//go:build cgo
+
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/peerdiscovery"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestClassifyDiscoveredPeers(t *testing.T) {
+	found := []peerdiscovery.Peer{
+		{Name: "town-beta", URL: "10.0.0.2:8080/beads", Source: "towns.yaml"},
+		{Name: "town-gamma", URL: "10.0.0.3:8080/beads", Source: "towns.yaml"},
+		{Name: "town-beta", URL: "10.0.0.9:8080/beads", Source: peerdiscovery.SourceMDNS},
+		{Name: "gamma-renamed", URL: "10.0.0.4:8080/beads", Source: peerdiscovery.SourceMDNS},
+		{Name: "town-delta", URL: "10.0.0.5:8080/beads", Source: peerdiscovery.SourceMDNS},
+	}
+	remotes := []storage.RemoteInfo{
+		{Name: "town-gamma", URL: "10.0.0.3:8080/beads"},
+		{Name: "old-name", URL: "10.0.0.4:8080/beads"},
+	}
+
+	got := classifyDiscoveredPeers(found, remotes)
+	want := []struct {
+		name       string
+		source     string
+		configured bool
+	}{
+		{"town-beta", "towns.yaml", false},
+		{"town-gamma", "towns.yaml", true},
+		{"gamma-renamed", peerdiscovery.SourceMDNS, true}, // same URL as a configured remote
+		{"town-delta", peerdiscovery.SourceMDNS, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d peers, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || got[i].Source != w.source || got[i].Configured != w.configured {
+			t.Errorf("peer %d = %+v, want %+v", i, got[i], w)
+		}
+	}
+}
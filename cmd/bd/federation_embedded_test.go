@@ -46,7 +46,7 @@ func TestFormatFederationPeerListJSONPreservesLegacyKeys(t *testing.T) {
 	formatted := formatFederationPeerListJSON([]storage.RemoteInfo{{
 		Name: "town-beta",
 		URL:  "file:///tmp/town-beta",
-	}})
+	}}, nil)
 
 	raw, err := json.Marshal(formatted)
 	if err != nil {
@@ -59,6 +59,9 @@ func TestFormatFederationPeerListJSONPreservesLegacyKeys(t *testing.T) {
 	if strings.Contains(body, `"name"`) || strings.Contains(body, `"url"`) {
 		t.Fatalf("formatted JSON should not expose lowercase RemoteInfo storage tags, got %s", body)
 	}
+	if !strings.Contains(body, `"Direction":"bidirectional"`) {
+		t.Fatalf("peers without a direction should list as bidirectional, got %s", body)
+	}
 }
 
 func TestEmbeddedFederation(t *testing.T) {
@@ -149,6 +152,36 @@ func TestEmbeddedFederation(t *testing.T) {
 		}
 	})
 
+	t.Run("peer_direction", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "fddir")
+
+		out := bdFederationFail(t, bd, dir, "add-peer", "bad-dir", "file:///tmp/bad-dir", "--direction", "sideways")
+		if !strings.Contains(out, "invalid peer direction") {
+			t.Errorf("expected invalid direction error, got: %s", out)
+		}
+
+		bdFederation(t, bd, dir, "add-peer", "upstream", "file:///tmp/upstream", "--direction", "pull-only")
+		bdFederation(t, bd, dir, "add-peer", "plain", "file:///tmp/plain")
+		bdFederation(t, bd, dir, "set-direction", "plain", "push-only")
+
+		var peers []federationPeerListJSON
+		if err := json.Unmarshal([]byte(bdFederation(t, bd, dir, "list-peers", "--json")), &peers); err != nil {
+			t.Fatalf("failed to parse JSON: %v", err)
+		}
+		got := map[string]string{}
+		for _, p := range peers {
+			got[p.Name] = p.Direction
+		}
+		if got["upstream"] != "pull-only" || got["plain"] != "push-only" {
+			t.Errorf("directions = %v, want upstream pull-only and plain push-only", got)
+		}
+
+		out = bdFederationFail(t, bd, dir, "set-direction", "missing", "pull-only")
+		if !strings.Contains(out, "no federation peer named missing") {
+			t.Errorf("expected unknown peer error, got: %s", out)
+		}
+	})
+
 	t.Run("remove_peer", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "fdrm")
 
@@ -32,3 +32,6 @@ each maintaining their own Dolt database while sharing updates via remotes.`,
 func init() {
 	rootCmd.AddCommand(federationCmd)
 }
+
+// federationOutputSchemas is empty without CGO: federation has no --json output.
+func federationOutputSchemas() []outputSchema { return nil }
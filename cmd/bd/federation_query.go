@@ -0,0 +1,232 @@
+//go:build cgo
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// federationLocalOrigin is the origin reported for this workspace's issues.
+const federationLocalOrigin = "local"
+
+var (
+	federationQueryFetch   bool
+	federationQueryNoLocal bool
+	federationQueryAll     bool
+	federationQueryLimit   int
+	federationQuerySort    string
+)
+
+var federationQueryCmd = &cobra.Command{
+	Use:   "query <expression>",
+	Short: "Query issues across this workspace and every peer",
+	Long: `Run a 'bd query' expression against this workspace and every federation
+peer, and merge the results with the town each issue came from.
+
+Peers are read from their last-fetched branch, the same cached copy that
+resolves cross-town dependencies, so the query works offline and never
+touches a peer's database. --fetch refreshes each peer first; a peer that
+cannot be fetched is still queried from its cached copy and reported as
+stale. Peers that have never been fetched are reported and skipped.
+
+The expression syntax is the one 'bd query' uses (see 'bd query --help').
+As there, closed issues are left out unless the expression filters on
+status or --all is given. Wisps are never included.
+
+Examples:
+  bd federation query "status=blocked"
+  bd federation query "priority<=1 AND type=bug" --fetch
+  bd federation query "label=release-blocker" --peer town-beta
+  bd federation query "assignee=none" --no-local --json`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runFederationQuery,
+}
+
+func init() {
+	federationQueryCmd.Flags().StringVar(&federationPeer, "peer", "", "Query only this peer (plus local unless --no-local)")
+	federationQueryCmd.Flags().BoolVar(&federationQueryFetch, "fetch", false, "Fetch each peer before querying instead of using its cached copy")
+	federationQueryCmd.Flags().BoolVar(&federationQueryNoLocal, "no-local", false, "Leave out this workspace's own issues")
+	federationQueryCmd.Flags().BoolVarP(&federationQueryAll, "all", "a", false, "Include closed issues")
+	federationQueryCmd.Flags().IntVarP(&federationQueryLimit, "limit", "n", 100, "Limit merged results (0 = unlimited)")
+	federationQueryCmd.Flags().StringVar(&federationQuerySort, "sort", "priority", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	federationCmd.AddCommand(federationQueryCmd)
+}
+
+// federationQueryHit is one merged result.
+type federationQueryHit struct {
+	Origin string       `json:"origin"`
+	Issue  *types.Issue `json:"issue"`
+}
+
+// federationQuerySource reports how one town was queried.
+type federationQuerySource struct {
+	Origin string `json:"origin"`
+	Ref    string `json:"ref,omitempty"` // empty for local
+	Count  int    `json:"count"`
+	Stale  bool   `json:"stale,omitempty"` // --fetch failed; results are from the cached copy
+	Error  string `json:"error,omitempty"`
+}
+
+// federationQueryJSON is the --json output of bd federation query.
+type federationQueryJSON struct {
+	Query     string                  `json:"query"`
+	Sources   []federationQuerySource `json:"sources"`
+	Results   []federationQueryHit    `json:"results"`
+	Truncated bool                    `json:"truncated,omitempty"`
+}
+
+func runFederationQuery(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+	queryStr := strings.Join(args, " ")
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	node, err := query.Parse(queryStr)
+	if err != nil {
+		FatalErrorRespectJSON("parsing query: %v", err)
+	}
+	result, err := query.NewEvaluator(time.Now()).Evaluate(node)
+	if err != nil {
+		FatalErrorRespectJSON("evaluating query: %v", err)
+	}
+	filter := result.Filter
+	if !federationQueryAll && filter.Status == nil && !hasExplicitStatusFilter(node) {
+		filter.ExcludeStatus = append(filter.ExcludeStatus, types.StatusClosed)
+	}
+	persistentOnly := false
+	filter.Ephemeral = &persistentOnly
+	// Limits apply to the merged list; per-source limits would drop
+	// high-priority issues from a town whose rows sort late.
+	filter.Limit = 0
+
+	var peers []string
+	if federationPeer != "" || federationQueryNoLocal {
+		peers = selectFederationPeers(ds)
+	} else if remotes, err := ds.ListRemotes(ctx); err != nil {
+		FatalErrorRespectJSON("failed to list peers: %v", err)
+	} else {
+		for _, r := range remotes {
+			if r.Name != "origin" {
+				peers = append(peers, r.Name)
+			}
+		}
+	}
+	branch, err := ds.CurrentBranch(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to get current branch: %v", err)
+	}
+
+	var sources []federationQuerySource
+	var hits []federationQueryHit
+	collect := func(src federationQuerySource, issues []*types.Issue) federationQuerySource {
+		for _, issue := range issues {
+			if result.Predicate != nil && !result.Predicate(issue) {
+				continue
+			}
+			hits = append(hits, federationQueryHit{Origin: src.Origin, Issue: issue})
+			src.Count++
+		}
+		return src
+	}
+
+	if !federationQueryNoLocal {
+		src := federationQuerySource{Origin: federationLocalOrigin}
+		issues, err := ds.SearchIssues(ctx, "", filter)
+		if err != nil {
+			src.Error = err.Error()
+		}
+		sources = append(sources, collect(src, issues))
+	}
+	for _, peer := range peers {
+		src := federationQuerySource{Origin: peer, Ref: peer + "/" + branch}
+		if federationQueryFetch {
+			if err := ds.Fetch(ctx, peer); err != nil {
+				src.Stale = true
+				src.Error = err.Error()
+			}
+		}
+		issues, err := ds.SearchIssuesAsOf(ctx, src.Ref, "", filter)
+		if err != nil {
+			// No remote-tracking branch means the peer was never fetched.
+			src.Stale = false
+			src.Error = fmt.Sprintf("no cached copy of %s (run 'bd federation fetch --peer %s'): %v", peer, peer, err)
+		}
+		sources = append(sources, collect(src, issues))
+	}
+
+	sortFederationQueryHits(hits, federationQuerySort)
+	truncated := federationQueryLimit > 0 && len(hits) > federationQueryLimit
+	if truncated {
+		hits = hits[:federationQueryLimit]
+	}
+
+	if jsonOutput {
+		if hits == nil {
+			hits = []federationQueryHit{}
+		}
+		outputJSON(federationQueryJSON{Query: queryStr, Sources: sources, Results: hits, Truncated: truncated})
+		return
+	}
+
+	for _, src := range sources {
+		switch {
+		case src.Error != "" && src.Stale:
+			fmt.Fprintf(os.Stderr, "%s %s: fetch failed, using cached copy: %s\n", ui.RenderWarn("⚠"), src.Origin, src.Error)
+		case src.Error != "":
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", ui.RenderFail("✗"), src.Origin, src.Error)
+		}
+	}
+	if len(hits) == 0 {
+		fmt.Printf("No issues found matching query: %s\n", queryStr)
+		return
+	}
+	width := 0
+	for _, h := range hits {
+		width = max(width, len(h.Origin))
+	}
+	fmt.Printf("Found %d issues in %d towns:\n", len(hits), countFederationQueryOrigins(hits))
+	var buf strings.Builder
+	for _, h := range hits {
+		buf.WriteString(ui.RenderAccent(fmt.Sprintf("%-*s", width, h.Origin)))
+		buf.WriteString("  ")
+		formatQueryIssue(&buf, h.Issue)
+	}
+	fmt.Print(buf.String())
+	if truncated {
+		fmt.Printf("%s\n", ui.RenderMuted(fmt.Sprintf("Showing first %d results; use --limit 0 for all", federationQueryLimit)))
+	}
+}
+
+// sortFederationQueryHits orders merged results by sortBy, breaking ties by
+// origin and ID so output is stable across runs.
+func sortFederationQueryHits(hits []federationQueryHit, sortBy string) {
+	slices.SortStableFunc(hits, func(a, b federationQueryHit) int {
+		if sortBy != "" {
+			if r := compareIssuesBy(a.Issue, b.Issue, sortBy); r != 0 {
+				return r
+			}
+		}
+		return cmp.Or(cmp.Compare(a.Origin, b.Origin), cmp.Compare(a.Issue.ID, b.Issue.ID))
+	})
+}
+
+func countFederationQueryOrigins(hits []federationQueryHit) int {
+	seen := map[string]bool{}
+	for _, h := range hits {
+		seen[h.Origin] = true
+	}
+	return len(seen)
+}
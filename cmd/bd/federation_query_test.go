@@ -0,0 +1,30 @@
+//go:build cgo
+
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSortFederationQueryHits(t *testing.T) {
+	hits := []federationQueryHit{
+		{Origin: "town-beta", Issue: &types.Issue{ID: "tb-2", Priority: 1}},
+		{Origin: federationLocalOrigin, Issue: &types.Issue{ID: "bd-9", Priority: 2}},
+		{Origin: "town-beta", Issue: &types.Issue{ID: "tb-1", Priority: 0}},
+		{Origin: federationLocalOrigin, Issue: &types.Issue{ID: "bd-3", Priority: 1}},
+		{Origin: "town-alpha", Issue: &types.Issue{ID: "ta-1", Priority: 1}},
+	}
+	sortFederationQueryHits(hits, "priority")
+
+	want := []string{"town-beta/tb-1", "local/bd-3", "town-alpha/ta-1", "town-beta/tb-2", "local/bd-9"}
+	for i, h := range hits {
+		if got := h.Origin + "/" + h.Issue.ID; got != want[i] {
+			t.Errorf("hit %d = %s, want %s", i, got, want[i])
+		}
+	}
+	if n := countFederationQueryOrigins(hits); n != 3 {
+		t.Errorf("countFederationQueryOrigins = %d, want 3", n)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// formatFlagHelp is the --format help text shared by show, ready, and blocked.
+const formatFlagHelp = "Go template rendered once per issue, e.g. '{{.ID}}\\t{{.Title}}\\t{{.Priority}}' (\"json\" is an alias for --json)"
+
+// formatTemplateFuncs are the functions available to --format templates in
+// addition to the text/template builtins.
+var formatTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// formatTemplateEscapes expands the escapes a shell leaves literal inside
+// single quotes, so '{{.ID}}\t{{.Title}}' produces a tab.
+var formatTemplateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// outputFormatFlag returns a command's --format value. "json" is the
+// --format json alias for --json (GH#2612): it sets jsonOutput and returns "".
+func outputFormatFlag(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	if strings.EqualFold(format, "json") {
+		jsonOutput = true
+		return ""
+	}
+	return format
+}
+
+// parseFormatTemplate parses a --format template.
+func parseFormatTemplate(format string) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(formatTemplateFuncs).Parse(formatTemplateEscapes.Replace(format))
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeFormatTemplate renders tmpl once per item, one item per line. Output
+// is buffered so a template error part way through prints nothing.
+func writeFormatTemplate[T any](w io.Writer, tmpl *template.Template, items []T) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		start := buf.Len()
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("template execution error: %w", err)
+		}
+		if buf.Len() == start || buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// renderFormatTemplate parses format and writes items with it.
+func renderFormatTemplate[T any](w io.Writer, format string, items []T) error {
+	tmpl, err := parseFormatTemplate(format)
+	if err != nil {
+		return err
+	}
+	return writeFormatTemplate(w, tmpl, items)
+}
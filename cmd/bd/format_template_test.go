@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRenderFormatTemplate(t *testing.T) {
+	t.Parallel()
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "First", Priority: 1, Labels: []string{"a", "b"}},
+		{ID: "bd-2", Title: "Second", Priority: 3},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"shell escapes", `{{.ID}}\t{{.Title}}\t{{.Priority}}`, "bd-1\tFirst\t1\nbd-2\tSecond\t3\n"},
+		{"trailing newline not doubled", "{{.ID}}\n", "bd-1\nbd-2\n"},
+		{"join", `{{.ID}}={{join .Labels ","}}`, "bd-1=a,b\nbd-2=\n"},
+		{"json", `{{json .Labels}}`, "[\"a\",\"b\"]\nnull\n"},
+		{"empty render keeps line", `{{if .Labels}}{{.ID}}{{end}}`, "bd-1\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderFormatTemplate(&buf, tt.format, issues); err != nil {
+				t.Fatalf("renderFormatTemplate: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFormatTemplateErrors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := renderFormatTemplate(&buf, "{{.ID", []*types.Issue{{ID: "bd-1"}}); err == nil || !strings.Contains(err.Error(), "invalid format template") {
+		t.Errorf("parse error = %v, want invalid format template", err)
+	}
+
+	blocked := []*types.BlockedIssue{{Issue: types.Issue{ID: "bd-1"}}, {Issue: types.Issue{ID: "bd-2"}}}
+	err := renderFormatTemplate(&buf, "{{.ID}} {{.NoSuchField}}", blocked)
+	if err == nil || !strings.Contains(err.Error(), "template execution error") {
+		t.Errorf("execution error = %v, want template execution error", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("partial output written on error: %q", buf.String())
+	}
+}
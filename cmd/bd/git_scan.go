@@ -0,0 +1,483 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var gitCmd = &cobra.Command{
+	Use:     "git",
+	GroupID: "sync",
+	Short:   "Connect issues to git history",
+	Long: `Connect issues to git history.
+
+'bd git scan' closes issues named in commit messages ("fixes bd-a1b2") and
+records every commit that mentions an issue in its commit links; 'bd git
+links' lists them, as do 'bd show' and 'bd history'. Use 'bd link commit'
+to link a commit by hand.
+
+The post-commit and post-merge hooks (bd hooks install) scan new commits
+automatically when git.close-on-commit or git.link-commits is set in
+config.yaml. git.link-commits records links without closing anything.`,
+}
+
+var gitScanCmd = &cobra.Command{
+	Use:   "scan [<revision-range>]",
+	Short: "Close and link issues referenced in commit messages",
+	Long: `Scan commit messages for closing references and close those issues.
+
+A reference is a closing keyword followed by one or more issue IDs with this
+database's prefix (or one of allowed_prefixes):
+
+  fixes bd-a1b2        closes bd-a1b2, bd-c3d4        Resolved: bd-a1b2.1
+
+Keywords are close, closes, closed, fix, fixes, fixed, resolve, resolves and
+resolved, in any case. Each referenced issue is closed with the commit SHA
+in its close reason, and the (issue, commit) pair is recorded as a commit
+link. Issues that are already closed just get the link. A recorded link is
+never acted on again, so rescanning is safe and an issue reopened after an
+automatic close stays open.
+
+The normal close checks apply: locked, blocked, pinned, and gate issues are
+skipped and reported. IDs with a known prefix that match no issue are
+reported as unresolved.
+
+Other mentions of an existing issue ("refs bd-a1b2", "part of bd-a1b2") are
+recorded as "references" links without changing the issue. With --no-close,
+closing references are recorded the same way.
+
+The revision range is passed to 'git log' (default HEAD, newest --limit
+commits).
+
+EXAMPLES:
+  bd git scan                       # Last 100 commits on HEAD
+  bd git scan origin/main..HEAD     # Commits not yet on origin/main
+  bd git scan --dry-run --json      # Report without closing anything`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("git scan")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		limit, _ := cmd.Flags().GetInt("limit")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		noClose, _ := cmd.Flags().GetBool("no-close")
+
+		revRange := "HEAD"
+		if len(args) == 1 {
+			revRange = args[0]
+		}
+		commits, err := readCommitMessages(revRange, limit)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		ctx := rootCtx
+		result, err := scanCommitsForClosures(ctx, store, commits, commitRefPrefixes(ctx, store), dryRun, noClose)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		result.Range = revRange
+		if !dryRun && len(result.Closed)+len(result.Linked)+len(result.Referenced) > 0 {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if quiet {
+			for _, r := range result.Closed {
+				fmt.Printf("beads: closed %s (commit %s)\n", r.IssueID, shortSHA(r.Commit))
+			}
+			return
+		}
+		printGitScanResult(result, dryRun)
+	},
+}
+
+var gitLinksCmd = &cobra.Command{
+	Use:   "links <issue-id>",
+	Short: "List commits linked to an issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		cls, ok := storage.UnwrapStore(store).(storage.CommitLinkStore)
+		if !ok {
+			FatalErrorRespectJSON("commit links are not supported by this storage backend")
+		}
+		links, err := cls.GetCommitLinks(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if links == nil {
+				links = []*types.CommitLink{}
+			}
+			outputJSON(links)
+			return
+		}
+		if len(links) == 0 {
+			fmt.Printf("No commits linked to %s\n", id)
+			return
+		}
+		for _, l := range links {
+			fmt.Println(formatCommitLinkLine(l))
+		}
+	},
+}
+
+// loadCommitLinks returns the commits linked to issueID, or nil when the
+// backend has no commit links. Best effort, for display alongside an issue.
+func loadCommitLinks(ctx context.Context, s storage.DoltStorage, issueID string) []*types.CommitLink {
+	cls, ok := storage.UnwrapStore(s).(storage.CommitLinkStore)
+	if !ok {
+		return nil
+	}
+	links, _ := cls.GetCommitLinks(ctx, issueID)
+	return links
+}
+
+// formatCommitLinkLine renders one linked commit for bd show and bd history.
+func formatCommitLinkLine(l *types.CommitLink) string {
+	return fmt.Sprintf("  %s %s %s", ui.RenderAccent(shortSHA(l.CommitSHA)), ui.RenderMuted(fmt.Sprintf("%-10s", l.Action)), l.Summary)
+}
+
+// commitMessage is one commit read from git log.
+type commitMessage struct {
+	SHA     string
+	Subject string
+	Body    string // full message, subject included
+}
+
+// gitScanRef is one reference found by bd git scan.
+type gitScanRef struct {
+	IssueID string `json:"issue_id"`
+	Commit  string `json:"commit"`
+	Subject string `json:"subject,omitempty"`
+	Reason  string `json:"reason,omitempty"` // why a reference was skipped
+}
+
+// gitScanResult is the JSON output of bd git scan.
+type gitScanResult struct {
+	Range      string       `json:"range"`
+	Commits    int          `json:"commits_scanned"`
+	DryRun     bool         `json:"dry_run,omitempty"`
+	Closed     []gitScanRef `json:"closed"`
+	Linked     []gitScanRef `json:"linked,omitempty"`     // already closed; link recorded
+	Referenced []gitScanRef `json:"referenced,omitempty"` // mentioned without closing
+	Skipped    []gitScanRef `json:"skipped,omitempty"`    // close checks refused
+	Unresolved []gitScanRef `json:"unresolved,omitempty"` // no such issue
+}
+
+// closingKeywords are the verbs that mark a commit as closing an issue.
+const closingKeywords = `(?i:close[sd]?|fix(?:e[sd])?|resolve[sd]?)`
+
+// closingRefPattern builds the pattern matching a closing keyword followed
+// by a list of issue IDs with one of prefixes.
+func closingRefPattern(prefixes []string) (*regexp.Regexp, *regexp.Regexp) {
+	quoted := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		if p = strings.TrimSuffix(strings.TrimSpace(p), "-"); p != "" {
+			quoted = append(quoted, regexp.QuoteMeta(p))
+		}
+	}
+	id := `(?:` + strings.Join(quoted, "|") + `)-[a-z0-9]+(?:\.[0-9]+)*`
+	list := `\b` + closingKeywords + `\b:?\s+(` + id + `(?:\s*(?:,|&|\band\b)\s*` + id + `)*)\b`
+	return regexp.MustCompile(list), regexp.MustCompile(`\b` + id + `\b`)
+}
+
+// parseClosingRefs returns the issue IDs a commit message closes, in order
+// of first mention. Only IDs with one of prefixes are recognized.
+func parseClosingRefs(message string, prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	listRe, idRe := closingRefPattern(prefixes)
+	seen := map[string]bool{}
+	var ids []string
+	for _, m := range listRe.FindAllStringSubmatch(message, -1) {
+		for _, id := range idRe.FindAllString(m[1], -1) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// parseIssueMentions returns every issue ID with one of prefixes that a
+// commit message mentions, in order of first mention.
+func parseIssueMentions(message string, prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	_, idRe := closingRefPattern(prefixes)
+	seen := map[string]bool{}
+	var ids []string
+	for _, id := range idRe.FindAllString(message, -1) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// commitRefPrefixes returns the issue prefixes recognized in commit
+// messages: the workspace prefix plus allowed_prefixes.
+func commitRefPrefixes(ctx context.Context, s storage.DoltStorage) []string {
+	var prefixes []string
+	prefix := config.GetString("issue-prefix")
+	if prefix == "" && s != nil {
+		prefix, _ = s.GetConfig(ctx, "issue_prefix")
+	}
+	if prefix != "" {
+		prefixes = append(prefixes, prefix)
+	}
+	if s != nil {
+		if allowed, _ := s.GetConfig(ctx, "allowed_prefixes"); allowed != "" {
+			for _, p := range strings.Split(allowed, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					prefixes = append(prefixes, p)
+				}
+			}
+		}
+	}
+	return prefixes
+}
+
+// readCommitMessages returns up to limit commits in revRange, oldest first,
+// so the earliest closing commit is the one recorded in the close reason.
+func readCommitMessages(revRange string, limit int) ([]commitMessage, error) {
+	if strings.HasPrefix(revRange, "-") {
+		return nil, fmt.Errorf("invalid revision range %q", revRange)
+	}
+	args := []string{"log", "--reverse", "--format=%H%x1f%s%x1f%B%x1e"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, revRange, "--")
+	out, err := exec.Command("git", args...).Output() // #nosec G204 -- fixed git subcommand; range validated above
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return nil, fmt.Errorf("git log %s: %s", revRange, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, fmt.Errorf("git log %s: %w", revRange, err)
+	}
+	var commits []commitMessage
+	for _, rec := range bytes.Split(out, []byte{0x1e}) {
+		fields := strings.SplitN(strings.TrimLeft(string(rec), "\n"), "\x1f", 3)
+		if len(fields) != 3 || fields[0] == "" {
+			continue
+		}
+		commits = append(commits, commitMessage{SHA: fields[0], Subject: fields[1], Body: fields[2]})
+	}
+	return commits, nil
+}
+
+// scanCommitsForClosures closes the issues that commits reference with
+// closing keywords and records commit links, including "references" links
+// for other mentions. With noClose every reference is a mention. With dryRun
+// nothing is written; the result reports what would happen.
+func scanCommitsForClosures(ctx context.Context, s storage.DoltStorage, commits []commitMessage, prefixes []string, dryRun, noClose bool) (*gitScanResult, error) {
+	cls, ok := storage.UnwrapStore(s).(storage.CommitLinkStore)
+	if !ok {
+		return nil, fmt.Errorf("commit links are not supported by this storage backend")
+	}
+	result := &gitScanResult{Commits: len(commits), DryRun: dryRun, Closed: []gitScanRef{}}
+	closedInScan := map[string]bool{}
+
+	for _, c := range commits {
+		var closing []string
+		if !noClose {
+			closing = parseClosingRefs(c.Body, prefixes)
+		}
+		for _, id := range closing {
+			ref := gitScanRef{IssueID: id, Commit: c.SHA, Subject: c.Subject}
+			issue, err := s.GetIssue(ctx, id)
+			if err != nil || issue == nil {
+				result.Unresolved = append(result.Unresolved, ref)
+				continue
+			}
+			linked, err := cls.HasCommitLink(ctx, id, c.SHA)
+			if err != nil {
+				return nil, err
+			}
+			if linked {
+				continue
+			}
+
+			action := "closes"
+			if issue.Status == types.StatusClosed || closedInScan[id] {
+				action = "linked"
+			} else if reason := commitCloseBlocker(ctx, s, issue); reason != "" {
+				ref.Reason = reason
+				result.Skipped = append(result.Skipped, ref)
+				continue
+			}
+
+			if !dryRun {
+				if action == "closes" {
+					reason := fmt.Sprintf("Closed by commit %s: %s", shortSHA(c.SHA), c.Subject)
+					if err := s.CloseIssue(ctx, id, reason, actor, ""); err != nil {
+						ref.Reason = err.Error()
+						result.Skipped = append(result.Skipped, ref)
+						continue
+					}
+					audit.LogFieldChange(id, "status", string(issue.Status), "closed", actor, reason)
+				}
+				link := &types.CommitLink{IssueID: id, CommitSHA: c.SHA, Action: action, Summary: c.Subject}
+				if _, err := cls.AddCommitLink(ctx, link); err != nil {
+					return nil, err
+				}
+			}
+			if action == "closes" {
+				closedInScan[id] = true
+				result.Closed = append(result.Closed, ref)
+			} else {
+				result.Linked = append(result.Linked, ref)
+			}
+		}
+
+		for _, id := range parseIssueMentions(c.Body, prefixes) {
+			if slices.Contains(closing, id) {
+				continue
+			}
+			if issue, err := s.GetIssue(ctx, id); err != nil || issue == nil {
+				continue // bare mentions of unknown IDs are not worth reporting
+			}
+			linked, err := cls.HasCommitLink(ctx, id, c.SHA)
+			if err != nil {
+				return nil, err
+			}
+			if linked {
+				continue
+			}
+			if !dryRun {
+				link := &types.CommitLink{IssueID: id, CommitSHA: c.SHA, Action: "references", Summary: c.Subject}
+				if _, err := cls.AddCommitLink(ctx, link); err != nil {
+					return nil, err
+				}
+			}
+			result.Referenced = append(result.Referenced, gitScanRef{IssueID: id, Commit: c.SHA, Subject: c.Subject})
+		}
+	}
+	return result, nil
+}
+
+// commitCloseBlocker returns why bd close (without --force) would refuse
+// to close issue, or "" when it may be closed.
+func commitCloseBlocker(ctx context.Context, s storage.DoltStorage, issue *types.Issue) string {
+	if err := validateIssueClosable(issue.ID, issue, false); err != nil {
+		return err.Error()
+	}
+	if err := checkIssueLock(ctx, s, issue.ID); err != nil {
+		return err.Error()
+	}
+	if err := checkGateSatisfaction(issue); err != nil {
+		return err.Error()
+	}
+	if err := checkStatusTransition(ctx, s, issue, types.StatusClosed); err != nil {
+		return err.Error()
+	}
+	if blocked, blockers, err := s.IsBlocked(ctx, issue.ID); err == nil && blocked && len(blockers) > 0 {
+		return fmt.Sprintf("blocked by open issues %v", blockers)
+	}
+	return ""
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func printGitScanResult(r *gitScanResult, dryRun bool) {
+	verb := "Closed"
+	if dryRun {
+		verb = "Would close"
+	}
+	for _, ref := range r.Closed {
+		fmt.Printf("%s %s %s (commit %s: %s)\n", ui.RenderPass("✓"), verb, ui.RenderID(ref.IssueID), shortSHA(ref.Commit), ref.Subject)
+	}
+	for _, ref := range r.Linked {
+		fmt.Printf("  Linked %s to commit %s (already closed)\n", ui.RenderID(ref.IssueID), shortSHA(ref.Commit))
+	}
+	for _, ref := range r.Referenced {
+		fmt.Printf("  Linked %s to commit %s (referenced)\n", ui.RenderID(ref.IssueID), shortSHA(ref.Commit))
+	}
+	for _, ref := range r.Skipped {
+		fmt.Printf("%s Skipped %s (commit %s): %s\n", ui.RenderWarn("⚠"), ui.RenderID(ref.IssueID), shortSHA(ref.Commit), ref.Reason)
+	}
+	for _, ref := range r.Unresolved {
+		fmt.Printf("%s Unresolved reference %s in commit %s: %s\n", ui.RenderFail("✗"), ref.IssueID, shortSHA(ref.Commit), ref.Subject)
+	}
+	if len(r.Closed)+len(r.Linked)+len(r.Referenced)+len(r.Skipped)+len(r.Unresolved) == 0 {
+		fmt.Printf("No new issue references in %d commit(s)\n", r.Commits)
+	}
+}
+
+// scanCommitsForHook runs 'bd git scan' from a git hook when
+// git.close-on-commit or git.link-commits is enabled; with only
+// git.link-commits the scan records links without closing. Best effort:
+// failures are logged, never returned, so they cannot block the commit or
+// merge.
+func scanCommitsForHook(reason string, scanArgs ...string) {
+	closeOnCommit := config.GetBool("git.close-on-commit")
+	if !closeOnCommit && !config.GetBool("git.link-commits") {
+		return
+	}
+	if !closeOnCommit {
+		scanArgs = append([]string{"--no-close"}, scanArgs...)
+	}
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	debug.Logf("%s: scanning commits for issue references\n", reason)
+
+	// Shell out like importJSONLForSync so the hook process never opens the
+	// database itself.
+	cmd := exec.Command("bd", append([]string{"git", "scan", "--quiet"}, scanArgs...)...) // #nosec G204 -- fixed bd subcommand
+	cmd.Dir = exportSubprocessDir(beadsDir)
+	cmd.Env = filterEnv(os.Environ(), "BD_GIT_HOOK")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "beads: %s commit scan warning: %v\n%s", reason, err, out)
+		return
+	}
+	os.Stderr.Write(out) //nolint:errcheck // best-effort hook output
+}
+
+func init() {
+	gitScanCmd.Flags().Bool("dry-run", false, "Report what would be closed without changing anything")
+	gitScanCmd.Flags().Int("limit", 100, "Maximum number of commits to scan (0 = no limit)")
+	gitScanCmd.Flags().Bool("no-close", false, "Record references as links without closing any issue")
+	gitScanCmd.Flags().Bool("quiet", false, "Only print issues that were closed (used by git hooks)")
+	gitCmd.AddCommand(gitScanCmd)
+	gitCmd.AddCommand(gitLinksCmd)
+	rootCmd.AddCommand(gitCmd)
+}
@@ -0,0 +1,132 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedGitScan(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "gs")
+	fixed := bdCreate(t, bd, dir, "Crash on empty list")
+	done := bdCreate(t, bd, dir, "Already done")
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+	run("close", done.ID)
+
+	commit := func(msg string) {
+		t.Helper()
+		cmd := exec.Command("git", "commit", "--allow-empty", "--no-verify", "-m", msg)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git commit: %v\n%s", err, out)
+		}
+	}
+	commit("Handle empty list\n\nFixes " + fixed.ID + ", closes " + done.ID)
+	commit("Refactor\n\nresolves gs-zzzz")
+	commit("Tidy up\n\nPart of " + fixed.ID)
+
+	scan := func(args ...string) gitScanResult {
+		t.Helper()
+		out := run(append([]string{"git", "scan", "--json"}, args...)...)
+		var r gitScanResult
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("parse scan output: %v\n%s", err, out)
+		}
+		return r
+	}
+
+	dry := scan("--dry-run")
+	if len(dry.Closed) != 1 || bdShow(t, bd, dir, fixed.ID).Status != types.StatusOpen {
+		t.Fatalf("dry run should report one close and change nothing: %+v", dry)
+	}
+
+	r := scan()
+	if r.Commits < 3 {
+		t.Errorf("commits_scanned = %d, want at least 3", r.Commits)
+	}
+	if len(r.Closed) != 1 || r.Closed[0].IssueID != fixed.ID {
+		t.Errorf("closed = %+v, want %s", r.Closed, fixed.ID)
+	}
+	if len(r.Linked) != 1 || r.Linked[0].IssueID != done.ID {
+		t.Errorf("linked = %+v, want %s", r.Linked, done.ID)
+	}
+	if len(r.Referenced) != 1 || r.Referenced[0].IssueID != fixed.ID || r.Referenced[0].Subject != "Tidy up" {
+		t.Errorf("referenced = %+v, want %s from Tidy up", r.Referenced, fixed.ID)
+	}
+	if len(r.Unresolved) != 1 || r.Unresolved[0].IssueID != "gs-zzzz" {
+		t.Errorf("unresolved = %+v, want gs-zzzz", r.Unresolved)
+	}
+	issue := bdShow(t, bd, dir, fixed.ID)
+	if issue.Status != types.StatusClosed || !strings.Contains(issue.CloseReason, "Handle empty list") {
+		t.Errorf("issue not closed by commit: status=%s reason=%q", issue.Status, issue.CloseReason)
+	}
+
+	// A reopened issue must not be closed again by the same commit.
+	run("reopen", fixed.ID)
+	if again := scan(); len(again.Closed)+len(again.Linked)+len(again.Referenced) != 0 {
+		t.Errorf("rescan should be a no-op: %+v", again)
+	}
+	if got := bdShow(t, bd, dir, fixed.ID).Status; got != types.StatusOpen {
+		t.Errorf("reopened issue status = %s, want open", got)
+	}
+
+	var links []*types.CommitLink
+	out := run("git", "links", fixed.ID, "--json")
+	if err := json.Unmarshal([]byte(out), &links); err != nil {
+		t.Fatalf("parse links: %v\n%s", err, out)
+	}
+	if got := commitLinkSummaries(links); got != "closes:Handle empty list,references:Tidy up" {
+		t.Errorf("links = %q", got)
+	}
+
+	// Manual links resolve the commit in git and show up in bd show.
+	run("link", "commit", "HEAD~1", done.ID)
+	if out := run("link", "commit", "HEAD~1", done.ID, "--json"); !strings.Contains(out, `"exists"`) {
+		t.Errorf("relinking should report exists: %s", out)
+	}
+	var details []*types.IssueDetails
+	out = run("show", done.ID, "--json")
+	if err := json.Unmarshal([]byte(out), &details); err != nil {
+		t.Fatalf("parse show: %v\n%s", err, out)
+	}
+	if got := commitLinkSummaries(details[0].Commits); got != "linked:Handle empty list,references:Refactor" {
+		t.Errorf("show commits = %q", got)
+	}
+	if out := run("show", done.ID); !strings.Contains(out, "COMMITS") {
+		t.Errorf("show output missing COMMITS section:\n%s", out)
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "link", "commit", "deadbeef", done.ID); err == nil || !strings.Contains(string(out), "unknown commit") {
+		t.Errorf("linking an unknown short SHA should fail: %v\n%s", err, out)
+	}
+}
+
+// commitLinkSummaries renders links as sorted "action:summary" pairs; links
+// created in the same second have no stable order.
+func commitLinkSummaries(links []*types.CommitLink) string {
+	var out []string
+	for _, l := range links {
+		out = append(out, l.Action+":"+l.Summary)
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseClosingRefs(t *testing.T) {
+	prefixes := []string{"bd", "web-app"}
+	tests := []struct {
+		name string
+		msg  string
+		want []string
+	}{
+		{"fixes", "Fix crash on empty list\n\nfixes bd-a1b2", []string{"bd-a1b2"}},
+		{"keyword case and colon", "Resolved: bd-a1b2.1", []string{"bd-a1b2.1"}},
+		{"list", "closes bd-a1, bd-b2 and bd-c3 & bd-d4", []string{"bd-a1", "bd-b2", "bd-c3", "bd-d4"}},
+		{"hyphenated prefix", "Fixes web-app-9zz", []string{"web-app-9zz"}},
+		{"sentence end", "This fixes bd-a1b2.", []string{"bd-a1b2"}},
+		{"dedup across keywords", "fix bd-a1\ncloses bd-a1", []string{"bd-a1"}},
+		{"mention without keyword", "see bd-a1b2 for context", nil},
+		{"unknown prefix", "fixes gh-123", nil},
+		{"keyword inside word", "prefixes bd-a1", nil},
+		{"counter ids", "closes bd-42", []string{"bd-42"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseClosingRefs(tt.msg, prefixes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseClosingRefs(%q) = %v, want %v", tt.msg, got, tt.want)
+			}
+		})
+	}
+
+	if got := parseClosingRefs("fixes bd-a1", nil); got != nil {
+		t.Errorf("no prefixes should match nothing, got %v", got)
+	}
+}
+
+func TestParseIssueMentions(t *testing.T) {
+	got := parseIssueMentions("Part of bd-a1b2 (see bd-c3.1, bd-a1b2); not gh-9", []string{"bd"})
+	want := []string{"bd-a1b2", "bd-c3.1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIssueMentions = %v, want %v", got, want)
+	}
+}
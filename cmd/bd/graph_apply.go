@@ -273,16 +273,23 @@ func createIssuesFromGraph(planFile string, dryRun bool, opts GraphApplyOptions)
 		FatalError("parsing graph plan: %v", err)
 	}
 
-	if err := validateGraphApplyPlan(&plan, loadEmbeddedCustomTypes()); err != nil {
+	applyGraphPlan(&plan, dryRun, opts)
+}
+
+// applyGraphPlan validates plan and either previews it or creates every node
+// and edge in a single transaction, printing the key -> ID mapping. Shared by
+// --graph and --from-file.
+func applyGraphPlan(plan *GraphApplyPlan, dryRun bool, opts GraphApplyOptions) {
+	if err := validateGraphApplyPlan(plan, loadEmbeddedCustomTypes()); err != nil {
 		FatalError("invalid graph plan: %v", err)
 	}
 
 	if dryRun {
-		emitGraphApplyDryRun(&plan)
+		emitGraphApplyDryRun(plan)
 		return
 	}
 
-	result, err := executeGraphApply(rootCtx, &plan, opts)
+	result, err := executeGraphApply(rootCtx, plan, opts)
 	if err != nil {
 		FatalError("graph create: %v", err)
 	}
@@ -493,163 +500,172 @@ func executeGraphApply(ctx context.Context, plan *GraphApplyPlan, opts GraphAppl
 		return nil, err
 	}
 
-	keyToID := make(map[string]string, len(plan.Nodes))
-
 	commitMsg := plan.CommitMessage
 	if commitMsg == "" {
 		commitMsg = fmt.Sprintf("bd: graph-apply %d nodes", len(plan.Nodes))
 	}
 
+	var keyToID map[string]string
 	if err := store.RunInTransaction(ctx, commitMsg, func(tx storage.Transaction) error {
-		issues := make([]*types.Issue, 0, len(plan.Nodes))
-		pendingAssignees := make(map[int]string)
+		var err error
+		keyToID, err = applyGraphPlanInTx(ctx, tx, plan, opts)
+		return err
+	}); err != nil {
+		return nil, err
+	}
 
-		for i, node := range plan.Nodes {
-			issueType := types.IssueType(node.Type)
-			if issueType == "" {
-				issueType = types.TypeTask
-			}
+	return &GraphApplyResult{IDs: keyToID}, nil
+}
 
-			var metadataJSON json.RawMessage
-			if len(node.Metadata) > 0 {
-				raw, err := json.Marshal(node.Metadata)
-				if err != nil {
-					return fmt.Errorf("node %q: marshaling metadata: %w", node.Key, err)
-				}
-				metadataJSON = raw
-			}
+// applyGraphPlanInTx creates the plan's nodes, edges, and parent links inside
+// tx and returns the key -> ID mapping. Callers own the transaction, so other
+// writes can share the same Dolt commit.
+func applyGraphPlanInTx(ctx context.Context, tx storage.Transaction, plan *GraphApplyPlan, opts GraphApplyOptions) (map[string]string, error) {
+	keyToID := make(map[string]string, len(plan.Nodes))
+	issues := make([]*types.Issue, 0, len(plan.Nodes))
+	pendingAssignees := make(map[int]string)
 
-			priority := 2 // Default P2
-			if node.Priority != nil {
-				priority = *node.Priority
-			}
+	for i, node := range plan.Nodes {
+		issueType := types.IssueType(node.Type)
+		if issueType == "" {
+			issueType = types.TypeTask
+		}
 
-			issue := &types.Issue{
-				Title:     node.Title,
-				IssueType: issueType,
-				Status:    types.StatusOpen,
-				Priority:  priority,
-				Labels:    node.Labels,
-				Metadata:  metadataJSON,
-				Ephemeral: opts.Ephemeral,
-				NoHistory: opts.NoHistory,
-			}
-			if node.Description != "" {
-				issue.Description = node.Description
-			}
-			if node.Assignee != "" {
-				if node.AssignAfterCreate {
-					pendingAssignees[i] = node.Assignee
-				} else {
-					issue.Assignee = node.Assignee
-				}
+		var metadataJSON json.RawMessage
+		if len(node.Metadata) > 0 {
+			raw, err := json.Marshal(node.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("node %q: marshaling metadata: %w", node.Key, err)
 			}
-
-			issues = append(issues, issue)
+			metadataJSON = raw
 		}
 
-		if err := tx.CreateIssues(ctx, issues, actor); err != nil {
-			return fmt.Errorf("batch create: %w", err)
+		priority := 2 // Default P2
+		if node.Priority != nil {
+			priority = *node.Priority
 		}
 
-		for i, node := range plan.Nodes {
-			keyToID[node.Key] = issues[i].ID
+		issue := &types.Issue{
+			Title:     node.Title,
+			IssueType: issueType,
+			Status:    types.StatusOpen,
+			Priority:  priority,
+			Labels:    node.Labels,
+			Metadata:  metadataJSON,
+			Ephemeral: opts.Ephemeral,
+			NoHistory: opts.NoHistory,
 		}
-
-		// Resolve MetadataRefs now that all IDs are known.
-		for i, node := range plan.Nodes {
-			if len(node.MetadataRefs) == 0 {
-				continue
-			}
-			mergedMeta := make(map[string]string)
-			if issues[i].Metadata != nil {
-				if err := json.Unmarshal(issues[i].Metadata, &mergedMeta); err != nil {
-					return fmt.Errorf("node %q: re-parsing metadata: %w", node.Key, err)
-				}
-			}
-			for metaKey, refKey := range node.MetadataRefs {
-				mergedMeta[metaKey] = keyToID[refKey]
-			}
-			metaJSON, err := json.Marshal(mergedMeta)
-			if err != nil {
-				return fmt.Errorf("node %q: marshaling updated metadata: %w", node.Key, err)
-			}
-			updates := map[string]interface{}{
-				"metadata": json.RawMessage(metaJSON),
-			}
-			if err := tx.UpdateIssue(ctx, issues[i].ID, updates, actor); err != nil {
-				return fmt.Errorf("node %q: updating metadata refs: %w", node.Key, err)
+		if node.Description != "" {
+			issue.Description = node.Description
+		}
+		if node.Assignee != "" {
+			if node.AssignAfterCreate {
+				pendingAssignees[i] = node.Assignee
+			} else {
+				issue.Assignee = node.Assignee
 			}
 		}
 
-		parentDepPairs := graphApplyParentDepPairs(plan.Nodes, keyToID)
-		if err := validateGraphApplyPlannedParentBlockingPaths(ctx, tx, plan, keyToID, parentDepPairs); err != nil {
-			return err
-		}
-		canSkipLocalCycleChecks := graphApplyPlanCanSkipSQLCycleChecks(plan)
+		issues = append(issues, issue)
+	}
 
-		// Add dependencies from edges.
-		for i, edge := range plan.Edges {
-			fromID := resolveEdgeRef(edge.FromKey, edge.FromID, keyToID)
-			toID := resolveEdgeRef(edge.ToKey, edge.ToID, keyToID)
-			depType := graphApplyDependencyType(edge.Type)
-			if parentDepPairs[graphApplyDepPairKey(fromID, toID)] {
-				if depType == types.DepParentChild {
-					continue
-				}
-				return fmt.Errorf("edge %d %s->%s duplicates a parent-child relationship with dependency type %q", i, fromID, toID, depType)
-			}
-			if parentDepPairs[graphApplyDepPairKey(toID, fromID)] && graphApplyCycleRelevantDependencyType(depType) {
-				return fmt.Errorf("edge %d %s->%s creates a blocking reverse of a parent-child relationship", i, fromID, toID)
-			}
-			dep := &types.Dependency{
-				IssueID:     fromID,
-				DependsOnID: toID,
-				Type:        depType,
-			}
-			addOpts := storage.DependencyAddOptions{}
-			if canSkipLocalCycleChecks && graphApplyEdgeCanSkipSQLCycleCheck(edge, depType) {
-				addOpts.SkipCycleCheck = true
-			}
-			if err := tx.AddDependencyWithOptions(ctx, dep, actor, addOpts); err != nil {
-				return fmt.Errorf("adding edge %s->%s: %w", fromID, toID, err)
+	if err := tx.CreateIssues(ctx, issues, actor); err != nil {
+		return nil, fmt.Errorf("batch create: %w", err)
+	}
+
+	for i, node := range plan.Nodes {
+		keyToID[node.Key] = issues[i].ID
+	}
+
+	// Resolve MetadataRefs now that all IDs are known.
+	for i, node := range plan.Nodes {
+		if len(node.MetadataRefs) == 0 {
+			continue
+		}
+		mergedMeta := make(map[string]string)
+		if issues[i].Metadata != nil {
+			if err := json.Unmarshal(issues[i].Metadata, &mergedMeta); err != nil {
+				return nil, fmt.Errorf("node %q: re-parsing metadata: %w", node.Key, err)
 			}
 		}
+		for metaKey, refKey := range node.MetadataRefs {
+			mergedMeta[metaKey] = keyToID[refKey]
+		}
+		metaJSON, err := json.Marshal(mergedMeta)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: marshaling updated metadata: %w", node.Key, err)
+		}
+		updates := map[string]interface{}{
+			"metadata": json.RawMessage(metaJSON),
+		}
+		if err := tx.UpdateIssue(ctx, issues[i].ID, updates, actor); err != nil {
+			return nil, fmt.Errorf("node %q: updating metadata refs: %w", node.Key, err)
+		}
+	}
 
-		// Add parent-child dependencies.
-		for i, node := range plan.Nodes {
-			parentID := node.ParentID
-			if node.ParentKey != "" {
-				parentID = keyToID[node.ParentKey]
-			}
-			if parentID != "" {
-				dep := &types.Dependency{
-					IssueID:     issues[i].ID,
-					DependsOnID: parentID,
-					Type:        types.DepParentChild,
-				}
-				if err := tx.AddDependency(ctx, dep, actor); err != nil {
-					return fmt.Errorf("node %q: adding parent-child dep: %w", node.Key, err)
-				}
+	parentDepPairs := graphApplyParentDepPairs(plan.Nodes, keyToID)
+	if err := validateGraphApplyPlannedParentBlockingPaths(ctx, tx, plan, keyToID, parentDepPairs); err != nil {
+		return nil, err
+	}
+	canSkipLocalCycleChecks := graphApplyPlanCanSkipSQLCycleChecks(plan)
+
+	// Add dependencies from edges.
+	for i, edge := range plan.Edges {
+		fromID := resolveEdgeRef(edge.FromKey, edge.FromID, keyToID)
+		toID := resolveEdgeRef(edge.ToKey, edge.ToID, keyToID)
+		depType := graphApplyDependencyType(edge.Type)
+		if parentDepPairs[graphApplyDepPairKey(fromID, toID)] {
+			if depType == types.DepParentChild {
+				continue
 			}
+			return nil, fmt.Errorf("edge %d %s->%s duplicates a parent-child relationship with dependency type %q", i, fromID, toID, depType)
+		}
+		if parentDepPairs[graphApplyDepPairKey(toID, fromID)] && graphApplyCycleRelevantDependencyType(depType) {
+			return nil, fmt.Errorf("edge %d %s->%s creates a blocking reverse of a parent-child relationship", i, fromID, toID)
 		}
+		dep := &types.Dependency{
+			IssueID:     fromID,
+			DependsOnID: toID,
+			Type:        depType,
+		}
+		addOpts := storage.DependencyAddOptions{}
+		if canSkipLocalCycleChecks && graphApplyEdgeCanSkipSQLCycleCheck(edge, depType) {
+			addOpts.SkipCycleCheck = true
+		}
+		if err := tx.AddDependencyWithOptions(ctx, dep, actor, addOpts); err != nil {
+			return nil, fmt.Errorf("adding edge %s->%s: %w", fromID, toID, err)
+		}
+	}
 
-		// Apply deferred assignees.
-		for i, assignee := range pendingAssignees {
-			updates := map[string]interface{}{
-				"assignee": assignee,
+	// Add parent-child dependencies.
+	for i, node := range plan.Nodes {
+		parentID := node.ParentID
+		if node.ParentKey != "" {
+			parentID = keyToID[node.ParentKey]
+		}
+		if parentID != "" {
+			dep := &types.Dependency{
+				IssueID:     issues[i].ID,
+				DependsOnID: parentID,
+				Type:        types.DepParentChild,
 			}
-			if err := tx.UpdateIssue(ctx, issues[i].ID, updates, actor); err != nil {
-				return fmt.Errorf("node %q: setting assignee: %w", plan.Nodes[i].Key, err)
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return nil, fmt.Errorf("node %q: adding parent-child dep: %w", node.Key, err)
 			}
 		}
+	}
 
-		return nil
-	}); err != nil {
-		return nil, err
+	// Apply deferred assignees.
+	for i, assignee := range pendingAssignees {
+		updates := map[string]interface{}{
+			"assignee": assignee,
+		}
+		if err := tx.UpdateIssue(ctx, issues[i].ID, updates, actor); err != nil {
+			return nil, fmt.Errorf("node %q: setting assignee: %w", plan.Nodes[i].Key, err)
+		}
 	}
 
-	return &GraphApplyResult{IDs: keyToID}, nil
+	return keyToID, nil
 }
 
 func validateGraphApplyPlannedParentBlockingPaths(ctx context.Context, tx storage.Transaction, plan *GraphApplyPlan, keyToID map[string]string, parentDepPairs map[string]bool) error {
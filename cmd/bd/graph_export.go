@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"io"
 	"os"
 	"strings"
 
@@ -46,8 +47,8 @@ func renderGraphDOT(layout *GraphLayout, subgraph *TemplateSubgraph) {
 
 	// Emit edges
 	for _, dep := range subgraph.Dependencies {
-		// Only include blocking dependencies in the graph
-		if dep.Type != types.DepBlocks && dep.Type != types.DepParentChild {
+		// Only include blocking dependencies and ordering hints in the graph
+		if !isGraphExportEdge(dep.Type) {
 			continue
 		}
 		// Ensure both endpoints exist in the subgraph
@@ -96,11 +97,20 @@ func dotEdgeStyle(depType types.DependencyType) string {
 		return " [style=solid, arrowhead=normal]"
 	case types.DepParentChild:
 		return " [style=dashed, arrowhead=empty, color=\"#999999\"]"
+	case types.DepSuggests:
+		// Soft ordering: dotted and left out of rank assignment.
+		return " [style=dotted, arrowhead=open, color=\"#999999\", constraint=false]"
 	default:
 		return ""
 	}
 }
 
+// isGraphExportEdge reports whether DOT and HTML exports draw edges of
+// depType: blocks, parent-child, and suggests.
+func isGraphExportEdge(depType types.DependencyType) bool {
+	return depType == types.DepBlocks || depType == types.DepParentChild || depType == types.DepSuggests
+}
+
 // dotEscapeID escapes an ID for DOT format by replacing characters
 // that could break quoted strings (backslash, double-quote).
 func dotEscapeID(id string) string {
@@ -189,7 +199,7 @@ func buildHTMLGraphData(layout *GraphLayout, _ *TemplateSubgraph) []HTMLNode {
 func buildHTMLEdgeData(layout *GraphLayout, subgraph *TemplateSubgraph) []HTMLEdge {
 	edges := make([]HTMLEdge, 0, len(subgraph.Dependencies))
 	for _, dep := range subgraph.Dependencies {
-		if dep.Type != types.DepBlocks && dep.Type != types.DepParentChild {
+		if !isGraphExportEdge(dep.Type) {
 			continue
 		}
 		if layout.Nodes[dep.IssueID] == nil || layout.Nodes[dep.DependsOnID] == nil {
@@ -221,6 +231,7 @@ svg { width: 100vw; height: 100vh; display: block; }
 .link { fill: none; stroke-width: 1.5; marker-end: url(#arrow); }
 .link.blocks { stroke: #666; }
 .link.parent-child { stroke: #555; stroke-dasharray: 5,3; }
+.link.suggests { stroke: #777; stroke-dasharray: 2,3; }
 #tooltip { position: absolute; background: #16213e; border: 1px solid #444; border-radius: 6px; padding: 10px 14px; font-size: 12px; pointer-events: none; opacity: 0; transition: opacity 0.15s; max-width: 320px; z-index: 10; }
 #tooltip .tt-id { color: #7ec8e3; font-weight: bold; }
 #tooltip .tt-status { display: inline-block; padding: 1px 6px; border-radius: 3px; font-size: 10px; margin-left: 6px; }
@@ -245,6 +256,7 @@ svg { width: 100vw; height: 100vh; display: block; }
   <h3 style="margin-top:8px">Edges</h3>
   <div class="legend-item"><svg width="30" height="10"><line x1="0" y1="5" x2="30" y2="5" stroke="#888" stroke-width="1.5"/></svg> blocks</div>
   <div class="legend-item"><svg width="30" height="10"><line x1="0" y1="5" x2="30" y2="5" stroke="#666" stroke-width="1.5" stroke-dasharray="5,3"/></svg> parent-child</div>
+  <div class="legend-item"><svg width="30" height="10"><line x1="0" y1="5" x2="30" y2="5" stroke="#777" stroke-width="1.5" stroke-dasharray="2,3"/></svg> suggests</div>
 </div>
 <div id="controls">
   <button onclick="resetZoom()">Reset View</button>
@@ -279,7 +291,7 @@ const simulation = d3.forceSimulation(nodes)
 
 const link = g.append("g").selectAll("line").data(links).join("line")
   .attr("class", d => "link " + d.type)
-  .attr("stroke-dasharray", d => d.type === "parent-child" ? "5,3" : null);
+  .attr("stroke-dasharray", d => d.type === "parent-child" ? "5,3" : d.type === "suggests" ? "2,3" : null);
 
 const node = g.append("g").selectAll("g").data(nodes).join("g").attr("class","node")
   .call(d3.drag().on("start", dragStart).on("drag", dragged).on("end", dragEnd));
@@ -329,3 +341,89 @@ svg.call(zoom.transform, d3.zoomIdentity.translate(width/4, height/4).scale(0.8)
 </body>
 </html>
 `
+
+// SVG graph geometry: one column per layer, one row per node in a layer.
+const (
+	svgNodeWidth  = 220
+	svgNodeHeight = 44
+	svgColumnGap  = 60
+	svgRowGap     = 16
+	svgMargin     = 16
+)
+
+// renderGraphSVG writes the layered graph as a standalone SVG document. Unlike
+// --html it needs no scripts or network access, so it can be embedded in
+// static pages such as 'bd report --html'.
+func renderGraphSVG(w io.Writer, layout *GraphLayout, subgraph *TemplateSubgraph) {
+	rows := 0
+	for _, layer := range layout.Layers {
+		rows = max(rows, len(layer))
+	}
+	width := 2*svgMargin + len(layout.Layers)*(svgNodeWidth+svgColumnGap) - svgColumnGap
+	height := 2*svgMargin + rows*(svgNodeHeight+svgRowGap) - svgRowGap
+	if len(layout.Nodes) == 0 {
+		width, height = 2*svgMargin, 2*svgMargin
+	}
+
+	pos := func(node *GraphNode) (x, y int) {
+		return svgMargin + node.Layer*(svgNodeWidth+svgColumnGap), svgMargin + node.Position*(svgNodeHeight+svgRowGap)
+	}
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="11">`+"\n",
+		width, height, width, height)
+	fmt.Fprintln(w, `<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="#666666"/></marker></defs>`)
+
+	// Edges first so nodes are drawn over them; blocker points to blocked.
+	for _, dep := range subgraph.Dependencies {
+		if !isGraphExportEdge(dep.Type) {
+			continue
+		}
+		from, to := layout.Nodes[dep.DependsOnID], layout.Nodes[dep.IssueID]
+		if from == nil || to == nil {
+			continue
+		}
+		fx, fy := pos(from)
+		tx, ty := pos(to)
+		var d string
+		if from.Layer < to.Layer {
+			x1, y1 := fx+svgNodeWidth, fy+svgNodeHeight/2
+			x2, y2 := tx, ty+svgNodeHeight/2
+			mid := (x1 + x2) / 2
+			d = fmt.Sprintf("M%d,%d C%d,%d %d,%d %d,%d", x1, y1, mid, y1, mid, y2, x2, y2)
+		} else {
+			// Same or earlier layer (parent-child, suggests, cycles): loop
+			// around the right-hand side of both boxes.
+			x1, y1 := fx+svgNodeWidth, fy+svgNodeHeight/2
+			x2, y2 := tx+svgNodeWidth, ty+svgNodeHeight/2
+			bulge := max(x1, x2) + svgColumnGap/2
+			d = fmt.Sprintf("M%d,%d C%d,%d %d,%d %d,%d", x1, y1, bulge, y1, bulge, y2, x2, y2)
+		}
+		stroke, dash := "#666666", ""
+		switch dep.Type {
+		case types.DepParentChild:
+			stroke, dash = "#999999", ` stroke-dasharray="6,3"`
+		case types.DepSuggests:
+			stroke, dash = "#999999", ` stroke-dasharray="2,3"`
+		}
+		fmt.Fprintf(w, `<path d="%s" fill="none" stroke="%s"%s marker-end="url(#arrow)"/>`+"\n", d, stroke, dash)
+	}
+
+	for _, layer := range layout.Layers {
+		for _, id := range layer {
+			node := layout.Nodes[id]
+			if node == nil {
+				continue
+			}
+			x, y := pos(node)
+			_, fillColor, fontColor := dotNodeAttrs(node)
+			fmt.Fprintf(w, `<g><title>%s</title>`, html.EscapeString(node.Issue.ID+": "+node.Issue.Title))
+			fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="%s" stroke="#999999"/>`,
+				x, y, svgNodeWidth, svgNodeHeight, fillColor)
+			fmt.Fprintf(w, `<text x="%d" y="%d" fill="%s" font-weight="bold">%s %s · P%d</text>`,
+				x+8, y+17, fontColor, statusPlainIcon(node.Issue.Status), html.EscapeString(id), node.Issue.Priority)
+			fmt.Fprintf(w, `<text x="%d" y="%d" fill="%s">%s</text></g>`+"\n",
+				x+8, y+34, fontColor, html.EscapeString(truncateTitle(node.Issue.Title, 34)))
+		}
+	}
+	fmt.Fprintln(w, "</svg>")
+}
@@ -267,6 +267,24 @@ func TestBuildHTMLEdgeData(t *testing.T) {
 	}
 }
 
+func TestBuildHTMLEdgeDataIncludesSuggests(t *testing.T) {
+	t.Parallel()
+	subgraph, layout := makeTestSubgraph()
+	subgraph.Dependencies = append(subgraph.Dependencies,
+		&types.Dependency{IssueID: "test-d", DependsOnID: "test-c", Type: types.DepSuggests},
+		&types.Dependency{IssueID: "test-d", DependsOnID: "test-b", Type: types.DepRelated},
+	)
+
+	edges := buildHTMLEdgeData(layout, subgraph)
+
+	if len(edges) != 4 {
+		t.Fatalf("Expected 4 edges (related omitted), got %d", len(edges))
+	}
+	if last := edges[3]; last.Type != "suggests" || last.Source != "test-c" || last.Target != "test-d" {
+		t.Errorf("suggests edge = %+v, want test-c -> test-d", last)
+	}
+}
+
 func TestDotEdgeStyle(t *testing.T) {
 	t.Parallel()
 	blocks := dotEdgeStyle(types.DepBlocks)
@@ -279,6 +297,11 @@ func TestDotEdgeStyle(t *testing.T) {
 		t.Error("parent-child edge should be dashed")
 	}
 
+	suggests := dotEdgeStyle(types.DepSuggests)
+	if !strings.Contains(suggests, "dotted") || !strings.Contains(suggests, "constraint=false") {
+		t.Errorf("suggests edge should be dotted and not affect ranks, got %q", suggests)
+	}
+
 	related := dotEdgeStyle(types.DepRelated)
 	if related != "" {
 		t.Errorf("related edge should have no style, got %q", related)
@@ -367,3 +390,43 @@ func TestRenderGraphHTML_EmptyEdgesNotNull(t *testing.T) {
 		t.Error("nodes must never be null")
 	}
 }
+
+func TestRenderGraphSVG(t *testing.T) {
+	t.Parallel()
+	subgraph, layout := makeTestSubgraph()
+	subgraph.Issues[2].Title = `Fix <script>alert("x")</script>`
+
+	var buf bytes.Buffer
+	renderGraphSVG(&buf, layout, subgraph)
+	out := buf.String()
+
+	if !strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg"`) || !strings.HasSuffix(out, "</svg>\n") {
+		t.Fatalf("not a standalone SVG document:\n%s", out)
+	}
+	if got := strings.Count(out, "<rect "); got != 4 {
+		t.Errorf("got %d node boxes, want 4", got)
+	}
+	// Two blocks edges plus one parent-child edge.
+	if got := strings.Count(out, `marker-end="url(#arrow)"`); got != 3 {
+		t.Errorf("got %d edges, want 3", got)
+	}
+	if !strings.Contains(out, `stroke-dasharray="6,3"`) {
+		t.Error("parent-child edge should be dashed")
+	}
+	if strings.Contains(out, "<script>") || !strings.Contains(out, "&lt;script&gt;") {
+		t.Error("issue titles should be escaped")
+	}
+	if !strings.Contains(out, "#f8d7da") {
+		t.Error("blocked node should use the blocked fill color")
+	}
+}
+
+func TestRenderGraphSVG_Empty(t *testing.T) {
+	t.Parallel()
+	subgraph := &TemplateSubgraph{IssueMap: map[string]*types.Issue{}}
+	var buf bytes.Buffer
+	renderGraphSVG(&buf, computeLayout(subgraph), subgraph)
+	if strings.Contains(buf.String(), "<rect") || !strings.Contains(buf.String(), "</svg>") {
+		t.Errorf("empty graph should render an empty SVG, got:\n%s", buf.String())
+	}
+}
@@ -18,6 +18,10 @@ var historyCmd = &cobra.Command{
 	Long: `Show the complete version history of an issue, including all commits
 where the issue was modified.
 
+Git commits linked to the issue (bd git scan, bd link commit) are listed
+after the history. JSON output contains only the version history; use
+'bd git links --json' for linked commits.
+
 Examples:
   bd history bd-123           # Show all history for issue bd-123
   bd history bd-123 --limit 5 # Show last 5 changes`,
@@ -40,6 +44,7 @@ Examples:
 				return
 			}
 			fmt.Printf("No history found for issue %s\n", issueID)
+			printHistoryCommitLinks(issueID)
 			return
 		}
 
@@ -81,9 +86,23 @@ Examples:
 			}
 		}
 		fmt.Println()
+		printHistoryCommitLinks(issueID)
 	},
 }
 
+// printHistoryCommitLinks lists the git commits linked to issueID, if any.
+func printHistoryCommitLinks(issueID string) {
+	links := loadCommitLinks(rootCtx, store, issueID)
+	if len(links) == 0 {
+		return
+	}
+	fmt.Printf("%s Linked commits (%d)\n\n", ui.RenderAccent("🔗"), len(links))
+	for _, l := range links {
+		fmt.Println(formatCommitLinkLine(l))
+	}
+	fmt.Println()
+}
+
 func init() {
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of history entries (0 = all)")
 	historyCmd.ValidArgsFunction = issueIDCompletion
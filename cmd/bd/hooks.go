@@ -19,7 +19,7 @@ import (
 
 // managedHookNames lists the git hooks managed by beads.
 // Hook content is generated dynamically by generateHookSection().
-var managedHookNames = []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+var managedHookNames = []string{"pre-commit", "post-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 
 const hookVersionPrefix = "# bd-hooks-version: "
 const shimVersionPrefix = "# bd-shim "
@@ -473,7 +473,7 @@ type HookStatus struct {
 
 // CheckGitHooks checks the status of bd git hooks in .git/hooks/
 func CheckGitHooks() []HookStatus {
-	hooks := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+	hooks := []string{"pre-commit", "post-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 	statuses := make([]HookStatus, 0, len(hooks))
 
 	// Get hooks directory from common git dir (hooks are shared across worktrees)
@@ -612,10 +612,11 @@ var hooksCmd = &cobra.Command{
 	Use:     "hooks",
 	GroupID: "setup",
 	Short:   "Manage git hooks for beads integration",
-	Long: `Install, uninstall, or list git hooks for beads integration.
+	Long: `Install, upgrade, inspect, or uninstall git hooks for beads integration.
 
 The hooks provide:
 - pre-commit: Run chained hooks before commit
+- post-commit: Link and close issues named in the commit message
 - post-merge: Run chained hooks after pull/merge
 - pre-push: Run chained hooks before push
 - post-checkout: Run chained hooks after branch checkout
@@ -637,6 +638,7 @@ outside the markers is preserved across installs and upgrades.
 
 Installed hooks:
   - pre-commit: Run chained hooks before commit
+  - post-commit: Link and close issues named in the commit message
   - post-merge: Run chained hooks after pull/merge
   - pre-push: Run chained hooks before push
   - post-checkout: Run chained hooks after branch checkout
@@ -679,6 +681,9 @@ Installed hooks:
 			for _, hookName := range managedHookNames {
 				fmt.Printf("  - %s\n", hookName)
 			}
+			if !beadsHooks && !shared {
+				printHooksInstallHints()
+			}
 		}
 	},
 }
@@ -754,9 +759,10 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 			hooksDir = ".beads-hooks"
 		}
 	} else {
-		// Use common git directory for hooks (shared across worktrees)
+		// Use the effective hooks directory (core.hooksPath or the common
+		// git directory, shared across worktrees)
 		var err error
-		hooksDir, err = git.GetGitHooksDir()
+		hooksDir, err = managedHooksDir()
 		if err != nil {
 			return err
 		}
@@ -784,43 +790,24 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 	// Install each hook using section markers (GH#1380).
 	// Only the content between markers is managed by beads; user content
 	// outside the markers is preserved across reinstalls and upgrades.
+	var delegated map[string]bool
+	if !beadsHooks && !shared {
+		delegated = hooksDelegatedToManager(hooksDir)
+	}
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
-		section := generateHookSection(hookName)
-
-		// Read existing hook file (if any)
-		// #nosec G304 -- hook path constrained to hooks directory
-		existing, readErr := os.ReadFile(hookPath)
-
-		if readErr != nil && !os.IsNotExist(readErr) {
-			return fmt.Errorf("failed to read %s: %w", hookName, readErr)
-		}
-
-		var newContent string
-		if os.IsNotExist(readErr) {
-			// No existing file — create with shebang + section
-			newContent = "#!/usr/bin/env sh\n" + section
-		} else {
-			existingStr := string(existing)
-			// Check if file already has section markers
-			if strings.Contains(existingStr, hookSectionBeginPrefix) {
-				// Update only the section between markers
-				newContent = injectHookSection(existingStr, section)
-			} else {
-				// Check if this is a legacy bd hook (shim or inline)
-				versionInfo, _ := getHookVersion(hookPath)
-				if versionInfo.IsBdHook {
-					// Legacy bd hook — replace entire file with section format
-					newContent = "#!/usr/bin/env sh\n" + section
-				} else {
-					// Non-bd hook — inject section (preserving existing content)
-					newContent = injectHookSection(existingStr, section)
-				}
+		if delegated[hookName] {
+			// The hook manager's config or the user's script already runs
+			// 'bd hooks run'; a beads section would run every bd hook twice.
+			if err := stripHookSection(hookPath); err != nil {
+				return err
 			}
+			continue
+		}
+		newContent, _, err := renderManagedHook(hookPath, hookName)
+		if err != nil {
+			return err
 		}
-
-		// Normalize line endings to LF
-		newContent = strings.ReplaceAll(newContent, "\r\n", "\n")
 
 		// Write hook file
 		// #nosec G306 -- git hooks must be executable for Git to run them
@@ -843,6 +830,46 @@ func installHooksWithOptions(hookNames []string, force bool, shared bool, chain
 	return nil
 }
 
+// renderManagedHook returns the content hookPath should have with the
+// current beads section, along with its existing content ("" when the file
+// does not exist). User content outside the section markers is preserved;
+// legacy bd hooks (shims and inline hooks without markers) are replaced.
+func renderManagedHook(hookPath, hookName string) (string, string, error) {
+	section := generateHookSection(hookName)
+
+	// #nosec G304 -- hook path constrained to hooks directory
+	existing, readErr := os.ReadFile(hookPath)
+	if readErr != nil && !os.IsNotExist(readErr) {
+		return "", "", fmt.Errorf("failed to read %s: %w", hookName, readErr)
+	}
+
+	var newContent string
+	if os.IsNotExist(readErr) {
+		// No existing file — create with shebang + section
+		newContent = "#!/usr/bin/env sh\n" + section
+	} else {
+		existingStr := string(existing)
+		// Check if file already has section markers
+		if strings.Contains(existingStr, hookSectionBeginPrefix) {
+			// Update only the section between markers
+			newContent = injectHookSection(existingStr, section)
+		} else {
+			// Check if this is a legacy bd hook (shim or inline)
+			versionInfo, _ := getHookVersion(hookPath)
+			if versionInfo.IsBdHook {
+				// Legacy bd hook — replace entire file with section format
+				newContent = "#!/usr/bin/env sh\n" + section
+			} else {
+				// Non-bd hook — inject section (preserving existing content)
+				newContent = injectHookSection(existingStr, section)
+			}
+		}
+	}
+
+	// Normalize line endings to LF
+	return strings.ReplaceAll(newContent, "\r\n", "\n"), string(existing), nil
+}
+
 // preservePreexistingHooks copies non-beads hooks from the currently effective
 // hooks directory into targetDir. This prevents hooks from a global
 // core.hooksPath (or the default .git/hooks/) from being silently lost when
@@ -1177,7 +1204,7 @@ func uninstallHooks() error {
 	if err != nil {
 		return err
 	}
-	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
+	hookNames := []string{"pre-commit", "post-commit", "post-merge", "pre-push", "post-checkout", "prepare-commit-msg"}
 
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
@@ -1511,6 +1538,9 @@ func filterEnv(env []string, key string) []string {
 
 // runPostMergeHook runs chained hooks after merge, then runs the legacy
 // JSONL import fallback only when no Dolt remote is configured. See GH#3729.
+// With git.close-on-commit or git.link-commits it links (and closes) issues
+// named by the merged commits, and with molecule.sweep.on-merge it closes
+// molecules completed by the merge.
 //
 // Returns 0 on success (or if not applicable).
 //
@@ -1521,6 +1551,22 @@ func runPostMergeHook() int {
 		return exitCode
 	}
 	importJSONLForSync("post-merge")
+	scanCommitsForHook("post-merge", "--limit", "0", "ORIG_HEAD..HEAD")
+	sweepMoleculesForHook("post-merge")
+	return 0
+}
+
+// runPostCommitHook runs chained hooks after a commit, then links the new
+// commit to the issues it mentions (git.link-commits) and closes those it
+// references with closing keywords (git.close-on-commit). post-commit cannot
+// affect the commit, so it always succeeds.
+//
+//nolint:unparam // Always returns 0 by design - the commit already exists
+func runPostCommitHook() int {
+	if exitCode := runChainedHook("post-commit", nil); exitCode != 0 {
+		fmt.Fprintf(os.Stderr, "beads: chained post-commit hook exited %d\n", exitCode)
+	}
+	scanCommitsForHook("post-commit", "--limit", "1", "HEAD")
 	return 0
 }
 
@@ -1638,6 +1684,7 @@ thin shim scripts installed in .git/hooks/.
 
 Supported hooks:
   - pre-commit: Run chained hooks before commit
+  - post-commit: Link and close issues named in the commit message
   - post-merge: Run chained hooks after pull/merge
   - pre-push: Run chained hooks before push
   - post-checkout: Run chained hooks after branch checkout
@@ -1662,6 +1709,8 @@ installed bd version - upgrading bd automatically updates hook behavior.`,
 		switch hookName {
 		case "pre-commit":
 			exitCode = runPreCommitHook()
+		case "post-commit":
+			exitCode = runPostCommitHook()
 		case "post-merge":
 			exitCode = runPostMergeHook()
 		case "pre-push":
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor/fix"
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Hook states reported by 'bd hooks status'.
+const (
+	hookStateMissing   = "missing"   // no hook file
+	hookStateCurrent   = "current"   // beads section matches this bd version
+	hookStateOutdated  = "outdated"  // beads section from another bd version
+	hookStateLegacy    = "legacy"    // pre-marker shim or inline hook; upgrade rewrites it
+	hookStateDelegated = "delegated" // a hook manager or user script already runs 'bd hooks run'
+	hookStateUnmanaged = "unmanaged" // hook exists but never calls bd
+)
+
+// userBdHooksRunPattern matches a hand-written 'bd hooks run' call.
+var userBdHooksRunPattern = regexp.MustCompile(`\bbd\s+hooks\s+run\b`)
+
+// hookFileStatus describes one managed hook in the effective hooks directory.
+type hookFileStatus struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	State   string `json:"state"`
+	Version string `json:"version,omitempty"`
+}
+
+// hookManagerStatus describes an external hook manager found in the repo.
+type hookManagerStatus struct {
+	Name           string   `json:"name"`
+	ConfigFile     string   `json:"config_file"`
+	Active         bool     `json:"active"` // its scripts are in the hooks directory
+	HooksWithBd    []string `json:"hooks_with_bd,omitempty"`
+	HooksWithoutBd []string `json:"hooks_without_bd,omitempty"`
+}
+
+// hooksStatusReport is the JSON shape of 'bd hooks status'.
+type hooksStatusReport struct {
+	HooksDir      string              `json:"hooks_dir"`
+	CoreHooksPath string              `json:"core_hooks_path,omitempty"`
+	BdVersion     string              `json:"bd_version"`
+	Hooks         []hookFileStatus    `json:"hooks"`
+	Managers      []hookManagerStatus `json:"managers,omitempty"`
+}
+
+// needsAttention reports whether any hook is missing or stale.
+func (r *hooksStatusReport) needsAttention() bool {
+	for _, h := range r.Hooks {
+		switch h.State {
+		case hookStateMissing, hookStateOutdated, hookStateLegacy, hookStateUnmanaged:
+			return true
+		}
+	}
+	for _, m := range r.Managers {
+		if m.Active && len(m.HooksWithoutBd) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// managedHooksDir returns the directory bd writes hooks to by default: git's
+// effective hooks directory (core.hooksPath or the common .git/hooks).
+// Husky v9 points core.hooksPath at .husky/_, which husky regenerates on
+// every install; its user hooks live one level up in .husky/, so bd writes
+// there instead.
+func managedHooksDir() (string, error) {
+	dir, err := git.GetGitHooksDir()
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(dir) == "_" && filepath.Base(filepath.Dir(dir)) == ".husky" {
+		return filepath.Dir(dir), nil
+	}
+	return dir, nil
+}
+
+// coreHooksPath returns the raw core.hooksPath setting, or "" when unset.
+func coreHooksPath() string {
+	out, err := exec.Command("git", "config", "--get", "core.hooksPath").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// hooksDelegatedToManager returns the hooks in hooksDir that already run
+// 'bd hooks run' outside a beads section: lefthook scripts whose config
+// calls bd, and user scripts (e.g. husky's .husky/<hook>) that call it
+// directly. Injecting a section into these would run bd twice, and
+// lefthook overwrites its scripts on every 'lefthook install' anyway.
+func hooksDelegatedToManager(hooksDir string) map[string]bool {
+	var lefthookWithBd map[string]bool
+	if repoRoot := git.GetRepoRoot(); repoRoot != "" {
+		if integ := fix.CheckLefthookBdIntegration(repoRoot); integ != nil {
+			lefthookWithBd = make(map[string]bool, len(integ.HooksWithBd))
+			for _, name := range integ.HooksWithBd {
+				lefthookWithBd[name] = true
+			}
+		}
+	}
+
+	delegated := make(map[string]bool)
+	for _, name := range managedHookNames {
+		hookPath := filepath.Join(hooksDir, name)
+		// #nosec G304 -- hook path constrained to hooks directory
+		content, err := os.ReadFile(hookPath)
+		if err != nil {
+			continue
+		}
+		if info, err := getHookVersion(hookPath); err == nil && info.IsBdHook && !strings.Contains(string(content), hookSectionBeginPrefix) {
+			continue // legacy bd hook: replaced, not delegated
+		}
+		rest, _ := removeHookSection(string(content))
+		if userBdHooksRunPattern.MatchString(rest) ||
+			(lefthookWithBd[name] && strings.Contains(rest, "lefthook")) {
+			delegated[name] = true
+		}
+	}
+	return delegated
+}
+
+// collectHooksStatus inspects the effective hooks directory and any
+// external hook managers in the repository.
+func collectHooksStatus() (*hooksStatusReport, error) {
+	hooksDir, err := managedHooksDir()
+	if err != nil {
+		return nil, err
+	}
+	report := &hooksStatusReport{
+		HooksDir:      hooksDir,
+		CoreHooksPath: coreHooksPath(),
+		BdVersion:     Version,
+	}
+
+	delegated := hooksDelegatedToManager(hooksDir)
+	for _, name := range managedHookNames {
+		hookPath := filepath.Join(hooksDir, name)
+		st := hookFileStatus{Name: name, Path: hookPath}
+		rendered, existing, err := renderManagedHook(hookPath, name)
+		switch {
+		case err != nil:
+			return nil, err
+		case existing == "" && !fileExists(hookPath):
+			st.State = hookStateMissing
+		case delegated[name]:
+			st.State = hookStateDelegated
+		default:
+			info, _ := getHookVersion(hookPath)
+			st.Version = info.Version
+			switch {
+			case !info.IsBdHook:
+				st.State = hookStateUnmanaged
+			case !strings.Contains(existing, hookSectionBeginPrefix):
+				st.State = hookStateLegacy
+			case rendered == existing:
+				st.State = hookStateCurrent
+			default:
+				st.State = hookStateOutdated
+			}
+		}
+		report.Hooks = append(report.Hooks, st)
+	}
+
+	if repoRoot := git.GetRepoRoot(); repoRoot != "" {
+		active := fix.DetectActiveHookManager(repoRoot)
+		for _, m := range fix.DetectExternalHookManagers(repoRoot) {
+			ms := hookManagerStatus{
+				Name:       m.Name,
+				ConfigFile: m.ConfigFile,
+				Active:     m.Name == active || (m.Name == "husky" && isHuskyDir(hooksDir)),
+			}
+			if m.Name == "lefthook" {
+				if integ := fix.CheckLefthookBdIntegration(repoRoot); integ != nil {
+					ms.HooksWithBd = integ.HooksWithBd
+					ms.HooksWithoutBd = append(integ.HooksWithoutBd, integ.HooksNotInConfig...)
+				}
+			}
+			report.Managers = append(report.Managers, ms)
+		}
+	}
+	return report, nil
+}
+
+// fileExists reports whether path exists (of any type).
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// lefthookSnippet returns the lefthook.yml entries that run bd for hooks.
+func lefthookSnippet(hooks []string) string {
+	var b strings.Builder
+	for _, name := range hooks {
+		args := " {0}"
+		if name == "pre-commit" {
+			args = ""
+		}
+		fmt.Fprintf(&b, "%s:\n  commands:\n    bd:\n      run: bd hooks run %s%s\n", name, name, args)
+		if name == "pre-push" {
+			b.WriteString("      use_stdin: true\n")
+		}
+	}
+	return b.String()
+}
+
+// printLefthookHint tells the user how to wire bd into an active lefthook
+// config; hooks injected into lefthook's scripts are lost on the next
+// 'lefthook install'.
+func printLefthookHint(report *hooksStatusReport) {
+	for _, m := range report.Managers {
+		if m.Name != "lefthook" || !m.Active || len(m.HooksWithoutBd) == 0 {
+			continue
+		}
+		fmt.Println()
+		fmt.Printf("%s lefthook manages these hooks; 'lefthook install' will overwrite beads sections.\n", ui.RenderWarn("⚠"))
+		fmt.Printf("  Add this to %s, then run 'lefthook install':\n\n", m.ConfigFile)
+		for _, line := range strings.Split(strings.TrimRight(lefthookSnippet(m.HooksWithoutBd), "\n"), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+var hooksStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show hook versions, hooks directory, and hook manager integration",
+	Long: `Show where git runs hooks from and the state of each bd-managed hook.
+
+The hooks directory honors core.hooksPath (including .beads/hooks,
+.beads-hooks, and husky's .husky/). Each hook is reported as:
+
+  current     beads section matches this bd version
+  outdated    beads section from another version ('bd hooks upgrade')
+  legacy      pre-marker bd shim or inline hook ('bd hooks upgrade')
+  delegated   a hook manager or user script already runs 'bd hooks run'
+  unmanaged   hook exists but never calls bd ('bd hooks install')
+  missing     no hook file ('bd hooks install')
+
+Detected hook managers (lefthook, husky, pre-commit, ...) are listed too;
+for lefthook, the config entries needed to run bd are printed.
+
+Use --check in scripts: it exits 1 when any hook needs attention.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		check, _ := cmd.Flags().GetBool("check")
+		report, err := collectHooksStatus()
+		if err != nil {
+			FatalErrorRespectJSON("checking hooks: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(report)
+		} else {
+			fmt.Printf("Hooks directory: %s", report.HooksDir)
+			if report.CoreHooksPath != "" {
+				fmt.Printf(" (core.hooksPath=%s)", report.CoreHooksPath)
+			}
+			fmt.Printf("\nbd version: %s\n\n", report.BdVersion)
+			for _, h := range report.Hooks {
+				icon := ui.RenderPass("✓")
+				switch h.State {
+				case hookStateMissing, hookStateUnmanaged:
+					icon = ui.RenderFail("✗")
+				case hookStateOutdated, hookStateLegacy:
+					icon = ui.RenderWarn("⚠")
+				}
+				line := fmt.Sprintf("  %s %-19s %s", icon, h.Name, h.State)
+				if h.Version != "" && h.State != hookStateCurrent {
+					line += fmt.Sprintf(" (v%s)", h.Version)
+				}
+				fmt.Println(line)
+			}
+			if len(report.Managers) > 0 {
+				fmt.Println("\nHook managers:")
+				for _, m := range report.Managers {
+					state := "config found"
+					if m.Active {
+						state = "active"
+					}
+					fmt.Printf("  %s (%s, %s)\n", m.Name, m.ConfigFile, state)
+				}
+			}
+			printLefthookHint(report)
+			if report.needsAttention() {
+				fmt.Println("\nRun 'bd hooks upgrade' to refresh installed hooks, or 'bd hooks install' to add missing ones.")
+			}
+		}
+		if check && report.needsAttention() {
+			os.Exit(1)
+		}
+	},
+}
+
+// hookUpgradeResult is one entry in 'bd hooks upgrade' output.
+type hookUpgradeResult struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	FromState   string `json:"from_state"`
+	FromVersion string `json:"from_version,omitempty"`
+}
+
+var hooksUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade installed bd hooks to this bd version in place",
+	Long: `Rewrite installed bd hooks so their beads section matches this bd version.
+
+Only hooks that already run bd are touched: outdated beads sections are
+replaced between their markers (user content outside the markers is kept),
+and legacy shims or inline hooks are converted to the section format.
+Missing and unmanaged hooks are left alone — use 'bd hooks install' to add
+them. Hooks whose manager config already runs 'bd hooks run' (lefthook,
+husky scripts) lose any duplicate beads section.
+
+The hooks directory honors core.hooksPath, so hooks installed with --beads
+or --shared are upgraded where they are.
+
+EXAMPLES:
+  bd hooks upgrade             # Upgrade outdated hooks
+  bd hooks upgrade --dry-run   # Show what would change`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		report, err := collectHooksStatus()
+		if err != nil {
+			FatalErrorRespectJSON("checking hooks: %v", err)
+		}
+
+		upgraded, err := upgradeHooks(report, dryRun)
+		if err != nil {
+			FatalErrorRespectJSON("upgrading hooks: %v", err)
+		}
+		installed := 0
+		for _, h := range report.Hooks {
+			if h.State != hookStateMissing && h.State != hookStateUnmanaged {
+				installed++
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"hooks_dir":  report.HooksDir,
+				"bd_version": Version,
+				"dry_run":    dryRun,
+				"upgraded":   upgraded,
+			})
+			return
+		}
+		if installed == 0 {
+			fmt.Printf("No bd hooks installed in %s — run 'bd hooks install'\n", report.HooksDir)
+			return
+		}
+		if len(upgraded) == 0 {
+			fmt.Printf("%s Hooks in %s are up to date (v%s)\n", ui.RenderPass("✓"), report.HooksDir, Version)
+		} else {
+			verb := "Upgraded"
+			if dryRun {
+				verb = "Would upgrade"
+			}
+			fmt.Printf("%s %d hook(s) in %s to v%s:\n", verb, len(upgraded), report.HooksDir, Version)
+			for _, u := range upgraded {
+				from := u.FromState
+				if u.FromVersion != "" {
+					from += " v" + u.FromVersion
+				}
+				fmt.Printf("  - %s (%s)\n", u.Name, from)
+			}
+		}
+		printLefthookHint(report)
+	},
+}
+
+// upgradeHooks rewrites the outdated and legacy hooks in report to the
+// current section format and strips duplicate sections from delegated hooks.
+// With dryRun it only reports what would change.
+func upgradeHooks(report *hooksStatusReport, dryRun bool) ([]hookUpgradeResult, error) {
+	var upgraded []hookUpgradeResult
+	for _, h := range report.Hooks {
+		var newContent string
+		switch h.State {
+		case hookStateOutdated, hookStateLegacy:
+			var err error
+			newContent, _, err = renderManagedHook(h.Path, h.Name)
+			if err != nil {
+				return upgraded, err
+			}
+		case hookStateDelegated:
+			// #nosec G304 -- hook path constrained to hooks directory
+			content, err := os.ReadFile(h.Path)
+			if err != nil || !strings.Contains(string(content), hookSectionBeginPrefix) {
+				continue
+			}
+		default:
+			continue
+		}
+		upgraded = append(upgraded, hookUpgradeResult{Name: h.Name, Path: h.Path, FromState: h.State, FromVersion: h.Version})
+		if dryRun {
+			continue
+		}
+		var err error
+		if h.State == hookStateDelegated {
+			err = stripHookSection(h.Path)
+		} else {
+			// #nosec G306 -- git hooks must be executable for Git to run them
+			err = os.WriteFile(h.Path, []byte(newContent), 0755)
+		}
+		if err != nil {
+			return upgraded, fmt.Errorf("%s: %w", h.Name, err)
+		}
+	}
+	return upgraded, nil
+}
+
+func init() {
+	hooksStatusCmd.Flags().Bool("check", false, "Exit 1 if any hook is missing, outdated, or unmanaged")
+	hooksUpgradeCmd.Flags().Bool("dry-run", false, "Show which hooks would be upgraded without writing")
+	hooksCmd.AddCommand(hooksStatusCmd)
+	hooksCmd.AddCommand(hooksUpgradeCmd)
+}
+
+// printHooksInstallHints prints hook-manager guidance after 'bd hooks install'.
+func printHooksInstallHints() {
+	report, err := collectHooksStatus()
+	if err != nil {
+		return
+	}
+	printLefthookHint(report)
+}
+
+// stripHookSection removes the beads section from the hook at path, if any.
+func stripHookSection(path string) error {
+	// #nosec G304 -- hook path constrained to hooks directory
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	stripped, found := removeHookSection(string(content))
+	if !found {
+		return nil
+	}
+	// #nosec G306 -- git hooks must be executable for Git to run them
+	if err := os.WriteFile(path, []byte(stripped), 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func hookStates(report *hooksStatusReport) map[string]string {
+	states := make(map[string]string, len(report.Hooks))
+	for _, h := range report.Hooks {
+		states[h.Name] = h.State
+	}
+	return states
+}
+
+func TestHooksStatusAndUpgrade(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	runInDir(t, tmpDir, func() {
+		report, err := collectHooksStatus()
+		if err != nil {
+			t.Fatalf("collectHooksStatus: %v", err)
+		}
+		if got := hookStates(report)["pre-commit"]; got != hookStateMissing {
+			t.Errorf("before install pre-commit = %q, want missing", got)
+		}
+		if !report.needsAttention() {
+			t.Error("missing hooks should need attention")
+		}
+
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatalf("installHooksWithOptions: %v", err)
+		}
+		report, err = collectHooksStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, state := range hookStates(report) {
+			if state != hookStateCurrent {
+				t.Errorf("after install %s = %q, want current", name, state)
+			}
+		}
+		if report.needsAttention() {
+			t.Error("freshly installed hooks should not need attention")
+		}
+
+		hooksDir := report.HooksDir
+		// pre-commit: section from an older release, with user content around it.
+		old := "#!/bin/sh\necho lint\n" +
+			hookSectionBeginPrefix + " v0.40.0 ---\nbd hooks run pre-commit\n" +
+			hookSectionEndPrefix + " v0.40.0 ---\necho after\n"
+		// post-merge: pre-marker thin shim.
+		shim := "#!/bin/sh\n" + shimVersionPrefix + "v1\nexec bd hooks run post-merge \"$@\"\n"
+		// pre-push: user hook that never calls bd.
+		user := "#!/bin/sh\necho push\n"
+		for name, content := range map[string]string{"pre-commit": old, "post-merge": shim, "pre-push": user} {
+			if err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0700); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		report, err = collectHooksStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		states := hookStates(report)
+		want := map[string]string{
+			"pre-commit":    hookStateOutdated,
+			"post-merge":    hookStateLegacy,
+			"pre-push":      hookStateUnmanaged,
+			"post-checkout": hookStateCurrent,
+		}
+		for name, w := range want {
+			if states[name] != w {
+				t.Errorf("%s = %q, want %q", name, states[name], w)
+			}
+		}
+
+		dry, err := upgradeHooks(report, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dry) != 2 {
+			t.Errorf("dry run would upgrade %d hooks, want 2: %+v", len(dry), dry)
+		}
+		if content, _ := os.ReadFile(filepath.Join(hooksDir, "pre-commit")); string(content) != old {
+			t.Error("dry run modified pre-commit")
+		}
+
+		if _, err := upgradeHooks(report, false); err != nil {
+			t.Fatal(err)
+		}
+		content, _ := os.ReadFile(filepath.Join(hooksDir, "pre-commit"))
+		got := string(content)
+		if !strings.Contains(got, hookSectionBeginLine()) || strings.Contains(got, "v0.40.0") {
+			t.Errorf("pre-commit section not upgraded:\n%s", got)
+		}
+		if !strings.Contains(got, "echo lint") || !strings.Contains(got, "echo after") {
+			t.Errorf("user content lost:\n%s", got)
+		}
+		if content, _ := os.ReadFile(filepath.Join(hooksDir, "pre-push")); string(content) != user {
+			t.Error("upgrade must not touch unmanaged hooks")
+		}
+
+		report, err = collectHooksStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		states = hookStates(report)
+		if states["pre-commit"] != hookStateCurrent || states["post-merge"] != hookStateCurrent {
+			t.Errorf("after upgrade: %v", states)
+		}
+	})
+}
+
+func TestInstallHooks_SkipsHooksThatAlreadyRunBd(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	runInDir(t, tmpDir, func() {
+		hooksDir, err := managedHooksDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(hooksDir, 0750); err != nil {
+			t.Fatal(err)
+		}
+		// A hand-wired hook that calls bd, plus a stale beads section that
+		// would make bd run twice.
+		wired := "#!/bin/sh\nbd hooks run pre-commit\n\n" + generateHookSection("pre-commit")
+		preCommit := filepath.Join(hooksDir, "pre-commit")
+		if err := os.WriteFile(preCommit, []byte(wired), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := installHooksWithOptions(managedHookNames, false, false, false, false); err != nil {
+			t.Fatal(err)
+		}
+		content, _ := os.ReadFile(preCommit)
+		if strings.Contains(string(content), hookSectionBeginPrefix) {
+			t.Errorf("install should drop the duplicate section:\n%s", content)
+		}
+		if !strings.Contains(string(content), "bd hooks run pre-commit") {
+			t.Errorf("user's bd call removed:\n%s", content)
+		}
+
+		report, err := collectHooksStatus()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hookStates(report)["pre-commit"]; got != hookStateDelegated {
+			t.Errorf("pre-commit = %q, want delegated", got)
+		}
+	})
+}
+
+func TestManagedHooksDir_HuskyV9(t *testing.T) {
+	tmpDir := newGitRepo(t)
+	runInDir(t, tmpDir, func() {
+		if err := os.MkdirAll(filepath.Join(tmpDir, ".husky", "_"), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if out, err := exec.Command("git", "config", "core.hooksPath", ".husky/_").CombinedOutput(); err != nil {
+			t.Fatalf("git config: %v\n%s", err, out)
+		}
+		dir, err := managedHooksDir()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := filepath.EvalSymlinks(filepath.Join(tmpDir, ".husky"))
+		if got, _ := filepath.EvalSymlinks(dir); got != want {
+			t.Errorf("managedHooksDir = %q, want %q", dir, want)
+		}
+	})
+}
+
+func TestLefthookSnippet(t *testing.T) {
+	got := lefthookSnippet([]string{"pre-commit", "pre-push"})
+	for _, want := range []string{
+		"pre-commit:\n  commands:\n    bd:\n      run: bd hooks run pre-commit\n",
+		"run: bd hooks run pre-push {0}\n      use_stdin: true\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("snippet missing %q:\n%s", want, got)
+		}
+	}
+}
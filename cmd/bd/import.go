@@ -1,19 +1,25 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/age"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/telemetry"
 	"github.com/steveyegge/beads/internal/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/term"
 )
 
 var importCmd = &cobra.Command{
@@ -54,16 +60,62 @@ Common fields:
   metadata               Arbitrary JSON object preserved verbatim.
 
 Timestamps (created_at, updated_at, started_at, closed_at) are preserved
-when present in the JSONL and otherwise filled in by the importer. The
-legacy "wisp" boolean is accepted as an alias for "ephemeral".
-
-By default rows whose updated_at is older than the local issue's are
-skipped (reported as stale_skipped_ids), so a routine import never rolls
-issues back. The guard is also enforced inside the upsert itself, so a
-local update that lands while the import is running is preserved rather
-than overwritten. To deliberately restore an older snapshot, pass
---allow-stale, which imports every row even when it overwrites newer
-local state.
+when present in the JSONL and otherwise filled in by the importer.
+
+'bd export --schema-header' writes a schema header as the first line, e.g.
+{"_schema":"beads-jsonl/1","_bd_version":"1.0.5"}. Files without one are
+treated as unversioned exports and migrated on load (the legacy "wisp"
+boolean becomes "ephemeral"; tombstone rows are dropped). A file from a
+newer schema than this bd supports is imported best-effort with a warning.
+
+A row whose updated_at is older than the local issue's is a conflict: the
+local copy changed after the imported one was written. --on-conflict
+decides what happens:
+
+  newest       (default) Merge field by field against the version both
+               copies started from (found in Dolt history). Fields only
+               the import changed are taken; fields only changed locally
+               are kept; a field both sides changed keeps the newer,
+               local value. An old copy with no edits of its own is
+               skipped (reported as stale_skipped_ids), so a routine
+               import never rolls issues back.
+  ours         Keep the local copy whole.
+  theirs       Take the imported copy whole, even over newer local state
+               (--allow-stale is shorthand). Use to restore a snapshot.
+  interactive  Merge like newest, but ask about each field both sides
+               changed. Needs a terminal.
+
+Rows newer than the local copy are applied under every strategy, and
+labels, comments, and dependencies are only ever added. Each resolved
+conflict is recorded as an event on the issue. The guard is also enforced
+inside the upsert itself, so a local update that lands while the import
+is running is preserved rather than overwritten.
+
+Large files are streamed rather than loaded into memory: records are
+written and Dolt-committed in batches of --batch-size, with a progress bar
+on an interactive terminal. After each batch a checkpoint is saved to
+.beads/import-checkpoint.json; if an import is interrupted or hits a bad
+line, fix the cause and rerun with --resume to continue after the last
+committed batch. Dependencies on issues that appear later in the file are
+added once every batch has been imported.
+
+--dry-run reads the whole file and reports exactly what the import would
+do without writing anything: issues to create, issues to update with each
+changed field (old → new), labels and comments to add, new dependency
+edges, stale rows that would be skipped, and local issues absent from the
+file (which import never deletes). Add --json for the full preview.
+
+--verify (or import.verify: true in config.yaml) refuses any file without a
+valid SSH signature in <file>.sig from a key listed in the allowed signers
+file (default .beads/allowed_signers, same format as ssh-keygen's
+allowed_signers). Unsigned, tampered, and untrusted-key files are rejected
+before anything is written. Create signatures with 'bd export --sign'.
+
+Files encrypted with age ('bd export --encrypt-to', or the age CLI) are
+detected and decrypted while they stream in, using the key in
+BEADS_AGE_IDENTITY, the age-keygen key file named by BEADS_AGE_IDENTITY_FILE,
+or the passphrase in BEADS_AGE_PASSPHRASE. --verify checks the signature of
+the encrypted file. Encrypted imports cannot be resumed.
 
 EXAMPLES:
   bd import                        # Import from configured import.path
@@ -71,10 +123,14 @@ EXAMPLES:
   bd import -i backup.jsonl        # Legacy alias for a specific file
   bd import -                      # Read JSONL from stdin
   cat issues.jsonl | bd import -   # Pipe JSONL from another tool
-  bd import --dry-run              # Show what would be imported
+  bd import --dry-run backup.jsonl # Preview creates, field updates, and new edges
   bd import --dedup                # Skip issues with duplicate titles
-  bd import --allow-stale old.jsonl # Restore an older snapshot (overwrites newer local rows)
-  bd import --json                 # Structured output with created and skipped IDs`,
+  bd import --on-conflict theirs old.jsonl # Restore an older snapshot (overwrites newer local rows)
+  bd import --on-conflict interactive peer.jsonl # Choose a side for fields both copies changed
+  bd import --json                 # Structured output with created and skipped IDs
+  bd import --resume huge.jsonl    # Continue an interrupted import
+  bd import --verify peer.jsonl    # Require a trusted signature in peer.jsonl.sig
+  BEADS_AGE_IDENTITY_FILE=~/.config/beads/key.txt bd import issues.jsonl.age`,
 	GroupID: "sync",
 	RunE:    runImport,
 }
@@ -84,13 +140,23 @@ var (
 	importDedup      bool
 	importAllowStale bool
 	importInput      string
+	importBatchSize  int
+	importResume     bool
+	importOnConflict string
+	importVerify     bool
+	importSigners    string
 )
 
 func init() {
 	importCmd.Flags().StringVarP(&importInput, "input", "i", "", "Read JSONL from a specific file")
-	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Show what would be imported without importing")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Preview creates, field-level updates, and dependency changes without writing")
 	importCmd.Flags().BoolVar(&importDedup, "dedup", false, "Skip lines whose title matches an existing open issue")
-	importCmd.Flags().BoolVar(&importAllowStale, "allow-stale", false, "Import rows even when older than the local issue (required to restore an older snapshot)")
+	importCmd.Flags().BoolVar(&importAllowStale, "allow-stale", false, "Same as --on-conflict theirs: import rows even when older than the local issue")
+	importCmd.Flags().StringVar(&importOnConflict, "on-conflict", conflictNewest, "How to resolve an issue also changed locally since the imported copy: newest, ours, theirs, interactive")
+	importCmd.Flags().IntVar(&importBatchSize, "batch-size", 1000, "Records written and committed per transaction")
+	importCmd.Flags().BoolVar(&importResume, "resume", false, "Continue an interrupted file import from its last committed batch")
+	importCmd.Flags().BoolVar(&importVerify, "verify", false, "Refuse files without a valid signature (<file>.sig) from an allowed signer")
+	importCmd.Flags().StringVar(&importSigners, "allowed-signers", "", "allowed_signers file for --verify (default: .beads/allowed_signers)")
 	rootCmd.AddCommand(importCmd)
 }
 
@@ -99,21 +165,46 @@ func runImport(cmd *cobra.Command, args []string) error {
 	if importInput != "" && len(args) > 0 {
 		return fmt.Errorf("use either --input or a positional file, not both")
 	}
+	if importBatchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
 
 	fromStdin := importInput == "-" || (len(args) > 0 && args[0] == "-")
+	verify := importVerify || config.GetBool("import.verify")
+
+	strategy, err := validateImportConflictStrategy(importOnConflict, importAllowStale)
+	if err != nil {
+		return err
+	}
+	importOnConflict = strategy
+	if strategy == conflictInteractive && !importDryRun {
+		if err := interactiveImportAvailable(fromStdin); err != nil {
+			return err
+		}
+	}
 
 	if fromStdin {
-		return runImportFromReader(ctx, os.Stdin, "stdin")
+		if importResume {
+			return fmt.Errorf("--resume needs a file; stdin cannot be re-read from a checkpoint")
+		}
+		if verify {
+			return fmt.Errorf("--verify needs a file; stdin has no signature to check")
+		}
+		r, _, err := maybeDecryptImport(os.Stdin)
+		if err != nil {
+			return err
+		}
+		return runImportFromReader(ctx, r, "stdin", nil)
 	}
 
 	// Determine source file
+	beadsDir := beads.FindBeadsDir()
 	var jsonlPath string
 	if importInput != "" {
 		jsonlPath = importInput
 	} else if len(args) > 0 {
 		jsonlPath = args[0]
 	} else {
-		beadsDir := beads.FindBeadsDir()
 		if beadsDir == "" {
 			return fmt.Errorf("%s — %s", activeWorkspaceNotFoundError(), diagHint())
 		}
@@ -128,7 +219,7 @@ func runImport(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("cannot read %s: %w", jsonlPath, err)
 	}
-	if info.Size() == 0 {
+	if info.Size() == 0 && !verify {
 		if jsonOutput {
 			outputJSON(importResultJSON{Source: jsonlPath})
 			return nil
@@ -143,184 +234,368 @@ func runImport(cmd *cobra.Command, args []string) error {
 	}
 	defer f.Close()
 
-	return runImportFromReader(ctx, f, jsonlPath)
+	var signedBy string
+	if verify {
+		signedBy, err = verifyImportFile(f, jsonlPath, allowedSignersPath(importSigners, beadsDir))
+		if err != nil {
+			return err
+		}
+		if !jsonOutput {
+			fmt.Fprintf(os.Stderr, "Verified signature by %s\n", signedBy)
+		}
+	}
+
+	absPath, err := filepath.Abs(jsonlPath)
+	if err != nil {
+		absPath = jsonlPath
+	}
+	src := &importFile{f: f, path: absPath, info: info, beadsDir: beadsDir, signedBy: signedBy}
+
+	// An age-encrypted file is decrypted as it streams. Checkpoints record
+	// plaintext offsets, which cannot be sought to in the ciphertext, so
+	// such imports run without them.
+	head := make([]byte, len(age.Magic)+1)
+	n, _ := f.ReadAt(head, 0)
+	if age.IsEncrypted(head[:n]) {
+		if importResume {
+			return fmt.Errorf("--resume is not supported for encrypted files; rerun without it")
+		}
+		r, _, err := maybeDecryptImport(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", jsonlPath, err)
+		}
+		src.beadsDir = ""
+		return runImportFromReader(ctx, r, jsonlPath, src)
+	}
+	return runImportFromReader(ctx, f, jsonlPath, src)
+}
+
+// importFile describes an on-disk import source, which unlike stdin has a
+// known size for the progress bar and can be resumed from a checkpoint.
+type importFile struct {
+	f        *os.File
+	path     string // absolute
+	info     os.FileInfo
+	beadsDir string // holds the checkpoint; "" disables checkpoints
+	signedBy string // trusted principal when --verify checked the signature
 }
 
 type importResultJSON struct {
-	Source              string   `json:"source"`
-	Created             int      `json:"created"`
-	Skipped             int      `json:"skipped"`
-	DedupHits           int      `json:"dedup_skipped,omitempty"`
-	Memories            int      `json:"memories,omitempty"`
-	IDs                 []string `json:"ids,omitempty"`
-	StaleSkippedIDs     []string `json:"stale_skipped_ids,omitempty"`
-	SkippedDependencies []string `json:"skipped_dependencies,omitempty"`
-	DryRun              bool     `json:"dry_run,omitempty"`
+	Source              string           `json:"source"`
+	SignedBy            string           `json:"signed_by,omitempty"`
+	Created             int              `json:"created"`
+	Skipped             int              `json:"skipped"`
+	DedupHits           int              `json:"dedup_skipped,omitempty"`
+	Memories            int              `json:"memories,omitempty"`
+	Batches             int              `json:"batches,omitempty"`
+	ResumedFromLine     int              `json:"resumed_from_line,omitempty"`
+	IDs                 []string         `json:"ids,omitempty"`
+	StaleSkippedIDs     []string         `json:"stale_skipped_ids,omitempty"`
+	SkippedDependencies []string         `json:"skipped_dependencies,omitempty"`
+	DryRun              bool             `json:"dry_run,omitempty"`
+	Conflicts           []ImportConflict `json:"conflicts,omitempty"`
+	Preview             *ImportPreview   `json:"preview,omitempty"`
 }
 
-func runImportFromReader(ctx context.Context, r io.Reader, source string) error {
+// runImportFromReader streams JSONL from r into the store in batches of
+// --batch-size records. Each batch is written and Dolt-committed on its own,
+// so memory stays bounded by the batch rather than the file. For a file
+// source, a checkpoint is saved after every batch; if the import fails
+// part-way, 'bd import --resume' continues after the last committed batch.
+func runImportFromReader(ctx context.Context, r io.Reader, source string, src *importFile) (retErr error) {
 	if store == nil {
 		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
 	}
+	ctx, span := telemetry.Tracer("github.com/steveyegge/beads/import").Start(ctx, "bd.import",
+		trace.WithAttributes(
+			attribute.String("bd.import.source", source),
+			attribute.Bool("bd.import.dry_run", importDryRun),
+		),
+	)
+	defer func() { telemetry.EndSpan(span, retErr) }()
+
+	checkpointing := src != nil && src.beadsDir != "" && !importDryRun
+	cp := &importCheckpoint{}
+	if src != nil {
+		cp.Source = src.path
+	}
+	jr := jsonl.NewReader(r)
 
-	scanner := bufio.NewScanner(r)
-	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
-
-	var issues []*types.Issue
-	var memories []memoryRecord
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var peek map[string]json.RawMessage
-		if err := json.Unmarshal([]byte(line), &peek); err != nil {
-			return fmt.Errorf("failed to parse JSONL line: %w", err)
+	result := importResultJSON{Source: source, DryRun: importDryRun}
+	if src != nil {
+		result.SignedBy = src.signedBy
+	}
+	var preview *ImportPreview
+	if importDryRun {
+		preview = &ImportPreview{}
+	}
+	if importResume && src != nil {
+		saved, err := loadImportCheckpoint(src.beadsDir)
+		if err != nil {
+			return err
 		}
-
-		if rawType, ok := peek["_type"]; ok {
-			var typeStr string
-			if err := json.Unmarshal(rawType, &typeStr); err == nil && typeStr == "memory" {
-				var mem memoryRecord
-				if err := json.Unmarshal([]byte(line), &mem); err != nil {
-					return fmt.Errorf("failed to parse memory record: %w", err)
-				}
-				if mem.Key != "" && mem.Value != "" {
-					memories = append(memories, mem)
-				}
-				continue
+		switch {
+		case saved == nil:
+			fmt.Fprintln(os.Stderr, "No import checkpoint found; starting from the beginning.")
+		case !saved.matches(src.path, src.f):
+			return fmt.Errorf("import checkpoint for %s (line %d) does not match this file's contents; rerun without --resume to start over",
+				saved.Source, saved.Line)
+		default:
+			if _, err := src.f.Seek(saved.Offset, io.SeekStart); err != nil {
+				return fmt.Errorf("seek to checkpoint: %w", err)
 			}
-		}
-
-		var issue types.Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return fmt.Errorf("failed to parse issue from JSONL: %w", err)
-		}
-		if issue.Status == "tombstone" {
-			continue
-		}
-		if _, hasWisp := peek["wisp"]; hasWisp && !issue.Ephemeral {
-			var wisp bool
-			if err := json.Unmarshal(peek["wisp"], &wisp); err == nil && wisp {
-				issue.Ephemeral = true
+			cp = saved
+			jr = jsonl.NewReader(src.f)
+			jr.ResumeAt(cp.Line, cp.Offset, cp.SchemaVersion)
+			result.ResumedFromLine = cp.Line + 1
+			if !jsonOutput {
+				fmt.Fprintf(os.Stderr, "Resuming %s at line %d (%d issues already imported)\n", source, cp.Line+1, cp.Created)
 			}
 		}
-		issue.SetDefaults()
-		issues = append(issues, &issue)
 	}
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to scan JSONL: %w", err)
+
+	// Dedup compares against issues that were open before the import began.
+	var openTitles map[string]bool
+	if importDedup {
+		openTitles = loadOpenIssueTitles(ctx, store)
 	}
 
-	// Dedup: skip issues whose title matches an existing open issue
-	dedupHits := 0
-	if importDedup && len(issues) > 0 {
-		issues, dedupHits = filterDuplicatesByTitle(ctx, store, issues)
+	// status.import-map renames foreign workflow states on the way in.
+	statusMap, err := loadStatusMap(ctx, store, "status.import-map")
+	if err != nil {
+		return err
 	}
 
-	result := importResultJSON{
-		Source:    source,
-		DedupHits: dedupHits,
-		DryRun:    importDryRun,
+	resolver := &importConflictResolver{strategy: importOnConflict}
+	if importOnConflict == conflictInteractive && !importDryRun {
+		resolver.ask = newConflictPrompter()
 	}
 
-	if importDryRun {
-		result.Created = len(issues)
-		result.Memories = len(memories)
-		result.Skipped = dedupHits
-		if jsonOutput {
-			outputJSON(result)
+	showProgress := src != nil && !jsonOutput && resolver.ask == nil && term.IsTerminal(int(os.Stderr.Fd()))
+	opts := ImportOptions{SkipPrefixValidation: true, AllowStale: importOnConflict == conflictTheirs, DeferMissingDependencyTargets: true}
+
+	batch := make([]*types.Issue, 0, importBatchSize)
+	memories := make(map[string]string)
+	flush := func(final bool) error {
+		if len(batch) == 0 && len(memories) == 0 {
 			return nil
 		}
-		fmt.Fprintf(os.Stderr, "Would import %d issues and %d memories from %s", len(issues), len(memories), source)
-		if dedupHits > 0 {
-			fmt.Fprintf(os.Stderr, " (%d duplicates skipped)", dedupHits)
+		cp.Batches++
+		conflicts, err := resolver.resolve(ctx, store, batch)
+		if err != nil {
+			return err
+		}
+		if preview != nil {
+			preview.Conflicts = append(preview.Conflicts, conflicts...)
+			staleBefore := len(preview.Stale)
+			if err := preview.previewBatch(ctx, store, batch, memories, opts.AllowStale); err != nil {
+				return err
+			}
+			stale := len(preview.Stale) - staleBefore
+			cp.Created += len(batch) - stale
+			cp.Skipped += stale
+			cp.Memories += len(memories)
+		} else {
+			createdBefore, memoriesBefore := cp.Created, cp.Memories
+			if err := importBatch(ctx, batch, memories, opts, cp, &result); err != nil {
+				return err
+			}
+			recordImportConflicts(ctx, store, conflicts, source)
+			result.Conflicts = append(result.Conflicts, conflicts...)
+			if created, mems := cp.Created-createdBefore, cp.Memories-memoriesBefore; created > 0 || mems > 0 {
+				msg := fmt.Sprintf("bd import: %d issues", created)
+				if mems > 0 {
+					msg += fmt.Sprintf(", %d memories", mems)
+				}
+				msg += " from " + filepath.Base(source)
+				if !final || cp.Batches > 1 {
+					msg += fmt.Sprintf(" (batch %d)", cp.Batches)
+				}
+				if err := store.Commit(ctx, msg); err != nil && !isDoltNothingToCommit(err) {
+					return fmt.Errorf("commit: %w", err)
+				}
+			}
+		}
+		batch = batch[:0]
+		clear(memories)
+
+		cp.Line, cp.Offset, cp.SchemaVersion = jr.Line(), jr.Offset(), jr.Version
+		if checkpointing {
+			hash, err := lineHashBefore(src.f, cp.Offset)
+			if err == nil {
+				cp.LastLineHash = hash
+				err = saveImportCheckpoint(src.beadsDir, cp)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save import checkpoint: %v\n", err)
+			}
+		}
+		if showProgress {
+			fmt.Fprintf(os.Stderr, "\r%s %d issues", progressBar(int(jr.Offset()), int(src.info.Size())), cp.Created)
 		}
-		fmt.Fprintln(os.Stderr)
 		return nil
 	}
-
-	// Import memories
-	for _, mem := range memories {
-		storageKey := kvPrefix + memoryPrefix + mem.Key
-		if err := store.SetConfig(ctx, storageKey, mem.Value); err != nil {
-			return fmt.Errorf("failed to import memory %q: %w", mem.Key, err)
+	// fail reports an error that stopped the import part-way through.
+	fail := func(err error) error {
+		if showProgress {
+			fmt.Fprintln(os.Stderr)
+		}
+		if checkpointing && cp.Batches > 0 {
+			return fmt.Errorf("%w\n%d issues imported through line %d; fix the input and run 'bd import --resume %s' to continue", err, cp.Created, cp.Line, source)
 		}
-		result.Memories++
+		return err
 	}
 
-	// Import issues
-	if len(issues) > 0 {
-		opts := ImportOptions{SkipPrefixValidation: true, AllowStale: importAllowStale}
-		importResult, err := importIssuesCore(ctx, "", store, issues, opts)
+	warned := false
+	for {
+		rec, err := jr.Next()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return fmt.Errorf("import failed: %w", err)
+			return fail(err)
+		}
+		if !warned {
+			warned = true
+			warnIfJSONLSchemaAhead(jr)
+		}
+
+		issue, memKey, memValue, err := decodeImportRecord(rec)
+		if err != nil {
+			return fail(fmt.Errorf("line %d: %w", jr.Line(), err))
+		}
+		if issue != nil {
+			issue.Status = statusMap.Apply(issue.Status)
+		}
+		switch {
+		case issue != nil && openTitles[strings.ToLower(issue.Title)]:
+			cp.DedupHits++
+		case issue != nil:
+			batch = append(batch, issue)
+		case memKey != "":
+			memories[memKey] = memValue
+		}
+		if len(batch)+len(memories) >= importBatchSize {
+			if err := flush(false); err != nil {
+				return fail(err)
+			}
 		}
-		result.Created = importResult.Created
-		result.Skipped += importResult.Skipped
-		result.SkippedDependencies = append(result.SkippedDependencies, importResult.SkippedDependencies...)
-		result.IDs = append(result.IDs, importResult.ImportedIDs...)
-		result.StaleSkippedIDs = append(result.StaleSkippedIDs, importResult.StaleSkippedIDs...)
+	}
+	if err := flush(true); err != nil {
+		return fail(err)
+	}
+	if showProgress {
+		fmt.Fprintln(os.Stderr)
 	}
 
-	if result.Created > 0 || result.Memories > 0 {
-		commitMsg := fmt.Sprintf("bd import: %d issues", result.Created)
-		if result.Memories > 0 {
-			commitMsg += fmt.Sprintf(", %d memories", result.Memories)
+	// Edges whose target appeared in a later batch can be added now.
+	if len(cp.Deferred) > 0 && !importDryRun {
+		for _, dep := range cp.Deferred {
+			if err := store.AddDependency(ctx, dep, getActorWithGit()); err != nil {
+				result.SkippedDependencies = append(result.SkippedDependencies,
+					fmt.Sprintf("%s -> %s: %v", dep.IssueID, dep.DependsOnID, err))
+			}
 		}
-		commitMsg += fmt.Sprintf(" from %s", filepath.Base(source))
-		if err := store.Commit(ctx, commitMsg); err != nil {
+		if err := store.Commit(ctx, fmt.Sprintf("bd import: %d dependencies from %s", len(cp.Deferred), filepath.Base(source))); err != nil && !isDoltNothingToCommit(err) {
 			return fmt.Errorf("commit: %w", err)
 		}
 	}
+	if checkpointing {
+		clearImportCheckpoint(src.beadsDir)
+	}
+
+	if preview != nil {
+		if err := preview.finish(ctx, store); err != nil {
+			return err
+		}
+		result.Preview = preview
+		result.StaleSkippedIDs = preview.Stale
+	}
+
+	result.Created = cp.Created
+	result.Memories = cp.Memories
+	result.DedupHits = cp.DedupHits
+	result.Skipped = cp.Skipped + cp.DedupHits
+	if cp.Batches > 1 {
+		result.Batches = cp.Batches
+	}
 
 	if jsonOutput {
 		outputJSON(result)
 		return nil
 	}
 
+	if preview != nil {
+		printImportPreview(source, preview, result.DedupHits)
+		return nil
+	}
+
 	fmt.Fprintf(os.Stderr, "Imported %d issues", result.Created)
 	if result.Memories > 0 {
 		fmt.Fprintf(os.Stderr, " and %d memories", result.Memories)
 	}
 	fmt.Fprintf(os.Stderr, " from %s", source)
-	if dedupHits > 0 {
-		fmt.Fprintf(os.Stderr, " (%d duplicates skipped)", dedupHits)
+	if result.Batches > 1 {
+		fmt.Fprintf(os.Stderr, " in %d batches", result.Batches)
 	}
-	if staleSkipped := result.Skipped - dedupHits; staleSkipped > 0 {
-		fmt.Fprintf(os.Stderr, " (%d stale skipped; use --allow-stale to restore older rows)", staleSkipped)
+	if result.DedupHits > 0 {
+		fmt.Fprintf(os.Stderr, " (%d duplicates skipped)", result.DedupHits)
+	}
+	if staleSkipped := result.Skipped - result.DedupHits; staleSkipped > 0 {
+		fmt.Fprintf(os.Stderr, " (%d stale skipped; use --on-conflict theirs to restore older rows)", staleSkipped)
 	}
 	fmt.Fprintln(os.Stderr)
+	for _, c := range result.Conflicts {
+		fmt.Fprintf(os.Stderr, "  Conflict %s: %s\n", c.ID, c.describe())
+	}
 	for _, skipped := range result.SkippedDependencies {
 		fmt.Fprintf(os.Stderr, "Skipped dependency: %s\n", skipped)
 	}
 	return nil
 }
 
-// filterDuplicatesByTitle removes issues whose title matches an existing open issue.
-func filterDuplicatesByTitle(ctx context.Context, st storage.DoltStorage, issues []*types.Issue) ([]*types.Issue, int) {
-	existing, err := st.SearchIssues(ctx, "", types.IssueFilter{})
+// importBatch writes one batch of memories and issues, folding the outcome
+// into the checkpoint totals and result.
+func importBatch(ctx context.Context, issues []*types.Issue, memories map[string]string, opts ImportOptions, cp *importCheckpoint, result *importResultJSON) error {
+	memoryKeys := make([]string, 0, len(memories))
+	for key := range memories {
+		memoryKeys = append(memoryKeys, key)
+	}
+	sort.Strings(memoryKeys)
+	for _, storageKey := range memoryKeys {
+		if err := store.SetConfig(ctx, storageKey, memories[storageKey]); err != nil {
+			return fmt.Errorf("failed to import memory %q: %w", strings.TrimPrefix(storageKey, kvPrefix+memoryPrefix), err)
+		}
+		cp.Memories++
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	importResult, err := importIssuesCore(ctx, "", store, issues, opts)
 	if err != nil {
-		return issues, 0
+		return fmt.Errorf("import failed: %w", err)
 	}
+	cp.Created += importResult.Created
+	cp.Skipped += importResult.Skipped
+	cp.Deferred = append(cp.Deferred, importResult.DeferredDependencies...)
+	result.SkippedDependencies = append(result.SkippedDependencies, importResult.SkippedDependencies...)
+	result.IDs = append(result.IDs, importResult.ImportedIDs...)
+	result.StaleSkippedIDs = append(result.StaleSkippedIDs, importResult.StaleSkippedIDs...)
+	return nil
+}
 
-	titleSet := make(map[string]bool, len(existing))
+// loadOpenIssueTitles returns the lowercased titles of all non-closed issues.
+func loadOpenIssueTitles(ctx context.Context, st storage.DoltStorage) map[string]bool {
+	existing, err := st.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil
+	}
+	titles := make(map[string]bool, len(existing))
 	for _, issue := range existing {
 		if issue.Status != types.StatusClosed {
-			titleSet[strings.ToLower(issue.Title)] = true
-		}
-	}
-
-	var kept []*types.Issue
-	skipped := 0
-	for _, issue := range issues {
-		if titleSet[strings.ToLower(issue.Title)] {
-			skipped++
-			continue
+			titles[strings.ToLower(issue.Title)] = true
 		}
-		kept = append(kept, issue)
 	}
-	return kept, skipped
+	return titles
 }
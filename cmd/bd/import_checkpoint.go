@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importCheckpointFile records how far a file import got, so that
+// 'bd import --resume' can continue after the last committed batch.
+const importCheckpointFile = "import-checkpoint.json"
+
+// importCheckpoint is written to .beads/ after every committed batch and
+// removed when the import finishes.
+type importCheckpoint struct {
+	Source string `json:"source"`
+
+	// Position just past the last committed record, and a hash of that
+	// record's line. Resuming re-reads the line ending at Offset and checks
+	// the hash, so the file may be fixed up after the checkpoint (the usual
+	// reason to resume) but not shifted before it.
+	Line          int    `json:"line"`
+	Offset        int64  `json:"offset"`
+	LastLineHash  string `json:"last_line_sha256"`
+	SchemaVersion int    `json:"schema_version"`
+
+	// Running totals, so the final report covers the whole file.
+	Batches   int `json:"batches"`
+	Created   int `json:"created"`
+	Skipped   int `json:"skipped"`
+	DedupHits int `json:"dedup_skipped"`
+	Memories  int `json:"memories"`
+
+	// Edges whose target had not been imported yet; retried at the end.
+	Deferred []*types.Dependency `json:"deferred_dependencies,omitempty"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// matches reports whether f is the checkpointed file with everything up to
+// the checkpoint unchanged.
+func (cp *importCheckpoint) matches(path string, f io.ReaderAt) bool {
+	if cp.Source != path {
+		return false
+	}
+	hash, err := lineHashBefore(f, cp.Offset)
+	return err == nil && hash == cp.LastLineHash
+}
+
+// lineHashBefore returns the SHA-256 of the line that ends at offset, as
+// reported by jsonl.Reader.Offset: one byte past the line's newline (or past
+// the end of a final line that has none).
+func lineHashBefore(f io.ReaderAt, offset int64) (string, error) {
+	if offset <= 0 {
+		return "", nil
+	}
+	// Walk back from the terminating newline to the previous one.
+	end := offset - 1
+	start := end
+	buf := make([]byte, 64*1024)
+	for start > 0 {
+		n := int64(len(buf))
+		if start < n {
+			n = start
+		}
+		if _, err := f.ReadAt(buf[:n], start-n); err != nil && err != io.EOF {
+			return "", err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			start = start - n + int64(i) + 1
+			break
+		}
+		start -= n
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(f, start, end-start)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// loadImportCheckpoint returns the checkpoint in beadsDir, or nil if there
+// is none.
+func loadImportCheckpoint(beadsDir string) (*importCheckpoint, error) {
+	data, err := os.ReadFile(filepath.Join(beadsDir, importCheckpointFile)) //nolint:gosec
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read import checkpoint: %w", err)
+	}
+	var cp importCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("parse import checkpoint: %w", err)
+	}
+	return &cp, nil
+}
+
+func saveImportCheckpoint(beadsDir string, cp *importCheckpoint) error {
+	cp.UpdatedAt = time.Now().UTC()
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(filepath.Join(beadsDir, importCheckpointFile), data, 0o600)
+}
+
+func clearImportCheckpoint(beadsDir string) {
+	_ = os.Remove(filepath.Join(beadsDir, importCheckpointFile))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestImportCheckpointRoundTrip(t *testing.T) {
+	beadsDir := t.TempDir()
+	if cp, err := loadImportCheckpoint(beadsDir); err != nil || cp != nil {
+		t.Fatalf("empty dir: got %+v, %v", cp, err)
+	}
+
+	source := filepath.Join(t.TempDir(), "big.jsonl")
+	if err := os.WriteFile(source, []byte(`{"id":"bd-1","title":"x"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	hash, err := lineHashBefore(f, info.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := &importCheckpoint{
+		Source: source, Line: 1, Offset: info.Size(), LastLineHash: hash, SchemaVersion: 1, Batches: 1, Created: 1,
+		Deferred: []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-2000", Type: types.DepBlocks}},
+	}
+	if err := saveImportCheckpoint(beadsDir, cp); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadImportCheckpoint(beadsDir)
+	if err != nil || got == nil {
+		t.Fatalf("load: %+v, %v", got, err)
+	}
+	if got.Offset != info.Size() || got.Created != 1 || len(got.Deferred) != 1 || got.Deferred[0].DependsOnID != "bd-2000" {
+		t.Errorf("round trip = %+v", got)
+	}
+	if !got.matches(source, f) {
+		t.Error("checkpoint should match the unchanged source")
+	}
+	if got.matches(source+".other", f) {
+		t.Error("checkpoint should not match a different path")
+	}
+
+	clearImportCheckpoint(beadsDir)
+	if cp, _ := loadImportCheckpoint(beadsDir); cp != nil {
+		t.Errorf("checkpoint survived clear: %+v", cp)
+	}
+}
+
+func TestLineHashBefore(t *testing.T) {
+	hashOf := func(content string, offset int) string {
+		t.Helper()
+		h, err := lineHashBefore(strings.NewReader(content), int64(offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	lines := "{\"id\":\"a\"}\n{\"id\":\"b\"}\n{\"id\":\"c\"}\n"
+	afterB := strings.Index(lines, "{\"id\":\"c\"}")
+	want := hashOf("{\"id\":\"b\"}\n", len("{\"id\":\"b\"}\n"))
+
+	if got := hashOf(lines, afterB); got != want {
+		t.Error("hash of the line ending at offset should ignore earlier lines")
+	}
+	// Fixing a line after the checkpoint keeps it valid.
+	fixed := lines[:afterB] + "{\"id\":\"c\",\"title\":\"fixed\"}\n"
+	if got := hashOf(fixed, afterB); got != want {
+		t.Error("edits after the checkpoint should not change the hash")
+	}
+	// Inserting before it shifts the offset onto a different line.
+	shifted := "{\"id\":\"new\"}\n" + lines
+	if got := hashOf(shifted, afterB); got == want {
+		t.Error("edits before the checkpoint should change the hash")
+	}
+	// A final line without a trailing newline (offset counts one past EOF).
+	noNewline := strings.TrimSuffix(lines, "\n")
+	if got, last := hashOf(noNewline, len(lines)), hashOf("{\"id\":\"c\"}\n", len("{\"id\":\"c\"}\n")); got != last {
+		t.Error("final line without newline should hash like one with it")
+	}
+}
+
+func TestIndexBatchDependencies(t *testing.T) {
+	explicit := &types.Dependency{IssueID: "bd-1", DependsOnID: "bd-9", Type: types.DepBlocks}
+	implicit := &types.Dependency{DependsOnID: "bd-8", Type: types.DepRelated}
+	index := indexBatchDependencies([]*types.Issue{
+		{ID: "bd-1", Dependencies: []*types.Dependency{explicit, nil}},
+		{ID: "bd-2", Dependencies: []*types.Dependency{implicit}},
+	})
+	if index["bd-1\x00bd-9"] != explicit {
+		t.Error("explicit edge not indexed")
+	}
+	if index["bd-2\x00bd-8"] != implicit {
+		t.Error("edge without IssueID should be keyed by its owning issue")
+	}
+	if len(index) != 2 {
+		t.Errorf("index has %d entries, want 2", len(index))
+	}
+}
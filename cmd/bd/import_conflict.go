@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Import conflict strategies for 'bd import --on-conflict'. A conflict is an
+// incoming issue that differs from the local copy while the local copy was
+// updated after the incoming one (the row the stale guard would skip).
+// Incoming rows that are newer than the local copy are plain updates and are
+// applied under every strategy, as are label, comment, and dependency
+// additions, which never conflict.
+const (
+	// conflictNewest merges field by field against the common base: each
+	// side's non-overlapping edits are kept, and a field both sides changed
+	// keeps the newer (local) value.
+	conflictNewest = "newest"
+	// conflictOurs keeps the local copy of a conflicting issue whole.
+	conflictOurs = "ours"
+	// conflictTheirs takes the imported copy whole (same as --allow-stale).
+	conflictTheirs = "theirs"
+	// conflictInteractive merges like newest but asks about each field both
+	// sides changed.
+	conflictInteractive = "interactive"
+)
+
+// validateImportConflictStrategy checks --on-conflict against --allow-stale,
+// which is shorthand for theirs.
+func validateImportConflictStrategy(strategy string, allowStale bool) (string, error) {
+	switch strategy {
+	case conflictNewest, conflictOurs, conflictTheirs, conflictInteractive:
+	default:
+		return "", fmt.Errorf("invalid --on-conflict %q (want newest, ours, theirs, or interactive)", strategy)
+	}
+	if allowStale {
+		if strategy != conflictNewest && strategy != conflictTheirs {
+			return "", fmt.Errorf("--allow-stale is the same as --on-conflict theirs; it cannot be combined with --on-conflict %s", strategy)
+		}
+		return conflictTheirs, nil
+	}
+	return strategy, nil
+}
+
+// ImportConflict records how one conflicting issue was resolved.
+type ImportConflict struct {
+	ID       string `json:"id"`
+	Strategy string `json:"strategy"`
+	// KeptLocal and TookImported name the differing fields resolved to each
+	// side. Overlapping lists the fields both sides had changed.
+	KeptLocal    []string `json:"kept_local,omitempty"`
+	TookImported []string `json:"took_imported,omitempty"`
+	Overlapping  []string `json:"overlapping,omitempty"`
+}
+
+// describe lists which side each differing field was resolved to.
+func (c ImportConflict) describe() string {
+	var parts []string
+	if len(c.KeptLocal) > 0 {
+		parts = append(parts, "kept local "+strings.Join(c.KeptLocal, ", "))
+	}
+	if len(c.TookImported) > 0 {
+		parts = append(parts, "took imported "+strings.Join(c.TookImported, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// summary is the event text recorded on the issue.
+func (c ImportConflict) summary(source string) string {
+	return fmt.Sprintf("Import conflict resolved (%s) from %s: %s", c.Strategy, filepath.Base(source), c.describe())
+}
+
+// historyBaseFields are the columns storage.HistoryViewer returns, and so the
+// only fields whose common-base value is known. A field outside this set
+// that differs is treated as changed on both sides.
+var historyBaseFields = map[string]bool{
+	"title": true, "description": true, "design": true, "acceptance_criteria": true, "notes": true,
+	"status": true, "priority": true, "issue_type": true, "assignee": true, "owner": true,
+	"created_by": true, "estimated_minutes": true, "created_at": true, "closed_at": true,
+	"close_reason": true, "pinned": true, "mol_type": true,
+}
+
+// importConflictResolver applies an --on-conflict strategy to each batch
+// before it is written.
+type importConflictResolver struct {
+	strategy string
+	// ask chooses a side for a field both sides changed; nil (dry run) keeps
+	// the local value.
+	ask func(issue *types.Issue, field string, local, imported any) (takeImported bool, err error)
+}
+
+// resolve rewrites the conflicting issues in batch in place and returns what
+// it decided. An issue resolved entirely to the local side is left as is, so
+// the stale guard skips it exactly as before; a merged issue takes the local
+// updated_at, so the guard lets it through unless the local copy changes
+// again before the write.
+func (r *importConflictResolver) resolve(ctx context.Context, st storage.DoltStorage, batch []*types.Issue) ([]ImportConflict, error) {
+	ids := make([]string, 0, len(batch))
+	for _, issue := range batch {
+		if issue.ID != "" && !issue.UpdatedAt.IsZero() {
+			ids = append(ids, issue.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	existing, err := st.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("load existing issues: %w", err)
+	}
+	local := make(map[string]*types.Issue, len(existing))
+	for _, issue := range existing {
+		local[issue.ID] = issue
+	}
+
+	var conflicts []ImportConflict
+	for i, incoming := range batch {
+		l := local[incoming.ID]
+		if l == nil || incoming.UpdatedAt.IsZero() || !incoming.UpdatedAt.UTC().Before(l.UpdatedAt.UTC()) {
+			continue
+		}
+		changes := diffIssueFields(l, incoming)
+		if len(changes) == 0 {
+			continue
+		}
+		conflict, keepLocal, err := r.resolveIssue(ctx, st, l, incoming, changes)
+		if err != nil {
+			return nil, err
+		}
+		if conflict == nil {
+			continue
+		}
+		conflicts = append(conflicts, *conflict)
+		if r.strategy == conflictTheirs || len(conflict.TookImported) == 0 {
+			continue
+		}
+		merged, err := mergeIssueFields(incoming, l, keepLocal)
+		if err != nil {
+			return nil, fmt.Errorf("merge %s: %w", incoming.ID, err)
+		}
+		batch[i] = merged
+	}
+	return conflicts, nil
+}
+
+// resolveIssue decides each differing field of one stale row. It returns a
+// nil conflict when the row is simply an older copy (only the local side
+// changed anything), which the stale guard handles on its own.
+func (r *importConflictResolver) resolveIssue(ctx context.Context, st storage.DoltStorage, local, incoming *types.Issue, changes []ImportFieldChange) (*ImportConflict, []string, error) {
+	// The base also tells ours and theirs whether they discarded a real
+	// edit, which is what makes the row a conflict worth recording.
+	var base map[string]any
+	if b := historyBase(ctx, st, local.ID, incoming.UpdatedAt); b != nil {
+		base = issueFieldMap(b)
+	}
+	return r.decide(incoming, base, changes)
+}
+
+// decide resolves each change given the base field values (nil when the
+// base is unknown, which makes every field overlapping).
+func (r *importConflictResolver) decide(incoming *types.Issue, base map[string]any, changes []ImportFieldChange) (*ImportConflict, []string, error) {
+	conflict := &ImportConflict{ID: incoming.ID, Strategy: r.strategy}
+	var keepLocal []string
+	discarded := false
+	for _, c := range changes {
+		oursChanged, theirsChanged := true, true
+		if base != nil && historyBaseFields[c.Field] {
+			oursChanged = !previewValuesEqual(base[c.Field], c.Old)
+			theirsChanged = !previewValuesEqual(base[c.Field], c.New)
+		}
+
+		overlapping := oursChanged && theirsChanged
+		if overlapping {
+			conflict.Overlapping = append(conflict.Overlapping, c.Field)
+		}
+
+		var takeImported bool
+		switch {
+		case r.strategy == conflictOurs:
+			takeImported = false
+		case r.strategy == conflictTheirs:
+			takeImported = true
+		case !overlapping:
+			takeImported = !oursChanged
+		default:
+			if r.strategy == conflictInteractive && r.ask != nil {
+				var err error
+				if takeImported, err = r.ask(incoming, c.Field, c.Old, c.New); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		if takeImported {
+			conflict.TookImported = append(conflict.TookImported, c.Field)
+			discarded = discarded || oursChanged
+		} else {
+			conflict.KeptLocal = append(conflict.KeptLocal, c.Field)
+			keepLocal = append(keepLocal, c.Field)
+			discarded = discarded || theirsChanged
+		}
+	}
+	if !discarded && len(conflict.TookImported) == 0 {
+		return nil, nil, nil
+	}
+	return conflict, keepLocal, nil
+}
+
+// historyBase returns the local version of an issue as it stood when the
+// incoming copy was last updated: the newest committed version whose
+// updated_at is not after at. It returns nil when history is unavailable.
+func historyBase(ctx context.Context, st storage.DoltStorage, id string, at time.Time) *types.Issue {
+	entries, err := st.History(ctx, id)
+	if err != nil {
+		return nil
+	}
+	at = at.UTC().Truncate(time.Second)
+	var base *types.Issue
+	for _, e := range entries {
+		if e.Issue == nil || e.Issue.UpdatedAt.UTC().Truncate(time.Second).After(at) {
+			continue
+		}
+		if base == nil || e.Issue.UpdatedAt.After(base.UpdatedAt) {
+			base = e.Issue
+		}
+	}
+	return base
+}
+
+// mergeIssueFields returns a copy of incoming with the named fields taken
+// from local, stamped with local's updated_at.
+func mergeIssueFields(incoming, local *types.Issue, fields []string) (*types.Issue, error) {
+	merged := issueFieldMap(incoming)
+	localFields := issueFieldMap(local)
+	for _, f := range fields {
+		if v, ok := localFields[f]; ok {
+			merged[f] = v
+		} else {
+			delete(merged, f)
+		}
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	var out types.Issue
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	out.UpdatedAt = local.UpdatedAt
+	out.SourceRepo, out.IDPrefix, out.PrefixOverride = incoming.SourceRepo, incoming.IDPrefix, incoming.PrefixOverride
+	return &out, nil
+}
+
+// recordImportConflicts adds an audit event to each resolved issue.
+func recordImportConflicts(ctx context.Context, st storage.DoltStorage, conflicts []ImportConflict, source string) {
+	actor := getActorWithGit()
+	for _, c := range conflicts {
+		if err := st.AddComment(ctx, c.ID, actor, c.summary(source)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record conflict resolution on %s: %v\n", c.ID, err)
+		}
+	}
+}
+
+// newConflictPrompter asks on the terminal which side of an overlapping edit
+// to keep. "O" or "T" answers every remaining question the same way.
+func newConflictPrompter() func(*types.Issue, string, any, any) (bool, error) {
+	reader := bufio.NewReader(os.Stdin)
+	var always *bool
+	return func(issue *types.Issue, field string, local, imported any) (bool, error) {
+		if always != nil {
+			return *always, nil
+		}
+		fmt.Fprintf(os.Stderr, "\n%s %s: both sides changed %s\n", issue.ID, truncateTitle(issue.Title, 50), field)
+		fmt.Fprintf(os.Stderr, "  local:    %s\n  imported: %s\n", formatPreviewValue(local), formatPreviewValue(imported))
+		for {
+			fmt.Fprint(os.Stderr, "Keep [o]urs or take [t]heirs? (O/T for all remaining) [o]: ")
+			line, err := readLineWithContext(getRootContext(), reader, os.Stdin)
+			if err != nil {
+				return false, err
+			}
+			switch answer := strings.TrimSpace(line); answer {
+			case "", "o", "ours":
+				return false, nil
+			case "t", "theirs":
+				return true, nil
+			case "O", "T":
+				v := answer == "T"
+				always = &v
+				return v, nil
+			}
+		}
+	}
+}
+
+// interactiveImportAvailable reports whether prompts can be shown: stdin must
+// be a terminal and must not be the JSONL source.
+func interactiveImportAvailable(fromStdin bool) error {
+	if fromStdin || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("--on-conflict interactive needs a terminal on stdin (and a file source, not '-')")
+	}
+	return nil
+}
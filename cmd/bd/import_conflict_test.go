@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateImportConflictStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		strategy   string
+		allowStale bool
+		want       string
+		wantErr    bool
+	}{
+		{strategy: "newest", want: "newest"},
+		{strategy: "ours", want: "ours"},
+		{strategy: "newest", allowStale: true, want: "theirs"},
+		{strategy: "theirs", allowStale: true, want: "theirs"},
+		{strategy: "ours", allowStale: true, wantErr: true},
+		{strategy: "mine", wantErr: true},
+	} {
+		got, err := validateImportConflictStrategy(tc.strategy, tc.allowStale)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("validate(%q, %v) = %q, %v", tc.strategy, tc.allowStale, got, err)
+		}
+	}
+}
+
+func TestImportConflictDecide(t *testing.T) {
+	incoming := &types.Issue{ID: "bd-1", Title: "Base"}
+	// Local renamed the issue; the import changed priority; both changed notes.
+	base := map[string]any{"title": "Base", "priority": float64(2), "notes": "base"}
+	changes := []ImportFieldChange{
+		{Field: "notes", Old: "local", New: "imported"},
+		{Field: "priority", Old: float64(2), New: float64(0)},
+		{Field: "title", Old: "Local", New: "Base"},
+	}
+
+	for _, tc := range []struct {
+		strategy     string
+		ask          bool
+		keptLocal    []string
+		tookImported []string
+	}{
+		{strategy: conflictNewest, keptLocal: []string{"notes", "title"}, tookImported: []string{"priority"}},
+		{strategy: conflictInteractive, ask: true, keptLocal: []string{"title"}, tookImported: []string{"notes", "priority"}},
+		{strategy: conflictOurs, keptLocal: []string{"notes", "priority", "title"}},
+		{strategy: conflictTheirs, tookImported: []string{"notes", "priority", "title"}},
+	} {
+		r := &importConflictResolver{strategy: tc.strategy}
+		var asked []string
+		if tc.ask {
+			r.ask = func(_ *types.Issue, field string, _, _ any) (bool, error) {
+				asked = append(asked, field)
+				return true, nil
+			}
+		}
+		c, keep, err := r.decide(incoming, base, changes)
+		if err != nil || c == nil {
+			t.Fatalf("%s: decide = %+v, %v", tc.strategy, c, err)
+		}
+		if !reflect.DeepEqual(c.KeptLocal, tc.keptLocal) || !reflect.DeepEqual(c.TookImported, tc.tookImported) {
+			t.Errorf("%s: kept %v took %v", tc.strategy, c.KeptLocal, c.TookImported)
+		}
+		if !reflect.DeepEqual(keep, tc.keptLocal) {
+			t.Errorf("%s: keepLocal = %v", tc.strategy, keep)
+		}
+		if !reflect.DeepEqual(c.Overlapping, []string{"notes"}) {
+			t.Errorf("%s: overlapping = %v", tc.strategy, c.Overlapping)
+		}
+		if tc.ask && !reflect.DeepEqual(asked, []string{"notes"}) {
+			t.Errorf("%s: asked about %v", tc.strategy, asked)
+		}
+	}
+
+	// An older copy with no edits of its own is not a conflict.
+	r := &importConflictResolver{strategy: conflictNewest}
+	c, _, err := r.decide(incoming, base, changes[2:])
+	if err != nil || c != nil {
+		t.Errorf("stale copy: decide = %+v, %v", c, err)
+	}
+
+	// Without a base every differing field overlaps and newest keeps local.
+	c, _, _ = r.decide(incoming, nil, changes[1:2])
+	if c == nil || !reflect.DeepEqual(c.KeptLocal, []string{"priority"}) {
+		t.Errorf("no base: decide = %+v", c)
+	}
+}
+
+func TestMergeIssueFields(t *testing.T) {
+	localAt := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	local := &types.Issue{ID: "bd-1", Title: "Local", Priority: 1, Assignee: "", UpdatedAt: localAt}
+	incoming := &types.Issue{
+		ID: "bd-1", Title: "Imported", Priority: 0, Assignee: "bob", SourceRepo: "peer",
+		UpdatedAt: localAt.Add(-time.Hour), Labels: []string{"x"},
+	}
+	merged, err := mergeIssueFields(incoming, local, []string{"title", "assignee"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Title != "Local" || merged.Assignee != "" || merged.Priority != 0 {
+		t.Errorf("merged = %q/%q/P%d", merged.Title, merged.Assignee, merged.Priority)
+	}
+	if !merged.UpdatedAt.Equal(localAt) || merged.SourceRepo != "peer" || len(merged.Labels) != 1 {
+		t.Errorf("merged updated_at %v, source %q, labels %v", merged.UpdatedAt, merged.SourceRepo, merged.Labels)
+	}
+	if incoming.Title != "Imported" {
+		t.Error("merge modified the incoming issue")
+	}
+}
@@ -111,6 +111,50 @@ func TestEmbeddedImport(t *testing.T) {
 		}
 	})
 
+	t.Run("auto_on_stale", func(t *testing.T) {
+		dir, _, _ := bdInit(t, bd, "--prefix", "imstale")
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "import.auto-on-stale", "true"); err != nil {
+			t.Fatalf("config set: %v\n%s", err, out)
+		}
+		list := func() (stdout, stderr string) {
+			t.Helper()
+			cmd := exec.Command(bd, "list", "--json")
+			cmd.Dir = dir
+			cmd.Env = bdEnv(dir)
+			out, errOut, err := runCommandBuffers(t, cmd)
+			if err != nil {
+				t.Fatalf("bd list: %v\n%s%s", err, out.String(), errOut.String())
+			}
+			return out.String(), errOut.String()
+		}
+
+		// Nothing newer than the last import: list stays read-only.
+		if _, stderr := list(); strings.Contains(stderr, "Refreshed") {
+			t.Fatalf("list refreshed without a JSONL file: %s", stderr)
+		}
+
+		// last_import_time has second precision; keep the file's mtime
+		// strictly between it and the refresh.
+		time.Sleep(2 * time.Second)
+		jsonlPath := filepath.Join(dir, ".beads", "issues.jsonl")
+		now := time.Now().UTC()
+		writeJSONLFile(t, jsonlPath, []types.Issue{
+			{ID: "imstale-aaa", Title: "Stale Refresh Issue", Status: types.StatusOpen, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		})
+		mtime := time.Now().Add(-time.Second)
+		if err := os.Chtimes(jsonlPath, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+
+		stdout, stderr := list()
+		if !strings.Contains(stderr, "Refreshed 1 issues") || !strings.Contains(stdout, "imstale-aaa") {
+			t.Fatalf("stale list did not refresh:\nstdout: %s\nstderr: %s", stdout, stderr)
+		}
+		if _, stderr := list(); strings.Contains(stderr, "Refreshed") {
+			t.Errorf("list refreshed again after the import: %s", stderr)
+		}
+	})
+
 	t.Run("dry_run", func(t *testing.T) {
 		dir, _, _ := bdInit(t, bd, "--prefix", "imdry")
 
@@ -121,8 +165,8 @@ func TestEmbeddedImport(t *testing.T) {
 		})
 
 		out := bdImport(t, bd, dir, "--dry-run")
-		if !strings.Contains(out, "Would import") {
-			t.Errorf("expected 'Would import' in dry-run output, got: %s", out)
+		if !strings.Contains(out, "Import preview") || !strings.Contains(out, "+ imdry-qqq") {
+			t.Errorf("expected a preview creating imdry-qqq in dry-run output, got: %s", out)
 		}
 
 		// Verify issue was NOT actually created
@@ -0,0 +1,751 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// markdownFrontMatter is the YAML header of an issue file. Pointer and slice
+// fields distinguish "left out" (not managed by the file) from "set to empty".
+type markdownFrontMatter struct {
+	ID          string    `yaml:"id,omitempty"`
+	Title       string    `yaml:"title,omitempty"`
+	Status      string    `yaml:"status,omitempty"`
+	Priority    *int      `yaml:"priority,omitempty"`
+	Type        string    `yaml:"type,omitempty"`
+	Assignee    *string   `yaml:"assignee,omitempty"`
+	Labels      *[]string `yaml:"labels,omitempty"`
+	Parent      *string   `yaml:"parent,omitempty"`
+	Deps        *[]string `yaml:"deps,omitempty"`
+	ExternalRef string    `yaml:"external_ref,omitempty"`
+}
+
+// markdownIssue is one parsed issue file.
+type markdownIssue struct {
+	Path               string // relative to the imported directory
+	Key                string // Path without .md, used by deps/parent references
+	Front              markdownFrontMatter
+	Title              string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+}
+
+// markdownSections maps the H2 headings that hold issue fields (compared
+// case-insensitively) to the field they fill. Other headings stay in the
+// surrounding section's text.
+var markdownSections = map[string]string{
+	"design":              "design",
+	"acceptance criteria": "acceptance_criteria",
+	"notes":               "notes",
+}
+
+var (
+	markdownH1Regex = regexp.MustCompile(`^#\s+(.+?)\s*#*\s*$`)
+	markdownH2Regex = regexp.MustCompile(`^##\s+(.+?)\s*#*\s*$`)
+)
+
+// markdownBOM is the UTF-8 byte order mark some editors write.
+const markdownBOM = "\uFEFF"
+
+// errNoFrontMatter marks Markdown files that are not issue files.
+var errNoFrontMatter = errors.New("no front matter")
+
+// parseMarkdownIssue parses one issue file. Files that do not start with a
+// "---" front matter block return errNoFrontMatter.
+func parseMarkdownIssue(rel string, data []byte) (*markdownIssue, error) {
+	data = bytes.TrimPrefix(data, []byte(markdownBOM))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, errNoFrontMatter
+	}
+	rest := text[len("---\n"):]
+	end := strings.Index(rest, "\n---\n")
+	var header, body string
+	switch {
+	case end >= 0:
+		header, body = rest[:end], rest[end+len("\n---\n"):]
+	case strings.HasSuffix(rest, "\n---"):
+		header = strings.TrimSuffix(rest, "\n---")
+	default:
+		return nil, fmt.Errorf("%s: front matter is not closed with ---", rel)
+	}
+
+	issue := &markdownIssue{Path: filepath.ToSlash(rel)}
+	issue.Key = strings.TrimSuffix(issue.Path, filepath.Ext(issue.Path))
+	dec := yaml.NewDecoder(strings.NewReader(header))
+	dec.KnownFields(true)
+	if err := dec.Decode(&issue.Front); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: front matter: %w", rel, err)
+	}
+	issue.Front.ID = strings.TrimSpace(issue.Front.ID)
+
+	sections := map[string]*strings.Builder{"description": {}}
+	current := "description"
+	inFence := false
+	sawContent := false
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if !sawContent && trimmed == "" {
+				continue
+			}
+			if !sawContent && issue.Title == "" {
+				if m := markdownH1Regex.FindStringSubmatch(line); m != nil {
+					issue.Title = m[1]
+					sawContent = true
+					continue
+				}
+			}
+			if m := markdownH2Regex.FindStringSubmatch(line); m != nil {
+				if field, ok := markdownSections[strings.ToLower(m[1])]; ok {
+					current = field
+					if sections[field] == nil {
+						sections[field] = &strings.Builder{}
+					}
+					sawContent = true
+					continue
+				}
+			}
+		}
+		sawContent = true
+		sections[current].WriteString(line)
+		sections[current].WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	section := func(name string) string {
+		if b := sections[name]; b != nil {
+			return strings.TrimSpace(b.String())
+		}
+		return ""
+	}
+	issue.Description = section("description")
+	issue.Design = section("design")
+	issue.AcceptanceCriteria = section("acceptance_criteria")
+	issue.Notes = section("notes")
+
+	if t := strings.TrimSpace(issue.Front.Title); t != "" {
+		issue.Title = t
+	}
+	if issue.Title == "" {
+		issue.Title = strings.TrimSuffix(filepath.Base(rel), filepath.Ext(rel))
+	}
+	if p := issue.Front.Priority; p != nil && (*p < 0 || *p > 4) {
+		return nil, fmt.Errorf("%s: priority must be 0-4, got %d", rel, *p)
+	}
+	for _, raw := range issue.deps() {
+		if _, err := parseMarkdownDep(raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", rel, err)
+		}
+	}
+	return issue, nil
+}
+
+func (m *markdownIssue) deps() []string {
+	if m.Front.Deps == nil {
+		return nil
+	}
+	return *m.Front.Deps
+}
+
+// parseMarkdownDep parses a deps entry: "ref" (blocks) or "type:ref". As in
+// bd create --file, every entry is an edge from this issue to ref.
+func parseMarkdownDep(raw string) (specDep, error) {
+	raw = strings.TrimSpace(raw)
+	depType, ref, typed := strings.Cut(raw, ":")
+	if !typed {
+		return specDep{depType: string(types.DepBlocks), ref: raw}, nil
+	}
+	depType, ref = strings.TrimSpace(depType), strings.TrimSpace(ref)
+	dt := types.DependencyType(depType)
+	if ref == "" || !dt.IsWellKnown() || dt == types.DepParentChild {
+		return specDep{}, fmt.Errorf("invalid dependency %q: want an issue ID or 'type:ID' with a dependency type other than parent-child (use parent:)", raw)
+	}
+	return specDep{depType: depType, ref: ref}, nil
+}
+
+// readMarkdownIssueDir parses every *.md issue file under dir. Markdown files
+// without front matter are returned as skipped rather than imported.
+func readMarkdownIssueDir(dir string) ([]*markdownIssue, []string, error) {
+	var issues []*markdownIssue
+	var skipped []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- walking a user-provided directory
+		if err != nil {
+			return err
+		}
+		issue, err := parseMarkdownIssue(rel, data)
+		if errors.Is(err, errNoFrontMatter) {
+			skipped = append(skipped, filepath.ToSlash(rel))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		issues = append(issues, issue)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := map[string]string{}
+	for _, issue := range issues {
+		if id := issue.Front.ID; id != "" {
+			if prev, ok := ids[id]; ok {
+				return nil, nil, fmt.Errorf("%s and %s both declare id %s", prev, issue.Path, id)
+			}
+			ids[id] = issue.Path
+		}
+	}
+	return issues, skipped, nil
+}
+
+// markdownImportEntry reports what happened to one file.
+type markdownImportEntry struct {
+	File    string   `json:"file"`
+	ID      string   `json:"id,omitempty"` // empty for a planned create without an id
+	Title   string   `json:"title"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// markdownImportResult is the bd import markdown --json output.
+type markdownImportResult struct {
+	Dir        string                `json:"dir"`
+	Created    []markdownImportEntry `json:"created"`
+	Updated    []markdownImportEntry `json:"updated"`
+	Unchanged  int                   `json:"unchanged"`
+	Skipped    []string              `json:"skipped,omitempty"` // files without front matter
+	IDsWritten []string              `json:"ids_written,omitempty"`
+	DryRun     bool                  `json:"dry_run,omitempty"`
+}
+
+// markdownIssueReader is the read side shared by the store and a transaction.
+type markdownIssueReader interface {
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+	GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error)
+}
+
+// markdownPlan is the write plan for one file.
+type markdownPlan struct {
+	file         *markdownIssue
+	create       *types.Issue // set when the issue does not exist yet
+	id           string       // existing or explicit ID; filled in on create
+	fields       map[string]interface{}
+	addLabels    []string
+	removeLabels []string
+	addDeps      []specDep
+	removeDeps   []string
+	setParent    *string // ref of the new parent ("" to unlink), nil to leave alone
+	oldParents   []string
+	changes      []string
+}
+
+// planMarkdownImport works out the writes that bring the database in line
+// with files. deps and parent may name other files by key; those resolve to
+// the file's id, or to the issue a new file creates when the plan is applied.
+func planMarkdownImport(ctx context.Context, r markdownIssueReader, files []*markdownIssue) ([]*markdownPlan, error) {
+	keyIDs := map[string]string{}
+	for _, f := range files {
+		if f.Front.ID != "" {
+			keyIDs[f.Key] = f.Front.ID
+		}
+	}
+	resolve := func(ref string) string {
+		if id, ok := keyIDs[ref]; ok {
+			return id
+		}
+		return ref
+	}
+
+	plans := make([]*markdownPlan, 0, len(files))
+	for _, f := range files {
+		p := &markdownPlan{file: f, id: f.Front.ID}
+		var existing *types.Issue
+		if p.id != "" {
+			issue, err := r.GetIssue(ctx, p.id)
+			switch {
+			case err == nil:
+				existing = issue
+			case !errors.Is(err, storage.ErrNotFound):
+				return nil, fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+
+		var labels []string
+		var deps []*types.Dependency
+		if existing == nil {
+			p.create = markdownNewIssue(f)
+		} else {
+			p.fields, p.changes = markdownFieldUpdates(existing, f)
+			var err error
+			if labels, err = r.GetLabels(ctx, p.id); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Path, err)
+			}
+			if deps, err = r.GetDependencyRecords(ctx, p.id); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+
+		if f.Front.Labels != nil {
+			want := normalizeLabels(*f.Front.Labels)
+			for _, l := range want {
+				if !slices.Contains(labels, l) {
+					p.addLabels = append(p.addLabels, l)
+				}
+			}
+			for _, l := range labels {
+				if !slices.Contains(want, l) {
+					p.removeLabels = append(p.removeLabels, l)
+				}
+			}
+			if len(p.addLabels)+len(p.removeLabels) > 0 {
+				p.changes = append(p.changes, "labels")
+			}
+		}
+
+		if f.Front.Deps != nil {
+			want := map[string]specDep{}
+			for _, raw := range *f.Front.Deps {
+				d, _ := parseMarkdownDep(raw) // validated while parsing
+				want[resolve(d.ref)] = d
+			}
+			have := map[string]types.DependencyType{}
+			for _, dep := range deps {
+				if dep.Type != types.DepParentChild {
+					have[dep.DependsOnID] = dep.Type
+				}
+			}
+			for target, d := range want {
+				if t, ok := have[target]; !ok || string(t) != d.depType {
+					if ok {
+						p.removeDeps = append(p.removeDeps, target)
+					}
+					p.addDeps = append(p.addDeps, d)
+				}
+			}
+			for target := range have {
+				if _, ok := want[target]; !ok {
+					p.removeDeps = append(p.removeDeps, target)
+				}
+			}
+			sort.Strings(p.removeDeps)
+			sort.Slice(p.addDeps, func(i, j int) bool { return p.addDeps[i].ref < p.addDeps[j].ref })
+			if len(p.addDeps)+len(p.removeDeps) > 0 {
+				p.changes = append(p.changes, "deps")
+			}
+		}
+
+		if f.Front.Parent != nil {
+			want := strings.TrimSpace(*f.Front.Parent)
+			var current []string
+			for _, dep := range deps {
+				if dep.Type == types.DepParentChild {
+					current = append(current, dep.DependsOnID)
+				}
+			}
+			if target := resolve(want); !(len(current) == 1 && current[0] == target) && !(want == "" && len(current) == 0) {
+				p.setParent = &want
+				p.oldParents = current
+				p.changes = append(p.changes, "parent")
+			}
+		}
+		plans = append(plans, p)
+	}
+	return plans, nil
+}
+
+// markdownNewIssue builds the issue a file creates.
+func markdownNewIssue(f *markdownIssue) *types.Issue {
+	issue := &types.Issue{
+		ID:                 f.Front.ID,
+		Title:              f.Title,
+		Description:        f.Description,
+		Design:             f.Design,
+		AcceptanceCriteria: f.AcceptanceCriteria,
+		Notes:              f.Notes,
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+	}
+	if f.Front.Status != "" {
+		issue.Status = types.Status(f.Front.Status)
+	}
+	if f.Front.Priority != nil {
+		issue.Priority = *f.Front.Priority
+	}
+	if f.Front.Type != "" {
+		issue.IssueType = types.IssueType(f.Front.Type)
+	}
+	if f.Front.Assignee != nil {
+		issue.Assignee = *f.Front.Assignee
+	}
+	if f.Front.ExternalRef != "" {
+		ref := f.Front.ExternalRef
+		issue.ExternalRef = &ref
+	}
+	return issue
+}
+
+// markdownFieldUpdates compares an existing issue with its file. The title
+// and body sections are always managed by the file; front matter fields
+// only when present.
+func markdownFieldUpdates(existing *types.Issue, f *markdownIssue) (map[string]interface{}, []string) {
+	updates := map[string]interface{}{}
+	var changes []string
+	set := func(field string, value interface{}) {
+		updates[field] = value
+		changes = append(changes, field)
+	}
+	text := func(field, have, want string) {
+		if strings.TrimSpace(have) != want {
+			set(field, want)
+		}
+	}
+	text("title", existing.Title, f.Title)
+	text("description", existing.Description, f.Description)
+	text("design", existing.Design, f.Design)
+	text("acceptance_criteria", existing.AcceptanceCriteria, f.AcceptanceCriteria)
+	text("notes", existing.Notes, f.Notes)
+	if s := f.Front.Status; s != "" && s != string(existing.Status) {
+		set("status", s)
+	}
+	if p := f.Front.Priority; p != nil && *p != existing.Priority {
+		set("priority", *p)
+	}
+	if t := f.Front.Type; t != "" && t != string(existing.IssueType) {
+		set("issue_type", t)
+	}
+	if a := f.Front.Assignee; a != nil && *a != existing.Assignee {
+		set("assignee", *a)
+	}
+	if ref := f.Front.ExternalRef; ref != "" && (existing.ExternalRef == nil || *existing.ExternalRef != ref) {
+		set("external_ref", ref)
+	}
+	return updates, changes
+}
+
+func normalizeLabels(labels []string) []string {
+	var out []string
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l != "" && !slices.Contains(out, l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// applyMarkdownPlans performs plans inside tx: creates first so references
+// between new files resolve, then field, label, and dependency changes.
+func applyMarkdownPlans(ctx context.Context, tx storage.Transaction, plans []*markdownPlan, actor string) error {
+	keyIDs := map[string]string{}
+	for _, p := range plans {
+		if p.create != nil {
+			issue := *p.create
+			issue.CreatedBy = actor
+			if err := tx.CreateIssue(ctx, &issue, actor); err != nil {
+				return fmt.Errorf("%s: %w", p.file.Path, err)
+			}
+			p.id = issue.ID
+		}
+		keyIDs[p.file.Key] = p.id
+	}
+	resolve := func(ref string) string {
+		if id, ok := keyIDs[ref]; ok {
+			return id
+		}
+		return ref
+	}
+
+	for _, p := range plans {
+		if len(p.fields) > 0 {
+			if err := tx.UpdateIssue(ctx, p.id, p.fields, actor); err != nil {
+				return fmt.Errorf("%s: %w", p.file.Path, err)
+			}
+		}
+		for _, l := range p.removeLabels {
+			if err := tx.RemoveLabel(ctx, p.id, l, actor); err != nil {
+				return fmt.Errorf("%s: %w", p.file.Path, err)
+			}
+		}
+		for _, l := range p.addLabels {
+			if err := tx.AddLabel(ctx, p.id, l, actor); err != nil {
+				return fmt.Errorf("%s: %w", p.file.Path, err)
+			}
+		}
+		for _, target := range p.removeDeps {
+			if err := tx.RemoveDependency(ctx, p.id, target, actor); err != nil {
+				return fmt.Errorf("%s: %w", p.file.Path, err)
+			}
+		}
+		for _, d := range p.addDeps {
+			dep := &types.Dependency{IssueID: p.id, DependsOnID: resolve(d.ref), Type: types.DependencyType(d.depType)}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return fmt.Errorf("%s: dependency on %s: %w", p.file.Path, dep.DependsOnID, err)
+			}
+		}
+		if p.setParent != nil {
+			for _, old := range p.oldParents {
+				if err := tx.RemoveDependency(ctx, p.id, old, actor); err != nil {
+					return fmt.Errorf("%s: %w", p.file.Path, err)
+				}
+			}
+			if *p.setParent != "" {
+				dep := &types.Dependency{IssueID: p.id, DependsOnID: resolve(*p.setParent), Type: types.DepParentChild}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("%s: parent %s: %w", p.file.Path, dep.DependsOnID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writeMarkdownID inserts "id: <id>" as the first front matter line of the
+// file at path, leaving the rest of the file byte-for-byte unchanged.
+func writeMarkdownID(path, id string) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- file from the imported directory
+	if err != nil {
+		return err
+	}
+	bom := bytes.HasPrefix(data, []byte(markdownBOM))
+	data = bytes.TrimPrefix(data, []byte(markdownBOM))
+	nl := "\n"
+	if bytes.HasPrefix(data, []byte("---\r\n")) {
+		nl = "\r\n"
+	}
+	open := "---" + nl
+	if !bytes.HasPrefix(data, []byte(open)) {
+		return fmt.Errorf("%s: no front matter", path)
+	}
+	var out bytes.Buffer
+	if bom {
+		out.WriteString(markdownBOM)
+	}
+	out.WriteString(open)
+	out.WriteString("id: " + id + nl)
+	out.Write(data[len(open):])
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out.Bytes(), info.Mode().Perm())
+}
+
+var importMarkdownCmd = &cobra.Command{
+	Use:   "markdown <dir>",
+	Short: "Import issues from a directory of Markdown files",
+	Long: `Import issues from a directory of Markdown files, one issue per file.
+
+Every *.md file under <dir> that starts with a YAML front matter block is an
+issue file; other Markdown files (a README, say) are skipped. A file looks
+like this:
+
+  ---
+  id: bd-12                  # omit for a new issue
+  status: open
+  priority: 1
+  type: feature
+  assignee: alice
+  labels: [auth, backend]
+  parent: auth/epic          # an ID or another file's path without .md
+  deps: [bd-3, related:bd-9] # "ref" (blocks) or "type:ref"
+  external_ref: gh-42
+  ---
+  # Single sign-on
+
+  Description in Markdown.
+
+  ## Design
+  ...
+  ## Acceptance Criteria
+  ...
+  ## Notes
+  ...
+
+The title comes from front matter "title", else the first "# " heading, else
+the file name. Text before the first Design / Acceptance Criteria / Notes
+section is the description; those sections fill the matching fields.
+
+The files are the source of truth for what they contain. The title and the
+four text fields are always set from the file. Front matter fields that are
+left out are not managed: their values in the database are kept. labels,
+deps, and parent replace the current set when present; write "labels: []"
+to clear labels.
+
+Files with an id update that issue, or create it with that ID if it does not
+exist. Files without one create a new issue, and the assigned ID is written
+back into the file's front matter so the next import updates it instead of
+creating a duplicate (--no-write-ids to leave the files untouched). deps
+and parent may name other files in the directory by path, so a plan can be
+written before any issue exists.
+
+Everything is imported in one transaction: an error in any file rolls back
+the whole import. --dry-run shows what would change without writing.
+
+'bd export markdown' writes the same format, so issues can round-trip
+between the database and a docs directory.
+
+Examples:
+  bd import markdown docs/issues/
+  bd import markdown docs/issues/ --dry-run
+  bd import markdown plans/ --no-write-ids --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportMarkdown,
+}
+
+func init() {
+	importMarkdownCmd.Flags().Bool("dry-run", false, "Show what would be created and updated without writing")
+	importMarkdownCmd.Flags().Bool("no-write-ids", false, "Do not write assigned IDs back into new files")
+	importCmd.AddCommand(importMarkdownCmd)
+}
+
+func runImportMarkdown(cmd *cobra.Command, args []string) error {
+	ctx := rootCtx
+	dir := args[0]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	noWriteIDs, _ := cmd.Flags().GetBool("no-write-ids")
+	if store == nil {
+		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("cannot read %s: %w", dir, err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	files, skipped, err := readMarkdownIssueDir(dir)
+	if err != nil {
+		return err
+	}
+	result := markdownImportResult{Dir: dir, Skipped: skipped, DryRun: dryRun, Created: []markdownImportEntry{}, Updated: []markdownImportEntry{}}
+	if !dryRun {
+		CheckReadonly("import markdown")
+	}
+
+	var plans []*markdownPlan
+	if dryRun {
+		if plans, err = planMarkdownImport(ctx, store, files); err != nil {
+			return err
+		}
+	} else {
+		actor := getActor()
+		msg := fmt.Sprintf("bd: import markdown %d file(s) from %s", len(files), filepath.Base(filepath.Clean(dir)))
+		err = transactHonoringAutoCommit(ctx, store, msg, func(tx storage.Transaction) error {
+			// transact may retry fn, so plan against the transaction each time.
+			var err error
+			if plans, err = planMarkdownImport(ctx, tx, files); err != nil {
+				return err
+			}
+			return applyMarkdownPlans(ctx, tx, plans, actor)
+		})
+		if err != nil {
+			return err
+		}
+		commandDidWrite.Store(true)
+	}
+
+	for _, p := range plans {
+		entry := markdownImportEntry{File: p.file.Path, ID: p.id, Title: p.file.Title, Changes: p.changes}
+		switch {
+		case p.create != nil:
+			result.Created = append(result.Created, entry)
+		case len(p.changes) > 0:
+			result.Updated = append(result.Updated, entry)
+		default:
+			result.Unchanged++
+		}
+	}
+
+	if !dryRun && !noWriteIDs {
+		for _, p := range plans {
+			if p.create == nil || p.file.Front.ID != "" {
+				continue
+			}
+			if err := writeMarkdownID(filepath.Join(dir, filepath.FromSlash(p.file.Path)), p.id); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not write id %s into %s: %v\n", p.id, p.file.Path, err)
+				continue
+			}
+			result.IDsWritten = append(result.IDsWritten, p.file.Path)
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return nil
+	}
+	printMarkdownImportResult(&result)
+	return nil
+}
+
+func printMarkdownImportResult(r *markdownImportResult) {
+	verb := map[bool]string{true: "Would create", false: "Created"}[r.DryRun]
+	for _, e := range r.Created {
+		id := e.ID
+		if id == "" {
+			id = "(new)"
+		}
+		fmt.Printf("%s %s %s: %s  %s\n", ui.RenderPass("+"), verb, id, e.Title, ui.RenderMuted(e.File))
+	}
+	verb = map[bool]string{true: "Would update", false: "Updated"}[r.DryRun]
+	for _, e := range r.Updated {
+		fmt.Printf("%s %s %s: %s (%s)  %s\n", ui.RenderWarn("~"), verb, e.ID, e.Title, strings.Join(e.Changes, ", "), ui.RenderMuted(e.File))
+	}
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged", len(r.Created), len(r.Updated), r.Unchanged)
+	if r.DryRun {
+		summary += " (dry run)"
+	}
+	fmt.Println(summary)
+	if len(r.IDsWritten) > 0 {
+		fmt.Printf("Wrote new IDs into %d file(s)\n", len(r.IDsWritten))
+	}
+	for _, s := range r.Skipped {
+		fmt.Printf("%s Skipped %s (no front matter)\n", ui.RenderMuted("-"), s)
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseMarkdownIssue(t *testing.T) {
+	data := `---
+id: bd-12
+status: in_progress
+priority: 1
+type: feature
+labels: [auth, backend]
+parent: plans/epic
+deps: [bd-3, related:bd-9]
+---
+# Single sign-on
+
+Let users log in with SSO.
+
+` + "```" + `
+## Notes
+not a section inside a fence
+` + "```" + `
+
+## Design
+
+Use OIDC.
+
+## Acceptance Criteria
+- works
+
+## notes
+Ship it.
+`
+	issue, err := parseMarkdownIssue("plans/sso.md", []byte(data))
+	if err != nil {
+		t.Fatalf("parseMarkdownIssue: %v", err)
+	}
+	if issue.Key != "plans/sso" || issue.Front.ID != "bd-12" || issue.Title != "Single sign-on" {
+		t.Errorf("key/id/title = %q/%q/%q", issue.Key, issue.Front.ID, issue.Title)
+	}
+	if !strings.HasPrefix(issue.Description, "Let users log in with SSO.") || !strings.Contains(issue.Description, "not a section inside a fence") {
+		t.Errorf("description = %q", issue.Description)
+	}
+	if issue.Design != "Use OIDC." || issue.AcceptanceCriteria != "- works" || issue.Notes != "Ship it." {
+		t.Errorf("sections = %q / %q / %q", issue.Design, issue.AcceptanceCriteria, issue.Notes)
+	}
+	if got := *issue.Front.Labels; !reflect.DeepEqual(got, []string{"auth", "backend"}) {
+		t.Errorf("labels = %v", got)
+	}
+	if *issue.Front.Parent != "plans/epic" || *issue.Front.Priority != 1 {
+		t.Errorf("parent/priority = %q/%d", *issue.Front.Parent, *issue.Front.Priority)
+	}
+}
+
+func TestParseMarkdownIssueTitleFallbacks(t *testing.T) {
+	issue, err := parseMarkdownIssue("fix-login.md", []byte("---\nstatus: open\n---\nJust a body.\n"))
+	if err != nil {
+		t.Fatalf("parseMarkdownIssue: %v", err)
+	}
+	if issue.Title != "fix-login" || issue.Description != "Just a body." {
+		t.Errorf("title/description = %q/%q", issue.Title, issue.Description)
+	}
+	if issue.Front.Labels != nil || issue.Front.Deps != nil || issue.Front.Parent != nil {
+		t.Error("omitted labels/deps/parent should stay unmanaged (nil)")
+	}
+
+	issue, err = parseMarkdownIssue("x.md", []byte("---\ntitle: From front matter\nlabels: []\n---\n# From heading\n"))
+	if err != nil {
+		t.Fatalf("parseMarkdownIssue: %v", err)
+	}
+	if issue.Title != "From front matter" {
+		t.Errorf("title = %q, want front matter title to win", issue.Title)
+	}
+	if issue.Front.Labels == nil || len(*issue.Front.Labels) != 0 {
+		t.Error("labels: [] should clear labels, not leave them unmanaged")
+	}
+}
+
+func TestParseMarkdownIssueErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":  "---\nowner: bob\n---\n",
+		"unclosed":       "---\nid: bd-1\n",
+		"bad priority":   "---\npriority: 7\n---\n",
+		"bad dep type":   "---\ndeps: [bogus:bd-1]\n---\n",
+		"parent-child":   "---\ndeps: [parent-child:bd-1]\n---\n",
+		"empty dep type": "---\ndeps: [\"related:\"]\n---\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseMarkdownIssue("x.md", []byte(data)); err == nil || errors.Is(err, errNoFrontMatter) {
+				t.Errorf("err = %v, want a parse error", err)
+			}
+		})
+	}
+	if _, err := parseMarkdownIssue("README.md", []byte("# Readme\n")); !errors.Is(err, errNoFrontMatter) {
+		t.Errorf("README err = %v, want errNoFrontMatter", err)
+	}
+}
+
+func TestReadMarkdownIssueDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, data string) {
+		t.Helper()
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("README.md", "# Issues\n")
+	write("a.md", "---\nid: bd-1\n---\n# A\n")
+	write("sub/b.md", "---\nparent: a\n---\n# B\n")
+	write(".git/c.md", "---\nid: bd-9\n---\n")
+	write("notes.txt", "---\nid: bd-8\n---\n")
+
+	issues, skipped, err := readMarkdownIssueDir(dir)
+	if err != nil {
+		t.Fatalf("readMarkdownIssueDir: %v", err)
+	}
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "sub/b"}) {
+		t.Errorf("keys = %v", keys)
+	}
+	if !reflect.DeepEqual(skipped, []string{"README.md"}) {
+		t.Errorf("skipped = %v", skipped)
+	}
+
+	write("dup.md", "---\nid: bd-1\n---\n")
+	if _, _, err := readMarkdownIssueDir(dir); err == nil || !strings.Contains(err.Error(), "bd-1") {
+		t.Errorf("duplicate id err = %v", err)
+	}
+}
+
+func TestWriteMarkdownID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.md")
+	original := "---\r\nstatus: open\r\n---\r\n# X\r\n"
+	if err := os.WriteFile(path, []byte(markdownBOM+original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeMarkdownID(path, "bd-7"); err != nil {
+		t.Fatalf("writeMarkdownID: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	want := markdownBOM + "---\r\nid: bd-7\r\nstatus: open\r\n---\r\n# X\r\n"
+	if string(got) != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownFieldUpdates(t *testing.T) {
+	existing := &types.Issue{ID: "bd-1", Title: "Old", Description: "Body\n", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: "alice"}
+	issue, err := parseMarkdownIssue("x.md", []byte("---\nid: bd-1\nstatus: closed\npriority: 2\n---\n# New\n\nBody\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates, changes := markdownFieldUpdates(existing, issue)
+	if !reflect.DeepEqual(changes, []string{"title", "status"}) {
+		t.Errorf("changes = %v, want title and status only", changes)
+	}
+	if updates["title"] != "New" || updates["status"] != "closed" {
+		t.Errorf("updates = %v", updates)
+	}
+}
+
+func TestRenderMarkdownIssueRoundTrip(t *testing.T) {
+	ref := "gh-42"
+	issue := &types.Issue{
+		ID:                 "bd-5",
+		Title:              "Round trip",
+		Description:        "Description.",
+		Design:             "Design.",
+		AcceptanceCriteria: "Criteria.",
+		Notes:              "Notes.",
+		Status:             types.StatusBlocked,
+		Priority:           0,
+		IssueType:          types.TypeBug,
+		Assignee:           "bob",
+		ExternalRef:        &ref,
+		Labels:             []string{"b", "a"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-5", DependsOnID: "bd-1", Type: types.DepParentChild},
+			{IssueID: "bd-5", DependsOnID: "bd-2", Type: types.DepBlocks},
+			{IssueID: "bd-5", DependsOnID: "bd-3", Type: types.DepRelated},
+		},
+	}
+	data, err := renderMarkdownIssue(issue)
+	if err != nil {
+		t.Fatalf("renderMarkdownIssue: %v", err)
+	}
+	parsed, err := parseMarkdownIssue("bd-5.md", data)
+	if err != nil {
+		t.Fatalf("parse rendered issue: %v\n%s", err, data)
+	}
+	if updates, changes := markdownFieldUpdates(issue, parsed); len(updates) != 0 {
+		t.Errorf("round trip changed %v\n%s", changes, data)
+	}
+	if got := *parsed.Front.Labels; !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("labels = %v", got)
+	}
+	if got := *parsed.Front.Deps; !reflect.DeepEqual(got, []string{"bd-2", "related:bd-3"}) {
+		t.Errorf("deps = %v", got)
+	}
+	if *parsed.Front.Parent != "bd-1" || *parsed.Front.Priority != 0 {
+		t.Errorf("parent/priority = %q/%d", *parsed.Front.Parent, *parsed.Front.Priority)
+	}
+}
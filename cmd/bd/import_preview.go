@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// ImportFieldChange is one issue field that an import would overwrite.
+type ImportFieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// ImportPreviewIssue is an issue an import would create or update.
+type ImportPreviewIssue struct {
+	ID            string              `json:"id"`
+	Title         string              `json:"title"`
+	Fields        []ImportFieldChange `json:"fields,omitempty"`
+	LabelsAdded   []string            `json:"labels_added,omitempty"`
+	CommentsAdded int                 `json:"comments_added,omitempty"`
+}
+
+// ImportPreviewDependency is a dependency edge an import would add.
+type ImportPreviewDependency struct {
+	IssueID     string               `json:"issue_id"`
+	DependsOnID string               `json:"depends_on_id"`
+	Type        types.DependencyType `json:"type"`
+}
+
+// ImportPreview is what 'bd import --dry-run' reports. Import is an upsert
+// that only ever adds labels, comments, and dependencies, so nothing is
+// deleted; local issues absent from the file are listed in NotInFile.
+type ImportPreview struct {
+	Creates         []ImportPreviewIssue      `json:"creates"`
+	Updates         []ImportPreviewIssue      `json:"updates"`
+	Unchanged       int                       `json:"unchanged"`
+	Stale           []string                  `json:"stale,omitempty"`
+	Conflicts       []ImportConflict          `json:"conflicts,omitempty"`
+	DependencyAdds  []ImportPreviewDependency `json:"dependency_adds,omitempty"`
+	MemoriesNew     int                       `json:"memories_new,omitempty"`
+	MemoriesChanged int                       `json:"memories_changed,omitempty"`
+	NotInFile       []string                  `json:"not_in_file,omitempty"`
+
+	seen map[string]bool
+}
+
+// previewIgnoredFields are not compared: updated_at changes on every write,
+// and labels, dependencies, and comments are merged rather than replaced, so
+// they are reported as additions instead.
+var previewIgnoredFields = map[string]bool{
+	"updated_at":   true,
+	"labels":       true,
+	"dependencies": true,
+	"comments":     true,
+}
+
+// previewBatch classifies one batch of incoming issues and memories against
+// the store without writing anything.
+func (p *ImportPreview) previewBatch(ctx context.Context, st storage.DoltStorage, issues []*types.Issue, memories map[string]string, allowStale bool) error {
+	if p.seen == nil {
+		p.seen = make(map[string]bool)
+	}
+	for key, value := range memories {
+		existing, err := st.GetConfig(ctx, key)
+		switch {
+		case err != nil || existing == "":
+			p.MemoriesNew++
+		case existing != value:
+			p.MemoriesChanged++
+		}
+	}
+
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if issue.ID != "" {
+			ids = append(ids, issue.ID)
+			p.seen[issue.ID] = true
+		}
+	}
+	local := make(map[string]*types.Issue, len(ids))
+	var labels map[string][]string
+	var deps map[string][]*types.Dependency
+	var comments map[string][]*types.Comment
+	if len(ids) > 0 {
+		existing, err := st.GetIssuesByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("load existing issues: %w", err)
+		}
+		for _, issue := range existing {
+			local[issue.ID] = issue
+		}
+		if labels, err = st.GetLabelsForIssues(ctx, ids); err != nil {
+			return fmt.Errorf("load existing labels: %w", err)
+		}
+		if deps, err = st.GetDependencyRecordsForIssues(ctx, ids); err != nil {
+			return fmt.Errorf("load existing dependencies: %w", err)
+		}
+		if comments, err = st.GetCommentsForIssues(ctx, ids); err != nil {
+			return fmt.Errorf("load existing comments: %w", err)
+		}
+	}
+
+	for _, issue := range issues {
+		existing := local[issue.ID]
+		if existing == nil {
+			p.Creates = append(p.Creates, ImportPreviewIssue{ID: issue.ID, Title: issue.Title})
+			p.addDependencies(issue, nil)
+			continue
+		}
+		// Mirrors filterStaleImportIssues: an older snapshot is skipped whole.
+		if !allowStale && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.UTC().Before(existing.UpdatedAt.UTC()) {
+			p.Stale = append(p.Stale, issue.ID)
+			continue
+		}
+		change := ImportPreviewIssue{
+			ID:            issue.ID,
+			Title:         issue.Title,
+			Fields:        diffIssueFields(existing, issue),
+			LabelsAdded:   missingLabels(labels[issue.ID], issue.Labels),
+			CommentsAdded: countNewComments(comments[issue.ID], issue.Comments),
+		}
+		p.addDependencies(issue, deps[issue.ID])
+		if len(change.Fields) == 0 && len(change.LabelsAdded) == 0 && change.CommentsAdded == 0 {
+			p.Unchanged++
+			continue
+		}
+		p.Updates = append(p.Updates, change)
+	}
+	return nil
+}
+
+// addDependencies records the incoming edges of issue that are not in local.
+func (p *ImportPreview) addDependencies(issue *types.Issue, local []*types.Dependency) {
+	have := make(map[string]bool, len(local))
+	for _, dep := range local {
+		have[dep.DependsOnID] = true
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || have[dep.DependsOnID] {
+			continue
+		}
+		have[dep.DependsOnID] = true
+		issueID := dep.IssueID
+		if issueID == "" {
+			issueID = issue.ID
+		}
+		p.DependencyAdds = append(p.DependencyAdds, ImportPreviewDependency{IssueID: issueID, DependsOnID: dep.DependsOnID, Type: dep.Type})
+	}
+}
+
+// finish lists local issues the file did not mention. It loads every local
+// issue, so it runs once after the whole file has been read.
+func (p *ImportPreview) finish(ctx context.Context, st storage.DoltStorage) error {
+	all, err := st.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return fmt.Errorf("list local issues: %w", err)
+	}
+	for _, issue := range all {
+		if !p.seen[issue.ID] {
+			p.NotInFile = append(p.NotInFile, issue.ID)
+		}
+	}
+	sort.Strings(p.NotInFile)
+	return nil
+}
+
+// diffIssueFields compares the JSON form of two issues field by field.
+// Timestamps compare at second precision, since the database stores no
+// more; an incoming zero timestamp means "absent" and is filled in by the
+// importer, so it is not a change.
+func diffIssueFields(local, incoming *types.Issue) []ImportFieldChange {
+	before, after := issueFieldMap(local), issueFieldMap(incoming)
+	keys := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	var changes []ImportFieldChange
+	for k := range keys {
+		if previewIgnoredFields[k] || isZeroTimeValue(after[k]) || previewValuesEqual(before[k], after[k]) {
+			continue
+		}
+		changes = append(changes, ImportFieldChange{Field: k, Old: before[k], New: after[k]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func issueFieldMap(issue *types.Issue) map[string]any {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+func previewValuesEqual(a, b any) bool {
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			ta, errA := time.Parse(time.RFC3339Nano, as)
+			tb, errB := time.Parse(time.RFC3339Nano, bs)
+			if errA == nil && errB == nil {
+				return ta.Truncate(time.Second).Equal(tb.Truncate(time.Second))
+			}
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isZeroTimeValue(v any) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil && t.IsZero()
+}
+
+// missingLabels returns the incoming labels not already on the issue.
+func missingLabels(local, incoming []string) []string {
+	have := make(map[string]bool, len(local))
+	for _, l := range local {
+		have[l] = true
+	}
+	var added []string
+	for _, l := range incoming {
+		if !have[l] {
+			have[l] = true
+			added = append(added, l)
+		}
+	}
+	return added
+}
+
+// countNewComments counts incoming comments with no local match on author,
+// text, and timestamp, the same identity PersistComments uses to dedup.
+func countNewComments(local, incoming []*types.Comment) int {
+	key := func(c *types.Comment) string {
+		return c.Author + "\x00" + c.Text + "\x00" + c.CreatedAt.UTC().Truncate(time.Second).Format(time.RFC3339)
+	}
+	have := make(map[string]bool, len(local))
+	for _, c := range local {
+		have[key(c)] = true
+	}
+	n := 0
+	for _, c := range incoming {
+		if c != nil && !have[key(c)] {
+			n++
+		}
+	}
+	return n
+}
+
+// importPreviewListLimit caps each section of the text preview; --json has
+// everything.
+const importPreviewListLimit = 50
+
+func printImportPreview(source string, p *ImportPreview, dedupHits int) {
+	fmt.Printf("\n%s Import preview for %s (dry run, nothing written)\n\n", ui.RenderAccent("🔍"), source)
+
+	more := func(shown, total int) {
+		if total > shown {
+			fmt.Printf("  ... and %d more (use --json for the full list)\n", total-shown)
+		}
+	}
+	fmt.Printf("Create (%d):\n", len(p.Creates))
+	for i, c := range p.Creates {
+		if i == importPreviewListLimit {
+			more(i, len(p.Creates))
+			break
+		}
+		fmt.Printf("  + %s  %s\n", c.ID, c.Title)
+	}
+
+	fmt.Printf("Update (%d):\n", len(p.Updates))
+	for i, u := range p.Updates {
+		if i == importPreviewListLimit {
+			more(i, len(p.Updates))
+			break
+		}
+		fmt.Printf("  ~ %s  %s\n", u.ID, u.Title)
+		for _, f := range u.Fields {
+			fmt.Printf("      %s: %s → %s\n", f.Field, formatPreviewValue(f.Old), formatPreviewValue(f.New))
+		}
+		if len(u.LabelsAdded) > 0 {
+			fmt.Printf("      labels: +%s\n", strings.Join(u.LabelsAdded, ", +"))
+		}
+		if u.CommentsAdded > 0 {
+			fmt.Printf("      comments: +%d\n", u.CommentsAdded)
+		}
+	}
+
+	fmt.Printf("Dependencies (+%d):\n", len(p.DependencyAdds))
+	for i, d := range p.DependencyAdds {
+		if i == importPreviewListLimit {
+			more(i, len(p.DependencyAdds))
+			break
+		}
+		fmt.Printf("  + %s → %s (%s)\n", d.IssueID, d.DependsOnID, d.Type)
+	}
+
+	fmt.Printf("Unchanged: %d\n", p.Unchanged)
+	if len(p.Conflicts) > 0 {
+		fmt.Printf("Conflicts (%d, --on-conflict %s):\n", len(p.Conflicts), p.Conflicts[0].Strategy)
+		for i, c := range p.Conflicts {
+			if i == importPreviewListLimit {
+				more(i, len(p.Conflicts))
+				break
+			}
+			fmt.Printf("  ! %s  %s\n", c.ID, c.describe())
+			if len(c.Overlapping) > 0 {
+				fmt.Printf("      changed on both sides: %s\n", strings.Join(c.Overlapping, ", "))
+			}
+		}
+	}
+	if len(p.Stale) > 0 {
+		fmt.Printf("Skipped, local copy is newer (%d; --on-conflict theirs overwrites): %s\n", len(p.Stale), previewIDList(p.Stale))
+	}
+	if dedupHits > 0 {
+		fmt.Printf("Skipped, duplicate title (--dedup): %d\n", dedupHits)
+	}
+	if p.MemoriesNew > 0 || p.MemoriesChanged > 0 {
+		fmt.Printf("Memories: %d new, %d changed\n", p.MemoriesNew, p.MemoriesChanged)
+	}
+	fmt.Printf("Delete: none (import never deletes")
+	if len(p.NotInFile) > 0 {
+		fmt.Printf("; %d local issues are not in the file: %s", len(p.NotInFile), previewIDList(p.NotInFile))
+	}
+	fmt.Println(")")
+	fmt.Println()
+}
+
+func previewIDList(ids []string) string {
+	if len(ids) <= 10 {
+		return strings.Join(ids, ", ")
+	}
+	return strings.Join(ids[:10], ", ") + fmt.Sprintf(", ... (%d more)", len(ids)-10)
+}
+
+// formatPreviewValue renders a field value on one line, truncating long text.
+func formatPreviewValue(v any) string {
+	if v == nil {
+		return "(unset)"
+	}
+	var s string
+	if str, ok := v.(string); ok {
+		s = fmt.Sprintf("%q", str)
+	} else {
+		data, _ := json.Marshal(v)
+		s = string(data)
+	}
+	return truncateTitle(s, 60)
+}
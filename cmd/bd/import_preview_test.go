@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDiffIssueFields(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	local := &types.Issue{
+		ID: "bd-1", Title: "Old title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		CreatedAt: created, UpdatedAt: created,
+		Labels: []string{"a"},
+	}
+	incoming := &types.Issue{
+		ID: "bd-1", Title: "New title", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+		// Sub-second drift and a newer updated_at are not changes.
+		CreatedAt: created.Add(300 * time.Millisecond), UpdatedAt: created.Add(time.Hour),
+		Labels: []string{"a", "b"},
+	}
+
+	changes := diffIssueFields(local, incoming)
+	if len(changes) != 2 || changes[0].Field != "priority" || changes[1].Field != "title" {
+		t.Fatalf("changes = %+v, want priority and title", changes)
+	}
+	if changes[1].Old != "Old title" || changes[1].New != "New title" {
+		t.Errorf("title change = %+v", changes[1])
+	}
+
+	// A zero incoming timestamp is filled in by the importer, not cleared.
+	incoming = &types.Issue{ID: "bd-1", Title: "Old title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if changes := diffIssueFields(local, incoming); len(changes) != 0 {
+		t.Errorf("zero timestamps reported as changes: %+v", changes)
+	}
+}
+
+func TestMissingLabelsAndComments(t *testing.T) {
+	if got := missingLabels([]string{"a", "b"}, []string{"b", "c", "c"}); len(got) != 1 || got[0] != "c" {
+		t.Errorf("missingLabels = %v, want [c]", got)
+	}
+
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	local := []*types.Comment{{Author: "alice", Text: "hi", CreatedAt: at}}
+	incoming := []*types.Comment{
+		{Author: "alice", Text: "hi", CreatedAt: at.Add(200 * time.Millisecond)},
+		{Author: "bob", Text: "hi", CreatedAt: at},
+		nil,
+	}
+	if got := countNewComments(local, incoming); got != 1 {
+		t.Errorf("countNewComments = %d, want 1", got)
+	}
+}
+
+func TestPreviewAddDependencies(t *testing.T) {
+	var p ImportPreview
+	issue := &types.Issue{ID: "bd-2", Dependencies: []*types.Dependency{
+		{DependsOnID: "bd-1", Type: types.DepBlocks},
+		{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepRelated},
+		{DependsOnID: "bd-3", Type: types.DepRelated},
+		nil,
+	}}
+	p.addDependencies(issue, []*types.Dependency{{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks}})
+	if len(p.DependencyAdds) != 1 {
+		t.Fatalf("DependencyAdds = %+v, want only bd-2 -> bd-3", p.DependencyAdds)
+	}
+	if d := p.DependencyAdds[0]; d.IssueID != "bd-2" || d.DependsOnID != "bd-3" || d.Type != types.DepRelated {
+		t.Errorf("edge = %+v", d)
+	}
+}
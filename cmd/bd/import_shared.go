@@ -1,14 +1,14 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/jsonl"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -36,6 +36,11 @@ type ImportOptions struct {
 	// guard otherwise silently no-ops per row (bd-6dnrw.9). Only settable
 	// via explicit `bd import --allow-stale`; auto-import paths never set it.
 	AllowStale bool
+	// DeferMissingDependencyTargets reports edges whose target does not
+	// exist yet in ImportResult.DeferredDependencies instead of as skipped.
+	// Batched imports set it so an edge to an issue later in the file can
+	// be retried once every batch has landed.
+	DeferMissingDependencyTargets bool
 }
 
 // ImportResult describes what an import operation did.
@@ -54,6 +59,9 @@ type ImportResult struct {
 	ImportedIDs         []string
 	StaleSkippedIDs     []string
 	SkippedDependencies []string
+	// DeferredDependencies holds edges whose target was not found, when
+	// ImportOptions.DeferMissingDependencyTargets is set.
+	DeferredDependencies []*types.Dependency
 }
 
 // importIssuesCore imports issues into the Dolt store.
@@ -84,6 +92,8 @@ func importIssuesCore(ctx context.Context, _ string, store storage.DoltStorage,
 
 	var skippedDependencies []string
 	skippedDependencySet := make(map[string]struct{})
+	var deferredDependencies []*types.Dependency
+	var batchDeps map[string]*types.Dependency // built on first deferral
 	// In-txn half of the stale guard: rows the conditional upsert rejected
 	// (local update committed between the pre-filter read and the batch
 	// write). The transaction may retry, so dedup by ID.
@@ -100,6 +110,15 @@ func importIssuesCore(ctx context.Context, _ string, store storage.DoltStorage,
 				return
 			}
 			skippedDependencySet[skipped] = struct{}{}
+			if opts.DeferMissingDependencyTargets && reason == storage.SkipReasonTargetNotFound {
+				if batchDeps == nil {
+					batchDeps = indexBatchDependencies(issues)
+				}
+				if dep := batchDeps[issueID+"\x00"+dependsOnID]; dep != nil {
+					deferredDependencies = append(deferredDependencies, dep)
+					return
+				}
+			}
 			skippedDependencies = append(skippedDependencies, skipped)
 		},
 		OnStaleRejected: func(issueID string) {
@@ -119,14 +138,34 @@ func importIssuesCore(ctx context.Context, _ string, store storage.DoltStorage,
 		importedIDs = append(importedIDs, issue.ID)
 	}
 	return &ImportResult{
-		Created:             len(importedIDs),
-		Skipped:             len(staleSkippedIDs),
-		ImportedIDs:         importedIDs,
-		StaleSkippedIDs:     staleSkippedIDs,
-		SkippedDependencies: skippedDependencies,
+		Created:              len(importedIDs),
+		Skipped:              len(staleSkippedIDs),
+		ImportedIDs:          importedIDs,
+		StaleSkippedIDs:      staleSkippedIDs,
+		SkippedDependencies:  skippedDependencies,
+		DeferredDependencies: deferredDependencies,
 	}, nil
 }
 
+// indexBatchDependencies maps "issueID\x00dependsOnID" to each dependency
+// carried by issues, so a skipped edge reported by ID can be recovered.
+func indexBatchDependencies(issues []*types.Issue) map[string]*types.Dependency {
+	index := make(map[string]*types.Dependency)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			issueID := dep.IssueID
+			if issueID == "" {
+				issueID = issue.ID
+			}
+			index[issueID+"\x00"+dep.DependsOnID] = dep
+		}
+	}
+	return index
+}
+
 func filterStaleImportIssues(ctx context.Context, store storage.DoltStorage, issues []*types.Issue) ([]*types.Issue, []string, error) {
 	ids := make([]string, 0, len(issues))
 	seen := make(map[string]struct{}, len(issues))
@@ -202,87 +241,84 @@ func importFromLocalJSONL(ctx context.Context, store storage.DoltStorage, localP
 // entries (memories). Pure function — no store I/O.
 func parseJSONLFile(path string) ([]*types.Issue, map[string]string, error) {
 	//nolint:gosec // G304: path from user-provided CLI argument
-	data, err := os.ReadFile(path)
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read JSONL file %s: %w", path, err)
 	}
+	defer f.Close()
+	return parseJSONLRecords(f)
+}
 
-	scanner := bufio.NewScanner(strings.NewReader(string(data)))
-	// Allow up to 64MB per line for large descriptions
-	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+// parseJSONLRecords decodes issue and memory records from a JSONL stream.
+// The optional schema header is consumed by jsonl.Reader, which also
+// migrates records from older exports; a file from a newer schema is
+// loaded best-effort with a warning.
+func parseJSONLRecords(in io.Reader) ([]*types.Issue, map[string]string, error) {
+	r := jsonl.NewReader(in)
 	var issues []*types.Issue
 	configEntries := make(map[string]string)
+	warned := false
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
 		}
-
-		// Peek at the record to check for _type field
-		var peek map[string]json.RawMessage
-		if err := json.Unmarshal([]byte(line), &peek); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse JSONL line: %w", err)
+		if err != nil {
+			return nil, nil, err
 		}
-
-		// Skip the optional beads-jsonl metadata/header record.
-		// Canonical exports produced by the stable-ordering /
-		// git-merge convention prepend a schema+provenance line, e.g.
-		// {"_schema":"beads-jsonl/1","_dolt_branch":"main",
-		// "_dolt_commit":"...","_sort":"stable-v1"}. It carries no
-		// _type and no issue fields; without this guard it falls
-		// through to the issue path, unmarshals into an empty Issue,
-		// and aborts the whole import with "validation failed for
-		// issue : title is required". Identified by the _schema
-		// sentinel, which real issue/memory records never carry.
-		if _, isHeader := peek["_schema"]; isHeader {
-			continue
+		if !warned {
+			warned = true
+			warnIfJSONLSchemaAhead(r)
 		}
 
-		// Check if this is a memory record
-		if rawType, ok := peek["_type"]; ok {
-			var typeStr string
-			if err := json.Unmarshal(rawType, &typeStr); err == nil && typeStr == "memory" {
-				var mem memoryRecord
-				if err := json.Unmarshal([]byte(line), &mem); err != nil {
-					return nil, nil, fmt.Errorf("failed to parse memory record: %w", err)
-				}
-				if mem.Key != "" && mem.Value != "" {
-					configEntries[kvPrefix+memoryPrefix+mem.Key] = mem.Value
-				}
-				continue
-			}
+		issue, memKey, memValue, err := decodeImportRecord(rec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", r.Line(), err)
+		}
+		if issue != nil {
+			issues = append(issues, issue)
+		} else if memKey != "" {
+			configEntries[memKey] = memValue
 		}
+	}
+
+	return issues, configEntries, nil
+}
 
-		// Regular issue record
-		var issue types.Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return nil, nil, fmt.Errorf("failed to parse issue from JSONL: %w", err)
+// decodeImportRecord turns one migrated JSONL record into either an issue
+// or a memory, returned as its config storage key and value. An empty
+// memory record yields neither.
+func decodeImportRecord(rec *jsonl.Record) (*types.Issue, string, string, error) {
+	if rec.Type() == "memory" {
+		var mem memoryRecord
+		if err := rec.Decode(&mem); err != nil {
+			return nil, "", "", fmt.Errorf("failed to parse memory record: %w", err)
 		}
-		// Skip tombstone entries: these are deleted issues exported by older
-		// versions (pre-v0.50) with status "tombstone" and deleted_at set.
-		// They are not valid for re-import since "tombstone" is not a real status.
-		if issue.Status == "tombstone" {
-			continue
+		if mem.Key == "" || mem.Value == "" {
+			return nil, "", "", nil
 		}
+		return nil, kvPrefix + memoryPrefix + mem.Key, mem.Value, nil
+	}
 
-		// v0.35–v0.37 exported "wisp" (bool), renamed to "ephemeral" in v0.38+.
-		// map old field name so the flag is preserved on import.
-		if _, hasWisp := peek["wisp"]; hasWisp && !issue.Ephemeral {
-			var wisp bool
-			if err := json.Unmarshal(peek["wisp"], &wisp); err == nil && wisp {
-				issue.Ephemeral = true
-			}
-		}
+	var issue types.Issue
+	if err := rec.Decode(&issue); err != nil {
+		return nil, "", "", fmt.Errorf("failed to parse issue from JSONL: %w", err)
+	}
+	issue.SetDefaults()
+	return &issue, "", "", nil
+}
 
-		issue.SetDefaults()
-		issues = append(issues, &issue)
+// warnIfJSONLSchemaAhead warns on stderr when r's file was written with a
+// newer schema than this bd understands. Call it after the first record.
+func warnIfJSONLSchemaAhead(r *jsonl.Reader) {
+	bdVersion := ""
+	if r.Header != nil {
+		bdVersion = r.Header.BDVersion
 	}
-	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("failed to scan JSONL: %w", err)
+	if err := jsonl.CheckVersion(r.Version, bdVersion); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
-
-	return issues, configEntries, nil
 }
 
 // importFromLocalJSONLFull imports issues and memories from a local JSONL file
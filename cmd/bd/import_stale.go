@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/lockfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// importLockFile serializes automatic JSONL imports (import.auto-on-stale
+// refreshes and bd watch-jsonl), so two processes never import the same
+// file at once.
+const importLockFile = "import.lock"
+
+// importLockTimeout bounds how long a refresh waits for another process's
+// import before giving up and reading the database as it is.
+const importLockTimeout = 30 * time.Second
+
+// staleRefreshCommands may refresh the database from a newer JSONL file
+// before reading, when import.auto-on-stale is set.
+var staleRefreshCommands = map[string]bool{
+	"list":  true,
+	"show":  true,
+	"ready": true,
+}
+
+// shouldRefreshStaleJSONL reports whether cmd should check the JSONL file for
+// changes newer than the last import. These commands are otherwise
+// read-only: the caller checks staleness on the read-only store and reopens
+// it writable (reopenStoreWritable) only when there is something to import.
+func shouldRefreshStaleJSONL(cmd *cobra.Command) bool {
+	if cmd == nil || !staleRefreshCommands[cmd.Name()] {
+		return false
+	}
+	if cmd.Parent() != nil && cmd.Parent().Name() != "bd" {
+		return false
+	}
+	return config.GetBool("import.auto-on-stale")
+}
+
+// refreshStaleJSONL re-imports the configured JSONL file when it changed after
+// the recorded last_import_time. It is best-effort: on any failure the
+// command goes on to read the database as it is.
+func refreshStaleJSONL(ctx context.Context, s storage.DoltStorage, beadsDir string) {
+	path := configuredImportJSONLPath(beadsDir)
+	if !jsonlNewerThanLastImport(ctx, s, beadsDir, path) {
+		return
+	}
+
+	release, err := acquireImportLock(ctx, beadsDir, importLockTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping refresh from %s: %v\n", filepath.Base(path), err)
+		return
+	}
+	defer release()
+	// Another process may have imported the file while we waited.
+	if !jsonlNewerThanLastImport(ctx, s, beadsDir, path) {
+		return
+	}
+
+	result, err := importFromLocalJSONLFull(ctx, s, path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: refresh from %s failed: %v\n", filepath.Base(path), err)
+		return
+	}
+	if err := s.SetMetadata(ctx, "last_import_time", time.Now().Format(time.RFC3339)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record last_import_time: %v\n", err)
+	}
+	msg := fmt.Sprintf("bd: refresh %d issues from %s", result.Issues, filepath.Base(path))
+	if err := s.Commit(ctx, msg); err != nil && !isDoltNothingToCommit(err) {
+		fmt.Fprintf(os.Stderr, "Warning: refresh from %s: commit failed: %v\n", filepath.Base(path), err)
+		return
+	}
+	if !isQuiet() {
+		fmt.Fprintf(os.Stderr, "Refreshed %d issues from %s (newer than the last import)\n", result.Issues, path)
+	}
+}
+
+// reopenStoreWritable closes the read-only store s and opens it again
+// writable so a stale refresh can import. If the writable open fails the
+// refresh is skipped and s is reopened read-only, so the command still reads
+// the database as it is.
+func reopenStoreWritable(ctx context.Context, s storage.DoltStorage, cfg *dolt.Config) storage.DoltStorage {
+	writable := *cfg
+	writable.ReadOnly = false
+	_ = s.Close()
+	ws, err := newDoltStore(ctx, &writable)
+	if err == nil {
+		storeIsReadOnly = false
+		return ws
+	}
+	fmt.Fprintf(os.Stderr, "Warning: skipping refresh: cannot open the database writable: %v\n", err)
+	rs, err := newDoltStore(ctx, cfg)
+	if err != nil {
+		FatalError("failed to open database: %v", err)
+	}
+	return rs
+}
+
+// jsonlNewerThanLastImport reports whether path was modified after the
+// recorded last_import_time, ignoring a file this clone's own auto-export
+// wrote.
+func jsonlNewerThanLastImport(ctx context.Context, s storage.DoltStorage, beadsDir, path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	if jsonlWrittenByAutoExport(beadsDir, info) {
+		return false
+	}
+	return info.ModTime().After(loadLastImportTime(ctx, s))
+}
+
+// loadLastImportTime returns the recorded last_import_time, or the zero time.
+func loadLastImportTime(ctx context.Context, s storage.DoltStorage) time.Time {
+	value, err := s.GetMetadata(ctx, "last_import_time")
+	if err != nil || value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// acquireImportLock takes the import lock in beadsDir, polling for up to
+// timeout while another process holds it. The returned func releases it.
+func acquireImportLock(ctx context.Context, beadsDir string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(filepath.Join(beadsDir, importLockFile), os.O_CREATE|os.O_RDWR, 0o600) //nolint:gosec // path is constructed internally
+	if err != nil {
+		return nil, fmt.Errorf("open import lock: %w", err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := lockfile.FlockExclusiveNonBlocking(f)
+		if err == nil {
+			return func() {
+				_ = lockfile.FlockUnlock(f)
+				_ = f.Close()
+			}, nil
+		}
+		if !lockfile.IsLocked(err) || time.Now().After(deadline) {
+			_ = f.Close()
+			if lockfile.IsLocked(err) {
+				return nil, fmt.Errorf("another import is still running after %s", timeout)
+			}
+			return nil, fmt.Errorf("lock import: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
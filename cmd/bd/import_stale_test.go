@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireImportLock(t *testing.T) {
+	beadsDir := t.TempDir()
+	ctx := context.Background()
+
+	release, err := acquireImportLock(ctx, beadsDir, time.Second)
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	// A second holder waits, then gives up.
+	start := time.Now()
+	if _, err := acquireImportLock(ctx, beadsDir, 300*time.Millisecond); err == nil || !strings.Contains(err.Error(), "still running") {
+		t.Fatalf("second acquire = %v, want timeout", err)
+	}
+	if waited := time.Since(start); waited < 300*time.Millisecond {
+		t.Errorf("gave up after %s, want at least the timeout", waited)
+	}
+
+	// Released while a waiter polls: the waiter gets it.
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		release()
+	}()
+	release2, err := acquireImportLock(ctx, beadsDir, 2*time.Second)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release2()
+
+	// A canceled context stops the wait.
+	hold, err := acquireImportLock(ctx, beadsDir, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer hold()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := acquireImportLock(canceled, beadsDir, time.Second); err != context.Canceled {
+		t.Errorf("acquire with canceled context = %v", err)
+	}
+}
@@ -21,6 +21,20 @@ var labelCmd = &cobra.Command{
 	Short:   "Manage issue labels",
 }
 
+// labelChangeJSON is the --json output for one label added to, removed from,
+// or propagated to an issue.
+type labelChangeJSON struct {
+	Status  string `json:"status"` // added, removed, or propagated
+	IssueID string `json:"issue_id"`
+	Label   string `json:"label"`
+}
+
+// labelCountJSON is one entry of bd label list-all --json.
+type labelCountJSON struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
 // processBatchLabelOperation wraps label add/remove for multiple issues in a
 // single transaction for atomicity.
 func processBatchLabelOperation(issueIDs []string, label string, operation string, jsonOut bool,
@@ -40,13 +54,9 @@ func processBatchLabelOperation(issueIDs []string, label string, operation strin
 	}
 	commandDidWrite.Store(true)
 	if jsonOut {
-		results := make([]map[string]interface{}, 0, len(issueIDs))
+		results := make([]labelChangeJSON, 0, len(issueIDs))
 		for _, issueID := range issueIDs {
-			results = append(results, map[string]interface{}{
-				"status":   operation,
-				"issue_id": issueID,
-				"label":    label,
-			})
+			results = append(results, labelChangeJSON{Status: operation, IssueID: issueID, Label: label})
 		}
 		outputJSON(results)
 	} else {
@@ -139,11 +149,25 @@ var labelRemoveCmd = &cobra.Command{
 }
 var labelListCmd = &cobra.Command{
 	Use:   "list [issue-id]",
-	Short: "List labels for an issue",
-	Args:  cobra.ExactArgs(1),
+	Short: "List labels for an issue (or every label with --counts)",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
 		ctx := rootCtx
+		if counts, _ := cmd.Flags().GetBool("counts"); counts {
+			if len(args) > 0 {
+				FatalErrorRespectJSON("--counts lists every label; drop the issue ID")
+			}
+			labelCounts, err := countLabels(ctx, store)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			outputLabelCounts(labelCounts)
+			return
+		}
+		if len(args) == 0 {
+			FatalErrorRespectJSON("issue ID required (or use --counts to list every label)")
+		}
 		// Resolve partial ID first
 		var issueID string
 		var err error
@@ -181,70 +205,55 @@ var labelListAllCmd = &cobra.Command{
 	Short: "List all unique labels in the database",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
-		ctx := rootCtx
-		var issues []*types.Issue
-		var err error
-		// Direct mode
-		issues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+		labelCounts, err := countLabels(rootCtx, store)
 		if err != nil {
 			FatalErrorRespectJSON("%v", err)
 		}
-		// Collect unique labels with counts
-		labelCounts := make(map[string]int)
-		for _, issue := range issues {
-			// Direct mode - need to fetch labels
-			labels, err := store.GetLabels(ctx, issue.ID)
-			if err != nil {
-				FatalErrorRespectJSON("getting labels for %s: %v", issue.ID, err)
-			}
-			for _, label := range labels {
-				labelCounts[label]++
-			}
-		}
-		type labelInfo struct {
-			Label string `json:"label"`
-			Count int    `json:"count"`
-		}
-		if len(labelCounts) == 0 {
-			if jsonOutput {
-				outputJSON([]labelInfo{})
-			} else {
-				fmt.Println("\nNo labels found in database")
-			}
-			return
-		}
-		// Sort labels alphabetically
-		labels := make([]string, 0, len(labelCounts))
-		for label := range labelCounts {
-			labels = append(labels, label)
-		}
-		sort.Strings(labels)
+		outputLabelCounts(labelCounts)
+	},
+}
+
+// outputLabelCounts prints every label with its issue count, alphabetically.
+func outputLabelCounts(labelCounts map[string]int) {
+	if len(labelCounts) == 0 {
 		if jsonOutput {
-			// Output as array of {label, count} objects
-			result := make([]labelInfo, 0, len(labels))
-			for _, label := range labels {
-				result = append(result, labelInfo{
-					Label: label,
-					Count: labelCounts[label],
-				})
-			}
-			outputJSON(result)
-			return
+			outputJSON([]labelCountJSON{})
+		} else {
+			fmt.Println("\nNo labels found in database")
 		}
-		fmt.Printf("\n%s All labels (%d unique):\n", ui.RenderAccent("🏷"), len(labels))
-		// Find longest label for alignment
-		maxLen := 0
+		return
+	}
+	// Sort labels alphabetically
+	labels := make([]string, 0, len(labelCounts))
+	for label := range labelCounts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	if jsonOutput {
+		// Output as array of {label, count} objects
+		result := make([]labelCountJSON, 0, len(labels))
 		for _, label := range labels {
-			if len(label) > maxLen {
-				maxLen = len(label)
-			}
+			result = append(result, labelCountJSON{
+				Label: label,
+				Count: labelCounts[label],
+			})
 		}
-		for _, label := range labels {
-			padding := strings.Repeat(" ", maxLen-len(label))
-			fmt.Printf("  %s%s  (%d issues)\n", label, padding, labelCounts[label])
+		outputJSON(result)
+		return
+	}
+	fmt.Printf("\n%s All labels (%d unique):\n", ui.RenderAccent("🏷"), len(labels))
+	// Find longest label for alignment
+	maxLen := 0
+	for _, label := range labels {
+		if len(label) > maxLen {
+			maxLen = len(label)
 		}
-		fmt.Println()
-	},
+	}
+	for _, label := range labels {
+		padding := strings.Repeat(" ", maxLen-len(label))
+		fmt.Printf("  %s%s  (%d issues)\n", label, padding, labelCounts[label])
+	}
+	fmt.Println()
 }
 
 var labelPropagateCmd = &cobra.Command{
@@ -278,7 +287,7 @@ var labelPropagateCmd = &cobra.Command{
 
 		if len(children) == 0 {
 			if jsonOutput {
-				outputJSON([]map[string]interface{}{})
+				outputJSON([]labelChangeJSON{})
 			} else {
 				fmt.Printf("No children found for %s\n", parentID)
 			}
@@ -300,13 +309,9 @@ var labelPropagateCmd = &cobra.Command{
 		}
 
 		if jsonOutput {
-			results := make([]map[string]interface{}, 0, len(children))
+			results := make([]labelChangeJSON, 0, len(children))
 			for _, child := range children {
-				results = append(results, map[string]interface{}{
-					"status":   "propagated",
-					"issue_id": child.ID,
-					"label":    label,
-				})
+				results = append(results, labelChangeJSON{Status: "propagated", IssueID: child.ID, Label: label})
 			}
 			outputJSON(results)
 		} else {
@@ -324,10 +329,16 @@ func init() {
 	labelListCmd.ValidArgsFunction = issueIDCompletion
 	labelPropagateCmd.ValidArgsFunction = issueIDCompletion
 
+	labelListCmd.Flags().Bool("counts", false, "List every label with the number of issues carrying it")
+	labelDeleteCmd.Flags().Bool("force", false, "Actually delete (without it, only reports how many issues carry the label)")
+
 	labelCmd.AddCommand(labelAddCmd)
 	labelCmd.AddCommand(labelRemoveCmd)
 	labelCmd.AddCommand(labelListCmd)
 	labelCmd.AddCommand(labelListAllCmd)
 	labelCmd.AddCommand(labelPropagateCmd)
+	labelCmd.AddCommand(labelRenameCmd)
+	labelCmd.AddCommand(labelMergeCmd)
+	labelCmd.AddCommand(labelDeleteCmd)
 	rootCmd.AddCommand(labelCmd)
 }
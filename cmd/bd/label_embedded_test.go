@@ -311,6 +311,100 @@ func TestEmbeddedLabel(t *testing.T) {
 		}
 	})
 
+	// ===== Label Maintenance =====
+
+	t.Run("label_list_counts", func(t *testing.T) {
+		a := bdCreate(t, bd, dir, "Counts A", "--type", "task")
+		b := bdCreate(t, bd, dir, "Counts B", "--type", "task")
+		bdLabel(t, bd, dir, "add", a.ID, b.ID, "count-me")
+
+		s := strings.TrimSpace(bdLabelJSONOutput(t, bd, dir, "list", "--counts", "--json"))
+		var results []labelCountJSON
+		if err := json.Unmarshal([]byte(s[strings.Index(s, "["):]), &results); err != nil {
+			t.Fatalf("parse label list --counts JSON: %v\n%s", err, s)
+		}
+		found := false
+		for _, r := range results {
+			if r.Label == "count-me" {
+				found = true
+				if r.Count != 2 {
+					t.Errorf("count-me count = %d, want 2", r.Count)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("count-me missing from %s", s)
+		}
+		bdLabelFail(t, bd, dir, "list", a.ID, "--counts")
+	})
+
+	t.Run("label_rename", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Rename me", "--type", "task")
+		wisp := bdCreate(t, bd, dir, "Rename wisp", "--type", "task", "--ephemeral")
+		bdLabel(t, bd, dir, "add", issue.ID, wisp.ID, "bakend")
+
+		out := bdLabel(t, bd, dir, "rename", "bakend", "backend-rn")
+		if !strings.Contains(out, "on 2 issue(s)") {
+			t.Errorf("expected 2 issues renamed: %s", out)
+		}
+		for _, id := range []string{issue.ID, wisp.ID} {
+			labels := bdLabelListJSON(t, bd, dir, id)
+			if len(labels) != 1 || labels[0] != "backend-rn" {
+				t.Errorf("labels on %s = %v, want [backend-rn]", id, labels)
+			}
+		}
+
+		// Renaming onto a label already in use must go through merge.
+		other := bdCreate(t, bd, dir, "Rename clash", "--type", "task")
+		bdLabel(t, bd, dir, "add", other.ID, "clash-src")
+		out = bdLabelFail(t, bd, dir, "rename", "clash-src", "backend-rn")
+		if !strings.Contains(out, "bd label merge") {
+			t.Errorf("expected merge hint: %s", out)
+		}
+	})
+
+	t.Run("label_merge", func(t *testing.T) {
+		both := bdCreate(t, bd, dir, "Merge both", "--type", "task")
+		src := bdCreate(t, bd, dir, "Merge src", "--type", "task")
+		bdLabel(t, bd, dir, "add", both.ID, "merge-a")
+		bdLabel(t, bd, dir, "add", both.ID, "merge-dst")
+		bdLabel(t, bd, dir, "add", src.ID, "merge-b")
+
+		s := strings.TrimSpace(bdLabelJSONOutput(t, bd, dir, "merge", "merge-a", "merge-b", "merge-dst", "--json"))
+		var result labelBulkJSON
+		if err := json.Unmarshal([]byte(s[strings.Index(s, "{"):]), &result); err != nil {
+			t.Fatalf("parse merge JSON: %v\n%s", err, s)
+		}
+		if result.Status != "merged" || result.Target != "merge-dst" || len(result.Issues) != 2 {
+			t.Errorf("unexpected merge result: %+v", result)
+		}
+		for _, id := range []string{both.ID, src.ID} {
+			labels := bdLabelListJSON(t, bd, dir, id)
+			if len(labels) != 1 || labels[0] != "merge-dst" {
+				t.Errorf("labels on %s = %v, want [merge-dst]", id, labels)
+			}
+		}
+	})
+
+	t.Run("label_delete", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Delete label", "--type", "task")
+		bdLabel(t, bd, dir, "add", issue.ID, "doomed")
+
+		out := bdLabel(t, bd, dir, "delete", "doomed")
+		if !strings.Contains(out, "--force") {
+			t.Errorf("expected --force hint: %s", out)
+		}
+		if labels := bdLabelListJSON(t, bd, dir, issue.ID); len(labels) != 1 {
+			t.Fatalf("delete without --force changed labels: %v", labels)
+		}
+
+		bdLabel(t, bd, dir, "delete", "doomed", "--force")
+		if labels := bdLabelListJSON(t, bd, dir, issue.ID); len(labels) != 0 {
+			t.Errorf("labels after delete = %v, want none", labels)
+		}
+		bdLabelFail(t, bd, dir, "delete", "doomed", "--force")
+	})
+
 	// ===== Error Cases =====
 
 	t.Run("label_add_empty_label", func(t *testing.T) {
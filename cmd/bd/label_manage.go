@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// labelBulkJSON is the --json output of bd label rename, merge, and delete.
+type labelBulkJSON struct {
+	Status string   `json:"status"` // renamed, merged, deleted, or dry_run
+	Labels []string `json:"labels"` // labels rewritten or deleted
+	Target string   `json:"target,omitempty"`
+	Issues []string `json:"issues"` // issues (and wisps) whose labels changed
+}
+
+// countLabels returns how many issues and wisps carry each label.
+func countLabels(ctx context.Context, s storage.DoltStorage) (map[string]int, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	labelsByIssue, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("getting labels: %w", err)
+	}
+	counts := make(map[string]int)
+	for _, labels := range labelsByIssue {
+		for _, label := range labels {
+			counts[label]++
+		}
+	}
+	return counts, nil
+}
+
+// issuesWithLabel returns the sorted IDs of issues and wisps carrying label.
+func issuesWithLabel(ctx context.Context, s storage.DoltStorage, label string) ([]string, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{label}})
+	if err != nil {
+		return nil, fmt.Errorf("finding issues labeled '%s': %w", label, err)
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// rewriteLabels removes each of from from every issue carrying it and, when
+// to is set, adds to in its place, all in one transaction. Issues that
+// already carry to keep a single copy. The per-issue label_removed and
+// label_added events are recorded by the transaction's label methods. It
+// returns the sorted IDs of the issues that changed.
+func rewriteLabels(ctx context.Context, s storage.DoltStorage, from []string, to, commitMsg string) ([]string, error) {
+	affected := make(map[string][]string) // issue ID -> labels to remove
+	for _, label := range from {
+		ids, err := issuesWithLabel(ctx, s, label)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			affected[id] = append(affected[id], label)
+		}
+	}
+	if len(affected) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(affected))
+	for id := range affected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	hasTarget := make(map[string]bool)
+	if to != "" {
+		targetIDs, err := issuesWithLabel(ctx, s, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range targetIDs {
+			hasTarget[id] = true
+		}
+	}
+
+	err := transactHonoringAutoCommit(ctx, s, commitMsg, func(tx storage.Transaction) error {
+		for _, id := range ids {
+			for _, label := range affected[id] {
+				if err := tx.RemoveLabel(ctx, id, label, actor); err != nil {
+					return fmt.Errorf("remove label '%s' from %s: %w", label, id, err)
+				}
+			}
+			if to != "" && !hasTarget[id] {
+				if err := tx.AddLabel(ctx, id, to, actor); err != nil {
+					return fmt.Errorf("add label '%s' to %s: %w", to, id, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	commandDidWrite.Store(true)
+	return ids, nil
+}
+
+// validateLabelTarget rejects labels that bd label add would also reject.
+func validateLabelTarget(label string) string {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		FatalErrorRespectJSON("label cannot be empty")
+	}
+	if strings.HasPrefix(label, "provides:") {
+		FatalErrorRespectJSON("'provides:' labels are reserved for cross-project capabilities. Hint: use 'bd ship %s' instead", strings.TrimPrefix(label, "provides:"))
+	}
+	return label
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a label on every issue and wisp that carries it",
+	Long: `Rename a label on every issue and wisp that carries it, in one transaction.
+
+Each affected issue records a label_removed and a label_added event. If any
+issue already carries <new>, use 'bd label merge' instead.
+
+Examples:
+  bd label rename bakend backend
+  bd label rename team:core team:platform --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("label rename")
+		ctx := rootCtx
+		oldLabel := strings.TrimSpace(args[0])
+		newLabel := validateLabelTarget(args[1])
+		if oldLabel == newLabel {
+			FatalErrorRespectJSON("old and new labels are the same")
+		}
+
+		existing, err := issuesWithLabel(ctx, store, newLabel)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(existing) > 0 {
+			FatalErrorWithHintRespectJSON(
+				fmt.Sprintf("label '%s' is already on %d issue(s)", newLabel, len(existing)),
+				fmt.Sprintf("use 'bd label merge %s %s' to combine them", oldLabel, newLabel))
+		}
+
+		ids, err := rewriteLabels(ctx, store, []string{oldLabel}, newLabel,
+			fmt.Sprintf("bd: label rename '%s' -> '%s'", oldLabel, newLabel))
+		if err != nil {
+			FatalErrorRespectJSON("label rename: %v", err)
+		}
+		if len(ids) == 0 {
+			FatalErrorRespectJSON("no issues carry label '%s'", oldLabel)
+		}
+		outputLabelBulk(labelBulkJSON{Status: "renamed", Labels: []string{oldLabel}, Target: newLabel, Issues: ids})
+	},
+}
+
+var labelMergeCmd = &cobra.Command{
+	Use:   "merge <source>... <target>",
+	Short: "Merge one or more labels into a target label",
+	Long: `Replace each source label with the target label on every issue and wisp,
+in one transaction. Issues that already carry the target keep one copy.
+
+Each affected issue records label_removed events for the sources and a
+label_added event for the target when it was missing.
+
+Examples:
+  bd label merge bug-fix bugfix fix
+  bd label merge frontend ui`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("label merge")
+		ctx := rootCtx
+		target := validateLabelTarget(args[len(args)-1])
+		var sources []string
+		seen := map[string]bool{target: true}
+		for _, arg := range args[:len(args)-1] {
+			label := strings.TrimSpace(arg)
+			if label == "" || seen[label] {
+				continue
+			}
+			seen[label] = true
+			sources = append(sources, label)
+		}
+		if len(sources) == 0 {
+			FatalErrorRespectJSON("no source labels differ from the target '%s'", target)
+		}
+
+		ids, err := rewriteLabels(ctx, store, sources, target,
+			fmt.Sprintf("bd: label merge '%s' -> '%s'", strings.Join(sources, "', '"), target))
+		if err != nil {
+			FatalErrorRespectJSON("label merge: %v", err)
+		}
+		if len(ids) == 0 {
+			FatalErrorRespectJSON("no issues carry label(s) '%s'", strings.Join(sources, "', '"))
+		}
+		outputLabelBulk(labelBulkJSON{Status: "merged", Labels: sources, Target: target, Issues: ids})
+	},
+}
+
+var labelDeleteCmd = &cobra.Command{
+	Use:   "delete <label>",
+	Short: "Remove a label from every issue and wisp",
+	Long: `Remove a label from every issue and wisp that carries it, in one transaction.
+
+Without --force, only reports which issues carry the label. Each affected
+issue records a label_removed event.
+
+Examples:
+  bd label delete wontfix           # preview
+  bd label delete wontfix --force`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		label := strings.TrimSpace(args[0])
+		if label == "" {
+			FatalErrorRespectJSON("label cannot be empty")
+		}
+		force, _ := cmd.Flags().GetBool("force")
+
+		if !force {
+			ids, err := issuesWithLabel(ctx, store, label)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if jsonOutput {
+				outputJSON(labelBulkJSON{Status: "dry_run", Labels: []string{label}, Issues: ids})
+				return
+			}
+			fmt.Printf("Label '%s' is on %d issue(s)", label, len(ids))
+			if len(ids) > 0 {
+				fmt.Printf(": %s", strings.Join(ids, ", "))
+			}
+			fmt.Printf("\nRe-run with --force to delete it.\n")
+			return
+		}
+
+		CheckReadonly("label delete")
+		ids, err := rewriteLabels(ctx, store, []string{label}, "", fmt.Sprintf("bd: label delete '%s'", label))
+		if err != nil {
+			FatalErrorRespectJSON("label delete: %v", err)
+		}
+		if len(ids) == 0 {
+			FatalErrorRespectJSON("no issues carry label '%s'", label)
+		}
+		outputLabelBulk(labelBulkJSON{Status: "deleted", Labels: []string{label}, Issues: ids})
+	},
+}
+
+// outputLabelBulk reports a finished rename, merge, or delete.
+func outputLabelBulk(result labelBulkJSON) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	labels := "'" + strings.Join(result.Labels, "', '") + "'"
+	switch result.Status {
+	case "renamed":
+		fmt.Printf("%s Renamed label %s to '%s' on %d issue(s)\n", ui.RenderPass("✓"), labels, result.Target, len(result.Issues))
+	case "merged":
+		fmt.Printf("%s Merged label(s) %s into '%s' on %d issue(s)\n", ui.RenderPass("✓"), labels, result.Target, len(result.Issues))
+	default:
+		fmt.Printf("%s Deleted label %s from %d issue(s)\n", ui.RenderPass("✓"), labels, len(result.Issues))
+	}
+}
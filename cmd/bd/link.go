@@ -20,7 +20,9 @@ dependency (id2 blocks id1). Use --type to specify a different relationship.
 Examples:
   bd link bd-123 bd-456                    # bd-456 blocks bd-123
   bd link bd-123 bd-456 --type related     # bd-123 related to bd-456
-  bd link bd-123 bd-456 --type parent-child`,
+  bd link bd-123 bd-456 --type parent-child
+
+To link a git commit to an issue, use 'bd link commit <sha> <issue-id>'.`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("link")
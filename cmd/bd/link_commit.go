@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// fullSHAPattern matches a complete SHA-1 or SHA-256 commit hash.
+var fullSHAPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+var linkCommitCmd = &cobra.Command{
+	Use:   "commit <sha> <issue-id>",
+	Short: "Link a git commit to an issue",
+	Long: `Record that a git commit touched an issue.
+
+The commit is resolved in the current git repository, so abbreviated SHAs
+and refs like HEAD work, and its subject is stored with the link. A full
+SHA from another repository is accepted as-is; pass --summary to describe it.
+
+Linked commits appear in 'bd show', 'bd history', and 'bd git links'.
+
+Examples:
+  bd link commit HEAD bd-123
+  bd link commit 3f2a9c1 bd-123
+  bd link commit <full-sha> bd-123 --summary "Upstream fix"`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("link commit")
+		summary, _ := cmd.Flags().GetString("summary")
+		ctx := rootCtx
+
+		sha, subject, err := resolveCommit(args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if summary == "" {
+			summary = subject
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		cls, ok := storage.UnwrapStore(store).(storage.CommitLinkStore)
+		if !ok {
+			FatalErrorRespectJSON("commit links are not supported by this storage backend")
+		}
+		link := &types.CommitLink{IssueID: id, CommitSHA: sha, Action: "references", Summary: summary}
+		added, err := cls.AddCommitLink(ctx, link)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if added {
+			commandDidWrite.Store(true)
+		}
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			status := "added"
+			if !added {
+				status = "exists"
+			}
+			outputJSON(map[string]interface{}{
+				"status":     status,
+				"issue_id":   id,
+				"commit_sha": sha,
+				"summary":    summary,
+			})
+			return
+		}
+		if !added {
+			fmt.Printf("Commit %s is already linked to %s\n", shortSHA(sha), id)
+			return
+		}
+		fmt.Printf("%s Linked commit %s to %s\n", ui.RenderPass("✓"), ui.RenderAccent(shortSHA(sha)), formatFeedbackIDParen(id, lookupTitle(id)))
+	},
+}
+
+// resolveCommit returns the full SHA and subject of rev in the current git
+// repository. A full SHA that git cannot resolve is returned unchanged with
+// an empty subject, so commits from other repositories can be linked.
+func resolveCommit(rev string) (sha, subject string, err error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", "", fmt.Errorf("invalid commit %q", rev)
+	}
+	out, gitErr := exec.Command("git", "show", "-s", "--format=%H%x1f%s", rev+"^{commit}", "--").Output() // #nosec G204 -- fixed git subcommand; rev validated above
+	if gitErr == nil {
+		if full, subj, ok := strings.Cut(strings.TrimSpace(string(out)), "\x1f"); ok {
+			return full, subj, nil
+		}
+	}
+	if lower := strings.ToLower(rev); fullSHAPattern.MatchString(lower) {
+		return lower, "", nil
+	}
+	return "", "", fmt.Errorf("unknown commit %q: not found in this git repository", rev)
+}
+
+func init() {
+	linkCommitCmd.Flags().String("summary", "", "Description stored with the link (default: the commit subject)")
+	linkCmd.AddCommand(linkCommitCmd)
+}
@@ -4,19 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/validation"
 )
 
 // LintResult holds the validation result for a single issue.
 type LintResult struct {
-	ID       string   `json:"id"`
-	Title    string   `json:"title"`
-	Type     string   `json:"type"`
-	Missing  []string `json:"missing,omitempty"`
-	Warnings int      `json:"warnings"`
+	ID         string                     `json:"id"`
+	Title      string                     `json:"title"`
+	Type       string                     `json:"type"`
+	Missing    []string                   `json:"missing,omitempty"`
+	Violations []validation.RuleViolation `json:"violations,omitempty"`
+	Warnings   int                        `json:"warnings"`
 }
 
 var lintCmd = &cobra.Command{
@@ -34,18 +38,38 @@ Section requirements by type:
   epic:     Success Criteria
   chore:    (none)
 
+Content rules are configured in config.yaml and are all off by default:
+
+  lint:
+    require-acceptance-criteria: [feature]    # Types needing acceptance criteria
+    description-min-length: 40                # Minimum description length
+    title-max-length: 80                      # Maximum title length
+    title-pattern: '^[A-Z]'                   # Regexp every title must match
+    forbidden-text: [TODO, TBD, lorem ipsum]  # Placeholders (case-insensitive)
+    require-priority: true                    # Flag priorities outside P0-P4
+
+bd lint exits 1 when any issue has warnings, so it can gate CI.
+
 Examples:
   bd lint                    # Lint all open issues
   bd lint bd-abc             # Lint specific issue
   bd lint bd-abc bd-def      # Lint multiple issues
   bd lint --type bug         # Lint only bugs
   bd lint --status all       # Lint all issues (including closed)
+  bd lint --label agent      # Lint issues labeled "agent"
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := rootCtx
 
 		typeFilter, _ := cmd.Flags().GetString("type")
 		statusFilter, _ := cmd.Flags().GetString("status")
+		labelFilters, _ := cmd.Flags().GetStringSlice("label")
+		assigneeFilter, _ := cmd.Flags().GetString("assignee")
+
+		rules, err := lintContentRules()
+		if err != nil {
+			FatalError("%v", err)
+		}
 
 		var issues []*types.Issue
 
@@ -87,6 +111,10 @@ Examples:
 				t := types.IssueType(typeFilter)
 				filter.IssueType = &t
 			}
+			filter.Labels = labelFilters
+			if assigneeFilter != "" {
+				filter.Assignee = &assigneeFilter
+			}
 
 			var err error
 			issues, err = store.SearchIssues(ctx, "", filter)
@@ -99,30 +127,27 @@ Examples:
 		totalWarnings := 0
 
 		for _, issue := range issues {
-			err := validation.LintIssue(issue)
-			if err == nil {
-				continue // No warnings for this issue
-			}
-
-			templateErr, ok := err.(*validation.TemplateError)
-			if !ok {
-				continue
+			var missing []string
+			if templateErr, ok := validation.LintIssue(issue).(*validation.TemplateError); ok {
+				for _, m := range templateErr.Missing {
+					missing = append(missing, m.Heading)
+				}
 			}
-
-			missing := make([]string, len(templateErr.Missing))
-			for i, m := range templateErr.Missing {
-				missing[i] = m.Heading
+			violations := validation.CheckContentRules(issue, rules)
+			if len(missing) == 0 && len(violations) == 0 {
+				continue // No warnings for this issue
 			}
 
 			result := LintResult{
-				ID:       issue.ID,
-				Title:    issue.Title,
-				Type:     string(issue.IssueType),
-				Missing:  missing,
-				Warnings: len(missing),
+				ID:         issue.ID,
+				Title:      issue.Title,
+				Type:       string(issue.IssueType),
+				Missing:    missing,
+				Violations: violations,
+				Warnings:   len(missing) + len(violations),
 			}
 			results = append(results, result)
-			totalWarnings += len(missing)
+			totalWarnings += result.Warnings
 		}
 
 		if jsonOutput {
@@ -146,12 +171,15 @@ Examples:
 			return
 		}
 
-		fmt.Printf("Template warnings (%d issues, %d warnings):\n\n", len(results), totalWarnings)
+		fmt.Printf("Lint warnings (%d issues, %d warnings):\n\n", len(results), totalWarnings)
 		for _, r := range results {
 			fmt.Printf("%s [%s]: %s\n", r.ID, r.Type, r.Title)
 			for _, m := range r.Missing {
 				fmt.Printf("  ⚠ Missing: %s\n", m)
 			}
+			for _, v := range r.Violations {
+				fmt.Printf("  ⚠ %s: %s\n", v.Rule, v.Message)
+			}
 			fmt.Println()
 		}
 
@@ -163,6 +191,33 @@ Examples:
 func init() {
 	lintCmd.Flags().StringP("type", "t", "", "Filter by issue type (bug, task, feature, epic)")
 	lintCmd.Flags().StringP("status", "s", "", "Filter by status (default: open, use 'all' for all)")
+	lintCmd.Flags().StringSliceP("label", "l", nil, "Filter by labels (AND: must have ALL)")
+	lintCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 
 	rootCmd.AddCommand(lintCmd)
 }
+
+// lintContentRules reads the lint.* content rules from config.
+func lintContentRules() (validation.ContentRules, error) {
+	rules := validation.ContentRules{
+		MinDescriptionLength: config.GetInt("lint.description-min-length"),
+		MaxTitleLength:       config.GetInt("lint.title-max-length"),
+		ForbiddenText:        config.GetStringSlice("lint.forbidden-text"),
+		RequirePriority:      config.GetBool("lint.require-priority"),
+	}
+	for _, entry := range config.GetStringSlice("lint.require-acceptance-criteria") {
+		for _, t := range strings.Split(entry, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				rules.RequireAcceptanceCriteria = append(rules.RequireAcceptanceCriteria, types.IssueType(t))
+			}
+		}
+	}
+	if pattern := config.GetString("lint.title-pattern"); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return rules, fmt.Errorf("invalid lint.title-pattern %q: %w", pattern, err)
+		}
+		rules.TitlePattern = re
+	}
+	return rules, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -246,6 +247,61 @@ func TestEmbeddedLint(t *testing.T) {
 	})
 }
 
+// TestEmbeddedLintContentRules checks the lint.* content rules from config.yaml.
+func TestEmbeddedLintContentRules(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "lr")
+
+	rules := "\nlint:\n  description-min-length: 20\n  forbidden-text: [TBD]\n  title-pattern: '^[A-Z]'\n"
+	f, err := os.OpenFile(filepath.Join(beadsDir, "config.yaml"), os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(rules); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	clean := bdCreate(t, bd, dir, "Tidy the changelog", "--type", "chore",
+		"--description", "Group entries by release and fix links.")
+	sloppy := bdCreate(t, bd, dir, "tidy stuff", "--type", "chore",
+		"--description", "TBD", "--label", "agent")
+
+	t.Run("violations_reported", func(t *testing.T) {
+		m := bdLintJSON(t, bd, dir, "--type", "chore")
+		results := m["results"].([]interface{})
+		if len(results) != 1 {
+			t.Fatalf("expected only %s to be flagged, got %v", sloppy.ID, results)
+		}
+		rm := results[0].(map[string]interface{})
+		if rm["id"] != sloppy.ID {
+			t.Errorf("flagged %v, want %s", rm["id"], sloppy.ID)
+		}
+		var got []string
+		for _, v := range rm["violations"].([]interface{}) {
+			got = append(got, v.(map[string]interface{})["rule"].(string))
+		}
+		if strings.Join(got, ",") != "description-length,title-pattern,forbidden-text" {
+			t.Errorf("rules = %v", got)
+		}
+	})
+
+	t.Run("label_filter_and_exit_code", func(t *testing.T) {
+		out, exitCode := bdLint(t, bd, dir, "--label", "agent")
+		if exitCode != 1 || !strings.Contains(out, "forbidden-text:") {
+			t.Errorf("expected exit 1 with forbidden-text warning, got %d:\n%s", exitCode, out)
+		}
+		if _, exitCode := bdLint(t, bd, dir, clean.ID); exitCode != 0 {
+			t.Errorf("expected exit 0 for %s, got %d", clean.ID, exitCode)
+		}
+	})
+}
+
 // TestEmbeddedLintConcurrent exercises lint operations concurrently.
 func TestEmbeddedLintConcurrent(t *testing.T) {
 	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
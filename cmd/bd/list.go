@@ -45,6 +45,23 @@ func withFetchOneExtra(filter types.IssueFilter) types.IssueFilter {
 	return filter
 }
 
+// countListMatches returns how many issues the list filter matches, ignoring
+// Limit and Offset. Plain searches are a SQL COUNT(*); --ready and searches
+// that merge wisps have no count query, so their matches are fetched and
+// counted.
+func countListMatches(ctx context.Context, s storage.DoltStorage, filter types.IssueFilter, ready bool) (int64, error) {
+	filter.Limit, filter.Offset = 0, 0
+	if ready {
+		issues, err := s.GetReadyWork(ctx, readyWorkFilterFromIssueFilter(filter))
+		return int64(len(issues)), err
+	}
+	if filter.SkipWisps && filter.Ephemeral == nil {
+		return s.CountIssues(ctx, "", filter)
+	}
+	issues, err := s.SearchIssues(ctx, "", filter)
+	return int64(len(issues)), err
+}
+
 func readyWorkFilterFromIssueFilter(filter types.IssueFilter) types.WorkFilter {
 	wf := types.WorkFilter{
 		Status:         types.StatusOpen,
@@ -196,7 +213,7 @@ func displayWatchedIssueList(ctx context.Context, store watchListDependencyStore
 			allDeps = deps
 		}
 	}
-	displayPrettyListWithDeps(issues, true, allDeps)
+	displayPrettyListWithDeps(issues, true, allDeps, nil)
 }
 
 func watchIssues(ctx context.Context, store storage.DoltStorage, filter types.IssueFilter, ready bool, parentID string, sortBy string, reverse bool, effectiveLimit int) {
@@ -335,6 +352,31 @@ func issueOrNil(iwc *types.IssueWithCounts) *types.Issue {
 	return iwc.Issue
 }
 
+// attachChildProgress fills Progress on the epic and molecule roots in iwc.
+func attachChildProgress(ctx context.Context, s storage.DoltStorage, iwc []*types.IssueWithCounts) {
+	issues := make([]*types.Issue, 0, len(iwc))
+	for _, item := range iwc {
+		issues = append(issues, issueOrNil(item))
+	}
+	progress := loadChildProgress(ctx, s, issues)
+	for _, item := range iwc {
+		if item != nil && item.Issue != nil {
+			item.Progress = progress[item.ID]
+		}
+	}
+}
+
+// attachBlockingCounts fills BlockingCount on the open issues in iwc that
+// block other open work.
+func attachBlockingCounts(ctx context.Context, s storage.DoltStorage, iwc []*types.IssueWithCounts) {
+	counts := loadBlockingCounts(ctx, s)
+	for _, item := range iwc {
+		if item != nil && item.Issue != nil && item.Status != types.StatusClosed {
+			item.BlockingCount = counts[item.ID]
+		}
+	}
+}
+
 // skipLabelsIssueView wraps IssueWithCounts so the JSON encoder always emits
 // `labels: []` regardless of the omitempty tag on Issue.Labels. AD-02 contract:
 // with --skip-labels, every issue's labels field is present and empty.
@@ -459,17 +501,24 @@ var listCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		in := gatherListInput(cmd)
 
+		if in.allWorkspaces {
+			listAllWorkspaces(rootCtx, in)
+			return
+		}
+
 		if usesProxiedServer() {
+			if in.asOf != "" {
+				FatalError("--as-of is not supported under --proxied-server")
+			}
+			if in.countOnly {
+				FatalError("--count-only is not supported under --proxied-server")
+			}
 			if err := runListProxiedServer(cmd, rootCtx, in); err != nil {
 				FatalError("%v", err)
 			}
 			return
 		}
 
-		if in.offset > 0 {
-			FatalError("--offset is only supported under --proxied-server")
-		}
-
 		cfg, err := loadDirectListFilterConfig(rootCtx, store)
 		if err != nil {
 			FatalError("%v", err)
@@ -492,6 +541,32 @@ var listCmd = &cobra.Command{
 			activeStore = routedStore
 		}
 
+		if in.countOnly {
+			if in.watchMode || in.asOf != "" {
+				FatalError("--count-only cannot be combined with --watch or --as-of")
+			}
+			n, err := countListMatches(ctx, activeStore, filter, in.readyFlag)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if jsonOutput {
+				outputJSON(struct {
+					Count int64 `json:"count"`
+				}{Count: n})
+			} else {
+				fmt.Println(n)
+			}
+			return
+		}
+
+		if in.asOf != "" {
+			if in.watchMode || in.readyFlag || in.offset > 0 {
+				FatalError("--as-of cannot be combined with --watch, --ready, or --offset")
+			}
+			listIssuesAsOf(ctx, activeStore, in, filter)
+			return
+		}
+
 		if in.watchMode {
 			watchIssues(ctx, activeStore, filter, in.readyFlag, in.parentID, in.sortBy, in.reverse, in.effectiveLimit)
 			return
@@ -516,6 +591,8 @@ var listCmd = &cobra.Command{
 			if iwc == nil {
 				iwc = []*types.IssueWithCounts{}
 			}
+			attachChildProgress(ctx, activeStore, iwc)
+			attachBlockingCounts(ctx, activeStore, iwc)
 			if in.skipLabels {
 				outputJSON(newSkipLabelsListJSONResponse(iwc))
 				printTruncationHint(truncated, in.effectiveLimit)
@@ -560,6 +637,9 @@ var listCmd = &cobra.Command{
 		if in.prettyFormat && !jsonOutput {
 			// Special handling for --tree --parent combination (hierarchical descendants)
 			if in.parentID != "" && !in.readyFlag {
+				if in.offset > 0 {
+					FatalError("--offset is not supported with hierarchical --parent + pretty/tree")
+				}
 				treeIssues, err := getHierarchicalChildren(ctx, activeStore, "", in.parentID, filter)
 				if err != nil {
 					FatalError("%v", err)
@@ -573,7 +653,7 @@ var listCmd = &cobra.Command{
 				// Load dependencies for tree structure
 				// Best effort: display gracefully degrades with empty data
 				allDeps, _ := activeStore.GetAllDependencyRecords(ctx)
-				displayPrettyListWithDeps(treeIssues, false, allDeps)
+				displayPrettyListWithDeps(treeIssues, false, allDeps, loadBlockingCounts(ctx, activeStore))
 				printSkipLabelsFooter(in.skipLabels)
 				return
 			}
@@ -582,7 +662,7 @@ var listCmd = &cobra.Command{
 			// Load dependencies for tree structure
 			// Best effort: display gracefully degrades with empty data
 			allDeps, _ := activeStore.GetAllDependencyRecords(ctx)
-			displayPrettyListWithDeps(issues, false, allDeps)
+			displayPrettyListWithDeps(issues, false, allDeps, loadBlockingCounts(ctx, activeStore))
 			printTruncationHint(truncated, in.effectiveLimit)
 			printSkipLabelsFooter(in.skipLabels)
 			return
@@ -616,6 +696,8 @@ var listCmd = &cobra.Command{
 		// Now scoped to only the displayed issues, making it O(displayed_issues).
 		// Best effort: display gracefully degrades with empty data
 		blockedByMap, blocksMap, parentMap, _ := activeStore.GetBlockingInfoForIssues(ctx, issueIDs)
+		progressMap := loadChildProgress(ctx, activeStore, issues)
+		blockingMap := loadBlockingCounts(ctx, activeStore)
 
 		// Build output in buffer for pager support (bd-jdz3)
 		var buf strings.Builder
@@ -632,13 +714,13 @@ var listCmd = &cobra.Command{
 			buf.WriteString(fmt.Sprintf("\nFound %d issues:\n\n", len(issues)))
 			for _, issue := range issues {
 				labels := labelsMap[issue.ID]
-				formatIssueLong(&buf, issue, labels, in.skipLabels)
+				formatIssueLongWithProgress(&buf, issue, labels, in.skipLabels, progressMap[issue.ID])
 			}
 		} else {
 			// Compact format: one line per issue
 			for _, issue := range issues {
 				labels := labelsMap[issue.ID]
-				formatIssueCompact(&buf, issue, labels, blockedByMap[issue.ID], blocksMap[issue.ID], parentMap[issue.ID])
+				formatIssueCompactWithProgress(&buf, issue, labels, blockedByMap[issue.ID], blocksMap[issue.ID], parentMap[issue.ID], progressMap[issue.ID], blockingMap[issue.ID])
 			}
 		}
 
@@ -675,10 +757,12 @@ func init() {
 	listCmd.Flags().String("label-regex", "", "Filter by label regex pattern (e.g., 'tech-(debt|legacy)')")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("spec", "", "Filter by spec_id prefix")
+	listCmd.Flags().String("project", "", "Filter by issue ID prefix, e.g. a monorepo sub-project prefix from the projects config")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 50, "Limit results (default 50, use 0 for unlimited)")
-	listCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based). Only supported under --proxied-server.")
-	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
+	listCmd.Flags().Int("offset", 0, "Skip the first N matching results (0-based); combine with --limit to page")
+	listCmd.Flags().Bool("count-only", false, "Print the number of matching issues instead of listing them (ignores --limit/--offset)")
+	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or a Go template rendered per issue (e.g. '{{.ID}}\\t{{.Title}}')")
 	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
 	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
@@ -762,8 +846,12 @@ func init() {
 	// Pager control (bd-jdz3)
 	listCmd.Flags().Bool("no-pager", false, "Disable pager output")
 
+	// Point-in-time reads via Dolt AS OF
+	listCmd.Flags().String("as-of", "", "List issues as they were at a commit hash, branch, or date/time (e.g. 2026-01-05, -7d)")
+
 	// Ready filter: show only issues ready to be worked on (bd-ihu31)
 	listCmd.Flags().Bool("ready", false, "Show only ready issues (no active blockers, same semantics as bd ready)")
+	listCmd.Flags().Bool("all-workspaces", false, "List issues from every workspace registered with bd ws, grouped by workspace (filters and --limit apply per workspace)")
 
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(listCmd)
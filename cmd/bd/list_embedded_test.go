@@ -691,12 +691,27 @@ func TestEmbeddedList(t *testing.T) {
 		}
 	})
 
-	t.Run("reject_offset_in_direct_mode", func(t *testing.T) {
-		// --offset is only honored under --proxied-server; the direct
-		// (embedded) path must fatal before touching the store.
-		out := bdListFail(t, bd, dir, "--offset", "1")
-		if !strings.Contains(out, "--offset is only supported under --proxied-server") {
-			t.Errorf("expected --offset direct-mode rejection, got: %s", out)
+	t.Run("offset_pages_through_results", func(t *testing.T) {
+		all := bdListJSON(t, bd, dir, "--limit", "0", "--flat")
+		if len(all) < 4 {
+			t.Fatalf("need at least 4 issues to page, got %d", len(all))
+		}
+		page := bdListJSON(t, bd, dir, "--offset", "1", "--limit", "2", "--flat")
+		if len(page) != 2 {
+			t.Fatalf("--offset 1 --limit 2 returned %d issues, want 2", len(page))
+		}
+		for i, issue := range page {
+			if issue.ID != all[i+1].ID {
+				t.Errorf("page[%d] = %s, want %s", i, issue.ID, all[i+1].ID)
+			}
+		}
+	})
+
+	t.Run("count_only", func(t *testing.T) {
+		all := bdListJSON(t, bd, dir, "--limit", "0")
+		out := strings.TrimSpace(bdList(t, bd, dir, "--count-only", "--limit", "1"))
+		if out != fmt.Sprint(len(all)) {
+			t.Errorf("--count-only = %q, want %d (limit must be ignored)", out, len(all))
 		}
 	})
 }
@@ -928,3 +943,43 @@ func TestEmbeddedListConcurrent(t *testing.T) {
 	t.Logf("concurrency test: %d/%d workers succeeded, %d IDs created, %d in final list",
 		successes, numWorkers, len(allIDs), len(finalIssues))
 }
+
+func TestEmbeddedListBlockingCount(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "bc")
+
+	blocker := bdCreate(t, bd, dir, "Shared schema change", "--type", "task")
+	first := bdCreate(t, bd, dir, "API endpoint", "--type", "task")
+	second := bdCreate(t, bd, dir, "UI form", "--type", "task")
+	done := bdCreate(t, bd, dir, "Old migration", "--type", "task")
+	bdDepAdd(t, bd, dir, first.ID, blocker.ID)
+	bdDepAdd(t, bd, dir, second.ID, blocker.ID)
+	bdDepAdd(t, bd, dir, done.ID, blocker.ID)
+	bdClose(t, bd, dir, done.ID, "--force")
+
+	out := bdList(t, bd, dir)
+	var blockerLine string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, blocker.ID) && strings.Contains(line, "Shared schema change") {
+			blockerLine = line
+		}
+	}
+	if !strings.Contains(blockerLine, "blocking 2 issues") {
+		t.Errorf("blocker line = %q, want \"blocking 2 issues\"\nfull output:\n%s", blockerLine, out)
+	}
+
+	for _, issue := range bdListJSON(t, bd, dir) {
+		want := 0
+		if issue.ID == blocker.ID {
+			want = 2
+		}
+		if issue.BlockingCount != want {
+			t.Errorf("%s blocking_count = %d, want %d", issue.ID, issue.BlockingCount, want)
+		}
+	}
+}
@@ -201,6 +201,9 @@ func buildListFilter(in listInput, cfg listFilterConfig) (types.IssueFilter, err
 			filter.IDs = ids
 		}
 	}
+	if in.project != "" {
+		filter.IDPrefix = strings.TrimSuffix(in.project, "-") + "-"
+	}
 	if in.specPrefix != "" {
 		filter.SpecIDPrefix = in.specPrefix
 	}
@@ -84,6 +84,12 @@ func formatPrettyIssueWithContext(issue *types.Issue, parentEpic string) string
 // When labelsSkipped is true (AD-02 --skip-labels), the Labels: line shows
 // "(suppressed by --skip-labels)" instead of the (empty) hydration result.
 func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string, labelsSkipped bool) {
+	formatIssueLongWithProgress(buf, issue, labels, labelsSkipped, nil)
+}
+
+// formatIssueLongWithProgress is formatIssueLong plus a Progress: line for
+// epic and molecule roots with children.
+func formatIssueLongWithProgress(buf *strings.Builder, issue *types.Issue, labels []string, labelsSkipped bool, progress *types.ChildProgress) {
 	status := string(issue.Status)
 	if status == "closed" {
 		line := fmt.Sprintf("%s%s [P%d] [%s] %s\n  %s",
@@ -103,6 +109,9 @@ func formatIssueLong(buf *strings.Builder, issue *types.Issue, labels []string,
 	if issue.Assignee != "" {
 		buf.WriteString(fmt.Sprintf("  Assignee: %s\n", issue.Assignee))
 	}
+	if progress != nil {
+		buf.WriteString(fmt.Sprintf("  Progress: %d/%d children closed (%d%%)\n", progress.Closed, progress.Total, progress.Percent))
+	}
 	if desc := strings.TrimSpace(issue.Description); desc != "" {
 		buf.WriteString("  Description:\n")
 		for _, line := range strings.Split(desc, "\n") {
@@ -229,6 +238,13 @@ func getClosedBlockerIDs(ctx context.Context, s storage.DoltStorage, allDeps map
 // Uses status icons for better scanability - consistent with bd graph
 // Format: [icon] [pin] ID [Priority] [Type] @assignee [labels] - Title (parent: X, blocked by: Y, blocks: Z)
 func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []string, blockedBy, blocks []string, parent string) {
+	formatIssueCompactWithProgress(buf, issue, labels, blockedBy, blocks, parent, nil, 0)
+}
+
+// formatIssueCompactWithProgress is formatIssueCompact with an "N/M (P%)"
+// roll-up after the title of epic and molecule roots and, when blocking is
+// positive, a "blocking N issues" annotation so high-leverage blockers stand out.
+func formatIssueCompactWithProgress(buf *strings.Builder, issue *types.Issue, labels []string, blockedBy, blocks []string, parent string, progress *types.ChildProgress, blocking int) {
 	labelsStr := ""
 	if len(labels) > 0 {
 		labelsStr = fmt.Sprintf(" %v", labels)
@@ -243,6 +259,10 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 	if depInfo != "" {
 		depInfo = " " + depInfo
 	}
+	if progress != nil {
+		depInfo = " " + formatChildProgress(progress) + depInfo
+	}
+	blockingInfo := formatBlockingAnnotation(issue, blocking)
 
 	// Get styled status icon — override to blocked when issue has open blockers (GH#2858)
 	statusIcon := renderStatusIcon(issue.Status)
@@ -259,14 +279,75 @@ func formatIssueCompact(buf *strings.Builder, issue *types.Issue, labels []strin
 		buf.WriteString("\n")
 	} else {
 		// Active issues: status icon + semantic colors for priority/type
-		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s%s\n",
+		buf.WriteString(fmt.Sprintf("%s %s%s [%s] [%s]%s%s - %s%s%s\n",
 			statusIcon,
 			pinIndicator(issue),
 			ui.RenderID(issue.ID),
 			ui.RenderPriority(issue.Priority),
 			ui.RenderType(string(issue.IssueType)),
-			assigneeStr, labelsStr, issue.Title, depInfo))
+			assigneeStr, labelsStr, issue.Title, depInfo, blockingInfo))
+	}
+}
+
+// isProgressRoot reports whether an issue gets a child roll-up in list and
+// show output: epics and molecule roots.
+func isProgressRoot(issue *types.Issue) bool {
+	return issue.IssueType == types.TypeEpic || issue.IssueType == types.TypeMolecule
+}
+
+// loadChildProgress fetches child roll-ups for the progress roots among
+// issues in a single aggregated query. Best effort: nil on error.
+func loadChildProgress(ctx context.Context, s storage.DoltStorage, issues []*types.Issue) map[string]*types.ChildProgress {
+	var ids []string
+	for _, issue := range issues {
+		if issue != nil && isProgressRoot(issue) {
+			ids = append(ids, issue.ID)
+		}
+	}
+	if len(ids) == 0 || s == nil {
+		return nil
+	}
+	progress, err := s.GetChildProgress(ctx, ids)
+	if err != nil {
+		return nil
 	}
+	return progress
+}
+
+// loadBlockingCounts counts, per blocker, the open issues it currently
+// blocks. Best effort: nil on error.
+func loadBlockingCounts(ctx context.Context, s storage.DoltStorage) map[string]int {
+	if s == nil {
+		return nil
+	}
+	blocked, err := s.GetBlockedIssues(ctx, types.WorkFilter{})
+	if err != nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, b := range blocked {
+		for _, id := range b.BlockedBy {
+			counts[id]++
+		}
+	}
+	return counts
+}
+
+// formatBlockingAnnotation renders " blocking 3 issues" for an issue that
+// is not closed and blocks n > 0 open issues, and "" otherwise.
+func formatBlockingAnnotation(issue *types.Issue, n int) string {
+	if n <= 0 || issue.Status == types.StatusClosed {
+		return ""
+	}
+	if n == 1 {
+		return " " + ui.RenderWarn("blocking 1 issue")
+	}
+	return " " + ui.RenderWarn(fmt.Sprintf("blocking %d issues", n))
+}
+
+// formatChildProgress renders a roll-up as "3/5 (60%)".
+func formatChildProgress(p *types.ChildProgress) string {
+	return fmt.Sprintf("%d/%d (%d%%)", p.Closed, p.Total, p.Percent)
 }
 
 // hasCustomMetadata returns true if the issue has non-empty custom metadata.
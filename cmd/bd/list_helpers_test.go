@@ -200,7 +200,7 @@ func TestDisplayReadyList(t *testing.T) {
 	t.Run("WithParentEpics", func(t *testing.T) {
 		epicMap := map[string]string{"bd-1": "My Epic"}
 		out := captureStdout(t, func() error {
-			displayReadyList(issues, epicMap)
+			displayReadyList(issues, epicMap, nil)
 			return nil
 		})
 		if !strings.Contains(out, "bd-1") || !strings.Contains(out, "bd-2") {
@@ -216,7 +216,7 @@ func TestDisplayReadyList(t *testing.T) {
 
 	t.Run("WithNilEpicMap", func(t *testing.T) {
 		out := captureStdout(t, func() error {
-			displayReadyList(issues, nil)
+			displayReadyList(issues, nil, nil)
 			return nil
 		})
 		if !strings.Contains(out, "bd-1") || !strings.Contains(out, "bd-2") {
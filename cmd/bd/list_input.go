@@ -21,6 +21,7 @@ type listInput struct {
 	assignee    string
 	titleSearch string
 	specPrefix  string
+	project     string
 	idFilter    string
 
 	labels        []string
@@ -90,7 +91,12 @@ type listInput struct {
 	effectiveLimit int
 	sqlLimit       int
 
-	offset int // 0-based starting offset; honored under --proxied-server only.
+	offset    int  // 0-based starting offset into the sorted result set.
+	countOnly bool // print the number of matches instead of the issues
+
+	asOf string // commit, branch, or time to read the backlog at; "" = now
+
+	allWorkspaces bool // query every registered workspace instead of the current store
 
 	repoOverride    string
 	repoOverrideSet bool
@@ -112,11 +118,7 @@ func gatherListInput(cmd *cobra.Command) listInput {
 	in.limitChanged = cmd.Flags().Changed("limit")
 	in.allFlag, _ = cmd.Flags().GetBool("all")
 
-	in.formatStr, _ = cmd.Flags().GetString("format")
-	if strings.EqualFold(in.formatStr, "json") {
-		jsonOutput = true
-		in.formatStr = ""
-	}
+	in.formatStr = outputFormatFlag(cmd)
 	in.jsonOutput = jsonOutput
 
 	in.labels, _ = cmd.Flags().GetStringSlice("label")
@@ -126,6 +128,7 @@ func gatherListInput(cmd *cobra.Command) listInput {
 	in.labelRegex, _ = cmd.Flags().GetString("label-regex")
 	in.titleSearch, _ = cmd.Flags().GetString("title")
 	in.specPrefix, _ = cmd.Flags().GetString("spec")
+	in.project, _ = cmd.Flags().GetString("project")
 	in.idFilter, _ = cmd.Flags().GetString("id")
 	in.longFormat, _ = cmd.Flags().GetBool("long")
 	in.sortBy, _ = cmd.Flags().GetString("sort")
@@ -259,6 +262,7 @@ func gatherListInput(cmd *cobra.Command) listInput {
 	}
 	in.noPager, _ = cmd.Flags().GetBool("no-pager")
 	in.readyFlag, _ = cmd.Flags().GetBool("ready")
+	in.allWorkspaces, _ = cmd.Flags().GetBool("all-workspaces")
 
 	if in.sortBy != "" {
 		validSortFields := map[string]bool{
@@ -314,6 +318,10 @@ func gatherListInput(cmd *cobra.Command) listInput {
 		in.offset = offset
 	}
 
+	in.countOnly, _ = cmd.Flags().GetBool("count-only")
+
+	in.asOf, _ = cmd.Flags().GetString("as-of")
+
 	in.repoOverride, _ = cmd.Flags().GetString("repo")
 	in.repoOverrideSet = cmd.Flags().Changed("repo")
 
@@ -1,10 +1,8 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"text/template"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -93,57 +91,34 @@ func outputDotFormat(issues []*types.Issue, depsByIssueID map[string][]*types.De
 	return nil
 }
 
+// outputFormattedList renders --format output for bd list: the 'dot' and
+// 'digraph' dependency-graph presets, or a Go template rendered once per
+// issue.
 func outputFormattedList(issues []*types.Issue, depsByIssueID map[string][]*types.Dependency, formatStr string) error {
-	// Handle special 'dot' format (Graphviz output)
-	if formatStr == "dot" {
+	switch formatStr {
+	case "dot":
 		return outputDotFormat(issues, depsByIssueID)
+	case "digraph":
+		return outputDigraphFormat(issues, depsByIssueID)
 	}
+	return renderFormatTemplate(os.Stdout, formatStr, issues)
+}
 
-	// Built-in format presets
-	presets := map[string]string{
-		"digraph": "{{.IssueID}} {{.DependsOnID}}",
-	}
-
-	// Check if it's a preset
-	templateStr, isPreset := presets[formatStr]
-	if !isPreset {
-		templateStr = formatStr
-	}
-
-	// Parse template
-	tmpl, err := template.New("format").Parse(templateStr)
-	if err != nil {
-		return fmt.Errorf("invalid format template: %w", err)
-	}
-
-	// Build map of all issues for quick lookup
-	issueMap := make(map[string]bool)
+// outputDigraphFormat prints one "from to" line per dependency between listed
+// issues, the input format of golang.org/x/tools/cmd/digraph.
+func outputDigraphFormat(issues []*types.Issue, depsByIssueID map[string][]*types.Dependency) error {
+	issueMap := make(map[string]bool, len(issues))
 	for _, issue := range issues {
 		issueMap[issue.ID] = true
 	}
 
-	// For each issue, output its dependencies using the template
+	// Only output edges where both nodes are in the filtered list
 	for _, issue := range issues {
 		for _, dep := range depsByIssueID[issue.ID] {
-			// Only output edges where both nodes are in the filtered list
 			if issueMap[dep.DependsOnID] {
-				// Template data includes both issue and dependency info
-				data := map[string]interface{}{
-					"IssueID":     issue.ID,
-					"DependsOnID": dep.DependsOnID,
-					"Type":        dep.Type,
-					"Issue":       issue,
-					"Dependency":  dep,
-				}
-
-				var buf bytes.Buffer
-				if err := tmpl.Execute(&buf, data); err != nil {
-					return fmt.Errorf("template execution error: %w", err)
-				}
-				fmt.Println(buf.String())
+				fmt.Printf("%s %s\n", issue.ID, dep.DependsOnID)
 			}
 		}
 	}
-
 	return nil
 }
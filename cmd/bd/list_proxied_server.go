@@ -108,7 +108,7 @@ func runListProxiedHierarchicalParent(ctx context.Context, uw uow.UnitOfWork, in
 		return err
 	}
 
-	displayPrettyListWithDeps(treeIssues, false, depsByIssueID)
+	displayPrettyListWithDeps(treeIssues, false, depsByIssueID, nil)
 	printSkipLabelsFooter(in.skipLabels)
 	return nil
 }
@@ -219,7 +219,7 @@ func runListProxiedWatch(_ *cobra.Command, ctx context.Context, in listInput) er
 	if err != nil {
 		return fmt.Errorf("initial query: %w", err)
 	}
-	displayPrettyListWithDeps(issues, true, deps)
+	displayPrettyListWithDeps(issues, true, deps, nil)
 	printTruncationHint(hasMore, in.effectiveLimit)
 	lastSnapshot := issueSnapshot(issues)
 
@@ -246,7 +246,7 @@ func runListProxiedWatch(_ *cobra.Command, ctx context.Context, in listInput) er
 			snap := issueSnapshot(issues)
 			if snap != lastSnapshot {
 				lastSnapshot = snap
-				displayPrettyListWithDeps(issues, true, deps)
+				displayPrettyListWithDeps(issues, true, deps, nil)
 				printTruncationHint(hasMore, in.effectiveLimit)
 				fmt.Fprintf(os.Stderr, "\nWatching for changes... (Press Ctrl+C to exit)\n")
 			}
@@ -293,7 +293,7 @@ func renderProxiedListText(ctx context.Context, uw uow.UnitOfWork, issues []*typ
 		if err != nil {
 			return err
 		}
-		displayPrettyListWithDeps(issues, false, depsByIssueID)
+		displayPrettyListWithDeps(issues, false, depsByIssueID, nil)
 		printTruncationHint(truncated, in.effectiveLimit)
 		printSkipLabelsFooter(in.skipLabels)
 		return nil
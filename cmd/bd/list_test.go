@@ -1181,6 +1181,39 @@ func TestFormatIssueCompactBlockedIcon(t *testing.T) {
 	})
 }
 
+func TestFormatIssueCompactBlockingCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   types.Status
+		blocking int
+		want     string
+	}{
+		{"one", types.StatusOpen, 1, "blocking 1 issue"},
+		{"several", types.StatusInProgress, 3, "blocking 3 issues"},
+		{"none", types.StatusOpen, 0, ""},
+		{"closed", types.StatusClosed, 2, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := &types.Issue{ID: "test-blocker", Title: "Blocker", Priority: 1, IssueType: types.TypeTask, Status: tt.status}
+			var buf strings.Builder
+			formatIssueCompactWithProgress(&buf, issue, nil, nil, nil, "", nil, tt.blocking)
+			result := buf.String()
+			if tt.want == "" {
+				if strings.Contains(result, "blocking") {
+					t.Errorf("output = %q, want no blocking annotation", result)
+				}
+				return
+			}
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("output = %q, want to contain %q", result, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseTimeFlag(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -120,7 +120,7 @@ func compareIssuesByPriority(a, b *types.Issue) int {
 
 // printPrettyTree recursively prints the issue tree
 // Children are sorted by priority (P0 first) for intuitive reading
-func printPrettyTree(childrenMap map[string][]*types.Issue, parentID string, prefix string) {
+func printPrettyTree(childrenMap map[string][]*types.Issue, parentID string, prefix string, blocking map[string]int) {
 	children := childrenMap[parentID]
 
 	// Sort children by priority using same comparison as roots for consistency
@@ -132,24 +132,25 @@ func printPrettyTree(childrenMap map[string][]*types.Issue, parentID string, pre
 		if isLast {
 			connector = "└── "
 		}
-		fmt.Printf("%s%s%s\n", prefix, connector, formatPrettyIssue(child))
+		fmt.Printf("%s%s%s%s\n", prefix, connector, formatPrettyIssue(child), formatBlockingAnnotation(child, blocking[child.ID]))
 
 		extension := "│   "
 		if isLast {
 			extension = "    "
 		}
-		printPrettyTree(childrenMap, child.ID, prefix+extension)
+		printPrettyTree(childrenMap, child.ID, prefix+extension, blocking)
 	}
 }
 
 // displayPrettyList displays issues in pretty tree format (GH#654)
 // Uses buildIssueTree which only supports dotted ID hierarchy
 func displayPrettyList(issues []*types.Issue, showHeader bool) {
-	displayPrettyListWithDeps(issues, showHeader, nil)
+	displayPrettyListWithDeps(issues, showHeader, nil, nil)
 }
 
-// displayPrettyListWithDeps displays issues in tree format using dependency data
-func displayPrettyListWithDeps(issues []*types.Issue, showHeader bool, allDeps map[string][]*types.Dependency) {
+// displayPrettyListWithDeps displays issues in tree format using dependency
+// data. blocking, when non-nil, maps blockers to the open issues they block.
+func displayPrettyListWithDeps(issues []*types.Issue, showHeader bool, allDeps map[string][]*types.Dependency, blocking map[string]int) {
 	if showHeader {
 		// Clear screen and show header
 		fmt.Print("\033[2J\033[H")
@@ -167,8 +168,8 @@ func displayPrettyListWithDeps(issues []*types.Issue, showHeader bool, allDeps m
 	roots, childrenMap := buildIssueTreeWithDeps(issues, allDeps)
 
 	for _, issue := range roots {
-		fmt.Println(formatPrettyIssue(issue))
-		printPrettyTree(childrenMap, issue.ID, "")
+		fmt.Println(formatPrettyIssue(issue) + formatBlockingAnnotation(issue, blocking[issue.ID]))
+		printPrettyTree(childrenMap, issue.ID, "", blocking)
 	}
 
 	// Summary
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// workspaceIssue is an issue tagged with the workspace it came from, as
+// emitted by bd list --all-workspaces --json.
+type workspaceIssue struct {
+	Workspace string `json:"workspace"`
+	*types.Issue
+}
+
+// listAllWorkspaces runs the list query against every registered workspace
+// and prints the results grouped by workspace. Filters and --limit apply to
+// each workspace separately; a workspace that cannot be opened is skipped
+// with a warning.
+func listAllWorkspaces(ctx context.Context, in listInput) {
+	if in.watchMode || in.asOf != "" || in.countOnly || in.formatStr != "" {
+		FatalErrorRespectJSON("--all-workspaces cannot be combined with --watch, --as-of, --count-only, or --format")
+	}
+	reg, err := config.LoadWorkspaces()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if len(reg.Workspaces) == 0 {
+		FatalErrorRespectJSON("no workspaces registered (see bd ws add)")
+	}
+
+	results := make([]workspaceIssue, 0)
+	var buf strings.Builder
+	for _, ws := range reg.Workspaces {
+		issues, err := listWorkspaceIssues(ctx, ws, in)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping workspace %s: %v\n", ws.Name, err)
+			continue
+		}
+		sortIssues(issues, in.sortBy, in.reverse)
+		for _, issue := range issues {
+			results = append(results, workspaceIssue{Workspace: ws.Name, Issue: issue})
+		}
+		if jsonOutput {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("%s %s\n", ui.RenderBold(ws.Name), ui.RenderMuted(fmt.Sprintf("(%d)", len(issues)))))
+		for _, issue := range issues {
+			buf.WriteString("  ")
+			formatIssueCompact(&buf, issue, issue.Labels, nil, nil, "")
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(results)
+		return
+	}
+	fmt.Print(buf.String())
+}
+
+// listWorkspaceIssues opens ws read-only and returns the issues matching in.
+func listWorkspaceIssues(ctx context.Context, ws config.Workspace, in listInput) ([]*types.Issue, error) {
+	beadsDir := beads.FindBeadsDirFrom(ws.Path)
+	if beadsDir == "" {
+		return nil, fmt.Errorf("no beads project at %s", ws.Path)
+	}
+	s, err := newReadOnlyStoreFromConfig(ctx, beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = s.Close() }()
+
+	cfg, err := loadDirectListFilterConfig(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := buildListFilter(in, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if in.readyFlag {
+		return s.GetReadyWork(ctx, readyWorkFilterFromIssueFilter(filter))
+	}
+	return s.SearchIssues(ctx, "", filter)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// unlockJSON is one entry of bd unlock --json.
+type unlockJSON struct {
+	IssueID  string `json:"issue_id"`
+	Unlocked bool   `json:"unlocked"`
+}
+
+var lockCmd = &cobra.Command{
+	Use:     "lock [id...]",
+	GroupID: "issues",
+	Short:   "Lock issues against changes by other actors",
+	Long: `Lock issues against changes by other actors.
+
+While an issue is locked, the store refuses to update or close it for any
+actor other than the lock holder, whichever command or API makes the change
+(bd update, bd close, bd batch, bd stale sweep, bd age, bd serve). bd delete
+refuses too. update and close accept --force to override the lock; delete
+requires releasing it first with 'bd unlock --force'. Use this to freeze an
+issue while reviewing agent output.
+
+Locks are stored in the database, so they travel with push/pull like the
+issues they guard. A lock without --until holds until released.
+
+Run with no IDs (or --list) to show the active locks.
+
+Examples:
+  bd lock bd-abc --reason "reviewing agent output"
+  bd lock bd-abc --until +2h       # Lock expires in two hours
+  bd lock bd-abc --force           # Take over another actor's lock
+  bd lock                          # List active locks`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listFlag, _ := cmd.Flags().GetBool("list")
+		ctx := rootCtx
+
+		ls, ok := storage.UnwrapStore(store).(storage.LockStore)
+		if !ok {
+			FatalErrorRespectJSON("issue locks are not supported by this storage backend")
+		}
+
+		if listFlag || len(args) == 0 {
+			locks, err := ls.ListIssueLocks(ctx)
+			if err != nil {
+				FatalErrorRespectJSON("listing locks: %v", err)
+			}
+			if jsonOutput {
+				if locks == nil {
+					locks = []*types.IssueLock{}
+				}
+				outputJSON(locks)
+				return
+			}
+			if len(locks) == 0 {
+				fmt.Println("No active locks")
+				return
+			}
+			for _, lock := range locks {
+				fmt.Printf("%s %s locked by %s\n", ui.RenderAccent("*"), lock.IssueID, issueops.DescribeIssueLock(lock))
+			}
+			return
+		}
+
+		CheckReadonly("lock")
+		reason, _ := cmd.Flags().GetString("reason")
+		force, _ := cmd.Flags().GetBool("force")
+
+		var expiresAt *time.Time
+		if untilStr, _ := cmd.Flags().GetString("until"); untilStr != "" {
+			t, err := timeparsing.ParseRelativeTime(untilStr, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("invalid --until format %q. Examples: +1h, tomorrow, next monday, 2025-01-15", untilStr)
+			}
+			if !t.After(time.Now()) {
+				FatalErrorRespectJSON("--until %q is in the past", untilStr)
+			}
+			expiresAt = &t
+		}
+
+		locked := []*types.IssueLock{}
+		for _, id := range args {
+			fullID, err := utils.ResolvePartialID(ctx, store, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+				continue
+			}
+			lock := &types.IssueLock{IssueID: fullID, Holder: actor, Reason: reason, ExpiresAt: expiresAt}
+			if err := ls.LockIssue(ctx, lock, force); err != nil {
+				fmt.Fprintf(os.Stderr, "Error locking %s: %v\n", fullID, err)
+				continue
+			}
+			locked = append(locked, lock)
+			if !jsonOutput {
+				fmt.Printf("%s Locked %s\n", ui.RenderAccent("*"), fullID)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(locked)
+		}
+		if len(locked) > 0 {
+			commandDidWrite.Store(true)
+		}
+		if len(locked) < len(args) {
+			os.Exit(1)
+		}
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:     "unlock [id...]",
+	GroupID: "issues",
+	Short:   "Release issue locks",
+	Long: `Release locks taken with bd lock.
+
+Only the lock holder can release a lock unless --force is given.
+
+Examples:
+  bd unlock bd-abc
+  bd unlock bd-abc --force         # Break another actor's lock`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("unlock")
+		force, _ := cmd.Flags().GetBool("force")
+		ctx := rootCtx
+
+		ls, ok := storage.UnwrapStore(store).(storage.LockStore)
+		if !ok {
+			FatalErrorRespectJSON("issue locks are not supported by this storage backend")
+		}
+
+		results := []unlockJSON{}
+		unlocked := 0
+		for _, id := range args {
+			fullID, err := utils.ResolvePartialID(ctx, store, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+				continue
+			}
+			if err := ls.UnlockIssue(ctx, fullID, actor, force); err != nil {
+				fmt.Fprintf(os.Stderr, "Error unlocking %s: %v\n", fullID, err)
+				results = append(results, unlockJSON{IssueID: fullID})
+				continue
+			}
+			unlocked++
+			results = append(results, unlockJSON{IssueID: fullID, Unlocked: true})
+			if !jsonOutput {
+				fmt.Printf("%s Unlocked %s\n", ui.RenderPass("✓"), fullID)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		}
+		if unlocked > 0 {
+			commandDidWrite.Store(true)
+		}
+		if unlocked < len(args) {
+			os.Exit(1)
+		}
+	},
+}
+
+// checkIssueLock returns an error when id is locked by an actor other than
+// the current one. The store enforces locks on update and close as well;
+// commands check first so they can refuse before doing other work and point
+// at --force. Backends without lock support never block.
+func checkIssueLock(ctx context.Context, s storage.DoltStorage, id string) error {
+	ls, ok := storage.UnwrapStore(s).(storage.LockStore)
+	if !ok {
+		return nil
+	}
+	lock, err := ls.GetIssueLock(ctx, id)
+	if err != nil {
+		return fmt.Errorf("checking lock on %s: %w", id, err)
+	}
+	if lock == nil || lock.Holder == actor {
+		return nil
+	}
+	return fmt.Errorf("%w by %s", storage.ErrIssueLocked, issueops.DescribeIssueLock(lock))
+}
+
+func init() {
+	lockCmd.Flags().String("reason", "", "Why the issue is locked")
+	lockCmd.Flags().String("until", "", "Lock expiry (e.g., +2h, tomorrow); default holds until unlocked")
+	lockCmd.Flags().BoolP("force", "f", false, "Take over a lock held by another actor")
+	lockCmd.Flags().Bool("list", false, "List active locks")
+	lockCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(lockCmd)
+
+	unlockCmd.Flags().BoolP("force", "f", false, "Release a lock held by another actor")
+	unlockCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(unlockCmd)
+}
@@ -0,0 +1,189 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedLock(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "lk")
+	issue := bdCreate(t, bd, dir, "Under review")
+
+	run := func(who string, args ...string) (string, error) {
+		cmd := exec.Command(bd, append([]string{"--actor", who}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	mustRun := func(who string, args ...string) string {
+		t.Helper()
+		out, err := run(who, args...)
+		if err != nil {
+			t.Fatalf("bd %s (as %s): %v\n%s", strings.Join(args, " "), who, err, out)
+		}
+		return out
+	}
+
+	mustRun("alice", "lock", issue.ID, "--reason", "reviewing agent output", "--until", "+2h")
+
+	t.Run("list", func(t *testing.T) {
+		out := mustRun("alice", "lock", "--json")
+		var locks []*types.IssueLock
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "["):]), &locks); err != nil {
+			t.Fatalf("parse locks: %v\n%s", err, out)
+		}
+		if len(locks) != 1 || locks[0].IssueID != issue.ID || locks[0].Holder != "alice" ||
+			locks[0].Reason != "reviewing agent output" || locks[0].ExpiresAt == nil {
+			t.Errorf("locks = %+v", locks)
+		}
+	})
+
+	t.Run("others refused", func(t *testing.T) {
+		if out, _ := run("bob", "update", issue.ID, "--title", "Changed"); !strings.Contains(out, "issue is locked by alice") {
+			t.Errorf("update by bob not refused:\n%s", out)
+		}
+		if out, _ := run("bob", "close", issue.ID); !strings.Contains(out, "use --force to override") {
+			t.Errorf("close by bob not refused:\n%s", out)
+		}
+		if out, err := run("bob", "delete", issue.ID, "--force"); err == nil || !strings.Contains(out, "cannot delete locked issues") {
+			t.Errorf("delete by bob not refused (%v):\n%s", err, out)
+		}
+		if out, err := run("bob", "lock", issue.ID); err == nil {
+			t.Errorf("bob took alice's lock without --force:\n%s", out)
+		}
+		if out, err := run("bob", "unlock", issue.ID); err == nil {
+			t.Errorf("bob released alice's lock without --force:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Under review" || got.Status == types.StatusClosed {
+			t.Errorf("locked issue changed: %+v", got)
+		}
+	})
+
+	t.Run("holder and force", func(t *testing.T) {
+		mustRun("alice", "update", issue.ID, "--title", "Reviewed")
+		mustRun("bob", "update", issue.ID, "--priority", "1", "--force")
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Reviewed" || got.Priority != 1 {
+			t.Errorf("holder/forced updates not applied: %+v", got)
+		}
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		mustRun("bob", "unlock", issue.ID, "--force")
+		if out := mustRun("bob", "lock"); !strings.Contains(out, "No active locks") {
+			t.Errorf("lock still listed after unlock:\n%s", out)
+		}
+		mustRun("bob", "close", issue.ID)
+	})
+}
+
+// TestEmbeddedLockEnforcedByStore checks that locks hold for writers that
+// never call checkIssueLock: bd batch and the stale sweeper go straight to
+// the store.
+func TestEmbeddedLockEnforcedByStore(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "le")
+	locked := bdCreate(t, bd, dir, "Locked by alice")
+	free := bdCreate(t, bd, dir, "Unlocked")
+
+	ctx := t.Context()
+	st := openStore(t, beadsDir, "le")
+	if err := st.LockIssue(ctx, &types.IssueLock{IssueID: locked.ID, Holder: "alice"}, false); err != nil {
+		t.Fatalf("LockIssue: %v", err)
+	}
+
+	t.Run("batch", func(t *testing.T) {
+		for _, script := range []string{
+			"close " + locked.ID + " done",
+			"update " + locked.ID + " priority=0",
+			"update " + free.ID + " priority=1\nclose " + locked.ID,
+		} {
+			err := runBatchScriptInTx(t, ctx, st, script)
+			if !errors.Is(err, storage.ErrIssueLocked) {
+				t.Errorf("batch %q: err = %v, want ErrIssueLocked", script, err)
+			}
+		}
+		for _, id := range []string{locked.ID, free.ID} {
+			got, err := st.GetIssue(ctx, id)
+			if err != nil {
+				t.Fatalf("GetIssue %s: %v", id, err)
+			}
+			if got.Status != types.StatusOpen || got.Priority != 2 {
+				t.Errorf("%s changed by a rolled-back batch: status=%s priority=%d", id, got.Status, got.Priority)
+			}
+		}
+	})
+
+	t.Run("stale sweep", func(t *testing.T) {
+		policy := config.StalePolicy{LabelAfterDays: 30, CloseAfterDays: 7, Label: "stale"}
+		time.Sleep(1100 * time.Millisecond) // event timestamps have second precision
+		for _, id := range []string{locked.ID, free.ID} {
+			if err := st.AddLabel(ctx, id, "stale", "test"); err != nil {
+				t.Fatalf("AddLabel %s: %v", id, err)
+			}
+		}
+		res, err := sweepStaleIssues(ctx, st, policy, "sweeper", false, time.Now().AddDate(0, 0, 60))
+		if err != nil {
+			t.Fatalf("sweep: %v", err)
+		}
+		if got, err := st.GetIssue(ctx, locked.ID); err != nil || got.Status != types.StatusOpen {
+			t.Errorf("locked issue closed by the sweeper: %+v (%v)", got, err)
+		}
+		if got, err := st.GetIssue(ctx, free.ID); err != nil || got.Status != types.StatusClosed {
+			t.Errorf("unlocked issue not closed: %+v (%v)", got, err)
+		}
+		if len(res.Locked) != 1 || res.Locked[0].ID != locked.ID || res.Locked[0].LockedBy != "alice" || len(res.Failed) != 0 {
+			t.Errorf("locked = %+v, failed = %+v, want %s skipped as locked by alice", res.Locked, res.Failed, locked.ID)
+		}
+	})
+
+	t.Run("serve", func(t *testing.T) {
+		srv := newGRPCServer(st, "server", 0)
+		// What the interceptor hands the handler for bob's agent token.
+		ctx := context.WithValue(ctx, serveActorKey{}, "bob")
+		title := "Changed over gRPC"
+		_, err := srv.UpdateIssue(ctx, &beadsv1.UpdateIssueRequest{Id: locked.ID, Title: &title, Actor: "alice"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("update by bob claiming to be alice: %v, want FailedPrecondition", err)
+		}
+		if _, err := srv.CloseIssue(ctx, &beadsv1.CloseIssueRequest{Id: locked.ID}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("close by bob: %v, want FailedPrecondition", err)
+		}
+	})
+
+	t.Run("holder and override", func(t *testing.T) {
+		if err := st.UpdateIssue(ctx, locked.ID, map[string]interface{}{"priority": 1}, "alice"); err != nil {
+			t.Errorf("holder update: %v", err)
+		}
+		if err := st.CloseIssue(storage.WithLockOverride(ctx), locked.ID, "forced", "bob", ""); err != nil {
+			t.Errorf("forced close: %v", err)
+		}
+	})
+}
@@ -119,20 +119,35 @@ var (
 // readOnlyCommands lists commands that only read from the database.
 // These commands open the store in read-only mode. See GH#804.
 var readOnlyCommands = map[string]bool{
-	"list":       true,
-	"ready":      true,
-	"show":       true,
-	"stats":      true,
-	"blocked":    true,
-	"count":      true,
-	"search":     true,
-	"graph":      true,
-	"duplicates": true,
-	"comments":   true, // list comments (not add)
-	"current":    true, // bd sync mode current
-	"ping":       true,
-	"backup":     true, // reads from Dolt, writes only to .beads/backup/
-	"export":     true, // reads from Dolt, writes JSONL to file/stdout
+	"list":             true,
+	"ready":            true,
+	"show":             true,
+	"stats":            true,
+	"blocked":          true,
+	"count":            true,
+	"search":           true,
+	"graph":            true,
+	"duplicates":       true,
+	"comments":         true, // list comments (not add)
+	"current":          true, // bd sync mode current
+	"ping":             true,
+	"backup":           true, // reads from Dolt, writes only to .beads/backup/
+	"export":           true, // reads from Dolt, writes JSONL to file/stdout
+	"advise":           true, // inspects the workspace, changes nothing
+	"plan":             true, // diffs a spec file against the database (bd apply writes)
+	"workload":         true,
+	"skills":           true,
+	"unblock-analysis": true,
+	"mirror":           true, // reads from Dolt, writes only the SQLite mirror
+	"blame":            true,
+	"digest":           true, // reads from Dolt, delivers outside the database
+}
+
+// readOnlyCommandPaths lists read-only commands whose leaf name is shared
+// with a writing command elsewhere in the tree (bd ci report records CI
+// runs), so they are keyed by full command path instead.
+var readOnlyCommandPaths = map[string]bool{
+	"bd report": true, // reads from Dolt, writes only the report directory
 }
 
 // isReadOnlyCommand returns true if the command only reads from the database.
@@ -142,6 +157,12 @@ func isReadOnlyCommand(cmdName string) bool {
 	return readOnlyCommands[cmdName]
 }
 
+// isReadOnlyCmd reports whether cmd only reads from the database, checking
+// both the leaf-name and the full-path lists.
+func isReadOnlyCmd(cmd *cobra.Command) bool {
+	return isReadOnlyCommand(cmd.Name()) || readOnlyCommandPaths[cmd.CommandPath()]
+}
+
 // loadBeadsEnvFile loads .beads/.env into process environment for per-project
 // Dolt credentials (GH#2520). Uses gotenv.Load which is non-overriding —
 // existing shell env vars always take precedence.
@@ -334,7 +355,13 @@ func isSelectedNoDBCommand(cmd *cobra.Command) bool {
 	if cmd.Name() == "context" || cmd.Name() == "where" {
 		return true
 	}
-	if cmd.Parent() == nil || cmd.Parent().Name() != "dolt" {
+	if cmd.Parent() == nil {
+		return false
+	}
+	if cmd.Parent().Name() == "server" {
+		return true
+	}
+	if cmd.Parent().Name() != "dolt" {
 		return false
 	}
 	switch cmd.Name() {
@@ -393,6 +420,9 @@ func prepareSelectedCommandContext(beadsDir string, loadEnv bool) {
 	if err := config.Initialize(); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to reinitialize config for selected beads dir: %v\n", err)
 	}
+	if err := applyConfigProfile(beadsDir); err != nil {
+		FatalError("%v", err)
+	}
 	config.CheckBeadsDirPermissions(beadsDir)
 	loadServerModeFromBeadsDir(beadsDir)
 }
@@ -520,6 +550,7 @@ func init() {
 
 	// Register persistent flags
 	rootCmd.PersistentFlags().StringVarP(&changeDir, "directory", "C", "", "Change to this directory before running the command (like git -C)")
+	rootCmd.PersistentFlags().StringVar(&workspaceFlag, "workspace", "", "Run against a named workspace (see bd ws)")
 	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "Database path (default: auto-discover .beads/*.db)")
 	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Actor name for audit trail (default: $BEADS_ACTOR, git user.name, $USER)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
@@ -529,7 +560,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&readonlyMode, "readonly", false, "Read-only mode: block write operations (for worker sandboxes)")
 	rootCmd.PersistentFlags().BoolVar(&globalFlag, "global", false, "Use the global shared-server database (beads_global)")
 	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd dolt commit; uncommitted changes persist in the working set until then. SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
-	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Generate CPU profile for performance analysis")
+	rootCmd.PersistentFlags().StringVar(&configProfileFlag, "profile", "", "Config profile from metadata.json to apply (default: $BD_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "cpu-profile", false, "Generate CPU profile for performance analysis")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose/debug output")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output (errors only)")
 	rootCmd.PersistentFlags().BoolVar(&ignoreSchemaSkew, "ignore-schema-skew", false, "Proceed despite forward schema drift (some queries may fail)")
@@ -576,14 +608,23 @@ func resolveChangeDirBeadsDir(path string) (string, error) {
 	return beadsDir, nil
 }
 
-func applyChangeDirSelection() {
-	if strings.TrimSpace(changeDir) == "" {
-		return
+func applyChangeDirSelection(cmd *cobra.Command) {
+	var beadsDir string
+	var err error
+	if strings.TrimSpace(changeDir) != "" {
+		if strings.TrimSpace(workspaceFlag) != "" {
+			FatalError("-C and --workspace cannot be combined")
+		}
+		beadsDir, err = resolveChangeDirBeadsDir(changeDir)
+	} else {
+		beadsDir, err = resolveWorkspaceBeadsDir(cmd)
 	}
-	beadsDir, err := resolveChangeDirBeadsDir(changeDir)
 	if err != nil {
 		FatalError("%v", err)
 	}
+	if beadsDir == "" {
+		return
+	}
 	changeDirEnvSnapshot = make(map[string]envSnapshotValue, 3)
 	for _, key := range []string{"BEADS_DIR", "BEADS_DB", "BD_DB"} {
 		value, ok := os.LookupEnv(key)
@@ -643,6 +684,8 @@ var rootCmd = &cobra.Command{
 
 		// Start root span for this command. rootCtx now carries the span, so
 		// all downstream DB and AI calls become child spans automatically.
+		// A TRACEPARENT from the caller makes it a child of the caller's span.
+		rootCtx = telemetry.ContextFromEnv(rootCtx)
 		rootCtx, commandSpan = telemetry.Tracer("bd").Start(rootCtx, "bd.command."+cmd.Name(),
 			oteltrace.WithAttributes(
 				attribute.String("bd.command", cmd.Name()),
@@ -655,7 +698,13 @@ var rootCmd = &cobra.Command{
 		debug.SetVerbose(verboseFlag)
 		debug.SetQuiet(quietFlag)
 
-		applyChangeDirSelection()
+		applyChangeDirSelection(cmd)
+
+		// --profile selects a metadata.json profile for every config load in
+		// this process, the same way BD_PROFILE does.
+		if cmd.Root().PersistentFlags().Changed("profile") {
+			_ = os.Setenv(configfile.ProfileEnvVar, configProfileFlag)
+		}
 
 		// Block dangerous env var overrides that could cause data fragmentation (bd-hevyw).
 		if err := checkBlockedEnvVars(); err != nil {
@@ -763,9 +812,13 @@ var rootCmd = &cobra.Command{
 			"powershell",
 			"prime",
 			"quickstart",
+			"schema", // prints static JSON Schemas
+			"server", // lifecycle subcommands manage the server themselves
 			"setup",
+			"sync", // replays queued writes in child processes
 			"version",
 			"where",
+			"ws", // manages the user-level workspace registry
 			"zsh",
 		}
 
@@ -799,6 +852,20 @@ var rootCmd = &cobra.Command{
 			skipsStoreInit = true
 		}
 
+		// bd list --all-workspaces opens each workspace's store itself.
+		if cmdName == "list" && !isSubcommand {
+			if all, _ := cmd.Flags().GetBool("all-workspaces"); all {
+				skipsStoreInit = true
+			}
+		}
+
+		// bd serve --openapi only prints the static REST API document.
+		if cmdName == "serve" && !isSubcommand {
+			if printSpec, _ := cmd.Flags().GetBool("openapi"); printSpec {
+				skipsStoreInit = true
+			}
+		}
+
 		// Skip for root command with no subcommand (just shows help)
 		if cmd.Parent() == nil && cmdName == cmd.Use {
 			skipsStoreInit = true
@@ -932,7 +999,11 @@ var rootCmd = &cobra.Command{
 		// Check if this is a read-only command (GH#804)
 		// Read-only commands open the store in read-only mode to avoid modifying
 		// the database (which breaks file watchers).
-		useReadOnly := isReadOnlyCommand(cmd.Name())
+		useReadOnly := isReadOnlyCmd(cmd)
+		// import.auto-on-stale lets list/show/ready re-import a JSONL file
+		// that changed after the last import. They still open read-only; the
+		// store is reopened writable only when the file is actually stale.
+		refreshStale := shouldRefreshStaleJSONL(cmd)
 
 		// Auto-migrate database on version bump (bd-jgxi).
 		// Runs for ALL commands (including read-only ones) because the migration
@@ -1047,6 +1118,7 @@ var rootCmd = &cobra.Command{
 			}
 			uowProvider = p
 
+			enforceAccess(cmd, nil)
 			syncCommandContext()
 			return
 		}
@@ -1103,6 +1175,11 @@ var rootCmd = &cobra.Command{
 				}
 				os.Exit(1)
 			}
+			// Offline mode: journal create/update/close for bd sync --flush
+			// rather than failing when the server is unreachable.
+			if queueOfflineWrite(cmd, beadsDir, err) {
+				os.Exit(0)
+			}
 			FatalError("failed to open database: %v", err)
 		}
 
@@ -1121,6 +1198,15 @@ var rootCmd = &cobra.Command{
 		if shouldRunAutoImportJSONL(cmd, store, useReadOnly, globalFlag, doltCfg.ServerMode) {
 			maybeAutoImportJSONL(rootCtx, store, beadsDir)
 		}
+		if refreshStale && !globalFlag && !doltCfg.ServerMode &&
+			jsonlNewerThanLastImport(rootCtx, store, beadsDir, configuredImportJSONLPath(beadsDir)) {
+			if storeIsReadOnly {
+				store = reopenStoreWritable(rootCtx, store, doltCfg)
+			}
+			if !storeIsReadOnly {
+				refreshStaleJSONL(rootCtx, store, beadsDir)
+			}
+		}
 
 		// Validate workspace identity for write commands (GH#2438, GH#2372)
 		// Skip for read-only commands since they can't corrupt data.
@@ -1137,6 +1223,12 @@ var rootCmd = &cobra.Command{
 			hookRunner = hooks.NewRunner(filepath.Join(beadsDir, "hooks"))
 		}
 
+		// Wrap store with OTel spans and bd.storage.* metrics (no-op
+		// unless telemetry is enabled).
+		if store != nil {
+			store = telemetry.WrapStorage(store)
+		}
+
 		// Wrap store with hook-firing decorator so ALL mutations
 		// automatically fire on_create/on_update/on_close hooks.
 		// Set BD_NO_HOOKS=1 to disable all hook firing (useful for
@@ -1146,6 +1238,11 @@ var rootCmd = &cobra.Command{
 			store = storage.NewHookFiringStore(store, hookRunner)
 		}
 
+		// Check the caller's API token (BEADS_TOKEN) against the command's
+		// role requirement. Must run before syncCommandContext so a token's
+		// name becomes the actor.
+		enforceAccess(cmd, store)
+
 		// Warn if multiple databases detected in directory hierarchy
 		warnMultipleDatabases(dbPath)
 
@@ -1241,10 +1338,15 @@ var rootCmd = &cobra.Command{
 				}
 			}
 
+			// Auto-mirror: refresh the read-only SQLite mirror if enabled and due.
+			if shouldRunPostCommandAutoExport(cmd) {
+				maybeAutoMirror(rootCtx)
+			}
+
 			// Auto-push: push to Dolt remote if enabled and due.
 			// Skip for read-only commands to avoid unnecessary network operations
 			// and metadata writes on commands like bd list/show/ready (GH#2191).
-			if !isReadOnlyCommand(cmd.Name()) {
+			if !isReadOnlyCmd(cmd) {
 				maybeAutoPush(rootCtx)
 			}
 
@@ -1287,7 +1389,7 @@ func shouldRunPostCommandAutoExport(cmd *cobra.Command) bool {
 	if cmd == nil {
 		return true
 	}
-	return !isReadOnlyCommand(cmd.Name())
+	return !isReadOnlyCmd(cmd)
 }
 
 func shouldRunAutoImportJSONL(cmd *cobra.Command, s storage.DoltStorage, useReadOnly, globalFlag, serverMode bool) bool {
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/atomicfile"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var mirrorCmd = &cobra.Command{
+	Use:     "mirror [path]",
+	GroupID: "sync",
+	Short:   "Write a read-only SQLite snapshot of the database",
+	Long: `Write a read-only SQLite snapshot of the database.
+
+The mirror holds every issue (wisps and templates included) with its labels,
+dependencies, comments, and events, flattened into plain tables that
+analytics tools and scripts can query without touching the Dolt server:
+
+  issues, labels, dependencies, comments, events, mirror_meta
+
+mirror_meta records the Dolt commit the snapshot was taken from, when it was
+taken, and the bd version. The file is built next to the target, marked
+read-only, and renamed into place, so readers never see a partial mirror.
+Changes made to the mirror are never read back into beads.
+
+The path defaults to mirror.path (relative to .beads/). Building the file
+needs the sqlite3 command-line shell on PATH.
+
+To refresh the mirror automatically after write commands (throttled, and
+skipped when nothing changed since the last mirror):
+
+  bd config set mirror.auto true
+  bd config set mirror.interval 15m
+
+For a fixed schedule instead, run 'bd mirror' from cron.
+
+Examples:
+  bd mirror --to sqlite                     # Write .beads/mirror.sqlite
+  bd mirror --to sqlite /srv/beads.sqlite   # Write a specific file`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		to, _ := cmd.Flags().GetString("to")
+		if to != "sqlite" {
+			FatalErrorWithHint(fmt.Sprintf("unsupported mirror format %q", to), "Use --to sqlite")
+		}
+
+		var path string
+		if len(args) == 1 {
+			path = args[0]
+		} else {
+			var err error
+			if path, err = configuredMirrorPath(); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+
+		ctx := rootCtx
+		snap, err := collectMirrorSnapshot(ctx, store)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := buildSQLiteMirror(ctx, path, snap); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+			saveMirrorState(beadsDir, &mirrorState{LastDoltCommit: snap.Commit, Timestamp: time.Now(), Path: path})
+		}
+
+		summary := newMirrorSummary(path, snap)
+		if jsonOutput {
+			outputJSON(summary)
+			return
+		}
+		fmt.Printf("%s Mirrored %d issue(s) to %s (commit %s)\n", ui.RenderPass("✓"), summary.Issues, path, shortCommit(snap.Commit))
+		fmt.Printf("  %d dependencies, %d labels, %d comments, %d events\n",
+			summary.Dependencies, summary.Labels, summary.Comments, summary.Events)
+	},
+}
+
+func init() {
+	mirrorCmd.Flags().String("to", "sqlite", "Mirror format (only sqlite is supported)")
+	rootCmd.AddCommand(mirrorCmd)
+}
+
+// mirrorSummary is the --json output of bd mirror.
+type mirrorSummary struct {
+	Path         string `json:"path"`
+	Format       string `json:"format"`
+	DoltCommit   string `json:"dolt_commit"`
+	Issues       int    `json:"issues"`
+	Dependencies int    `json:"dependencies"`
+	Labels       int    `json:"labels"`
+	Comments     int    `json:"comments"`
+	Events       int    `json:"events"`
+}
+
+func newMirrorSummary(path string, snap *mirrorSnapshot) mirrorSummary {
+	s := mirrorSummary{Path: path, Format: "sqlite", DoltCommit: snap.Commit, Issues: len(snap.Issues), Events: len(snap.Events)}
+	for _, deps := range snap.Deps {
+		s.Dependencies += len(deps)
+	}
+	for _, labels := range snap.Labels {
+		s.Labels += len(labels)
+	}
+	for _, comments := range snap.Comments {
+		s.Comments += len(comments)
+	}
+	return s
+}
+
+// configuredMirrorPath resolves mirror.path, which is relative to .beads/
+// unless absolute.
+func configuredMirrorPath() (string, error) {
+	path := config.GetString("mirror.path")
+	if path == "" {
+		path = "mirror.sqlite"
+	}
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return "", fmt.Errorf("no .beads directory found; pass the mirror path explicitly")
+	}
+	return filepath.Join(beadsDir, path), nil
+}
+
+// mirrorState tracks the last mirror so auto-mirror can skip unchanged data.
+type mirrorState struct {
+	LastDoltCommit string    `json:"last_dolt_commit"`
+	Timestamp      time.Time `json:"timestamp"`
+	Path           string    `json:"path"`
+}
+
+const mirrorStateFile = "mirror-state.json"
+
+// maybeAutoMirror refreshes the SQLite mirror if mirror.auto is set, the
+// throttle interval has passed, and the database changed since the last
+// mirror. Called from PersistentPostRun after auto-export.
+func maybeAutoMirror(ctx context.Context) {
+	if os.Getenv("BD_GIT_HOOK") == "1" {
+		debug.Logf("auto-mirror: skipping — running as git hook\n")
+		return
+	}
+	if !config.GetBool("mirror.auto") || store == nil {
+		return
+	}
+	if lm, ok := storage.UnwrapStore(store).(storage.LifecycleManager); ok && lm.IsClosed() {
+		return
+	}
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	path, err := configuredMirrorPath()
+	if err != nil {
+		return
+	}
+
+	state := loadMirrorState(beadsDir)
+	interval := config.GetDuration("mirror.interval")
+	if interval == 0 {
+		interval = 15 * time.Minute
+	}
+	currentCommit, err := store.GetCurrentCommit(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: auto-mirror skipped: failed to get current commit: %v\n", err)
+		return
+	}
+	if state.Path == path && state.LastDoltCommit == currentCommit && currentCommit != "" {
+		debug.Logf("auto-mirror: no changes since last mirror\n")
+		return
+	}
+	if state.Path == path && !state.Timestamp.IsZero() && time.Since(state.Timestamp) < interval {
+		debug.Logf("auto-mirror: throttled (last mirror %s ago, interval %s)\n",
+			time.Since(state.Timestamp).Round(time.Second), interval)
+		return
+	}
+
+	snap, err := collectMirrorSnapshot(ctx, store)
+	if err == nil {
+		err = buildSQLiteMirror(ctx, path, snap)
+	}
+	if err != nil {
+		if !isQuiet() && !jsonOutput {
+			fmt.Fprintf(os.Stderr, "Warning: auto-mirror failed: %v\n", err)
+		}
+		return
+	}
+	debug.Logf("auto-mirror: wrote %d issues to %s\n", len(snap.Issues), path)
+	saveMirrorState(beadsDir, &mirrorState{LastDoltCommit: snap.Commit, Timestamp: time.Now(), Path: path})
+}
+
+func loadMirrorState(beadsDir string) *mirrorState {
+	data, err := os.ReadFile(filepath.Join(beadsDir, mirrorStateFile)) //nolint:gosec
+	if err != nil {
+		return &mirrorState{}
+	}
+	var state mirrorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return &mirrorState{}
+	}
+	return &state
+}
+
+func saveMirrorState(beadsDir string, state *mirrorState) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := atomicfile.WriteFile(filepath.Join(beadsDir, mirrorStateFile), data, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: mirror: failed to save state: %v\n", err)
+	}
+}
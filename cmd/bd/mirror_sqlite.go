@@ -0,0 +1,276 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// mirrorSnapshot is everything written to a mirror, read from the store in
+// one pass so the mirror reflects a single Dolt commit.
+type mirrorSnapshot struct {
+	Commit      string
+	GeneratedAt time.Time
+	Issues      []*types.Issue
+	Labels      map[string][]string
+	Deps        map[string][]*types.Dependency
+	Comments    map[string][]*types.Comment
+	Events      []*types.Event
+}
+
+// collectMirrorSnapshot reads every issue (wisps and templates included)
+// with its labels, dependencies, comments, and the full event log.
+func collectMirrorSnapshot(ctx context.Context, s storage.DoltStorage) (*mirrorSnapshot, error) {
+	snap := &mirrorSnapshot{GeneratedAt: time.Now().UTC()}
+	commit, err := s.GetCurrentCommit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current commit: %w", err)
+	}
+	snap.Commit = commit
+
+	snap.Issues, err = s.SearchIssues(ctx, "", types.IssueFilter{Limit: 0})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	sort.Slice(snap.Issues, func(i, j int) bool { return snap.Issues[i].ID < snap.Issues[j].ID })
+	ids := make([]string, len(snap.Issues))
+	for i, issue := range snap.Issues {
+		ids[i] = issue.ID
+	}
+	if len(ids) > 0 {
+		if snap.Labels, err = s.GetLabelsForIssues(ctx, ids); err != nil {
+			return nil, fmt.Errorf("failed to load labels: %w", err)
+		}
+		if snap.Deps, err = s.GetDependencyRecordsForIssues(ctx, ids); err != nil {
+			return nil, fmt.Errorf("failed to load dependencies: %w", err)
+		}
+		if snap.Comments, err = s.GetCommentsForIssues(ctx, ids); err != nil {
+			return nil, fmt.Errorf("failed to load comments: %w", err)
+		}
+	}
+	if snap.Events, err = s.GetAllEventsSince(ctx, time.Time{}); err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	return snap, nil
+}
+
+// sqliteMirrorSchema is the mirror's table layout. It is a flattened,
+// query-friendly view of the Dolt schema rather than a copy of it, so it
+// stays stable across Dolt migrations.
+const sqliteMirrorSchema = `CREATE TABLE mirror_meta (key TEXT PRIMARY KEY, value TEXT);
+CREATE TABLE issues (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL,
+  description TEXT,
+  design TEXT,
+  acceptance_criteria TEXT,
+  notes TEXT,
+  status TEXT,
+  priority INTEGER,
+  issue_type TEXT,
+  assignee TEXT,
+  owner TEXT,
+  estimated_minutes INTEGER,
+  created_at TEXT,
+  created_by TEXT,
+  updated_at TEXT,
+  started_at TEXT,
+  closed_at TEXT,
+  close_reason TEXT,
+  due_at TEXT,
+  defer_until TEXT,
+  external_ref TEXT,
+  source_system TEXT,
+  metadata TEXT,
+  ephemeral INTEGER,
+  pinned INTEGER,
+  is_template INTEGER,
+  mol_type TEXT,
+  work_type TEXT
+);
+CREATE TABLE labels (issue_id TEXT NOT NULL, label TEXT NOT NULL, PRIMARY KEY (issue_id, label));
+CREATE TABLE dependencies (
+  issue_id TEXT NOT NULL,
+  depends_on_id TEXT NOT NULL,
+  type TEXT NOT NULL,
+  created_at TEXT,
+  created_by TEXT,
+  metadata TEXT,
+  PRIMARY KEY (issue_id, depends_on_id, type)
+);
+CREATE TABLE comments (id TEXT, issue_id TEXT NOT NULL, author TEXT, text TEXT, created_at TEXT);
+CREATE TABLE events (
+  id TEXT,
+  issue_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  actor TEXT,
+  old_value TEXT,
+  new_value TEXT,
+  comment TEXT,
+  created_at TEXT
+);
+CREATE INDEX idx_issues_status ON issues (status);
+CREATE INDEX idx_labels_label ON labels (label);
+CREATE INDEX idx_dependencies_depends_on ON dependencies (depends_on_id);
+CREATE INDEX idx_comments_issue ON comments (issue_id);
+CREATE INDEX idx_events_issue ON events (issue_id);
+`
+
+// writeSQLiteMirrorScript writes the SQL that builds a mirror of snap into
+// an empty SQLite database, wrapped in a single transaction.
+func writeSQLiteMirrorScript(w io.Writer, snap *mirrorSnapshot) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "BEGIN;")
+	fmt.Fprint(bw, sqliteMirrorSchema)
+
+	meta := [][2]string{
+		{"bd_version", Version},
+		{"dolt_commit", snap.Commit},
+		{"generated_at", snap.GeneratedAt.Format(time.RFC3339)},
+	}
+	for _, kv := range meta {
+		insertSQLiteRow(bw, "mirror_meta", kv[0], kv[1])
+	}
+
+	for _, issue := range snap.Issues {
+		var estimate any
+		if issue.EstimatedMinutes != nil {
+			estimate = *issue.EstimatedMinutes
+		}
+		var externalRef any
+		if issue.ExternalRef != nil {
+			externalRef = *issue.ExternalRef
+		}
+		insertSQLiteRow(bw, "issues",
+			issue.ID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+			string(issue.Status), issue.Priority, string(issue.IssueType), issue.Assignee, issue.Owner, estimate,
+			issue.CreatedAt, issue.CreatedBy, issue.UpdatedAt, issue.StartedAt, issue.ClosedAt, issue.CloseReason,
+			issue.DueAt, issue.DeferUntil, externalRef, issue.SourceSystem, string(issue.Metadata),
+			issue.Ephemeral, issue.Pinned, issue.IsTemplate, string(issue.MolType), string(issue.WorkType))
+		for _, label := range snap.Labels[issue.ID] {
+			insertSQLiteRow(bw, "labels", issue.ID, label)
+		}
+		for _, dep := range snap.Deps[issue.ID] {
+			insertSQLiteRow(bw, "dependencies", dep.IssueID, dep.DependsOnID, string(dep.Type), dep.CreatedAt, dep.CreatedBy, dep.Metadata)
+		}
+		for _, c := range snap.Comments[issue.ID] {
+			insertSQLiteRow(bw, "comments", c.ID, c.IssueID, c.Author, c.Text, c.CreatedAt)
+		}
+	}
+	for _, e := range snap.Events {
+		insertSQLiteRow(bw, "events", e.ID, e.IssueID, string(e.EventType), e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt)
+	}
+
+	fmt.Fprintln(bw, "COMMIT;")
+	return bw.Flush()
+}
+
+// insertSQLiteRow writes one "INSERT OR REPLACE" statement. OR REPLACE keeps
+// a duplicate row from aborting the mirror; the last copy wins.
+func insertSQLiteRow(w io.Writer, table string, values ...any) {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = sqliteLiteral(v)
+	}
+	fmt.Fprintf(w, "INSERT OR REPLACE INTO %s VALUES (%s);\n", table, strings.Join(literals, ", "))
+}
+
+// sqliteLiteral renders v as a SQLite literal. Times become RFC 3339 text in
+// UTC, zero times and nil pointers become NULL, and bools become 0 or 1.
+func sqliteLiteral(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return sqliteQuote(x)
+	case *string:
+		if x == nil {
+			return "NULL"
+		}
+		return sqliteQuote(*x)
+	case int:
+		return strconv.Itoa(x)
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		if x.IsZero() {
+			return "NULL"
+		}
+		return sqliteQuote(x.UTC().Format(time.RFC3339Nano))
+	case *time.Time:
+		if x == nil {
+			return "NULL"
+		}
+		return sqliteLiteral(*x)
+	default:
+		return sqliteQuote(fmt.Sprint(x))
+	}
+}
+
+// sqliteQuote quotes s as a SQL string. Text containing NUL bytes, which the
+// sqlite3 shell cannot read, is written as a hex blob cast back to text.
+func sqliteQuote(s string) string {
+	if strings.ContainsRune(s, 0) {
+		return "CAST(X'" + hex.EncodeToString([]byte(s)) + "' AS TEXT)"
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// buildSQLiteMirror writes snap to a new SQLite database at path. The
+// database is built next to path by the sqlite3 shell, made read-only, and
+// renamed into place, so readers never see a partial mirror.
+func buildSQLiteMirror(ctx context.Context, path string, snap *mirrorSnapshot) error {
+	sqlite3, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return fmt.Errorf("sqlite3 not found in PATH; install the SQLite command-line shell to build mirrors")
+	}
+	var script bytes.Buffer
+	if err := writeSQLiteMirrorScript(&script, snap); err != nil {
+		return fmt.Errorf("failed to generate mirror: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	// An empty file is a valid empty SQLite database.
+	cmd := exec.CommandContext(ctx, sqlite3, "-bail", tmpPath) // #nosec G204 -- fixed binary, temp path we created
+	cmd.Stdin = &script
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if trimmed := strings.TrimSpace(string(out)); trimmed != "" {
+			return fmt.Errorf("sqlite3 failed: %w: %s", err, trimmed)
+		}
+		return fmt.Errorf("sqlite3 failed: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o444); err != nil {
+		return fmt.Errorf("failed to make mirror read-only: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to move mirror into place: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSQLiteLiteral(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.FixedZone("X", 3600))
+	ref := "gh-9"
+	var nilTime *time.Time
+	var nilString *string
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"string", "plain", "'plain'"},
+		{"quote", "it's", "'it''s'"},
+		{"nul", "a\x00b", "CAST(X'610062' AS TEXT)"},
+		{"string pointer", &ref, "'gh-9'"},
+		{"nil string pointer", nilString, "NULL"},
+		{"nil", nil, "NULL"},
+		{"int", 3, "3"},
+		{"true", true, "1"},
+		{"false", false, "0"},
+		{"time in UTC", ts, "'2026-03-04T04:06:07Z'"},
+		{"zero time", time.Time{}, "NULL"},
+		{"time pointer", &ts, "'2026-03-04T04:06:07Z'"},
+		{"nil time pointer", nilTime, "NULL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqliteLiteral(tt.in); got != tt.want {
+				t.Errorf("sqliteLiteral(%v) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func testMirrorSnapshot() *mirrorSnapshot {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &mirrorSnapshot{
+		Commit:      "abc123",
+		GeneratedAt: created,
+		Issues: []*types.Issue{
+			{ID: "mi-1", Title: "Parent's epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic, CreatedAt: created, UpdatedAt: created},
+			{ID: "mi-2", Title: "Child", Status: types.StatusClosed, Priority: 0, IssueType: types.TypeTask, CreatedAt: created, UpdatedAt: created, ClosedAt: &created, Ephemeral: true},
+		},
+		Labels: map[string][]string{"mi-2": {"backend", "urgent"}},
+		Deps: map[string][]*types.Dependency{
+			"mi-2": {{IssueID: "mi-2", DependsOnID: "mi-1", Type: types.DepParentChild, CreatedAt: created}},
+		},
+		Comments: map[string][]*types.Comment{
+			"mi-1": {{ID: "c1", IssueID: "mi-1", Author: "alice", Text: "looks good", CreatedAt: created}},
+		},
+		Events: []*types.Event{
+			{ID: "e1", IssueID: "mi-2", EventType: types.EventClosed, Actor: "alice", CreatedAt: created},
+		},
+	}
+}
+
+func TestWriteSQLiteMirrorScript(t *testing.T) {
+	var sb strings.Builder
+	if err := writeSQLiteMirrorScript(&sb, testMirrorSnapshot()); err != nil {
+		t.Fatalf("writeSQLiteMirrorScript: %v", err)
+	}
+	script := sb.String()
+	for _, want := range []string{
+		"BEGIN;\n",
+		"CREATE TABLE issues (",
+		"INSERT OR REPLACE INTO mirror_meta VALUES ('dolt_commit', 'abc123');",
+		"'mi-1', 'Parent''s epic'",
+		"INSERT OR REPLACE INTO labels VALUES ('mi-2', 'urgent');",
+		"INSERT OR REPLACE INTO dependencies VALUES ('mi-2', 'mi-1', 'parent-child', '2026-01-02T03:04:05Z', '', '');",
+		"INSERT OR REPLACE INTO events VALUES ('e1', 'mi-2', 'closed', 'alice', NULL, NULL, NULL, '2026-01-02T03:04:05Z');",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	if !strings.HasSuffix(script, "COMMIT;\n") {
+		t.Error("script should end with COMMIT")
+	}
+}
+
+func TestBuildSQLiteMirror(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 not installed")
+	}
+	path := filepath.Join(t.TempDir(), "out", "mirror.sqlite")
+	ctx := context.Background()
+
+	// A second build must replace the read-only first one.
+	for i := 0; i < 2; i++ {
+		if err := buildSQLiteMirror(ctx, path, testMirrorSnapshot()); err != nil {
+			t.Fatalf("buildSQLiteMirror (run %d): %v", i+1, err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat mirror: %v", err)
+	}
+	if info.Mode().Perm()&0o222 != 0 {
+		t.Errorf("mirror should be read-only, mode %v", info.Mode().Perm())
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".mirror.sqlite.*"))
+	if len(leftovers) != 0 {
+		t.Errorf("temp files left behind: %v", leftovers)
+	}
+
+	out, err := exec.Command("sqlite3", path,
+		"SELECT count(*) FROM issues;"+
+			"SELECT title FROM issues WHERE id = 'mi-1';"+
+			"SELECT ephemeral FROM issues WHERE id = 'mi-2';"+
+			"SELECT group_concat(label) FROM labels;"+
+			"SELECT depends_on_id FROM dependencies WHERE issue_id = 'mi-2';"+
+			"SELECT value FROM mirror_meta WHERE key = 'dolt_commit';").CombinedOutput()
+	if err != nil {
+		t.Fatalf("query mirror: %v\n%s", err, out)
+	}
+	want := "2\nParent's epic\n1\nbackend,urgent\nmi-1\nabc123\n"
+	if string(out) != want {
+		t.Errorf("mirror contents:\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -47,10 +47,14 @@ The molecule metaphor:
   - Distilling extracts a proto from an ad-hoc epic
 
 Commands:
+  catalog      List built-in and user-defined templates
+  instantiate  Create a molecule from a catalog template
   show       Show proto/molecule structure and variables
+  lint       Validate molecule structure (cycles, orphans, priorities)
   pour       Instantiate proto as persistent mol (liquid phase)
   wisp       Instantiate proto as ephemeral wisp (vapor phase)
   bond       Polymorphic combine: proto+proto, proto+mol, mol+mol
+  move       Move issues under a new parent
   squash     Condense molecule to digest
   burn       Discard wisp
   distill    Extract proto from ad-hoc epic
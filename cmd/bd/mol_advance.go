@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// molecule.auto-advance modes.
+const (
+	autoAdvanceOff   = "off"
+	autoAdvanceActor = "actor" // closed step's assignee, else the closing actor
+	autoAdvanceAgent = "agent" // molecule root's assignee, else as actor
+)
+
+// AutoAdvanceResult records the step assigned after a molecule step closed.
+type AutoAdvanceResult struct {
+	MoleculeID string `json:"molecule_id"`
+	StepID     string `json:"step_id"`
+	StepTitle  string `json:"step_title"`
+	Assignee   string `json:"assignee"`
+}
+
+// autoAdvanceMode reads molecule.auto-advance, treating unknown values as off.
+func autoAdvanceMode() string {
+	switch mode := config.GetString("molecule.auto-advance"); mode {
+	case autoAdvanceActor, autoAdvanceAgent:
+		return mode
+	case "", autoAdvanceOff:
+		return autoAdvanceOff
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid molecule.auto-advance %q (want off, actor, or agent)\n", mode)
+		return autoAdvanceOff
+	}
+}
+
+// autoAdvanceAssignee picks who receives the next step.
+func autoAdvanceAssignee(mode string, closedStep *types.Issue, rootAssignee, actorName string) string {
+	if mode == autoAdvanceAgent && rootAssignee != "" {
+		return rootAssignee
+	}
+	if closedStep != nil && closedStep.Assignee != "" {
+		return closedStep.Assignee
+	}
+	return actorName
+}
+
+// autoAdvanceCandidates returns the molecule's ready steps that nobody has
+// taken yet, in step order.
+func autoAdvanceCandidates(progress *MoleculeProgress) []*types.Issue {
+	var candidates []*types.Issue
+	for _, step := range progress.Steps {
+		if step.Status == "ready" && step.Issue.Assignee == "" {
+			candidates = append(candidates, step.Issue)
+		}
+	}
+	return candidates
+}
+
+// autoAdvanceMolecule assigns the next unblocked step of closedStep's
+// molecule when molecule.auto-advance is enabled. Unlike 'bd close
+// --continue' it leaves the step open and only sets the assignee, so the
+// assignee still claims it explicitly. Returns nil when nothing was assigned.
+func autoAdvanceMolecule(ctx context.Context, s storage.DoltStorage, closedStep *types.Issue, actorName string) (*AutoAdvanceResult, error) {
+	mode := autoAdvanceMode()
+	if mode == autoAdvanceOff || closedStep == nil {
+		return nil, nil
+	}
+
+	moleculeID := findParentMolecule(ctx, s, closedStep.ID)
+	if moleculeID == "" {
+		return nil, nil // Not part of a molecule
+	}
+	progress, err := getMoleculeProgress(ctx, s, moleculeID)
+	if err != nil {
+		return nil, fmt.Errorf("could not load molecule: %w", err)
+	}
+	if progress.Completed >= progress.Total {
+		return nil, nil
+	}
+
+	assignee := autoAdvanceAssignee(mode, closedStep, progress.Assignee, actorName)
+	if assignee == "" {
+		return nil, nil
+	}
+
+	// Same optimistic concurrency as AdvanceToNextStep: re-check inside the
+	// transaction so two closers never assign the same step.
+	for _, candidate := range autoAdvanceCandidates(progress) {
+		err := s.RunInTransaction(ctx, fmt.Sprintf("bd: auto-advance %s to %s", moleculeID, candidate.ID), func(tx storage.Transaction) error {
+			current, txErr := tx.GetIssue(ctx, candidate.ID)
+			if txErr != nil {
+				return txErr
+			}
+			if current == nil || current.Status != types.StatusOpen || current.Assignee != "" {
+				return fmt.Errorf("step %s already taken", candidate.ID)
+			}
+			return tx.UpdateIssue(ctx, candidate.ID, map[string]interface{}{"assignee": assignee}, actorName)
+		})
+		if err != nil {
+			continue // Taken concurrently; try the next ready step
+		}
+		audit.LogFieldChange(candidate.ID, "assignee", "", assignee, actorName,
+			fmt.Sprintf("auto-advance after %s closed", closedStep.ID))
+		return &AutoAdvanceResult{
+			MoleculeID: moleculeID,
+			StepID:     candidate.ID,
+			StepTitle:  candidate.Title,
+			Assignee:   assignee,
+		}, nil
+	}
+	return nil, nil
+}
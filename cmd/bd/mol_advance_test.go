@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAutoAdvanceAssignee(t *testing.T) {
+	worked := &types.Issue{ID: "mol-1.1", Assignee: "polecat"}
+	unassigned := &types.Issue{ID: "mol-1.1"}
+
+	tests := []struct {
+		name  string
+		mode  string
+		step  *types.Issue
+		root  string
+		actor string
+		want  string
+	}{
+		{"actor mode keeps step assignee", autoAdvanceActor, worked, "witness", "closer", "polecat"},
+		{"actor mode falls back to closer", autoAdvanceActor, unassigned, "witness", "closer", "closer"},
+		{"agent mode prefers molecule agent", autoAdvanceAgent, worked, "witness", "closer", "witness"},
+		{"agent mode without agent acts like actor", autoAdvanceAgent, worked, "", "closer", "polecat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoAdvanceAssignee(tt.mode, tt.step, tt.root, tt.actor); got != tt.want {
+				t.Errorf("autoAdvanceAssignee() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoAdvanceCandidates(t *testing.T) {
+	progress := &MoleculeProgress{Steps: []*StepStatus{
+		{Issue: &types.Issue{ID: "s1"}, Status: "done"},
+		{Issue: &types.Issue{ID: "s2", Assignee: "other"}, Status: "ready"},
+		{Issue: &types.Issue{ID: "s3"}, Status: "blocked"},
+		{Issue: &types.Issue{ID: "s4"}, Status: "ready"},
+		{Issue: &types.Issue{ID: "s5"}, Status: "ready"},
+	}}
+	got := autoAdvanceCandidates(progress)
+	if len(got) != 2 || got[0].ID != "s4" || got[1].ID != "s5" {
+		t.Errorf("candidates = %v, want [s4 s5]", got)
+	}
+}
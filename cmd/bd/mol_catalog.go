@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/formula"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Catalog sources, in lookup priority order for 'bd mol instantiate'.
+const (
+	catalogSourceDB      = "db"      // proto bead stored in the database (user-defined)
+	catalogSourceFormula = "formula" // formula file on the search path (user-defined)
+	catalogSourceBuiltin = "builtin" // formula compiled into bd
+)
+
+var molCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "List molecule templates available to instantiate",
+	Long: `List built-in and user-defined molecule templates.
+
+A template is a root epic with ordered steps and dependencies. Three
+sources are merged, highest priority first:
+
+  db       Protos stored in the database ('template' label)
+  formula  Workflow formulas on the formula search path
+  builtin  Templates that ship with bd
+
+A template shadows lower-priority templates with the same name. Use
+'bd mol catalog add' to store a built-in or formula in the database so it
+can be customized and shared with everyone using this database.
+
+Examples:
+  bd mol catalog                      # List all templates
+  bd mol catalog --json               # Machine-readable catalog
+  bd mol catalog add mol-feature      # Store the built-in in the database
+  bd mol instantiate mol-bugfix --var title="Login fails on Safari"`,
+	Args: cobra.NoArgs,
+	Run:  runMolCatalog,
+}
+
+var molCatalogAddCmd = &cobra.Command{
+	Use:   "add <formula-name>",
+	Short: "Store a built-in or formula template in the database",
+	Long: `Cook a built-in or formula template into a proto stored in the database.
+
+The proto's ID is the template name. Once stored, it takes priority over
+the built-in or formula it came from and can be edited like any issue.
+
+Examples:
+  bd mol catalog add mol-release
+  bd mol catalog add mol-release --force   # Replace an existing proto`,
+	Args: cobra.ExactArgs(1),
+	Run:  runMolCatalogAdd,
+}
+
+var molInstantiateCmd = &cobra.Command{
+	Use:   "instantiate <template>",
+	Short: "Create a molecule from a catalog template",
+	Long: `Instantiate a catalog template, creating the root epic, its steps, and
+their dependencies in a single transaction.
+
+{{name}} placeholders in titles and descriptions are replaced with --var
+values. Variables with defaults may be omitted; missing required variables
+are reported before anything is created.
+
+The template is looked up by name in the database, then on the formula
+search path, then among the built-ins (see 'bd mol catalog').
+
+Examples:
+  bd mol instantiate mol-feature --var title="Dark mode"
+  bd mol instantiate mol-release --var title="Release 2.1" --var version=2.1.0
+  bd mol instantiate mol-bugfix --var title="Crash on save" --dry-run
+  bd mol instantiate mol-feature --var title=Spike --ephemeral`,
+	Args: cobra.ExactArgs(1),
+	Run:  runMolInstantiate,
+}
+
+// MolCatalogEntry is one template in 'bd mol catalog' output.
+type MolCatalogEntry struct {
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	ID          string   `json:"id,omitempty"` // proto ID for db templates
+	Description string   `json:"description,omitempty"`
+	Steps       int      `json:"steps"`
+	Vars        []string `json:"vars"`
+}
+
+func runMolCatalog(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	entries, err := collectMolCatalog(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(entries)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Println("No molecule templates found.")
+		return
+	}
+
+	fmt.Printf("\n%s Molecule catalog (%d templates)\n", ui.RenderAccent("🧪"), len(entries))
+	for _, source := range []string{catalogSourceDB, catalogSourceFormula, catalogSourceBuiltin} {
+		printed := false
+		for _, e := range entries {
+			if e.Source != source {
+				continue
+			}
+			if !printed {
+				fmt.Printf("\n%s:\n", source)
+				printed = true
+			}
+			vars := ""
+			if len(e.Vars) > 0 {
+				vars = ui.RenderMuted(" vars: " + strings.Join(e.Vars, ", "))
+			}
+			fmt.Printf("  %-24s %2d steps  %s%s\n", e.Name, e.Steps, truncateDescription(e.Description, 50), vars)
+		}
+	}
+	fmt.Printf("\nInstantiate with: bd mol instantiate <name> --var key=value\n\n")
+}
+
+// collectMolCatalog merges the three template sources. Within and across
+// sources, the first template seen for a name wins.
+func collectMolCatalog(ctx context.Context) ([]MolCatalogEntry, error) {
+	var db, files, builtins []MolCatalogEntry
+
+	if store != nil {
+		protos, err := store.GetIssuesByLabel(ctx, BeadsTemplateLabel)
+		if err != nil {
+			return nil, fmt.Errorf("listing protos: %w", err)
+		}
+		for _, proto := range protos {
+			subgraph, err := loadTemplateSubgraph(ctx, store, proto.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping proto %s: %v\n", proto.ID, err)
+				continue
+			}
+			db = append(db, MolCatalogEntry{
+				Name:        proto.ID,
+				Source:      catalogSourceDB,
+				ID:          proto.ID,
+				Description: proto.Title,
+				Steps:       len(subgraph.Issues) - 1,
+				Vars:        extractAllVariables(subgraph),
+			})
+		}
+	}
+
+	for _, dir := range getFormulaSearchPaths() {
+		formulas, err := scanFormulaDir(dir)
+		if err != nil {
+			continue // Skip inaccessible directories
+		}
+		for _, f := range formulas {
+			if f.Type == formula.TypeWorkflow {
+				files = append(files, formulaCatalogEntry(f, catalogSourceFormula))
+			}
+		}
+	}
+
+	builtin, err := formula.Builtins()
+	if err != nil {
+		return nil, fmt.Errorf("loading built-in templates: %w", err)
+	}
+	for _, f := range builtin {
+		builtins = append(builtins, formulaCatalogEntry(f, catalogSourceBuiltin))
+	}
+
+	return mergeMolCatalog(db, files, builtins), nil
+}
+
+// mergeMolCatalog concatenates catalog sources in priority order, dropping
+// entries whose name was already taken, and sorts each source by name.
+func mergeMolCatalog(sources ...[]MolCatalogEntry) []MolCatalogEntry {
+	seen := make(map[string]bool)
+	var merged []MolCatalogEntry
+	for _, entries := range sources {
+		start := len(merged)
+		for _, e := range entries {
+			if seen[e.Name] {
+				continue
+			}
+			seen[e.Name] = true
+			merged = append(merged, e)
+		}
+		section := merged[start:]
+		sort.Slice(section, func(i, j int) bool { return section[i].Name < section[j].Name })
+	}
+	return merged
+}
+
+func formulaCatalogEntry(f *formula.Formula, source string) MolCatalogEntry {
+	vars := make([]string, 0, len(f.Vars))
+	for name := range f.Vars {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+	return MolCatalogEntry{
+		Name:        f.Formula,
+		Source:      source,
+		Description: f.Description,
+		Steps:       countSteps(f.Steps),
+		Vars:        vars,
+	}
+}
+
+func runMolCatalogAdd(cmd *cobra.Command, args []string) {
+	CheckReadonly("mol catalog add")
+
+	ctx := rootCtx
+	force, _ := cmd.Flags().GetBool("force")
+	name := args[0]
+
+	if store == nil {
+		FatalError("no database connection")
+	}
+
+	resolved, err := loadAndResolveFormula(name, nil)
+	if err != nil {
+		builtin, builtinErr := formula.LoadBuiltin(name)
+		if builtinErr != nil {
+			FatalErrorRespectJSON("%s is neither a formula on the search path nor a built-in template", name)
+		}
+		resolved = builtin
+	}
+
+	vars := formula.ExtractVariables(resolved)
+	if err := persistCookFormula(ctx, resolved, resolved.Formula, force, vars, nil); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+}
+
+// resolveCatalogTemplate finds a template by name using the catalog's
+// priority order and returns its subgraph and source.
+func resolveCatalogTemplate(ctx context.Context, name string, vars map[string]string) (*TemplateSubgraph, string, error) {
+	if protoID, err := resolveProtoIDOrTitle(ctx, store, name); err == nil {
+		subgraph, err := loadTemplateSubgraph(ctx, store, protoID)
+		if err != nil {
+			return nil, "", fmt.Errorf("loading proto %s: %w", protoID, err)
+		}
+		return subgraph, catalogSourceDB, nil
+	} else if strings.HasPrefix(err.Error(), "ambiguous") {
+		return nil, "", err
+	}
+
+	if subgraph, err := resolveAndCookFormulaWithVars(name, nil, vars); err == nil {
+		return subgraph, catalogSourceFormula, nil
+	}
+
+	builtin, err := formula.LoadBuiltin(name)
+	if err != nil {
+		return nil, "", fmt.Errorf("no template %q in the catalog (see 'bd mol catalog')", name)
+	}
+	subgraph, err := cookFormulaToSubgraphWithVars(builtin, builtin.Formula, builtin.Vars)
+	if err != nil {
+		return nil, "", fmt.Errorf("cooking built-in %s: %w", name, err)
+	}
+	return subgraph, catalogSourceBuiltin, nil
+}
+
+func runMolInstantiate(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("mol instantiate")
+	}
+
+	ctx := rootCtx
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	ephemeral, _ := cmd.Flags().GetBool("ephemeral")
+
+	if store == nil {
+		FatalError("no database connection")
+	}
+
+	vars := make(map[string]string)
+	for _, v := range varFlags {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			FatalError("invalid variable format '%s', expected 'key=value'", v)
+		}
+		vars[parts[0]] = parts[1]
+	}
+
+	subgraph, source, err := resolveCatalogTemplate(ctx, args[0], vars)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	vars = applyVariableDefaults(vars, subgraph)
+	var missingVars []string
+	for _, v := range extractRequiredVariables(subgraph) {
+		if _, ok := vars[v]; !ok {
+			missingVars = append(missingVars, v)
+		}
+	}
+	if len(missingVars) > 0 {
+		FatalErrorWithHint(
+			fmt.Sprintf("missing required variables: %s", strings.Join(missingVars, ", ")),
+			fmt.Sprintf("Provide them with: --var %s=<value>", missingVars[0]),
+		)
+	}
+
+	if dryRun {
+		fmt.Printf("\nDry run: would instantiate %d issues from %s template %s\n\n", len(subgraph.Issues), source, args[0])
+		for _, issue := range subgraph.Issues {
+			marker := ""
+			if issue.ID == subgraph.Root.ID {
+				marker = " [ROOT]"
+			}
+			fmt.Printf("  - %s%s\n", substituteVariables(issue.Title, vars), marker)
+		}
+		return
+	}
+
+	prefix := types.IDPrefixMol
+	if ephemeral {
+		prefix = types.IDPrefixWisp
+	}
+	result, err := spawnMolecule(ctx, store, subgraph, vars, assignee, actor, ephemeral, prefix)
+	if err != nil {
+		FatalErrorRespectJSON("instantiating %s: %v", args[0], err)
+	}
+
+	if jsonOutput {
+		type instantiateResult struct {
+			*InstantiateResult
+			Template string `json:"template"`
+			Source   string `json:"source"`
+		}
+		outputJSON(instantiateResult{result, args[0], source})
+		return
+	}
+
+	fmt.Printf("%s Instantiated %s: created %d issues\n", ui.RenderPass("✓"), args[0], result.Created)
+	fmt.Printf("  Root issue: %s\n", result.NewEpicID)
+	fmt.Printf("  Template source: %s\n", source)
+}
+
+func init() {
+	molCatalogAddCmd.Flags().Bool("force", false, "Replace an existing proto with the same name")
+
+	molInstantiateCmd.Flags().StringArray("var", []string{}, "Variable substitution (key=value)")
+	molInstantiateCmd.Flags().Bool("dry-run", false, "Preview what would be created")
+	molInstantiateCmd.Flags().String("assignee", "", "Assign the root issue to this agent/user")
+	molInstantiateCmd.Flags().Bool("ephemeral", false, "Create the molecule as a wisp")
+
+	molCatalogCmd.AddCommand(molCatalogAddCmd)
+	molCmd.AddCommand(molCatalogCmd)
+	molCmd.AddCommand(molInstantiateCmd)
+}
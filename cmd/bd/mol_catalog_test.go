@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/formula"
+)
+
+func TestMergeMolCatalog(t *testing.T) {
+	db := []MolCatalogEntry{{Name: "mol-feature", Source: catalogSourceDB}}
+	files := []MolCatalogEntry{
+		{Name: "mol-zeta", Source: catalogSourceFormula},
+		{Name: "mol-alpha", Source: catalogSourceFormula},
+	}
+	builtins := []MolCatalogEntry{
+		{Name: "mol-feature", Source: catalogSourceBuiltin},
+		{Name: "mol-bugfix", Source: catalogSourceBuiltin},
+	}
+
+	got := mergeMolCatalog(db, files, builtins)
+	want := []struct{ name, source string }{
+		{"mol-feature", catalogSourceDB},
+		{"mol-alpha", catalogSourceFormula},
+		{"mol-zeta", catalogSourceFormula},
+		{"mol-bugfix", catalogSourceBuiltin},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || got[i].Source != w.source {
+			t.Errorf("entry %d = %s/%s, want %s/%s", i, got[i].Name, got[i].Source, w.name, w.source)
+		}
+	}
+}
+
+func TestInstantiateBuiltinTemplate(t *testing.T) {
+	f, err := formula.LoadBuiltin("mol-release")
+	if err != nil {
+		t.Fatalf("LoadBuiltin: %v", err)
+	}
+
+	entry := formulaCatalogEntry(f, catalogSourceBuiltin)
+	if entry.Steps != countSteps(f.Steps) || len(entry.Vars) != len(f.Vars) {
+		t.Errorf("catalog entry = %+v", entry)
+	}
+
+	subgraph, err := cookFormulaToSubgraphWithVars(f, f.Formula, f.Vars)
+	if err != nil {
+		t.Fatalf("cook: %v", err)
+	}
+	if len(subgraph.Issues) != countSteps(f.Steps)+1 {
+		t.Errorf("got %d issues, want root + %d steps", len(subgraph.Issues), countSteps(f.Steps))
+	}
+	if len(subgraph.Dependencies) == 0 {
+		t.Error("expected step ordering dependencies")
+	}
+
+	required := extractRequiredVariables(subgraph)
+	for _, v := range []string{"title", "version"} {
+		found := false
+		for _, r := range required {
+			found = found || r == v
+		}
+		if !found {
+			t.Errorf("required vars %v missing %q", required, v)
+		}
+	}
+
+	vars := map[string]string{"title": "Release 2.1", "version": "2.1.0"}
+	if got := substituteVariables(subgraph.Root.Title, vars); got != "Release 2.1" {
+		t.Errorf("root title = %q", got)
+	}
+}
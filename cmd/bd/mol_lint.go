@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var molLintCmd = &cobra.Command{
+	Use:   "lint <root-id>",
+	Short: "Validate a molecule's step structure",
+	Long: `Check a molecule (or proto) for structural problems before agents pick it up.
+
+Rules:
+  blocking-cycle        Steps block each other in a cycle, so none can start
+  child-blocks-parent   A step is blocked by its own parent or ancestor,
+                        which deadlocks (same as the doctor check)
+  orphan-step           A step belongs to the molecule only by ID pattern
+                        (parent.N) and has no parent-child link
+  priority-inversion    A step is blocked by a lower-priority step, so the
+                        urgent work waits on work ranked as less important
+
+Priority inversions are warnings; everything else is an error. The command
+exits non-zero when errors are found, or on any finding with --strict.
+
+Examples:
+  bd mol lint bd-abc
+  bd mol lint bd-abc --strict
+  bd mol lint bd-abc --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runMolLint,
+}
+
+// Lint severities.
+const (
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+)
+
+// MolLintFinding is one structural problem in a molecule.
+type MolLintFinding struct {
+	Rule     string   `json:"rule"`
+	Severity string   `json:"severity"`
+	IssueIDs []string `json:"issue_ids"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"`
+}
+
+// MolLintResult is the JSON output of bd mol lint.
+type MolLintResult struct {
+	RootID   string            `json:"root_id"`
+	Steps    int               `json:"steps"`
+	Findings []*MolLintFinding `json:"findings"`
+	Errors   int               `json:"errors"`
+	Warnings int               `json:"warnings"`
+	OK       bool              `json:"ok"`
+}
+
+func runMolLint(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+	rootID, err := resolveProtoIDOrTitle(ctx, store, args[0])
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	subgraph, err := loadTemplateSubgraph(ctx, store, rootID)
+	if err != nil {
+		FatalErrorRespectJSON("loading molecule: %v", err)
+	}
+
+	result := &MolLintResult{
+		RootID:   subgraph.Root.ID,
+		Steps:    len(subgraph.Issues) - 1,
+		Findings: lintMolecule(subgraph),
+	}
+	for _, f := range result.Findings {
+		if f.Severity == lintSeverityError {
+			result.Errors++
+		} else {
+			result.Warnings++
+		}
+	}
+	result.OK = result.Errors == 0 && (!strict || result.Warnings == 0)
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		printMolLintResult(result, subgraph.Root.Title)
+	}
+	if !result.OK {
+		os.Exit(1)
+	}
+}
+
+// lintMolecule runs every structural rule over a loaded molecule subgraph.
+// Findings are ordered by severity, then rule, then first issue ID.
+func lintMolecule(subgraph *TemplateSubgraph) []*MolLintFinding {
+	findings := []*MolLintFinding{}
+	findings = append(findings, lintBlockingCycles(subgraph)...)
+	findings = append(findings, lintChildBlocksParent(subgraph)...)
+	findings = append(findings, lintOrphanSteps(subgraph)...)
+	findings = append(findings, lintPriorityInversions(subgraph)...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == lintSeverityError
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.IssueIDs[0] < b.IssueIDs[0]
+	})
+	return findings
+}
+
+// moleculeBlockingEdges returns blocker lists (issue → issues it waits on)
+// for blocking dependencies with both ends inside the molecule.
+func moleculeBlockingEdges(subgraph *TemplateSubgraph) map[string][]string {
+	edges := make(map[string][]string)
+	for _, dep := range subgraph.Dependencies {
+		if !dep.Type.IsBlockingEdge() {
+			continue
+		}
+		if _, ok := subgraph.IssueMap[dep.IssueID]; !ok {
+			continue
+		}
+		if _, ok := subgraph.IssueMap[dep.DependsOnID]; !ok {
+			continue
+		}
+		edges[dep.IssueID] = append(edges[dep.IssueID], dep.DependsOnID)
+	}
+	for id := range edges {
+		sort.Strings(edges[id])
+	}
+	return edges
+}
+
+// moleculeParents maps each step to its parent-child parent in the molecule.
+func moleculeParents(subgraph *TemplateSubgraph) map[string]string {
+	parents := make(map[string]string)
+	for _, dep := range subgraph.Dependencies {
+		if dep.Type != types.DepParentChild {
+			continue
+		}
+		if _, ok := subgraph.IssueMap[dep.DependsOnID]; ok {
+			parents[dep.IssueID] = dep.DependsOnID
+		}
+	}
+	return parents
+}
+
+func lintBlockingCycles(subgraph *TemplateSubgraph) []*MolLintFinding {
+	edges := moleculeBlockingEdges(subgraph)
+	ids := make([]string, 0, len(subgraph.IssueMap))
+	for id := range subgraph.IssueMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+	seen := make(map[string]bool)
+	var findings []*MolLintFinding
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = onStack
+		stack = append(stack, id)
+		for _, next := range edges[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case onStack:
+				start := len(stack) - 1
+				for stack[start] != next {
+					start--
+				}
+				cycle := append([]string(nil), stack[start:]...)
+				key := cycleKey(cycle)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				path := strings.Join(append(cycle, next), " → ")
+				findings = append(findings, &MolLintFinding{
+					Rule:     "blocking-cycle",
+					Severity: lintSeverityError,
+					IssueIDs: cycle,
+					Message:  fmt.Sprintf("steps block each other in a cycle: %s", path),
+					Fix:      fmt.Sprintf("remove one edge, e.g. 'bd dep remove %s %s'", cycle[len(cycle)-1], next),
+				})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return findings
+}
+
+// cycleKey identifies a cycle regardless of which member the walk entered at.
+func cycleKey(cycle []string) string {
+	sorted := append([]string(nil), cycle...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\x00")
+}
+
+func lintChildBlocksParent(subgraph *TemplateSubgraph) []*MolLintFinding {
+	parents := moleculeParents(subgraph)
+	var findings []*MolLintFinding
+	for _, dep := range subgraph.Dependencies {
+		if !dep.Type.IsBlockingEdge() {
+			continue
+		}
+		// Walk up from the blocked step; a blocker among its ancestors can
+		// never close before the step does.
+		visited := map[string]bool{dep.IssueID: true}
+		for p := parents[dep.IssueID]; p != "" && !visited[p]; p = parents[p] {
+			visited[p] = true
+			if p == dep.DependsOnID {
+				findings = append(findings, &MolLintFinding{
+					Rule:     "child-blocks-parent",
+					Severity: lintSeverityError,
+					IssueIDs: []string{dep.IssueID, dep.DependsOnID},
+					Message:  fmt.Sprintf("%s is blocked by its ancestor %s (%s), which cannot close until %s does", dep.IssueID, dep.DependsOnID, dep.Type, dep.IssueID),
+					Fix:      fmt.Sprintf("bd dep remove %s %s", dep.IssueID, dep.DependsOnID),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+func lintOrphanSteps(subgraph *TemplateSubgraph) []*MolLintFinding {
+	parents := moleculeParents(subgraph)
+	var findings []*MolLintFinding
+	for _, issue := range subgraph.Issues {
+		if issue.ID == subgraph.Root.ID {
+			continue
+		}
+		if _, ok := parents[issue.ID]; ok {
+			continue
+		}
+		parentID := subgraph.Root.ID
+		if i := strings.LastIndex(issue.ID, "."); i > 0 {
+			if _, ok := subgraph.IssueMap[issue.ID[:i]]; ok {
+				parentID = issue.ID[:i]
+			}
+		}
+		findings = append(findings, &MolLintFinding{
+			Rule:     "orphan-step",
+			Severity: lintSeverityError,
+			IssueIDs: []string{issue.ID},
+			Message:  fmt.Sprintf("%s has no parent-child link; it is only matched by its ID", issue.ID),
+			Fix:      fmt.Sprintf("bd dep add %s %s --type parent-child", issue.ID, parentID),
+		})
+	}
+	return findings
+}
+
+func lintPriorityInversions(subgraph *TemplateSubgraph) []*MolLintFinding {
+	var findings []*MolLintFinding
+	for _, dep := range subgraph.Dependencies {
+		if !dep.Type.IsBlockingEdge() {
+			continue
+		}
+		blocked, ok1 := subgraph.IssueMap[dep.IssueID]
+		blocker, ok2 := subgraph.IssueMap[dep.DependsOnID]
+		if !ok1 || !ok2 || blocker.Status == types.StatusClosed {
+			continue
+		}
+		// Lower number = higher priority.
+		if blocker.Priority > blocked.Priority {
+			findings = append(findings, &MolLintFinding{
+				Rule:     "priority-inversion",
+				Severity: lintSeverityWarning,
+				IssueIDs: []string{blocker.ID, blocked.ID},
+				Message:  fmt.Sprintf("P%d step %s is blocked by P%d step %s", blocked.Priority, blocked.ID, blocker.Priority, blocker.ID),
+				Fix:      fmt.Sprintf("bd update %s --priority %d", blocker.ID, blocked.Priority),
+			})
+		}
+	}
+	return findings
+}
+
+func printMolLintResult(result *MolLintResult, title string) {
+	fmt.Printf("\n%s Lint %s (%d steps)\n\n", ui.RenderAccent("🧪"), formatFeedbackID(result.RootID, title), result.Steps)
+	if len(result.Findings) == 0 {
+		fmt.Printf("  %s No structural problems found\n\n", ui.RenderPass("✓"))
+		return
+	}
+	for _, f := range result.Findings {
+		icon := ui.RenderWarn("⚠")
+		if f.Severity == lintSeverityError {
+			icon = ui.RenderFail("✗")
+		}
+		fmt.Printf("  %s [%s] %s\n", icon, f.Rule, f.Message)
+		if f.Fix != "" {
+			fmt.Printf("      → %s\n", f.Fix)
+		}
+	}
+	fmt.Printf("\n%d error(s), %d warning(s)\n\n", result.Errors, result.Warnings)
+}
+
+func init() {
+	molLintCmd.Flags().Bool("strict", false, "Exit non-zero on warnings as well as errors")
+	molCmd.AddCommand(molLintCmd)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func lintTestSubgraph(issues []*types.Issue, deps []*types.Dependency) *TemplateSubgraph {
+	subgraph := &TemplateSubgraph{
+		Root:         issues[0],
+		Issues:       issues,
+		IssueMap:     make(map[string]*types.Issue),
+		Dependencies: deps,
+	}
+	for _, issue := range issues {
+		subgraph.IssueMap[issue.ID] = issue
+	}
+	return subgraph
+}
+
+func lintRules(findings []*MolLintFinding) map[string]int {
+	rules := make(map[string]int)
+	for _, f := range findings {
+		rules[f.Rule]++
+	}
+	return rules
+}
+
+func TestLintMoleculeClean(t *testing.T) {
+	root := &types.Issue{ID: "mol-1", Priority: 1}
+	a := &types.Issue{ID: "mol-1.1", Priority: 1}
+	b := &types.Issue{ID: "mol-1.2", Priority: 2}
+	subgraph := lintTestSubgraph([]*types.Issue{root, a, b}, []*types.Dependency{
+		{IssueID: a.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: b.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+	})
+	if findings := lintMolecule(subgraph); len(findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", findings)
+	}
+}
+
+func TestLintMoleculeFindings(t *testing.T) {
+	root := &types.Issue{ID: "mol-1", Priority: 1}
+	a := &types.Issue{ID: "mol-1.1", Priority: 1}
+	b := &types.Issue{ID: "mol-1.2", Priority: 1}
+	c := &types.Issue{ID: "mol-1.3", Priority: 3}
+	sub := &types.Issue{ID: "mol-1.1.1", Priority: 1}
+	orphan := &types.Issue{ID: "mol-1.4", Priority: 2}
+	subgraph := lintTestSubgraph([]*types.Issue{root, a, b, c, sub, orphan}, []*types.Dependency{
+		{IssueID: a.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: b.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: c.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: sub.ID, DependsOnID: a.ID, Type: types.DepParentChild},
+		// a ⇄ b cycle
+		{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks},
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepWaitsFor},
+		// grandchild blocked by the molecule root
+		{IssueID: sub.ID, DependsOnID: root.ID, Type: types.DepBlocks},
+		// P1 step waits on a P3 step
+		{IssueID: b.ID, DependsOnID: c.ID, Type: types.DepBlocks},
+		// non-blocking edges are ignored
+		{IssueID: c.ID, DependsOnID: a.ID, Type: types.DepRelated},
+	})
+
+	findings := lintMolecule(subgraph)
+	rules := lintRules(findings)
+	want := map[string]int{"blocking-cycle": 1, "child-blocks-parent": 1, "orphan-step": 1, "priority-inversion": 1}
+	for rule, n := range want {
+		if rules[rule] != n {
+			t.Errorf("%s findings = %d, want %d (all: %v)", rule, rules[rule], n, rules)
+		}
+	}
+
+	if last := findings[len(findings)-1]; last.Severity != lintSeverityWarning {
+		t.Errorf("warnings should sort after errors, last = %+v", last)
+	}
+	for _, f := range findings {
+		if f.Fix == "" {
+			t.Errorf("%s finding has no fix: %+v", f.Rule, f)
+		}
+		switch f.Rule {
+		case "child-blocks-parent":
+			if f.IssueIDs[0] != sub.ID || f.IssueIDs[1] != root.ID {
+				t.Errorf("child-blocks-parent ids = %v", f.IssueIDs)
+			}
+		case "orphan-step":
+			if f.IssueIDs[0] != orphan.ID || f.Fix != "bd dep add mol-1.4 mol-1 --type parent-child" {
+				t.Errorf("orphan finding = %+v", f)
+			}
+		case "priority-inversion":
+			if f.IssueIDs[0] != c.ID || f.Fix != "bd update mol-1.3 --priority 1" {
+				t.Errorf("priority finding = %+v", f)
+			}
+		}
+	}
+}
+
+func TestLintMoleculeClosedBlockerNoInversion(t *testing.T) {
+	root := &types.Issue{ID: "mol-1", Priority: 0}
+	done := &types.Issue{ID: "mol-1.1", Priority: 4, Status: types.StatusClosed}
+	next := &types.Issue{ID: "mol-1.2", Priority: 0}
+	subgraph := lintTestSubgraph([]*types.Issue{root, done, next}, []*types.Dependency{
+		{IssueID: done.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: next.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+		{IssueID: next.ID, DependsOnID: done.ID, Type: types.DepBlocks},
+	})
+	if findings := lintMolecule(subgraph); len(findings) != 0 {
+		t.Fatalf("closed blocker should not be flagged, got %+v", findings)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var molMoveCmd = &cobra.Command{
+	Use:   "move <issue-id> [issue-id...] --to <parent-id>",
+	Short: "Move issues under a new parent",
+	Long: `Move issues (and their subtrees) under a new parent in one transaction.
+
+Each issue's parent-child dependency is replaced by one on the new parent and
+a 'moved' event records the old and new parent. The move is refused without
+changing anything when the new parent is one of the moved issues or their
+descendants, or when the new parent (or one of its ancestors) is blocked by
+a moved issue: children inherit their parent's blocked state, so the moved
+work could never become ready.
+
+An issue whose ID is a hierarchical child of its old parent (bd-a3f8.2 under
+bd-a3f8) is renumbered to the new parent's next child ID, and descendants
+sharing its ID follow it (bd-a3f8.2.1 -> bd-c4d1.5.1). Dependencies,
+references, and mentions in issue text are updated and a 'renamed' event is
+recorded. Use --keep-ids to leave IDs alone.
+
+Examples:
+  bd mol move bd-a3f8.2 bd-a3f8.3 --to bd-c4d1
+  bd mol move bd-x9k2 --to bd-c4d1 --keep-ids`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("mol move")
+		ctx := rootCtx
+
+		to, _ := cmd.Flags().GetString("to")
+		keepIDs, _ := cmd.Flags().GetBool("keep-ids")
+
+		parentID, err := utils.ResolvePartialID(ctx, store, to)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", to, err)
+		}
+		ids := make([]string, 0, len(args))
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			ids = append(ids, id)
+		}
+
+		ms, ok := storage.UnwrapStore(store).(storage.IssueMoveStore)
+		if !ok {
+			FatalErrorRespectJSON("moving issues is not supported by this storage backend")
+		}
+		result, err := ms.MoveIssues(ctx, ids, parentID, storage.MoveIssuesOptions{
+			Renumber: !keepIDs,
+			MaxDepth: maxHierarchyDepth(),
+		}, actor)
+		if err != nil {
+			FatalErrorRespectJSON("failed to move issues: %v", err)
+		}
+		if len(result.Moves) > 0 {
+			commandDidWrite.Store(true)
+		}
+		if err := rewriteIDReferences(ctx, store, result.Renames, actor); err != nil {
+			WarnError("failed to update some references: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if len(result.Moves) == 0 {
+			fmt.Printf("%s Already under %s\n", ui.RenderPass("✓"), parentID)
+			return
+		}
+		for _, m := range result.Moves {
+			from := m.OldParent
+			if from == "" {
+				from = "(no parent)"
+			}
+			fmt.Printf("  %s: %s -> %s\n", m.ID, ui.RenderMuted(from), ui.RenderAccent(parentID))
+		}
+		if len(result.Renames) > 0 {
+			oldIDs := make([]string, 0, len(result.Renames))
+			for oldID := range result.Renames {
+				oldIDs = append(oldIDs, oldID)
+			}
+			sort.Strings(oldIDs)
+			fmt.Println()
+			for _, oldID := range oldIDs {
+				fmt.Printf("  %s -> %s\n", ui.RenderWarn(oldID), ui.RenderAccent(result.Renames[oldID]))
+			}
+		}
+		fmt.Printf("\n%s Moved %d issue(s) under %s\n", ui.RenderPass("✓"), len(result.Moves), parentID)
+	},
+}
+
+func init() {
+	molMoveCmd.Flags().String("to", "", "New parent issue ID (required)")
+	molMoveCmd.Flags().Bool("keep-ids", false, "Keep hierarchical IDs instead of renumbering under the new parent")
+	_ = molMoveCmd.MarkFlagRequired("to")
+	molCmd.AddCommand(molMoveCmd)
+}
@@ -0,0 +1,90 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedMolMove(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "mm")
+
+	t.Run("moves_and_renumbers_subtrees", func(t *testing.T) {
+		from := bdCreate(t, bd, dir, "From", "--type", "epic")
+		to := bdCreate(t, bd, dir, "To", "--type", "epic")
+		bdCommand(t, bd, dir, "child", "add", from.ID, "One")
+		bdCommand(t, bd, dir, "child", "add", from.ID, "Two")
+		bdCommand(t, bd, dir, "child", "add", from.ID+".1", "Grandchild")
+
+		out := bdCommand(t, bd, dir, "--json", "mol", "move", from.ID+".1", from.ID+".2", "--to", to.ID)
+		var result types.MoveResult
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &result); err != nil {
+			t.Fatalf("parse move result: %v\n%s", err, out)
+		}
+		if len(result.Moves) != 2 {
+			t.Fatalf("expected 2 moves, got %+v", result.Moves)
+		}
+		for oldID, newID := range map[string]string{
+			from.ID + ".1":   to.ID + ".1",
+			from.ID + ".1.1": to.ID + ".1.1",
+			from.ID + ".2":   to.ID + ".2",
+		} {
+			if got := result.Renames[oldID]; got != newID {
+				t.Errorf("rename of %s = %q, want %q", oldID, got, newID)
+			}
+		}
+		if got := bdShow(t, bd, dir, to.ID+".1.1").Title; got != "Grandchild" {
+			t.Errorf("grandchild not renamed with its parent: title %q", got)
+		}
+		children := bdCommand(t, bd, dir, "children", from.ID)
+		if strings.Contains(children, "One") || strings.Contains(children, "Two") {
+			t.Errorf("moved issues still listed under old parent:\n%s", children)
+		}
+	})
+
+	t.Run("keep_ids", func(t *testing.T) {
+		from := bdCreate(t, bd, dir, "Keep from", "--type", "epic")
+		to := bdCreate(t, bd, dir, "Keep to", "--type", "epic")
+		bdCommand(t, bd, dir, "child", "add", from.ID, "Stays named")
+
+		bdCommand(t, bd, dir, "mol", "move", from.ID+".1", "--to", to.ID, "--keep-ids")
+		if !strings.Contains(bdCommand(t, bd, dir, "children", to.ID), from.ID+".1") {
+			t.Errorf("%s.1 not moved under %s with its ID", from.ID, to.ID)
+		}
+	})
+
+	t.Run("rejects_invalid_moves", func(t *testing.T) {
+		epic := bdCreate(t, bd, dir, "Epic", "--type", "epic")
+		blocker := bdCreate(t, bd, dir, "Blocker", "--type", "epic")
+		bdCommand(t, bd, dir, "child", "add", epic.ID, "Kid")
+		bdCommand(t, bd, dir, "dep", "add", epic.ID, blocker.ID)
+
+		for _, tc := range []struct {
+			args []string
+			want string
+		}{
+			{[]string{epic.ID, "--to", epic.ID + ".1"}, "own descendant"},
+			{[]string{blocker.ID, "--to", epic.ID}, "deadlock"},
+		} {
+			args := append([]string{"mol", "move"}, tc.args...)
+			out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+			if err == nil || !strings.Contains(string(out), tc.want) {
+				t.Errorf("mol move %v: expected %q error, got %v:\n%s", tc.args, tc.want, err, out)
+			}
+		}
+		if strings.Contains(bdCommand(t, bd, dir, "children", epic.ID), blocker.ID) {
+			t.Errorf("rejected move still attached %s under %s", blocker.ID, epic.ID)
+		}
+	})
+}
@@ -21,7 +21,8 @@ A molecule is considered stale if:
   3. Not assigned to anyone (optional, use --unassigned)
   4. Is blocking other work (optional, use --blocking)
 
-By default, shows all complete-but-unclosed molecules.
+By default, shows all complete-but-unclosed molecules. Use 'bd mol sweep'
+to close them, on demand or on a schedule.
 
 Examples:
   bd mol stale              # List all stale molecules
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var molSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Auto-close molecules whose children are all closed",
+	Long: `Close every open molecule root whose children are all closed.
+
+This is the scheduled counterpart of 'bd mol stale': instead of listing
+complete-but-unclosed molecules it closes them. Each close is recorded in the
+events table like any other close, with a reason naming the sweeper and the
+child count, so 'bd history' shows what the sweeper did.
+
+Which roots are swept is configurable:
+
+  molecule.sweep.types    Issue types treated as roots (default: epic, molecule)
+  molecule.sweep.labels   If set, only roots carrying one of these labels
+  molecule.sweep.on-merge Sweep from the post-merge git hook (default: false)
+
+--type and --label override the configured values. Template protos are
+never swept.
+
+Run it once, on a schedule from cron or CI, or keep it running with --every
+alongside a Dolt server.
+
+Examples:
+  bd mol sweep --dry-run               # Show what would be closed
+  bd mol sweep                         # Close complete molecules now
+  bd mol sweep --label patrol          # Only roots labeled patrol
+  bd mol sweep --every 10m             # Sweep every 10 minutes until interrupted`,
+	Args: cobra.NoArgs,
+	Run:  runMolSweep,
+}
+
+// sweepCriteria selects which molecule roots the sweeper may close.
+type sweepCriteria struct {
+	Types  []types.IssueType
+	Labels []string // any-of; empty means no label restriction
+}
+
+// SweptMolecule is one root closed (or closable) by the sweeper.
+type SweptMolecule struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Type     string `json:"issue_type"`
+	Children int    `json:"children"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SweepResult is the JSON output of one sweep pass.
+type SweepResult struct {
+	Closed   []*SweptMolecule `json:"closed"`
+	Failed   []*SweptMolecule `json:"failed,omitempty"`
+	Scanned  int              `json:"scanned"`
+	DryRun   bool             `json:"dry_run,omitempty"`
+	SweptAt  time.Time        `json:"swept_at"`
+	Criteria struct {
+		Types  []types.IssueType `json:"types"`
+		Labels []string          `json:"labels,omitempty"`
+	} `json:"criteria"`
+}
+
+func runMolSweep(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	every, _ := cmd.Flags().GetDuration("every")
+	typeFlags, _ := cmd.Flags().GetStringSlice("type")
+	labelFlags, _ := cmd.Flags().GetStringSlice("label")
+
+	if !dryRun {
+		CheckReadonly("mol sweep")
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+	if every < 0 {
+		FatalErrorRespectJSON("--every must be positive")
+	}
+
+	criteria := sweepCriteriaFromConfig()
+	if cmd.Flags().Changed("type") {
+		criteria.Types = nil
+		for _, t := range typeFlags {
+			criteria.Types = append(criteria.Types, types.IssueType(t))
+		}
+	}
+	if cmd.Flags().Changed("label") {
+		criteria.Labels = labelFlags
+	}
+	if len(criteria.Types) == 0 {
+		FatalErrorRespectJSON("no issue types to sweep (set --type or molecule.sweep.types)")
+	}
+
+	ctx := rootCtx
+	for {
+		result, err := sweepCompletedMolecules(ctx, store, criteria, actor, dryRun, time.Now())
+		if err != nil {
+			if every == 0 {
+				FatalErrorRespectJSON("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: sweep failed: %v\n", err)
+		} else {
+			if !dryRun && len(result.Closed) > 0 {
+				ids := make([]string, len(result.Closed))
+				for i, m := range result.Closed {
+					ids[i] = m.ID
+				}
+				if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+					Command:  "mol sweep",
+					IssueIDs: ids,
+				}); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to commit sweep: %v\n", err)
+				}
+			}
+			printSweepResult(result)
+		}
+
+		if every == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(every):
+		}
+	}
+}
+
+// sweepCriteriaFromConfig reads molecule.sweep.types and molecule.sweep.labels.
+func sweepCriteriaFromConfig() sweepCriteria {
+	var c sweepCriteria
+	for _, t := range config.GetStringSlice("molecule.sweep.types") {
+		c.Types = append(c.Types, types.IssueType(t))
+	}
+	c.Labels = config.GetStringSlice("molecule.sweep.labels")
+	return c
+}
+
+// sweepCompletedMolecules closes every open root matching c whose children
+// are all closed. Child counts come from one aggregated GetChildProgress
+// query; each close goes through CloseIssue so it lands in the events table.
+func sweepCompletedMolecules(ctx context.Context, s storage.DoltStorage, c sweepCriteria, actorName string, dryRun bool, now time.Time) (*SweepResult, error) {
+	result := &SweepResult{Closed: []*SweptMolecule{}, DryRun: dryRun, SweptAt: now}
+	result.Criteria.Types = c.Types
+	result.Criteria.Labels = c.Labels
+
+	var roots []*types.Issue
+	for _, t := range c.Types {
+		issueType := t
+		issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+			IssueType:     &issueType,
+			LabelsAny:     c.Labels,
+			ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+			ExcludeLabels: []string{BeadsTemplateLabel},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing %s roots: %w", t, err)
+		}
+		roots = append(roots, issues...)
+	}
+	result.Scanned = len(roots)
+	if len(roots) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(roots))
+	for i, r := range roots {
+		ids[i] = r.ID
+	}
+	progress, err := s.GetChildProgress(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading child progress: %w", err)
+	}
+
+	for _, root := range selectSweepable(roots, progress, c) {
+		p := progress[root.ID]
+		swept := &SweptMolecule{ID: root.ID, Title: root.Title, Type: string(root.IssueType), Children: p.Total}
+		if !dryRun {
+			reason := fmt.Sprintf("swept: all %d children closed", p.Total)
+			if err := s.CloseIssue(ctx, root.ID, reason, actorName, ""); err != nil {
+				swept.Error = err.Error()
+				result.Failed = append(result.Failed, swept)
+				continue
+			}
+		}
+		result.Closed = append(result.Closed, swept)
+	}
+	return result, nil
+}
+
+// selectSweepable returns the roots whose children are all closed, sorted
+// by ID. Roots with no children are never swept.
+func selectSweepable(roots []*types.Issue, progress map[string]*types.ChildProgress, c sweepCriteria) []*types.Issue {
+	var out []*types.Issue
+	for _, root := range roots {
+		if root.Status == types.StatusClosed || root.Status == types.StatusPinned {
+			continue
+		}
+		if !slices.Contains(c.Types, root.IssueType) {
+			continue
+		}
+		if len(c.Labels) > 0 && !slices.ContainsFunc(root.Labels, func(l string) bool { return slices.Contains(c.Labels, l) }) {
+			continue
+		}
+		if slices.Contains(root.Labels, BeadsTemplateLabel) {
+			continue
+		}
+		if p := progress[root.ID]; p == nil || p.Total == 0 || p.Closed < p.Total {
+			continue
+		}
+		out = append(out, root)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func printSweepResult(result *SweepResult) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	if isQuiet() && len(result.Failed) == 0 {
+		return
+	}
+	verb := "Closed"
+	if result.DryRun {
+		verb = "Would close"
+	}
+	stamp := result.SweptAt.Format("15:04:05")
+	if len(result.Closed) == 0 && len(result.Failed) == 0 {
+		fmt.Printf("[%s] No complete molecules to sweep (%d roots scanned)\n", stamp, result.Scanned)
+		return
+	}
+	fmt.Printf("[%s] %s %d complete molecule(s):\n", stamp, verb, len(result.Closed))
+	for _, m := range result.Closed {
+		fmt.Printf("  %s %s (%d children)\n", ui.RenderPass("✓"), formatFeedbackID(m.ID, m.Title), m.Children)
+	}
+	for _, m := range result.Failed {
+		fmt.Printf("  %s %s: %s\n", ui.RenderFail("✗"), m.ID, m.Error)
+	}
+}
+
+// sweepMoleculesForHook runs 'bd mol sweep' from a git hook when
+// molecule.sweep.on-merge is enabled. Best effort: failures are logged, never
+// returned, so they cannot block the merge.
+func sweepMoleculesForHook(reason string) {
+	if !config.GetBool("molecule.sweep.on-merge") {
+		return
+	}
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	debug.Logf("%s: sweeping complete molecules\n", reason)
+
+	// Shell out like importJSONLForSync so the hook process never opens the
+	// database itself.
+	cmd := exec.Command("bd", "mol", "sweep", "--quiet")
+	cmd.Dir = exportSubprocessDir(beadsDir)
+	cmd.Env = filterEnv(os.Environ(), "BD_GIT_HOOK")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "beads: %s molecule sweep warning: %v\n%s", reason, err, out)
+	}
+}
+
+func init() {
+	molSweepCmd.Flags().Bool("dry-run", false, "Show what would be closed without closing")
+	molSweepCmd.Flags().Duration("every", 0, "Keep running and sweep at this interval (e.g. 10m)")
+	molSweepCmd.Flags().StringSlice("type", nil, "Issue types treated as molecule roots (overrides molecule.sweep.types)")
+	molSweepCmd.Flags().StringSlice("label", nil, "Only sweep roots with one of these labels (overrides molecule.sweep.labels)")
+
+	molCmd.AddCommand(molSweepCmd)
+}
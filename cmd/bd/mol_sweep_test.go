@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSelectSweepable(t *testing.T) {
+	roots := []*types.Issue{
+		{ID: "bd-3", IssueType: types.TypeEpic, Status: types.StatusOpen},
+		{ID: "bd-1", IssueType: types.TypeMolecule, Status: types.StatusInProgress, Labels: []string{"patrol"}},
+		{ID: "bd-2", IssueType: types.TypeEpic, Status: types.StatusOpen},                                       // children still open
+		{ID: "bd-4", IssueType: types.TypeEpic, Status: types.StatusOpen},                                       // no children
+		{ID: "bd-5", IssueType: types.TypeEpic, Status: types.StatusOpen, Labels: []string{BeadsTemplateLabel}}, // proto
+		{ID: "bd-6", IssueType: types.TypeTask, Status: types.StatusOpen},                                       // not a root type
+		{ID: "bd-7", IssueType: types.TypeEpic, Status: types.StatusPinned},
+	}
+	progress := map[string]*types.ChildProgress{
+		"bd-1": types.NewChildProgress(2, 2),
+		"bd-2": types.NewChildProgress(3, 2),
+		"bd-3": types.NewChildProgress(1, 1),
+		"bd-5": types.NewChildProgress(1, 1),
+		"bd-6": types.NewChildProgress(1, 1),
+		"bd-7": types.NewChildProgress(1, 1),
+	}
+	ids := func(issues []*types.Issue) []string {
+		out := []string{}
+		for _, i := range issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	all := sweepCriteria{Types: []types.IssueType{types.TypeEpic, types.TypeMolecule}}
+	if got := ids(selectSweepable(roots, progress, all)); len(got) != 2 || got[0] != "bd-1" || got[1] != "bd-3" {
+		t.Errorf("default criteria selected %v, want [bd-1 bd-3]", got)
+	}
+
+	labeled := sweepCriteria{Types: all.Types, Labels: []string{"patrol"}}
+	if got := ids(selectSweepable(roots, progress, labeled)); len(got) != 1 || got[0] != "bd-1" {
+		t.Errorf("label criteria selected %v, want [bd-1]", got)
+	}
+
+	epicsOnly := sweepCriteria{Types: []types.IssueType{types.TypeEpic}}
+	if got := ids(selectSweepable(roots, progress, epicsOnly)); len(got) != 1 || got[0] != "bd-3" {
+		t.Errorf("type criteria selected %v, want [bd-3]", got)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/offline"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/telemetry"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// offlineQueueableCommands are the write commands that are journaled
+// instead of failing when the Dolt server cannot be reached and
+// offline.queue-writes is enabled.
+var offlineQueueableCommands = []string{"create", "update", "close"}
+
+// offlineReplayEnv marks a child process started by bd sync --flush, so a
+// still-unreachable server fails the replay instead of re-queuing it.
+const offlineReplayEnv = "BD_OFFLINE_REPLAY"
+
+// queueOfflineWrite appends the current invocation to the offline journal
+// when openErr means the server is unreachable and the command is a
+// queueable write. It reports whether the command was queued; the caller
+// should then exit successfully.
+func queueOfflineWrite(cmd *cobra.Command, beadsDir string, openErr error) bool {
+	if beadsDir == "" || !usesSQLServer() || !dolt.IsUnreachable(openErr) {
+		return false
+	}
+	if cmd.Parent() == nil || cmd.Parent().HasParent() || !slices.Contains(offlineQueueableCommands, cmd.Name()) {
+		return false
+	}
+	if os.Getenv(offlineReplayEnv) != "" || !config.GetBool("offline.queue-writes") {
+		return false
+	}
+
+	dir, _ := os.Getwd()
+	entry := offline.Entry{
+		Args:     os.Args[1:],
+		Dir:      dir,
+		Actor:    actor,
+		QueuedAt: time.Now().UTC(),
+		Reason:   firstLine(openErr.Error()),
+	}
+	journal := offline.Open(beadsDir)
+	if err := journal.Append(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not queue offline write: %v\n", err)
+		return false
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"queued":  true,
+			"command": "bd " + strings.Join(entry.Args, " "),
+			"journal": journal.Path(),
+			"reason":  entry.Reason,
+		})
+		return true
+	}
+	fmt.Fprintf(os.Stderr, "%s Dolt server unreachable; queued offline: bd %s\n",
+		ui.RenderWarn("⚠"), strings.Join(entry.Args, " "))
+	fmt.Fprintf(os.Stderr, "  Replay with 'bd sync --flush' once the server is back.\n")
+	if cmd.Name() == "create" {
+		fmt.Fprintf(os.Stderr, "  The issue ID is assigned when the write is replayed.\n")
+	}
+	return true
+}
+
+// firstLine trims a multi-line error (connection errors carry hints) to
+// its first line for the journal.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+var syncFlush bool
+
+var syncCmd = &cobra.Command{
+	Use:     "sync",
+	GroupID: "sync",
+	Short:   "Show or replay writes queued while the Dolt server was unreachable",
+	Long: `Show or replay the offline write queue.
+
+With offline.queue-writes: true in config.yaml, bd create, update, and close
+append the command to .beads/offline-queue.jsonl instead of failing when the
+Dolt server cannot be reached. Nothing is written to the database until the
+queue is replayed, and created issues get their IDs at replay time, so a
+queued 'bd create --json' prints {"queued": true, ...} without an id.
+
+Without flags, lists the queued commands. With --flush, re-runs them in
+order with their original actor and working directory. Replay stops at the
+first command that fails; it and everything after it stay queued.
+
+Queueing is off by default: without it these commands fail while the
+server is unreachable.
+
+Examples:
+  bd sync            # Show queued writes
+  bd sync --flush    # Replay them now that the server is reachable`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			FatalErrorWithHint(activeWorkspaceNotFoundError(), diagHint())
+		}
+		journal := offline.Open(beadsDir)
+		entries, err := journal.Entries()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if !syncFlush {
+			if jsonOutput {
+				if entries == nil {
+					entries = []offline.Entry{}
+				}
+				outputJSON(entries)
+				return
+			}
+			if len(entries) == 0 {
+				fmt.Println("No queued offline writes.")
+				return
+			}
+			fmt.Printf("%d queued offline write(s):\n", len(entries))
+			for _, e := range entries {
+				fmt.Printf("  %s  bd %s\n", ui.RenderMuted(e.QueuedAt.Local().Format("2006-01-02 15:04")), strings.Join(e.Args, " "))
+			}
+			fmt.Println("\nReplay with 'bd sync --flush'.")
+			return
+		}
+
+		CheckReadonly("sync --flush")
+		replayed, failure := replayOfflineEntries(entries)
+
+		// Keep the failed entry and everything after it, plus anything queued
+		// by other processes while the replay ran.
+		remaining := entries[replayed:]
+		if current, err := journal.Entries(); err == nil && len(current) > len(entries) {
+			remaining = append(remaining, current[len(entries):]...)
+		}
+		if err := journal.Replace(remaining); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			result := map[string]interface{}{
+				"replayed":  replayed,
+				"remaining": len(remaining),
+			}
+			if failure != "" {
+				result["error"] = failure
+			}
+			outputJSON(result)
+		} else if len(entries) == 0 {
+			fmt.Println("No queued offline writes.")
+		} else {
+			fmt.Printf("Replayed %d of %d queued write(s)\n", replayed, len(entries))
+			if failure != "" {
+				fmt.Fprintf(os.Stderr, "%s\n", failure)
+				fmt.Fprintf(os.Stderr, "%d write(s) remain queued.\n", len(remaining))
+			}
+		}
+		if failure != "" {
+			os.Exit(1)
+		}
+	},
+}
+
+// replayOfflineEntries runs each entry as a child bd process, stopping at
+// the first failure. It returns how many succeeded and a description of the
+// failure, if any.
+func replayOfflineEntries(entries []offline.Entry) (int, string) {
+	if len(entries) == 0 {
+		return 0, ""
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Sprintf("cannot locate bd executable: %v", err)
+	}
+	for i, e := range entries {
+		child := exec.Command(self, e.Args...) // #nosec G204 -- replays the user's own queued bd invocation
+		child.Dir = e.Dir
+		child.Env = telemetry.EnvWithTrace(rootCtx, append(os.Environ(), offlineReplayEnv+"=1"))
+		if e.Actor != "" {
+			child.Env = append(child.Env, "BD_ACTOR="+e.Actor)
+		}
+		out, err := child.CombinedOutput()
+		if err != nil {
+			return i, fmt.Sprintf("bd %s failed: %v\n%s", strings.Join(e.Args, " "), err, strings.TrimSpace(string(out)))
+		}
+		if !jsonOutput {
+			fmt.Printf("  %s bd %s\n", ui.RenderPass("✓"), strings.Join(e.Args, " "))
+		}
+	}
+	return len(entries), ""
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncFlush, "flush", false, "Replay queued writes against the database")
+	rootCmd.AddCommand(syncCmd)
+}
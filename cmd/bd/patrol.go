@@ -0,0 +1,560 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/town"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Patrol statuses shown by bd patrol list.
+const (
+	patrolStatusScheduled = "scheduled" // never run, not yet due
+	patrolStatusRunning   = "running"   // current wisp still open
+	patrolStatusDone      = "done"      // current wisp closed or gone
+	patrolStatusDue       = "due"       // next run is due
+	patrolStatusMissed    = "missed"    // due, and the previous cycle was missed
+	patrolStatusInvalid   = "invalid"   // schedule no longer parses
+)
+
+// patrolView is one row of bd patrol list.
+type patrolView struct {
+	*types.Patrol
+	Status  string     `json:"status"`
+	NextDue *time.Time `json:"next_due,omitempty"`
+}
+
+// patrolRunResult is one patrol's outcome in bd patrol run.
+type patrolRunResult struct {
+	Name    string     `json:"name"`
+	Formula string     `json:"formula"`
+	Agent   string     `json:"agent,omitempty"`
+	Action  string     `json:"action"` // created, would-create, skipped, error
+	WispID  string     `json:"wisp_id,omitempty"`
+	Missed  bool       `json:"missed"`
+	NextDue *time.Time `json:"next_due,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
+var patrolCmd = &cobra.Command{
+	Use:     "patrol",
+	GroupID: "advanced",
+	Short:   "Schedule recurring patrol wisps for agents",
+	Long: `Schedule patrols: recurring wisps created from a formula and assigned to an
+agent, such as a deacon that sweeps the town every 30 minutes.
+
+Patrols are stored in the database, so every clone and agent sees the same
+schedule. 'bd patrol run' is the scheduler tick: run it from cron, a systemd
+timer, or the orchestrator loop, every minute or so. For each patrol that is
+due it creates a root-only wisp assigned to the patrol's agent.
+
+A cycle is complete when its wisp is closed (or burned or squashed). A patrol
+is missed when it comes due while its previous wisp is still open, or when
+whole cycles passed without a tick. Missed patrols increment the patrol's
+missed count and fire the on_patrol_missed hook.
+
+Schedules set with the old town.patrol.<formula> config keys are imported
+as patrols named after their formula by the next add, remove, or run.
+
+Schedules:
+  30m, 6h, @every 30m      interval after the previous run
+  @hourly, @daily, @weekly, @monthly
+  "*/15 * * * *"           5-field cron (minute hour day-of-month month day-of-week), local time
+
+Examples:
+  bd patrol add deacon mol-deacon-patrol 30m --agent deacon
+  bd patrol add nightly-audit mol-audit "0 2 * * *" --agent witness
+  bd patrol list
+  bd patrol run`,
+}
+
+var patrolAddCmd = &cobra.Command{
+	Use:   "add <name> <formula> <schedule>",
+	Short: "Add a patrol",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("patrol add")
+		agent, _ := cmd.Flags().GetString("agent")
+		schedule := strings.TrimSpace(args[2])
+		if _, err := town.ParseSchedule(schedule); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if _, err := resolveAndCookFormulaWithVars(args[1], nil, nil); err != nil {
+			FatalErrorWithHint(fmt.Sprintf("formula %q: %v", args[1], err), "run 'bd formula list' to see available formulas")
+		}
+		ps := patrolStore(store)
+		if err := importLegacyPatrols(rootCtx, store, ps); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		p := &types.Patrol{
+			Name:      strings.TrimSpace(args[0]),
+			Formula:   args[1],
+			Agent:     strings.TrimSpace(agent),
+			Schedule:  schedule,
+			CreatedBy: actor,
+		}
+		if err := ps.AddPatrol(rootCtx, p); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(p)
+			return
+		}
+		msg := fmt.Sprintf("%s Added patrol %s: %s %s", ui.RenderPass("✓"), ui.RenderAccent(p.Name), p.Formula, p.Schedule)
+		if p.Agent != "" {
+			msg += " → " + p.Agent
+		}
+		fmt.Println(msg)
+	},
+}
+
+var patrolRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a patrol",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("patrol remove")
+		name := args[0]
+		ps := patrolStore(store)
+		if err := importLegacyPatrols(rootCtx, store, ps); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := ps.RemovePatrol(rootCtx, name); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				FatalErrorRespectJSON("no patrol named %q", name)
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		commandDidWrite.Store(true)
+
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": name})
+			return
+		}
+		fmt.Printf("%s Removed patrol %s\n", ui.RenderPass("✓"), name)
+	},
+}
+
+var patrolListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List patrols with their status and next due time",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		patrols, err := patrolStore(store).GetPatrols(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		warnLegacyPatrols(ctx, store)
+		now := time.Now()
+		views := make([]patrolView, 0, len(patrols))
+		for _, p := range patrols {
+			sched, err := town.ParseSchedule(p.Schedule)
+			if err != nil {
+				views = append(views, patrolView{Patrol: p, Status: patrolStatusInvalid})
+				continue
+			}
+			wispOpen, _, err := patrolWispState(ctx, store, p)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			ev := evaluatePatrol(p, sched, wispOpen, now)
+			views = append(views, patrolView{Patrol: p, Status: ev.status, NextDue: nonZeroTime(ev.next)})
+		}
+
+		if jsonOutput {
+			outputJSON(views)
+			return
+		}
+		if len(views) == 0 {
+			fmt.Printf("\nNo patrols. Add one with 'bd patrol add'.\n\n")
+			return
+		}
+		fmt.Printf("\n%s Patrols (%d):\n\n", ui.RenderAccent("🔁"), len(views))
+		for _, v := range views {
+			agent := v.Agent
+			if agent == "" {
+				agent = "-"
+			}
+			fmt.Printf("  %s %s %s → %s\n", renderPatrolStatus(v.Status), ui.RenderAccent(v.Name), v.Formula, agent)
+			details := []string{"schedule " + v.Schedule}
+			if v.NextDue != nil {
+				details = append(details, "next "+v.NextDue.Local().Format("2006-01-02 15:04"))
+			}
+			if v.LastRunAt != nil {
+				details = append(details, fmt.Sprintf("last %s (%s)", v.LastRunAt.Local().Format("2006-01-02 15:04"), v.LastWispID))
+			}
+			if v.MissedCount > 0 {
+				details = append(details, fmt.Sprintf("missed %d", v.MissedCount))
+			}
+			fmt.Printf("      %s\n", ui.RenderMuted(strings.Join(details, " · ")))
+		}
+		fmt.Println()
+	},
+}
+
+var patrolRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create wisps for every patrol that is due (one scheduler tick)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("patrol run")
+		}
+		if err := ensureDirectMode("patrol run requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		ps := patrolStore(store)
+		if dryRun {
+			warnLegacyPatrols(ctx, store)
+		} else if err := importLegacyPatrols(ctx, store, ps); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		patrols, err := ps.GetPatrols(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		now := time.Now()
+		results := make([]patrolRunResult, 0, len(patrols))
+		failed := false
+		for _, p := range patrols {
+			r := runPatrol(ctx, store, ps, p, now, dryRun)
+			if r.Action == "error" {
+				failed = true
+			}
+			results = append(results, r)
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		} else {
+			displayPatrolRun(results, dryRun)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	patrolAddCmd.Flags().String("agent", "", "Agent to assign each patrol wisp to")
+	patrolRunCmd.Flags().Bool("dry-run", false, "Show which patrols are due without creating wisps")
+
+	patrolCmd.AddCommand(patrolAddCmd, patrolRemoveCmd, patrolListCmd, patrolRunCmd)
+	rootCmd.AddCommand(patrolCmd)
+}
+
+// patrolStore returns s as a PatrolStore, exiting when the backend does not
+// support patrols.
+func patrolStore(s storage.DoltStorage) storage.PatrolStore {
+	ps, ok := storage.UnwrapStore(s).(storage.PatrolStore)
+	if !ok {
+		FatalErrorRespectJSON("patrols are not supported by this storage backend")
+	}
+	return ps
+}
+
+// importLegacyPatrols moves patrol schedules still held in town.patrol.*
+// config keys (from before the patrols table) into the table. Each becomes
+// a patrol named after its formula with no agent; its key is deleted once
+// imported, or when a patrol of that name already exists. A key whose
+// interval does not parse is left in place with a warning.
+func importLegacyPatrols(ctx context.Context, s storage.DoltStorage, ps storage.PatrolStore) error {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	legacy := town.LegacyPatrols(all)
+	if len(legacy) == 0 {
+		return nil
+	}
+	existing, err := ps.GetPatrols(ctx)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		names[p.Name] = true
+	}
+	for _, lp := range legacy {
+		if _, err := town.ParseSchedule(lp.Interval); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: not importing %s: %v\n", lp.Key, err)
+			continue
+		}
+		if !names[lp.Formula] {
+			p := &types.Patrol{Name: lp.Formula, Formula: lp.Formula, Schedule: lp.Interval, CreatedBy: actor}
+			if err := ps.AddPatrol(ctx, p); err != nil {
+				return fmt.Errorf("import %s: %w", lp.Key, err)
+			}
+			names[p.Name] = true
+			if !jsonOutput {
+				fmt.Fprintf(os.Stderr, "Imported patrol %s (%s) from %s\n", p.Name, p.Schedule, lp.Key)
+			}
+		}
+		if err := s.DeleteConfig(ctx, lp.Key); err != nil {
+			return fmt.Errorf("delete %s: %w", lp.Key, err)
+		}
+		commandDidWrite.Store(true)
+	}
+	return nil
+}
+
+// warnLegacyPatrols tells read-only callers about town.patrol.* keys that
+// the next bd patrol add, remove, or run will import.
+func warnLegacyPatrols(ctx context.Context, s storage.DoltStorage) {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return
+	}
+	if legacy := town.LegacyPatrols(all); len(legacy) > 0 {
+		fmt.Fprintf(os.Stderr, "Note: %d patrol(s) in town.patrol.* config keys will be imported on the next 'bd patrol run'\n", len(legacy))
+	}
+}
+
+// patrolEval is the scheduler's view of one patrol at a point in time.
+type patrolEval struct {
+	status string
+	next   time.Time // zero when the schedule never fires again
+	due    bool
+	missed bool
+}
+
+// evaluatePatrol decides whether p is due at now. The next run follows the
+// last run, or the patrol's creation when it has never run. A due patrol is
+// missed when its previous wisp is still open or when the cycle after next
+// has also come due (ticks were skipped).
+func evaluatePatrol(p *types.Patrol, sched town.Schedule, wispOpen bool, now time.Time) patrolEval {
+	base := p.CreatedAt
+	if p.LastRunAt != nil {
+		base = *p.LastRunAt
+	}
+	ev := patrolEval{next: sched.Next(base)}
+	ev.due = !ev.next.IsZero() && !now.Before(ev.next)
+	if ev.due {
+		following := sched.Next(ev.next)
+		ev.missed = wispOpen || (!following.IsZero() && !now.Before(following))
+	}
+
+	switch {
+	case ev.missed:
+		ev.status = patrolStatusMissed
+	case ev.due:
+		ev.status = patrolStatusDue
+	case wispOpen:
+		ev.status = patrolStatusRunning
+	case p.LastRunAt == nil:
+		ev.status = patrolStatusScheduled
+	default:
+		ev.status = patrolStatusDone
+	}
+	return ev
+}
+
+// patrolWispState reports whether p's current wisp is still open. When it
+// has closed, closedAt is its close time; a wisp that no longer exists
+// (burned or squashed) counts as finished, with a nil closedAt.
+func patrolWispState(ctx context.Context, s storage.DoltStorage, p *types.Patrol) (open bool, closedAt *time.Time, err error) {
+	if p.LastWispID == "" || p.LastCompletedAt != nil {
+		return false, p.LastCompletedAt, nil
+	}
+	wisp, err := s.GetIssue(ctx, p.LastWispID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("loading patrol %s wisp %s: %w", p.Name, p.LastWispID, err)
+	}
+	if wisp.Status == types.StatusClosed {
+		return false, wisp.ClosedAt, nil
+	}
+	return true, nil, nil
+}
+
+// runPatrol performs one scheduler tick for p: it records completion of the
+// current wisp, and when p is due creates the next wisp and records the run.
+func runPatrol(ctx context.Context, s storage.DoltStorage, ps storage.PatrolStore, p *types.Patrol, now time.Time, dryRun bool) patrolRunResult {
+	r := patrolRunResult{Name: p.Name, Formula: p.Formula, Agent: p.Agent, Action: "skipped"}
+	fail := func(err error) patrolRunResult {
+		r.Action = "error"
+		r.Error = err.Error()
+		return r
+	}
+
+	sched, err := town.ParseSchedule(p.Schedule)
+	if err != nil {
+		return fail(err)
+	}
+	wispOpen, closedAt, err := patrolWispState(ctx, s, p)
+	if err != nil {
+		return fail(err)
+	}
+	if !wispOpen && p.LastWispID != "" && p.LastCompletedAt == nil && !dryRun {
+		completedAt := now
+		if closedAt != nil {
+			completedAt = *closedAt
+		}
+		if err := ps.RecordPatrolCompletion(ctx, p.Name, completedAt); err != nil {
+			return fail(err)
+		}
+		commandDidWrite.Store(true)
+	}
+
+	ev := evaluatePatrol(p, sched, wispOpen, now)
+	r.Missed = ev.missed
+	if !ev.due {
+		r.NextDue = nonZeroTime(ev.next)
+		return r
+	}
+	r.NextDue = nonZeroTime(sched.Next(now))
+	if dryRun {
+		r.Action = "would-create"
+		return r
+	}
+
+	wisp, err := createPatrolWisp(ctx, s, p)
+	if err != nil {
+		return fail(err)
+	}
+	if err := ps.RecordPatrolRun(ctx, p.Name, now, wisp.ID, ev.missed); err != nil {
+		return fail(err)
+	}
+	commandDidWrite.Store(true)
+	r.Action = "created"
+	r.WispID = wisp.ID
+
+	if ev.missed {
+		firePatrolMissedHook(ctx, s, p, wispOpen, wisp)
+	}
+	return r
+}
+
+// createPatrolWisp creates a root-only wisp from p's formula, assigned to
+// p's agent, subject to the town wisp quota.
+func createPatrolWisp(ctx context.Context, s storage.DoltStorage, p *types.Patrol) (*types.Issue, error) {
+	subgraph, err := resolveAndCookFormulaWithVars(p.Formula, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("formula %s: %w", p.Formula, err)
+	}
+	vars := applyVariableDefaults(map[string]string{}, subgraph)
+	var missing []string
+	for _, v := range extractRequiredVariables(subgraph) {
+		if _, ok := vars[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("formula %s needs variables without defaults: %s", p.Formula, strings.Join(missing, ", "))
+	}
+	if err := checkWispQuota(ctx, s, 1); err != nil {
+		return nil, err
+	}
+
+	result, err := spawnMoleculeWithOptions(ctx, s, subgraph, CloneOptions{
+		Vars:      vars,
+		Assignee:  p.Agent,
+		Actor:     actor,
+		Ephemeral: true,
+		Prefix:    types.IDPrefixWisp,
+		RootOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating wisp: %w", err)
+	}
+	wisp, err := s.GetIssue(ctx, result.NewEpicID)
+	if err != nil {
+		return nil, fmt.Errorf("loading wisp %s: %w", result.NewEpicID, err)
+	}
+	return wisp, nil
+}
+
+// firePatrolMissedHook runs on_patrol_missed with the wisp that was not
+// finished in time, or the new wisp when cycles were skipped outright.
+func firePatrolMissedHook(ctx context.Context, s storage.DoltStorage, p *types.Patrol, staleOpen bool, newWisp *types.Issue) {
+	runner := getHookRunner()
+	if runner == nil {
+		return
+	}
+	issue := newWisp
+	if staleOpen {
+		if stale, err := s.GetIssue(ctx, p.LastWispID); err == nil {
+			issue = stale
+		}
+	}
+	if err := runner.RunSync(hooks.EventPatrolMissed, issue); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: on_patrol_missed hook failed for patrol %s: %v\n", p.Name, err)
+	}
+}
+
+func displayPatrolRun(results []patrolRunResult, dryRun bool) {
+	if len(results) == 0 {
+		fmt.Println("No patrols. Add one with 'bd patrol add'.")
+		return
+	}
+	ran := 0
+	for _, r := range results {
+		switch r.Action {
+		case "created":
+			ran++
+			line := fmt.Sprintf("%s %s: created %s", ui.RenderPass("✓"), ui.RenderAccent(r.Name), r.WispID)
+			if r.Agent != "" {
+				line += " → " + r.Agent
+			}
+			if r.Missed {
+				line += " " + ui.RenderWarn("(previous cycle missed)")
+			}
+			fmt.Println(line)
+		case "would-create":
+			ran++
+			line := fmt.Sprintf("  %s: would create a wisp from %s", ui.RenderAccent(r.Name), r.Formula)
+			if r.Missed {
+				line += " " + ui.RenderWarn("(previous cycle missed)")
+			}
+			fmt.Println(line)
+		case "error":
+			fmt.Printf("%s %s: %s\n", ui.RenderFail("✗"), ui.RenderAccent(r.Name), r.Error)
+		}
+	}
+	if ran == 0 {
+		fmt.Println("No patrols due")
+	} else if dryRun {
+		fmt.Printf("\nDry run: %d patrol(s) due\n", ran)
+	}
+}
+
+func renderPatrolStatus(status string) string {
+	label := fmt.Sprintf("%-9s", status)
+	switch status {
+	case patrolStatusMissed, patrolStatusInvalid:
+		return ui.RenderFail(label)
+	case patrolStatusDue:
+		return ui.RenderWarn(label)
+	case patrolStatusDone:
+		return ui.RenderPass(label)
+	default:
+		return ui.RenderMuted(label)
+	}
+}
+
+// nonZeroTime returns &t, or nil for the zero time.
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,157 @@
+//go:build cgo
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+)
+
+func TestEmbeddedPatrol(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "pt")
+	formulaDir := filepath.Join(beadsDir, "formulas")
+	if err := os.MkdirAll(formulaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	formula := "formula = \"mol-test-patrol\"\nversion = 1\ntype = \"workflow\"\n\n[[steps]]\nid = \"sweep\"\ntitle = \"Sweep\"\n"
+	if err := os.WriteFile(filepath.Join(formulaDir, "mol-test-patrol.formula.toml"), []byte(formula), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) []byte {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+	tick := func() []patrolRunResult {
+		t.Helper()
+		var results []patrolRunResult
+		if raw := run("patrol", "run", "--json"); json.Unmarshal(raw, &results) != nil || len(results) != 1 {
+			t.Fatalf("parse patrol run: %s", raw)
+		}
+		return results
+	}
+	// query runs fn against the database directly.
+	query := func(fn func(db *sql.DB) error) {
+		t.Helper()
+		database := ""
+		if cfg, _ := configfile.Load(beadsDir); cfg != nil {
+			database = cfg.GetDoltDatabase()
+		}
+		db, cleanup, err := embeddeddolt.OpenSQL(t.Context(), filepath.Join(beadsDir, "embeddeddolt"), database, "main")
+		if err != nil {
+			t.Fatalf("OpenSQL: %v", err)
+		}
+		defer cleanup()
+		if err := fn(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// backdate moves the patrol's clock back so the next tick sees it due.
+	backdate := func(column, interval string) {
+		t.Helper()
+		query(func(db *sql.DB) error {
+			_, err := db.ExecContext(t.Context(),
+				"UPDATE patrols SET "+column+" = DATE_SUB("+column+", INTERVAL "+interval+") WHERE name = 'deacon'")
+			return err
+		})
+	}
+
+	run("patrol", "add", "deacon", "mol-test-patrol", "30m", "--agent", "deacon")
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "patrol", "add", "bad", "mol-test-patrol", "soon"); err == nil {
+		t.Errorf("invalid schedule should fail\n%s", out)
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "patrol", "add", "deacon", "mol-test-patrol", "1h"); err == nil {
+		t.Errorf("duplicate patrol name should fail\n%s", out)
+	}
+
+	if r := tick()[0]; r.Action != "skipped" {
+		t.Fatalf("new patrol ran before its first due time: %+v", r)
+	}
+
+	backdate("created_at", "31 MINUTE")
+	first := tick()[0]
+	if first.Action != "created" || first.WispID == "" || first.Missed {
+		t.Fatalf("first run = %+v, want a new wisp", first)
+	}
+	if wisp := bdShow(t, bd, dir, first.WispID); wisp.Assignee != "deacon" || !wisp.Ephemeral {
+		t.Errorf("wisp %s assignee = %q ephemeral = %v, want deacon ephemeral", wisp.ID, wisp.Assignee, wisp.Ephemeral)
+	}
+
+	// Due again while the first wisp is still open: missed.
+	backdate("last_run_at", "31 MINUTE")
+	second := tick()[0]
+	if second.Action != "created" || !second.Missed || second.WispID == first.WispID {
+		t.Fatalf("second run = %+v, want a new wisp marked missed", second)
+	}
+
+	// Closing the wisp completes the cycle.
+	run("close", second.WispID)
+	if r := tick()[0]; r.Action != "skipped" {
+		t.Fatalf("run after completion = %+v, want skipped", r)
+	}
+	var views []patrolView
+	if raw := run("patrol", "list", "--json"); json.Unmarshal(raw, &views) != nil || len(views) != 1 {
+		t.Fatalf("parse patrol list: %s", raw)
+	}
+	if v := views[0]; v.Status != patrolStatusDone || v.MissedCount != 1 || v.LastCompletedAt == nil || v.LastWispID != second.WispID {
+		t.Errorf("patrol = %+v status %s, want done with one miss", v.Patrol, v.Status)
+	}
+
+	run("patrol", "remove", "deacon")
+	if raw := run("patrol", "list", "--json"); json.Unmarshal(raw, &views) != nil || len(views) != 0 {
+		t.Errorf("patrols after remove = %s", raw)
+	}
+
+	// Schedules left in the old town.patrol.* keys are imported by the next
+	// tick; a key whose interval no longer parses stays put.
+	query(func(db *sql.DB) error {
+		_, err := db.ExecContext(t.Context(),
+			"INSERT INTO config (`key`, value) VALUES ('town.patrol.mol-test-patrol', '30m'), ('town.patrol.mol-bad', '5s')")
+		return err
+	})
+	if raw := run("patrol", "list", "--json"); json.Unmarshal(raw, &views) != nil || len(views) != 0 {
+		t.Errorf("patrol list imported legacy keys: %s", raw)
+	}
+	if r := tick()[0]; r.Name != "mol-test-patrol" || r.Formula != "mol-test-patrol" || r.Action != "skipped" {
+		t.Errorf("run after import = %+v, want mol-test-patrol skipped", r)
+	}
+	if raw := run("patrol", "list", "--json"); json.Unmarshal(raw, &views) != nil || len(views) != 1 || views[0].Schedule != "30m" {
+		t.Errorf("patrols after import = %s", raw)
+	}
+	var left []string
+	query(func(db *sql.DB) error {
+		rows, err := db.QueryContext(t.Context(), "SELECT `key` FROM config WHERE `key` LIKE 'town.patrol.%' ORDER BY `key`")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				return err
+			}
+			left = append(left, key)
+		}
+		return rows.Err()
+	})
+	if len(left) != 1 || left[0] != "town.patrol.mol-bad" {
+		t.Errorf("legacy keys left = %v, want only town.patrol.mol-bad", left)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/town"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEvaluatePatrol(t *testing.T) {
+	sched, err := town.ParseSchedule("30m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	ran := created.Add(30 * time.Minute)
+	completed := ran.Add(5 * time.Minute)
+
+	tests := []struct {
+		name       string
+		patrol     types.Patrol
+		wispOpen   bool
+		now        time.Time
+		wantStatus string
+		wantNext   time.Time
+	}{
+		{"new, not due", types.Patrol{CreatedAt: created}, false, created.Add(10 * time.Minute), patrolStatusScheduled, created.Add(30 * time.Minute)},
+		{"new, due", types.Patrol{CreatedAt: created}, false, created.Add(30 * time.Minute), patrolStatusDue, created.Add(30 * time.Minute)},
+		{"running", types.Patrol{CreatedAt: created, LastRunAt: &ran, LastWispID: "w-1"}, true, ran.Add(time.Minute), patrolStatusRunning, ran.Add(30 * time.Minute)},
+		{"done", types.Patrol{CreatedAt: created, LastRunAt: &ran, LastWispID: "w-1", LastCompletedAt: &completed}, false, ran.Add(10 * time.Minute), patrolStatusDone, ran.Add(30 * time.Minute)},
+		{"due after completion", types.Patrol{CreatedAt: created, LastRunAt: &ran, LastWispID: "w-1", LastCompletedAt: &completed}, false, ran.Add(45 * time.Minute), patrolStatusDue, ran.Add(30 * time.Minute)},
+		{"due with wisp still open", types.Patrol{CreatedAt: created, LastRunAt: &ran, LastWispID: "w-1"}, true, ran.Add(30 * time.Minute), patrolStatusMissed, ran.Add(30 * time.Minute)},
+		{"skipped cycles", types.Patrol{CreatedAt: created, LastRunAt: &ran, LastWispID: "w-1", LastCompletedAt: &completed}, false, ran.Add(61 * time.Minute), patrolStatusMissed, ran.Add(30 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := evaluatePatrol(&tt.patrol, sched, tt.wispOpen, tt.now)
+			if ev.status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", ev.status, tt.wantStatus)
+			}
+			if !ev.next.Equal(tt.wantNext) {
+				t.Errorf("next = %v, want %v", ev.next, tt.wantNext)
+			}
+			wantDue := tt.wantStatus == patrolStatusDue || tt.wantStatus == patrolStatusMissed
+			if ev.due != wantDue || ev.missed != (tt.wantStatus == patrolStatusMissed) {
+				t.Errorf("due, missed = %v, %v for status %q", ev.due, ev.missed, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// loadProjectPrefixes reads the monorepo sub-project mapping from the
+// projects config of s.
+func loadProjectPrefixes(ctx context.Context, s storage.DoltStorage) ([]types.ProjectPrefix, error) {
+	value, err := s.GetConfig(ctx, "projects")
+	if err != nil {
+		return nil, fmt.Errorf("reading projects: %w", err)
+	}
+	projects, err := types.ParseProjectPrefixes(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid projects: %w", err)
+	}
+	return projects, nil
+}
+
+// projectPrefixForCwd returns the sub-project prefix mapped to the working
+// directory, or "" when the working directory is outside every sub-project.
+func projectPrefixForCwd(projects []types.ProjectPrefix) string {
+	if len(projects) == 0 {
+		return ""
+	}
+	root, cwd := repoRootAndCwd()
+	if root == "" || cwd == "" {
+		return ""
+	}
+	return projectPrefixForDir(projects, root, cwd)
+}
+
+// repoRootAndCwd returns the repository root (the git root, else the parent
+// of the .beads directory) and the working directory, or "" for either when
+// it cannot be determined.
+func repoRootAndCwd() (root, cwd string) {
+	root = git.GetRepoRoot()
+	if root == "" {
+		if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+			root = filepath.Dir(beadsDir)
+		}
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = ""
+	}
+	return root, cwd
+}
+
+// projectPrefixForDir returns the sub-project prefix mapped to dir within
+// the repository rooted at root.
+func projectPrefixForDir(projects []types.ProjectPrefix, root, dir string) string {
+	rel := repoRelativeDir(root, dir)
+	if rel == "" {
+		return ""
+	}
+	if p, ok := types.MatchProjectPrefix(projects, rel); ok {
+		return p.Prefix
+	}
+	return ""
+}
+
+// repoRelativeDir returns dir relative to root, slash-separated, or "" when
+// the relation cannot be computed.
+func repoRelativeDir(root, dir string) string {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// projectDirForID returns the sub-project directory whose prefix issueID
+// uses, or "" when the ID belongs to no sub-project.
+func projectDirForID(projects []types.ProjectPrefix, issueID string) string {
+	best := types.ProjectPrefix{}
+	for _, p := range projects {
+		if strings.HasPrefix(issueID, p.Prefix+"-") && len(p.Prefix) > len(best.Prefix) {
+			best = p
+		}
+	}
+	return best.Dir
+}
@@ -0,0 +1,55 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedProjectPrefixes(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "mono")
+	webDir := filepath.Join(dir, "apps", "web", "src")
+	apiDir := filepath.Join(dir, "services", "api")
+	for _, d := range []string{webDir, apiDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "projects", "web:/abs"); err == nil {
+		t.Errorf("expected absolute project directory to be rejected:\n%s", out)
+	}
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "projects", "web:apps/web,api:services/api"); err != nil {
+		t.Fatalf("bd config set projects failed: %v\n%s", err, out)
+	}
+
+	web := bdCreate(t, bd, webDir, "Web issue")
+	api := bdCreate(t, bd, apiDir, "API issue")
+	root := bdCreate(t, bd, dir, "Root issue")
+	explicit := bdCreate(t, bd, dir, "Explicit web issue", "--id", "web-42")
+	for _, tc := range []struct{ id, prefix string }{
+		{web.ID, "web-"}, {api.ID, "api-"}, {root.ID, "mono-"}, {explicit.ID, "web-"},
+	} {
+		if !strings.HasPrefix(tc.id, tc.prefix) {
+			t.Errorf("ID %s should start with %s", tc.id, tc.prefix)
+		}
+	}
+
+	issues := bdListJSON(t, bd, dir, "--project", "web")
+	got := make(map[string]bool)
+	for _, issue := range issues {
+		got[issue.ID] = true
+	}
+	if len(got) != 2 || !got[web.ID] || !got[explicit.ID] {
+		t.Errorf("bd list --project web = %v, want %s and %s", got, web.ID, explicit.ID)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var purgeActorCmd = &cobra.Command{
+	Use:     "purge-actor <name>",
+	GroupID: "maint",
+	Short:   "Remove an actor's identity from all issues (GDPR-style erasure)",
+	Long: `Anonymize or remove every trace of an actor in the database.
+
+Covers assignee, created_by, owner, sender, and actor on issues and wisps;
+dependency creators; comment authors; event actors and the old/new values of
+assignment events; interactions; locks; CI run reporters; assignment rule
+assignees and creators; patrol agents and creators; alias creators; whoever
+resolved an external URL dependency; and @mentions in titles, descriptions,
+design, acceptance criteria, notes, and comments.
+
+By default the actor is replaced by a stable pseudonym
+(anonymized-<hash>), so history stays readable and consistent. With
+--remove, identity columns are cleared, the actor's comments, events,
+interactions, and locks are deleted, and the actor is dropped from assignment
+rules (a rule left with no assignees is deleted); @mentions still become the
+pseudonym.
+
+Without --force this previews the report and changes nothing.
+
+This rewrites the current data only. Older Dolt commits still contain the
+actor, both in row data and as commit author. To erase it from history:
+  1. bd purge-actor <name> --force
+  2. bd flatten --force            # squash history into one commit, then GC
+  3. Replace the Dolt remote: push the flattened database to a fresh remote
+     (or force-push), and re-clone every other clone from it. Clones and
+     backups made earlier keep the old history.
+Files outside the database are not rewritten: .beads/interactions.jsonl
+(append-only audit log), JSONL exports, and their git history.
+
+Examples:
+  bd purge-actor alice                         # Preview the report
+  bd purge-actor alice --force                 # Replace alice with a pseudonym
+  bd purge-actor alice --replacement former-contributor --force
+  bd purge-actor alice --remove --force --json # Delete alice's comments too`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		remove, _ := cmd.Flags().GetBool("remove")
+		replacement, _ := cmd.Flags().GetString("replacement")
+		if !dryRun {
+			CheckReadonly("purge-actor")
+		}
+
+		purger, ok := storage.UnwrapStore(store).(storage.ActorPurger)
+		if !ok {
+			FatalErrorRespectJSON("storage backend does not support purge-actor")
+		}
+
+		name := args[0]
+		if replacement == "" {
+			replacement = actorPseudonym(name)
+		}
+		preview := dryRun || !force
+		report, err := purger.PurgeActor(rootCtx, storage.ActorPurgeOptions{
+			Actor:       name,
+			Replacement: replacement,
+			Remove:      remove,
+			DryRun:      preview,
+		})
+		if err != nil {
+			FatalErrorRespectJSON("purge-actor failed: %v", err)
+		}
+
+		if !preview && report.TotalRows > 0 {
+			commandDidWrite.Store(true)
+		}
+		if jsonOutput {
+			outputJSON(report)
+		} else {
+			printActorPurgeReport(report)
+		}
+		if preview && !dryRun && report.TotalRows > 0 {
+			FatalErrorWithHint(
+				fmt.Sprintf("would change %d row(s) naming %s", report.TotalRows, name),
+				fmt.Sprintf("Use --force to confirm.\n  bd purge-actor %s --force", name))
+		}
+	},
+}
+
+// actorPseudonym is the default replacement for a purged actor: stable, so
+// repeated purges and separate clones agree, but not reversible.
+func actorPseudonym(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return "anonymized-" + hex.EncodeToString(sum[:4])
+}
+
+func printActorPurgeReport(r *storage.ActorPurgeReport) {
+	if r.TotalRows == 0 {
+		fmt.Printf("No data names %s\n", r.Actor)
+		return
+	}
+	verb := "Purged"
+	if r.DryRun {
+		verb = "Would purge"
+	}
+	mode := "replaced by " + r.Replacement
+	if r.Remove {
+		mode = "removed"
+	}
+	fmt.Printf("%s %s from %d issue(s) (%s)\n\n", verb, r.Actor, len(r.IssueIDs), mode)
+	for _, c := range r.Changes {
+		fmt.Printf("  %-32s %-10s %d\n", c.Table+"."+c.Column, c.Action, c.Rows)
+	}
+	fmt.Printf("\n  Total rows: %d\n", r.TotalRows)
+	if !r.DryRun {
+		fmt.Printf("\n%s Dolt history still contains %s; run 'bd flatten --force' and replace the remote to erase it (see bd purge-actor --help)\n",
+			ui.RenderWarn("!"), r.Actor)
+	}
+}
+
+func init() {
+	purgeActorCmd.Flags().Bool("force", false, "Apply the changes (default previews only)")
+	purgeActorCmd.Flags().Bool("dry-run", false, "Preview the report without changing anything")
+	purgeActorCmd.Flags().Bool("remove", false, "Clear identity fields and delete the actor's comments and events instead of pseudonymizing")
+	purgeActorCmd.Flags().String("replacement", "", "Pseudonym to write in place of the actor (default anonymized-<hash>)")
+	rootCmd.AddCommand(purgeActorCmd)
+}
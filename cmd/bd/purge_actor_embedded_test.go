@@ -0,0 +1,280 @@
+//go:build cgo
+
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+)
+
+func TestEmbeddedPurgeActor(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "pa")
+
+	run := func(who string, args ...string) (string, error) {
+		cmd := exec.Command(bd, append([]string{"--actor", who}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	mustRun := func(who string, args ...string) string {
+		t.Helper()
+		out, err := run(who, args...)
+		if err != nil {
+			t.Fatalf("bd %s (as %s): %v\n%s", strings.Join(args, " "), who, err, out)
+		}
+		return out
+	}
+	report := func(out string) *storage.ActorPurgeReport {
+		t.Helper()
+		var r storage.ActorPurgeReport
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &r); err != nil {
+			t.Fatalf("parse report: %v\n%s", err, out)
+		}
+		return &r
+	}
+
+	created := mustRun("alice", "create", "Alice's bug", "--assignee", "alice", "--silent")
+	aliceIssue := strings.TrimSpace(created)
+	bobIssue := strings.TrimSpace(mustRun("bob", "create", "Bob's task", "-d", "ask @alice, not @alicia", "--silent"))
+	mustRun("alice", "comments", "add", bobIssue, "I'll take a look")
+	mustRun("carol", "comments", "add", bobIssue, "thanks @alice")
+
+	t.Run("preview changes nothing", func(t *testing.T) {
+		out, err := run("admin", "purge-actor", "alice")
+		if err == nil || !strings.Contains(out, "--force") {
+			t.Errorf("preview should refuse without --force (%v):\n%s", err, out)
+		}
+		r := report(mustRun("admin", "purge-actor", "alice", "--dry-run", "--json"))
+		if !r.DryRun || r.TotalRows == 0 || len(r.IssueIDs) != 2 {
+			t.Errorf("dry-run report = %+v", r)
+		}
+		if got := bdShow(t, bd, dir, aliceIssue); got.Assignee != "alice" {
+			t.Errorf("dry run changed assignee to %q", got.Assignee)
+		}
+	})
+
+	t.Run("anonymize", func(t *testing.T) {
+		r := report(mustRun("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force", "--json"))
+		if r.DryRun || r.Replacement != "former-dev" || r.TotalRows == 0 {
+			t.Errorf("report = %+v", r)
+		}
+		got := bdShow(t, bd, dir, aliceIssue)
+		if got.Assignee != "former-dev" || got.CreatedBy != "former-dev" {
+			t.Errorf("alice's issue not anonymized: assignee=%q created_by=%q", got.Assignee, got.CreatedBy)
+		}
+		bob := bdShow(t, bd, dir, bobIssue)
+		if bob.Description != "ask @former-dev, not @alicia" {
+			t.Errorf("mention not rewritten: %q", bob.Description)
+		}
+		for _, c := range bob.Comments {
+			if c.Author == "alice" || strings.Contains(c.Text, "@alice ") || strings.HasSuffix(c.Text, "@alice") {
+				t.Errorf("comment still names alice: %+v", c)
+			}
+		}
+		if out := mustRun("admin", "purge-actor", "alice", "--dry-run"); !strings.Contains(out, "No data names alice") {
+			t.Errorf("alice still present after purge:\n%s", out)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		r := report(mustRun("admin", "purge-actor", "carol", "--remove", "--force", "--json"))
+		if !r.Remove {
+			t.Errorf("report = %+v", r)
+		}
+		for _, c := range bdShow(t, bd, dir, bobIssue).Comments {
+			if c.Author == "carol" {
+				t.Errorf("carol's comment not deleted: %+v", c)
+			}
+		}
+	})
+}
+
+// purgeActorRepo inits a repo for a purge-actor test of one table. It returns
+// a runner that acts as the given actor, a reader for the values of one
+// column, queried straight from the embedded database, and the .beads dir.
+func purgeActorRepo(t *testing.T, prefix string) (run func(who string, args ...string) string, values func(query string) []string, beadsDir string) {
+	t.Helper()
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", prefix)
+
+	run = func(who string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command(bd, append([]string{"--actor", who}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = bdEnv(dir)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("bd %s (as %s): %v\n%s", strings.Join(args, " "), who, err, out)
+		}
+		return string(out)
+	}
+	values = func(query string) []string {
+		t.Helper()
+		db, cleanup, err := embeddeddolt.OpenSQL(t.Context(), filepath.Join(beadsDir, "embeddeddolt"), prefix, "main")
+		if err != nil {
+			t.Fatalf("OpenSQL: %v", err)
+		}
+		defer cleanup()
+		rows, err := db.QueryContext(t.Context(), query)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		defer rows.Close()
+		got := []string{}
+		for rows.Next() {
+			var v sql.NullString
+			if err := rows.Scan(&v); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+			got = append(got, v.String)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		return got
+	}
+	return run, values, beadsDir
+}
+
+func TestEmbeddedPurgeActorCIRuns(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	run, values, _ := purgeActorRepo(t, "pci")
+	issue := strings.TrimSpace(run("admin", "create", "Flaky build", "--silent"))
+	sha := strings.Repeat("ab", 20)
+	run("alice", "ci", "report", "--status", "success", "--name", "build", "--issue", issue, "--commit", sha)
+	run("carol", "ci", "report", "--status", "failure", "--name", "lint", "--issue", issue, "--commit", sha)
+	const query = "SELECT reported_by FROM ci_runs ORDER BY name"
+
+	run("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force")
+	if got := values(query); strings.Join(got, ",") != "former-dev,carol" {
+		t.Errorf("after anonymize, reported_by = %v", got)
+	}
+	// Removal keeps the run and clears only who reported it.
+	run("admin", "purge-actor", "carol", "--remove", "--force")
+	if got := values(query); strings.Join(got, ",") != "former-dev," {
+		t.Errorf("after remove, reported_by = %v", got)
+	}
+}
+
+func TestEmbeddedPurgeActorAssignmentRules(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	run, values, _ := purgeActorRepo(t, "par")
+	run("alice", "assign", "rule", "add", "frontend", "--strategy", "round-robin", "--assignees", "alice,bob")
+	run("carol", "assign", "rule", "add", "bugs", "--strategy", "fixed", "--assignees", "carol")
+	run("admin", "assign", "rule", "add", "mixed", "--strategy", "round-robin", "--assignees", "alicex,carol")
+	const query = "SELECT CONCAT(name, ':', assignees, ':', COALESCE(created_by, '')) FROM assignment_rules ORDER BY name"
+
+	run("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force")
+	want := "bugs:carol:carol,frontend:former-dev,bob:former-dev,mixed:alicex,carol:admin"
+	if got := strings.Join(values(query), ","); got != want {
+		t.Errorf("after anonymize, rules = %s, want %s", got, want)
+	}
+	// A rule whose only assignee is removed goes with it.
+	run("admin", "purge-actor", "carol", "--remove", "--force")
+	want = "frontend:former-dev,bob:former-dev,mixed:alicex:admin"
+	if got := strings.Join(values(query), ","); got != want {
+		t.Errorf("after remove, rules = %s, want %s", got, want)
+	}
+}
+
+func TestEmbeddedPurgeActorPatrols(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	run, values, beadsDir := purgeActorRepo(t, "ppt")
+	formulaDir := filepath.Join(beadsDir, "formulas")
+	if err := os.MkdirAll(formulaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	formula := "formula = \"mol-test-patrol\"\nversion = 1\ntype = \"workflow\"\n\n[[steps]]\nid = \"sweep\"\ntitle = \"Sweep\"\n"
+	if err := os.WriteFile(filepath.Join(formulaDir, "mol-test-patrol.formula.toml"), []byte(formula), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("alice", "patrol", "add", "deacon", "mol-test-patrol", "30m", "--agent", "carol")
+	run("carol", "patrol", "add", "witness", "mol-test-patrol", "1h", "--agent", "alice")
+	const query = "SELECT CONCAT(name, ':', agent, ':', COALESCE(created_by, '')) FROM patrols ORDER BY name"
+
+	run("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force")
+	if got := strings.Join(values(query), ","); got != "deacon:carol:former-dev,witness:former-dev:carol" {
+		t.Errorf("after anonymize, patrols = %s", got)
+	}
+	// Removal keeps the patrols and clears who runs and created them.
+	run("admin", "purge-actor", "carol", "--remove", "--force")
+	if got := strings.Join(values(query), ","); got != "deacon::former-dev,witness:former-dev:" {
+		t.Errorf("after remove, patrols = %s", got)
+	}
+}
+
+func TestEmbeddedPurgeActorIssueAliases(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	run, values, _ := purgeActorRepo(t, "pal")
+	issue := strings.TrimSpace(run("admin", "create", "Login bug", "--silent"))
+	run("alice", "alias", "add", "login-bug", issue)
+	run("carol", "alias", "add", "auth-bug", issue)
+	const query = "SELECT CONCAT(alias, ':', COALESCE(created_by, '')) FROM issue_aliases ORDER BY alias"
+
+	run("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force")
+	if got := strings.Join(values(query), ","); got != "auth-bug:carol,login-bug:former-dev" {
+		t.Errorf("after anonymize, aliases = %s", got)
+	}
+	// Removal keeps the aliases and clears who created them.
+	run("admin", "purge-actor", "carol", "--remove", "--force")
+	if got := strings.Join(values(query), ","); got != "auth-bug:,login-bug:former-dev" {
+		t.Errorf("after remove, aliases = %s", got)
+	}
+}
+
+func TestEmbeddedPurgeActorExternalRefs(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	run, values, _ := purgeActorRepo(t, "pxr")
+	issue := strings.TrimSpace(run("admin", "create", "Needs upstream fixes", "--silent"))
+	first, second := "https://github.com/org/repo/issues/1", "https://github.com/org/repo/issues/2"
+	run("admin", "dep", "add", issue, first)
+	run("admin", "dep", "add", issue, second)
+	run("alice", "dep", "resolve", first)
+	run("carol", "dep", "resolve", second)
+	const query = "SELECT CONCAT(resolved, ':', COALESCE(resolved_by, '')) FROM external_refs ORDER BY url"
+
+	run("admin", "purge-actor", "alice", "--replacement", "former-dev", "--force")
+	if got := strings.Join(values(query), ","); got != "1:former-dev,1:carol" {
+		t.Errorf("after anonymize, external refs = %s", got)
+	}
+	// Removal keeps the URL resolved and clears who resolved it.
+	run("admin", "purge-actor", "carol", "--remove", "--force")
+	if got := strings.Join(values(query), ","); got != "1:former-dev,1:" {
+		t.Errorf("after remove, external refs = %s", got)
+	}
+}
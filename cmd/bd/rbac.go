@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/rbac"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// adminCommands are top-level commands that change workspace configuration,
+// schema, credentials, or history. They require the admin role.
+var adminCommands = map[string]bool{
+	"admin":         true,
+	"bootstrap":     true,
+	"branch":        true,
+	"compact":       true,
+	"config":        true,
+	"db":            true,
+	"doctor":        true,
+	"dolt":          true,
+	"federation":    true,
+	"flatten":       true,
+	"gc":            true,
+	"hooks":         true,
+	"init":          true,
+	"migrate":       true,
+	"purge":         true,
+	"purge-actor":   true,
+	"rename-prefix": true,
+	"restore":       true,
+	"server":        true,
+	"setup":         true,
+	"sql":           true,
+	"token":         true,
+	"upgrade":       true,
+	"vc":            true,
+	"worktree":      true,
+}
+
+// rbacReadCommands are read-only command paths (without the leading "bd")
+// beyond readOnlyCommands. Entries here do not change how the store opens.
+var rbacReadCommands = map[string]bool{
+	"assign rule list": true,
+	"children":         true,
+	"dep cycles":       true,
+	"dep tree":         true,
+	"diff":             true,
+	"epic status":      true,
+	"export markdown":  true,
+	"find-duplicates":  true,
+	"git links":        true,
+	"history":          true,
+	"info":             true,
+	"kv get":           true,
+	"label list-all":   true,
+	"lint":             true,
+	"query":            true,
+	"schema":           true,
+	"similar":          true,
+	"stale":            true,
+	"state":            true,
+	"status":           true,
+	"statuses":         true,
+	"tree":             true,
+	"types":            true,
+	"where":            true,
+}
+
+// agentCommands are the issue-level writes an agent token may run.
+var agentCommands = map[string]bool{
+	"assign":        true,
+	"ci report":     true,
+	"close":         true,
+	"comment":       true,
+	"comments add":  true,
+	"create":        true,
+	"defer":         true,
+	"dep add":       true,
+	"dep relate":    true,
+	"dep remove":    true,
+	"dep resolve":   true,
+	"dep unrelate":  true,
+	"dep unresolve": true,
+	"label add":     true,
+	"label remove":  true,
+	"link":          true,
+	"link commit":   true,
+	"note":          true,
+	"priority":      true,
+	"q":             true,
+	"reopen":        true,
+	"set-state":     true,
+	"start":         true,
+	"tag":           true,
+	"todo add":      true,
+	"todo done":     true,
+	"undefer":       true,
+	"update":        true,
+}
+
+// commandPermission classifies cmd for role checks. Commands not listed as
+// read, agent, or admin need the writer role.
+func commandPermission(cmd *cobra.Command) rbac.Permission {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	top, _, _ := strings.Cut(path, " ")
+	switch {
+	case adminCommands[top]:
+		return rbac.PermAdmin
+	case isReadOnlyCmd(cmd) || rbacReadCommands[path]:
+		return rbac.PermRead
+	case agentCommands[path]:
+		return rbac.PermIssueWrite
+	default:
+		return rbac.PermWrite
+	}
+}
+
+// enforceAccess checks the BEADS_TOKEN presented for this invocation
+// against the command's permission, exiting on denial. A valid token's
+// name replaces the actor. Without a token the command runs unrestricted
+// unless rbac.require-token is set. s is nil when the command runs without
+// a local store (proxied-server mode), where tokens cannot be verified.
+//
+// For the CLI this check is advisory: the caller already has the database
+// on disk (or its SQL port) and can write to it without going through bd.
+// It keeps well-behaved agents in their lane; it is not a security boundary.
+// Entry points whose callers never touch the database, such as network
+// servers, must call authorizeToken themselves to get real enforcement.
+func enforceAccess(cmd *cobra.Command, s storage.DoltStorage) {
+	raw := os.Getenv("BEADS_TOKEN")
+	required := config.GetBool("rbac.require-token")
+	if raw == "" && !required {
+		return
+	}
+	perm := commandPermission(cmd)
+
+	var ts storage.TokenStore
+	if s != nil {
+		ts, _ = storage.UnwrapStore(s).(storage.TokenStore)
+	}
+	if ts == nil {
+		FatalErrorRespectJSON("API tokens are not supported by this storage backend")
+	}
+
+	if raw == "" {
+		if isTokenBootstrap(rootCtx, cmd, ts) {
+			return
+		}
+		reason := "this workspace requires an API token (rbac.require-token); set BEADS_TOKEN"
+		recordAccess(cmd.CommandPath(), nil, perm, false, reason)
+		FatalErrorRespectJSON("access denied: %s", reason)
+	}
+
+	tok, err := authorizeToken(rootCtx, ts, raw, perm, cmd.CommandPath())
+	if err != nil {
+		FatalErrorRespectJSON("access denied: %v", err)
+	}
+	actor = tok.Name
+}
+
+// authorizeToken verifies raw and checks that its role grants perm for the
+// operation named by what (a command path or API method). Every entry point
+// that accepts tokens goes through it, so all of them apply the same roles.
+// Writes and denials are recorded in the audit stream. On a role denial the
+// verified token is returned along with the error; an invalid token returns
+// a nil token.
+func authorizeToken(ctx context.Context, ts storage.TokenStore, raw string, perm rbac.Permission, what string) (*storage.APIToken, error) {
+	tok, err := verifyAPIToken(ctx, ts, raw)
+	if err != nil {
+		recordAccess(what, nil, perm, false, err.Error())
+		return nil, err
+	}
+	if !rbac.Role(tok.Role).Allows(perm) {
+		err := fmt.Errorf("token %q has role %s; %s requires %s", tok.Name, tok.Role, what, perm)
+		recordAccess(what, tok, perm, false, err.Error())
+		return tok, err
+	}
+	if perm > rbac.PermRead {
+		recordAccess(what, tok, perm, true, "")
+	}
+	return tok, nil
+}
+
+// verifyAPIToken resolves a plaintext token to its active stored record.
+// Unknown IDs and wrong secrets get the same message.
+func verifyAPIToken(ctx context.Context, ts storage.TokenStore, raw string) (*storage.APIToken, error) {
+	id, secret, err := rbac.ParseToken(raw)
+	if err != nil {
+		return nil, err
+	}
+	tok, err := ts.GetAPIToken(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) || (err == nil && !rbac.SecretMatches(secret, tok.SecretHash)) {
+		return nil, errors.New("invalid API token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("verifying API token: %w", err)
+	}
+	if tok.RevokedAt != nil {
+		return nil, fmt.Errorf("API token %s has been revoked", tok.ID)
+	}
+	if !tok.Active(time.Now()) {
+		return nil, fmt.Errorf("API token %s has expired", tok.ID)
+	}
+	return tok, nil
+}
+
+// isTokenBootstrap reports whether cmd is 'bd token create' in a workspace
+// with no active admin token, the one tokenless command rbac.require-token
+// allows so the first admin token can be created.
+func isTokenBootstrap(ctx context.Context, cmd *cobra.Command, ts storage.TokenStore) bool {
+	if cmd != tokenCreateCmd {
+		return false
+	}
+	tokens, err := ts.ListAPITokens(ctx)
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	for _, tok := range tokens {
+		if tok.Role == string(rbac.RoleAdmin) && tok.Active(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordAccess appends an "access" entry for the operation named by what to
+// the audit stream. Best-effort: a read-only or missing .beads directory must
+// not block the command.
+func recordAccess(what string, tok *storage.APIToken, perm rbac.Permission, allowed bool, reason string) {
+	extra := map[string]any{
+		"command":    what,
+		"permission": perm.String(),
+		"allowed":    allowed,
+	}
+	who := actor
+	if tok != nil {
+		who = tok.Name
+		extra["token_id"] = tok.ID
+		extra["role"] = tok.Role
+	}
+	e := &audit.Entry{Kind: "access", Actor: who, Extra: extra}
+	if !allowed {
+		e.Error = reason
+	}
+	_, _ = audit.Append(e)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rbac"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestCommandPermission(t *testing.T) {
+	tests := []struct {
+		args string
+		want rbac.Permission
+	}{
+		{"list", rbac.PermRead},
+		{"show", rbac.PermRead},
+		{"dep tree", rbac.PermRead},
+		{"label list", rbac.PermRead},
+		{"create", rbac.PermIssueWrite},
+		{"update", rbac.PermIssueWrite},
+		{"comments add", rbac.PermIssueWrite},
+		{"dep add", rbac.PermIssueWrite},
+		{"ci report", rbac.PermIssueWrite}, // leaf name shared with bd report
+		{"report", rbac.PermRead},
+		{"delete", rbac.PermWrite},
+		{"import", rbac.PermWrite},
+		{"label rename", rbac.PermWrite},
+		{"token create", rbac.PermAdmin},
+		{"token list", rbac.PermAdmin},
+		{"config set", rbac.PermAdmin},
+	}
+	for _, tt := range tests {
+		cmd, _, err := rootCmd.Find(strings.Fields(tt.args))
+		if err != nil {
+			t.Fatalf("find %q: %v", tt.args, err)
+		}
+		if got := commandPermission(cmd); got != tt.want {
+			t.Errorf("commandPermission(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+// memTokenStore is an in-memory storage.TokenStore for auth tests.
+type memTokenStore map[string]*storage.APIToken
+
+func (m memTokenStore) CreateAPIToken(_ context.Context, tok *storage.APIToken) error {
+	tok.CreatedAt = time.Now()
+	m[tok.ID] = tok
+	return nil
+}
+
+func (m memTokenStore) GetAPIToken(_ context.Context, id string) (*storage.APIToken, error) {
+	if tok, ok := m[id]; ok {
+		return tok, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (m memTokenStore) ListAPITokens(context.Context) ([]*storage.APIToken, error) {
+	var out []*storage.APIToken
+	for _, tok := range m {
+		out = append(out, tok)
+	}
+	return out, nil
+}
+
+func (m memTokenStore) RevokeAPIToken(_ context.Context, id string) error {
+	tok, ok := m[id]
+	if !ok || tok.RevokedAt != nil {
+		return storage.ErrNotFound
+	}
+	now := time.Now()
+	tok.RevokedAt = &now
+	return nil
+}
+
+// addToken mints a token with role into m and returns its plaintext.
+func (m memTokenStore) addToken(t *testing.T, name string, role rbac.Role) string {
+	t.Helper()
+	id, secret, raw, err := rbac.NewToken()
+	if err != nil {
+		t.Fatalf("NewToken: %v", err)
+	}
+	_ = m.CreateAPIToken(context.Background(), &storage.APIToken{ID: id, Name: name, Role: string(role), SecretHash: rbac.HashSecret(secret)})
+	return raw
+}
+
+func TestAuthorizeToken(t *testing.T) {
+	t.Chdir(t.TempDir()) // keep access entries out of the repo's audit log
+	ctx := context.Background()
+	ts := memTokenStore{}
+	agent := ts.addToken(t, "triage-bot", rbac.RoleAgent)
+	revoked := ts.addToken(t, "old-bot", rbac.RoleAdmin)
+	revokedID, _, _ := rbac.ParseToken(revoked)
+	_ = ts.RevokeAPIToken(ctx, revokedID)
+
+	tok, err := authorizeToken(ctx, ts, agent, rbac.PermIssueWrite, "update")
+	if err != nil || tok.Name != "triage-bot" {
+		t.Fatalf("agent update: tok=%+v err=%v", tok, err)
+	}
+	tok, err = authorizeToken(ctx, ts, agent, rbac.PermWrite, "delete")
+	if err == nil || tok == nil || !strings.Contains(err.Error(), "has role agent") {
+		t.Errorf("agent delete: tok=%+v err=%v, want role denial with token", tok, err)
+	}
+	for _, raw := range []string{revoked, "bdt_nope_nope", "garbage"} {
+		if tok, err := authorizeToken(ctx, ts, raw, rbac.PermRead, "list"); err == nil || tok != nil {
+			t.Errorf("authorizeToken(%q) = %+v, %v; want invalid token", raw, tok, err)
+		}
+	}
+}
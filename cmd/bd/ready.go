@@ -34,9 +34,15 @@ Use --gated to find molecules ready for gate-resume dispatch:
 Use --claim to atomically claim the first ready issue matching the filters:
   bd ready --claim --json
 
+Use --for to show only work an actor has the skills for: issues whose
+"skill:<name>" labels are all offered by the actor in assign.skills (see
+'bd skills'). Issues with no skill labels are always included:
+  bd ready --for claude-1
+
 This is useful for agents executing molecules to see which steps can run next.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		claimReady, _ := cmd.Flags().GetBool("claim")
+		format := outputFormatFlag(cmd)
 
 		// Handle --gated flag (gate-resume discovery)
 		gated, _ := cmd.Flags().GetBool("gated")
@@ -84,6 +90,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		includeDeferred, _ := cmd.Flags().GetBool("include-deferred")
 		includeEphemeral, _ := cmd.Flags().GetBool("include-ephemeral")
 		excludeTypeStrs, _ := cmd.Flags().GetStringSlice("exclude-type")
+		forActor, _ := cmd.Flags().GetString("for")
 		var molType *types.MolType
 		if molTypeStr != "" {
 			mt := types.MolType(molTypeStr)
@@ -195,6 +202,15 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			}
 		}
 
+		if forActor != "" {
+			required, err := requiredSkillCounts(ctx, activeStore)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			offered := offeredSkills(config.GetStringMapStringSlice("assign.skills"), forActor)
+			filter.ExcludeLabels = append(filter.ExcludeLabels, unofferedSkillLabels(required, offered)...)
+		}
+
 		if claimReady {
 			claimed, err := activeStore.ClaimReadyIssue(ctx, filter, actor)
 			if err != nil {
@@ -242,6 +258,14 @@ This is useful for agents executing molecules to see which steps can run next.`,
 			if results == nil {
 				results = []*types.IssueWithCounts{}
 			}
+			ids := make([]string, len(results))
+			for i, r := range results {
+				ids[i] = r.ID
+			}
+			ciRuns := loadCIRuns(ctx, activeStore, ids)
+			for _, r := range results {
+				r.CIStatus = types.CIStatusSummary(ciRuns[r.ID])
+			}
 			outputJSON(results)
 			if truncated {
 				fmt.Fprintf(os.Stderr, "Showing %d of %d ready issues. Use --limit 0 for all, or --limit N to raise the cap.\n", len(results), totalReady)
@@ -265,6 +289,16 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				truncated = true
 			}
 		}
+		if format != "" {
+			if err := renderFormatTemplate(os.Stdout, format, issues); err != nil {
+				FatalError("%v", err)
+			}
+			if truncated {
+				fmt.Fprintf(os.Stderr, "Showing %d of %d ready issues. Use --limit 0 for all, or --limit N to raise the cap.\n", len(issues), totalReady)
+			}
+			return
+		}
+
 		// Show upgrade notification if needed
 		maybeShowUpgradeNotification()
 
@@ -286,6 +320,7 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}
 		// Build parent epic map for pretty display
 		parentEpicMap := buildParentEpicMap(ctx, activeStore, issues)
+		ciStatus := readyCIStatuses(ctx, activeStore, issues)
 
 		// Determine display mode: --plain or --pretty=false triggers plain format
 		usePlain := plainFormat || !prettyFormat
@@ -302,10 +337,13 @@ This is useful for agents executing molecules to see which steps can run next.`,
 				if issue.Assignee != "" {
 					fmt.Printf("   Assignee: %s\n", issue.Assignee)
 				}
+				if ciStatus[issue.ID] == types.CIStatusFailure {
+					fmt.Printf("   CI: %s\n", ui.RenderFail("failing"))
+				}
 			}
 			fmt.Println()
 		} else {
-			displayReadyList(issues, parentEpicMap)
+			displayReadyList(issues, parentEpicMap, ciStatus)
 		}
 
 		// Show truncation footer if results were limited
@@ -325,6 +363,7 @@ var blockedCmd = &cobra.Command{
 		// Use factory to respect backend configuration (bd-m2jr: SQLite fallback fix)
 		ctx := rootCtx
 		parentID, _ := cmd.Flags().GetString("parent")
+		format := outputFormatFlag(cmd)
 		var blockedFilter types.WorkFilter
 		if parentID != "" {
 			blockedFilter.ParentID = &parentID
@@ -341,6 +380,12 @@ var blockedCmd = &cobra.Command{
 			outputJSON(blocked)
 			return
 		}
+		if format != "" {
+			if err := renderFormatTemplate(os.Stdout, format, blocked); err != nil {
+				FatalError("%v", err)
+			}
+			return
+		}
 		if len(blocked) == 0 {
 			fmt.Printf("\n%s No blocked issues\n\n", ui.RenderPass("✨"))
 			return
@@ -414,14 +459,32 @@ func buildParentEpicMap(ctx context.Context, s storage.DoltStorage, issues []*ty
 	return result
 }
 
-// displayReadyList displays ready issues in pretty format with optional parent epic context
-func displayReadyList(issues []*types.Issue, parentEpicMap map[string]string) {
+// readyCIStatuses returns the CI status summary for each issue with CI runs.
+func readyCIStatuses(ctx context.Context, s storage.DoltStorage, issues []*types.Issue) map[string]string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	statuses := make(map[string]string)
+	for id, runs := range loadCIRuns(ctx, s, ids) {
+		statuses[id] = types.CIStatusSummary(runs)
+	}
+	return statuses
+}
+
+// displayReadyList displays ready issues in pretty format with optional parent
+// epic context, flagging issues whose latest commit is failing CI.
+func displayReadyList(issues []*types.Issue, parentEpicMap map[string]string, ciStatus map[string]string) {
 	for _, issue := range issues {
 		epicTitle := ""
 		if parentEpicMap != nil {
 			epicTitle = parentEpicMap[issue.ID]
 		}
-		fmt.Println(formatPrettyIssueWithContext(issue, epicTitle))
+		line := formatPrettyIssueWithContext(issue, epicTitle)
+		if ciStatus[issue.ID] == types.CIStatusFailure {
+			line += " " + ui.RenderFail("[CI failing]")
+		}
+		fmt.Println(line)
 	}
 
 	// Summary footer
@@ -750,10 +813,13 @@ func init() {
 	readyCmd.Flags().StringSlice("exclude-type", nil, "Exclude issue types from results (comma-separated or repeatable, e.g., --exclude-type=convoy,epic)")
 	readyCmd.Flags().Bool("explain", false, "Show dependency-aware reasoning for why issues are ready or blocked")
 	readyCmd.Flags().Bool("claim", false, "Atomically claim the first ready issue matching the filters")
+	readyCmd.Flags().String("for", "", "Show only issues whose required skills (skill:<name> labels) this actor offers")
+	readyCmd.Flags().String("format", "", formatFlagHelp)
 	// Metadata filtering (GH#1406)
 	readyCmd.Flags().StringArray("metadata-field", nil, "Filter by metadata field (key=value, repeatable)")
 	readyCmd.Flags().String("has-metadata-key", "", "Filter issues that have this metadata key set")
 	rootCmd.AddCommand(readyCmd)
 	blockedCmd.Flags().String("parent", "", "Filter to descendants of this bead/epic")
+	blockedCmd.Flags().String("format", "", formatFlagHelp)
 	rootCmd.AddCommand(blockedCmd)
 }
@@ -259,6 +259,52 @@ func TestEmbeddedReady(t *testing.T) {
 	})
 }
 
+func TestEmbeddedReadySuggestsOrdering(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sg")
+	first := bdCreate(t, bd, dir, "Write schema", "--priority", "1")
+	second := bdCreate(t, bd, dir, "Write API", "--priority", "1")
+
+	readyIDs := func() []string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, "ready", "--json")
+		if err != nil {
+			t.Fatalf("bd ready --json failed: %v\n%s", err, out)
+		}
+		var ready []types.IssueWithCounts
+		if err := json.Unmarshal(bytes.TrimSpace(out), &ready); err != nil {
+			t.Fatalf("parse ready JSON: %v\n%s", err, out)
+		}
+		var ids []string
+		for _, issue := range ready {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	// Same priority: newest first by default.
+	if got := readyIDs(); len(got) != 2 || got[0] != second.ID {
+		t.Fatalf("ready before suggestion = %v, want %s first", got, second.ID)
+	}
+
+	bdDepAdd(t, bd, dir, second.ID, first.ID, "--type", "suggests")
+	got := readyIDs()
+	if len(got) != 2 || got[0] != first.ID || got[1] != second.ID {
+		t.Fatalf("ready with suggestion = %v, want %s then %s (both still ready)", got, first.ID, second.ID)
+	}
+
+	// A higher-priority issue is not held back by a suggestion.
+	bdCommand(t, bd, dir, "update", second.ID, "--priority", "0")
+	if got := readyIDs(); len(got) != 2 || got[0] != second.ID {
+		t.Fatalf("ready after reprioritizing = %v, want %s first", got, second.ID)
+	}
+}
+
 func TestEmbeddedReadyConcurrent(t *testing.T) {
 	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
 		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
@@ -21,7 +22,10 @@ This updates:
 - The issue's primary ID
 - All references in other issues (descriptions, titles, notes, etc.)
 - Dependencies pointing to/from this issue
-- Labels, comments, and events
+- Labels, comments, events, aliases, and child counters
+
+The new ID must not already be an issue, wisp, or alias. With --json the
+result lists every table and column whose rows were moved.
 
 Examples:
   bd rename bd-w382l bd-dolt     # Rename to memorable ID
@@ -65,19 +69,12 @@ func runRename(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get issue %s: %w", oldID, err)
 	}
 
-	// Check if new ID already exists
-	_, err = store.GetIssue(ctx, newID)
-	if err == nil {
-		return fmt.Errorf("issue %s already exists", newID)
-	}
-	if !errors.Is(err, storage.ErrNotFound) {
-		return fmt.Errorf("failed to check for existing issue: %w", err)
-	}
-
-	// Update the issue ID
+	// Update the issue ID. The store rejects a newID that is already an
+	// issue, wisp, or alias.
 	oldIssue.ID = newID
 	actor := getActorWithGit()
-	if err := store.UpdateIssueID(ctx, oldID, newID, oldIssue, actor); err != nil {
+	result, err := store.UpdateIssueID(ctx, oldID, newID, oldIssue, actor)
+	if err != nil {
 		return fmt.Errorf("failed to rename issue: %w", err)
 	}
 
@@ -87,10 +84,21 @@ func runRename(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Warning: failed to update some references: %v\n", err)
 	}
 
-	fmt.Printf("Renamed %s -> %s\n", ui.RenderWarn(oldID), ui.RenderAccent(newID))
-
 	commandDidWrite.Store(true)
 
+	if jsonOutput {
+		outputJSON(result)
+		return nil
+	}
+	fmt.Printf("Renamed %s -> %s\n", ui.RenderWarn(oldID), ui.RenderAccent(newID))
+	if len(result.Tables) > 1 {
+		moved := make([]string, 0, len(result.Tables)-1)
+		for _, t := range result.Tables[1:] {
+			moved = append(moved, fmt.Sprintf("%s.%s (%d)", t.Table, t.Column, t.Rows))
+		}
+		fmt.Println(ui.RenderMuted("  Updated " + strings.Join(moved, ", ")))
+	}
+
 	return nil
 }
 
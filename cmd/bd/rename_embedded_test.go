@@ -0,0 +1,66 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedRename(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "rn")
+
+	t.Run("rejects_existing_targets", func(t *testing.T) {
+		a := bdCreate(t, bd, dir, "A")
+		b := bdCreate(t, bd, dir, "B")
+		bdCommand(t, bd, dir, "alias", "add", "taken-name", b.ID)
+
+		for target, want := range map[string]string{
+			b.ID:         "already exists",
+			"taken-name": "already an alias",
+		} {
+			out, err := bdRunWithFlockRetry(t, bd, dir, "rename", a.ID, target)
+			if err == nil || !strings.Contains(string(out), want) {
+				t.Errorf("rename onto %s: expected %q error, got %v:\n%s", target, want, err, out)
+			}
+		}
+		if got := bdShow(t, bd, dir, b.ID).Title; got != "B" {
+			t.Errorf("rename target was modified: title %q", got)
+		}
+	})
+
+	t.Run("reports_tables_touched", func(t *testing.T) {
+		parent := bdCreate(t, bd, dir, "Parent", "--label", "keep")
+		bdCommand(t, bd, dir, "child", "add", parent.ID, "Kid")
+
+		out := bdCommand(t, bd, dir, "--json", "rename", parent.ID, "rn-parent")
+		var result types.RenameResult
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &result); err != nil {
+			t.Fatalf("parse rename result: %v\n%s", err, out)
+		}
+		touched := make(map[string]int)
+		for _, tbl := range result.Tables {
+			touched[tbl.Table+"."+tbl.Column] = tbl.Rows
+		}
+		for _, col := range []string{"issues.id", "labels.issue_id", "child_counters.parent_id", "dependencies.depends_on_issue_id"} {
+			if touched[col] == 0 {
+				t.Errorf("result missing %s: %+v", col, result.Tables)
+			}
+		}
+
+		out = bdCommand(t, bd, dir, "child", "counters", "--all")
+		if !strings.Contains(out, "rn-parent ") {
+			t.Errorf("child counter did not follow the rename:\n%s", out)
+		}
+	})
+}
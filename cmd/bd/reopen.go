@@ -48,6 +48,12 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 				result.Close()
 				continue
 			}
+			if err := checkStatusTransition(ctx, issueStore, issue, types.StatusOpen); err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err)
+				hasError = true
+				result.Close()
+				continue
+			}
 			if err := issueStore.ReopenIssue(ctx, fullID, reason, actor); err != nil {
 				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", fullID, err)
 				hasError = true
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	GroupID: "views",
+	Short:   "Generate a static project report",
+	Long: `Generate a static, self-contained project report.
+
+With --html <dir>, writes <dir>/index.html containing:
+  - Backlog summary: counts by status, type, and priority; ready and blocked work
+  - Molecule progress for molecules with steps in progress
+  - Dependency graph of open issues as an inline SVG
+  - Doctor status (skip with --no-doctor)
+
+The page has no scripts or external assets, so the directory can be
+published as a CI artifact or a GitHub Pages site.
+
+Examples:
+  bd report --html out/
+  bd report --html public/ --title "Sprint 12" --no-doctor`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, _ := cmd.Flags().GetString("html")
+		title, _ := cmd.Flags().GetString("title")
+		noDoctor, _ := cmd.Flags().GetBool("no-doctor")
+		if dir == "" {
+			FatalErrorWithHint("bd report needs an output format", "Use --html <dir> to write a static HTML report")
+		}
+
+		report, err := collectProjectReport(rootCtx, title)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !noDoctor {
+			if cwd, err := os.Getwd(); err == nil {
+				result := runDiagnostics(cwd)
+				report.Doctor = &result
+			}
+		}
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			FatalErrorRespectJSON("creating %s: %v", dir, err)
+		}
+		path := filepath.Join(dir, "index.html")
+		f, err := os.Create(path) // #nosec G304 -- user-chosen output path
+		if err != nil {
+			FatalErrorRespectJSON("creating %s: %v", path, err)
+		}
+		if err := writeReportHTML(f, report); err != nil {
+			_ = f.Close()
+			FatalErrorRespectJSON("writing %s: %v", path, err)
+		}
+		if err := f.Close(); err != nil {
+			FatalErrorRespectJSON("writing %s: %v", path, err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"path": path, "generated_at": report.GeneratedAt})
+			return
+		}
+		fmt.Printf("%s Wrote report to %s\n", ui.RenderPass("✓"), path)
+	},
+}
+
+// reportCount is one row of a count table in the report.
+type reportCount struct {
+	Name  string
+	Count int
+}
+
+// projectReport is everything rendered by 'bd report --html'.
+type projectReport struct {
+	Title       string
+	GeneratedAt time.Time
+	Version     string
+	Stats       *types.Statistics
+	ByType      []reportCount
+	ByPriority  []reportCount
+	Ready       []*types.Issue
+	Blocked     []*types.BlockedIssue
+	Molecules   []*types.MoleculeProgressStats
+	GraphSVG    template.HTML
+	Doctor      *doctorResult
+}
+
+// collectProjectReport gathers the store-backed sections of the report.
+func collectProjectReport(ctx context.Context, title string) (*projectReport, error) {
+	report := &projectReport{Title: title, GeneratedAt: time.Now().UTC(), Version: Version}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load statistics: %w", err)
+	}
+	report.Stats = stats
+
+	var open []*types.Issue
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusDeferred} {
+		s := status
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &s, SkipWisps: true})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load issues: %w", err)
+		}
+		open = append(open, issues...)
+	}
+	report.ByType, report.ByPriority = reportOpenCounts(open)
+
+	if report.Ready, err = store.GetReadyWork(ctx, types.WorkFilter{}); err != nil {
+		return nil, fmt.Errorf("failed to load ready work: %w", err)
+	}
+	if report.Blocked, err = store.GetBlockedIssues(ctx, types.WorkFilter{}); err != nil {
+		return nil, fmt.Errorf("failed to load blocked issues: %w", err)
+	}
+
+	for _, id := range findInProgressMoleculeIDs(ctx, store, "") {
+		progress, err := store.GetMoleculeProgress(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load progress for %s: %w", id, err)
+		}
+		report.Molecules = append(report.Molecules, progress)
+	}
+
+	subgraphs, err := loadAllGraphSubgraphs(ctx, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dependency graph: %w", err)
+	}
+	merged := mergeSubgraphsForHTML(subgraphs)
+	var svg bytes.Buffer
+	renderGraphSVG(&svg, computeLayout(merged), merged)
+	report.GraphSVG = template.HTML(svg.String()) // #nosec G203 -- renderGraphSVG escapes all issue text
+
+	return report, nil
+}
+
+// reportOpenCounts counts open issues by type (largest first) and priority.
+func reportOpenCounts(issues []*types.Issue) (byType, byPriority []reportCount) {
+	typeCounts, priorities := make(map[string]int), make(map[int]int)
+	for _, issue := range issues {
+		typeCounts[string(issue.IssueType)]++
+		priorities[issue.Priority]++
+	}
+	for name, n := range typeCounts {
+		byType = append(byType, reportCount{Name: name, Count: n})
+	}
+	sort.Slice(byType, func(i, j int) bool {
+		if byType[i].Count != byType[j].Count {
+			return byType[i].Count > byType[j].Count
+		}
+		return byType[i].Name < byType[j].Name
+	})
+	var keys []int
+	for p := range priorities {
+		keys = append(keys, p)
+	}
+	sort.Ints(keys)
+	for _, p := range keys {
+		byPriority = append(byPriority, reportCount{Name: fmt.Sprintf("P%d", p), Count: priorities[p]})
+	}
+	return byType, byPriority
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(done, total int) int {
+		if total == 0 {
+			return 0
+		}
+		return done * 100 / total
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{if .Title}}{{.Title}}{{else}}Beads report{{end}}</title>
+<style>
+body { font-family: -apple-system, Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #1a1a1a; padding: 0 1em; }
+h1 { margin-bottom: 0; }
+.meta { color: #666; margin-top: 0.3em; }
+section { margin-top: 2em; }
+table { border-collapse: collapse; margin: 0.5em 0; }
+th, td { border-bottom: 1px solid #ddd; padding: 4px 10px; text-align: left; }
+td.n { text-align: right; }
+.cards { display: flex; flex-wrap: wrap; gap: 10px; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: 8px 14px; min-width: 90px; }
+.card b { display: block; font-size: 1.6em; }
+.bar { background: #eee; border-radius: 4px; width: 200px; height: 10px; display: inline-block; }
+.bar span { background: #2e7d32; border-radius: 4px; height: 10px; display: block; }
+.graph { overflow-x: auto; border: 1px solid #ddd; border-radius: 6px; }
+.ok { color: #2e7d32; } .warning { color: #b26a00; } .error { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>{{if .Title}}{{.Title}}{{else}}Beads report{{end}}</h1>
+<p class="meta">Generated {{.GeneratedAt.Format "2006-01-02 15:04 UTC"}} by bd {{.Version}}</p>
+
+<section>
+<h2>Backlog summary</h2>
+<div class="cards">
+<div class="card"><b>{{.Stats.TotalIssues}}</b>total</div>
+<div class="card"><b>{{.Stats.OpenIssues}}</b>open</div>
+<div class="card"><b>{{.Stats.InProgressIssues}}</b>in progress</div>
+<div class="card"><b>{{.Stats.BlockedIssues}}</b>blocked</div>
+<div class="card"><b>{{.Stats.DeferredIssues}}</b>deferred</div>
+<div class="card"><b>{{.Stats.ReadyIssues}}</b>ready</div>
+<div class="card"><b>{{.Stats.ClosedIssues}}</b>closed</div>
+</div>
+{{if .ByType}}<h3>Open by type</h3>
+<table>{{range .ByType}}<tr><td>{{.Name}}</td><td class="n">{{.Count}}</td></tr>{{end}}</table>{{end}}
+{{if .ByPriority}}<h3>Open by priority</h3>
+<table>{{range .ByPriority}}<tr><td>{{.Name}}</td><td class="n">{{.Count}}</td></tr>{{end}}</table>{{end}}
+<h3>Ready ({{len .Ready}})</h3>
+{{if .Ready}}<table><tr><th>ID</th><th>P</th><th>Type</th><th>Title</th><th>Assignee</th></tr>
+{{range .Ready}}<tr><td>{{.ID}}</td><td>P{{.Priority}}</td><td>{{.IssueType}}</td><td>{{.Title}}</td><td>{{.Assignee}}</td></tr>
+{{end}}</table>{{else}}<p>No ready work.</p>{{end}}
+<h3>Blocked ({{len .Blocked}})</h3>
+{{if .Blocked}}<table><tr><th>ID</th><th>P</th><th>Title</th><th>Blocked by</th></tr>
+{{range .Blocked}}<tr><td>{{.ID}}</td><td>P{{.Priority}}</td><td>{{.Title}}</td><td>{{range $i, $b := .BlockedBy}}{{if $i}}, {{end}}{{$b}}{{end}}</td></tr>
+{{end}}</table>{{else}}<p>Nothing is blocked.</p>{{end}}
+</section>
+
+<section>
+<h2>Molecule progress</h2>
+{{if .Molecules}}<table><tr><th>Molecule</th><th>Progress</th><th></th><th>In progress</th></tr>
+{{range .Molecules}}<tr><td>{{.MoleculeID}} {{.MoleculeTitle}}</td><td><span class="bar"><span style="width: {{percent .Completed .Total}}%"></span></span></td><td class="n">{{.Completed}}/{{.Total}}</td><td class="n">{{.InProgress}}</td></tr>
+{{end}}</table>{{else}}<p>No molecules in progress.</p>{{end}}
+</section>
+
+<section>
+<h2>Dependency graph</h2>
+<div class="graph">{{.GraphSVG}}</div>
+</section>
+
+{{with .Doctor}}<section>
+<h2>Doctor</h2>
+<p class="{{if .OverallOK}}ok{{else}}error{{end}}">{{if .OverallOK}}All checks passed{{else}}Some checks need attention{{end}}</p>
+<table><tr><th>Check</th><th>Status</th><th>Message</th></tr>
+{{range .Checks}}<tr><td>{{.Name}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+</section>{{end}}
+</body>
+</html>
+`))
+
+// writeReportHTML renders report as a single self-contained HTML page.
+func writeReportHTML(w io.Writer, report *projectReport) error {
+	return reportTemplate.Execute(w, report)
+}
+
+func init() {
+	reportCmd.Flags().String("html", "", "Write a static HTML report to this directory (index.html)")
+	reportCmd.Flags().String("title", "", "Report title (default \"Beads report\")")
+	reportCmd.Flags().Bool("no-doctor", false, "Skip the doctor checks section")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReportOpenCounts(t *testing.T) {
+	t.Parallel()
+	issues := []*types.Issue{
+		{IssueType: types.TypeTask, Priority: 2},
+		{IssueType: types.TypeBug, Priority: 0},
+		{IssueType: types.TypeTask, Priority: 2},
+		{IssueType: types.TypeEpic, Priority: 1},
+	}
+	byType, byPriority := reportOpenCounts(issues)
+
+	wantType := []reportCount{{"task", 2}, {"bug", 1}, {"epic", 1}}
+	if len(byType) != len(wantType) {
+		t.Fatalf("byType = %v, want %v", byType, wantType)
+	}
+	for i := range wantType {
+		if byType[i] != wantType[i] {
+			t.Errorf("byType[%d] = %v, want %v", i, byType[i], wantType[i])
+		}
+	}
+	wantPriority := []reportCount{{"P0", 1}, {"P1", 1}, {"P2", 2}}
+	for i := range wantPriority {
+		if byPriority[i] != wantPriority[i] {
+			t.Errorf("byPriority[%d] = %v, want %v", i, byPriority[i], wantPriority[i])
+		}
+	}
+}
+
+func TestWriteReportHTML(t *testing.T) {
+	t.Parallel()
+	report := &projectReport{
+		Title:       "Sprint <12>",
+		GeneratedAt: time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC),
+		Version:     "1.2.3",
+		Stats:       &types.Statistics{TotalIssues: 7, OpenIssues: 3, ClosedIssues: 4},
+		ByType:      []reportCount{{"task", 3}},
+		Ready:       []*types.Issue{{ID: "bd-1", Title: "Ready & waiting", Priority: 1, IssueType: types.TypeTask}},
+		Blocked: []*types.BlockedIssue{{
+			Issue:     types.Issue{ID: "bd-2", Title: "Stuck", Priority: 2},
+			BlockedBy: []string{"bd-1", "bd-3"},
+		}},
+		Molecules: []*types.MoleculeProgressStats{{MoleculeID: "bd-m", MoleculeTitle: "Release", Total: 4, Completed: 1}},
+		GraphSVG:  template.HTML(`<svg id="g"></svg>`),
+		Doctor: &doctorResult{Checks: []doctorCheck{
+			{Name: "Schema", Status: statusOK, Message: "up to date"},
+			{Name: "Hooks", Status: statusWarning, Message: "missing"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeReportHTML(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<title>Sprint &lt;12&gt;</title>",
+		"Generated 2026-03-02 09:30 UTC by bd 1.2.3",
+		"<b>7</b>total",
+		"Ready &amp; waiting",
+		"bd-1, bd-3",
+		"width: 25%",
+		"1/4",
+		`<svg id="g"></svg>`,
+		"Some checks need attention",
+		`<td class="warning">warning</td>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(out, "<script") || strings.Contains(out, "http://") || strings.Contains(out, "https://") {
+		t.Error("report should not reference scripts or external assets")
+	}
+}
+
+func TestWriteReportHTML_NoDoctor(t *testing.T) {
+	t.Parallel()
+	report := &projectReport{GeneratedAt: time.Now(), Stats: &types.Statistics{}}
+	var buf bytes.Buffer
+	if err := writeReportHTML(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "<title>Beads report</title>") {
+		t.Error("default title missing")
+	}
+	if strings.Contains(out, "<h2>Doctor</h2>") {
+		t.Error("doctor section should be omitted without results")
+	}
+	if !strings.Contains(out, "No molecules in progress.") || !strings.Contains(out, "No ready work.") {
+		t.Error("empty sections should say so")
+	}
+}
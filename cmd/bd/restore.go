@@ -19,8 +19,9 @@ var restoreCmd = &cobra.Command{
 	Long: `Restore full history of a compacted issue from Dolt version history.
 
 When an issue is compacted, its description and notes are truncated.
-This command queries Dolt's history tables to find the pre-compaction
-version and displays the full issue content.
+This command shows the snapshot taken when the issue was compacted, or
+else queries Dolt's history tables to find the pre-compaction version, and
+displays the full issue content. Use 'bd compact restore' to write it back.
 
 This is read-only and does not modify the database.`,
 	Args: cobra.ExactArgs(1),
@@ -46,6 +47,22 @@ This is read-only and does not modify the database.`,
 			os.Exit(1)
 		}
 
+		// Prefer the snapshot taken at compaction time: Dolt history may
+		// have been squashed since by 'bd compact'.
+		if ss, ok := storage.UnwrapStore(store).(storage.CompactionSnapshotStore); ok {
+			snapshots, err := ss.GetCompactionSnapshots(ctx, issueID)
+			if err == nil {
+				if original := originalFromSnapshots(snapshots); original != nil {
+					if jsonOutput {
+						outputJSON(original)
+					} else {
+						displayRestoredIssue(original, "compaction snapshot")
+					}
+					return
+				}
+			}
+		}
+
 		// Query Dolt history for the pre-compaction version
 		history, err := store.History(ctx, issueID)
 		if err != nil {
@@ -80,7 +97,11 @@ This is read-only and does not modify the database.`,
 		if jsonOutput {
 			outputJSON(best.Issue)
 		} else {
-			displayRestoredIssue(best.Issue, best.CommitHash)
+			hashDisplay := best.CommitHash
+			if len(hashDisplay) > 8 {
+				hashDisplay = hashDisplay[:8]
+			}
+			displayRestoredIssue(best.Issue, "Dolt commit "+ui.RenderWarn(hashDisplay))
 		}
 	},
 }
@@ -95,13 +116,10 @@ func init() {
 	rootCmd.AddCommand(restoreCmd)
 }
 
-// displayRestoredIssue displays the restored issue in a readable format
-func displayRestoredIssue(issue *types.Issue, commitHash string) {
-	hashDisplay := commitHash
-	if len(hashDisplay) > 8 {
-		hashDisplay = hashDisplay[:8]
-	}
-	fmt.Printf("\n%s %s (restored from Dolt commit %s)\n", ui.RenderAccent("📜"), ui.RenderBold(issue.ID), ui.RenderWarn(hashDisplay))
+// displayRestoredIssue displays the restored issue in a readable format.
+// source says where it came from, e.g. "Dolt commit abcd1234".
+func displayRestoredIssue(issue *types.Issue, source string) {
+	fmt.Printf("\n%s %s (restored from %s)\n", ui.RenderAccent("📜"), ui.RenderBold(issue.ID), source)
 	fmt.Printf("%s\n\n", ui.RenderBold(issue.Title))
 
 	if issue.Description != "" {
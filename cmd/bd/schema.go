@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// outputSchema describes the --json payload of one command. Value is a zero
+// value of the type the command passes to outputJSON.
+type outputSchema struct {
+	Command     string
+	Description string
+	Value       interface{}
+}
+
+// outputSchemas is the registry printed by bd schema output. Keep it in step
+// with the types the listed commands pass to outputJSON; schema_test.go
+// checks that every entry reflects.
+func outputSchemas() []outputSchema {
+	schemas := []outputSchema{
+		{"blocked", "Blocked issues with their blockers", []*types.BlockedIssue{}},
+		{"close", "Closed issues", []*types.Issue{}},
+		{"close --continue", "Closed issues plus molecule progress (--continue, --claim-next, --suggest-next)", closeFollowUpJSON{}},
+		{"comment", "The added comment", &types.Comment{}},
+		{"comments", "Comments on an issue", []*types.Comment{}},
+		{"comments add", "The added comment", &types.Comment{}},
+		{"create", "The created issue", &types.Issue{}},
+		{"dep add", "One added dependency", depChangeJSON{}},
+		{"dep add --file", "Dependencies added from a file", depBulkAddJSON{}},
+		{"dep cycles", "Dependency cycles, each a list of issues", [][]*types.Issue{}},
+		{"dep list", "Dependencies or dependents of the given issues", []*types.IssueWithDependencyMetadata{}},
+		{"dep remove", "One removed dependency", depChangeJSON{}},
+		{"dep tree", "Dependency tree nodes in display order", []*types.TreeNode{}},
+		{"doctor", "Health check results", doctorResult{}},
+		{"doctor --agent", "Health check diagnostics for agents", agentDoctorResult{}},
+		{"label add", "One entry per labeled issue", []labelChangeJSON{}},
+		{"label delete", "The deleted label and the issues it was removed from", labelBulkJSON{}},
+		{"label list", "Labels on an issue", []string{}},
+		{"label list --counts", "Every label with its issue count", []labelCountJSON{}},
+		{"label list-all", "Every label with its issue count", []labelCountJSON{}},
+		{"label merge", "The merged labels, target, and affected issues", labelBulkJSON{}},
+		{"label propagate", "One entry per child that received the label", []labelChangeJSON{}},
+		{"label remove", "One entry per unlabeled issue", []labelChangeJSON{}},
+		{"label rename", "The renamed label, its new name, and affected issues", labelBulkJSON{}},
+		{"list", "Issues with dependency counts", []*types.IssueWithCounts{}},
+		{"lock", "Locks taken, or with no IDs the active locks", []*types.IssueLock{}},
+		{"purge-actor", "Per-table counts of rows anonymized, cleared, deleted, or rewritten", &storage.ActorPurgeReport{}},
+		{"ready", "Ready issues with dependency counts", []*types.IssueWithCounts{}},
+		{"show", "Issues with labels, dependencies, and comments", []*types.IssueDetails{}},
+		{"similar", "Issues ranked by embedding similarity to the query", similarJSON{}},
+		{"show --backlinks", "Issues whose description or comments mention each shown issue, keyed by ID", map[string][]backlinkJSON{}},
+		{"stats", "Alias of status", &StatusOutput{}},
+		{"status", "Database summary and recent activity", &StatusOutput{}},
+		{"summarize", "Markdown summary of an epic, optionally written to its notes", summarizeJSON{}},
+		{"tree", "The root issue with nested children and recursive roll-ups", &ShowTreeNode{}},
+		{"triage", "Proposed (and, with --apply, applied) triage of untriaged issues", triagePatch{}},
+		{"token create", "The new token, including the plaintext secret (shown only here)", tokenCreateJSON{}},
+		{"token list", "All API tokens, revoked and expired included (no secrets)", []*storage.APIToken{}},
+		{"token revoke", "One entry per argument, whether a token was revoked", []tokenRevokeJSON{}},
+		{"unlock", "One entry per issue, whether its lock was released", []unlockJSON{}},
+		{"update", "Updated issues", []*types.Issue{}},
+	}
+	schemas = append(schemas, federationOutputSchemas()...)
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Command < schemas[j].Command })
+	return schemas
+}
+
+// reflectOutputSchema builds the JSON Schema for one registry entry.
+func reflectOutputSchema(s outputSchema) *jsonschema.Schema {
+	r := &jsonschema.Reflector{
+		// Legacy mode injects schema_version into object payloads, and
+		// omitempty is tagged too unevenly to derive "required" from it.
+		AllowAdditionalProperties:  true,
+		RequiredFromJSONSchemaTags: true,
+	}
+	schema := r.Reflect(s.Value)
+	schema.Title = "bd " + s.Command + " --json"
+	schema.Description = s.Description
+	return schema
+}
+
+var schemaCmd = &cobra.Command{
+	Use:     "schema",
+	GroupID: "setup",
+	Short:   "Print JSON Schemas for bd output",
+}
+
+var schemaOutputCmd = &cobra.Command{
+	Use:   "output [command...]",
+	Short: "Print the JSON Schema of a command's --json output",
+	Long: `Print the JSON Schema of a command's --json output.
+
+With no arguments, prints an object mapping every documented command to its
+schema. With a command (e.g. "bd schema output dep add"), prints that
+command's schema alone; quote variants that name a flag, as in
+bd schema output 'close --continue'. --list prints the documented commands.
+
+Schemas describe the payload. With BD_JSON_ENVELOPE=1 the payload is under
+"data"; in legacy mode object payloads also carry an injected
+"schema_version" field. See docs/JSON_SCHEMA.md.`,
+	Example: `  bd schema output --list
+  bd schema output list
+  bd schema output dep add > dep-add.schema.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listOnly, _ := cmd.Flags().GetBool("list")
+		schemas := outputSchemas()
+
+		if listOnly {
+			for _, s := range schemas {
+				fmt.Printf("%-24s %s\n", s.Command, s.Description)
+			}
+			return
+		}
+
+		var out interface{}
+		if len(args) == 0 {
+			all := make(map[string]*jsonschema.Schema, len(schemas))
+			for _, s := range schemas {
+				all[s.Command] = reflectOutputSchema(s)
+			}
+			out = all
+		} else {
+			name := strings.Join(args, " ")
+			for _, s := range schemas {
+				if s.Command == name {
+					out = reflectOutputSchema(s)
+					break
+				}
+			}
+			if out == nil {
+				FatalErrorWithHint(fmt.Sprintf("no output schema for %q", name),
+					"run 'bd schema output --list' to see documented commands")
+			}
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			FatalError("encoding schema: %v", err)
+		}
+	},
+}
+
+func init() {
+	schemaOutputCmd.Flags().Bool("list", false, "List documented commands instead of printing schemas")
+	schemaCmd.AddCommand(schemaOutputCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOutputSchemasReflect(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+	for _, s := range outputSchemas() {
+		if seen[s.Command] {
+			t.Errorf("duplicate output schema for %q", s.Command)
+		}
+		seen[s.Command] = true
+
+		data, err := json.Marshal(reflectOutputSchema(s))
+		if err != nil {
+			t.Errorf("%s: marshal schema: %v", s.Command, err)
+			continue
+		}
+		if !strings.Contains(string(data), `"$defs"`) && !strings.Contains(string(data), `"type"`) {
+			t.Errorf("%s: schema has no type information: %s", s.Command, data)
+		}
+	}
+	for _, cmd := range []string{"list", "show", "create", "update", "close", "dep add", "label add", "comments", "doctor", "stats"} {
+		if !seen[cmd] {
+			t.Errorf("no output schema for %q", cmd)
+		}
+	}
+}
+
+// Field names in the schemas are the ones consumers read from --json output.
+func TestOutputSchemaFieldNames(t *testing.T) {
+	t.Parallel()
+
+	want := map[string][]string{
+		"list":           {`"id"`, `"dependency_count"`},
+		"dep add":        {`"issue_id"`, `"depends_on_id"`},
+		"label list-all": {`"label"`, `"count"`},
+		"doctor":         {`"overall_ok"`, `"checks"`},
+	}
+	for _, s := range outputSchemas() {
+		fields, ok := want[s.Command]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(reflectOutputSchema(s))
+		if err != nil {
+			t.Fatalf("%s: marshal schema: %v", s.Command, err)
+		}
+		for _, field := range fields {
+			if !strings.Contains(string(data), field) {
+				t.Errorf("%s schema missing %s", s.Command, field)
+			}
+		}
+	}
+}
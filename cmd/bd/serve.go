@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/config"
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	GroupID: "advanced",
+	Short:   "Serve the database over gRPC, REST, and GraphQL",
+	Long: `Serve the database over gRPC, REST, and/or GraphQL so orchestrators,
+agents, and web UIs can read and write issues without exec'ing bd or polling.
+
+gRPC (--grpc): the service (beads.v1.Beads, defined in
+api/beadsv1/beads.proto) covers:
+
+  GetIssue, ListIssues, ReadyWork        reads
+  CreateIssue, UpdateIssue, CloseIssue   issue writes
+  AddDependency, RemoveDependency,
+  ListDependencies                       dependencies
+  WatchEvents                            server-streamed audit events
+
+Writes are committed exactly as the matching bd command would commit them,
+and go through the same checks, including issue locks (bd lock).
+WatchEvents reads the event log every --poll-interval and pushes new events
+to every subscribed client, optionally replaying from a timestamp.
+
+REST (--rest): the same operations (except WatchEvents) as JSON over HTTP
+under /v1/, with snake_case fields as in bd --json. The OpenAPI 3 document
+is served at GET /openapi.json; bd serve --openapi prints it without
+starting a server, for generating clients in other languages.
+
+GraphQL (--graphql): a read-only schema over issues, labels, comments, and
+dependencies, served at POST /graphql. Edges resolve lazily, so one query can
+fetch an epic, its children, and each child's open blockers:
+
+  { issue(id: "bd-1") { title children { id status blockers(openOnly: true) { id title } } } }
+
+Query nesting is capped at 12 levels. Use gRPC or REST for writes.
+
+Authentication uses API tokens (bd token). Writes need a token whose role
+allows issue writes (agent or above), sent as "Authorization: Bearer <token>"
+(gRPC: "authorization" metadata); the token's name is recorded as the actor
+and actor fields in the request are ignored. Reads, including GraphQL and
+WatchEvents, need a token only when rbac.require-token is set. Without
+rbac.require-token, bd serve only listens on loopback addresses, so nothing
+is readable from other hosts without a token.
+
+--rest and --graphql may be given the same address, in which case both are
+served by one listener. In embedded mode the server holds the database
+open, so other bd commands against the same .beads/ wait for it to stop —
+run a Dolt server (bd dolt start) when bd and bd serve need to share the
+database.
+
+Stop the server with Ctrl+C; open streams are ended and in-flight calls are
+allowed to finish.
+
+Examples:
+  bd serve --grpc localhost:7420
+  bd serve --grpc localhost:7420 --poll-interval 250ms
+  bd serve --rest localhost:7421
+  bd serve --rest localhost:7421 --graphql localhost:7422
+  bd serve --rest localhost:7421 --graphql localhost:7421   # One listener for both
+  BD_RBAC_REQUIRE_TOKEN=true bd serve --rest :7421   # Tokens for every request
+  bd serve --openapi > openapi.json
+  bd --readonly serve --grpc localhost:7420   # Reject writes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if printSpec, _ := cmd.Flags().GetBool("openapi"); printSpec {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(openAPISpec()); err != nil {
+				FatalError("encoding OpenAPI document: %v", err)
+			}
+			return
+		}
+
+		var opts serveOptions
+		opts.grpcAddr, _ = cmd.Flags().GetString("grpc")
+		opts.restAddr, _ = cmd.Flags().GetString("rest")
+		opts.graphqlAddr, _ = cmd.Flags().GetString("graphql")
+		if opts.grpcAddr == "" && opts.restAddr == "" && opts.graphqlAddr == "" {
+			FatalErrorWithHint("no protocol to serve", "Use --grpc, --rest, and/or --graphql <addr>, e.g. bd serve --grpc localhost:7420")
+		}
+		opts.pollInterval, _ = cmd.Flags().GetDuration("poll-interval")
+		if opts.pollInterval <= 0 {
+			FatalErrorRespectJSON("--poll-interval must be positive")
+		}
+		if err := runServe(rootCtx, opts); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("grpc", "", "Serve the gRPC API on this address (e.g. localhost:7420)")
+	serveCmd.Flags().String("rest", "", "Serve the REST API on this address (e.g. localhost:7421)")
+	serveCmd.Flags().String("graphql", "", "Serve the GraphQL API on this address (e.g. localhost:7422; may equal --rest)")
+	serveCmd.Flags().Bool("openapi", false, "Print the REST API's OpenAPI 3 document and exit")
+	serveCmd.Flags().Duration("poll-interval", time.Second, "How often WatchEvents checks for new events")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// serveOptions selects the protocols bd serve runs; empty addresses are off.
+type serveOptions struct {
+	grpcAddr     string
+	restAddr     string
+	graphqlAddr  string
+	pollInterval time.Duration
+}
+
+// runServe serves the selected APIs until interrupted. REST and GraphQL share
+// one HTTP server per distinct address.
+func runServe(ctx context.Context, opts serveOptions) error {
+	requireToken := config.GetBool("rbac.require-token")
+	for _, addr := range []string{opts.grpcAddr, opts.restAddr, opts.graphqlAddr} {
+		if addr == "" {
+			continue
+		}
+		if err := checkServeBind(addr, requireToken); err != nil {
+			return err
+		}
+	}
+
+	impl := newGRPCServer(store, getActor(), opts.pollInterval)
+	impl.readonly = readonlyMode
+	impl.auth = newServeAuth(store, requireToken)
+
+	var grpcSrv *grpc.Server
+	var grpcLis net.Listener
+	if opts.grpcAddr != "" {
+		lis, err := net.Listen("tcp", opts.grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", opts.grpcAddr, err)
+		}
+		grpcLis = lis
+		grpcSrv = grpc.NewServer(
+			grpc.UnaryInterceptor(impl.auth.unaryInterceptor),
+			grpc.StreamInterceptor(impl.auth.streamInterceptor),
+		)
+		beadsv1.RegisterBeadsServer(grpcSrv, impl)
+	}
+
+	muxes := map[string]*http.ServeMux{}
+	var addrOrder []string
+	muxFor := func(addr string) *http.ServeMux {
+		if mux, ok := muxes[addr]; ok {
+			return mux
+		}
+		mux := http.NewServeMux()
+		muxes[addr] = mux
+		addrOrder = append(addrOrder, addr)
+		return mux
+	}
+	if opts.restAddr != "" {
+		if err := registerREST(muxFor(opts.restAddr), impl); err != nil {
+			return err
+		}
+	}
+	if opts.graphqlAddr != "" {
+		schema, err := newGraphQLSchema(store)
+		if err != nil {
+			return fmt.Errorf("internal error: invalid GraphQL schema: %w", err)
+		}
+		muxFor(opts.graphqlAddr).Handle("POST /graphql", impl.auth.requireRead("serve graphql", &relay.Handler{Schema: schema}))
+	}
+	type httpListener struct {
+		srv *http.Server
+		lis net.Listener
+	}
+	var httpServers []httpListener
+	listening := map[string]string{} // flag address -> bound address
+	for _, addr := range addrOrder {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			if grpcLis != nil {
+				_ = grpcLis.Close()
+			}
+			for _, h := range httpServers {
+				_ = h.lis.Close()
+			}
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+		listening[addr] = lis.Addr().String()
+		httpServers = append(httpServers, httpListener{
+			srv: &http.Server{Handler: muxes[addr], ReadHeaderTimeout: 10 * time.Second},
+			lis: lis,
+		})
+	}
+
+	// Stopping one server (signal or failure) stops the others.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	go func() {
+		select {
+		case <-sigChan:
+		case <-ctx.Done():
+		}
+		impl.shutdown()
+		if grpcSrv != nil {
+			grpcSrv.GracefulStop()
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		for _, h := range httpServers {
+			_ = h.srv.Shutdown(shutdownCtx)
+		}
+	}()
+
+	endpoints := map[string]string{}
+	if grpcLis != nil {
+		endpoints["grpc"] = grpcLis.Addr().String()
+	}
+	if opts.restAddr != "" {
+		endpoints["rest"] = "http://" + listening[opts.restAddr] + "/v1/"
+		endpoints["openapi"] = "http://" + listening[opts.restAddr] + "/openapi.json"
+	}
+	if opts.graphqlAddr != "" {
+		endpoints["graphql"] = "http://" + listening[opts.graphqlAddr] + "/graphql"
+	}
+	if jsonOutput {
+		outputJSON(endpoints)
+	} else {
+		for _, name := range []struct{ key, label string }{
+			{"grpc", "gRPC"}, {"rest", "REST"}, {"openapi", "OpenAPI"}, {"graphql", "GraphQL"},
+		} {
+			if addr, ok := endpoints[name.key]; ok {
+				fmt.Fprintf(os.Stderr, "Serving %s on %s\n", name.label, addr)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Press Ctrl+C to stop\n")
+	}
+
+	var g errgroup.Group
+	if grpcSrv != nil {
+		g.Go(func() error {
+			defer cancel()
+			return grpcSrv.Serve(grpcLis)
+		})
+	}
+	for _, h := range httpServers {
+		g.Go(func() error {
+			defer cancel()
+			if err := h.srv.Serve(h.lis); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "\nStopped serving.\n")
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/rbac"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// serveAuth checks the API tokens (bd token) that bd serve callers present.
+// Writes always need a token whose role allows them; reads need one only
+// when rbac.require-token is set.
+type serveAuth struct {
+	tokens   storage.TokenStore // nil when the backend has no token support
+	required bool
+}
+
+// serveActorKey carries the authenticated token's name through a request.
+type serveActorKey struct{}
+
+func newServeAuth(st storage.DoltStorage, required bool) *serveAuth {
+	a := &serveAuth{required: required}
+	if st != nil {
+		a.tokens, _ = storage.UnwrapStore(st).(storage.TokenStore)
+	}
+	return a
+}
+
+// authorize checks the bearer token raw for an operation needing perm and
+// returns ctx carrying the token's name as the actor. Failures are gRPC
+// statuses: Unauthenticated for a missing or invalid token, PermissionDenied
+// for a role that does not allow perm.
+func (a *serveAuth) authorize(ctx context.Context, raw string, perm rbac.Permission, what string) (context.Context, error) {
+	if raw == "" {
+		if perm == rbac.PermRead && !a.required {
+			return ctx, nil
+		}
+		reason := "an API token is required; send Authorization: Bearer <token> (see bd token create)"
+		if perm == rbac.PermRead {
+			reason = "this workspace requires an API token (rbac.require-token); send Authorization: Bearer <token>"
+		}
+		recordAccess(what, nil, perm, false, reason)
+		return ctx, status.Error(codes.Unauthenticated, reason)
+	}
+	if a.tokens == nil {
+		return ctx, status.Error(codes.Unimplemented, "API tokens are not supported by this storage backend")
+	}
+	tok, err := authorizeToken(ctx, a.tokens, raw, perm, what)
+	if err != nil {
+		if tok != nil {
+			return ctx, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, serveActorKey{}, tok.Name), nil
+}
+
+// requireRead guards a read-only HTTP handler such as GraphQL: it needs a
+// token only when rbac.require-token is set.
+func (a *serveAuth) requireRead(what string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := a.authorize(r.Context(), bearerToken(r.Header.Get("Authorization")), rbac.PermRead, what)
+		if err != nil {
+			writeRESTError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// grpcWriteMethods are the gRPC methods that change issues. Every other
+// method is a read.
+var grpcWriteMethods = map[string]bool{
+	beadsv1.Beads_CreateIssue_FullMethodName:      true,
+	beadsv1.Beads_UpdateIssue_FullMethodName:      true,
+	beadsv1.Beads_CloseIssue_FullMethodName:       true,
+	beadsv1.Beads_AddDependency_FullMethodName:    true,
+	beadsv1.Beads_RemoveDependency_FullMethodName: true,
+}
+
+// grpcMethodPermission is the permission a gRPC method needs.
+func grpcMethodPermission(fullMethod string) rbac.Permission {
+	if grpcWriteMethods[fullMethod] {
+		return rbac.PermIssueWrite
+	}
+	return rbac.PermRead
+}
+
+// unaryInterceptor authorizes each unary call from the token in its
+// "authorization: Bearer <token>" metadata and hands the handler a context
+// carrying the token's name as the actor.
+func (a *serveAuth) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, grpcBearerToken(ctx), grpcMethodPermission(info.FullMethod), "serve "+path.Base(info.FullMethod))
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor authorizes streaming calls (WatchEvents), which are
+// reads.
+func (a *serveAuth) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if _, err := a.authorize(ss.Context(), grpcBearerToken(ss.Context()), grpcMethodPermission(info.FullMethod), "serve "+path.Base(info.FullMethod)); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// grpcBearerToken extracts the token from a call's authorization metadata.
+func grpcBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, v := range md.Get("authorization") {
+		if tok := bearerToken(v); tok != "" {
+			return tok
+		}
+	}
+	return ""
+}
+
+// serveActor returns the actor authenticated for ctx, if any.
+func serveActor(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(serveActorKey{}).(string)
+	return name, ok
+}
+
+// bearerToken extracts the token from an Authorization header value.
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// checkServeBind refuses to listen on addr beyond the loopback interface
+// unless every request must carry a token, so reads cannot leak to other
+// hosts.
+func checkServeBind(addr string, requireToken bool) error {
+	if requireToken || isLoopbackAddr(addr) {
+		return nil
+	}
+	return fmt.Errorf("refusing to serve on %s without rbac.require-token: bind to localhost, or set rbac.require-token: true so every request needs an API token", addr)
+}
+
+// isLoopbackAddr reports whether a host:port listen address binds only the
+// loopback interface. An empty host binds every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/rbac"
+)
+
+func TestServeAuthorizeSetsActor(t *testing.T) {
+	t.Chdir(t.TempDir()) // keep access entries out of the repo's audit log
+	tokens := memTokenStore{}
+	agent := tokens.addToken(t, "triage-bot", rbac.RoleAgent)
+	a := &serveAuth{tokens: tokens}
+	s := &grpcServer{actor: "server", auth: a}
+
+	ctx, err := a.authorize(context.Background(), agent, rbac.PermIssueWrite, "serve closeIssue")
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if got := s.actorFor(ctx, "someone-else"); got != "triage-bot" {
+		t.Errorf("actorFor = %q, want the token's name", got)
+	}
+	if got := s.actorFor(context.Background(), ""); got != "server" {
+		t.Errorf("unauthenticated actorFor = %q, want server", got)
+	}
+
+	if _, err := a.authorize(context.Background(), "", rbac.PermIssueWrite, "serve closeIssue"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("tokenless write: %v, want Unauthenticated", err)
+	}
+	if _, err := (&serveAuth{}).authorize(context.Background(), agent, rbac.PermRead, "serve getIssue"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("no token store: %v, want Unimplemented", err)
+	}
+}
+
+func TestGRPCUnaryInterceptor(t *testing.T) {
+	t.Chdir(t.TempDir())
+	tokens := memTokenStore{}
+	reader := tokens.addToken(t, "dashboard", rbac.RoleReader)
+	agent := tokens.addToken(t, "triage-bot", rbac.RoleAgent)
+	a := &serveAuth{tokens: tokens}
+	s := &grpcServer{actor: "server", auth: a}
+
+	call := func(method, token string) (string, error) {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		var actor string
+		_, err := a.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ any) (any, error) {
+			actor = s.actorFor(ctx, "payload-actor")
+			return nil, nil
+		})
+		return actor, err
+	}
+
+	if _, err := call(beadsv1.Beads_CloseIssue_FullMethodName, ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("tokenless close: %v, want Unauthenticated", err)
+	}
+	if _, err := call(beadsv1.Beads_UpdateIssue_FullMethodName, reader); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("reader update: %v, want PermissionDenied", err)
+	}
+	if actor, err := call(beadsv1.Beads_UpdateIssue_FullMethodName, agent); err != nil || actor != "triage-bot" {
+		t.Errorf("agent update: actor=%q err=%v, want triage-bot", actor, err)
+	}
+	if _, err := call(beadsv1.Beads_GetIssue_FullMethodName, ""); err != nil {
+		t.Errorf("tokenless read: %v", err)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]string{
+		"Bearer bdt_a_b":   "bdt_a_b",
+		"bearer  bdt_a_b ": "bdt_a_b",
+		"Basic dXNlcjpw":   "",
+		"bdt_a_b":          "",
+		"":                 "",
+	}
+	for header, want := range tests {
+		if got := bearerToken(header); got != want {
+			t.Errorf("bearerToken(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestCheckServeBind(t *testing.T) {
+	tests := []struct {
+		addr     string
+		required bool
+		ok       bool
+	}{
+		{"localhost:7420", false, true},
+		{"127.0.0.1:7420", false, true},
+		{"[::1]:7420", false, true},
+		{":7420", false, false},
+		{"0.0.0.0:7420", false, false},
+		{"10.0.0.5:7420", false, false},
+		{":7420", true, true},
+		{"0.0.0.0:7420", true, true},
+	}
+	for _, tt := range tests {
+		if err := checkServeBind(tt.addr, tt.required); (err == nil) != tt.ok {
+			t.Errorf("checkServeBind(%q, %v) = %v, want ok=%v", tt.addr, tt.required, err, tt.ok)
+		}
+	}
+}
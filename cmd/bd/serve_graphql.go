@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// graphqlSchema is the read-only GraphQL schema served by bd serve --graphql.
+// Edges are resolved lazily, so a query only pays for the fields it asks for.
+const graphqlSchema = `
+schema {
+  query: Query
+}
+
+scalar Time
+
+type Query {
+  # One issue by ID, or null if it does not exist.
+  issue(id: ID!): Issue
+  # Issues matching a filter, like bd list. Closed issues are included
+  # unless status is given.
+  issues(query: String, status: [String!], type: String, assignee: String, labels: [String!], limit: Int): [Issue!]!
+  # Open issues with no open blockers, like bd ready.
+  ready(type: String, assignee: String, labels: [String!], limit: Int): [Issue!]!
+}
+
+type Issue {
+  id: ID!
+  title: String!
+  description: String!
+  design: String!
+  acceptanceCriteria: String!
+  notes: String!
+  status: String!
+  priority: Int!
+  issueType: String!
+  assignee: String
+  owner: String
+  createdBy: String
+  createdAt: Time!
+  updatedAt: Time!
+  closedAt: Time
+  closeReason: String
+  dueAt: Time
+  deferUntil: Time
+  externalRef: String
+  pinned: Boolean!
+  ephemeral: Boolean!
+  labels: [String!]!
+  comments: [Comment!]!
+  # Issues this issue depends on, optionally limited to one dependency type.
+  dependencies(type: String): [Edge!]!
+  # Issues that depend on this issue, optionally limited to one dependency type.
+  dependents(type: String): [Edge!]!
+  # Issues blocking this one (blocks, conditional-blocks, waits-for).
+  blockers(openOnly: Boolean = false): [Issue!]!
+  # Issues this one blocks.
+  blocking(openOnly: Boolean = false): [Issue!]!
+  parent: Issue
+  children: [Issue!]!
+}
+
+# Edge is one dependency seen from the issue it was resolved on; issue is the
+# other end.
+type Edge {
+  type: String!
+  issue: Issue!
+}
+
+type Comment {
+  id: ID!
+  author: String!
+  text: String!
+  createdAt: Time!
+}
+`
+
+// graphqlMaxDepth bounds query nesting so one request cannot walk the whole
+// dependency graph through blockers { blockers { ... } }.
+const graphqlMaxDepth = 12
+
+// newGraphQLSchema parses the schema against a resolver backed by st.
+func newGraphQLSchema(st storage.DoltStorage) (*graphql.Schema, error) {
+	return graphql.ParseSchema(graphqlSchema, &graphqlResolver{store: st}, graphql.MaxDepth(graphqlMaxDepth))
+}
+
+// graphqlResolver resolves the Query type.
+type graphqlResolver struct {
+	store storage.DoltStorage
+}
+
+func (r *graphqlResolver) Issue(ctx context.Context, args struct{ ID graphql.ID }) (*issueResolver, error) {
+	issue, err := r.store.GetIssue(ctx, string(args.ID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.wrap(issue), nil
+}
+
+func (r *graphqlResolver) Issues(ctx context.Context, args struct {
+	Query    *string
+	Status   *[]string
+	Type     *string
+	Assignee *string
+	Labels   *[]string
+	Limit    *int32
+}) ([]*issueResolver, error) {
+	var filter types.IssueFilter
+	if args.Status != nil {
+		for _, st := range *args.Status {
+			filter.Statuses = append(filter.Statuses, types.Status(st))
+		}
+	}
+	if args.Type != nil {
+		it := types.IssueType(*args.Type)
+		filter.IssueType = &it
+	}
+	filter.Assignee = args.Assignee
+	if args.Labels != nil {
+		filter.Labels = *args.Labels
+	}
+	if args.Limit != nil {
+		filter.Limit = int(*args.Limit)
+	}
+	query := ""
+	if args.Query != nil {
+		query = *args.Query
+	}
+	issues, err := r.store.SearchIssues(ctx, query, filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.wrapAll(issues), nil
+}
+
+func (r *graphqlResolver) Ready(ctx context.Context, args struct {
+	Type     *string
+	Assignee *string
+	Labels   *[]string
+	Limit    *int32
+}) ([]*issueResolver, error) {
+	filter := types.WorkFilter{Assignee: args.Assignee}
+	if args.Type != nil {
+		filter.Type = *args.Type
+	}
+	if args.Labels != nil {
+		filter.Labels = *args.Labels
+	}
+	if args.Limit != nil {
+		filter.Limit = int(*args.Limit)
+	}
+	issues, err := r.store.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.wrapAll(issues), nil
+}
+
+func (r *graphqlResolver) wrap(issue *types.Issue) *issueResolver {
+	return &issueResolver{issue: issue, store: r.store}
+}
+
+func (r *graphqlResolver) wrapAll(issues []*types.Issue) []*issueResolver {
+	out := make([]*issueResolver, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, r.wrap(issue))
+	}
+	return out
+}
+
+// issueResolver resolves the Issue type. Scalar fields come from the loaded
+// issue; edges are read from the store when asked for.
+type issueResolver struct {
+	issue *types.Issue
+	store storage.DoltStorage
+}
+
+func (r *issueResolver) ID() graphql.ID             { return graphql.ID(r.issue.ID) }
+func (r *issueResolver) Title() string              { return r.issue.Title }
+func (r *issueResolver) Description() string        { return r.issue.Description }
+func (r *issueResolver) Design() string             { return r.issue.Design }
+func (r *issueResolver) AcceptanceCriteria() string { return r.issue.AcceptanceCriteria }
+func (r *issueResolver) Notes() string              { return r.issue.Notes }
+func (r *issueResolver) Status() string             { return string(r.issue.Status) }
+func (r *issueResolver) IssueType() string          { return string(r.issue.IssueType) }
+func (r *issueResolver) Assignee() *string          { return optionalString(r.issue.Assignee) }
+func (r *issueResolver) Owner() *string             { return optionalString(r.issue.Owner) }
+func (r *issueResolver) CreatedBy() *string         { return optionalString(r.issue.CreatedBy) }
+func (r *issueResolver) CloseReason() *string       { return optionalString(r.issue.CloseReason) }
+func (r *issueResolver) Pinned() bool               { return r.issue.Pinned }
+func (r *issueResolver) Ephemeral() bool            { return r.issue.Ephemeral }
+func (r *issueResolver) CreatedAt() graphql.Time    { return graphql.Time{Time: r.issue.CreatedAt} }
+func (r *issueResolver) UpdatedAt() graphql.Time    { return graphql.Time{Time: r.issue.UpdatedAt} }
+func (r *issueResolver) ClosedAt() *graphql.Time    { return optionalTime(r.issue.ClosedAt) }
+func (r *issueResolver) DueAt() *graphql.Time       { return optionalTime(r.issue.DueAt) }
+func (r *issueResolver) DeferUntil() *graphql.Time  { return optionalTime(r.issue.DeferUntil) }
+
+func (r *issueResolver) Priority() int32 {
+	return int32(r.issue.Priority) // #nosec G115 -- priorities are 0-4
+}
+
+func (r *issueResolver) ExternalRef() *string {
+	if r.issue.ExternalRef == nil {
+		return nil
+	}
+	return optionalString(*r.issue.ExternalRef)
+}
+
+func (r *issueResolver) Labels(ctx context.Context) ([]string, error) {
+	labels, err := r.store.GetLabels(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+	return labels, nil
+}
+
+func (r *issueResolver) Comments(ctx context.Context) ([]*commentResolver, error) {
+	comments, err := r.store.GetIssueComments(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*commentResolver, 0, len(comments))
+	for _, c := range comments {
+		out = append(out, &commentResolver{c})
+	}
+	return out, nil
+}
+
+func (r *issueResolver) Dependencies(ctx context.Context, args struct{ Type *string }) ([]*edgeResolver, error) {
+	deps, err := r.store.GetDependenciesWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.edges(deps, args.Type), nil
+}
+
+func (r *issueResolver) Dependents(ctx context.Context, args struct{ Type *string }) ([]*edgeResolver, error) {
+	deps, err := r.store.GetDependentsWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.edges(deps, args.Type), nil
+}
+
+func (r *issueResolver) Blockers(ctx context.Context, args struct{ OpenOnly bool }) ([]*issueResolver, error) {
+	deps, err := r.store.GetDependenciesWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.blockingEdges(deps, args.OpenOnly), nil
+}
+
+func (r *issueResolver) Blocking(ctx context.Context, args struct{ OpenOnly bool }) ([]*issueResolver, error) {
+	deps, err := r.store.GetDependentsWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return r.blockingEdges(deps, args.OpenOnly), nil
+}
+
+func (r *issueResolver) Parent(ctx context.Context) (*issueResolver, error) {
+	deps, err := r.store.GetDependenciesWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range deps {
+		if dep.DependencyType == types.DepParentChild {
+			return r.wrap(&dep.Issue), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *issueResolver) Children(ctx context.Context) ([]*issueResolver, error) {
+	deps, err := r.store.GetDependentsWithMetadata(ctx, r.issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := []*issueResolver{}
+	for _, dep := range deps {
+		if dep.DependencyType == types.DepParentChild {
+			out = append(out, r.wrap(&dep.Issue))
+		}
+	}
+	return out, nil
+}
+
+func (r *issueResolver) wrap(issue *types.Issue) *issueResolver {
+	return &issueResolver{issue: issue, store: r.store}
+}
+
+// edges converts dependency rows, keeping only depType when it is set.
+func (r *issueResolver) edges(deps []*types.IssueWithDependencyMetadata, depType *string) []*edgeResolver {
+	out := []*edgeResolver{}
+	for _, dep := range deps {
+		if depType != nil && string(dep.DependencyType) != *depType {
+			continue
+		}
+		out = append(out, &edgeResolver{depType: dep.DependencyType, issue: r.wrap(&dep.Issue)})
+	}
+	return out
+}
+
+// blockingEdges returns the far ends of the hard-blocking edges in deps.
+func (r *issueResolver) blockingEdges(deps []*types.IssueWithDependencyMetadata, openOnly bool) []*issueResolver {
+	out := []*issueResolver{}
+	for _, dep := range deps {
+		if !dep.DependencyType.IsBlockingEdge() {
+			continue
+		}
+		if openOnly && dep.Status == types.StatusClosed {
+			continue
+		}
+		out = append(out, r.wrap(&dep.Issue))
+	}
+	return out
+}
+
+// edgeResolver resolves the Edge type.
+type edgeResolver struct {
+	depType types.DependencyType
+	issue   *issueResolver
+}
+
+func (r *edgeResolver) Type() string          { return string(r.depType) }
+func (r *edgeResolver) Issue() *issueResolver { return r.issue }
+
+// commentResolver resolves the Comment type.
+type commentResolver struct {
+	comment *types.Comment
+}
+
+func (r *commentResolver) ID() graphql.ID          { return graphql.ID(r.comment.ID) }
+func (r *commentResolver) Author() string          { return r.comment.Author }
+func (r *commentResolver) Text() string            { return r.comment.Text }
+func (r *commentResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.comment.CreatedAt} }
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
@@ -0,0 +1,122 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGraphQLSchemaParses(t *testing.T) {
+	if _, err := newGraphQLSchema(nil); err != nil {
+		t.Fatalf("newGraphQLSchema: %v", err)
+	}
+}
+
+func TestGraphQLNestedQuery(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s := newTestStoreWithPrefix(t, filepath.Join(t.TempDir(), ".beads", "beads.db"), "gq")
+
+	create := func(title string, issueType types.IssueType, status types.Status) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: issueType, CreatedAt: time.Now()}
+		if status == types.StatusClosed {
+			now := time.Now()
+			issue.ClosedAt = &now
+		}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", title, err)
+		}
+		return issue
+	}
+	link := func(from, to *types.Issue, depType types.DependencyType) {
+		t.Helper()
+		dep := &types.Dependency{IssueID: from.ID, DependsOnID: to.ID, Type: depType}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency: %v", err)
+		}
+	}
+
+	epic := create("Epic", types.TypeEpic, types.StatusOpen)
+	child := create("Child", types.TypeTask, types.StatusOpen)
+	openBlocker := create("Open blocker", types.TypeBug, types.StatusOpen)
+	doneBlocker := create("Done blocker", types.TypeTask, types.StatusClosed)
+	link(child, epic, types.DepParentChild)
+	link(child, openBlocker, types.DepBlocks)
+	link(child, doneBlocker, types.DepBlocks)
+	if err := s.AddLabel(ctx, child.ID, "api", "test"); err != nil {
+		t.Fatalf("AddLabel: %v", err)
+	}
+
+	schema, err := newGraphQLSchema(s)
+	if err != nil {
+		t.Fatalf("newGraphQLSchema: %v", err)
+	}
+	query := `query($id: ID!) {
+		issue(id: $id) {
+			title
+			children {
+				id
+				labels
+				parent { id }
+				all: blockers { id }
+				open: blockers(openOnly: true) { title }
+				dependencies(type: "blocks") { type issue { id } }
+			}
+		}
+		missing: issue(id: "gq-nope") { id }
+	}`
+	resp := schema.Exec(ctx, query, "", map[string]any{"id": epic.ID})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("query errors: %v", resp.Errors)
+	}
+
+	var got struct {
+		Issue struct {
+			Title    string
+			Children []struct {
+				ID     string
+				Labels []string
+				Parent struct{ ID string }
+				All    []struct{ ID string }
+				Open   []struct{ Title string }
+				Deps   []struct {
+					Type  string
+					Issue struct{ ID string }
+				} `json:"dependencies"`
+			}
+		}
+		Missing *struct{ ID string }
+	}
+	if err := json.Unmarshal(resp.Data, &got); err != nil {
+		t.Fatalf("unmarshal %s: %v", resp.Data, err)
+	}
+	if got.Missing != nil {
+		t.Errorf("missing issue resolved to %+v, want null", got.Missing)
+	}
+	if len(got.Issue.Children) != 1 {
+		t.Fatalf("children = %+v, want one child", got.Issue.Children)
+	}
+	c := got.Issue.Children[0]
+	if c.ID != child.ID || c.Parent.ID != epic.ID {
+		t.Errorf("child %s parent %s, want %s parent %s", c.ID, c.Parent.ID, child.ID, epic.ID)
+	}
+	if len(c.Labels) != 1 || c.Labels[0] != "api" {
+		t.Errorf("labels = %v, want [api]", c.Labels)
+	}
+	if len(c.All) != 2 {
+		t.Errorf("blockers = %v, want 2", c.All)
+	}
+	if len(c.Open) != 1 || c.Open[0].Title != "Open blocker" {
+		t.Errorf("open blockers = %v, want only the open blocker", c.Open)
+	}
+	if len(c.Deps) != 2 {
+		t.Errorf("blocks dependencies = %v, want 2 (parent-child filtered out)", c.Deps)
+	}
+}
@@ -0,0 +1,566 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// eventOverlap is how far back each WatchEvents poll re-reads the event log.
+// Event timestamps are not strictly increasing across concurrent writers, so
+// an event can land with a created_at at or just before the cursor; the
+// overlap catches it and the seen set keeps it from being sent twice.
+const eventOverlap = 2 * time.Second
+
+// grpcServer implements beadsv1.BeadsServer on top of a bd store.
+type grpcServer struct {
+	beadsv1.UnimplementedBeadsServer
+
+	store        storage.DoltStorage
+	actor        string
+	readonly     bool
+	pollInterval time.Duration
+	auth         *serveAuth
+
+	// writeMu serializes writes so each one is committed on its own with the
+	// right message, as if it were a separate bd invocation.
+	writeMu sync.Mutex
+
+	// done is closed on shutdown to end open WatchEvents streams, which
+	// would otherwise hold up a graceful stop forever.
+	done chan struct{}
+}
+
+func newGRPCServer(st storage.DoltStorage, actor string, pollInterval time.Duration) *grpcServer {
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	return &grpcServer{store: st, actor: actor, pollInterval: pollInterval, auth: newServeAuth(st, false), done: make(chan struct{})}
+}
+
+// shutdown ends open WatchEvents streams.
+func (s *grpcServer) shutdown() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+func (s *grpcServer) GetIssue(ctx context.Context, req *beadsv1.GetIssueRequest) (*beadsv1.Issue, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	return s.loadIssue(ctx, req.GetId())
+}
+
+func (s *grpcServer) ListIssues(ctx context.Context, req *beadsv1.ListIssuesRequest) (*beadsv1.ListIssuesResponse, error) {
+	filter := types.IssueFilter{Labels: req.GetLabels(), Limit: int(req.GetLimit())}
+	for _, st := range req.GetStatuses() {
+		filter.Statuses = append(filter.Statuses, types.Status(st))
+	}
+	if t := req.GetIssueType(); t != "" {
+		it := types.IssueType(t)
+		filter.IssueType = &it
+	}
+	if a := req.GetAssignee(); a != "" {
+		filter.Assignee = &a
+	}
+	if req.Priority != nil {
+		p := int(req.GetPriority())
+		filter.Priority = &p
+	}
+	issues, err := s.store.SearchIssues(ctx, req.GetQuery(), filter)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return s.issueList(ctx, issues)
+}
+
+func (s *grpcServer) ReadyWork(ctx context.Context, req *beadsv1.ReadyWorkRequest) (*beadsv1.ListIssuesResponse, error) {
+	filter := types.WorkFilter{
+		Type:       req.GetIssueType(),
+		Unassigned: req.GetUnassigned(),
+		Labels:     req.GetLabels(),
+		Limit:      int(req.GetLimit()),
+	}
+	if a := req.GetAssignee(); a != "" {
+		filter.Assignee = &a
+	}
+	if req.Priority != nil {
+		p := int(req.GetPriority())
+		filter.Priority = &p
+	}
+	if parent := req.GetParentId(); parent != "" {
+		filter.ParentID = &parent
+	}
+	issues, err := s.store.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return s.issueList(ctx, issues)
+}
+
+func (s *grpcServer) CreateIssue(ctx context.Context, req *beadsv1.CreateIssueRequest) (*beadsv1.Issue, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetTitle()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	actor := s.actorFor(ctx, req.GetActor())
+	issue := &types.Issue{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+		Assignee:    req.GetAssignee(),
+		CreatedBy:   actor,
+	}
+	if req.Priority != nil {
+		issue.Priority = int(req.GetPriority())
+	}
+	if t := req.GetIssueType(); t != "" {
+		issue.IssueType = types.IssueType(t)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	err := s.store.RunInTransaction(ctx, "bd: serve create", func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+			return err
+		}
+		for _, label := range req.GetLabels() {
+			if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+				return err
+			}
+		}
+		if parent := req.GetParentId(); parent != "" {
+			dep := &types.Dependency{IssueID: issue.ID, DependsOnID: parent, Type: types.DepParentChild}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.commit(ctx, actor, "create", issue.ID); err != nil {
+		return nil, err
+	}
+	return s.loadIssue(ctx, issue.ID)
+}
+
+func (s *grpcServer) UpdateIssue(ctx context.Context, req *beadsv1.UpdateIssueRequest) (*beadsv1.Issue, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	updates := map[string]interface{}{}
+	if req.Title != nil {
+		if strings.TrimSpace(req.GetTitle()) == "" {
+			return nil, status.Error(codes.InvalidArgument, "title cannot be empty")
+		}
+		updates["title"] = req.GetTitle()
+	}
+	if req.Description != nil {
+		updates["description"] = req.GetDescription()
+	}
+	if req.Status != nil {
+		updates["status"] = req.GetStatus()
+	}
+	if req.Priority != nil {
+		updates["priority"] = int(req.GetPriority())
+	}
+	if req.Assignee != nil {
+		updates["assignee"] = req.GetAssignee()
+	}
+	if req.Notes != nil {
+		updates["notes"] = req.GetNotes()
+	}
+	if len(updates) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+	}
+
+	actor := s.actorFor(ctx, req.GetActor())
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.store.UpdateIssue(ctx, req.GetId(), updates, actor); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.commit(ctx, actor, "update", req.GetId()); err != nil {
+		return nil, err
+	}
+	return s.loadIssue(ctx, req.GetId())
+}
+
+func (s *grpcServer) CloseIssue(ctx context.Context, req *beadsv1.CloseIssueRequest) (*beadsv1.Issue, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	reason := req.GetReason()
+	if reason == "" {
+		reason = "Closed"
+	}
+	actor := s.actorFor(ctx, req.GetActor())
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.store.CloseIssue(ctx, req.GetId(), reason, actor, ""); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.commit(ctx, actor, "close", req.GetId()); err != nil {
+		return nil, err
+	}
+	return s.loadIssue(ctx, req.GetId())
+}
+
+func (s *grpcServer) AddDependency(ctx context.Context, req *beadsv1.AddDependencyRequest) (*beadsv1.Dependency, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	if req.GetIssueId() == "" || req.GetDependsOnId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "issue_id and depends_on_id are required")
+	}
+	depType := types.DependencyType(req.GetType())
+	if depType == "" {
+		depType = types.DepBlocks
+	}
+	if !depType.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid dependency type %q", depType)
+	}
+	actor := s.actorFor(ctx, req.GetActor())
+	dep := &types.Dependency{IssueID: req.GetIssueId(), DependsOnID: req.GetDependsOnId(), Type: depType, CreatedBy: actor}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.store.AddDependency(ctx, dep, actor); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.commit(ctx, actor, "dep add", dep.IssueID, dep.DependsOnID); err != nil {
+		return nil, err
+	}
+	if dep.CreatedAt.IsZero() {
+		dep.CreatedAt = time.Now()
+	}
+	return protoDependency(dep), nil
+}
+
+func (s *grpcServer) RemoveDependency(ctx context.Context, req *beadsv1.RemoveDependencyRequest) (*beadsv1.RemoveDependencyResponse, error) {
+	if err := s.checkWritable(); err != nil {
+		return nil, err
+	}
+	if req.GetIssueId() == "" || req.GetDependsOnId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "issue_id and depends_on_id are required")
+	}
+	actor := s.actorFor(ctx, req.GetActor())
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.store.RemoveDependency(ctx, req.GetIssueId(), req.GetDependsOnId(), actor); err != nil {
+		return nil, grpcError(err)
+	}
+	if err := s.commit(ctx, actor, "dep remove", req.GetIssueId(), req.GetDependsOnId()); err != nil {
+		return nil, err
+	}
+	return &beadsv1.RemoveDependencyResponse{}, nil
+}
+
+func (s *grpcServer) ListDependencies(ctx context.Context, req *beadsv1.ListDependenciesRequest) (*beadsv1.ListDependenciesResponse, error) {
+	if req.GetIssueId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "issue_id is required")
+	}
+	if _, err := s.store.GetIssue(ctx, req.GetIssueId()); err != nil {
+		return nil, grpcError(err)
+	}
+	deps, err := s.store.GetDependencyRecords(ctx, req.GetIssueId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &beadsv1.ListDependenciesResponse{}
+	for _, dep := range deps {
+		resp.Dependencies = append(resp.Dependencies, protoDependency(dep))
+	}
+	return resp, nil
+}
+
+// WatchEvents polls the event log every pollInterval and sends whatever is
+// new. Polling happens server-side and only while a client is listening.
+func (s *grpcServer) WatchEvents(req *beadsv1.WatchEventsRequest, stream beadsv1.Beads_WatchEventsServer) error {
+	ctx := stream.Context()
+	filter := newEventFilter(req)
+	var cursor *eventCursor
+	if req.GetSince() != nil {
+		cursor = newEventCursor(req.GetSince().AsTime())
+	} else {
+		// Start from now: read the overlap window once and mark it seen so
+		// the first poll does not replay events from before the call.
+		cursor = newEventCursor(time.Now())
+		events, err := s.store.GetAllEventsSince(ctx, cursor.queryFrom())
+		if err != nil {
+			return grpcError(err)
+		}
+		cursor.next(events)
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := s.store.GetAllEventsSince(ctx, cursor.queryFrom())
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return grpcError(err)
+		}
+		for _, e := range cursor.next(events) {
+			if !filter.match(e) {
+				continue
+			}
+			if err := stream.Send(protoEvent(e)); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// eventCursor tracks how far a WatchEvents stream has read the event log.
+type eventCursor struct {
+	since time.Time
+	seen  map[string]time.Time
+}
+
+func newEventCursor(since time.Time) *eventCursor {
+	return &eventCursor{since: since, seen: map[string]time.Time{}}
+}
+
+// queryFrom is the time the next poll should read events after.
+func (c *eventCursor) queryFrom() time.Time {
+	if c.since.IsZero() {
+		return c.since
+	}
+	return c.since.Add(-eventOverlap)
+}
+
+// next returns the events not returned before, in log order, and advances
+// the cursor past them.
+func (c *eventCursor) next(events []*types.Event) []*types.Event {
+	var fresh []*types.Event
+	for _, e := range events {
+		if _, ok := c.seen[e.ID]; ok {
+			continue
+		}
+		c.seen[e.ID] = e.CreatedAt
+		fresh = append(fresh, e)
+		if e.CreatedAt.After(c.since) {
+			c.since = e.CreatedAt
+		}
+	}
+	// Only events inside the overlap window can be read again.
+	horizon := c.queryFrom()
+	for id, at := range c.seen {
+		if at.Before(horizon) {
+			delete(c.seen, id)
+		}
+	}
+	return fresh
+}
+
+// eventFilter applies a WatchEventsRequest's issue and type filters.
+type eventFilter struct {
+	issueID string
+	types   []string
+}
+
+func newEventFilter(req *beadsv1.WatchEventsRequest) eventFilter {
+	return eventFilter{issueID: req.GetIssueId(), types: req.GetEventTypes()}
+}
+
+func (f eventFilter) match(e *types.Event) bool {
+	if f.issueID != "" && e.IssueID != f.issueID {
+		return false
+	}
+	return len(f.types) == 0 || slices.Contains(f.types, string(e.EventType))
+}
+
+func (s *grpcServer) checkWritable() error {
+	if s.readonly {
+		return status.Error(codes.PermissionDenied, "server is in read-only mode")
+	}
+	return nil
+}
+
+// actorFor returns who a write is attributed to: the authenticated token's
+// name when there is one, otherwise the requested actor or the server's.
+func (s *grpcServer) actorFor(ctx context.Context, requested string) string {
+	if name, ok := serveActor(ctx); ok {
+		return name
+	}
+	if requested != "" {
+		return requested
+	}
+	return s.actor
+}
+
+// commit records a write the way the matching bd command would.
+func (s *grpcServer) commit(ctx context.Context, actor, command string, ids ...string) error {
+	commandDidWrite.Store(true)
+	if err := commitPendingIfEmbedded(ctx, s.store, actor, doltAutoCommitParams{Command: command, IssueIDs: ids}); err != nil {
+		return status.Errorf(codes.Internal, "write succeeded but commit failed: %v", err)
+	}
+	return nil
+}
+
+// loadIssue returns the issue with its labels and dependencies.
+func (s *grpcServer) loadIssue(ctx context.Context, id string) (*beadsv1.Issue, error) {
+	issue, err := s.store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	if issue.Labels, err = s.store.GetLabels(ctx, id); err != nil {
+		return nil, grpcError(err)
+	}
+	if issue.Dependencies, err = s.store.GetDependencyRecords(ctx, id); err != nil {
+		return nil, grpcError(err)
+	}
+	return protoIssue(issue), nil
+}
+
+// issueList converts issues, loading labels and dependencies in bulk.
+func (s *grpcServer) issueList(ctx context.Context, issues []*types.Issue) (*beadsv1.ListIssuesResponse, error) {
+	resp := &beadsv1.ListIssuesResponse{}
+	if len(issues) == 0 {
+		return resp, nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := s.store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	deps, err := s.store.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+		issue.Dependencies = deps[issue.ID]
+		resp.Issues = append(resp.Issues, protoIssue(issue))
+	}
+	return resp, nil
+}
+
+// grpcError maps storage errors to gRPC status codes. Errors without a
+// clear mapping keep their message and get codes.Unknown.
+func grpcError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrIssueLocked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func protoIssue(issue *types.Issue) *beadsv1.Issue {
+	out := &beadsv1.Issue{
+		Id:                 issue.ID,
+		Title:              issue.Title,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+		Status:             string(issue.Status),
+		Priority:           int32(issue.Priority), // #nosec G115 -- priorities are 0-4
+		IssueType:          string(issue.IssueType),
+		Assignee:           issue.Assignee,
+		Owner:              issue.Owner,
+		CreatedBy:          issue.CreatedBy,
+		CreatedAt:          protoTime(&issue.CreatedAt),
+		UpdatedAt:          protoTime(&issue.UpdatedAt),
+		ClosedAt:           protoTime(issue.ClosedAt),
+		CloseReason:        issue.CloseReason,
+		DueAt:              protoTime(issue.DueAt),
+		DeferUntil:         protoTime(issue.DeferUntil),
+		Ephemeral:          issue.Ephemeral,
+		Pinned:             issue.Pinned,
+		Labels:             issue.Labels,
+	}
+	if issue.ExternalRef != nil {
+		out.ExternalRef = *issue.ExternalRef
+	}
+	if len(issue.Metadata) > 0 && string(issue.Metadata) != "null" && json.Valid(issue.Metadata) {
+		out.Metadata = string(issue.Metadata)
+	}
+	for _, dep := range issue.Dependencies {
+		out.Dependencies = append(out.Dependencies, protoDependency(dep))
+	}
+	return out
+}
+
+func protoDependency(dep *types.Dependency) *beadsv1.Dependency {
+	return &beadsv1.Dependency{
+		IssueId:     dep.IssueID,
+		DependsOnId: dep.DependsOnID,
+		Type:        string(dep.Type),
+		CreatedAt:   protoTime(&dep.CreatedAt),
+		CreatedBy:   dep.CreatedBy,
+	}
+}
+
+func protoEvent(e *types.Event) *beadsv1.Event {
+	return &beadsv1.Event{
+		Id:        e.ID,
+		IssueId:   e.IssueID,
+		EventType: string(e.EventType),
+		Actor:     e.Actor,
+		OldValue:  e.OldValue,
+		NewValue:  e.NewValue,
+		Comment:   e.Comment,
+		CreatedAt: protoTime(&e.CreatedAt),
+	}
+}
+
+// protoTime converts t, leaving nil and zero times unset.
+func protoTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
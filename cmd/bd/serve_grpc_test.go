@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEventCursorNext(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ev := func(id string, offset time.Duration) *types.Event {
+		return &types.Event{ID: id, CreatedAt: base.Add(offset)}
+	}
+
+	c := newEventCursor(base)
+	if got := c.queryFrom(); !got.Equal(base.Add(-eventOverlap)) {
+		t.Fatalf("queryFrom() = %v, want %v", got, base.Add(-eventOverlap))
+	}
+
+	first := c.next([]*types.Event{ev("e1", time.Second), ev("e2", 2*time.Second)})
+	if len(first) != 2 {
+		t.Fatalf("first poll returned %d events, want 2", len(first))
+	}
+
+	// The overlap re-reads e2; a late writer lands e3 just before it.
+	second := c.next([]*types.Event{ev("e2", 2*time.Second), ev("e3", 1500*time.Millisecond)})
+	if len(second) != 1 || second[0].ID != "e3" {
+		t.Fatalf("second poll returned %v, want only e3", second)
+	}
+
+	// Events older than the overlap window are forgotten.
+	c.next([]*types.Event{ev("e4", time.Minute)})
+	if _, ok := c.seen["e1"]; ok {
+		t.Error("e1 should have been dropped from the seen set")
+	}
+	if _, ok := c.seen["e4"]; !ok {
+		t.Error("e4 should be in the seen set")
+	}
+}
+
+func TestEventCursorZeroSince(t *testing.T) {
+	c := newEventCursor(time.Time{})
+	if !c.queryFrom().IsZero() {
+		t.Errorf("queryFrom() = %v, want zero time", c.queryFrom())
+	}
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	created := &types.Event{IssueID: "bd-1", EventType: types.EventCreated}
+	closed := &types.Event{IssueID: "bd-2", EventType: types.EventClosed}
+
+	tests := []struct {
+		name       string
+		req        *beadsv1.WatchEventsRequest
+		wantCreate bool
+		wantClose  bool
+	}{
+		{"no filter", &beadsv1.WatchEventsRequest{}, true, true},
+		{"issue", &beadsv1.WatchEventsRequest{IssueId: "bd-2"}, false, true},
+		{"type", &beadsv1.WatchEventsRequest{EventTypes: []string{"created"}}, true, false},
+		{"issue and type", &beadsv1.WatchEventsRequest{IssueId: "bd-2", EventTypes: []string{"created"}}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newEventFilter(tt.req)
+			if got := f.match(created); got != tt.wantCreate {
+				t.Errorf("match(created) = %v, want %v", got, tt.wantCreate)
+			}
+			if got := f.match(closed); got != tt.wantClose {
+				t.Errorf("match(closed) = %v, want %v", got, tt.wantClose)
+			}
+		})
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", fmt.Errorf("issue bd-1: %w", storage.ErrNotFound), codes.NotFound},
+		{"locked", fmt.Errorf("%w by alice", storage.ErrIssueLocked), codes.FailedPrecondition},
+		{"canceled", context.Canceled, codes.Canceled},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"status", status.Error(codes.InvalidArgument, "bad"), codes.InvalidArgument},
+		{"other", errors.New("boom"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(grpcError(tt.err)); got != tt.want {
+				t.Errorf("grpcError(%v) code = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+	if grpcError(nil) != nil {
+		t.Error("grpcError(nil) should be nil")
+	}
+}
+
+func TestProtoIssue(t *testing.T) {
+	created := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ref := "gh-12"
+	issue := &types.Issue{
+		ID:          "bd-1",
+		Title:       "Serve over gRPC",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeFeature,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		ExternalRef: &ref,
+		Metadata:    []byte(`{"k":"v"}`),
+		Labels:      []string{"api"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-0", Type: types.DepBlocks, CreatedAt: created},
+		},
+	}
+	got := protoIssue(issue)
+	if got.GetId() != "bd-1" || got.GetPriority() != 1 || got.GetIssueType() != "feature" {
+		t.Errorf("protoIssue basic fields = %v", got)
+	}
+	if got.GetExternalRef() != "gh-12" || got.GetMetadata() != `{"k":"v"}` {
+		t.Errorf("protoIssue external_ref/metadata = %q/%q", got.GetExternalRef(), got.GetMetadata())
+	}
+	if got.GetClosedAt() != nil {
+		t.Error("closed_at should be unset for an open issue")
+	}
+	if !got.GetCreatedAt().AsTime().Equal(created) {
+		t.Errorf("created_at = %v, want %v", got.GetCreatedAt().AsTime(), created)
+	}
+	if len(got.GetDependencies()) != 1 || got.GetDependencies()[0].GetDependsOnId() != "bd-0" {
+		t.Errorf("dependencies = %v", got.GetDependencies())
+	}
+}
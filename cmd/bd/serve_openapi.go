@@ -0,0 +1,167 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPISpec returns the OpenAPI 3 document for the REST API. Paths come
+// from restRoutes and schemas from the proto messages they exchange, so the
+// document always matches what bd serve --rest actually serves.
+func openAPISpec() map[string]any {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"error": map[string]any{"type": "string"}},
+			"required":   []string{"error"},
+		},
+	}
+	paths := map[string]any{}
+	for _, route := range restRoutes() {
+		item, _ := paths[route.Path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = openAPIOperation(route, schemas)
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Beads REST API",
+			"version": Version,
+			"description": "REST API served by bd serve --rest. Writes need an API token " +
+				"(bd token) with the agent role or above, are attributed to the token's name, " +
+				"and fail with 403 when the server runs with --readonly. Reads need a token " +
+				"only when the workspace sets rbac.require-token.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "description": "API token from bd token create"},
+			},
+		},
+	}
+}
+
+func openAPIOperation(route restRoute, schemas map[string]any) map[string]any {
+	op := map[string]any{
+		"operationId": route.OperationID,
+		"summary":     route.Summary,
+	}
+	if route.Method != http.MethodGet {
+		op["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	}
+	if len(route.Params) > 0 {
+		var params []any
+		for _, p := range route.Params {
+			schema := map[string]any{"type": p.Type}
+			if p.Repeated {
+				schema = map[string]any{"type": "array", "items": schema}
+			}
+			params = append(params, map[string]any{
+				"name":        p.Name,
+				"in":          p.In,
+				"required":    p.In == "path",
+				"description": p.Description,
+				"schema":      schema,
+			})
+		}
+		op["parameters"] = params
+	}
+	if route.Body != nil {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{
+					"schema": openAPIObject(route.Body.ProtoReflect().Descriptor(), route.BodyOmit, schemas),
+				},
+			},
+		}
+	}
+
+	success := map[string]any{"description": http.StatusText(route.Status)}
+	if route.Response != nil {
+		success["content"] = map[string]any{
+			"application/json": map[string]any{"schema": openAPIRef(route.Response, schemas)},
+		}
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(route.Status): success,
+		"default": map[string]any{
+			"description": "Error",
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}},
+			},
+		},
+	}
+	return op
+}
+
+// openAPIRef registers msg's schema in schemas and returns a reference to it.
+func openAPIRef(msg proto.Message, schemas map[string]any) map[string]any {
+	return openAPIMessageRef(msg.ProtoReflect().Descriptor(), schemas)
+}
+
+func openAPIMessageRef(md protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	name := string(md.Name())
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = nil // reserve the name so recursive messages terminate
+		schemas[name] = openAPIObject(md, nil, schemas)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// openAPIObject describes md as an object schema keyed by proto field names,
+// leaving out the fields in omit.
+func openAPIObject(md protoreflect.MessageDescriptor, omit []string, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		if slices.Contains(omit, name) {
+			continue
+		}
+		schema := openAPIField(fd, schemas)
+		if fd.IsList() {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+		props[name] = schema
+	}
+	return map[string]any{"type": "object", "properties": props}
+}
+
+// openAPIField describes one (non-repeated) field the way protojson encodes it.
+func openAPIField(fd protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64-bit integers as strings.
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		return openAPIMessageRef(fd.Message(), schemas)
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
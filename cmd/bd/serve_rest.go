@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	beadsv1 "github.com/steveyegge/beads/api/beadsv1"
+	"github.com/steveyegge/beads/internal/rbac"
+)
+
+// restMaxBody caps request bodies; issue payloads are a few KB at most.
+const restMaxBody = 1 << 20
+
+// restJSON encodes responses with the proto field names, so REST payloads
+// use the same snake_case keys as bd --json.
+var restJSON = protojson.MarshalOptions{UseProtoNames: true}
+
+// restParam is one query or path parameter of a REST route.
+type restParam struct {
+	Name        string
+	In          string // "query" or "path"
+	Type        string // "string", "integer", or "boolean"
+	Repeated    bool
+	Description string
+}
+
+// restRoute is one REST endpoint. Each route forwards to the matching gRPC
+// method, so REST and gRPC share validation, attribution, and commits.
+// restRoutes drives both the HTTP mux and the OpenAPI document.
+type restRoute struct {
+	Method      string
+	Path        string // net/http pattern syntax, e.g. /v1/issues/{id}
+	OperationID string
+	Summary     string
+	Params      []restParam
+	Body        proto.Message // request body schema; nil when there is none
+	BodyOmit    []string      // body fields filled from the path or the token instead
+	Response    proto.Message // response schema; nil for 204 No Content
+	Status      int
+	Call        func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error)
+}
+
+var restIDParam = restParam{Name: "id", In: "path", Type: "string", Description: "Issue ID"}
+
+func restRoutes() []restRoute {
+	return []restRoute{
+		{
+			Method: http.MethodGet, Path: "/v1/issues", OperationID: "listIssues",
+			Summary: "List issues matching a filter, like bd list",
+			Params: []restParam{
+				{Name: "query", In: "query", Type: "string", Description: "Free-text search over titles, descriptions, and IDs"},
+				{Name: "status", In: "query", Type: "string", Repeated: true, Description: "Only issues in these statuses"},
+				{Name: "type", In: "query", Type: "string", Description: "Only issues of this type"},
+				{Name: "assignee", In: "query", Type: "string", Description: "Only issues assigned to this actor"},
+				{Name: "label", In: "query", Type: "string", Repeated: true, Description: "Issues must carry all of these labels"},
+				{Name: "priority", In: "query", Type: "integer", Description: "Only issues at this priority"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of issues (0 means no limit)"},
+			},
+			Response: &beadsv1.ListIssuesResponse{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				q := r.URL.Query()
+				req := &beadsv1.ListIssuesRequest{
+					Query:     q.Get("query"),
+					Statuses:  q["status"],
+					IssueType: q.Get("type"),
+					Assignee:  q.Get("assignee"),
+					Labels:    q["label"],
+				}
+				var err error
+				if req.Priority, err = queryInt32Ptr(q.Get("priority"), "priority"); err != nil {
+					return nil, err
+				}
+				if req.Limit, err = queryInt32(q.Get("limit"), "limit"); err != nil {
+					return nil, err
+				}
+				return s.ListIssues(ctx, req)
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/v1/issues", OperationID: "createIssue",
+			Summary:  "Create an issue",
+			Body:     &beadsv1.CreateIssueRequest{},
+			BodyOmit: []string{"actor"},
+			Response: &beadsv1.Issue{}, Status: http.StatusCreated,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				req := &beadsv1.CreateIssueRequest{}
+				if err := decodeRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				return s.CreateIssue(ctx, req)
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/v1/issues/{id}", OperationID: "getIssue",
+			Summary:  "Get one issue with its labels and dependencies",
+			Params:   []restParam{restIDParam},
+			Response: &beadsv1.Issue{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				return s.GetIssue(ctx, &beadsv1.GetIssueRequest{Id: r.PathValue("id")})
+			},
+		},
+		{
+			Method: http.MethodPatch, Path: "/v1/issues/{id}", OperationID: "updateIssue",
+			Summary:  "Update the fields present in the body",
+			Params:   []restParam{restIDParam},
+			Body:     &beadsv1.UpdateIssueRequest{},
+			BodyOmit: []string{"id", "actor"},
+			Response: &beadsv1.Issue{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				req := &beadsv1.UpdateIssueRequest{}
+				if err := decodeRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.Id = r.PathValue("id")
+				return s.UpdateIssue(ctx, req)
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/v1/issues/{id}/close", OperationID: "closeIssue",
+			Summary:  "Close an issue",
+			Params:   []restParam{restIDParam},
+			Body:     &beadsv1.CloseIssueRequest{},
+			BodyOmit: []string{"id", "actor"},
+			Response: &beadsv1.Issue{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				req := &beadsv1.CloseIssueRequest{}
+				if err := decodeRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.Id = r.PathValue("id")
+				return s.CloseIssue(ctx, req)
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/v1/issues/{id}/dependencies", OperationID: "listDependencies",
+			Summary:  "List the dependencies of an issue",
+			Params:   []restParam{restIDParam},
+			Response: &beadsv1.ListDependenciesResponse{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				return s.ListDependencies(ctx, &beadsv1.ListDependenciesRequest{IssueId: r.PathValue("id")})
+			},
+		},
+		{
+			Method: http.MethodPost, Path: "/v1/issues/{id}/dependencies", OperationID: "addDependency",
+			Summary:  "Record that the issue depends on depends_on_id",
+			Params:   []restParam{restIDParam},
+			Body:     &beadsv1.AddDependencyRequest{},
+			BodyOmit: []string{"issue_id", "actor"},
+			Response: &beadsv1.Dependency{}, Status: http.StatusCreated,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				req := &beadsv1.AddDependencyRequest{}
+				if err := decodeRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.IssueId = r.PathValue("id")
+				return s.AddDependency(ctx, req)
+			},
+		},
+		{
+			Method: http.MethodDelete, Path: "/v1/issues/{id}/dependencies/{depends_on_id}", OperationID: "removeDependency",
+			Summary: "Remove the dependency of the issue on depends_on_id",
+			Params: []restParam{
+				restIDParam,
+				{Name: "depends_on_id", In: "path", Type: "string", Description: "ID of the issue depended on"},
+			},
+			Status: http.StatusNoContent,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				_, err := s.RemoveDependency(ctx, &beadsv1.RemoveDependencyRequest{
+					IssueId:     r.PathValue("id"),
+					DependsOnId: r.PathValue("depends_on_id"),
+				})
+				return nil, err
+			},
+		},
+		{
+			Method: http.MethodGet, Path: "/v1/ready", OperationID: "readyWork",
+			Summary: "List open issues with no open blockers, like bd ready",
+			Params: []restParam{
+				{Name: "type", In: "query", Type: "string", Description: "Only issues of this type"},
+				{Name: "assignee", In: "query", Type: "string", Description: "Only issues assigned to this actor"},
+				{Name: "unassigned", In: "query", Type: "boolean", Description: "Only unassigned issues"},
+				{Name: "label", In: "query", Type: "string", Repeated: true, Description: "Issues must carry all of these labels"},
+				{Name: "priority", In: "query", Type: "integer", Description: "Only issues at this priority"},
+				{Name: "parent", In: "query", Type: "string", Description: "Only descendants of this issue"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of issues (0 means no limit)"},
+			},
+			Response: &beadsv1.ListIssuesResponse{}, Status: http.StatusOK,
+			Call: func(ctx context.Context, s *grpcServer, r *http.Request) (proto.Message, error) {
+				q := r.URL.Query()
+				req := &beadsv1.ReadyWorkRequest{
+					IssueType: q.Get("type"),
+					Assignee:  q.Get("assignee"),
+					Labels:    q["label"],
+					ParentId:  q.Get("parent"),
+				}
+				if v := q.Get("unassigned"); v != "" {
+					b, err := strconv.ParseBool(v)
+					if err != nil {
+						return nil, status.Errorf(codes.InvalidArgument, "invalid unassigned %q", v)
+					}
+					req.Unassigned = b
+				}
+				var err error
+				if req.Priority, err = queryInt32Ptr(q.Get("priority"), "priority"); err != nil {
+					return nil, err
+				}
+				if req.Limit, err = queryInt32(q.Get("limit"), "limit"); err != nil {
+					return nil, err
+				}
+				return s.ReadyWork(ctx, req)
+			},
+		},
+	}
+}
+
+// registerREST adds the REST routes and GET /openapi.json to mux. GET routes
+// are reads; every other route is an issue write and needs a bearer token
+// whose role allows it.
+func registerREST(mux *http.ServeMux, s *grpcServer) error {
+	spec, err := json.MarshalIndent(openAPISpec(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding OpenAPI document: %w", err)
+	}
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+	for _, route := range restRoutes() {
+		perm := rbac.PermRead
+		if route.Method != http.MethodGet {
+			perm = rbac.PermIssueWrite
+		}
+		mux.HandleFunc(route.Method+" "+route.Path, func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := s.auth.authorize(r.Context(), bearerToken(r.Header.Get("Authorization")), perm, "serve "+route.OperationID)
+			if err != nil {
+				writeRESTError(w, err)
+				return
+			}
+			resp, err := route.Call(ctx, s, r)
+			if err != nil {
+				writeRESTError(w, err)
+				return
+			}
+			if resp == nil {
+				w.WriteHeader(route.Status)
+				return
+			}
+			data, err := restJSON.Marshal(resp)
+			if err != nil {
+				writeRESTError(w, err)
+				return
+			}
+			// protojson deliberately varies its whitespace; compact it so
+			// responses are byte-stable.
+			var out bytes.Buffer
+			if err := json.Compact(&out, data); err != nil {
+				writeRESTError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(route.Status)
+			_, _ = out.WriteTo(w)
+		})
+	}
+	return nil
+}
+
+// decodeRESTBody reads a JSON request body into msg. Proto (snake_case) and
+// JSON (lowerCamel) field names are both accepted; unknown fields are errors.
+func decodeRESTBody(r *http.Request, msg proto.Message) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, restMaxBody+1))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "reading request body: %v", err)
+	}
+	if len(data) > restMaxBody {
+		return status.Errorf(codes.InvalidArgument, "request body exceeds %d bytes", restMaxBody)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// writeRESTError writes err as {"error": "..."} with the HTTP status that
+// matches its gRPC code.
+func writeRESTError(w http.ResponseWriter, err error) {
+	st, _ := status.FromError(grpcError(err))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": st.Message()})
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499 // client closed request
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func queryInt32(v, name string) (int32, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s %q", name, v)
+	}
+	return int32(n), nil
+}
+
+func queryInt32Ptr(v, name string) (*int32, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := queryInt32(v, name)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
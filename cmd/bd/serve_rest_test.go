@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/steveyegge/beads/internal/rbac"
+)
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	data, err := json.Marshal(openAPISpec())
+	if err != nil {
+		t.Fatalf("marshal spec: %v", err)
+	}
+	var spec struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("unmarshal spec: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", spec.OpenAPI)
+	}
+
+	ops := map[string]bool{}
+	for _, route := range restRoutes() {
+		if _, ok := spec.Paths[route.Path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("spec is missing %s %s", route.Method, route.Path)
+		}
+		if ops[route.OperationID] {
+			t.Errorf("duplicate operationId %q", route.OperationID)
+		}
+		ops[route.OperationID] = true
+	}
+
+	// Every $ref must point at a schema the document defines.
+	for _, ref := range strings.Split(string(data), `"$ref":"#/components/schemas/`)[1:] {
+		name := ref[:strings.IndexByte(ref, '"')]
+		if spec.Components.Schemas[name] == nil {
+			t.Errorf("dangling $ref to %q", name)
+		}
+	}
+	if strings.Contains(string(data), `"Timestamp"`) {
+		t.Error("timestamps should be date-time strings, not a Timestamp schema")
+	}
+}
+
+func TestOpenAPIBodyOmitsPathFields(t *testing.T) {
+	spec := openAPISpec()
+	op := spec["paths"].(map[string]any)["/v1/issues/{id}"].(map[string]any)["patch"].(map[string]any)
+	body := op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+	props := body["schema"].(map[string]any)["properties"].(map[string]any)
+	if _, ok := props["id"]; ok {
+		t.Error("PATCH body should not include id, which comes from the path")
+	}
+	if _, ok := props["status"]; !ok {
+		t.Error("PATCH body should include status")
+	}
+}
+
+func TestRESTErrors(t *testing.T) {
+	t.Chdir(t.TempDir()) // keep access entries out of the repo's audit log
+	tokens := memTokenStore{}
+	writer := tokens.addToken(t, "ci-bot", rbac.RoleWriter)
+	mux := http.NewServeMux()
+	if err := registerREST(mux, &grpcServer{readonly: true, auth: &serveAuth{tokens: tokens}}); err != nil {
+		t.Fatalf("registerREST: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"bad limit", http.MethodGet, "/v1/issues?limit=abc", "", http.StatusBadRequest},
+		{"bad unassigned", http.MethodGet, "/v1/ready?unassigned=maybe", "", http.StatusBadRequest},
+		{"readonly create", http.MethodPost, "/v1/issues", `{"title":"x"}`, http.StatusForbidden},
+		{"readonly close", http.MethodPost, "/v1/issues/bd-1/close", `{}`, http.StatusForbidden},
+		{"wrong method", http.MethodPut, "/v1/issues/bd-1", "", http.StatusMethodNotAllowed},
+		{"openapi", http.MethodGet, "/openapi.json", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+writer)
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.want, rec.Body)
+			}
+			if tt.want >= 400 && tt.want != http.StatusMethodNotAllowed {
+				var body map[string]string
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+					t.Errorf("error body = %s, want {\"error\": ...}", rec.Body)
+				}
+			}
+		})
+	}
+}
+
+func TestRESTInvalidBody(t *testing.T) {
+	t.Chdir(t.TempDir())
+	tokens := memTokenStore{}
+	agent := tokens.addToken(t, "triage-bot", rbac.RoleAgent)
+	mux := http.NewServeMux()
+	if err := registerREST(mux, &grpcServer{auth: &serveAuth{tokens: tokens}}); err != nil {
+		t.Fatalf("registerREST: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/issues", strings.NewReader(`{"bogus":1}`))
+	req.Header.Set("Authorization", "Bearer "+agent)
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "bogus") {
+		t.Errorf("unknown field: %d %s, want 400 naming the field", rec.Code, rec.Body)
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.InvalidArgument:  http.StatusBadRequest,
+		codes.NotFound:         http.StatusNotFound,
+		codes.PermissionDenied: http.StatusForbidden,
+		codes.AlreadyExists:    http.StatusConflict,
+		codes.Internal:         http.StatusInternalServerError,
+		codes.Unknown:          http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := httpStatusFromCode(code); got != want {
+			t.Errorf("httpStatusFromCode(%v) = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestRESTAuth(t *testing.T) {
+	t.Chdir(t.TempDir())
+	tokens := memTokenStore{}
+	reader := tokens.addToken(t, "dashboard", rbac.RoleReader)
+	agent := tokens.addToken(t, "triage-bot", rbac.RoleAgent)
+
+	tests := []struct {
+		name     string
+		required bool
+		method   string
+		path     string
+		token    string
+		want     int
+	}{
+		{"write without token", false, http.MethodPost, "/v1/issues/bd-1/close", "", http.StatusUnauthorized},
+		{"write with bad token", false, http.MethodPost, "/v1/issues/bd-1/close", "bdt_nope_nope", http.StatusUnauthorized},
+		{"write with reader token", false, http.MethodPost, "/v1/issues/bd-1/close", reader, http.StatusForbidden},
+		// Authenticated, then refused by --readonly: the role check passed.
+		{"write with agent token", false, http.MethodPost, "/v1/issues/bd-1/close", agent, http.StatusForbidden},
+		{"read without token", false, http.MethodGet, "/v1/issues?limit=abc", "", http.StatusBadRequest},
+		{"read without token when required", true, http.MethodGet, "/v1/issues?limit=abc", "", http.StatusUnauthorized},
+		{"read with token when required", true, http.MethodGet, "/v1/issues?limit=abc", reader, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			if err := registerREST(mux, &grpcServer{readonly: true, auth: &serveAuth{tokens: tokens, required: tt.required}}); err != nil {
+				t.Fatalf("registerREST: %v", err)
+			}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{}`))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			mux.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
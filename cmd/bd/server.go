@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/doltserver"
+)
+
+var serverCmd = &cobra.Command{
+	Use:     "server",
+	GroupID: "setup",
+	Short:   "Manage the Dolt SQL server for this project",
+	Long: `Start, stop, restart, and inspect the dolt sql-server that beads manages
+for this project in server mode.
+
+The server runs in the background with the project's data directory and a
+per-project port. Its PID, port, and log live in .beads/ (dolt-server.pid,
+dolt-server.port, dolt-server.log). Any bd command that cannot connect
+starts it on demand unless dolt.auto-start is false, so these commands are
+mainly for explicit control and troubleshooting.
+
+'bd server status' also runs a SELECT 1 health check, which catches a
+server whose process is alive but no longer answers queries; 'bd server
+restart' replaces it.
+
+These are the same operations as 'bd dolt start/stop/status'.
+
+Examples:
+  bd server status           # PID, port, data dir, and health
+  bd server restart          # Stop (flushing the working set) and start again
+  bd server stop --force     # Kill a server that ignores a graceful stop`,
+}
+
+var serverStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the Dolt SQL server for this project",
+	Args:  cobra.NoArgs,
+	Run:   doltStartCmd.Run,
+}
+
+var serverStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the Dolt SQL server for this project",
+	Args:  cobra.NoArgs,
+	Run:   doltStopCmd.Run,
+}
+
+var serverStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show Dolt server status and health",
+	Args:  cobra.NoArgs,
+	Run:   doltStatusCmd.Run,
+}
+
+var serverRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart the Dolt SQL server for this project",
+	Long: `Stop the managed dolt sql-server, flushing uncommitted working-set
+changes first, and start a fresh one on the same data directory.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !usesSQLServer() {
+			fmt.Fprintln(os.Stderr, "Error: 'bd server restart' is not supported in embedded mode (no Dolt server)")
+			os.Exit(1)
+		}
+		beadsDir := selectedDoltBeadsDir()
+		if beadsDir == "" {
+			FatalErrorWithHint(activeWorkspaceNotFoundError(), diagHint())
+		}
+		serverDir := doltserver.ResolveServerDir(beadsDir)
+		force, _ := cmd.Flags().GetBool("force")
+
+		if err := doltserver.StopWithForce(serverDir, force); err != nil && !errors.Is(err, doltserver.ErrServerNotRunning) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		state, err := doltserver.Start(serverDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Dolt server restarted (PID %d, port %d)\n", state.PID, state.Port)
+		fmt.Printf("  Data: %s\n", state.DataDir)
+		fmt.Printf("  Logs: %s\n", doltserver.LogPath(serverDir))
+	},
+}
+
+func init() {
+	serverStopCmd.Flags().Bool("force", false, "Force stop the server")
+	serverRestartCmd.Flags().Bool("force", false, "Force stop the running server before starting")
+
+	serverCmd.AddCommand(serverStartCmd)
+	serverCmd.AddCommand(serverStopCmd)
+	serverCmd.AddCommand(serverStatusCmd)
+	serverCmd.AddCommand(serverRestartCmd)
+	rootCmd.AddCommand(serverCmd)
+}
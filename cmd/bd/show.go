@@ -24,7 +24,10 @@ var showCmd = &cobra.Command{
 		shortMode, _ := cmd.Flags().GetBool("short")
 		longMode, _ := cmd.Flags().GetBool("long")
 		showRefs, _ := cmd.Flags().GetBool("refs")
+		showBacklinks, _ := cmd.Flags().GetBool("backlinks")
+		showActivity, _ := cmd.Flags().GetBool("activity")
 		showChildren, _ := cmd.Flags().GetBool("children")
+		showTree, _ := cmd.Flags().GetBool("tree")
 		asOfRef, _ := cmd.Flags().GetString("as-of")
 		idFlags, _ := cmd.Flags().GetStringArray("id")
 		localTime, _ := cmd.Flags().GetBool("local-time")
@@ -32,6 +35,7 @@ var showCmd = &cobra.Command{
 		currentMode, _ := cmd.Flags().GetBool("current")
 		includeDepends, _ := cmd.Flags().GetBool("include-dependents")
 		includeComments, _ := cmd.Flags().GetBool("include-comments")
+		format := outputFormatFlag(cmd)
 		ctx := rootCtx
 
 		// Helper to format timestamp based on --local-time flag
@@ -65,7 +69,11 @@ var showCmd = &cobra.Command{
 
 		// Handle --as-of flag: show issue at a specific point in history
 		if asOfRef != "" {
-			showIssueAsOf(ctx, args, asOfRef, shortMode)
+			ref, err := resolveAsOfRef(ctx, store, asOfRef)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			showIssueAsOf(ctx, args, ref, shortMode)
 			return
 		}
 
@@ -105,14 +113,34 @@ var showCmd = &cobra.Command{
 			return
 		}
 
+		// Handle --backlinks flag: show issues whose text mentions this issue
+		if showBacklinks {
+			showIssueBacklinks(ctx, args, jsonOutput)
+			return
+		}
+
+		// Handle --activity flag: one chronological feed of everything that
+		// happened to the issue
+		if showActivity {
+			showIssueActivity(ctx, args, jsonOutput)
+			return
+		}
+
 		// Handle --children flag: show only children of this issue
 		if showChildren {
 			showIssueChildren(ctx, args, jsonOutput, shortMode)
 			return
 		}
 
+		// Handle --tree flag: show the full step hierarchy with roll-ups
+		if showTree {
+			showIssueTrees(ctx, args, jsonOutput)
+			return
+		}
+
 		// Direct mode - use routed resolution for cross-repo lookups
 		allDetails := []interface{}{}
+		var templateDetails []*types.IssueDetails
 		foundCount := 0
 		for idx, id := range args {
 			// Resolve and get issue with routing (e.g., gt-xyz routes to another rig)
@@ -142,6 +170,21 @@ var showCmd = &cobra.Command{
 				continue
 			}
 
+			if format != "" && !jsonOutput {
+				details := &types.IssueDetails{Issue: *issue}
+				details.Labels, _ = issueStore.GetLabels(ctx, issue.ID)
+				details.Dependencies, _ = issueStore.GetDependenciesWithMetadata(ctx, issue.ID)
+				for _, dep := range details.Dependencies {
+					if dep.DependencyType == types.DepParentChild {
+						details.Parent = &dep.ID
+						break
+					}
+				}
+				templateDetails = append(templateDetails, details)
+				result.Close()
+				continue
+			}
+
 			if jsonOutput {
 				// be-ijck6q: default is count-only (no dependents/comments slice in output).
 				// Use --include-dependents / --include-comments to stream the full lists.
@@ -156,6 +199,22 @@ var showCmd = &cobra.Command{
 				details.DependencyCount = &depnCount
 				cmtCount, _ := issueStore.CountIssueComments(ctx, issue.ID)
 				details.CommentCount = &cmtCount
+				details.Commits = loadCommitLinks(ctx, issueStore, issue.ID)
+				details.ExternalDeps = loadExternalDeps(ctx, issueStore, issue.ID)
+				if runs := loadCIRuns(ctx, issueStore, []string{issue.ID})[issue.ID]; len(runs) > 0 {
+					details.CIRuns = types.LatestCIRuns(runs)
+					details.CIStatus = types.CIStatusSummary(runs)
+				}
+
+				// Epic/molecule progress — one aggregated query, so it is
+				// present even without --include-dependents.
+				if progress := loadChildProgress(ctx, issueStore, []*types.Issue{issue})[issue.ID]; progress != nil {
+					closeable := progress.Total == progress.Closed
+					details.EpicTotalChildren = &progress.Total
+					details.EpicClosedChildren = &progress.Closed
+					details.EpicPercent = &progress.Percent
+					details.EpicCloseable = &closeable
+				}
 
 				// --include-dependents: stream via Iter, shallow-copy each item.
 				// May be slow on hub beads with many dependents.
@@ -179,8 +238,9 @@ var showCmd = &cobra.Command{
 						}
 						details.Dependents = shallowDeps
 
-						// Epic progress from streamed dependents.
-						if issue.IssueType == types.TypeEpic && len(shallowDeps) > 0 {
+						// Epic progress from streamed dependents, when the
+						// aggregated roll-up above found nothing.
+						if details.EpicTotalChildren == nil && issue.IssueType == types.TypeEpic && len(shallowDeps) > 0 {
 							total, closed := 0, 0
 							for _, dep := range shallowDeps {
 								if dep.DependencyType == types.DepParentChild {
@@ -320,6 +380,14 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show dependencies on external URLs with their resolved state
+			if extDeps := loadExternalDeps(ctx, issueStore, issue.ID); len(extDeps) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("EXTERNAL"))
+				for _, d := range extDeps {
+					fmt.Println(formatExternalDepLine(d))
+				}
+			}
+
 			// Show dependents - grouped by dependency type for clarity
 			dependentsWithMeta, _ := issueStore.GetDependentsWithMetadata(ctx, issue.ID) // Best effort: show issue even if dependents unavailable
 			if len(dependentsWithMeta) > 0 {
@@ -345,22 +413,12 @@ var showCmd = &cobra.Command{
 					for _, dep := range children {
 						fmt.Println(formatDependencyLine("↳", dep))
 					}
-					// Epic progress summary
-					if issue.IssueType == types.TypeEpic {
-						closedCount := 0
-						for _, dep := range children {
-							if dep.Issue.Status == types.StatusClosed {
-								closedCount++
-							}
-						}
-						pct := 0
-						if len(children) > 0 {
-							pct = (closedCount * 100) / len(children)
-						}
-						if closedCount == len(children) {
-							fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), closedCount, len(children), pct)
+					// Epic/molecule progress summary
+					if progress := loadChildProgress(ctx, issueStore, []*types.Issue{issue})[issue.ID]; progress != nil {
+						if progress.Closed == progress.Total {
+							fmt.Printf("  %s %d/%d complete (%d%%) — eligible for close\n", ui.RenderPass("✓"), progress.Closed, progress.Total, progress.Percent)
 						} else {
-							fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), closedCount, len(children), pct)
+							fmt.Printf("  %s %d/%d complete (%d%%)\n", ui.RenderMuted("◐"), progress.Closed, progress.Total, progress.Percent)
 						}
 					}
 				}
@@ -386,6 +444,22 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show commits that touched this issue
+			if links := loadCommitLinks(ctx, issueStore, issue.ID); len(links) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("COMMITS"))
+				for _, l := range links {
+					fmt.Println(formatCommitLinkLine(l))
+				}
+			}
+
+			// Show CI results for the latest reported commit
+			if runs := loadCIRuns(ctx, issueStore, []string{issue.ID})[issue.ID]; len(runs) > 0 {
+				fmt.Printf("\n%s\n", ui.RenderBold("CI"))
+				for _, r := range types.LatestCIRuns(runs) {
+					fmt.Println(formatCIRunLine(r))
+				}
+			}
+
 			// Show comments
 			comments, _ := issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
 			if len(comments) > 0 {
@@ -409,7 +483,14 @@ var showCmd = &cobra.Command{
 			result.Close() // Close routed storage after each iteration
 		}
 
-		if jsonOutput {
+		if format != "" && !jsonOutput {
+			if foundCount == 0 {
+				os.Exit(1)
+			}
+			if err := renderFormatTemplate(os.Stdout, format, templateDetails); err != nil {
+				FatalError("%v", err)
+			}
+		} else if jsonOutput {
 			if len(allDetails) > 0 {
 				outputJSON(allDetails)
 			} else {
@@ -471,13 +552,17 @@ func init() {
 	showCmd.Flags().Bool("short", false, "Show compact one-line output per issue")
 	showCmd.Flags().Bool("long", false, "Show all available fields (extended metadata, agent identity, gate fields, etc.)")
 	showCmd.Flags().Bool("refs", false, "Show issues that reference this issue (reverse lookup)")
+	showCmd.Flags().Bool("backlinks", false, "Show issues whose description or comments mention this issue")
+	showCmd.Flags().Bool("activity", false, "Show events, comments, dependency changes, and Dolt commits as one chronological feed")
 	showCmd.Flags().Bool("children", false, "Show only the children of this issue")
-	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash or branch (requires Dolt)")
+	showCmd.Flags().Bool("tree", false, "Show the full child hierarchy with closed/total progress at each level")
+	showCmd.Flags().String("as-of", "", "Show issue as it existed at a commit hash, branch, or date/time (e.g. 2026-01-05, -7d)")
 	showCmd.Flags().StringArray("id", nil, "Issue ID (use for IDs that look like flags, e.g., --id=gt--xyz)")
 	showCmd.Flags().Bool("local-time", false, "Show timestamps in local time instead of UTC")
 	showCmd.Flags().BoolP("watch", "w", false, "Watch for changes and auto-refresh display")
 	showCmd.Flags().Bool("current", false, "Show the currently active issue (in-progress, hooked, or last touched)")
 	showCmd.Flags().Bool("include-dependents", false, "Stream full dependent issues in JSON output (--json only; may be slow on hub beads)")
+	showCmd.Flags().String("format", "", formatFlagHelp)
 	showCmd.Flags().Bool("include-comments", false, "Stream full comment bodies in JSON output (--json only; may be slow on issues with many comments)")
 	showCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(showCmd)
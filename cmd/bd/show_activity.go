@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Kinds of bd show --activity entries.
+const (
+	activityEvent      = "event"
+	activityComment    = "comment"
+	activityDependency = "dependency"
+	activityCommit     = "commit"
+)
+
+// activityEntry is one line of bd show --activity, and its --json form.
+type activityEntry struct {
+	At        time.Time `json:"at"`
+	Kind      string    `json:"kind"`
+	Actor     string    `json:"actor,omitempty"`
+	Summary   string    `json:"summary"`
+	EventType string    `json:"event_type,omitempty"`
+	Commit    string    `json:"commit,omitempty"`
+}
+
+// issueActivityInputs is everything buildIssueActivity merges, loaded up
+// front so the feed itself is computed without touching the store.
+type issueActivityInputs struct {
+	IssueID    string
+	Events     []*types.Event
+	Comments   []*types.Comment
+	Deps       []*types.Dependency // dependencies of the issue
+	Dependents []*types.Dependency // dependencies on the issue
+	History    []*storage.HistoryEntry
+}
+
+// loadIssueActivity reads issueID's events, comments, dependencies in both
+// directions, and Dolt history. History is best effort: a store that cannot
+// walk its commits still shows the rest of the feed.
+func loadIssueActivity(ctx context.Context, s storage.DoltStorage, issueID string) (*issueActivityInputs, error) {
+	in := &issueActivityInputs{IssueID: issueID}
+	var err error
+	if in.Events, err = s.GetEvents(ctx, issueID, 0); err != nil {
+		return nil, fmt.Errorf("getting events: %w", err)
+	}
+	if in.Comments, err = s.GetIssueComments(ctx, issueID); err != nil {
+		return nil, fmt.Errorf("getting comments: %w", err)
+	}
+	if in.Deps, err = s.GetDependencyRecords(ctx, issueID); err != nil {
+		return nil, fmt.Errorf("getting dependencies: %w", err)
+	}
+	dependents, err := s.GetDependentsWithMetadata(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("getting dependents: %w", err)
+	}
+	if len(dependents) > 0 {
+		ids := make([]string, len(dependents))
+		for i, d := range dependents {
+			ids[i] = d.ID
+		}
+		records, err := s.GetDependencyRecordsForIssues(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("getting dependents: %w", err)
+		}
+		for _, id := range ids {
+			for _, dep := range records[id] {
+				if dep.DependsOnID == issueID {
+					in.Dependents = append(in.Dependents, dep)
+				}
+			}
+		}
+	}
+	in.History, _ = s.History(ctx, issueID) // Best effort: history needs Dolt commits
+	return in, nil
+}
+
+// buildIssueActivity interleaves the inputs into one feed, oldest first.
+func buildIssueActivity(in *issueActivityInputs) []activityEntry {
+	var feed []activityEntry
+
+	// A comment added through the event log and also stored as a comment
+	// row appears once, as the comment.
+	commentKeys := make(map[string]bool, len(in.Comments))
+	for _, c := range in.Comments {
+		commentKeys[c.Author+"\x00"+strings.TrimSpace(c.Text)] = true
+		feed = append(feed, activityEntry{
+			At: c.CreatedAt, Kind: activityComment, Actor: c.Author,
+			Summary: "commented: " + digestPreview(c.Text),
+		})
+	}
+	for _, e := range in.Events {
+		if e.EventType == types.EventCommented && e.Comment != nil &&
+			commentKeys[e.Actor+"\x00"+strings.TrimSpace(*e.Comment)] {
+			continue
+		}
+		feed = append(feed, activityEntry{
+			At: e.CreatedAt, Kind: activityEvent, Actor: e.Actor,
+			Summary: describeActivityEvent(e), EventType: string(e.EventType),
+		})
+	}
+	for _, dep := range in.Deps {
+		feed = append(feed, activityEntry{
+			At: dep.CreatedAt, Kind: activityDependency, Actor: dep.CreatedBy,
+			Summary: fmt.Sprintf("added dependency on %s (%s)", dep.DependsOnID, dep.Type),
+		})
+	}
+	for _, dep := range in.Dependents {
+		feed = append(feed, activityEntry{
+			At: dep.CreatedAt, Kind: activityDependency, Actor: dep.CreatedBy,
+			Summary: fmt.Sprintf("%s added a dependency on this issue (%s)", dep.IssueID, dep.Type),
+		})
+	}
+
+	history := append([]*storage.HistoryEntry(nil), in.History...)
+	sort.SliceStable(history, func(i, j int) bool { return history[i].CommitDate.Before(history[j].CommitDate) })
+	var prev *types.Issue
+	for _, h := range history {
+		changes := describeIssueSnapshotChange(prev, h.Issue)
+		if changes == "" && prev != nil && h.Issue != nil {
+			// History lists every commit the row exists in; skip commits
+			// that left this issue untouched.
+			if h.Issue.UpdatedAt.Equal(prev.UpdatedAt) {
+				continue
+			}
+			changes = "issue updated"
+		}
+		summary := "Dolt commit"
+		if changes != "" {
+			summary += ": " + changes
+		}
+		feed = append(feed, activityEntry{
+			At: h.CommitDate, Kind: activityCommit, Actor: h.Committer,
+			Summary: summary, Commit: h.CommitHash,
+		})
+		if h.Issue != nil {
+			prev = h.Issue
+		}
+	}
+
+	sort.SliceStable(feed, func(i, j int) bool { return feed[i].At.Before(feed[j].At) })
+	return feed
+}
+
+// describeActivityEvent renders an event as a short phrase. Update events
+// carry the old issue and the update map as JSON; close events carry the
+// close reason.
+func describeActivityEvent(e *types.Event) string {
+	oldFields := standupEventFields(e.OldValue)
+	newFields := standupEventFields(e.NewValue)
+	change := func(field string) string {
+		return fmt.Sprintf("%s %s → %s", field, activityValue(oldFields[field]), activityValue(newFields[field]))
+	}
+
+	switch e.EventType {
+	case types.EventCreated:
+		return "created"
+	case types.EventClosed:
+		if reason, ok := newFields["close_reason"]; ok && reason != "" {
+			return "closed: " + reason
+		}
+		if e.NewValue != nil && *e.NewValue != "" && !strings.HasPrefix(*e.NewValue, "{") {
+			return "closed: " + *e.NewValue
+		}
+		return "closed"
+	case types.EventReopened:
+		return "reopened"
+	case types.EventCommented:
+		if e.Comment != nil {
+			return "commented: " + digestPreview(*e.Comment)
+		}
+		return "commented"
+	case types.EventStatusChanged, types.EventUpdated:
+		updates := activityUpdateKeys(e.NewValue)
+		if len(updates) == 0 {
+			return "updated"
+		}
+		var parts, other []string
+		for _, field := range updates {
+			switch field {
+			case "status", "assignee":
+				parts = append(parts, change(field))
+			default:
+				other = append(other, field)
+			}
+		}
+		if len(other) > 0 {
+			parts = append(parts, "updated "+strings.Join(other, ", "))
+		}
+		return strings.Join(parts, "; ")
+	}
+
+	phrase := strings.ReplaceAll(string(e.EventType), "_", " ")
+	for _, v := range []*string{e.NewValue, e.Comment} {
+		if v != nil && *v != "" {
+			return phrase + " " + digestPreview(*v)
+		}
+	}
+	return phrase
+}
+
+// activityUpdateKeys returns the sorted field names of an update event's
+// new value (the update map).
+func activityUpdateKeys(raw *string) []string {
+	if raw == nil {
+		return nil
+	}
+	var decoded map[string]any
+	if json.Unmarshal([]byte(*raw), &decoded) != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(decoded))
+	for k := range decoded {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func activityValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+// describeIssueSnapshotChange compares the issue at two consecutive
+// commits. With no earlier snapshot the issue was added in this commit.
+func describeIssueSnapshotChange(prev, cur *types.Issue) string {
+	switch {
+	case cur == nil:
+		return ""
+	case prev == nil:
+		return fmt.Sprintf("issue added [P%d - %s]", cur.Priority, cur.Status)
+	}
+	var changes []string
+	if prev.Status != cur.Status {
+		changes = append(changes, fmt.Sprintf("status %s → %s", prev.Status, cur.Status))
+	}
+	if prev.Priority != cur.Priority {
+		changes = append(changes, fmt.Sprintf("priority P%d → P%d", prev.Priority, cur.Priority))
+	}
+	if prev.Assignee != cur.Assignee {
+		changes = append(changes, fmt.Sprintf("assignee %s → %s", activityValue(prev.Assignee), activityValue(cur.Assignee)))
+	}
+	if prev.Title != cur.Title {
+		changes = append(changes, "title changed")
+	}
+	return strings.Join(changes, ", ")
+}
+
+// showIssueActivity displays the activity feed of the given issue(s).
+func showIssueActivity(ctx context.Context, args []string, jsonOut bool) {
+	allFeeds := make(map[string][]activityEntry)
+
+	for _, id := range args {
+		result, err := resolveAndGetIssueWithRouting(ctx, store, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+			continue
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
+			continue
+		}
+		in, err := loadIssueActivity(ctx, result.Store, result.ResolvedID)
+		title := result.Issue.Title
+		result.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting activity for %s: %v\n", id, err)
+			continue
+		}
+		allFeeds[result.ResolvedID] = buildIssueActivity(in)
+		if !jsonOut {
+			printIssueActivity(result.ResolvedID, title, allFeeds[result.ResolvedID])
+		}
+	}
+
+	if jsonOut {
+		outputJSON(allFeeds)
+	}
+}
+
+func printIssueActivity(issueID, title string, feed []activityEntry) {
+	if len(feed) == 0 {
+		fmt.Printf("\n%s: No activity found\n", ui.RenderAccent(issueID))
+		return
+	}
+	fmt.Printf("\n%s Activity for %s: %s (%d)\n\n", ui.RenderAccent("📰"), issueID, title, len(feed))
+	for _, entry := range feed {
+		line := entry.Summary
+		if entry.Kind == activityCommit && len(entry.Commit) >= 8 {
+			line = ui.RenderMuted(entry.Commit[:8]) + " " + line
+		}
+		if entry.Actor != "" {
+			line += " " + ui.RenderMuted("— "+entry.Actor)
+		}
+		fmt.Printf("  %-14s %s\n", formatTimeAgo(entry.At), line)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDescribeActivityEvent(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		event *types.Event
+		want  string
+	}{
+		{&types.Event{EventType: types.EventCreated}, "created"},
+		{&types.Event{EventType: types.EventClosed, NewValue: str("Fixed in v2")}, "closed: Fixed in v2"},
+		{&types.Event{EventType: types.EventClosed, NewValue: str(`{"status":"closed"}`)}, "closed"},
+		{&types.Event{EventType: types.EventStatusChanged,
+			OldValue: str(`{"id":"bd-1","status":"open","assignee":""}`),
+			NewValue: str(`{"status":"in_progress","assignee":"alice","priority":1}`)},
+			"assignee (none) → alice; status open → in_progress; updated priority"},
+		{&types.Event{EventType: types.EventUpdated, NewValue: str(`{"title":"New"}`)}, "updated title"},
+		{&types.Event{EventType: types.EventCommented, Comment: str("LGTM")}, "commented: LGTM"},
+		{&types.Event{EventType: types.EventLabelAdded, Comment: str("bug")}, "label added bug"},
+	}
+	for _, tt := range tests {
+		if got := describeActivityEvent(tt.event); got != tt.want {
+			t.Errorf("describeActivityEvent(%s) = %q, want %q", tt.event.EventType, got, tt.want)
+		}
+	}
+}
+
+func TestBuildIssueActivity(t *testing.T) {
+	base := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return base.Add(time.Duration(m) * time.Minute) }
+	str := func(s string) *string { return &s }
+	snapshot := func(status types.Status, updated int) *types.Issue {
+		return &types.Issue{ID: "bd-1", Status: status, Priority: 2, UpdatedAt: at(updated)}
+	}
+
+	in := &issueActivityInputs{
+		IssueID: "bd-1",
+		Events: []*types.Event{
+			{EventType: types.EventClosed, Actor: "bob", CreatedAt: at(30), NewValue: str("Done")},
+			{EventType: types.EventCreated, Actor: "alice", CreatedAt: at(0)},
+			// The same comment as the comment row below: listed once.
+			{EventType: types.EventCommented, Actor: "bob", CreatedAt: at(10), Comment: str("On it")},
+		},
+		Comments:   []*types.Comment{{Author: "bob", Text: "On it", CreatedAt: at(10)}},
+		Deps:       []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks, CreatedBy: "alice", CreatedAt: at(5)}},
+		Dependents: []*types.Dependency{{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepRelated, CreatedBy: "carol", CreatedAt: at(20)}},
+		History: []*storage.HistoryEntry{
+			// Newest first, as History returns them.
+			{CommitHash: "c3", CommitDate: at(31), Issue: snapshot(types.StatusClosed, 30)},
+			{CommitHash: "c2", CommitDate: at(21), Issue: snapshot(types.StatusOpen, 0)}, // bd-1 untouched
+			{CommitHash: "c1", CommitDate: at(1), Issue: snapshot(types.StatusOpen, 0)},
+		},
+	}
+
+	feed := buildIssueActivity(in)
+	want := []string{
+		"created",
+		"Dolt commit: issue added [P2 - open]",
+		"added dependency on bd-2 (blocks)",
+		"commented: On it",
+		"bd-3 added a dependency on this issue (related)",
+		"closed: Done",
+		"Dolt commit: status open → closed",
+	}
+	if len(feed) != len(want) {
+		t.Fatalf("feed = %+v, want %d entries", feed, len(want))
+	}
+	for i, w := range want {
+		if feed[i].Summary != w {
+			t.Errorf("feed[%d] = %q, want %q", i, feed[i].Summary, w)
+		}
+	}
+	if feed[3].Kind != activityComment || feed[6].Commit != "c3" {
+		t.Errorf("kinds = %+v", feed)
+	}
+}
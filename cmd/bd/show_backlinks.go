@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// backlinkJSON is one entry of bd show --backlinks --json: another issue
+// whose description or comments mention the shown issue.
+type backlinkJSON struct {
+	ID          string       `json:"id"`
+	Title       string       `json:"title"`
+	Status      types.Status `json:"status"`
+	Priority    int          `json:"priority"`
+	Description bool         `json:"description"`           // mentioned in the description
+	CommentIDs  []string     `json:"comment_ids,omitempty"` // comments that mention it
+}
+
+// issueBacklinks groups the references to issueID by source issue.
+func issueBacklinks(ctx context.Context, s storage.DoltStorage, issueID string) ([]backlinkJSON, error) {
+	rs, ok := storage.UnwrapStore(s).(storage.ReferenceStore)
+	if !ok {
+		return nil, fmt.Errorf("backlinks are not supported by this storage backend")
+	}
+	refs, err := rs.GetBacklinks(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	bySource := make(map[string]*backlinkJSON)
+	for _, ref := range refs {
+		link, ok := bySource[ref.SourceID]
+		if !ok {
+			link = &backlinkJSON{ID: ref.SourceID}
+			bySource[ref.SourceID] = link
+			order = append(order, ref.SourceID)
+		}
+		if ref.SourceKind == types.ReferenceSourceComment {
+			link.CommentIDs = append(link.CommentIDs, ref.SourceRef)
+		} else {
+			link.Description = true
+		}
+	}
+	if len(order) > 0 {
+		sources, err := s.GetIssuesByIDs(ctx, order)
+		if err != nil {
+			return nil, fmt.Errorf("getting referencing issues: %w", err)
+		}
+		for _, issue := range sources {
+			if link, ok := bySource[issue.ID]; ok {
+				link.Title = issue.Title
+				link.Status = issue.Status
+				link.Priority = issue.Priority
+			}
+		}
+	}
+
+	links := make([]backlinkJSON, 0, len(order))
+	for _, id := range order {
+		links = append(links, *bySource[id])
+	}
+	return links, nil
+}
+
+// showIssueBacklinks displays the issues whose descriptions or comments
+// mention the given issue(s).
+func showIssueBacklinks(ctx context.Context, args []string, jsonOut bool) {
+	allLinks := make(map[string][]backlinkJSON)
+	var ids []string
+
+	for _, id := range args {
+		result, err := resolveAndGetIssueWithRouting(ctx, store, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+			continue
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
+			continue
+		}
+		links, err := issueBacklinks(ctx, result.Store, result.ResolvedID)
+		result.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting backlinks for %s: %v\n", id, err)
+			continue
+		}
+		allLinks[result.ResolvedID] = links
+		ids = append(ids, result.ResolvedID)
+	}
+
+	if jsonOut {
+		outputJSON(allLinks)
+		return
+	}
+
+	for _, issueID := range ids {
+		links := allLinks[issueID]
+		if len(links) == 0 {
+			fmt.Printf("\n%s: No backlinks found\n", ui.RenderAccent(issueID))
+			continue
+		}
+		fmt.Printf("\n%s Mentions of %s (%d):\n", ui.RenderAccent("🔗"), issueID, len(links))
+		for _, link := range links {
+			var where []string
+			if link.Description {
+				where = append(where, "description")
+			}
+			switch n := len(link.CommentIDs); {
+			case n == 1:
+				where = append(where, "1 comment")
+			case n > 1:
+				where = append(where, fmt.Sprintf("%d comments", n))
+			}
+			line := fmt.Sprintf("%s: %s [P%d - %s] (%s)", link.ID, link.Title, link.Priority, link.Status, strings.Join(where, ", "))
+			if link.Status == types.StatusClosed {
+				line = ui.RenderMuted(line)
+			}
+			fmt.Printf("    %s\n", line)
+		}
+		fmt.Println()
+	}
+}
@@ -242,6 +242,110 @@ func TestEmbeddedShow(t *testing.T) {
 		}
 	})
 
+	// ===== --backlinks =====
+
+	t.Run("show_backlinks", func(t *testing.T) {
+		target := bdCreate(t, bd, dir, "Backlink target", "--type", "task")
+		fromDesc := bdCreate(t, bd, dir, "Backlink source", "--type", "task",
+			"--description", fmt.Sprintf("Needs %s first; cc @backlink-reviewer, not dev@example.com.", target.ID))
+		fromComment := bdCreate(t, bd, dir, "Backlink commenter", "--type", "task")
+		store := openStore(t, beadsDir, "ts")
+		if _, err := store.AddIssueComment(t.Context(), fromComment.ID, "tester", "Same root cause as "+target.ID+"."); err != nil {
+			store.Close()
+			t.Fatalf("AddIssueComment: %v", err)
+		}
+		if _, err := store.AddIssueComment(t.Context(), target.ID, "tester", "Self mention "+target.ID); err != nil {
+			store.Close()
+			t.Fatalf("AddIssueComment: %v", err)
+		}
+		mentions, err := store.GetMentions(t.Context(), "backlink-reviewer")
+		store.Close() // release flock before subprocess
+		if err != nil {
+			t.Fatalf("GetMentions: %v", err)
+		}
+		if len(mentions) != 1 || mentions[0].SourceID != fromDesc.ID {
+			t.Errorf("mentions of backlink-reviewer = %+v, want one from %s", mentions, fromDesc.ID)
+		}
+
+		out, err := bdRunWithFlockRetry(t, bd, dir, "show", target.ID, "--backlinks", "--json")
+		if err != nil {
+			t.Fatalf("bd show --backlinks --json failed: %v\n%s", err, out)
+		}
+		var links map[string]json.RawMessage // also carries schema_version
+		if err := json.Unmarshal(out[strings.Index(string(out), "{"):], &links); err != nil {
+			t.Fatalf("parse backlinks JSON: %v\n%s", err, out)
+		}
+		var got []backlinkJSON
+		if err := json.Unmarshal(links[target.ID], &got); err != nil {
+			t.Fatalf("parse backlinks of %s: %v\n%s", target.ID, err, out)
+		}
+		if len(got) != 2 {
+			t.Fatalf("backlinks = %+v, want 2 (self mention excluded)", got)
+		}
+		byID := map[string]backlinkJSON{got[0].ID: got[0], got[1].ID: got[1]}
+		if l := byID[fromDesc.ID]; !l.Description || len(l.CommentIDs) != 0 || l.Title != "Backlink source" {
+			t.Errorf("description backlink = %+v", l)
+		}
+		if l := byID[fromComment.ID]; l.Description || len(l.CommentIDs) != 1 {
+			t.Errorf("comment backlink = %+v", l)
+		}
+
+		// Rewriting the description drops its references.
+		bdUpdate(t, bd, dir, fromDesc.ID, "--description", "No references now")
+		text := bdShowRaw(t, bd, dir, target.ID, "--backlinks")
+		if strings.Contains(text, fromDesc.ID) || !strings.Contains(text, fromComment.ID) {
+			t.Errorf("after update, backlinks = %s", text)
+		}
+	})
+
+	// ===== --activity =====
+
+	t.Run("show_activity", func(t *testing.T) {
+		blocker := bdCreate(t, bd, dir, "Activity blocker", "--type", "task")
+		issue := bdCreate(t, bd, dir, "Activity issue", "--type", "task")
+		bdDepAdd(t, bd, dir, issue.ID, blocker.ID)
+		bdComment(t, bd, dir, issue.ID, "Looking into it")
+		bdUpdate(t, bd, dir, issue.ID, "--status", "in_progress")
+
+		out, err := bdRunWithFlockRetry(t, bd, dir, "show", issue.ID, "--activity", "--json")
+		if err != nil {
+			t.Fatalf("bd show --activity --json failed: %v\n%s", err, out)
+		}
+		var feeds map[string]json.RawMessage // also carries schema_version
+		if err := json.Unmarshal(out[strings.Index(string(out), "{"):], &feeds); err != nil {
+			t.Fatalf("parse activity JSON: %v\n%s", err, out)
+		}
+		var feed []activityEntry
+		if err := json.Unmarshal(feeds[issue.ID], &feed); err != nil {
+			t.Fatalf("parse activity of %s: %v\n%s", issue.ID, err, out)
+		}
+		kinds := make(map[string]bool)
+		var summaries []string
+		for i, e := range feed {
+			kinds[e.Kind] = true
+			summaries = append(summaries, e.Summary)
+			if i > 0 && e.At.Before(feed[i-1].At) {
+				t.Errorf("feed not chronological at %d: %+v", i, feed)
+			}
+		}
+		for _, kind := range []string{activityEvent, activityComment, activityDependency, activityCommit} {
+			if !kinds[kind] {
+				t.Errorf("feed has no %s entries: %v", kind, summaries)
+			}
+		}
+		joined := strings.Join(summaries, "\n")
+		for _, want := range []string{"created", "added dependency on " + blocker.ID, "commented: Looking into it", "status open → in_progress"} {
+			if !strings.Contains(joined, want) {
+				t.Errorf("feed missing %q:\n%s", want, joined)
+			}
+		}
+
+		text := bdShowRaw(t, bd, dir, blocker.ID, "--activity")
+		if !strings.Contains(text, issue.ID+" added a dependency on this issue") || !strings.Contains(text, "just now") {
+			t.Errorf("blocker activity = %s", text)
+		}
+	})
+
 	// ===== --children =====
 
 	t.Run("show_children", func(t *testing.T) {
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// ShowTreeNode is one issue in 'bd show --tree' output.
+type ShowTreeNode struct {
+	ID        string               `json:"id"`
+	Title     string               `json:"title"`
+	Status    types.Status         `json:"status"`
+	IssueType types.IssueType      `json:"issue_type"`
+	Progress  *types.ChildProgress `json:"progress,omitempty"`
+	Children  []*ShowTreeNode      `json:"children,omitempty"`
+
+	// Set by bd tree only.
+	EstimatedMinutes *int                   `json:"estimated_minutes,omitempty"`
+	Rollup           *types.HierarchyRollup `json:"rollup,omitempty"`
+}
+
+// showIssueTrees renders the full parent-child hierarchy under each issue,
+// with a closed/total roll-up on every node that has children.
+func showIssueTrees(ctx context.Context, args []string, jsonOut bool) {
+	var trees []*ShowTreeNode
+	for _, id := range args {
+		result, err := resolveAndGetIssueWithRouting(ctx, store, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+			continue
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
+			continue
+		}
+		tree, err := loadShowTree(ctx, result.Store, result.ResolvedID)
+		result.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading tree for %s: %v\n", id, err)
+			continue
+		}
+		trees = append(trees, tree)
+	}
+
+	if jsonOut {
+		if len(trees) == 0 {
+			FatalErrorRespectJSON("no issues found matching the provided IDs")
+		}
+		outputJSON(trees)
+		return
+	}
+	if len(trees) == 0 {
+		os.Exit(1)
+	}
+	for i, tree := range trees {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(formatShowTreeNode(tree))
+		printShowTree(tree.Children, "")
+	}
+}
+
+// loadShowTree loads the descendants of rootID and fetches the roll-up for
+// every node in one aggregated query.
+func loadShowTree(ctx context.Context, s storage.DoltStorage, rootID string) (*ShowTreeNode, error) {
+	subgraph, err := loadTemplateSubgraph(ctx, s, rootID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(subgraph.Issues))
+	for i, issue := range subgraph.Issues {
+		ids[i] = issue.ID
+	}
+	progress, err := s.GetChildProgress(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading progress: %w", err)
+	}
+	return buildShowTree(subgraph, progress), nil
+}
+
+// buildShowTree converts a subgraph into a nested tree following
+// parent-child edges. Siblings are ordered by creation time, then ID, so
+// molecule steps appear in the order they were poured.
+func buildShowTree(subgraph *TemplateSubgraph, progress map[string]*types.ChildProgress) *ShowTreeNode {
+	childIDs := make(map[string][]string)
+	for _, dep := range subgraph.Dependencies {
+		if dep.Type == types.DepParentChild {
+			childIDs[dep.DependsOnID] = append(childIDs[dep.DependsOnID], dep.IssueID)
+		}
+	}
+
+	visited := make(map[string]bool)
+	var build func(issue *types.Issue) *ShowTreeNode
+	build = func(issue *types.Issue) *ShowTreeNode {
+		visited[issue.ID] = true
+		node := &ShowTreeNode{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    issue.Status,
+			IssueType: issue.IssueType,
+			Progress:  progress[issue.ID],
+		}
+		var children []*types.Issue
+		for _, id := range childIDs[issue.ID] {
+			if child, ok := subgraph.IssueMap[id]; ok && !visited[id] {
+				children = append(children, child)
+			}
+		}
+		sort.SliceStable(children, func(i, j int) bool {
+			if !children[i].CreatedAt.Equal(children[j].CreatedAt) {
+				return children[i].CreatedAt.Before(children[j].CreatedAt)
+			}
+			return children[i].ID < children[j].ID
+		})
+		for _, child := range children {
+			if !visited[child.ID] { // Cycle/diamond guard (GH#2719)
+				node.Children = append(node.Children, build(child))
+			}
+		}
+		return node
+	}
+	return build(subgraph.Root)
+}
+
+func printShowTree(nodes []*ShowTreeNode, prefix string) {
+	for i, node := range nodes {
+		connector, extension := "├── ", "│   "
+		if i == len(nodes)-1 {
+			connector, extension = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s\n", prefix, connector, formatShowTreeNode(node))
+		printShowTree(node.Children, prefix+extension)
+	}
+}
+
+func formatShowTreeNode(node *ShowTreeNode) string {
+	line := fmt.Sprintf("%s %s %s", renderStatusIcon(node.Status), node.ID, node.Title)
+	if node.Status == types.StatusClosed {
+		line = fmt.Sprintf("%s %s", renderStatusIcon(node.Status), ui.RenderMuted(node.ID+" "+node.Title))
+	}
+	if node.Progress != nil {
+		line += " " + ui.RenderMuted(formatChildProgress(node.Progress))
+	}
+	return line
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildShowTree(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	root := &types.Issue{ID: "mol-1", Title: "Release", IssueType: types.TypeEpic, CreatedAt: base}
+	step2 := &types.Issue{ID: "mol-1.2", Title: "Tag", CreatedAt: base.Add(2 * time.Minute)}
+	step1 := &types.Issue{ID: "mol-1.1", Title: "Build", Status: types.StatusClosed, CreatedAt: base.Add(time.Minute)}
+	sub := &types.Issue{ID: "mol-1.1.1", Title: "Compile", Status: types.StatusClosed, CreatedAt: base.Add(3 * time.Minute)}
+
+	subgraph := &TemplateSubgraph{
+		Root:     root,
+		Issues:   []*types.Issue{root, step2, step1, sub},
+		IssueMap: map[string]*types.Issue{root.ID: root, step1.ID: step1, step2.ID: step2, sub.ID: sub},
+		Dependencies: []*types.Dependency{
+			{IssueID: step2.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+			{IssueID: step1.ID, DependsOnID: root.ID, Type: types.DepParentChild},
+			{IssueID: step2.ID, DependsOnID: step1.ID, Type: types.DepBlocks},
+			{IssueID: sub.ID, DependsOnID: step1.ID, Type: types.DepParentChild},
+			{IssueID: root.ID, DependsOnID: sub.ID, Type: types.DepParentChild}, // cycle
+		},
+	}
+	progress := map[string]*types.ChildProgress{
+		root.ID:  types.NewChildProgress(2, 1),
+		step1.ID: types.NewChildProgress(1, 1),
+	}
+
+	tree := buildShowTree(subgraph, progress)
+	if tree.ID != root.ID || tree.Progress.Percent != 50 {
+		t.Fatalf("root = %+v", tree)
+	}
+	if len(tree.Children) != 2 || tree.Children[0].ID != step1.ID || tree.Children[1].ID != step2.ID {
+		t.Fatalf("steps not in creation order: %+v", tree.Children)
+	}
+	build := tree.Children[0]
+	if len(build.Children) != 1 || build.Children[0].ID != sub.ID {
+		t.Fatalf("nested step missing: %+v", build.Children)
+	}
+	if len(build.Children[0].Children) != 0 {
+		t.Error("cycle back to root should be cut")
+	}
+	if tree.Children[1].Progress != nil {
+		t.Error("leaf should have no progress")
+	}
+	if got := formatShowTreeNode(tree); !strings.Contains(got, "1/2 (50%)") {
+		t.Errorf("formatShowTreeNode(root) = %q, want roll-up", got)
+	}
+}
+
+func TestFormatIssueCompactWithProgress(t *testing.T) {
+	epic := &types.Issue{ID: "bd-1", Title: "Epic", IssueType: types.TypeEpic, Status: types.StatusOpen}
+	var buf strings.Builder
+	formatIssueCompactWithProgress(&buf, epic, nil, nil, nil, "", types.NewChildProgress(4, 3), 0)
+	if !strings.Contains(buf.String(), "Epic 3/4 (75%)") {
+		t.Errorf("compact output = %q, want roll-up after title", buf.String())
+	}
+
+	buf.Reset()
+	formatIssueLongWithProgress(&buf, epic, nil, false, types.NewChildProgress(4, 3))
+	if !strings.Contains(buf.String(), "Progress: 3/4 children closed (75%)") {
+		t.Errorf("long output = %q, want progress line", buf.String())
+	}
+
+	if !isProgressRoot(&types.Issue{IssueType: types.TypeMolecule}) || isProgressRoot(&types.Issue{IssueType: types.TypeTask}) {
+		t.Error("isProgressRoot should accept epics and molecules only")
+	}
+}
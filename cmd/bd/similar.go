@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/embedding"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// similarEmbedBatchSize bounds the number of texts sent to the embeddings
+// provider in one request.
+const similarEmbedBatchSize = 64
+
+var similarCmd = &cobra.Command{
+	Use:     "similar <issue-id | \"free text\">",
+	GroupID: "views",
+	Short:   "Find the issues most similar to an issue or a description",
+	Long: `Find the existing issues nearest to an issue or to free text, ranked by
+the cosine similarity of their embeddings. Run it before bd create to check
+whether the work is already filed.
+
+The argument is treated as an issue ID when it names an existing issue, and
+as free text otherwise. Issue embeddings are computed on first use and cached
+in the clone-local issue_vectors table; only issues whose title or
+description changed are re-embedded.
+
+Providers (config ai.embeddings.provider, or --provider):
+  local   Hashed word and character features (default; no network, lexical only)
+  ollama  An Ollama server's /api/embed (ai.embeddings.url, default
+          http://localhost:11434; model default nomic-embed-text)
+  openai  An OpenAI-compatible /embeddings API (ai.embeddings.url, default
+          https://api.openai.com/v1; requires OPENAI_API_KEY or
+          ai.embeddings.api_key; model default text-embedding-3-small)
+
+Examples:
+  bd similar bd-a3f8                          # Issues similar to bd-a3f8
+  bd similar "login fails after token expiry" # Check before filing
+  bd similar bd-a3f8 --status all -n 5        # Include closed issues
+  bd similar "flaky CI" --provider ollama     # Use a local Ollama model
+  bd similar bd-a3f8 --json                   # JSON output`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runSimilar,
+}
+
+func init() {
+	similarCmd.Flags().IntP("limit", "n", 10, "Maximum number of issues to show")
+	similarCmd.Flags().Float64("threshold", 0.2, "Minimum similarity (0.0-1.0)")
+	similarCmd.Flags().StringP("status", "s", "", "Filter by status, or 'all' (default: non-closed)")
+	similarCmd.Flags().String("provider", "", "Embeddings provider: local, ollama, openai (default from config ai.embeddings.provider)")
+	similarCmd.Flags().String("model", "", "Embedding model (default from config ai.embeddings.model)")
+	rootCmd.AddCommand(similarCmd)
+}
+
+// similarIssueJSON is one ranked result of bd similar --json.
+type similarIssueJSON struct {
+	ID         string          `json:"id"`
+	Title      string          `json:"title"`
+	Status     types.Status    `json:"status"`
+	Priority   int             `json:"priority"`
+	IssueType  types.IssueType `json:"issue_type"`
+	Similarity float64         `json:"similarity"`
+}
+
+// similarJSON is the bd similar --json payload.
+type similarJSON struct {
+	Query    string             `json:"query"`              // the issue ID or free text searched for
+	QueryID  string             `json:"query_id,omitempty"` // set when the query is an issue
+	Provider string             `json:"provider"`
+	Model    string             `json:"model"`
+	Results  []similarIssueJSON `json:"results"`
+}
+
+func runSimilar(cmd *cobra.Command, args []string) {
+	limit, _ := cmd.Flags().GetInt("limit")
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	status, _ := cmd.Flags().GetString("status")
+	providerName, _ := cmd.Flags().GetString("provider")
+	model, _ := cmd.Flags().GetString("model")
+
+	ctx := rootCtx
+
+	provider, err := embedding.NewProvider(embedding.Options{Provider: providerName, Model: model})
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	// A single argument that names an issue is an issue query; anything
+	// else is free text.
+	query := strings.Join(args, " ")
+	var queryIssue *types.Issue
+	if len(args) == 1 && !strings.ContainsAny(query, " \t\n") {
+		if result, err := resolveAndGetIssueWithRouting(ctx, store, query); err == nil && result != nil {
+			queryIssue = result.Issue
+			result.Close()
+		}
+	}
+
+	filter := types.IssueFilter{}
+	if status != "" && status != "all" {
+		s := types.Status(status)
+		filter.Status = &s
+	}
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("fetching issues: %v", err)
+	}
+	candidates := make([]*types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if status == "" && issue.Status == types.StatusClosed {
+			continue
+		}
+		if queryIssue != nil && issue.ID == queryIssue.ID {
+			continue
+		}
+		candidates = append(candidates, issue)
+	}
+
+	toEmbed := candidates
+	if queryIssue != nil {
+		toEmbed = append(toEmbed, queryIssue)
+	}
+	vectors, err := issueVectors(ctx, store, provider, toEmbed)
+	if err != nil {
+		FatalErrorRespectJSON("computing embeddings: %v", err)
+	}
+
+	var queryVector []float32
+	if queryIssue != nil {
+		queryVector = vectors[queryIssue.ID]
+	} else {
+		embedded, err := provider.Embed(ctx, []string{query})
+		if err != nil {
+			FatalErrorRespectJSON("computing embeddings: %v", err)
+		}
+		queryVector = embedded[0]
+	}
+
+	results := make([]similarIssueJSON, 0)
+	for _, issue := range candidates {
+		score := embedding.Cosine(queryVector, vectors[issue.ID])
+		if score < threshold {
+			continue
+		}
+		results = append(results, similarIssueJSON{
+			ID:         issue.ID,
+			Title:      issue.Title,
+			Status:     issue.Status,
+			Priority:   issue.Priority,
+			IssueType:  issue.IssueType,
+			Similarity: score,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Similarity != results[j].Similarity {
+			return results[i].Similarity > results[j].Similarity
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	if jsonOutput {
+		out := similarJSON{Query: query, Provider: provider.Name(), Model: provider.Model(), Results: results}
+		if queryIssue != nil {
+			out.QueryID = queryIssue.ID
+		}
+		outputJSON(out)
+		return
+	}
+
+	subject := fmt.Sprintf("%q", query)
+	if queryIssue != nil {
+		subject = queryIssue.ID
+	}
+	if len(results) == 0 {
+		fmt.Printf("No issues similar to %s (threshold %.2f)\n", subject, threshold)
+		return
+	}
+	fmt.Printf("\n%s Issues similar to %s (%s/%s):\n\n", ui.RenderAccent("🔍"), subject, provider.Name(), provider.Model())
+	for _, r := range results {
+		line := fmt.Sprintf("%s: %s [P%d - %s]", r.ID, r.Title, r.Priority, r.Status)
+		if r.Status == types.StatusClosed {
+			line = ui.RenderMuted(line)
+		}
+		fmt.Printf("  %.2f  %s\n", r.Similarity, line)
+	}
+	fmt.Println()
+}
+
+// issueVectors returns an embedding for each issue, keyed by ID. Vectors
+// cached for the provider and model are reused while the issue's text is
+// unchanged; the rest are embedded and, unless the store is read-only,
+// cached. Backends without a vector cache embed every issue.
+func issueVectors(ctx context.Context, s storage.DoltStorage, provider embedding.Provider, issues []*types.Issue) (map[string][]float32, error) {
+	vs, _ := storage.UnwrapStore(s).(storage.VectorStore)
+
+	cached := make(map[string]*storage.IssueVector)
+	if vs != nil {
+		stored, err := vs.GetIssueVectors(ctx, provider.Name(), provider.Model())
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range stored {
+			cached[v.IssueID] = v
+		}
+	}
+
+	vectors := make(map[string][]float32, len(issues))
+	var stale []*storage.IssueVector
+	var texts []string
+	for _, issue := range issues {
+		text := issueText(issue)
+		sum := sha256.Sum256([]byte(text))
+		hash := hex.EncodeToString(sum[:])
+		if v, ok := cached[issue.ID]; ok && v.ContentHash == hash {
+			vectors[issue.ID] = v.Vector
+			continue
+		}
+		stale = append(stale, &storage.IssueVector{
+			IssueID:     issue.ID,
+			Provider:    provider.Name(),
+			Model:       provider.Model(),
+			ContentHash: hash,
+		})
+		texts = append(texts, text)
+	}
+
+	for start := 0; start < len(texts); start += similarEmbedBatchSize {
+		end := min(start+similarEmbedBatchSize, len(texts))
+		embedded, err := provider.Embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for i, vec := range embedded {
+			stale[start+i].Vector = vec
+			vectors[stale[start+i].IssueID] = vec
+		}
+	}
+
+	if vs != nil && !readonlyMode && len(stale) > 0 {
+		// The vectors are already computed; a failed cache write only costs
+		// re-embedding next time.
+		if err := vs.UpsertIssueVectors(ctx, stale); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: caching embeddings: %v\n", err)
+		}
+	}
+	return vectors, nil
+}
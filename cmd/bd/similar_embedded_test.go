@@ -0,0 +1,93 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/embedding"
+)
+
+// bdSimilarJSON runs "bd similar --json" and parses the result.
+func bdSimilarJSON(t *testing.T, bd, dir string, args ...string) similarJSON {
+	t.Helper()
+	cmd := exec.Command(bd, append([]string{"similar", "--json"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(dir)
+	stdout, stderr, err := runCommandBuffers(t, cmd)
+	if err != nil {
+		t.Fatalf("bd similar --json %s failed: %v\nstdout:\n%s\nstderr:\n%s", strings.Join(args, " "), err, stdout.String(), stderr.String())
+	}
+	s := strings.TrimSpace(stdout.String())
+	var out similarJSON
+	if err := json.Unmarshal([]byte(s[strings.Index(s, "{"):]), &out); err != nil {
+		t.Fatalf("parse similar JSON: %v\n%s", err, s)
+	}
+	return out
+}
+
+func TestEmbeddedSimilar(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "sim")
+
+	login := bdCreate(t, bd, dir, "Login fails when session token expires", "--type", "bug",
+		"--description", "Users are logged out and the login page errors after the session token expires")
+	dup := bdCreate(t, bd, dir, "Session token expiry breaks login", "--type", "bug",
+		"--description", "After the token expires, logging in again fails with an error")
+	bdCreate(t, bd, dir, "Add dark mode to settings page", "--type", "feature",
+		"--description", "Offer a dark color theme toggle in user settings")
+
+	t.Run("issue_query", func(t *testing.T) {
+		out := bdSimilarJSON(t, bd, dir, login.ID)
+		if out.QueryID != login.ID {
+			t.Errorf("query_id = %q, want %q", out.QueryID, login.ID)
+		}
+		if out.Provider != embedding.ProviderLocal || out.Model != "hash-256" {
+			t.Errorf("provider/model = %s/%s, want local/hash-256", out.Provider, out.Model)
+		}
+		if len(out.Results) == 0 || out.Results[0].ID != dup.ID {
+			t.Fatalf("results = %+v, want %s first", out.Results, dup.ID)
+		}
+		for _, r := range out.Results {
+			if r.ID == login.ID {
+				t.Errorf("query issue %s listed in its own results", login.ID)
+			}
+		}
+	})
+
+	t.Run("free_text_query", func(t *testing.T) {
+		out := bdSimilarJSON(t, bd, dir, "dark theme for the settings")
+		if out.QueryID != "" {
+			t.Errorf("query_id = %q, want empty for free text", out.QueryID)
+		}
+		if len(out.Results) == 0 || out.Results[0].Title != "Add dark mode to settings page" {
+			t.Fatalf("results = %+v, want the dark mode issue first", out.Results)
+		}
+	})
+
+	t.Run("vectors_cached", func(t *testing.T) {
+		s := openStore(t, beadsDir, "sim")
+		vectors, err := s.GetIssueVectors(context.Background(), embedding.ProviderLocal, "hash-256")
+		if err != nil {
+			t.Fatalf("GetIssueVectors: %v", err)
+		}
+		if len(vectors) != 3 {
+			t.Errorf("cached %d vectors, want 3", len(vectors))
+		}
+		for _, v := range vectors {
+			if len(v.Vector) != 256 {
+				t.Errorf("%s: vector length %d, want 256", v.IssueID, len(v.Vector))
+			}
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// skillLabelPrefix marks a label as a capability an issue requires, e.g.
+// "skill:go". Actors offer capabilities through the assign.skills config map.
+const skillLabelPrefix = "skill:"
+
+// ActorSkills is one actor's offered capabilities in 'bd skills' output.
+type ActorSkills struct {
+	Actor  string   `json:"actor"`
+	Skills []string `json:"skills"`
+}
+
+// RequiredSkill is one capability required by open work in 'bd skills' output.
+type RequiredSkill struct {
+	Skill  string   `json:"skill"`
+	Issues int      `json:"issues"`
+	Actors []string `json:"actors"` // actors offering the skill; empty means nobody can take the work
+}
+
+// SkillsReport is the JSON document emitted by 'bd skills'.
+type SkillsReport struct {
+	Actors   []*ActorSkills   `json:"actors"`
+	Required []*RequiredSkill `json:"required"`
+}
+
+var skillsCmd = &cobra.Command{
+	Use:     "skills",
+	GroupID: "views",
+	Short:   "Show skills actors offer and skills open work requires",
+	Long: `Show capability tags for heterogeneous agent fleets.
+
+Issues require a skill by carrying a "skill:<name>" label:
+
+  bd label add bd-123 skill:go
+  bd create "Fix login page" -l skill:frontend -l skill:design
+
+Actors offer skills through the assign.skills map in config.yaml:
+
+  assign:
+    skills:
+      claude-1: [go, sql]
+      alice: [frontend, design]
+
+An actor can take an issue when it offers every skill the issue requires;
+issues with no skill labels suit anyone. 'bd ready --for <actor>' lists the
+ready work an actor can take, and 'bd assign --balance' only proposes
+assignments that match.
+
+This command lists each actor's skills and, for every skill required by
+open work, how many issues need it and which actors offer it.
+
+Examples:
+  bd skills
+  bd skills --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		required, err := requiredSkillCounts(rootCtx, store)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		report := buildSkillsReport(config.GetStringMapStringSlice("assign.skills"), required)
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		displaySkillsReport(report)
+	},
+}
+
+// requiredSkillCounts counts, per required skill, the issues that are not
+// closed and carry its skill label.
+func requiredSkillCounts(ctx context.Context, s storage.DoltStorage) (map[string]int, error) {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		return nil, fmt.Errorf("listing open issues: %w", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("getting labels: %w", err)
+	}
+	counts := map[string]int{}
+	for _, issueLabels := range labels {
+		for _, skill := range requiredSkills(issueLabels) {
+			counts[skill]++
+		}
+	}
+	return counts, nil
+}
+
+// requiredSkills returns the skills named by an issue's skill labels.
+func requiredSkills(labels []string) []string {
+	var skills []string
+	for _, label := range labels {
+		if skill, ok := strings.CutPrefix(label, skillLabelPrefix); ok && skill != "" {
+			skills = append(skills, skill)
+		}
+	}
+	return skills
+}
+
+// offeredSkills returns the skills actor offers in the assign.skills map.
+// Config map keys are case-insensitive, so the lookup is too.
+func offeredSkills(skills map[string][]string, actor string) []string {
+	if offered, ok := skills[actor]; ok {
+		return offered
+	}
+	for name, offered := range skills {
+		if strings.EqualFold(name, actor) {
+			return offered
+		}
+	}
+	return nil
+}
+
+// hasRequiredSkills reports whether offered covers every skill the labels
+// require.
+func hasRequiredSkills(offered, labels []string) bool {
+	for _, skill := range requiredSkills(labels) {
+		if !slices.Contains(offered, skill) {
+			return false
+		}
+	}
+	return true
+}
+
+// unofferedSkillLabels returns the labels of required skills that offered
+// does not cover. Excluding them from a ready query leaves only work the actor
+// can take.
+func unofferedSkillLabels(required map[string]int, offered []string) []string {
+	var out []string
+	for skill := range required {
+		if !slices.Contains(offered, skill) {
+			out = append(out, skillLabelPrefix+skill)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// buildSkillsReport combines the configured offers with the skills open work
+// requires. Actors and skills are sorted by name.
+func buildSkillsReport(skills map[string][]string, required map[string]int) *SkillsReport {
+	report := &SkillsReport{Actors: []*ActorSkills{}, Required: []*RequiredSkill{}}
+	for name, offered := range skills {
+		report.Actors = append(report.Actors, &ActorSkills{Actor: name, Skills: offered})
+	}
+	sort.Slice(report.Actors, func(i, j int) bool { return report.Actors[i].Actor < report.Actors[j].Actor })
+	for skill, n := range required {
+		r := &RequiredSkill{Skill: skill, Issues: n, Actors: []string{}}
+		for _, a := range report.Actors {
+			if slices.Contains(a.Skills, skill) {
+				r.Actors = append(r.Actors, a.Actor)
+			}
+		}
+		report.Required = append(report.Required, r)
+	}
+	sort.Slice(report.Required, func(i, j int) bool { return report.Required[i].Skill < report.Required[j].Skill })
+	return report
+}
+
+func displaySkillsReport(report *SkillsReport) {
+	if len(report.Actors) == 0 && len(report.Required) == 0 {
+		fmt.Printf("\n%s No skills configured or required\n", ui.RenderMuted("○"))
+		fmt.Printf("  Tag issues with 'skill:<name>' labels and set assign.skills in config.yaml.\n\n")
+		return
+	}
+	fmt.Printf("\n%s Offered skills:\n", ui.RenderAccent("🧰"))
+	if len(report.Actors) == 0 {
+		fmt.Printf("  %s\n", ui.RenderMuted("(none — set assign.skills in config.yaml)"))
+	}
+	for _, a := range report.Actors {
+		fmt.Printf("  %s: %s\n", ui.RenderAccent(a.Actor), strings.Join(a.Skills, ", "))
+	}
+	fmt.Printf("\n%s Required by open work:\n", ui.RenderAccent("🏷"))
+	if len(report.Required) == 0 {
+		fmt.Printf("  %s\n", ui.RenderMuted("(none)"))
+	}
+	for _, r := range report.Required {
+		who := strings.Join(r.Actors, ", ")
+		if len(r.Actors) == 0 {
+			who = ui.RenderWarn("no actor offers this")
+		}
+		fmt.Printf("  %s%s  (%d issues)  %s\n", skillLabelPrefix, r.Skill, r.Issues, who)
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(skillsCmd)
+}
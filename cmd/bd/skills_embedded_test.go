@@ -0,0 +1,87 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedReadyForActor(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, beadsDir, _ := bdInit(t, bd, "--prefix", "sk")
+	anyone := bdCreate(t, bd, dir, "Anyone can do this")
+	goWork := bdCreate(t, bd, dir, "Go work", "--labels", "skill:go")
+	goSQL := bdCreate(t, bd, dir, "Go and SQL work", "--labels", "skill:go,skill:sql")
+	design := bdCreate(t, bd, dir, "Design work", "--labels", "skill:design")
+
+	cfg := filepath.Join(beadsDir, "config.yaml")
+	f, err := os.OpenFile(cfg, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = f.WriteString("\nassign:\n  skills:\n    claude-1: [go, sql]\n    alice: [design]\n")
+	_ = f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	readyFor := func(actor string) []string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, "ready", "--for", actor, "--json")
+		if err != nil {
+			t.Fatalf("bd ready --for %s: %v\n%s", actor, err, out)
+		}
+		var issues []struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(out, &issues); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		ids := []string{}
+		for _, it := range issues {
+			ids = append(ids, it.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	sorted := func(ids ...string) []string {
+		slices.Sort(ids)
+		return ids
+	}
+
+	if got, want := readyFor("claude-1"), sorted(anyone.ID, goWork.ID, goSQL.ID); !slices.Equal(got, want) {
+		t.Errorf("ready --for claude-1 = %v, want %v", got, want)
+	}
+	if got, want := readyFor("alice"), sorted(anyone.ID, design.ID); !slices.Equal(got, want) {
+		t.Errorf("ready --for alice = %v, want %v", got, want)
+	}
+	if got, want := readyFor("nobody"), []string{anyone.ID}; !slices.Equal(got, want) {
+		t.Errorf("ready --for nobody = %v, want %v", got, want)
+	}
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "skills", "--json")
+	if err != nil {
+		t.Fatalf("bd skills: %v\n%s", err, out)
+	}
+	var report SkillsReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		t.Fatalf("parse skills: %v\n%s", err, out)
+	}
+	var summary []string
+	for _, r := range report.Required {
+		summary = append(summary, r.Skill+"="+strings.Join(r.Actors, "+"))
+	}
+	if want := []string{"design=alice", "go=claude-1", "sql=claude-1"}; !slices.Equal(summary, want) {
+		t.Errorf("required skills = %v, want %v", summary, want)
+	}
+}
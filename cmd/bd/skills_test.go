@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHasRequiredSkills(t *testing.T) {
+	tests := []struct {
+		name    string
+		offered []string
+		labels  []string
+		want    bool
+	}{
+		{"no requirements", nil, []string{"bug", "frontend"}, true},
+		{"all offered", []string{"go", "sql"}, []string{"skill:go", "skill:sql", "backend"}, true},
+		{"one missing", []string{"go"}, []string{"skill:go", "skill:sql"}, false},
+		{"nothing offered", nil, []string{"skill:go"}, false},
+		{"bare prefix ignored", nil, []string{"skill:"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasRequiredSkills(tt.offered, tt.labels); got != tt.want {
+				t.Errorf("hasRequiredSkills(%v, %v) = %v, want %v", tt.offered, tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOfferedSkills(t *testing.T) {
+	// Viper lowercases map keys, so actor lookups ignore case.
+	skills := map[string][]string{"claude-1": {"go"}}
+	if got := offeredSkills(skills, "Claude-1"); !slices.Equal(got, []string{"go"}) {
+		t.Errorf("offeredSkills(Claude-1) = %v, want [go]", got)
+	}
+	if got := offeredSkills(skills, "alice"); got != nil {
+		t.Errorf("offeredSkills(alice) = %v, want nil", got)
+	}
+}
+
+func TestUnofferedSkillLabels(t *testing.T) {
+	required := map[string]int{"go": 2, "sql": 1, "design": 4}
+	got := unofferedSkillLabels(required, []string{"go"})
+	if want := []string{"skill:design", "skill:sql"}; !slices.Equal(got, want) {
+		t.Errorf("unofferedSkillLabels = %v, want %v", got, want)
+	}
+}
+
+func TestBuildSkillsReport(t *testing.T) {
+	report := buildSkillsReport(
+		map[string][]string{"bob": {"go"}, "alice": {"go", "design"}},
+		map[string]int{"go": 3, "rust": 1},
+	)
+	if len(report.Actors) != 2 || report.Actors[0].Actor != "alice" {
+		t.Fatalf("actors = %+v, want alice first", report.Actors)
+	}
+	if len(report.Required) != 2 {
+		t.Fatalf("required = %+v, want 2 skills", report.Required)
+	}
+	goSkill, rust := report.Required[0], report.Required[1]
+	if goSkill.Skill != "go" || goSkill.Issues != 3 || !slices.Equal(goSkill.Actors, []string{"alice", "bob"}) {
+		t.Errorf("go = %+v, want 3 issues offered by alice and bob", goSkill)
+	}
+	if rust.Skill != "rust" || len(rust.Actors) != 0 {
+		t.Errorf("rust = %+v, want no actors", rust)
+	}
+}
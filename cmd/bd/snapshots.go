@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Config keys for snapshot retention, stored in the database config table.
+const (
+	snapshotsKeepLastKey = "snapshots.keep_last"
+	snapshotsKeepDaysKey = "snapshots.keep_days"
+)
+
+var snapshotsCmd = &cobra.Command{
+	Use:     "snapshots",
+	GroupID: "maint",
+	Short:   "Inspect and prune issue and compaction snapshots",
+	Long: `Inspect and prune the snapshot tables.
+
+issue_snapshots and compaction_snapshots keep copies of issues taken before
+they were compacted, so 'bd restore' and 'bd compact restore' can undo it.
+They are never pruned automatically; set a retention and run
+'bd snapshots prune' (e.g. from cron) to bound their size.
+
+Retention (per issue, applied to both tables):
+  snapshots.keep_last   Keep the N newest snapshots of each issue
+  snapshots.keep_days   Keep snapshots younger than M days
+
+A snapshot is kept when either rule keeps it. A rule set to 0 (the default)
+is disabled; with both disabled nothing is pruned. Pruned snapshots can no
+longer be restored.
+
+Examples:
+  bd config set snapshots.keep_last 2
+  bd config set snapshots.keep_days 90
+  bd snapshots status
+  bd snapshots prune --dry-run
+  bd snapshots prune --keep-last 1`,
+}
+
+var snapshotsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show snapshot table sizes and retention",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		pruner := snapshotPruner(store)
+		retention := loadSnapshotRetention(ctx, cmd)
+
+		usage, err := pruner.SnapshotUsage(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("failed to measure snapshots: %v", err)
+		}
+		pending, err := pruner.PruneSnapshots(ctx, retention, time.Now(), true)
+		if err != nil {
+			FatalErrorRespectJSON("failed to apply retention: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"tables":    usage,
+				"keep_last": retention.KeepLast,
+				"keep_days": retention.KeepDays,
+				"prunable":  pending,
+			})
+			return
+		}
+
+		for _, u := range usage {
+			fmt.Printf("%-22s %6d snapshots of %d issues, %s\n", u.Table, u.Rows, u.Issues, formatBytes(u.Bytes))
+		}
+		fmt.Printf("\nRetention: %s\n", describeSnapshotRetention(retention))
+		total, bytes := 0, int64(0)
+		for _, p := range pending {
+			total += p.Pruned
+			bytes += p.Bytes
+		}
+		if total > 0 {
+			fmt.Printf("%s %d snapshot(s) (%s) outside retention; run 'bd snapshots prune'\n",
+				ui.RenderWarn("!"), total, formatBytes(bytes))
+		}
+	},
+}
+
+var snapshotsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete snapshots outside the retention settings",
+	Long: `Delete snapshots outside retention from issue_snapshots and
+compaction_snapshots.
+
+Retention comes from snapshots.keep_last and snapshots.keep_days; --keep-last
+and --keep-days override them for this run.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("snapshots prune")
+		}
+		ctx := rootCtx
+		retention := loadSnapshotRetention(ctx, cmd)
+		if retention.KeepLast <= 0 && retention.KeepDays <= 0 {
+			FatalErrorWithHint("no snapshot retention configured",
+				"Set snapshots.keep_last or snapshots.keep_days, or pass --keep-last/--keep-days")
+		}
+
+		results, err := snapshotPruner(store).PruneSnapshots(ctx, retention, time.Now(), dryRun)
+		if err != nil {
+			FatalErrorRespectJSON("failed to prune snapshots: %v", err)
+		}
+		if !dryRun {
+			commandDidWrite.Store(true)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"dry_run":   dryRun,
+				"keep_last": retention.KeepLast,
+				"keep_days": retention.KeepDays,
+				"tables":    results,
+			})
+			return
+		}
+
+		verb := "Pruned"
+		if dryRun {
+			verb = "Would prune"
+		}
+		for _, r := range results {
+			fmt.Printf("%s %d %s row(s) (%s), kept %d\n", verb, r.Pruned, r.Table, formatBytes(r.Bytes), r.Kept)
+		}
+		if !dryRun {
+			fmt.Printf("%s Retention: %s\n", ui.RenderPass("✓"), describeSnapshotRetention(retention))
+		}
+	},
+}
+
+// snapshotPruner returns the store's snapshot pruning support, exiting when
+// the backend has none.
+func snapshotPruner(s storage.DoltStorage) storage.SnapshotPruner {
+	sp, ok := storage.UnwrapStore(s).(storage.SnapshotPruner)
+	if !ok {
+		FatalErrorRespectJSON("snapshot retention is not supported by this storage backend")
+	}
+	return sp
+}
+
+// loadSnapshotRetention reads retention from the database config, letting
+// --keep-last and --keep-days override it when the command has them set.
+func loadSnapshotRetention(ctx context.Context, cmd *cobra.Command) storage.SnapshotRetention {
+	all, err := store.GetAllConfig(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to read config: %v", err)
+	}
+	retention, errs := snapshotRetentionFromConfig(all)
+	for _, err := range errs {
+		WarnError("%v", err)
+	}
+	if cmd.Flags().Changed("keep-last") {
+		retention.KeepLast, _ = cmd.Flags().GetInt("keep-last")
+	}
+	if cmd.Flags().Changed("keep-days") {
+		retention.KeepDays, _ = cmd.Flags().GetInt("keep-days")
+	}
+	if retention.KeepLast < 0 || retention.KeepDays < 0 {
+		FatalErrorRespectJSON("snapshot retention must not be negative")
+	}
+	return retention
+}
+
+// snapshotRetentionFromConfig parses the retention keys from a config map
+// (as returned by GetAllConfig). Unparseable values are reported in errs
+// and left disabled.
+func snapshotRetentionFromConfig(all map[string]string) (retention storage.SnapshotRetention, errs []error) {
+	parse := func(key string) int {
+		value, ok := all[key]
+		if !ok || value == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			errs = append(errs, fmt.Errorf("%s: %q is not a non-negative integer; ignoring", key, value))
+			return 0
+		}
+		return n
+	}
+	retention.KeepLast = parse(snapshotsKeepLastKey)
+	retention.KeepDays = parse(snapshotsKeepDaysKey)
+	return retention, errs
+}
+
+// describeSnapshotRetention renders retention for humans.
+func describeSnapshotRetention(r storage.SnapshotRetention) string {
+	switch {
+	case r.KeepLast > 0 && r.KeepDays > 0:
+		return fmt.Sprintf("keep last %d per issue, or any younger than %d days", r.KeepLast, r.KeepDays)
+	case r.KeepLast > 0:
+		return fmt.Sprintf("keep last %d per issue", r.KeepLast)
+	case r.KeepDays > 0:
+		return fmt.Sprintf("keep %d days", r.KeepDays)
+	default:
+		return "none (snapshots are kept forever)"
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{snapshotsStatusCmd, snapshotsPruneCmd} {
+		c.Flags().Int("keep-last", 0, "Keep the N newest snapshots of each issue (overrides snapshots.keep_last)")
+		c.Flags().Int("keep-days", 0, "Keep snapshots younger than M days (overrides snapshots.keep_days)")
+	}
+	snapshotsPruneCmd.Flags().Bool("dry-run", false, "Show what would be pruned without deleting")
+	snapshotsCmd.AddCommand(snapshotsStatusCmd, snapshotsPruneCmd)
+	rootCmd.AddCommand(snapshotsCmd)
+}
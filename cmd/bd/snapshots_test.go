@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestSnapshotRetentionFromConfig(t *testing.T) {
+	got, errs := snapshotRetentionFromConfig(map[string]string{
+		snapshotsKeepLastKey: "3",
+		snapshotsKeepDaysKey: "90",
+		"compact.schedule":   "@daily",
+	})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if want := (storage.SnapshotRetention{KeepLast: 3, KeepDays: 90}); got != want {
+		t.Errorf("retention = %+v, want %+v", got, want)
+	}
+
+	got, errs = snapshotRetentionFromConfig(map[string]string{
+		snapshotsKeepLastKey: "many",
+		snapshotsKeepDaysKey: "-1",
+	})
+	if len(errs) != 2 {
+		t.Errorf("errs = %v, want 2", errs)
+	}
+	if got != (storage.SnapshotRetention{}) {
+		t.Errorf("retention = %+v, want disabled", got)
+	}
+}
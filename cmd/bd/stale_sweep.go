@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var staleSweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Label inactive issues stale and close them after a grace period",
+	Long: `Apply the stale issue policy: label open and in-progress issues that have
+had no activity for a while, and close them if they stay untouched.
+
+The policy is configured, and disabled until stale.label-after-days is set:
+
+  stale.label-after-days   Days without activity before an issue is labeled
+  stale.close-after-days   Days an issue stays labeled before it is closed
+                           (0 = label only, never close)
+  stale.label              Label used to mark stale issues (default: stale)
+  stale.exempt-labels      Issues with any of these labels are never touched
+  stale.exempt-priorities  Issues at these priorities are never touched
+
+Activity is any change or comment on the issue other than the stale label
+itself. An issue that sees activity after it was labeled has the label
+removed again, so commenting on a stale issue keeps it open. Closes are
+recorded with a reason naming the sweeper, so 'bd history' shows why.
+Issues locked by another actor (bd lock) are left alone and listed as
+skipped.
+
+Run it once, on a schedule from cron or CI, or keep it running with --every
+alongside a Dolt server. 'bd doctor' reports issues approaching staleness.
+
+Examples:
+  bd stale sweep --dry-run     # Show what would be labeled or closed
+  bd stale sweep               # Apply the policy now
+  bd stale sweep --every 1h    # Sweep every hour until interrupted`,
+	Args: cobra.NoArgs,
+	Run:  runStaleSweep,
+}
+
+// StaleSweptIssue is one issue labeled, unlabeled, or closed by the sweeper.
+type StaleSweptIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	IdleDays int    `json:"idle_days"`
+	LockedBy string `json:"locked_by,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// StaleSweepResult is the JSON output of one stale sweep pass.
+type StaleSweepResult struct {
+	Labeled   []*StaleSweptIssue `json:"labeled"`
+	Unlabeled []*StaleSweptIssue `json:"unlabeled"`
+	Closed    []*StaleSweptIssue `json:"closed"`
+	Locked    []*StaleSweptIssue `json:"locked,omitempty"`
+	Failed    []*StaleSweptIssue `json:"failed,omitempty"`
+	Scanned   int                `json:"scanned"`
+	DryRun    bool               `json:"dry_run,omitempty"`
+	SweptAt   time.Time          `json:"swept_at"`
+	Policy    struct {
+		LabelAfterDays   int      `json:"label_after_days"`
+		CloseAfterDays   int      `json:"close_after_days"`
+		Label            string   `json:"label"`
+		ExemptLabels     []string `json:"exempt_labels,omitempty"`
+		ExemptPriorities []int    `json:"exempt_priorities,omitempty"`
+	} `json:"policy"`
+}
+
+// staleAction is what the sweeper does to one issue.
+type staleAction int
+
+const (
+	staleActionNone staleAction = iota
+	staleActionLabel
+	staleActionUnlabel
+	staleActionClose
+)
+
+func runStaleSweep(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	every, _ := cmd.Flags().GetDuration("every")
+
+	if !dryRun {
+		CheckReadonly("stale sweep")
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+	if every < 0 {
+		FatalErrorRespectJSON("--every must be positive")
+	}
+	policy := config.GetStalePolicy()
+	if policy.LabelAfterDays <= 0 {
+		FatalErrorRespectJSON("stale policy is disabled (set stale.label-after-days)")
+	}
+	if policy.CloseAfterDays < 0 {
+		FatalErrorRespectJSON("stale.close-after-days must not be negative")
+	}
+
+	ctx := rootCtx
+	for {
+		result, err := sweepStaleIssues(ctx, store, policy, actor, dryRun, time.Now())
+		if err != nil {
+			if every == 0 {
+				FatalErrorRespectJSON("%v", err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: stale sweep failed: %v\n", err)
+		} else {
+			if !dryRun {
+				var ids []string
+				for _, group := range [][]*StaleSweptIssue{result.Labeled, result.Unlabeled, result.Closed} {
+					for _, it := range group {
+						ids = append(ids, it.ID)
+					}
+				}
+				if len(ids) > 0 {
+					if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+						Command:  "stale sweep",
+						IssueIDs: ids,
+					}); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to commit stale sweep: %v\n", err)
+					}
+				}
+			}
+			printStaleSweepResult(result)
+		}
+
+		if every == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(every):
+		}
+	}
+}
+
+// sweepStaleIssues applies policy to every open or in-progress issue that
+// is either inactive for policy.LabelAfterDays or already carries the stale
+// label. Labels and closes go through the store so they land in the events
+// table. Issues locked by an actor other than actorName are reported in
+// Locked and not touched.
+func sweepStaleIssues(ctx context.Context, s storage.DoltStorage, policy config.StalePolicy, actorName string, dryRun bool, now time.Time) (*StaleSweepResult, error) {
+	result := &StaleSweepResult{
+		Labeled:   []*StaleSweptIssue{},
+		Unlabeled: []*StaleSweptIssue{},
+		Closed:    []*StaleSweptIssue{},
+		DryRun:    dryRun,
+		SweptAt:   now,
+	}
+	result.Policy.LabelAfterDays = policy.LabelAfterDays
+	result.Policy.CloseAfterDays = policy.CloseAfterDays
+	result.Policy.Label = policy.Label
+	result.Policy.ExemptLabels = policy.ExemptLabels
+	result.Policy.ExemptPriorities = policy.ExemptPriorities
+
+	// updated_at is only a first cut: comments and labels do not bump it,
+	// so events and comments below decide whether an issue is inactive.
+	inactive, err := s.GetStaleIssues(ctx, types.StaleFilter{Days: policy.LabelAfterDays})
+	if err != nil {
+		return nil, fmt.Errorf("listing inactive issues: %w", err)
+	}
+	labeled, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		LabelsAny:     []string{policy.Label},
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s issues: %w", policy.Label, err)
+	}
+	seen := map[string]bool{}
+	var candidates []*types.Issue
+	for _, issue := range append(inactive, labeled...) {
+		if issue == nil || seen[issue.ID] {
+			continue
+		}
+		seen[issue.ID] = true
+		candidates = append(candidates, issue)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	result.Scanned = len(candidates)
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, issue := range candidates {
+		ids[i] = issue.ID
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading comments: %w", err)
+	}
+
+	ls, _ := storage.UnwrapStore(s).(storage.LockStore)
+	for _, issue := range candidates {
+		events, err := s.GetEvents(ctx, issue.ID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("loading events for %s: %w", issue.ID, err)
+		}
+		action, lastActivity := decideStaleAction(policy, issue, events, comments[issue.ID], now)
+		if action == staleActionNone {
+			continue
+		}
+		swept := &StaleSweptIssue{ID: issue.ID, Title: issue.Title, IdleDays: int(now.Sub(lastActivity).Hours() / 24)}
+		if ls != nil {
+			lock, err := ls.GetIssueLock(ctx, issue.ID)
+			if err != nil {
+				return nil, fmt.Errorf("checking lock on %s: %w", issue.ID, err)
+			}
+			if lock != nil && lock.Holder != actorName {
+				swept.LockedBy = issueops.DescribeIssueLock(lock)
+				result.Locked = append(result.Locked, swept)
+				continue
+			}
+		}
+		var applyErr error
+		var bucket *[]*StaleSweptIssue
+		switch action {
+		case staleActionLabel:
+			bucket = &result.Labeled
+			if !dryRun {
+				applyErr = s.AddLabel(ctx, issue.ID, policy.Label, actorName)
+			}
+		case staleActionUnlabel:
+			bucket = &result.Unlabeled
+			if !dryRun {
+				applyErr = s.RemoveLabel(ctx, issue.ID, policy.Label, actorName)
+			}
+		case staleActionClose:
+			bucket = &result.Closed
+			if !dryRun {
+				reason := fmt.Sprintf("stale: no activity for %d days", swept.IdleDays)
+				applyErr = s.CloseIssue(ctx, issue.ID, reason, actorName, "")
+			}
+		}
+		if applyErr != nil {
+			swept.Error = applyErr.Error()
+			result.Failed = append(result.Failed, swept)
+			continue
+		}
+		*bucket = append(*bucket, swept)
+	}
+	return result, nil
+}
+
+// decideStaleAction returns what the policy does to issue at now, along
+// with the time of its last activity: the latest of updated_at, its events,
+// and its comments. Adding or removing the stale label and priority aging
+// (bd age) are not activity.
+func decideStaleAction(policy config.StalePolicy, issue *types.Issue, events []*types.Event, comments []*types.Comment, now time.Time) (staleAction, time.Time) {
+	hasLabel := slices.Contains(issue.Labels, policy.Label)
+	lastActivity := issue.UpdatedAt
+	var labeledAt time.Time
+	for _, e := range events {
+		if e.EventType == types.EventPriorityAged {
+			continue
+		}
+		if isStaleLabelEvent(e, policy.Label) {
+			if e.EventType == types.EventLabelAdded && e.CreatedAt.After(labeledAt) {
+				labeledAt = e.CreatedAt
+			}
+			continue
+		}
+		if e.CreatedAt.After(lastActivity) {
+			lastActivity = e.CreatedAt
+		}
+	}
+	for _, c := range comments {
+		if c.CreatedAt.After(lastActivity) {
+			lastActivity = c.CreatedAt
+		}
+	}
+
+	if issue.Status == types.StatusClosed || issue.Status == types.StatusPinned {
+		return staleActionNone, lastActivity
+	}
+	if staleExempt(policy, issue) {
+		if hasLabel {
+			return staleActionUnlabel, lastActivity
+		}
+		return staleActionNone, lastActivity
+	}
+
+	day := 24 * time.Hour
+	if !hasLabel {
+		if now.Sub(lastActivity) >= time.Duration(policy.LabelAfterDays)*day {
+			return staleActionLabel, lastActivity
+		}
+		return staleActionNone, lastActivity
+	}
+	if labeledAt.IsZero() {
+		// Label predates event history; assume it was applied on schedule.
+		labeledAt = lastActivity.Add(time.Duration(policy.LabelAfterDays) * day)
+	}
+	if lastActivity.After(labeledAt) {
+		return staleActionUnlabel, lastActivity
+	}
+	if policy.CloseAfterDays > 0 && now.Sub(labeledAt) >= time.Duration(policy.CloseAfterDays)*day {
+		return staleActionClose, lastActivity
+	}
+	return staleActionNone, lastActivity
+}
+
+// isStaleLabelEvent reports whether e adds or removes label.
+func isStaleLabelEvent(e *types.Event, label string) bool {
+	if e.EventType != types.EventLabelAdded && e.EventType != types.EventLabelRemoved {
+		return false
+	}
+	if e.Comment == nil {
+		return false
+	}
+	return *e.Comment == "Added label: "+label || *e.Comment == "Removed label: "+label
+}
+
+// staleExempt reports whether the policy must leave issue alone.
+func staleExempt(policy config.StalePolicy, issue *types.Issue) bool {
+	return slices.Contains(issue.Labels, BeadsTemplateLabel) || policy.Exempt(issue.Labels, issue.Priority)
+}
+
+func printStaleSweepResult(result *StaleSweepResult) {
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	if isQuiet() && len(result.Failed) == 0 {
+		return
+	}
+	stamp := result.SweptAt.Format("15:04:05")
+	if len(result.Labeled)+len(result.Unlabeled)+len(result.Closed)+len(result.Locked)+len(result.Failed) == 0 {
+		fmt.Printf("[%s] No stale issues to sweep (%d issues scanned)\n", stamp, result.Scanned)
+		return
+	}
+	labelVerb, unlabelVerb, closeVerb := "Labeled", "Unlabeled", "Closed"
+	if result.DryRun {
+		labelVerb, unlabelVerb, closeVerb = "Would label", "Would unlabel", "Would close"
+	}
+	groups := []struct {
+		heading string
+		issues  []*StaleSweptIssue
+		icon    string
+	}{
+		{fmt.Sprintf("%s %d issue(s) %q", labelVerb, len(result.Labeled), result.Policy.Label), result.Labeled, ui.RenderWarn("⏰")},
+		{fmt.Sprintf("%s %d issue(s) with new activity", unlabelVerb, len(result.Unlabeled)), result.Unlabeled, ui.RenderPass("↺")},
+		{fmt.Sprintf("%s %d stale issue(s)", closeVerb, len(result.Closed)), result.Closed, ui.RenderPass("✓")},
+	}
+	for _, g := range groups {
+		if len(g.issues) == 0 {
+			continue
+		}
+		fmt.Printf("[%s] %s:\n", stamp, g.heading)
+		for _, it := range g.issues {
+			fmt.Printf("  %s %s (idle %d days)\n", g.icon, formatFeedbackID(it.ID, it.Title), it.IdleDays)
+		}
+	}
+	if len(result.Locked) > 0 {
+		fmt.Printf("[%s] Skipped %d locked issue(s):\n", stamp, len(result.Locked))
+		for _, it := range result.Locked {
+			fmt.Printf("  %s %s locked by %s\n", ui.RenderAccent("*"), formatFeedbackID(it.ID, it.Title), it.LockedBy)
+		}
+	}
+	for _, it := range result.Failed {
+		fmt.Printf("  %s %s: %s\n", ui.RenderFail("✗"), it.ID, it.Error)
+	}
+}
+
+func init() {
+	staleSweepCmd.Flags().Bool("dry-run", false, "Show what would be labeled or closed without changing anything")
+	staleSweepCmd.Flags().Duration("every", 0, "Keep running and sweep at this interval (e.g. 1h)")
+
+	staleCmd.AddCommand(staleSweepCmd)
+}
@@ -0,0 +1,82 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmbeddedStaleSweep(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ss")
+	revived := bdCreate(t, bd, dir, "Revived after labeling")
+	idle := bdCreate(t, bd, dir, "Still idle")
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := bdRunWithFlockRetry(t, bd, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+	sweep := func(args ...string) StaleSweepResult {
+		t.Helper()
+		out := run(append([]string{"stale", "sweep", "--json"}, args...)...)
+		var res StaleSweepResult
+		if err := json.Unmarshal([]byte(out), &res); err != nil {
+			t.Fatalf("parse: %v\n%s", err, out)
+		}
+		return res
+	}
+	ids := func(issues []*StaleSweptIssue) []string {
+		out := []string{}
+		for _, it := range issues {
+			out = append(out, it.ID)
+		}
+		return out
+	}
+
+	if out, err := bdRunWithFlockRetry(t, bd, dir, "stale", "sweep"); err == nil {
+		t.Fatalf("sweep with no policy should fail\n%s", out)
+	}
+
+	run("config", "set", "stale.label-after-days", "30")
+	run("label", "add", revived.ID, "stale")
+	run("label", "add", idle.ID, "stale")
+	time.Sleep(1100 * time.Millisecond) // event timestamps have second precision
+	run("comments", "add", revived.ID, "Still relevant")
+
+	dry := sweep("--dry-run")
+	if !dry.DryRun || !slices.Equal(ids(dry.Unlabeled), []string{revived.ID}) {
+		t.Errorf("dry run unlabeled = %v, want [%s]", ids(dry.Unlabeled), revived.ID)
+	}
+	if len(dry.Labeled) != 0 || len(dry.Closed) != 0 {
+		t.Errorf("dry run labeled=%v closed=%v, want none", ids(dry.Labeled), ids(dry.Closed))
+	}
+	if got := bdShow(t, bd, dir, revived.ID); !slices.Contains(got.Labels, "stale") {
+		t.Errorf("dry run removed the label: %v", got.Labels)
+	}
+
+	res := sweep()
+	if !slices.Equal(ids(res.Unlabeled), []string{revived.ID}) {
+		t.Errorf("unlabeled = %v, want [%s]", ids(res.Unlabeled), revived.ID)
+	}
+	if got := bdShow(t, bd, dir, revived.ID); slices.Contains(got.Labels, "stale") {
+		t.Errorf("%s still labeled stale: %v", revived.ID, got.Labels)
+	}
+	// Labeled just now and close-after-days is unset, so it stays open.
+	if got := bdShow(t, bd, dir, idle.ID); !slices.Contains(got.Labels, "stale") || got.Status != "open" {
+		t.Errorf("%s labels=%v status=%s, want stale/open", idle.ID, got.Labels, got.Status)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDecideStaleAction(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	strPtr := func(s string) *string { return &s }
+	labelAdded := func(at time.Time) *types.Event {
+		return &types.Event{EventType: types.EventLabelAdded, Comment: strPtr("Added label: stale"), CreatedAt: at}
+	}
+	commented := func(at time.Time) *types.Event {
+		return &types.Event{EventType: types.EventCommented, CreatedAt: at}
+	}
+	commentAt := func(at time.Time) []*types.Comment {
+		return []*types.Comment{{Text: "still relevant", CreatedAt: at}}
+	}
+	policy := config.StalePolicy{
+		LabelAfterDays:   30,
+		CloseAfterDays:   14,
+		Label:            "stale",
+		ExemptLabels:     []string{"keep"},
+		ExemptPriorities: []int{0},
+	}
+
+	tests := []struct {
+		name     string
+		policy   config.StalePolicy
+		issue    *types.Issue
+		events   []*types.Event
+		comments []*types.Comment
+		want     staleAction
+	}{
+		{
+			name:  "recently updated",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(5)},
+			want:  staleActionNone,
+		},
+		{
+			name:  "inactive past threshold",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(31)},
+			want:  staleActionLabel,
+		},
+		{
+			name:   "recent event keeps it fresh",
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(40)},
+			events: []*types.Event{commented(daysAgo(3))},
+			want:   staleActionNone,
+		},
+		{
+			name:   "priority aging is not activity",
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(40)},
+			events: []*types.Event{{EventType: types.EventPriorityAged, CreatedAt: daysAgo(2)}},
+			want:   staleActionLabel,
+		},
+		{
+			name:     "recent comment keeps it fresh",
+			issue:    &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(40)},
+			comments: commentAt(daysAgo(1)),
+			want:     staleActionNone,
+		},
+		{
+			name:  "exempt label",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(90), Labels: []string{"keep"}},
+			want:  staleActionNone,
+		},
+		{
+			name:  "exempt priority",
+			issue: &types.Issue{Status: types.StatusInProgress, Priority: 0, UpdatedAt: daysAgo(90)},
+			want:  staleActionNone,
+		},
+		{
+			name:  "template",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(90), Labels: []string{BeadsTemplateLabel}},
+			want:  staleActionNone,
+		},
+		{
+			name:   "labeled within grace period",
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(40), Labels: []string{"stale"}},
+			events: []*types.Event{labelAdded(daysAgo(10))},
+			want:   staleActionNone,
+		},
+		{
+			name:   "labeled past grace period",
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(50), Labels: []string{"stale"}},
+			events: []*types.Event{labelAdded(daysAgo(20))},
+			want:   staleActionClose,
+		},
+		{
+			name:   "label only policy never closes",
+			policy: config.StalePolicy{LabelAfterDays: 30, Label: "stale"},
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(50), Labels: []string{"stale"}},
+			events: []*types.Event{labelAdded(daysAgo(20))},
+			want:   staleActionNone,
+		},
+		{
+			name:   "activity after labeling",
+			issue:  &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(50), Labels: []string{"stale"}},
+			events: []*types.Event{commented(daysAgo(2)), labelAdded(daysAgo(20))},
+			want:   staleActionUnlabel,
+		},
+		{
+			name:  "labeled but now exempt",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(50), Labels: []string{"stale", "keep"}},
+			want:  staleActionUnlabel,
+		},
+		{
+			name:  "label without event history",
+			issue: &types.Issue{Status: types.StatusOpen, Priority: 2, UpdatedAt: daysAgo(50), Labels: []string{"stale"}},
+			want:  staleActionClose,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			if tt.policy.LabelAfterDays != 0 {
+				p = tt.policy
+			}
+			if got, _ := decideStaleAction(p, tt.issue, tt.events, tt.comments, now); got != tt.want {
+				t.Errorf("decideStaleAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
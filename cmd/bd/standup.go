@@ -0,0 +1,437 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var standupCmd = &cobra.Command{
+	Use:     "standup",
+	GroupID: "views",
+	Short:   "Summarize recent activity as a Markdown standup report",
+	Long: `Summarize what happened in a time window as Markdown, ready to paste into a
+status update or hand to an agent.
+
+The report lists issues that were:
+  closed       closed in the window
+  created      created in the window
+  reassigned   had their assignee changed in the window
+  blocked      are blocked now by a dependency added, or a status set to
+               blocked, in the window
+
+Items are grouped by the molecule (or epic) they belong to; everything else
+falls under "Other work".
+
+--actor limits the report to one person: events they performed plus issues
+assigned to them. "me" means the current actor (see 'bd config get actor').
+
+Examples:
+  bd standup                            # Everyone, since yesterday
+  bd standup --since yesterday --actor me
+  bd standup --since 7d --actor alice
+  bd standup --json                     # Structured output for agents`,
+	Args: cobra.NoArgs,
+	Run:  runStandup,
+}
+
+// StandupItem is one issue in a standup section.
+type StandupItem struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Status   string    `json:"status"`
+	Assignee string    `json:"assignee,omitempty"`
+	Actor    string    `json:"actor,omitempty"`
+	At       time.Time `json:"at"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// StandupGroup holds the activity for one molecule, or for issues outside
+// any molecule when MoleculeID is empty.
+type StandupGroup struct {
+	MoleculeID    string         `json:"molecule_id,omitempty"`
+	MoleculeTitle string         `json:"molecule_title,omitempty"`
+	Closed        []*StandupItem `json:"closed,omitempty"`
+	Created       []*StandupItem `json:"created,omitempty"`
+	Reassigned    []*StandupItem `json:"reassigned,omitempty"`
+	Blocked       []*StandupItem `json:"blocked,omitempty"`
+}
+
+// StandupReport is the JSON output of bd standup.
+type StandupReport struct {
+	Since  time.Time       `json:"since"`
+	Until  time.Time       `json:"until"`
+	Actor  string          `json:"actor,omitempty"`
+	Groups []*StandupGroup `json:"groups"`
+}
+
+// standupInputs is everything buildStandupReport needs, loaded up front so
+// the report itself is computed without touching the store.
+type standupInputs struct {
+	Events  []*types.Event
+	Issues  map[string]*types.Issue        // issues referenced by events or blocked
+	Blocked []*types.BlockedIssue          // currently blocked issues
+	Deps    map[string][]*types.Dependency // dependency records of blocked issues
+}
+
+func runStandup(cmd *cobra.Command, args []string) {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	who, _ := cmd.Flags().GetString("actor")
+	if who == "me" {
+		who = actor
+	}
+
+	now := time.Now()
+	since, err := parseWindowFlag(sinceFlag, now)
+	if err != nil {
+		FatalErrorRespectJSON("invalid --since: %v", err)
+	}
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+
+	ctx := rootCtx
+	in := &standupInputs{Issues: make(map[string]*types.Issue)}
+	if in.Events, err = store.GetAllEventsSince(ctx, since); err != nil {
+		FatalErrorRespectJSON("failed to load events: %v", err)
+	}
+	if in.Blocked, err = store.GetBlockedIssues(ctx, types.WorkFilter{}); err != nil {
+		FatalErrorRespectJSON("failed to load blocked issues: %v", err)
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	addID := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, e := range in.Events {
+		addID(e.IssueID)
+	}
+	blockedIDs := make([]string, len(in.Blocked))
+	for i, b := range in.Blocked {
+		blockedIDs[i] = b.ID
+		in.Issues[b.ID] = &b.Issue
+		addID(b.ID)
+	}
+	if len(blockedIDs) > 0 {
+		if in.Deps, err = store.GetDependencyRecordsForIssues(ctx, blockedIDs); err != nil {
+			FatalErrorRespectJSON("failed to load dependencies: %v", err)
+		}
+	}
+	if len(ids) > 0 {
+		issues, err := store.GetIssuesByIDs(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("failed to load issues: %v", err)
+		}
+		for _, issue := range issues {
+			in.Issues[issue.ID] = issue
+		}
+	}
+
+	report := buildStandupReport(in, who, since, now)
+
+	// Molecule membership and titles only for issues that made the report.
+	var reported []string
+	for _, item := range report.Groups[0].all() {
+		reported = append(reported, item.ID)
+	}
+	molecules := findParentMolecules(ctx, store, reported)
+	var rootIDs []string
+	for _, root := range molecules {
+		if in.Issues[root] == nil {
+			rootIDs = append(rootIDs, root)
+		}
+	}
+	if len(rootIDs) > 0 {
+		if roots, err := store.GetIssuesByIDs(ctx, rootIDs); err == nil {
+			for _, r := range roots {
+				in.Issues[r.ID] = r
+			}
+		}
+	}
+	report.Groups = groupStandupByMolecule(report.Groups[0], molecules, in.Issues)
+
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	writeStandupMarkdown(os.Stdout, report)
+}
+
+// buildStandupReport collects the window's activity into a single ungrouped
+// StandupGroup (Groups[0]); groupStandupByMolecule splits it afterwards.
+func buildStandupReport(in *standupInputs, who string, since, now time.Time) *StandupReport {
+	report := &StandupReport{Since: since, Until: now, Actor: who}
+	all := &StandupGroup{}
+
+	item := func(id string, e *types.Event) *StandupItem {
+		it := &StandupItem{ID: id}
+		if issue := in.Issues[id]; issue != nil {
+			it.Title = issue.Title
+			it.Status = string(issue.Status)
+			it.Assignee = issue.Assignee
+		}
+		if e != nil {
+			it.Actor = e.Actor
+			it.At = e.CreatedAt
+		}
+		return it
+	}
+	involves := func(e *types.Event, id string, extra ...string) bool {
+		if who == "" || (e != nil && e.Actor == who) {
+			return true
+		}
+		if issue := in.Issues[id]; issue != nil && issue.Assignee == who {
+			return true
+		}
+		for _, name := range extra {
+			if name == who {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Latest event per issue and section, so repeated edits list once.
+	closed := make(map[string]*StandupItem)
+	created := make(map[string]*StandupItem)
+	reassigned := make(map[string]*StandupItem)
+	blocked := make(map[string]*StandupItem)
+	keep := func(section map[string]*StandupItem, it *StandupItem) {
+		if prev := section[it.ID]; prev == nil || !it.At.Before(prev.At) {
+			section[it.ID] = it
+		}
+	}
+
+	for _, e := range in.Events {
+		if e.CreatedAt.Before(since) || e.CreatedAt.After(now) {
+			continue
+		}
+		switch e.EventType {
+		case types.EventClosed:
+			if involves(e, e.IssueID) {
+				keep(closed, item(e.IssueID, e))
+			}
+		case types.EventCreated:
+			if involves(e, e.IssueID) {
+				keep(created, item(e.IssueID, e))
+			}
+		case types.EventUpdated, types.EventStatusChanged:
+			update := standupEventFields(e.NewValue)
+			if newAssignee, ok := update["assignee"]; ok {
+				oldAssignee := standupEventFields(e.OldValue)["assignee"]
+				if oldAssignee != newAssignee && involves(e, e.IssueID, oldAssignee, newAssignee) {
+					it := item(e.IssueID, e)
+					it.Detail = fmt.Sprintf("%s → %s", standupName(oldAssignee), standupName(newAssignee))
+					keep(reassigned, it)
+				}
+			}
+			if update["status"] == string(types.StatusBlocked) && involves(e, e.IssueID) {
+				if issue := in.Issues[e.IssueID]; issue != nil && issue.Status == types.StatusBlocked {
+					it := item(e.IssueID, e)
+					it.Detail = "status set to blocked"
+					keep(blocked, it)
+				}
+			}
+		}
+	}
+
+	for _, b := range in.Blocked {
+		blockers := make(map[string]bool, len(b.BlockedBy))
+		for _, id := range b.BlockedBy {
+			blockers[id] = true
+		}
+		for _, dep := range in.Deps[b.ID] {
+			if !dep.Type.IsBlockingEdge() || !blockers[dep.DependsOnID] {
+				continue
+			}
+			if dep.CreatedAt.Before(since) || dep.CreatedAt.After(now) {
+				continue
+			}
+			e := &types.Event{Actor: dep.CreatedBy, CreatedAt: dep.CreatedAt}
+			if !involves(e, b.ID) {
+				continue
+			}
+			it := item(b.ID, e)
+			it.Detail = "blocked by " + dep.DependsOnID
+			keep(blocked, it)
+		}
+	}
+
+	all.Closed = sortedStandupItems(closed)
+	all.Created = sortedStandupItems(created)
+	all.Reassigned = sortedStandupItems(reassigned)
+	all.Blocked = sortedStandupItems(blocked)
+	report.Groups = []*StandupGroup{all}
+	return report
+}
+
+// standupEventFields decodes the string fields of an event's old/new value,
+// which hold JSON (the old issue, or the update map).
+func standupEventFields(raw *string) map[string]string {
+	fields := make(map[string]string)
+	if raw == nil || *raw == "" {
+		return fields
+	}
+	var decoded map[string]interface{}
+	if json.Unmarshal([]byte(*raw), &decoded) != nil {
+		return fields
+	}
+	for k, v := range decoded {
+		switch val := v.(type) {
+		case string:
+			fields[k] = val
+		case nil:
+			fields[k] = ""
+		}
+	}
+	return fields
+}
+
+func standupName(name string) string {
+	if name == "" {
+		return "(unassigned)"
+	}
+	return name
+}
+
+func sortedStandupItems(section map[string]*StandupItem) []*StandupItem {
+	items := make([]*StandupItem, 0, len(section))
+	for _, it := range section {
+		items = append(items, it)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].At.Equal(items[j].At) {
+			return items[i].At.Before(items[j].At)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
+
+// all returns every item in the group, across sections.
+func (g *StandupGroup) all() []*StandupItem {
+	var items []*StandupItem
+	for _, section := range [][]*StandupItem{g.Closed, g.Created, g.Reassigned, g.Blocked} {
+		items = append(items, section...)
+	}
+	return items
+}
+
+// groupStandupByMolecule splits an ungrouped report by molecule root.
+// Molecule groups are sorted by ID; "Other work" comes last.
+func groupStandupByMolecule(all *StandupGroup, molecules map[string]string, issues map[string]*types.Issue) []*StandupGroup {
+	groups := make(map[string]*StandupGroup)
+	get := func(id string) *StandupGroup {
+		root := molecules[id]
+		g := groups[root]
+		if g == nil {
+			g = &StandupGroup{MoleculeID: root}
+			if r := issues[root]; root != "" && r != nil {
+				g.MoleculeTitle = r.Title
+			}
+			groups[root] = g
+		}
+		return g
+	}
+	for _, it := range all.Closed {
+		g := get(it.ID)
+		g.Closed = append(g.Closed, it)
+	}
+	for _, it := range all.Created {
+		g := get(it.ID)
+		g.Created = append(g.Created, it)
+	}
+	for _, it := range all.Reassigned {
+		g := get(it.ID)
+		g.Reassigned = append(g.Reassigned, it)
+	}
+	for _, it := range all.Blocked {
+		g := get(it.ID)
+		g.Blocked = append(g.Blocked, it)
+	}
+
+	roots := make([]string, 0, len(groups))
+	for root := range groups {
+		if root != "" {
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+	out := make([]*StandupGroup, 0, len(groups))
+	for _, root := range roots {
+		out = append(out, groups[root])
+	}
+	if g := groups[""]; g != nil {
+		out = append(out, g)
+	}
+	return out
+}
+
+func writeStandupMarkdown(w io.Writer, report *StandupReport) {
+	title := "# Standup"
+	if report.Actor != "" {
+		title += ": " + report.Actor
+	}
+	fmt.Fprintf(w, "%s\n\n_%s → %s_\n", title,
+		report.Since.Local().Format("2006-01-02 15:04"), report.Until.Local().Format("2006-01-02 15:04"))
+
+	if len(report.Groups) == 0 {
+		fmt.Fprintf(w, "\nNo activity in this window.\n")
+		return
+	}
+	for _, g := range report.Groups {
+		switch {
+		case g.MoleculeID == "":
+			fmt.Fprintf(w, "\n## Other work\n")
+		case g.MoleculeTitle != "":
+			fmt.Fprintf(w, "\n## %s: %s\n", g.MoleculeID, g.MoleculeTitle)
+		default:
+			fmt.Fprintf(w, "\n## %s\n", g.MoleculeID)
+		}
+		writeStandupSection(w, "Closed", g.Closed)
+		writeStandupSection(w, "Created", g.Created)
+		writeStandupSection(w, "Reassigned", g.Reassigned)
+		writeStandupSection(w, "Newly blocked", g.Blocked)
+	}
+}
+
+func writeStandupSection(w io.Writer, heading string, items []*StandupItem) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n**%s**\n\n", heading)
+	for _, it := range items {
+		line := "- `" + it.ID + "`"
+		if it.Title != "" {
+			line += " " + it.Title
+		}
+		var notes []string
+		if it.Detail != "" {
+			notes = append(notes, it.Detail)
+		}
+		if it.Actor != "" {
+			notes = append(notes, "by "+it.Actor)
+		}
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func init() {
+	standupCmd.Flags().String("since", "yesterday", "Start of the window (e.g. yesterday, 24h, 7d, 2026-01-15)")
+	standupCmd.Flags().String("actor", "", `Only activity by or assigned to this actor ("me" for the current actor)`)
+	rootCmd.AddCommand(standupCmd)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildStandupReport(t *testing.T) {
+	now := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	at := func(h int) time.Time { return now.Add(time.Duration(h) * time.Hour) }
+	str := func(s string) *string { return &s }
+
+	in := &standupInputs{
+		Issues: map[string]*types.Issue{
+			"mol-1.1": {ID: "mol-1.1", Title: "Build", Status: types.StatusClosed, Assignee: "alice"},
+			"mol-1.2": {ID: "mol-1.2", Title: "Tag", Status: types.StatusOpen, Assignee: "bob"},
+			"bd-9":    {ID: "bd-9", Title: "Flaky test", Status: types.StatusOpen, Assignee: "alice"},
+			"bd-10":   {ID: "bd-10", Title: "Docs", Status: types.StatusBlocked, Assignee: "carol"},
+		},
+		Events: []*types.Event{
+			{IssueID: "mol-1.1", EventType: types.EventClosed, Actor: "alice", CreatedAt: at(-2)},
+			{IssueID: "bd-9", EventType: types.EventCreated, Actor: "alice", CreatedAt: at(-5)},
+			{IssueID: "mol-1.2", EventType: types.EventUpdated, Actor: "alice", CreatedAt: at(-3),
+				OldValue: str(`{"id":"mol-1.2","assignee":"alice"}`), NewValue: str(`{"assignee":"bob"}`)},
+			{IssueID: "bd-10", EventType: types.EventStatusChanged, Actor: "carol", CreatedAt: at(-1),
+				NewValue: str(`{"status":"blocked"}`)},
+			// Outside the window.
+			{IssueID: "bd-9", EventType: types.EventClosed, Actor: "alice", CreatedAt: at(-30)},
+		},
+		Blocked: []*types.BlockedIssue{
+			{Issue: types.Issue{ID: "mol-1.2", Title: "Tag", Assignee: "bob"}, BlockedBy: []string{"bd-9"}},
+		},
+		Deps: map[string][]*types.Dependency{
+			"mol-1.2": {
+				{IssueID: "mol-1.2", DependsOnID: "mol-1", Type: types.DepParentChild, CreatedAt: at(-4)},
+				{IssueID: "mol-1.2", DependsOnID: "bd-9", Type: types.DepBlocks, CreatedBy: "bob", CreatedAt: at(-4)},
+			},
+		},
+	}
+
+	all := buildStandupReport(in, "", since, now).Groups[0]
+	if len(all.Closed) != 1 || all.Closed[0].ID != "mol-1.1" {
+		t.Errorf("closed = %+v", all.Closed)
+	}
+	if len(all.Created) != 1 || all.Created[0].ID != "bd-9" {
+		t.Errorf("created = %+v", all.Created)
+	}
+	if len(all.Reassigned) != 1 || all.Reassigned[0].Detail != "alice → bob" {
+		t.Errorf("reassigned = %+v", all.Reassigned)
+	}
+	if len(all.Blocked) != 2 || all.Blocked[0].ID != "mol-1.2" || all.Blocked[0].Detail != "blocked by bd-9" {
+		t.Errorf("blocked = %+v", all.Blocked)
+	}
+
+	// Carol only touched bd-10.
+	carol := buildStandupReport(in, "carol", since, now).Groups[0]
+	if got := carol.all(); len(got) != 1 || got[0].ID != "bd-10" {
+		t.Errorf("carol's report = %+v", got)
+	}
+	// Bob was reassigned mol-1.2 and added its blocker.
+	bob := buildStandupReport(in, "bob", since, now).Groups[0]
+	if len(bob.Reassigned) != 1 || len(bob.Blocked) != 1 || len(bob.Closed) != 0 {
+		t.Errorf("bob's report = %+v", bob)
+	}
+}
+
+func TestGroupStandupByMoleculeMarkdown(t *testing.T) {
+	all := &StandupGroup{
+		Closed:  []*StandupItem{{ID: "mol-1.1", Title: "Build", Actor: "alice"}},
+		Created: []*StandupItem{{ID: "bd-9", Title: "Flaky test", Actor: "alice"}},
+	}
+	molecules := map[string]string{"mol-1.1": "mol-1"}
+	issues := map[string]*types.Issue{"mol-1": {ID: "mol-1", Title: "Release"}}
+
+	report := &StandupReport{
+		Since:  time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC),
+		Until:  time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC),
+		Actor:  "alice",
+		Groups: groupStandupByMolecule(all, molecules, issues),
+	}
+	if len(report.Groups) != 2 || report.Groups[0].MoleculeID != "mol-1" || report.Groups[1].MoleculeID != "" {
+		t.Fatalf("groups = %+v", report.Groups)
+	}
+
+	var buf strings.Builder
+	writeStandupMarkdown(&buf, report)
+	out := buf.String()
+	for _, want := range []string{
+		"# Standup: alice",
+		"## mol-1: Release",
+		"- `mol-1.1` Build (by alice)",
+		"## Other work",
+		"**Created**",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "## mol-1") > strings.Index(out, "## Other work") {
+		t.Error("molecule groups should precede Other work")
+	}
+
+	var empty strings.Builder
+	writeStandupMarkdown(&empty, &StandupReport{Since: report.Since, Until: report.Until})
+	if !strings.Contains(empty.String(), "No activity") {
+		t.Errorf("empty report = %q", empty.String())
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// defaultBranchPattern is used when git.branch-pattern is unset.
+const defaultBranchPattern = "{type}/{id}-{slug}"
+
+// branchMetadataKey is the issue metadata key holding the branch started
+// for the issue.
+const branchMetadataKey = "branch"
+
+var startCmd = &cobra.Command{
+	Use:     "start <issue-id>",
+	GroupID: "issues",
+	Short:   "Start work on an issue in a new git branch",
+	Long: `Start work on an issue: create a git branch for it, claim it, and record
+the branch on the issue.
+
+The branch name comes from git.branch-pattern (default "{type}/{id}-{slug}"):
+
+  {id}     issue ID                  {type}   issue type
+  {slug}   title, lowercased and     {actor}  who is starting the work
+           hyphenated
+
+The issue is claimed first (assigned to you and moved to in_progress), so
+an issue someone else already holds is refused before any branch is made.
+If the branch already exists it is checked out instead (--from is then
+ignored). The branch name is stored in the issue's "branch" metadata for
+later PR generation.
+
+Examples:
+  bd start bd-a1b2                        # feature/bd-a1b2-add-dark-mode
+  bd start bd-a1b2 --branch fix-login     # explicit branch name
+  bd start bd-a1b2 --from origin/main     # branch from a specific commit`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("start")
+		branch, _ := cmd.Flags().GetString("branch")
+		from, _ := cmd.Flags().GetString("from")
+		ctx := rootCtx
+
+		if !isGitRepo() {
+			FatalErrorRespectJSON("bd start must be run inside a git repository")
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			FatalErrorRespectJSON("issue %s not found", id)
+		}
+		if issue.Status == types.StatusClosed {
+			FatalErrorRespectJSON("%s is closed; reopen it before starting work", id)
+		}
+		if branch == "" {
+			branch = issueBranchName(config.GetString("git.branch-pattern"), issue, actor)
+		}
+		if err := checkBranchName(branch); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if strings.HasPrefix(from, "-") {
+			FatalErrorRespectJSON("invalid --from %q", from)
+		}
+
+		if err := store.ClaimIssue(ctx, id, actor); err != nil {
+			FatalErrorRespectJSON("cannot start %s: %v", id, err)
+		}
+		commandDidWrite.Store(true)
+
+		created, err := switchToBranch(branch, from)
+		if err != nil {
+			FatalErrorRespectJSON("claimed %s but could not switch to branch %s: %v", id, branch, err)
+		}
+
+		meta, err := json.Marshal(map[string]string{branchMetadataKey: branch})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		merged, err := mergeMetadata(issue.Metadata, meta)
+		if err != nil {
+			FatalErrorRespectJSON("failed to record branch on %s: %v", id, err)
+		}
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"metadata": merged}, actor); err != nil {
+			FatalErrorRespectJSON("failed to record branch on %s: %v", id, err)
+		}
+		if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+			Command:  "start",
+			IssueIDs: []string{id},
+		}); err != nil {
+			FatalErrorRespectJSON("failed to commit: %v", err)
+		}
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id":       id,
+				"branch":         branch,
+				"branch_created": created,
+				"status":         types.StatusInProgress,
+				"assignee":       actor,
+			})
+			return
+		}
+		verb := "Switched to existing branch"
+		if created {
+			verb = "Created branch"
+		}
+		fmt.Printf("%s Started %s\n", ui.RenderPass("✓"), formatFeedbackIDParen(id, issue.Title))
+		fmt.Printf("  %s %s\n", verb, ui.RenderAccent(branch))
+	},
+}
+
+// issueBranchName expands a git.branch-pattern for issue. An empty pattern
+// uses defaultBranchPattern.
+func issueBranchName(pattern string, issue *types.Issue, who string) string {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = defaultBranchPattern
+	}
+	slug := slugify(issue.Title)
+	if slug == "" {
+		slug = "work"
+	}
+	r := strings.NewReplacer(
+		"{id}", issue.ID,
+		"{slug}", slug,
+		"{type}", string(issue.IssueType),
+		"{actor}", slugify(who),
+	)
+	return r.Replace(pattern)
+}
+
+// checkBranchName rejects names git would refuse as a branch.
+func checkBranchName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	if out, err := exec.Command("git", "check-ref-format", "--branch", name).CombinedOutput(); err != nil { // #nosec G204 -- fixed git subcommand; name validated above
+		return fmt.Errorf("invalid branch name %q: %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// switchToBranch checks out branch, creating it from from (or HEAD) when it
+// does not exist yet. Reports whether the branch was created.
+func switchToBranch(branch, from string) (bool, error) {
+	exists := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch).Run() == nil // #nosec G204 -- branch validated by checkBranchName
+	args := []string{"switch", branch}
+	if !exists {
+		args = []string{"switch", "-c", branch}
+		if from != "" {
+			args = append(args, from)
+		}
+	}
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil { // #nosec G204 -- fixed git subcommand
+		return false, fmt.Errorf("%s", strings.TrimSpace(string(out)))
+	}
+	return !exists, nil
+}
+
+func init() {
+	startCmd.Flags().String("branch", "", "Branch name (default: from git.branch-pattern)")
+	startCmd.Flags().String("from", "", "Commit or branch to start the new branch from (default: HEAD)")
+	startCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(startCmd)
+}
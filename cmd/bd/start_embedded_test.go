@@ -0,0 +1,84 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedStart(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "st")
+	issue := bdCreate(t, bd, dir, "Add dark mode", "--type", "feature")
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("commit", "--allow-empty", "--no-verify", "-m", "initial")
+
+	start := func(who string, args ...string) (string, error) {
+		out, err := bdRunWithFlockRetry(t, bd, dir, append([]string{"--actor", who, "start"}, args...)...)
+		return string(out), err
+	}
+
+	out, err := start("alice", issue.ID, "--json")
+	if err != nil {
+		t.Fatalf("bd start: %v\n%s", err, out)
+	}
+	var res map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	want := "feature/" + issue.ID + "-add-dark-mode"
+	if res["branch"] != want || res["branch_created"] != true {
+		t.Errorf("result = %v, want new branch %s", res, want)
+	}
+	if got := git("branch", "--show-current"); got != want {
+		t.Errorf("current branch = %q, want %q", got, want)
+	}
+
+	got := bdShow(t, bd, dir, issue.ID)
+	if got.Status != types.StatusInProgress || got.Assignee != "alice" {
+		t.Errorf("issue status=%s assignee=%q, want in_progress/alice", got.Status, got.Assignee)
+	}
+	var meta map[string]string
+	if err := json.Unmarshal(got.Metadata, &meta); err != nil || meta["branch"] != want {
+		t.Errorf("metadata = %s, want branch %s", got.Metadata, want)
+	}
+
+	// Starting again switches back to the existing branch.
+	git("switch", "-c", "elsewhere")
+	if out, err := start("alice", issue.ID); err != nil || !strings.Contains(out, "Switched to existing branch") {
+		t.Errorf("restart: %v\n%s", err, out)
+	}
+	if got := git("branch", "--show-current"); got != want {
+		t.Errorf("current branch after restart = %q", got)
+	}
+
+	// Someone else cannot start an issue alice holds.
+	git("switch", "elsewhere")
+	if out, err := start("bob", issue.ID); err == nil {
+		t.Errorf("bob should be refused:\n%s", out)
+	}
+	if got := git("branch", "--show-current"); got != "elsewhere" {
+		t.Errorf("refused start switched branches to %q", got)
+	}
+}
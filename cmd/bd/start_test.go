@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueBranchName(t *testing.T) {
+	issue := &types.Issue{ID: "bd-a1b2", Title: "Add dark mode (settings page)!", IssueType: types.TypeFeature}
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", "feature/bd-a1b2-add-dark-mode-settings-page"},
+		{"{id}", "bd-a1b2"},
+		{"{actor}/{id}-{slug}", "alice-smith/bd-a1b2-add-dark-mode-settings-page"},
+	}
+	for _, tt := range tests {
+		if got := issueBranchName(tt.pattern, issue, "Alice Smith"); got != tt.want {
+			t.Errorf("issueBranchName(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+
+	untitled := &types.Issue{ID: "bd-x", Title: "???", IssueType: types.TypeBug}
+	if got := issueBranchName("", untitled, "a"); got != "bug/bd-x-work" {
+		t.Errorf("untitled branch = %q", got)
+	}
+}
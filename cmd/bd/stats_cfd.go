@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// cfdStatusOrder is the workflow order of the built-in statuses in a
+// cumulative flow report. Other statuses follow, sorted by name.
+var cfdStatusOrder = []string{
+	string(types.StatusOpen),
+	string(types.StatusInProgress),
+	string(types.StatusBlocked),
+	string(types.StatusDeferred),
+	string(types.StatusClosed),
+}
+
+// CFDDay holds the number of issues in each status at the end of Date (UTC).
+type CFDDay struct {
+	Date   string         `json:"date"`
+	Counts map[string]int `json:"counts"`
+	Total  int            `json:"total"`
+}
+
+// CFDReport is the JSON document emitted by 'bd stats --cfd'.
+type CFDReport struct {
+	Since    time.Time `json:"since"`
+	Until    time.Time `json:"until"`
+	Statuses []string  `json:"statuses"`
+	Days     []CFDDay  `json:"days"`
+}
+
+// runStatsCFD implements 'bd stats --cfd'.
+func runStatsCFD(cmd *cobra.Command) {
+	window, _ := cmd.Flags().GetString("since")
+	csvOutput, _ := cmd.Flags().GetBool("csv")
+
+	now := time.Now()
+	since, err := parseWindowFlag(window, now)
+	if err != nil {
+		FatalErrorRespectJSON("invalid --since: %v", err)
+	}
+
+	ctx := rootCtx
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{SkipWisps: true})
+	if err != nil {
+		FatalErrorRespectJSON("failed to load issues: %v", err)
+	}
+	events, err := store.GetAllEventsSince(ctx, since)
+	if err != nil {
+		FatalErrorRespectJSON("failed to load events: %v", err)
+	}
+
+	report := computeCFDReport(issues, events, since, now)
+	switch {
+	case jsonOutput:
+		outputJSON(report)
+	case csvOutput:
+		if err := writeCFDCSV(os.Stdout, report); err != nil {
+			FatalErrorRespectJSON("writing CSV: %v", err)
+		}
+	default:
+		printCFDReport(report)
+	}
+}
+
+// computeCFDReport reconstructs how many issues sat in each status at the
+// end of every UTC day from since through now. Each issue starts from its
+// current status and walks its status events in the window backwards; an
+// issue counts from the day it was created.
+func computeCFDReport(issues []*types.Issue, events []*types.Event, since, now time.Time) *CFDReport {
+	transitions := make(map[string][]*types.Event)
+	for _, e := range events {
+		switch e.EventType {
+		case types.EventStatusChanged, types.EventClosed, types.EventReopened:
+			transitions[e.IssueID] = append(transitions[e.IssueID], e)
+		}
+	}
+
+	first := time.Date(since.UTC().Year(), since.UTC().Month(), since.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	var dayEnds []time.Time
+	for d := first.AddDate(0, 0, 1); ; d = d.AddDate(0, 0, 1) {
+		if !d.Before(now) {
+			dayEnds = append(dayEnds, now)
+			break
+		}
+		dayEnds = append(dayEnds, d)
+	}
+
+	report := &CFDReport{Since: since, Until: now, Days: make([]CFDDay, len(dayEnds))}
+	for i := range dayEnds {
+		report.Days[i] = CFDDay{Date: first.AddDate(0, 0, i).Format("2006-01-02"), Counts: make(map[string]int)}
+	}
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		history := cfdStatusHistory(issue, transitions[issue.ID])
+		for i, end := range dayEnds {
+			if !issue.CreatedAt.Before(end) {
+				continue
+			}
+			status := history.at(end)
+			report.Days[i].Counts[status]++
+			report.Days[i].Total++
+			seen[status] = true
+		}
+	}
+
+	for _, s := range cfdStatusOrder {
+		report.Statuses = append(report.Statuses, s)
+		delete(seen, s)
+	}
+	var extra []string
+	for s := range seen {
+		extra = append(extra, s)
+	}
+	sort.Strings(extra)
+	report.Statuses = append(report.Statuses, extra...)
+	return report
+}
+
+// cfdHistory is an issue's status over time: before[i] is its status just
+// before changes[i]; after the last change it is current.
+type cfdHistory struct {
+	changes []time.Time
+	before  []string
+	current string
+}
+
+// at returns the status at instant t.
+func (h cfdHistory) at(t time.Time) string {
+	i := sort.Search(len(h.changes), func(i int) bool { return !h.changes[i].Before(t) })
+	if i == len(h.changes) {
+		return h.current
+	}
+	return h.before[i]
+}
+
+// cfdStatusHistory rebuilds an issue's statuses from its status events.
+// Update events carry the issue as it was before the change in old_value;
+// close events record only the reason, so the status before a close is the
+// previous event's target status, or in_progress/open from started_at.
+func cfdStatusHistory(issue *types.Issue, events []*types.Event) cfdHistory {
+	slices.SortStableFunc(events, func(a, b *types.Event) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	h := cfdHistory{current: string(issue.Status)}
+	for i, e := range events {
+		before := cfdEventStatus(e.OldValue)
+		if before == "" && i > 0 {
+			before = cfdEventTarget(events[i-1])
+		}
+		if before == "" {
+			before = string(types.StatusOpen)
+			if issue.StartedAt != nil && issue.StartedAt.Before(e.CreatedAt) {
+				before = string(types.StatusInProgress)
+			}
+		}
+		h.changes = append(h.changes, e.CreatedAt)
+		h.before = append(h.before, before)
+	}
+	return h
+}
+
+// cfdEventTarget returns the status an event moved its issue to.
+func cfdEventTarget(e *types.Event) string {
+	if s := cfdEventStatus(e.NewValue); s != "" {
+		return s
+	}
+	switch e.EventType {
+	case types.EventClosed:
+		return string(types.StatusClosed)
+	case types.EventReopened:
+		return string(types.StatusOpen)
+	}
+	return ""
+}
+
+// cfdEventStatus reads the "status" key from an event value holding JSON.
+func cfdEventStatus(value *string) string {
+	if value == nil || !strings.HasPrefix(strings.TrimSpace(*value), "{") {
+		return ""
+	}
+	var v struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal([]byte(*value), &v) != nil {
+		return ""
+	}
+	return v.Status
+}
+
+// writeCFDCSV writes one row per day with a column per status.
+func writeCFDCSV(out io.Writer, report *CFDReport) error {
+	w := csv.NewWriter(out)
+	header := append([]string{"date"}, report.Statuses...)
+	header = append(header, "total")
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, day := range report.Days {
+		row := []string{day.Date}
+		for _, s := range report.Statuses {
+			row = append(row, strconv.Itoa(day.Counts[s]))
+		}
+		row = append(row, strconv.Itoa(day.Total))
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func printCFDReport(report *CFDReport) {
+	fmt.Printf("\n%s Cumulative flow: %s → %s\n\n", ui.RenderAccent("📈"),
+		report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+
+	fmt.Printf("  %-10s", "DATE")
+	for _, s := range report.Statuses {
+		fmt.Printf(" %11s", strings.ToUpper(s))
+	}
+	fmt.Printf(" %7s\n", "TOTAL")
+	for _, day := range report.Days {
+		fmt.Printf("  %-10s", day.Date)
+		for _, s := range report.Statuses {
+			fmt.Printf(" %11d", day.Counts[s])
+		}
+		fmt.Printf(" %7d\n", day.Total)
+	}
+	fmt.Printf("\nUse --csv or --json to chart it.\n\n")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestComputeCFDReport(t *testing.T) {
+	// Four days: 03-15 through 03-18 (today, ending at now).
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	since := time.Date(2026, 3, 15, 8, 0, 0, 0, time.UTC)
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	str := func(s string) *string { return &s }
+
+	issues := []*types.Issue{
+		// Open on the 15th, in progress from the 16th, closed with bd close on the 17th.
+		{ID: "a", Status: types.StatusClosed, CreatedAt: day(10, 9), StartedAt: ptr(day(16, 9)), ClosedAt: ptr(day(17, 9))},
+		// Created on the 16th, blocked on the 17th via update.
+		{ID: "b", Status: types.StatusBlocked, CreatedAt: day(16, 10)},
+		// Closed before the window; no events in it.
+		{ID: "old", Status: types.StatusClosed, CreatedAt: day(1, 9), ClosedAt: ptr(day(2, 9))},
+		// Created today in a custom status.
+		{ID: "c", Status: types.Status("review"), CreatedAt: day(18, 8)},
+	}
+	events := []*types.Event{
+		{IssueID: "a", EventType: types.EventStatusChanged, OldValue: str(`{"id":"a","status":"open"}`), NewValue: str(`{"status":"in_progress"}`), CreatedAt: day(16, 9)},
+		{IssueID: "a", EventType: types.EventClosed, OldValue: str(""), NewValue: str("done"), CreatedAt: day(17, 9)},
+		{IssueID: "b", EventType: types.EventStatusChanged, OldValue: str(`{"id":"b","status":"open"}`), NewValue: str(`{"status":"blocked"}`), CreatedAt: day(17, 11)},
+		{IssueID: "b", EventType: types.EventCommented, CreatedAt: day(17, 12)},
+	}
+
+	report := computeCFDReport(issues, events, since, now)
+	if want := []string{"open", "in_progress", "blocked", "deferred", "closed", "review"}; !slices.Equal(report.Statuses, want) {
+		t.Fatalf("statuses = %v, want %v", report.Statuses, want)
+	}
+	want := []struct {
+		date   string
+		counts map[string]int
+	}{
+		{"2026-03-15", map[string]int{"open": 1, "closed": 1}},
+		{"2026-03-16", map[string]int{"open": 1, "in_progress": 1, "closed": 1}},
+		{"2026-03-17", map[string]int{"blocked": 1, "closed": 2}},
+		{"2026-03-18", map[string]int{"blocked": 1, "closed": 2, "review": 1}},
+	}
+	if len(report.Days) != len(want) {
+		t.Fatalf("days = %d, want %d", len(report.Days), len(want))
+	}
+	for i, w := range want {
+		got := report.Days[i]
+		total := 0
+		for _, n := range w.counts {
+			total += n
+		}
+		if got.Date != w.date || got.Total != total {
+			t.Errorf("day %d = %s total %d, want %s total %d", i, got.Date, got.Total, w.date, total)
+		}
+		for _, s := range report.Statuses {
+			if got.Counts[s] != w.counts[s] {
+				t.Errorf("%s %s = %d, want %d", w.date, s, got.Counts[s], w.counts[s])
+			}
+		}
+	}
+}
+
+func TestWriteCFDCSV(t *testing.T) {
+	report := &CFDReport{
+		Statuses: []string{"open", "closed"},
+		Days:     []CFDDay{{Date: "2026-03-15", Counts: map[string]int{"open": 2, "closed": 1}, Total: 3}},
+	}
+	var buf bytes.Buffer
+	if err := writeCFDCSV(&buf, report); err != nil {
+		t.Fatalf("writeCFDCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(rows) != 2 || !slices.Equal(rows[0], []string{"date", "open", "closed", "total"}) {
+		t.Fatalf("rows = %v", rows)
+	}
+	if want := []string{"2026-03-15", "2", "1", "3"}; !slices.Equal(rows[1], want) {
+		t.Errorf("row = %v, want %v", rows[1], want)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Segmentation dimensions for 'bd stats --flow --by'.
+const (
+	flowByLabel    = "label"
+	flowByAssignee = "assignee"
+	flowByType     = "type"
+)
+
+// flowAllSegment is the segment name for the unsegmented totals.
+const flowAllSegment = "all"
+
+// FlowDurations summarizes a set of durations, in hours.
+type FlowDurations struct {
+	Count    int     `json:"count"`
+	AvgHours float64 `json:"avg_hours"`
+	P50Hours float64 `json:"p50_hours"`
+	P85Hours float64 `json:"p85_hours"`
+}
+
+// FlowWeek is the number of issues closed in the ISO week starting WeekStart.
+type FlowWeek struct {
+	WeekStart string `json:"week_start"`
+	Closed    int    `json:"closed"`
+}
+
+// FlowSegment holds flow metrics for one label, assignee, or issue type.
+type FlowSegment struct {
+	Segment    string         `json:"segment"`
+	Closed     int            `json:"closed"`
+	LeadTime   *FlowDurations `json:"lead_time,omitempty"`
+	CycleTime  *FlowDurations `json:"cycle_time,omitempty"`
+	Throughput []FlowWeek     `json:"throughput"`
+}
+
+// FlowReport is the JSON document emitted by 'bd stats --flow'.
+type FlowReport struct {
+	Since    time.Time      `json:"since"`
+	Until    time.Time      `json:"until"`
+	GroupBy  string         `json:"group_by,omitempty"`
+	Segments []*FlowSegment `json:"segments"`
+}
+
+// runStatsFlow implements 'bd stats --flow'.
+func runStatsFlow(cmd *cobra.Command) {
+	window, _ := cmd.Flags().GetString("window")
+	groupBy, _ := cmd.Flags().GetString("by")
+	csvOutput, _ := cmd.Flags().GetBool("csv")
+
+	switch groupBy {
+	case "", flowByLabel, flowByAssignee, flowByType:
+	default:
+		FatalErrorRespectJSON("invalid --by %q (want label, assignee, or type)", groupBy)
+	}
+	now := time.Now()
+	since, err := parseWindowFlag(window, now)
+	if err != nil {
+		FatalErrorRespectJSON("invalid --window: %v", err)
+	}
+
+	ctx := rootCtx
+	closed, err := store.SearchIssues(ctx, "", types.IssueFilter{ClosedAfter: &since})
+	if err != nil {
+		FatalErrorRespectJSON("failed to load closed issues: %v", err)
+	}
+	events, err := store.GetAllEventsSince(ctx, since)
+	if err != nil {
+		FatalErrorRespectJSON("failed to load events: %v", err)
+	}
+	if groupBy == flowByLabel {
+		ids := make([]string, len(closed))
+		for i, issue := range closed {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("failed to load labels: %v", err)
+		}
+		for _, issue := range closed {
+			issue.Labels = labels[issue.ID]
+		}
+	}
+
+	report := computeFlowReport(closed, events, groupBy, since, now)
+	switch {
+	case jsonOutput:
+		outputJSON(report)
+	case csvOutput:
+		if err := writeFlowCSV(os.Stdout, report); err != nil {
+			FatalErrorRespectJSON("writing CSV: %v", err)
+		}
+	default:
+		printFlowReport(report)
+	}
+}
+
+// inProgressStarts returns, per issue, the earliest status_changed event
+// that moved it to in_progress. The event's new value is the JSON of the
+// update, so the target status is read from its "status" key.
+func inProgressStarts(events []*types.Event) map[string]time.Time {
+	starts := make(map[string]time.Time)
+	for _, e := range events {
+		if e.EventType != types.EventStatusChanged || e.NewValue == nil {
+			continue
+		}
+		var update struct {
+			Status string `json:"status"`
+		}
+		if json.Unmarshal([]byte(*e.NewValue), &update) != nil || update.Status != string(types.StatusInProgress) {
+			continue
+		}
+		if t, ok := starts[e.IssueID]; !ok || e.CreatedAt.Before(t) {
+			starts[e.IssueID] = e.CreatedAt
+		}
+	}
+	return starts
+}
+
+// computeFlowReport measures issues closed in [since, now]. Lead time runs
+// from creation to close. Cycle time runs from the first move to in_progress
+// to close, using the earlier of started_at and the status_changed event;
+// issues that never entered in_progress have no cycle time. The "all"
+// segment always comes first, followed by the --by segments sorted by name.
+func computeFlowReport(issues []*types.Issue, events []*types.Event, groupBy string, since, now time.Time) *FlowReport {
+	starts := inProgressStarts(events)
+
+	type accum struct {
+		lead, cycle []float64
+		weeks       map[string]int
+	}
+	segments := make(map[string]*accum)
+	add := func(name string, lead float64, cycle *float64, week string) {
+		a := segments[name]
+		if a == nil {
+			a = &accum{weeks: make(map[string]int)}
+			segments[name] = a
+		}
+		a.lead = append(a.lead, lead)
+		if cycle != nil {
+			a.cycle = append(a.cycle, *cycle)
+		}
+		a.weeks[week]++
+	}
+
+	for _, issue := range issues {
+		if issue.ClosedAt == nil || issue.ClosedAt.Before(since) || issue.ClosedAt.After(now) {
+			continue
+		}
+		closedAt := *issue.ClosedAt
+		lead := closedAt.Sub(issue.CreatedAt).Hours()
+
+		var cycle *float64
+		start, ok := starts[issue.ID]
+		if issue.StartedAt != nil && (!ok || issue.StartedAt.Before(start)) {
+			start, ok = *issue.StartedAt, true
+		}
+		if ok && !start.After(closedAt) {
+			hours := closedAt.Sub(start).Hours()
+			cycle = &hours
+		}
+
+		week := flowWeekStart(closedAt).Format("2006-01-02")
+		add(flowAllSegment, lead, cycle, week)
+		for _, name := range flowSegmentNames(issue, groupBy) {
+			add(name, lead, cycle, week)
+		}
+	}
+
+	var weeks []string
+	for w := flowWeekStart(since); !w.After(now); w = w.AddDate(0, 0, 7) {
+		weeks = append(weeks, w.Format("2006-01-02"))
+	}
+
+	names := make([]string, 0, len(segments))
+	for name := range segments {
+		if name != flowAllSegment {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{flowAllSegment}, names...)
+
+	report := &FlowReport{Since: since, Until: now, GroupBy: groupBy, Segments: []*FlowSegment{}}
+	for _, name := range names {
+		seg := &FlowSegment{Segment: name, Throughput: make([]FlowWeek, len(weeks))}
+		if a := segments[name]; a != nil {
+			seg.Closed = len(a.lead)
+			seg.LeadTime = summarizeFlowDurations(a.lead)
+			seg.CycleTime = summarizeFlowDurations(a.cycle)
+			for i, w := range weeks {
+				seg.Throughput[i] = FlowWeek{WeekStart: w, Closed: a.weeks[w]}
+			}
+		} else {
+			for i, w := range weeks {
+				seg.Throughput[i] = FlowWeek{WeekStart: w}
+			}
+		}
+		report.Segments = append(report.Segments, seg)
+	}
+	return report
+}
+
+// flowSegmentNames returns the segments an issue counts toward. An issue
+// with several labels counts once under each.
+func flowSegmentNames(issue *types.Issue, groupBy string) []string {
+	switch groupBy {
+	case flowByLabel:
+		if len(issue.Labels) == 0 {
+			return []string{"(no label)"}
+		}
+		return issue.Labels
+	case flowByAssignee:
+		if issue.Assignee == "" {
+			return []string{"(unassigned)"}
+		}
+		return []string{issue.Assignee}
+	case flowByType:
+		return []string{string(issue.IssueType)}
+	}
+	return nil
+}
+
+// flowWeekStart returns midnight UTC on the Monday of t's ISO week.
+func flowWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// summarizeFlowDurations returns mean and nearest-rank percentiles, or nil
+// for an empty set.
+func summarizeFlowDurations(hours []float64) *FlowDurations {
+	if len(hours) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, h := range sorted {
+		sum += h
+	}
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	return &FlowDurations{
+		Count:    len(sorted),
+		AvgHours: sum / float64(len(sorted)),
+		P50Hours: rank(0.50),
+		P85Hours: rank(0.85),
+	}
+}
+
+// writeFlowCSV writes one row per segment and week, repeating the segment's
+// window-wide lead and cycle times on each row so the file charts directly.
+func writeFlowCSV(out io.Writer, report *FlowReport) error {
+	w := csv.NewWriter(out)
+	header := []string{"segment", "week_start", "closed",
+		"lead_avg_hours", "lead_p50_hours", "lead_p85_hours",
+		"cycle_avg_hours", "cycle_p50_hours", "cycle_p85_hours"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	durationCols := func(d *FlowDurations) []string {
+		if d == nil {
+			return []string{"", "", ""}
+		}
+		return []string{formatFlowHours(d.AvgHours), formatFlowHours(d.P50Hours), formatFlowHours(d.P85Hours)}
+	}
+	for _, seg := range report.Segments {
+		for _, week := range seg.Throughput {
+			row := []string{seg.Segment, week.WeekStart, strconv.Itoa(week.Closed)}
+			row = append(row, durationCols(seg.LeadTime)...)
+			row = append(row, durationCols(seg.CycleTime)...)
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatFlowHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', 1, 64)
+}
+
+// formatFlowDuration renders hours compactly for the terminal (e.g. 5.5h, 3.2d).
+func formatFlowDuration(h float64) string {
+	if h < 48 {
+		return fmt.Sprintf("%.1fh", h)
+	}
+	return fmt.Sprintf("%.1fd", h/24)
+}
+
+func printFlowReport(report *FlowReport) {
+	fmt.Printf("\n%s Flow: %s → %s\n\n", ui.RenderAccent("📈"),
+		report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+
+	durations := func(d *FlowDurations) string {
+		if d == nil {
+			return fmt.Sprintf("%-22s", "-")
+		}
+		return fmt.Sprintf("%-22s", fmt.Sprintf("%s / %s / %s",
+			formatFlowDuration(d.P50Hours), formatFlowDuration(d.P85Hours), formatFlowDuration(d.AvgHours)))
+	}
+	fmt.Printf("  %-20s %7s  %-22s %-22s %s\n", "SEGMENT", "CLOSED", "LEAD p50/p85/avg", "CYCLE p50/p85/avg", "PER WEEK")
+	for _, seg := range report.Segments {
+		perWeek := 0.0
+		if len(seg.Throughput) > 0 {
+			perWeek = float64(seg.Closed) / float64(len(seg.Throughput))
+		}
+		fmt.Printf("  %-20s %7d  %s %s %.1f\n", seg.Segment, seg.Closed, durations(seg.LeadTime), durations(seg.CycleTime), perWeek)
+	}
+
+	if all := report.Segments[0]; len(all.Throughput) > 0 {
+		fmt.Printf("\nWeekly throughput (%s):\n", all.Segment)
+		peak := 0
+		for _, w := range all.Throughput {
+			peak = max(peak, w.Closed)
+		}
+		for _, w := range all.Throughput {
+			bar := ""
+			if peak > 0 {
+				bar = strings.Repeat("█", w.Closed*30/peak)
+			}
+			fmt.Printf("  %s %4d %s\n", w.WeekStart, w.Closed, ui.RenderAccent(bar))
+		}
+	}
+	fmt.Println()
+}
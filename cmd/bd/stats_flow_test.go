@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestComputeFlowReport(t *testing.T) {
+	// Wednesday 2026-03-18; the window covers two ISO weeks.
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	since := now.AddDate(0, 0, -7)
+	at := func(days float64) *time.Time {
+		t := now.Add(time.Duration(days * 24 * float64(time.Hour)))
+		return &t
+	}
+	inProgress := `{"status":"in_progress"}`
+
+	issues := []*types.Issue{
+		// 4-day lead, cycle from the status_changed event: 1 day.
+		{ID: "a", IssueType: types.TypeBug, Assignee: "ann", Labels: []string{"api"},
+			CreatedAt: *at(-5), ClosedAt: at(-1)},
+		// 4-day lead, started_at earlier than the event: 2 days of cycle.
+		{ID: "b", IssueType: types.TypeTask, Assignee: "bob", Labels: []string{"api", "ui"},
+			CreatedAt: *at(-8), StartedAt: at(-6), ClosedAt: at(-4)},
+		// Never in progress: lead time only.
+		{ID: "c", IssueType: types.TypeTask, CreatedAt: *at(-3), ClosedAt: at(-2)},
+		// Closed before the window.
+		{ID: "old", IssueType: types.TypeTask, CreatedAt: *at(-30), ClosedAt: at(-20)},
+	}
+	events := []*types.Event{
+		{IssueID: "a", EventType: types.EventStatusChanged, NewValue: &inProgress, CreatedAt: *at(-2)},
+		{IssueID: "a", EventType: types.EventStatusChanged, NewValue: &inProgress, CreatedAt: *at(-1.5)},
+		{IssueID: "b", EventType: types.EventStatusChanged, NewValue: &inProgress, CreatedAt: *at(-5)},
+	}
+
+	report := computeFlowReport(issues, events, flowByLabel, since, now)
+	names := make([]string, len(report.Segments))
+	for i, seg := range report.Segments {
+		names[i] = seg.Segment
+	}
+	if want := []string{"all", "(no label)", "api", "ui"}; !slices.Equal(names, want) {
+		t.Fatalf("segments = %v, want %v", names, want)
+	}
+
+	all := report.Segments[0]
+	if all.Closed != 3 {
+		t.Fatalf("all.Closed = %d, want 3", all.Closed)
+	}
+	if all.LeadTime.P50Hours != 96 || all.LeadTime.Count != 3 {
+		t.Errorf("lead time = %+v, want p50 96h over 3", all.LeadTime)
+	}
+	if all.CycleTime.Count != 2 || all.CycleTime.AvgHours != 36 {
+		t.Errorf("cycle time = %+v, want avg 36h over 2", all.CycleTime)
+	}
+	if len(all.Throughput) != 2 {
+		t.Fatalf("throughput weeks = %+v, want 2", all.Throughput)
+	}
+	if all.Throughput[0] != (FlowWeek{WeekStart: "2026-03-09", Closed: 1}) ||
+		all.Throughput[1] != (FlowWeek{WeekStart: "2026-03-16", Closed: 2}) {
+		t.Errorf("throughput = %+v", all.Throughput)
+	}
+
+	api := report.Segments[2]
+	if api.Closed != 2 {
+		t.Errorf("api.Closed = %d, want 2 (multi-label issues count under each)", api.Closed)
+	}
+	if noLabel := report.Segments[1]; noLabel.CycleTime != nil {
+		t.Errorf("issue never in progress should have no cycle time, got %+v", noLabel.CycleTime)
+	}
+}
+
+func TestFlowWeekStart(t *testing.T) {
+	sunday := time.Date(2026, 3, 22, 23, 0, 0, 0, time.UTC)
+	if got := flowWeekStart(sunday).Format("2006-01-02"); got != "2026-03-16" {
+		t.Errorf("flowWeekStart(Sunday) = %s, want Monday 2026-03-16", got)
+	}
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	if got := flowWeekStart(monday); !got.Equal(monday) {
+		t.Errorf("flowWeekStart(Monday) = %s", got)
+	}
+}
+
+func TestWriteFlowCSV(t *testing.T) {
+	report := &FlowReport{Segments: []*FlowSegment{{
+		Segment:    "all",
+		Closed:     1,
+		LeadTime:   &FlowDurations{Count: 1, AvgHours: 12, P50Hours: 12, P85Hours: 12},
+		Throughput: []FlowWeek{{WeekStart: "2026-03-09"}, {WeekStart: "2026-03-16", Closed: 1}},
+	}}}
+	var buf bytes.Buffer
+	if err := writeFlowCSV(&buf, report); err != nil {
+		t.Fatalf("writeFlowCSV: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "segment" {
+		t.Fatalf("rows = %v", rows)
+	}
+	if want := []string{"all", "2026-03-16", "1", "12.0", "12.0", "12.0", "", "", ""}; !slices.Equal(rows[2], want) {
+		t.Errorf("row = %v, want %v", rows[2], want)
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/issueops"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -39,6 +41,26 @@ average lead time), and recent activity over the last 24 hours from git history.
 Similar to how 'git status' shows working tree state, 'bd status' gives you
 a quick overview of your issue database without needing multiple queries.
 
+With --flow, report delivery flow instead of counts over a window (default
+90 days): lead time (created → closed), cycle time (first in_progress →
+closed), and weekly throughput. Segment with --by label|assignee|type and
+export with --json or --csv for charting.
+
+With --cfd, export cumulative flow data: the number of issues in each status
+at the end of every day (UTC) since --since (default 90 days), rebuilt from
+the events table. Export with --csv or --json for charting.
+
+With --group-by, show issue counts grouped by one dimension or pivoted by
+two (rows,columns): status, type, label, assignee, priority, or week (the
+Monday, UTC, of the week each issue was created). Combine with --assigned to
+limit to your issues; bd count --group-by offers the full set of filters.
+
+With --as-of, counts are computed from the issues table at a past commit,
+branch, or the latest commit before a date/time.
+
+Counts are cached per clone and reused until an issue changes, so repeat
+calls on large databases return immediately. Use --refresh to recompute.
+
 Use cases:
   - Quick project health check
   - Onboarding for new contributors
@@ -50,11 +72,18 @@ Examples:
   bd status --no-activity      # Skip git activity (faster)
   bd status --json             # JSON format output
   bd status --assigned         # Show issues assigned to current user
+  bd status --refresh          # Recompute cached counts
+  bd stats --as-of "last monday"  # Counts as of last Monday's commit
+  bd stats --flow              # Lead/cycle time and throughput, last 90 days
+  bd stats --flow --by assignee --window 30d --csv > flow.csv
+  bd stats --cfd --since 90d --csv > cfd.csv
+  bd stats --group-by assignee,status  # Pivot table of assignees × statuses
   bd stats                     # Alias for bd status`,
 	Run: func(cmd *cobra.Command, args []string) {
 		showAll, _ := cmd.Flags().GetBool("all")
 		showAssigned, _ := cmd.Flags().GetBool("assigned")
 		noActivity, _ := cmd.Flags().GetBool("no-activity")
+		refresh, _ := cmd.Flags().GetBool("refresh")
 		jsonFormat, _ := cmd.Flags().GetBool("json")
 
 		// Override global jsonOutput if --json flag is set
@@ -62,20 +91,80 @@ Examples:
 			jsonOutput = true
 		}
 
+		flow, _ := cmd.Flags().GetBool("flow")
+		if cfd, _ := cmd.Flags().GetBool("cfd"); cfd {
+			if flow {
+				FatalErrorRespectJSON("--cfd cannot be combined with --flow")
+			}
+			runStatsCFD(cmd)
+			return
+		}
+		if groupBySpec, _ := cmd.Flags().GetString("group-by"); groupBySpec != "" {
+			if flow {
+				FatalErrorRespectJSON("--group-by cannot be combined with --flow (use --by to segment flow metrics)")
+			}
+			if asOf, _ := cmd.Flags().GetString("as-of"); asOf != "" {
+				FatalErrorRespectJSON("--group-by cannot be combined with --as-of")
+			}
+			dims, err := parseCountGroupBy(groupBySpec)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			filter := types.IssueFilter{SkipWisps: true}
+			if showAssigned {
+				filter.Assignee = &actor
+			}
+			runCountGroupBy(rootCtx, filter, dims)
+			return
+		}
+
+		if flow {
+			runStatsFlow(cmd)
+			return
+		}
+
 		// Get statistics
 		var stats *types.Statistics
 		var err error
 
 		ctx := rootCtx
 
-		// Direct mode
-		stats, err = store.GetStatistics(ctx)
-		if err != nil {
-			FatalErrorRespectJSON("%v", err)
+		// Counts are materialized and reused until the issues table changes;
+		// --refresh drops the cached copy so they are recomputed now.
+		if refresh {
+			if err := store.SetLocalMetadata(ctx, issueops.StatisticsCacheKey, ""); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not clear cached statistics: %v\n", err)
+			}
+		}
+
+		asOf, _ := cmd.Flags().GetString("as-of")
+		if asOf != "" {
+			// Historical counts come straight from the rows at that commit;
+			// the cache and git activity describe the present.
+			ref, err := resolveAsOfRef(ctx, store, asOf)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			var filter types.IssueFilter
+			if showAssigned {
+				filter.Assignee = &actor
+			}
+			issues, err := store.SearchIssuesAsOf(ctx, ref, "", filter)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			stats = statisticsFromIssues(issues)
+			noActivity = true
+		} else {
+			// Direct mode
+			stats, err = store.GetStatistics(ctx)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
 		}
 
 		// Filter by assignee if requested (overrides stats with filtered counts)
-		if showAssigned {
+		if showAssigned && asOf == "" {
 			stats = getAssignedStatistics(actor)
 			if stats == nil {
 				FatalErrorRespectJSON("failed to get assigned statistics")
@@ -100,14 +189,20 @@ Examples:
 		}
 
 		// Human-readable colorized output using semantic ui package
-		fmt.Printf("\n%s Issue Database Status\n\n", ui.RenderAccent("📊"))
+		if asOf != "" {
+			fmt.Printf("\n%s Issue Database Status (as of %s)\n\n", ui.RenderAccent("📊"), ui.RenderMuted(asOf))
+		} else {
+			fmt.Printf("\n%s Issue Database Status\n\n", ui.RenderAccent("📊"))
+		}
 		fmt.Printf("Summary:\n")
 		fmt.Printf("  Total Issues:           %d\n", stats.TotalIssues)
 		fmt.Printf("  Open:                   %s\n", ui.RenderPass(fmt.Sprintf("%d", stats.OpenIssues)))
 		fmt.Printf("  In Progress:            %s\n", ui.RenderWarn(fmt.Sprintf("%d", stats.InProgressIssues)))
 		fmt.Printf("  Blocked:                %s\n", ui.RenderFail(fmt.Sprintf("%d", stats.BlockedIssues)))
 		fmt.Printf("  Closed:                 %d\n", stats.ClosedIssues)
-		fmt.Printf("  Ready to Work:          %s\n", ui.RenderPass(fmt.Sprintf("%d", stats.ReadyIssues)))
+		if asOf == "" {
+			fmt.Printf("  Ready to Work:          %s\n", ui.RenderPass(fmt.Sprintf("%d", stats.ReadyIssues)))
+		}
 
 		// Extended statistics (only show if non-zero)
 		hasExtended := stats.PinnedIssues > 0 ||
@@ -170,11 +265,26 @@ func getAssignedStatistics(assignee string) *types.Statistics {
 		return nil
 	}
 
+	stats := statisticsFromIssues(issues)
+
+	// Get ready work count for this assignee
+	readyFilter := types.WorkFilter{
+		Assignee: &assigneePtr,
+	}
+	readyIssues, err := store.GetReadyWork(ctx, readyFilter)
+	if err == nil {
+		stats.ReadyIssues = len(readyIssues)
+	}
+
+	return stats
+}
+
+// statisticsFromIssues counts issues by stored status. Ready work is left
+// to the caller, since it depends on the dependency graph.
+func statisticsFromIssues(issues []*types.Issue) *types.Statistics {
 	stats := &types.Statistics{
 		TotalIssues: len(issues),
 	}
-
-	// Count by status
 	for _, issue := range issues {
 		switch issue.Status {
 		case types.StatusOpen:
@@ -188,17 +298,10 @@ func getAssignedStatistics(assignee string) *types.Statistics {
 		case types.StatusClosed:
 			stats.ClosedIssues++
 		}
+		if issue.Pinned {
+			stats.PinnedIssues++
+		}
 	}
-
-	// Get ready work count for this assignee
-	readyFilter := types.WorkFilter{
-		Assignee: &assigneePtr,
-	}
-	readyIssues, err := store.GetReadyWork(ctx, readyFilter)
-	if err == nil {
-		stats.ReadyIssues = len(readyIssues)
-	}
-
 	return stats
 }
 
@@ -206,6 +309,15 @@ func init() {
 	statusCmd.Flags().Bool("all", false, "Show all issues (default behavior)")
 	statusCmd.Flags().Bool("assigned", false, "Show issues assigned to current user")
 	statusCmd.Flags().Bool("no-activity", false, "Skip git activity tracking (faster)")
+	statusCmd.Flags().Bool("refresh", false, "Recompute statistics instead of using cached counts")
+	statusCmd.Flags().Bool("flow", false, "Show lead time, cycle time, and weekly throughput")
+	statusCmd.Flags().String("window", "90d", "Time window for --flow (e.g. 30d, 12w)")
+	statusCmd.Flags().String("by", "", "Segment --flow by label, assignee, or type")
+	statusCmd.Flags().Bool("csv", false, "Output --flow or --cfd as CSV (one row per segment and week, or per day)")
+	statusCmd.Flags().Bool("cfd", false, "Show daily issue counts per status (cumulative flow diagram data)")
+	statusCmd.Flags().String("since", "90d", "Time window for --cfd (e.g. 30d, 12w, 2026-01-01)")
+	statusCmd.Flags().String("group-by", "", "Group counts by status|type|label|assignee|priority|week, or pivot by two (e.g. assignee,status)")
+	statusCmd.Flags().String("as-of", "", "Show counts as they were at a commit hash, branch, or date/time (e.g. 2026-01-05, -7d)")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(statusCmd)
 }
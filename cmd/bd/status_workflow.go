@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// loadStatusTransitions reads status.transitions from s. An unset key
+// yields nil, which allows every transition.
+func loadStatusTransitions(ctx context.Context, s storage.DoltStorage) (types.StatusTransitions, error) {
+	value, err := s.GetConfig(ctx, "status.transitions")
+	if err != nil {
+		return nil, fmt.Errorf("reading status.transitions: %w", err)
+	}
+	transitions, err := types.ParseStatusTransitions(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status.transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// checkStatusTransition returns an error when status.transitions does not
+// allow issue to move to the given status.
+func checkStatusTransition(ctx context.Context, s storage.DoltStorage, issue *types.Issue, to types.Status) error {
+	transitions, err := loadStatusTransitions(ctx, s)
+	if err != nil {
+		return err
+	}
+	if transitions.Allows(issue.Status, to) {
+		return nil
+	}
+	allowed := "none"
+	if targets := transitions.Targets(issue.Status); len(targets) > 0 {
+		names := make([]string, len(targets))
+		for i, t := range targets {
+			names[i] = string(t)
+		}
+		allowed = strings.Join(names, ", ")
+	}
+	return fmt.Errorf("cannot move %s from %s to %s: not allowed by status.transitions (allowed: %s)", issue.ID, issue.Status, to, allowed)
+}
+
+// loadStatusMap reads a status.import-map or status.export-map from s.
+func loadStatusMap(ctx context.Context, s storage.DoltStorage, key string) (types.StatusMap, error) {
+	value, err := s.GetConfig(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", key, err)
+	}
+	m, err := types.ParseStatusMap(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return m, nil
+}
+
+// validateStatusWorkflowConfig checks a status.transitions,
+// status.import-map, status.export-map, or status.policy.* value before bd
+// config set stores it. Other keys pass.
+func validateStatusWorkflowConfig(key, value string) error {
+	var err error
+	switch {
+	case key == "status.transitions":
+		_, err = types.ParseStatusTransitions(value)
+	case key == "status.import-map", key == "status.export-map":
+		_, err = types.ParseStatusMap(value)
+	case strings.HasPrefix(key, types.StatusPolicyPrefix):
+		_, err = types.ParseStatusPolicy(key, value)
+	}
+	return err
+}
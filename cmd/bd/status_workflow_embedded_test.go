@@ -0,0 +1,140 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedStatusWorkflow(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sw")
+
+	for _, kv := range [][2]string{
+		{"status.custom", "in_review:wip"},
+		{"status.transitions", "open->in_progress,in_progress->in_review,in_review->closed,*->open"},
+		{"status.import-map", "todo:open,doing:in_progress,review:in_review"},
+		{"status.export-map", "in_review:in_progress"},
+	} {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", kv[0], kv[1]); err != nil {
+			t.Fatalf("bd config set %s failed: %v\n%s", kv[0], err, out)
+		}
+	}
+
+	t.Run("invalid_config_rejected", func(t *testing.T) {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "status.transitions", "open=>closed"); err == nil {
+			t.Errorf("expected invalid status.transitions to be rejected:\n%s", out)
+		}
+	})
+
+	t.Run("transitions", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Workflow issue")
+
+		out := bdUpdateFail(t, bd, dir, issue.ID, "--status", "in_review")
+		if !strings.Contains(out, "not allowed by status.transitions (allowed: in_progress, open)") {
+			t.Errorf("unexpected error:\n%s", out)
+		}
+		out = bdCloseFail(t, bd, dir, issue.ID)
+		if !strings.Contains(out, "not allowed by status.transitions") {
+			t.Errorf("unexpected close error:\n%s", out)
+		}
+
+		bdUpdate(t, bd, dir, issue.ID, "--status", "in_progress")
+		bdUpdate(t, bd, dir, issue.ID, "--status", "in_review")
+		bdClose(t, bd, dir, issue.ID)
+		if got := bdShow(t, bd, dir, issue.ID); got.Status != types.StatusClosed {
+			t.Errorf("status = %s, want closed", got.Status)
+		}
+	})
+
+	t.Run("import_and_export_maps", func(t *testing.T) {
+		jsonlPath := filepath.Join(t.TempDir(), "import.jsonl")
+		now := time.Now().UTC()
+		writeJSONLFile(t, jsonlPath, []types.Issue{
+			{ID: "sw-todo", Title: "Mapped todo", Status: "todo", IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			{ID: "sw-review", Title: "Mapped review", Status: "review", IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		})
+		bdImport(t, bd, dir, jsonlPath)
+
+		if got := bdShow(t, bd, dir, "sw-todo"); got.Status != types.StatusOpen {
+			t.Errorf("sw-todo status = %s, want open", got.Status)
+		}
+		if got := bdShow(t, bd, dir, "sw-review"); got.Status != "in_review" {
+			t.Errorf("sw-review status = %s, want in_review", got.Status)
+		}
+
+		out := bdExport(t, bd, dir)
+		for _, line := range strings.Split(out, "\n") {
+			if strings.Contains(line, `"id":"sw-review"`) && !strings.Contains(line, `"status":"in_progress"`) {
+				t.Errorf("export did not map in_review:\n%s", line)
+			}
+		}
+	})
+}
+
+func TestEmbeddedStatusPolicy(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sp")
+
+	for _, kv := range [][2]string{
+		{"status.policy.bug.closed", "close_reason,commit"},
+		{"status.policy.*.in_progress", "assignee"},
+	} {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", kv[0], kv[1]); err != nil {
+			t.Fatalf("bd config set %s failed: %v\n%s", kv[0], err, out)
+		}
+	}
+
+	t.Run("invalid_config_rejected", func(t *testing.T) {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "config", "set", "status.policy.bug.closed", "owner"); err == nil {
+			t.Errorf("expected unknown policy field to be rejected:\n%s", out)
+		}
+	})
+
+	t.Run("in_progress_requires_assignee", func(t *testing.T) {
+		issue := bdCreate(t, bd, dir, "Policy task")
+		out := bdUpdateFail(t, bd, dir, issue.ID, "--status", "in_progress")
+		if !strings.Contains(out, "policy requires assignee") {
+			t.Errorf("unexpected error:\n%s", out)
+		}
+		bdUpdate(t, bd, dir, issue.ID, "--status", "in_progress", "--assignee", "alice")
+		if got := bdShow(t, bd, dir, issue.ID); got.Status != types.StatusInProgress {
+			t.Errorf("status = %s, want in_progress", got.Status)
+		}
+	})
+
+	t.Run("closing_bug_requires_reason_and_commit", func(t *testing.T) {
+		bug := bdCreate(t, bd, dir, "Policy bug", "--type", "bug")
+		out := bdCloseFail(t, bd, dir, bug.ID)
+		if !strings.Contains(out, "bug policy requires close_reason, commit") {
+			t.Errorf("unexpected error:\n%s", out)
+		}
+		out = bdCloseFail(t, bd, dir, bug.ID, "--reason", "Fixed")
+		if !strings.Contains(out, "bug policy requires commit") {
+			t.Errorf("unexpected error:\n%s", out)
+		}
+		bdClose(t, bd, dir, bug.ID, "--reason", "Fixed in a1b2c3d")
+		if got := bdShow(t, bd, dir, bug.ID); got.Status != types.StatusClosed {
+			t.Errorf("status = %s, want closed", got.Status)
+		}
+
+		task := bdCreate(t, bd, dir, "Policy-free task")
+		bdClose(t, bd, dir, task.ID)
+	})
+}
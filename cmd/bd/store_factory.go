@@ -58,9 +58,26 @@ func newDoltStore(ctx context.Context, cfg *dolt.Config) (storage.DoltStorage, e
 		// Read-only commands must not be bricked by the #4259
 		// remote-migrate gate (bd-578h9.5); server mode's ReadOnly opens
 		// already skip migration entirely.
-		return embeddeddolt.OpenForReadOnlyCommand(ctx, cfg.BeadsDir, cfg.Database, "main")
+		return openEmbeddedOnActiveBranch(cfg.BeadsDir, func(branch string) (storage.DoltStorage, error) {
+			return embeddeddolt.OpenForReadOnlyCommand(ctx, cfg.BeadsDir, cfg.Database, branch)
+		})
 	}
-	return embeddeddolt.Open(ctx, cfg.BeadsDir, cfg.Database, "main")
+	return openEmbeddedOnActiveBranch(cfg.BeadsDir, func(branch string) (storage.DoltStorage, error) {
+		return embeddeddolt.Open(ctx, cfg.BeadsDir, cfg.Database, branch)
+	})
+}
+
+// openEmbeddedOnActiveBranch opens an embedded store on the branch selected
+// with 'bd branch switch', explaining how to get back to main when that
+// branch can no longer be opened.
+func openEmbeddedOnActiveBranch(beadsDir string, open func(branch string) (storage.DoltStorage, error)) (storage.DoltStorage, error) {
+	branch := activeDoltBranch(beadsDir)
+	s, err := open(branch)
+	if err != nil && branch != defaultDoltBranch {
+		return nil, fmt.Errorf("open branch %q (selected in %s; delete that file to return to main): %w",
+			branch, filepath.Join(beadsDir, doltBranchFile), err)
+	}
+	return s, err
 }
 
 // acquireEmbeddedLock acquires an exclusive flock on the embeddeddolt data
@@ -114,7 +131,9 @@ func newDoltStoreFromConfig(ctx context.Context, beadsDir string) (storage.DoltS
 		}
 		database = sanitized
 	}
-	return embeddeddolt.Open(ctx, beadsDir, database, "main")
+	return openEmbeddedOnActiveBranch(beadsDir, func(branch string) (storage.DoltStorage, error) {
+		return embeddeddolt.Open(ctx, beadsDir, database, branch)
+	})
 }
 
 // migrateHyphenatedDB renames a legacy hyphenated database directory and
@@ -189,5 +208,7 @@ func newReadOnlyStoreFromConfig(ctx context.Context, beadsDir string) (storage.D
 	// run the remote-migrate gate (a behind, remote-backed database would fail
 	// hard) and must not write migrations into the target's history
 	// (bd-6dnrw.32, GH#3231).
-	return embeddeddolt.OpenReadOnly(ctx, beadsDir, database, "main")
+	return openEmbeddedOnActiveBranch(beadsDir, func(branch string) (storage.DoltStorage, error) {
+		return embeddeddolt.OpenReadOnly(ctx, beadsDir, database, branch)
+	})
 }
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Markers delimiting the summary block bd summarize --write maintains in the
+// epic's notes, so re-running replaces the block instead of appending.
+const (
+	summaryNotesStart = "<!-- bd summarize -->"
+	summaryNotesEnd   = "<!-- /bd summarize -->"
+)
+
+// defaultSummaryTemplate renders an epicSummaryData as Markdown.
+const defaultSummaryTemplate = `## {{.Epic.Title}} ({{.Epic.ID}})
+
+**Progress:** {{.Closed}}/{{.Total}} children closed ({{.Percent}}%){{if .StatusCounts}} ·{{range .StatusCounts}} {{.Status}}: {{.Count}}{{end}}{{end}}
+{{if .Children}}
+### Children
+{{range .Children}}
+- [{{if .Done}}x{{else}} {{end}}] {{.ID}}: {{.Title}} ({{.Status}}, P{{.Priority}}{{if .Assignee}}, @{{.Assignee}}{{end}})
+{{- end}}
+{{end}}{{if .Blocked}}
+### Blockers
+{{range .Blocked}}
+- {{.ID}} is blocked by {{range $i, $b := .BlockedBy}}{{if $i}}, {{end}}{{$b.ID}} ({{$b.Title}}, {{$b.Status}}){{end}}
+{{- end}}
+{{end}}{{if .Events}}
+### Recent activity
+{{range .Events}}
+- {{.Time}} {{.IssueID}}: {{.Text}}{{if .Actor}} by {{.Actor}}{{end}}
+{{- end}}
+{{end}}`
+
+var summarizeCmd = &cobra.Command{
+	Use:     "summarize <epic-id>",
+	GroupID: "views",
+	Short:   "Summarize an epic's children, blockers, and recent activity as Markdown",
+	Long: `Aggregate an epic's children, their statuses, blockers, and recent events
+into a concise Markdown summary.
+
+The summary is rendered from a Go text/template; pass --template to use your
+own. Templates see these fields:
+
+  .Epic           the epic (*types.Issue)
+  .Total .Closed  child counts; .Percent is the closed percentage
+  .StatusCounts   [{Status, Count}] for each status present
+  .Children       [{ID, Title, Status, Priority, Assignee, Done}]
+  .Blocked        open children with open blockers: [{ID, Title, BlockedBy}]
+  .Events         most recent child events: [{Time, IssueID, Actor, Text}]
+
+--polish asks an LLM to tighten the rendered Markdown, using the bd triage
+provider settings (ai.triage.provider, ai.triage.model, ai.triage.url).
+
+--write stores the summary in the epic's notes between marker comments, so
+running it again replaces the previous summary and leaves other notes alone.
+
+Examples:
+  bd summarize bd-a3f8                      # Print the summary
+  bd summarize bd-a3f8 --write              # Save it to the epic's notes
+  bd summarize bd-a3f8 --polish             # Let an LLM tighten the prose
+  bd summarize bd-a3f8 --template tmpl.md   # Use a custom template`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSummarize,
+}
+
+func init() {
+	summarizeCmd.Flags().String("template", "", "Go text/template file to render instead of the default")
+	summarizeCmd.Flags().Int("events", 10, "Number of recent child events to include")
+	summarizeCmd.Flags().Bool("polish", false, "Ask an LLM to tighten the rendered summary")
+	summarizeCmd.Flags().String("provider", "", "LLM provider for --polish: anthropic, openai (default from config ai.triage.provider)")
+	summarizeCmd.Flags().String("model", "", "Model for --polish (default from config ai.triage.model)")
+	summarizeCmd.Flags().Bool("write", false, "Write the summary to the epic's notes")
+	rootCmd.AddCommand(summarizeCmd)
+}
+
+// epicSummaryIssue is one issue as templates see it.
+type epicSummaryIssue struct {
+	ID       string
+	Title    string
+	Status   types.Status
+	Priority int
+	Assignee string
+	Done     bool
+}
+
+// epicSummaryBlocked is an open child and the open issues blocking it.
+type epicSummaryBlocked struct {
+	epicSummaryIssue
+	BlockedBy []epicSummaryIssue
+}
+
+// epicSummaryEvent is one recent event on a child.
+type epicSummaryEvent struct {
+	Time    string
+	IssueID string
+	Actor   string
+	Text    string
+}
+
+// epicSummaryData is the data summary templates render.
+type epicSummaryData struct {
+	Epic         *types.Issue
+	Total        int
+	Closed       int
+	Percent      int
+	StatusCounts []statusCount
+	Children     []epicSummaryIssue
+	Blocked      []epicSummaryBlocked
+	Events       []epicSummaryEvent
+}
+
+type statusCount struct {
+	Status types.Status
+	Count  int
+}
+
+// summarizeJSON is the bd summarize --json payload.
+type summarizeJSON struct {
+	EpicID   string `json:"epic_id"`
+	Markdown string `json:"markdown"`
+	Polished bool   `json:"polished"`
+	Written  bool   `json:"written"`
+}
+
+func runSummarize(cmd *cobra.Command, args []string) {
+	templatePath, _ := cmd.Flags().GetString("template")
+	eventLimit, _ := cmd.Flags().GetInt("events")
+	polish, _ := cmd.Flags().GetBool("polish")
+	providerName, _ := cmd.Flags().GetString("provider")
+	model, _ := cmd.Flags().GetString("model")
+	write, _ := cmd.Flags().GetBool("write")
+
+	ctx := rootCtx
+	if write {
+		CheckReadonly("summarize --write")
+	}
+
+	tmplText := defaultSummaryTemplate
+	if templatePath != "" {
+		data, err := os.ReadFile(templatePath) // #nosec G304 -- user-provided path is intentional
+		if err != nil {
+			FatalErrorRespectJSON("reading template: %v", err)
+		}
+		tmplText = string(data)
+	}
+	tmpl, err := template.New("summary").Parse(tmplText)
+	if err != nil {
+		FatalErrorRespectJSON("parsing template: %v", err)
+	}
+
+	result, err := resolveAndGetIssueWithRouting(ctx, store, args[0])
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+	}
+	if result == nil || result.Issue == nil {
+		if result != nil {
+			result.Close()
+		}
+		FatalErrorRespectJSON("issue %s not found", args[0])
+	}
+	defer result.Close()
+	epic := result.Issue
+
+	data, err := buildEpicSummary(ctx, result.Store, epic, eventLimit)
+	if err != nil {
+		FatalErrorRespectJSON("summarizing %s: %v", epic.ID, err)
+	}
+	markdown, err := renderEpicSummary(tmpl, data)
+	if err != nil {
+		FatalErrorRespectJSON("rendering template: %v", err)
+	}
+
+	if polish {
+		llm, err := newTriageLLM(providerName, model)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		polished, err := polishSummary(ctx, llm, markdown)
+		if err != nil {
+			FatalErrorRespectJSON("polishing summary: %v", err)
+		}
+		markdown = polished
+	}
+
+	if write {
+		notes := replaceSummaryBlock(epic.Notes, markdown)
+		if err := result.Store.UpdateIssue(ctx, epic.ID, map[string]interface{}{"notes": notes}, actor); err != nil {
+			FatalErrorRespectJSON("writing notes of %s: %v", epic.ID, err)
+		}
+		commandDidWrite.Store(true)
+	}
+
+	if jsonOutput {
+		outputJSON(summarizeJSON{EpicID: epic.ID, Markdown: markdown, Polished: polish, Written: write})
+		return
+	}
+	fmt.Print(markdown)
+	if write {
+		fmt.Printf("\n%s Summary written to %s notes\n", ui.RenderPass("✓"), epic.ID)
+	}
+}
+
+// buildEpicSummary gathers the children of epic, their blockers, and their
+// most recent eventLimit events.
+func buildEpicSummary(ctx context.Context, s storage.DoltStorage, epic *types.Issue, eventLimit int) (*epicSummaryData, error) {
+	parentID := epic.ID
+	children, err := s.SearchIssues(ctx, "", types.IssueFilter{ParentID: &parentID})
+	if err != nil {
+		return nil, fmt.Errorf("getting children: %w", err)
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if children[i].Priority != children[j].Priority {
+			return children[i].Priority < children[j].Priority
+		}
+		return children[i].ID < children[j].ID
+	})
+
+	data := &epicSummaryData{Epic: epic, Total: len(children)}
+	counts := make(map[types.Status]int)
+	var events []*types.Event
+	for _, child := range children {
+		counts[child.Status]++
+		item := summaryIssue(child)
+		data.Children = append(data.Children, item)
+		if item.Done {
+			data.Closed++
+		} else {
+			blockers, err := openBlockers(ctx, s, child.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(blockers) > 0 {
+				data.Blocked = append(data.Blocked, epicSummaryBlocked{epicSummaryIssue: item, BlockedBy: blockers})
+			}
+		}
+		if eventLimit > 0 {
+			childEvents, err := s.GetEvents(ctx, child.ID, eventLimit)
+			if err != nil {
+				return nil, fmt.Errorf("getting events of %s: %w", child.ID, err)
+			}
+			events = append(events, childEvents...)
+		}
+	}
+	if data.Total > 0 {
+		data.Percent = data.Closed * 100 / data.Total
+	}
+	for status, n := range counts {
+		data.StatusCounts = append(data.StatusCounts, statusCount{Status: status, Count: n})
+	}
+	sort.Slice(data.StatusCounts, func(i, j int) bool { return data.StatusCounts[i].Status < data.StatusCounts[j].Status })
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.After(events[j].CreatedAt) })
+	if eventLimit > 0 && len(events) > eventLimit {
+		events = events[:eventLimit]
+	}
+	for _, e := range events {
+		data.Events = append(data.Events, epicSummaryEvent{
+			Time:    e.CreatedAt.Local().Format("2006-01-02 15:04"),
+			IssueID: e.IssueID,
+			Actor:   e.Actor,
+			Text:    describeSummaryEvent(e),
+		})
+	}
+	return data, nil
+}
+
+// renderEpicSummary renders data with tmpl as trimmed Markdown.
+func renderEpicSummary(tmpl *template.Template, data *epicSummaryData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
+
+func summaryIssue(issue *types.Issue) epicSummaryIssue {
+	return epicSummaryIssue{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   issue.Status,
+		Priority: issue.Priority,
+		Assignee: issue.Assignee,
+		Done:     issue.Status == types.StatusClosed,
+	}
+}
+
+// openBlockers returns the non-closed issues that block issueID.
+func openBlockers(ctx context.Context, s storage.DoltStorage, issueID string) ([]epicSummaryIssue, error) {
+	deps, err := s.GetDependenciesWithMetadata(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("getting blockers of %s: %w", issueID, err)
+	}
+	var blockers []epicSummaryIssue
+	for _, dep := range deps {
+		if dep.DependencyType == types.DepBlocks && dep.Status != types.StatusClosed {
+			blockers = append(blockers, summaryIssue(&dep.Issue))
+		}
+	}
+	return blockers, nil
+}
+
+// describeSummaryEvent renders an event as a short phrase.
+func describeSummaryEvent(e *types.Event) string {
+	value := func(p *string) string {
+		if p == nil {
+			return ""
+		}
+		return *p
+	}
+	switch e.EventType {
+	case types.EventStatusChanged:
+		return fmt.Sprintf("status %s → %s", value(e.OldValue), value(e.NewValue))
+	case types.EventCommented:
+		return "commented"
+	case types.EventLabelAdded, types.EventLabelRemoved, types.EventDependencyAdded, types.EventDependencyRemoved:
+		if v := value(e.NewValue); v != "" {
+			return fmt.Sprintf("%s %s", strings.ReplaceAll(string(e.EventType), "_", " "), v)
+		}
+	}
+	return strings.ReplaceAll(string(e.EventType), "_", " ")
+}
+
+// polishSummary asks llm to tighten a rendered summary without dropping
+// facts.
+func polishSummary(ctx context.Context, llm triageLLM, markdown string) (string, error) {
+	prompt := "Rewrite this Markdown status summary of an epic to be concise and easy to scan. " +
+		"Keep every issue ID, count, and blocker; do not invent facts. " +
+		"Respond ONLY with the Markdown, no other text.\n\n" + markdown
+	reply, err := llm.Complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	reply = strings.TrimSpace(reply)
+	// Unwrap a fenced reply.
+	if strings.HasPrefix(reply, "```") {
+		reply = strings.TrimPrefix(reply[strings.Index(reply, "\n")+1:], "\n")
+		reply = strings.TrimSuffix(strings.TrimSpace(reply), "```")
+	}
+	return strings.TrimSpace(reply) + "\n", nil
+}
+
+// replaceSummaryBlock returns notes with the bd summarize block set to
+// summary: an existing block is replaced, otherwise one is appended.
+func replaceSummaryBlock(notes, summary string) string {
+	block := summaryNotesStart + "\n" + strings.TrimSpace(summary) + "\n" + summaryNotesEnd
+	start := strings.Index(notes, summaryNotesStart)
+	if start >= 0 {
+		if end := strings.Index(notes[start:], summaryNotesEnd); end >= 0 {
+			return notes[:start] + block + notes[start+end+len(summaryNotesEnd):]
+		}
+	}
+	if strings.TrimSpace(notes) == "" {
+		return block
+	}
+	return strings.TrimRight(notes, "\n") + "\n\n" + block
+}
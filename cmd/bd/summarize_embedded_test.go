@@ -0,0 +1,66 @@
+//go:build cgo
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedSummarize(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "sm")
+
+	epic := bdCreate(t, bd, dir, "Launch", "--type", "epic")
+	done := bdCreate(t, bd, dir, "Design schema", "--parent", epic.ID)
+	todo := bdCreate(t, bd, dir, "Wire up API", "--parent", epic.ID)
+	blocker := bdCreate(t, bd, dir, "Get credentials")
+	for _, args := range [][]string{
+		{"close", done.ID},
+		{"dep", "add", todo.ID, blocker.ID},
+		{"update", epic.ID, "--notes", "Keep this note."},
+	} {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, args...); err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "summarize", epic.ID)
+	if err != nil {
+		t.Fatalf("bd summarize failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"1/2 children closed (50%)",
+		"[x] " + done.ID,
+		"[ ] " + todo.ID,
+		todo.ID + " is blocked by " + blocker.ID,
+		"### Recent activity",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+	if got := bdShow(t, bd, dir, epic.ID); got.Notes != "Keep this note." {
+		t.Errorf("summarize without --write changed notes: %q", got.Notes)
+	}
+
+	// Writing twice replaces the summary block rather than stacking copies.
+	for i := 0; i < 2; i++ {
+		if out, err := bdRunWithFlockRetry(t, bd, dir, "summarize", epic.ID, "--write"); err != nil {
+			t.Fatalf("bd summarize --write failed: %v\n%s", err, out)
+		}
+	}
+	notes := bdShow(t, bd, dir, epic.ID).Notes
+	if !strings.HasPrefix(notes, "Keep this note.\n\n"+summaryNotesStart) {
+		t.Errorf("notes lost existing text:\n%s", notes)
+	}
+	if n := strings.Count(notes, summaryNotesStart); n != 1 {
+		t.Errorf("notes hold %d summary blocks, want 1:\n%s", n, notes)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDefaultSummaryTemplate(t *testing.T) {
+	t.Parallel()
+
+	open := epicSummaryIssue{ID: "bd-2", Title: "Wire up API", Status: types.StatusOpen, Priority: 1, Assignee: "alice"}
+	data := &epicSummaryData{
+		Epic:         &types.Issue{ID: "bd-1", Title: "Launch"},
+		Total:        2,
+		Closed:       1,
+		Percent:      50,
+		StatusCounts: []statusCount{{Status: types.StatusClosed, Count: 1}, {Status: types.StatusOpen, Count: 1}},
+		Children: []epicSummaryIssue{
+			{ID: "bd-3", Title: "Design schema", Status: types.StatusClosed, Priority: 2, Done: true},
+			open,
+		},
+		Blocked: []epicSummaryBlocked{{
+			epicSummaryIssue: open,
+			BlockedBy:        []epicSummaryIssue{{ID: "bd-9", Title: "Get credentials", Status: types.StatusOpen}},
+		}},
+		Events: []epicSummaryEvent{{Time: "2026-01-02 15:04", IssueID: "bd-3", Actor: "bob", Text: "closed"}},
+	}
+
+	got, err := renderEpicSummary(template.Must(template.New("summary").Parse(defaultSummaryTemplate)), data)
+	if err != nil {
+		t.Fatalf("renderEpicSummary: %v", err)
+	}
+	for _, want := range []string{
+		"## Launch (bd-1)",
+		"**Progress:** 1/2 children closed (50%) · closed: 1 open: 1",
+		"- [x] bd-3: Design schema (closed, P2)",
+		"- [ ] bd-2: Wire up API (open, P1, @alice)",
+		"- bd-2 is blocked by bd-9 (Get credentials, open)",
+		"- 2026-01-02 15:04 bd-3: closed by bob",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestReplaceSummaryBlock(t *testing.T) {
+	t.Parallel()
+
+	block := summaryNotesStart + "\nnew\n" + summaryNotesEnd
+	tests := []struct {
+		name  string
+		notes string
+		want  string
+	}{
+		{"empty notes", "", block},
+		{"append", "keep me\n", "keep me\n\n" + block},
+		{"replace", "before\n" + summaryNotesStart + "\nold\n" + summaryNotesEnd + "\nafter", "before\n" + block + "\nafter"},
+		{"unterminated block appends", "x " + summaryNotesStart, "x " + summaryNotesStart + "\n\n" + block},
+	}
+	for _, tt := range tests {
+		if got := replaceSummaryBlock(tt.notes, "new\n"); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/rbac"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// tokenCreateJSON is the bd token create --json result. Token is the only
+// place the plaintext secret ever appears.
+type tokenCreateJSON struct {
+	*storage.APIToken
+	Token string `json:"token"`
+}
+
+// tokenRevokeJSON is one entry of bd token revoke --json.
+type tokenRevokeJSON struct {
+	ID      string `json:"id"`
+	Revoked bool   `json:"revoked"`
+}
+
+var tokenCmd = &cobra.Command{
+	Use:     "token",
+	GroupID: "setup",
+	Short:   "Manage API tokens for role-based access",
+	Long: `Manage API tokens that grant a role to bd callers.
+
+A caller presents a token by exporting it as BEADS_TOKEN; the MCP server
+passes its own BEADS_TOKEN through to every bd it runs. The token's role
+limits which commands run, and its name becomes the actor recorded on every
+change. Denied commands and writes made with a token are recorded in the
+audit stream (.beads/interactions.jsonl, kind "access").
+
+Roles, from least to most privileged:
+  reader   read-only commands (list, show, ready, search, ...)
+  agent    issue-level writes (create, update, close, comment, dep, label)
+  writer   all data writes (delete, import, sync, ...)
+  admin    everything, including bd token, bd config, and schema changes
+
+Without a token bd runs unrestricted, as before. Set rbac.require-token: true
+in config.yaml to refuse tokenless commands; with no admin token yet,
+'bd token create' is still allowed so the first admin token can be minted.
+
+Tokens are stored hashed in a clone-local table that is never pushed.
+For commands run locally the check is advisory: a caller who can run bd
+can also open the .beads directory or the Dolt server's SQL port directly
+and write without a token. Use roles to keep cooperating agents in their
+lane, not as a security boundary against a hostile local user.
+
+Examples:
+  bd token create ci-reader --role reader
+  bd token create triage-bot --role agent --expires +30d
+  bd token list
+  bd token revoke 3f9a1c2e`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API token (the secret is shown once)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		ts := requireTokenStore()
+		roleStr, _ := cmd.Flags().GetString("role")
+		role, err := rbac.ParseRole(roleStr)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		var expiresAt *time.Time
+		if expiresStr, _ := cmd.Flags().GetString("expires"); expiresStr != "" {
+			t, err := timeparsing.ParseRelativeTime(expiresStr, time.Now())
+			if err != nil {
+				FatalErrorRespectJSON("invalid --expires format %q. Examples: +30d, +12h, 2025-12-31", expiresStr)
+			}
+			if !t.After(time.Now()) {
+				FatalErrorRespectJSON("--expires %q is in the past", expiresStr)
+			}
+			expiresAt = &t
+		}
+
+		id, secret, plaintext, err := rbac.NewToken()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		tok := &storage.APIToken{
+			ID:         id,
+			Name:       args[0],
+			Role:       string(role),
+			SecretHash: rbac.HashSecret(secret),
+			CreatedBy:  actor,
+			ExpiresAt:  expiresAt,
+		}
+		if err := ts.CreateAPIToken(ctx, tok); err != nil {
+			FatalErrorRespectJSON("creating token: %v", err)
+		}
+		recordTokenEvent("token_create", tok)
+
+		if jsonOutput {
+			outputJSON(tokenCreateJSON{APIToken: tok, Token: plaintext})
+			return
+		}
+		fmt.Printf("%s Created %s token %s (%s)\n", ui.RenderPass("✓"), role, tok.Name, tok.ID)
+		fmt.Printf("\n  %s\n\n", plaintext)
+		fmt.Println("Store this token now; it cannot be shown again. Present it with BEADS_TOKEN.")
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tokens, err := requireTokenStore().ListAPITokens(rootCtx)
+		if err != nil {
+			FatalErrorRespectJSON("listing tokens: %v", err)
+		}
+		if jsonOutput {
+			if tokens == nil {
+				tokens = []*storage.APIToken{}
+			}
+			outputJSON(tokens)
+			return
+		}
+		if len(tokens) == 0 {
+			fmt.Println("No API tokens")
+			return
+		}
+		now := time.Now()
+		tw := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tROLE\tSTATUS\tCREATED BY\tEXPIRES")
+		for _, tok := range tokens {
+			status := "active"
+			switch {
+			case tok.RevokedAt != nil:
+				status = "revoked"
+			case !tok.Active(now):
+				status = "expired"
+			}
+			expires := "never"
+			if tok.ExpiresAt != nil {
+				expires = tok.ExpiresAt.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", tok.ID, tok.Name, tok.Role, status, tok.CreatedBy, expires)
+		}
+		_ = tw.Flush()
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <id|name>...",
+	Short: "Revoke API tokens",
+	Long: `Revoke API tokens by ID or by name. A name must match exactly one active
+token. Revoked tokens are refused immediately and stay listed as revoked.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		ts := requireTokenStore()
+		tokens, err := ts.ListAPITokens(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("listing tokens: %v", err)
+		}
+
+		results := []tokenRevokeJSON{}
+		revoked := 0
+		for _, ref := range args {
+			tok, err := findAPIToken(tokens, ref)
+			if err == nil {
+				err = ts.RevokeAPIToken(ctx, tok.ID)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error revoking %s: %v\n", ref, err)
+				results = append(results, tokenRevokeJSON{ID: ref})
+				continue
+			}
+			revoked++
+			recordTokenEvent("token_revoke", tok)
+			results = append(results, tokenRevokeJSON{ID: tok.ID, Revoked: true})
+			if !jsonOutput {
+				fmt.Printf("%s Revoked %s (%s)\n", ui.RenderPass("✓"), tok.Name, tok.ID)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(results)
+		}
+		if revoked < len(args) {
+			os.Exit(1)
+		}
+	},
+}
+
+// requireTokenStore returns the store's TokenStore or exits.
+func requireTokenStore() storage.TokenStore {
+	ts, ok := storage.UnwrapStore(store).(storage.TokenStore)
+	if !ok {
+		FatalErrorRespectJSON("API tokens are not supported by this storage backend")
+	}
+	return ts
+}
+
+// findAPIToken resolves ref to a token by exact ID, or by name among the
+// active tokens.
+func findAPIToken(tokens []*storage.APIToken, ref string) (*storage.APIToken, error) {
+	now := time.Now()
+	var byName []*storage.APIToken
+	for _, tok := range tokens {
+		if tok.ID == ref {
+			return tok, nil
+		}
+		if tok.Name == ref && tok.Active(now) {
+			byName = append(byName, tok)
+		}
+	}
+	switch len(byName) {
+	case 0:
+		return nil, fmt.Errorf("%w: no active token with ID or name %q", storage.ErrNotFound, ref)
+	case 1:
+		return byName[0], nil
+	default:
+		return nil, errors.New("name matches several active tokens; revoke by ID")
+	}
+}
+
+// recordTokenEvent appends a token lifecycle event to the audit stream.
+// Best-effort, like audit.LogFieldChange.
+func recordTokenEvent(kind string, tok *storage.APIToken) {
+	_, _ = audit.Append(&audit.Entry{
+		Kind:  kind,
+		Actor: actor,
+		Extra: map[string]any{"token_id": tok.ID, "token_name": tok.Name, "role": tok.Role},
+	})
+}
+
+func init() {
+	tokenCreateCmd.Flags().String("role", string(rbac.RoleReader), "Role granted by the token: reader, agent, writer, admin")
+	tokenCreateCmd.Flags().String("expires", "", "Token expiry (e.g., +30d, 2025-12-31); default never expires")
+	tokenCmd.AddCommand(tokenCreateCmd, tokenListCmd, tokenRevokeCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
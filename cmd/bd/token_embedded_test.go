@@ -0,0 +1,107 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedTokenRBAC(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tk")
+	issue := bdCreate(t, bd, dir, "Public issue")
+
+	run := func(token string, extraEnv []string, args ...string) (string, error) {
+		cmd := exec.Command(bd, args...)
+		cmd.Dir = dir
+		cmd.Env = append(bdEnv(dir), extraEnv...)
+		if token != "" {
+			cmd.Env = append(cmd.Env, "BEADS_TOKEN="+token)
+		}
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+	createToken := func(token string, extraEnv []string, name, role string) string {
+		t.Helper()
+		out, err := run(token, extraEnv, "token", "create", name, "--role", role, "--json")
+		if err != nil {
+			t.Fatalf("token create %s: %v\n%s", name, err, out)
+		}
+		var created struct {
+			ID    string `json:"id"`
+			Token string `json:"token"`
+		}
+		if err := json.Unmarshal([]byte(out[strings.Index(out, "{"):]), &created); err != nil {
+			t.Fatalf("parse token create: %v\n%s", err, out)
+		}
+		return created.Token
+	}
+
+	reader := createToken("", nil, "contributor", "reader")
+	agent := createToken("", nil, "triage-bot", "agent")
+
+	t.Run("reader", func(t *testing.T) {
+		if out, err := run(reader, nil, "show", issue.ID); err != nil {
+			t.Errorf("reader show: %v\n%s", err, out)
+		}
+		out, err := run(reader, nil, "update", issue.ID, "--title", "Defaced")
+		if err == nil || !strings.Contains(out, `token "contributor" has role reader`) {
+			t.Errorf("reader update not denied (%v):\n%s", err, out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Title != "Public issue" {
+			t.Errorf("denied update applied: %q", got.Title)
+		}
+	})
+
+	t.Run("agent", func(t *testing.T) {
+		if out, err := run(agent, nil, "update", issue.ID, "--title", "Triaged"); err != nil {
+			t.Fatalf("agent update: %v\n%s", err, out)
+		}
+		if out, err := run(agent, nil, "delete", issue.ID, "--force"); err == nil {
+			t.Errorf("agent delete not denied:\n%s", out)
+		}
+		if out, err := run(agent, nil, "token", "list"); err == nil {
+			t.Errorf("agent token list not denied:\n%s", out)
+		}
+	})
+
+	t.Run("audit", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join(dir, ".beads", "interactions.jsonl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		log := string(data)
+		for _, want := range []string{`"kind":"token_create"`, `"kind":"access"`, `"allowed":false`, `"actor":"triage-bot"`} {
+			if !strings.Contains(log, want) {
+				t.Errorf("audit stream missing %s:\n%s", want, log)
+			}
+		}
+	})
+
+	t.Run("require token", func(t *testing.T) {
+		require := []string{"BD_RBAC_REQUIRE_TOKEN=true"}
+		if out, err := run("", require, "list"); err == nil || !strings.Contains(out, "requires an API token") {
+			t.Errorf("tokenless list not denied (%v):\n%s", err, out)
+		}
+		admin := createToken("", require, "root", "admin")
+		if out, err := run("", require, "token", "create", "sneaky", "--role", "admin"); err == nil {
+			t.Errorf("tokenless token create allowed after bootstrap:\n%s", out)
+		}
+		if out, err := run(admin, require, "token", "revoke", "contributor"); err != nil {
+			t.Fatalf("admin revoke: %v\n%s", err, out)
+		}
+		if out, err := run(reader, require, "list"); err == nil || !strings.Contains(out, "revoked") {
+			t.Errorf("revoked token accepted (%v):\n%s", err, out)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/town"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var townCmd = &cobra.Command{
+	Use:     "town",
+	GroupID: "setup",
+	Short:   "Manage orchestrator (gastown) settings stored in the database",
+	Long: `Manage settings for orchestrator ("gastown") workspaces. They live in the
+database config table under town.*, so every clone and agent sees the same
+values:
+
+  duplicates-threshold     Duplicates tolerated by bd doctor in orchestrator mode (default 1000)
+  wisp-quota               Maximum open wisps; bd mol wisp create refuses beyond it (default 0 = unlimited)
+  patrol-digest-threshold  Patrol digest beads tolerated before bd doctor warns (default 10)
+  session-bead-threshold   Session-ended beads tolerated before bd doctor warns (default 50)
+
+The agent registry (bd town agent) is stored alongside them for the
+orchestrator to read with 'bd town show --json'. Patrol schedules are managed
+with 'bd patrol'.
+
+Examples:
+  bd town show
+  bd town set wisp-quota 500
+  bd town unset duplicates-threshold
+  bd town agent add witness --role monitor`,
+}
+
+var townShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective town settings",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("town show requires direct database access"); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+		all, err := store.GetAllConfig(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("reading town settings: %v", err)
+		}
+		cfg := town.FromConfig(all)
+		openWisps, err := countOpenWisps(ctx, store, 0)
+		if err != nil {
+			FatalErrorRespectJSON("counting open wisps: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(townShowJSON{Config: cfg, OpenWisps: openWisps})
+			return
+		}
+
+		fmt.Printf("\n%s Town settings:\n\n", ui.RenderAccent("🏘"))
+		for _, s := range town.Settings {
+			line := fmt.Sprintf("  %-24s %d", s.Name, cfg.Value(s))
+			if _, ok := all[s.Key]; !ok {
+				line += " " + ui.RenderMuted("(default)")
+			}
+			if s.Key == "town.wisp_quota" {
+				line += fmt.Sprintf("  %s", ui.RenderMuted(fmt.Sprintf("%d open", openWisps)))
+			}
+			fmt.Println(line)
+		}
+
+		fmt.Printf("\n  Agents:\n")
+		if len(cfg.Agents) == 0 {
+			fmt.Printf("    %s\n", ui.RenderMuted("none"))
+		}
+		for _, a := range cfg.Agents {
+			role := a.Role
+			if role == "" {
+				role = "-"
+			}
+			fmt.Printf("    %-30s %s\n", a.Name, role)
+		}
+		fmt.Println()
+	},
+}
+
+var townSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "Set a town setting",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("town set")
+		s := lookupTownSetting(args[0])
+		n, err := town.ParseSettingValue(args[1])
+		if err != nil {
+			FatalErrorRespectJSON("invalid %s: %v", s.Name, err)
+		}
+		setTownConfig("town set", s.Key, fmt.Sprint(n))
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"setting": s.Name, "key": s.Key, "value": n})
+			return
+		}
+		fmt.Printf("%s Set %s = %d\n", ui.RenderPass("✓"), s.Name, n)
+	},
+}
+
+var townUnsetCmd = &cobra.Command{
+	Use:   "unset <setting>",
+	Short: "Reset a town setting to its default",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("town unset")
+		s := lookupTownSetting(args[0])
+		deleteTownConfig("town unset", s.Key)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"setting": s.Name, "key": s.Key, "value": s.Default})
+			return
+		}
+		fmt.Printf("%s Reset %s to default (%d)\n", ui.RenderPass("✓"), s.Name, s.Default)
+	},
+}
+
+var townAgentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Manage the town agent registry",
+	Long: `Register the agents that work in this town, with an optional role.
+
+Examples:
+  bd town agent add witness --role monitor
+  bd town agent list
+  bd town agent remove witness`,
+}
+
+var townAgentAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register an agent (replaces an existing registration)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("town agent add")
+		name := args[0]
+		if err := town.ValidateName(name); err != nil {
+			FatalErrorRespectJSON("invalid agent name: %v", err)
+		}
+		role, _ := cmd.Flags().GetString("role")
+		role = strings.TrimSpace(role)
+		setTownConfig("town agent add", town.AgentPrefix+name, role)
+
+		if jsonOutput {
+			outputJSON(town.Agent{Name: name, Role: role})
+			return
+		}
+		msg := fmt.Sprintf("%s Registered agent %s", ui.RenderPass("✓"), ui.RenderAccent(name))
+		if role != "" {
+			msg += " (" + role + ")"
+		}
+		fmt.Println(msg)
+	},
+}
+
+var townAgentRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister an agent",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("town agent remove")
+		requireTownEntry(town.AgentPrefix, args[0], "agent")
+		deleteTownConfig("town agent remove", town.AgentPrefix+args[0])
+
+		if jsonOutput {
+			outputJSON(map[string]string{"name": args[0], "status": "removed"})
+			return
+		}
+		fmt.Printf("%s Removed agent %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var townAgentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered agents",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadTownConfig()
+		if jsonOutput {
+			outputJSON(cfg.Agents)
+			return
+		}
+		if len(cfg.Agents) == 0 {
+			fmt.Println("No agents registered")
+			return
+		}
+		for _, a := range cfg.Agents {
+			role := a.Role
+			if role == "" {
+				role = "-"
+			}
+			fmt.Printf("%-30s %s\n", a.Name, role)
+		}
+	},
+}
+
+func init() {
+	townAgentAddCmd.Flags().String("role", "", "Agent role (e.g. monitor, worker)")
+
+	townAgentCmd.AddCommand(townAgentAddCmd, townAgentRemoveCmd, townAgentListCmd)
+	townCmd.AddCommand(townShowCmd, townSetCmd, townUnsetCmd, townAgentCmd)
+	rootCmd.AddCommand(townCmd)
+}
+
+// townShowJSON is the --json output of bd town show.
+type townShowJSON struct {
+	town.Config
+	OpenWisps int `json:"open_wisps"`
+}
+
+// lookupTownSetting resolves a setting name or exits listing the valid ones.
+func lookupTownSetting(name string) town.Setting {
+	s, ok := town.LookupSetting(name)
+	if !ok {
+		names := make([]string, 0, len(town.Settings))
+		for _, s := range town.Settings {
+			names = append(names, s.Name)
+		}
+		FatalErrorRespectJSON("unknown town setting %q (valid: %s)", name, strings.Join(names, ", "))
+	}
+	return s
+}
+
+func loadTownConfig() town.Config {
+	if err := ensureDirectMode("town commands require direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	all, err := store.GetAllConfig(rootCtx)
+	if err != nil {
+		FatalErrorRespectJSON("reading town settings: %v", err)
+	}
+	return town.FromConfig(all)
+}
+
+// requireTownEntry exits unless an agent key exists.
+func requireTownEntry(prefix, name, kind string) {
+	if err := ensureDirectMode("town commands require direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	all, err := store.GetAllConfig(rootCtx)
+	if err != nil {
+		FatalErrorRespectJSON("reading town settings: %v", err)
+	}
+	if _, ok := all[prefix+name]; !ok {
+		FatalErrorRespectJSON("no %s named %q", kind, name)
+	}
+}
+
+func setTownConfig(op, key, value string) {
+	if err := ensureDirectMode(op + " requires direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := store.SetConfig(rootCtx, key, value); err != nil {
+		FatalErrorRespectJSON("setting %s: %v", key, err)
+	}
+	commandDidWrite.Store(true)
+}
+
+func deleteTownConfig(op, key string) {
+	if err := ensureDirectMode(op + " requires direct database access"); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := store.DeleteConfig(rootCtx, key); err != nil {
+		FatalErrorRespectJSON("deleting %s: %v", key, err)
+	}
+	commandDidWrite.Store(true)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var treeCmd = &cobra.Command{
+	Use:     "tree <root-id>",
+	GroupID: "deps",
+	Short:   "Show a multi-level parent-child hierarchy with recursive roll-ups",
+	Long: `Show every issue beneath a root (initiative, epic, story, ...) through all
+parent-child levels, with progress and estimates rolled up recursively.
+
+Each node with children shows closed/total and percent over ALL of its
+descendants, not just its direct children, plus the summed estimate of its
+descendants and how much of it is still open. The roll-ups for the whole
+tree come from a single recursive query.
+
+Examples:
+  bd tree bd-init1                 # Full portfolio under an initiative
+  bd tree bd-epic1 --max-depth 2   # Epic, its stories, and their tasks
+  bd tree bd-init1 --json          # Nested nodes with rollup objects`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		if maxDepth < 0 {
+			FatalErrorRespectJSON("--max-depth must be >= 0")
+		}
+
+		result, err := resolveAndGetIssueWithRouting(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		if result == nil || result.Issue == nil {
+			if result != nil {
+				result.Close()
+			}
+			FatalErrorRespectJSON("issue %s not found", args[0])
+		}
+		defer result.Close()
+
+		subgraph, err := loadTemplateSubgraph(ctx, result.Store, result.ResolvedID)
+		if err != nil {
+			FatalErrorRespectJSON("loading hierarchy: %v", err)
+		}
+		rollups, err := result.Store.GetHierarchyRollup(ctx, result.ResolvedID)
+		if err != nil {
+			FatalErrorRespectJSON("loading roll-ups: %v", err)
+		}
+		tree := buildShowTree(subgraph, nil)
+		attachHierarchyRollups(tree, subgraph.IssueMap, rollups)
+		if maxDepth > 0 {
+			pruneShowTree(tree, maxDepth)
+		}
+
+		if jsonOutput {
+			outputJSON(tree)
+			return
+		}
+		fmt.Println(formatHierarchyNode(tree))
+		printHierarchy(tree.Children, "")
+	},
+}
+
+// attachHierarchyRollups sets the estimate and recursive roll-up on node and
+// everything beneath it.
+func attachHierarchyRollups(node *ShowTreeNode, issues map[string]*types.Issue, rollups map[string]*types.HierarchyRollup) {
+	if issue, ok := issues[node.ID]; ok {
+		node.EstimatedMinutes = issue.EstimatedMinutes
+	}
+	node.Rollup = rollups[node.ID]
+	for _, child := range node.Children {
+		attachHierarchyRollups(child, issues, rollups)
+	}
+}
+
+// pruneShowTree drops nodes more than depth levels below node. Roll-ups
+// still cover the pruned descendants.
+func pruneShowTree(node *ShowTreeNode, depth int) {
+	if depth == 0 {
+		node.Children = nil
+		return
+	}
+	for _, child := range node.Children {
+		pruneShowTree(child, depth-1)
+	}
+}
+
+func printHierarchy(nodes []*ShowTreeNode, prefix string) {
+	for i, node := range nodes {
+		connector, extension := "├── ", "│   "
+		if i == len(nodes)-1 {
+			connector, extension = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s\n", prefix, connector, formatHierarchyNode(node))
+		printHierarchy(node.Children, prefix+extension)
+	}
+}
+
+func formatHierarchyNode(node *ShowTreeNode) string {
+	label := fmt.Sprintf("%s %s [%s]", node.ID, node.Title, node.IssueType)
+	line := fmt.Sprintf("%s %s", renderStatusIcon(node.Status), label)
+	if node.Status == types.StatusClosed {
+		line = fmt.Sprintf("%s %s", renderStatusIcon(node.Status), ui.RenderMuted(label))
+	}
+	var details []string
+	if r := node.Rollup; r != nil {
+		details = append(details, fmt.Sprintf("%d/%d (%d%%)", r.Closed, r.Total, r.Percent))
+		if r.EstimatedMinutes > 0 {
+			details = append(details, fmt.Sprintf("%s of %s left", formatEstimateMinutes(r.RemainingMinutes), formatEstimateMinutes(r.EstimatedMinutes)))
+		}
+	} else if node.EstimatedMinutes != nil {
+		details = append(details, formatEstimateMinutes(*node.EstimatedMinutes))
+	}
+	if len(details) > 0 {
+		line += " " + ui.RenderMuted(strings.Join(details, ", "))
+	}
+	return line
+}
+
+// formatEstimateMinutes renders minutes as e.g. "45m", "2h", or "1h30m".
+func formatEstimateMinutes(minutes int) string {
+	if minutes == 0 {
+		return "0m"
+	}
+	s := strings.TrimSuffix((time.Duration(minutes) * time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func init() {
+	treeCmd.Flags().Int("max-depth", 0, "Limit displayed levels below the root (0 = unlimited; roll-ups still count everything)")
+	rootCmd.AddCommand(treeCmd)
+}
@@ -0,0 +1,59 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedTree(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tr")
+
+	initiative := bdCreate(t, bd, dir, "Initiative", "--type", "epic")
+	epic := bdCreate(t, bd, dir, "Epic", "--type", "epic", "--parent", initiative.ID)
+	story := bdCreate(t, bd, dir, "Story", "--type", "story", "--parent", epic.ID, "--estimate", "60")
+	done := bdCreate(t, bd, dir, "Done task", "--parent", story.ID, "--estimate", "30")
+	bdCreate(t, bd, dir, "Open task", "--parent", story.ID, "--estimate", "90")
+	bdClose(t, bd, dir, done.ID)
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "tree", initiative.ID, "--json")
+	if err != nil {
+		t.Fatalf("bd tree failed: %v\n%s", err, out)
+	}
+	var tree ShowTreeNode
+	if err := json.Unmarshal(out[strings.Index(string(out), "{"):], &tree); err != nil {
+		t.Fatalf("parse tree: %v\n%s", err, out)
+	}
+
+	if tree.Rollup == nil || tree.Rollup.Total != 4 || tree.Rollup.Closed != 1 || tree.Rollup.Percent != 25 {
+		t.Fatalf("initiative rollup = %+v, want 1/4 closed", tree.Rollup)
+	}
+	if tree.Rollup.EstimatedMinutes != 180 || tree.Rollup.RemainingMinutes != 150 {
+		t.Errorf("initiative estimates = %d/%d, want 180 total, 150 remaining", tree.Rollup.EstimatedMinutes, tree.Rollup.RemainingMinutes)
+	}
+	if len(tree.Children) != 1 || len(tree.Children[0].Children) != 1 {
+		t.Fatalf("unexpected shape: %+v", tree)
+	}
+	storyNode := tree.Children[0].Children[0]
+	if storyNode.Rollup == nil || storyNode.Rollup.Total != 2 || len(storyNode.Children) != 2 {
+		t.Errorf("story node = %+v", storyNode)
+	}
+
+	out, err = bdRunWithFlockRetry(t, bd, dir, "tree", initiative.ID, "--max-depth", "1")
+	if err != nil {
+		t.Fatalf("bd tree --max-depth failed: %v\n%s", err, out)
+	}
+	text := string(out)
+	if !strings.Contains(text, "1/4 (25%)") || !strings.Contains(text, "2h30m of 3h left") || strings.Contains(text, story.ID) {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFormatEstimateMinutes(t *testing.T) {
+	t.Parallel()
+	for minutes, want := range map[int]string{0: "0m", 45: "45m", 60: "1h", 90: "1h30m", 600: "10h"} {
+		if got := formatEstimateMinutes(minutes); got != want {
+			t.Errorf("formatEstimateMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestAttachHierarchyRollupsAndPrune(t *testing.T) {
+	t.Parallel()
+	est := 30
+	leaf := &ShowTreeNode{ID: "bd-1.1.1"}
+	story := &ShowTreeNode{ID: "bd-1.1", Children: []*ShowTreeNode{leaf}}
+	root := &ShowTreeNode{ID: "bd-1", Children: []*ShowTreeNode{story}}
+	issues := map[string]*types.Issue{leaf.ID: {ID: leaf.ID, EstimatedMinutes: &est}}
+	rollups := map[string]*types.HierarchyRollup{
+		root.ID:  {Total: 2, Closed: 1, Percent: 50, EstimatedMinutes: 30},
+		story.ID: {Total: 1, EstimatedMinutes: 30, RemainingMinutes: 30},
+	}
+
+	attachHierarchyRollups(root, issues, rollups)
+	if root.Rollup.Total != 2 || story.Rollup.RemainingMinutes != 30 || leaf.Rollup != nil {
+		t.Fatalf("rollups not attached: root=%+v story=%+v leaf=%+v", root.Rollup, story.Rollup, leaf.Rollup)
+	}
+	if leaf.EstimatedMinutes == nil || *leaf.EstimatedMinutes != 30 {
+		t.Errorf("leaf estimate = %v, want 30", leaf.EstimatedMinutes)
+	}
+
+	pruneShowTree(root, 1)
+	if len(root.Children) != 1 || story.Children != nil {
+		t.Errorf("prune to depth 1 left %d children under root, %d under story", len(root.Children), len(story.Children))
+	}
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// triageMaxParents bounds the candidate parents listed in the prompt.
+const triageMaxParents = 50
+
+var triageCmd = &cobra.Command{
+	Use:     "triage",
+	GroupID: "issues",
+	Short:   "Propose priority, type, labels, and parent for untriaged issues using an LLM",
+	Long: `Send untriaged issues to an LLM and propose a priority, type, labels, and
+parent epic or molecule for each one, as a reviewable patch.
+
+Untriaged issues are open issues with no labels, no assignee, and no parent.
+Every issue has a priority (P2 by default), so one is proposed for each.
+
+Nothing is written unless --apply is given. To review before applying, save
+the patch with --output, edit or trim it, then apply it with --patch:
+
+  bd triage --output triage.json            # Propose and save the patch
+  bd triage --patch triage.json             # Show a saved patch
+  bd triage --patch triage.json --apply     # Apply the reviewed patch
+
+Providers (config ai.triage.provider, or --provider):
+  anthropic  Claude via ANTHROPIC_API_KEY or ai.api_key (default;
+             model default from ai.model)
+  openai     An OpenAI-compatible chat completions API at ai.triage.url
+             (default https://api.openai.com/v1, which requires
+             OPENAI_API_KEY or ai.triage.api_key). Point ai.triage.url at
+             http://localhost:11434/v1 to use a local Ollama model.
+
+Examples:
+  bd triage                                 # Propose for up to 20 issues
+  bd triage --apply                         # Propose and apply at once
+  bd triage --provider openai --model gpt-4o-mini
+  bd triage --limit 50 --json               # JSON patch on stdout`,
+	Run: runTriage,
+}
+
+func init() {
+	triageCmd.Flags().String("provider", "", "LLM provider: anthropic, openai (default from config ai.triage.provider)")
+	triageCmd.Flags().String("model", "", "Model to use (default from config ai.triage.model)")
+	triageCmd.Flags().IntP("limit", "n", 20, "Maximum number of untriaged issues to send")
+	triageCmd.Flags().StringP("output", "o", "", "Write the proposed patch to this file for review")
+	triageCmd.Flags().String("patch", "", "Use a saved patch instead of asking the LLM")
+	triageCmd.Flags().Bool("apply", false, "Apply the proposals")
+	rootCmd.AddCommand(triageCmd)
+}
+
+// triageProposal is the proposed triage of one issue. Empty fields leave the
+// issue unchanged.
+type triageProposal struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title,omitempty"` // for reviewers; not applied
+	Priority  *int     `json:"priority,omitempty"`
+	IssueType string   `json:"issue_type,omitempty"`
+	Labels    []string `json:"labels,omitempty"` // added; existing labels are kept
+	Parent    string   `json:"parent,omitempty"` // epic or molecule to file the issue under
+	Reason    string   `json:"reason,omitempty"`
+}
+
+// triagePatch is the reviewable patch bd triage proposes and --apply applies.
+type triagePatch struct {
+	Provider  string           `json:"provider,omitempty"`
+	Model     string           `json:"model,omitempty"`
+	Proposals []triageProposal `json:"proposals"`
+	Applied   bool             `json:"applied"`
+}
+
+func runTriage(cmd *cobra.Command, _ []string) {
+	providerName, _ := cmd.Flags().GetString("provider")
+	model, _ := cmd.Flags().GetString("model")
+	limit, _ := cmd.Flags().GetInt("limit")
+	outputPath, _ := cmd.Flags().GetString("output")
+	patchPath, _ := cmd.Flags().GetString("patch")
+	apply, _ := cmd.Flags().GetBool("apply")
+
+	ctx := rootCtx
+	if apply {
+		CheckReadonly("triage --apply")
+	}
+
+	var patch *triagePatch
+	if patchPath != "" {
+		data, err := os.ReadFile(patchPath) // #nosec G304 -- user-provided path is intentional
+		if err != nil {
+			FatalErrorRespectJSON("reading patch: %v", err)
+		}
+		patch = &triagePatch{}
+		if err := json.Unmarshal(data, patch); err != nil {
+			FatalErrorRespectJSON("parsing patch %s: %v", patchPath, err)
+		}
+		patch.Applied = false
+	} else {
+		llm, err := newTriageLLM(providerName, model)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		patch, err = proposeTriage(ctx, store, llm, limit)
+		if err != nil {
+			FatalErrorRespectJSON("triage: %v", err)
+		}
+	}
+
+	if outputPath != "" {
+		data, err := json.MarshalIndent(patch, "", "  ")
+		if err != nil {
+			FatalErrorRespectJSON("encoding patch: %v", err)
+		}
+		if err := os.WriteFile(outputPath, append(data, '\n'), 0o600); err != nil {
+			FatalErrorRespectJSON("writing patch: %v", err)
+		}
+	}
+
+	if apply && len(patch.Proposals) > 0 {
+		if err := applyTriagePatch(ctx, store, patch); err != nil {
+			FatalErrorRespectJSON("applying triage: %v", err)
+		}
+		commandDidWrite.Store(true)
+		patch.Applied = true
+	}
+
+	if jsonOutput {
+		outputJSON(patch)
+		return
+	}
+	renderTriagePatch(patch)
+	switch {
+	case patch.Applied:
+		fmt.Printf("%s Applied triage to %d issue(s)\n", ui.RenderPass("✓"), len(patch.Proposals))
+	case len(patch.Proposals) == 0:
+	case outputPath != "":
+		fmt.Printf("Patch written to %s; review it, then run: bd triage --patch %s --apply\n", outputPath, outputPath)
+	default:
+		fmt.Println("Run with --apply to apply, or --output <file> to save the patch for review.")
+	}
+}
+
+// untriagedIssues returns up to limit open issues with no labels, no
+// assignee, and no parent, oldest first.
+func untriagedIssues(ctx context.Context, s storage.DoltStorage, limit int) ([]*types.Issue, error) {
+	open := types.StatusOpen
+	notTemplate, notEphemeral := false, false
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Status:       &open,
+		NoLabels:     true,
+		NoAssignee:   true,
+		NoParent:     true,
+		IsTemplate:   &notTemplate,
+		Ephemeral:    &notEphemeral,
+		ExcludeTypes: []types.IssueType{types.TypeEpic, types.TypeMolecule, types.TypeGate, types.TypeMessage, types.TypeEvent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].CreatedAt.Before(issues[j].CreatedAt) })
+	if limit > 0 && len(issues) > limit {
+		issues = issues[:limit]
+	}
+	return issues, nil
+}
+
+// triageParentCandidates returns the open epics and molecules issues may be
+// filed under.
+func triageParentCandidates(ctx context.Context, s storage.DoltStorage) ([]*types.Issue, error) {
+	var parents []*types.Issue
+	for _, t := range []types.IssueType{types.TypeEpic, types.TypeMolecule} {
+		issueType := t
+		found, err := s.SearchIssues(ctx, "", types.IssueFilter{
+			IssueType:     &issueType,
+			ExcludeStatus: []types.Status{types.StatusClosed},
+		})
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, found...)
+	}
+	if len(parents) > triageMaxParents {
+		parents = parents[:triageMaxParents]
+	}
+	return parents, nil
+}
+
+// proposeTriage asks llm to triage the untriaged issues.
+func proposeTriage(ctx context.Context, s storage.DoltStorage, llm triageLLM, limit int) (*triagePatch, error) {
+	patch := &triagePatch{Provider: llm.Name(), Model: llm.Model(), Proposals: []triageProposal{}}
+
+	issues, err := untriagedIssues(ctx, s, limit)
+	if err != nil {
+		return nil, fmt.Errorf("finding untriaged issues: %w", err)
+	}
+	if len(issues) == 0 {
+		return patch, nil
+	}
+	parents, err := triageParentCandidates(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("finding parent candidates: %w", err)
+	}
+	labelCounts, err := countLabels(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("counting labels: %w", err)
+	}
+	customTypes, _ := s.GetCustomTypes(ctx) // Best effort: core types still apply
+
+	fmt.Fprintf(os.Stderr, "Triaging %d issue(s) with %s/%s...\n", len(issues), llm.Name(), llm.Model())
+	reply, err := llm.Complete(ctx, buildTriagePrompt(issues, parents, labelCounts, customTypes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", llm.Name(), err)
+	}
+	patch.Proposals, err = parseTriageReply(reply, issues, parents, customTypes)
+	if err != nil {
+		return nil, err
+	}
+	return patch, nil
+}
+
+// buildTriagePrompt describes the issues to triage and the vocabulary the
+// proposals must use.
+func buildTriagePrompt(issues, parents []*types.Issue, labelCounts map[string]int, customTypes []string) string {
+	var sb strings.Builder
+	sb.WriteString("You are triaging issues in a software project's issue tracker.\n")
+	sb.WriteString("For each issue, propose:\n")
+	sb.WriteString("  - priority (int): 0 = critical, 1 = high, 2 = medium, 3 = low, 4 = backlog\n")
+	sb.WriteString("  - issue_type (string): one of the types listed below\n")
+	sb.WriteString("  - labels (array of strings): 1-3 labels, preferring existing ones\n")
+	sb.WriteString("  - parent (string): the ID of the epic or molecule below it belongs under, or \"\" if none fits\n")
+	sb.WriteString("  - reason (string): one short sentence explaining the proposal\n\n")
+	sb.WriteString("Respond with a JSON array of objects with fields id, priority, issue_type, labels, parent, reason.\n")
+	sb.WriteString("Respond ONLY with the JSON array, no other text.\n\n")
+
+	typeNames := []string{"bug", "feature", "task", "chore", "decision", "spike", "story", "milestone"}
+	typeNames = append(typeNames, customTypes...)
+	fmt.Fprintf(&sb, "Issue types: %s\n", strings.Join(typeNames, ", "))
+
+	if len(labelCounts) > 0 {
+		labels := make([]string, 0, len(labelCounts))
+		for label := range labelCounts {
+			labels = append(labels, label)
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			if labelCounts[labels[i]] != labelCounts[labels[j]] {
+				return labelCounts[labels[i]] > labelCounts[labels[j]]
+			}
+			return labels[i] < labels[j]
+		})
+		if len(labels) > 100 {
+			labels = labels[:100]
+		}
+		fmt.Fprintf(&sb, "Existing labels: %s\n", strings.Join(labels, ", "))
+	}
+
+	if len(parents) > 0 {
+		sb.WriteString("\nCandidate parents:\n")
+		for _, p := range parents {
+			fmt.Fprintf(&sb, "  [%s] (%s) %s\n", p.ID, p.IssueType, p.Title)
+		}
+	}
+
+	sb.WriteString("\nIssues to triage:\n")
+	for _, issue := range issues {
+		fmt.Fprintf(&sb, "--- [%s] (%s, P%d) %s\n", issue.ID, issue.IssueType, issue.Priority, issue.Title)
+		if issue.Description != "" {
+			desc := issue.Description
+			if len(desc) > 800 {
+				desc = desc[:800] + "..."
+			}
+			fmt.Fprintf(&sb, "%s\n", desc)
+		}
+	}
+	return sb.String()
+}
+
+// parseTriageReply extracts the proposals from the LLM's reply, dropping
+// proposals for issues that were not asked about and any field outside the
+// allowed vocabulary.
+func parseTriageReply(reply string, issues, parents []*types.Issue, customTypes []string) ([]triageProposal, error) {
+	jsonText := reply
+	if idx := strings.Index(jsonText, "["); idx >= 0 {
+		jsonText = jsonText[idx:]
+	}
+	if idx := strings.LastIndex(jsonText, "]"); idx >= 0 {
+		jsonText = jsonText[:idx+1]
+	}
+	var raw []triageProposal
+	if err := json.Unmarshal([]byte(jsonText), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse AI response: %w", err)
+	}
+
+	asked := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		asked[issue.ID] = issue
+	}
+	validParent := make(map[string]bool, len(parents))
+	for _, p := range parents {
+		validParent[p.ID] = true
+	}
+
+	proposals := make([]triageProposal, 0, len(raw))
+	seen := make(map[string]bool)
+	for _, p := range raw {
+		issue, ok := asked[p.ID]
+		if !ok || seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+
+		out := triageProposal{ID: p.ID, Title: issue.Title, Reason: p.Reason}
+		if p.Priority != nil && *p.Priority >= 0 && *p.Priority <= 4 && *p.Priority != issue.Priority {
+			out.Priority = p.Priority
+		}
+		if t := types.IssueType(utils.NormalizeIssueType(p.IssueType)); t != "" && t != issue.IssueType &&
+			t.IsValidWithCustom(customTypes) {
+			out.IssueType = string(t)
+		}
+		labelSeen := make(map[string]bool)
+		for _, label := range p.Labels {
+			label = strings.TrimSpace(label)
+			if label != "" && !labelSeen[label] {
+				labelSeen[label] = true
+				out.Labels = append(out.Labels, label)
+			}
+		}
+		if p.Parent != "" && p.Parent != p.ID && validParent[p.Parent] {
+			out.Parent = p.Parent
+		}
+		if out.Priority == nil && out.IssueType == "" && len(out.Labels) == 0 && out.Parent == "" {
+			continue
+		}
+		proposals = append(proposals, out)
+	}
+	return proposals, nil
+}
+
+// applyTriagePatch applies every proposal in one transaction.
+func applyTriagePatch(ctx context.Context, s storage.DoltStorage, patch *triagePatch) error {
+	commitMsg := fmt.Sprintf("bd: triage %d issue(s)", len(patch.Proposals))
+	return transactHonoringAutoCommit(ctx, s, commitMsg, func(tx storage.Transaction) error {
+		for _, p := range patch.Proposals {
+			updates := make(map[string]interface{})
+			if p.Priority != nil {
+				updates["priority"] = *p.Priority
+			}
+			if p.IssueType != "" {
+				updates["issue_type"] = utils.NormalizeIssueType(p.IssueType)
+			}
+			if len(updates) > 0 {
+				if err := tx.UpdateIssue(ctx, p.ID, updates, actor); err != nil {
+					return fmt.Errorf("update %s: %w", p.ID, err)
+				}
+			}
+			for _, label := range p.Labels {
+				if err := tx.AddLabel(ctx, p.ID, label, actor); err != nil {
+					return fmt.Errorf("label %s: %w", p.ID, err)
+				}
+			}
+			if p.Parent != "" {
+				dep := &types.Dependency{IssueID: p.ID, DependsOnID: p.Parent, Type: types.DepParentChild}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("file %s under %s: %w", p.ID, p.Parent, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func renderTriagePatch(patch *triagePatch) {
+	if len(patch.Proposals) == 0 {
+		fmt.Println("No untriaged issues to propose changes for")
+		return
+	}
+	fmt.Printf("\n%s Triage proposals (%d):\n\n", ui.RenderAccent("📋"), len(patch.Proposals))
+	for _, p := range patch.Proposals {
+		fmt.Printf("%s: %s\n", ui.RenderAccent(p.ID), p.Title)
+		if p.Priority != nil {
+			fmt.Printf("  priority: P%d\n", *p.Priority)
+		}
+		if p.IssueType != "" {
+			fmt.Printf("  type:     %s\n", p.IssueType)
+		}
+		if len(p.Labels) > 0 {
+			fmt.Printf("  labels:   +%s\n", strings.Join(p.Labels, ", +"))
+		}
+		if p.Parent != "" {
+			fmt.Printf("  parent:   %s\n", p.Parent)
+		}
+		if p.Reason != "" {
+			fmt.Printf("  %s\n", ui.RenderMuted(p.Reason))
+		}
+		fmt.Println()
+	}
+}
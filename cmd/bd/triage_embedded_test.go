@@ -0,0 +1,70 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedTriagePatch(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "tr")
+
+	epic := bdCreate(t, bd, dir, "Editor polish", "--type", "epic")
+	issue := bdCreate(t, bd, dir, "Crash on save")
+
+	patchPath := filepath.Join(t.TempDir(), "triage.json")
+	patch := `{"proposals": [{"id": "` + issue.ID + `", "priority": 0, "issue_type": "bug",
+		"labels": ["editor", "crash"], "parent": "` + epic.ID + `", "reason": "data loss"}]}`
+	if err := os.WriteFile(patchPath, []byte(patch), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("show_does_not_write", func(t *testing.T) {
+		out, err := bdRunWithFlockRetry(t, bd, dir, "triage", "--patch", patchPath)
+		if err != nil {
+			t.Fatalf("bd triage --patch failed: %v\n%s", err, out)
+		}
+		if !strings.Contains(string(out), "--apply") {
+			t.Errorf("preview should explain --apply:\n%s", out)
+		}
+		if got := bdShow(t, bd, dir, issue.ID); got.Priority != 2 || len(got.Labels) != 0 {
+			t.Errorf("preview changed the issue: priority %d, labels %v", got.Priority, got.Labels)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		out, err := bdRunWithFlockRetry(t, bd, dir, "triage", "--patch", patchPath, "--apply", "--json")
+		if err != nil {
+			t.Fatalf("bd triage --apply failed: %v\n%s", err, out)
+		}
+		var result triagePatch
+		if err := json.Unmarshal(out[strings.Index(string(out), "{"):], &result); err != nil {
+			t.Fatalf("parse triage JSON: %v\n%s", err, out)
+		}
+		if !result.Applied || len(result.Proposals) != 1 {
+			t.Errorf("result = %+v, want one applied proposal", result)
+		}
+
+		got := bdShow(t, bd, dir, issue.ID)
+		if got.Priority != 0 || got.IssueType != "bug" {
+			t.Errorf("priority/type = P%d/%s, want P0/bug", got.Priority, got.IssueType)
+		}
+		if strings.Join(got.Labels, ",") != "crash,editor" {
+			t.Errorf("labels = %v, want [crash editor]", got.Labels)
+		}
+		children := bdShowRaw(t, bd, dir, epic.ID, "--children")
+		if !strings.Contains(children, issue.ID) {
+			t.Errorf("%s not filed under %s:\n%s", issue.ID, epic.ID, children)
+		}
+	})
+}
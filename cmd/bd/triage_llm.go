@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// triageLLM sends one prompt to a chat model and returns its text reply.
+type triageLLM interface {
+	Name() string
+	Model() string
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// newTriageLLM returns the LLM named by provider (default: config
+// ai.triage.provider). "anthropic" uses ANTHROPIC_API_KEY or ai.api_key like
+// the other AI commands; "openai" speaks the OpenAI chat completions API at
+// ai.triage.url, which also covers Ollama, vLLM, and similar local servers.
+func newTriageLLM(provider, model string) (triageLLM, error) {
+	if provider == "" {
+		provider = config.GetString("ai.triage.provider")
+	}
+	if model == "" {
+		model = config.GetString("ai.triage.model")
+	}
+
+	switch provider {
+	case "", "anthropic":
+		apiKey := os.Getenv("ANTHROPIC_API_KEY")
+		if apiKey == "" {
+			apiKey = config.GetString("ai.api_key")
+		}
+		if apiKey == "" {
+			return nil, fmt.Errorf("anthropic triage requires ANTHROPIC_API_KEY environment variable or ai.api_key in config")
+		}
+		if model == "" {
+			model = config.DefaultAIModel()
+		}
+		return &anthropicTriageLLM{client: anthropic.NewClient(option.WithAPIKey(apiKey)), model: model}, nil
+	case "openai":
+		url := strings.TrimSuffix(config.GetString("ai.triage.url"), "/")
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			apiKey = config.GetString("ai.triage.api_key")
+		}
+		if url == "" {
+			// Local OpenAI-compatible servers usually need no key; the
+			// hosted API always does.
+			url = "https://api.openai.com/v1"
+			if apiKey == "" {
+				return nil, fmt.Errorf("openai triage requires OPENAI_API_KEY environment variable or ai.triage.api_key in config (or ai.triage.url for a local server)")
+			}
+		}
+		if model == "" {
+			model = "gpt-4o-mini"
+		}
+		return &openAITriageLLM{
+			url:    url,
+			model:  model,
+			apiKey: apiKey,
+			client: &http.Client{Timeout: 2 * time.Minute},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown triage provider %q (use: anthropic, openai)", provider)
+	}
+}
+
+// anthropicTriageLLM calls the Anthropic Messages API.
+type anthropicTriageLLM struct {
+	client anthropic.Client
+	model  string
+}
+
+func (l *anthropicTriageLLM) Name() string  { return "anthropic" }
+func (l *anthropicTriageLLM) Model() string { return l.model }
+
+func (l *anthropicTriageLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	tracer := telemetry.Tracer("github.com/steveyegge/beads/ai")
+	aiCtx, aiSpan := tracer.Start(ctx, "anthropic.messages.new")
+	defer aiSpan.End()
+	aiSpan.SetAttributes(
+		attribute.String("bd.ai.model", l.model),
+		attribute.String("bd.ai.operation", "triage"),
+	)
+	t0 := time.Now()
+	message, err := l.client.Messages.New(aiCtx, anthropic.MessageNewParams{
+		Model:     l.model,
+		MaxTokens: 4096,
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+		},
+	})
+	if err != nil {
+		aiSpan.RecordError(err)
+		aiSpan.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	aiSpan.SetAttributes(
+		attribute.Int64("bd.ai.input_tokens", message.Usage.InputTokens),
+		attribute.Int64("bd.ai.output_tokens", message.Usage.OutputTokens),
+		attribute.Float64("bd.ai.duration_ms", float64(time.Since(t0).Milliseconds())),
+	)
+	if len(message.Content) == 0 || message.Content[0].Type != "text" {
+		return "", fmt.Errorf("unexpected AI response format")
+	}
+	return message.Content[0].Text, nil
+}
+
+// openAITriageLLM calls an OpenAI-compatible /chat/completions endpoint.
+type openAITriageLLM struct {
+	url    string
+	model  string
+	apiKey string
+	client *http.Client
+}
+
+func (l *openAITriageLLM) Name() string  { return "openai" }
+func (l *openAITriageLLM) Model() string { return l.model }
+
+func (l *openAITriageLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":    l.model,
+		"messages": []map[string]string{{"role": "user", "content": prompt}},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.apiKey)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return "", fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(respBody, &completion); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", fmt.Errorf("response has no choices")
+	}
+	return completion.Choices[0].Message.Content, nil
+}
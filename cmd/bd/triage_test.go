@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseTriageReply(t *testing.T) {
+	t.Parallel()
+
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Crash on save", IssueType: types.TypeTask, Priority: 2},
+		{ID: "bd-2", Title: "Tidy README", IssueType: types.TypeChore, Priority: 2},
+	}
+	parents := []*types.Issue{{ID: "bd-epic", IssueType: types.TypeEpic}}
+
+	reply := "Here you go:\n```json\n" + `[
+		{"id": "bd-1", "priority": 0, "issue_type": "bug", "labels": ["editor", " editor ", ""], "parent": "bd-epic", "reason": "data loss"},
+		{"id": "bd-2", "priority": 2, "issue_type": "chore", "labels": [], "parent": "bd-nope"},
+		{"id": "bd-9", "priority": 1},
+		{"id": "bd-1", "priority": 4}
+	]` + "\n```"
+
+	got, err := parseTriageReply(reply, issues, parents, nil)
+	if err != nil {
+		t.Fatalf("parseTriageReply: %v", err)
+	}
+	// bd-2 proposes no change (same priority/type, unknown parent) and bd-9
+	// was not asked about; the second bd-1 entry is a duplicate.
+	if len(got) != 1 {
+		t.Fatalf("got %d proposals, want 1: %+v", len(got), got)
+	}
+	p := got[0]
+	if p.ID != "bd-1" || p.Title != "Crash on save" || p.Reason != "data loss" {
+		t.Errorf("proposal = %+v", p)
+	}
+	if p.Priority == nil || *p.Priority != 0 {
+		t.Errorf("priority = %v, want 0", p.Priority)
+	}
+	if p.IssueType != "bug" {
+		t.Errorf("issue_type = %q, want bug", p.IssueType)
+	}
+	if strings.Join(p.Labels, ",") != "editor" {
+		t.Errorf("labels = %q, want [editor]", p.Labels)
+	}
+	if p.Parent != "bd-epic" {
+		t.Errorf("parent = %q, want bd-epic", p.Parent)
+	}
+}
+
+func TestParseTriageReplyRejectsInvalidFields(t *testing.T) {
+	t.Parallel()
+
+	issues := []*types.Issue{{ID: "bd-1", IssueType: types.TypeTask, Priority: 2}}
+	got, err := parseTriageReply(`[{"id": "bd-1", "priority": 9, "issue_type": "nonsense", "parent": "bd-1", "labels": ["ok"]}]`,
+		issues, []*types.Issue{{ID: "bd-1"}}, nil)
+	if err != nil {
+		t.Fatalf("parseTriageReply: %v", err)
+	}
+	if len(got) != 1 || got[0].Priority != nil || got[0].IssueType != "" || got[0].Parent != "" {
+		t.Errorf("invalid fields kept: %+v", got)
+	}
+
+	if _, err := parseTriageReply("no json here", issues, nil, nil); err == nil {
+		t.Error("expected an error for a reply without JSON")
+	}
+}
+
+func TestOpenAITriageLLM(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none without a key", got)
+		}
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "llama3" || len(req.Messages) != 1 || req.Messages[0].Content != "hello" {
+			t.Errorf("request = %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"[]"}}]}`))
+	}))
+	defer srv.Close()
+
+	llm := &openAITriageLLM{url: srv.URL + "/v1", model: "llama3", client: srv.Client()}
+	reply, err := llm.Complete(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if reply != "[]" {
+		t.Errorf("reply = %q, want []", reply)
+	}
+}
+
+func TestBuildTriagePrompt(t *testing.T) {
+	t.Parallel()
+
+	prompt := buildTriagePrompt(
+		[]*types.Issue{{ID: "bd-1", Title: "Crash on save", IssueType: types.TypeTask, Priority: 2, Description: "Stack trace attached"}},
+		[]*types.Issue{{ID: "bd-epic", Title: "Editor polish", IssueType: types.TypeEpic}},
+		map[string]int{"editor": 3, "docs": 1},
+		[]string{"incident"},
+	)
+	for _, want := range []string{"[bd-1] (task, P2) Crash on save", "Stack trace attached", "[bd-epic] (epic) Editor polish", "Existing labels: editor, docs", "incident"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
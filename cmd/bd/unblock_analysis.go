@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// UnblockCandidate is one open blocker ranked by 'bd unblock-analysis'.
+type UnblockCandidate struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Status   types.Status `json:"status"`
+	Priority int          `json:"priority"`
+	Assignee string       `json:"assignee,omitempty"`
+	types.UnblockImpact
+	Blocked bool `json:"blocked"` // the candidate itself waits on open blockers
+}
+
+var unblockAnalysisCmd = &cobra.Command{
+	Use:     "unblock-analysis",
+	GroupID: "deps",
+	Short:   "Rank open issues by how much work finishing them would unblock",
+	Long: `Rank open issues by the downstream work they block, to answer "what single
+issue should we finish to free the most work?"
+
+For each open issue that blocks other open work, counts the issues waiting on
+it directly and transitively (A blocks B blocks C counts both B and C for A),
+and sums their estimates. Blocking edges are blocks, conditional-blocks, and
+waits-for; closed issues on either side are ignored. The whole closure comes
+from a single recursive query.
+
+Candidates that are themselves blocked are marked, since finishing them first
+requires clearing their own blockers.
+
+Examples:
+  bd unblock-analysis            # Top 10 blockers
+  bd unblock-analysis -n 0       # Every blocker
+  bd unblock-analysis --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			FatalErrorRespectJSON("--limit must be >= 0")
+		}
+		candidates, err := loadUnblockCandidates(rootCtx, store)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if limit > 0 && len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+		if jsonOutput {
+			outputJSON(candidates)
+			return
+		}
+		displayUnblockCandidates(candidates)
+	},
+}
+
+// loadUnblockCandidates returns every open blocker, ranked.
+func loadUnblockCandidates(ctx context.Context, s storage.DoltStorage) ([]*UnblockCandidate, error) {
+	impact, err := s.GetUnblockImpact(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("analyzing dependencies: %w", err)
+	}
+	if len(impact) == 0 {
+		return []*UnblockCandidate{}, nil
+	}
+	ids := make([]string, 0, len(impact))
+	for id := range impact {
+		ids = append(ids, id)
+	}
+	issues, err := s.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading blockers: %w", err)
+	}
+	blocked, err := s.GetBlockedIssues(ctx, types.WorkFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing blocked issues: %w", err)
+	}
+	blockedIDs := make(map[string]bool, len(blocked))
+	for _, b := range blocked {
+		blockedIDs[b.ID] = true
+	}
+	return rankUnblockCandidates(issues, impact, blockedIDs), nil
+}
+
+// rankUnblockCandidates pairs issues with their impact and sorts them by
+// transitive count, then estimate, then priority, then ID.
+func rankUnblockCandidates(issues []*types.Issue, impact map[string]*types.UnblockImpact, blockedIDs map[string]bool) []*UnblockCandidate {
+	candidates := []*UnblockCandidate{}
+	for _, issue := range issues {
+		r := impact[issue.ID]
+		if r == nil {
+			continue
+		}
+		candidates = append(candidates, &UnblockCandidate{
+			ID:            issue.ID,
+			Title:         issue.Title,
+			Status:        issue.Status,
+			Priority:      issue.Priority,
+			Assignee:      issue.Assignee,
+			UnblockImpact: *r,
+			Blocked:       issue.Status == types.StatusBlocked || blockedIDs[issue.ID],
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Unblocks != b.Unblocks {
+			return a.Unblocks > b.Unblocks
+		}
+		if a.EstimatedMinutes != b.EstimatedMinutes {
+			return a.EstimatedMinutes > b.EstimatedMinutes
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	return candidates
+}
+
+func displayUnblockCandidates(candidates []*UnblockCandidate) {
+	if len(candidates) == 0 {
+		fmt.Printf("\n%s Nothing is blocking open work\n\n", ui.RenderPass("✨"))
+		return
+	}
+	fmt.Printf("\n%s Finish these to unblock the most work:\n\n", ui.RenderAccent("🔓"))
+	for i, c := range candidates {
+		line := fmt.Sprintf("  %d. [%s] %s", i+1, ui.RenderPriority(c.Priority), formatFeedbackID(c.ID, c.Title))
+		if c.Assignee != "" {
+			line += " @" + c.Assignee
+		}
+		impact := fmt.Sprintf("unblocks %d (%d direct)", c.Unblocks, c.Direct)
+		if c.EstimatedMinutes > 0 {
+			impact += ", " + formatEstimateMinutes(c.EstimatedMinutes)
+		}
+		line += "  " + ui.RenderAccent(impact)
+		if c.Blocked {
+			line += "  " + ui.RenderWarn("[itself blocked]")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+func init() {
+	unblockAnalysisCmd.Flags().IntP("limit", "n", 10, "Maximum blockers to show (0 for all)")
+	rootCmd.AddCommand(unblockAnalysisCmd)
+}
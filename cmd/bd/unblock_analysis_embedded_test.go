@@ -0,0 +1,71 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedUnblockAnalysis(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	dir, _, _ := bdInit(t, bd, "--prefix", "ua")
+
+	// schema blocks api and form, which both block docs; schema is the
+	// highest-leverage issue. The closed issue must not count for api.
+	schema := bdCreate(t, bd, dir, "Schema")
+	api := bdCreate(t, bd, dir, "API", "--estimate", "120")
+	form := bdCreate(t, bd, dir, "Form", "--estimate", "60")
+	docs := bdCreate(t, bd, dir, "Docs", "--estimate", "30")
+	old := bdCreate(t, bd, dir, "Old work")
+	bdDepAdd(t, bd, dir, api.ID, schema.ID)
+	bdDepAdd(t, bd, dir, form.ID, schema.ID)
+	bdDepAdd(t, bd, dir, docs.ID, api.ID)
+	bdDepAdd(t, bd, dir, docs.ID, form.ID, "--type", "waits-for")
+	bdDepAdd(t, bd, dir, old.ID, api.ID)
+	bdClose(t, bd, dir, old.ID, "--force")
+
+	out, err := bdRunWithFlockRetry(t, bd, dir, "unblock-analysis", "--json")
+	if err != nil {
+		t.Fatalf("bd unblock-analysis failed: %v\n%s", err, out)
+	}
+	var candidates []*UnblockCandidate
+	if err := json.Unmarshal(out[strings.Index(string(out), "["):], &candidates); err != nil {
+		t.Fatalf("parse: %v\n%s", err, out)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("got %d candidates, want schema, api, form: %+v", len(candidates), candidates)
+	}
+	top := candidates[0]
+	if top.ID != schema.ID || top.Unblocks != 3 || top.Direct != 2 || top.EstimatedMinutes != 210 || top.Blocked {
+		t.Errorf("top candidate = %+v, want schema unblocking 3 (2 direct, 210m)", top)
+	}
+	// api and form tie on every sort key but the generated ID, so only
+	// check that both follow schema, each unblocking docs alone.
+	seen := map[string]bool{}
+	for _, c := range candidates[1:] {
+		seen[c.ID] = true
+		if c.Unblocks != 1 || c.Direct != 1 || c.EstimatedMinutes != 30 || !c.Blocked {
+			t.Errorf("candidate %s = %+v, want blocked and unblocking docs only", c.ID, c)
+		}
+	}
+	if !seen[api.ID] || !seen[form.ID] {
+		t.Errorf("candidates after schema = %+v, want api and form", candidates[1:])
+	}
+
+	out, err = bdRunWithFlockRetry(t, bd, dir, "unblock-analysis", "-n", "1")
+	if err != nil {
+		t.Fatalf("bd unblock-analysis -n 1 failed: %v\n%s", err, out)
+	}
+	text := string(out)
+	if !strings.Contains(text, schema.ID) || !strings.Contains(text, "unblocks 3 (2 direct), 3h30m") || strings.Contains(text, api.ID) {
+		t.Errorf("unexpected text output:\n%s", text)
+	}
+}
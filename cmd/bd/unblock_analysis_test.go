@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRankUnblockCandidates(t *testing.T) {
+	t.Parallel()
+	issues := []*types.Issue{
+		{ID: "bd-a", Title: "Small", Priority: 1, Status: types.StatusOpen},
+		{ID: "bd-b", Title: "Big", Priority: 2, Status: types.StatusOpen},
+		{ID: "bd-c", Title: "Big but cheap", Priority: 0, Status: types.StatusOpen},
+		{ID: "bd-d", Title: "Tie on everything", Priority: 0, Status: types.StatusBlocked},
+		{ID: "bd-e", Title: "Blocks nothing", Status: types.StatusOpen},
+	}
+	impact := map[string]*types.UnblockImpact{
+		"bd-a": {Unblocks: 1, Direct: 1},
+		"bd-b": {Unblocks: 4, Direct: 1, EstimatedMinutes: 300},
+		"bd-c": {Unblocks: 4, Direct: 4, EstimatedMinutes: 60},
+		"bd-d": {Unblocks: 4, Direct: 2, EstimatedMinutes: 60},
+	}
+
+	got := rankUnblockCandidates(issues, impact, map[string]bool{"bd-a": true})
+
+	want := []string{"bd-b", "bd-c", "bd-d", "bd-a"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("rank %d = %s, want %s", i+1, got[i].ID, id)
+		}
+	}
+	if !got[2].Blocked || !got[3].Blocked || got[0].Blocked {
+		t.Errorf("blocked flags = %v %v %v %v, want bd-d (status) and bd-a (dependency) only",
+			got[0].Blocked, got[1].Blocked, got[2].Blocked, got[3].Blocked)
+	}
+	if got[0].Direct != 1 || got[0].EstimatedMinutes != 300 {
+		t.Errorf("bd-b impact = %+v", got[0].UnblockImpact)
+	}
+}
@@ -283,6 +283,7 @@ create, update, show, or close operation).`,
 
 		// Get claim flag
 		claimFlag, _ := cmd.Flags().GetBool("claim")
+		forceFlag, _ := cmd.Flags().GetBool("force")
 
 		if len(updates) == 0 && !claimFlag {
 			fmt.Println("No updates specified")
@@ -290,6 +291,9 @@ create, update, show, or close operation).`,
 		}
 
 		ctx := rootCtx
+		if forceFlag {
+			ctx = storage.WithLockOverride(ctx)
+		}
 
 		updatedIssues := []*types.Issue{}
 		var firstUpdatedID string // Track first successful update for last-touched
@@ -347,6 +351,14 @@ create, update, show, or close operation).`,
 				continue
 			}
 
+			if !forceFlag {
+				if err := checkIssueLock(ctx, issueStore, result.ResolvedID); err != nil {
+					fmt.Fprintf(os.Stderr, "cannot update %s: %v (use --force to override)\n", id, err)
+					closeIfUnmutated(result)
+					continue
+				}
+			}
+
 			// Handle claim operation atomically using compare-and-swap semantics
 			if claimFlag {
 				if err := issueStore.ClaimIssue(ctx, result.ResolvedID, actor); err != nil {
@@ -398,6 +410,13 @@ create, update, show, or close operation).`,
 				combined += appendNotes
 				regularUpdates["notes"] = combined
 			}
+			if status, ok := regularUpdates["status"].(string); ok {
+				if err := checkStatusTransition(ctx, issueStore, issue, types.Status(status)); err != nil {
+					fmt.Fprintf(os.Stderr, "%s\n", err)
+					closeIfUnmutated(result)
+					continue
+				}
+			}
 			if len(regularUpdates) > 0 {
 				if err := issueStore.UpdateIssue(ctx, result.ResolvedID, regularUpdates, actor); err != nil {
 					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
@@ -652,6 +671,7 @@ func init() {
 	updateCmd.Flags().StringSlice("remove-label", nil, "Remove labels (repeatable)")
 	updateCmd.Flags().StringSlice("set-labels", nil, "Set labels, replacing all existing (repeatable)")
 	updateCmd.Flags().String("parent", "", "New parent issue ID (reparents the issue, use empty string to remove parent)")
+	updateCmd.Flags().BoolP("force", "f", false, "Update even if another actor holds a lock on the issue (bd lock)")
 	updateCmd.Flags().Bool("claim", false, "Atomically claim the issue (sets assignee to you, status to in_progress; idempotent if already claimed by you)")
 	updateCmd.Flags().String("session", "", "Claude Code session ID for status=closed (or set CLAUDE_SESSION_ID env var)")
 	// Time-based scheduling flags (GH#820)
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/ui"
 )
 
@@ -72,7 +73,7 @@ Examples:
 				if err := store.Commit(ctx, fmt.Sprintf("Resolve merge conflicts from %s using %s strategy", branchName, vcMergeStrategy)); err != nil {
 					FatalErrorRespectJSON("conflicts resolved but commit failed: %v", err)
 				}
-				if rs, ok := store.(interface {
+				if rs, ok := storage.UnwrapStore(store).(interface {
 					RecomputeBlockedAfterMerge(ctx context.Context, fromCommit string) error
 				}); ok {
 					if err := rs.RecomputeBlockedAfterMerge(ctx, preHead); err != nil {
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+var watchJSONLCmd = &cobra.Command{
+	Use:   "watch-jsonl [file]",
+	Short: "Auto-import the JSONL file whenever it changes",
+	Long: `Watch .beads/issues.jsonl (or the configured import.path, or [file]) and
+import it each time it changes, so the database catches up with a git
+pull, checkout, or merge without a manual 'bd import'.
+
+Changes are debounced: a burst of writes (git rewriting the file, an
+editor saving twice) triggers one import once the file has been quiet for
+--debounce. Each import is the same streaming upsert as 'bd import', with
+the same --on-conflict handling, and records last_import_time in the
+database metadata.
+
+A file last written by this clone's own auto-export is not re-imported,
+since it already matches the database. At startup the file is imported
+once if it changed after the recorded last_import_time.
+
+The watcher runs in the foreground until interrupted; run it under your
+process supervisor or in a spare terminal.
+
+EXAMPLES:
+  bd watch-jsonl                        # Watch the configured import path
+  bd watch-jsonl --debounce 2s          # Wait for 2s of quiet before importing
+  bd watch-jsonl --on-conflict ours     # Never let the file override newer local edits`,
+	GroupID: "sync",
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runWatchJSONL,
+}
+
+var watchJSONLDebounce time.Duration
+
+func init() {
+	watchJSONLCmd.Flags().DurationVar(&watchJSONLDebounce, "debounce", 500*time.Millisecond, "Quiet period after the last change before importing")
+	watchJSONLCmd.Flags().StringVar(&importOnConflict, "on-conflict", conflictNewest, "How to resolve an issue also changed locally: newest, ours, theirs")
+	rootCmd.AddCommand(watchJSONLCmd)
+}
+
+func runWatchJSONL(cmd *cobra.Command, args []string) error {
+	CheckReadonly("watch-jsonl")
+	if store == nil {
+		return fmt.Errorf("no database — run 'bd init' or 'bd bootstrap' first")
+	}
+	strategy, err := validateImportConflictStrategy(importOnConflict, false)
+	if err != nil {
+		return err
+	}
+	if strategy == conflictInteractive {
+		return fmt.Errorf("--on-conflict interactive is not available to the watcher; use newest, ours, or theirs")
+	}
+
+	beadsDir := beads.FindBeadsDir()
+	var path string
+	switch {
+	case len(args) > 0:
+		path = args[0]
+	case beadsDir == "":
+		return fmt.Errorf("%s — %s", activeWorkspaceNotFoundError(), diagHint())
+	default:
+		path = configuredImportJSONLPath(beadsDir)
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return err
+	}
+
+	ctx := rootCtx
+	lastImport := loadLastImportTime(ctx, store)
+	importIfChanged := func(initial bool) {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() == 0 {
+			return
+		}
+		if jsonlWrittenByAutoExport(beadsDir, info) {
+			debug.Logf("watch-jsonl: %s was written by auto-export; skipping\n", path)
+			return
+		}
+		if initial && !info.ModTime().After(lastImport) {
+			return
+		}
+		if err := watchImportOnce(ctx, path, info, beadsDir); err != nil {
+			fmt.Fprintf(os.Stderr, "%s import failed: %v\n", time.Now().Format(time.TimeOnly), err)
+			return
+		}
+		lastImport = time.Now()
+	}
+
+	importIfChanged(true)
+	fmt.Fprintf(os.Stderr, "Watching %s for changes... (Press Ctrl+C to exit)\n", path)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-sigChan
+		cancel()
+	}()
+
+	err = watchFileDebounced(watchCtx, path, watchJSONLDebounce, func() { importIfChanged(false) })
+	fmt.Fprintf(os.Stderr, "\nStopped watching.\n")
+	return err
+}
+
+// watchImportOnce runs one streaming import of path and records the time.
+// It holds the import lock, so a stale refresh in another bd process does
+// not import the same file concurrently.
+func watchImportOnce(ctx context.Context, path string, info os.FileInfo, beadsDir string) error {
+	if beadsDir != "" {
+		release, err := acquireImportLock(ctx, beadsDir, importLockTimeout)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: watched import path
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(os.Stderr, "%s ", time.Now().Format(time.TimeOnly))
+	if err := runImportFromReader(ctx, f, path, &importFile{f: f, path: path, info: info, beadsDir: beadsDir}); err != nil {
+		return err
+	}
+	if err := store.SetMetadata(ctx, "last_import_time", time.Now().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("record last_import_time: %w", err)
+	}
+	if err := store.Commit(ctx, "bd watch-jsonl: record last_import_time"); err != nil && !isDoltNothingToCommit(err) {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// jsonlWrittenByAutoExport reports whether the file has not changed since
+// this clone's last auto-export, which saves its state just after writing.
+func jsonlWrittenByAutoExport(beadsDir string, info os.FileInfo) bool {
+	if beadsDir == "" {
+		return false
+	}
+	state := loadExportAutoState(beadsDir)
+	return !state.Timestamp.IsZero() && !info.ModTime().After(state.Timestamp)
+}
+
+// watchFileDebounced calls onChange once path has stopped changing for
+// debounce. It watches the parent directory, because git and most editors
+// replace files by renaming a new one into place, which drops a watch on
+// the file itself.
+func watchFileDebounced(ctx context.Context, path string, debounce time.Duration, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("start file watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("watch %s: %w", filepath.Dir(path), err)
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Warning: file watcher: %v\n", err)
+		case <-timer.C:
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchFileDebounced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	done := make(chan error, 1)
+	go func() {
+		done <- watchFileDebounced(ctx, path, 200*time.Millisecond, func() { calls.Add(1) })
+	}()
+	time.Sleep(100 * time.Millisecond) // let the watcher start
+
+	// A burst of writes, including a rename into place, is one change.
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(path, []byte("{}\n{}\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	tmp := filepath.Join(dir, "issues.jsonl.tmp")
+	if err := os.WriteFile(tmp, []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	// Other files in the directory are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "other.jsonl"), []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(400 * time.Millisecond)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("onChange called %d times, want 1", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchFileDebounced: %v", err)
+	}
+}
+
+func TestJSONLWrittenByAutoExport(t *testing.T) {
+	beadsDir := t.TempDir()
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsonlWrittenByAutoExport(beadsDir, info) {
+		t.Error("no export state should mean the file came from elsewhere")
+	}
+
+	saveExportAutoState(beadsDir, &exportAutoState{Timestamp: info.ModTime().Add(time.Millisecond)})
+	if !jsonlWrittenByAutoExport(beadsDir, info) {
+		t.Error("file unchanged since auto-export should be skipped")
+	}
+
+	later := info.ModTime().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if jsonlWrittenByAutoExport(beadsDir, info) {
+		t.Error("file changed after auto-export (e.g. git pull) should be imported")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/town"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -23,6 +24,7 @@ import (
 // Commands:
 //   bd mol wisp list    - List all wisps in current context
 //   bd mol wisp gc      - Garbage collect orphaned wisps
+//   bd mol wisp burn    - Burn wisps selected by filter
 
 var wispCmd = &cobra.Command{
 	Use:   "wisp [proto-id]",
@@ -30,7 +32,7 @@ var wispCmd = &cobra.Command{
 	Long: `Create or manage wisps - EPHEMERAL molecules for operational workflows.
 
 When called with a proto-id argument, creates a wisp from that proto.
-When called with a subcommand (list, gc), manages existing wisps.
+When called with a subcommand (list, gc, burn), manages existing wisps.
 
 Wisps are issues with Ephemeral=true in the main database. They're stored
 locally but NOT synced via git.
@@ -61,10 +63,12 @@ Examples:
   bd mol wisp mol-my-workflow                  # Ephemeral operational cycle
   bd mol wisp list                             # List all wisps
   bd mol wisp gc                               # Garbage collect old wisps
+  bd mol wisp burn --closed --older-than 24h   # Burn wisps by filter
 
 Subcommands:
   list  List all wisps in current context
-  gc    Garbage collect orphaned wisps`,
+  gc    Garbage collect orphaned wisps
+  burn  Burn wisps selected by age, label, status, or creator`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runWisp,
 }
@@ -269,6 +273,14 @@ func runWispCreate(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	adding := len(subgraph.Issues)
+	if rootOnly {
+		adding = 1
+	}
+	if err := checkWispQuota(ctx, store, adding); err != nil {
+		FatalErrorWithHint(err.Error(), "close or burn finished wisps, or raise it with 'bd town set wisp-quota <n>'")
+	}
+
 	// Spawn as ephemeral in main database (Ephemeral=true, not synced via git)
 	// Use wisp prefix for distinct visual recognition (see types.IDPrefixWisp)
 	result, err := spawnMoleculeWithOptions(ctx, store, subgraph, CloneOptions{
@@ -302,6 +314,41 @@ func runWispCreate(cmd *cobra.Command, args []string) {
 	fmt.Printf("  bd mol burn %s           # Discard without creating digest\n", result.NewEpicID)
 }
 
+// checkWispQuota refuses to add wisp issues beyond town.wisp_quota open wisps.
+func checkWispQuota(ctx context.Context, s storage.DoltStorage, adding int) error {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("reading wisp quota: %w", err)
+	}
+	quota := town.FromConfig(all).WispQuota
+	if quota == 0 {
+		return nil
+	}
+	open, err := countOpenWisps(ctx, s, quota)
+	if err != nil {
+		return fmt.Errorf("counting open wisps: %w", err)
+	}
+	if open+adding > quota {
+		return fmt.Errorf("wisp quota exceeded: %d open + %d new > quota of %d", open, adding, quota)
+	}
+	return nil
+}
+
+// countOpenWisps counts non-closed ephemeral issues, stopping at limit
+// (0 = no limit).
+func countOpenWisps(ctx context.Context, s storage.DoltStorage, limit int) (int, error) {
+	ephemeral := true
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Ephemeral:     &ephemeral,
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		Limit:         limit,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(issues), nil
+}
+
 // isProtoIssue checks if an issue is a proto (has the template label)
 func isProtoIssue(issue *types.Issue) bool {
 	for _, label := range issue.Labels {
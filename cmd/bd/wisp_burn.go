@@ -0,0 +1,345 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var wispBurnCmd = &cobra.Command{
+	Use:   "burn",
+	Short: "Burn wisps selected by age, label, status, or creator",
+	Long: `Burn (delete without digest) every wisp matching a filter.
+
+Unlike 'bd mol burn', which takes explicit molecule IDs, this selects wisps
+by their attributes. At least one selector is required. Selectors combine
+with AND semantics; --label may be repeated and every label must match.
+
+Wisps are deleted in batches of --batch-size, each in its own transaction,
+so a failure partway through keeps the batches already burned. Pinned wisps
+and infrastructure types (types.infra) are never selected.
+
+Age is measured from the wisp's last update. --older-than accepts Go
+durations (90m, 24h) and day/week units (7d, 2w).
+
+Examples:
+  bd mol wisp burn --older-than 24h --label patrol --closed --dry-run
+  bd mol wisp burn --older-than 7d --force
+  bd mol wisp burn --created-by deacon --group-by label
+  bd mol wisp burn --status open --type heartbeat --older-than 2h --force`,
+	Run: runWispBurn,
+}
+
+// wispBurnCriteria selects wisps for a filtered burn.
+type wispBurnCriteria struct {
+	OlderThan *time.Time // last updated before this time
+	Labels    []string   // must carry every label
+	Status    *types.Status
+	CreatedBy string
+	Type      *types.IssueType
+}
+
+func (c wispBurnCriteria) empty() bool {
+	return c.OlderThan == nil && len(c.Labels) == 0 && c.Status == nil && c.CreatedBy == "" && c.Type == nil
+}
+
+// WispBurnGroup is the number of wisps burned for one --group-by value.
+type WispBurnGroup struct {
+	Group string `json:"group"`
+	Count int    `json:"count"`
+}
+
+// WispBurnResult is the JSON output for wisp burn.
+type WispBurnResult struct {
+	DeletedIDs   []string        `json:"deleted_ids"`
+	DeletedCount int             `json:"deleted_count"`
+	Candidates   int             `json:"candidates"`
+	Batches      int             `json:"batches"`
+	GroupBy      string          `json:"group_by"`
+	Groups       []WispBurnGroup `json:"groups"`
+	DryRun       bool            `json:"dry_run,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+func runWispBurn(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	statusStr, _ := cmd.Flags().GetString("status")
+	closedOnly, _ := cmd.Flags().GetBool("closed")
+	createdBy, _ := cmd.Flags().GetString("created-by")
+	typeStr, _ := cmd.Flags().GetString("type")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+
+	if !dryRun {
+		CheckReadonly("wisp burn")
+	}
+	ctx := rootCtx
+	now := time.Now()
+
+	if store == nil {
+		FatalErrorWithHint("no database connection", diagHint())
+	}
+	if batchSize <= 0 {
+		FatalErrorRespectJSON("--batch-size must be positive")
+	}
+	switch groupBy {
+	case "type", "status", "label", "creator":
+	default:
+		FatalErrorRespectJSON("invalid --group-by %q: must be type, status, label, or creator", groupBy)
+	}
+
+	var criteria wispBurnCriteria
+	if olderThan != "" {
+		cutoff, err := parseWispAge(olderThan, now)
+		if err != nil {
+			FatalErrorRespectJSON("invalid --older-than: %v", err)
+		}
+		criteria.OlderThan = &cutoff
+	}
+	criteria.Labels = labels
+	if closedOnly && statusStr != "" && statusStr != string(types.StatusClosed) {
+		FatalErrorRespectJSON("--closed conflicts with --status %s", statusStr)
+	}
+	if closedOnly {
+		statusStr = string(types.StatusClosed)
+	}
+	if statusStr != "" {
+		status := types.Status(statusStr)
+		criteria.Status = &status
+	}
+	criteria.CreatedBy = createdBy
+	if typeStr != "" {
+		it := types.IssueType(typeStr)
+		criteria.Type = &it
+	}
+	if criteria.empty() {
+		FatalErrorRespectJSON("at least one selector is required (--older-than, --label, --status/--closed, --created-by, --type)")
+	}
+
+	// Push the cheap predicates into the query; creator and pinned are
+	// filtered in memory by selectWispsForBurn.
+	ephemeral := true
+	filter := types.IssueFilter{
+		Ephemeral:     &ephemeral,
+		Labels:        criteria.Labels,
+		Status:        criteria.Status,
+		IssueType:     criteria.Type,
+		UpdatedBefore: criteria.OlderThan,
+	}
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("listing wisps: %v", err)
+	}
+	var candidates []*types.Issue
+	for _, issue := range selectWispsForBurn(issues, criteria) {
+		if store.IsInfraTypeCtx(ctx, issue.IssueType) {
+			continue
+		}
+		candidates = append(candidates, issue)
+	}
+
+	result := WispBurnResult{
+		DeletedIDs: []string{},
+		Candidates: len(candidates),
+		GroupBy:    groupBy,
+		Groups:     groupWispBurnCounts(candidates, groupBy),
+		DryRun:     dryRun,
+	}
+	if len(candidates) == 0 {
+		if jsonOutput {
+			outputJSON(result)
+		} else {
+			fmt.Println("No wisps match the filter")
+		}
+		return
+	}
+
+	if dryRun {
+		if jsonOutput {
+			for _, issue := range candidates {
+				result.DeletedIDs = append(result.DeletedIDs, issue.ID)
+			}
+			outputJSON(result)
+			return
+		}
+		fmt.Printf("Dry run: would burn %d wisp(s):\n\n", len(candidates))
+		for _, issue := range candidates {
+			fmt.Printf("  %s: %s [%s] (last updated: %s)\n", issue.ID, issue.Title, issue.Status, formatTimeAgo(issue.UpdatedAt))
+		}
+		printWispBurnGroups(result.Groups, groupBy)
+		fmt.Printf("\nRun without --dry-run to burn these wisps.\n")
+		return
+	}
+
+	if !force && !jsonOutput {
+		fmt.Printf("About to burn %d wisp(s) with no digest.\n", len(candidates))
+		printWispBurnGroups(result.Groups, groupBy)
+		fmt.Printf("\nContinue? [y/N] ")
+
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Canceled.")
+			return
+		}
+	}
+
+	// Burn batch by batch; the per-group summary reflects what was actually
+	// deleted if a later batch fails.
+	var burned []*types.Issue
+	for start := 0; start < len(candidates); start += batchSize {
+		batch := candidates[start:min(start+batchSize, len(candidates))]
+		ids := make([]string, len(batch))
+		for i, issue := range batch {
+			ids[i] = issue.ID
+		}
+		br, err := burnWisps(ctx, store, ids)
+		if err != nil {
+			result.Error = fmt.Sprintf("batch %d: %v", result.Batches+1, err)
+			break
+		}
+		result.Batches++
+		result.DeletedIDs = append(result.DeletedIDs, br.DeletedIDs...)
+		result.DeletedCount += br.DeletedCount
+		burned = append(burned, batch...)
+	}
+	result.Groups = groupWispBurnCounts(burned, groupBy)
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		fmt.Printf("%s Burned %d of %d wisp(s) in %d batch(es)\n",
+			ui.RenderPass("✓"), result.DeletedCount, result.Candidates, result.Batches)
+		printWispBurnGroups(result.Groups, groupBy)
+	}
+	if result.Error != "" {
+		FatalError("burning wisps: %s", result.Error)
+	}
+}
+
+// parseWispAge converts --older-than into a cutoff time. Go durations are
+// tried first so "30m" means minutes, as with 'wisp gc --age'; compact day,
+// week, month, and year units are accepted as well.
+func parseWispAge(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("duration must be positive: %s", s)
+		}
+		return now.Add(-d), nil
+	}
+	return parseWindowFlag(s, now)
+}
+
+// selectWispsForBurn applies every criterion in memory and drops pinned
+// wisps. It is safe to call on a pre-filtered query result.
+func selectWispsForBurn(issues []*types.Issue, c wispBurnCriteria) []*types.Issue {
+	var selected []*types.Issue
+	for _, issue := range issues {
+		if !issue.Ephemeral || issue.Pinned {
+			continue
+		}
+		if c.OlderThan != nil && !issue.UpdatedAt.Before(*c.OlderThan) {
+			continue
+		}
+		if c.Status != nil && issue.Status != *c.Status {
+			continue
+		}
+		if c.Type != nil && issue.IssueType != *c.Type {
+			continue
+		}
+		if c.CreatedBy != "" && issue.CreatedBy != c.CreatedBy {
+			continue
+		}
+		if !hasAllLabels(issue.Labels, c.Labels) {
+			continue
+		}
+		selected = append(selected, issue)
+	}
+	return selected
+}
+
+func hasAllLabels(have, want []string) bool {
+	for _, l := range want {
+		if !slices.Contains(have, l) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupWispBurnCounts counts wisps per --group-by value, largest group
+// first. With "label", a wisp counts once under each of its labels.
+func groupWispBurnCounts(issues []*types.Issue, groupBy string) []WispBurnGroup {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		var keys []string
+		switch groupBy {
+		case "status":
+			keys = []string{string(issue.Status)}
+		case "label":
+			keys = issue.Labels
+		case "creator":
+			keys = []string{issue.CreatedBy}
+		default:
+			keys = []string{string(issue.IssueType)}
+		}
+		if len(keys) == 0 {
+			keys = []string{""}
+		}
+		for _, k := range keys {
+			if k == "" {
+				k = "(none)"
+			}
+			counts[k]++
+		}
+	}
+	groups := make([]WispBurnGroup, 0, len(counts))
+	for k, n := range counts {
+		groups = append(groups, WispBurnGroup{Group: k, Count: n})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Group < groups[j].Group
+	})
+	return groups
+}
+
+func printWispBurnGroups(groups []WispBurnGroup, groupBy string) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Printf("\nBy %s:\n", groupBy)
+	width := 0
+	for _, g := range groups {
+		width = max(width, len(g.Group))
+	}
+	for _, g := range groups {
+		fmt.Printf("  %s%s  %d\n", g.Group, strings.Repeat(" ", width-len(g.Group)), g.Count)
+	}
+}
+
+func init() {
+	wispBurnCmd.Flags().String("older-than", "", "Only wisps not updated within this duration (e.g. 90m, 24h, 7d)")
+	wispBurnCmd.Flags().StringSlice("label", nil, "Only wisps with this label (repeatable, AND semantics)")
+	wispBurnCmd.Flags().String("status", "", "Only wisps with this status")
+	wispBurnCmd.Flags().Bool("closed", false, "Only closed wisps (shorthand for --status closed)")
+	wispBurnCmd.Flags().String("created-by", "", "Only wisps created by this actor")
+	wispBurnCmd.Flags().String("type", "", "Only wisps of this issue type")
+	wispBurnCmd.Flags().String("group-by", "type", "Summarize counts by: type, status, label, creator")
+	wispBurnCmd.Flags().Int("batch-size", 200, "Wisps deleted per transaction")
+	wispBurnCmd.Flags().Bool("dry-run", false, "Preview what would be burned")
+	wispBurnCmd.Flags().BoolP("force", "f", false, "Skip confirmation prompt")
+
+	wispCmd.AddCommand(wispBurnCmd)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSelectWispsForBurn(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	issues := []*types.Issue{
+		{ID: "w-1", Ephemeral: true, Status: types.StatusClosed, UpdatedAt: ago(48 * time.Hour), Labels: []string{"patrol"}, CreatedBy: "deacon", IssueType: types.TypeTask},
+		{ID: "w-2", Ephemeral: true, Status: types.StatusOpen, UpdatedAt: ago(48 * time.Hour), Labels: []string{"patrol"}, CreatedBy: "witness", IssueType: types.TypeTask},
+		{ID: "w-3", Ephemeral: true, Status: types.StatusClosed, UpdatedAt: ago(time.Hour), Labels: []string{"patrol"}, CreatedBy: "deacon", IssueType: types.TypeTask},
+		{ID: "w-4", Ephemeral: true, Status: types.StatusClosed, UpdatedAt: ago(48 * time.Hour), Labels: []string{"patrol"}, Pinned: true},
+		{ID: "w-5", Ephemeral: true, Status: types.StatusClosed, UpdatedAt: ago(48 * time.Hour), Labels: []string{"other"}},
+		{ID: "bd-6", Status: types.StatusClosed, UpdatedAt: ago(48 * time.Hour), Labels: []string{"patrol"}},
+	}
+	cutoff := ago(24 * time.Hour)
+	closed := types.StatusClosed
+
+	ids := func(sel []*types.Issue) []string {
+		out := []string{}
+		for _, i := range sel {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		criteria wispBurnCriteria
+		want     []string
+	}{
+		{"age label closed", wispBurnCriteria{OlderThan: &cutoff, Labels: []string{"patrol"}, Status: &closed}, []string{"w-1"}},
+		{"age only", wispBurnCriteria{OlderThan: &cutoff}, []string{"w-1", "w-2", "w-5"}},
+		{"creator", wispBurnCriteria{CreatedBy: "deacon"}, []string{"w-1", "w-3"}},
+		{"all labels required", wispBurnCriteria{Labels: []string{"patrol", "other"}}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(selectWispsForBurn(issues, tt.criteria))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupWispBurnCounts(t *testing.T) {
+	issues := []*types.Issue{
+		{IssueType: types.TypeTask, Labels: []string{"patrol", "ops"}, CreatedBy: "deacon"},
+		{IssueType: types.TypeTask, Labels: []string{"patrol"}},
+		{IssueType: types.TypeBug},
+	}
+
+	byType := groupWispBurnCounts(issues, "type")
+	if len(byType) != 2 || byType[0].Group != "task" || byType[0].Count != 2 {
+		t.Errorf("by type = %+v", byType)
+	}
+
+	byLabel := groupWispBurnCounts(issues, "label")
+	want := []WispBurnGroup{{"patrol", 2}, {"(none)", 1}, {"ops", 1}}
+	if len(byLabel) != len(want) {
+		t.Fatalf("by label = %+v, want %+v", byLabel, want)
+	}
+	for i := range want {
+		if byLabel[i] != want[i] {
+			t.Errorf("by label = %+v, want %+v", byLabel, want)
+		}
+	}
+
+	byCreator := groupWispBurnCounts(issues, "creator")
+	if byCreator[0].Group != "(none)" || byCreator[0].Count != 2 {
+		t.Errorf("by creator = %+v", byCreator)
+	}
+}
+
+func TestParseWispAge(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"30m", now.Add(-30 * time.Minute)},
+		{"24h", now.Add(-24 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+	}
+	for _, tt := range tests {
+		got, err := parseWispAge(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseWispAge(%q) = %s, %v; want %s", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseWispAge("-1h", now); err == nil {
+		t.Error("expected error for negative duration")
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// AssigneeWorkload is one assignee's share of the active work. Blocked
+// issues are also counted in Open or InProgress.
+type AssigneeWorkload struct {
+	Assignee         string `json:"assignee"`
+	Open             int    `json:"open"`
+	InProgress       int    `json:"in_progress"`
+	Blocked          int    `json:"blocked"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+}
+
+// Active is the number of open and in-progress issues, the load measure
+// used by 'bd assign --balance'.
+func (w *AssigneeWorkload) Active() int {
+	return w.Open + w.InProgress
+}
+
+// WorkloadReport is the JSON document emitted by 'bd workload'.
+type WorkloadReport struct {
+	Assignees  []*AssigneeWorkload `json:"assignees"`
+	Unassigned *AssigneeWorkload   `json:"unassigned"`
+}
+
+// AssignmentProposal is one assignment suggested by 'bd assign --balance'.
+type AssignmentProposal struct {
+	IssueID  string `json:"issue_id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee"`
+	Load     int    `json:"load"` // assignee's active issues before this one
+	Error    string `json:"error,omitempty"`
+}
+
+// BalanceResult is the JSON output of 'bd assign --balance'.
+type BalanceResult struct {
+	Proposals []*AssignmentProposal `json:"proposals"`
+	Unmatched []string              `json:"unmatched,omitempty"` // ready issues whose required skills no agent offers
+	Agents    []string              `json:"agents"`
+	Applied   bool                  `json:"applied"`
+}
+
+var workloadCmd = &cobra.Command{
+	Use:     "workload",
+	GroupID: "views",
+	Short:   "Show open, in-progress, and blocked work per assignee",
+	Long: `Show how active work is spread across assignees.
+
+For each assignee: open and in-progress issue counts, how many of those are
+blocked (by status or by open dependencies), and the sum of their estimates.
+Unassigned work is shown on its own line. Closed, deferred, and pinned issues
+and templates are not counted.
+
+Use 'bd assign --balance' to hand unassigned ready work to the least-loaded
+agents.
+
+Examples:
+  bd workload
+  bd workload --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		report, err := loadWorkload(rootCtx, store)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(report)
+			return
+		}
+		displayWorkload(report)
+	},
+}
+
+// loadWorkload computes the workload report from the store.
+func loadWorkload(ctx context.Context, s storage.DoltStorage) (*WorkloadReport, error) {
+	ephemeral := false
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Ephemeral:     &ephemeral,
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusDeferred, types.StatusPinned},
+		ExcludeLabels: []string{BeadsTemplateLabel},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing active issues: %w", err)
+	}
+	blocked, err := s.GetBlockedIssues(ctx, types.WorkFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("listing blocked issues: %w", err)
+	}
+	blockedIDs := make(map[string]bool, len(blocked))
+	for _, b := range blocked {
+		blockedIDs[b.ID] = true
+	}
+	return computeWorkload(issues, blockedIDs), nil
+}
+
+// computeWorkload tallies issues per assignee. Assignees are sorted by
+// active count, busiest first, then by name.
+func computeWorkload(issues []*types.Issue, blockedIDs map[string]bool) *WorkloadReport {
+	report := &WorkloadReport{Assignees: []*AssigneeWorkload{}, Unassigned: &AssigneeWorkload{}}
+	byAssignee := map[string]*AssigneeWorkload{}
+	for _, issue := range issues {
+		var w *AssigneeWorkload
+		if issue.Assignee == "" {
+			w = report.Unassigned
+		} else if w = byAssignee[issue.Assignee]; w == nil {
+			w = &AssigneeWorkload{Assignee: issue.Assignee}
+			byAssignee[issue.Assignee] = w
+			report.Assignees = append(report.Assignees, w)
+		}
+		switch issue.Status {
+		case types.StatusInProgress:
+			w.InProgress++
+		case types.StatusOpen, types.StatusBlocked:
+			w.Open++
+		default:
+			continue // custom statuses are not active work
+		}
+		if issue.Status == types.StatusBlocked || blockedIDs[issue.ID] {
+			w.Blocked++
+		}
+		if issue.EstimatedMinutes != nil {
+			w.EstimatedMinutes += *issue.EstimatedMinutes
+		}
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.Active() != b.Active() {
+			return a.Active() > b.Active()
+		}
+		return a.Assignee < b.Assignee
+	})
+	return report
+}
+
+func displayWorkload(report *WorkloadReport) {
+	if len(report.Assignees) == 0 && report.Unassigned.Active() == 0 {
+		fmt.Printf("\n%s No active work\n\n", ui.RenderPass("✨"))
+		return
+	}
+	fmt.Printf("\n%s Workload by assignee\n\n", ui.RenderAccent("📋"))
+	fmt.Printf("%-24s %6s %12s %8s %9s\n", "ASSIGNEE", "OPEN", "IN PROGRESS", "BLOCKED", "ESTIMATE")
+	row := func(name string, w *AssigneeWorkload) {
+		estimate := "-"
+		if w.EstimatedMinutes > 0 {
+			estimate = formatEstimateMinutes(w.EstimatedMinutes)
+		}
+		blocked := fmt.Sprintf("%8d", w.Blocked)
+		if w.Blocked > 0 {
+			blocked = ui.RenderWarn(blocked)
+		}
+		fmt.Printf("%-24s %6d %12d %s %9s\n", truncateTitle(name, 24), w.Open, w.InProgress, blocked, estimate)
+	}
+	for _, w := range report.Assignees {
+		row(w.Assignee, w)
+	}
+	if report.Unassigned.Active() > 0 {
+		row("(unassigned)", report.Unassigned)
+	}
+	fmt.Println()
+}
+
+// runAssignBalance implements 'bd assign --balance'.
+func runAssignBalance(cmd *cobra.Command) {
+	agents, _ := cmd.Flags().GetStringSlice("agents")
+	apply, _ := cmd.Flags().GetBool("apply")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if apply {
+		CheckReadonly("assign --balance --apply")
+	}
+	ctx := rootCtx
+
+	report, err := loadWorkload(ctx, store)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if !cmd.Flags().Changed("agents") {
+		agents = config.GetStringSlice("assign.agents")
+	}
+	if len(agents) == 0 {
+		// Fall back to everyone who already holds active work.
+		for _, w := range report.Assignees {
+			agents = append(agents, w.Assignee)
+		}
+	}
+	if len(agents) == 0 {
+		FatalErrorRespectJSON("no agents to balance across (pass --agents or set assign.agents)")
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Unassigned: true, Limit: limit})
+	if err != nil {
+		FatalErrorRespectJSON("listing ready work: %v", err)
+	}
+	skills := config.GetStringMapStringSlice("assign.skills")
+	if len(ready) > 0 {
+		ids := make([]string, len(ready))
+		for i, issue := range ready {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("loading labels: %v", err)
+		}
+		for _, issue := range ready {
+			issue.Labels = labels[issue.ID]
+		}
+	}
+	proposals, unmatched := proposeAssignments(ready, report, agents, skills)
+	result := &BalanceResult{Proposals: proposals, Unmatched: unmatched, Agents: agents, Applied: apply}
+
+	if apply {
+		var ids []string
+		for _, p := range proposals {
+			if err := store.UpdateIssue(ctx, p.IssueID, map[string]interface{}{"assignee": p.Assignee}, actor); err != nil {
+				p.Error = err.Error()
+				continue
+			}
+			ids = append(ids, p.IssueID)
+		}
+		if len(ids) > 0 {
+			if err := commitPendingIfEmbedded(ctx, store, actor, doltAutoCommitParams{
+				Command:  "assign",
+				IssueIDs: ids,
+			}); err != nil {
+				FatalErrorRespectJSON("failed to commit: %v", err)
+			}
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	displayBalanceResult(result)
+}
+
+// proposeAssignments hands each ready issue, in order, to the matching agent
+// with the fewest active issues, counting earlier proposals. Ties go to the
+// smaller total estimate, then the name. An agent matches an issue when it
+// offers, in skills, every skill the issue's skill labels require. Issues no
+// agent matches are returned as unmatched.
+func proposeAssignments(ready []*types.Issue, report *WorkloadReport, agents []string, skills map[string][]string) ([]*AssignmentProposal, []string) {
+	loads := make(map[string]*AssigneeWorkload, len(agents))
+	for _, w := range report.Assignees {
+		loads[w.Assignee] = &AssigneeWorkload{Assignee: w.Assignee, Open: w.Open, InProgress: w.InProgress, EstimatedMinutes: w.EstimatedMinutes}
+	}
+	for _, a := range agents {
+		if loads[a] == nil {
+			loads[a] = &AssigneeWorkload{Assignee: a}
+		}
+	}
+
+	proposals := []*AssignmentProposal{}
+	var unmatched []string
+	for _, issue := range ready {
+		var best *AssigneeWorkload
+		for _, a := range agents {
+			if !hasRequiredSkills(offeredSkills(skills, a), issue.Labels) {
+				continue
+			}
+			w := loads[a]
+			if best == nil || w.Active() < best.Active() ||
+				(w.Active() == best.Active() && (w.EstimatedMinutes < best.EstimatedMinutes ||
+					(w.EstimatedMinutes == best.EstimatedMinutes && w.Assignee < best.Assignee))) {
+				best = w
+			}
+		}
+		if best == nil {
+			unmatched = append(unmatched, issue.ID)
+			continue
+		}
+		proposals = append(proposals, &AssignmentProposal{
+			IssueID:  issue.ID,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Assignee: best.Assignee,
+			Load:     best.Active(),
+		})
+		best.Open++
+		if issue.EstimatedMinutes != nil {
+			best.EstimatedMinutes += *issue.EstimatedMinutes
+		}
+	}
+	return proposals, unmatched
+}
+
+func displayBalanceResult(result *BalanceResult) {
+	if len(result.Proposals) == 0 && len(result.Unmatched) == 0 {
+		fmt.Printf("\n%s No unassigned ready work\n\n", ui.RenderPass("✨"))
+		return
+	}
+	verb := "Proposed"
+	if result.Applied {
+		verb = "Assigned"
+	}
+	fmt.Printf("\n%s %s %d assignment(s):\n\n", ui.RenderAccent("⚖"), verb, len(result.Proposals))
+	for _, p := range result.Proposals {
+		if p.Error != "" {
+			fmt.Printf("  %s %s → %s: %s\n", ui.RenderFail("✗"), p.IssueID, p.Assignee, p.Error)
+			continue
+		}
+		fmt.Printf("  [%s] %s → %s %s\n", ui.RenderPriority(p.Priority), formatFeedbackID(p.IssueID, p.Title),
+			ui.RenderAccent(p.Assignee), ui.RenderMuted(fmt.Sprintf("(%d active)", p.Load)))
+	}
+	if len(result.Unmatched) > 0 {
+		fmt.Printf("\n  %s No agent offers the required skills: %v\n", ui.RenderWarn("⚠"), result.Unmatched)
+	}
+	if !result.Applied && len(result.Proposals) > 0 {
+		fmt.Printf("\nRun again with --apply to make these assignments.\n")
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(workloadCmd)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestComputeWorkload(t *testing.T) {
+	est := func(m int) *int { return &m }
+	issues := []*types.Issue{
+		{ID: "bd-1", Assignee: "alice", Status: types.StatusOpen, EstimatedMinutes: est(60)},
+		{ID: "bd-2", Assignee: "alice", Status: types.StatusInProgress, EstimatedMinutes: est(30)},
+		{ID: "bd-3", Assignee: "alice", Status: types.StatusBlocked},
+		{ID: "bd-4", Assignee: "bob", Status: types.StatusOpen},
+		{ID: "bd-5", Status: types.StatusOpen, EstimatedMinutes: est(15)},
+	}
+	report := computeWorkload(issues, map[string]bool{"bd-4": true})
+
+	if len(report.Assignees) != 2 || report.Assignees[0].Assignee != "alice" || report.Assignees[1].Assignee != "bob" {
+		t.Fatalf("assignees = %+v, want alice then bob", report.Assignees)
+	}
+	alice, bob := report.Assignees[0], report.Assignees[1]
+	if alice.Open != 2 || alice.InProgress != 1 || alice.Blocked != 1 || alice.EstimatedMinutes != 90 {
+		t.Errorf("alice = %+v, want open=2 in_progress=1 blocked=1 estimate=90", alice)
+	}
+	if bob.Open != 1 || bob.Blocked != 1 {
+		t.Errorf("bob = %+v, want open=1 blocked=1", bob)
+	}
+	if report.Unassigned.Open != 1 || report.Unassigned.EstimatedMinutes != 15 {
+		t.Errorf("unassigned = %+v, want open=1 estimate=15", report.Unassigned)
+	}
+}
+
+func TestProposeAssignments(t *testing.T) {
+	report := &WorkloadReport{Assignees: []*AssigneeWorkload{
+		{Assignee: "alice", Open: 2},
+		{Assignee: "bob", InProgress: 1},
+	}}
+	ready := []*types.Issue{
+		{ID: "bd-1", Priority: 0},
+		{ID: "bd-2", Priority: 1},
+		{ID: "bd-3", Priority: 1, Labels: []string{"skill:frontend"}},
+		{ID: "bd-4", Priority: 2},
+	}
+	agents := []string{"alice", "bob", "carol"}
+
+	got := func(proposals []*AssignmentProposal) []string {
+		var out []string
+		for _, p := range proposals {
+			out = append(out, p.IssueID+"="+p.Assignee)
+		}
+		return out
+	}
+
+	skills := map[string][]string{"carol": {"frontend"}}
+	proposals, unmatched := proposeAssignments(ready[:2], report, agents, skills)
+	want := []string{"bd-1=carol", "bd-2=bob"}
+	if !slices.Equal(got(proposals), want) || len(unmatched) != 0 {
+		t.Errorf("proposals = %v unmatched = %v, want %v", got(proposals), unmatched, want)
+	}
+	if report.Assignees[0].Open != 2 {
+		t.Errorf("proposeAssignments modified the report: %+v", report.Assignees[0])
+	}
+
+	// Only carol offers frontend, so she gets bd-3 despite her load.
+	proposals, unmatched = proposeAssignments(ready, report, agents, skills)
+	want = []string{"bd-1=carol", "bd-2=bob", "bd-3=carol", "bd-4=alice"}
+	if !slices.Equal(got(proposals), want) || len(unmatched) != 0 {
+		t.Errorf("skills: proposals = %v unmatched = %v, want %v", got(proposals), unmatched, want)
+	}
+
+	// Nobody offers frontend.
+	proposals, unmatched = proposeAssignments(ready, report, []string{"alice", "bob"}, nil)
+	if len(proposals) != 3 || !slices.Equal(unmatched, []string{"bd-3"}) {
+		t.Errorf("no skills: proposals = %v unmatched = %v, want bd-3 unmatched", got(proposals), unmatched)
+	}
+}
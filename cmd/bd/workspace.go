@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// workspaceFlag holds the global --workspace selection.
+var workspaceFlag string
+
+var wsCmd = &cobra.Command{
+	Use:     "ws",
+	Aliases: []string{"workspace"},
+	GroupID: "setup",
+	Short:   "Manage named workspaces (beads projects on this machine)",
+	Long: `Register the beads projects you work in under short names, then target
+them from anywhere without changing directory.
+
+Workspaces are stored per user in workspaces.json next to the user config
+(~/.config/bd/workspaces.json). Every command accepts --workspace <name>,
+which behaves like -C <workspace path>. When the current directory is not
+inside a beads project, bd uses the workspace chosen with bd ws switch.
+
+Examples:
+  bd ws add web ~/src/web        # Register a project
+  bd ws add api                  # Register the current project as "api"
+  bd ws list                     # Show workspaces (* marks the current one)
+  bd ws switch web               # Default to "web" outside any project
+  bd --workspace api ready       # Run one command against "api"
+  bd list --all-workspaces       # List issues across every workspace`,
+}
+
+var wsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered workspaces",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		reg, err := config.LoadWorkspaces()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			entries := make([]workspaceJSON, 0, len(reg.Workspaces))
+			for _, ws := range reg.Workspaces {
+				entries = append(entries, newWorkspaceJSON(ws, reg.Current))
+			}
+			outputJSON(entries)
+			return
+		}
+		if len(reg.Workspaces) == 0 {
+			fmt.Println("No workspaces registered. Add one with: bd ws add <name> [path]")
+			return
+		}
+		for _, ws := range reg.Workspaces {
+			marker := " "
+			if ws.Name == reg.Current {
+				marker = ui.RenderAccent("*")
+			}
+			fmt.Printf("%s %-16s %s\n", marker, ws.Name, ws.Path)
+		}
+	},
+}
+
+var wsAddCmd = &cobra.Command{
+	Use:   "add <name> [path]",
+	Short: "Register a beads project as a workspace",
+	Long: `Register a beads project under a name. The path defaults to the current
+directory and must be inside a beads project. Re-adding a name updates
+its path.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, path := args[0], "."
+		if len(args) == 2 {
+			path = args[1]
+		}
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			FatalErrorRespectJSON("cannot resolve %q: %v", path, err)
+		}
+		if beads.FindBeadsDirFrom(absPath) == "" {
+			FatalErrorRespectJSON("no beads project found at %s (run bd init there first)", absPath)
+		}
+
+		reg, err := config.LoadWorkspaces()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := reg.Add(name, absPath); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := config.SaveWorkspaces(reg); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		ws, _ := reg.Find(name)
+		if jsonOutput {
+			outputJSON(newWorkspaceJSON(ws, reg.Current))
+			return
+		}
+		fmt.Printf("%s Added workspace %s → %s\n", ui.RenderPass("✓"), name, absPath)
+	},
+}
+
+var wsRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Unregister a workspace (the project itself is untouched)",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		reg, err := config.LoadWorkspaces()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !reg.Remove(args[0]) {
+			FatalErrorRespectJSON("unknown workspace %q (see bd ws list)", args[0])
+		}
+		if err := config.SaveWorkspaces(reg); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": args[0]})
+			return
+		}
+		fmt.Printf("%s Removed workspace %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var wsSwitchCmd = &cobra.Command{
+	Use:   "switch <name>",
+	Short: "Set the workspace used outside any beads project",
+	Long: `Set the current workspace. bd uses it whenever the working directory is
+not inside a beads project and no -C, --workspace, --db, or BEADS_DIR
+selection applies. A project you are standing in always wins.
+
+Use --clear to unset the current workspace.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if clear, _ := cmd.Flags().GetBool("clear"); clear {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		reg, err := config.LoadWorkspaces()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(args) == 1 {
+			if _, ok := reg.Find(args[0]); !ok {
+				FatalErrorRespectJSON("unknown workspace %q (see bd ws list)", args[0])
+			}
+			reg.Current = args[0]
+		} else {
+			reg.Current = ""
+		}
+		if err := config.SaveWorkspaces(reg); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]string{"current": reg.Current})
+			return
+		}
+		if reg.Current == "" {
+			fmt.Printf("%s Cleared current workspace\n", ui.RenderPass("✓"))
+			return
+		}
+		fmt.Printf("%s Switched to workspace %s\n", ui.RenderPass("✓"), reg.Current)
+	},
+}
+
+// workspaceJSON is one entry of bd ws list --json.
+type workspaceJSON struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Current bool   `json:"current"`
+}
+
+func newWorkspaceJSON(ws config.Workspace, current string) workspaceJSON {
+	return workspaceJSON{Name: ws.Name, Path: ws.Path, Current: ws.Name == current}
+}
+
+// resolveWorkspaceBeadsDir returns the .beads directory selected by
+// --workspace, or by the current workspace when nothing else selects a
+// project. It returns "" when no workspace applies.
+func resolveWorkspaceBeadsDir(cmd *cobra.Command) (string, error) {
+	name := strings.TrimSpace(workspaceFlag)
+	explicit := name != ""
+	if !explicit && !usesCurrentWorkspace(cmd) {
+		return "", nil
+	}
+
+	reg, err := config.LoadWorkspaces()
+	if err != nil {
+		if explicit {
+			return "", err
+		}
+		debug.Logf("workspaces: %v", err)
+		return "", nil
+	}
+	if !explicit {
+		name = reg.Current
+		if name == "" || beads.FindBeadsDir() != "" {
+			return "", nil
+		}
+	}
+
+	ws, ok := reg.Find(name)
+	if !ok {
+		if !explicit {
+			fmt.Fprintf(os.Stderr, "Warning: current workspace %q is not registered; run bd ws switch\n", name)
+			return "", nil
+		}
+		return "", fmt.Errorf("unknown workspace %q (see bd ws list)", name)
+	}
+	beadsDir := beads.FindBeadsDirFrom(ws.Path)
+	if beadsDir == "" {
+		if !explicit {
+			fmt.Fprintf(os.Stderr, "Warning: current workspace %q has no beads project at %s\n", name, ws.Path)
+			return "", nil
+		}
+		return "", fmt.Errorf("workspace %q has no beads project at %s", name, ws.Path)
+	}
+	return beadsDir, nil
+}
+
+// usesCurrentWorkspace reports whether cmd may fall back to the current
+// workspace: not for bd init or bd ws itself, and not when the database is
+// already selected explicitly.
+func usesCurrentWorkspace(cmd *cobra.Command) bool {
+	if cmd == nil || cmd == initCmd || cmd == wsCmd || cmd.Parent() == wsCmd {
+		return false
+	}
+	if dbPath != "" || globalFlag {
+		return false
+	}
+	for _, key := range []string{"BEADS_DIR", "BEADS_DB", "BD_DB"} {
+		if os.Getenv(key) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	wsSwitchCmd.Flags().Bool("clear", false, "Unset the current workspace")
+
+	wsCmd.AddCommand(wsListCmd)
+	wsCmd.AddCommand(wsAddCmd)
+	wsCmd.AddCommand(wsRemoveCmd)
+	wsCmd.AddCommand(wsSwitchCmd)
+	rootCmd.AddCommand(wsCmd)
+}
@@ -0,0 +1,118 @@
+//go:build cgo
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bdWorkspaceRun runs bd in dir with HOME pointed at home, so every call
+// shares one workspace registry.
+func bdWorkspaceRun(t *testing.T, bd, home, dir string, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(bd, args...)
+	cmd.Dir = dir
+	cmd.Env = bdEnv(home)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestEmbeddedWorkspaces(t *testing.T) {
+	if os.Getenv("BEADS_TEST_EMBEDDED_DOLT") != "1" {
+		t.Skip("set BEADS_TEST_EMBEDDED_DOLT=1 to run embedded dolt integration tests")
+	}
+	t.Parallel()
+
+	bd := buildEmbeddedBD(t)
+	alphaDir, _, _ := bdInit(t, bd, "--prefix", "wa")
+	betaDir, _, _ := bdInit(t, bd, "--prefix", "wb")
+	alpha := bdCreate(t, bd, alphaDir, "Alpha work")
+	beta := bdCreate(t, bd, betaDir, "Beta work")
+
+	home := t.TempDir()
+	outside := t.TempDir()
+	run := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := bdWorkspaceRun(t, bd, home, dir, args...)
+		if err != nil {
+			t.Fatalf("bd %s failed: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return out
+	}
+
+	run(outside, "ws", "add", "alpha", alphaDir)
+	run(betaDir, "ws", "add", "beta")
+	if out, err := bdWorkspaceRun(t, bd, home, outside, "ws", "add", "nowhere", outside); err == nil {
+		t.Errorf("expected adding a directory without a project to fail:\n%s", out)
+	}
+
+	t.Run("workspace_flag", func(t *testing.T) {
+		out := run(outside, "--workspace", "beta", "show", beta.ID, "--json")
+		if !strings.Contains(out, beta.ID) {
+			t.Errorf("--workspace beta did not show %s:\n%s", beta.ID, out)
+		}
+		if out, err := bdWorkspaceRun(t, bd, home, outside, "--workspace", "missing", "list"); err == nil || !strings.Contains(out, `unknown workspace "missing"`) {
+			t.Errorf("expected unknown workspace error, got %v:\n%s", err, out)
+		}
+	})
+
+	t.Run("switch", func(t *testing.T) {
+		run(outside, "ws", "switch", "alpha")
+		out := run(outside, "list", "--json")
+		if !strings.Contains(out, alpha.ID) || strings.Contains(out, beta.ID) {
+			t.Errorf("list outside a project should use the current workspace:\n%s", out)
+		}
+		// A project you are standing in wins over the current workspace.
+		out = run(betaDir, "list", "--json")
+		if !strings.Contains(out, beta.ID) || strings.Contains(out, alpha.ID) {
+			t.Errorf("list inside beta should ignore the current workspace:\n%s", out)
+		}
+
+		var entries []workspaceJSON
+		if err := json.Unmarshal([]byte(run(outside, "ws", "list", "--json")), &entries); err != nil {
+			t.Fatalf("parse ws list: %v", err)
+		}
+		if len(entries) != 2 || !entries[0].Current || entries[0].Name != "alpha" {
+			t.Errorf("unexpected ws list: %+v", entries)
+		}
+		run(outside, "ws", "switch", "--clear")
+	})
+
+	t.Run("list_all_workspaces", func(t *testing.T) {
+		var issues []struct {
+			Workspace string `json:"workspace"`
+			ID        string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(run(outside, "list", "--all-workspaces", "--json")), &issues); err != nil {
+			t.Fatalf("parse list --all-workspaces: %v", err)
+		}
+		got := make(map[string]string)
+		for _, issue := range issues {
+			got[issue.ID] = issue.Workspace
+		}
+		if got[alpha.ID] != "alpha" || got[beta.ID] != "beta" {
+			t.Errorf("unexpected aggregate: %+v", issues)
+		}
+
+		out := run(outside, "list", "--all-workspaces")
+		if !strings.Contains(out, "alpha (1)") || !strings.Contains(out, "beta (1)") {
+			t.Errorf("text output not grouped by workspace:\n%s", out)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		run(outside, "ws", "remove", "beta")
+		reg, err := os.ReadFile(filepath.Join(home, ".config", "bd", "workspaces.json"))
+		if err != nil {
+			t.Fatalf("read registry: %v", err)
+		}
+		if strings.Contains(string(reg), `"beta"`) {
+			t.Errorf("beta still registered:\n%s", reg)
+		}
+	})
+}
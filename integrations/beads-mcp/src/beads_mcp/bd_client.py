@@ -216,6 +216,7 @@ class BdCliClient(BdClientBase):
     beads_dir: str | None
     beads_db: str | None
     actor: str | None
+    token: str | None
     no_auto_flush: bool
     no_auto_import: bool
     working_dir: str | None
@@ -229,6 +230,7 @@ class BdCliClient(BdClientBase):
         no_auto_flush: bool | None = None,
         no_auto_import: bool | None = None,
         working_dir: str | None = None,
+        token: str | None = None,
     ):
         """Initialize bd client.
 
@@ -240,6 +242,7 @@ class BdCliClient(BdClientBase):
             no_auto_flush: Disable automatic JSONL sync (optional, loads from config if not provided)
             no_auto_import: Disable automatic JSONL import (optional, loads from config if not provided)
             working_dir: Working directory for bd commands (optional, loads from config/env if not provided)
+            token: API token exported to bd as BEADS_TOKEN (optional, loads from config if not provided)
         """
         config = load_config()
         self.bd_path = bd_path if bd_path is not None else config.beads_path
@@ -249,6 +252,7 @@ class BdCliClient(BdClientBase):
         self.no_auto_flush = no_auto_flush if no_auto_flush is not None else config.beads_no_auto_flush
         self.no_auto_import = no_auto_import if no_auto_import is not None else config.beads_no_auto_import
         self.working_dir = working_dir if working_dir is not None else config.beads_working_dir
+        self.token = token if token is not None else config.beads_token
 
     def _get_working_dir(self) -> str:
         """Get working directory for bd commands.
@@ -261,6 +265,24 @@ class BdCliClient(BdClientBase):
         # Use process working directory (set by MCP client at spawn time)
         return os.getcwd()
 
+    def _build_env(self) -> dict[str, str]:
+        """Build the environment for bd subprocesses.
+
+        The API token travels in the environment rather than on the command
+        line so it never shows up in process listings.
+
+        Returns:
+            Copy of os.environ with database and token settings applied
+        """
+        env = os.environ.copy()
+        if self.beads_dir:
+            env["BEADS_DIR"] = self.beads_dir
+        elif self.beads_db:
+            env["BEADS_DB"] = self.beads_db
+        if self.token:
+            env["BEADS_TOKEN"] = self.token
+        return env
+
     def _global_flags(self) -> list[str]:
         """Build list of global flags for bd commands.
 
@@ -295,12 +317,7 @@ class BdCliClient(BdClientBase):
         cmd = [self.bd_path, *args, *self._global_flags(), "--json"]
         working_dir = cwd if cwd is not None else self._get_working_dir()
 
-        # Set up environment with database configuration
-        env = os.environ.copy()
-        if self.beads_dir:
-            env["BEADS_DIR"] = self.beads_dir
-        elif self.beads_db:
-            env["BEADS_DB"] = self.beads_db
+        env = self._build_env()
 
         # Log database routing for debugging
         if self.beads_dir:
@@ -648,12 +665,7 @@ class BdCliClient(BdClientBase):
             *self._global_flags(),
         ]
 
-        # Set up environment with database configuration
-        env = os.environ.copy()
-        if self.beads_dir:
-            env["BEADS_DIR"] = self.beads_dir
-        elif self.beads_db:
-            env["BEADS_DB"] = self.beads_db
+        env = self._build_env()
 
         try:
             process = await asyncio.create_subprocess_exec(
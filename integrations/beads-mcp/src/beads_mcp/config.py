@@ -35,6 +35,7 @@ class Config(BaseSettings):
     beads_dir: str | None = None
     beads_db: str | None = None
     beads_actor: str | None = None
+    beads_token: str | None = None
     beads_no_auto_flush: bool = False
     beads_no_auto_import: bool = False
     beads_working_dir: str | None = None
@@ -164,6 +165,7 @@ def load_config() -> Config:
             + "  BEADS_DB              - Path to database file (deprecated, use BEADS_DIR)\n"
             + "  BEADS_WORKING_DIR     - Working directory for bd commands (default: $PWD or cwd)\n"
             + "  BEADS_ACTOR           - Actor name for audit trail (default: $USER)\n"
+            + "  BEADS_TOKEN           - API token passed to bd for role-based access (bd token create)\n"
             + "  BEADS_NO_AUTO_FLUSH   - Disable automatic JSONL sync (default: false)\n"
             + "  BEADS_NO_AUTO_IMPORT  - Disable automatic JSONL import (default: false)"
         )
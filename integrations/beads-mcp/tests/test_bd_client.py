@@ -62,6 +62,19 @@ async def test_run_command_success(bd_client, mock_process):
     assert result == result_data
 
 
+@pytest.mark.asyncio
+async def test_run_command_passes_token_in_env(mock_process):
+    """Test the API token reaches bd via BEADS_TOKEN, not argv."""
+    client = BdClient(bd_path="/usr/bin/bd", token="bdt_abc_secret")
+    mock_process.communicate = AsyncMock(return_value=(b"{}", b""))
+
+    with patch("asyncio.create_subprocess_exec", return_value=mock_process) as mock_exec:
+        await client._run_command("show", "bd-1")
+
+    assert mock_exec.call_args.kwargs["env"]["BEADS_TOKEN"] == "bdt_abc_secret"
+    assert "bdt_abc_secret" not in mock_exec.call_args.args
+
+
 @pytest.mark.asyncio
 async def test_run_command_not_found(bd_client):
     """Test command execution when bd executable not found."""
@@ -0,0 +1,652 @@
+// Package age encrypts and decrypts files in the age v1 format
+// (age-encryption.org/v1), so exports encrypted by bd can be opened with the
+// age CLI and vice versa. It supports X25519 keys (age1... recipients,
+// AGE-SECRET-KEY-1... identities) and scrypt passphrases; the ASCII armor
+// and plugin recipients are not implemented.
+package age
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// Magic is the first line of every age file.
+	Magic = "age-encryption.org/v1"
+
+	fileKeySize = 16
+	nonceSize   = 16
+	chunkSize   = 64 * 1024
+	columns     = 64 // base64 characters per stanza body line
+
+	x25519Label = "age-encryption.org/v1/X25519"
+	scryptLabel = "age-encryption.org/v1/scrypt"
+
+	// DefaultScryptWorkFactor is the log2 of the scrypt cost used for new
+	// passphrase files, matching the age CLI.
+	DefaultScryptWorkFactor = 18
+	// maxScryptWorkFactor bounds the cost a file can ask a decrypter for.
+	maxScryptWorkFactor = 22
+)
+
+// ErrIncorrectIdentity is returned by Decrypt when none of the identities
+// (or the passphrase) can open the file.
+var ErrIncorrectIdentity = errors.New("age: no identity matched the file")
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// IsEncrypted reports whether data begins with the age header line.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(Magic+"\n"))
+}
+
+// A Recipient wraps a file key for one reader.
+type Recipient interface {
+	wrap(fileKey []byte) (*stanza, error)
+}
+
+// An Identity unwraps the file key from a header's stanzas.
+type Identity interface {
+	unwrap(stanzas []*stanza) ([]byte, error)
+}
+
+// stanza is one recipient line of the header and its wrapped file key.
+type stanza struct {
+	typ  string
+	args []string
+	body []byte
+}
+
+func (s *stanza) marshal(b *bytes.Buffer) {
+	b.WriteString("-> " + s.typ)
+	for _, a := range s.args {
+		b.WriteString(" " + a)
+	}
+	b.WriteString("\n")
+	enc := b64.EncodeToString(s.body)
+	for len(enc) >= columns {
+		b.WriteString(enc[:columns] + "\n")
+		enc = enc[columns:]
+	}
+	// The last line is always short, possibly empty.
+	b.WriteString(enc + "\n")
+}
+
+// Encrypt writes the age header for recipients to dst and returns a writer
+// that encrypts everything written to it. Close must be called to write
+// the final chunk; it does not close dst.
+func Encrypt(dst io.Writer, recipients ...Recipient) (io.WriteCloser, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("age: no recipients")
+	}
+	fileKey := make([]byte, fileKeySize)
+	if _, err := rand.Read(fileKey); err != nil {
+		return nil, err
+	}
+
+	var hdr bytes.Buffer
+	hdr.WriteString(Magic + "\n")
+	for _, r := range recipients {
+		s, err := r.wrap(fileKey)
+		if err != nil {
+			return nil, err
+		}
+		if s.typ == "scrypt" && len(recipients) != 1 {
+			return nil, errors.New("age: a passphrase cannot be combined with other recipients")
+		}
+		s.marshal(&hdr)
+	}
+	hdr.WriteString("---")
+	mac, err := headerMAC(fileKey, hdr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&hdr, " %s\n", b64.EncodeToString(mac))
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	hdr.Write(nonce)
+	if _, err := dst.Write(hdr.Bytes()); err != nil {
+		return nil, err
+	}
+	aead, err := payloadAEAD(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{aead: aead, dst: dst, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+// Decrypt reads the age header from src, unwraps the file key with the
+// first identity that matches, and returns a reader of the plaintext.
+// Payload tampering surfaces as an error from Read.
+func Decrypt(src io.Reader, identities ...Identity) (io.Reader, error) {
+	if len(identities) == 0 {
+		return nil, errors.New("age: no identities")
+	}
+	br := bufio.NewReader(src)
+	stanzas, raw, mac, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileKey []byte
+	for _, id := range identities {
+		fileKey, err = id.unwrap(stanzas)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrIncorrectIdentity) {
+			return nil, err
+		}
+	}
+	if fileKey == nil {
+		return nil, ErrIncorrectIdentity
+	}
+	want, err := headerMAC(fileKey, raw)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(mac, want) {
+		return nil, errors.New("age: header MAC mismatch")
+	}
+
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(br, nonce); err != nil {
+		return nil, fmt.Errorf("age: read payload nonce: %w", err)
+	}
+	aead, err := payloadAEAD(fileKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{aead: aead, src: br}, nil
+}
+
+// readHeader parses the header and returns its stanzas, the bytes the MAC
+// covers (through "---"), and the MAC.
+func readHeader(br *bufio.Reader) ([]*stanza, []byte, []byte, error) {
+	var raw bytes.Buffer
+	line, err := br.ReadString('\n')
+	if err != nil || line != Magic+"\n" {
+		return nil, nil, nil, errors.New("age: not an age file")
+	}
+	raw.WriteString(line)
+
+	var stanzas []*stanza
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("age: truncated header: %w", err)
+		}
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			raw.WriteString("---")
+			mac, err := b64.DecodeString(strings.TrimSuffix(line[len("--- "):], "\n"))
+			if err != nil || len(mac) != sha256.Size {
+				return nil, nil, nil, errors.New("age: malformed header MAC")
+			}
+			if len(stanzas) == 0 {
+				return nil, nil, nil, errors.New("age: header has no recipients")
+			}
+			return stanzas, raw.Bytes(), mac, nil
+		case strings.HasPrefix(line, "-> "):
+			raw.WriteString(line)
+			fields := strings.Split(strings.TrimSuffix(line[len("-> "):], "\n"), " ")
+			s := &stanza{typ: fields[0], args: fields[1:]}
+			for {
+				bodyLine, err := br.ReadString('\n')
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("age: truncated header: %w", err)
+				}
+				raw.WriteString(bodyLine)
+				enc := strings.TrimSuffix(bodyLine, "\n")
+				if len(enc) > columns {
+					return nil, nil, nil, errors.New("age: malformed stanza body")
+				}
+				chunk, err := b64.DecodeString(enc)
+				if err != nil {
+					return nil, nil, nil, errors.New("age: malformed stanza body")
+				}
+				s.body = append(s.body, chunk...)
+				if len(enc) < columns {
+					break
+				}
+			}
+			stanzas = append(stanzas, s)
+		default:
+			return nil, nil, nil, errors.New("age: malformed header line")
+		}
+	}
+}
+
+func headerMAC(fileKey, header []byte) ([]byte, error) {
+	key, err := hkdf.Key(sha256.New, fileKey, nil, "header", 32)
+	if err != nil {
+		return nil, err
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(header)
+	return h.Sum(nil), nil
+}
+
+func payloadAEAD(fileKey, nonce []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, fileKey, nonce, "payload", chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return chacha20poly1305.New(key)
+}
+
+// wrapKey seals the file key under key with an all-zero nonce, which is
+// safe because every wrapping key is used once.
+func wrapKey(key, fileKey []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), fileKey, nil), nil
+}
+
+func unwrapKey(key, body []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) != fileKeySize+aead.Overhead() {
+		return nil, errors.New("age: malformed wrapped file key")
+	}
+	fileKey, err := aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), body, nil)
+	if err != nil {
+		return nil, ErrIncorrectIdentity
+	}
+	return fileKey, nil
+}
+
+// X25519Recipient is an age1... public key.
+type X25519Recipient struct {
+	pub *ecdh.PublicKey
+}
+
+// ParseX25519Recipient parses an age1... recipient.
+func ParseX25519Recipient(s string) (*X25519Recipient, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil || hrp != "age" {
+		return nil, fmt.Errorf("age: malformed recipient %q", s)
+	}
+	pub, err := ecdh.X25519().NewPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("age: malformed recipient %q: %w", s, err)
+	}
+	return &X25519Recipient{pub: pub}, nil
+}
+
+// String returns the age1... encoding of r.
+func (r *X25519Recipient) String() string {
+	s, _ := bech32Encode("age", r.pub.Bytes())
+	return s
+}
+
+func (r *X25519Recipient) wrap(fileKey []byte) (*stanza, error) {
+	eph, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := eph.ECDH(r.pub)
+	if err != nil {
+		return nil, err
+	}
+	share := eph.PublicKey().Bytes()
+	salt := append(append([]byte{}, share...), r.pub.Bytes()...)
+	key, err := hkdf.Key(sha256.New, shared, salt, x25519Label, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	body, err := wrapKey(key, fileKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stanza{typ: "X25519", args: []string{b64.EncodeToString(share)}, body: body}, nil
+}
+
+// X25519Identity is an AGE-SECRET-KEY-1... private key.
+type X25519Identity struct {
+	priv *ecdh.PrivateKey
+}
+
+// GenerateX25519Identity returns a new random identity.
+func GenerateX25519Identity() (*X25519Identity, error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &X25519Identity{priv: priv}, nil
+}
+
+// ParseX25519Identity parses an AGE-SECRET-KEY-1... identity.
+func ParseX25519Identity(s string) (*X25519Identity, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil || hrp != "age-secret-key-" {
+		return nil, errors.New("age: malformed secret key")
+	}
+	priv, err := ecdh.X25519().NewPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("age: malformed secret key: %w", err)
+	}
+	return &X25519Identity{priv: priv}, nil
+}
+
+// String returns the AGE-SECRET-KEY-1... encoding of i.
+func (i *X25519Identity) String() string {
+	s, _ := bech32Encode("age-secret-key-", i.priv.Bytes())
+	return strings.ToUpper(s)
+}
+
+// Recipient returns the public key matching i.
+func (i *X25519Identity) Recipient() *X25519Recipient {
+	return &X25519Recipient{pub: i.priv.PublicKey()}
+}
+
+func (i *X25519Identity) unwrap(stanzas []*stanza) ([]byte, error) {
+	for _, s := range stanzas {
+		if s.typ != "X25519" {
+			continue
+		}
+		if len(s.args) != 1 {
+			return nil, errors.New("age: malformed X25519 stanza")
+		}
+		share, err := b64.DecodeString(s.args[0])
+		if err != nil {
+			return nil, errors.New("age: malformed X25519 stanza")
+		}
+		peer, err := ecdh.X25519().NewPublicKey(share)
+		if err != nil {
+			return nil, errors.New("age: malformed X25519 stanza")
+		}
+		shared, err := i.priv.ECDH(peer)
+		if err != nil {
+			return nil, fmt.Errorf("age: X25519 stanza: %w", err)
+		}
+		salt := append(append([]byte{}, share...), i.priv.PublicKey().Bytes()...)
+		key, err := hkdf.Key(sha256.New, shared, salt, x25519Label, chacha20poly1305.KeySize)
+		if err != nil {
+			return nil, err
+		}
+		fileKey, err := unwrapKey(key, s.body)
+		if errors.Is(err, ErrIncorrectIdentity) {
+			continue
+		}
+		return fileKey, err
+	}
+	return nil, ErrIncorrectIdentity
+}
+
+// ScryptRecipient encrypts to a passphrase. It must be a file's only
+// recipient.
+type ScryptRecipient struct {
+	passphrase []byte
+	workFactor int
+}
+
+// NewScryptRecipient returns a passphrase recipient using the default work
+// factor.
+func NewScryptRecipient(passphrase string) (*ScryptRecipient, error) {
+	if passphrase == "" {
+		return nil, errors.New("age: empty passphrase")
+	}
+	return &ScryptRecipient{passphrase: []byte(passphrase), workFactor: DefaultScryptWorkFactor}, nil
+}
+
+// SetWorkFactor sets the log2 scrypt cost. Lower values are faster and
+// weaker; tests use them to stay quick.
+func (r *ScryptRecipient) SetWorkFactor(logN int) {
+	if logN < 1 || logN > maxScryptWorkFactor {
+		panic("age: scrypt work factor out of range")
+	}
+	r.workFactor = logN
+}
+
+func (r *ScryptRecipient) wrap(fileKey []byte) (*stanza, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(r.passphrase, append([]byte(scryptLabel), salt...), 1<<r.workFactor, 8, 1, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	body, err := wrapKey(key, fileKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stanza{typ: "scrypt", args: []string{b64.EncodeToString(salt), strconv.Itoa(r.workFactor)}, body: body}, nil
+}
+
+// ScryptIdentity decrypts files encrypted to a passphrase.
+type ScryptIdentity struct {
+	passphrase []byte
+}
+
+// NewScryptIdentity returns a passphrase identity.
+func NewScryptIdentity(passphrase string) (*ScryptIdentity, error) {
+	if passphrase == "" {
+		return nil, errors.New("age: empty passphrase")
+	}
+	return &ScryptIdentity{passphrase: []byte(passphrase)}, nil
+}
+
+func (i *ScryptIdentity) unwrap(stanzas []*stanza) ([]byte, error) {
+	for _, s := range stanzas {
+		if s.typ != "scrypt" {
+			continue
+		}
+		if len(stanzas) != 1 {
+			return nil, errors.New("age: scrypt stanza must be the only recipient")
+		}
+		if len(s.args) != 2 {
+			return nil, errors.New("age: malformed scrypt stanza")
+		}
+		salt, err := b64.DecodeString(s.args[0])
+		if err != nil || len(salt) != 16 {
+			return nil, errors.New("age: malformed scrypt stanza")
+		}
+		logN, err := strconv.Atoi(s.args[1])
+		if err != nil || logN < 1 || strconv.Itoa(logN) != s.args[1] {
+			return nil, errors.New("age: malformed scrypt stanza")
+		}
+		if logN > maxScryptWorkFactor {
+			return nil, fmt.Errorf("age: scrypt work factor %d is too large", logN)
+		}
+		key, err := scrypt.Key(i.passphrase, append([]byte(scryptLabel), salt...), 1<<logN, 8, 1, chacha20poly1305.KeySize)
+		if err != nil {
+			return nil, err
+		}
+		return unwrapKey(key, s.body)
+	}
+	return nil, ErrIncorrectIdentity
+}
+
+// ParseIdentities reads an identity file as written by age-keygen: one
+// AGE-SECRET-KEY-1... per line, with blank lines and # comments ignored.
+func ParseIdentities(r io.Reader) ([]Identity, error) {
+	var ids []Identity
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, err := ParseX25519Identity(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("age: no identities found")
+	}
+	return ids, nil
+}
+
+// ParseRecipients reads a recipients file: one age1... key per line, with
+// blank lines and # comments ignored.
+func ParseRecipients(r io.Reader) ([]Recipient, error) {
+	var rs []Recipient
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rec, err := ParseX25519Recipient(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		rs = append(rs, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, errors.New("age: no recipients found")
+	}
+	return rs, nil
+}
+
+// writer encrypts the payload in 64 KiB chunks (the STREAM construction).
+// A full chunk is held back until more data arrives, so Close can mark the
+// true last chunk.
+type writer struct {
+	aead    cipher.AEAD
+	dst     io.Writer
+	buf     []byte
+	counter uint64
+	err     error
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := 0
+	for len(p) > 0 {
+		if len(w.buf) == chunkSize {
+			if err := w.flush(false); err != nil {
+				return n, err
+			}
+		}
+		k := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+k]
+		p = p[k:]
+		n += k
+	}
+	return n, nil
+}
+
+// Close writes the last chunk. It does not close the underlying writer.
+func (w *writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.flush(true); err != nil {
+		return err
+	}
+	w.err = errors.New("age: write after Close")
+	return nil
+}
+
+func (w *writer) flush(last bool) error {
+	out := w.aead.Seal(nil, streamNonce(w.counter, last), w.buf, nil)
+	if _, err := w.dst.Write(out); err != nil {
+		w.err = err
+		return err
+	}
+	w.counter++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// reader decrypts the payload chunk by chunk.
+type reader struct {
+	aead    cipher.AEAD
+	src     *bufio.Reader
+	buf     []byte
+	counter uint64
+	done    bool
+	err     error
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.readChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *reader) readChunk() error {
+	in := make([]byte, chunkSize+r.aead.Overhead())
+	n, err := io.ReadFull(r.src, in)
+	var last bool
+	switch {
+	case err == io.EOF:
+		return errors.New("age: payload truncated")
+	case err == io.ErrUnexpectedEOF:
+		last = true
+	case err != nil:
+		return err
+	default:
+		_, peekErr := r.src.Peek(1)
+		last = peekErr == io.EOF
+	}
+	if last && n == r.aead.Overhead() && r.counter > 0 {
+		return errors.New("age: empty last chunk")
+	}
+	out, err := r.aead.Open(in[:0], streamNonce(r.counter, last), in[:n], nil)
+	if err != nil {
+		return errors.New("age: payload failed authentication")
+	}
+	r.counter++
+	r.buf = out
+	r.done = last
+	return nil
+}
+
+// streamNonce is an 11-byte big-endian chunk counter followed by the
+// last-chunk flag.
+func streamNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+	for i := 10; i >= 3; i-- {
+		nonce[i] = byte(counter)
+		counter >>= 8
+	}
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
@@ -0,0 +1,212 @@
+package age
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func encrypt(t *testing.T, plaintext []byte, recipients ...Recipient) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := Encrypt(&buf, recipients...)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(ciphertext []byte, identities ...Identity) ([]byte, error) {
+	r, err := Decrypt(bytes.NewReader(ciphertext), identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func newIdentity(t *testing.T) *X25519Identity {
+	t.Helper()
+	id, err := GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestX25519RoundTrip(t *testing.T) {
+	alice, bob := newIdentity(t), newIdentity(t)
+	// Sizes around the 64 KiB chunk boundary exercise the last-chunk flag.
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize} {
+		plaintext := make([]byte, size)
+		if _, err := rand.Read(plaintext); err != nil {
+			t.Fatal(err)
+		}
+		ct := encrypt(t, plaintext, alice.Recipient(), bob.Recipient())
+		if !IsEncrypted(ct) {
+			t.Fatalf("size %d: output lacks the age header", size)
+		}
+		for _, id := range []*X25519Identity{alice, bob} {
+			got, err := decrypt(ct, id)
+			if err != nil {
+				t.Fatalf("size %d: Decrypt: %v", size, err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Fatalf("size %d: plaintext mismatch", size)
+			}
+		}
+	}
+
+	ct := encrypt(t, []byte("secret"), alice.Recipient())
+	if _, err := decrypt(ct, bob); !errors.Is(err, ErrIncorrectIdentity) {
+		t.Errorf("wrong identity: got %v, want ErrIncorrectIdentity", err)
+	}
+}
+
+func TestScryptRoundTrip(t *testing.T) {
+	r, err := NewScryptRecipient("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetWorkFactor(10)
+	ct := encrypt(t, []byte("{\"id\":\"bd-1\"}\n"), r)
+
+	right, _ := NewScryptIdentity("correct horse")
+	got, err := decrypt(ct, right)
+	if err != nil || string(got) != "{\"id\":\"bd-1\"}\n" {
+		t.Fatalf("Decrypt = %q, %v", got, err)
+	}
+	wrong, _ := NewScryptIdentity("battery staple")
+	if _, err := decrypt(ct, wrong); !errors.Is(err, ErrIncorrectIdentity) {
+		t.Errorf("wrong passphrase: got %v, want ErrIncorrectIdentity", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := Encrypt(&buf, r, newIdentity(t).Recipient()); err == nil {
+		t.Error("a passphrase combined with another recipient should be refused")
+	}
+}
+
+func TestTampering(t *testing.T) {
+	id := newIdentity(t)
+	plaintext := bytes.Repeat([]byte("issue line\n"), chunkSize/8)
+	ct := encrypt(t, plaintext, id.Recipient())
+	hdrEnd := bytes.Index(ct, []byte("\n--- ")) + 1
+
+	flipped := bytes.Clone(ct)
+	flipped[len(flipped)-20] ^= 1
+	if _, err := decrypt(flipped, id); err == nil {
+		t.Error("flipped payload byte decrypted without error")
+	}
+	if _, err := decrypt(ct[:len(ct)-100], id); err == nil {
+		t.Error("truncated payload decrypted without error")
+	}
+	// Dropping the final chunk leaves a valid non-final chunk at EOF.
+	if _, err := decrypt(ct[:hdrEnd+len("--- ")+43+1+nonceSize+chunkSize+16], id); err == nil {
+		t.Error("payload missing its last chunk decrypted without error")
+	}
+
+	mac := bytes.Clone(ct)
+	mac[hdrEnd+len("--- ")] ^= 'A' ^ 'B'
+	if _, err := decrypt(mac, id); err == nil {
+		t.Error("tampered header MAC accepted")
+	}
+	if _, err := decrypt([]byte("{\"id\":\"bd-1\"}\n"), id); err == nil {
+		t.Error("plain JSONL accepted as an age file")
+	}
+}
+
+func TestKeyEncoding(t *testing.T) {
+	// Bech32 checksum vectors from BIP 173.
+	for _, s := range []string{"A12UEL5L", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"} {
+		if _, _, err := bech32Decode(s); err != nil {
+			t.Errorf("bech32Decode(%q): %v", s, err)
+		}
+	}
+	for _, s := range []string{"A12UEL5M", "a12UEL5L"} {
+		if _, _, err := bech32Decode(s); err == nil {
+			t.Errorf("bech32Decode(%q) accepted a bad string", s)
+		}
+	}
+
+	// A fixed key (32 bytes of 0x42) checks decoding and re-encoding.
+	const secret = "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX"
+	id, err := ParseX25519Identity(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(id.priv.Bytes(), bytes.Repeat([]byte{0x42}, 32)) {
+		t.Errorf("decoded key = %x", id.priv.Bytes())
+	}
+	if id.String() != secret {
+		t.Errorf("String() = %s", id)
+	}
+	rec, err := ParseX25519Recipient(id.Recipient().String())
+	if err != nil || rec.String() != id.Recipient().String() {
+		t.Errorf("recipient round trip: %v, %v", rec, err)
+	}
+	if _, err := ParseX25519Recipient(strings.Replace(id.Recipient().String(), "age1", "age2", 1)); err == nil {
+		t.Error("recipient with the wrong prefix accepted")
+	}
+
+	file := "# created: today\n# public key: " + id.Recipient().String() + "\n" + secret + "\n"
+	ids, err := ParseIdentities(strings.NewReader(file))
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("ParseIdentities = %v, %v", ids, err)
+	}
+	recs, err := ParseRecipients(strings.NewReader("# team\n" + id.Recipient().String() + "\n"))
+	if err != nil || len(recs) != 1 {
+		t.Fatalf("ParseRecipients = %v, %v", recs, err)
+	}
+	got, err := decrypt(encrypt(t, []byte("ok"), recs...), ids...)
+	if err != nil || string(got) != "ok" {
+		t.Errorf("round trip through parsed keys = %q, %v", got, err)
+	}
+}
+
+// TestAgeInterop checks files against the age CLI in both directions when
+// it is installed.
+func TestAgeInterop(t *testing.T) {
+	ageBin, err := exec.LookPath("age")
+	if err != nil {
+		t.Skip("age not installed")
+	}
+	dir := t.TempDir()
+	id := newIdentity(t)
+	keyFile := filepath.Join(dir, "key.txt")
+	if err := os.WriteFile(keyFile, []byte(id.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	plaintext := bytes.Repeat([]byte("{\"id\":\"bd-1\"}\n"), 10000)
+
+	ours := filepath.Join(dir, "ours.age")
+	if err := os.WriteFile(ours, encrypt(t, plaintext, id.Recipient()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(ageBin, "-d", "-i", keyFile, ours).Output()
+	if err != nil || !bytes.Equal(out, plaintext) {
+		t.Fatalf("age -d of our file: %v", err)
+	}
+
+	cmd := exec.Command(ageBin, "-r", id.Recipient().String())
+	cmd.Stdin = bytes.NewReader(plaintext)
+	theirs, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("age -r: %v", err)
+	}
+	got, err := decrypt(theirs, id)
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Decrypt of age's file: %v", err)
+	}
+}
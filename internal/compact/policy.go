@@ -0,0 +1,157 @@
+package compact
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Config keys for policy-driven compaction. Policies are stored in the
+// database config table as JSON under PolicyPrefix + name.
+const (
+	PolicyPrefix = "compact.policy."
+	ScheduleKey  = "compact.schedule"
+	LastRunKey   = "compact.last_run"
+)
+
+// Summary lengths kept by policy compaction at each tier.
+const (
+	Tier1SummaryLen = 500
+	Tier2SummaryLen = 120
+)
+
+// Policy selects closed issues for compaction. An issue matches when it has
+// been closed at least OlderThanDays, its text content is at least MinSize
+// bytes and would shrink, its type is in Types (any type when empty), and it
+// is exactly one tier below Tier.
+type Policy struct {
+	Name          string   `json:"name"`
+	Tier          int      `json:"tier"`
+	OlderThanDays int      `json:"older_than_days"`
+	MinSize       int      `json:"min_size,omitempty"`
+	Types         []string `json:"types,omitempty"`
+}
+
+// Validate checks a policy before it is stored. Issue types are checked
+// against the project's custom types by the caller.
+func (p *Policy) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("policy name cannot be empty")
+	}
+	if strings.ContainsAny(p.Name, " \t\r\n") {
+		return fmt.Errorf("policy name %q cannot contain whitespace", p.Name)
+	}
+	if p.Tier != 1 && p.Tier != 2 {
+		return fmt.Errorf("tier must be 1 or 2, got %d", p.Tier)
+	}
+	if p.OlderThanDays < 0 {
+		return fmt.Errorf("age must not be negative, got %d days", p.OlderThanDays)
+	}
+	if p.MinSize < 0 {
+		return fmt.Errorf("minimum size must not be negative, got %d", p.MinSize)
+	}
+	for _, t := range p.Types {
+		if strings.TrimSpace(t) == "" {
+			return fmt.Errorf("issue type cannot be empty")
+		}
+	}
+	return nil
+}
+
+// Encode returns the config value stored for the policy.
+func (p *Policy) Encode() (string, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Matches reports whether issue should be compacted by the policy at now.
+func (p *Policy) Matches(issue *types.Issue, now time.Time) bool {
+	if issue.Status != types.StatusClosed || issue.ClosedAt == nil {
+		return false
+	}
+	if issue.CompactionLevel != p.Tier-1 {
+		return false
+	}
+	if now.Sub(*issue.ClosedAt) < time.Duration(p.OlderThanDays)*24*time.Hour {
+		return false
+	}
+	size := ContentSize(issue)
+	if size < p.MinSize || len(Truncate(issue, p.Tier)["description"].(string)) >= size {
+		return false
+	}
+	return len(p.Types) == 0 || slices.Contains(p.Types, string(issue.IssueType))
+}
+
+// PoliciesFromConfig reads every policy from the database config map (as
+// returned by GetAllConfig), sorted by name. Values that fail to parse are
+// reported in errs and skipped.
+func PoliciesFromConfig(all map[string]string) (policies []*Policy, errs []error) {
+	for key, value := range all {
+		name, ok := strings.CutPrefix(key, PolicyPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		var p Policy
+		if err := json.Unmarshal([]byte(value), &p); err != nil {
+			errs = append(errs, fmt.Errorf("policy %s: %w", name, err))
+			continue
+		}
+		p.Name = name
+		if err := p.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("policy %s: %w", name, err))
+			continue
+		}
+		policies = append(policies, &p)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies, errs
+}
+
+// ContentSize is the compactable text of an issue, in bytes.
+func ContentSize(issue *types.Issue) int {
+	return len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+}
+
+// Truncate returns the compacted text fields for issue at tier without
+// calling a model: the description is cut to its first paragraph (falling
+// back to the design or notes when empty) and to the tier's length, and the
+// other fields are cleared. The original is kept in a compaction snapshot.
+func Truncate(issue *types.Issue, tier int) map[string]interface{} {
+	limit := Tier1SummaryLen
+	if tier >= 2 {
+		limit = Tier2SummaryLen
+	}
+	text := issue.Description
+	for _, alt := range []string{issue.Design, issue.Notes, issue.AcceptanceCriteria} {
+		if strings.TrimSpace(text) != "" {
+			break
+		}
+		text = alt
+	}
+	text = strings.TrimSpace(text)
+	if para, _, ok := strings.Cut(text, "\n\n"); ok {
+		text = para
+	}
+	if len(text) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = strings.TrimSpace(text[:cut]) + "…"
+	}
+	return map[string]interface{}{
+		"description":         text,
+		"design":              "",
+		"notes":               "",
+		"acceptance_criteria": "",
+	}
+}
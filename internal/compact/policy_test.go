@@ -0,0 +1,114 @@
+package compact
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPolicyMatches(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	closed := now.Add(-40 * 24 * time.Hour)
+	long := strings.Repeat("x", 1000)
+	base := func() *types.Issue {
+		return &types.Issue{ID: "bd-1", Status: types.StatusClosed, ClosedAt: &closed, IssueType: types.TypeTask, Description: long}
+	}
+	p := &Policy{Name: "p", Tier: 1, OlderThanDays: 30, MinSize: 500, Types: []string{"task"}}
+
+	tests := []struct {
+		name   string
+		mutate func(*types.Issue)
+		want   bool
+	}{
+		{"matches", func(*types.Issue) {}, true},
+		{"open", func(i *types.Issue) { i.Status = types.StatusOpen }, false},
+		{"too recent", func(i *types.Issue) { c := now.Add(-10 * 24 * time.Hour); i.ClosedAt = &c }, false},
+		{"too small", func(i *types.Issue) { i.Description = strings.Repeat("x", 499) }, false},
+		{"other type", func(i *types.Issue) { i.IssueType = types.TypeBug }, false},
+		{"already tier 1", func(i *types.Issue) { i.CompactionLevel = 1 }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := base()
+			tt.mutate(issue)
+			if got := p.Matches(issue, now); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	tiny := base()
+	tiny.Description = "tiny"
+	if (&Policy{Name: "any", Tier: 1}).Matches(tiny, now) {
+		t.Error("issues that would not shrink should not match")
+	}
+
+	tier2 := &Policy{Name: "t2", Tier: 2, OlderThanDays: 30}
+	issue := base()
+	if tier2.Matches(issue, now) {
+		t.Error("tier 2 should skip uncompacted issues")
+	}
+	issue.CompactionLevel = 1
+	if !tier2.Matches(issue, now) {
+		t.Error("tier 2 should match tier 1 issues")
+	}
+}
+
+func TestPoliciesFromConfig(t *testing.T) {
+	all := map[string]string{
+		PolicyPrefix + "b":    `{"tier":2,"older_than_days":90}`,
+		PolicyPrefix + "a":    `{"tier":1,"older_than_days":30,"types":["bug"]}`,
+		PolicyPrefix + "bad":  `{"tier":3}`,
+		PolicyPrefix + "junk": `not json`,
+		ScheduleKey:           "@daily",
+	}
+	policies, errs := PoliciesFromConfig(all)
+	if len(policies) != 2 || policies[0].Name != "a" || policies[1].Name != "b" {
+		t.Fatalf("policies = %+v", policies)
+	}
+	if policies[0].Types[0] != "bug" || policies[1].Tier != 2 {
+		t.Errorf("fields not decoded: %+v %+v", policies[0], policies[1])
+	}
+	if len(errs) != 2 {
+		t.Errorf("want 2 errors, got %v", errs)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	for _, p := range []Policy{
+		{Name: "", Tier: 1},
+		{Name: "a b", Tier: 1},
+		{Name: "a", Tier: 0},
+		{Name: "a", Tier: 1, OlderThanDays: -1},
+		{Name: "a", Tier: 1, MinSize: -1},
+		{Name: "a", Tier: 1, Types: []string{" "}},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", p)
+		}
+	}
+	if err := (&Policy{Name: "a", Tier: 2, Types: []string{"bug"}}).Validate(); err != nil {
+		t.Errorf("valid policy rejected: %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	issue := &types.Issue{Description: "First para.\n\nSecond para.", Design: "d", Notes: "n", AcceptanceCriteria: "a"}
+	got := Truncate(issue, 1)
+	if got["description"] != "First para." || got["design"] != "" || got["notes"] != "" || got["acceptance_criteria"] != "" {
+		t.Errorf("Truncate = %v", got)
+	}
+
+	long := &types.Issue{Description: strings.Repeat("é", 200)}
+	d := Truncate(long, 2)["description"].(string)
+	if !strings.HasSuffix(d, "…") || len(d) > Tier2SummaryLen+len("…") || !strings.HasPrefix(d, "é") {
+		t.Errorf("tier 2 truncation = %q (%d bytes)", d, len(d))
+	}
+
+	fallback := &types.Issue{Notes: "only notes"}
+	if got := Truncate(fallback, 1)["description"]; got != "only notes" {
+		t.Errorf("empty description should fall back to notes, got %q", got)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -199,12 +201,23 @@ func Initialize() error {
 	v.SetDefault("federation.sovereignty", "")                     // T1 | T2 | T3 | T4 (empty = no restriction)
 	v.SetDefault("federation.allowed-remote-patterns", []string{}) // glob patterns restricting allowed remote URLs (enterprise lockdown)
 	v.SetDefault("federation.exclude_types", []string{"wisp"})     // issue types excluded from federation push (privacy filter)
+	v.SetDefault("federation.exclude_tables", []string{})          // tables (glob patterns) whose rows are withheld from federation push
+	v.SetDefault("federation.allow_wisps", false)                  // push wisp data to peers (otherwise always withheld)
+	v.SetDefault("federation.stale-threshold", "24h")              // doctor warns when a peer's last_sync is older than this
+	v.SetDefault("federation.sync-interval", "15m")                // bd federation daemon: default per-peer sync interval
+	v.SetDefault("federation.max-backoff", "1h")                   // bd federation daemon: cap on retry delay after failures
+	v.SetDefault("federation.registry", []string{})                // bd federation discover: registry files/URLs listing peer towns
 
 	// Push configuration defaults
 	v.SetDefault("no-push", false)
 
 	// Create command defaults
 	v.SetDefault("create.require-description", false)
+	// Duplicate guard: "none" (default) | "warn" | "error". Checks a new issue
+	// against open issues before creating it; duplicate-threshold > 0 also
+	// flags near-duplicates by find-duplicates' mechanical similarity.
+	v.SetDefault("create.duplicate-check", "none")
+	v.SetDefault("create.duplicate-threshold", 0.0)
 
 	// Validation configuration defaults (bd-t7jq)
 	// Values: "warn" | "error" | "none"
@@ -215,6 +228,62 @@ func Initialize() error {
 	v.SetDefault("validation.on-close", "none")
 	v.SetDefault("validation.on-sync", "none")
 
+	// bd lint content rules, on top of the per-type template sections.
+	// All are off by default; see validation.ContentRules.
+	v.SetDefault("lint.require-acceptance-criteria", []string{})
+	v.SetDefault("lint.description-min-length", 0)
+	v.SetDefault("lint.title-max-length", 0)
+	v.SetDefault("lint.title-pattern", "")
+	v.SetDefault("lint.forbidden-text", []string{})
+	v.SetDefault("lint.require-priority", false)
+
+	// Molecule auto-advance: after a step closes, assign the next ready step.
+	// - "off": disabled (default)
+	// - "actor": assign to the closed step's assignee, else the closing actor
+	// - "agent": assign to the molecule root's assignee, else as "actor"
+	v.SetDefault("molecule.auto-advance", "off")
+
+	// Molecule sweeper (bd mol sweep): which open roots with all children
+	// closed get auto-closed, and whether the post-merge hook runs it.
+	v.SetDefault("molecule.sweep.types", []string{"epic", "molecule"})
+	v.SetDefault("molecule.sweep.labels", []string{})
+	v.SetDefault("molecule.sweep.on-merge", false)
+
+	// Assignment balancing (bd assign --balance): the agents to balance
+	// across, and per-agent skill labels restricting what each may take.
+	v.SetDefault("assign.agents", []string{})
+	v.SetDefault("assign.skills", map[string][]string{})
+
+	// Stale issue policy (bd stale sweep): label issues with no activity for
+	// label-after-days, then close them close-after-days later. 0 disables
+	// each stage.
+	v.SetDefault("stale.label-after-days", 0)
+	v.SetDefault("stale.close-after-days", 0)
+	v.SetDefault("stale.label", "stale")
+	v.SetDefault("stale.exempt-labels", []string{})
+	v.SetDefault("stale.exempt-priorities", []int{})
+
+	// Priority aging (bd age): raise issues open longer than their type's
+	// threshold by one priority level per threshold, stopping at
+	// highest-priority. No thresholds means aging is off.
+	v.SetDefault("aging.after-days", map[string]int{})
+	v.SetDefault("aging.highest-priority", 1)
+	v.SetDefault("aging.exempt-labels", []string{})
+
+	// Notification digests (bd digest): how digests are delivered and when
+	// bd digest daemon sends them. The SMTP password is best supplied through
+	// BD_DIGEST_SMTP_PASSWORD rather than written to config.yaml.
+	v.SetDefault("digest.deliver", "stdout")           // stdout | email | webhook
+	v.SetDefault("digest.send-at", "08:00")            // bd digest daemon: local time of day to send
+	v.SetDefault("digest.actors", []string{})          // actors to send to (empty = everyone with news)
+	v.SetDefault("digest.emails", map[string]string{}) // actor -> email address
+	v.SetDefault("digest.webhook-url", "")             // URL each digest is POSTed to as JSON
+	v.SetDefault("digest.smtp.host", "")
+	v.SetDefault("digest.smtp.port", 587)
+	v.SetDefault("digest.smtp.username", "")
+	v.SetDefault("digest.smtp.password", "")
+	v.SetDefault("digest.smtp.from", "")
+
 	// Metadata schema validation (GH#1416 Phase 2)
 	// - "none": no metadata schema validation (default)
 	// - "warn": validate and print warnings but proceed
@@ -227,8 +296,11 @@ func Initialize() error {
 	v.SetDefault("hierarchy.max-depth", 3)
 
 	// Git configuration defaults (GH#600)
-	v.SetDefault("git.author", "")         // Override commit author (e.g., "beads-bot <beads@example.com>")
-	v.SetDefault("git.no-gpg-sign", false) // Disable GPG signing for beads commits
+	v.SetDefault("git.author", "")             // Override commit author (e.g., "beads-bot <beads@example.com>")
+	v.SetDefault("git.no-gpg-sign", false)     // Disable GPG signing for beads commits
+	v.SetDefault("git.close-on-commit", false) // Close issues named in commit messages from git hooks
+	v.SetDefault("git.link-commits", false)    // Link commits to the issues they mention from git hooks
+	v.SetDefault("git.branch-pattern", "")     // bd start branch name; empty = "{type}/{id}-{slug}"
 
 	// Directory-aware label scoping (GH#541)
 	// Maps directory patterns to labels for automatic filtering in monorepos
@@ -247,15 +319,39 @@ func Initialize() error {
 	v.SetDefault("export.interval", "60s")
 	v.SetDefault("export.path", "issues.jsonl") // relative to .beads/; canonical name
 	v.SetDefault("export.git-add", false)
+	// Schema header line ({"_schema":...}) at the top of exports; off so
+	// every line stays an issue for jq and scripts.
+	v.SetDefault("export.schema-header", false)
+	v.SetDefault("export.encrypt-to", []string{}) // age recipients for bd export; secrets stay in BEADS_AGE_* env vars
+
+	// Auto-mirror: optional read-only SQLite snapshot (bd mirror) refreshed
+	// after write commands, for analytics tools that should not hit Dolt.
+	v.SetDefault("mirror.auto", false)
+	v.SetDefault("mirror.interval", "15m")
+	v.SetDefault("mirror.path", "mirror.sqlite") // relative to .beads/
 
 	// Auto-import: legacy compatibility fallback for projects that have not
 	// configured a Dolt remote yet. Hook code skips this path when sync.remote
 	// is configured because JSONL import is upsert-only, not reconciliation.
 	v.SetDefault("import.auto", true)
 	v.SetDefault("import.path", "issues.jsonl") // relative to .beads/; canonical import name
+	// Opt-in: list/show/ready re-import import.path when it changed after the
+	// last import (e.g. after git pull) instead of reading stale data.
+	v.SetDefault("import.auto-on-stale", false)
+
+	// Offline mode (opt-in): create/update/close are journaled to
+	// .beads/offline-queue.jsonl when the Dolt server is unreachable, and
+	// replayed by bd sync --flush. Off by default because a queued create
+	// exits 0 without an issue ID, which scripts would take as success.
+	v.SetDefault("offline.queue-writes", false)
 
 	// AI configuration defaults
 	v.SetDefault("ai.model", "claude-haiku-4-5-20251001")
+	// Embeddings for bd similar: local (built-in, no network), ollama, or openai.
+	v.SetDefault("ai.embeddings.provider", "local")
+	// LLM for bd triage: anthropic (uses ai.api_key) or openai (any
+	// OpenAI-compatible chat API at ai.triage.url).
+	v.SetDefault("ai.triage.provider", "anthropic")
 
 	// Output configuration (GH#1384)
 	// Controls title display in command feedback messages.
@@ -654,6 +750,14 @@ func GetInt(key string) int {
 	return v.GetInt(key)
 }
 
+// GetFloat64 retrieves a floating-point configuration value
+func GetFloat64(key string) float64 {
+	if v == nil {
+		return 0
+	}
+	return v.GetFloat64(key)
+}
+
 // GetDuration retrieves a duration configuration value
 func GetDuration(key string) time.Duration {
 	if v == nil {
@@ -730,6 +834,14 @@ func GetStringMapString(key string) map[string]string {
 	return v.GetStringMapString(key)
 }
 
+// GetStringMapStringSlice retrieves a map[string][]string configuration value
+func GetStringMapStringSlice(key string) map[string][]string {
+	if v == nil {
+		return map[string][]string{}
+	}
+	return v.GetStringMapStringSlice(key)
+}
+
 // GetDirectoryLabels returns labels for the current working directory based on config.
 // It checks directory.labels config for matching patterns.
 // Returns nil if no labels are configured for the current directory.
@@ -869,17 +981,191 @@ func GetIdentity(flagValue string) string {
 
 // FederationConfig holds the federation (Dolt remote) configuration.
 type FederationConfig struct {
-	Remote       string      // dolthub://org/beads, gs://bucket/beads, s3://bucket/beads
-	Sovereignty  Sovereignty // T1, T2, T3, T4
-	ExcludeTypes []string    // issue types excluded from federation push (e.g. ["wisp"])
+	Remote         string            // dolthub://org/beads, gs://bucket/beads, s3://bucket/beads
+	Sovereignty    Sovereignty       // T1, T2, T3, T4
+	ExcludeTypes   []string          // issue types excluded from federation push (e.g. ["wisp"])
+	StaleThreshold time.Duration     // max peer last_sync age before doctor warns
+	SyncInterval   time.Duration     // default per-peer interval for bd federation daemon
+	MaxBackoff     time.Duration     // cap on the daemon's retry delay after failures
+	PeerIntervals  map[string]string // per-peer interval overrides (peer name -> duration)
+}
+
+// WispTablePatterns match the wisp tables. They are dolt_ignore'd, but any
+// that end up tracked are withheld from federation peers like ephemeral issues.
+var WispTablePatterns = []string{"wisps", "wisp_*"}
+
+// FederationPeerFilter is the filter applied to data pushed to one federation peer.
+type FederationPeerFilter struct {
+	ExcludeTypes  []string // issue types withheld from the peer ("wisp" matches ephemeral issues)
+	ExcludeTables []string // tables (glob patterns) whose rows are withheld from the peer
+	AllowWisps    bool     // push wisp data to the peer
+}
+
+// Empty reports whether the filter withholds nothing.
+func (f FederationPeerFilter) Empty() bool {
+	return len(f.ExcludeTypes) == 0 && len(f.ExcludeTables) == 0
+}
+
+// GetFederationPeerFilter returns the push filter for peer. Keys under
+// federation.peer-filters.<peer> (exclude_types, exclude_tables, allow_wisps)
+// override the global federation.* values of the same name. Unless the peer
+// allows wisps, the "wisp" type and WispTablePatterns are always excluded,
+// whatever the exclude lists say.
+func GetFederationPeerFilter(peer string) FederationPeerFilter {
+	prefix := "federation.peer-filters." + peer + "."
+	setting := func(key string) string {
+		if v != nil && v.IsSet(prefix+key) {
+			return prefix + key
+		}
+		return "federation." + key
+	}
+	f := FederationPeerFilter{
+		ExcludeTypes:  GetStringSlice(setting("exclude_types")),
+		ExcludeTables: GetStringSlice(setting("exclude_tables")),
+		AllowWisps:    GetBool(setting("allow_wisps")),
+	}
+	return f.withWispPolicy()
+}
+
+// withWispPolicy adds or removes the wisp exclusions according to AllowWisps.
+func (f FederationPeerFilter) withWispPolicy() FederationPeerFilter {
+	excludeTypes := slices.DeleteFunc(slices.Clone(f.ExcludeTypes), func(t string) bool { return t == "wisp" })
+	excludeTables := slices.DeleteFunc(slices.Clone(f.ExcludeTables), func(t string) bool { return slices.Contains(WispTablePatterns, t) })
+	if !f.AllowWisps {
+		excludeTypes = append(excludeTypes, "wisp")
+		excludeTables = append(excludeTables, WispTablePatterns...)
+	}
+	f.ExcludeTypes, f.ExcludeTables = excludeTypes, excludeTables
+	return f
 }
 
 // GetFederationConfig returns the current federation configuration.
 func GetFederationConfig() FederationConfig {
 	return FederationConfig{
-		Remote:       GetString("federation.remote"),
-		Sovereignty:  GetSovereignty(),
-		ExcludeTypes: GetStringSlice("federation.exclude_types"),
+		Remote:         GetString("federation.remote"),
+		Sovereignty:    GetSovereignty(),
+		ExcludeTypes:   GetStringSlice("federation.exclude_types"),
+		StaleThreshold: GetDuration("federation.stale-threshold"),
+		SyncInterval:   GetDuration("federation.sync-interval"),
+		MaxBackoff:     GetDuration("federation.max-backoff"),
+		PeerIntervals:  GetStringMapString("federation.peer-intervals"),
+	}
+}
+
+// StalePolicy holds the stale issue policy applied by bd stale sweep.
+type StalePolicy struct {
+	LabelAfterDays   int      // days without activity before an issue is labeled (0 = disabled)
+	CloseAfterDays   int      // days an issue stays labeled before it is closed (0 = never close)
+	Label            string   // label marking stale issues
+	ExemptLabels     []string // issues with any of these labels are never touched
+	ExemptPriorities []int    // issues at these priorities are never touched
+}
+
+// GetStalePolicy returns the current stale issue policy.
+func GetStalePolicy() StalePolicy {
+	p := StalePolicy{
+		LabelAfterDays: GetInt("stale.label-after-days"),
+		CloseAfterDays: GetInt("stale.close-after-days"),
+		Label:          strings.TrimSpace(GetString("stale.label")),
+		ExemptLabels:   GetStringSlice("stale.exempt-labels"),
+	}
+	if v != nil {
+		p.ExemptPriorities = v.GetIntSlice("stale.exempt-priorities")
+	}
+	if p.Label == "" {
+		p.Label = "stale"
+	}
+	return p
+}
+
+// Exempt reports whether an issue with these labels and priority is
+// excluded from the stale policy.
+func (p StalePolicy) Exempt(labels []string, priority int) bool {
+	if slices.Contains(p.ExemptPriorities, priority) {
+		return true
+	}
+	return slices.ContainsFunc(labels, func(l string) bool { return slices.Contains(p.ExemptLabels, l) })
+}
+
+// PriorityAgingPolicy holds the priority aging policy applied by bd age.
+type PriorityAgingPolicy struct {
+	AfterDays       map[string]int // days at one priority before aging, by issue type ("default" for the rest; 0 = never)
+	HighestPriority int            // aging never raises an issue above this priority
+	ExemptLabels    []string       // issues with any of these labels are never aged
+}
+
+// GetPriorityAgingPolicy returns the current priority aging policy.
+func GetPriorityAgingPolicy() PriorityAgingPolicy {
+	p := PriorityAgingPolicy{
+		AfterDays:       map[string]int{},
+		HighestPriority: GetInt("aging.highest-priority"),
+		ExemptLabels:    GetStringSlice("aging.exempt-labels"),
+	}
+	if v != nil {
+		// AllKeys covers both a nested after-days map and the flat
+		// aging.after-days.<type> keys written by bd config set.
+		for _, key := range v.AllKeys() {
+			issueType, ok := strings.CutPrefix(key, "aging.after-days.")
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(v.GetString(key)))
+			if err == nil && n >= 0 {
+				p.AfterDays[issueType] = n
+			}
+		}
+	}
+	p.HighestPriority = max(p.HighestPriority, 0)
+	return p
+}
+
+// Enabled reports whether any issue type has an aging threshold.
+func (p PriorityAgingPolicy) Enabled() bool {
+	for _, days := range p.AfterDays {
+		if days > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Threshold returns the days an issue of issueType waits at one priority
+// before aging, or 0 if the type does not age. A type set to 0 opts out of
+// the default.
+func (p PriorityAgingPolicy) Threshold(issueType string) int {
+	if days, ok := p.AfterDays[strings.ToLower(issueType)]; ok {
+		return days
+	}
+	return p.AfterDays["default"]
+}
+
+// DigestConfig holds the notification digest settings used by bd digest.
+type DigestConfig struct {
+	Deliver      string            // stdout, email, or webhook
+	SendAt       string            // local time of day (HH:MM) bd digest daemon sends at
+	Actors       []string          // actors to send to; empty means everyone with news
+	Emails       map[string]string // actor -> email address (keys are lowercased)
+	WebhookURL   string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+}
+
+// GetDigestConfig returns the current notification digest configuration.
+func GetDigestConfig() DigestConfig {
+	return DigestConfig{
+		Deliver:      strings.TrimSpace(GetString("digest.deliver")),
+		SendAt:       strings.TrimSpace(GetString("digest.send-at")),
+		Actors:       GetStringSlice("digest.actors"),
+		Emails:       GetStringMapString("digest.emails"),
+		WebhookURL:   strings.TrimSpace(GetString("digest.webhook-url")),
+		SMTPHost:     strings.TrimSpace(GetString("digest.smtp.host")),
+		SMTPPort:     GetInt("digest.smtp.port"),
+		SMTPUsername: GetString("digest.smtp.username"),
+		SMTPPassword: GetString("digest.smtp.password"),
+		SMTPFrom:     strings.TrimSpace(GetString("digest.smtp.from")),
 	}
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -68,6 +69,7 @@ func TestDefaults(t *testing.T) {
 		{"actor", "", func(k string) interface{} { return GetString(k) }},
 		{"export.auto", false, func(k string) interface{} { return GetBool(k) }},
 		{"export.git-add", false, func(k string) interface{} { return GetBool(k) }},
+		{"offline.queue-writes", false, func(k string) interface{} { return GetBool(k) }},
 	}
 
 	for _, tt := range tests {
@@ -1190,6 +1192,27 @@ func TestFederationConfigDefaults(t *testing.T) {
 	}
 }
 
+func TestDigestConfigDefaults(t *testing.T) {
+	restore := envSnapshot(t)
+	defer restore()
+	t.Setenv("BD_DIGEST_SMTP_PASSWORD", "hunter2")
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	cfg := GetDigestConfig()
+	if cfg.Deliver != "stdout" || cfg.SendAt != "08:00" || cfg.SMTPPort != 587 {
+		t.Errorf("GetDigestConfig() = %+v, want stdout delivery at 08:00 over port 587", cfg)
+	}
+	if cfg.SMTPPassword != "hunter2" {
+		t.Errorf("GetDigestConfig().SMTPPassword = %q, want it from BD_DIGEST_SMTP_PASSWORD", cfg.SMTPPassword)
+	}
+	if !IsYamlOnlyKey("digest.emails.alice") {
+		t.Error("digest.* keys should be yaml-only")
+	}
+}
+
 func TestFederationConfigFromFile(t *testing.T) {
 	// Create a temporary directory for config file
 	tmpDir := t.TempDir()
@@ -1228,6 +1251,43 @@ federation:
 	}
 }
 
+func TestPriorityAgingPolicyFromFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	configContent := `
+aging:
+  after-days:
+    bug: 14
+    default: 30
+    epic: 0
+  exempt-labels: [icebox]
+aging.after-days.chore: 7
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Chdir(tmpDir)
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+
+	p := GetPriorityAgingPolicy()
+	if !p.Enabled() || p.HighestPriority != 1 {
+		t.Errorf("policy = %+v, want enabled with highest priority 1", p)
+	}
+	for issueType, want := range map[string]int{"bug": 14, "Task": 30, "epic": 0, "chore": 7} {
+		if got := p.Threshold(issueType); got != want {
+			t.Errorf("Threshold(%q) = %d, want %d", issueType, got, want)
+		}
+	}
+	if len(p.ExemptLabels) != 1 || p.ExemptLabels[0] != "icebox" {
+		t.Errorf("ExemptLabels = %v, want [icebox]", p.ExemptLabels)
+	}
+}
+
 func TestFederationExcludeTypesOptOut(t *testing.T) {
 	tmpDir := t.TempDir()
 	beadsDir := filepath.Join(tmpDir, ".beads")
@@ -1251,6 +1311,60 @@ federation:
 	}
 }
 
+func TestGetFederationPeerFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	configContent := `
+federation:
+  exclude_types: []
+  exclude_tables: [comments]
+  peer-filters:
+    town-beta:
+      exclude_types: [decision]
+      allow_wisps: true
+    town-gamma:
+      exclude_tables: [events, wisps]
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Chdir(tmpDir)
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	tests := []struct {
+		peer       string
+		wantTypes  []string
+		wantTables []string
+		wantWisps  bool
+	}{
+		// Global lists apply; wisps are withheld even though exclude_types is empty.
+		{"town-alpha", []string{"wisp"}, []string{"comments", "wisps", "wisp_*"}, false},
+		// Per-peer keys override the global ones; allow_wisps lifts the wisp exclusion.
+		{"town-beta", []string{"decision"}, []string{"comments"}, true},
+		// A wisp pattern listed explicitly is not duplicated.
+		{"town-gamma", []string{"wisp"}, []string{"events", "wisps", "wisp_*"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.peer, func(t *testing.T) {
+			f := GetFederationPeerFilter(tt.peer)
+			if !slices.Equal(f.ExcludeTypes, tt.wantTypes) {
+				t.Errorf("ExcludeTypes = %v, want %v", f.ExcludeTypes, tt.wantTypes)
+			}
+			if !slices.Equal(f.ExcludeTables, tt.wantTables) {
+				t.Errorf("ExcludeTables = %v, want %v", f.ExcludeTables, tt.wantTables)
+			}
+			if f.AllowWisps != tt.wantWisps {
+				t.Errorf("AllowWisps = %v, want %v", f.AllowWisps, tt.wantWisps)
+			}
+		})
+	}
+}
+
 func TestGetSovereigntyInvalid(t *testing.T) {
 	// Isolate from environment variables
 	restore := envSnapshot(t)
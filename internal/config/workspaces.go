@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Workspace is a named beads project registered with bd ws add.
+type Workspace struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// WorkspaceRegistry is the set of workspaces known on this machine, stored
+// next to the user-level config.yaml in workspaces.json.
+type WorkspaceRegistry struct {
+	// Current names the workspace bd uses when the working directory is not
+	// inside a beads project.
+	Current    string      `json:"current,omitempty"`
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+// WorkspacesPath returns the path of the workspace registry file.
+func WorkspacesPath() string {
+	return filepath.Join(filepath.Dir(UserConfigYamlPath()), "workspaces.json")
+}
+
+// LoadWorkspaces reads the workspace registry. A missing file yields an
+// empty registry.
+func LoadWorkspaces() (*WorkspaceRegistry, error) {
+	path := WorkspacesPath()
+	data, err := os.ReadFile(path) // #nosec G304 - path derived from the user config dir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &WorkspaceRegistry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var reg WorkspaceRegistry
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &reg, nil
+}
+
+// SaveWorkspaces writes the workspace registry, sorted by name.
+func SaveWorkspaces(reg *WorkspaceRegistry) error {
+	path := WorkspacesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	sort.Slice(reg.Workspaces, func(i, j int) bool { return reg.Workspaces[i].Name < reg.Workspaces[j].Name })
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode workspaces: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the workspace with the given name.
+func (r *WorkspaceRegistry) Find(name string) (Workspace, bool) {
+	for _, ws := range r.Workspaces {
+		if ws.Name == name {
+			return ws, true
+		}
+	}
+	return Workspace{}, false
+}
+
+// Add registers a workspace. Re-adding an existing name updates its path.
+func (r *WorkspaceRegistry) Add(name, path string) error {
+	if err := validateWorkspaceName(name); err != nil {
+		return err
+	}
+	for i := range r.Workspaces {
+		if r.Workspaces[i].Name == name {
+			r.Workspaces[i].Path = path
+			return nil
+		}
+	}
+	r.Workspaces = append(r.Workspaces, Workspace{Name: name, Path: path})
+	return nil
+}
+
+// Remove unregisters a workspace and reports whether it existed. Removing
+// the current workspace clears Current.
+func (r *WorkspaceRegistry) Remove(name string) bool {
+	for i, ws := range r.Workspaces {
+		if ws.Name == name {
+			r.Workspaces = append(r.Workspaces[:i], r.Workspaces[i+1:]...)
+			if r.Current == name {
+				r.Current = ""
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func validateWorkspaceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("workspace name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\ `+"\t") {
+		return fmt.Errorf("invalid workspace name %q: must not contain slashes or whitespace", name)
+	}
+	return nil
+}
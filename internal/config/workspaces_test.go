@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestWorkspaceRegistryRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	reg, err := LoadWorkspaces()
+	if err != nil {
+		t.Fatalf("LoadWorkspaces (missing file): %v", err)
+	}
+	if len(reg.Workspaces) != 0 {
+		t.Fatalf("expected empty registry, got %v", reg.Workspaces)
+	}
+
+	if err := reg.Add("web", "/src/web"); err != nil {
+		t.Fatalf("Add web: %v", err)
+	}
+	if err := reg.Add("api", "/src/api"); err != nil {
+		t.Fatalf("Add api: %v", err)
+	}
+	if err := reg.Add("web", "/src/web2"); err != nil {
+		t.Fatalf("re-Add web: %v", err)
+	}
+	reg.Current = "web"
+	if err := SaveWorkspaces(reg); err != nil {
+		t.Fatalf("SaveWorkspaces: %v", err)
+	}
+
+	got, err := LoadWorkspaces()
+	if err != nil {
+		t.Fatalf("LoadWorkspaces: %v", err)
+	}
+	if got.Current != "web" || len(got.Workspaces) != 2 || got.Workspaces[0].Name != "api" {
+		t.Fatalf("unexpected registry after reload: %+v", got)
+	}
+	if ws, ok := got.Find("web"); !ok || ws.Path != "/src/web2" {
+		t.Errorf("Find(web) = %+v, %v", ws, ok)
+	}
+
+	if !got.Remove("web") {
+		t.Error("Remove(web) = false")
+	}
+	if got.Current != "" {
+		t.Errorf("removing the current workspace should clear Current, got %q", got.Current)
+	}
+	if got.Remove("web") {
+		t.Error("second Remove(web) = true")
+	}
+}
+
+func TestWorkspaceRegistryRejectsBadNames(t *testing.T) {
+	reg := &WorkspaceRegistry{}
+	for _, name := range []string{"", "a/b", "a b"} {
+		if err := reg.Add(name, "/tmp"); err == nil {
+			t.Errorf("Add(%q) should fail", name)
+		}
+	}
+}
@@ -29,10 +29,13 @@ var YamlOnlyKeys = map[string]bool{
 	"identity": true,
 
 	// Git settings
-	"git.author":      true,
-	"git.no-gpg-sign": true,
-	"no-push":         true,
-	"no-git-ops":      true, // Disable git ops in bd prime session close protocol (GH#593)
+	"git.author":          true,
+	"git.no-gpg-sign":     true,
+	"git.close-on-commit": true,
+	"git.link-commits":    true,
+	"git.branch-pattern":  true,
+	"no-push":             true,
+	"no-git-ops":          true, // Disable git ops in bd prime session close protocol (GH#593)
 
 	// Sync settings
 	"sync.remote":     true, // Primary: any Dolt-compatible remote URL
@@ -47,6 +50,8 @@ var YamlOnlyKeys = map[string]bool{
 
 	// Create command settings
 	"create.require-description": true,
+	"create.duplicate-check":     true,
+	"create.duplicate-threshold": true,
 
 	// Validation settings (bd-t7jq)
 	// Values: "warn" | "error" | "none"
@@ -57,18 +62,51 @@ var YamlOnlyKeys = map[string]bool{
 	// Hierarchy settings (GH#995)
 	"hierarchy.max-depth": true,
 
+	// Molecule settings
+	// Values: "off" | "actor" | "agent"
+	"molecule.auto-advance":   true,
+	"molecule.sweep.types":    true,
+	"molecule.sweep.labels":   true,
+	"molecule.sweep.on-merge": true,
+
+	// Assignment balancing (bd assign --balance)
+	"assign.agents": true,
+	"assign.skills": true,
+
+	// Stale issue policy (bd stale sweep)
+	"stale.label-after-days":  true,
+	"stale.close-after-days":  true,
+	"stale.label":             true,
+	"stale.exempt-labels":     true,
+	"stale.exempt-priorities": true,
+
 	// Backup settings (must be in yaml so GetValueSource can detect overrides)
 	"backup.enabled":  true,
 	"backup.interval": true,
 	"backup.git-push": true,
 	"backup.git-repo": true,
 
+	// Export settings
+	"export.encrypt-to": true, // who can read exports must not be changed through the database
+
 	// Import settings
-	"import.path": true,
+	"import.path":            true,
+	"import.auto-on-stale":   true, // decides whether list/show/ready may reopen the store writable
+	"import.verify":          true, // trust policy must not be disabled through the database
+	"import.allowed-signers": true,
+
+	// Offline settings (read before the store opens)
+	"offline.queue-writes": true,
+
+	// Access control (read before commands run; see bd token)
+	"rbac.require-token": true,
 
 	// Dolt server settings
 	"dolt.shared-server": true, // Shared Dolt server at ~/.beads/shared-server/ (GH#2377)
 	"dolt.max-conns":     true, // Connection pool size override (default 10, GH#3140)
+	"dolt.max-clients":   true, // Max concurrent bd processes per server (admission control)
+	"dolt.rate-limit":    true, // Per-actor store opens per interval, e.g. "60/m"
+	"dolt.queue-timeout": true, // How long to queue before a backpressure error (default 30s)
 	"dolt.debug":         true, // Debug-mode dolt sql-server: --loglevel=debug + --prof cpu
 
 	// Secrets: tokens and API keys must NOT be stored in the Dolt database
@@ -92,7 +130,7 @@ func IsYamlOnlyKey(key string) bool {
 	}
 
 	// Check prefix matches for nested keys
-	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "backup.", "export.", "dolt.", "federation."}
+	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "lint.", "hierarchy.", "ai.", "backup.", "export.", "mirror.", "dolt.", "federation.", "aging.", "digest."}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
 			return true
@@ -129,9 +167,11 @@ func isGitTracked(path string) bool {
 }
 
 var secretKeyEnvVarHints = map[string]string{ //nolint:gosec // Values are environment variable names, not credentials.
-	"ai.api_key":     "ANTHROPIC_API_KEY",
-	"github.token":   "GITHUB_TOKEN",
-	"linear.api_key": "LINEAR_API_KEY",
+	"ai.api_key":            "ANTHROPIC_API_KEY",
+	"ai.embeddings.api_key": "OPENAI_API_KEY",
+	"ai.triage.api_key":     "OPENAI_API_KEY",
+	"github.token":          "GITHUB_TOKEN",
+	"linear.api_key":        "LINEAR_API_KEY",
 }
 
 // secretKeyEnvVarHint returns a suggested environment variable name for a
@@ -34,6 +34,13 @@ type Config struct {
 	DoltServerTLS      bool   `json:"dolt_server_tls,omitempty"`      // Enable TLS for server connections (required for Hosted Dolt)
 	DoltDataDir        string `json:"dolt_data_dir,omitempty"`        // Custom dolt data directory (absolute path; default: .beads/dolt)
 	DoltRemotesAPIPort int    `json:"dolt_remotesapi_port,omitempty"` // Dolt remotesapi port for federation (default: 8080)
+
+	// Connection pool for the Dolt sql-server connection. Zero values keep the
+	// built-in defaults; BEADS_DOLT_MAX_CONNS and dolt.max-conns still win for
+	// the pool size.
+	DoltMaxOpenConns    int    `json:"dolt_max_open_conns,omitempty"`    // Max open connections (default: 10)
+	DoltMaxIdleConns    int    `json:"dolt_max_idle_conns,omitempty"`    // Max idle connections kept warm (default: 5)
+	DoltConnMaxLifetime string `json:"dolt_conn_max_lifetime,omitempty"` // Max reuse time per connection, e.g. "30m" (default: 1h)
 	// Note: Password should be set via BEADS_DOLT_PASSWORD env var for security
 
 	// Project identity — unique ID generated at bd init time.
@@ -53,6 +60,15 @@ type Config struct {
 	// upgrade notifications firing after git operations reset metadata.json.
 	// bd-tok: This field is kept for backwards compatibility when reading old configs.
 	LastBdVersion string `json:"last_bd_version,omitempty"`
+
+	// Named override sets selected with BD_PROFILE or --profile
+	// (see Profile). Kept raw so unknown profiles never fail to load.
+	Profiles map[string]json.RawMessage `json:"profiles,omitempty"`
+
+	activeProfile string   // profile applied by Load
+	onDisk        *Config  // settings as read from disk, before overrides
+	applied       *Config  // settings right after overrides were applied
+	overridden    []string // settings replaced by the profile or BD_* env vars
 }
 
 func DefaultConfig() *Config {
@@ -94,6 +110,9 @@ func Load(beadsDir string) (*Config, error) {
 		// Remove legacy file (best effort: migration already saved to new location)
 		_ = os.Remove(legacyPath)
 
+		if err := cfg.applyOverrides(); err != nil {
+			return nil, err
+		}
 		return &cfg, nil
 	}
 	if err != nil {
@@ -104,6 +123,9 @@ func Load(beadsDir string) (*Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing config: %w", err)
 	}
+	if err := cfg.applyOverrides(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
@@ -112,6 +134,7 @@ func (c *Config) Save(beadsDir string) error {
 	configPath := ConfigPath(beadsDir)
 
 	saved := *c
+	c.restoreOverridden(&saved)
 	if filepath.IsAbs(saved.DoltDataDir) {
 		saved.DoltDataDir = ""
 	}
@@ -415,6 +438,19 @@ func (c *Config) GetDoltDataDir() string {
 	return c.DoltDataDir
 }
 
+// GetDoltConnMaxLifetime returns the configured pool connection lifetime, or
+// 0 when unset or not a valid positive duration.
+func (c *Config) GetDoltConnMaxLifetime() time.Duration {
+	if c.DoltConnMaxLifetime == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.DoltConnMaxLifetime)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
 // GetDoltRemotesAPIPort returns the Dolt remotesapi port used for federation.
 // Checks BEADS_DOLT_REMOTESAPI_PORT env var first, then config, then default (8080).
 func (c *Config) GetDoltRemotesAPIPort() int {
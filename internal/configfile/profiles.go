@@ -0,0 +1,230 @@
+package configfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ProfileEnvVar selects the active profile from metadata.json. The --profile
+// flag sets it for the current process.
+const ProfileEnvVar = "BD_PROFILE"
+
+// Profile is a named set of overrides stored under "profiles" in
+// metadata.json. Any setting listed in OverridableSettings may appear at the
+// top level of a profile; the "config" object overrides config.yaml keys.
+//
+//	"profiles": {
+//	  "ci":        {"dolt_mode": "embedded", "config": {"no-push": true}},
+//	  "team-dolt": {"dolt_mode": "server", "dolt_server_host": "dolt.internal"}
+//	}
+type Profile struct {
+	// Settings holds the metadata.json overrides, keyed by JSON name.
+	Settings map[string]json.RawMessage
+	// Config holds config.yaml overrides, keyed by config key.
+	Config map[string]interface{}
+}
+
+// OverridableSettings lists the metadata.json settings that profiles and
+// BD_* environment variables may override. The environment variable for a
+// setting is EnvVarForSetting(name), e.g. dolt_server_host is overridden by
+// BD_DOLT_SERVER_HOST.
+var OverridableSettings = []string{
+	"dolt_mode",
+	"dolt_server_host",
+	"dolt_server_port",
+	"dolt_server_socket",
+	"dolt_server_user",
+	"dolt_database",
+	"dolt_server_tls",
+	"dolt_data_dir",
+	"dolt_remotesapi_port",
+	"dolt_max_open_conns",
+	"dolt_max_idle_conns",
+	"dolt_conn_max_lifetime",
+	"deletions_retention_days",
+	"stale_closed_issues_days",
+}
+
+// EnvVarForSetting returns the BD_* environment variable that overrides the
+// metadata.json setting name.
+func EnvVarForSetting(name string) string {
+	return "BD_" + strings.ToUpper(name)
+}
+
+// ActiveProfileName returns the profile selected by BD_PROFILE, or "".
+func ActiveProfileName() string {
+	return strings.TrimSpace(os.Getenv(ProfileEnvVar))
+}
+
+// ProfileNames returns the names of the profiles defined in c, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveProfile returns the name of the profile applied when c was loaded,
+// or "" when none was.
+func (c *Config) ActiveProfile() string {
+	return c.activeProfile
+}
+
+// GetProfile parses the profile called name.
+func (c *Config) GetProfile(name string) (*Profile, error) {
+	raw, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return nil, fmt.Errorf("profile %q not found: metadata.json defines no profiles", name)
+		}
+		return nil, fmt.Errorf("profile %q not found (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("parsing profile %q: %w", name, err)
+	}
+	p := &Profile{Settings: make(map[string]json.RawMessage)}
+	for key, value := range fields {
+		if key == "config" {
+			if err := json.Unmarshal(value, &p.Config); err != nil {
+				return nil, fmt.Errorf("parsing profile %q: config: %w", name, err)
+			}
+			continue
+		}
+		if !isOverridableSetting(key) {
+			return nil, fmt.Errorf("profile %q: %s cannot be set in a profile", name, key)
+		}
+		p.Settings[key] = value
+	}
+	return p, nil
+}
+
+// applyOverrides layers the active profile and then BD_* environment
+// variables over c. The on-disk values of every overridden setting are kept
+// so Save writes them back instead of the overrides.
+func (c *Config) applyOverrides() error {
+	overrides := make(map[string]json.RawMessage)
+	if name := ActiveProfileName(); name != "" {
+		p, err := c.GetProfile(name)
+		if err != nil {
+			return err
+		}
+		for key, value := range p.Settings {
+			overrides[key] = value
+		}
+		c.activeProfile = name
+	}
+	for _, key := range OverridableSettings {
+		value, ok := os.LookupEnv(EnvVarForSetting(key))
+		if !ok {
+			continue
+		}
+		raw, err := settingJSON(key, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvVarForSetting(key), err)
+		}
+		overrides[key] = raw
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	onDisk, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	overlay, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(overlay, c); err != nil {
+		return fmt.Errorf("applying overrides: %w", err)
+	}
+	var base Config
+	if err := json.Unmarshal(onDisk, &base); err != nil {
+		return err
+	}
+	applied := *c
+	c.onDisk, c.applied = &base, &applied
+	c.overridden = make([]string, 0, len(overrides))
+	for key := range overrides {
+		c.overridden = append(c.overridden, key)
+	}
+	return nil
+}
+
+// restoreOverridden resets every setting of saved that still holds its
+// override value to the value loaded from disk, so Save never persists a
+// profile or environment override.
+func (c *Config) restoreOverridden(saved *Config) {
+	if c.onDisk == nil {
+		return
+	}
+	applied := reflect.ValueOf(c.applied).Elem()
+	out := reflect.ValueOf(saved).Elem()
+	base := reflect.ValueOf(c.onDisk).Elem()
+	for _, key := range c.overridden {
+		i := settingFieldIndex(key)
+		if i < 0 {
+			continue
+		}
+		if reflect.DeepEqual(out.Field(i).Interface(), applied.Field(i).Interface()) {
+			out.Field(i).Set(base.Field(i))
+		}
+	}
+}
+
+// settingJSON converts an environment variable value for setting key into
+// the JSON value of the matching Config field.
+func settingJSON(key, value string) (json.RawMessage, error) {
+	i := settingFieldIndex(key)
+	if i < 0 {
+		return nil, fmt.Errorf("unknown setting %s", key)
+	}
+	value = strings.TrimSpace(value)
+	switch reflect.TypeOf(Config{}).Field(i).Type.Kind() {
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", value)
+		}
+		return json.Marshal(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", value)
+		}
+		return json.Marshal(b)
+	default:
+		return json.Marshal(value)
+	}
+}
+
+// settingFieldIndex returns the index of the Config field whose JSON name is
+// key, or -1.
+func settingFieldIndex(key string) int {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func isOverridableSetting(key string) bool {
+	for _, k := range OverridableSettings {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package configfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProfilesMetadata(t *testing.T) string {
+	t.Helper()
+	beadsDir := t.TempDir()
+	data := `{
+  "database": "dolt",
+  "dolt_mode": "embedded",
+  "dolt_server_host": "10.0.0.1",
+  "profiles": {
+    "ci": {"dolt_server_port": 3400, "config": {"no-push": true}},
+    "team-dolt": {"dolt_mode": "server", "dolt_server_host": "dolt.internal"}
+  }
+}`
+	if err := os.WriteFile(filepath.Join(beadsDir, ConfigFileName), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return beadsDir
+}
+
+func TestLoadAppliesProfile(t *testing.T) {
+	beadsDir := writeProfilesMetadata(t)
+
+	t.Run("no profile", func(t *testing.T) {
+		cfg, err := Load(beadsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.DoltMode != "embedded" || cfg.ActiveProfile() != "" {
+			t.Errorf("DoltMode = %q, ActiveProfile = %q", cfg.DoltMode, cfg.ActiveProfile())
+		}
+	})
+
+	t.Run("team-dolt", func(t *testing.T) {
+		t.Setenv(ProfileEnvVar, "team-dolt")
+		cfg, err := Load(beadsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.DoltMode != "server" || cfg.DoltServerHost != "dolt.internal" {
+			t.Errorf("profile not applied: mode=%q host=%q", cfg.DoltMode, cfg.DoltServerHost)
+		}
+		if cfg.ActiveProfile() != "team-dolt" {
+			t.Errorf("ActiveProfile() = %q", cfg.ActiveProfile())
+		}
+	})
+
+	t.Run("config keys", func(t *testing.T) {
+		t.Setenv(ProfileEnvVar, "ci")
+		cfg, err := Load(beadsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := cfg.GetProfile("ci")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Config["no-push"] != true || cfg.DoltServerPort != 3400 {
+			t.Errorf("unexpected ci profile: %+v port=%d", p.Config, cfg.DoltServerPort)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		t.Setenv(ProfileEnvVar, "missing")
+		_, err := Load(beadsDir)
+		if err == nil || !strings.Contains(err.Error(), "available: ci, team-dolt") {
+			t.Errorf("expected unknown profile error, got %v", err)
+		}
+	})
+}
+
+func TestGetProfileRejectsUnknownSetting(t *testing.T) {
+	cfg := &Config{Profiles: map[string]json.RawMessage{
+		"bad": json.RawMessage(`{"project_id": "x"}`),
+	}}
+	if _, err := cfg.GetProfile("bad"); err == nil {
+		t.Error("expected project_id to be rejected in a profile")
+	}
+}
+
+func TestLoadAppliesSettingEnvVars(t *testing.T) {
+	beadsDir := writeProfilesMetadata(t)
+	t.Setenv(ProfileEnvVar, "team-dolt")
+	t.Setenv("BD_DOLT_SERVER_HOST", "env.internal")
+	t.Setenv("BD_DOLT_SERVER_PORT", "3500")
+	t.Setenv("BD_DOLT_SERVER_TLS", "true")
+
+	cfg, err := Load(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DoltServerHost != "env.internal" {
+		t.Errorf("env should win over profile: host = %q", cfg.DoltServerHost)
+	}
+	if cfg.DoltServerPort != 3500 || !cfg.DoltServerTLS {
+		t.Errorf("port = %d, tls = %v", cfg.DoltServerPort, cfg.DoltServerTLS)
+	}
+
+	t.Setenv("BD_DOLT_SERVER_PORT", "not-a-port")
+	if _, err := Load(beadsDir); err == nil || !strings.Contains(err.Error(), "BD_DOLT_SERVER_PORT") {
+		t.Errorf("expected invalid port error, got %v", err)
+	}
+}
+
+func TestSaveKeepsOverridesOutOfMetadata(t *testing.T) {
+	beadsDir := writeProfilesMetadata(t)
+	t.Setenv(ProfileEnvVar, "team-dolt")
+	t.Setenv("BD_DOLT_SERVER_USER", "ci-bot")
+
+	cfg, err := Load(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.DoltServerHost = "edited.internal"
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(beadsDir, ConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved Config
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.DoltMode != "embedded" {
+		t.Errorf("profile dolt_mode leaked into metadata.json: %q", saved.DoltMode)
+	}
+	if saved.DoltServerUser != "" {
+		t.Errorf("BD_DOLT_SERVER_USER leaked into metadata.json: %q", saved.DoltServerUser)
+	}
+	if saved.DoltServerHost != "edited.internal" {
+		t.Errorf("explicit edit lost: host = %q", saved.DoltServerHost)
+	}
+	if len(saved.Profiles) != 2 {
+		t.Errorf("profiles not preserved: %v", saved.ProfileNames())
+	}
+}
@@ -304,10 +304,18 @@ type Config struct {
 
 // State holds runtime information about a managed server.
 type State struct {
-	Running bool   `json:"running"`
-	PID     int    `json:"pid"`
-	Port    int    `json:"port"`
-	DataDir string `json:"data_dir"`
+	Running bool    `json:"running"`
+	PID     int     `json:"pid"`
+	Port    int     `json:"port"`
+	DataDir string  `json:"data_dir"`
+	Health  *Health `json:"health,omitempty"`
+}
+
+// Health is the result of probing a running server with a trivial query.
+type Health struct {
+	OK        bool   `json:"ok"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
 }
 
 // file paths within .beads/
@@ -1208,6 +1216,35 @@ func KillStaleServers(beadsDir string) ([]int, error) {
 	)
 }
 
+// CheckHealth runs SELECT 1 against the server at host:port. A process that
+// holds the PID file but no longer answers queries (wedged, or still
+// replaying its journal) otherwise looks identical to a healthy one.
+func CheckHealth(host string, port int, user, password string) *Health {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dsn := doltutil.ServerDSN{
+		Host:     host,
+		Port:     port,
+		User:     user,
+		Password: password,
+		Timeout:  5 * time.Second,
+	}.String()
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return &Health{Error: err.Error()}
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	start := time.Now()
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return &Health{Error: err.Error()}
+	}
+	return &Health{OK: true, LatencyMS: time.Since(start).Milliseconds()}
+}
+
 // waitForReady polls TCP until the server accepts connections.
 func waitForReady(host string, port int, timeout time.Duration) error {
 	addr := net.JoinHostPort(host, strconv.Itoa(port))
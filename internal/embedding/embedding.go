@@ -0,0 +1,111 @@
+// Package embedding computes vector embeddings of issue text for similarity
+// search (bd similar). Providers are pluggable: a built-in local model that
+// needs no network, an Ollama server, or any OpenAI-compatible embeddings API.
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Provider names accepted by ai.embeddings.provider.
+const (
+	ProviderLocal  = "local"
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+)
+
+// defaultHTTPTimeout bounds a single embeddings request to a remote provider.
+const defaultHTTPTimeout = 60 * time.Second
+
+// Provider turns texts into embedding vectors. Embed returns one vector per
+// input text, in input order; all vectors from one provider and model have
+// the same length.
+type Provider interface {
+	// Name is the provider name, e.g. "local" or "ollama".
+	Name() string
+	// Model identifies the embedding model. Vectors from different models
+	// are not comparable, so stored vectors are keyed by provider and model.
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Options selects and configures a provider. Empty fields fall back to the
+// ai.embeddings.* config keys, then to per-provider defaults.
+type Options struct {
+	Provider string
+	Model    string
+	URL      string
+	APIKey   string
+}
+
+// NewProvider returns the provider described by opts and the
+// ai.embeddings.provider, ai.embeddings.model, ai.embeddings.url, and
+// ai.embeddings.api_key config keys.
+func NewProvider(opts Options) (Provider, error) {
+	name := firstNonEmpty(opts.Provider, config.GetString("ai.embeddings.provider"), ProviderLocal)
+	model := firstNonEmpty(opts.Model, config.GetString("ai.embeddings.model"))
+	url := strings.TrimSuffix(firstNonEmpty(opts.URL, config.GetString("ai.embeddings.url")), "/")
+	client := &http.Client{Timeout: defaultHTTPTimeout}
+
+	switch strings.ToLower(name) {
+	case ProviderLocal:
+		return NewLocalProvider(model)
+	case ProviderOllama:
+		return &ollamaProvider{
+			url:    firstNonEmpty(url, "http://localhost:11434"),
+			model:  firstNonEmpty(model, "nomic-embed-text"),
+			client: client,
+		}, nil
+	case ProviderOpenAI:
+		// OPENAI_API_KEY takes precedence over config, like ANTHROPIC_API_KEY
+		// over ai.api_key.
+		apiKey := firstNonEmpty(opts.APIKey, os.Getenv("OPENAI_API_KEY"), config.GetString("ai.embeddings.api_key"))
+		if apiKey == "" {
+			return nil, fmt.Errorf("openai embeddings require OPENAI_API_KEY environment variable or ai.embeddings.api_key in config")
+		}
+		return &openAIProvider{
+			url:    firstNonEmpty(url, "https://api.openai.com/v1"),
+			model:  firstNonEmpty(model, "text-embedding-3-small"),
+			apiKey: apiKey,
+			client: client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (use: %s, %s, %s)", name, ProviderLocal, ProviderOllama, ProviderOpenAI)
+	}
+}
+
+// Cosine returns the cosine similarity of a and b, or 0 when their lengths
+// differ or either is all zeros.
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, magA, magB float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		magA += x * x
+		magB += y * y
+	}
+	if magA == 0 || magB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(magA) * math.Sqrt(magB))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
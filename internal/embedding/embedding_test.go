@@ -0,0 +1,147 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestLocalProviderRanksRelatedTextHigher(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewLocalProvider("")
+	if err != nil {
+		t.Fatalf("NewLocalProvider: %v", err)
+	}
+	if p.Model() != "hash-256" {
+		t.Errorf("Model() = %q, want hash-256", p.Model())
+	}
+
+	vecs, err := p.Embed(context.Background(), []string{
+		"Login fails with expired session token",
+		"login failing when the session token expired",
+		"Add dark mode to the settings page",
+	})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs[0]) != 256 {
+		t.Fatalf("len(vector) = %d, want 256", len(vecs[0]))
+	}
+	related, unrelated := Cosine(vecs[0], vecs[1]), Cosine(vecs[0], vecs[2])
+	if related <= unrelated {
+		t.Errorf("related similarity %.3f <= unrelated %.3f", related, unrelated)
+	}
+
+	again, _ := p.Embed(context.Background(), []string{"Login fails with expired session token"})
+	if !reflect.DeepEqual(again[0], vecs[0]) {
+		t.Error("local embeddings are not deterministic")
+	}
+}
+
+func TestNewLocalProviderRejectsBadModel(t *testing.T) {
+	t.Parallel()
+
+	for _, model := range []string{"nomic-embed-text", "hash-x", "hash-4"} {
+		if _, err := NewLocalProvider(model); err == nil {
+			t.Errorf("NewLocalProvider(%q) succeeded, want error", model)
+		}
+	}
+}
+
+func TestOllamaProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			t.Errorf("path = %q, want /api/embed", r.URL.Path)
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "nomic-embed-text" || len(req.Input) != 2 {
+			t.Errorf("request = %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"embeddings":[[1,0],[0,1]]}`))
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(Options{Provider: ProviderOllama, URL: srv.URL + "/"})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	vecs, err := p.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if want := [][]float32{{1, 0}, {0, 1}}; !reflect.DeepEqual(vecs, want) {
+		t.Errorf("vectors = %v, want %v", vecs, want)
+	}
+}
+
+func TestOpenAIProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("path = %q, want /v1/embeddings", r.URL.Path)
+		}
+		// Out of order on purpose: results are placed by index.
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(Options{Provider: ProviderOpenAI, URL: srv.URL + "/v1", APIKey: "sk-test"})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	if p.Model() != "text-embedding-3-small" {
+		t.Errorf("Model() = %q", p.Model())
+	}
+	vecs, err := p.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if want := [][]float32{{1, 0}, {0, 1}}; !reflect.DeepEqual(vecs, want) {
+		t.Errorf("vectors = %v, want %v", vecs, want)
+	}
+}
+
+func TestProviderHTTPError(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(Options{Provider: ProviderOllama, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	if _, err := p.Embed(context.Background(), []string{"a"}); err == nil {
+		t.Error("Embed succeeded, want error")
+	}
+}
+
+func TestCosine(t *testing.T) {
+	t.Parallel()
+
+	if got := Cosine([]float32{1, 0}, []float32{1, 0}); got != 1 {
+		t.Errorf("identical = %v, want 1", got)
+	}
+	if got := Cosine([]float32{1, 0}, []float32{0, 1}); got != 0 {
+		t.Errorf("orthogonal = %v, want 0", got)
+	}
+	if got := Cosine([]float32{1, 0}, []float32{1, 0, 0}); got != 0 {
+		t.Errorf("mismatched lengths = %v, want 0", got)
+	}
+}
@@ -0,0 +1,101 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultLocalDims is the vector length of the default local model, hash-256.
+const defaultLocalDims = 256
+
+// localProvider embeds text with the hashing trick: every word, word bigram,
+// and character trigram is hashed into one of dims buckets with a hashed
+// sign. It needs no network or model download and is deterministic, so its
+// vectors never go stale, but it only captures lexical overlap; use ollama
+// or openai for semantic similarity.
+type localProvider struct {
+	dims int
+}
+
+// NewLocalProvider returns the built-in hashing provider. model is
+// "hash-<dims>" (e.g. "hash-512"); empty selects hash-256.
+func NewLocalProvider(model string) (Provider, error) {
+	if model == "" {
+		return &localProvider{dims: defaultLocalDims}, nil
+	}
+	dims, err := strconv.Atoi(strings.TrimPrefix(model, "hash-"))
+	if !strings.HasPrefix(model, "hash-") || err != nil || dims < 16 || dims > 8192 {
+		return nil, fmt.Errorf("invalid local embeddings model %q (use hash-<dims>, 16-8192)", model)
+	}
+	return &localProvider{dims: dims}, nil
+}
+
+func (p *localProvider) Name() string  { return ProviderLocal }
+func (p *localProvider) Model() string { return "hash-" + strconv.Itoa(p.dims) }
+
+func (p *localProvider) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = p.embed(text)
+	}
+	return vectors, nil
+}
+
+func (p *localProvider) embed(text string) []float32 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	counts := make(map[string]float64)
+	prev := ""
+	for _, w := range words {
+		if len([]rune(w)) < 2 {
+			prev = ""
+			continue
+		}
+		counts["w:"+w]++
+		if prev != "" {
+			counts["b:"+prev+" "+w]++
+		}
+		prev = w
+		// Trigrams of the padded word let inflections (fail/failing) overlap.
+		r := []rune(" " + w + " ")
+		for j := 0; j+3 <= len(r); j++ {
+			counts["t:"+string(r[j:j+3])] += 0.5
+		}
+	}
+
+	vec := make([]float64, p.dims)
+	for feature, n := range counts {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		weight := 1 + math.Log(n) // dampen repeated terms
+		if n < 1 {
+			weight = n
+		}
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vec[sum%uint64(p.dims)] += weight
+	}
+
+	var norm float64
+	for _, x := range vec {
+		norm += x * x
+	}
+	out := make([]float32, p.dims)
+	if norm == 0 {
+		return out
+	}
+	norm = math.Sqrt(norm)
+	for i, x := range vec {
+		out[i] = float32(x / norm)
+	}
+	return out
+}
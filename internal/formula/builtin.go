@@ -0,0 +1,58 @@
+package formula
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// BuiltinSource is the Source recorded on formulas that ship with bd.
+const BuiltinSource = "builtin"
+
+//go:embed builtin/*.formula.toml
+var builtinFS embed.FS
+
+// Builtins returns the molecule templates compiled into bd, sorted by name.
+// Formulas found on the search paths take precedence over these.
+func Builtins() ([]*Formula, error) {
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, err
+	}
+	parser := NewParser()
+	var formulas []*Formula
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), FormulaExtTOML) {
+			continue
+		}
+		data, err := builtinFS.ReadFile(path.Join("builtin", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseTOML(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse builtin %s: %w", entry.Name(), err)
+		}
+		f.Source = BuiltinSource
+		SetSourceInfo(f)
+		formulas = append(formulas, f)
+	}
+	sort.Slice(formulas, func(i, j int) bool { return formulas[i].Formula < formulas[j].Formula })
+	return formulas, nil
+}
+
+// LoadBuiltin returns the built-in formula with the given name.
+func LoadBuiltin(name string) (*Formula, error) {
+	formulas, err := Builtins()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range formulas {
+		if f.Formula == name {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("no built-in formula %q", name)
+}
@@ -0,0 +1,37 @@
+package formula
+
+import "testing"
+
+func TestBuiltinsAreValid(t *testing.T) {
+	builtins, err := Builtins()
+	if err != nil {
+		t.Fatalf("Builtins() error: %v", err)
+	}
+	if len(builtins) == 0 {
+		t.Fatal("expected at least one built-in formula")
+	}
+	for _, f := range builtins {
+		if f.Source != BuiltinSource {
+			t.Errorf("%s: Source = %q, want %q", f.Formula, f.Source, BuiltinSource)
+		}
+		if err := f.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v", f.Formula, err)
+		}
+		if def := f.Vars["title"]; def == nil || !def.Required {
+			t.Errorf("%s: built-ins must require a title var for the root epic", f.Formula)
+		}
+	}
+}
+
+func TestLoadBuiltin(t *testing.T) {
+	f, err := LoadBuiltin("mol-feature")
+	if err != nil {
+		t.Fatalf("LoadBuiltin(mol-feature) error: %v", err)
+	}
+	if len(f.Steps) == 0 {
+		t.Error("mol-feature has no steps")
+	}
+	if _, err := LoadBuiltin("mol-does-not-exist"); err == nil {
+		t.Error("expected error for unknown built-in")
+	}
+}
@@ -15,13 +15,21 @@ const (
 	EventCreate = "create"
 	EventUpdate = "update"
 	EventClose  = "close"
+	// EventBlocking fires for the blocker when another issue starts
+	// depending on it through a blocking edge.
+	EventBlocking = "blocking"
+	// EventPatrolMissed fires when bd patrol run finds that a patrol's
+	// previous wisp is still open, or that whole cycles were skipped.
+	EventPatrolMissed = "patrol_missed"
 )
 
 // Hook file names
 const (
-	HookOnCreate = "on_create"
-	HookOnUpdate = "on_update"
-	HookOnClose  = "on_close"
+	HookOnCreate       = "on_create"
+	HookOnUpdate       = "on_update"
+	HookOnClose        = "on_close"
+	HookOnBlocking     = "on_blocking"
+	HookOnPatrolMissed = "on_patrol_missed"
 )
 
 // Runner handles hook execution
@@ -130,6 +138,10 @@ func eventToHook(event string) string {
 		return HookOnUpdate
 	case EventClose:
 		return HookOnClose
+	case EventBlocking:
+		return HookOnBlocking
+	case EventPatrolMissed:
+		return HookOnPatrolMissed
 	default:
 		return ""
 	}
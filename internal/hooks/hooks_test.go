@@ -44,6 +44,8 @@ func TestEventToHook(t *testing.T) {
 		{EventCreate, HookOnCreate},
 		{EventUpdate, HookOnUpdate},
 		{EventClose, HookOnClose},
+		{EventBlocking, HookOnBlocking},
+		{EventPatrolMissed, HookOnPatrolMissed},
 		{"unknown", ""},
 		{"", ""},
 	}
@@ -381,6 +383,8 @@ func TestAllHookEvents(t *testing.T) {
 		{EventCreate, HookOnCreate},
 		{EventUpdate, HookOnUpdate},
 		{EventClose, HookOnClose},
+		{EventBlocking, HookOnBlocking},
+		{EventPatrolMissed, HookOnPatrolMissed},
 	}
 
 	for _, e := range events {
@@ -0,0 +1,48 @@
+package jsonl
+
+import "encoding/json"
+
+// migration upgrades a record written at schema version from to from+1.
+// It returns false to drop the record.
+type migration struct {
+	from  int
+	apply func(rec *Record) bool
+}
+
+// migrations are applied in order to every record whose file version is at
+// or below each step's from. Append a step when bumping SchemaVersion.
+var migrations = []migration{
+	{from: 0, apply: migrateV0ToV1},
+}
+
+// migrate upgrades rec from version to SchemaVersion. Records from files
+// newer than SchemaVersion pass through unchanged.
+func migrate(rec *Record, version int) bool {
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		if !m.apply(rec) {
+			return false
+		}
+	}
+	return true
+}
+
+// migrateV0ToV1 handles headerless exports from before versioning:
+//   - v0.35–v0.37 exported "wisp" (bool), renamed to "ephemeral" in v0.38+.
+//   - Pre-v0.50 exported deleted issues with status "tombstone", which is not
+//     a real status and cannot be re-imported; they are dropped.
+func migrateV0ToV1(rec *Record) bool {
+	if rec.String("status") == "tombstone" {
+		return false
+	}
+	if raw, ok := rec.Fields["wisp"]; ok {
+		var wisp bool
+		if json.Unmarshal(raw, &wisp) == nil && wisp {
+			rec.set("ephemeral", json.RawMessage("true"))
+		}
+		rec.remove("wisp")
+	}
+	return true
+}
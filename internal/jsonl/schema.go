@@ -0,0 +1,235 @@
+// Package jsonl defines the versioned beads JSONL interchange format and
+// reads it back, migrating records from older schema versions so exports
+// written by any earlier bd load cleanly into the current one.
+//
+// A file may start with a header line carrying the schema version:
+//
+//	{"_schema":"beads-jsonl/1","_bd_version":"1.0.5"}
+//
+// Files without a header predate versioning and are read as version 0.
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SchemaName is the format name in the header's _schema field.
+const SchemaName = "beads-jsonl"
+
+// SchemaVersion is the JSONL schema version this build writes. Bump it, and
+// add a migration from the previous version, whenever the record format
+// changes in a way older readers or newer writers need to know about.
+const SchemaVersion = 1
+
+// maxLineSize bounds a single JSONL line (large descriptions, comments).
+const maxLineSize = 64 * 1024 * 1024
+
+// Header is the optional first line of a JSONL export.
+type Header struct {
+	Schema    string `json:"_schema"`
+	BDVersion string `json:"_bd_version,omitempty"`
+}
+
+// NewHeader returns the header for files written by this build.
+func NewHeader(bdVersion string) Header {
+	return Header{Schema: fmt.Sprintf("%s/%d", SchemaName, SchemaVersion), BDVersion: bdVersion}
+}
+
+// Version parses the schema version out of h.Schema.
+func (h Header) Version() (int, error) {
+	name, ver, ok := strings.Cut(h.Schema, "/")
+	if !ok || name != SchemaName {
+		return 0, fmt.Errorf("unrecognized JSONL schema %q", h.Schema)
+	}
+	n, err := strconv.Atoi(ver)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid JSONL schema version in %q", h.Schema)
+	}
+	return n, nil
+}
+
+// WriteHeader writes the header line for this build to w.
+func WriteHeader(w io.Writer, bdVersion string) error {
+	data, err := json.Marshal(NewHeader(bdVersion))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// ErrSchemaAhead reports a file written with a newer schema than this
+// build understands. Readers still load it best-effort: unknown fields are
+// ignored, so callers usually warn rather than fail.
+var ErrSchemaAhead = errors.New("JSONL schema is newer than this bd supports")
+
+// CheckVersion returns an error wrapping ErrSchemaAhead when version is
+// newer than SchemaVersion.
+func CheckVersion(version int, bdVersion string) error {
+	if version <= SchemaVersion {
+		return nil
+	}
+	by := ""
+	if bdVersion != "" {
+		by = " (written by bd " + bdVersion + ")"
+	}
+	return fmt.Errorf("%w: file is %s/%d%s, this bd reads up to %s/%d; upgrade bd",
+		ErrSchemaAhead, SchemaName, version, by, SchemaName, SchemaVersion)
+}
+
+// ReadFileHeader returns the header and schema version of the JSONL file at
+// path. A file without a header returns (nil, 0, nil).
+func ReadFileHeader(path string) (*Header, int, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: caller-controlled workspace path
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	r := NewReader(f)
+	if _, err := r.Next(); err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	return r.Header, r.Version, nil
+}
+
+// Record is one JSONL line decoded into its top-level fields.
+type Record struct {
+	Fields map[string]json.RawMessage
+	raw    []byte
+	dirty  bool
+}
+
+// Type returns the record's _type discriminator ("issue", "memory"), or ""
+// for untyped records, which older exports used for issues.
+func (r *Record) Type() string {
+	var t string
+	if raw, ok := r.Fields["_type"]; ok {
+		_ = json.Unmarshal(raw, &t)
+	}
+	return t
+}
+
+// String returns the string value of field, or "" if absent or not a string.
+func (r *Record) String(field string) string {
+	var s string
+	if raw, ok := r.Fields[field]; ok {
+		_ = json.Unmarshal(raw, &s)
+	}
+	return s
+}
+
+// Decode unmarshals the (migrated) record into v.
+func (r *Record) Decode(v interface{}) error {
+	if r.dirty {
+		data, err := json.Marshal(r.Fields)
+		if err != nil {
+			return err
+		}
+		r.raw, r.dirty = data, false
+	}
+	return json.Unmarshal(r.raw, v)
+}
+
+func (r *Record) set(field string, value json.RawMessage) {
+	r.Fields[field] = value
+	r.dirty = true
+}
+
+func (r *Record) remove(field string) {
+	if _, ok := r.Fields[field]; ok {
+		delete(r.Fields, field)
+		r.dirty = true
+	}
+}
+
+// Reader streams records from a JSONL file, skipping blank lines and the
+// header and migrating each record up to SchemaVersion.
+type Reader struct {
+	// Header is the file's header, or nil for a headerless (version 0) file.
+	// Set once the first line has been read.
+	Header *Header
+	// Version is the file's schema version.
+	Version int
+
+	scanner *bufio.Scanner
+	line    int
+	offset  int64
+	started bool
+}
+
+// NewReader returns a Reader over r.
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), maxLineSize)
+	return &Reader{scanner: scanner}
+}
+
+// Line returns the 1-based line number of the last record returned.
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Offset returns the number of input bytes consumed so far, i.e. the byte
+// position just past the last line read.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// ResumeAt tells a Reader whose input has already been positioned at a
+// previously recorded Offset where it is in the file: the line number and
+// schema version in effect at that point. Call it before the first Next.
+func (r *Reader) ResumeAt(line int, offset int64, version int) {
+	r.line, r.offset, r.Version, r.started = line, offset, version, true
+}
+
+// Next returns the next record, or io.EOF when the input is exhausted.
+// Records a migration drops (such as legacy tombstones) are skipped.
+func (r *Reader) Next() (*Record, error) {
+	for r.scanner.Scan() {
+		r.line++
+		r.offset += int64(len(r.scanner.Bytes())) + 1
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		rec := &Record{raw: []byte(line)}
+		if err := json.Unmarshal(rec.raw, &rec.Fields); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse JSONL line: %w", r.line, err)
+		}
+
+		if _, isHeader := rec.Fields["_schema"]; isHeader {
+			if r.started {
+				continue // Concatenated exports repeat the header; keep the first.
+			}
+			r.started = true
+			var h Header
+			if err := json.Unmarshal(rec.raw, &h); err != nil {
+				return nil, fmt.Errorf("line %d: invalid JSONL header: %w", r.line, err)
+			}
+			v, err := h.Version()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", r.line, err)
+			}
+			r.Header, r.Version = &h, v
+			continue
+		}
+		r.started = true
+
+		if !migrate(rec, r.Version) {
+			continue
+		}
+		return rec, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan JSONL: %w", err)
+	}
+	return nil, io.EOF
+}
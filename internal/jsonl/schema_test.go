@@ -0,0 +1,173 @@
+package jsonl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testIssue struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Ephemeral bool   `json:"ephemeral"`
+}
+
+func readAll(t *testing.T, input string) (*Reader, []*Record) {
+	t.Helper()
+	r := NewReader(strings.NewReader(input))
+	var recs []*Record
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return r, recs
+		}
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func TestReaderHeaderRoundTrip(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, "1.0.5"); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString(`{"id":"bd-1","_type":"issue","wisp":true}` + "\n")
+	buf.WriteString(`{"_type":"memory","key":"k","value":"v"}` + "\n")
+
+	r, recs := readAll(t, buf.String())
+	if r.Version != SchemaVersion || r.Header == nil || r.Header.BDVersion != "1.0.5" {
+		t.Fatalf("header = %+v version %d", r.Header, r.Version)
+	}
+	if len(recs) != 2 || recs[0].Type() != "issue" || recs[1].Type() != "memory" {
+		t.Fatalf("records = %+v", recs)
+	}
+	// Current-version records are not migrated.
+	var issue testIssue
+	if err := recs[0].Decode(&issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Ephemeral {
+		t.Error("v1 record should not get the v0 wisp migration")
+	}
+}
+
+func TestReaderMigratesHeaderless(t *testing.T) {
+	t.Parallel()
+	input := strings.Join([]string{
+		`{"id":"bd-1","status":"open","wisp":true}`,
+		``,
+		`{"id":"bd-2","status":"tombstone"}`,
+		`{"id":"bd-3","status":"closed","wisp":false}`,
+	}, "\n")
+
+	r, recs := readAll(t, input)
+	if r.Header != nil || r.Version != 0 {
+		t.Fatalf("headerless file: header %+v version %d", r.Header, r.Version)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want tombstone dropped", len(recs))
+	}
+	var first, second testIssue
+	if err := recs[0].Decode(&first); err != nil {
+		t.Fatal(err)
+	}
+	if err := recs[1].Decode(&second); err != nil {
+		t.Fatal(err)
+	}
+	if !first.Ephemeral || second.Ephemeral || second.ID != "bd-3" {
+		t.Errorf("migrated = %+v, %+v", first, second)
+	}
+	if _, ok := recs[0].Fields["wisp"]; ok {
+		t.Error("wisp field should be removed")
+	}
+	if r.Line() != 4 {
+		t.Errorf("Line() = %d, want 4", r.Line())
+	}
+}
+
+func TestReaderNewerSchema(t *testing.T) {
+	t.Parallel()
+	input := `{"_schema":"beads-jsonl/99","_bd_version":"9.0.0"}` + "\n" + `{"id":"bd-1","future_field":1}`
+	r, recs := readAll(t, input)
+	if r.Version != 99 || len(recs) != 1 {
+		t.Fatalf("version %d, %d records", r.Version, len(recs))
+	}
+	err := CheckVersion(r.Version, r.Header.BDVersion)
+	if !errors.Is(err, ErrSchemaAhead) || !strings.Contains(err.Error(), "9.0.0") {
+		t.Errorf("CheckVersion = %v", err)
+	}
+	if err := CheckVersion(SchemaVersion, ""); err != nil {
+		t.Errorf("CheckVersion(current) = %v", err)
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	t.Parallel()
+	for name, input := range map[string]string{
+		"bad json":    `{"id":`,
+		"bad schema":  `{"_schema":"other/1"}`,
+		"bad version": `{"_schema":"beads-jsonl/x"}`,
+	} {
+		if _, err := NewReader(strings.NewReader(input)).Next(); err == nil || err == io.EOF {
+			t.Errorf("%s: Next() = %v, want error", name, err)
+		}
+	}
+}
+
+func TestReadFileHeader(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	versioned := filepath.Join(dir, "v.jsonl")
+	var buf bytes.Buffer
+	_ = WriteHeader(&buf, "1.0.5")
+	if err := os.WriteFile(versioned, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	h, v, err := ReadFileHeader(versioned)
+	if err != nil || h == nil || v != SchemaVersion {
+		t.Errorf("ReadFileHeader(versioned) = %+v, %d, %v", h, v, err)
+	}
+
+	legacy := filepath.Join(dir, "legacy.jsonl")
+	if err := os.WriteFile(legacy, []byte(`{"id":"bd-1"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	h, v, err = ReadFileHeader(legacy)
+	if err != nil || h != nil || v != 0 {
+		t.Errorf("ReadFileHeader(legacy) = %+v, %d, %v", h, v, err)
+	}
+}
+
+func TestReaderResumeAt(t *testing.T) {
+	t.Parallel()
+	input := `{"_schema":"beads-jsonl/1"}` + "\n" + `{"id":"bd-1"}` + "\n" + `{"id":"bd-2"}` + "\n"
+	r := NewReader(strings.NewReader(input))
+	if _, err := r.Next(); err != nil {
+		t.Fatal(err)
+	}
+	line, offset := r.Line(), r.Offset()
+	if line != 2 || int(offset) != strings.Index(input, `{"id":"bd-2"}`) {
+		t.Fatalf("after first record: line %d offset %d", line, offset)
+	}
+
+	resumed := NewReader(strings.NewReader(input[offset:]))
+	resumed.ResumeAt(line, offset, r.Version)
+	rec, err := resumed.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.String("id") != "bd-2" || resumed.Line() != 3 || resumed.Version != SchemaVersion {
+		t.Errorf("resumed at %q line %d version %d", rec.String("id"), resumed.Line(), resumed.Version)
+	}
+	if resumed.Offset() != int64(len(input)) {
+		t.Errorf("Offset() = %d, want %d", resumed.Offset(), len(input))
+	}
+}
@@ -0,0 +1,120 @@
+// Package offline records write commands that could not reach the Dolt
+// server so they can be replayed once it is reachable again.
+package offline
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// JournalFileName is the queue file inside .beads/. It is per-machine and
+// gitignored.
+const JournalFileName = "offline-queue.jsonl"
+
+// Entry is one queued command invocation.
+type Entry struct {
+	Args     []string  `json:"args"`            // argv after the binary name
+	Dir      string    `json:"dir"`             // working directory the command ran in
+	Actor    string    `json:"actor,omitempty"` // actor at queue time, restored on replay
+	QueuedAt time.Time `json:"queued_at"`
+	Reason   string    `json:"reason,omitempty"` // connection error that caused queuing
+}
+
+// Journal is an append-only JSONL queue of entries.
+type Journal struct {
+	path string
+}
+
+// Open returns the journal for beadsDir. The file is created on first Append.
+func Open(beadsDir string) *Journal {
+	return &Journal{path: filepath.Join(beadsDir, JournalFileName)}
+}
+
+// Path returns the journal file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Append adds e to the end of the journal.
+func (j *Journal) Append(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode offline entry: %w", err)
+	}
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) // #nosec G304 -- path is inside .beads/
+	if err != nil {
+		return fmt.Errorf("open offline queue: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write offline queue: %w", err)
+	}
+	return f.Close()
+}
+
+// Entries returns the queued entries in the order they were appended. A
+// missing journal is an empty queue.
+func (j *Journal) Entries() ([]Entry, error) {
+	f, err := os.Open(j.path) // #nosec G304 -- path is inside .beads/
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open offline queue: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", j.path, line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read offline queue: %w", err)
+	}
+	return entries, nil
+}
+
+// Replace atomically rewrites the journal to hold exactly entries, removing
+// the file when none remain.
+func (j *Journal) Replace(entries []Entry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove offline queue: %w", err)
+		}
+		return nil
+	}
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600) // #nosec G304 -- path is inside .beads/
+	if err != nil {
+		return fmt.Errorf("rewrite offline queue: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("rewrite offline queue: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rewrite offline queue: %w", err)
+	}
+	return os.Rename(tmp, j.path)
+}
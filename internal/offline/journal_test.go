@@ -0,0 +1,52 @@
+package offline
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestJournalAppendAndReplace(t *testing.T) {
+	j := Open(t.TempDir())
+
+	entries, err := j.Entries()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("empty journal: got %v, %v", entries, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, args := range [][]string{
+		{"create", "Fix login", "-p", "1"},
+		{"close", "bd-abc", "--reason", "done"},
+	} {
+		if err := j.Append(Entry{Args: args, Dir: "/repo", Actor: "alice", QueuedAt: now}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	entries, err = j.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Args[0] != "create" || entries[1].Args[1] != "bd-abc" {
+		t.Fatalf("entries out of order or missing: %+v", entries)
+	}
+	if entries[0].Actor != "alice" || !entries[0].QueuedAt.Equal(now) {
+		t.Errorf("entry fields not round-tripped: %+v", entries[0])
+	}
+
+	if err := j.Replace(entries[1:]); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	entries, _ = j.Entries()
+	if len(entries) != 1 || entries[0].Args[0] != "close" {
+		t.Fatalf("after Replace: %+v", entries)
+	}
+
+	if err := j.Replace(nil); err != nil {
+		t.Fatalf("Replace(nil): %v", err)
+	}
+	if _, err := os.Stat(j.Path()); !os.IsNotExist(err) {
+		t.Errorf("journal file should be removed when empty, stat err = %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package peerdiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ServiceName is the DNS-SD service type towns advertise under.
+const ServiceName = "_beads._tcp.local."
+
+// mdnsTTL is the TTL, in seconds, of advertised records.
+const mdnsTTL = 120
+
+// classUnicastResponse is the mDNS "QU" bit: the querier asks for a unicast
+// reply (RFC 6762 §5.4).
+const classUnicastResponse = 1 << 15
+
+// mdnsGroup is the IPv4 mDNS multicast group.
+var mdnsGroup = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+// Browse queries the local network for advertised towns and collects the
+// answers that arrive within timeout. Each town is reported once.
+func Browse(ctx context.Context, timeout time.Duration) ([]Peer, error) {
+	query, err := browseQuery()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		return nil, fmt.Errorf("mdns browse: %w", err)
+	}
+	defer conn.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+
+	if _, err := conn.WriteToUDP(query, mdnsGroup); err != nil {
+		return nil, fmt.Errorf("mdns browse: send query: %w", err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, fmt.Errorf("mdns browse: %w", err)
+	}
+
+	var peers []Peer
+	seen := make(map[string]bool)
+	buf := make([]byte, 9000)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if ctx.Err() != nil {
+				return peers, ctx.Err()
+			}
+			return peers, fmt.Errorf("mdns browse: %w", err)
+		}
+		for _, p := range parseAnnouncement(buf[:n]) {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				peers = append(peers, p)
+			}
+		}
+	}
+	return peers, nil
+}
+
+// Advertise answers mDNS browse queries for self until ctx is done, after
+// announcing it once to the group.
+func Advertise(ctx context.Context, self Peer) error {
+	announcement, err := announcement(self, 0, nil)
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenMulticastUDP("udp4", nil, mdnsGroup)
+	if err != nil {
+		return fmt.Errorf("mdns advertise: %w", err)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.WriteToUDP(announcement, mdnsGroup); err != nil {
+		return fmt.Errorf("mdns advertise: announce: %w", err)
+	}
+
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("mdns advertise: %w", err)
+		}
+		resp, unicast := answerQuery(buf[:n], self, src.Port)
+		if resp == nil {
+			continue
+		}
+		dst := mdnsGroup
+		if unicast {
+			dst = src
+		}
+		_, _ = conn.WriteToUDP(resp, dst) // Best effort: the querier retries
+	}
+}
+
+// browseQuery builds a PTR query for ServiceName asking for unicast replies.
+func browseQuery() ([]byte, error) {
+	name, err := dnsmessage.NewName(ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET | classUnicastResponse,
+		}},
+	}
+	return msg.Pack()
+}
+
+// answerQuery returns the response to an mDNS query if it browses for
+// ServiceName, and whether to send it unicast to the querier. Queries from a
+// port other than 5353 are legacy unicast queries (RFC 6762 §6.7): the reply
+// goes back to the querier's port and repeats its ID and question.
+func answerQuery(msg []byte, self Peer, srcPort int) ([]byte, bool) {
+	var query dnsmessage.Message
+	if err := query.Unpack(msg); err != nil || query.Header.Response {
+		return nil, false
+	}
+	for _, q := range query.Questions {
+		if q.Type != dnsmessage.TypePTR && q.Type != dnsmessage.TypeALL {
+			continue
+		}
+		if !strings.EqualFold(q.Name.String(), ServiceName) {
+			continue
+		}
+		legacy := srcPort != mdnsGroup.Port
+		var id uint16
+		var questions []dnsmessage.Question
+		if legacy {
+			id = query.Header.ID
+			questions = []dnsmessage.Question{q}
+		}
+		resp, err := announcement(self, id, questions)
+		if err != nil {
+			return nil, false
+		}
+		return resp, legacy || q.Class&classUnicastResponse != 0
+	}
+	return nil, false
+}
+
+// announcement builds the response advertising self: a PTR from ServiceName
+// to the town's instance name and a TXT record carrying its details.
+func announcement(self Peer, id uint16, questions []dnsmessage.Question) ([]byte, error) {
+	service, err := dnsmessage.NewName(ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := dnsmessage.NewName(self.Name + "." + ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("mdns: invalid town name %q: %w", self.Name, err)
+	}
+	txt := []string{"name=" + self.Name, "url=" + self.URL}
+	if self.Sovereignty != "" {
+		txt = append(txt, "sov="+self.Sovereignty)
+	}
+	msg := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, Response: true, Authoritative: true},
+		Questions: questions,
+		Answers: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: service, Class: dnsmessage.ClassINET, TTL: mdnsTTL},
+			Body:   &dnsmessage.PTRResource{PTR: instance},
+		}},
+		Additionals: []dnsmessage.Resource{{
+			Header: dnsmessage.ResourceHeader{Name: instance, Class: dnsmessage.ClassINET, TTL: mdnsTTL},
+			Body:   &dnsmessage.TXTResource{TXT: txt},
+		}},
+	}
+	return msg.Pack()
+}
+
+// parseAnnouncement extracts the towns advertised in an mDNS response: every
+// TXT record under ServiceName that carries a url.
+func parseAnnouncement(msg []byte) []Peer {
+	var resp dnsmessage.Message
+	if err := resp.Unpack(msg); err != nil || !resp.Header.Response {
+		return nil
+	}
+	var peers []Peer
+	for _, r := range append(resp.Answers, resp.Additionals...) {
+		txt, ok := r.Body.(*dnsmessage.TXTResource)
+		if !ok {
+			continue
+		}
+		instance := r.Header.Name.String()
+		if !strings.HasSuffix(strings.ToLower(instance), "."+ServiceName) {
+			continue
+		}
+		p := Peer{Name: strings.TrimSuffix(instance[:len(instance)-len(ServiceName)], "."), Source: SourceMDNS}
+		for _, field := range txt.TXT {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "name":
+				p.Name = value
+			case "url":
+				p.URL = value
+			case "sov":
+				p.Sovereignty = strings.ToUpper(value)
+			}
+		}
+		if p.Name != "" && p.URL != "" {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,75 @@
+package peerdiscovery
+
+import (
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestAnswerQueryRoundTrip(t *testing.T) {
+	self := Peer{Name: "town-beta", URL: "192.168.1.100:8080/beads", Sovereignty: "T2"}
+	query, err := browseQuery()
+	if err != nil {
+		t.Fatalf("browseQuery: %v", err)
+	}
+
+	resp, unicast := answerQuery(query, self, 53000)
+	if resp == nil {
+		t.Fatal("answerQuery ignored a browse query for the service")
+	}
+	if !unicast {
+		t.Error("query from an ephemeral port should get a unicast reply")
+	}
+	var msg dnsmessage.Message
+	if err := msg.Unpack(resp); err != nil {
+		t.Fatalf("unpack response: %v", err)
+	}
+	if len(msg.Questions) != 1 {
+		t.Errorf("legacy unicast reply should repeat the question, got %d", len(msg.Questions))
+	}
+
+	peers := parseAnnouncement(resp)
+	if len(peers) != 1 {
+		t.Fatalf("parseAnnouncement = %v, want one peer", peers)
+	}
+	want := Peer{Name: "town-beta", URL: "192.168.1.100:8080/beads", Sovereignty: "T2", Source: SourceMDNS}
+	if peers[0] != want {
+		t.Errorf("peer = %+v, want %+v", peers[0], want)
+	}
+}
+
+func TestAnswerQueryIgnoresOtherTraffic(t *testing.T) {
+	self := Peer{Name: "town-beta", URL: "host:8080/beads"}
+
+	other, err := (&dnsmessage.Message{Questions: []dnsmessage.Question{{
+		Name:  dnsmessage.MustNewName("_http._tcp.local."),
+		Type:  dnsmessage.TypePTR,
+		Class: dnsmessage.ClassINET,
+	}}}).Pack()
+	if err != nil {
+		t.Fatalf("pack: %v", err)
+	}
+	if resp, _ := answerQuery(other, self, 5353); resp != nil {
+		t.Error("answered a query for another service")
+	}
+
+	announce, err := announcement(self, 0, nil)
+	if err != nil {
+		t.Fatalf("announcement: %v", err)
+	}
+	if resp, _ := answerQuery(announce, self, 5353); resp != nil {
+		t.Error("answered a response")
+	}
+	if peers := parseAnnouncement(mustBrowseQuery(t)); peers != nil {
+		t.Errorf("parsed peers from a query: %v", peers)
+	}
+}
+
+func mustBrowseQuery(t *testing.T) []byte {
+	t.Helper()
+	q, err := browseQuery()
+	if err != nil {
+		t.Fatalf("browseQuery: %v", err)
+	}
+	return q
+}
@@ -0,0 +1,97 @@
+// Package peerdiscovery finds federation peers without manual URL copying:
+// towns that advertise themselves over mDNS on the local network, and towns
+// listed in a static registry file or URL.
+package peerdiscovery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceMDNS is the Source of peers found on the local network.
+const SourceMDNS = "mdns"
+
+// registryFetchTimeout bounds a registry download.
+const registryFetchTimeout = 10 * time.Second
+
+// maxRegistrySize caps how much of a registry is read.
+const maxRegistrySize = 1 << 20
+
+// Peer is a federation peer found by discovery.
+type Peer struct {
+	Name        string `json:"name" yaml:"name"`
+	URL         string `json:"url" yaml:"url"`
+	Sovereignty string `json:"sovereignty,omitempty" yaml:"sovereignty,omitempty"`
+	Source      string `json:"source" yaml:"-"` // SourceMDNS, or the registry path/URL
+}
+
+// registry is the format of a static peer registry (YAML or JSON):
+//
+//	peers:
+//	  - name: town-beta
+//	    url: 192.168.1.100:8080/beads
+//	    sovereignty: T2
+type registry struct {
+	Peers []Peer `yaml:"peers"`
+}
+
+// LoadRegistry reads the peers listed in a registry file, or in the registry
+// served at an http(s) URL.
+func LoadRegistry(ctx context.Context, src string) ([]Peer, error) {
+	data, err := readRegistry(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	var reg registry
+	if err := yaml.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("parse registry %s: %w", src, err)
+	}
+	for i := range reg.Peers {
+		p := &reg.Peers[i]
+		p.Name = strings.TrimSpace(p.Name)
+		p.URL = strings.TrimSpace(p.URL)
+		if p.Name == "" || p.URL == "" {
+			return nil, fmt.Errorf("registry %s: peer %d needs a name and url", src, i+1)
+		}
+		p.Sovereignty = strings.ToUpper(strings.TrimSpace(p.Sovereignty))
+		p.Source = src
+	}
+	return reg.Peers, nil
+}
+
+func readRegistry(ctx context.Context, src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		data, err := os.ReadFile(src) //nolint:gosec // user-supplied registry path
+		if err != nil {
+			return nil, fmt.Errorf("read registry: %w", err)
+		}
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registryFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch registry: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch registry: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch registry %s: %s", src, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistrySize))
+	if err != nil {
+		return nil, fmt.Errorf("fetch registry %s: %w", src, err)
+	}
+	return data, nil
+}
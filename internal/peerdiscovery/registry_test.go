@@ -0,0 +1,77 @@
+package peerdiscovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRegistryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "towns.yaml")
+	content := `
+peers:
+  - name: town-beta
+    url: 192.168.1.100:8080/beads
+    sovereignty: t2
+  - name: town-gamma
+    url: dolthub://acme/town-gamma
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	peers, err := LoadRegistry(context.Background(), path)
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+	want := []Peer{
+		{Name: "town-beta", URL: "192.168.1.100:8080/beads", Sovereignty: "T2", Source: path},
+		{Name: "town-gamma", URL: "dolthub://acme/town-gamma", Source: path},
+	}
+	if len(peers) != len(want) {
+		t.Fatalf("got %d peers, want %d: %v", len(peers), len(want), peers)
+	}
+	for i := range want {
+		if peers[i] != want[i] {
+			t.Errorf("peer %d = %+v, want %+v", i, peers[i], want[i])
+		}
+	}
+}
+
+func TestLoadRegistryURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/towns.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"peers": [{"name": "town-delta", "url": "host:8080/beads", "sovereignty": "T1"}]}`))
+	}))
+	defer srv.Close()
+
+	peers, err := LoadRegistry(context.Background(), srv.URL+"/towns.json")
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+	if len(peers) != 1 || peers[0].Name != "town-delta" || peers[0].Sovereignty != "T1" {
+		t.Errorf("peers = %+v", peers)
+	}
+
+	if _, err := LoadRegistry(context.Background(), srv.URL+"/missing.json"); err == nil {
+		t.Error("expected an error for a missing registry")
+	}
+}
+
+func TestLoadRegistryRequiresURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "towns.yaml")
+	if err := os.WriteFile(path, []byte("peers:\n  - name: town-beta\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadRegistry(context.Background(), path)
+	if err == nil || !strings.Contains(err.Error(), "peer 1 needs a name and url") {
+		t.Errorf("err = %v, want missing url error", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Package rbac defines the roles and API tokens used to restrict what a bd
+// caller may do. Roles are ordered: each one grants everything the roles
+// below it grant.
+//
+//	reader  read-only commands
+//	agent   issue-level writes (create, update, close, comment, dep, label)
+//	writer  all data writes, including delete, import, and sync
+//	admin   everything, including tokens, config, and schema changes
+//
+// Tokens are "bdt_<id>_<secret>". Only the SHA-256 of the secret is stored.
+package rbac
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Role is an access level granted by an API token.
+type Role string
+
+// Roles, from least to most privileged.
+const (
+	RoleReader Role = "reader"
+	RoleAgent  Role = "agent"
+	RoleWriter Role = "writer"
+	RoleAdmin  Role = "admin"
+)
+
+// Roles lists the valid roles from least to most privileged.
+var Roles = []Role{RoleReader, RoleAgent, RoleWriter, RoleAdmin}
+
+// Permission is the access level a command needs.
+type Permission int
+
+// Permissions, from least to most privileged. Each role grants the
+// permission of the same rank and everything below it.
+const (
+	PermRead Permission = iota
+	PermIssueWrite
+	PermWrite
+	PermAdmin
+)
+
+// String returns the lowest role that grants p.
+func (p Permission) String() string {
+	if p < PermRead || p > PermAdmin {
+		return fmt.Sprintf("Permission(%d)", int(p))
+	}
+	return string(Roles[p])
+}
+
+// ParseRole validates a role name.
+func ParseRole(s string) (Role, error) {
+	for _, r := range Roles {
+		if string(r) == strings.ToLower(strings.TrimSpace(s)) {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("invalid role %q (valid: reader, agent, writer, admin)", s)
+}
+
+// rank returns r's position in Roles, or -1 for an unknown role.
+func (r Role) rank() int {
+	for i, known := range Roles {
+		if r == known {
+			return i
+		}
+	}
+	return -1
+}
+
+// Allows reports whether r grants p. Unknown roles grant nothing.
+func (r Role) Allows(p Permission) bool {
+	rank := r.rank()
+	return rank >= 0 && rank >= int(p)
+}
+
+const tokenPrefix = "bdt_"
+
+// NewToken generates a token ID and secret and returns them with the
+// plaintext token to hand to the caller.
+func NewToken() (id, secret, token string, err error) {
+	idBytes := make([]byte, 4)
+	secretBytes := make([]byte, 24)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", "", fmt.Errorf("generate token id: %w", err)
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", "", fmt.Errorf("generate token secret: %w", err)
+	}
+	id = hex.EncodeToString(idBytes)
+	secret = hex.EncodeToString(secretBytes)
+	return id, secret, tokenPrefix + id + "_" + secret, nil
+}
+
+// ParseToken splits a plaintext token into its ID and secret.
+func ParseToken(token string) (id, secret string, err error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(token), tokenPrefix)
+	if ok {
+		id, secret, ok = strings.Cut(rest, "_")
+	}
+	if !ok || id == "" || secret == "" {
+		return "", "", fmt.Errorf("malformed API token (expected %s<id>_<secret>)", tokenPrefix)
+	}
+	return id, secret, nil
+}
+
+// HashSecret returns the hex SHA-256 of a token secret, as stored.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// SecretMatches reports whether secret hashes to storedHash, in constant time.
+func SecretMatches(secret, storedHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(storedHash)) == 1
+}
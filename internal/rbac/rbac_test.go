@@ -0,0 +1,55 @@
+package rbac
+
+import "testing"
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role Role
+		want []bool // PermRead, PermIssueWrite, PermWrite, PermAdmin
+	}{
+		{RoleReader, []bool{true, false, false, false}},
+		{RoleAgent, []bool{true, true, false, false}},
+		{RoleWriter, []bool{true, true, true, false}},
+		{RoleAdmin, []bool{true, true, true, true}},
+		{Role("root"), []bool{false, false, false, false}},
+	}
+	for _, tt := range tests {
+		for p, want := range tt.want {
+			if got := tt.role.Allows(Permission(p)); got != want {
+				t.Errorf("%s.Allows(%s) = %v, want %v", tt.role, Permission(p), got, want)
+			}
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	if r, err := ParseRole(" Writer "); err != nil || r != RoleWriter {
+		t.Errorf("ParseRole(Writer) = %q, %v", r, err)
+	}
+	if _, err := ParseRole("owner"); err == nil {
+		t.Error("ParseRole(owner) should fail")
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	id, secret, token, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotID, gotSecret, err := ParseToken(token)
+	if err != nil || gotID != id || gotSecret != secret {
+		t.Fatalf("ParseToken(%q) = %q, %q, %v", token, gotID, gotSecret, err)
+	}
+	hash := HashSecret(secret)
+	if !SecretMatches(secret, hash) {
+		t.Error("secret does not match its own hash")
+	}
+	if SecretMatches(secret+"x", hash) {
+		t.Error("wrong secret matched")
+	}
+	for _, bad := range []string{"", "bdt_", "bdt_abc", "bdt__secret", "xyz_abc_secret"} {
+		if _, _, err := ParseToken(bad); err == nil {
+			t.Errorf("ParseToken(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Package sshsig creates and verifies detached SSH signatures in the
+// OpenSSH SSHSIG format (PROTOCOL.sshsig), so files signed by bd can be
+// checked with 'ssh-keygen -Y verify' and vice versa.
+package sshsig
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	magic       = "SSHSIG"
+	sigVersion  = 1
+	pemType     = "SSH SIGNATURE"
+	defaultHash = "sha512"
+)
+
+// ErrInvalidSignature is returned when a signature does not match the data,
+// is malformed, or was made for another namespace.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// signedData is the blob the key actually signs.
+type signedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          string
+}
+
+// wireSignature is the armored signature's payload after the magic preamble.
+type wireSignature struct {
+	Version       uint32
+	PublicKey     string
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     string
+}
+
+func newHash(alg string) (hash.Hash, error) {
+	switch alg {
+	case "sha512":
+		return sha512.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("%w: unsupported hash algorithm %q", ErrInvalidSignature, alg)
+}
+
+func messageDigest(alg string, data io.Reader) ([]byte, error) {
+	h, err := newHash(alg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(h, data); err != nil {
+		return nil, fmt.Errorf("hash data: %w", err)
+	}
+	return h.Sum(nil), nil
+}
+
+func signedBlob(namespace, alg string, digest []byte) []byte {
+	return append([]byte(magic), ssh.Marshal(signedData{
+		Namespace:     namespace,
+		HashAlgorithm: alg,
+		Hash:          string(digest),
+	})...)
+}
+
+// Sign signs data with signer under namespace and returns an armored
+// "-----BEGIN SSH SIGNATURE-----" block.
+func Sign(signer ssh.Signer, namespace string, data io.Reader) ([]byte, error) {
+	digest, err := messageDigest(defaultHash, data)
+	if err != nil {
+		return nil, err
+	}
+	blob := signedBlob(namespace, defaultHash, digest)
+
+	var sig *ssh.Signature
+	if as, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// ssh-rsa (SHA-1) signatures are rejected by current OpenSSH.
+		sig, err = as.SignWithAlgorithm(rand.Reader, blob, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = signer.Sign(rand.Reader, blob)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	payload := append([]byte(magic), ssh.Marshal(wireSignature{
+		Version:       sigVersion,
+		PublicKey:     string(signer.PublicKey().Marshal()),
+		Namespace:     namespace,
+		HashAlgorithm: defaultHash,
+		Signature:     string(ssh.Marshal(sig)),
+	})...)
+	return armor(payload), nil
+}
+
+// armor wraps payload like ssh-keygen does: base64 in 70-column lines.
+func armor(payload []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(payload)
+	var b bytes.Buffer
+	b.WriteString("-----BEGIN " + pemType + "-----\n")
+	for len(enc) > 70 {
+		b.WriteString(enc[:70] + "\n")
+		enc = enc[70:]
+	}
+	b.WriteString(enc + "\n-----END " + pemType + "-----\n")
+	return b.Bytes()
+}
+
+// Verify checks an armored signature over data for namespace and returns
+// the public key that made it. Callers decide whether that key is trusted.
+func Verify(armored []byte, namespace string, data io.Reader) (ssh.PublicKey, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != pemType {
+		return nil, fmt.Errorf("%w: not an SSH signature", ErrInvalidSignature)
+	}
+	payload, ok := bytes.CutPrefix(block.Bytes, []byte(magic))
+	if !ok {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidSignature)
+	}
+	var ws wireSignature
+	if err := ssh.Unmarshal(payload, &ws); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if ws.Version != sigVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSignature, ws.Version)
+	}
+	if ws.Namespace != namespace {
+		return nil, fmt.Errorf("%w: signed for namespace %q, want %q", ErrInvalidSignature, ws.Namespace, namespace)
+	}
+	pub, err := ssh.ParsePublicKey([]byte(ws.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key: %v", ErrInvalidSignature, err)
+	}
+	var sig ssh.Signature
+	if err := ssh.Unmarshal([]byte(ws.Signature), &sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	digest, err := messageDigest(ws.HashAlgorithm, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := pub.Verify(signedBlob(namespace, ws.HashAlgorithm, digest), &sig); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return pub, nil
+}
+
+// SignFile signs data with the SSH key at keyPath. keyPath may name the
+// private key or its ".pub" file; when an ssh-agent (SSH_AUTH_SOCK) holds
+// the key, the agent signs, so passphrase-protected and hardware keys work.
+// Otherwise the private key file must be unencrypted.
+func SignFile(keyPath, namespace string, data io.Reader) ([]byte, error) {
+	keyPath = expandHome(keyPath)
+	privPath := strings.TrimSuffix(keyPath, ".pub")
+
+	if pubBytes, err := os.ReadFile(privPath + ".pub"); err == nil { //nolint:gosec // G304: configured key path
+		if pub, _, _, _, err := ssh.ParseAuthorizedKey(pubBytes); err == nil {
+			if sig, ok, err := signWithAgent(pub, namespace, data); ok || err != nil {
+				return sig, err
+			}
+		}
+	}
+
+	privBytes, err := os.ReadFile(privPath) //nolint:gosec // G304: configured key path
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(privBytes)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf("signing key %s is passphrase-protected; load it into ssh-agent with 'ssh-add %s'", privPath, privPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key %s: %w", privPath, err)
+	}
+	return Sign(signer, namespace, data)
+}
+
+// signWithAgent signs with the agent's copy of pub. ok is false when no
+// agent is running or it does not hold the key.
+func signWithAgent(pub ssh.PublicKey, namespace string, data io.Reader) (sig []byte, ok bool, err error) {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, false, nil
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer conn.Close()
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		return nil, false, nil
+	}
+	want := pub.Marshal()
+	for _, s := range signers {
+		if bytes.Equal(s.PublicKey().Marshal(), want) {
+			sig, err := Sign(s, namespace, data)
+			return sig, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// AllowedSigner is one line of an OpenSSH allowed_signers file.
+type AllowedSigner struct {
+	Principals []string
+	Namespaces []string // empty means any namespace
+	Key        ssh.PublicKey
+}
+
+// ParseAllowedSigners reads an allowed_signers file (see ssh-keygen(1)):
+// "principals [options] keytype base64-key [comment]" per line. Options
+// other than namespaces= are accepted and ignored.
+func ParseAllowedSigners(r io.Reader) ([]AllowedSigner, error) {
+	var signers []AllowedSigner
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		principals, rest, _ := strings.Cut(text, " ")
+		key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(rest)))
+		if err != nil {
+			return nil, fmt.Errorf("allowed signers line %d: %w", line, err)
+		}
+		s := AllowedSigner{Principals: strings.Split(principals, ","), Key: key}
+		for _, opt := range options {
+			if v, ok := strings.CutPrefix(opt, "namespaces="); ok {
+				s.Namespaces = strings.Split(strings.Trim(v, `"`), ",")
+			}
+		}
+		signers = append(signers, s)
+	}
+	return signers, sc.Err()
+}
+
+// FindPrincipal returns the principal allowed to sign for namespace with
+// key, or "" if the key is not allowed.
+func FindPrincipal(signers []AllowedSigner, key ssh.PublicKey, namespace string) string {
+	want := key.Marshal()
+	for _, s := range signers {
+		if !bytes.Equal(s.Key.Marshal(), want) {
+			continue
+		}
+		if len(s.Namespaces) > 0 && !containsPattern(s.Namespaces, namespace) {
+			continue
+		}
+		return s.Principals[0]
+	}
+	return ""
+}
+
+func containsPattern(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, s); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
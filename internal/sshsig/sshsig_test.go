@@ -0,0 +1,122 @@
+package sshsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	signer := newSigner(t)
+	data := []byte("{\"id\":\"bd-1\"}\n")
+
+	sig, err := Sign(signer, "beads-export", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := Verify(sig, "beads-export", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !bytes.Equal(pub.Marshal(), signer.PublicKey().Marshal()) {
+		t.Error("Verify returned a different key")
+	}
+
+	if _, err := Verify(sig, "beads-export", bytes.NewReader([]byte("{\"id\":\"bd-2\"}\n"))); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered data: got %v, want ErrInvalidSignature", err)
+	}
+	if _, err := Verify(sig, "git", bytes.NewReader(data)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong namespace: got %v, want ErrInvalidSignature", err)
+	}
+	if _, err := Verify([]byte("garbage"), "beads-export", bytes.NewReader(data)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("garbage: got %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestAllowedSigners(t *testing.T) {
+	alice, bob := newSigner(t), newSigner(t)
+	file := "# trusted publishers\n" +
+		"alice@example.com " + string(ssh.MarshalAuthorizedKey(alice.PublicKey())) +
+		`bob@example.com namespaces="git" ` + string(ssh.MarshalAuthorizedKey(bob.PublicKey()))
+
+	signers, err := ParseAllowedSigners(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := FindPrincipal(signers, alice.PublicKey(), "beads-export"); got != "alice@example.com" {
+		t.Errorf("alice principal = %q", got)
+	}
+	if got := FindPrincipal(signers, bob.PublicKey(), "beads-export"); got != "" {
+		t.Errorf("bob is restricted to git but matched %q", got)
+	}
+}
+
+// TestOpenSSHInterop checks signatures against ssh-keygen in both
+// directions when it is installed.
+func TestOpenSSHInterop(t *testing.T) {
+	keygen, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	dir := t.TempDir()
+	key := filepath.Join(dir, "id_ed25519")
+	if out, err := exec.Command(keygen, "-q", "-t", "ed25519", "-N", "", "-C", "test", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	data := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(data, []byte("{\"id\":\"bd-1\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pubBytes, _ := os.ReadFile(key + ".pub")
+	allowed := filepath.Join(dir, "allowed_signers")
+	if err := os.WriteFile(allowed, append([]byte("pub@example.com "), pubBytes...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	f, _ := os.Open(data)
+	sig, err := SignFile(key, "beads-export", f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(data+".sig", sig, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	verify := exec.Command(keygen, "-Y", "verify", "-f", allowed, "-I", "pub@example.com", "-n", "beads-export", "-s", data+".sig")
+	verify.Stdin, _ = os.Open(data)
+	if out, err := verify.CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen rejected our signature: %v\n%s", err, out)
+	}
+
+	if out, err := exec.Command(keygen, "-Y", "sign", "-f", key, "-n", "beads-export", data).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen -Y sign: %v\n%s", err, out)
+	}
+	theirs, _ := os.ReadFile(data + ".sig")
+	f, _ = os.Open(data)
+	defer f.Close()
+	if _, err := Verify(theirs, "beads-export", f); err != nil {
+		t.Errorf("ssh-keygen signature rejected: %v", err)
+	}
+}
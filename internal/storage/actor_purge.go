@@ -0,0 +1,47 @@
+package storage
+
+import "context"
+
+// ActorPurgeOptions selects what ActorPurger.PurgeActor does.
+type ActorPurgeOptions struct {
+	// Actor is the identity to purge, matched exactly.
+	Actor string
+	// Replacement is the pseudonym written in place of Actor. It is always
+	// used for @mentions in issue and comment text.
+	Replacement string
+	// Remove clears identity columns and deletes the actor's comments,
+	// events, interactions, and locks instead of attributing them to
+	// Replacement.
+	Remove bool
+	// DryRun computes the report in a transaction that is rolled back.
+	DryRun bool
+}
+
+// ActorPurgeChange counts the rows one purge step changed.
+type ActorPurgeChange struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	// Action is "anonymized", "cleared", "deleted", or "rewritten".
+	Action string `json:"action"`
+	Rows   int64  `json:"rows"`
+}
+
+// ActorPurgeReport describes what PurgeActor changed, or would change on a
+// dry run.
+type ActorPurgeReport struct {
+	Actor       string             `json:"actor"`
+	Replacement string             `json:"replacement"`
+	Remove      bool               `json:"remove"`
+	DryRun      bool               `json:"dry_run"`
+	Changes     []ActorPurgeChange `json:"changes"`
+	// IssueIDs lists the issues and wisps that had at least one row changed.
+	IssueIDs  []string `json:"issue_ids"`
+	TotalRows int64    `json:"total_rows"`
+}
+
+// ActorPurger removes an actor's identity from the working set (bd
+// purge-actor). It does not touch Dolt history; see bd flatten. Callers
+// should type-assert to this interface.
+type ActorPurger interface {
+	PurgeActor(ctx context.Context, opts ActorPurgeOptions) (*ActorPurgeReport, error)
+}
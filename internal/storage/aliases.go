@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AliasStore maps memorable names to issue IDs in the issue_aliases table.
+// Callers should type-assert to this interface.
+type AliasStore interface {
+	// AddAlias points alias at issueID. It fails if the alias is taken or
+	// is itself an issue ID.
+	AddAlias(ctx context.Context, alias, issueID, actor string) error
+	// RemoveAlias deletes alias, returning ErrNotFound if there is none.
+	RemoveAlias(ctx context.Context, alias string) error
+	// ResolveAlias returns the issue ID alias points at, or ErrNotFound.
+	ResolveAlias(ctx context.Context, alias string) (string, error)
+	// GetAliases returns the aliases of issueID ordered by alias, or every
+	// alias when issueID is empty.
+	GetAliases(ctx context.Context, issueID string) ([]*types.IssueAlias, error)
+}
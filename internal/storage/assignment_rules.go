@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AssignmentRuleStore holds the auto-assignment rules applied by bd create
+// and bd assign --auto. Callers should type-assert to this interface.
+type AssignmentRuleStore interface {
+	// AddAssignmentRule stores rule. It fails if a rule with the same name
+	// exists. rule.CreatedAt is set when zero.
+	AddAssignmentRule(ctx context.Context, rule *types.AssignmentRule) error
+	// RemoveAssignmentRule deletes the named rule, returning ErrNotFound if
+	// there is none.
+	RemoveAssignmentRule(ctx context.Context, name string) error
+	// GetAssignmentRules returns every rule in the order they are tried:
+	// priority, then name.
+	GetAssignmentRules(ctx context.Context) ([]*types.AssignmentRule, error)
+	// AdvanceAssignmentRule increments the named rule's round-robin cursor
+	// and returns its value before the increment.
+	AdvanceAssignmentRule(ctx context.Context, name string) (int, error)
+}
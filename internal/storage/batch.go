@@ -15,6 +15,11 @@ const (
 	OrphanAllow OrphanHandling = "allow"
 )
 
+// SkipReasonTargetNotFound is the OnSkippedDependency reason for an edge
+// whose target issue does not exist (yet). Streaming imports retry these
+// once every batch has landed, since the target may appear later in the file.
+const SkipReasonTargetNotFound = "target not found"
+
 // BatchCreateOptions contains options for batch issue creation.
 // This is a backend-agnostic type that can be used by any storage implementation.
 type BatchCreateOptions struct {
@@ -11,7 +11,7 @@ type BulkIssueStore interface {
 	CreateIssuesWithFullOptions(ctx context.Context, issues []*types.Issue, actor string, opts BatchCreateOptions) error
 	DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool, dryRun bool) (*types.DeleteIssuesResult, error)
 	DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error)
-	UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error
+	UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) (*types.RenameResult, error)
 	ClaimIssue(ctx context.Context, id string, actor string) error
 	ClaimReadyIssue(ctx context.Context, filter types.WorkFilter, actor string) (*types.Issue, error)
 	PromoteFromEphemeral(ctx context.Context, id string, actor string) error
@@ -4,6 +4,8 @@ import (
 	"context"
 	"strconv"
 	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 type childCounterReservationKey struct{}
@@ -39,3 +41,20 @@ func HasReservedChildCounter(ctx context.Context, parentID string, childNum int)
 	}
 	return reservation.parentID == parentID && reservation.childNum == childNum
 }
+
+// ChildCounterStore inspects and repairs the child_counters table and
+// renumbers hierarchical children. Callers should type-assert to this
+// interface.
+type ChildCounterStore interface {
+	// ChildCounterStatus returns, per parent with a counter or with
+	// hierarchical children, the counter next to the children that exist.
+	ChildCounterStatus(ctx context.Context) ([]*types.ChildCounterStatus, error)
+	// RepairChildCounters raises counters that trail an existing child and
+	// deletes counters of parents that no longer exist. It returns the
+	// number of counters changed.
+	RepairChildCounters(ctx context.Context) (int, error)
+	// RenumberChildren renames issues by renames (old ID -> new ID) in one
+	// transaction and raises parentID's counter to at least lastChild.
+	// renames may permute IDs among themselves.
+	RenumberChildren(ctx context.Context, parentID string, renames map[string]string, lastChild int, actor string) error
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CIRunStore records CI results reported against issues (bd ci report).
+// Callers should type-assert to this interface.
+type CIRunStore interface {
+	// RecordCIRun stores run, replacing any earlier report for the same
+	// issue, workflow, and commit, and records a ci_reported event on the
+	// issue. run.UpdatedAt is set when zero.
+	RecordCIRun(ctx context.Context, run *types.CIRun, actor string) error
+	// GetCIRuns returns the runs for each of issueIDs, newest first.
+	// Issues without runs are absent from the map.
+	GetCIRuns(ctx context.Context, issueIDs []string) (map[string][]*types.CIRun, error)
+}
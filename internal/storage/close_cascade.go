@@ -0,0 +1,12 @@
+package storage
+
+import "context"
+
+// CascadeCloser closes an issue together with its open descendants.
+// Callers should type-assert to this interface.
+type CascadeCloser interface {
+	// CloseCascade closes rootID and every open parent-child descendant in
+	// one transaction, recording a 'closed' event for each. It returns the
+	// IDs closed, root last; nothing is closed if any close fails.
+	CloseCascade(ctx context.Context, rootID, reason, actor, session string) ([]string, error)
+}
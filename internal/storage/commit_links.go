@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CommitLinkStore records git commits that touched issues (bd git scan,
+// bd link commit). Callers should type-assert to this interface.
+type CommitLinkStore interface {
+	// AddCommitLink records link and reports whether it was new. An
+	// existing (issue, commit) pair is left unchanged. link.CreatedAt is
+	// set when zero.
+	AddCommitLink(ctx context.Context, link *types.CommitLink) (bool, error)
+	// HasCommitLink reports whether the (issueID, commitSHA) pair is recorded.
+	HasCommitLink(ctx context.Context, issueID, commitSHA string) (bool, error)
+	// GetCommitLinks returns issueID's links, oldest first.
+	GetCommitLinks(ctx context.Context, issueID string) ([]*types.CommitLink, error)
+	// GetCommitLinksForCommit returns the links whose commit SHA starts
+	// with sha, so abbreviated SHAs match.
+	GetCommitLinksForCommit(ctx context.Context, sha string) ([]*types.CommitLink, error)
+}
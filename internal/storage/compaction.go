@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -13,3 +14,35 @@ type CompactionStore interface {
 	GetTier1Candidates(ctx context.Context) ([]*types.CompactionCandidate, error)
 	GetTier2Candidates(ctx context.Context) ([]*types.CompactionCandidate, error)
 }
+
+// CompactionSnapshotStore keeps pre-compaction copies of issues in the
+// compaction_snapshots table so compaction can be undone. Callers should
+// type-assert to this interface.
+type CompactionSnapshotStore interface {
+	// SaveCompactionSnapshot records issue as it was before compaction to level.
+	SaveCompactionSnapshot(ctx context.Context, issue *types.Issue, level int) error
+	// GetCompactionSnapshots returns an issue's snapshots, oldest first.
+	GetCompactionSnapshots(ctx context.Context, issueID string) ([]*types.CompactionSnapshot, error)
+	// ClearCompaction resets an issue's compaction metadata after its content
+	// has been restored. Snapshots are kept.
+	ClearCompaction(ctx context.Context, issueID string) error
+}
+
+// SnapshotRetention bounds the rows kept in the snapshot tables. A snapshot
+// survives a prune when it is among the KeepLast newest for its issue or
+// younger than KeepDays; a zero field disables that rule, and a snapshot is
+// only pruned when at least one rule is enabled.
+type SnapshotRetention struct {
+	KeepLast int
+	KeepDays int
+}
+
+// SnapshotPruner reports on and prunes the issue_snapshots and
+// compaction_snapshots tables. Callers should type-assert to this interface.
+type SnapshotPruner interface {
+	// SnapshotUsage returns row and payload size totals for each table.
+	SnapshotUsage(ctx context.Context) ([]*types.SnapshotTableUsage, error)
+	// PruneSnapshots deletes rows outside retention from each table. With
+	// dryRun, nothing is deleted and the counts are what would be removed.
+	PruneSnapshots(ctx context.Context, retention SnapshotRetention, now time.Time, dryRun bool) ([]*types.SnapshotPruneResult, error)
+}
@@ -28,4 +28,19 @@ type DependencyQueryStore interface {
 	// and are in the given status. Preferred over CountDependents + per-row filtering
 	// for the bd close epic-closure check.
 	CountDependentsByStatus(ctx context.Context, issueID string, status types.Status) (int64, error)
+
+	// GetChildProgress returns closed/total parent-child counts for each
+	// parent in one aggregated query, for epic and molecule roll-ups in list
+	// and show. Parents without children are absent from the map.
+	GetChildProgress(ctx context.Context, parentIDs []string) (map[string]*types.ChildProgress, error)
+
+	// GetHierarchyRollup returns counts and estimates over all descendants
+	// of rootID and of every issue beneath it, for bd tree. Issues without
+	// children are absent from the map.
+	GetHierarchyRollup(ctx context.Context, rootID string) (map[string]*types.HierarchyRollup, error)
+
+	// GetUnblockImpact returns, per open blocker, the open issues it blocks
+	// directly and transitively and their summed estimates, for bd
+	// unblock-analysis. Issues that block nothing are absent from the map.
+	GetUnblockImpact(ctx context.Context) (map[string]*types.UnblockImpact, error)
 }
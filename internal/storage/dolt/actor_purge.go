@@ -0,0 +1,27 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// PurgeActor implements storage.ActorPurger. A dry run executes the same
+// statements in a read transaction, which is always rolled back.
+func (s *DoltStore) PurgeActor(ctx context.Context, opts storage.ActorPurgeOptions) (*storage.ActorPurgeReport, error) {
+	var report *storage.ActorPurgeReport
+	fn := func(tx *sql.Tx) error {
+		var err error
+		report, err = issueops.PurgeActorInTx(ctx, tx, opts)
+		return err
+	}
+	var err error
+	if opts.DryRun {
+		err = s.withReadTx(ctx, fn)
+	} else {
+		err = s.withRetryTx(ctx, fn)
+	}
+	return report, err
+}
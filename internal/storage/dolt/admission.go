@@ -0,0 +1,306 @@
+package dolt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// Admission control for shared Dolt servers.
+//
+// The connection pool caps connections per process, but nothing caps the
+// number of processes: a swarm of agents each opening a pool of ten exhausts
+// the server's max_connections long before the pool gauges (GH#3140) show a
+// problem. Admission control bounds this on the client side, before any
+// connection is opened:
+//
+//   - dolt.max-clients limits how many bd processes on this machine may hold
+//     an open store against the same server. Each open store holds a slot
+//     (an flock'd file) until Close, so a crashed process frees its slot.
+//   - dolt.rate-limit limits how often each actor may open a store against
+//     the server, using a token bucket shared across processes.
+//
+// Both queue for up to dolt.queue-timeout and then fail with a
+// BackpressureError instead of piling more connections onto the server.
+
+// defaultQueueTimeout is how long a store open waits for a client slot or a
+// rate-limit token before giving up with ErrServerBusy.
+const defaultQueueTimeout = 30 * time.Second
+
+// admissionDir holds slot locks and rate-limit state. A variable so tests can
+// point it at a temp directory.
+var admissionDir = filepath.Join(os.TempDir(), "beads-admission")
+
+// ErrServerBusy is the sentinel matched by errors.Is for every
+// BackpressureError.
+var ErrServerBusy = errors.New("dolt server busy")
+
+// BackpressureError reports that admission control refused a store open
+// because the shared server is at its configured client or rate limit.
+type BackpressureError struct {
+	Server string        // host:port or socket path
+	Reason string        // which limit was hit
+	Waited time.Duration // how long the open queued before giving up
+	Hint   string        // which setting to change
+}
+
+func (e *BackpressureError) Error() string {
+	return fmt.Sprintf("dolt server %s busy: %s (queued %s)\n\n%s",
+		e.Server, e.Reason, e.Waited.Round(time.Millisecond), e.Hint)
+}
+
+func (e *BackpressureError) Unwrap() error { return ErrServerBusy }
+
+// admissionTicket is the client slot held by an open store.
+type admissionTicket struct {
+	f *os.File
+}
+
+// release frees the slot. Safe to call on a nil ticket.
+func (t *admissionTicket) release() {
+	if t == nil || t.f == nil {
+		return
+	}
+	_ = lockfile.FlockUnlock(t.f) // Best effort: closing the fd also drops the lock
+	_ = t.f.Close()
+	t.f = nil
+}
+
+// rateLimit is a parsed dolt.rate-limit value.
+type rateLimit struct {
+	perSecond float64
+	burst     int
+	spec      string
+}
+
+// parseRateLimit parses "<n>/<unit>" where unit is s, m, or h (e.g. "60/m").
+// A bare number means per minute. The bucket holds n tokens, so an idle actor
+// may open n stores back to back before being throttled.
+func parseRateLimit(spec string) (rateLimit, error) {
+	spec = strings.TrimSpace(spec)
+	countStr, unit, hasUnit := strings.Cut(spec, "/")
+	if !hasUnit {
+		unit = "m"
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || n <= 0 {
+		return rateLimit{}, fmt.Errorf("invalid dolt.rate-limit %q: want <count>/<s|m|h>, e.g. 60/m", spec)
+	}
+	var per time.Duration
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s", "sec", "second":
+		per = time.Second
+	case "m", "min", "minute":
+		per = time.Minute
+	case "h", "hour":
+		per = time.Hour
+	default:
+		return rateLimit{}, fmt.Errorf("invalid dolt.rate-limit %q: unit must be s, m, or h", spec)
+	}
+	return rateLimit{perSecond: float64(n) / per.Seconds(), burst: n, spec: spec}, nil
+}
+
+// admissionServerKey identifies the server for slot and bucket files.
+func admissionServerKey(cfg *Config) string {
+	if cfg.ServerSocket != "" {
+		return cfg.ServerSocket
+	}
+	return net.JoinHostPort(cfg.ServerHost, strconv.Itoa(cfg.ServerPort))
+}
+
+// admissionFileName turns parts into a filesystem-safe name.
+func admissionFileName(parts ...string) string {
+	name := strings.Join(parts, "-")
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// acquireAdmission applies dolt.rate-limit and dolt.max-clients before a
+// server-mode store opens. It returns the slot to hold until Close, or nil
+// when no client limit is configured. Admission control is disabled under
+// BEADS_TEST_MODE so parallel tests sharing a test server are not throttled.
+func acquireAdmission(ctx context.Context, cfg *Config) (*admissionTicket, error) {
+	if os.Getenv("BEADS_TEST_MODE") == "1" || (cfg.MaxClients <= 0 && cfg.RateLimit == "") {
+		return nil, nil
+	}
+	timeout := cfg.QueueTimeout
+	if timeout <= 0 {
+		timeout = defaultQueueTimeout
+	}
+	start := time.Now()
+	deadline := start.Add(timeout)
+	server := admissionServerKey(cfg)
+
+	if cfg.RateLimit != "" {
+		limit, err := parseRateLimit(cfg.RateLimit)
+		if err != nil {
+			return nil, err
+		}
+		if err := waitRateLimit(ctx, server, cfg.Actor, limit, start, deadline); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MaxClients <= 0 {
+		return nil, nil
+	}
+	return acquireClientSlot(ctx, server, cfg.MaxClients, start, deadline)
+}
+
+// acquireClientSlot takes one of maxClients slot locks for server, polling
+// with backoff until deadline.
+func acquireClientSlot(ctx context.Context, server string, maxClients int, start, deadline time.Time) (*admissionTicket, error) {
+	if err := os.MkdirAll(admissionDir, 0o755); err != nil {
+		return nil, fmt.Errorf("admission control: %w", err)
+	}
+	backoff := 25 * time.Millisecond
+	for {
+		for i := 0; i < maxClients; i++ {
+			path := filepath.Join(admissionDir, admissionFileName(server, "slot", strconv.Itoa(i))+".lock")
+			// #nosec G304 - controlled path
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+			if err != nil {
+				return nil, fmt.Errorf("admission control: %w", err)
+			}
+			if err := lockfile.FlockExclusiveNonBlocking(f); err == nil {
+				return &admissionTicket{f: f}, nil
+			} else if !lockfile.IsLocked(err) {
+				_ = f.Close()
+				return nil, fmt.Errorf("admission control: %w", err)
+			}
+			_ = f.Close()
+		}
+		if err := admissionWait(ctx, backoff, deadline); err != nil {
+			return nil, &BackpressureError{
+				Server: server,
+				Reason: fmt.Sprintf("all %d client slots in use", maxClients),
+				Waited: time.Since(start),
+				Hint: "Too many bd processes are connected to this server. Retry later, or raise\n" +
+					"dolt.max-clients / dolt.queue-timeout in .beads/config.yaml.",
+			}
+		}
+		if backoff < time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// bucketState is the token bucket persisted per server and actor.
+type bucketState struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// waitRateLimit takes one token from the actor's bucket, waiting for a refill
+// when the bucket is empty and the refill arrives before deadline.
+func waitRateLimit(ctx context.Context, server, actor string, limit rateLimit, start, deadline time.Time) error {
+	if err := os.MkdirAll(admissionDir, 0o755); err != nil {
+		return fmt.Errorf("admission control: %w", err)
+	}
+	if actor == "" {
+		actor = "unknown"
+	}
+	path := filepath.Join(admissionDir, admissionFileName(server, "actor", actor)+".json")
+	for {
+		wait, err := takeToken(path, limit, time.Now())
+		if err != nil {
+			return fmt.Errorf("admission control: %w", err)
+		}
+		if wait == 0 {
+			return nil
+		}
+		if time.Now().Add(wait).After(deadline) || admissionWait(ctx, wait, deadline) != nil {
+			return &BackpressureError{
+				Server: server,
+				Reason: fmt.Sprintf("actor %q exceeded dolt.rate-limit %s", actor, limit.spec),
+				Waited: time.Since(start),
+				Hint:   "Slow down, or raise dolt.rate-limit / dolt.queue-timeout in .beads/config.yaml.",
+			}
+		}
+	}
+}
+
+// takeToken consumes a token from the bucket file at path under an exclusive
+// lock. It returns 0 on success, or how long until the next token refills.
+func takeToken(path string, limit rateLimit, now time.Time) (time.Duration, error) {
+	// #nosec G304 - controlled path
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if err := lockfile.FlockExclusiveBlocking(f); err != nil {
+		return 0, err
+	}
+	defer func() { _ = lockfile.FlockUnlock(f) }()
+
+	state := bucketState{Tokens: float64(limit.burst), Updated: now}
+	if data, err := io.ReadAll(f); err == nil && len(data) > 0 {
+		var saved bucketState
+		if json.Unmarshal(data, &saved) == nil && !saved.Updated.IsZero() {
+			state = saved
+		}
+	}
+	if elapsed := now.Sub(state.Updated).Seconds(); elapsed > 0 {
+		state.Tokens += elapsed * limit.perSecond
+		state.Updated = now
+	}
+	if state.Tokens > float64(limit.burst) {
+		state.Tokens = float64(limit.burst)
+	}
+
+	var wait time.Duration
+	if state.Tokens >= 1 {
+		state.Tokens--
+	} else {
+		wait = time.Duration((1 - state.Tokens) / limit.perSecond * float64(time.Second))
+		if wait <= 0 {
+			wait = time.Millisecond
+		}
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return 0, err
+	}
+	if err := f.Truncate(0); err != nil {
+		return 0, err
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return 0, err
+	}
+	return wait, nil
+}
+
+// admissionWait sleeps for d, returning an error if ctx is canceled or the
+// sleep would run past deadline.
+func admissionWait(ctx context.Context, d time.Duration, deadline time.Time) error {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return context.DeadlineExceeded
+	}
+	if d > remaining {
+		d = remaining
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package dolt
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func useTempAdmissionDir(t *testing.T) {
+	t.Helper()
+	prev := admissionDir
+	admissionDir = t.TempDir()
+	t.Cleanup(func() { admissionDir = prev })
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		spec      string
+		perSecond float64
+		burst     int
+	}{
+		{"10/s", 10, 10},
+		{"60/m", 1, 60},
+		{"120", 2, 120},
+		{"3600/h", 1, 3600},
+	}
+	for _, tt := range tests {
+		got, err := parseRateLimit(tt.spec)
+		if err != nil {
+			t.Fatalf("parseRateLimit(%q): %v", tt.spec, err)
+		}
+		if got.perSecond != tt.perSecond || got.burst != tt.burst {
+			t.Errorf("parseRateLimit(%q) = %+v", tt.spec, got)
+		}
+	}
+	for _, spec := range []string{"", "0/m", "-1/s", "ten/m", "5/d"} {
+		if _, err := parseRateLimit(spec); err == nil {
+			t.Errorf("parseRateLimit(%q) should fail", spec)
+		}
+	}
+}
+
+func TestAcquireAdmission_ClientSlots(t *testing.T) {
+	useTempAdmissionDir(t)
+	t.Setenv("BEADS_TEST_MODE", "")
+	cfg := &Config{ServerHost: "127.0.0.1", ServerPort: 3999, MaxClients: 2, QueueTimeout: 100 * time.Millisecond}
+
+	first, err := acquireAdmission(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := acquireAdmission(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = acquireAdmission(context.Background(), cfg)
+	var bp *BackpressureError
+	if !errors.As(err, &bp) || !errors.Is(err, ErrServerBusy) {
+		t.Fatalf("third client: got %v, want BackpressureError", err)
+	}
+	if !strings.Contains(err.Error(), "all 2 client slots in use") || !strings.Contains(err.Error(), "127.0.0.1:3999") {
+		t.Errorf("unexpected error text: %v", err)
+	}
+
+	// A released slot admits the queued client.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		first.release()
+	}()
+	cfg.QueueTimeout = 2 * time.Second
+	third, err := acquireAdmission(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("queued client not admitted after release: %v", err)
+	}
+	second.release()
+	third.release()
+}
+
+func TestAcquireAdmission_RateLimit(t *testing.T) {
+	useTempAdmissionDir(t)
+	t.Setenv("BEADS_TEST_MODE", "")
+	cfg := &Config{ServerHost: "127.0.0.1", ServerPort: 3999, RateLimit: "2/h", Actor: "alice", QueueTimeout: 50 * time.Millisecond}
+
+	for i := 0; i < 2; i++ {
+		if _, err := acquireAdmission(context.Background(), cfg); err != nil {
+			t.Fatalf("open %d within burst: %v", i+1, err)
+		}
+	}
+	_, err := acquireAdmission(context.Background(), cfg)
+	if !errors.Is(err, ErrServerBusy) || !strings.Contains(err.Error(), `actor "alice"`) {
+		t.Fatalf("third open: got %v, want rate-limit backpressure", err)
+	}
+
+	// Buckets are per actor.
+	cfg.Actor = "bob"
+	if _, err := acquireAdmission(context.Background(), cfg); err != nil {
+		t.Errorf("other actor throttled: %v", err)
+	}
+}
+
+func TestTakeTokenRefills(t *testing.T) {
+	useTempAdmissionDir(t)
+	limit, _ := parseRateLimit("1/s")
+	path := filepath.Join(admissionDir, "bucket.json")
+	now := time.Now()
+
+	if wait, err := takeToken(path, limit, now); err != nil || wait != 0 {
+		t.Fatalf("first token: wait=%v err=%v", wait, err)
+	}
+	if wait, _ := takeToken(path, limit, now); wait <= 0 || wait > time.Second {
+		t.Errorf("empty bucket wait = %v, want (0, 1s]", wait)
+	}
+	if wait, _ := takeToken(path, limit, now.Add(1100*time.Millisecond)); wait != 0 {
+		t.Errorf("token not refilled after 1.1s: wait=%v", wait)
+	}
+}
+
+func TestAcquireAdmission_DisabledInTestMode(t *testing.T) {
+	useTempAdmissionDir(t)
+	t.Setenv("BEADS_TEST_MODE", "1")
+	cfg := &Config{ServerHost: "127.0.0.1", ServerPort: 3999, MaxClients: 1, RateLimit: "bogus"}
+	if ticket, err := acquireAdmission(context.Background(), cfg); ticket != nil || err != nil {
+		t.Errorf("test mode should bypass admission control: %v, %v", ticket, err)
+	}
+}
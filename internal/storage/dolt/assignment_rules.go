@@ -0,0 +1,45 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAssignmentRule implements storage.AssignmentRuleStore.
+func (s *DoltStore) AddAssignmentRule(ctx context.Context, rule *types.AssignmentRule) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.AddAssignmentRuleInTx(ctx, tx, rule)
+	})
+}
+
+// RemoveAssignmentRule implements storage.AssignmentRuleStore.
+func (s *DoltStore) RemoveAssignmentRule(ctx context.Context, name string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemoveAssignmentRuleInTx(ctx, tx, name)
+	})
+}
+
+// GetAssignmentRules implements storage.AssignmentRuleStore.
+func (s *DoltStore) GetAssignmentRules(ctx context.Context) ([]*types.AssignmentRule, error) {
+	var result []*types.AssignmentRule
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAssignmentRulesInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// AdvanceAssignmentRule implements storage.AssignmentRuleStore.
+func (s *DoltStore) AdvanceAssignmentRule(ctx context.Context, name string) (int, error) {
+	var prev int
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		prev, err = issueops.AdvanceAssignmentRuleInTx(ctx, tx, name)
+		return err
+	})
+	return prev, err
+}
@@ -0,0 +1,38 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChildCounterStatus implements storage.ChildCounterStore.
+func (s *DoltStore) ChildCounterStatus(ctx context.Context) ([]*types.ChildCounterStatus, error) {
+	var result []*types.ChildCounterStatus
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ChildCounterStatusInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// RepairChildCounters implements storage.ChildCounterStore.
+func (s *DoltStore) RepairChildCounters(ctx context.Context) (int, error) {
+	var changed int
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		changed, err = issueops.RepairChildCountersInTx(ctx, tx)
+		return err
+	})
+	return changed, err
+}
+
+// RenumberChildren implements storage.ChildCounterStore.
+func (s *DoltStore) RenumberChildren(ctx context.Context, parentID string, renames map[string]string, lastChild int, actor string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RenumberChildrenInTx(ctx, tx, parentID, renames, lastChild, actor)
+	})
+}
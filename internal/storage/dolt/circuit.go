@@ -2,6 +2,7 @@ package dolt
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -377,6 +378,22 @@ func cleanStaleCircuitBreakerFilesIn(dir string) {
 	}
 }
 
+// IsUnreachable reports whether err means the Dolt server could not be
+// reached at all — refused, timed out, unresolvable, or short-circuited by
+// an open breaker — as opposed to a query or schema failure.
+func IsUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) || isConnectionError(err) {
+		return true
+	}
+	errStr := strings.ToLower(err.Error())
+	return strings.Contains(errStr, "no such host") ||
+		strings.Contains(errStr, "network is unreachable") ||
+		strings.Contains(errStr, "server not reachable")
+}
+
 // isConnectionError returns true if the error indicates the Dolt server is
 // unreachable or down. Only these errors trip the circuit breaker — query-level
 // errors (syntax, missing table, etc.) do not.
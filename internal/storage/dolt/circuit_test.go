@@ -3,6 +3,7 @@ package dolt
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
@@ -460,6 +461,27 @@ func TestIsConnectionError(t *testing.T) {
 	}
 }
 
+func TestIsUnreachable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"breaker open", fmt.Errorf("open store: %w", ErrCircuitOpen), true},
+		{"refused with hint", errors.New("failed to connect to Dolt server at 127.0.0.1:3307: dial tcp: connection refused\n\nThe Dolt server may not be running."), true},
+		{"dns failure", errors.New("dial tcp: lookup dolt.internal: no such host"), true},
+		{"schema error", errors.New("Error 1146: Table doesn't exist"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnreachable(tt.err); got != tt.expected {
+				t.Errorf("IsUnreachable(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
 // newTestCircuitBreaker creates a circuit breaker with a temp file for testing.
 // Uses port 99999 which has no listener, so active probes will fail.
 func newTestCircuitBreaker(t *testing.T) *circuitBreaker {
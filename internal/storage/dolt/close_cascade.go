@@ -0,0 +1,22 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// CloseCascade implements storage.CascadeCloser.
+func (s *DoltStore) CloseCascade(ctx context.Context, rootID, reason, actor, session string) ([]string, error) {
+	var closed []string
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		closed, err = issueops.CloseCascadeInTx(ctx, tx, rootID, reason, actor, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
@@ -0,0 +1,53 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddCommitLink implements storage.CommitLinkStore.
+func (s *DoltStore) AddCommitLink(ctx context.Context, link *types.CommitLink) (bool, error) {
+	var added bool
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		added, err = issueops.AddCommitLinkInTx(ctx, tx, link)
+		return err
+	})
+	return added, err
+}
+
+// HasCommitLink implements storage.CommitLinkStore.
+func (s *DoltStore) HasCommitLink(ctx context.Context, issueID, commitSHA string) (bool, error) {
+	var found bool
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		found, err = issueops.HasCommitLinkInTx(ctx, tx, issueID, commitSHA)
+		return err
+	})
+	return found, err
+}
+
+// GetCommitLinks implements storage.CommitLinkStore.
+func (s *DoltStore) GetCommitLinks(ctx context.Context, issueID string) ([]*types.CommitLink, error) {
+	var result []*types.CommitLink
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// GetCommitLinksForCommit implements storage.CommitLinkStore.
+func (s *DoltStore) GetCommitLinksForCommit(ctx context.Context, sha string) ([]*types.CommitLink, error) {
+	var result []*types.CommitLink
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksForCommitInTx(ctx, tx, sha)
+		return err
+	})
+	return result, err
+}
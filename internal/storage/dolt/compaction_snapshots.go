@@ -0,0 +1,62 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveCompactionSnapshot implements storage.CompactionSnapshotStore.
+func (s *DoltStore) SaveCompactionSnapshot(ctx context.Context, issue *types.Issue, level int) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.SaveCompactionSnapshotInTx(ctx, tx, issue, level)
+	})
+}
+
+// GetCompactionSnapshots implements storage.CompactionSnapshotStore.
+func (s *DoltStore) GetCompactionSnapshots(ctx context.Context, issueID string) ([]*types.CompactionSnapshot, error) {
+	var result []*types.CompactionSnapshot
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCompactionSnapshotsInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// ClearCompaction implements storage.CompactionSnapshotStore.
+func (s *DoltStore) ClearCompaction(ctx context.Context, issueID string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.ClearCompactionInTx(ctx, tx, issueID)
+	})
+}
+
+// SnapshotUsage implements storage.SnapshotPruner.
+func (s *DoltStore) SnapshotUsage(ctx context.Context) ([]*types.SnapshotTableUsage, error) {
+	var result []*types.SnapshotTableUsage
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SnapshotUsageInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// PruneSnapshots implements storage.SnapshotPruner.
+func (s *DoltStore) PruneSnapshots(ctx context.Context, retention storage.SnapshotRetention, now time.Time, dryRun bool) ([]*types.SnapshotPruneResult, error) {
+	var result []*types.SnapshotPruneResult
+	run := s.withRetryTx
+	if dryRun {
+		run = s.withReadTx
+	}
+	err := run(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.PruneSnapshotsInTx(ctx, tx, retention, now, dryRun)
+		return err
+	})
+	return result, err
+}
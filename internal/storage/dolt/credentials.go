@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -28,9 +29,11 @@ const credentialKeyFile = ".beads-credential-key" //nolint:gosec // G101: not a
 
 const awsResponseChecksumValidationEnv = "AWS_RESPONSE_CHECKSUM_VALIDATION"
 
-// federationEnvMutex protects process-wide env vars from concurrent access.
-// Environment variables are process-global, so we need to serialize federation operations.
-var federationEnvMutex sync.Mutex
+// remoteEnvMutex protects the process-wide S3 checksum env var from concurrent
+// access. Credentials never go through the process environment: they are
+// scoped to a single dolt subprocess via remoteCredentials.applyToCmd, so peer
+// syncs do not serialize on this lock.
+var remoteEnvMutex sync.Mutex
 
 // validPeerNameRegex matches valid peer names (alphanumeric, hyphens, underscores)
 var validPeerNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
@@ -125,6 +128,71 @@ func (s *DoltStore) ensureCredentialKey(ctx context.Context) error {
 	return s.initCredentialKey(ctx)
 }
 
+// MigrateCredentialKey re-runs credential key initialization: it moves a key
+// left in the old dbPath location into beadsDir, or generates a key and
+// re-encrypts passwords stored under the legacy dbPath-derived key. The key
+// file's permissions are reset to 0600. Used by bd doctor --fix.
+func (s *DoltStore) MigrateCredentialKey(ctx context.Context) error {
+	if s.beadsDir == "" {
+		return fmt.Errorf("credential key migration requires a beads directory")
+	}
+	s.mu.Lock()
+	s.credentialKey = nil
+	err := s.initCredentialKey(ctx)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	keyPath := filepath.Join(s.beadsDir, credentialKeyFile)
+	if err := os.Chmod(keyPath, 0600); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to set credential key permissions: %w", err)
+	}
+	return nil
+}
+
+// UndecryptableFederationPeers returns the names of peers whose stored
+// password cannot be decrypted with the credential key in beadsDir. Unlike
+// ensureCredentialKey it never generates a key, so a missing key file reports
+// every peer with a stored password.
+func (s *DoltStore) UndecryptableFederationPeers(ctx context.Context) ([]string, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT name, password_encrypted FROM federation_peers
+		WHERE password_encrypted IS NOT NULL AND LENGTH(password_encrypted) > 0
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federation peer credentials: %w", err)
+	}
+	defer rows.Close()
+
+	s.mu.RLock()
+	key := s.credentialKey
+	s.mu.RUnlock()
+	if key == nil && s.beadsDir != "" {
+		key, _ = os.ReadFile(filepath.Join(s.beadsDir, credentialKeyFile)) //nolint:gosec // G304: path is derived from trusted beadsDir
+		if len(key) != 32 {
+			key = nil
+		}
+	}
+
+	var bad []string
+	for rows.Next() {
+		var name string
+		var encrypted []byte
+		if err := rows.Scan(&name, &encrypted); err != nil {
+			return nil, fmt.Errorf("failed to scan federation peer credentials: %w", err)
+		}
+		if key == nil {
+			bad = append(bad, name)
+			continue
+		}
+		if _, err := decryptWithKey(encrypted, key); err != nil {
+			bad = append(bad, name)
+		}
+	}
+	return bad, rows.Err()
+}
+
 // legacyEncryptionKey derives the old predictable key from dbPath.
 // Used only during migration from the old key derivation scheme.
 func (s *DoltStore) legacyEncryptionKey() []byte {
@@ -267,6 +335,9 @@ func (s *DoltStore) AddFederationPeer(ctx context.Context, peer *storage.Federat
 	if err := validatePeerName(peer.Name); err != nil {
 		return fmt.Errorf("invalid peer name: %w", err)
 	}
+	if err := storage.ValidatePeerDirection(peer.Direction); err != nil {
+		return err
+	}
 
 	// Encrypt password before storing
 	var encryptedPwd []byte
@@ -283,15 +354,16 @@ func (s *DoltStore) AddFederationPeer(ctx context.Context, peer *storage.Federat
 
 	// Upsert the peer credentials
 	_, err = s.execContext(ctx, `
-		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty, direction)
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			remote_url = VALUES(remote_url),
 			username = VALUES(username),
 			password_encrypted = VALUES(password_encrypted),
 			sovereignty = VALUES(sovereignty),
+			direction = VALUES(direction),
 			updated_at = CURRENT_TIMESTAMP
-	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty)
+	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty, peer.Direction)
 
 	if err != nil {
 		return fmt.Errorf("failed to add federation peer: %w", err)
@@ -317,9 +389,9 @@ func (s *DoltStore) GetFederationPeer(ctx context.Context, name string) (*storag
 	var username sql.NullString
 
 	err := s.db.QueryRowContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, sovereignty, direction, last_sync, created_at, updated_at
 		FROM federation_peers WHERE name = ?
-	`, name).Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &lastSync, &peer.CreatedAt, &peer.UpdatedAt)
+	`, name).Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &peer.Direction, &lastSync, &peer.CreatedAt, &peer.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("%w: federation peer %s", storage.ErrNotFound, name)
@@ -352,7 +424,7 @@ func (s *DoltStore) GetFederationPeer(ctx context.Context, name string) (*storag
 // ListFederationPeers returns all configured federation peers.
 func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.FederationPeer, error) {
 	rows, err := s.queryContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, sovereignty, direction, last_sync, created_at, updated_at
 		FROM federation_peers ORDER BY name
 	`)
 	if err != nil {
@@ -367,7 +439,7 @@ func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.Federat
 		var lastSync sql.NullTime
 		var username sql.NullString
 
-		if err := rows.Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &lastSync, &peer.CreatedAt, &peer.UpdatedAt); err != nil {
+		if err := rows.Scan(&peer.Name, &peer.RemoteURL, &username, &encryptedPwd, &peer.Sovereignty, &peer.Direction, &lastSync, &peer.CreatedAt, &peer.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan federation peer: %w", err)
 		}
 
@@ -421,8 +493,11 @@ func (s *DoltStore) updatePeerLastSync(ctx context.Context, name string) error {
 }
 
 // remoteCredentials holds authentication credentials for a Dolt remote.
-// Used to pass credentials to CLI subprocesses via cmd.Env (isolated) or to
-// the SQL path via process env vars under mutex protection.
+// Credentials are passed per call: the password only ever reaches a dolt CLI
+// subprocess via cmd.Env, and the SQL path passes just the username through
+// the procedure's --user flag. Nothing is written to the process environment,
+// so concurrent operations against different remotes cannot observe each
+// other's secrets and child processes do not inherit them.
 type remoteCredentials struct {
 	username string
 	password string
@@ -497,24 +572,6 @@ func applyNoGitHooksToCmd(cmd *exec.Cmd) {
 	setCmdEnv(cmd, "GIT_CONFIG_PARAMETERS", "'core.hooksPath=/dev/null'")
 }
 
-// setFederationCredentials sets DOLT_REMOTE_USER and DOLT_REMOTE_PASSWORD env vars.
-// Returns a cleanup function that must be called (typically via defer) to unset them.
-// The caller must hold federationEnvMutex.
-// Only used for SQL-path operations where the in-process Dolt server reads from
-// the process environment. CLI operations should use remoteCredentials.applyToCmd instead.
-func setFederationCredentials(username, password string) func() {
-	if username != "" {
-		_ = os.Setenv("DOLT_REMOTE_USER", username) // Best effort: Setenv failure is extremely rare in practice
-	}
-	if password != "" {
-		_ = os.Setenv("DOLT_REMOTE_PASSWORD", password) // Best effort: Setenv failure is extremely rare in practice
-	}
-	return func() {
-		_ = os.Unsetenv("DOLT_REMOTE_USER")     // Best effort cleanup of auth env vars
-		_ = os.Unsetenv("DOLT_REMOTE_PASSWORD") // Best effort cleanup of auth env vars
-	}
-}
-
 func setS3ChecksumEnv() func() {
 	prev, hadPrev := os.LookupEnv(awsResponseChecksumValidationEnv)
 	_ = os.Setenv(awsResponseChecksumValidationEnv, "when_required")
@@ -527,64 +584,80 @@ func setS3ChecksumEnv() func() {
 	}
 }
 
-func withRemoteOperationEnv(creds *remoteCredentials, s3Checksum bool, fn func() error) error {
-	if creds.empty() && !s3Checksum {
+// withRemoteOperationEnv executes fn with the S3 checksum env var applied when
+// s3Checksum is set. The in-process AWS SDK used by the SQL path reads it from
+// the process environment, so it is the one remote setting that still has to
+// be set process-wide under remoteEnvMutex.
+func withRemoteOperationEnv(s3Checksum bool, fn func() error) error {
+	if !s3Checksum {
 		return fn()
 	}
-	federationEnvMutex.Lock()
-	defer federationEnvMutex.Unlock()
+	remoteEnvMutex.Lock()
+	defer remoteEnvMutex.Unlock()
 
-	var cleanups []func()
-	if !creds.empty() {
-		cleanups = append(cleanups, setFederationCredentials(creds.username, creds.password))
-	}
-	if s3Checksum {
-		cleanups = append(cleanups, setS3ChecksumEnv())
-	}
-	defer func() {
-		for i := len(cleanups) - 1; i >= 0; i-- {
-			cleanups[i]()
-		}
-	}()
+	cleanup := setS3ChecksumEnv()
+	defer cleanup()
 
 	return fn()
 }
 
-// withEnvCredentials executes fn with credentials set as process-wide env vars,
-// protected by federationEnvMutex. This is required for SQL-path operations
-// (CALL DOLT_PUSH/PULL) where the in-process Dolt server reads credentials
-// from the process environment. CLI operations should NOT use this — use
-// remoteCredentials.applyToCmd instead for race-free subprocess isolation.
-func withEnvCredentials(creds *remoteCredentials, fn func() error) error {
-	return withRemoteOperationEnv(creds, false, fn)
+// errPasswordNeedsCLI is returned when password credentials would have to go
+// through the SQL path, which has no way to pass them.
+var errPasswordNeedsCLI = errors.New("password auth requires the dolt CLI route")
+
+// remoteProcCall builds a CALL statement for a Dolt remote procedure
+// (DOLT_PUSH, DOLT_PULL, DOLT_FETCH), prefixing --user when a username is set.
+// The procedures take no password argument, so credentials carrying one fail
+// with errPasswordNeedsCLI rather than silently authenticating without it;
+// callers route those through the CLI instead.
+func (c *remoteCredentials) remoteProcCall(proc string, args ...any) (string, []any, error) {
+	if !c.empty() && c.password != "" {
+		return "", nil, fmt.Errorf("CALL %s: %w", proc, errPasswordNeedsCLI)
+	}
+	var callArgs []any
+	if !c.empty() && c.username != "" {
+		callArgs = append(callArgs, "--user", c.username)
+	}
+	callArgs = append(callArgs, args...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(callArgs)), ", ")
+	return "CALL " + proc + "(" + placeholders + ")", callArgs, nil
 }
 
 // withPeerCredentials looks up credentials for a federation peer and passes
 // them to fn. The callback receives the credentials and is responsible for
-// applying them appropriately: CLI operations use creds.applyToCmd for
-// subprocess isolation; SQL operations use withEnvCredentials for mutex-protected
-// process env access.
+// applying them per call: CLI operations use creds.applyToCmd for subprocess
+// isolation; SQL operations build their CALL with creds.remoteProcCall. No
+// process-global state is touched, so syncs with different peers can run
+// concurrently.
 func (s *DoltStore) withPeerCredentials(ctx context.Context, peerName string, fn func(creds *remoteCredentials) error) error {
-	peer, err := s.GetFederationPeer(ctx, peerName)
+	creds, err := s.peerCredentials(ctx, peerName)
 	if err != nil {
-		return fmt.Errorf("failed to get peer credentials: %w", err)
-	}
-
-	var creds *remoteCredentials
-	if peer != nil && (peer.Username != "" || peer.Password != "") {
-		creds = &remoteCredentials{username: peer.Username, password: peer.Password}
+		return err
 	}
 
 	err = fn(creds)
 
 	// Update last sync time on success
-	if err == nil && peer != nil {
+	if err == nil {
 		_ = s.updatePeerLastSync(ctx, peerName) // Best effort: peer sync timestamp is advisory
 	}
 
 	return err
 }
 
+// peerCredentials looks up the stored credentials for a federation peer.
+// Returns nil credentials when the peer has neither a username nor a password.
+func (s *DoltStore) peerCredentials(ctx context.Context, peerName string) (*remoteCredentials, error) {
+	peer, err := s.GetFederationPeer(ctx, peerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer credentials: %w", err)
+	}
+	if peer.Username == "" && peer.Password == "" {
+		return nil, nil
+	}
+	return &remoteCredentials{username: peer.Username, password: peer.Password}, nil
+}
+
 // FederationPeer is an alias for storage.FederationPeer for convenience.
 type FederationPeer = storage.FederationPeer
 
@@ -593,7 +666,7 @@ func (s *DoltStore) prepareCLIRouteForPeerCredentials(ctx context.Context, peer
 		return false, nil // no credentials to pass
 	}
 	if !s.serverMode {
-		return false, nil // embedded mode: withEnvCredentials works in-process
+		return false, nil // embedded mode: the SQL path runs in-process
 	}
 	if !s.hasCLIDatabase() {
 		return false, nil
@@ -621,8 +694,9 @@ func (s *DoltStore) shouldUseCLIForPeerCredentialsWithError(ctx context.Context,
 // be used instead of SQL path for credential-bearing push/pull operations.
 //
 // When true, callers should route through doltCLIPush/Pull instead of
-// CALL DOLT_PUSH/PULL, because withEnvCredentials() sets env vars on the
-// bd client process — the external server process cannot see them.
+// CALL DOLT_PUSH/PULL, because the SQL path can only forward the username —
+// the password must reach a dolt process through its environment, and only a
+// subprocess started by bd can be given one per call.
 //
 // Returns true when ALL conditions are met:
 //  1. Credentials exist (remoteUser or remotePassword non-empty)
@@ -643,7 +717,7 @@ func (s *DoltStore) prepareCLIRouteForCredentials(ctx context.Context, remote st
 		return false, nil // no credentials to pass
 	}
 	if !s.serverMode {
-		return false, nil // embedded mode: withEnvCredentials works in-process
+		return false, nil // embedded mode: the SQL path runs in-process
 	}
 	if !s.hasCLIDatabase() {
 		return false, nil
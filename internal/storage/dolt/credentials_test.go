@@ -2,6 +2,7 @@ package dolt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -145,7 +146,7 @@ func TestApplyNoGitHooksToCmdComposesWithCredentials(t *testing.T) {
 func TestWithRemoteOperationEnvRestoresS3ChecksumEnv(t *testing.T) {
 	t.Setenv(awsResponseChecksumValidationEnv, "when_supported")
 
-	err := withRemoteOperationEnv(nil, true, func() error {
+	err := withRemoteOperationEnv(true, func() error {
 		if got := os.Getenv(awsResponseChecksumValidationEnv); got != "when_required" {
 			t.Fatalf("%s during operation = %q, want when_required", awsResponseChecksumValidationEnv, got)
 		}
@@ -165,7 +166,7 @@ func TestWithRemoteOperationEnvUnsetsS3ChecksumEnv(t *testing.T) {
 		t.Fatalf("unset %s: %v", awsResponseChecksumValidationEnv, err)
 	}
 
-	err := withRemoteOperationEnv(nil, true, func() error {
+	err := withRemoteOperationEnv(true, func() error {
 		if got := os.Getenv(awsResponseChecksumValidationEnv); got != "when_required" {
 			t.Fatalf("%s during operation = %q, want when_required", awsResponseChecksumValidationEnv, got)
 		}
@@ -871,3 +872,36 @@ func TestEnvPrefixesForRemoteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteProcCallPassesUserWithoutProcessEnv(t *testing.T) {
+	t.Setenv("DOLT_REMOTE_USER", "")
+	t.Setenv("DOLT_REMOTE_PASSWORD", "")
+
+	creds := &remoteCredentials{username: "alice"}
+	query, args, err := creds.remoteProcCall("DOLT_PUSH", "peer", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "CALL DOLT_PUSH(?, ?, ?, ?)" {
+		t.Fatalf("query = %q", query)
+	}
+	if fmt.Sprint(args) != "[--user alice peer main]" {
+		t.Fatalf("args = %v", args)
+	}
+
+	// A password cannot be passed to the procedure, so the SQL route refuses
+	// it instead of authenticating with the username alone.
+	creds.password = "secret"
+	if _, _, err := creds.remoteProcCall("DOLT_PUSH", "peer", "main"); !errors.Is(err, errPasswordNeedsCLI) {
+		t.Fatalf("password creds: err = %v, want errPasswordNeedsCLI", err)
+	}
+	if os.Getenv("DOLT_REMOTE_USER") != "" || os.Getenv("DOLT_REMOTE_PASSWORD") != "" {
+		t.Fatal("remoteProcCall must not touch the process environment")
+	}
+
+	var nilCreds *remoteCredentials
+	query, args, err = nilCreds.remoteProcCall("DOLT_FETCH", "peer")
+	if err != nil || query != "CALL DOLT_FETCH(?)" || len(args) != 1 {
+		t.Fatalf("nil creds: query = %q args = %v", query, args)
+	}
+}
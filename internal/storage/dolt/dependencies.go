@@ -266,6 +266,42 @@ func (s *DoltStore) GetDependencyCounts(ctx context.Context, issueIDs []string)
 	return result, err
 }
 
+// GetChildProgress returns parent-child roll-up counts for multiple parents.
+// Delegates to issueops.GetChildProgressInTx for shared query logic.
+func (s *DoltStore) GetChildProgress(ctx context.Context, parentIDs []string) (map[string]*types.ChildProgress, error) {
+	var result map[string]*types.ChildProgress
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetChildProgressInTx(ctx, tx, parentIDs)
+		return err
+	})
+	return result, err
+}
+
+// GetHierarchyRollup returns recursive roll-ups for rootID's subtree.
+// Delegates to issueops.GetHierarchyRollupInTx for shared query logic.
+func (s *DoltStore) GetHierarchyRollup(ctx context.Context, rootID string) (map[string]*types.HierarchyRollup, error) {
+	var result map[string]*types.HierarchyRollup
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetHierarchyRollupInTx(ctx, tx, rootID)
+		return err
+	})
+	return result, err
+}
+
+// GetUnblockImpact returns downstream blocked-work counts per open blocker.
+// Delegates to issueops.GetUnblockImpactInTx for shared query logic.
+func (s *DoltStore) GetUnblockImpact(ctx context.Context) (map[string]*types.UnblockImpact, error) {
+	var result map[string]*types.UnblockImpact
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetUnblockImpactInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
 // GetDependencyTree returns a dependency tree for visualization
 func (s *DoltStore) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
 	var result []*types.TreeNode
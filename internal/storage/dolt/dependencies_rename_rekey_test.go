@@ -45,7 +45,7 @@ func TestUpdateIssueIDRekeysDependencySource(t *testing.T) {
 	}
 
 	// Rename the source issue rk-old -> rk-new.
-	if err := store.UpdateIssueID(ctx, "rk-old", "rk-new", &types.Issue{ID: "rk-new", Title: "rk-new"}, "alice"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, "rk-old", "rk-new", &types.Issue{ID: "rk-new", Title: "rk-new"}, "alice"); err != nil {
 		t.Fatalf("UpdateIssueID: %v", err)
 	}
 
@@ -75,7 +75,7 @@ func (s *DoltStore) ImportIssueComment(ctx context.Context, issueID, author, tex
 	if isWisp {
 		return result, nil
 	}
-	if err := s.doltAddAndCommit(ctx, []string{"comments"}, fmt.Sprintf("bd: comment %s", issueID)); err != nil {
+	if err := s.doltAddAndCommit(ctx, []string{"comments", "issue_references"}, fmt.Sprintf("bd: comment %s", issueID)); err != nil {
 		return nil, err
 	}
 	return result, nil
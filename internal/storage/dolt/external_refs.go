@@ -0,0 +1,27 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetExternalRefResolved implements storage.ExternalRefStore.
+func (s *DoltStore) SetExternalRefResolved(ctx context.Context, url string, resolved bool, actor string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.SetExternalRefResolvedInTx(ctx, tx, url, resolved, actor)
+	})
+}
+
+// GetExternalRefs implements storage.ExternalRefStore.
+func (s *DoltStore) GetExternalRefs(ctx context.Context, urls []string) (map[string]*types.ExternalRef, error) {
+	var result map[string]*types.ExternalRef
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetExternalRefsInTx(ctx, tx, urls)
+		return err
+	})
+	return result, err
+}
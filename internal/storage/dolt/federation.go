@@ -2,6 +2,7 @@ package dolt
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
@@ -16,7 +17,7 @@ import (
 )
 
 // federationStagingBranch is the temporary branch used to filter excluded
-// issue types before pushing to a federation peer.
+// issue types and tables before pushing to a federation peer.
 const federationStagingBranch = "__federation_push_staging"
 
 // FederatedStorage implementation for DoltStore
@@ -26,9 +27,22 @@ const federationStagingBranch = "__federation_push_staging"
 // If credentials are stored for this peer, they are used automatically.
 // For git-protocol remotes, uses CLI `dolt push` to avoid MySQL connection timeouts.
 func (s *DoltStore) PushTo(ctx context.Context, peer string) error {
+	if err := s.checkPeerDirection(ctx, peer, true); err != nil {
+		return err
+	}
 	return s.pushRefToPeer(ctx, peer, s.branch)
 }
 
+// checkPeerDirection refuses a pull from a push-only peer or a push to a
+// pull-only one.
+func (s *DoltStore) checkPeerDirection(ctx context.Context, peer string, push bool) error {
+	direction, err := issueops.PeerDirection(ctx, s.db, peer)
+	if err != nil {
+		return err
+	}
+	return storage.CheckPeerDirection(peer, direction, push)
+}
+
 // pushRefToPeer pushes a specific refspec to a peer remote. The refspec can be
 // a simple branch name ("main") or a mapping ("staging:main").
 func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec string) error {
@@ -45,12 +59,14 @@ func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec stri
 		} else if useCLI {
 			return s.doltCLIPushRefToPeer(ctx, peer, refspec, creds)
 		}
-		return withEnvCredentials(creds, func() error {
-			if err := s.execWithLongTimeout(ctx, "CALL DOLT_PUSH(?, ?)", peer, refspec); err != nil {
-				return fmt.Errorf("failed to push to peer %s: %w", peer, err)
-			}
-			return nil
-		})
+		query, args, err := creds.remoteProcCall("DOLT_PUSH", peer, refspec)
+		if err != nil {
+			return fmt.Errorf("failed to push to peer %s: %w", peer, err)
+		}
+		if err := s.execWithLongTimeout(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to push to peer %s: %w", peer, err)
+		}
+		return nil
 	})
 }
 
@@ -59,6 +75,10 @@ func (s *DoltStore) pushRefToPeer(ctx context.Context, peer string, refspec stri
 // For git-protocol remotes, uses CLI `dolt pull` to avoid MySQL connection timeouts.
 // Returns any merge conflicts if present.
 func (s *DoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	if err := s.checkPeerDirection(ctx, peer, false); err != nil {
+		return nil, err
+	}
+
 	// GH#2474: Auto-commit pending changes before pull to prevent
 	// "cannot merge with uncommitted changes" errors.
 	if !s.readOnly {
@@ -104,10 +124,12 @@ func (s *DoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Confli
 			pullErr := s.finishCLIPull(ctx, s.doltCLIPullFromPeer(ctx, peer, creds))
 			return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
 		}
-		return withEnvCredentials(creds, func() error {
-			pullErr := s.pullWithAutoResolve(ctx, peer, "CALL DOLT_PULL(?)", peer)
-			return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
-		})
+		query, args, err := creds.remoteProcCall("DOLT_PULL", peer)
+		if err != nil {
+			return fmt.Errorf("failed to pull from peer %s: %w", peer, err)
+		}
+		pullErr := s.pullWithAutoResolve(ctx, peer, query, args...)
+		return s.peerPullOutcome(ctx, peer, pullErr, &conflicts)
 	})
 	return s.finishPeerPull(ctx, conflicts, err, preHead)
 }
@@ -153,27 +175,56 @@ func (s *DoltStore) finishPeerPull(ctx context.Context, conflicts []storage.Conf
 // If credentials are stored for this peer, they are used automatically.
 // For git-protocol remotes, uses CLI `dolt fetch` to avoid MySQL connection timeouts.
 func (s *DoltStore) Fetch(ctx context.Context, peer string) error {
+	return s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
+		return s.fetchFromPeer(ctx, peer, creds)
+	})
+}
+
+// ProbePeer fetches from a peer with its stored credentials without recording
+// a sync. Doctor uses it to verify that the remote resolves and the
+// credentials authenticate without making the peer look freshly synced.
+func (s *DoltStore) ProbePeer(ctx context.Context, peer string) error {
+	creds, err := s.peerCredentials(ctx, peer)
+	if err != nil {
+		return err
+	}
+	return s.fetchFromPeer(ctx, peer, creds)
+}
+
+// PeerWispCounts reports wisp data in a peer's last-fetched branch, keyed by
+// table. Doctor uses it to flag peers that are pushing wisps. Returns nil when
+// the peer has not been fetched.
+func (s *DoltStore) PeerWispCounts(ctx context.Context, peer string) (map[string]int, error) {
+	var counts map[string]int
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		counts, err = issueops.PeerWispCountsInTx(ctx, tx, peer)
+		return err
+	})
+	return counts, err
+}
+
+// fetchFromPeer routes a peer fetch through the CLI or SQL path.
+func (s *DoltStore) fetchFromPeer(ctx context.Context, peer string, creds *remoteCredentials) error {
 	if useCLI, err := s.prepareCLIRouteForPeerGitProtocol(ctx, peer); err != nil {
 		return err
 	} else if useCLI {
-		return s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
-			return s.doltCLIFetchFromPeer(ctx, peer, creds)
-		})
+		return s.doltCLIFetchFromPeer(ctx, peer, creds)
 	}
-	return s.withPeerCredentials(ctx, peer, func(creds *remoteCredentials) error {
-		// Credential CLI routing: route fetch through CLI subprocess.
-		if useCLI, err := s.prepareCLIRouteForPeerCredentials(ctx, peer, creds); err != nil {
-			return err
-		} else if useCLI {
-			return s.doltCLIFetchFromPeer(ctx, peer, creds)
-		}
-		return withEnvCredentials(creds, func() error {
-			if err := s.execWithLongTimeout(ctx, "CALL DOLT_FETCH(?)", peer); err != nil {
-				return fmt.Errorf("failed to fetch from peer %s: %w", peer, err)
-			}
-			return nil
-		})
-	})
+	// Credential CLI routing: route fetch through CLI subprocess.
+	if useCLI, err := s.prepareCLIRouteForPeerCredentials(ctx, peer, creds); err != nil {
+		return err
+	} else if useCLI {
+		return s.doltCLIFetchFromPeer(ctx, peer, creds)
+	}
+	query, args, err := creds.remoteProcCall("DOLT_FETCH", peer)
+	if err != nil {
+		return fmt.Errorf("failed to fetch from peer %s: %w", peer, err)
+	}
+	if err := s.execWithLongTimeout(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to fetch from peer %s: %w", peer, err)
+	}
+	return nil
 }
 
 // ListRemotes returns configured remote names and URLs.
@@ -310,6 +361,24 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 		Peer:      peer,
 		StartTime: time.Now(),
 	}
+	direction, err := issueops.PeerDirection(ctx, s.db, peer)
+	if err != nil {
+		result.Error = err
+		return result, err
+	}
+	result.Direction = direction
+
+	// A push-only peer is never merged from: push and stop.
+	if direction == storage.PeerDirectionPushOnly {
+		if err := s.filteredPushToPeer(ctx, peer, config.GetFederationPeerFilter(peer)); err != nil {
+			result.Error = fmt.Errorf("push failed: %w", err)
+			return result, result.Error
+		}
+		result.Pushed = true
+		_ = s.setLastSyncTime(ctx, peer) // Best effort: sync timestamp is advisory for scheduling
+		result.EndTime = time.Now()
+		return result, nil
+	}
 
 	// Step 1: Fetch from peer
 	if err := s.Fetch(ctx, peer); err != nil {
@@ -370,13 +439,15 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 		result.PulledCommits = 1 // Simplified - could count actual commits
 	}
 
-	// Step 5: Push our changes to peer, filtering excluded types.
-	excludeTypes := config.GetFederationConfig().ExcludeTypes
-	if err := s.filteredPushToPeer(ctx, peer, excludeTypes); err != nil {
-		// Push failure is not fatal - peer may not accept pushes
-		result.PushError = err
-	} else {
-		result.Pushed = true
+	// Step 5: Push our changes to peer, filtering excluded types and tables.
+	// A pull-only peer is an upstream we consume and never push to.
+	if direction != storage.PeerDirectionPullOnly {
+		if err := s.filteredPushToPeer(ctx, peer, config.GetFederationPeerFilter(peer)); err != nil {
+			// Push failure is not fatal - peer may not accept pushes
+			result.PushError = err
+		} else {
+			result.Pushed = true
+		}
 	}
 
 	// Record last sync time
@@ -386,18 +457,20 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 	return result, nil
 }
 
-// filteredPushToPeer pushes to a peer after filtering out excluded issue types.
-// When excludeTypes is empty, delegates directly to PushTo (no filtering).
+// filteredPushToPeer pushes to a peer after filtering out excluded issue types
+// and tables. When the filter is empty, delegates directly to PushTo (no
+// filtering).
 //
-// For non-empty excludeTypes, the method creates a temporary staging branch,
-// deletes matching issues, commits the filtered state, and pushes the staging
-// branch to the peer using a refspec. The staging branch is always cleaned up.
+// Otherwise the method creates a temporary staging branch, deletes matching
+// issues and the rows of matching tracked tables, commits the filtered state,
+// and pushes the staging branch to the peer using a refspec. The staging
+// branch is always cleaned up.
 //
 // The special type "wisp" matches issues with ephemeral=true in the committed
 // issues table. Wisps normally live in dolt_ignore'd tables and are not pushed,
-// so this acts as a defense-in-depth safety net.
-func (s *DoltStore) filteredPushToPeer(ctx context.Context, peer string, excludeTypes []string) error {
-	if len(excludeTypes) == 0 {
+// so this and the wisp table patterns act as a defense-in-depth safety net.
+func (s *DoltStore) filteredPushToPeer(ctx context.Context, peer string, filter config.FederationPeerFilter) error {
+	if filter.Empty() {
 		return s.PushTo(ctx, peer)
 	}
 
@@ -429,7 +502,7 @@ func (s *DoltStore) filteredPushToPeer(ctx context.Context, peer string, exclude
 
 	// Delete excluded issues from the committed issues table.
 	deleted := false
-	for _, excludeType := range excludeTypes {
+	for _, excludeType := range filter.ExcludeTypes {
 		var result interface{ RowsAffected() (int64, error) }
 		var execErr error
 		if excludeType == "wisp" {
@@ -444,9 +517,31 @@ func (s *DoltStore) filteredPushToPeer(ctx context.Context, peer string, exclude
 		}
 	}
 
+	// Empty excluded tables. Only tables tracked on the staging branch can
+	// reach the peer; untracked (dolt_ignore'd) tables are left alone.
+	if len(filter.ExcludeTables) > 0 {
+		tables, err := issueops.TrackedTablesAsOf(ctx, conn, federationStagingBranch)
+		if err != nil {
+			return fmt.Errorf("federation filter: %w", err)
+		}
+		for _, table := range tables {
+			if !issueops.MatchesTablePattern(table, filter.ExcludeTables) {
+				continue
+			}
+			//nolint:gosec // G201: table comes from SHOW TABLES, not user input.
+			result, err := conn.ExecContext(ctx, fmt.Sprintf("DELETE FROM `%s`", table))
+			if err != nil {
+				return fmt.Errorf("federation filter: exclude table %s: %w", table, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				deleted = true
+			}
+		}
+	}
+
 	if deleted {
 		if _, err := conn.ExecContext(ctx, "CALL DOLT_COMMIT('-Am', ?)",
-			"federation: exclude private issue types"); err != nil {
+			"federation: exclude private issue types and tables"); err != nil {
 			return fmt.Errorf("federation filter: commit filtered state: %w", err)
 		}
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -474,7 +475,7 @@ func TestFilteredPushExcludesWisp(t *testing.T) {
 	// Run filteredPushToPeer — push will fail (no remote) but the staging
 	// branch logic runs first. We verify the staging branch behavior by
 	// checking that the original branch is untouched afterward.
-	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", []string{"wisp"})
+	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", config.FederationPeerFilter{ExcludeTypes: []string{"wisp"}})
 	if pushErr == nil {
 		t.Fatal("expected push error for nonexistent peer")
 	}
@@ -508,8 +509,8 @@ func TestFilteredPushExcludesWisp(t *testing.T) {
 	}
 }
 
-// TestFilteredPushOptOut verifies that setting federation.exclude_types to
-// an empty list disables filtering (backward-compatible opt-out).
+// TestFilteredPushOptOut verifies that an empty filter (allow_wisps with no
+// exclude lists) disables filtering.
 func TestFilteredPushOptOut(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -534,7 +535,7 @@ func TestFilteredPushOptOut(t *testing.T) {
 	// With empty exclude list, filteredPushToPeer delegates directly to PushTo
 	// (no staging branch created). It will fail due to no remote, but the
 	// important thing is no staging branch is created.
-	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", []string{})
+	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", config.FederationPeerFilter{})
 	if pushErr == nil {
 		t.Fatal("expected push error for nonexistent peer")
 	}
@@ -592,7 +593,7 @@ func TestFilteredPushExcludesCustomType(t *testing.T) {
 	}
 
 	// Exclude "message" type — task should remain, message should be filtered.
-	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", []string{"message"})
+	pushErr := store.filteredPushToPeer(ctx, "nonexistent-peer", config.FederationPeerFilter{ExcludeTypes: []string{"message"}})
 	if pushErr == nil {
 		t.Fatal("expected push error for nonexistent peer")
 	}
@@ -620,7 +621,7 @@ func TestFilteredPushStagingBranchCleanupOnError(t *testing.T) {
 
 	// Run filtered push to a nonexistent peer — will fail, but staging
 	// branch should still be cleaned up.
-	_ = store.filteredPushToPeer(ctx, "no-such-peer", []string{"wisp", "message"})
+	_ = store.filteredPushToPeer(ctx, "no-such-peer", config.FederationPeerFilter{ExcludeTypes: []string{"wisp", "message"}})
 
 	branches, err := store.ListBranches(ctx)
 	if err != nil {
@@ -1378,8 +1378,8 @@ func TestSQLRemotePersistsAcrossExternalServerRestart(t *testing.T) {
 //
 // The test proves routing works end-to-end: if shouldUseCLIForCredentials
 // routes to doltCLIPush, the CLI uses the file:// remote and push succeeds.
-// If the guard fails and falls through to SQL withEnvCredentials, the external
-// server process cannot see the env vars and push fails (SC-001).
+// If the guard fails and falls through to SQL CALL DOLT_PUSH('--user', ...), the external
+// server never receives the password and push fails (SC-001).
 func TestCredentialCLIRoutingE2E(t *testing.T) {
 	testutil.RequireDoltBinary(t)
 
@@ -1496,8 +1496,8 @@ func TestCredentialCLIRoutingE2E(t *testing.T) {
 
 	// 7. Push should succeed via CLI credential routing
 	// If the guard works: doltCLIPush uses CLI dir's file:// remote → success
-	// If guard fails: withEnvCredentials + SQL CALL DOLT_PUSH('--user',...) → fails
-	// (external server can't see env vars set on bd client process)
+	// If guard fails: SQL CALL DOLT_PUSH('--user',...) → fails
+	// (external server never receives the password)
 	err = store.Push(ctx)
 	require.NoError(t, err, "Push should succeed via CLI credential routing (SC-001)")
 }
@@ -0,0 +1,45 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LockIssue implements storage.LockStore.
+func (s *DoltStore) LockIssue(ctx context.Context, lock *types.IssueLock, force bool) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.LockIssueInTx(ctx, tx, lock, force)
+	})
+}
+
+// UnlockIssue implements storage.LockStore.
+func (s *DoltStore) UnlockIssue(ctx context.Context, issueID, actor string, force bool) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.UnlockIssueInTx(ctx, tx, issueID, actor, force)
+	})
+}
+
+// GetIssueLock implements storage.LockStore.
+func (s *DoltStore) GetIssueLock(ctx context.Context, issueID string) (*types.IssueLock, error) {
+	var result *types.IssueLock
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueLockInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// ListIssueLocks implements storage.LockStore.
+func (s *DoltStore) ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error) {
+	var result []*types.IssueLock
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListIssueLocksInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
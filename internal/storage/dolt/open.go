@@ -255,6 +255,28 @@ func applyResolvedConfig(beadsDir string, fileCfg *configfile.Config, cfg *Confi
 			}
 		}
 	}
+
+	// metadata.json pool settings fill whatever is still unset.
+	if cfg.MaxOpenConns == 0 {
+		cfg.MaxOpenConns = fileCfg.DoltMaxOpenConns
+	}
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = fileCfg.DoltMaxIdleConns
+	}
+	if cfg.ConnMaxLifetime == 0 {
+		cfg.ConnMaxLifetime = fileCfg.GetDoltConnMaxLifetime()
+	}
+
+	// Admission control for shared servers (config.yaml or BD_DOLT_* env).
+	if cfg.MaxClients == 0 {
+		cfg.MaxClients = config.GetInt("dolt.max-clients")
+	}
+	if cfg.RateLimit == "" {
+		cfg.RateLimit = config.GetString("dolt.rate-limit")
+	}
+	if cfg.QueueTimeout == 0 {
+		cfg.QueueTimeout = config.GetDuration("dolt.queue-timeout")
+	}
 }
 
 // applyCentralConfigDefaults loads the central server config from
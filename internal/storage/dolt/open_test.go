@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/doltserver"
@@ -317,4 +318,28 @@ func TestApplyResolvedConfig(t *testing.T) {
 			t.Fatalf("ServerUser override lost: %q", cfg.ServerUser)
 		}
 	})
+
+	t.Run("applies pool settings from metadata", func(t *testing.T) {
+		t.Setenv("BEADS_DOLT_MAX_CONNS", "")
+		beadsDir := t.TempDir()
+		fileCfg := &configfile.Config{
+			Backend:             configfile.BackendDolt,
+			DoltMaxOpenConns:    25,
+			DoltMaxIdleConns:    8,
+			DoltConnMaxLifetime: "15m",
+		}
+		cfg := &Config{}
+
+		applyResolvedConfig(beadsDir, fileCfg, cfg)
+
+		if cfg.MaxOpenConns != 25 {
+			t.Errorf("MaxOpenConns = %d, want 25", cfg.MaxOpenConns)
+		}
+		if cfg.MaxIdleConns != 8 {
+			t.Errorf("MaxIdleConns = %d, want 8", cfg.MaxIdleConns)
+		}
+		if cfg.ConnMaxLifetime != 15*time.Minute {
+			t.Errorf("ConnMaxLifetime = %v, want 15m", cfg.ConnMaxLifetime)
+		}
+	})
 }
@@ -0,0 +1,49 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddPatrol implements storage.PatrolStore.
+func (s *DoltStore) AddPatrol(ctx context.Context, patrol *types.Patrol) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.AddPatrolInTx(ctx, tx, patrol)
+	})
+}
+
+// RemovePatrol implements storage.PatrolStore.
+func (s *DoltStore) RemovePatrol(ctx context.Context, name string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RemovePatrolInTx(ctx, tx, name)
+	})
+}
+
+// GetPatrols implements storage.PatrolStore.
+func (s *DoltStore) GetPatrols(ctx context.Context) ([]*types.Patrol, error) {
+	var result []*types.Patrol
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetPatrolsInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// RecordPatrolRun implements storage.PatrolStore.
+func (s *DoltStore) RecordPatrolRun(ctx context.Context, name string, ranAt time.Time, wispID string, missed bool) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RecordPatrolRunInTx(ctx, tx, name, ranAt, wispID, missed)
+	})
+}
+
+// RecordPatrolCompletion implements storage.PatrolStore.
+func (s *DoltStore) RecordPatrolCompletion(ctx context.Context, name string, completedAt time.Time) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RecordPatrolCompletionInTx(ctx, tx, name, completedAt)
+	})
+}
@@ -0,0 +1,27 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// AgeIssuePriority implements storage.PriorityAger.
+func (s *DoltStore) AgeIssuePriority(ctx context.Context, issueID string, from, to int, reason, actor string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		if err := issueops.AgeIssuePriorityInTx(ctx, tx, issueID, from, to, reason, actor); err != nil {
+			return err
+		}
+		for _, table := range []string{"issues", "events"} {
+			_, _ = tx.ExecContext(ctx, "CALL DOLT_ADD(?)", table)
+		}
+		commitMsg := fmt.Sprintf("bd: age priority of %s", issueID)
+		if _, err := tx.ExecContext(ctx, "CALL DOLT_COMMIT('-m', ?, '--author', ?)",
+			commitMsg, s.commitAuthorString()); err != nil && !isDoltNothingToCommit(err) {
+			return fmt.Errorf("dolt commit: %w", err)
+		}
+		return nil
+	})
+}
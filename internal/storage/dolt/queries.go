@@ -83,33 +83,34 @@ func (s *DoltStore) GetStaleIssues(ctx context.Context, filter types.StaleFilter
 	return result, err
 }
 
-// GetStatistics returns summary statistics
+// GetStatistics returns summary statistics. Results are materialized in the
+// clone-local local_metadata table keyed by the issues table hash, so repeat
+// calls on an unchanged database skip the full-table scans.
 func (s *DoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error) {
-	stats := &types.Statistics{}
-
+	var stats *types.Statistics
+	var hash string
+	fresh := false
 	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
-		return issueops.ScanIssueCountsInTx(ctx, tx, stats)
+		hash = issueops.IssuesTableHashInTx(ctx, tx)
+		if stats = issueops.CachedStatisticsInTx(ctx, tx, hash); stats != nil {
+			return nil
+		}
+		var err error
+		stats, err = issueops.ComputeStatisticsInTx(ctx, tx)
+		fresh = true
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get statistics: %w", err)
 	}
 
-	var blockedCount int
-	if err := s.withReadTx(ctx, func(tx *sql.Tx) error {
-		return tx.QueryRowContext(ctx, `
-			SELECT COUNT(*) FROM issues
-			WHERE is_blocked = 1 AND status <> 'closed' AND status <> 'pinned'
-		`).Scan(&blockedCount)
-	}); err != nil {
-		return nil, fmt.Errorf("failed to count blocked issues: %w", err)
-	}
-	stats.BlockedIssues = blockedCount
-
-	stats.ReadyIssues = stats.OpenIssues - blockedCount
-	if stats.ReadyIssues < 0 {
-		stats.ReadyIssues = 0
+	// Best effort: a read-only server or missing local_metadata only costs
+	// the next caller a recompute.
+	if fresh && hash != "" {
+		if value, err := issueops.StatisticsCacheValue(hash, stats); err == nil {
+			_ = s.SetLocalMetadata(ctx, issueops.StatisticsCacheKey, value)
+		}
 	}
-
 	return stats, nil
 }
 
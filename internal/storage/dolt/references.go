@@ -0,0 +1,31 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetBacklinks implements storage.ReferenceStore.
+func (s *DoltStore) GetBacklinks(ctx context.Context, issueID string) ([]*types.IssueReference, error) {
+	var result []*types.IssueReference
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetBacklinksInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// GetMentions implements storage.ReferenceStore.
+func (s *DoltStore) GetMentions(ctx context.Context, actor string) ([]*types.IssueReference, error) {
+	var result []*types.IssueReference
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetMentionsInTx(ctx, tx, actor)
+		return err
+	})
+	return result, err
+}
@@ -8,9 +8,17 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// UpdateIssueID updates an issue ID and all its references.
-func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
-	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
-		return issueops.UpdateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
+// UpdateIssueID updates an issue ID and all its references, reporting the
+// rows moved per table.
+func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) (*types.RenameResult, error) {
+	var result *types.RenameResult
+	err := s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.UpdateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
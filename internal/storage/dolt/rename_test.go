@@ -56,7 +56,7 @@ func TestUpdateIssueIDUpdatesWispDependencyTargets(t *testing.T) {
 
 	// Now rename the issue
 	newID := "test-new1"
-	if err := store.UpdateIssueID(ctx, "test-old1", newID, issue, "test"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, "test-old1", newID, issue, "test"); err != nil {
 		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
@@ -133,7 +133,7 @@ func TestUpdateIssueIDUpdatesPersistentDependencyTargets(t *testing.T) {
 
 	before := readDependencyTargetRow(t, ctx, store, source.ID, target.ID)
 	newID := "test-target-new1"
-	if err := store.UpdateIssueID(ctx, target.ID, newID, target, "test"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, target.ID, newID, target, "test"); err != nil {
 		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
@@ -220,7 +220,7 @@ func TestUpdateIssueIDUpdatesWispTargetDependencyRows(t *testing.T) {
 
 	newID := "test-wisp-target-new"
 	wispTarget.ID = newID
-	if err := store.UpdateIssueID(ctx, "test-wisp-target-old", newID, wispTarget, "test"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, "test-wisp-target-old", newID, wispTarget, "test"); err != nil {
 		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
@@ -338,7 +338,7 @@ func TestUpdateIssueIDDependencyTargetCollisionFails(t *testing.T) {
 		t.Fatalf("failed to add external dependency: %v", err)
 	}
 
-	if err := store.UpdateIssueID(ctx, target.ID, newID, target, "test"); err == nil {
+	if _, err := store.UpdateIssueID(ctx, target.ID, newID, target, "test"); err == nil {
 		t.Fatal("UpdateIssueID succeeded despite a colliding dependency target")
 	}
 
@@ -527,7 +527,7 @@ func TestUpdateIssueIDRenamesWisp(t *testing.T) {
 	// Rename the wisp
 	newID := "test-renamed-wisp"
 	wisp.ID = newID
-	if err := store.UpdateIssueID(ctx, oldID, newID, wisp, "tester"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, oldID, newID, wisp, "tester"); err != nil {
 		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
@@ -611,7 +611,7 @@ func TestUpdateIssueIDStillWorksForRegularIssues(t *testing.T) {
 	// Rename it
 	newID := "test-regular-renamed"
 	issue.ID = newID
-	if err := store.UpdateIssueID(ctx, "test-regular-1", newID, issue, "tester"); err != nil {
+	if _, err := store.UpdateIssueID(ctx, "test-regular-1", newID, issue, "tester"); err != nil {
 		t.Fatalf("UpdateIssueID failed: %v", err)
 	}
 
@@ -634,3 +634,75 @@ func TestUpdateIssueIDStillWorksForRegularIssues(t *testing.T) {
 		t.Fatalf("expected 1 rename event for new ID, got %d", eventCount)
 	}
 }
+
+// TestUpdateIssueIDRejectsExistingTarget verifies that a rename onto an ID
+// already used by an issue or wisp fails without touching either row.
+func TestUpdateIssueIDRejectsExistingTarget(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{ID: "test-rn-src", Title: "Source", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	taken := &types.Issue{ID: "test-rn-taken", Title: "Taken", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	wisp := &types.Issue{ID: "test-rn-wisp", Title: "Wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Ephemeral: true}
+	for _, i := range []*types.Issue{issue, taken, wisp} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("failed to create %s: %v", i.ID, err)
+		}
+	}
+
+	for _, target := range []string{taken.ID, wisp.ID} {
+		if _, err := store.UpdateIssueID(ctx, issue.ID, target, issue, "test"); err == nil {
+			t.Errorf("rename onto %s succeeded, want conflict error", target)
+		}
+	}
+	got, err := store.GetIssue(ctx, taken.ID)
+	if err != nil || got.Title != "Taken" {
+		t.Errorf("target issue changed by rejected rename: %+v, %v", got, err)
+	}
+	if _, err := store.GetIssue(ctx, issue.ID); err != nil {
+		t.Errorf("source issue lost after rejected rename: %v", err)
+	}
+}
+
+// TestUpdateIssueIDReportsWispTables verifies that renaming a wisp moves its
+// labels and reports every table touched.
+func TestUpdateIssueIDReportsWispTables(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	wisp := &types.Issue{ID: "test-rn-w-old", Title: "Wisp", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Ephemeral: true}
+	if err := store.CreateIssue(ctx, wisp, "test"); err != nil {
+		t.Fatalf("failed to create wisp: %v", err)
+	}
+	if err := store.AddLabel(ctx, wisp.ID, "keep", "test"); err != nil {
+		t.Fatalf("failed to label wisp: %v", err)
+	}
+
+	result, err := store.UpdateIssueID(ctx, wisp.ID, "test-rn-w-new", wisp, "test")
+	if err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+	if !result.Wisp {
+		t.Error("result.Wisp = false, want true")
+	}
+	touched := make(map[string]int)
+	for _, tbl := range result.Tables {
+		touched[tbl.Table+"."+tbl.Column] = tbl.Rows
+	}
+	if touched["wisps.id"] != 1 || touched["wisp_labels.issue_id"] != 1 {
+		t.Errorf("unexpected tables touched: %+v", result.Tables)
+	}
+
+	var n int
+	if err := store.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM wisp_labels WHERE issue_id = ?`, "test-rn-w-new").Scan(&n); err != nil {
+		t.Fatalf("count wisp labels: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected label to follow the wisp, found %d", n)
+	}
+}
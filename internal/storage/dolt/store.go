@@ -152,6 +152,23 @@ var _ storage.GarbageCollector = (*DoltStore)(nil)
 var _ storage.Flattener = (*DoltStore)(nil)
 var _ storage.Compactor = (*DoltStore)(nil)
 var _ storage.SchemaMigrator = (*DoltStore)(nil)
+var _ storage.ReferenceStore = (*DoltStore)(nil)
+var _ storage.LockStore = (*DoltStore)(nil)
+var _ storage.CommitLinkStore = (*DoltStore)(nil)
+var _ storage.CIRunStore = (*DoltStore)(nil)
+var _ storage.PriorityAger = (*DoltStore)(nil)
+var _ storage.ExternalRefStore = (*DoltStore)(nil)
+var _ storage.AssignmentRuleStore = (*DoltStore)(nil)
+var _ storage.PatrolStore = (*DoltStore)(nil)
+var _ storage.CompactionSnapshotStore = (*DoltStore)(nil)
+var _ storage.SnapshotPruner = (*DoltStore)(nil)
+var _ storage.ChildCounterStore = (*DoltStore)(nil)
+var _ storage.AliasStore = (*DoltStore)(nil)
+var _ storage.IssueMoveStore = (*DoltStore)(nil)
+var _ storage.CascadeCloser = (*DoltStore)(nil)
+var _ storage.VectorStore = (*DoltStore)(nil)
+var _ storage.TokenStore = (*DoltStore)(nil)
+var _ storage.ActorPurger = (*DoltStore)(nil)
 
 // DoltStore implements the Storage interface using Dolt
 type DoltStore struct {
@@ -190,6 +207,9 @@ type DoltStore struct {
 	remotePassword string // Remote auth password for Hosted Dolt push/pull (optional)
 	serverMode     bool   // true when connected to external dolt sql-server (not embedded)
 
+	// admission is the dolt.max-clients slot held while the store is open.
+	admission *admissionTicket
+
 	// autoStartedServerDir is set when this store triggered a dolt sql-server
 	// auto-start. Close() uses it to stop the server when the last store
 	// referencing it is closed (tracked via autoStartRefs).
@@ -215,7 +235,8 @@ type Config struct {
 	ServerTLS      bool   // Enable TLS for server connections (required for Hosted Dolt)
 
 	// Remote auth for Hosted Dolt push/pull (optional)
-	// When set, Push/Pull use the --user flag and set DOLT_REMOTE_PASSWORD env var.
+	// When set, Push/Pull use the --user flag; the password is only passed to
+	// dolt CLI subprocesses via their environment, never set process-wide.
 	RemoteUser     string // Hosted Dolt remote user (set via DOLT_REMOTE_USER env var)
 	RemotePassword string // Hosted Dolt remote password (set via DOLT_REMOTE_PASSWORD env var)
 
@@ -267,6 +288,22 @@ type Config struct {
 	// NewConnection event in dolt-server.log and churns the pool for no
 	// benefit when the server is local and stable.
 	ConnMaxLifetime time.Duration
+
+	// MaxClients caps how many bd processes on this machine may hold an
+	// open store against the same server (0 = unlimited). See admission.go.
+	MaxClients int
+
+	// RateLimit caps how often Actor may open a store against the server,
+	// as "<count>/<s|m|h>" (empty = unlimited).
+	RateLimit string
+
+	// QueueTimeout is how long an open waits for a client slot or rate-limit
+	// token before failing with ErrServerBusy (0 = default 30s).
+	QueueTimeout time.Duration
+
+	// Actor identifies the caller for per-actor rate limiting
+	// (default: BEADS_ACTOR, BD_ACTOR, then $USER).
+	Actor string
 }
 
 // Defaults for the *sql.DB connection pool. Exported for tests/callers that
@@ -863,6 +900,14 @@ func applyConfigDefaults(cfg *Config) {
 	if cfg.Remote == "" {
 		cfg.Remote = "origin"
 	}
+	if cfg.Actor == "" {
+		for _, env := range []string{"BEADS_ACTOR", "BD_ACTOR", "USER"} {
+			if v := os.Getenv(env); v != "" {
+				cfg.Actor = v
+				break
+			}
+		}
+	}
 
 	// Server connection defaults (applied in server mode; embedded mode bypasses TCP)
 	if cfg.ServerSocket == "" {
@@ -1077,6 +1122,23 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		breaker.RecordSuccess()
 	}
 
+	// Admission control: queue for a client slot and a rate-limit token
+	// before opening the pool, so a swarm of agents backs off instead of
+	// exhausting the server's connections.
+	admission, err := acquireAdmission(ctx, cfg)
+	if err != nil {
+		if autoStartedDir != "" {
+			_ = autoStartRelease(autoStartedDir)
+		}
+		return nil, err
+	}
+	opened := false
+	defer func() {
+		if !opened {
+			admission.release()
+		}
+	}()
+
 	// Server mode: connect via MySQL protocol to dolt sql-server
 	db, connStr, err := openServerConnection(ctx, cfg)
 	if err != nil {
@@ -1109,6 +1171,7 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		remotePassword:       cfg.RemotePassword,
 		serverMode:           true,
 		readOnly:             cfg.ReadOnly,
+		admission:            admission,
 		autoStartedServerDir: autoStartedDir,
 	}
 
@@ -1152,6 +1215,7 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 	// These report sql.DB.Stats() on each OTel scrape — no-op when telemetry is off.
 	store.registerPoolGauges()
 
+	opened = true
 	return store, nil
 }
 
@@ -1617,6 +1681,8 @@ func (s *DoltStore) Close() error {
 		}
 	}
 	s.db = nil
+	s.admission.release()
+	s.admission = nil
 
 	// Stop auto-started server when the last store referencing it closes.
 	if s.autoStartedServerDir != "" {
@@ -1665,6 +1731,16 @@ func (s *DoltStore) DoltGC(ctx context.Context) error {
 	return versioncontrolops.DoltGC(ctx, conn)
 }
 
+// AnalyzeTables refreshes index statistics for every table in the database.
+func (s *DoltStore) AnalyzeTables(ctx context.Context) ([]string, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection for analyze: %w", err)
+	}
+	defer conn.Close()
+	return versioncontrolops.AnalyzeTables(ctx, conn)
+}
+
 // Flatten squashes all Dolt commit history into a single commit.
 // Pins a single connection because the stored procedures (DOLT_CHECKOUT,
 // DOLT_RESET, etc.) rely on session-scoped state that would be lost if
@@ -2220,8 +2296,8 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 	}
 	// Credential CLI routing: when credentials are set and server is external,
 	// route through CLI subprocess so credentials reach the dolt process via
-	// cmd.Env (applyToCmd). The SQL path's withEnvCredentials sets process-wide
-	// env vars that an external server cannot see.
+	// cmd.Env (applyToCmd). The SQL path can only forward --user; the password
+	// would have to live in the external server's own environment.
 	if useCLI, err := s.prepareCLIRouteForCredentials(ctx, remote, creds); err != nil {
 		return err
 	} else if useCLI {
@@ -2242,7 +2318,7 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 		return s.doltCLIPush(ctx, remote, force, creds)
 	}
 	if s.remoteUser != "" && remote == s.remote {
-		return withRemoteOperationEnv(creds, s.isS3Remote(ctx, remote), func() error {
+		return withRemoteOperationEnv(s.isS3Remote(ctx, remote), func() error {
 			if force {
 				if err := s.execWithLongTimeoutNoTx(ctx, "CALL DOLT_PUSH('--force', '--user', ?, ?, ?)", s.remoteUser, remote, s.branch); err != nil {
 					return fmt.Errorf("failed to force push to %s/%s: %w", remote, s.branch, err)
@@ -2255,7 +2331,7 @@ func (s *DoltStore) pushToRemote(ctx context.Context, remote string, force bool)
 			return nil
 		})
 	}
-	return withRemoteOperationEnv(nil, s.isS3Remote(ctx, remote), func() error {
+	return withRemoteOperationEnv(s.isS3Remote(ctx, remote), func() error {
 		if force {
 			if err := s.execWithLongTimeoutNoTx(ctx, "CALL DOLT_PUSH('--force', ?, ?)", remote, s.branch); err != nil {
 				return fmt.Errorf("failed to force push to %s/%s: %w", remote, s.branch, err)
@@ -2371,14 +2447,14 @@ func (s *DoltStore) pullTransport(ctx context.Context, remote string) error {
 	// guard is a push-only optimization; SQL pull keeps pullWithAutoResolve in
 	// charge of metadata-only conflict repair.
 	if s.remoteUser != "" && remote == s.remote {
-		return withRemoteOperationEnv(creds, s.isS3Remote(ctx, remote), func() error {
+		return withRemoteOperationEnv(s.isS3Remote(ctx, remote), func() error {
 			if err := s.pullWithAutoResolve(ctx, remote, "CALL DOLT_PULL('--user', ?, ?, ?)", s.remoteUser, remote, s.branch); err != nil {
 				return fmt.Errorf("failed to pull from %s/%s: %w", remote, s.branch, err)
 			}
 			return nil
 		})
 	}
-	return withRemoteOperationEnv(nil, s.isS3Remote(ctx, remote), func() error {
+	return withRemoteOperationEnv(s.isS3Remote(ctx, remote), func() error {
 		if err := s.pullWithAutoResolve(ctx, remote, "CALL DOLT_PULL(?, ?)", remote, s.branch); err != nil {
 			return fmt.Errorf("failed to pull from %s/%s: %w", remote, s.branch, err)
 		}
@@ -2795,6 +2871,23 @@ func (s *DoltStore) Merge(ctx context.Context, branch string) (conflicts []stora
 	return conflicts, err
 }
 
+// StageMerge merges branch into the working set without committing; the
+// caller concludes it with Commit or discards it with AbortMerge. Implements
+// storage.StagedMerger.
+func (s *DoltStore) StageMerge(ctx context.Context, branch, strategy string) error {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection for merge: %w", err)
+	}
+	defer conn.Close()
+	return versioncontrolops.StageMerge(ctx, conn, branch, strategy)
+}
+
+// AbortMerge discards a merge staged by StageMerge.
+func (s *DoltStore) AbortMerge(ctx context.Context) error {
+	return versioncontrolops.AbortMerge(ctx, s.db)
+}
+
 // RecomputeBlockedAfterMerge recomputes the denormalized is_blocked column
 // for the rows changed since fromCommit and commits the result — the hook a
 // caller that resolved merge conflicts itself must run after committing the
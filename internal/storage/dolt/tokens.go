@@ -0,0 +1,46 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// CreateAPIToken implements storage.TokenStore. api_tokens is dolt-ignored,
+// so no Dolt commit is needed.
+func (s *DoltStore) CreateAPIToken(ctx context.Context, token *storage.APIToken) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.CreateAPITokenInTx(ctx, tx, token)
+	})
+}
+
+// GetAPIToken implements storage.TokenStore.
+func (s *DoltStore) GetAPIToken(ctx context.Context, id string) (*storage.APIToken, error) {
+	var result *storage.APIToken
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAPITokenInTx(ctx, tx, id)
+		return err
+	})
+	return result, err
+}
+
+// ListAPITokens implements storage.TokenStore.
+func (s *DoltStore) ListAPITokens(ctx context.Context) ([]*storage.APIToken, error) {
+	var result []*storage.APIToken
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListAPITokensInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// RevokeAPIToken implements storage.TokenStore.
+func (s *DoltStore) RevokeAPIToken(ctx context.Context, id string) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.RevokeAPITokenInTx(ctx, tx, id)
+	})
+}
@@ -618,10 +618,14 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 		}
 	}
 
-	if _, err := issueops.UpdateIssueWithoutEventInTx(ctx, t.txFor(table), id, updates, actor); err != nil {
+	result, err := issueops.UpdateIssueWithoutEventInTx(ctx, t.txFor(table), id, updates, actor)
+	if err != nil {
 		return wrapExecError("update issue in tx", err)
 	}
 	t.dirty.MarkDirty(table)
+	if result.ReferencesChanged {
+		t.dirty.MarkDirty("issue_references")
+	}
 	return nil
 }
 
@@ -895,6 +899,15 @@ func (t *doltTransaction) ImportIssueComment(ctx context.Context, issueID, autho
 		return nil, fmt.Errorf("failed to add comment: %w", err)
 	}
 	t.dirty.MarkDirty(table)
+	if table == "comments" {
+		changed, err := issueops.SyncIssueReferencesInTx(ctx, t.regularTx, issueID, types.ReferenceSourceComment, id, text)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			t.dirty.MarkDirty("issue_references")
+		}
+	}
 
 	return &types.Comment{ID: id, IssueID: issueID, Author: author, Text: text, CreatedAt: createdAt}, nil
 }
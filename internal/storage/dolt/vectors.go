@@ -0,0 +1,28 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// GetIssueVectors implements storage.VectorStore.
+func (s *DoltStore) GetIssueVectors(ctx context.Context, provider, model string) ([]*storage.IssueVector, error) {
+	var result []*storage.IssueVector
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueVectorsInTx(ctx, tx, provider, model)
+		return err
+	})
+	return result, err
+}
+
+// UpsertIssueVectors implements storage.VectorStore. issue_vectors is
+// dolt-ignored, so no Dolt commit is needed.
+func (s *DoltStore) UpsertIssueVectors(ctx context.Context, vectors []*storage.IssueVector) error {
+	return s.withRetryTx(ctx, func(tx *sql.Tx) error {
+		return issueops.UpsertIssueVectorsInTx(ctx, tx, vectors)
+	})
+}
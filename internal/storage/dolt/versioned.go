@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/issueops"
@@ -31,6 +32,30 @@ func (s *DoltStore) AsOf(ctx context.Context, issueID string, ref string) (*type
 	return s.getIssueAsOf(ctx, issueID, ref)
 }
 
+// SearchIssuesAsOf searches issues as they existed at a commit or branch.
+// Implements storage.HistoryViewer.
+func (s *DoltStore) SearchIssuesAsOf(ctx context.Context, ref string, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var result []*types.Issue
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SearchIssuesAsOfInTx(ctx, tx, ref, query, filter)
+		return err
+	})
+	return result, err
+}
+
+// CommitAsOf returns the latest commit made at or before t.
+// Implements storage.HistoryViewer.
+func (s *DoltStore) CommitAsOf(ctx context.Context, t time.Time) (string, error) {
+	var result string
+	err := s.withReadTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.CommitAsOfInTx(ctx, tx, t)
+		return err
+	})
+	return result, err
+}
+
 // Diff returns changes between two commits/branches.
 // Implements storage.VersionedStorage.
 func (s *DoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]*storage.DiffEntry, error) {
@@ -66,6 +91,12 @@ func (s *DoltStore) GetConflicts(ctx context.Context) ([]storage.Conflict, error
 	return versioncontrolops.GetConflicts(ctx, s.db)
 }
 
+// GetConflictDetails returns the per-field merge conflicts in the current state.
+// Implements storage.VersionControl.
+func (s *DoltStore) GetConflictDetails(ctx context.Context) ([]storage.Conflict, error) {
+	return versioncontrolops.GetConflictDetails(ctx, s.db)
+}
+
 // CommitExists checks whether a commit hash exists in the repository.
 // Returns false for empty strings, malformed input, or non-existent commits.
 func (s *DoltStore) CommitExists(ctx context.Context, commitHash string) (bool, error) {
@@ -0,0 +1,23 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// PurgeActor implements storage.ActorPurger. A dry run runs without commit,
+// so withConn rolls the transaction back.
+func (s *EmbeddedDoltStore) PurgeActor(ctx context.Context, opts storage.ActorPurgeOptions) (*storage.ActorPurgeReport, error) {
+	var report *storage.ActorPurgeReport
+	err := s.withConn(ctx, !opts.DryRun, func(tx *sql.Tx) error {
+		var err error
+		report, err = issueops.PurgeActorInTx(ctx, tx, opts)
+		return err
+	})
+	return report, err
+}
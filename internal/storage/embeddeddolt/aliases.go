@@ -0,0 +1,47 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAlias implements storage.AliasStore.
+func (s *EmbeddedDoltStore) AddAlias(ctx context.Context, alias, issueID, actor string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AddAliasInTx(ctx, tx, alias, issueID, actor)
+	})
+}
+
+// RemoveAlias implements storage.AliasStore.
+func (s *EmbeddedDoltStore) RemoveAlias(ctx context.Context, alias string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveAliasInTx(ctx, tx, alias)
+	})
+}
+
+// ResolveAlias implements storage.AliasStore.
+func (s *EmbeddedDoltStore) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	var issueID string
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		issueID, err = issueops.ResolveAliasInTx(ctx, tx, alias)
+		return err
+	})
+	return issueID, err
+}
+
+// GetAliases implements storage.AliasStore.
+func (s *EmbeddedDoltStore) GetAliases(ctx context.Context, issueID string) ([]*types.IssueAlias, error) {
+	var aliases []*types.IssueAlias
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		aliases, err = issueops.GetAliasesInTx(ctx, tx, issueID)
+		return err
+	})
+	return aliases, err
+}
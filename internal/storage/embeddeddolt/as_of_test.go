@@ -0,0 +1,80 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedSearchIssuesAsOf(t *testing.T) {
+	te := newTestEnv(t, "aso")
+	ctx := t.Context()
+
+	for _, issue := range []*types.Issue{
+		{ID: "aso-1", Title: "first", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "aso-2", Title: "second", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug},
+	} {
+		if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", issue.ID, err)
+		}
+	}
+	if err := te.store.AddLabel(ctx, "aso-2", "backend", "tester"); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.Commit(ctx, "seed"); err != nil {
+		t.Fatal(err)
+	}
+	seed, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := te.store.CloseIssue(ctx, "aso-2", "done", "tester", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.CreateIssue(ctx, &types.Issue{ID: "aso-3", Title: "third", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "tester"); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.Commit(ctx, "later"); err != nil {
+		t.Fatal(err)
+	}
+
+	open := types.StatusOpen
+	issues, err := te.store.SearchIssuesAsOf(ctx, seed, "", types.IssueFilter{Status: &open})
+	if err != nil {
+		t.Fatalf("SearchIssuesAsOf: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d open issues at seed, want 2: %+v", len(issues), issues)
+	}
+
+	issues, err = te.store.SearchIssuesAsOf(ctx, seed, "", types.IssueFilter{Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("SearchIssuesAsOf(label): %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "aso-2" || issues[0].Status != types.StatusOpen {
+		t.Fatalf("label search at seed = %+v, want open aso-2", issues)
+	}
+
+	issues, err = te.store.SearchIssuesAsOf(ctx, "HEAD", "", types.IssueFilter{Status: &open})
+	if err != nil {
+		t.Fatalf("SearchIssuesAsOf(HEAD): %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d open issues at HEAD, want 2", len(issues))
+	}
+
+	head, err := te.store.CommitAsOf(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CommitAsOf: %v", err)
+	}
+	if cur, _ := te.store.GetCurrentCommit(ctx); head != cur {
+		t.Errorf("CommitAsOf(future) = %s, want HEAD %s", head, cur)
+	}
+	if _, err := te.store.CommitAsOf(ctx, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("CommitAsOf before history: expected error")
+	}
+}
@@ -0,0 +1,47 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAssignmentRule implements storage.AssignmentRuleStore.
+func (s *EmbeddedDoltStore) AddAssignmentRule(ctx context.Context, rule *types.AssignmentRule) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AddAssignmentRuleInTx(ctx, tx, rule)
+	})
+}
+
+// RemoveAssignmentRule implements storage.AssignmentRuleStore.
+func (s *EmbeddedDoltStore) RemoveAssignmentRule(ctx context.Context, name string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RemoveAssignmentRuleInTx(ctx, tx, name)
+	})
+}
+
+// GetAssignmentRules implements storage.AssignmentRuleStore.
+func (s *EmbeddedDoltStore) GetAssignmentRules(ctx context.Context) ([]*types.AssignmentRule, error) {
+	var result []*types.AssignmentRule
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAssignmentRulesInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// AdvanceAssignmentRule implements storage.AssignmentRuleStore.
+func (s *EmbeddedDoltStore) AdvanceAssignmentRule(ctx context.Context, name string) (int, error) {
+	var prev int
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		prev, err = issueops.AdvanceAssignmentRuleInTx(ctx, tx, name)
+		return err
+	})
+	return prev, err
+}
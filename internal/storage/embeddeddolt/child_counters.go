@@ -0,0 +1,40 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChildCounterStatus implements storage.ChildCounterStore.
+func (s *EmbeddedDoltStore) ChildCounterStatus(ctx context.Context) ([]*types.ChildCounterStatus, error) {
+	var result []*types.ChildCounterStatus
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ChildCounterStatusInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// RepairChildCounters implements storage.ChildCounterStore.
+func (s *EmbeddedDoltStore) RepairChildCounters(ctx context.Context) (int, error) {
+	var changed int
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		changed, err = issueops.RepairChildCountersInTx(ctx, tx)
+		return err
+	})
+	return changed, err
+}
+
+// RenumberChildren implements storage.ChildCounterStore.
+func (s *EmbeddedDoltStore) RenumberChildren(ctx context.Context, parentID string, renames map[string]string, lastChild int, actor string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RenumberChildrenInTx(ctx, tx, parentID, renames, lastChild, actor)
+	})
+}
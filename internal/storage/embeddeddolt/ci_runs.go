@@ -0,0 +1,29 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RecordCIRun implements storage.CIRunStore.
+func (s *EmbeddedDoltStore) RecordCIRun(ctx context.Context, run *types.CIRun, actor string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RecordCIRunInTx(ctx, tx, run, actor)
+	})
+}
+
+// GetCIRuns implements storage.CIRunStore.
+func (s *EmbeddedDoltStore) GetCIRuns(ctx context.Context, issueIDs []string) (map[string][]*types.CIRun, error) {
+	var result map[string][]*types.CIRun
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCIRunsInTx(ctx, tx, issueIDs)
+		return err
+	})
+	return result, err
+}
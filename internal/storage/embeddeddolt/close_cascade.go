@@ -0,0 +1,24 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// CloseCascade implements storage.CascadeCloser.
+func (s *EmbeddedDoltStore) CloseCascade(ctx context.Context, rootID, reason, actor, session string) ([]string, error) {
+	var closed []string
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		closed, err = issueops.CloseCascadeInTx(ctx, tx, rootID, reason, actor, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
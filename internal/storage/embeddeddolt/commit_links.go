@@ -0,0 +1,55 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddCommitLink implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) AddCommitLink(ctx context.Context, link *types.CommitLink) (bool, error) {
+	var added bool
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		added, err = issueops.AddCommitLinkInTx(ctx, tx, link)
+		return err
+	})
+	return added, err
+}
+
+// HasCommitLink implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) HasCommitLink(ctx context.Context, issueID, commitSHA string) (bool, error) {
+	var found bool
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		found, err = issueops.HasCommitLinkInTx(ctx, tx, issueID, commitSHA)
+		return err
+	})
+	return found, err
+}
+
+// GetCommitLinks implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) GetCommitLinks(ctx context.Context, issueID string) ([]*types.CommitLink, error) {
+	var result []*types.CommitLink
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// GetCommitLinksForCommit implements storage.CommitLinkStore.
+func (s *EmbeddedDoltStore) GetCommitLinksForCommit(ctx context.Context, sha string) ([]*types.CommitLink, error) {
+	var result []*types.CommitLink
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetCommitLinksForCommitInTx(ctx, tx, sha)
+		return err
+	})
+	return result, err
+}
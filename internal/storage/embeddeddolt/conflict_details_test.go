@@ -0,0 +1,68 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// TestGetConflictDetails merges a branch that edits the same issue field as
+// main and checks the conflict is reported per field with both values.
+func TestGetConflictDetails(t *testing.T) {
+	ctx := t.Context()
+	te := newTestEnv(t, "cd")
+	conn := openSettleConn(t, ctx, te)
+
+	for _, stmt := range []string{
+		"INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, issue_type, status, priority, created_at, updated_at) " +
+			"VALUES ('cd-1', 'Original', '', '', '', '', 'task', 'open', 2, NOW(), NOW())",
+		"CALL DOLT_COMMIT('-Am', 'base')",
+		"CALL DOLT_CHECKOUT('-b', 'town-beta')",
+		"UPDATE issues SET title = 'Theirs', priority = 1 WHERE id = 'cd-1'",
+		"CALL DOLT_COMMIT('-Am', 'peer edit')",
+		"CALL DOLT_CHECKOUT('main')",
+		"UPDATE issues SET title = 'Ours', priority = 3 WHERE id = 'cd-1'",
+		"CALL DOLT_COMMIT('-Am', 'local edit')",
+		"SET @@dolt_allow_commit_conflicts = 1",
+		"CALL DOLT_MERGE('town-beta')",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	details, err := versioncontrolops.GetConflictDetails(ctx, conn)
+	if err != nil {
+		t.Fatalf("GetConflictDetails: %v", err)
+	}
+	got := make(map[string][2]interface{})
+	for _, c := range details {
+		if c.Table != "issues" || c.IssueID != "cd-1" {
+			t.Errorf("unexpected conflict %+v", c)
+			continue
+		}
+		got[c.Field] = [2]interface{}{c.OursValue, c.TheirsValue}
+	}
+	if v := got["title"]; v != [2]interface{}{"Ours", "Theirs"} {
+		t.Errorf("title conflict = %v, want [Ours Theirs]", v)
+	}
+	if v := got["priority"]; v != [2]interface{}{"3", "1"} {
+		t.Errorf("priority conflict = %v, want [3 1]", v)
+	}
+	if _, ok := got["status"]; ok {
+		t.Errorf("unchanged status reported as conflict: %v", details)
+	}
+
+	if err := versioncontrolops.ResolveConflicts(ctx, conn, "issues", "theirs"); err != nil {
+		t.Fatalf("ResolveConflicts: %v", err)
+	}
+	details, err = versioncontrolops.GetConflictDetails(ctx, conn)
+	if err != nil {
+		t.Fatalf("GetConflictDetails after resolve: %v", err)
+	}
+	if len(details) != 0 {
+		t.Errorf("conflicts after resolve = %v, want none", details)
+	}
+}
@@ -0,0 +1,88 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEmbeddedDiffFieldsAndDependencies(t *testing.T) {
+	te := newTestEnv(t, "dif")
+	ctx := t.Context()
+
+	for _, issue := range []*types.Issue{
+		{ID: "dif-1", Title: "first", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "dif-2", Title: "second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "dif-3", Title: "third", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := te.store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", issue.ID, err)
+		}
+	}
+	if err := te.store.Commit(ctx, "seed"); err != nil {
+		t.Fatal(err)
+	}
+	from, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := te.store.UpdateIssue(ctx, "dif-1", map[string]interface{}{"priority": 0, "assignee": "alice"}, "tester"); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.CloseIssue(ctx, "dif-2", "done", "tester", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.AddDependency(ctx, &types.Dependency{IssueID: "dif-3", DependsOnID: "dif-1", Type: types.DepBlocks}, "tester"); err != nil {
+		t.Fatal(err)
+	}
+	if err := te.store.Commit(ctx, "edit"); err != nil {
+		t.Fatal(err)
+	}
+	to, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := te.store.Diff(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	byID := make(map[string]*storage.DiffEntry)
+	for _, e := range entries {
+		byID[e.IssueID] = e
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(entries), byID)
+	}
+
+	wantChanges := []storage.FieldChange{
+		{Field: "priority", Old: "2", New: "0"},
+		{Field: "assignee", Old: "", New: "alice"},
+	}
+	if got := byID["dif-1"].Changes; !reflect.DeepEqual(got, wantChanges) {
+		t.Errorf("dif-1 changes = %+v, want %+v", got, wantChanges)
+	}
+
+	closed := byID["dif-2"]
+	if !closed.Closed() {
+		t.Errorf("dif-2 not reported closed: %+v", closed)
+	}
+	if closed.NewValue.CloseReason != "done" {
+		t.Errorf("dif-2 close reason = %q, want done", closed.NewValue.CloseReason)
+	}
+
+	// dif-3's row may or may not be touched by AddDependency; either way its
+	// dependency change is reported.
+	wantDeps := []storage.DependencyChange{{DependsOnID: "dif-1", DiffType: "added", NewType: "blocks"}}
+	if got := byID["dif-3"].DependencyChanges; !reflect.DeepEqual(got, wantDeps) {
+		t.Errorf("dif-3 dependency changes = %+v, want %+v", got, wantDeps)
+	}
+	if byID["dif-1"].Closed() {
+		t.Error("dif-1 reported closed")
+	}
+}
@@ -187,6 +187,28 @@ func (s *EmbeddedDoltStore) RemoveFederationPeer(ctx context.Context, name strin
 	return nil
 }
 
+// peerDirection returns the peer's configured direction ("" for a plain
+// remote without a federation_peers row).
+func (s *EmbeddedDoltStore) peerDirection(ctx context.Context, peer string) (string, error) {
+	var direction string
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		direction, err = issueops.PeerDirection(ctx, tx, peer)
+		return err
+	})
+	return direction, err
+}
+
+// checkPeerDirection refuses a pull from a push-only peer or a push to a
+// pull-only one.
+func (s *EmbeddedDoltStore) checkPeerDirection(ctx context.Context, peer string, push bool) error {
+	direction, err := s.peerDirection(ctx, peer)
+	if err != nil {
+		return err
+	}
+	return storage.CheckPeerDirection(peer, direction, push)
+}
+
 // ---------------------------------------------------------------------------
 // SyncStore implementation
 // ---------------------------------------------------------------------------
@@ -200,6 +222,24 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 		Peer:      peer,
 		StartTime: time.Now(),
 	}
+	direction, err := s.peerDirection(ctx, peer)
+	if err != nil {
+		result.Error = err
+		return result, err
+	}
+	result.Direction = direction
+
+	// A push-only peer is never merged from: push and stop.
+	if direction == storage.PeerDirectionPushOnly {
+		if err := s.PushTo(ctx, peer); err != nil {
+			result.Error = fmt.Errorf("push failed: %w", err)
+			return result, result.Error
+		}
+		result.Pushed = true
+		_ = s.setLastSyncTime(ctx, peer)
+		result.EndTime = time.Now()
+		return result, nil
+	}
 
 	// Step 1: Fetch
 	if err := s.Fetch(ctx, peer); err != nil {
@@ -256,11 +296,13 @@ func (s *EmbeddedDoltStore) Sync(ctx context.Context, peer string, strategy stri
 		result.PulledCommits = 1
 	}
 
-	// Step 5: Push
-	if err := s.PushTo(ctx, peer); err != nil {
-		result.PushError = err
-	} else {
-		result.Pushed = true
+	// Step 5: Push, unless the peer is a pull-only upstream.
+	if direction != storage.PeerDirectionPullOnly {
+		if err := s.PushTo(ctx, peer); err != nil {
+			result.PushError = err
+		} else {
+			result.Pushed = true
+		}
 	}
 
 	// Record last sync time in metadata.
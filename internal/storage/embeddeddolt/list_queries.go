@@ -91,6 +91,36 @@ func (s *EmbeddedDoltStore) GetDependencyCounts(ctx context.Context, issueIDs []
 	return result, err
 }
 
+func (s *EmbeddedDoltStore) GetChildProgress(ctx context.Context, parentIDs []string) (map[string]*types.ChildProgress, error) {
+	var result map[string]*types.ChildProgress
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetChildProgressInTx(ctx, tx, parentIDs)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) GetHierarchyRollup(ctx context.Context, rootID string) (map[string]*types.HierarchyRollup, error) {
+	var result map[string]*types.HierarchyRollup
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetHierarchyRollupInTx(ctx, tx, rootID)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) GetUnblockImpact(ctx context.Context) (map[string]*types.UnblockImpact, error) {
+	var result map[string]*types.UnblockImpact
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetUnblockImpactInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
 func (s *EmbeddedDoltStore) GetBlockingInfoForIssues(ctx context.Context, issueIDs []string) (
 	blockedByMap map[string][]string,
 	blocksMap map[string][]string,
@@ -0,0 +1,26 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MoveIssues implements storage.IssueMoveStore.
+func (s *EmbeddedDoltStore) MoveIssues(ctx context.Context, ids []string, newParentID string, opts storage.MoveIssuesOptions, actor string) (*types.MoveResult, error) {
+	var result *types.MoveResult
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.MoveIssuesInTx(ctx, tx, ids, newParentID, opts, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -22,6 +22,10 @@ import (
 // variable identifiers (@@<db>_head_ref) where hyphens are invalid.
 var validIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
 
+// validBranchName matches the branch names OpenSQL will splice into a
+// revision database name.
+var validBranchName = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_./-]*$`)
+
 const (
 	commitName  = "beads"
 	commitEmail = "beads@local"
@@ -29,8 +33,22 @@ const (
 
 // OpenSQL opens an embedded Dolt database at dir. The returned cleanup
 // function closes both the *sql.DB and the underlying connector.
+//
+// A branch other than main opens the revision database "<database>/<branch>",
+// which pins every session the pool creates to that branch. The driver does
+// not keep sessions between statements, so setting @@<db>_head_ref on one
+// would not reach the next.
 func OpenSQL(ctx context.Context, dir, database, branch string) (*sql.DB, func() error, error) {
-	dsn := buildDSN(dir, database)
+	branch = strings.TrimSpace(branch)
+	revision := strings.TrimSpace(database) != "" && branch != "" && branch != "main"
+	dsnDatabase := database
+	if revision {
+		if !validBranchName.MatchString(branch) {
+			return nil, nil, fmt.Errorf("invalid branch name: %q", branch)
+		}
+		dsnDatabase = database + "/" + branch
+	}
+	dsn := buildDSN(dir, dsnDatabase)
 
 	cfg, err := doltembed.ParseDSN(dsn)
 	if err != nil {
@@ -82,10 +100,16 @@ func OpenSQL(ctx context.Context, dir, database, branch string) (*sql.DB, func()
 			}
 			return nil, nil, errors.Join(errors.New(msg), cleanup())
 		}
+		if revision {
+			if _, err := db.ExecContext(ctx, "USE `"+dsnDatabase+"`"); err != nil {
+				return nil, nil, errors.Join(fmt.Errorf("open branch %s: %w", branch, err), cleanup())
+			}
+			return db, cleanup, nil
+		}
 		if _, err := db.ExecContext(ctx, "USE `"+database+"`"); err != nil {
 			return nil, nil, errors.Join(err, cleanup())
 		}
-		if strings.TrimSpace(branch) != "" {
+		if branch != "" {
 			if _, err := db.ExecContext(ctx, fmt.Sprintf("SET @@%s_head_ref = %s", database, sqlStringLiteral(branch))); err != nil {
 				return nil, nil, errors.Join(err, cleanup())
 			}
@@ -0,0 +1,64 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// TestPeerWispCounts checks the doctor's peer wisp scan against a branch
+// named like a remote-tracking ref ("<peer>/main"), which AS OF resolves the
+// same way.
+func TestPeerWispCounts(t *testing.T) {
+	ctx := t.Context()
+	te := newTestEnv(t, "pw")
+	conn := openSettleConn(t, ctx, te)
+
+	tables, err := issueops.TrackedTablesAsOf(ctx, conn, "main")
+	if err != nil {
+		t.Fatalf("TrackedTablesAsOf: %v", err)
+	}
+	if !slices.Contains(tables, "issues") {
+		t.Errorf("tracked tables %v missing issues", tables)
+	}
+	if slices.Contains(tables, "wisps") {
+		t.Errorf("dolt_ignore'd wisps table reported as tracked: %v", tables)
+	}
+
+	for _, stmt := range []string{
+		"CALL DOLT_CHECKOUT('-b', 'town-beta/main')",
+		"INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, issue_type, status, priority, ephemeral, created_at, updated_at) " +
+			"VALUES ('pw-leak', 'Leaked wisp', '', '', '', '', 'task', 'open', 2, 1, NOW(), NOW())",
+		"CALL DOLT_COMMIT('-Am', 'peer leaks a wisp')",
+		"CALL DOLT_CHECKOUT('main')",
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	counts, err := issueops.PeerWispCountsInTx(ctx, tx, "town-beta")
+	if err != nil {
+		t.Fatalf("PeerWispCountsInTx: %v", err)
+	}
+	if len(counts) != 1 || counts["issues"] != 1 {
+		t.Errorf("counts = %v, want map[issues:1]", counts)
+	}
+
+	counts, err = issueops.PeerWispCountsInTx(ctx, tx, "town-unfetched")
+	if err != nil {
+		t.Fatalf("PeerWispCountsInTx(unfetched): %v", err)
+	}
+	if counts != nil {
+		t.Errorf("unfetched peer counts = %v, want nil", counts)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// AgeIssuePriority implements storage.PriorityAger.
+func (s *EmbeddedDoltStore) AgeIssuePriority(ctx context.Context, issueID string, from, to int, reason, actor string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.AgeIssuePriorityInTx(ctx, tx, issueID, from, to, reason, actor)
+	})
+}
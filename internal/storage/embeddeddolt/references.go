@@ -0,0 +1,33 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage/issueops"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetBacklinks implements storage.ReferenceStore.
+func (s *EmbeddedDoltStore) GetBacklinks(ctx context.Context, issueID string) ([]*types.IssueReference, error) {
+	var result []*types.IssueReference
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetBacklinksInTx(ctx, tx, issueID)
+		return err
+	})
+	return result, err
+}
+
+// GetMentions implements storage.ReferenceStore.
+func (s *EmbeddedDoltStore) GetMentions(ctx context.Context, actor string) ([]*types.IssueReference, error) {
+	var result []*types.IssueReference
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetMentionsInTx(ctx, tx, actor)
+		return err
+	})
+	return result, err
+}
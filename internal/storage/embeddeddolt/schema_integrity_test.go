@@ -0,0 +1,96 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/storage/schema"
+)
+
+// TestExpectedTablesMatchMigrations fails when a migration adds a column or
+// index to a core table without a matching entry in schema.ExpectedTables.
+func TestExpectedTablesMatchMigrations(t *testing.T) {
+	te := newTestEnv(t, "sig")
+	ctx := t.Context()
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "main")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	defer cleanup()
+
+	drift, err := schema.ScanDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("ScanDrift: %v", err)
+	}
+	for _, d := range drift {
+		t.Errorf("fresh database drifts from ExpectedTables: %s", d.Summary())
+	}
+
+	for _, table := range schema.ExpectedTables {
+		expected := make(map[string]bool, len(table.Columns))
+		for _, c := range table.Columns {
+			expected[c.Name] = true
+		}
+		rows, err := db.QueryContext(ctx,
+			"SELECT COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", table.Name)
+		if err != nil {
+			t.Fatalf("columns of %s: %v", table.Name, err)
+		}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatal(err)
+			}
+			if !expected[name] {
+				t.Errorf("%s.%s exists after migrations but is not in schema.ExpectedTables", table.Name, name)
+			}
+		}
+		_ = rows.Close()
+	}
+}
+
+func TestRepairDriftRestoresColumnsAndIndexes(t *testing.T) {
+	te := newTestEnv(t, "sir")
+	ctx := t.Context()
+	te.exec(t, ctx, "DROP INDEX idx_issues_spec_id ON issues")
+	te.exec(t, ctx, "ALTER TABLE issues DROP COLUMN spec_id")
+	te.exec(t, ctx, "DROP INDEX idx_dependencies_thread ON dependencies")
+
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "main")
+	if err != nil {
+		t.Fatalf("OpenSQL: %v", err)
+	}
+	defer cleanup()
+
+	drift, err := schema.ScanDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("ScanDrift: %v", err)
+	}
+	if len(drift) != 2 {
+		t.Fatalf("got %d drifted tables, want 2: %+v", len(drift), drift)
+	}
+	if got := drift[0].Summary(); got != "issues: missing column spec_id, index idx_issues_spec_id" {
+		t.Errorf("issues drift = %q", got)
+	}
+	if got := drift[1].Summary(); got != "dependencies: missing index idx_dependencies_thread" {
+		t.Errorf("dependencies drift = %q", got)
+	}
+
+	applied, err := schema.RepairDrift(ctx, db, drift)
+	if err != nil {
+		t.Fatalf("RepairDrift: %v", err)
+	}
+	if len(applied) != 3 {
+		t.Errorf("applied %d statements, want 3: %v", len(applied), applied)
+	}
+
+	drift, err = schema.ScanDrift(ctx, db)
+	if err != nil {
+		t.Fatalf("ScanDrift after repair: %v", err)
+	}
+	if len(drift) != 0 {
+		t.Errorf("drift remains after repair: %+v", drift)
+	}
+}
@@ -0,0 +1,170 @@
+//go:build cgo
+
+package embeddeddolt_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/embeddeddolt"
+	"github.com/steveyegge/beads/internal/storage/versioncontrolops"
+)
+
+// execOn runs statements in order on one short-lived connection to branch.
+func (te *testEnv) execOn(t *testing.T, ctx context.Context, branch string, stmts ...string) {
+	t.Helper()
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, branch)
+	if err != nil {
+		t.Fatalf("OpenSQL(%s): %v", branch, err)
+	}
+	defer cleanup()
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("exec %q on %s: %v", stmt, branch, err)
+		}
+	}
+}
+
+// seedStageMergeBranch seeds stg-1 and stg-2 on main and creates branch
+// reorg from there, which retitles stg-1.
+func seedStageMergeBranch(t *testing.T, ctx context.Context, te *testEnv) {
+	t.Helper()
+	te.execOn(t, ctx, "main",
+		"INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, status, priority, issue_type) VALUES ('stg-1', 'base', '', '', '', '', 'open', 2, 'task')",
+		"INSERT INTO issues (id, title, description, design, acceptance_criteria, notes, status, priority, issue_type) VALUES ('stg-2', 'other', '', '', '', '', 'open', 2, 'task')",
+		"CALL DOLT_COMMIT('-Am', 'seed issues')",
+		"CALL DOLT_BRANCH('reorg', 'HEAD')",
+	)
+	te.execOn(t, ctx, "reorg",
+		"UPDATE issues SET title = 'reorganized' WHERE id = 'stg-1'",
+		"CALL DOLT_COMMIT('-Am', 'retitle on reorg')",
+	)
+}
+
+func TestEmbeddedStageMerge(t *testing.T) {
+	te := newTestEnv(t, "stg")
+	ctx := t.Context()
+	seedStageMergeBranch(t, ctx, te)
+	var _ storage.StagedMerger = te.store
+
+	head, err := te.store.GetCurrentCommit(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Staged: visible in the working set, HEAD unmoved — even for a merge
+	// that could fast-forward.
+	if err := te.store.StageMerge(ctx, "reorg", ""); err != nil {
+		t.Fatalf("StageMerge: %v", err)
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "reorganized")
+	if after, _ := te.store.GetCurrentCommit(ctx); after != head {
+		t.Errorf("HEAD moved to %s before commit", after)
+	}
+
+	// Aborted: the working set is back to HEAD.
+	if err := te.store.AbortMerge(ctx); err != nil {
+		t.Fatalf("AbortMerge: %v", err)
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "base")
+	status, err := te.store.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Staged)+len(status.Unstaged) != 0 {
+		t.Errorf("working set dirty after abort: %+v", status)
+	}
+
+	// Committed: the merge lands, and merging again has nothing to do.
+	if err := te.store.StageMerge(ctx, "reorg", ""); err != nil {
+		t.Fatalf("StageMerge again: %v", err)
+	}
+	if err := te.store.Commit(ctx, "merge reorg"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "reorganized")
+	if err := te.store.StageMerge(ctx, "reorg", ""); !errors.Is(err, storage.ErrNothingToMerge) {
+		t.Errorf("merge of an already merged branch = %v, want ErrNothingToMerge", err)
+	}
+}
+
+func TestEmbeddedStageMergeConflicts(t *testing.T) {
+	te := newTestEnv(t, "stgc")
+	ctx := t.Context()
+	seedStageMergeBranch(t, ctx, te)
+	te.execOn(t, ctx, "main",
+		"UPDATE issues SET title = 'local' WHERE id = 'stg-1'",
+		"CALL DOLT_COMMIT('-Am', 'retitle on main')",
+	)
+
+	// No strategy: the conflict is reported and nothing changes.
+	err := te.store.StageMerge(ctx, "reorg", "")
+	var mce *versioncontrolops.MergeConflictsError
+	if !errors.As(err, &mce) {
+		t.Fatalf("want MergeConflictsError, got: %v", err)
+	}
+	if len(mce.Conflicts) != 1 || mce.Conflicts[0].Field != "issues" {
+		t.Errorf("conflicts = %+v, want the issues table", mce.Conflicts)
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "local")
+	conflicts, err := te.store.GetConflicts(ctx)
+	if err != nil || len(conflicts) != 0 {
+		t.Errorf("live conflicts after abort = %+v, %v", conflicts, err)
+	}
+
+	// A strategy settles it in the staged merge.
+	if err := te.store.StageMerge(ctx, "reorg", "theirs"); err != nil {
+		t.Fatalf("StageMerge theirs: %v", err)
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "reorganized")
+	if err := te.store.Commit(ctx, "merge reorg"); err != nil {
+		t.Fatalf("Commit resolved merge: %v", err)
+	}
+}
+
+func TestEmbeddedStageMergeRefusesDirtyWorkingSet(t *testing.T) {
+	te := newTestEnv(t, "stgd")
+	ctx := t.Context()
+	seedStageMergeBranch(t, ctx, te)
+	te.execOn(t, ctx, "main", "UPDATE issues SET title = 'uncommitted' WHERE id = 'stg-2'")
+
+	if err := te.store.StageMerge(ctx, "reorg", ""); err == nil {
+		t.Fatal("StageMerge on a dirty working set succeeded")
+	}
+	te.assertIssueTitle(t, ctx, "issues", "stg-2", "uncommitted")
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "base")
+}
+
+// A store opened on a branch must read and write that branch on every
+// connection, not just the one that selected it.
+func TestEmbeddedOpenSQLOnBranch(t *testing.T) {
+	te := newTestEnv(t, "stgb")
+	ctx := t.Context()
+	seedStageMergeBranch(t, ctx, te)
+
+	db, cleanup, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "reorg")
+	if err != nil {
+		t.Fatalf("OpenSQL(reorg): %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		var branch, title string
+		if err := db.QueryRowContext(ctx, "SELECT active_branch(), title FROM issues WHERE id = 'stg-1'").Scan(&branch, &title); err != nil {
+			_ = cleanup()
+			t.Fatal(err)
+		}
+		if branch != "reorg" || title != "reorganized" {
+			_ = cleanup()
+			t.Fatalf("query %d saw branch %q title %q, want reorg/reorganized", i, branch, title)
+		}
+	}
+	// Release the engine before opening main: embedded Dolt admits one
+	// connector per directory at a time.
+	_ = cleanup()
+	te.assertIssueTitle(t, ctx, "issues", "stg-1", "base")
+
+	if _, _, err := embeddeddolt.OpenSQL(ctx, te.dataDir, te.database, "bad`name"); err == nil {
+		t.Error("OpenSQL accepted a branch name with a backtick")
+	}
+}
@@ -11,24 +11,26 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// GetStatistics returns summary statistics, served from the local_metadata
+// cache while the issues table hash is unchanged. On a miss the fresh result
+// is written back in the same connection, since opening the engine is the
+// dominant cost here.
 func (s *EmbeddedDoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error) {
-	stats := &types.Statistics{}
-	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
-		if err := issueops.ScanIssueCountsInTx(ctx, tx, stats); err != nil {
-			return err
+	var stats *types.Statistics
+	err := s.withConn(ctx, !s.readOnly, func(tx *sql.Tx) error {
+		hash := issueops.IssuesTableHashInTx(ctx, tx)
+		if stats = issueops.CachedStatisticsInTx(ctx, tx, hash); stats != nil {
+			return nil
 		}
-
-		var blockedCount int
-		if err := tx.QueryRowContext(ctx, `
-			SELECT COUNT(*) FROM issues
-			WHERE is_blocked = 1 AND status <> 'closed' AND status <> 'pinned'
-		`).Scan(&blockedCount); err != nil {
+		var err error
+		if stats, err = issueops.ComputeStatisticsInTx(ctx, tx); err != nil {
 			return err
 		}
-		stats.BlockedIssues = blockedCount
-		stats.ReadyIssues = stats.OpenIssues - stats.BlockedIssues
-		if stats.ReadyIssues < 0 {
-			stats.ReadyIssues = 0
+		if hash != "" && !s.readOnly {
+			// Best effort: a failed cache write only costs a recompute.
+			if value, encErr := issueops.StatisticsCacheValue(hash, stats); encErr == nil {
+				_ = issueops.SetLocalMetadataInTx(ctx, tx, issueops.StatisticsCacheKey, value)
+			}
 		}
 		return nil
 	})
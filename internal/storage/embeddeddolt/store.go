@@ -29,6 +29,23 @@ var _ storage.GarbageCollector = (*EmbeddedDoltStore)(nil)
 var _ storage.Flattener = (*EmbeddedDoltStore)(nil)
 var _ storage.Compactor = (*EmbeddedDoltStore)(nil)
 var _ storage.SchemaMigrator = (*EmbeddedDoltStore)(nil)
+var _ storage.ReferenceStore = (*EmbeddedDoltStore)(nil)
+var _ storage.LockStore = (*EmbeddedDoltStore)(nil)
+var _ storage.CommitLinkStore = (*EmbeddedDoltStore)(nil)
+var _ storage.CIRunStore = (*EmbeddedDoltStore)(nil)
+var _ storage.PriorityAger = (*EmbeddedDoltStore)(nil)
+var _ storage.ExternalRefStore = (*EmbeddedDoltStore)(nil)
+var _ storage.AssignmentRuleStore = (*EmbeddedDoltStore)(nil)
+var _ storage.PatrolStore = (*EmbeddedDoltStore)(nil)
+var _ storage.CompactionSnapshotStore = (*EmbeddedDoltStore)(nil)
+var _ storage.SnapshotPruner = (*EmbeddedDoltStore)(nil)
+var _ storage.ChildCounterStore = (*EmbeddedDoltStore)(nil)
+var _ storage.AliasStore = (*EmbeddedDoltStore)(nil)
+var _ storage.IssueMoveStore = (*EmbeddedDoltStore)(nil)
+var _ storage.CascadeCloser = (*EmbeddedDoltStore)(nil)
+var _ storage.VectorStore = (*EmbeddedDoltStore)(nil)
+var _ storage.TokenStore = (*EmbeddedDoltStore)(nil)
+var _ storage.ActorPurger = (*EmbeddedDoltStore)(nil)
 
 // EmbeddedDoltStore implements storage.DoltStorage backed by the embedded Dolt engine.
 // Each method call opens a short-lived connection, executes within an explicit
@@ -661,6 +678,26 @@ func (s *EmbeddedDoltStore) AsOf(ctx context.Context, issueID string, ref string
 	return result, err
 }
 
+func (s *EmbeddedDoltStore) SearchIssuesAsOf(ctx context.Context, ref string, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var result []*types.Issue
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.SearchIssuesAsOfInTx(ctx, tx, ref, query, filter)
+		return err
+	})
+	return result, err
+}
+
+func (s *EmbeddedDoltStore) CommitAsOf(ctx context.Context, t time.Time) (string, error) {
+	var result string
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.CommitAsOfInTx(ctx, tx, t)
+		return err
+	})
+	return result, err
+}
+
 func (s *EmbeddedDoltStore) Diff(ctx context.Context, fromRef, toRef string) ([]*storage.DiffEntry, error) {
 	var result []*storage.DiffEntry
 	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
@@ -717,10 +754,17 @@ func (s *EmbeddedDoltStore) DeleteIssuesBySourceRepo(ctx context.Context, source
 	return count, err
 }
 
-func (s *EmbeddedDoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
-	return s.withConn(ctx, true, func(tx *sql.Tx) error {
-		return issueops.UpdateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
+func (s *EmbeddedDoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) (*types.RenameResult, error) {
+	var result *types.RenameResult
+	err := s.withConn(ctx, true, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.UpdateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // ClaimIssue is implemented in issues.go.
@@ -0,0 +1,47 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// CreateAPIToken implements storage.TokenStore.
+func (s *EmbeddedDoltStore) CreateAPIToken(ctx context.Context, token *storage.APIToken) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.CreateAPITokenInTx(ctx, tx, token)
+	})
+}
+
+// GetAPIToken implements storage.TokenStore.
+func (s *EmbeddedDoltStore) GetAPIToken(ctx context.Context, id string) (*storage.APIToken, error) {
+	var result *storage.APIToken
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetAPITokenInTx(ctx, tx, id)
+		return err
+	})
+	return result, err
+}
+
+// ListAPITokens implements storage.TokenStore.
+func (s *EmbeddedDoltStore) ListAPITokens(ctx context.Context) ([]*storage.APIToken, error) {
+	var result []*storage.APIToken
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.ListAPITokensInTx(ctx, tx)
+		return err
+	})
+	return result, err
+}
+
+// RevokeAPIToken implements storage.TokenStore.
+func (s *EmbeddedDoltStore) RevokeAPIToken(ctx context.Context, id string) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.RevokeAPITokenInTx(ctx, tx, id)
+	})
+}
@@ -0,0 +1,29 @@
+//go:build cgo
+
+package embeddeddolt
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/issueops"
+)
+
+// GetIssueVectors implements storage.VectorStore.
+func (s *EmbeddedDoltStore) GetIssueVectors(ctx context.Context, provider, model string) ([]*storage.IssueVector, error) {
+	var result []*storage.IssueVector
+	err := s.withConn(ctx, false, func(tx *sql.Tx) error {
+		var err error
+		result, err = issueops.GetIssueVectorsInTx(ctx, tx, provider, model)
+		return err
+	})
+	return result, err
+}
+
+// UpsertIssueVectors implements storage.VectorStore.
+func (s *EmbeddedDoltStore) UpsertIssueVectors(ctx context.Context, vectors []*storage.IssueVector) error {
+	return s.withConn(ctx, true, func(tx *sql.Tx) error {
+		return issueops.UpsertIssueVectorsInTx(ctx, tx, vectors)
+	})
+}
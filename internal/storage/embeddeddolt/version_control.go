@@ -245,6 +245,22 @@ func (s *EmbeddedDoltStore) RecomputeBlockedAfterMerge(ctx context.Context, from
 	return s.recomputeBlockedAfterPull(ctx, fromCommit)
 }
 
+// StageMerge merges branch into the working set without committing; the
+// caller concludes it with Commit or discards it with AbortMerge. Implements
+// storage.StagedMerger.
+func (s *EmbeddedDoltStore) StageMerge(ctx context.Context, branch, strategy string) error {
+	return s.withMutatingPinnedDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		return versioncontrolops.StageMerge(ctx, db, branch, strategy)
+	})
+}
+
+// AbortMerge discards a merge staged by StageMerge.
+func (s *EmbeddedDoltStore) AbortMerge(ctx context.Context) error {
+	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		return versioncontrolops.AbortMerge(ctx, db)
+	})
+}
+
 func (s *EmbeddedDoltStore) GetConflicts(ctx context.Context) ([]storage.Conflict, error) {
 	var conflicts []storage.Conflict
 	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
@@ -255,6 +271,16 @@ func (s *EmbeddedDoltStore) GetConflicts(ctx context.Context) ([]storage.Conflic
 	return conflicts, err
 }
 
+func (s *EmbeddedDoltStore) GetConflictDetails(ctx context.Context) ([]storage.Conflict, error) {
+	var conflicts []storage.Conflict
+	err := s.withDBConn(ctx, func(db versioncontrolops.DBConn) error {
+		var err error
+		conflicts, err = versioncontrolops.GetConflictDetails(ctx, db)
+		return err
+	})
+	return conflicts, err
+}
+
 func (s *EmbeddedDoltStore) ResolveConflicts(ctx context.Context, table string, strategy string) error {
 	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
 		return versioncontrolops.ResolveConflicts(ctx, db, table, strategy)
@@ -353,12 +379,19 @@ func (s *EmbeddedDoltStore) Fetch(ctx context.Context, peer string) error {
 }
 
 func (s *EmbeddedDoltStore) PushTo(ctx context.Context, peer string) error {
+	if err := s.checkPeerDirection(ctx, peer, true); err != nil {
+		return err
+	}
 	return s.withMutatingDBConn(ctx, func(db versioncontrolops.DBConn) error {
 		return versioncontrolops.Push(ctx, db, peer, s.branch, remoteAuthUser())
 	})
 }
 
 func (s *EmbeddedDoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	if err := s.checkPeerDirection(ctx, peer, false); err != nil {
+		return nil, err
+	}
+
 	// Auto-commit pending changes before pull to prevent
 	// "cannot merge with uncommitted changes" errors.
 	if _, err := s.CommitPending(ctx, "beads"); err != nil {
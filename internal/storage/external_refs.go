@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExternalRefStore tracks external URLs that issues depend on (bd dep add
+// <issue> <url>). Callers should type-assert to this interface.
+type ExternalRefStore interface {
+	// SetExternalRefResolved marks url resolved or unresolved, which
+	// unblocks or re-blocks every issue that depends on it.
+	SetExternalRefResolved(ctx context.Context, url string, resolved bool, actor string) error
+	// GetExternalRefs returns the tracked state of urls. URLs that were
+	// never marked are absent from the map and count as unresolved.
+	GetExternalRefs(ctx context.Context, urls []string) (map[string]*types.ExternalRef, error)
+}
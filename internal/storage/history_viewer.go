@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -10,5 +11,7 @@ import (
 type HistoryViewer interface {
 	History(ctx context.Context, issueID string) ([]*HistoryEntry, error)
 	AsOf(ctx context.Context, issueID string, ref string) (*types.Issue, error)
+	SearchIssuesAsOf(ctx context.Context, ref string, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	CommitAsOf(ctx context.Context, t time.Time) (string, error)
 	Diff(ctx context.Context, fromRef, toRef string) ([]*DiffEntry, error)
 }
@@ -1,7 +1,7 @@
 // Package storage — hook_decorator.go
 //
 // HookFiringStore is a decorator around DoltStorage that automatically
-// fires on_create/on_update/on_close hooks after successful mutations.
+// fires on_create/on_update/on_close/on_blocking hooks after successful mutations.
 // This moves hook responsibility from individual CLI commands into the
 // storage layer, ensuring ALL mutations fire hooks — including future
 // commands that haven't been written yet.
@@ -17,6 +17,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/types"
@@ -52,16 +53,20 @@ func NewHookFiringStore(store DoltStorage, runner *hooks.Runner) *HookFiringStor
 // (e.g., StoreLocator, RawDBAccessor).
 func (h *HookFiringStore) Inner() DoltStorage { return h.inner }
 
-// UnwrapStore returns the underlying concrete store if s is a
-// HookFiringStore decorator, otherwise returns s unchanged.
+// UnwrapStore returns the underlying concrete store, peeling off any
+// decorators (HookFiringStore, telemetry instrumentation) that expose it via
+// Inner(). Otherwise returns s unchanged.
 // Use this before type assertions to optional interfaces
 // (StoreLocator, BackupStore, Flattener, etc.) so the assertion
 // reaches the concrete store rather than the decorator.
 func UnwrapStore(s DoltStorage) DoltStorage {
-	if h, ok := s.(*HookFiringStore); ok {
-		return h.inner
+	for {
+		w, ok := s.(interface{ Inner() DoltStorage })
+		if !ok {
+			return s
+		}
+		s = w.Inner()
 	}
-	return s
 }
 
 // ── Issue mutations ─────────────────────────────────────────────────
@@ -133,14 +138,37 @@ func (h *HookFiringStore) CloseIssue(ctx context.Context, id string, reason stri
 	return nil
 }
 
+// CloseCascade closes an issue and its open descendants through the inner
+// store's CascadeCloser and fires on_close for each issue closed.
+func (h *HookFiringStore) CloseCascade(ctx context.Context, rootID, reason, actor, session string) ([]string, error) {
+	cc, ok := UnwrapStore(h.inner).(CascadeCloser)
+	if !ok {
+		return nil, fmt.Errorf("cascade close is not supported by this storage backend")
+	}
+	closed, err := cc.CloseCascade(ctx, rootID, reason, actor, session)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range closed {
+		h.fireHookByID(ctx, hooks.EventClose, id)
+	}
+	return closed, nil
+}
+
 // ── Dependency mutations ────────────────────────────────────────────
 
-// AddDependency adds a dependency and fires on_update for the issue.
+// AddDependency adds a dependency and fires on_update for the issue, plus
+// on_blocking for the blocker when the new edge blocks open work.
 func (h *HookFiringStore) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	if err := h.inner.AddDependency(ctx, dep, actor); err != nil {
 		return err
 	}
 	h.fireDependencyHookByID(ctx, hooks.EventUpdate, dep.IssueID)
+	if h.runner != nil {
+		if p := blockingHookEvent(ctx, dep, h.inner.GetIssue); p != nil {
+			h.fireHook(p.event, p.issue)
+		}
+	}
 	return nil
 }
 
@@ -325,6 +353,27 @@ func dependencyHookEvents(ctx context.Context, issues []*types.Issue, get issueG
 	return events
 }
 
+// blockingHookEvent returns the on_blocking event for a newly added
+// dependency, or nil when the edge does not block or either issue is closed.
+// The payload is the blocker, so hooks can notify its assignee, with
+// Dependencies set to the new edge; its issue_id names the blocked issue.
+func blockingHookEvent(ctx context.Context, dep *types.Dependency, get issueGetter) *pendingHook {
+	if dep == nil || !dep.Type.IsBlockingEdge() {
+		return nil
+	}
+	blocked, err := get(ctx, dep.IssueID)
+	if err != nil || blocked.Status == types.StatusClosed {
+		return nil
+	}
+	blocker, err := get(ctx, dep.DependsOnID)
+	if err != nil || blocker.Status == types.StatusClosed {
+		return nil
+	}
+	edge := *dep
+	blocker.Dependencies = []*types.Dependency{&edge}
+	return &pendingHook{event: hooks.EventBlocking, issue: blocker}
+}
+
 func dependencySnapshot(ctx context.Context, issueID string, get issueGetter, getDeps dependencyRecordsGetter) (*types.Issue, error) {
 	snapshot, err := get(ctx, issueID)
 	if err != nil {
@@ -480,6 +529,9 @@ func (t *hookTrackingTransaction) AddDependencyWithOptions(ctx context.Context,
 	if issue, err := dependencySnapshot(ctx, dep.IssueID, t.Transaction.GetIssue, t.Transaction.GetDependencyRecords); err == nil {
 		t.pending = append(t.pending, pendingHook{hooks.EventUpdate, issue})
 	}
+	if p := blockingHookEvent(ctx, dep, t.Transaction.GetIssue); p != nil {
+		t.pending = append(t.pending, *p)
+	}
 	return nil
 }
 
@@ -532,6 +584,7 @@ func (t *hookTrackingTransaction) DeleteIssue(ctx context.Context, id string) er
 // Ensure compile-time interface satisfaction.
 var _ DoltStorage = (*HookFiringStore)(nil)
 var _ Transaction = (*hookTrackingTransaction)(nil)
+var _ CascadeCloser = (*HookFiringStore)(nil)
 
 // Ensure HookFiringStore's mutation methods are used (not the embedded passthrough).
 // This compile-time check prevents accidentally forgetting to override a method.
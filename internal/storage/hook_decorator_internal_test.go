@@ -62,6 +62,13 @@ func (s fakeHookStore) GetDependencyRecords(_ context.Context, id string) ([]*ty
 	return cloneDependenciesForHook(issue.Dependencies), nil
 }
 
+func (s fakeHookStore) AddDependency(_ context.Context, dep *types.Dependency, _ string) error {
+	if issue, ok := s.issues[dep.IssueID]; ok {
+		issue.Dependencies = append(issue.Dependencies, dep)
+	}
+	return nil
+}
+
 func (s fakeHookStore) RunInTransaction(ctx context.Context, _ string, fn func(tx Transaction) error) error {
 	return fn(fakeHookTransaction{issues: s.issues, dropDependencies: s.dropDependencies})
 }
@@ -107,6 +114,13 @@ func (tx fakeHookTransaction) GetDependencyRecords(_ context.Context, id string)
 	return cloneDependenciesForHook(issue.Dependencies), nil
 }
 
+func (tx fakeHookTransaction) AddDependencyWithOptions(_ context.Context, dep *types.Dependency, _ string, _ DependencyAddOptions) error {
+	if issue, ok := tx.issues[dep.IssueID]; ok {
+		issue.Dependencies = append(issue.Dependencies, dep)
+	}
+	return nil
+}
+
 func cloneForFakeHookStore(issue *types.Issue, dropDependencies bool) *types.Issue {
 	clone := cloneIssueForHook(issue)
 	if dropDependencies {
@@ -450,3 +464,69 @@ func TestNewHookFiringStoreNilRunnerSkipsCreateHooks(t *testing.T) {
 		t.Fatalf("CreateIssue: %v", err)
 	}
 }
+
+func TestHookFiringStoreAddDependencyFiresBlockingHook(t *testing.T) {
+	tests := []struct {
+		name          string
+		depType       types.DependencyType
+		blockerStatus types.Status
+		wantEvents    []string
+	}{
+		{"blocks", types.DepBlocks, types.StatusOpen, []string{hooks.EventUpdate, hooks.EventBlocking}},
+		{"waits-for", types.DepWaitsFor, types.StatusInProgress, []string{hooks.EventUpdate, hooks.EventBlocking}},
+		{"related", types.DepRelated, types.StatusOpen, []string{hooks.EventUpdate}},
+		{"closed blocker", types.DepBlocks, types.StatusClosed, []string{hooks.EventUpdate}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &recordingHookRunner{}
+			inner := fakeHookStore{issues: map[string]*types.Issue{
+				"blocked": {ID: "blocked", Status: types.StatusOpen},
+				"blocker": {ID: "blocker", Status: tt.blockerStatus, Assignee: "alice"},
+			}}
+			store := &HookFiringStore{DoltStorage: inner, inner: inner, runner: runner}
+			dep := &types.Dependency{IssueID: "blocked", DependsOnID: "blocker", Type: tt.depType}
+
+			if err := store.AddDependency(context.Background(), dep, "tester"); err != nil {
+				t.Fatalf("AddDependency: %v", err)
+			}
+			if !reflect.DeepEqual(runner.events, tt.wantEvents) {
+				t.Fatalf("events = %v, want %v", runner.events, tt.wantEvents)
+			}
+			if len(tt.wantEvents) < 2 {
+				return
+			}
+			payload := runner.issues[1]
+			if payload.ID != "blocker" || payload.Assignee != "alice" {
+				t.Fatalf("blocking payload = %s @%s, want blocker @alice", payload.ID, payload.Assignee)
+			}
+			if len(payload.Dependencies) != 1 || payload.Dependencies[0].IssueID != "blocked" {
+				t.Fatalf("blocking payload dependencies = %+v, want the new edge from blocked", payload.Dependencies)
+			}
+		})
+	}
+}
+
+func TestHookFiringStoreTransactionAddDependencyFiresBlockingHook(t *testing.T) {
+	runner := &recordingHookRunner{}
+	inner := fakeHookStore{issues: map[string]*types.Issue{
+		"blocked": {ID: "blocked", Status: types.StatusOpen},
+		"blocker": {ID: "blocker", Status: types.StatusOpen, Assignee: "alice"},
+	}}
+	store := &HookFiringStore{DoltStorage: inner, inner: inner, runner: runner}
+
+	err := store.RunInTransaction(context.Background(), "test", func(tx Transaction) error {
+		return tx.AddDependency(context.Background(), &types.Dependency{IssueID: "blocked", DependsOnID: "blocker", Type: types.DepBlocks}, "tester")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction: %v", err)
+	}
+
+	wantEvents := []string{hooks.EventUpdate, hooks.EventBlocking}
+	if !reflect.DeepEqual(runner.events, wantEvents) {
+		t.Fatalf("events = %v, want %v", runner.events, wantEvents)
+	}
+	if runner.issues[1].ID != "blocker" {
+		t.Fatalf("blocking hook issue ID = %q, want blocker", runner.issues[1].ID)
+	}
+}
@@ -0,0 +1,111 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAliasInTx points alias at issueID. The alias must not already exist or
+// be an issue ID, and issueID must be a persistent issue.
+func AddAliasInTx(ctx context.Context, tx *sql.Tx, alias, issueID, actor string) error {
+	var existing string
+	err := tx.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&existing)
+	switch {
+	case err == nil:
+		return fmt.Errorf("alias %q already points at %s", alias, existing)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("check alias %s: %w", alias, err)
+	}
+	for _, table := range []string{"issues", "wisps"} {
+		var one int
+		//nolint:gosec // G201: table is one of two hardcoded constants.
+		err := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT 1 FROM %s WHERE id = ?`, table), alias).Scan(&one)
+		switch {
+		case err == nil:
+			return fmt.Errorf("alias %q is already an issue ID", alias)
+		case !errors.Is(err, sql.ErrNoRows) && !isTableNotExistError(err):
+			return fmt.Errorf("check alias %s: %w", alias, err)
+		}
+	}
+	var one int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, issueID).Scan(&one)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		if IsActiveWispInTx(ctx, tx, issueID) {
+			return fmt.Errorf("cannot alias %s: wisps cannot have aliases", issueID)
+		}
+		return fmt.Errorf("issue %s: %w", issueID, storage.ErrNotFound)
+	case err != nil:
+		return fmt.Errorf("check issue %s: %w", issueID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_aliases (alias, issue_id, created_by) VALUES (?, ?, ?)`,
+		alias, issueID, actor); err != nil {
+		return fmt.Errorf("add alias %s: %w", alias, err)
+	}
+	return nil
+}
+
+// RemoveAliasInTx deletes alias.
+func RemoveAliasInTx(ctx context.Context, tx *sql.Tx, alias string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM issue_aliases WHERE alias = ?`, alias)
+	if err != nil {
+		return fmt.Errorf("remove alias %s: %w", alias, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("remove alias %s: %w", alias, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("alias %q: %w", alias, storage.ErrNotFound)
+	}
+	return nil
+}
+
+// ResolveAliasInTx returns the issue ID alias points at. Databases created
+// before aliases existed resolve nothing.
+func ResolveAliasInTx(ctx context.Context, tx *sql.Tx, alias string) (string, error) {
+	var issueID string
+	err := tx.QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, alias).Scan(&issueID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows), err != nil && isTableNotExistError(err):
+		return "", fmt.Errorf("alias %q: %w", alias, storage.ErrNotFound)
+	case err != nil:
+		return "", fmt.Errorf("resolve alias %s: %w", alias, err)
+	}
+	return issueID, nil
+}
+
+// GetAliasesInTx returns the aliases of issueID, or every alias when
+// issueID is empty, ordered by alias.
+func GetAliasesInTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.IssueAlias, error) {
+	query := `SELECT alias, issue_id, COALESCE(created_by, ''), created_at FROM issue_aliases`
+	var args []interface{}
+	if issueID != "" {
+		query += ` WHERE issue_id = ?`
+		args = append(args, issueID)
+	}
+	rows, err := tx.QueryContext(ctx, query+` ORDER BY alias`, args...)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*types.IssueAlias
+	for rows.Next() {
+		a := &types.IssueAlias{}
+		if err := rows.Scan(&a.Alias, &a.IssueID, &a.CreatedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+	return aliases, rows.Err()
+}
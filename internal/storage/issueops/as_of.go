@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -88,3 +90,63 @@ func AsOfInTx(ctx context.Context, tx *sql.Tx, issueID string, ref string) (*typ
 
 	return &issue, nil
 }
+
+// SearchIssuesAsOfInTx runs an issue search against the issues table as it
+// existed at ref. The ref is resolved to a commit hash and queried through
+// Dolt's read-only revision database ("<db>/<hash>"), so every filter that
+// SearchIssuesInTx supports works unchanged. Wisps are not versioned and are
+// never included.
+//
+// nolint:gosec // G201: the revision database name is built from DATABASE() and a resolved commit hash
+func SearchIssuesAsOfInTx(ctx context.Context, tx *sql.Tx, ref string, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	if err := ValidateRef(ref); err != nil {
+		return nil, fmt.Errorf("invalid ref: %w", err)
+	}
+	var hash string
+	if err := tx.QueryRowContext(ctx, "SELECT DOLT_HASHOF(?)", ref).Scan(&hash); err != nil {
+		// Older engines (and embedded Dolt) expose only the HASHOF alias.
+		if err := tx.QueryRowContext(ctx, "SELECT HASHOF(?)", ref).Scan(&hash); err != nil {
+			return nil, fmt.Errorf("resolve ref %s: %w", ref, err)
+		}
+	}
+	if !doltCommitHashRE.MatchString(hash) {
+		return nil, fmt.Errorf("resolve ref %s: unexpected commit hash %q", ref, hash)
+	}
+	var db sql.NullString
+	if err := tx.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&db); err != nil {
+		return nil, fmt.Errorf("search issues as of %s: %w", ref, err)
+	}
+	if !db.Valid || db.String == "" || strings.ContainsAny(db.String, "`/") {
+		return nil, fmt.Errorf("search issues as of %s: no usable current database", ref)
+	}
+
+	rev := fmt.Sprintf("`%s/%s`.", db.String, hash)
+	tables := FilterTables{
+		Main:         rev + IssuesFilterTables.Main,
+		Labels:       rev + IssuesFilterTables.Labels,
+		Dependencies: rev + IssuesFilterTables.Dependencies,
+		Comments:     rev + IssuesFilterTables.Comments,
+	}
+	filter.Ephemeral = nil
+	issues, err := searchTableInTx(ctx, tx, query, filter, tables)
+	if err != nil {
+		return nil, fmt.Errorf("search issues as of %s: %w", ref, err)
+	}
+	return issues, nil
+}
+
+// CommitAsOfInTx returns the most recent commit on the current branch made
+// at or before t, for resolving a timestamp into a ref usable by the AS OF
+// queries above. Returns storage.ErrNotFound when the history starts after t.
+func CommitAsOfInTx(ctx context.Context, tx *sql.Tx, t time.Time) (string, error) {
+	var hash string
+	err := tx.QueryRowContext(ctx,
+		"SELECT commit_hash FROM dolt_log WHERE date <= ? ORDER BY date DESC LIMIT 1", t.UTC()).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("%w: no commit at or before %s", storage.ErrNotFound, t.Format(time.RFC3339))
+	}
+	if err != nil {
+		return "", fmt.Errorf("find commit as of %s: %w", t.Format(time.RFC3339), err)
+	}
+	return hash, nil
+}
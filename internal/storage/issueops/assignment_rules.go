@@ -0,0 +1,110 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddAssignmentRuleInTx inserts rule, failing if its name is taken.
+func AddAssignmentRuleInTx(ctx context.Context, tx *sql.Tx, rule *types.AssignmentRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now().UTC()
+	}
+	var one int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM assignment_rules WHERE name = ?`, rule.Name).Scan(&one)
+	switch {
+	case err == nil:
+		return fmt.Errorf("assignment rule %q already exists", rule.Name)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("check assignment rule %s: %w", rule.Name, err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO assignment_rules (name, priority, label, path_prefix, issue_type, strategy, assignees, next_index, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.Name, rule.Priority, rule.Label, rule.PathPrefix, rule.IssueType, rule.Strategy,
+		strings.Join(rule.Assignees, ","), rule.NextIndex, rule.CreatedBy, rule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add assignment rule %s: %w", rule.Name, err)
+	}
+	return nil
+}
+
+// RemoveAssignmentRuleInTx deletes the named rule.
+func RemoveAssignmentRuleInTx(ctx context.Context, tx *sql.Tx, name string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM assignment_rules WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("remove assignment rule %s: %w", name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("remove assignment rule %s: %w", name, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("assignment rule %q: %w", name, storage.ErrNotFound)
+	}
+	return nil
+}
+
+// GetAssignmentRulesInTx returns every rule ordered by priority, then name.
+// Databases created before assignment_rules existed have none.
+func GetAssignmentRulesInTx(ctx context.Context, tx *sql.Tx) ([]*types.AssignmentRule, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT name, priority, label, path_prefix, issue_type, strategy, assignees, next_index, created_by, created_at
+		FROM assignment_rules ORDER BY priority, name`)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get assignment rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*types.AssignmentRule
+	for rows.Next() {
+		var rule types.AssignmentRule
+		var assignees string
+		var createdBy sql.NullString
+		if err := rows.Scan(&rule.Name, &rule.Priority, &rule.Label, &rule.PathPrefix, &rule.IssueType,
+			&rule.Strategy, &assignees, &rule.NextIndex, &createdBy, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan assignment rule: %w", err)
+		}
+		for _, a := range strings.Split(assignees, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				rule.Assignees = append(rule.Assignees, a)
+			}
+		}
+		rule.CreatedBy = createdBy.String
+		rules = append(rules, &rule)
+	}
+	return rules, rows.Err()
+}
+
+// AdvanceAssignmentRuleInTx increments the named rule's round-robin cursor
+// and returns its previous value. The increment comes first so concurrent
+// callers serialize on the row write.
+func AdvanceAssignmentRuleInTx(ctx context.Context, tx *sql.Tx, name string) (int, error) {
+	res, err := tx.ExecContext(ctx, `UPDATE assignment_rules SET next_index = next_index + 1 WHERE name = ?`, name)
+	if err != nil {
+		return 0, fmt.Errorf("advance assignment rule %s: %w", name, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("advance assignment rule %s: %w", name, err)
+	} else if n == 0 {
+		return 0, fmt.Errorf("assignment rule %q: %w", name, storage.ErrNotFound)
+	}
+	var next int
+	if err := tx.QueryRowContext(ctx, `SELECT next_index FROM assignment_rules WHERE name = ?`, name).Scan(&next); err != nil {
+		return 0, fmt.Errorf("read assignment rule %s: %w", name, err)
+	}
+	return next - 1, nil
+}
@@ -268,7 +268,13 @@ func GetBlockedIssuesInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilt
 			return nil, fmt.Errorf("blocked id rows from %s: %w", table, err)
 		}
 	}
-	if len(blockedIDList) == 0 {
+	// Dependencies on issues in federation peers and on external URLs are
+	// resolved at query time rather than through is_blocked.
+	federatedBlocked, err := OutsideBlockersInTx(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("get blocked issues: %w", err)
+	}
+	if len(blockedIDList) == 0 && len(federatedBlocked) == 0 {
 		return nil, nil
 	}
 
@@ -299,6 +305,10 @@ func GetBlockedIssuesInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilt
 		}
 	}
 
+	for id, refs := range federatedBlocked {
+		blockerMap[id] = append(blockerMap[id], refs...)
+	}
+
 	var inheritedIDs []string
 	for _, id := range blockedIDList {
 		if _, ok := blockerMap[id]; !ok {
@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
@@ -214,63 +213,6 @@ func DeleteIssuesBySourceRepoInTx(ctx context.Context, tx *sql.Tx, sourceRepo st
 	return int(rowsAffected), nil
 }
 
-//nolint:gosec // G201: table names are hardcoded
-func UpdateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
-	if IsActiveWispInTx(ctx, tx, oldID) {
-		return updateWispIDInTx(ctx, tx, oldID, newID, issue, actor)
-	}
-	return updateIssueIDInTx(ctx, tx, oldID, newID, issue, actor)
-}
-
-func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
-	now := time.Now().UTC()
-	result, err := tx.ExecContext(ctx, `
-		UPDATE issues
-		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
-		WHERE id = ?
-	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, now, oldID)
-	if err != nil {
-		return fmt.Errorf("update issue ID: %w", err)
-	}
-	if rows, _ := result.RowsAffected(); rows == 0 {
-		return fmt.Errorf("issue not found: %s", oldID)
-	}
-
-	if err := UpdateIssueIDInDependenciesInTx(ctx, tx, oldID, newID); err != nil {
-		return err
-	}
-
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, 'renamed', ?, ?, ?)
-	`, NewEventID(), newID, actor, oldID, newID)
-	return err
-}
-
-func updateWispIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
-	now := time.Now().UTC()
-	result, err := tx.ExecContext(ctx, `
-		UPDATE wisps
-		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
-		WHERE id = ?
-	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, now, oldID)
-	if err != nil {
-		return fmt.Errorf("update wisp ID: %w", err)
-	}
-	if rows, _ := result.RowsAffected(); rows == 0 {
-		return fmt.Errorf("wisp not found: %s", oldID)
-	}
-
-	if _, err = tx.ExecContext(ctx, `
-		INSERT INTO wisp_events (id, issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, 'renamed', ?, ?, ?)
-	`, NewEventID(), newID, actor, oldID, newID); err != nil {
-		return err
-	}
-
-	return UpdateWispIDInDependenciesInTx(ctx, tx, oldID, newID)
-}
-
 // FindWispDependentsRecursiveInTx walks wisp_dependencies to find all transitive
 // dependents of the given IDs.
 func FindWispDependentsRecursiveInTx(ctx context.Context, tx *sql.Tx, ids []string) (map[string]bool, error) {
@@ -0,0 +1,236 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChildCounterStatusInTx compares every child_counters row with the
+// hierarchical children in the issues table. Parents with children but no
+// counter row are included with HasCounter false. Wisp counters are not
+// covered.
+func ChildCounterStatusInTx(ctx context.Context, tx *sql.Tx) ([]*types.ChildCounterStatus, error) {
+	byParent := make(map[string]*types.ChildCounterStatus)
+	status := func(parentID string) *types.ChildCounterStatus {
+		s, ok := byParent[parentID]
+		if !ok {
+			s = &types.ChildCounterStatus{ParentID: parentID}
+			byParent[parentID] = s
+		}
+		return s
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT parent_id, last_child FROM child_counters`)
+	if err != nil {
+		return nil, fmt.Errorf("read child counters: %w", err)
+	}
+	for rows.Next() {
+		var parentID string
+		var lastChild int
+		if err := rows.Scan(&parentID, &lastChild); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan child counter: %w", err)
+		}
+		s := status(parentID)
+		s.LastChild, s.HasCounter = lastChild, true
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read child counters: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx, `SELECT id FROM issues WHERE id LIKE '%.%'`)
+	if err != nil {
+		return nil, fmt.Errorf("read hierarchical issues: %w", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan issue id: %w", err)
+		}
+		parentID, childNum, ok := ParseHierarchicalID(id)
+		if !ok {
+			continue
+		}
+		s := status(parentID)
+		s.Children++
+		s.MaxChild = max(s.MaxChild, childNum)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read hierarchical issues: %w", err)
+	}
+
+	parentIDs := make([]string, 0, len(byParent))
+	for id := range byParent {
+		parentIDs = append(parentIDs, id)
+	}
+	sort.Strings(parentIDs)
+	for start := 0; start < len(parentIDs); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(parentIDs))
+		placeholders, args := buildSQLInClause(parentIDs[start:end])
+		//nolint:gosec // G201: placeholders contains only ? markers.
+		existing, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT id FROM issues WHERE id IN (%s)`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("check parents: %w", err)
+		}
+		for existing.Next() {
+			var id string
+			if err := existing.Scan(&id); err != nil {
+				_ = existing.Close()
+				return nil, fmt.Errorf("scan parent id: %w", err)
+			}
+			byParent[id].ParentExists = true
+		}
+		_ = existing.Close()
+		if err := existing.Err(); err != nil {
+			return nil, fmt.Errorf("check parents: %w", err)
+		}
+	}
+
+	result := make([]*types.ChildCounterStatus, 0, len(parentIDs))
+	for _, id := range parentIDs {
+		result = append(result, byParent[id])
+	}
+	return result, nil
+}
+
+// RepairChildCountersInTx raises counters that trail an existing child and
+// deletes counters whose parent no longer exists. It returns the number of
+// counters changed.
+func RepairChildCountersInTx(ctx context.Context, tx *sql.Tx) (int, error) {
+	statuses, err := ChildCounterStatusInTx(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, s := range statuses {
+		switch {
+		case s.Orphaned():
+			if _, err := tx.ExecContext(ctx, `DELETE FROM child_counters WHERE parent_id = ?`, s.ParentID); err != nil {
+				return changed, fmt.Errorf("delete child counter for %s: %w", s.ParentID, err)
+			}
+		case s.Behind() && s.ParentExists:
+			if err := setChildCounterInTx(ctx, tx, s.ParentID, s.MaxChild); err != nil {
+				return changed, err
+			}
+		default:
+			continue
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// RenumberChildrenInTx renames issues by renames (old ID -> new ID) and
+// raises parentID's counter to at least lastChild. New IDs may reuse old IDs from the same
+// map, so every issue is first moved to a temporary ID. Dependencies,
+// references, and counters of renamed parents follow their issue, and each
+// issue gets a single 'renamed' event.
+func RenumberChildrenInTx(ctx context.Context, tx *sql.Tx, parentID string, renames map[string]string, lastChild int, actor string) error {
+	if IsActiveWispInTx(ctx, tx, parentID) {
+		return fmt.Errorf("renumbering wisp children is not supported")
+	}
+
+	oldIDs := make([]string, 0, len(renames))
+	for oldID, newID := range renames {
+		if oldID != newID {
+			oldIDs = append(oldIDs, oldID)
+		}
+	}
+	sort.Strings(oldIDs)
+	moving := make(map[string]bool, len(oldIDs))
+	for _, id := range oldIDs {
+		moving[id] = true
+	}
+	for _, oldID := range oldIDs {
+		newID := renames[oldID]
+		if moving[newID] {
+			continue
+		}
+		var exists int
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, newID).Scan(&exists)
+		if err == nil {
+			return fmt.Errorf("cannot rename %s to %s: issue already exists", oldID, newID)
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("check %s: %w", newID, err)
+		}
+	}
+
+	tempID := func(id string) string { return "~renumber~" + id }
+	for _, oldID := range oldIDs {
+		if err := moveIssueIDInTx(ctx, tx, oldID, tempID(oldID)); err != nil {
+			return err
+		}
+	}
+	for _, oldID := range oldIDs {
+		newID := renames[oldID]
+		if err := moveIssueIDInTx(ctx, tx, tempID(oldID), newID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, ?, 'renamed', ?, ?, ?)
+		`, NewEventID(), newID, actor, oldID, newID); err != nil {
+			return fmt.Errorf("record rename of %s: %w", oldID, err)
+		}
+	}
+	// Never lower the counter: numbers freed by renumbering (or by deleted
+	// children) are not handed out again.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO child_counters (parent_id, last_child) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE last_child = GREATEST(last_child, ?)
+	`, parentID, lastChild, lastChild); err != nil {
+		return fmt.Errorf("update child counter for %s: %w", parentID, err)
+	}
+	return nil
+}
+
+// moveIssueIDInTx changes an issue's ID without recording an event. Rows
+// sourced from the issue follow it through the FK's ON UPDATE CASCADE;
+// dependencies, references, and its own child counter are retargeted here.
+func moveIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string) error {
+	result, err := tx.ExecContext(ctx, `UPDATE issues SET id = ?, updated_at = ? WHERE id = ?`,
+		newID, time.Now().UTC(), oldID)
+	if err != nil {
+		return fmt.Errorf("rename %s: %w", displayID(oldID), err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("issue not found: %s", displayID(oldID))
+	}
+	if err := UpdateIssueIDInDependenciesInTx(ctx, tx, oldID, newID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE issue_references SET target = ? WHERE ref_type = ? AND target = ?
+	`, newID, types.ReferenceTypeIssue, oldID); err != nil && !isTableNotExistError(err) {
+		return fmt.Errorf("update issue references: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE child_counters SET parent_id = ? WHERE parent_id = ?`, newID, oldID); err != nil {
+		return fmt.Errorf("move child counter of %s: %w", displayID(oldID), err)
+	}
+	return nil
+}
+
+// displayID strips the temporary renumbering marker from error messages.
+func displayID(id string) string {
+	return strings.TrimPrefix(id, "~renumber~")
+}
+
+func setChildCounterInTx(ctx context.Context, tx *sql.Tx, parentID string, lastChild int) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO child_counters (parent_id, last_child) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE last_child = ?
+	`, parentID, lastChild, lastChild); err != nil {
+		return fmt.Errorf("set child counter for %s: %w", parentID, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// childProgressSources pairs each dependency table with the table holding
+// the child side of its rows: permanent children live in issues with their
+// edges in dependencies, wisp children in wisps with edges in wisp_dependencies.
+var childProgressSources = []struct{ depTable, childTable string }{
+	{"dependencies", "issues"},
+	{"wisp_dependencies", "wisps"},
+}
+
+// GetChildProgressInTx returns closed/total parent-child counts for each of
+// parentIDs, aggregated in SQL with one grouped query per table pair and
+// batch. Parents without children are absent from the result.
+func GetChildProgressInTx(ctx context.Context, tx *sql.Tx, parentIDs []string) (map[string]*types.ChildProgress, error) {
+	result := make(map[string]*types.ChildProgress)
+	if len(parentIDs) == 0 {
+		return result, nil
+	}
+
+	sources := childProgressSources
+	if empty, probeErr := wispsTableEmptyOrMissingInTx(ctx, tx); probeErr != nil {
+		return nil, fmt.Errorf("get child progress: probe: %w", probeErr)
+	} else if empty {
+		sources = sources[:1]
+	}
+
+	totals := make(map[string][2]int)
+	for start := 0; start < len(parentIDs); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(parentIDs))
+		placeholders, args := buildSQLInClause(parentIDs[start:end])
+
+		for _, src := range sources {
+			//nolint:gosec // G201: table names are hardcoded and placeholders contain only ? markers.
+			rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+				SELECT %s AS parent_id, COUNT(*),
+				       SUM(CASE WHEN c.status = 'closed' THEN 1 ELSE 0 END)
+				FROM %s d
+				JOIN %s c ON c.id = d.issue_id
+				WHERE d.type = 'parent-child' AND %s
+				GROUP BY %s
+			`, depTargetExpr("d"), src.depTable, src.childTable, depTargetIn("d", placeholders), depTargetExpr("d")), args...)
+			if err != nil {
+				if optionalBlockedTable(src.depTable) && isTableNotExistError(err) {
+					continue
+				}
+				return nil, fmt.Errorf("get child progress from %s: %w", src.depTable, err)
+			}
+			for rows.Next() {
+				var parentID string
+				var total, closed int
+				if err := rows.Scan(&parentID, &total, &closed); err != nil {
+					_ = rows.Close()
+					return nil, fmt.Errorf("get child progress: scan: %w", err)
+				}
+				t := totals[parentID]
+				totals[parentID] = [2]int{t[0] + total, t[1] + closed}
+			}
+			_ = rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, fmt.Errorf("get child progress: rows: %w", err)
+			}
+		}
+	}
+
+	for id, t := range totals {
+		result[id] = types.NewChildProgress(t[0], t[1])
+	}
+	return result, nil
+}
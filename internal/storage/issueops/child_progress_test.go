@@ -0,0 +1,59 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestGetChildProgressInTxSumsPermanentAndWispChildren(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`SELECT 1 FROM wisps LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	progressCols := []string{"parent_id", "count", "closed"}
+	mock.ExpectQuery(`(?s)FROM dependencies d\s+JOIN issues c ON c.id = d.issue_id.*GROUP BY`).
+		WithArgs("epic-1", "epic-2").
+		WillReturnRows(sqlmock.NewRows(progressCols).AddRow("epic-1", 3, 1))
+	mock.ExpectQuery(`(?s)FROM wisp_dependencies d\s+JOIN wisps c ON c.id = d.issue_id.*GROUP BY`).
+		WithArgs("epic-1", "epic-2").
+		WillReturnRows(sqlmock.NewRows(progressCols).AddRow("epic-1", 1, 1))
+
+	got, err := GetChildProgressInTx(context.Background(), tx, []string{"epic-1", "epic-2"})
+	if err != nil {
+		t.Fatalf("GetChildProgressInTx: %v", err)
+	}
+	p := got["epic-1"]
+	if p == nil || p.Total != 4 || p.Closed != 2 || p.Percent != 50 {
+		t.Fatalf("epic-1 progress = %+v, want 2/4 (50%%)", p)
+	}
+	if _, ok := got["epic-2"]; ok {
+		t.Fatal("childless parent should be absent")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
+
+func TestGetChildProgressInTxSkipsWispTablesWhenEmpty(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`SELECT 1 FROM wisps LIMIT 1`).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`(?s)FROM dependencies d\s+JOIN issues c`).
+		WillReturnRows(sqlmock.NewRows([]string{"parent_id", "count", "closed"}).AddRow("mol-1", 2, 2))
+
+	got, err := GetChildProgressInTx(context.Background(), tx, []string{"mol-1"})
+	if err != nil {
+		t.Fatalf("GetChildProgressInTx: %v", err)
+	}
+	if p := got["mol-1"]; p == nil || p.Percent != 100 {
+		t.Fatalf("mol-1 progress = %+v, want 100%%", p)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
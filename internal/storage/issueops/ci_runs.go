@@ -0,0 +1,87 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RecordCIRunInTx upserts run keyed by (issue, workflow, commit) and records
+// a ci_reported event on the issue.
+func RecordCIRunInTx(ctx context.Context, tx *sql.Tx, run *types.CIRun, actor string) error {
+	if run.IssueID == "" || run.Name == "" || run.CommitSHA == "" {
+		return fmt.Errorf("CI run needs an issue ID, a name, and a commit SHA")
+	}
+	if !types.IsValidCIStatus(run.Status) {
+		return fmt.Errorf("invalid CI status %q", run.Status)
+	}
+	if run.UpdatedAt.IsZero() {
+		run.UpdatedAt = time.Now().UTC()
+	}
+	if run.ReportedBy == "" {
+		run.ReportedBy = actor
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO ci_runs (issue_id, name, commit_sha, branch, status, url, reported_by, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE branch = VALUES(branch), status = VALUES(status), url = VALUES(url),
+			reported_by = VALUES(reported_by), updated_at = VALUES(updated_at)`,
+		run.IssueID, run.Name, run.CommitSHA, run.Branch, run.Status, run.URL, run.ReportedBy, run.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("record CI run %s for %s: %w", run.Name, run.IssueID, err)
+	}
+	short := run.CommitSHA
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	return RecordEventInTable(ctx, tx, "events", run.IssueID, types.EventCIReported, actor,
+		fmt.Sprintf("%s: %s @ %s", run.Name, run.Status, short))
+}
+
+// GetCIRunsInTx returns the CI runs for issueIDs, newest first per issue.
+// Databases created before ci_runs existed have none.
+func GetCIRunsInTx(ctx context.Context, tx *sql.Tx, issueIDs []string) (map[string][]*types.CIRun, error) {
+	result := make(map[string][]*types.CIRun)
+	for start := 0; start < len(issueIDs); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(issueIDs))
+		batch := issueIDs[start:end]
+		placeholders := make([]string, len(batch))
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			placeholders[i] = "?"
+			args[i] = id
+		}
+		//nolint:gosec // G201: only placeholders are interpolated
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, name, commit_sha, branch, status, url, reported_by, updated_at
+			FROM ci_runs WHERE issue_id IN (%s) ORDER BY updated_at DESC, name`,
+			strings.Join(placeholders, ",")), args...)
+		if err != nil {
+			if isTableNotExistError(err) {
+				return result, nil
+			}
+			return nil, fmt.Errorf("get CI runs: %w", err)
+		}
+		for rows.Next() {
+			var run types.CIRun
+			var url, reportedBy sql.NullString
+			if err := rows.Scan(&run.IssueID, &run.Name, &run.CommitSHA, &run.Branch, &run.Status, &url, &reportedBy, &run.UpdatedAt); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan CI run: %w", err)
+			}
+			run.URL = url.String
+			run.ReportedBy = reportedBy.String
+			result[run.IssueID] = append(result[run.IssueID], &run)
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("get CI runs: %w", err)
+		}
+	}
+	return result, nil
+}
@@ -41,6 +41,22 @@ func closeIssueInTx(ctx context.Context, tx *sql.Tx, id string, reason, actor, s
 		return nil, fmt.Errorf("affected by close for %s: %w", id, aerr)
 	}
 
+	// Locks only apply to issues; wisps cannot be locked.
+	if !isWisp {
+		if err := CheckIssueLockInTx(ctx, tx, id, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Enforce the closed status policy. Closing an already-closed issue is a
+	// no-op below, so it is not re-checked.
+	if issue, err := GetIssueInTx(ctx, tx, id); err == nil && issue != nil && issue.Status != types.StatusClosed {
+		issue.CloseReason = reason
+		if err := CheckStatusPolicyInTx(ctx, tx, issue, types.StatusClosed); err != nil {
+			return nil, err
+		}
+	}
+
 	now := time.Now().UTC()
 
 	result, err := tx.ExecContext(ctx, fmt.Sprintf(`
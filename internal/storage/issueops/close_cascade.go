@@ -0,0 +1,51 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CloseCascadeInTx closes rootID and each of its parent-child descendants
+// that is still open, recording one 'closed' event per issue. Descendants
+// are closed before the root, and their event notes the root they were
+// closed with. It returns the IDs actually closed, root last.
+func CloseCascadeInTx(ctx context.Context, tx *sql.Tx, rootID, reason, actor, session string) ([]string, error) {
+	descendants, err := GetDescendantIDsInTx(ctx, tx, rootID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("find descendants of %s: %w", rootID, err)
+	}
+	childReason := fmt.Sprintf("%s (closed with %s)", reason, rootID)
+
+	var closed []string
+	seen := make(map[string]bool, len(descendants))
+	for _, id := range append(descendants, rootID) {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		issue, err := GetIssueInTx(ctx, tx, id)
+		if errors.Is(err, storage.ErrNotFound) && id != rootID {
+			continue // dangling parent-child edge
+		}
+		if err != nil {
+			return nil, err
+		}
+		if issue.Status == types.StatusClosed {
+			continue
+		}
+		r := childReason
+		if id == rootID {
+			r = reason
+		}
+		if _, err := CloseIssueInTx(ctx, tx, id, r, actor, session); err != nil {
+			return nil, fmt.Errorf("close %s: %w", id, err)
+		}
+		closed = append(closed, id)
+	}
+	return closed, nil
+}
@@ -115,6 +115,8 @@ func AddIssueCommentInTx(ctx context.Context, tx *sql.Tx, issueID, author, text
 }
 
 // ImportIssueCommentInTx adds a comment preserving the original timestamp.
+// References and @mentions in comments on regular issues are recorded in
+// issue_references.
 //
 //nolint:gosec // G201: table names come from hardcoded constants
 func ImportIssueCommentInTx(ctx context.Context, tx *sql.Tx, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
@@ -143,6 +145,11 @@ func ImportIssueCommentInTx(ctx context.Context, tx *sql.Tx, issueID, author, te
 	`, commentTable), id, issueID, author, text, createdAt); err != nil {
 		return nil, fmt.Errorf("add comment to %s: %w", commentTable, err)
 	}
+	if !isWisp {
+		if _, err := SyncIssueReferencesInTx(ctx, tx, issueID, types.ReferenceSourceComment, id, text); err != nil {
+			return nil, err
+		}
+	}
 
 	return &types.Comment{
 		ID:        id,
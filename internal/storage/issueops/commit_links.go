@@ -0,0 +1,103 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddCommitLinkInTx records link unless the (issue, commit) pair already
+// exists, and reports whether a row was inserted.
+func AddCommitLinkInTx(ctx context.Context, tx *sql.Tx, link *types.CommitLink) (bool, error) {
+	if link.IssueID == "" || link.CommitSHA == "" {
+		return false, fmt.Errorf("commit link needs an issue ID and a commit SHA")
+	}
+	if link.Action == "" {
+		link.Action = "closes"
+	}
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now().UTC()
+	}
+	res, err := tx.ExecContext(ctx,
+		`INSERT IGNORE INTO commit_links (issue_id, commit_sha, action, summary, created_at) VALUES (?, ?, ?, ?, ?)`,
+		link.IssueID, link.CommitSHA, link.Action, link.Summary, link.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("add commit link %s -> %s: %w", link.CommitSHA, link.IssueID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("add commit link: %w", err)
+	}
+	return n > 0, nil
+}
+
+// HasCommitLinkInTx reports whether commitSHA is already linked to issueID.
+func HasCommitLinkInTx(ctx context.Context, tx *sql.Tx, issueID, commitSHA string) (bool, error) {
+	var one int
+	err := tx.QueryRowContext(ctx,
+		`SELECT 1 FROM commit_links WHERE issue_id = ? AND commit_sha = ?`, issueID, commitSHA).Scan(&one)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil && isTableNotExistError(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("check commit link: %w", err)
+	}
+	return true, nil
+}
+
+// GetCommitLinksInTx returns issueID's commit links, oldest first. Databases
+// created before commit_links existed have none.
+func GetCommitLinksInTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.CommitLink, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT issue_id, commit_sha, action, summary, created_at FROM commit_links
+		 WHERE issue_id = ? ORDER BY created_at, commit_sha`, issueID)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get commit links for %s: %w", issueID, err)
+	}
+	defer rows.Close()
+	return scanCommitLinks(rows)
+}
+
+func scanCommitLinks(rows *sql.Rows) ([]*types.CommitLink, error) {
+	var links []*types.CommitLink
+	for rows.Next() {
+		var link types.CommitLink
+		var summary sql.NullString
+		if err := rows.Scan(&link.IssueID, &link.CommitSHA, &link.Action, &summary, &link.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan commit link: %w", err)
+		}
+		link.Summary = summary.String
+		links = append(links, &link)
+	}
+	return links, rows.Err()
+}
+
+// GetCommitLinksForCommitInTx returns the links whose commit SHA starts
+// with sha, oldest first. sha must be hexadecimal.
+func GetCommitLinksForCommitInTx(ctx context.Context, tx *sql.Tx, sha string) ([]*types.CommitLink, error) {
+	sha = strings.ToLower(sha)
+	if sha == "" || strings.Trim(sha, "0123456789abcdef") != "" {
+		return nil, fmt.Errorf("invalid commit SHA %q", sha)
+	}
+	rows, err := tx.QueryContext(ctx,
+		`SELECT issue_id, commit_sha, action, summary, created_at FROM commit_links
+		 WHERE commit_sha LIKE ? ORDER BY created_at, issue_id`, sha+"%")
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get commit links for commit %s: %w", sha, err)
+	}
+	defer rows.Close()
+	return scanCommitLinks(rows)
+}
@@ -0,0 +1,69 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveCompactionSnapshotInTx stores issue as JSON before it is compacted to level.
+func SaveCompactionSnapshotInTx(ctx context.Context, tx *sql.Tx, issue *types.Issue, level int) error {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("encode snapshot of %s: %w", issue.ID, err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO compaction_snapshots (id, issue_id, compaction_level, snapshot_json, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		NewEventID(), issue.ID, level, data, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("save compaction snapshot of %s: %w", issue.ID, err)
+	}
+	return nil
+}
+
+// GetCompactionSnapshotsInTx returns an issue's snapshots, oldest first.
+func GetCompactionSnapshotsInTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.CompactionSnapshot, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, issue_id, compaction_level, snapshot_json, created_at
+		FROM compaction_snapshots WHERE issue_id = ?
+		ORDER BY created_at, compaction_level`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("get compaction snapshots of %s: %w", issueID, err)
+	}
+	defer rows.Close()
+
+	var snapshots []*types.CompactionSnapshot
+	for rows.Next() {
+		var snap types.CompactionSnapshot
+		var data []byte
+		if err := rows.Scan(&snap.ID, &snap.IssueID, &snap.Level, &data, &snap.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan compaction snapshot: %w", err)
+		}
+		if err := json.Unmarshal(data, &snap.Issue); err != nil {
+			return nil, fmt.Errorf("decode compaction snapshot %s: %w", snap.ID, err)
+		}
+		snapshots = append(snapshots, &snap)
+	}
+	return snapshots, rows.Err()
+}
+
+// ClearCompactionInTx resets an issue's compaction metadata.
+func ClearCompactionInTx(ctx context.Context, tx *sql.Tx, issueID string) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE issues SET compaction_level = 0, compacted_at = NULL, compacted_at_commit = NULL,
+			original_size = NULL, updated_at = ?
+		WHERE id = ?`, time.Now().UTC(), issueID)
+	if err != nil {
+		return fmt.Errorf("clear compaction of %s: %w", issueID, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("issue %s: %w", issueID, storage.ErrNotFound)
+	}
+	return nil
+}
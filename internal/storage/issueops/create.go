@@ -24,10 +24,11 @@ type BatchContext struct {
 
 // NewBatchContext reads config from the database and returns a BatchContext.
 func NewBatchContext(ctx context.Context, tx *sql.Tx, opts storage.BatchCreateOptions) (*BatchContext, error) {
-	customStatuses, err := GetCustomStatusesTx(ctx, tx)
+	detailedStatuses, err := ResolveCustomStatusesDetailedInTx(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get custom statuses: %w", err)
 	}
+	customStatuses := types.CustomStatusNames(detailedStatuses)
 	customTypes, err := ResolveCustomTypesInTx(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get custom types: %w", err)
@@ -36,8 +37,16 @@ func NewBatchContext(ctx context.Context, tx *sql.Tx, opts storage.BatchCreateOp
 	if err != nil {
 		return nil, err
 	}
-	var allowedPrefixes string
+	var allowedPrefixes, projects string
 	_ = tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", "allowed_prefixes").Scan(&allowedPrefixes)
+	// Monorepo sub-project prefixes are always allowed.
+	_ = tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", "projects").Scan(&projects)
+	if parsed, err := types.ParseProjectPrefixes(projects); err == nil && len(parsed) > 0 {
+		if allowedPrefixes != "" {
+			allowedPrefixes += ","
+		}
+		allowedPrefixes += types.ProjectPrefixList(parsed)
+	}
 
 	return &BatchContext{
 		CustomStatuses:  customStatuses,
@@ -149,6 +158,17 @@ func CreateIssueInTxWithResult(ctx context.Context, tx *sql.Tx, bc *BatchContext
 		return result, err
 	}
 	result.ChangedTables = mergeChangedTables(result.ChangedTables, commentResult.ChangedTables)
+
+	// Wisps are not indexed: issue_references only covers the issues table.
+	if issueTable == "issues" {
+		changed, err := SyncIssueReferencesInTx(ctx, tx, issue.ID, types.ReferenceSourceDescription, "", issue.Description)
+		if err != nil {
+			return result, err
+		}
+		if changed {
+			result.markChanged("issue_references")
+		}
+	}
 	return result, nil
 }
 
@@ -644,6 +664,15 @@ func PersistComments(ctx context.Context, tx *sql.Tx, issue *types.Issue) (Creat
 			return result, fmt.Errorf("failed to insert comment for %s: %w", issue.ID, err)
 		}
 		result.markChanged(commentTable)
+		if commentTable == "comments" {
+			changed, err := SyncIssueReferencesInTx(ctx, tx, issue.ID, types.ReferenceSourceComment, commentID, comment.Text)
+			if err != nil {
+				return result, err
+			}
+			if changed {
+				result.markChanged("issue_references")
+			}
+		}
 	}
 	return result, nil
 }
@@ -687,7 +716,7 @@ func PersistDependenciesWithOptionsResult(ctx context.Context, tx *sql.Tx, issue
 					fmt.Sprintf("SELECT 1 FROM %s WHERE id = ?", lookupTable),
 					dep.DependsOnID).Scan(&exists); err != nil {
 					if err == sql.ErrNoRows {
-						recordSkippedDependency(opts, dep, "target not found")
+						recordSkippedDependency(opts, dep, storage.SkipReasonTargetNotFound)
 						continue
 					}
 					return result, fmt.Errorf("failed to check dependency target %s for %s: %w", dep.DependsOnID, dep.IssueID, err)
@@ -4,13 +4,41 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// diffFields are the issue columns compared by DiffInTx, in display order.
+// Bookkeeping columns (updated_at, content_hash, ...) are left out so a
+// diff shows what a person or agent actually changed.
+var diffFields = []string{
+	"title",
+	"status",
+	"priority",
+	"issue_type",
+	"assignee",
+	"owner",
+	"description",
+	"design",
+	"acceptance_criteria",
+	"notes",
+	"close_reason",
+	"external_ref",
+	"spec_id",
+	"estimated_minutes",
+	"due_at",
+	"defer_until",
+}
+
 // DiffInTx returns changes between two commits or branches by querying
-// Dolt's dolt_diff() table function.
+// Dolt's dolt_diff() table function: one entry per issue that was added,
+// removed, or modified, with the fields that changed and the dependencies
+// added, removed, or retyped. An issue whose only change is a dependency
+// gets a "modified" entry with no values.
 //
 // nolint:gosec // G201: refs are validated by ValidateRef() - dolt_diff requires literal refs
 func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storage.DiffEntry, error) {
@@ -21,17 +49,19 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 		return nil, fmt.Errorf("invalid toRef: %w", err)
 	}
 
+	cols := make([]string, 0, 2*len(diffFields))
+	for _, f := range diffFields {
+		cols = append(cols, fmt.Sprintf("CAST(from_%[1]s AS CHAR), CAST(to_%[1]s AS CHAR)", f))
+	}
 	query := fmt.Sprintf(`
 		SELECT
 			COALESCE(from_id, '') as from_id,
 			COALESCE(to_id, '') as to_id,
 			diff_type,
-			from_title, to_title,
-			from_description, to_description,
-			from_status, to_status,
-			from_priority, to_priority
+			%s
 		FROM dolt_diff('%s', '%s', 'issues')
-	`, fromRef, toRef)
+		ORDER BY COALESCE(to_id, from_id)
+	`, strings.Join(cols, ",\n\t\t\t"), fromRef, toRef)
 
 	rows, err := tx.QueryContext(ctx, query)
 	if err != nil {
@@ -40,16 +70,15 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 	defer rows.Close()
 
 	var entries []*storage.DiffEntry
+	byID := make(map[string]*storage.DiffEntry)
 	for rows.Next() {
 		var fromID, toID, diffType string
-		var fromTitle, toTitle, fromDesc, toDesc, fromStatus, toStatus *string
-		var fromPriority, toPriority *int
-
-		if err := rows.Scan(&fromID, &toID, &diffType,
-			&fromTitle, &toTitle,
-			&fromDesc, &toDesc,
-			&fromStatus, &toStatus,
-			&fromPriority, &toPriority); err != nil {
+		values := make([]sql.NullString, 2*len(diffFields))
+		dest := []any{&fromID, &toID, &diffType}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan diff: %w", err)
 		}
 
@@ -65,44 +94,154 @@ func DiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]*storag
 
 		// Build old value for modified/removed
 		if diffType != "added" && fromID != "" {
-			entry.OldValue = &types.Issue{
-				ID: fromID,
-			}
-			if fromTitle != nil {
-				entry.OldValue.Title = *fromTitle
-			}
-			if fromDesc != nil {
-				entry.OldValue.Description = *fromDesc
-			}
-			if fromStatus != nil {
-				entry.OldValue.Status = types.Status(*fromStatus)
-			}
-			if fromPriority != nil {
-				entry.OldValue.Priority = *fromPriority
-			}
+			entry.OldValue = &types.Issue{ID: fromID}
 		}
-
 		// Build new value for modified/added
 		if diffType != "removed" && toID != "" {
-			entry.NewValue = &types.Issue{
-				ID: toID,
-			}
-			if toTitle != nil {
-				entry.NewValue.Title = *toTitle
-			}
-			if toDesc != nil {
-				entry.NewValue.Description = *toDesc
+			entry.NewValue = &types.Issue{ID: toID}
+		}
+		for i, field := range diffFields {
+			from, to := values[2*i], values[2*i+1]
+			if entry.OldValue != nil {
+				setDiffField(entry.OldValue, field, from.String)
 			}
-			if toStatus != nil {
-				entry.NewValue.Status = types.Status(*toStatus)
+			if entry.NewValue != nil {
+				setDiffField(entry.NewValue, field, to.String)
 			}
-			if toPriority != nil {
-				entry.NewValue.Priority = *toPriority
+			if diffType == "modified" && from.String != to.String {
+				entry.Changes = append(entry.Changes, storage.FieldChange{
+					Field: field,
+					Old:   from.String,
+					New:   to.String,
+				})
 			}
 		}
 
 		entries = append(entries, entry)
+		byID[entry.IssueID] = entry
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	depChanges, err := dependencyDiffInTx(ctx, tx, fromRef, toRef)
+	if err != nil {
+		return nil, err
+	}
+	var depOnly []*storage.DiffEntry
+	for _, dc := range depChanges {
+		entry, ok := byID[dc.issueID]
+		if !ok {
+			entry = &storage.DiffEntry{IssueID: dc.issueID, DiffType: "modified"}
+			byID[dc.issueID] = entry
+			depOnly = append(depOnly, entry)
+		}
+		// A removed issue takes its dependencies with it; listing them
+		// again is noise.
+		if entry.DiffType == "removed" {
+			continue
+		}
+		entry.DependencyChanges = append(entry.DependencyChanges, dc.change)
+	}
+	if len(depOnly) > 0 {
+		entries = append(entries, depOnly...)
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].IssueID < entries[j].IssueID })
+	}
+
+	return entries, nil
+}
+
+type issueDependencyChange struct {
+	issueID string
+	change  storage.DependencyChange
+}
+
+// dependencyDiffInTx returns the dependency edges that differ between two
+// validated refs, ordered by issue and target. A row whose target changed
+// is reported as the old edge removed and the new edge added.
+//
+// nolint:gosec // G201: refs are validated by the caller - dolt_diff requires literal refs
+func dependencyDiffInTx(ctx context.Context, tx *sql.Tx, fromRef, toRef string) ([]issueDependencyChange, error) {
+	query := fmt.Sprintf(`
+		SELECT from_issue_id, COALESCE(from_depends_on_issue_id, from_depends_on_wisp_id, from_depends_on_external), from_type,
+		       to_issue_id, COALESCE(to_depends_on_issue_id, to_depends_on_wisp_id, to_depends_on_external), to_type
+		FROM dolt_diff('%s', '%s', 'dependencies')
+	`, fromRef, toRef)
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency diff: %w", err)
 	}
+	defer rows.Close()
 
-	return entries, rows.Err()
+	var changes []issueDependencyChange
+	for rows.Next() {
+		var fromIssue, fromTarget, fromType, toIssue, toTarget, toType sql.NullString
+		if err := rows.Scan(&fromIssue, &fromTarget, &fromType, &toIssue, &toTarget, &toType); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency diff: %w", err)
+		}
+		sameEdge := fromIssue.Valid && toIssue.Valid &&
+			fromIssue.String == toIssue.String && fromTarget.String == toTarget.String
+		switch {
+		case sameEdge && fromType.String == toType.String:
+			// Only bookkeeping columns (metadata, thread_id, ...) changed.
+		case sameEdge:
+			changes = append(changes, issueDependencyChange{toIssue.String, storage.DependencyChange{
+				DependsOnID: toTarget.String, DiffType: "modified", OldType: fromType.String, NewType: toType.String,
+			}})
+		default:
+			if fromIssue.Valid {
+				changes = append(changes, issueDependencyChange{fromIssue.String, storage.DependencyChange{
+					DependsOnID: fromTarget.String, DiffType: "removed", OldType: fromType.String,
+				}})
+			}
+			if toIssue.Valid {
+				changes = append(changes, issueDependencyChange{toIssue.String, storage.DependencyChange{
+					DependsOnID: toTarget.String, DiffType: "added", NewType: toType.String,
+				}})
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].issueID != changes[j].issueID {
+			return changes[i].issueID < changes[j].issueID
+		}
+		return changes[i].change.DependsOnID < changes[j].change.DependsOnID
+	})
+	return changes, nil
+}
+
+// setDiffField stores one diffed column value on a partial issue. Columns
+// without a plain issue field (dates, estimates) are reported only through
+// DiffEntry.Changes.
+func setDiffField(issue *types.Issue, field, value string) {
+	switch field {
+	case "title":
+		issue.Title = value
+	case "status":
+		issue.Status = types.Status(value)
+	case "priority":
+		issue.Priority, _ = strconv.Atoi(value)
+	case "issue_type":
+		issue.IssueType = types.IssueType(value)
+	case "assignee":
+		issue.Assignee = value
+	case "owner":
+		issue.Owner = value
+	case "description":
+		issue.Description = value
+	case "design":
+		issue.Design = value
+	case "acceptance_criteria":
+		issue.AcceptanceCriteria = value
+	case "notes":
+		issue.Notes = value
+	case "close_reason":
+		issue.CloseReason = value
+	case "spec_id":
+		issue.SpecID = value
+	}
 }
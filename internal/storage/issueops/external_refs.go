@@ -0,0 +1,160 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ExternalURLBlockersInTx returns, for each open issue with a blocking
+// dependency on an external URL, the URLs that still block it. Beads cannot
+// see the status of a URL, so every URL blocks until it is marked resolved
+// in external_refs.
+func ExternalURLBlockersInTx(ctx context.Context, tx *sql.Tx) (map[string][]string, error) {
+	urlsByIssue := make(map[string][]string)
+	for _, src := range federatedDepSources {
+		//nolint:gosec // G201: table names are hardcoded.
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT d.issue_id, d.depends_on_external FROM %s d
+			JOIN %s s ON s.id = d.issue_id
+			WHERE (d.depends_on_external LIKE 'http://%%' OR d.depends_on_external LIKE 'https://%%')
+			  AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
+			  AND s.status <> 'closed' AND s.status <> 'pinned'
+		`, src.depTable, src.srcTable))
+		if err != nil {
+			if optionalBlockedTable(src.depTable) && isTableNotExistError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("external URL blockers from %s: %w", src.depTable, err)
+		}
+		for rows.Next() {
+			var issueID, ref string
+			if err := rows.Scan(&issueID, &ref); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("external URL blockers: scan: %w", err)
+			}
+			urlsByIssue[issueID] = append(urlsByIssue[issueID], ref)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("external URL blockers from %s: rows: %w", src.depTable, err)
+		}
+	}
+	if len(urlsByIssue) == 0 {
+		return nil, nil
+	}
+
+	var urls []string
+	for _, u := range urlsByIssue {
+		urls = append(urls, u...)
+	}
+	refs, err := GetExternalRefsInTx(ctx, tx, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for issueID, issueURLs := range urlsByIssue {
+		for _, u := range issueURLs {
+			if ref, ok := refs[u]; ok && ref.Resolved {
+				continue
+			}
+			result[issueID] = append(result[issueID], u)
+		}
+		sort.Strings(result[issueID])
+	}
+	return result, nil
+}
+
+// OutsideBlockersInTx merges FederatedBlockersInTx and
+// ExternalURLBlockersInTx: blocking dependencies whose target lives outside
+// this database and so is not part of the materialized is_blocked flag.
+func OutsideBlockersInTx(ctx context.Context, tx *sql.Tx) (map[string][]string, error) {
+	federated, err := FederatedBlockersInTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	urls, err := ExternalURLBlockersInTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return federated, nil
+	}
+	if federated == nil {
+		federated = make(map[string][]string, len(urls))
+	}
+	for id, refs := range urls {
+		federated[id] = append(federated[id], refs...)
+	}
+	return federated, nil
+}
+
+// GetExternalRefsInTx returns the tracked state of each URL that has a row
+// in external_refs. URLs without a row are absent (unresolved). Databases
+// created before external_refs existed have none.
+func GetExternalRefsInTx(ctx context.Context, tx *sql.Tx, urls []string) (map[string]*types.ExternalRef, error) {
+	result := make(map[string]*types.ExternalRef)
+	for start := 0; start < len(urls); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(urls))
+		placeholders, args := buildSQLInClause(urls[start:end])
+		//nolint:gosec // G201: only placeholders are interpolated
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT url, resolved, resolved_by, resolved_at FROM external_refs WHERE url IN (%s)`, placeholders), args...)
+		if err != nil {
+			if isTableNotExistError(err) {
+				return result, nil
+			}
+			return nil, fmt.Errorf("get external refs: %w", err)
+		}
+		for rows.Next() {
+			var ref types.ExternalRef
+			var resolvedBy sql.NullString
+			var resolvedAt sql.NullTime
+			if err := rows.Scan(&ref.URL, &ref.Resolved, &resolvedBy, &resolvedAt); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan external ref: %w", err)
+			}
+			ref.ResolvedBy = resolvedBy.String
+			if resolvedAt.Valid {
+				t := resolvedAt.Time
+				ref.ResolvedAt = &t
+			}
+			result[ref.URL] = &ref
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("get external refs: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// SetExternalRefResolvedInTx marks url resolved or unresolved. Marking a URL
+// resolved unblocks every issue that depends on it.
+func SetExternalRefResolvedInTx(ctx context.Context, tx *sql.Tx, url string, resolved bool, actor string) error {
+	if err := types.ValidateExternalURLRef(url); err != nil {
+		return err
+	}
+	var resolvedBy string
+	var resolvedAt *time.Time
+	if resolved {
+		now := time.Now().UTC()
+		resolvedBy, resolvedAt = actor, &now
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO external_refs (url, resolved, resolved_by, resolved_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE resolved = VALUES(resolved), resolved_by = VALUES(resolved_by),
+			resolved_at = VALUES(resolved_at)`,
+		url, resolved, resolvedBy, resolvedAt)
+	if err != nil {
+		return fmt.Errorf("set external ref %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// federatedBranchRegexp guards the branch name interpolated into AS OF.
+var federatedBranchRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_./-]*$`)
+
+// federatedDepSources pairs each dependency table with the table holding its
+// source issues, so closed sources can be skipped.
+var federatedDepSources = []struct{ depTable, srcTable string }{
+	{"dependencies", "issues"},
+	{"wisp_dependencies", "wisps"},
+}
+
+// FederatedBlockersInTx returns, for each open issue with a blocking
+// dependency on an issue in a federation peer ("<peer>/<issue-id>"), the refs
+// that still block it. A ref blocks unless the peer's last-fetched branch
+// shows the issue closed or pinned; refs that cannot be resolved (peer never
+// fetched, issue missing) block too, so a stale view never unblocks work.
+func FederatedBlockersInTx(ctx context.Context, tx *sql.Tx) (map[string][]string, error) {
+	refsByIssue := make(map[string][]string)
+	for _, src := range federatedDepSources {
+		//nolint:gosec // G201: table names are hardcoded.
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT d.issue_id, d.depends_on_external FROM %s d
+			JOIN %s s ON s.id = d.issue_id
+			WHERE d.depends_on_external IS NOT NULL
+			  AND (d.type = 'blocks' OR d.type = 'conditional-blocks')
+			  AND s.status <> 'closed' AND s.status <> 'pinned'
+		`, src.depTable, src.srcTable))
+		if err != nil {
+			if optionalBlockedTable(src.depTable) && isTableNotExistError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("federated blockers from %s: %w", src.depTable, err)
+		}
+		for rows.Next() {
+			var issueID, ref string
+			if err := rows.Scan(&issueID, &ref); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("federated blockers: scan: %w", err)
+			}
+			if types.IsFederatedRef(ref) {
+				refsByIssue[issueID] = append(refsByIssue[issueID], ref)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("federated blockers from %s: rows: %w", src.depTable, err)
+		}
+	}
+	if len(refsByIssue) == 0 {
+		return nil, nil
+	}
+
+	var refs []string
+	for _, r := range refsByIssue {
+		refs = append(refs, r...)
+	}
+	statuses, err := ResolveFederatedStatusesInTx(ctx, tx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for issueID, issueRefs := range refsByIssue {
+		for _, ref := range issueRefs {
+			if status, ok := statuses[ref]; ok && (status == types.StatusClosed || status == types.StatusPinned) {
+				continue
+			}
+			result[issueID] = append(result[issueID], ref)
+		}
+		sort.Strings(result[issueID])
+	}
+	return result, nil
+}
+
+// ResolveFederatedStatusesInTx looks up each "<peer>/<issue-id>" ref in the
+// peer's remote-tracking branch as of the last fetch. Refs whose peer has not
+// been fetched or whose issue does not exist there are absent from the result.
+func ResolveFederatedStatusesInTx(ctx context.Context, tx *sql.Tx, refs []string) (map[string]types.Status, error) {
+	idsByPeer := make(map[string][]string)
+	for _, ref := range refs {
+		if peer, id, ok := types.ParseFederatedRef(ref); ok {
+			idsByPeer[peer] = append(idsByPeer[peer], id)
+		}
+	}
+	result := make(map[string]types.Status)
+	if len(idsByPeer) == 0 {
+		return result, nil
+	}
+
+	var branch string
+	if err := tx.QueryRowContext(ctx, "SELECT active_branch()").Scan(&branch); err != nil {
+		return nil, fmt.Errorf("resolve federated deps: active branch: %w", err)
+	}
+	if !federatedBranchRegexp.MatchString(branch) {
+		return nil, fmt.Errorf("resolve federated deps: unsupported branch name %q", branch)
+	}
+
+	for peer, ids := range idsByPeer {
+		for start := 0; start < len(ids); start += queryBatchSize {
+			end := min(start+queryBatchSize, len(ids))
+			placeholders, args := buildSQLInClause(ids[start:end])
+			// Peer and branch names are validated above; AS OF takes no placeholder.
+			//nolint:gosec // G201: peer matches types.ParseFederatedRef, branch matches federatedBranchRegexp.
+			rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+				"SELECT id, status FROM issues AS OF '%s/%s' WHERE id IN (%s)", peer, branch, placeholders), args...)
+			if err != nil {
+				// The peer has not been fetched (no remote-tracking branch) or
+				// its schema predates issues; leave its refs unresolved.
+				break
+			}
+			for rows.Next() {
+				var id, status string
+				if err := rows.Scan(&id, &status); err != nil {
+					_ = rows.Close()
+					return nil, fmt.Errorf("resolve federated deps: scan: %w", err)
+				}
+				result[peer+"/"+id] = types.Status(status)
+			}
+			_ = rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, fmt.Errorf("resolve federated deps from %s: rows: %w", peer, err)
+			}
+		}
+	}
+	return result, nil
+}
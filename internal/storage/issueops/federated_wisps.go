@@ -0,0 +1,89 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// MatchesTablePattern reports whether table matches any of the glob patterns
+// (e.g. "comments", "wisp_*").
+func MatchesTablePattern(table string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, table); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TrackedTablesAsOf lists the tables committed at ref. Tables that only exist
+// in the working set, such as dolt_ignore'd ones, are not included.
+func TrackedTablesAsOf(ctx context.Context, db SQLQuerier, ref string) ([]string, error) {
+	if err := ValidateRef(ref); err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	//nolint:gosec // G201: ref is validated by ValidateRef above - AS OF requires a literal
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SHOW TABLES AS OF '%s'", ref))
+	if err != nil {
+		return nil, fmt.Errorf("list tables as of %s: %w", ref, err)
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("list tables as of %s: scan: %w", ref, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+// PeerWispCountsInTx counts wisp data in a peer's remote-tracking branch as of
+// the last fetch: ephemeral rows in issues and rows in any tracked wisp table.
+// Keys are table names; tables without wisp rows are omitted. Returns nil
+// when the peer has not been fetched.
+func PeerWispCountsInTx(ctx context.Context, tx *sql.Tx, peer string) (map[string]int, error) {
+	if err := ValidatePeerName(peer); err != nil {
+		return nil, fmt.Errorf("peer wisps: %w", err)
+	}
+	var branch string
+	if err := tx.QueryRowContext(ctx, "SELECT active_branch()").Scan(&branch); err != nil {
+		return nil, fmt.Errorf("peer wisps: active branch: %w", err)
+	}
+	if !federatedBranchRegexp.MatchString(branch) {
+		return nil, fmt.Errorf("peer wisps: unsupported branch name %q", branch)
+	}
+	ref := peer + "/" + branch
+
+	tables, err := TrackedTablesAsOf(ctx, tx, ref)
+	if err != nil {
+		// No remote-tracking branch: the peer has not been fetched.
+		return nil, nil
+	}
+	counts := make(map[string]int)
+	for _, table := range tables {
+		var query string
+		switch {
+		case table == "issues":
+			query = fmt.Sprintf("SELECT COUNT(*) FROM issues AS OF '%s' WHERE ephemeral = 1", ref)
+		case MatchesTablePattern(table, config.WispTablePatterns):
+			query = fmt.Sprintf("SELECT COUNT(*) FROM `%s` AS OF '%s'", table, ref)
+		default:
+			continue
+		}
+		var n int
+		//nolint:gosec // G201: ref is validated above and table comes from SHOW TABLES.
+		if err := tx.QueryRowContext(ctx, query).Scan(&n); err != nil {
+			return nil, fmt.Errorf("peer wisps: count %s: %w", table, err)
+		}
+		if n > 0 {
+			counts[table] = n
+		}
+	}
+	return counts, nil
+}
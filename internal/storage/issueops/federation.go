@@ -33,17 +33,21 @@ func AddFederationPeerInTx(ctx context.Context, tx *sql.Tx, peer *storage.Federa
 	if err := ValidatePeerName(peer.Name); err != nil {
 		return fmt.Errorf("invalid peer name: %w", err)
 	}
+	if err := storage.ValidatePeerDirection(peer.Direction); err != nil {
+		return err
+	}
 
 	_, err := tx.ExecContext(ctx, `
-		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO federation_peers (name, remote_url, username, password_encrypted, sovereignty, direction)
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 			remote_url = VALUES(remote_url),
 			username = VALUES(username),
 			password_encrypted = VALUES(password_encrypted),
 			sovereignty = VALUES(sovereignty),
+			direction = VALUES(direction),
 			updated_at = CURRENT_TIMESTAMP
-	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty)
+	`, peer.Name, peer.RemoteURL, peer.Username, encryptedPwd, peer.Sovereignty, peer.Direction)
 
 	if err != nil {
 		return fmt.Errorf("add federation peer: %w", err)
@@ -51,6 +55,20 @@ func AddFederationPeerInTx(ctx context.Context, tx *sql.Tx, peer *storage.Federa
 	return nil
 }
 
+// PeerDirection returns the configured direction of a peer, or "" when the
+// peer has no federation_peers row (a plain remote, which syncs both ways).
+func PeerDirection(ctx context.Context, db SQLQuerier, name string) (string, error) {
+	var direction string
+	err := db.QueryRowContext(ctx, "SELECT direction FROM federation_peers WHERE name = ?", name).Scan(&direction)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get peer direction: %w", err)
+	}
+	return direction, nil
+}
+
 // FederationPeerRow holds raw database fields for a federation peer.
 // The caller is responsible for decrypting EncryptedPwd.
 type FederationPeerRow struct {
@@ -66,11 +84,11 @@ func GetFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) (*Feder
 	var username sql.NullString
 
 	err := tx.QueryRowContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, sovereignty, direction, last_sync, created_at, updated_at
 		FROM federation_peers WHERE name = ?
 	`, name).Scan(
 		&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd,
-		&row.Peer.Sovereignty, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
+		&row.Peer.Sovereignty, &row.Peer.Direction, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -93,7 +111,7 @@ func GetFederationPeerInTx(ctx context.Context, tx *sql.Tx, name string) (*Feder
 // ListFederationPeersInTx returns all configured federation peer rows.
 func ListFederationPeersInTx(ctx context.Context, tx *sql.Tx) ([]*FederationPeerRow, error) {
 	rows, err := tx.QueryContext(ctx, `
-		SELECT name, remote_url, username, password_encrypted, sovereignty, last_sync, created_at, updated_at
+		SELECT name, remote_url, username, password_encrypted, sovereignty, direction, last_sync, created_at, updated_at
 		FROM federation_peers ORDER BY name
 	`)
 	if err != nil {
@@ -109,7 +127,7 @@ func ListFederationPeersInTx(ctx context.Context, tx *sql.Tx) ([]*FederationPeer
 
 		if err := rows.Scan(
 			&row.Peer.Name, &row.Peer.RemoteURL, &username, &row.EncryptedPwd,
-			&row.Peer.Sovereignty, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
+			&row.Peer.Sovereignty, &row.Peer.Direction, &lastSync, &row.Peer.CreatedAt, &row.Peer.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan federation peer: %w", err)
 		}
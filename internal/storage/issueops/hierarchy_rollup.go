@@ -0,0 +1,72 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetHierarchyRollupInTx returns recursive roll-ups for rootID and every
+// issue beneath it: counts and estimates over all descendants, not just
+// direct children. One recursive CTE walks the subtree, builds its
+// ancestor/descendant closure, and aggregates per ancestor. Issues without
+// children are absent from the result.
+func GetHierarchyRollupInTx(ctx context.Context, tx *sql.Tx, rootID string) (map[string]*types.HierarchyRollup, error) {
+	edges := fmt.Sprintf("SELECT issue_id, %s AS parent_id FROM dependencies d WHERE d.type = 'parent-child'", depTargetExpr("d"))
+	nodes := "SELECT id, status, estimated_minutes FROM issues"
+	if empty, err := wispsTableEmptyOrMissingInTx(ctx, tx); err != nil {
+		return nil, fmt.Errorf("get hierarchy rollup: probe: %w", err)
+	} else if !empty {
+		edges += fmt.Sprintf(" UNION ALL SELECT issue_id, %s AS parent_id FROM wisp_dependencies d WHERE d.type = 'parent-child'", depTargetExpr("d"))
+		nodes += " UNION ALL SELECT id, status, estimated_minutes FROM wisps"
+	}
+
+	// UNION (not UNION ALL) in the recursive members deduplicates rows, so
+	// a parent-child cycle terminates instead of recursing forever.
+	//nolint:gosec // G201: edges and nodes are built from hardcoded table names.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE
+		edges AS (%s),
+		subtree(id) AS (
+			SELECT ?
+			UNION
+			SELECT e.issue_id FROM edges e JOIN subtree s ON e.parent_id = s.id
+		),
+		closure(ancestor_id, id) AS (
+			SELECT id, id FROM subtree
+			UNION
+			SELECT c.ancestor_id, e.issue_id FROM closure c JOIN edges e ON e.parent_id = c.id
+		)
+		SELECT c.ancestor_id, COUNT(*),
+		       SUM(CASE WHEN n.status = 'closed' THEN 1 ELSE 0 END),
+		       COALESCE(SUM(n.estimated_minutes), 0),
+		       COALESCE(SUM(CASE WHEN n.status <> 'closed' THEN n.estimated_minutes ELSE 0 END), 0)
+		FROM closure c
+		JOIN (%s) n ON n.id = c.id
+		WHERE c.id <> c.ancestor_id
+		GROUP BY c.ancestor_id
+	`, edges, nodes), rootID)
+	if err != nil {
+		return nil, fmt.Errorf("get hierarchy rollup: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*types.HierarchyRollup)
+	for rows.Next() {
+		var id string
+		r := &types.HierarchyRollup{}
+		if err := rows.Scan(&id, &r.Total, &r.Closed, &r.EstimatedMinutes, &r.RemainingMinutes); err != nil {
+			return nil, fmt.Errorf("get hierarchy rollup: scan: %w", err)
+		}
+		if r.Total > 0 {
+			r.Percent = r.Closed * 100 / r.Total
+		}
+		result[id] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get hierarchy rollup: rows: %w", err)
+	}
+	return result, nil
+}
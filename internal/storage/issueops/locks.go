@@ -0,0 +1,165 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const lockColumns = `issue_id, holder, reason, created_at, expires_at`
+
+// scanIssueLock scans one locks row.
+func scanIssueLock(scan func(dest ...any) error) (*types.IssueLock, error) {
+	var lock types.IssueLock
+	var reason sql.NullString
+	var expiresAt sql.NullTime
+	if err := scan(&lock.IssueID, &lock.Holder, &reason, &lock.CreatedAt, &expiresAt); err != nil {
+		return nil, err
+	}
+	lock.Reason = reason.String
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		lock.ExpiresAt = &t
+	}
+	return &lock, nil
+}
+
+// GetIssueLockInTx returns the live lock on issueID, or nil when the issue is
+// unlocked or its lock has expired.
+func GetIssueLockInTx(ctx context.Context, tx *sql.Tx, issueID string) (*types.IssueLock, error) {
+	lock, err := scanIssueLock(tx.QueryRowContext(ctx,
+		`SELECT `+lockColumns+` FROM locks WHERE issue_id = ?`, issueID).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get lock for %s: %w", issueID, err)
+	}
+	if lock.Expired(time.Now()) {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// CheckIssueLockInTx returns storage.ErrIssueLocked when issueID holds a live
+// lock taken by someone other than actor, unless ctx carries
+// storage.WithLockOverride. Databases without a locks table never block.
+func CheckIssueLockInTx(ctx context.Context, tx *sql.Tx, issueID, actor string) error {
+	if storage.LockOverridden(ctx) {
+		return nil
+	}
+	lock, err := GetIssueLockInTx(ctx, tx, issueID)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil
+		}
+		return err
+	}
+	if lock == nil || lock.Holder == actor {
+		return nil
+	}
+	return fmt.Errorf("%w by %s", storage.ErrIssueLocked, DescribeIssueLock(lock))
+}
+
+// ListIssueLocksInTx returns all live locks ordered by issue ID.
+func ListIssueLocksInTx(ctx context.Context, tx *sql.Tx) ([]*types.IssueLock, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT `+lockColumns+` FROM locks ORDER BY issue_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list locks: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var locks []*types.IssueLock
+	for rows.Next() {
+		lock, err := scanIssueLock(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scan lock: %w", err)
+		}
+		if !lock.Expired(now) {
+			locks = append(locks, lock)
+		}
+	}
+	return locks, rows.Err()
+}
+
+// LockIssueInTx takes or refreshes the lock on lock.IssueID for lock.Holder.
+// A live lock held by another actor returns storage.ErrIssueLocked unless
+// force is set. lock.CreatedAt is set to the time the lock was taken.
+func LockIssueInTx(ctx context.Context, tx *sql.Tx, lock *types.IssueLock, force bool) error {
+	if lock.Holder == "" {
+		return fmt.Errorf("lock holder is required")
+	}
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, lock.IssueID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: issue %s", storage.ErrNotFound, lock.IssueID)
+	}
+	if err != nil {
+		return fmt.Errorf("lock %s: %w", lock.IssueID, err)
+	}
+
+	current, err := GetIssueLockInTx(ctx, tx, lock.IssueID)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.Holder != lock.Holder && !force {
+		return fmt.Errorf("%w by %s", storage.ErrIssueLocked, DescribeIssueLock(current))
+	}
+
+	lock.CreatedAt = time.Now().UTC()
+	var expiresAt any
+	if lock.ExpiresAt != nil {
+		expiresAt = lock.ExpiresAt.UTC()
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO locks (issue_id, holder, reason, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			holder = VALUES(holder),
+			reason = VALUES(reason),
+			created_at = VALUES(created_at),
+			expires_at = VALUES(expires_at)
+	`, lock.IssueID, lock.Holder, lock.Reason, lock.CreatedAt, expiresAt); err != nil {
+		return fmt.Errorf("lock %s: %w", lock.IssueID, err)
+	}
+	return nil
+}
+
+// UnlockIssueInTx releases the lock on issueID. A live lock held by someone
+// other than actor returns storage.ErrIssueLocked unless force is set. An
+// unlocked (or expired) issue returns storage.ErrNotFound.
+func UnlockIssueInTx(ctx context.Context, tx *sql.Tx, issueID, actor string, force bool) error {
+	current, err := GetIssueLockInTx(ctx, tx, issueID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("%w: no lock on %s", storage.ErrNotFound, issueID)
+	}
+	if current.Holder != actor && !force {
+		return fmt.Errorf("%w by %s", storage.ErrIssueLocked, DescribeIssueLock(current))
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM locks WHERE issue_id = ?`, issueID); err != nil {
+		return fmt.Errorf("unlock %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// DescribeIssueLock renders a lock's holder, expiry, and reason for messages:
+// "alice until 2025-01-15 10:00 (reviewing agent output)".
+func DescribeIssueLock(lock *types.IssueLock) string {
+	s := lock.Holder
+	if lock.ExpiresAt != nil {
+		s += " until " + lock.ExpiresAt.Local().Format("2006-01-02 15:04")
+	}
+	if lock.Reason != "" {
+		s += " (" + lock.Reason + ")"
+	}
+	return s
+}
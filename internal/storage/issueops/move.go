@@ -0,0 +1,277 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MoveIssuesInTx moves each issue in ids under newParentID, replacing its
+// parent-child dependencies and recording a 'moved' event (old parent ->
+// new parent). The whole batch is validated before anything is written:
+// the new parent must exist, must not lie inside a moved subtree, and must
+// not be blocked, directly or through its own ancestors, by a moved issue
+// or one of its descendants. Children inherit their parent's blocked state,
+// so such a move could never be completed. Issues already under
+// newParentID alone are left out of the result.
+//
+// With opts.Renumber, a persistent issue whose ID is a hierarchical child of
+// its old parent takes the new parent's next child ID, and descendants that
+// share its ID prefix follow it (bd-a.2.1 -> bd-b.4.1).
+func MoveIssuesInTx(ctx context.Context, tx *sql.Tx, ids []string, newParentID string, opts storage.MoveIssuesOptions, actor string) (*types.MoveResult, error) {
+	ids = dedupePreservingOrder(ids)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no issues to move")
+	}
+	for _, id := range append([]string{newParentID}, ids...) {
+		if err := checkMoveIssueExistsInTx(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	parents, err := loadParentEdgesInTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	blocking := make(map[string][]string)
+	if err := AppendBlockingGraphInTx(ctx, tx, []string{"dependencies", "wisp_dependencies"}, blocking); err != nil {
+		return nil, err
+	}
+	if err := checkMove(ids, newParentID, parents, blocking); err != nil {
+		return nil, err
+	}
+
+	result := &types.MoveResult{Parent: newParentID, Moves: []types.IssueMove{}}
+	parentIsWisp := IsActiveWispInTx(ctx, tx, newParentID)
+	for _, id := range ids {
+		oldParents := parents[id]
+		if len(oldParents) == 1 && oldParents[0] == newParentID {
+			continue
+		}
+		move := types.IssueMove{ID: id, OldParent: strings.Join(oldParents, ",")}
+		isWisp := IsActiveWispInTx(ctx, tx, id)
+
+		for _, oldParent := range oldParents {
+			if err := RemoveDependencyInTx(ctx, tx, id, oldParent); err != nil {
+				return nil, fmt.Errorf("detach %s from %s: %w", id, oldParent, err)
+			}
+		}
+		if err := AddDependencyInTx(ctx, tx, &types.Dependency{
+			IssueID:     id,
+			DependsOnID: newParentID,
+			Type:        types.DepParentChild,
+		}, actor, AddDependencyOpts{}); err != nil {
+			return nil, fmt.Errorf("attach %s to %s: %w", id, newParentID, err)
+		}
+		_, _, eventTable, _ := WispTableRouting(isWisp)
+		//nolint:gosec // G201: eventTable is hardcoded to "events" or "wisp_events"
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO %s (id, issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, eventTable), NewEventID(), id, types.EventMoved, actor, move.OldParent, newParentID); err != nil {
+			return nil, fmt.Errorf("record move of %s: %w", id, err)
+		}
+
+		if opts.Renumber && !isWisp && !parentIsWisp && len(oldParents) == 1 {
+			if idParent, _, ok := ParseHierarchicalID(id); ok && idParent == oldParents[0] {
+				renames, err := renumberMovedIssueInTx(ctx, tx, id, newParentID, moveSubtree(id, parents), opts.MaxDepth, actor)
+				if err != nil {
+					return nil, err
+				}
+				move.NewID = renames[id]
+				if result.Renames == nil {
+					result.Renames = make(map[string]string)
+				}
+				for oldID, newID := range renames {
+					result.Renames[oldID] = newID
+				}
+			}
+		}
+		result.Moves = append(result.Moves, move)
+	}
+	return result, nil
+}
+
+// checkMove validates a batch move against parents (child -> parents, from
+// parent-child dependencies) and blocking (issue -> blockers).
+func checkMove(ids []string, newParentID string, parents, blocking map[string][]string) error {
+	moving := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		moving[id] = true
+	}
+	ancestors := reachable(parents, newParentID)
+	for _, id := range ids {
+		if id == newParentID {
+			return fmt.Errorf("cannot move %s under itself", id)
+		}
+		if ancestors[id] {
+			return fmt.Errorf("cannot move %s under its own descendant %s", id, newParentID)
+		}
+		for ancestor := range reachable(parents, id) {
+			if ancestor != id && moving[ancestor] {
+				return fmt.Errorf("%s is inside %s, which is also being moved; move only %s", id, ancestor, ancestor)
+			}
+		}
+	}
+
+	// An issue waits on its blockers and, through is_blocked inheritance, on
+	// its parent. The move deadlocks when the new parent already waits on a
+	// moved issue, i.e. the new edge closes a cycle.
+	waits := make(map[string][]string, len(blocking)+len(parents))
+	for id, blockers := range blocking {
+		waits[id] = append(waits[id], blockers...)
+	}
+	edges := make([][2]string, 0, len(ids))
+	for child, ps := range parents {
+		if !moving[child] {
+			waits[child] = append(waits[child], ps...)
+		}
+	}
+	for _, id := range ids {
+		waits[id] = append(waits[id], newParentID)
+		edges = append(edges, [2]string{id, newParentID})
+	}
+	if cycle := CycleThroughEdgesInGraph(waits, edges); cycle != "" {
+		return fmt.Errorf("moving under %s would deadlock, a parent blocked by its own descendant: %s", newParentID, cycle)
+	}
+	return nil
+}
+
+// reachable returns the nodes reachable from start in graph, including start.
+func reachable(graph map[string][]string, start string) map[string]bool {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[node] {
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return seen
+}
+
+// moveSubtree returns id and its parent-child descendants.
+func moveSubtree(id string, parents map[string][]string) map[string]bool {
+	children := make(map[string][]string)
+	for child, ps := range parents {
+		for _, p := range ps {
+			children[p] = append(children[p], child)
+		}
+	}
+	return reachable(children, id)
+}
+
+// renumberMovedIssueInTx renames id to newParentID's next child ID, along
+// with the members of subtree whose ID extends id's. It returns the renames.
+func renumberMovedIssueInTx(ctx context.Context, tx *sql.Tx, id, newParentID string, subtree map[string]bool, maxDepth int, actor string) (map[string]string, error) {
+	if maxDepth < 1 {
+		maxDepth = types.MaxHierarchyDepth
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM issues WHERE id LIKE CONCAT(?, '.%')`, id)
+	if err != nil {
+		return nil, fmt.Errorf("list descendants of %s: %w", id, err)
+	}
+	oldIDs := []string{id}
+	for rows.Next() {
+		var descendant string
+		if err := rows.Scan(&descendant); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan descendant of %s: %w", id, err)
+		}
+		if subtree[descendant] {
+			oldIDs = append(oldIDs, descendant)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list descendants of %s: %w", id, err)
+	}
+	sort.Strings(oldIDs[1:])
+
+	newID, err := GetNextChildIDTx(ctx, tx, newParentID)
+	if err != nil {
+		return nil, err
+	}
+	renames := make(map[string]string, len(oldIDs))
+	for _, oldID := range oldIDs {
+		target := newID + strings.TrimPrefix(oldID, id)
+		if strings.Count(target, ".") > maxDepth {
+			return nil, fmt.Errorf("maximum hierarchy depth (%d) exceeded: %s would become %s", maxDepth, oldID, target)
+		}
+		if err := checkRenameTargetFreeInTx(ctx, tx, target); err != nil {
+			return nil, err
+		}
+		renames[oldID] = target
+	}
+	for _, oldID := range oldIDs {
+		if err := moveIssueIDInTx(ctx, tx, oldID, renames[oldID]); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
+			VALUES (?, ?, 'renamed', ?, ?, ?)
+		`, NewEventID(), renames[oldID], actor, oldID, renames[oldID]); err != nil {
+			return nil, fmt.Errorf("record rename of %s: %w", oldID, err)
+		}
+	}
+	return renames, nil
+}
+
+// loadParentEdgesInTx maps each issue to its parents from the parent-child
+// dependencies of both dependency tables.
+//
+//nolint:gosec // G201: table names are hardcoded
+func loadParentEdgesInTx(ctx context.Context, tx *sql.Tx) (map[string][]string, error) {
+	parents := make(map[string][]string)
+	for _, depTable := range []string{"dependencies", "wisp_dependencies"} {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT issue_id, %s FROM %s WHERE type = ?
+		`, DepTargetExpr, depTable), types.DepParentChild)
+		if err != nil {
+			if isTableNotExistError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("read parent links from %s: %w", depTable, err)
+		}
+		for rows.Next() {
+			var child, parent string
+			if err := rows.Scan(&child, &parent); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scan parent link: %w", err)
+			}
+			parents[child] = append(parents[child], parent)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("read parent links from %s: %w", depTable, err)
+		}
+	}
+	for _, ps := range parents {
+		sort.Strings(ps)
+	}
+	return parents, nil
+}
+
+func checkMoveIssueExistsInTx(ctx context.Context, tx *sql.Tx, id string) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, id).Scan(&exists)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("check %s: %w", id, err)
+	case IsActiveWispInTx(ctx, tx, id):
+		return nil
+	}
+	return fmt.Errorf("issue not found: %s", id)
+}
@@ -0,0 +1,73 @@
+package issueops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckMove(t *testing.T) {
+	t.Parallel()
+
+	// epic-a
+	// ├── a.1
+	// │   └── a.1.1
+	// └── a.2
+	// epic-b
+	parents := map[string][]string{
+		"a.1":   {"epic-a"},
+		"a.1.1": {"a.1"},
+		"a.2":   {"epic-a"},
+	}
+
+	tests := []struct {
+		name     string
+		ids      []string
+		parent   string
+		blocking map[string][]string
+		wantErr  string
+	}{
+		{name: "sibling_subtrees", ids: []string{"a.1", "a.2"}, parent: "epic-b"},
+		{name: "under_itself", ids: []string{"a.1"}, parent: "a.1", wantErr: "under itself"},
+		{name: "under_descendant", ids: []string{"epic-a"}, parent: "a.1.1", wantErr: "own descendant"},
+		{name: "nested_ids", ids: []string{"a.1", "a.1.1"}, parent: "epic-b", wantErr: "also being moved"},
+		{
+			name:     "parent_blocked_by_moved_issue",
+			ids:      []string{"a.2"},
+			parent:   "epic-b",
+			blocking: map[string][]string{"epic-b": {"a.2"}},
+			wantErr:  "deadlock",
+		},
+		{
+			name:     "parent_blocked_by_moved_descendant",
+			ids:      []string{"a.1"},
+			parent:   "epic-b",
+			blocking: map[string][]string{"epic-b": {"a.1.1"}},
+			wantErr:  "a.1 → epic-b → a.1.1 → a.1",
+		},
+		{
+			name:     "ancestor_of_parent_blocked",
+			ids:      []string{"epic-b"},
+			parent:   "a.1.1",
+			blocking: map[string][]string{"epic-a": {"epic-b"}},
+			wantErr:  "deadlock",
+		},
+		{
+			name:     "blocker_left_behind",
+			ids:      []string{"a.1"},
+			parent:   "epic-b",
+			blocking: map[string][]string{"epic-b": {"a.2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkMove(tt.ids, tt.parent, parents, tt.blocking)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
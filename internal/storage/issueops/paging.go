@@ -0,0 +1,61 @@
+package issueops
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Offset paging.
+//
+// A page can be cut in SQL only when a single table answers the query. When
+// the issues and wisps tables are merged, each table is instead asked for its
+// first Offset+Limit rows (still a SQL LIMIT, so memory stays bounded by the
+// page position rather than the table size), the merged rows are sorted, and
+// the page is cut from that window.
+
+// offsetWindowLimit returns the per-table limit that covers the rows an offset
+// skips plus the page itself. Zero (unlimited) stays zero.
+func offsetWindowLimit(limit, offset int) int {
+	if limit <= 0 {
+		return 0
+	}
+	return limit + offset
+}
+
+// cutPage drops the first offset items and caps the remainder at limit
+// (0 = no cap).
+func cutPage[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// sqlLimitOffset renders a LIMIT/OFFSET clause, or "" when neither is set.
+// MySQL has no OFFSET without LIMIT, so an unlimited offset uses the maximum
+// row count.
+func sqlLimitOffset(limit, offset int) string {
+	switch {
+	case limit > 0 && offset > 0:
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	case limit > 0:
+		return fmt.Sprintf("LIMIT %d", limit)
+	case offset > 0:
+		return fmt.Sprintf("LIMIT 18446744073709551615 OFFSET %d", offset)
+	}
+	return ""
+}
+
+// sortSearchIssues orders merged issues+wisps rows the way issueOpsOrderBy
+// orders each per-table query.
+func sortSearchIssues(issues []*types.Issue, sortBy string, sortDesc bool) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issueOpsLess(issues[i], issues[j], sortBy, sortDesc)
+	})
+}
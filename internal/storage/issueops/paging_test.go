@@ -0,0 +1,98 @@
+package issueops
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCutPage(t *testing.T) {
+	t.Parallel()
+
+	items := []int{0, 1, 2, 3, 4}
+	tests := []struct {
+		offset, limit int
+		want          []int
+	}{
+		{0, 0, []int{0, 1, 2, 3, 4}},
+		{2, 0, []int{2, 3, 4}},
+		{1, 2, []int{1, 2}},
+		{4, 3, []int{4}},
+		{5, 3, nil},
+		{9, 0, nil},
+	}
+	for _, tt := range tests {
+		if got := cutPage(items, tt.offset, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("cutPage(offset=%d, limit=%d) = %v, want %v", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSQLLimitOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		limit, offset int
+		want          string
+	}{
+		{0, 0, ""},
+		{10, 0, "LIMIT 10"},
+		{10, 20, "LIMIT 10 OFFSET 20"},
+		{0, 20, "LIMIT 18446744073709551615 OFFSET 20"},
+	}
+	for _, tt := range tests {
+		if got := sqlLimitOffset(tt.limit, tt.offset); got != tt.want {
+			t.Errorf("sqlLimitOffset(%d, %d) = %q, want %q", tt.limit, tt.offset, got, tt.want)
+		}
+	}
+}
+
+// With SkipWisps only the issues table answers, so the offset goes straight
+// into SQL.
+func TestSearchIssuesWithCountsPushesOffsetIntoSQL(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`SELECT 1 FROM wisp_dependencies LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(`(?s)FROM issues i.*ORDER BY i\.priority ASC, i\.created_at DESC, i\.id ASC\s+LIMIT 5 OFFSET 10`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	filter := types.IssueFilter{Limit: 5, Offset: 10, SkipWisps: true}
+	if _, err := SearchIssuesWithCountsInTx(context.Background(), tx, "", filter); err != nil {
+		t.Fatalf("SearchIssuesWithCountsInTx: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
+
+// When wisps are merged, each table must return its first Offset+Limit rows
+// so the page can be cut after the merge is sorted.
+func TestSearchIssuesWithCountsWidensLimitForMergedOffset(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`SELECT 1 FROM wisp_dependencies LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(`(?s)FROM issues i.*ORDER BY i\.priority ASC, i\.created_at DESC, i\.id ASC\s+LIMIT 15\s*$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT 1 FROM wisps LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery(`(?s)FROM wisps i.*ORDER BY i\.priority ASC, i\.created_at DESC, i\.id ASC\s+LIMIT 15\s*$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	got, err := SearchIssuesWithCountsInTx(context.Background(), tx, "", types.IssueFilter{Limit: 5, Offset: 10})
+	if err != nil {
+		t.Fatalf("SearchIssuesWithCountsInTx: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("SearchIssuesWithCountsInTx returned %d rows, want none", len(got))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddPatrolInTx inserts patrol, failing if its name is taken.
+func AddPatrolInTx(ctx context.Context, tx *sql.Tx, patrol *types.Patrol) error {
+	if err := patrol.Validate(); err != nil {
+		return err
+	}
+	if patrol.CreatedAt.IsZero() {
+		patrol.CreatedAt = time.Now().UTC()
+	}
+	var one int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM patrols WHERE name = ?`, patrol.Name).Scan(&one)
+	switch {
+	case err == nil:
+		return fmt.Errorf("patrol %q already exists", patrol.Name)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("check patrol %s: %w", patrol.Name, err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO patrols (name, formula, agent, schedule, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		patrol.Name, patrol.Formula, patrol.Agent, patrol.Schedule, patrol.CreatedBy, patrol.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add patrol %s: %w", patrol.Name, err)
+	}
+	return nil
+}
+
+// RemovePatrolInTx deletes the named patrol.
+func RemovePatrolInTx(ctx context.Context, tx *sql.Tx, name string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM patrols WHERE name = ?`, name)
+	if err != nil {
+		return fmt.Errorf("remove patrol %s: %w", name, err)
+	}
+	return requirePatrolRow(res, name, "remove")
+}
+
+// GetPatrolsInTx returns every patrol ordered by name. Databases created
+// before patrols existed have none.
+func GetPatrolsInTx(ctx context.Context, tx *sql.Tx) ([]*types.Patrol, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT name, formula, agent, schedule, last_run_at, last_wisp_id, last_completed_at, missed_count, created_by, created_at
+		FROM patrols ORDER BY name`)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get patrols: %w", err)
+	}
+	defer rows.Close()
+
+	var patrols []*types.Patrol
+	for rows.Next() {
+		var p types.Patrol
+		var lastRunAt, lastCompletedAt sql.NullTime
+		var createdBy sql.NullString
+		if err := rows.Scan(&p.Name, &p.Formula, &p.Agent, &p.Schedule, &lastRunAt, &p.LastWispID,
+			&lastCompletedAt, &p.MissedCount, &createdBy, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan patrol: %w", err)
+		}
+		if lastRunAt.Valid {
+			p.LastRunAt = &lastRunAt.Time
+		}
+		if lastCompletedAt.Valid {
+			p.LastCompletedAt = &lastCompletedAt.Time
+		}
+		p.CreatedBy = createdBy.String
+		patrols = append(patrols, &p)
+	}
+	return patrols, rows.Err()
+}
+
+// RecordPatrolRunInTx starts a new cycle for the named patrol.
+func RecordPatrolRunInTx(ctx context.Context, tx *sql.Tx, name string, ranAt time.Time, wispID string, missed bool) error {
+	missedInc := 0
+	if missed {
+		missedInc = 1
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE patrols SET last_run_at = ?, last_wisp_id = ?, last_completed_at = NULL, missed_count = missed_count + ?
+		WHERE name = ?`,
+		ranAt.UTC(), wispID, missedInc, name)
+	if err != nil {
+		return fmt.Errorf("record patrol run %s: %w", name, err)
+	}
+	return requirePatrolRow(res, name, "record run for")
+}
+
+// RecordPatrolCompletionInTx marks the named patrol's current cycle complete.
+func RecordPatrolCompletionInTx(ctx context.Context, tx *sql.Tx, name string, completedAt time.Time) error {
+	res, err := tx.ExecContext(ctx, `UPDATE patrols SET last_completed_at = ? WHERE name = ?`, completedAt.UTC(), name)
+	if err != nil {
+		return fmt.Errorf("record patrol completion %s: %w", name, err)
+	}
+	return requirePatrolRow(res, name, "record completion for")
+}
+
+// requirePatrolRow returns ErrNotFound when res touched no row.
+func requirePatrolRow(res sql.Result, name, op string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s patrol %s: %w", op, name, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("patrol %q: %w", name, storage.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CheckStatusPolicyInTx returns an error when issue, as it would be after
+// moving to status, lacks a field required by the status.policy.<type>.<status>
+// or status.policy.*.<status> config.
+func CheckStatusPolicyInTx(ctx context.Context, tx *sql.Tx, issue *types.Issue, status types.Status) error {
+	keys := []string{
+		types.StatusPolicyKey(issue.IssueType, status),
+		types.StatusPolicyKey("*", status),
+	}
+	cfg, err := getConfigKeysInTx(ctx, tx, keys...)
+	if err != nil {
+		return fmt.Errorf("read status policy: %w", err)
+	}
+
+	var required []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		value := cfg[key]
+		if value == "" {
+			continue
+		}
+		fields, err := types.ParseStatusPolicy(key, value)
+		if err != nil {
+			return err
+		}
+		for _, f := range fields {
+			if !seen[f] {
+				seen[f] = true
+				required = append(required, f)
+			}
+		}
+	}
+
+	missing := types.MissingPolicyFields(issue, required)
+	if len(missing) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("cannot move %s to %s: %s policy requires %s", issue.ID, status, issue.IssueType, strings.Join(missing, ", "))
+	if seen["commit"] && len(types.MissingPolicyFields(issue, []string{"commit"})) > 0 {
+		msg += " (mention the commit hash in the close reason or notes)"
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// issueWithUpdates returns a copy of issue with the policy-relevant fields
+// in updates applied, so a status change can be checked against the issue
+// as it will be after the update.
+func issueWithUpdates(issue *types.Issue, updates map[string]interface{}) *types.Issue {
+	v := *issue
+	for key, dst := range map[string]*string{
+		"assignee":            &v.Assignee,
+		"description":         &v.Description,
+		"design":              &v.Design,
+		"acceptance_criteria": &v.AcceptanceCriteria,
+		"notes":               &v.Notes,
+		"close_reason":        &v.CloseReason,
+	} {
+		switch x := updates[key].(type) {
+		case string:
+			*dst = x
+		case *string:
+			*dst = ""
+			if x != nil {
+				*dst = *x
+			}
+		}
+	}
+	if raw, ok := updates["external_ref"]; ok {
+		switch x := raw.(type) {
+		case string:
+			v.ExternalRef = &x
+		case *string:
+			v.ExternalRef = x
+		case nil:
+			v.ExternalRef = nil
+		}
+	}
+	if raw, ok := updates["estimated_minutes"]; ok {
+		switch x := raw.(type) {
+		case int:
+			v.EstimatedMinutes = &x
+		case *int:
+			v.EstimatedMinutes = x
+		case nil:
+			v.EstimatedMinutes = nil
+		}
+	}
+	if raw, ok := updates["due_at"]; ok {
+		switch x := raw.(type) {
+		case time.Time:
+			v.DueAt = &x
+		case *time.Time:
+			v.DueAt = x
+		case nil:
+			v.DueAt = nil
+		}
+	}
+	if raw, ok := updates["metadata"]; ok {
+		if s, err := storage.NormalizeMetadataValue(raw); err == nil {
+			v.Metadata = json.RawMessage(s)
+		}
+	}
+	if t, ok := updates["issue_type"].(string); ok {
+		v.IssueType = types.IssueType(t)
+	}
+	return &v
+}
@@ -0,0 +1,40 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AgeIssuePriorityInTx moves issueID from priority from to priority to and
+// records a priority_aged event with the old and new priorities. The update
+// is conditional on the issue still being open at from and not locked by
+// another actor.
+func AgeIssuePriorityInTx(ctx context.Context, tx *sql.Tx, issueID string, from, to int, reason, actor string) error {
+	if err := CheckIssueLockInTx(ctx, tx, issueID, actor); err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, `
+		UPDATE issues SET priority = ?
+		WHERE id = ? AND priority = ? AND status NOT IN (?, ?)`,
+		to, issueID, from, types.StatusClosed, types.StatusPinned)
+	if err != nil {
+		return fmt.Errorf("age priority of %s: %w", issueID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("age priority of %s: %w", issueID, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s is no longer open at priority %d", issueID, from)
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		NewEventID(), issueID, types.EventPriorityAged, actor, strconv.Itoa(from), strconv.Itoa(to), reason)
+	if err != nil {
+		return fmt.Errorf("record priority_aged event for %s: %w", issueID, err)
+	}
+	return nil
+}
@@ -88,6 +88,9 @@ func PromoteFromEphemeralInTx(ctx context.Context, tx *sql.Tx, id string, actor
 	if err := RetargetInboundDependenciesToIssueInTx(ctx, tx, id); err != nil {
 		return err
 	}
+	if _, err := IndexIssueReferencesInTx(ctx, tx, id); err != nil {
+		return fmt.Errorf("index references for promoted wisp %s: %w", id, err)
+	}
 
 	result, err := tx.ExecContext(ctx, `DELETE FROM wisps WHERE id = ?`, id)
 	if err != nil {
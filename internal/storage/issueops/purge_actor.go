@@ -0,0 +1,371 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// What removal does to a row whose actor column matches.
+const (
+	purgeClearNull  = "null"
+	purgeClearEmpty = "empty"
+	purgeDeleteRow  = "delete"
+)
+
+// actorColumn is a column that stores an actor name.
+type actorColumn struct {
+	table, column string
+	// issueColumn names the issue the row belongs to, for the report. It is
+	// empty for tables whose rows belong to no issue.
+	issueColumn string
+	// onRemove is what ActorPurgeOptions.Remove does to matching rows.
+	onRemove string
+}
+
+// actorColumns lists every column that records who did something. Event
+// old/new values are included because assignee and owner changes store the
+// actor name there.
+var actorColumns = []actorColumn{
+	{"issues", "assignee", "id", purgeClearNull},
+	{"issues", "created_by", "id", purgeClearEmpty},
+	{"issues", "owner", "id", purgeClearEmpty},
+	{"issues", "sender", "id", purgeClearEmpty},
+	{"issues", "actor", "id", purgeClearEmpty},
+	{"wisps", "assignee", "id", purgeClearNull},
+	{"wisps", "created_by", "id", purgeClearEmpty},
+	{"wisps", "owner", "id", purgeClearEmpty},
+	{"wisps", "sender", "id", purgeClearEmpty},
+	{"wisps", "actor", "id", purgeClearEmpty},
+	{"dependencies", "created_by", "issue_id", purgeClearEmpty},
+	{"wisp_dependencies", "created_by", "issue_id", purgeClearEmpty},
+	{"comments", "author", "issue_id", purgeDeleteRow},
+	{"wisp_comments", "author", "issue_id", purgeDeleteRow},
+	{"events", "actor", "issue_id", purgeDeleteRow},
+	{"events", "old_value", "issue_id", purgeClearNull},
+	{"events", "new_value", "issue_id", purgeClearNull},
+	{"wisp_events", "actor", "issue_id", purgeDeleteRow},
+	{"wisp_events", "old_value", "issue_id", purgeClearNull},
+	{"wisp_events", "new_value", "issue_id", purgeClearNull},
+	{"interactions", "actor", "issue_id", purgeDeleteRow},
+	{"locks", "holder", "issue_id", purgeDeleteRow},
+	{"ci_runs", "reported_by", "issue_id", purgeClearEmpty},
+	{"assignment_rules", "created_by", "", purgeClearEmpty},
+	{"patrols", "agent", "", purgeClearEmpty},
+	{"patrols", "created_by", "", purgeClearEmpty},
+	{"issue_aliases", "created_by", "issue_id", purgeClearEmpty},
+	{"external_refs", "resolved_by", "", purgeClearEmpty},
+}
+
+// actorListColumn is a comma-separated list of actor names.
+type actorListColumn struct {
+	table, column, keyColumn string
+}
+
+// actorListColumns are rewritten element by element. On removal a row whose
+// list ends up empty is deleted, since an assignment rule needs at least
+// one assignee.
+var actorListColumns = []actorListColumn{
+	{"assignment_rules", "assignees", "name"},
+}
+
+// mentionColumn is a free-text column that may @mention an actor.
+type mentionColumn struct {
+	table, column, keyColumn, issueColumn string
+	// indexed reports whether issue_references covers the table.
+	indexed bool
+}
+
+var mentionColumns = []mentionColumn{
+	{"issues", "title", "id", "id", true},
+	{"issues", "description", "id", "id", true},
+	{"issues", "design", "id", "id", true},
+	{"issues", "acceptance_criteria", "id", "id", true},
+	{"issues", "notes", "id", "id", true},
+	{"comments", "text", "id", "issue_id", true},
+	{"wisps", "title", "id", "id", false},
+	{"wisps", "description", "id", "id", false},
+	{"wisps", "design", "id", "id", false},
+	{"wisps", "acceptance_criteria", "id", "id", false},
+	{"wisps", "notes", "id", "id", false},
+	{"wisp_comments", "text", "id", "issue_id", false},
+}
+
+// PurgeActorInTx removes opts.Actor from every actor column and @mention in
+// the working set, then re-indexes the references of the regular issues it
+// touched. Tables missing from older databases are skipped.
+func PurgeActorInTx(ctx context.Context, tx *sql.Tx, opts storage.ActorPurgeOptions) (*storage.ActorPurgeReport, error) {
+	if opts.Actor == "" {
+		return nil, fmt.Errorf("purge actor: actor is required")
+	}
+	if opts.Replacement == "" || opts.Replacement == opts.Actor {
+		return nil, fmt.Errorf("purge actor: replacement must be set and differ from the actor")
+	}
+
+	report := &storage.ActorPurgeReport{
+		Actor:       opts.Actor,
+		Replacement: opts.Replacement,
+		Remove:      opts.Remove,
+		DryRun:      opts.DryRun,
+		Changes:     []storage.ActorPurgeChange{},
+		IssueIDs:    []string{},
+	}
+	touched := make(map[string]bool)
+	reindex := make(map[string]bool)
+	record := func(table, column, action string, rows int64) {
+		if rows > 0 {
+			report.Changes = append(report.Changes, storage.ActorPurgeChange{Table: table, Column: column, Action: action, Rows: rows})
+			report.TotalRows += rows
+		}
+	}
+
+	for _, col := range actorColumns {
+		ids, err := purgeIssueIDsInTx(ctx, tx, col.table, col.issueColumn,
+			col.column+" = ?", opts.Actor)
+		if err != nil {
+			return nil, err
+		}
+		if ids == nil {
+			continue // table does not exist
+		}
+		if opts.Remove && col.table == "comments" && col.column == "author" {
+			// Deleted comments take their parsed references with them.
+			if _, err := tx.ExecContext(ctx, `
+				DELETE FROM issue_references
+				WHERE source_kind = ? AND source_ref IN (SELECT id FROM comments WHERE author = ?)
+			`, types.ReferenceSourceComment, opts.Actor); err != nil && !isTableNotExistError(err) {
+				return nil, fmt.Errorf("purge actor: clear comment references: %w", err)
+			}
+			for _, id := range ids {
+				reindex[id] = true
+			}
+		}
+
+		//nolint:gosec // G201: table and column names come from actorColumns
+		query, args, action := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, col.table, col.column, col.column),
+			[]any{opts.Replacement, opts.Actor}, "anonymized"
+		if opts.Remove {
+			switch col.onRemove {
+			case purgeClearNull:
+				//nolint:gosec // G201: table and column names come from actorColumns
+				query, args, action = fmt.Sprintf(`UPDATE %s SET %s = NULL WHERE %s = ?`, col.table, col.column, col.column),
+					[]any{opts.Actor}, "cleared"
+			case purgeClearEmpty:
+				args, action = []any{"", opts.Actor}, "cleared"
+			case purgeDeleteRow:
+				//nolint:gosec // G201: table and column names come from actorColumns
+				query, args, action = fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, col.table, col.column),
+					[]any{opts.Actor}, "deleted"
+			}
+		}
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("purge actor: %s.%s: %w", col.table, col.column, err)
+		}
+		n, _ := res.RowsAffected()
+		record(col.table, col.column, action, n)
+		if n > 0 {
+			for _, id := range ids {
+				touched[id] = true
+			}
+		}
+	}
+
+	for _, col := range actorListColumns {
+		updated, deleted, err := rewriteActorListInTx(ctx, tx, col, opts)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Remove {
+			record(col.table, col.column, "cleared", updated)
+		} else {
+			record(col.table, col.column, "anonymized", updated)
+		}
+		record(col.table, col.column, "deleted", deleted)
+	}
+
+	for _, col := range mentionColumns {
+		n, err := rewriteMentionsInTx(ctx, tx, col, opts, touched, reindex)
+		if err != nil {
+			return nil, err
+		}
+		record(col.table, col.column, "rewritten", n)
+	}
+
+	var mentions int64
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issue_references WHERE ref_type = ? AND target = ?`,
+		types.ReferenceTypeActor, opts.Actor).Scan(&mentions); err != nil && !isTableNotExistError(err) {
+		return nil, fmt.Errorf("purge actor: count mentions: %w", err)
+	}
+	reindexIDs := sortedKeys(reindex)
+	for _, id := range reindexIDs {
+		if _, err := IndexIssueReferencesInTx(ctx, tx, id); err != nil {
+			return nil, fmt.Errorf("purge actor: %w", err)
+		}
+	}
+	// Anything still pointing at the actor came from text the parser no
+	// longer sees; drop it rather than leave the name behind.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_references WHERE ref_type = ? AND target = ?`,
+		types.ReferenceTypeActor, opts.Actor); err != nil && !isTableNotExistError(err) {
+		return nil, fmt.Errorf("purge actor: clear mentions: %w", err)
+	}
+	record("issue_references", "target", "rewritten", mentions)
+
+	report.IssueIDs = append(report.IssueIDs, sortedKeys(touched)...)
+	return report, nil
+}
+
+// purgeIssueIDsInTx returns the distinct issue IDs of rows in table matching
+// where. It returns nil (not an empty slice) when table does not exist, and
+// an empty slice when issueColumn is empty.
+func purgeIssueIDsInTx(ctx context.Context, tx *sql.Tx, table, issueColumn, where string, args ...any) ([]string, error) {
+	if issueColumn == "" {
+		issueColumn = "''" // still probe, so a missing table is skipped
+	}
+	//nolint:gosec // G201: identifiers come from the purge column tables
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT DISTINCT %s FROM %s WHERE %s`, issueColumn, table, where), args...)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("purge actor: scan %s: %w", table, err)
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id sql.NullString
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("purge actor: scan %s: %w", table, err)
+		}
+		if id.String != "" {
+			ids = append(ids, id.String)
+		}
+	}
+	return ids, rows.Err()
+}
+
+// rewriteMentionsInTx replaces @actor with @replacement in one text column
+// and returns the number of rows rewritten.
+func rewriteMentionsInTx(ctx context.Context, tx *sql.Tx, col mentionColumn, opts storage.ActorPurgeOptions, touched, reindex map[string]bool) (int64, error) {
+	//nolint:gosec // G201: identifiers come from mentionColumns
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT %s, %s, %s FROM %s WHERE INSTR(%s, ?) > 0`,
+		col.keyColumn, col.issueColumn, col.column, col.table, col.column), "@"+opts.Actor)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+	}
+	type rewrite struct{ key, issueID, text string }
+	var rewrites []rewrite
+	for rows.Next() {
+		var key, issueID string
+		var text sql.NullString
+		if err := rows.Scan(&key, &issueID, &text); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+		}
+		if updated, n := ReplaceMentions(text.String, opts.Actor, opts.Replacement); n > 0 {
+			rewrites = append(rewrites, rewrite{key, issueID, updated})
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+	}
+
+	for _, r := range rewrites {
+		//nolint:gosec // G201: identifiers come from mentionColumns
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`,
+			col.table, col.column, col.keyColumn), r.text, r.key); err != nil {
+			return 0, fmt.Errorf("purge actor: rewrite %s.%s: %w", col.table, col.column, err)
+		}
+		touched[r.issueID] = true
+		if col.indexed {
+			reindex[r.issueID] = true
+		}
+	}
+	return int64(len(rewrites)), nil
+}
+
+// rewriteActorListInTx replaces or drops opts.Actor in one list column. It
+// returns the number of rows rewritten and, on removal, deleted.
+func rewriteActorListInTx(ctx context.Context, tx *sql.Tx, col actorListColumn, opts storage.ActorPurgeOptions) (updated, deleted int64, err error) {
+	//nolint:gosec // G201: identifiers come from actorListColumns
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT %s, %s FROM %s WHERE INSTR(%s, ?) > 0`,
+		col.keyColumn, col.column, col.table, col.column), opts.Actor)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+	}
+	type rewrite struct {
+		key   string
+		names []string
+	}
+	var rewrites []rewrite
+	for rows.Next() {
+		var key, list string
+		if err := rows.Scan(&key, &list); err != nil {
+			_ = rows.Close()
+			return 0, 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+		}
+		var names []string
+		found := false
+		for _, name := range strings.Split(list, ",") {
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "":
+			case name != opts.Actor:
+				names = append(names, name)
+			default:
+				found = true
+				if !opts.Remove && !slices.Contains(names, opts.Replacement) {
+					names = append(names, opts.Replacement)
+				}
+			}
+		}
+		if found {
+			rewrites = append(rewrites, rewrite{key, names})
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("purge actor: read %s.%s: %w", col.table, col.column, err)
+	}
+
+	for _, r := range rewrites {
+		if len(r.names) == 0 {
+			//nolint:gosec // G201: identifiers come from actorListColumns
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`,
+				col.table, col.keyColumn), r.key); err != nil {
+				return 0, 0, fmt.Errorf("purge actor: delete from %s: %w", col.table, err)
+			}
+			deleted++
+			continue
+		}
+		//nolint:gosec // G201: identifiers come from actorListColumns
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`,
+			col.table, col.column, col.keyColumn), strings.Join(r.names, ","), r.key); err != nil {
+			return 0, 0, fmt.Errorf("purge actor: rewrite %s.%s: %w", col.table, col.column, err)
+		}
+		updated++
+	}
+	return updated, deleted, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -121,6 +121,26 @@ func buildReadyWorkPredicates(ctx context.Context, tx *sql.Tx, filter types.Work
 		}
 	}
 
+	// Blocking dependencies on issues in federation peers and on external
+	// URLs are not part of the materialized is_blocked flag; resolve them here.
+	federatedBlocked, err := OutsideBlockersInTx(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("get ready work: %w", err)
+	}
+	if len(federatedBlocked) > 0 {
+		blockedIDs := make([]string, 0, len(federatedBlocked))
+		for id := range federatedBlocked {
+			blockedIDs = append(blockedIDs, id)
+		}
+		sort.Strings(blockedIDs)
+		for start := 0; start < len(blockedIDs); start += queryBatchSize {
+			end := min(start+queryBatchSize, len(blockedIDs))
+			placeholders, batchArgs := buildSQLInClause(blockedIDs[start:end])
+			args = append(args, batchArgs...)
+			whereClauses = append(whereClauses, fmt.Sprintf("id NOT IN (%s)", placeholders))
+		}
+	}
+
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
 			whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM %s WHERE label = ?)", tables.Labels))
@@ -213,6 +233,17 @@ func GetReadyWorkInTx(
 	tx *sql.Tx,
 	filter types.WorkFilter,
 ) ([]*types.Issue, error) {
+	if filter.Offset > 0 {
+		window := filter
+		window.Limit = offsetWindowLimit(filter.Limit, filter.Offset)
+		window.Offset = 0
+		issues, err := GetReadyWorkInTx(ctx, tx, window)
+		if err != nil {
+			return nil, err
+		}
+		return cutPage(issues, filter.Offset, filter.Limit), nil
+	}
+
 	preds, err := buildReadyWorkPredicates(ctx, tx, filter, IssuesFilterTables)
 	if err != nil {
 		return nil, err
@@ -256,6 +287,9 @@ func GetReadyWorkInTx(
 			return nil, err
 		}
 	}
+	if err := applySuggestsTiebreakInTx(ctx, tx, ordered, filter.SortPolicy); err != nil {
+		return nil, fmt.Errorf("get ready work: %w", err)
+	}
 
 	return ordered, nil
 }
@@ -12,6 +12,36 @@ import (
 )
 
 func GetReadyWorkWithCountsInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
+	out, err := getReadyWorkWithCountsInTx(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]*types.Issue, 0, len(out))
+	for _, item := range out {
+		if item == nil || item.Issue == nil {
+			return out, nil
+		}
+		issues = append(issues, item.Issue)
+	}
+	if err := applySuggestsTiebreakInTx(ctx, tx, issues, filter.SortPolicy); err != nil {
+		return nil, fmt.Errorf("get ready work with counts: %w", err)
+	}
+	reorderIssuesWithCounts(out, issues)
+	return out, nil
+}
+
+func getReadyWorkWithCountsInTx(ctx context.Context, tx *sql.Tx, filter types.WorkFilter) ([]*types.IssueWithCounts, error) {
+	if filter.Offset > 0 {
+		window := filter
+		window.Limit = offsetWindowLimit(filter.Limit, filter.Offset)
+		window.Offset = 0
+		items, err := GetReadyWorkWithCountsInTx(ctx, tx, window)
+		if err != nil {
+			return nil, err
+		}
+		return cutPage(items, filter.Offset, filter.Limit), nil
+	}
+
 	wispDepsExist, err := optionalTableExistsInTx(ctx, tx, "wisp_dependencies")
 	if err != nil {
 		return nil, fmt.Errorf("get ready work with counts: wisp dependency probe: %w", err)
@@ -89,6 +119,12 @@ func sortIssuesWithCountsByPolicy(items []*types.IssueWithCounts, policy types.S
 		return
 	}
 	sortReadyIssues(issues, policy)
+	reorderIssuesWithCounts(items, issues)
+}
+
+// reorderIssuesWithCounts puts items in the order of issues, which holds the
+// same issues as items.
+func reorderIssuesWithCounts(items []*types.IssueWithCounts, issues []*types.Issue) {
 	byID := make(map[string]int, len(issues))
 	for i, iss := range issues {
 		byID[iss.ID] = i
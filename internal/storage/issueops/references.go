@@ -0,0 +1,242 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueRefPattern matches issue-ID-shaped tokens: a prefix, a hyphen, a hash
+// or counter, and optional hierarchical ".N" suffixes (bd-a3f8, bd-a3f8.1.2,
+// my-proj-42). It also matches ordinary hyphenated words; those are dropped
+// because they do not name an existing issue.
+var issueRefPattern = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9_]*(?:-[A-Za-z0-9_]+)*-[A-Za-z0-9]+(?:\.[0-9]+)*\b`)
+
+// mentionPattern matches @actor. The character before the @ must not be a
+// word character, so email addresses are not mentions, and the actor must
+// end in a letter, digit, or underscore, so trailing punctuation is dropped.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.@/])@([A-Za-z0-9](?:[A-Za-z0-9_./-]*[A-Za-z0-9_])?)`)
+
+// ParseMentions returns the distinct @actor mentions in text, in order of
+// first appearance, without the @.
+func ParseMentions(text string) []string {
+	var actors []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if actor := m[1]; !seen[actor] {
+			seen[actor] = true
+			actors = append(actors, actor)
+		}
+	}
+	return actors
+}
+
+// ReplaceMentions rewrites every @actor mention of actor in text to
+// @replacement and returns the new text with the number of mentions
+// replaced. Mentions of other actors that merely start with actor's name are
+// left alone.
+func ReplaceMentions(text, actor, replacement string) (string, int) {
+	var b strings.Builder
+	last, n := 0, 0
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[2], m[3]
+		if text[start:end] != actor {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(replacement)
+		last = end
+		n++
+	}
+	if n == 0 {
+		return text, 0
+	}
+	b.WriteString(text[last:])
+	return b.String(), n
+}
+
+// ParseIssueRefCandidates returns the distinct issue-ID-shaped tokens in
+// text, in order of first appearance. Callers must check which of them name
+// real issues.
+func ParseIssueRefCandidates(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range issueRefPattern.FindAllString(text, -1) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// existingIssueIDsInTx returns the IDs among candidates that exist in the
+// issues table, as stored.
+func existingIssueIDsInTx(ctx context.Context, tx *sql.Tx, candidates []string) ([]string, error) {
+	var found []string
+	for start := 0; start < len(candidates); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(candidates))
+		batch := candidates[start:end]
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+		//nolint:gosec // G201: only placeholders are interpolated
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+			`SELECT id FROM issues WHERE id IN (%s) ORDER BY id`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("resolve issue references: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("resolve issue references: scan: %w", err)
+			}
+			found = append(found, id)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("resolve issue references: rows: %w", err)
+		}
+	}
+	return found, nil
+}
+
+// SyncIssueReferencesInTx replaces the references recorded for one source,
+// an issue's description (sourceRef "") or one of its comments (sourceRef is
+// the comment ID), with those parsed from text. Issue references are kept
+// only when they name an existing issue other than sourceID. Wisp sources are
+// not indexed; callers skip them. Databases that predate migration 0052 have
+// no issue_references table and are left alone.
+//
+// Returns whether issue_references changed, so callers can stage it.
+func SyncIssueReferencesInTx(ctx context.Context, tx *sql.Tx, sourceID, sourceKind, sourceRef, text string) (bool, error) {
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM issue_references WHERE source_id = ? AND source_kind = ? AND source_ref = ?`,
+		sourceID, sourceKind, sourceRef)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("clear references from %s: %w", sourceID, err)
+	}
+	deleted, _ := res.RowsAffected()
+
+	var refs []types.IssueReference
+	var candidates []string
+	for _, id := range ParseIssueRefCandidates(text) {
+		if id != sourceID {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) > 0 {
+		ids, err := existingIssueIDsInTx(ctx, tx, candidates)
+		if err != nil {
+			return false, err
+		}
+		for _, id := range ids {
+			if id != sourceID {
+				refs = append(refs, types.IssueReference{RefType: types.ReferenceTypeIssue, Target: id})
+			}
+		}
+	}
+	for _, actor := range ParseMentions(text) {
+		refs = append(refs, types.IssueReference{RefType: types.ReferenceTypeActor, Target: actor})
+	}
+
+	for _, ref := range refs {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO issue_references (source_id, source_kind, source_ref, ref_type, target)
+			VALUES (?, ?, ?, ?, ?)
+		`, sourceID, sourceKind, sourceRef, ref.RefType, ref.Target); err != nil {
+			return false, fmt.Errorf("record reference from %s to %s: %w", sourceID, ref.Target, err)
+		}
+	}
+	return deleted > 0 || len(refs) > 0, nil
+}
+
+// IndexIssueReferencesInTx re-records the references parsed from a regular
+// issue's description and every one of its comments, for rows that reached
+// the issues and comments tables without passing through the write paths
+// above (e.g. a promoted wisp).
+func IndexIssueReferencesInTx(ctx context.Context, tx *sql.Tx, issueID string) (bool, error) {
+	var description string
+	if err := tx.QueryRowContext(ctx, `SELECT description FROM issues WHERE id = ?`, issueID).Scan(&description); err != nil {
+		return false, fmt.Errorf("read description of %s: %w", issueID, err)
+	}
+	changed, err := SyncIssueReferencesInTx(ctx, tx, issueID, types.ReferenceSourceDescription, "", description)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, text FROM comments WHERE issue_id = ? ORDER BY created_at, id`, issueID)
+	if err != nil {
+		return false, fmt.Errorf("read comments of %s: %w", issueID, err)
+	}
+	type comment struct{ id, text string }
+	var comments []comment
+	for rows.Next() {
+		var c comment
+		if err := rows.Scan(&c.id, &c.text); err != nil {
+			_ = rows.Close()
+			return false, fmt.Errorf("read comments of %s: scan: %w", issueID, err)
+		}
+		comments = append(comments, c)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("read comments of %s: rows: %w", issueID, err)
+	}
+
+	for _, c := range comments {
+		commentChanged, err := SyncIssueReferencesInTx(ctx, tx, issueID, types.ReferenceSourceComment, c.id, c.text)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || commentChanged
+	}
+	return changed, nil
+}
+
+// GetBacklinksInTx returns the references to issueID recorded from other
+// issues' descriptions and comments, ordered by source.
+func GetBacklinksInTx(ctx context.Context, tx *sql.Tx, issueID string) ([]*types.IssueReference, error) {
+	return queryReferencesInTx(ctx, tx, types.ReferenceTypeIssue, issueID)
+}
+
+// GetMentionsInTx returns the @actor mentions of actor, ordered by source.
+func GetMentionsInTx(ctx context.Context, tx *sql.Tx, actor string) ([]*types.IssueReference, error) {
+	return queryReferencesInTx(ctx, tx, types.ReferenceTypeActor, actor)
+}
+
+func queryReferencesInTx(ctx context.Context, tx *sql.Tx, refType, target string) ([]*types.IssueReference, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT source_id, source_kind, source_ref, ref_type, target
+		FROM issue_references
+		WHERE ref_type = ? AND target = ?
+		ORDER BY source_id, source_kind DESC, source_ref
+	`, refType, target)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get references to %s: %w", target, err)
+	}
+	defer rows.Close()
+
+	var refs []*types.IssueReference
+	for rows.Next() {
+		var ref types.IssueReference
+		if err := rows.Scan(&ref.SourceID, &ref.SourceKind, &ref.SourceRef, &ref.RefType, &ref.Target); err != nil {
+			return nil, fmt.Errorf("get references to %s: scan: %w", target, err)
+		}
+		refs = append(refs, &ref)
+	}
+	return refs, rows.Err()
+}
@@ -0,0 +1,115 @@
+package issueops
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@alice please look", []string{"alice"}},
+		{"cc @alice, @bob and @alice again", []string{"alice", "bob"}},
+		{"ask @beads/crew/dave.", []string{"beads/crew/dave"}},
+		{"(@carol) and @dan_2!", []string{"carol", "dan_2"}},
+		{"mail dev@example.com or x.@y", nil},
+		{"trailing @ alone", nil},
+	}
+	for _, tc := range tests {
+		if got := ParseMentions(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseMentions(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestReplaceMentions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want string
+		n    int
+	}{
+		{"@alice please look", "@anon please look", 1},
+		{"cc @alice, @alicia and (@alice).", "cc @anon, @alicia and (@anon).", 2},
+		{"@alice/crew stays, mail alice@example.com", "@alice/crew stays, mail alice@example.com", 0},
+	}
+	for _, tc := range tests {
+		if got, n := ReplaceMentions(tc.text, "alice", "anon"); got != tc.want || n != tc.n {
+			t.Errorf("ReplaceMentions(%q) = %q, %d; want %q, %d", tc.text, got, n, tc.want, tc.n)
+		}
+	}
+}
+
+func TestParseIssueRefCandidates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"blocked on bd-a3f8.", []string{"bd-a3f8"}},
+		{"see bd-a3f8.1.2 and (my-proj-42)", []string{"bd-a3f8.1.2", "my-proj-42"}},
+		{"bd-x1 bd-x1 https://example.com/bd-x2", []string{"bd-x1", "bd-x2"}},
+		{"no ids here", nil},
+	}
+	for _, tc := range tests {
+		if got := ParseIssueRefCandidates(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseIssueRefCandidates(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestSyncIssueReferencesInTxKeepsExistingIssues(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM issue_references").
+		WithArgs("bd-src", types.ReferenceSourceDescription, "").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	// bd-src is the source itself and never looked up; well-known is.
+	mock.ExpectQuery("SELECT id FROM issues WHERE id IN").
+		WithArgs("bd-dst", "well-known").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("bd-dst"))
+	mock.ExpectExec("INSERT IGNORE INTO issue_references").
+		WithArgs("bd-src", types.ReferenceSourceDescription, "", types.ReferenceTypeIssue, "bd-dst").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT IGNORE INTO issue_references").
+		WithArgs("bd-src", types.ReferenceSourceDescription, "", types.ReferenceTypeActor, "alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	changed, err := SyncIssueReferencesInTx(ctx, tx, "bd-src", types.ReferenceSourceDescription, "",
+		"bd-src depends on bd-dst, a well-known fix; @alice owns it")
+	if err != nil {
+		t.Fatalf("SyncIssueReferencesInTx: %v", err)
+	}
+	if !changed {
+		t.Error("changed = false, want true")
+	}
+	_ = tx.Rollback()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,213 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// renameColumn is a column holding an issue ID that must follow a rename.
+type renameColumn struct {
+	table, column string
+	filter        string // extra WHERE condition, if any
+	// cascade marks columns moved by an ON UPDATE CASCADE foreign key when
+	// the issues or wisps row is renamed; the rest are written explicitly.
+	cascade bool
+}
+
+// issueRenameColumns are the columns that reference a persistent issue.
+// child_counters lost its FK in migration 0039, so it is moved explicitly.
+var issueRenameColumns = []renameColumn{
+	{table: "labels", column: "issue_id", cascade: true},
+	{table: "comments", column: "issue_id", cascade: true},
+	{table: "events", column: "issue_id", cascade: true},
+	{table: "dependencies", column: "issue_id", cascade: true},
+	{table: "issue_snapshots", column: "issue_id", cascade: true},
+	{table: "compaction_snapshots", column: "issue_id", cascade: true},
+	{table: "issue_references", column: "source_id", cascade: true},
+	{table: "locks", column: "issue_id", cascade: true},
+	{table: "commit_links", column: "issue_id", cascade: true},
+	{table: "ci_runs", column: "issue_id", cascade: true},
+	{table: "issue_aliases", column: "issue_id", cascade: true},
+	{table: "dependencies", column: "depends_on_issue_id"},
+	{table: "wisp_dependencies", column: "depends_on_issue_id"},
+	{table: "issue_references", column: "target", filter: "ref_type = '" + string(types.ReferenceTypeIssue) + "'"},
+	{table: "child_counters", column: "parent_id"},
+}
+
+// wispRenameColumns are the columns that reference a wisp. The FKs of
+// wisp_labels, wisp_events and wisp_comments are clone-local and may be
+// missing, so those rows are moved explicitly rather than trusted to cascade.
+var wispRenameColumns = []renameColumn{
+	{table: "wisp_dependencies", column: "issue_id", cascade: true},
+	{table: "wisp_labels", column: "issue_id"},
+	{table: "wisp_events", column: "issue_id"},
+	{table: "wisp_comments", column: "issue_id"},
+	{table: "wisp_child_counters", column: "parent_id"},
+	{table: "dependencies", column: "depends_on_wisp_id"},
+	{table: "wisp_dependencies", column: "depends_on_wisp_id"},
+}
+
+// UpdateIssueIDInTx renames an issue or wisp and everything that references
+// it, returning the rows moved per table.
+//
+// newID must not already name an issue, wisp, or alias. Foreign key checks
+// stay on throughout: the issues (or wisps) row is renamed first, so rows
+// constrained by ON UPDATE CASCADE follow it within the same statement and
+// every remaining reference is rewritten afterwards inside the transaction.
+// A failure at any step rolls the whole rename back.
+//
+//nolint:gosec // G201: table names are hardcoded
+func UpdateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) (*types.RenameResult, error) {
+	if err := checkRenameTargetFreeInTx(ctx, tx, newID); err != nil {
+		return nil, err
+	}
+	result := &types.RenameResult{OldID: oldID, NewID: newID}
+	if IsActiveWispInTx(ctx, tx, oldID) {
+		result.Wisp = true
+		return result, updateWispIDInTx(ctx, tx, oldID, newID, issue, actor, result)
+	}
+	return result, updateIssueIDInTx(ctx, tx, oldID, newID, issue, actor, result)
+}
+
+// checkRenameTargetFreeInTx rejects a rename onto an ID that is already an
+// issue, a wisp, or an alias.
+func checkRenameTargetFreeInTx(ctx context.Context, tx *sql.Tx, newID string) error {
+	for _, check := range []struct{ query, what string }{
+		{`SELECT id FROM issues WHERE id = ?`, "issue %s already exists"},
+		{`SELECT id FROM wisps WHERE id = ?`, "wisp %s already exists"},
+		{`SELECT issue_id FROM issue_aliases WHERE alias = ?`, "%s is already an alias"},
+	} {
+		var found string
+		err := tx.QueryRowContext(ctx, check.query, newID).Scan(&found)
+		switch {
+		case err == nil:
+			return fmt.Errorf(check.what, newID)
+		case errors.Is(err, sql.ErrNoRows), isTableNotExistError(err):
+		default:
+			return fmt.Errorf("check rename target %s: %w", newID, err)
+		}
+	}
+	return nil
+}
+
+// countRenameRowsInTx records how many rows of each column reference oldID.
+// It runs before the rename, since cascaded rows change along with it.
+func countRenameRowsInTx(ctx context.Context, tx *sql.Tx, columns []renameColumn, oldID string, result *types.RenameResult) error {
+	for _, c := range columns {
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = ?`, c.table, c.column)
+		if c.filter != "" {
+			query += " AND " + c.filter
+		}
+		var n int
+		if err := tx.QueryRowContext(ctx, query, oldID).Scan(&n); err != nil {
+			if isTableNotExistError(err) {
+				continue
+			}
+			return fmt.Errorf("count %s.%s: %w", c.table, c.column, err)
+		}
+		if n > 0 {
+			result.Tables = append(result.Tables, types.RenamedTable{Table: c.table, Column: c.column, Rows: n})
+		}
+	}
+	return nil
+}
+
+// moveRenameColumnsInTx rewrites oldID to newID in the columns that have no
+// cascading foreign key and no dedicated handler.
+func moveRenameColumnsInTx(ctx context.Context, tx *sql.Tx, columns []renameColumn, oldID, newID string) error {
+	for _, c := range columns {
+		if c.cascade {
+			continue
+		}
+		query := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, c.table, c.column, c.column)
+		if c.filter != "" {
+			query += " AND " + c.filter
+		}
+		if _, err := tx.ExecContext(ctx, query, newID, oldID); err != nil && !isTableNotExistError(err) {
+			return fmt.Errorf("update %s.%s: %w", c.table, c.column, err)
+		}
+	}
+	return nil
+}
+
+// withoutDependencyTargets drops the dependency target columns, which
+// UpdateIssueIDInDependenciesInTx and UpdateWispIDInDependenciesInTx rewrite
+// (re-deriving each edge's key) instead of a plain UPDATE.
+func withoutDependencyTargets(columns []renameColumn) []renameColumn {
+	out := make([]renameColumn, 0, len(columns))
+	for _, c := range columns {
+		if c.column == "depends_on_issue_id" || c.column == "depends_on_wisp_id" {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func updateIssueIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string, result *types.RenameResult) error {
+	if err := countRenameRowsInTx(ctx, tx, issueRenameColumns, oldID, result); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	res, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
+		WHERE id = ?
+	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, now, oldID)
+	if err != nil {
+		return fmt.Errorf("update issue ID: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("issue not found: %s", oldID)
+	}
+	result.Tables = append([]types.RenamedTable{{Table: "issues", Column: "id", Rows: 1}}, result.Tables...)
+
+	if err := UpdateIssueIDInDependenciesInTx(ctx, tx, oldID, newID); err != nil {
+		return err
+	}
+	if err := moveRenameColumnsInTx(ctx, tx, withoutDependencyTargets(issueRenameColumns), oldID, newID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (id, issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, 'renamed', ?, ?, ?)
+	`, NewEventID(), newID, actor, oldID, newID)
+	return err
+}
+
+func updateWispIDInTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string, result *types.RenameResult) error {
+	if err := countRenameRowsInTx(ctx, tx, wispRenameColumns, oldID, result); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	res, err := tx.ExecContext(ctx, `
+		UPDATE wisps
+		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
+		WHERE id = ?
+	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, now, oldID)
+	if err != nil {
+		return fmt.Errorf("update wisp ID: %w", err)
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return fmt.Errorf("wisp not found: %s", oldID)
+	}
+	result.Tables = append([]types.RenamedTable{{Table: "wisps", Column: "id", Rows: 1}}, result.Tables...)
+
+	if err := moveRenameColumnsInTx(ctx, tx, withoutDependencyTargets(wispRenameColumns), oldID, newID); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `
+		INSERT INTO wisp_events (id, issue_id, event_type, actor, old_value, new_value)
+		VALUES (?, ?, 'renamed', ?, ?, ?)
+	`, NewEventID(), newID, actor, oldID, newID); err != nil {
+		return err
+	}
+
+	return UpdateWispIDInDependenciesInTx(ctx, tx, oldID, newID)
+}
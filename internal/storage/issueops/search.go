@@ -14,8 +14,22 @@ import (
 // with labels populated.
 //
 // Set filter.SkipWisps=true for callers that never need ephemeral results; this
-// avoids the unconditional full-table wisps scan (Q2 perf opt).
+// avoids the unconditional full-table wisps scan (Q2 perf opt). It also lets
+// filter.Offset be pushed straight into SQL; otherwise the page is cut from a
+// sorted window of both tables (see paging.go).
 func SearchIssuesInTx(ctx context.Context, tx *sql.Tx, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	if filter.Offset > 0 && !filter.SkipWisps {
+		window := filter
+		window.Limit = offsetWindowLimit(filter.Limit, filter.Offset)
+		window.Offset = 0
+		issues, err := SearchIssuesInTx(ctx, tx, query, window)
+		if err != nil {
+			return nil, err
+		}
+		sortSearchIssues(issues, filter.SortBy, filter.SortDesc)
+		return cutPage(issues, filter.Offset, filter.Limit), nil
+	}
+
 	// Route ephemeral-only queries to wisps table.
 	if filter.Ephemeral != nil && *filter.Ephemeral {
 		results, err := searchTableInTx(ctx, tx, query, filter, WispsFilterTables)
@@ -102,10 +116,7 @@ func searchTableInTx(ctx context.Context, tx *sql.Tx, query string, filter types
 	}
 
 	// Pattern A: full 47-column scan (used for unlimited queries or when NoIDShrink is set).
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = fmt.Sprintf(" LIMIT %d", filter.Limit)
-	}
+	limitSQL := sqlLimitOffset(filter.Limit, filter.Offset)
 
 	selectSQL := "SELECT "
 	if labelDriven {
@@ -155,9 +166,9 @@ func searchTablePatternB(ctx context.Context, tx *sql.Tx, fromSQL, whereSQL stri
 		idSelect = "SELECT DISTINCT "
 	}
 	//nolint:gosec // G201: SQL fragments from fixed column/table names and parameterized filters.
-	idQuery := fmt.Sprintf(`%s%s.id FROM %s %s %s LIMIT %d`,
+	idQuery := fmt.Sprintf(`%s%s.id FROM %s %s %s %s`,
 		idSelect, tables.Main, fromSQL, whereSQL,
-		issueOpsOrderBy(filter.SortBy, filter.SortDesc, tables.Main), filter.Limit)
+		issueOpsOrderBy(filter.SortBy, filter.SortDesc, tables.Main), sqlLimitOffset(filter.Limit, filter.Offset))
 
 	rows, err := tx.QueryContext(ctx, idQuery, args...)
 	if err != nil {
@@ -12,6 +12,17 @@ import (
 )
 
 func SearchIssuesWithCountsInTx(ctx context.Context, tx *sql.Tx, query string, filter types.IssueFilter) ([]*types.IssueWithCounts, error) {
+	if filter.Offset > 0 && !filter.SkipWisps {
+		window := filter
+		window.Limit = offsetWindowLimit(filter.Limit, filter.Offset)
+		window.Offset = 0
+		items, err := SearchIssuesWithCountsInTx(ctx, tx, query, window)
+		if err != nil {
+			return nil, err
+		}
+		return cutPage(items, filter.Offset, filter.Limit), nil
+	}
+
 	wispDepsExist, err := optionalTableExistsInTx(ctx, tx, "wisp_dependencies")
 	if err != nil {
 		return nil, fmt.Errorf("search issues with counts: wisp dependency probe: %w", err)
@@ -91,10 +102,7 @@ func runFilterSearchQueryInTx(ctx context.Context, tx *sql.Tx, query string, fil
 	if len(whereClauses) > 0 {
 		whereSQL = "WHERE " + joinAnd(whereClauses)
 	}
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = fmt.Sprintf("LIMIT %d", filter.Limit)
-	}
+	limitSQL := sqlLimitOffset(filter.Limit, filter.Offset)
 	orderBy := issueOpsOrderBy(filter.SortBy, filter.SortDesc, "i")
 	return runSearchQueryInTx(ctx, tx, tables, whereSQL, orderBy, limitSQL, args, includeWispReverseDeps, filter.SkipLabels)
 }
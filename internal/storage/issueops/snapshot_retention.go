@@ -0,0 +1,154 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// snapshotTable describes one table covered by snapshot retention.
+type snapshotTable struct {
+	name     string
+	timeCol  string
+	sizeExpr string
+}
+
+// snapshotTables lists the snapshot tables in the order they are reported.
+var snapshotTables = []snapshotTable{
+	{
+		name:     "issue_snapshots",
+		timeCol:  "snapshot_time",
+		sizeExpr: "COALESCE(LENGTH(original_content), 0) + COALESCE(LENGTH(archived_events), 0)",
+	},
+	{
+		name:     "compaction_snapshots",
+		timeCol:  "created_at",
+		sizeExpr: "COALESCE(LENGTH(snapshot_json), 0)",
+	},
+}
+
+// SnapshotRow is the retention-relevant part of a snapshot row.
+type SnapshotRow struct {
+	ID        string
+	IssueID   string
+	CreatedAt time.Time
+	Bytes     int64
+}
+
+// SnapshotUsageInTx returns row, issue and payload size totals for each
+// snapshot table. A table missing from an older schema reports zero.
+//
+//nolint:gosec // G201: table, column and size expressions are fixed internal strings.
+func SnapshotUsageInTx(ctx context.Context, tx *sql.Tx) ([]*types.SnapshotTableUsage, error) {
+	var usage []*types.SnapshotTableUsage
+	for _, t := range snapshotTables {
+		u := &types.SnapshotTableUsage{Table: t.name}
+		err := tx.QueryRowContext(ctx, fmt.Sprintf(
+			"SELECT COUNT(*), COUNT(DISTINCT issue_id), COALESCE(SUM(%s), 0) FROM %s", t.sizeExpr, t.name),
+		).Scan(&u.Rows, &u.Issues, &u.Bytes)
+		if err != nil && !isTableNotExistError(err) {
+			return nil, fmt.Errorf("measure %s: %w", t.name, err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, nil
+}
+
+// PruneSnapshotsInTx deletes snapshot rows outside retention. With dryRun
+// the rows are only counted.
+//
+//nolint:gosec // G201: table and column names are fixed internal strings.
+func PruneSnapshotsInTx(ctx context.Context, tx *sql.Tx, retention storage.SnapshotRetention, now time.Time, dryRun bool) ([]*types.SnapshotPruneResult, error) {
+	var results []*types.SnapshotPruneResult
+	for _, t := range snapshotTables {
+		rows, err := loadSnapshotRowsInTx(ctx, tx, t)
+		if err != nil {
+			return nil, err
+		}
+		prune := SelectSnapshotsToPrune(rows, retention, now)
+		result := &types.SnapshotPruneResult{Table: t.name, Pruned: len(prune), Kept: len(rows) - len(prune)}
+		ids := make([]string, 0, len(prune))
+		for _, row := range prune {
+			result.Bytes += row.Bytes
+			ids = append(ids, row.ID)
+		}
+		results = append(results, result)
+		if dryRun {
+			continue
+		}
+		for start := 0; start < len(ids); start += queryBatchSize {
+			end := min(start+queryBatchSize, len(ids))
+			placeholders, args := buildSQLInClause(ids[start:end])
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", t.name, placeholders), args...); err != nil {
+				return nil, fmt.Errorf("prune %s: %w", t.name, err)
+			}
+		}
+	}
+	return results, nil
+}
+
+//nolint:gosec // G201: table, column and size expressions are fixed internal strings.
+func loadSnapshotRowsInTx(ctx context.Context, tx *sql.Tx, t snapshotTable) ([]SnapshotRow, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(
+		"SELECT id, issue_id, %s, %s FROM %s", t.timeCol, t.sizeExpr, t.name))
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("load %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	var result []SnapshotRow
+	for rows.Next() {
+		var row SnapshotRow
+		if err := rows.Scan(&row.ID, &row.IssueID, &row.CreatedAt, &row.Bytes); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", t.name, err)
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// SelectSnapshotsToPrune returns the rows retention does not keep: those
+// outside the KeepLast newest for their issue and older than KeepDays.
+// Disabled rules (zero) keep nothing on their own; with both disabled
+// nothing is pruned.
+func SelectSnapshotsToPrune(rows []SnapshotRow, retention storage.SnapshotRetention, now time.Time) []SnapshotRow {
+	if retention.KeepLast <= 0 && retention.KeepDays <= 0 {
+		return nil
+	}
+	byIssue := make(map[string][]SnapshotRow)
+	for _, row := range rows {
+		byIssue[row.IssueID] = append(byIssue[row.IssueID], row)
+	}
+	cutoff := now.AddDate(0, 0, -retention.KeepDays)
+
+	var prune []SnapshotRow
+	for _, issueRows := range byIssue {
+		sort.SliceStable(issueRows, func(i, j int) bool {
+			return issueRows[i].CreatedAt.After(issueRows[j].CreatedAt)
+		})
+		for i, row := range issueRows {
+			if retention.KeepLast > 0 && i < retention.KeepLast {
+				continue
+			}
+			if retention.KeepDays > 0 && !row.CreatedAt.Before(cutoff) {
+				continue
+			}
+			prune = append(prune, row)
+		}
+	}
+	sort.Slice(prune, func(i, j int) bool {
+		if prune[i].IssueID != prune[j].IssueID {
+			return prune[i].IssueID < prune[j].IssueID
+		}
+		return prune[i].CreatedAt.Before(prune[j].CreatedAt)
+	})
+	return prune
+}
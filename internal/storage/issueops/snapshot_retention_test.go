@@ -0,0 +1,43 @@
+package issueops
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestSelectSnapshotsToPrune(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	rows := []SnapshotRow{
+		{ID: "a1", IssueID: "a", CreatedAt: now.Add(-100 * day)},
+		{ID: "a2", IssueID: "a", CreatedAt: now.Add(-50 * day)},
+		{ID: "a3", IssueID: "a", CreatedAt: now.Add(-5 * day)},
+		{ID: "b1", IssueID: "b", CreatedAt: now.Add(-200 * day)},
+	}
+
+	tests := []struct {
+		name      string
+		retention storage.SnapshotRetention
+		want      []string
+	}{
+		{"disabled", storage.SnapshotRetention{}, nil},
+		{"keep last", storage.SnapshotRetention{KeepLast: 1}, []string{"a1", "a2"}},
+		{"keep days", storage.SnapshotRetention{KeepDays: 30}, []string{"a1", "a2", "b1"}},
+		{"either rule keeps", storage.SnapshotRetention{KeepLast: 1, KeepDays: 60}, []string{"a1"}},
+		{"keep more than exist", storage.SnapshotRetention{KeepLast: 5}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, row := range SelectSnapshotsToPrune(rows, tt.retention, now) {
+				got = append(got, row.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pruned = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package issueops
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -34,3 +35,74 @@ func ScanIssueCountsInTx(ctx context.Context, tx *sql.Tx, stats *types.Statistic
 	}
 	return nil
 }
+
+// StatisticsCacheKey is the local_metadata key holding the materialized
+// statistics. Deleting it (setting it to "") forces the next GetStatistics
+// to recompute.
+const StatisticsCacheKey = "stats_cache"
+
+// statisticsCacheEntry is the JSON stored under StatisticsCacheKey. The
+// entry is valid only while the issues table still hashes to IssuesHash.
+type statisticsCacheEntry struct {
+	IssuesHash string            `json:"issues_hash"`
+	Stats      *types.Statistics `json:"stats"`
+}
+
+// ComputeStatisticsInTx computes Statistics from the issues table: the
+// status counts plus blocked/ready counts from the is_blocked column.
+func ComputeStatisticsInTx(ctx context.Context, tx *sql.Tx) (*types.Statistics, error) {
+	stats := &types.Statistics{}
+	if err := ScanIssueCountsInTx(ctx, tx, stats); err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM issues
+		WHERE is_blocked = 1 AND status <> 'closed' AND status <> 'pinned'
+	`).Scan(&stats.BlockedIssues); err != nil {
+		return nil, fmt.Errorf("count blocked issues: %w", err)
+	}
+	stats.ReadyIssues = max(stats.OpenIssues-stats.BlockedIssues, 0)
+	return stats, nil
+}
+
+// IssuesTableHashInTx returns the Dolt content hash of the issues table in
+// the working set. Any write to issues changes it, so it keys the statistics
+// cache. Returns "" when the engine cannot hash tables; callers then skip
+// the cache.
+func IssuesTableHashInTx(ctx context.Context, tx *sql.Tx) string {
+	var hash string
+	if err := tx.QueryRowContext(ctx, "SELECT DOLT_HASHOF_TABLE('issues')").Scan(&hash); err != nil {
+		return ""
+	}
+	return hash
+}
+
+// CachedStatisticsInTx returns the materialized statistics if they were
+// computed for issuesHash, or nil on a miss. A missing local_metadata table
+// or a corrupt entry is a miss, never an error.
+func CachedStatisticsInTx(ctx context.Context, tx *sql.Tx, issuesHash string) *types.Statistics {
+	if issuesHash == "" {
+		return nil
+	}
+	raw, err := GetLocalMetadataInTx(ctx, tx, StatisticsCacheKey)
+	if err != nil || raw == "" {
+		return nil
+	}
+	var entry statisticsCacheEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil || entry.Stats == nil {
+		return nil
+	}
+	if entry.IssuesHash != issuesHash {
+		return nil
+	}
+	return entry.Stats
+}
+
+// StatisticsCacheValue encodes stats for storage under StatisticsCacheKey.
+func StatisticsCacheValue(issuesHash string, stats *types.Statistics) (string, error) {
+	data, err := json.Marshal(statisticsCacheEntry{IssuesHash: issuesHash, Stats: stats})
+	if err != nil {
+		return "", fmt.Errorf("encode statistics cache: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,96 @@
+package issueops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCachedStatisticsInTx(t *testing.T) {
+	t.Parallel()
+
+	cached, err := StatisticsCacheValue("hash-1", &types.Statistics{TotalIssues: 7, ReadyIssues: 3})
+	if err != nil {
+		t.Fatalf("StatisticsCacheValue: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		hash  string
+		value string
+		want  int // TotalIssues, or -1 for a miss
+	}{
+		{"hit", "hash-1", cached, 7},
+		{"issues changed", "hash-2", cached, -1},
+		{"invalidated", "hash-1", "", -1},
+		{"corrupt entry", "hash-1", "{not json", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, mock, tx := beginMockTx(t)
+			mock.ExpectQuery("SELECT value FROM local_metadata").
+				WithArgs(StatisticsCacheKey).
+				WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(tt.value))
+
+			got := CachedStatisticsInTx(context.Background(), tx, tt.hash)
+			switch {
+			case tt.want < 0 && got != nil:
+				t.Fatalf("expected miss, got %+v", got)
+			case tt.want >= 0 && (got == nil || got.TotalIssues != tt.want):
+				t.Fatalf("expected hit with %d issues, got %+v", tt.want, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet SQL expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCachedStatisticsInTxWithoutHashSkipsLookup(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	if got := CachedStatisticsInTx(context.Background(), tx, ""); got != nil {
+		t.Fatalf("expected miss, got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unexpected SQL: %v", err)
+	}
+}
+
+func TestIssuesTableHashInTxUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`SELECT DOLT_HASHOF_TABLE\('issues'\)`).
+		WillReturnError(errors.New("function not found"))
+	if got := IssuesTableHashInTx(context.Background(), tx); got != "" {
+		t.Fatalf("IssuesTableHashInTx = %q, want empty on error", got)
+	}
+}
+
+func TestComputeStatisticsInTx(t *testing.T) {
+	t.Parallel()
+
+	_, mock, tx := beginMockTx(t)
+	mock.ExpectQuery(`(?s)SELECT\s+COUNT\(\*\) AS total.*FROM issues`).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "open", "in_progress", "closed", "deferred", "pinned"}).
+			AddRow(10, 4, 2, 3, 1, 0))
+	mock.ExpectQuery(`(?s)SELECT COUNT\(\*\) FROM issues\s+WHERE is_blocked = 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	stats, err := ComputeStatisticsInTx(context.Background(), tx)
+	if err != nil {
+		t.Fatalf("ComputeStatisticsInTx: %v", err)
+	}
+	if stats.TotalIssues != 10 || stats.BlockedIssues != 1 || stats.ReadyIssues != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet SQL expectations: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// awaitingSuggestionsInTx returns the IDs among ids that have a "suggests"
+// dependency on an issue that is not closed yet.
+func awaitingSuggestionsInTx(ctx context.Context, tx *sql.Tx, ids []string) (map[string]bool, error) {
+	waiting := make(map[string]bool)
+	for start := 0; start < len(ids); start += queryBatchSize {
+		end := min(start+queryBatchSize, len(ids))
+		placeholders, args := buildSQLInClause(ids[start:end])
+
+		//nolint:gosec // G201: placeholders contain only ? markers.
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT DISTINCT d.issue_id
+			FROM dependencies d
+			JOIN issues t ON t.id = d.depends_on_issue_id
+			WHERE d.type = 'suggests' AND t.status <> 'closed' AND d.issue_id IN (%s)
+		`, placeholders), args...)
+		if err != nil {
+			return nil, fmt.Errorf("get suggested ordering: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("get suggested ordering: scan: %w", err)
+			}
+			waiting[id] = true
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("get suggested ordering: rows: %w", err)
+		}
+	}
+	return waiting, nil
+}
+
+// applySuggestsTiebreakInTx reorders ready issues, already sorted by policy,
+// so that within each run the policy considers tied, issues still waiting on
+// a suggested predecessor come after those that are not.
+func applySuggestsTiebreakInTx(ctx context.Context, tx *sql.Tx, issues []*types.Issue, policy types.SortPolicy) error {
+	if len(issues) < 2 || policy == types.SortPolicyOldest {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	waiting, err := awaitingSuggestionsInTx(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	suggestsTiebreak(issues, policy, waiting)
+	return nil
+}
+
+// suggestsTiebreak applies the suggests tiebreak given the set of issues
+// waiting on an open suggested predecessor. Ties are runs of equal priority;
+// under the hybrid policy only recent issues tie, since older ones are
+// ordered by age.
+func suggestsTiebreak(issues []*types.Issue, policy types.SortPolicy, waiting map[string]bool) {
+	if len(waiting) == 0 || policy == types.SortPolicyOldest {
+		return
+	}
+	recentCutoff := time.Now().UTC().Add(-48 * time.Hour)
+	tied := func(a, b *types.Issue) bool {
+		if a.Priority != b.Priority {
+			return false
+		}
+		if policy == types.SortPolicyHybrid || policy == "" {
+			return !a.CreatedAt.Before(recentCutoff) && !b.CreatedAt.Before(recentCutoff)
+		}
+		return true
+	}
+	start := 0
+	for i := 1; i <= len(issues); i++ {
+		if i < len(issues) && tied(issues[start], issues[i]) {
+			continue
+		}
+		run := issues[start:i]
+		sort.SliceStable(run, func(a, b int) bool {
+			return !waiting[run[a].ID] && waiting[run[b].ID]
+		})
+		start = i
+	}
+}
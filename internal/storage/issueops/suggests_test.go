@@ -0,0 +1,75 @@
+package issueops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSuggestsTiebreak(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-72 * time.Hour)
+	ids := func(issues []*types.Issue) []string {
+		out := make([]string, len(issues))
+		for i, issue := range issues {
+			out[i] = issue.ID
+		}
+		return out
+	}
+	tests := []struct {
+		name   string
+		policy types.SortPolicy
+		issues []*types.Issue
+		want   []string
+	}{
+		{
+			name:   "waiting issue yields within its priority",
+			policy: types.SortPolicyPriority,
+			issues: []*types.Issue{
+				{ID: "a", Priority: 1, CreatedAt: now},
+				{ID: "b", Priority: 1, CreatedAt: now},
+				{ID: "c", Priority: 2, CreatedAt: now},
+			},
+			want: []string{"b", "a", "c"},
+		},
+		{
+			name:   "priority still wins",
+			policy: types.SortPolicyPriority,
+			issues: []*types.Issue{
+				{ID: "a", Priority: 0, CreatedAt: now},
+				{ID: "b", Priority: 1, CreatedAt: now},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:   "hybrid leaves age order alone",
+			policy: types.SortPolicyHybrid,
+			issues: []*types.Issue{
+				{ID: "a", Priority: 1, CreatedAt: old},
+				{ID: "b", Priority: 1, CreatedAt: old},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:   "oldest ignores suggestions",
+			policy: types.SortPolicyOldest,
+			issues: []*types.Issue{
+				{ID: "a", Priority: 1, CreatedAt: now},
+				{ID: "b", Priority: 1, CreatedAt: now},
+			},
+			want: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestsTiebreak(tt.issues, tt.policy, map[string]bool{"a": true})
+			got := ids(tt.issues)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+const apiTokenColumns = `id, name, role, secret_hash, created_by, created_at, expires_at, revoked_at`
+
+// scanAPIToken scans one api_tokens row.
+func scanAPIToken(scan func(dest ...any) error) (*storage.APIToken, error) {
+	var tok storage.APIToken
+	var expiresAt, revokedAt sql.NullTime
+	if err := scan(&tok.ID, &tok.Name, &tok.Role, &tok.SecretHash, &tok.CreatedBy,
+		&tok.CreatedAt, &expiresAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		tok.ExpiresAt = &t
+	}
+	if revokedAt.Valid {
+		t := revokedAt.Time
+		tok.RevokedAt = &t
+	}
+	return &tok, nil
+}
+
+// CreateAPITokenInTx inserts a token and sets its CreatedAt.
+func CreateAPITokenInTx(ctx context.Context, tx *sql.Tx, tok *storage.APIToken) error {
+	if tok.ID == "" || tok.Name == "" || tok.SecretHash == "" {
+		return fmt.Errorf("create api token: id, name, and secret hash are required")
+	}
+	tok.CreatedAt = time.Now().UTC()
+	var expiresAt any
+	if tok.ExpiresAt != nil {
+		expiresAt = tok.ExpiresAt.UTC()
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO api_tokens (id, name, role, secret_hash, created_by, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, tok.ID, tok.Name, tok.Role, tok.SecretHash, tok.CreatedBy, tok.CreatedAt, expiresAt); err != nil {
+		return fmt.Errorf("create api token: %w", err)
+	}
+	return nil
+}
+
+// GetAPITokenInTx returns the token with the given ID or storage.ErrNotFound.
+// Databases that predate ignored migration 0012 have no api_tokens table and
+// report every token as not found.
+func GetAPITokenInTx(ctx context.Context, tx *sql.Tx, id string) (*storage.APIToken, error) {
+	tok, err := scanAPIToken(tx.QueryRowContext(ctx,
+		`SELECT `+apiTokenColumns+` FROM api_tokens WHERE id = ?`, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) || isTableNotExistError(err) {
+		return nil, fmt.Errorf("%w: api token %s", storage.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api token %s: %w", id, err)
+	}
+	return tok, nil
+}
+
+// ListAPITokensInTx returns every token ordered by creation time.
+func ListAPITokensInTx(ctx context.Context, tx *sql.Tx) ([]*storage.APIToken, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT `+apiTokenColumns+` FROM api_tokens ORDER BY created_at, id`)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*storage.APIToken
+	for rows.Next() {
+		tok, err := scanAPIToken(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scan api token: %w", err)
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, rows.Err()
+}
+
+// RevokeAPITokenInTx sets revoked_at on an unrevoked token.
+func RevokeAPITokenInTx(ctx context.Context, tx *sql.Tx, id string) error {
+	res, err := tx.ExecContext(ctx,
+		`UPDATE api_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("revoke api token %s: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: active api token %s", storage.ErrNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GetUnblockImpactInTx returns, for every open issue that blocks other open
+// work, how much work sits downstream of it: the issues it blocks directly,
+// those it blocks transitively through further blocking edges, and their
+// summed estimates. One recursive CTE builds the blocker/blocked closure over
+// blocks, conditional-blocks, and waits-for edges between open issues and
+// aggregates per blocker. Issues that block nothing are absent from the map.
+func GetUnblockImpactInTx(ctx context.Context, tx *sql.Tx) (map[string]*types.UnblockImpact, error) {
+	edges := fmt.Sprintf("SELECT issue_id, %s AS blocker_id FROM dependencies d WHERE d.type IN ('blocks', 'conditional-blocks', 'waits-for')", depTargetExpr("d"))
+	nodes := "SELECT id, status, estimated_minutes FROM issues"
+	if empty, err := wispsTableEmptyOrMissingInTx(ctx, tx); err != nil {
+		return nil, fmt.Errorf("get unblock impact: probe: %w", err)
+	} else if !empty {
+		edges += fmt.Sprintf(" UNION ALL SELECT issue_id, %s AS blocker_id FROM wisp_dependencies d WHERE d.type IN ('blocks', 'conditional-blocks', 'waits-for')", depTargetExpr("d"))
+		nodes += " UNION ALL SELECT id, status, estimated_minutes FROM wisps"
+	}
+
+	// UNION (not UNION ALL) in the recursive member deduplicates rows, so a
+	// dependency cycle terminates instead of recursing forever. The direct
+	// flag is 1 only for seed rows, so each pair appears at most twice.
+	//nolint:gosec // G201: edges and nodes are built from hardcoded table names.
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE
+		nodes AS (%s),
+		open_edges AS (
+			SELECT e.issue_id, e.blocker_id
+			FROM (%s) e
+			JOIN nodes blocked ON blocked.id = e.issue_id
+			JOIN nodes blocker ON blocker.id = e.blocker_id
+			WHERE blocked.status <> 'closed' AND blocker.status <> 'closed'
+		),
+		closure(blocker_id, id, direct) AS (
+			SELECT blocker_id, issue_id, 1 FROM open_edges
+			UNION
+			SELECT c.blocker_id, e.issue_id, 0 FROM closure c JOIN open_edges e ON e.blocker_id = c.id
+		),
+		pairs AS (
+			SELECT blocker_id, id, MAX(direct) AS direct
+			FROM closure
+			WHERE id <> blocker_id
+			GROUP BY blocker_id, id
+		)
+		SELECT p.blocker_id, COUNT(*), SUM(p.direct), COALESCE(SUM(n.estimated_minutes), 0)
+		FROM pairs p
+		JOIN nodes n ON n.id = p.id
+		GROUP BY p.blocker_id
+	`, nodes, edges))
+	if err != nil {
+		return nil, fmt.Errorf("get unblock impact: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*types.UnblockImpact)
+	for rows.Next() {
+		var id string
+		r := &types.UnblockImpact{}
+		if err := rows.Scan(&id, &r.Unblocks, &r.Direct, &r.EstimatedMinutes); err != nil {
+			return nil, fmt.Errorf("get unblock impact: scan: %w", err)
+		}
+		result[id] = r
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get unblock impact: rows: %w", err)
+	}
+	return result, nil
+}
@@ -118,6 +118,9 @@ func DetermineEventType(oldIssue *types.Issue, updates map[string]interface{}) t
 type UpdateResult struct {
 	OldIssue *types.Issue
 	IsWisp   bool
+	// ReferencesChanged reports that a new description rewrote the
+	// issue_references rows parsed from it.
+	ReferencesChanged bool
 }
 
 // UpdateIssueInTx performs the full update SQL logic within a transaction.
@@ -147,6 +150,12 @@ func updateIssueInTx(ctx context.Context, tx *sql.Tx, id string, updates map[str
 		return nil, fmt.Errorf("failed to get issue for update: %w", err)
 	}
 
+	if !isWisp {
+		if err := CheckIssueLockInTx(ctx, tx, id, actor); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate issue_type against built-in + custom types (GH#3030).
 	// This mirrors the create path (PrepareIssueForInsert → ValidateWithCustom)
 	// and reads custom types from the same transaction, so it works reliably
@@ -163,6 +172,23 @@ func updateIssueInTx(ctx context.Context, tx *sql.Tx, id string, updates map[str
 		}
 	}
 
+	// Enforce status policies (status.policy.<type>.<status>) against the
+	// issue as it will look after this update.
+	if rawStatus, ok := updates["status"]; ok {
+		var newStatus types.Status
+		switch v := rawStatus.(type) {
+		case string:
+			newStatus = types.Status(v)
+		case types.Status:
+			newStatus = v
+		}
+		if newStatus != "" && newStatus != oldIssue.Status {
+			if err := CheckStatusPolicyInTx(ctx, tx, issueWithUpdates(oldIssue, updates), newStatus); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Build SET clauses.
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now().UTC()}
@@ -224,6 +250,13 @@ func updateIssueInTx(ctx context.Context, tx *sql.Tx, id string, updates map[str
 		return nil, fmt.Errorf("failed to update issue: %w", err)
 	}
 
+	result := &UpdateResult{OldIssue: oldIssue, IsWisp: isWisp}
+	if description, ok := updates["description"].(string); ok && !isWisp {
+		if result.ReferencesChanged, err = SyncIssueReferencesInTx(ctx, tx, id, types.ReferenceSourceDescription, "", description); err != nil {
+			return nil, err
+		}
+	}
+
 	if recordEvent {
 		oldData, _ := json.Marshal(oldIssue)
 		newData, _ := json.Marshal(updates)
@@ -261,7 +294,7 @@ func updateIssueInTx(ctx context.Context, tx *sql.Tx, id string, updates map[str
 		}
 	}
 
-	return &UpdateResult{OldIssue: oldIssue, IsWisp: isWisp}, nil
+	return result, nil
 }
 
 // RecordFullEventInTable records an event with both old and new values.
@@ -0,0 +1,78 @@
+package issueops
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// EncodeVector packs v as little-endian float32 values for the
+// issue_vectors.embedding column.
+func EncodeVector(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf
+}
+
+// DecodeVector unpacks a vector written by EncodeVector.
+func DecodeVector(buf []byte) ([]float32, error) {
+	if len(buf)%4 != 0 {
+		return nil, fmt.Errorf("vector blob length %d is not a multiple of 4", len(buf))
+	}
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return v, nil
+}
+
+// GetIssueVectorsInTx returns the cached vectors for provider and model.
+// Databases that predate ignored migration 0011 have no issue_vectors table
+// and return no vectors.
+func GetIssueVectorsInTx(ctx context.Context, tx *sql.Tx, provider, model string) ([]*storage.IssueVector, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT issue_id, content_hash, embedding FROM issue_vectors
+		WHERE provider = ? AND model = ?
+		ORDER BY issue_id
+	`, provider, model)
+	if err != nil {
+		if isTableNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get issue vectors: %w", err)
+	}
+	defer rows.Close()
+
+	var vectors []*storage.IssueVector
+	for rows.Next() {
+		v := &storage.IssueVector{Provider: provider, Model: model}
+		var blob []byte
+		if err := rows.Scan(&v.IssueID, &v.ContentHash, &blob); err != nil {
+			return nil, fmt.Errorf("get issue vectors: scan: %w", err)
+		}
+		if v.Vector, err = DecodeVector(blob); err != nil {
+			return nil, fmt.Errorf("get issue vectors: %s: %w", v.IssueID, err)
+		}
+		vectors = append(vectors, v)
+	}
+	return vectors, rows.Err()
+}
+
+// UpsertIssueVectorsInTx inserts or replaces cached vectors.
+func UpsertIssueVectorsInTx(ctx context.Context, tx *sql.Tx, vectors []*storage.IssueVector) error {
+	for _, v := range vectors {
+		if _, err := tx.ExecContext(ctx, `
+			REPLACE INTO issue_vectors (issue_id, provider, model, content_hash, dims, embedding)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, v.IssueID, v.Provider, v.Model, v.ContentHash, len(v.Vector), EncodeVector(v.Vector)); err != nil {
+			return fmt.Errorf("store vector for %s: %w", v.IssueID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrIssueLocked is returned when an issue is locked by another actor. The
+// wrapping error names the holder.
+var ErrIssueLocked = errors.New("issue is locked")
+
+type lockOverrideKey struct{}
+
+// WithLockOverride marks ctx as allowed to modify issues locked by another
+// actor, as 'bd update --force' and 'bd close --force' do.
+func WithLockOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lockOverrideKey{}, true)
+}
+
+// LockOverridden reports whether ctx was marked with WithLockOverride.
+func LockOverridden(ctx context.Context) bool {
+	overridden, _ := ctx.Value(lockOverrideKey{}).(bool)
+	return overridden
+}
+
+// LockStore manages issue locks (bd lock/unlock). Locks are enforced inside
+// the store's update, close, and priority aging transactions: a write by any
+// actor other than the holder fails with ErrIssueLocked unless its context
+// carries WithLockOverride. Callers should type-assert to this interface.
+type LockStore interface {
+	// LockIssue takes or refreshes lock.IssueID for lock.Holder. A live lock
+	// held by someone else returns ErrIssueLocked unless force is set, in
+	// which case the lock is taken over. Expired locks are replaced.
+	LockIssue(ctx context.Context, lock *types.IssueLock, force bool) error
+	// UnlockIssue releases issueID. A lock held by someone other than actor
+	// returns ErrIssueLocked unless force is set; a missing lock returns
+	// ErrNotFound.
+	UnlockIssue(ctx context.Context, issueID, actor string, force bool) error
+	// GetIssueLock returns the live lock on issueID, or nil if it is
+	// unlocked or the lock has expired.
+	GetIssueLock(ctx context.Context, issueID string) (*types.IssueLock, error)
+	// ListIssueLocks returns all live locks ordered by issue ID.
+	ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error)
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MoveIssuesOptions controls IssueMoveStore.MoveIssues.
+type MoveIssuesOptions struct {
+	// Renumber gives each moved issue whose ID is a hierarchical child of its
+	// old parent (bd-a3f8.2 under bd-a3f8) the new parent's next child ID.
+	// Its descendants keep their place under the new ID.
+	Renumber bool
+	// MaxDepth bounds the nesting of renumbered IDs (hierarchy.max-depth).
+	MaxDepth int
+}
+
+// IssueMoveStore moves issues between parents. Callers should type-assert
+// to this interface.
+type IssueMoveStore interface {
+	// MoveIssues replaces the parent-child dependency of each issue in ids
+	// with one on newParentID in a single transaction, recording a 'moved'
+	// event per issue. It fails without changing anything if the new parent
+	// lies inside a moved subtree or would be blocked by one.
+	MoveIssues(ctx context.Context, ids []string, newParentID string, opts MoveIssuesOptions, actor string) (*types.MoveResult, error)
+}
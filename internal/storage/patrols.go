@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PatrolStore holds the patrol schedules run by bd patrol run and the state
+// of each patrol's current cycle. Callers should type-assert to this
+// interface.
+type PatrolStore interface {
+	// AddPatrol stores patrol. It fails if a patrol with the same name
+	// exists. patrol.CreatedAt is set when zero.
+	AddPatrol(ctx context.Context, patrol *types.Patrol) error
+	// RemovePatrol deletes the named patrol, returning ErrNotFound if there
+	// is none.
+	RemovePatrol(ctx context.Context, name string) error
+	// GetPatrols returns every patrol ordered by name.
+	GetPatrols(ctx context.Context) ([]*types.Patrol, error)
+	// RecordPatrolRun starts a new cycle: it sets the last run time and
+	// wisp, clears the completion time, and counts a miss when missed is
+	// true.
+	RecordPatrolRun(ctx context.Context, name string, ranAt time.Time, wispID string, missed bool) error
+	// RecordPatrolCompletion marks the current cycle complete.
+	RecordPatrolCompletion(ctx context.Context, name string, completedAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package storage
+
+import "context"
+
+// PriorityAger raises the priority of issues left open too long (bd age).
+// Callers should type-assert to this interface.
+type PriorityAger interface {
+	// AgeIssuePriority moves issueID from priority from to priority to and
+	// records a priority_aged event carrying reason. It fails if the issue
+	// is no longer open at priority from, so a concurrent edit wins.
+	// updated_at is left alone: aging is bookkeeping, not activity.
+	AgeIssuePriority(ctx context.Context, issueID string, from, to int, reason, actor string) error
+}
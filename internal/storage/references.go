@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ReferenceStore reads the issue-ID references and @actor mentions recorded
+// from issue descriptions and comments when they are written. Callers should
+// type-assert to this interface.
+type ReferenceStore interface {
+	// GetBacklinks returns the references to issueID from other issues.
+	GetBacklinks(ctx context.Context, issueID string) ([]*types.IssueReference, error)
+	// GetMentions returns the @actor mentions of actor.
+	GetMentions(ctx context.Context, actor string) ([]*types.IssueReference, error)
+}
@@ -0,0 +1,431 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExpectedColumn is a column the binary's queries rely on. Definition is the
+// column DDL used to re-add it; every definition is nullable or defaulted so
+// adding it to a populated table never fails or rewrites existing values.
+type ExpectedColumn struct {
+	Name       string
+	Definition string
+}
+
+// ExpectedIndex is a secondary index the binary expects, by name.
+type ExpectedIndex struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// ExpectedTable is the expected shape of one core table at LatestVersion.
+type ExpectedTable struct {
+	Name        string
+	Columns     []ExpectedColumn
+	Indexes     []ExpectedIndex
+	ForeignKeys []string // constraint names
+}
+
+// issueColumns are shared by issues and wisps (wisps mirror the issues
+// schema so promotion is a row copy).
+var issueColumns = []ExpectedColumn{
+	{"id", "varchar(255) NOT NULL"},
+	{"content_hash", "varchar(64)"},
+	{"title", "varchar(500) NOT NULL DEFAULT ''"},
+	{"description", "longtext NOT NULL DEFAULT ''"},
+	{"design", "longtext NOT NULL DEFAULT ''"},
+	{"acceptance_criteria", "longtext NOT NULL DEFAULT ''"},
+	{"notes", "longtext NOT NULL DEFAULT ''"},
+	{"status", "varchar(32) NOT NULL DEFAULT 'open'"},
+	{"priority", "int NOT NULL DEFAULT '2'"},
+	{"issue_type", "varchar(32) NOT NULL DEFAULT 'task'"},
+	{"assignee", "varchar(255)"},
+	{"estimated_minutes", "int"},
+	{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+	{"created_by", "varchar(255) DEFAULT ''"},
+	{"owner", "varchar(255) DEFAULT ''"},
+	{"updated_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"},
+	{"closed_at", "datetime"},
+	{"closed_by_session", "varchar(255) DEFAULT ''"},
+	{"external_ref", "varchar(255)"},
+	{"spec_id", "varchar(1024)"},
+	{"compaction_level", "int DEFAULT '0'"},
+	{"compacted_at", "datetime"},
+	{"compacted_at_commit", "varchar(64)"},
+	{"original_size", "int"},
+	{"sender", "varchar(255) DEFAULT ''"},
+	{"ephemeral", "tinyint(1) DEFAULT '0'"},
+	{"wisp_type", "varchar(32) DEFAULT ''"},
+	{"pinned", "tinyint(1) DEFAULT '0'"},
+	{"is_template", "tinyint(1) DEFAULT '0'"},
+	{"mol_type", "varchar(32) DEFAULT ''"},
+	{"work_type", "varchar(32) DEFAULT 'mutex'"},
+	{"source_system", "varchar(255) DEFAULT ''"},
+	{"metadata", "json DEFAULT (json_object())"},
+	{"source_repo", "varchar(512) DEFAULT ''"},
+	{"close_reason", "longtext DEFAULT ''"},
+	{"event_kind", "varchar(32) DEFAULT ''"},
+	{"actor", "varchar(255) DEFAULT ''"},
+	{"target", "varchar(255) DEFAULT ''"},
+	{"payload", "text DEFAULT ''"},
+	{"await_type", "varchar(32) DEFAULT ''"},
+	{"await_id", "varchar(255) DEFAULT ''"},
+	{"timeout_ns", "bigint DEFAULT '0'"},
+	{"waiters", "text DEFAULT ''"},
+	{"hook_bead", "varchar(255) DEFAULT ''"},
+	{"role_bead", "varchar(255) DEFAULT ''"},
+	{"agent_state", "varchar(32) DEFAULT ''"},
+	{"last_activity", "datetime"},
+	{"role_type", "varchar(32) DEFAULT ''"},
+	{"rig", "varchar(255) DEFAULT ''"},
+	{"due_at", "datetime"},
+	{"defer_until", "datetime"},
+	{"no_history", "tinyint(1) DEFAULT '0'"},
+	{"started_at", "datetime"},
+	{"is_blocked", "tinyint(1) NOT NULL DEFAULT '0'"},
+}
+
+// issueIndexes returns the secondary indexes of issues or wisps; the two
+// tables use the same columns under a table-specific name prefix.
+func issueIndexes(prefix string) []ExpectedIndex {
+	return []ExpectedIndex{
+		{Name: prefix + "_assignee", Columns: []string{"assignee"}},
+		{Name: prefix + "_created_at", Columns: []string{"created_at"}},
+		{Name: prefix + "_external_ref", Columns: []string{"external_ref"}},
+		{Name: prefix + "_is_blocked", Columns: []string{"is_blocked", "status"}},
+		{Name: prefix + "_issue_type", Columns: []string{"issue_type"}},
+		{Name: prefix + "_priority", Columns: []string{"priority"}},
+		{Name: prefix + "_spec_id", Columns: []string{"spec_id"}},
+		{Name: prefix + "_status", Columns: []string{"status"}},
+	}
+}
+
+// ExpectedTables is the shape of the core tables after every migration up to
+// LatestVersion has run. TestExpectedTablesMatchMigrations keeps it honest:
+// a new migration that adds a column or index must add it here too.
+var ExpectedTables = []ExpectedTable{
+	{
+		Name:    "issues",
+		Columns: issueColumns,
+		Indexes: issueIndexes("idx_issues"),
+	},
+	{
+		Name: "dependencies",
+		Columns: []ExpectedColumn{
+			{"id", "char(36) NOT NULL"},
+			{"issue_id", "varchar(255) NOT NULL"},
+			{"type", "varchar(32) NOT NULL DEFAULT 'blocks'"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+			{"created_by", "varchar(255) NOT NULL DEFAULT ''"},
+			{"metadata", "json DEFAULT (json_object())"},
+			{"thread_id", "varchar(255) DEFAULT ''"},
+			{"depends_on_issue_id", "varchar(255)"},
+			{"depends_on_wisp_id", "varchar(255)"},
+			{"depends_on_external", "varchar(255)"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_dep_external_target", Columns: []string{"depends_on_external"}},
+			{Name: "idx_dep_issue_target", Columns: []string{"depends_on_issue_id"}},
+			{Name: "idx_dep_type_external", Columns: []string{"type", "depends_on_external"}},
+			{Name: "idx_dep_type_issue", Columns: []string{"type", "depends_on_issue_id"}},
+			{Name: "idx_dep_type_wisp", Columns: []string{"type", "depends_on_wisp_id"}},
+			{Name: "idx_dep_wisp_target", Columns: []string{"depends_on_wisp_id"}},
+			{Name: "idx_dependencies_issue", Columns: []string{"issue_id"}},
+			{Name: "idx_dependencies_thread", Columns: []string{"thread_id"}},
+			{Name: "uk_dep_external_target", Columns: []string{"issue_id", "depends_on_external"}, Unique: true},
+			{Name: "uk_dep_issue_target", Columns: []string{"issue_id", "depends_on_issue_id"}, Unique: true},
+			{Name: "uk_dep_wisp_target", Columns: []string{"issue_id", "depends_on_wisp_id"}, Unique: true},
+		},
+		ForeignKeys: []string{"fk_dep_issue", "fk_dep_issue_target"},
+	},
+	{
+		Name:    "wisps",
+		Columns: issueColumns,
+		Indexes: issueIndexes("idx_wisps"),
+	},
+	{
+		Name: "issue_references",
+		Columns: []ExpectedColumn{
+			{"source_id", "varchar(255) NOT NULL"},
+			{"source_kind", "varchar(16) NOT NULL"},
+			{"source_ref", "varchar(255) NOT NULL DEFAULT ''"},
+			{"ref_type", "varchar(16) NOT NULL"},
+			{"target", "varchar(255) NOT NULL"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_issue_references_target", Columns: []string{"ref_type", "target"}},
+		},
+		ForeignKeys: []string{"fk_issue_references_source"},
+	},
+	{
+		Name: "locks",
+		Columns: []ExpectedColumn{
+			{"issue_id", "varchar(255) NOT NULL"},
+			{"holder", "varchar(255) NOT NULL"},
+			{"reason", "text"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+			{"expires_at", "datetime"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_locks_holder", Columns: []string{"holder"}},
+		},
+		ForeignKeys: []string{"fk_locks_issue"},
+	},
+	{
+		Name: "commit_links",
+		Columns: []ExpectedColumn{
+			{"issue_id", "varchar(255) NOT NULL"},
+			{"commit_sha", "varchar(64) NOT NULL"},
+			{"action", "varchar(16) NOT NULL DEFAULT 'closes'"},
+			{"summary", "text"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_commit_links_sha", Columns: []string{"commit_sha"}},
+		},
+		ForeignKeys: []string{"fk_commit_links_issue"},
+	},
+	{
+		Name: "ci_runs",
+		Columns: []ExpectedColumn{
+			{"issue_id", "varchar(255) NOT NULL"},
+			{"name", "varchar(255) NOT NULL"},
+			{"commit_sha", "varchar(64) NOT NULL"},
+			{"branch", "varchar(255) NOT NULL DEFAULT ''"},
+			{"status", "varchar(16) NOT NULL"},
+			{"url", "text"},
+			{"reported_by", "varchar(255) DEFAULT ''"},
+			{"updated_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_ci_runs_commit", Columns: []string{"commit_sha"}},
+		},
+		ForeignKeys: []string{"fk_ci_runs_issue"},
+	},
+	{
+		Name: "assignment_rules",
+		Columns: []ExpectedColumn{
+			{"name", "varchar(255) NOT NULL"},
+			{"priority", "int NOT NULL DEFAULT '0'"},
+			{"label", "varchar(255) NOT NULL DEFAULT ''"},
+			{"path_prefix", "varchar(1024) NOT NULL DEFAULT ''"},
+			{"issue_type", "varchar(32) NOT NULL DEFAULT ''"},
+			{"strategy", "varchar(16) NOT NULL"},
+			{"assignees", "text NOT NULL"},
+			{"next_index", "int NOT NULL DEFAULT '0'"},
+			{"created_by", "varchar(255) DEFAULT ''"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		},
+	},
+	{
+		Name: "patrols",
+		Columns: []ExpectedColumn{
+			{"name", "varchar(255) NOT NULL"},
+			{"formula", "varchar(255) NOT NULL"},
+			{"agent", "varchar(255) NOT NULL DEFAULT ''"},
+			{"schedule", "varchar(255) NOT NULL"},
+			{"last_run_at", "datetime"},
+			{"last_wisp_id", "varchar(255) NOT NULL DEFAULT ''"},
+			{"last_completed_at", "datetime"},
+			{"missed_count", "int NOT NULL DEFAULT '0'"},
+			{"created_by", "varchar(255) DEFAULT ''"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		},
+	},
+	{
+		Name: "issue_aliases",
+		Columns: []ExpectedColumn{
+			{"alias", "varchar(255) NOT NULL"},
+			{"issue_id", "varchar(255) NOT NULL"},
+			{"created_by", "varchar(255) DEFAULT ''"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_issue_aliases_issue", Columns: []string{"issue_id"}},
+		},
+		ForeignKeys: []string{"fk_issue_aliases_issue"},
+	},
+	{
+		Name: "federation_peers",
+		Columns: []ExpectedColumn{
+			{"name", "varchar(255) NOT NULL"},
+			{"remote_url", "varchar(1024) NOT NULL DEFAULT ''"},
+			{"username", "varchar(255)"},
+			{"password_encrypted", "blob"},
+			{"sovereignty", "varchar(8) DEFAULT ''"},
+			{"last_sync", "datetime"},
+			{"created_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP"},
+			{"updated_at", "datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"},
+			{"direction", "varchar(16) NOT NULL DEFAULT ''"},
+		},
+		Indexes: []ExpectedIndex{
+			{Name: "idx_federation_peers_sovereignty", Columns: []string{"sovereignty"}},
+		},
+	},
+	{
+		Name: "external_refs",
+		Columns: []ExpectedColumn{
+			{"url", "varchar(255) NOT NULL"},
+			{"resolved", "tinyint(1) NOT NULL DEFAULT '0'"},
+			{"resolved_by", "varchar(255) DEFAULT ''"},
+			{"resolved_at", "datetime"},
+		},
+	},
+}
+
+// SchemaDrift lists what one table is missing relative to ExpectedTables.
+type SchemaDrift struct {
+	Table              string
+	MissingTable       bool
+	MissingColumns     []ExpectedColumn
+	MissingIndexes     []ExpectedIndex
+	MissingForeignKeys []string
+}
+
+// Summary renders the drift as "table: missing column x, index y".
+func (d SchemaDrift) Summary() string {
+	if d.MissingTable {
+		return d.Table + ": table missing"
+	}
+	var parts []string
+	for _, c := range d.MissingColumns {
+		parts = append(parts, "column "+c.Name)
+	}
+	for _, ix := range d.MissingIndexes {
+		parts = append(parts, "index "+ix.Name)
+	}
+	for _, fk := range d.MissingForeignKeys {
+		parts = append(parts, "foreign key "+fk)
+	}
+	return d.Table + ": missing " + strings.Join(parts, ", ")
+}
+
+// ScanDrift compares the live schema of the current database against
+// ExpectedTables and returns one entry per table that differs. Extra columns
+// and indexes are not reported: newer binaries may have added them.
+func ScanDrift(ctx context.Context, db DBConn) ([]SchemaDrift, error) {
+	columns, err := schemaNameSets(ctx, db,
+		`SELECT TABLE_NAME, COLUMN_NAME FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = DATABASE()`)
+	if err != nil {
+		return nil, fmt.Errorf("scan columns: %w", err)
+	}
+	indexes, err := schemaNameSets(ctx, db,
+		`SELECT TABLE_NAME, INDEX_NAME FROM INFORMATION_SCHEMA.STATISTICS WHERE TABLE_SCHEMA = DATABASE()`)
+	if err != nil {
+		return nil, fmt.Errorf("scan indexes: %w", err)
+	}
+	fks, err := schemaNameSets(ctx, db,
+		`SELECT TABLE_NAME, CONSTRAINT_NAME FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS
+		 WHERE TABLE_SCHEMA = DATABASE() AND CONSTRAINT_TYPE = 'FOREIGN KEY'`)
+	if err != nil {
+		return nil, fmt.Errorf("scan foreign keys: %w", err)
+	}
+
+	var drift []SchemaDrift
+	for _, t := range ExpectedTables {
+		d := SchemaDrift{Table: t.Name}
+		have, ok := columns[t.Name]
+		if !ok {
+			d.MissingTable = true
+			drift = append(drift, d)
+			continue
+		}
+		for _, c := range t.Columns {
+			if !have[c.Name] {
+				d.MissingColumns = append(d.MissingColumns, c)
+			}
+		}
+		for _, ix := range t.Indexes {
+			if !indexes[t.Name][ix.Name] {
+				d.MissingIndexes = append(d.MissingIndexes, ix)
+			}
+		}
+		for _, fk := range t.ForeignKeys {
+			if !fks[t.Name][fk] {
+				d.MissingForeignKeys = append(d.MissingForeignKeys, fk)
+			}
+		}
+		if len(d.MissingColumns)+len(d.MissingIndexes)+len(d.MissingForeignKeys) > 0 {
+			drift = append(drift, d)
+		}
+	}
+	return drift, nil
+}
+
+// RepairDrift adds the missing columns, then the missing indexes, reported
+// by ScanDrift. It never drops or modifies anything that exists. Missing
+// tables and foreign keys are left alone: recreating a table belongs to the
+// migrations, and a foreign key can fail on existing rows. Returns the DDL
+// statements that ran, in order; on error, those before the failure stay
+// applied.
+func RepairDrift(ctx context.Context, db DBConn, drift []SchemaDrift) ([]string, error) {
+	var applied []string
+	for _, d := range drift {
+		if d.MissingTable {
+			continue
+		}
+		for _, c := range d.MissingColumns {
+			stmt := fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN `%s` %s", d.Table, c.Name, c.Definition)
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				return applied, fmt.Errorf("%s: add column %s: %w", d.Table, c.Name, err)
+			}
+			applied = append(applied, stmt)
+		}
+	}
+	for _, d := range drift {
+		if d.MissingTable {
+			continue
+		}
+		for _, ix := range d.MissingIndexes {
+			kind := "INDEX"
+			if ix.Unique {
+				kind = "UNIQUE INDEX"
+			}
+			stmt := fmt.Sprintf("CREATE %s `%s` ON `%s` (`%s`)", kind, ix.Name, d.Table, strings.Join(ix.Columns, "`, `"))
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				return applied, fmt.Errorf("%s: create index %s: %w", d.Table, ix.Name, err)
+			}
+			applied = append(applied, stmt)
+		}
+	}
+	return applied, nil
+}
+
+// RepairedTables returns the sorted names of tables RepairDrift would touch.
+func RepairedTables(drift []SchemaDrift) []string {
+	var tables []string
+	for _, d := range drift {
+		if !d.MissingTable && len(d.MissingColumns)+len(d.MissingIndexes) > 0 {
+			tables = append(tables, d.Table)
+		}
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// schemaNameSets runs a (table, name) query and groups names by table.
+// Table names are lower-cased; information_schema case varies by engine.
+func schemaNameSets(ctx context.Context, db DBConn, query string) (map[string]map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := make(map[string]map[string]bool)
+	for rows.Next() {
+		var table, name string
+		if err := rows.Scan(&table, &name); err != nil {
+			return nil, err
+		}
+		table = strings.ToLower(table)
+		if out[table] == nil {
+			out[table] = make(map[string]bool)
+		}
+		out[table][strings.ToLower(name)] = true
+	}
+	return out, rows.Err()
+}
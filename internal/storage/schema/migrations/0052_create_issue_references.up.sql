@@ -0,0 +1,19 @@
+-- Migration 0052: issue_references indexes the @actor mentions and issue-ID
+-- references parsed out of issue descriptions and comments at write time.
+-- It backs bd show --backlinks and mention lookups. ("references" is a
+-- reserved word, hence the issue_ prefix.)
+--
+-- The primary key is the full natural key, so the same reference written on
+-- two clones merges as one row. source_ref is the comment ID for comment
+-- sources and '' for the description. Wisps are not indexed: their text lives
+-- in dolt-ignored tables and the FK below only covers issues.
+CREATE TABLE IF NOT EXISTS issue_references (
+    source_id VARCHAR(255) NOT NULL,
+    source_kind VARCHAR(16) NOT NULL,
+    source_ref VARCHAR(255) NOT NULL DEFAULT '',
+    ref_type VARCHAR(16) NOT NULL,
+    target VARCHAR(255) NOT NULL,
+    PRIMARY KEY (source_id, source_kind, source_ref, ref_type, target),
+    INDEX idx_issue_references_target (ref_type, target),
+    CONSTRAINT fk_issue_references_source FOREIGN KEY (source_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
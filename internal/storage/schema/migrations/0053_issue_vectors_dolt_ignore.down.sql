@@ -0,0 +1,2 @@
+-- Reverse migration 0053: remove the dolt_ignore entry for issue_vectors.
+DELETE FROM dolt_ignore WHERE pattern IN ('issue_vectors');
@@ -0,0 +1,7 @@
+-- Migration 0053: Register issue_vectors in dolt_ignore.
+--
+-- issue_vectors caches embeddings computed by bd similar. They are derived
+-- from issue text by whichever provider a clone is configured with (a local
+-- model or a remote API), so they are clone-local and must never be merged.
+-- The table itself is created by ignored migration 0011.
+REPLACE INTO dolt_ignore VALUES ('issue_vectors', true);
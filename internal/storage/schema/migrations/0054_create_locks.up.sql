@@ -0,0 +1,16 @@
+-- Migration 0054: locks holds advisory locks on issues (bd lock/unlock).
+-- While a lock is held and unexpired, bd update/close/delete by any actor
+-- other than the holder are refused unless forced. One lock per issue;
+-- expires_at NULL means the lock holds until released. Locks are versioned
+-- like the issues they guard, so a lock taken on one clone is honored on
+-- every clone that pulls it. Wisps cannot be locked: the FK covers issues.
+CREATE TABLE IF NOT EXISTS locks (
+    issue_id VARCHAR(255) NOT NULL,
+    holder VARCHAR(255) NOT NULL,
+    reason TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME,
+    PRIMARY KEY (issue_id),
+    INDEX idx_locks_holder (holder),
+    CONSTRAINT fk_locks_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,2 @@
+-- Reverse migration 0055: remove the dolt_ignore entry for api_tokens.
+DELETE FROM dolt_ignore WHERE pattern IN ('api_tokens');
@@ -0,0 +1,8 @@
+-- Migration 0055: Register api_tokens in dolt_ignore.
+--
+-- api_tokens holds the hashed API tokens that grant roles to bd callers
+-- (bd token create). Credentials must never be pushed to a remote or merged
+-- in from one, so the table is clone-local. In server mode every client of
+-- the shared sql-server sees the same working set, so one token list serves
+-- the whole server. The table itself is created by ignored migration 0012.
+REPLACE INTO dolt_ignore VALUES ('api_tokens', true);
@@ -0,0 +1,15 @@
+-- Migration 0056: commit_links records which git commits referenced an issue
+-- with a closing keyword ("fixes bd-a1b2"), written by bd git scan and the
+-- git.close-on-commit hooks. One row per (issue, commit); a recorded link
+-- is never acted on again, so an issue reopened after an automatic close
+-- stays open on the next scan. Versioned like the issues it points to.
+CREATE TABLE IF NOT EXISTS commit_links (
+    issue_id VARCHAR(255) NOT NULL,
+    commit_sha VARCHAR(64) NOT NULL,
+    action VARCHAR(16) NOT NULL DEFAULT 'closes',
+    summary TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, commit_sha),
+    INDEX idx_commit_links_sha (commit_sha),
+    CONSTRAINT fk_commit_links_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,17 @@
+-- Migration 0057: ci_runs records CI results reported against an issue's
+-- commits and branches (bd ci report). One row per (issue, workflow,
+-- commit); a later report for the same triple replaces the status, so a
+-- pending run becomes success or failure in place.
+CREATE TABLE IF NOT EXISTS ci_runs (
+    issue_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    commit_sha VARCHAR(64) NOT NULL,
+    branch VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    url TEXT,
+    reported_by VARCHAR(255) DEFAULT '',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, name, commit_sha),
+    INDEX idx_ci_runs_commit (commit_sha),
+    CONSTRAINT fk_ci_runs_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
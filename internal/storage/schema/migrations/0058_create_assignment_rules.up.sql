@@ -0,0 +1,18 @@
+-- Migration 0058: assignment_rules holds the auto-assignment rules applied
+-- by bd create and bd assign --auto. Rules are tried in priority order
+-- (lowest first, then name); the first whose label, path prefix, and issue
+-- type conditions all match picks the assignee with its strategy. next_index
+-- is the round-robin cursor. Versioned so every clone assigns the same way.
+CREATE TABLE IF NOT EXISTS assignment_rules (
+    name VARCHAR(255) NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    path_prefix VARCHAR(1024) NOT NULL DEFAULT '',
+    issue_type VARCHAR(32) NOT NULL DEFAULT '',
+    strategy VARCHAR(16) NOT NULL,
+    assignees TEXT NOT NULL,
+    next_index INT NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (name)
+);
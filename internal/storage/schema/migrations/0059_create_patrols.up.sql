@@ -0,0 +1,19 @@
+-- Migration 0059: patrols holds the recurring patrol schedules run by
+-- bd patrol run. Each patrol creates a wisp from its formula for its agent
+-- whenever its schedule (cron expression or interval) comes due.
+-- last_wisp_id/last_completed_at track the current cycle; missed_count
+-- counts cycles that came due while the previous wisp was still open or
+-- that were skipped entirely. Versioned so every clone patrols the same way.
+CREATE TABLE IF NOT EXISTS patrols (
+    name VARCHAR(255) NOT NULL,
+    formula VARCHAR(255) NOT NULL,
+    agent VARCHAR(255) NOT NULL DEFAULT '',
+    schedule VARCHAR(255) NOT NULL,
+    last_run_at DATETIME NULL,
+    last_wisp_id VARCHAR(255) NOT NULL DEFAULT '',
+    last_completed_at DATETIME NULL,
+    missed_count INT NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (name)
+);
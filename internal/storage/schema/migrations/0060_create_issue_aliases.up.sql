@@ -0,0 +1,14 @@
+-- Migration 0060: issue_aliases maps memorable names (infra-login-bug) to
+-- issue IDs so frequently referenced issues can be named without giving up
+-- their hash ID. Aliases resolve anywhere an issue ID is accepted. The FK
+-- cascades so an alias follows its issue through renames and disappears
+-- when the issue is deleted.
+CREATE TABLE IF NOT EXISTS issue_aliases (
+    alias VARCHAR(255) NOT NULL,
+    issue_id VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (alias),
+    INDEX idx_issue_aliases_issue (issue_id),
+    CONSTRAINT fk_issue_aliases_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
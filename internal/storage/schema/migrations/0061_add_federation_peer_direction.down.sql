@@ -0,0 +1 @@
+ALTER TABLE federation_peers DROP COLUMN direction;
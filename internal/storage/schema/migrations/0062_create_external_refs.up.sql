@@ -0,0 +1,11 @@
+-- Migration 0062: external_refs tracks URLs outside beads (GitHub issues,
+-- Jira tickets) that issues depend on via dependencies.depends_on_external.
+-- Their status is unknown to beads, so a blocking dependency on a URL holds
+-- until the URL is marked resolved here. A URL with no row is unresolved.
+CREATE TABLE IF NOT EXISTS external_refs (
+    url VARCHAR(255) NOT NULL,
+    resolved TINYINT(1) NOT NULL DEFAULT 0,
+    resolved_by VARCHAR(255) DEFAULT '',
+    resolved_at DATETIME,
+    PRIMARY KEY (url)
+);
@@ -0,0 +1,17 @@
+-- Ignored migration 0011: create the issue_vectors embedding cache.
+--
+-- Companion to main migration 0053, which registers the dolt_ignore pattern.
+-- One row per issue and embedding model. content_hash is a hash of the text
+-- that was embedded, so bd similar re-embeds only issues whose title or
+-- description changed. embedding holds dims little-endian float32 values.
+-- There is no foreign key to issues: rows for deleted issues are ignored at
+-- query time and overwritten if the ID is reused.
+CREATE TABLE IF NOT EXISTS issue_vectors (
+    issue_id VARCHAR(255) NOT NULL,
+    provider VARCHAR(64) NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    dims INT NOT NULL,
+    embedding LONGBLOB NOT NULL,
+    PRIMARY KEY (issue_id, provider, model)
+);
@@ -0,0 +1,16 @@
+-- Ignored migration 0012: create the api_tokens table.
+--
+-- Companion to main migration 0055, which registers the dolt_ignore pattern.
+-- One row per token. Only the SHA-256 of the token secret is stored; the
+-- plaintext is shown once by bd token create. Revoked tokens keep their row
+-- (revoked_at set) so the audit stream can still name them.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id VARCHAR(32) NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    secret_hash CHAR(64) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    revoked_at DATETIME
+);
@@ -33,6 +33,10 @@ var ErrNotInitialized = errors.New("database not initialized")
 // ErrPrefixMismatch is returned when an issue ID does not match the configured prefix.
 var ErrPrefixMismatch = errors.New("prefix mismatch")
 
+// ErrNothingToMerge is returned by StagedMerger.StageMerge when the current
+// branch already contains every commit of the branch being merged.
+var ErrNothingToMerge = errors.New("already up to date")
+
 // Storage is the interface satisfied by *dolt.DoltStore.
 // Consumers depend on this interface rather than on the concrete type so that
 // alternative implementations (mocks, proxies, etc.) can be substituted.
@@ -232,6 +236,13 @@ type GarbageCollector interface {
 	DoltGC(ctx context.Context) error
 }
 
+// StatisticsRefresher refreshes the index statistics the query planner uses.
+// Embedded mode does not collect statistics, so only server-backed stores
+// implement it; callers should type-assert to this interface.
+type StatisticsRefresher interface {
+	AnalyzeTables(ctx context.Context) ([]string, error)
+}
+
 // Flattener squashes all Dolt commit history into a single commit.
 // Callers should type-assert to this interface for history compaction.
 type Flattener interface {
@@ -248,6 +259,14 @@ type Compactor interface {
 	Compact(ctx context.Context, initialHash, boundaryHash string, oldCommits int, recentHashes []string) error
 }
 
+// StagedMerger merges a branch into the working set without committing it, so
+// the caller can vet the merged result before concluding the merge with Commit
+// or discarding it with AbortMerge. Used by bd branch merge.
+type StagedMerger interface {
+	StageMerge(ctx context.Context, branch, strategy string) error
+	AbortMerge(ctx context.Context) error
+}
+
 // LifecycleManager provides lifecycle inspection beyond Close().
 type LifecycleManager interface {
 	IsClosed() bool
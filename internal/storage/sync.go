@@ -8,6 +8,7 @@ import (
 // SyncResult contains the outcome of a Sync operation.
 type SyncResult struct {
 	Peer              string
+	Direction         string // Peer direction honored; pull-only skips the push, push-only the fetch and merge
 	StartTime         time.Time
 	EndTime           time.Time
 	Fetched           bool
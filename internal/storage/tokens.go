@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// APIToken is a stored API token (bd token create). The secret itself is
+// never stored; SecretHash holds its SHA-256.
+type APIToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	SecretHash string     `json:"-"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the token is neither revoked nor expired at now.
+func (t *APIToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// TokenStore manages API tokens in the clone-local, dolt-ignored api_tokens
+// table. Callers should type-assert to this interface.
+type TokenStore interface {
+	// CreateAPIToken stores a new token. CreatedAt is set by the store.
+	CreateAPIToken(ctx context.Context, token *APIToken) error
+	// GetAPIToken returns the token with the given ID, including revoked and
+	// expired tokens, or ErrNotFound.
+	GetAPIToken(ctx context.Context, id string) (*APIToken, error)
+	// ListAPITokens returns every token, revoked and expired included,
+	// ordered by creation time.
+	ListAPITokens(ctx context.Context) ([]*APIToken, error)
+	// RevokeAPIToken marks the token revoked. Revoking an unknown or already
+	// revoked token returns ErrNotFound.
+	RevokeAPIToken(ctx context.Context, id string) error
+}
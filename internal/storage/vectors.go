@@ -0,0 +1,25 @@
+package storage
+
+import "context"
+
+// IssueVector is a cached embedding of one issue's text under one embedding
+// provider and model.
+type IssueVector struct {
+	IssueID  string
+	Provider string
+	Model    string
+	// ContentHash identifies the embedded text, so callers can tell when the
+	// issue changed and the vector is stale.
+	ContentHash string
+	Vector      []float32
+}
+
+// VectorStore caches issue embeddings for similarity search in the
+// clone-local, dolt-ignored issue_vectors table. Callers should type-assert
+// to this interface.
+type VectorStore interface {
+	// GetIssueVectors returns every cached vector for provider and model.
+	GetIssueVectors(ctx context.Context, provider, model string) ([]*IssueVector, error)
+	// UpsertIssueVectors inserts or replaces the given vectors.
+	UpsertIssueVectors(ctx context.Context, vectors []*IssueVector) error
+}
@@ -45,5 +45,8 @@ type VersionControl interface {
 	Log(ctx context.Context, limit int) ([]CommitInfo, error)
 	Merge(ctx context.Context, branch string) ([]Conflict, error)
 	GetConflicts(ctx context.Context) ([]Conflict, error)
+	// GetConflictDetails reports each conflicted field of each conflicted
+	// row, with Table set, rather than one entry per table.
+	GetConflictDetails(ctx context.Context) ([]Conflict, error)
 	ResolveConflicts(ctx context.Context, table string, strategy string) error
 }
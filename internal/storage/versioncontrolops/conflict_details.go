@@ -0,0 +1,125 @@
+package versioncontrolops
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// conflictDetailSkipColumns are bookkeeping columns that differ whenever a
+// row does and carry no meaning of their own.
+var conflictDetailSkipColumns = map[string]bool{
+	"content_hash": true,
+}
+
+// GetConflictDetails returns one Conflict per conflicted field of every
+// conflicted row, read from dolt_conflicts_<table>. A row deleted on one side
+// is reported once with an empty Field and the diff types ("removed",
+// "modified", ...) as the values.
+func GetConflictDetails(ctx context.Context, db DBConn) ([]storage.Conflict, error) {
+	tables, err := GetConflicts(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var details []storage.Conflict
+	for _, t := range tables {
+		rows, err := tableConflictDetails(ctx, db, t.Field)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, rows...)
+	}
+	return details, nil
+}
+
+func tableConflictDetails(ctx context.Context, db DBConn, table string) ([]storage.Conflict, error) {
+	if err := validateTableName(table); err != nil {
+		return nil, fmt.Errorf("invalid table name: %w", err)
+	}
+	//nolint:gosec // G201: table is validated by validateTableName above.
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `dolt_conflicts_%s`", table))
+	if err != nil {
+		return nil, fmt.Errorf("get conflicts for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("get conflicts for %s: %w", table, err)
+	}
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+	// Columns present on both sides, in table order.
+	var fields []string
+	for _, c := range cols {
+		if name, ok := strings.CutPrefix(c, "our_"); ok && name != "diff_type" {
+			if _, ok := index["their_"+name]; ok && !conflictDetailSkipColumns[name] {
+				fields = append(fields, name)
+			}
+		}
+	}
+	keyColumn := "issue_id"
+	if _, ok := index["our_issue_id"]; !ok {
+		keyColumn = "id"
+	}
+
+	var details []storage.Conflict
+	values := make([]sql.NullString, len(cols))
+	dest := make([]any, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan conflict for %s: %w", table, err)
+		}
+		get := func(col string) sql.NullString {
+			if i, ok := index[col]; ok {
+				return values[i]
+			}
+			return sql.NullString{}
+		}
+		issueID := get("our_" + keyColumn).String
+		if issueID == "" {
+			issueID = get("their_" + keyColumn).String
+		}
+
+		ourDiff, theirDiff := get("our_diff_type").String, get("their_diff_type").String
+		if ourDiff == "removed" || theirDiff == "removed" {
+			details = append(details, storage.Conflict{
+				Table:       table,
+				IssueID:     issueID,
+				OursValue:   ourDiff,
+				TheirsValue: theirDiff,
+			})
+			continue
+		}
+		for _, f := range fields {
+			ours, theirs := get("our_"+f), get("their_"+f)
+			if ours == theirs {
+				continue
+			}
+			details = append(details, storage.Conflict{
+				Table:       table,
+				IssueID:     issueID,
+				Field:       f,
+				OursValue:   nullableValue(ours),
+				TheirsValue: nullableValue(theirs),
+			})
+		}
+	}
+	return details, rows.Err()
+}
+
+// nullableValue maps SQL NULL to nil so it renders as null, not "".
+func nullableValue(v sql.NullString) interface{} {
+	if !v.Valid {
+		return nil
+	}
+	return v.String
+}
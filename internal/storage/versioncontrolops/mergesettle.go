@@ -156,6 +156,100 @@ func SettleMerge(ctx context.Context, db DBConn, mergeErr error, preMergeClean b
 	return nil
 }
 
+// StageMerge merges branch into the current branch's working set without
+// committing, for callers that vet the merged result before concluding it
+// (bd branch merge). Safe conflict classes are auto-resolved and FK cascade
+// violations repaired as in SettleMerge. Any other conflict is resolved with
+// strategy ("ours" or "theirs"); with no strategy the merge is aborted and a
+// *MergeConflictsError reports the conflicted tables. On success the merge
+// stays staged until the caller commits it or calls AbortMerge.
+//
+// The working set must be clean: AbortMerge's hard-reset fallback relies on
+// nothing uncommitted predating the merge. db must be a single session, as
+// for MergeAndSettle.
+func StageMerge(ctx context.Context, db DBConn, branch, strategy string) error {
+	if !workingSetClean(ctx, db) {
+		return fmt.Errorf("working set has uncommitted changes; commit them before merging")
+	}
+	// With --no-commit an up-to-date merge succeeds silently, so ask first.
+	var upToDate bool
+	if err := db.QueryRowContext(ctx, "SELECT DOLT_MERGE_BASE(?, 'HEAD') = DOLT_HASHOF(?)", branch, branch).Scan(&upToDate); err != nil {
+		return fmt.Errorf("merge branch %s: %w", branch, err)
+	}
+	if upToDate {
+		return storage.ErrNothingToMerge
+	}
+	if _, err := db.ExecContext(ctx, "SET @@dolt_allow_commit_conflicts = 1"); err != nil {
+		return fmt.Errorf("set dolt_allow_commit_conflicts: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, "SET @@dolt_force_transaction_commit = 1"); err != nil {
+		return fmt.Errorf("set dolt_force_transaction_commit: %w", err)
+	}
+
+	// --no-ff keeps a fast-forward from moving HEAD before the caller's checks.
+	_, mergeErr := db.ExecContext(ctx, "CALL DOLT_MERGE('--no-ff', '--no-commit', ?)", branch)
+	if mergeErr != nil && strings.Contains(mergeErr.Error(), "up to date") {
+		return storage.ErrNothingToMerge
+	}
+
+	conflicts, err := GetConflicts(ctx, db)
+	if err != nil {
+		abortMerge(ctx, db, true)
+		return err
+	}
+	if len(conflicts) > 0 {
+		resolved, err := TryAutoResolveMergeConflicts(ctx, db)
+		if err != nil {
+			abortMerge(ctx, db, true)
+			return err
+		}
+		if !resolved {
+			if strategy == "" {
+				// Re-read: the resolver declines without resolving anything,
+				// but report what is actually left for the operator.
+				if remaining, err := GetConflicts(ctx, db); err == nil && len(remaining) > 0 {
+					conflicts = remaining
+				}
+				abortMerge(ctx, db, true)
+				return &MergeConflictsError{Conflicts: conflicts, MergeErr: mergeErr}
+			}
+			for _, c := range conflicts {
+				if err := ResolveConflicts(ctx, db, c.Field, strategy); err != nil {
+					abortMerge(ctx, db, true)
+					return err
+				}
+			}
+		}
+	} else if mergeErr != nil {
+		abortMerge(ctx, db, true)
+		return fmt.Errorf("merge branch %s: %w", branch, mergeErr)
+	}
+
+	repaired, hadViol, err := TryRepairFKCascadeViolations(ctx, db)
+	if err != nil {
+		abortMerge(ctx, db, true)
+		return err
+	}
+	if hadViol && !repaired {
+		abortMerge(ctx, db, true)
+		return fmt.Errorf("merge left constraint violations bd cannot auto-repair; inspect dolt_constraint_violations and resolve before retrying")
+	}
+	return nil
+}
+
+// AbortMerge discards a merge staged by StageMerge and restores the
+// pre-merge working set.
+func AbortMerge(ctx context.Context, db DBConn) error {
+	if _, err := db.ExecContext(ctx, "CALL DOLT_MERGE('--abort')"); err != nil {
+		// Merge state may already be closed; StageMerge started clean, so a
+		// hard reset loses nothing.
+		if _, rerr := db.ExecContext(ctx, "CALL DOLT_RESET('--hard')"); rerr != nil {
+			return fmt.Errorf("abort merge: %w", err)
+		}
+	}
+	return nil
+}
+
 // abortMerge restores the pre-merge state after a settle pass refused the
 // merge — the autocommit-mode stand-in for server mode's tx.Rollback().
 // DOLT_MERGE('--abort') is the precise tool but only works while merge state
@@ -487,6 +581,10 @@ var fkCascadeRepairDeletes = map[string]string{
 	"issue_snapshots":      `DELETE FROM issue_snapshots WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"compaction_snapshots": `DELETE FROM compaction_snapshots WHERE issue_id NOT IN (SELECT id FROM issues)`,
 	"child_counters":       `DELETE FROM child_counters WHERE parent_id NOT IN (SELECT id FROM issues)`,
+	"issue_references":     `DELETE FROM issue_references WHERE source_id NOT IN (SELECT id FROM issues)`,
+	"locks":                `DELETE FROM locks WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"commit_links":         `DELETE FROM commit_links WHERE issue_id NOT IN (SELECT id FROM issues)`,
+	"ci_runs":              `DELETE FROM ci_runs WHERE issue_id NOT IN (SELECT id FROM issues)`,
 }
 
 // TryRepairFKCascadeViolations repairs the post-merge foreign-key constraint
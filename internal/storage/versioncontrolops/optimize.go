@@ -0,0 +1,50 @@
+package versioncontrolops
+
+import (
+	"context"
+	"fmt"
+)
+
+// AnalyzeTables refreshes index statistics for every base table in the
+// current database and returns the tables analyzed. conn must be a
+// non-transactional connection.
+func AnalyzeTables(ctx context.Context, conn DBConn) ([]string, error) {
+	rows, err := conn.QueryContext(ctx,
+		"SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME")
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	for _, table := range tables {
+		// #nosec G202 -- table names come from information_schema, not user input
+		if _, err := conn.ExecContext(ctx, "ANALYZE TABLE `"+table+"`"); err != nil {
+			return nil, fmt.Errorf("analyze %s: %w", table, err)
+		}
+	}
+	return tables, nil
+}
+
+// LogicalSize returns the engine's estimate of the bytes held by the current
+// database's rows and indexes, excluding history and unreferenced chunks.
+// Comparing it with the on-disk size shows how much a GC could reclaim.
+func LogicalSize(ctx context.Context, conn DBConn) (int64, error) {
+	var size int64
+	err := conn.QueryRowContext(ctx,
+		"SELECT COALESCE(SUM(COALESCE(DATA_LENGTH, 0) + COALESCE(INDEX_LENGTH, 0)), 0) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'").Scan(&size)
+	if err != nil {
+		return 0, fmt.Errorf("logical size: %w", err)
+	}
+	return size, nil
+}
@@ -2,6 +2,8 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -17,14 +19,39 @@ type HistoryEntry struct {
 
 // DiffEntry represents a change between two commits.
 type DiffEntry struct {
-	IssueID  string       // The ID of the affected issue
-	DiffType string       // "added", "modified", or "removed"
-	OldValue *types.Issue // State before (nil for "added")
-	NewValue *types.Issue // State after (nil for "removed")
+	IssueID           string             // The ID of the affected issue
+	DiffType          string             // "added", "modified", or "removed"
+	OldValue          *types.Issue       // State before (nil for "added", or when only dependencies changed)
+	NewValue          *types.Issue       // State after (nil for "removed", or when only dependencies changed)
+	Changes           []FieldChange      // Fields that differ, for "modified"
+	DependencyChanges []DependencyChange // Dependencies of this issue added, removed, or retyped
+}
+
+// Closed reports whether the issue moved into the closed status.
+func (e *DiffEntry) Closed() bool {
+	return e.NewValue != nil && e.NewValue.Status == types.StatusClosed &&
+		(e.OldValue == nil || e.OldValue.Status != types.StatusClosed)
+}
+
+// FieldChange is one issue field that differs between two refs. Values are
+// rendered as text; an unset value is empty.
+type FieldChange struct {
+	Field string // Column name, e.g. "status" or "assignee"
+	Old   string
+	New   string
+}
+
+// DependencyChange is one dependency edge that differs between two refs.
+type DependencyChange struct {
+	DependsOnID string // The issue depended on
+	DiffType    string // "added", "removed", or "modified" (type changed)
+	OldType     string // Dependency type before (empty for "added")
+	NewType     string // Dependency type after (empty for "removed")
 }
 
 // Conflict represents a merge conflict.
 type Conflict struct {
+	Table       string      // Table holding the conflicting row (empty for table-level)
 	IssueID     string      // The ID of the conflicting issue
 	Field       string      // Which field has the conflict (empty for table-level)
 	OursValue   interface{} // Value on current branch
@@ -54,7 +81,43 @@ type FederationPeer struct {
 	Username    string     // SQL username for authentication
 	Password    string     // Password (decrypted, not stored directly)
 	Sovereignty string     // Sovereignty tier: T1, T2, T3, T4
+	Direction   string     // PeerDirection*; empty means bidirectional
 	LastSync    *time.Time // Last successful sync time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+// Peer directions limit which way a federation peer syncs. A pull-only peer
+// is an upstream that is consumed but never pushed to; a push-only peer
+// receives local changes but is never merged from.
+const (
+	PeerDirectionBidirectional = "bidirectional"
+	PeerDirectionPullOnly      = "pull-only"
+	PeerDirectionPushOnly      = "push-only"
+)
+
+// ErrPeerDirection is returned (wrapped) when a sync operation goes against
+// a peer's configured direction.
+var ErrPeerDirection = errors.New("operation not allowed by peer direction")
+
+// ValidatePeerDirection checks a direction; empty is bidirectional.
+func ValidatePeerDirection(direction string) error {
+	switch direction {
+	case "", PeerDirectionBidirectional, PeerDirectionPullOnly, PeerDirectionPushOnly:
+		return nil
+	}
+	return fmt.Errorf("invalid peer direction %q (must be %s, %s, or %s)",
+		direction, PeerDirectionBidirectional, PeerDirectionPullOnly, PeerDirectionPushOnly)
+}
+
+// CheckPeerDirection returns an ErrPeerDirection error when a peer with the
+// given direction may not be pulled from (push false) or pushed to (push true).
+func CheckPeerDirection(peer, direction string, push bool) error {
+	switch {
+	case push && direction == PeerDirectionPullOnly:
+		return fmt.Errorf("%w: peer %s is pull-only", ErrPeerDirection, peer)
+	case !push && direction == PeerDirectionPushOnly:
+		return fmt.Errorf("%w: peer %s is push-only", ErrPeerDirection, peer)
+	}
+	return nil
+}
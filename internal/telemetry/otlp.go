@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // buildOTLPMetricExporter creates an HTTP/protobuf OTLP metric exporter.
@@ -13,3 +15,10 @@ import (
 func buildOTLPMetricExporter(ctx context.Context, url string) (sdkmetric.Exporter, error) {
 	return otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(url))
 }
+
+// buildOTLPTraceExporter creates an HTTP/protobuf OTLP span exporter.
+// url is a full HTTP URL, e.g. http://localhost:4318/v1/traces. Compatible
+// with any OTLP HTTP trace receiver (OpenTelemetry Collector, Jaeger, Tempo).
+func buildOTLPTraceExporter(ctx context.Context, url string) (sdktrace.SpanExporter, error) {
+	return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(url))
+}
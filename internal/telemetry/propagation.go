@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ContextFromEnv returns ctx carrying the remote span context from the
+// TRACEPARENT and TRACESTATE environment variables, so a bd run by a traced
+// caller (CI job, agent harness, parent bd) joins the caller's trace.
+// Returns ctx unchanged when telemetry is disabled or neither is set.
+func ContextFromEnv(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+	if v := os.Getenv("TRACEPARENT"); v != "" {
+		carrier["traceparent"] = v
+	}
+	if v := os.Getenv("TRACESTATE"); v != "" {
+		carrier["tracestate"] = v
+	}
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// EnvWithTrace appends TRACEPARENT (and TRACESTATE, when present) for the
+// span in ctx to env, so a child process started with env continues the
+// trace. env is returned unchanged when there is no span to propagate.
+func EnvWithTrace(ctx context.Context, env []string) []string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for _, key := range []string{"traceparent", "tracestate"} {
+		if v := carrier.Get(key); v != "" {
+			env = append(env, strings.ToUpper(key)+"="+v)
+		}
+	}
+	return env
+}
@@ -15,22 +15,25 @@ import (
 
 const storageScopeName = "github.com/steveyegge/beads/storage"
 
-// InstrumentedStorage wraps storage.Storage with OTel tracing and metrics.
-// Every method gets a span and is counted in bd.storage.* metrics.
+// InstrumentedStorage wraps storage.DoltStorage with OTel tracing and metrics.
+// Every storage.Storage method, plus the bulk, federation, and version-control
+// methods below, gets a span and is counted in bd.storage.* metrics; other
+// DoltStorage methods pass through unchanged.
 // Use WrapStorage to create one; it returns the original store unchanged when
 // telemetry is disabled.
 type InstrumentedStorage struct {
-	inner      storage.Storage
-	tracer     trace.Tracer
-	ops        metric.Int64Counter
-	dur        metric.Float64Histogram
-	errs       metric.Int64Counter
-	issueGauge metric.Int64Gauge
+	storage.DoltStorage // embed for passthrough of non-instrumented methods
+	inner               storage.DoltStorage
+	tracer              trace.Tracer
+	ops                 metric.Int64Counter
+	dur                 metric.Float64Histogram
+	errs                metric.Int64Counter
+	issueGauge          metric.Int64Gauge
 }
 
 // WrapStorage returns s decorated with OTel instrumentation.
 // When telemetry is disabled, s is returned as-is with zero overhead.
-func WrapStorage(s storage.Storage) storage.Storage {
+func WrapStorage(s storage.DoltStorage) storage.DoltStorage {
 	if !Enabled() {
 		return s
 	}
@@ -49,15 +52,20 @@ func WrapStorage(s storage.Storage) storage.Storage {
 		metric.WithDescription("Current number of issues by status (snapshot from GetStatistics)"),
 	)
 	return &InstrumentedStorage{
-		inner:      s,
-		tracer:     Tracer(storageScopeName),
-		ops:        ops,
-		dur:        dur,
-		errs:       errs,
-		issueGauge: issueGauge,
+		DoltStorage: s,
+		inner:       s,
+		tracer:      Tracer(storageScopeName),
+		ops:         ops,
+		dur:         dur,
+		errs:        errs,
+		issueGauge:  issueGauge,
 	}
 }
 
+// Inner returns the wrapped store, so storage.UnwrapStore reaches the
+// concrete store for optional-interface assertions.
+func (s *InstrumentedStorage) Inner() storage.DoltStorage { return s.inner }
+
 // op starts a span and records a metric for the named storage operation.
 func (s *InstrumentedStorage) op(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span, time.Time) {
 	all := append([]attribute.KeyValue{attribute.String("db.operation", name)}, attrs...)
@@ -639,6 +647,117 @@ func (s *InstrumentedStorage) SlotClear(ctx context.Context, issueID, key, actor
 	return err
 }
 
+// ── Bulk import ─────────────────────────────────────────────────────────────
+
+func (s *InstrumentedStorage) CreateIssuesWithFullOptions(ctx context.Context, issues []*types.Issue, actor string, opts storage.BatchCreateOptions) error {
+	attrs := []attribute.KeyValue{
+		attribute.String("bd.actor", actor),
+		attribute.Int("bd.issue.count", len(issues)),
+	}
+	ctx, span, t := s.op(ctx, "CreateIssuesWithFullOptions", attrs...)
+	err := s.inner.CreateIssuesWithFullOptions(ctx, issues, actor, opts)
+	s.done(ctx, span, t, err, attrs...)
+	return err
+}
+
+func (s *InstrumentedStorage) GetDependencyRecordsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Dependency, error) {
+	attrs := []attribute.KeyValue{attribute.Int("bd.issue.count", len(issueIDs))}
+	ctx, span, t := s.op(ctx, "GetDependencyRecordsForIssues", attrs...)
+	v, err := s.inner.GetDependencyRecordsForIssues(ctx, issueIDs)
+	s.done(ctx, span, t, err, attrs...)
+	return v, err
+}
+
+func (s *InstrumentedStorage) GetBlockingInfoForIssues(ctx context.Context, issueIDs []string) (map[string][]string, map[string][]string, map[string]string, error) {
+	attrs := []attribute.KeyValue{attribute.Int("bd.issue.count", len(issueIDs))}
+	ctx, span, t := s.op(ctx, "GetBlockingInfoForIssues", attrs...)
+	blockedBy, blocks, parents, err := s.inner.GetBlockingInfoForIssues(ctx, issueIDs)
+	s.done(ctx, span, t, err, attrs...)
+	return blockedBy, blocks, parents, err
+}
+
+func (s *InstrumentedStorage) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	attrs := []attribute.KeyValue{attribute.Int("bd.issue.count", len(issueIDs))}
+	ctx, span, t := s.op(ctx, "GetLabelsForIssues", attrs...)
+	v, err := s.inner.GetLabelsForIssues(ctx, issueIDs)
+	s.done(ctx, span, t, err, attrs...)
+	return v, err
+}
+
+// ── Federation and remotes ──────────────────────────────────────────────────
+
+func (s *InstrumentedStorage) Sync(ctx context.Context, peer string, strategy string) (*storage.SyncResult, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("bd.peer", peer),
+		attribute.String("bd.sync.strategy", strategy),
+	}
+	ctx, span, t := s.op(ctx, "Sync", attrs...)
+	v, err := s.inner.Sync(ctx, peer, strategy)
+	if err == nil && v != nil {
+		span.SetAttributes(
+			attribute.Int("bd.sync.pulled_commits", v.PulledCommits),
+			attribute.Int("bd.sync.conflicts", len(v.Conflicts)),
+			attribute.Bool("bd.sync.pushed", v.Pushed),
+		)
+	}
+	s.done(ctx, span, t, err, attrs...)
+	return v, err
+}
+
+func (s *InstrumentedStorage) Fetch(ctx context.Context, peer string) error {
+	attrs := []attribute.KeyValue{attribute.String("bd.peer", peer)}
+	ctx, span, t := s.op(ctx, "Fetch", attrs...)
+	err := s.inner.Fetch(ctx, peer)
+	s.done(ctx, span, t, err, attrs...)
+	return err
+}
+
+func (s *InstrumentedStorage) PushTo(ctx context.Context, peer string) error {
+	attrs := []attribute.KeyValue{attribute.String("bd.peer", peer)}
+	ctx, span, t := s.op(ctx, "PushTo", attrs...)
+	err := s.inner.PushTo(ctx, peer)
+	s.done(ctx, span, t, err, attrs...)
+	return err
+}
+
+func (s *InstrumentedStorage) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	attrs := []attribute.KeyValue{attribute.String("bd.peer", peer)}
+	ctx, span, t := s.op(ctx, "PullFrom", attrs...)
+	v, err := s.inner.PullFrom(ctx, peer)
+	s.done(ctx, span, t, err, attrs...)
+	return v, err
+}
+
+func (s *InstrumentedStorage) Push(ctx context.Context) error {
+	ctx, span, t := s.op(ctx, "Push")
+	err := s.inner.Push(ctx)
+	s.done(ctx, span, t, err)
+	return err
+}
+
+func (s *InstrumentedStorage) Pull(ctx context.Context) error {
+	ctx, span, t := s.op(ctx, "Pull")
+	err := s.inner.Pull(ctx)
+	s.done(ctx, span, t, err)
+	return err
+}
+
+// ── Version control ─────────────────────────────────────────────────────────
+
+func (s *InstrumentedStorage) Commit(ctx context.Context, message string) error {
+	ctx, span, t := s.op(ctx, "Commit")
+	err := s.inner.Commit(ctx, message)
+	s.done(ctx, span, t, err)
+	return err
+}
+
+func (s *InstrumentedStorage) CommitPending(ctx context.Context, actor string) (bool, error) {
+	ctx, span, t := s.op(ctx, "CommitPending")
+	v, err := s.inner.CommitPending(ctx, actor)
+	s.done(ctx, span, t, err)
+	return v, err
+}
+
 // ── Lifecycle ────────────────────────────────────────────────────────────────
 
 func (s *InstrumentedStorage) Close() error {
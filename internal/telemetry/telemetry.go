@@ -1,7 +1,7 @@
 // Package telemetry provides OpenTelemetry integration for beads.
 //
-// Telemetry is opt-in: set BD_OTEL_METRICS_URL or BD_OTEL_STDOUT=true to activate.
-// No overhead when neither variable is set.
+// Telemetry is opt-in: set BD_OTEL_METRICS_URL, BD_OTEL_TRACES_URL, or
+// BD_OTEL_STDOUT=true to activate. No overhead when none is set.
 //
 // # Configuration
 //
@@ -9,6 +9,14 @@
 //	    Push metrics to VictoriaMetrics (or any OTLP HTTP receiver).
 //	    Presence of this variable enables telemetry.
 //
+//	BD_OTEL_TRACES_URL=http://localhost:4318/v1/traces
+//	    Push spans to any OTLP HTTP trace receiver (Jaeger, Tempo, collector).
+//	    Presence of this variable enables telemetry.
+//
+//	BD_OTEL_TRACES_SAMPLE_RATIO=0.1
+//	    Fraction of new traces to record (default 1). Traces continued from a
+//	    TRACEPARENT in the environment follow the parent's sampling decision.
+//
 //	BD_OTEL_LOGS_URL=http://localhost:9428/insert/opentelemetry/v1/logs
 //	    Push logs to VictoriaLogs (reserved for future log export).
 //
@@ -29,13 +37,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/metric"
 	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -49,17 +60,18 @@ const instrumentationScope = "github.com/steveyegge/beads"
 var shutdownFns []func(context.Context) error
 
 // Enabled reports whether telemetry is active.
-// True when BD_OTEL_METRICS_URL is set or BD_OTEL_STDOUT=true.
+// True when BD_OTEL_METRICS_URL or BD_OTEL_TRACES_URL is set, or BD_OTEL_STDOUT=true.
 func Enabled() bool {
 	return os.Getenv("BD_OTEL_METRICS_URL") != "" ||
+		os.Getenv("BD_OTEL_TRACES_URL") != "" ||
 		os.Getenv("BD_OTEL_STDOUT") == "true"
 }
 
 // Init configures OTel providers.
-// When neither BD_OTEL_METRICS_URL nor BD_OTEL_STDOUT is set, installs no-op
-// providers and returns immediately (zero overhead path).
+// When telemetry is not enabled, installs no-op providers and returns
+// immediately (zero overhead path).
 //
-// Traces are exported only when BD_OTEL_STDOUT=true (stdout, for local debugging).
+// Traces are exported to BD_OTEL_TRACES_URL and/or stdout (BD_OTEL_STDOUT=true).
 // Metrics are exported to BD_OTEL_METRICS_URL and/or stdout.
 func Init(ctx context.Context, serviceName, version string) error {
 	if !Enabled() {
@@ -80,8 +92,14 @@ func Init(ctx context.Context, serviceName, version string) error {
 		return fmt.Errorf("telemetry: resource: %w", err)
 	}
 
-	// Traces: stdout only (local debug). No remote trace backend in the default stack.
-	if os.Getenv("BD_OTEL_STDOUT") == "true" {
+	// W3C trace context, so spans join a trace started by the caller
+	// (see ContextFromEnv) and child processes can continue ours.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	// Traces: OTLP HTTP and/or stdout (local debug).
+	if os.Getenv("BD_OTEL_STDOUT") == "true" || os.Getenv("BD_OTEL_TRACES_URL") != "" {
 		tp, err := buildTraceProvider(ctx, res)
 		if err != nil {
 			return fmt.Errorf("telemetry: trace provider: %w", err)
@@ -103,16 +121,48 @@ func Init(ctx context.Context, serviceName, version string) error {
 	return nil
 }
 
-func buildTraceProvider(_ context.Context, res *resource.Resource) (*sdktrace.TracerProvider, error) {
-	exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+func buildTraceProvider(ctx context.Context, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+	sampler, err := traceSampler()
 	if err != nil {
 		return nil, err
 	}
-	return sdktrace.NewTracerProvider(
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithBatcher(exp),
-	), nil
+		sdktrace.WithSampler(sampler),
+	}
+
+	if os.Getenv("BD_OTEL_STDOUT") == "true" {
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	if url := os.Getenv("BD_OTEL_TRACES_URL"); url != "" {
+		exp, err := buildOTLPTraceExporter(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("otlp trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// traceSampler returns the sampler configured by BD_OTEL_TRACES_SAMPLE_RATIO.
+// New traces are sampled at that ratio (default: all); traces continued from
+// a parent keep the parent's decision.
+func traceSampler() (sdktrace.Sampler, error) {
+	v := os.Getenv("BD_OTEL_TRACES_SAMPLE_RATIO")
+	if v == "" {
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	}
+	ratio, err := strconv.ParseFloat(v, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("BD_OTEL_TRACES_SAMPLE_RATIO must be a number between 0 and 1, got %q", v)
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
 }
 
 func buildMetricProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
@@ -157,6 +207,15 @@ func Meter(name string) metric.Meter {
 	return otel.Meter(name)
 }
 
+// EndSpan records err (if any) on span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 // Shutdown flushes all spans/metrics and shuts down OTel providers.
 // Should be deferred in PersistentPostRun with a short-lived context.
 func Shutdown(ctx context.Context) {
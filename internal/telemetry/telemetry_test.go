@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracePropagationThroughEnv(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	t.Setenv("TRACEPARENT", traceparent)
+
+	ctx := ContextFromEnv(context.Background())
+	sc := trace.SpanContextFromContext(ctx)
+	if got := sc.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("TraceID = %s", got)
+	}
+	if !sc.IsRemote() || !sc.IsSampled() {
+		t.Errorf("span context = %+v, want remote and sampled", sc)
+	}
+
+	env := EnvWithTrace(ctx, []string{"HOME=/tmp"})
+	if len(env) != 2 || env[1] != "TRACEPARENT="+traceparent {
+		t.Errorf("EnvWithTrace = %v", env)
+	}
+}
+
+func TestContextFromEnvWithoutTraceparent(t *testing.T) {
+	t.Setenv("TRACEPARENT", "")
+	ctx := context.Background()
+	if got := ContextFromEnv(ctx); got != ctx {
+		t.Error("ContextFromEnv should return ctx unchanged without TRACEPARENT")
+	}
+	if env := EnvWithTrace(ctx, nil); len(env) != 0 {
+		t.Errorf("EnvWithTrace without a span = %v", env)
+	}
+}
+
+func TestTraceSampler(t *testing.T) {
+	for _, v := range []string{"", "0", "0.25", "1"} {
+		t.Setenv("BD_OTEL_TRACES_SAMPLE_RATIO", v)
+		if _, err := traceSampler(); err != nil {
+			t.Errorf("traceSampler(%q): %v", v, err)
+		}
+	}
+	for _, v := range []string{"-0.1", "1.5", "half"} {
+		t.Setenv("BD_OTEL_TRACES_SAMPLE_RATIO", v)
+		if _, err := traceSampler(); err == nil || !strings.Contains(err.Error(), "BD_OTEL_TRACES_SAMPLE_RATIO") {
+			t.Errorf("traceSampler(%q) error = %v", v, err)
+		}
+	}
+}
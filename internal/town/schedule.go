@@ -0,0 +1,173 @@
+package town
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinPatrolInterval is the shortest interval schedule accepted.
+const MinPatrolInterval = time.Minute
+
+// Schedule says when a patrol next comes due.
+type Schedule interface {
+	// Next returns the first due time strictly after t, or the zero time if
+	// the schedule never fires again.
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parses a patrol schedule:
+//
+//	30m, @every 30m          every 30 minutes after the previous run
+//	@hourly, @daily, @weekly, @monthly (@midnight = @daily)
+//	*/15 * * * *             5-field cron: minute hour day-of-month month day-of-week
+//
+// Cron fields accept *, numbers, ranges (1-5), lists (1,3), and steps (*/15,
+// 0-30/10). Cron schedules are evaluated in local time.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		return parseInterval(rest)
+	}
+	if len(strings.Fields(spec)) == 1 {
+		return parseInterval(spec)
+	}
+	return parseCron(spec)
+}
+
+// intervalSchedule fires a fixed duration after the previous run.
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+func parseInterval(spec string) (Schedule, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(spec))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q (use an interval like 30m or a cron expression like \"*/30 * * * *\")", spec)
+	}
+	if d < MinPatrolInterval {
+		return nil, fmt.Errorf("interval %s is shorter than %s", d, MinPatrolInterval)
+	}
+	return intervalSchedule(d), nil
+}
+
+// cronSchedule is a parsed 5-field cron expression.
+type cronSchedule struct {
+	minute, hour, dom, month, dow [61]bool
+	domAny, dowAny                bool
+}
+
+func parseCron(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: want 5 fields (minute hour day-of-month month day-of-week)", spec)
+	}
+	var s cronSchedule
+	for i, f := range []struct {
+		name     string
+		set      *[61]bool
+		min, max int
+	}{
+		{"minute", &s.minute, 0, 59},
+		{"hour", &s.hour, 0, 23},
+		{"day-of-month", &s.dom, 1, 31},
+		{"month", &s.month, 1, 12},
+		{"day-of-week", &s.dow, 0, 7},
+	} {
+		if err := parseCronField(fields[i], f.set, f.min, f.max); err != nil {
+			return nil, fmt.Errorf("invalid cron %s %q: %w", f.name, fields[i], err)
+		}
+	}
+	s.dow[0] = s.dow[0] || s.dow[7] // 7 is Sunday too
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return s, nil
+}
+
+func parseCronField(field string, set *[61]bool, min, max int) error {
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return fmt.Errorf("bad step %q", stepStr)
+			}
+			step = n
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return fmt.Errorf("bad value %q", loStr)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return fmt.Errorf("bad value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = max // "5/15" means from 5 to the end, every 15
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return fmt.Errorf("%q out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// dayMatches follows cron: when both day fields are restricted, either may
+// match; otherwise the restricted one must.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	t = t.Local()
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0) // e.g. Feb 30 never matches
+	for t.Before(limit) {
+		switch {
+		case !s.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hour[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
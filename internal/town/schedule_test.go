@@ -0,0 +1,70 @@
+package town
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleInterval(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 7, 30, 0, time.Local)
+	for _, spec := range []string{"30m", " 30m ", "@every 30m"} {
+		s, err := ParseSchedule(spec)
+		if err != nil {
+			t.Fatalf("ParseSchedule(%q): %v", spec, err)
+		}
+		if got, want := s.Next(start), start.Add(30*time.Minute); !got.Equal(want) {
+			t.Errorf("ParseSchedule(%q).Next = %v, want %v", spec, got, want)
+		}
+	}
+}
+
+func TestParseScheduleCron(t *testing.T) {
+	// Sunday, 1 March 2026, 10:07:30
+	start := time.Date(2026, 3, 1, 10, 7, 30, 0, time.Local)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", at(3, 1, 10, 15)},
+		{"7 * * * *", at(3, 1, 11, 7)}, // strictly after start
+		{"@hourly", at(3, 1, 11, 0)},
+		{"@daily", at(3, 2, 0, 0)},
+		{"@weekly", at(3, 8, 0, 0)},
+		{"@monthly", at(4, 1, 0, 0)},
+		{"0 9-17/4 * * 1-5", at(3, 2, 9, 0)},
+		{"30 8 * * 7", at(3, 8, 8, 30)}, // 7 is Sunday
+		{"0 0 15 * 3", at(3, 4, 0, 0)},  // day-of-month OR day-of-week
+		{"0,30 12 * 2,6 *", at(6, 1, 12, 0)},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := s.Next(start); !got.Equal(tt.want) {
+			t.Errorf("ParseSchedule(%q).Next = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseScheduleNeverFires(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next = %v, want zero time for February 30", got)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, bad := range []string{"", "soon", "30s", "@every 10s", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseSchedule(bad); err == nil {
+			t.Errorf("ParseSchedule(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package town holds orchestrator ("gastown") settings stored in the beads
+// database config table under the town. namespace: thresholds and quotas
+// that tune doctor and wisp creation, and the agent registry. Patrol
+// schedules live in the patrols table; this package parses them and reads
+// the town.patrol.* keys that held them before. Keeping them in the
+// database means every clone and every agent sees the same values, instead
+// of each caller passing its own flags.
+package town
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config keys and key prefixes.
+const (
+	KeyPrefix   = "town."
+	AgentPrefix = "town.agent."
+	// PatrolPrefix keys (town.patrol.<formula> = <interval>) held patrol
+	// schedules before the patrols table. bd patrol imports and deletes them.
+	PatrolPrefix = "town.patrol."
+)
+
+// Defaults for the numeric settings.
+const (
+	DefaultDuplicatesThreshold   = 1000 // Orchestrator wisps are ephemeral, so many duplicates are normal
+	DefaultWispQuota             = 0    // 0 = unlimited
+	DefaultPatrolDigestThreshold = 10
+	DefaultSessionBeadThreshold  = 50
+)
+
+// Setting describes one numeric town setting.
+type Setting struct {
+	Name    string // CLI name, e.g. "duplicates-threshold"
+	Key     string // Config key, e.g. "town.duplicates_threshold"
+	Default int
+	Help    string
+}
+
+// Settings lists the numeric town settings in display order.
+var Settings = []Setting{
+	{"duplicates-threshold", "town.duplicates_threshold", DefaultDuplicatesThreshold, "Duplicate issues tolerated by bd doctor in orchestrator mode"},
+	{"wisp-quota", "town.wisp_quota", DefaultWispQuota, "Maximum open wisps; bd mol wisp create refuses beyond it (0 = unlimited)"},
+	{"patrol-digest-threshold", "town.patrol_digest_threshold", DefaultPatrolDigestThreshold, "Patrol digest beads tolerated before bd doctor warns"},
+	{"session-bead-threshold", "town.session_bead_threshold", DefaultSessionBeadThreshold, "Session-ended beads tolerated before bd doctor warns"},
+}
+
+// LookupSetting finds a setting by CLI name or config key.
+func LookupSetting(name string) (Setting, bool) {
+	for _, s := range Settings {
+		if s.Name == name || s.Key == name {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// ParseSettingValue parses a numeric setting value, which must be a
+// non-negative integer.
+func ParseSettingValue(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a whole number", value)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("%d is negative", n)
+	}
+	return n, nil
+}
+
+// Agent is a registered town agent.
+type Agent struct {
+	Name string `json:"name"`
+	Role string `json:"role,omitempty"`
+}
+
+// ValidateName checks an agent name used as a key suffix.
+func ValidateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("name %q cannot contain whitespace", name)
+	}
+	return nil
+}
+
+// Config is the effective town configuration.
+type Config struct {
+	DuplicatesThreshold   int     `json:"duplicates_threshold"`
+	WispQuota             int     `json:"wisp_quota"`
+	PatrolDigestThreshold int     `json:"patrol_digest_threshold"`
+	SessionBeadThreshold  int     `json:"session_bead_threshold"`
+	Agents                []Agent `json:"agents"`
+}
+
+// Defaults returns the configuration with nothing set.
+func Defaults() Config {
+	return FromConfig(nil)
+}
+
+// FromConfig builds the effective configuration from the database config
+// map (as returned by GetAllConfig). Unparsable values fall back to their
+// defaults, so a bad write cannot break doctor or wisp creation.
+func FromConfig(all map[string]string) Config {
+	cfg := Config{Agents: []Agent{}}
+	for _, s := range Settings {
+		n := s.Default
+		if v, ok := all[s.Key]; ok {
+			if parsed, err := ParseSettingValue(v); err == nil {
+				n = parsed
+			}
+		}
+		*cfg.field(s.Key) = n
+	}
+	for key, value := range all {
+		if name, ok := strings.CutPrefix(key, AgentPrefix); ok && name != "" {
+			cfg.Agents = append(cfg.Agents, Agent{Name: name, Role: value})
+		}
+	}
+	sort.Slice(cfg.Agents, func(i, j int) bool { return cfg.Agents[i].Name < cfg.Agents[j].Name })
+	return cfg
+}
+
+// LegacyPatrol is a patrol schedule still stored under PatrolPrefix.
+type LegacyPatrol struct {
+	Key      string `json:"key"`
+	Formula  string `json:"formula"`
+	Interval string `json:"interval"`
+}
+
+// LegacyPatrols returns the patrol schedules held in town.patrol.* keys of
+// the database config map, ordered by formula. Values are not validated;
+// callers parse them with ParseSchedule.
+func LegacyPatrols(all map[string]string) []LegacyPatrol {
+	var patrols []LegacyPatrol
+	for key, value := range all {
+		if formula, ok := strings.CutPrefix(key, PatrolPrefix); ok && formula != "" {
+			patrols = append(patrols, LegacyPatrol{Key: key, Formula: formula, Interval: strings.TrimSpace(value)})
+		}
+	}
+	sort.Slice(patrols, func(i, j int) bool { return patrols[i].Formula < patrols[j].Formula })
+	return patrols
+}
+
+// Value returns the effective value of a numeric setting.
+func (c Config) Value(s Setting) int {
+	if p := c.field(s.Key); p != nil {
+		return *p
+	}
+	return s.Default
+}
+
+// field maps a numeric setting's config key to its Config field.
+func (c *Config) field(key string) *int {
+	switch key {
+	case "town.duplicates_threshold":
+		return &c.DuplicatesThreshold
+	case "town.wisp_quota":
+		return &c.WispQuota
+	case "town.patrol_digest_threshold":
+		return &c.PatrolDigestThreshold
+	case "town.session_bead_threshold":
+		return &c.SessionBeadThreshold
+	}
+	return nil
+}
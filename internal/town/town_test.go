@@ -0,0 +1,84 @@
+package town
+
+import (
+	"testing"
+)
+
+func TestFromConfig(t *testing.T) {
+	cfg := FromConfig(map[string]string{
+		"town.duplicates_threshold":   "25",
+		"town.wisp_quota":             "not-a-number", // falls back to default
+		"town.session_bead_threshold": "-3",           // falls back to default
+		"town.agent.witness":          "monitor",
+		"town.agent.deacon":           "",
+		"issue_prefix":                "bd",
+	})
+
+	if cfg.DuplicatesThreshold != 25 {
+		t.Errorf("DuplicatesThreshold = %d, want 25", cfg.DuplicatesThreshold)
+	}
+	if cfg.WispQuota != DefaultWispQuota {
+		t.Errorf("WispQuota = %d, want default %d", cfg.WispQuota, DefaultWispQuota)
+	}
+	if cfg.SessionBeadThreshold != DefaultSessionBeadThreshold {
+		t.Errorf("SessionBeadThreshold = %d, want default %d", cfg.SessionBeadThreshold, DefaultSessionBeadThreshold)
+	}
+	if cfg.PatrolDigestThreshold != DefaultPatrolDigestThreshold {
+		t.Errorf("PatrolDigestThreshold = %d, want default %d", cfg.PatrolDigestThreshold, DefaultPatrolDigestThreshold)
+	}
+
+	if len(cfg.Agents) != 2 || cfg.Agents[0] != (Agent{Name: "deacon"}) || cfg.Agents[1] != (Agent{Name: "witness", Role: "monitor"}) {
+		t.Errorf("Agents = %+v, want deacon then witness/monitor", cfg.Agents)
+	}
+}
+
+func TestLookupSetting(t *testing.T) {
+	for _, name := range []string{"wisp-quota", "town.wisp_quota"} {
+		s, ok := LookupSetting(name)
+		if !ok || s.Key != "town.wisp_quota" {
+			t.Errorf("LookupSetting(%q) = %+v, %v", name, s, ok)
+		}
+		if got := Defaults().Value(s); got != DefaultWispQuota {
+			t.Errorf("Defaults().Value(%s) = %d, want %d", s.Name, got, DefaultWispQuota)
+		}
+	}
+	if _, ok := LookupSetting("bogus"); ok {
+		t.Error("LookupSetting(bogus) found a setting")
+	}
+}
+
+func TestLegacyPatrols(t *testing.T) {
+	got := LegacyPatrols(map[string]string{
+		"town.patrol.mol-b-patrol": "6h",
+		"town.patrol.mol-a-patrol": " 30m ",
+		"town.patrol.mol-bad":      "5s", // returned; the caller rejects it
+		"town.patrol.":             "1h",
+		"town.agent.witness":       "monitor",
+	})
+	want := []LegacyPatrol{
+		{Key: "town.patrol.mol-a-patrol", Formula: "mol-a-patrol", Interval: "30m"},
+		{Key: "town.patrol.mol-b-patrol", Formula: "mol-b-patrol", Interval: "6h"},
+		{Key: "town.patrol.mol-bad", Formula: "mol-bad", Interval: "5s"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("LegacyPatrols = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("LegacyPatrols[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Every interval the old keys accepted is still a valid schedule.
+	for _, p := range got[:2] {
+		if _, err := ParseSchedule(p.Interval); err != nil {
+			t.Errorf("ParseSchedule(%q): %v", p.Interval, err)
+		}
+	}
+	if _, err := ParseSchedule(got[2].Interval); err == nil {
+		t.Errorf("ParseSchedule(%q) succeeded; the old keys rejected it", got[2].Interval)
+	}
+	if LegacyPatrols(nil) != nil {
+		t.Error("LegacyPatrols(nil) should be empty")
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Assignment strategies for AssignmentRule.
+const (
+	AssignStrategyFixed       = "fixed"        // always the first assignee
+	AssignStrategyRoundRobin  = "round-robin"  // each assignee in turn
+	AssignStrategyLeastLoaded = "least-loaded" // the assignee with the fewest open and in-progress issues
+)
+
+// AssignmentRule picks an assignee for new or unassigned issues (bd create,
+// bd assign --auto). Empty conditions match anything; a rule with no
+// conditions is a catch-all.
+type AssignmentRule struct {
+	Name       string    `json:"name"`
+	Priority   int       `json:"priority"` // lower is tried first
+	Label      string    `json:"label,omitempty"`
+	PathPrefix string    `json:"path_prefix,omitempty"` // slash-separated, relative to the repository root
+	IssueType  string    `json:"issue_type,omitempty"`
+	Strategy   string    `json:"strategy"`
+	Assignees  []string  `json:"assignees"`
+	NextIndex  int       `json:"next_index,omitempty"` // round-robin cursor
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IsValidAssignStrategy reports whether s is a known assignment strategy.
+func IsValidAssignStrategy(s string) bool {
+	switch s {
+	case AssignStrategyFixed, AssignStrategyRoundRobin, AssignStrategyLeastLoaded:
+		return true
+	}
+	return false
+}
+
+// Validate checks that the rule has a name, a known strategy, and at least
+// one assignee.
+func (r *AssignmentRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("assignment rule needs a name")
+	}
+	if !IsValidAssignStrategy(r.Strategy) {
+		return fmt.Errorf("invalid assignment strategy %q (must be %s, %s, or %s)",
+			r.Strategy, AssignStrategyFixed, AssignStrategyRoundRobin, AssignStrategyLeastLoaded)
+	}
+	if len(r.Assignees) == 0 {
+		return fmt.Errorf("assignment rule %s needs at least one assignee", r.Name)
+	}
+	return nil
+}
+
+// Matches reports whether issue, whose repository-relative path is
+// issuePath ("" when unknown), satisfies every condition of the rule.
+func (r *AssignmentRule) Matches(issue *Issue, issuePath string) bool {
+	if r.IssueType != "" && string(issue.IssueType) != r.IssueType {
+		return false
+	}
+	if r.Label != "" {
+		found := false
+		for _, l := range issue.Labels {
+			if l == r.Label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if r.PathPrefix != "" {
+		prefix := path.Clean(r.PathPrefix)
+		issuePath = path.Clean(strings.ReplaceAll(issuePath, "\\", "/"))
+		if issuePath != prefix && !strings.HasPrefix(issuePath, prefix+"/") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package types
+
+import "testing"
+
+func TestAssignmentRuleMatches(t *testing.T) {
+	bug := &Issue{IssueType: TypeBug, Labels: []string{"frontend", "p1"}}
+	tests := []struct {
+		name string
+		rule AssignmentRule
+		path string
+		want bool
+	}{
+		{"catch-all", AssignmentRule{}, "", true},
+		{"label", AssignmentRule{Label: "frontend"}, "", true},
+		{"missing label", AssignmentRule{Label: "backend"}, "", false},
+		{"type", AssignmentRule{IssueType: "bug"}, "", true},
+		{"other type", AssignmentRule{IssueType: "feature"}, "", false},
+		{"path under prefix", AssignmentRule{PathPrefix: "apps/web/"}, "apps/web/src", true},
+		{"path equals prefix", AssignmentRule{PathPrefix: "apps/web"}, "apps/web", true},
+		{"sibling path", AssignmentRule{PathPrefix: "apps/web"}, "apps/webby", false},
+		{"unknown path", AssignmentRule{PathPrefix: "apps/web"}, "", false},
+		{"all conditions", AssignmentRule{Label: "frontend", IssueType: "bug", PathPrefix: "apps"}, "apps/web", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(bug, tt.path); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentRuleValidate(t *testing.T) {
+	ok := AssignmentRule{Name: "web", Strategy: AssignStrategyRoundRobin, Assignees: []string{"alice"}}
+	if err := ok.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	for _, bad := range []AssignmentRule{
+		{Strategy: AssignStrategyFixed, Assignees: []string{"alice"}},
+		{Name: "web", Strategy: "random", Assignees: []string{"alice"}},
+		{Name: "web", Strategy: AssignStrategyFixed},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxExternalURLRefLength is the longest URL a dependency can point at; it
+// is the width of the dependencies.depends_on_external column.
+const MaxExternalURLRefLength = 255
+
+// IsExternalURLRef reports whether ref is a dependency target outside beads
+// given as an http(s) URL, e.g. a GitHub issue or a Jira ticket.
+func IsExternalURLRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://")
+}
+
+// ValidateExternalURLRef checks that ref is an absolute http(s) URL with a
+// host that fits in a dependency row.
+func ValidateExternalURLRef(ref string) error {
+	if len(ref) > MaxExternalURLRefLength {
+		return fmt.Errorf("external URL is %d characters; the limit is %d", len(ref), MaxExternalURLRefLength)
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return fmt.Errorf("invalid external URL %q: %w", ref, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid external URL %q: expected http(s)://host/...", ref)
+	}
+	if strings.ContainsAny(ref, " \t\n") {
+		return fmt.Errorf("invalid external URL %q: contains whitespace", ref)
+	}
+	return nil
+}
+
+// ExternalRef is the tracked state of a URL that issues depend on. Beads
+// cannot see the status of a GitHub issue or a Jira ticket, so an external
+// URL counts as unknown (and blocks) until someone marks it resolved.
+type ExternalRef struct {
+	URL        string     `json:"url"`
+	Resolved   bool       `json:"resolved"`
+	ResolvedBy string     `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ExternalDependency is an issue's dependency on an external URL, as shown
+// by bd show.
+type ExternalDependency struct {
+	ExternalRef
+	Type DependencyType `json:"type"`
+}
@@ -0,0 +1,28 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateExternalURLRef(t *testing.T) {
+	for _, ref := range []string{"https://github.com/org/repo/issues/42", "http://jira.example.com/browse/PROJ-12", "HTTPS://Example.com/x"} {
+		if !IsExternalURLRef(ref) {
+			t.Errorf("IsExternalURLRef(%q) = false, want true", ref)
+		}
+	}
+	for _, ref := range []string{"bd-a1b2", "platform/bd-a1b2", "external:beads:cap", "ftp://host/x", "github.com/org/repo"} {
+		if IsExternalURLRef(ref) {
+			t.Errorf("IsExternalURLRef(%q) = true, want false", ref)
+		}
+	}
+
+	if err := ValidateExternalURLRef("https://github.com/org/repo/issues/42"); err != nil {
+		t.Errorf("valid URL rejected: %v", err)
+	}
+	for _, ref := range []string{"https://", "https:///path", "https://host/a b", "https://host/" + strings.Repeat("x", MaxExternalURLRefLength)} {
+		if err := ValidateExternalURLRef(ref); err == nil {
+			t.Errorf("ValidateExternalURLRef(%q) = nil, want error", ref)
+		}
+	}
+}
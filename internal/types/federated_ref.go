@@ -0,0 +1,30 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// federatedPeerRegexp matches a federation peer (Dolt remote) name.
+var federatedPeerRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ParseFederatedRef splits a cross-workspace dependency target of the form
+// "<peer>/<issue-id>", e.g. "platform/bd-a1b2", where <peer> is a federation
+// peer. ok is false for anything else, including local IDs and
+// "external:<project>:<capability>" references.
+func ParseFederatedRef(ref string) (peer, id string, ok bool) {
+	peer, id, found := strings.Cut(ref, "/")
+	if !found || !federatedPeerRegexp.MatchString(peer) {
+		return "", "", false
+	}
+	if id == "" || strings.ContainsAny(id, "/: \t") {
+		return "", "", false
+	}
+	return peer, id, true
+}
+
+// IsFederatedRef reports whether ref names an issue in a federation peer.
+func IsFederatedRef(ref string) bool {
+	_, _, ok := ParseFederatedRef(ref)
+	return ok
+}
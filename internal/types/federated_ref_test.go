@@ -0,0 +1,18 @@
+package types
+
+import "testing"
+
+func TestParseFederatedRef(t *testing.T) {
+	peer, id, ok := ParseFederatedRef("platform/bd-a1b2")
+	if !ok || peer != "platform" || id != "bd-a1b2" {
+		t.Errorf("ParseFederatedRef(platform/bd-a1b2) = %q, %q, %v", peer, id, ok)
+	}
+	if _, id, ok := ParseFederatedRef("town-beta.eu/gt-x.1"); !ok || id != "gt-x.1" {
+		t.Errorf("dotted peer and child ID should parse, got %q, %v", id, ok)
+	}
+	for _, ref := range []string{"bd-a1b2", "external:beads:cap", "/bd-a1b2", "platform/", "a/b/c", "-peer/bd-1", "peer/bd 1"} {
+		if IsFederatedRef(ref) {
+			t.Errorf("IsFederatedRef(%q) = true, want false", ref)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Patrol is a recurring patrol: whenever Schedule comes due, bd patrol run
+// creates a wisp from Formula assigned to Agent. The Last* fields track the
+// current cycle.
+type Patrol struct {
+	Name            string     `json:"name"`
+	Formula         string     `json:"formula"`
+	Agent           string     `json:"agent,omitempty"`
+	Schedule        string     `json:"schedule"` // cron expression, @hourly-style descriptor, or interval ("30m", "@every 30m")
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastWispID      string     `json:"last_wisp_id,omitempty"`
+	LastCompletedAt *time.Time `json:"last_completed_at,omitempty"`
+	MissedCount     int        `json:"missed_count"`
+	CreatedBy       string     `json:"created_by,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Validate checks that the patrol has a name, a formula, and a schedule.
+// The schedule syntax is checked by the caller that parses it.
+func (p *Patrol) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("patrol needs a name")
+	}
+	if strings.ContainsAny(p.Name, " \t\r\n") {
+		return fmt.Errorf("patrol name %q cannot contain whitespace", p.Name)
+	}
+	if strings.TrimSpace(p.Formula) == "" {
+		return fmt.Errorf("patrol %s needs a formula", p.Name)
+	}
+	if strings.TrimSpace(p.Schedule) == "" {
+		return fmt.Errorf("patrol %s needs a schedule", p.Name)
+	}
+	return nil
+}
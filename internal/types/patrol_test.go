@@ -0,0 +1,24 @@
+package types
+
+import "testing"
+
+func TestPatrolValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		patrol  Patrol
+		wantErr bool
+	}{
+		{"valid", Patrol{Name: "deacon", Formula: "mol-deacon-patrol", Schedule: "30m"}, false},
+		{"no name", Patrol{Formula: "mol-deacon-patrol", Schedule: "30m"}, true},
+		{"name with space", Patrol{Name: "dea con", Formula: "mol-deacon-patrol", Schedule: "30m"}, true},
+		{"no formula", Patrol{Name: "deacon", Schedule: "30m"}, true},
+		{"no schedule", Patrol{Name: "deacon", Formula: "mol-deacon-patrol"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.patrol.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
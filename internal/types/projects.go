@@ -0,0 +1,85 @@
+package types
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ProjectPrefix maps a monorepo sub-project directory to the ID prefix its
+// issues use. Dir is slash-separated and relative to the repository root.
+type ProjectPrefix struct {
+	Prefix string `json:"prefix"`
+	Dir    string `json:"dir"`
+}
+
+// ParseProjectPrefixes parses the projects config value, a comma-separated
+// list of "prefix:dir" pairs, e.g. "web:apps/web,api:services/api". The
+// result is sorted by prefix.
+func ParseProjectPrefixes(value string) ([]ProjectPrefix, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	var projects []ProjectPrefix
+	prefixes := make(map[string]bool)
+	dirs := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		prefix, dir, ok := strings.Cut(part, ":")
+		prefix, dir = strings.TrimSpace(prefix), strings.TrimSpace(dir)
+		if !ok || prefix == "" || dir == "" {
+			return nil, fmt.Errorf("invalid project mapping %q: want prefix:dir", part)
+		}
+		if strings.HasSuffix(prefix, "-") || strings.ContainsAny(prefix, " \t/") {
+			return nil, fmt.Errorf("invalid project prefix %q", prefix)
+		}
+		dir = path.Clean(strings.ReplaceAll(dir, "\\", "/"))
+		if path.IsAbs(dir) || dir == "." || dir == ".." || strings.HasPrefix(dir, "../") {
+			return nil, fmt.Errorf("invalid project directory %q for %s: must be relative to the repository root", dir, prefix)
+		}
+		if prefixes[prefix] {
+			return nil, fmt.Errorf("duplicate project prefix %q", prefix)
+		}
+		if other, dup := dirs[dir]; dup {
+			return nil, fmt.Errorf("directory %q is mapped to both %s and %s", dir, other, prefix)
+		}
+		prefixes[prefix] = true
+		dirs[dir] = prefix
+		projects = append(projects, ProjectPrefix{Prefix: prefix, Dir: dir})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Prefix < projects[j].Prefix })
+	return projects, nil
+}
+
+// MatchProjectPrefix returns the project whose directory contains relDir, a
+// slash-separated path relative to the repository root. When directories
+// nest, the deepest one wins.
+func MatchProjectPrefix(projects []ProjectPrefix, relDir string) (ProjectPrefix, bool) {
+	relDir = path.Clean(strings.ReplaceAll(relDir, "\\", "/"))
+	var best ProjectPrefix
+	found := false
+	for _, p := range projects {
+		if relDir != p.Dir && !strings.HasPrefix(relDir, p.Dir+"/") {
+			continue
+		}
+		if !found || len(p.Dir) > len(best.Dir) {
+			best, found = p, true
+		}
+	}
+	return best, found
+}
+
+// ProjectPrefixList returns the prefixes of projects as a comma-separated
+// list in the format of the allowed_prefixes config.
+func ProjectPrefixList(projects []ProjectPrefix) string {
+	prefixes := make([]string, len(projects))
+	for i, p := range projects {
+		prefixes[i] = p.Prefix
+	}
+	return strings.Join(prefixes, ",")
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProjectPrefixes(t *testing.T) {
+	projects, err := ParseProjectPrefixes("web:apps/web/, api:services/api,ui:apps/web/ui")
+	if err != nil {
+		t.Fatalf("ParseProjectPrefixes: %v", err)
+	}
+	want := []ProjectPrefix{{"api", "services/api"}, {"ui", "apps/web/ui"}, {"web", "apps/web"}}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("projects = %v, want %v", projects, want)
+	}
+	if got := ProjectPrefixList(projects); got != "api,ui,web" {
+		t.Errorf("ProjectPrefixList = %q", got)
+	}
+
+	for _, bad := range []string{"web", "web:", ":apps/web", "web-:apps/web", "web:/abs", "web:../up", "web:.", "web:a,web:b", "web:a,api:a"} {
+		if _, err := ParseProjectPrefixes(bad); err == nil {
+			t.Errorf("ParseProjectPrefixes(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMatchProjectPrefix(t *testing.T) {
+	projects, err := ParseProjectPrefixes("web:apps/web,ui:apps/web/ui,api:services/api")
+	if err != nil {
+		t.Fatalf("ParseProjectPrefixes: %v", err)
+	}
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"apps/web", "web"},
+		{"apps/web/src/pages", "web"},
+		{"apps/web/ui/button", "ui"},
+		{"apps/webby", ""},
+		{"services/api", "api"},
+		{".", ""},
+		{"../elsewhere", ""},
+	}
+	for _, tt := range tests {
+		p, ok := MatchProjectPrefix(projects, tt.dir)
+		if got := p.Prefix; got != tt.want || ok != (tt.want != "") {
+			t.Errorf("MatchProjectPrefix(%q) = %q, %v; want %q", tt.dir, got, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// StatusTransitions is the set of allowed status changes configured via
+// status.transitions. A nil StatusTransitions allows every change.
+type StatusTransitions map[Status][]Status
+
+// StatusWildcard matches any status on either side of a transition.
+const StatusWildcard Status = "*"
+
+// ParseStatusTransitions parses a status.transitions config value of
+// comma-separated "from->to" pairs, e.g. "open->in_review,in_review->closed".
+// Either side may be "*" to match any status. An empty value returns nil,
+// which allows every transition.
+func ParseStatusTransitions(value string) (StatusTransitions, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t := make(StatusTransitions)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(part, "->")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid status transition %q: want from->to", part)
+		}
+		t[Status(from)] = append(t[Status(from)], Status(to))
+	}
+	return t, nil
+}
+
+// Allows reports whether an issue may move from one status to another.
+// Staying in the same status is always allowed.
+func (t StatusTransitions) Allows(from, to Status) bool {
+	if t == nil || from == to {
+		return true
+	}
+	for _, key := range []Status{from, StatusWildcard} {
+		for _, target := range t[key] {
+			if target == to || target == StatusWildcard {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Targets returns the statuses reachable from the given status, sorted.
+func (t StatusTransitions) Targets(from Status) []Status {
+	seen := make(map[Status]bool)
+	for _, key := range []Status{from, StatusWildcard} {
+		for _, target := range t[key] {
+			seen[target] = true
+		}
+	}
+	targets := make([]Status, 0, len(seen))
+	for s := range seen {
+		targets = append(targets, s)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+	return targets
+}
+
+// Statuses returns every concrete status the transitions mention.
+func (t StatusTransitions) Statuses() []Status {
+	seen := make(map[Status]bool)
+	for from, targets := range t {
+		seen[from] = true
+		for _, to := range targets {
+			seen[to] = true
+		}
+	}
+	delete(seen, StatusWildcard)
+	statuses := make([]Status, 0, len(seen))
+	for s := range seen {
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses
+}
+
+// StatusMap renames statuses, e.g. when importing JSONL from a tracker
+// whose workflow states differ from this project's.
+type StatusMap map[Status]Status
+
+// ParseStatusMap parses a status.import-map or status.export-map config
+// value of comma-separated "from:to" pairs, e.g. "todo:open,doing:in_progress".
+func ParseStatusMap(value string) (StatusMap, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	m := make(StatusMap)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(part, ":")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid status mapping %q: want from:to", part)
+		}
+		if _, dup := m[Status(from)]; dup {
+			return nil, fmt.Errorf("duplicate status mapping for %q", from)
+		}
+		m[Status(from)] = Status(to)
+	}
+	return m, nil
+}
+
+// Apply returns the mapped status, or s unchanged when it has no mapping.
+func (m StatusMap) Apply(s Status) Status {
+	if to, ok := m[s]; ok {
+		return to
+	}
+	return s
+}
+
+// StatusPolicyPrefix starts the config keys of status policies, which name
+// the fields an issue must have to enter a status:
+//
+//	status.policy.<type>.<status> = field,field,...
+//
+// <type> may be "*" to apply to every issue type.
+const StatusPolicyPrefix = "status.policy."
+
+// statusPolicyFields are the fields a status policy can require.
+var statusPolicyFields = map[string]bool{
+	"assignee":            true,
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+	"close_reason":        true,
+	"external_ref":        true,
+	"estimate":            true,
+	"due":                 true,
+	"commit":              true,
+}
+
+// DefaultCloseReason is the reason bd close records when none is given. It
+// does not satisfy a close_reason policy.
+const DefaultCloseReason = "Closed"
+
+// commitHashRegexp matches an abbreviated or full git commit hash.
+var commitHashRegexp = regexp.MustCompile(`\b[0-9a-f]{7,40}\b`)
+
+// StatusPolicyKey returns the config key of the policy for entering status
+// as issueType.
+func StatusPolicyKey(issueType IssueType, status Status) string {
+	return StatusPolicyPrefix + string(issueType) + "." + string(status)
+}
+
+// ParseStatusPolicy validates a status policy key and parses its value, a
+// comma-separated list of required fields.
+func ParseStatusPolicy(key, value string) ([]string, error) {
+	rest := strings.TrimPrefix(key, StatusPolicyPrefix)
+	issueType, status, ok := strings.Cut(rest, ".")
+	if rest == key || !ok || issueType == "" || status == "" || strings.Contains(status, ".") {
+		return nil, fmt.Errorf("invalid status policy key %q: want %s<type>.<status>", key, StatusPolicyPrefix)
+	}
+	var fields []string
+	for _, f := range strings.Split(value, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !statusPolicyFields[f] {
+			return nil, fmt.Errorf("unknown field %q in %s (valid: %s)", f, key, strings.Join(StatusPolicyFields(), ", "))
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// StatusPolicyFields returns the fields a status policy can require, sorted.
+func StatusPolicyFields() []string {
+	fields := make([]string, 0, len(statusPolicyFields))
+	for f := range statusPolicyFields {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MissingPolicyFields returns the required fields issue lacks. "commit" is
+// satisfied by a git commit hash in the close reason or notes, or by a
+// "commit" key in the metadata.
+func MissingPolicyFields(issue *Issue, required []string) []string {
+	var missing []string
+	for _, f := range required {
+		var ok bool
+		switch f {
+		case "assignee":
+			ok = strings.TrimSpace(issue.Assignee) != ""
+		case "description":
+			ok = strings.TrimSpace(issue.Description) != ""
+		case "design":
+			ok = strings.TrimSpace(issue.Design) != ""
+		case "acceptance_criteria":
+			ok = strings.TrimSpace(issue.AcceptanceCriteria) != ""
+		case "notes":
+			ok = strings.TrimSpace(issue.Notes) != ""
+		case "close_reason":
+			reason := strings.TrimSpace(issue.CloseReason)
+			ok = reason != "" && reason != DefaultCloseReason
+		case "external_ref":
+			ok = issue.ExternalRef != nil && strings.TrimSpace(*issue.ExternalRef) != ""
+		case "estimate":
+			ok = issue.EstimatedMinutes != nil
+		case "due":
+			ok = issue.DueAt != nil
+		case "commit":
+			ok = mentionsCommit(issue.CloseReason) || mentionsCommit(issue.Notes) || hasCommitMetadata(issue.Metadata)
+		}
+		if !ok {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// mentionsCommit reports whether text contains something that looks like a
+// git commit hash. Requiring a digit keeps hex-only words like "defaced" out.
+func mentionsCommit(text string) bool {
+	for _, m := range commitHashRegexp.FindAllString(text, -1) {
+		if strings.ContainsAny(m, "0123456789") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCommitMetadata reports whether metadata has a non-empty "commit" key.
+func hasCommitMetadata(metadata json.RawMessage) bool {
+	var m map[string]interface{}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &m) != nil {
+		return false
+	}
+	v, ok := m["commit"]
+	return ok && v != nil && v != ""
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStatusTransitions(t *testing.T) {
+	tr, err := ParseStatusTransitions("open->in_review, in_review->closed,in_review->open,*->deferred")
+	if err != nil {
+		t.Fatalf("ParseStatusTransitions: %v", err)
+	}
+
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{"open", "in_review", true},
+		{"open", "closed", false},
+		{"in_review", "closed", true},
+		{"closed", "deferred", true},
+		{"closed", "closed", true},
+		{"closed", "open", false},
+	}
+	for _, tt := range tests {
+		if got := tr.Allows(tt.from, tt.to); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	if got, want := tr.Targets("in_review"), []Status{"closed", "deferred", "open"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Targets(in_review) = %v, want %v", got, want)
+	}
+	if got, want := tr.Statuses(), []Status{"closed", "deferred", "in_review", "open"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Statuses() = %v, want %v", got, want)
+	}
+
+	var none StatusTransitions
+	if !none.Allows("open", "closed") {
+		t.Error("nil transitions should allow everything")
+	}
+	for _, bad := range []string{"open", "open->", "->closed", "open=>closed"} {
+		if _, err := ParseStatusTransitions(bad); err == nil {
+			t.Errorf("ParseStatusTransitions(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseStatusMap(t *testing.T) {
+	m, err := ParseStatusMap("todo:open, doing:in_progress,done:closed")
+	if err != nil {
+		t.Fatalf("ParseStatusMap: %v", err)
+	}
+	if got := m.Apply("doing"); got != StatusInProgress {
+		t.Errorf("Apply(doing) = %q", got)
+	}
+	if got := m.Apply("blocked"); got != StatusBlocked {
+		t.Errorf("Apply(blocked) = %q, want unchanged", got)
+	}
+	var none StatusMap
+	if got := none.Apply("todo"); got != "todo" {
+		t.Errorf("nil map Apply(todo) = %q", got)
+	}
+	for _, bad := range []string{"todo", "todo:", "todo:open,todo:closed"} {
+		if _, err := ParseStatusMap(bad); err == nil {
+			t.Errorf("ParseStatusMap(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseStatusPolicy(t *testing.T) {
+	fields, err := ParseStatusPolicy("status.policy.bug.closed", "close_reason, commit")
+	if err != nil {
+		t.Fatalf("ParseStatusPolicy: %v", err)
+	}
+	if want := []string{"close_reason", "commit"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if _, err := ParseStatusPolicy("status.policy.*.in_progress", "assignee"); err != nil {
+		t.Errorf("wildcard type: %v", err)
+	}
+	for _, bad := range [][2]string{
+		{"status.policy.bug", "assignee"},
+		{"status.policy.bug.closed.x", "assignee"},
+		{"status.policy..closed", "assignee"},
+		{"status.policy.bug.closed", "owner"},
+	} {
+		if _, err := ParseStatusPolicy(bad[0], bad[1]); err == nil {
+			t.Errorf("ParseStatusPolicy(%q, %q) should fail", bad[0], bad[1])
+		}
+	}
+}
+
+func TestMissingPolicyFields(t *testing.T) {
+	required := []string{"assignee", "close_reason", "commit"}
+	issue := &Issue{CloseReason: "Fixed"}
+	if got, want := MissingPolicyFields(issue, required), []string{"assignee", "commit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingPolicyFields = %v, want %v", got, want)
+	}
+
+	issue = &Issue{Assignee: "alice", CloseReason: "Fixed in a1b2c3d"}
+	if got := MissingPolicyFields(issue, required); len(got) != 0 {
+		t.Errorf("hash in close reason: missing %v", got)
+	}
+	issue = &Issue{Assignee: "alice", CloseReason: "Defaced page restored", Metadata: []byte(`{"commit":"a1b2c3d"}`)}
+	if got := MissingPolicyFields(issue, required); len(got) != 0 {
+		t.Errorf("commit in metadata: missing %v", got)
+	}
+	issue = &Issue{Assignee: "alice", CloseReason: "Defaced page restored"}
+	if got := MissingPolicyFields(issue, []string{"commit"}); len(got) != 1 {
+		t.Errorf("hex-only word should not count as a commit, missing %v", got)
+	}
+	issue = &Issue{CloseReason: DefaultCloseReason}
+	if got := MissingPolicyFields(issue, []string{"close_reason"}); len(got) != 1 {
+		t.Errorf("default close reason should not satisfy close_reason, missing %v", got)
+	}
+}
@@ -23,3 +23,96 @@ type DeleteIssuesResult struct {
 	EventsCount       int
 	OrphanedIssues    []string
 }
+
+// RenameResult describes an issue rename: every table and column whose rows
+// moved from OldID to NewID, whether written directly or carried along by an
+// ON UPDATE CASCADE foreign key.
+type RenameResult struct {
+	OldID  string         `json:"old_id"`
+	NewID  string         `json:"new_id"`
+	Wisp   bool           `json:"wisp,omitempty"`
+	Tables []RenamedTable `json:"tables"`
+}
+
+// RenamedTable counts the rows of one table column moved by a rename.
+type RenamedTable struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	Rows   int    `json:"rows"`
+}
+
+// MoveResult describes issues moved under a new parent. Renames maps the old
+// ID to the new ID of every issue renumbered under the new parent, including
+// descendants that kept their place under a renamed issue.
+type MoveResult struct {
+	Parent  string            `json:"parent"`
+	Moves   []IssueMove       `json:"moves"`
+	Renames map[string]string `json:"renames,omitempty"`
+}
+
+// IssueMove is one issue moved by a MoveResult. NewID is set when the issue
+// was renumbered under its new parent.
+type IssueMove struct {
+	ID        string `json:"id"`
+	NewID     string `json:"new_id,omitempty"`
+	OldParent string `json:"old_parent,omitempty"`
+}
+
+// CompactionSnapshot is a copy of an issue taken just before it was
+// compacted to Level, so the original content can be restored.
+type CompactionSnapshot struct {
+	ID        string
+	IssueID   string
+	Level     int
+	Issue     *Issue
+	CreatedAt time.Time
+}
+
+// SnapshotTableUsage summarizes one snapshot table (issue_snapshots or
+// compaction_snapshots). Bytes counts the stored snapshot payload.
+type SnapshotTableUsage struct {
+	Table  string `json:"table"`
+	Rows   int    `json:"rows"`
+	Issues int    `json:"issues"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// SnapshotPruneResult reports how many rows a prune removed (or would
+// remove, for a dry run) from one snapshot table.
+type SnapshotPruneResult struct {
+	Table  string `json:"table"`
+	Pruned int    `json:"pruned"`
+	Kept   int    `json:"kept"`
+	Bytes  int64  `json:"bytes_pruned"`
+}
+
+// ChildCounterStatus compares a parent's child_counters row with the
+// hierarchical children (parent.N) that actually exist.
+type ChildCounterStatus struct {
+	ParentID     string `json:"parent_id"`
+	LastChild    int    `json:"last_child"`    // counter value; 0 when there is no row
+	HasCounter   bool   `json:"has_counter"`   // a child_counters row exists
+	MaxChild     int    `json:"max_child"`     // highest existing direct child number
+	Children     int    `json:"children"`      // number of existing direct children
+	ParentExists bool   `json:"parent_exists"` // the parent issue exists
+}
+
+// Behind reports whether the counter trails an existing child, so it would
+// hand out a number that is already taken if the child scan were skipped.
+func (c *ChildCounterStatus) Behind() bool {
+	return c.Children > 0 && c.LastChild < c.MaxChild
+}
+
+// Orphaned reports whether the counter belongs to a parent that no longer
+// exists.
+func (c *ChildCounterStatus) Orphaned() bool {
+	return c.HasCounter && !c.ParentExists
+}
+
+// IssueAlias is a memorable name that resolves to an issue ID.
+type IssueAlias struct {
+	Alias     string    `json:"alias"`
+	IssueID   string    `json:"issue_id"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
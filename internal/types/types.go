@@ -747,6 +747,11 @@ type IssueWithCounts struct {
 	DependentCount  int     `json:"dependent_count"`
 	CommentCount    int     `json:"comment_count"`
 	Parent          *string `json:"parent,omitempty"` // Computed parent from parent-child dep (bd-ym8c)
+	CIStatus        string  `json:"ci_status,omitempty"`
+	// Progress is the child roll-up for epics and molecule roots (bd list only).
+	Progress *ChildProgress `json:"progress,omitempty"`
+	// BlockingCount is the number of open issues this one blocks (bd list only).
+	BlockingCount int `json:"blocking_count,omitempty"`
 }
 
 // IssueDetails extends Issue with labels, dependencies, dependents, and comments.
@@ -757,6 +762,10 @@ type IssueDetails struct {
 	Dependencies []*IssueWithDependencyMetadata `json:"dependencies,omitempty"`
 	Dependents   []*IssueWithDependencyMetadata `json:"dependents,omitempty"`
 	Comments     []*Comment                     `json:"comments,omitempty"`
+	Commits      []*CommitLink                  `json:"commits,omitempty"`
+	CIStatus     string                         `json:"ci_status,omitempty"`
+	CIRuns       []*CIRun                       `json:"ci_runs,omitempty"`
+	ExternalDeps []*ExternalDependency          `json:"external_dependencies,omitempty"`
 	Parent       *string                        `json:"parent,omitempty"`
 
 	// Cardinality fields — emitted by default (count-only mode).
@@ -770,6 +779,7 @@ type IssueDetails struct {
 	EpicTotalChildren  *int  `json:"epic_total_children,omitempty"`
 	EpicClosedChildren *int  `json:"epic_closed_children,omitempty"`
 	EpicCloseable      *bool `json:"epic_closeable,omitempty"`
+	EpicPercent        *int  `json:"epic_percent_complete,omitempty"`
 }
 
 // DependencyType categorizes the relationship
@@ -809,6 +819,9 @@ const (
 
 	// Delegation types (work delegation chains)
 	DepDelegatedFrom DependencyType = "delegated-from" // Work delegated from parent; completion cascades up
+
+	// Ordering hints (never block; break ready-work ties)
+	DepSuggests DependencyType = "suggests" // Prefer finishing the target first
 )
 
 // IsValid checks if the dependency type value is valid.
@@ -825,7 +838,7 @@ func WellKnownDependencyTypes() []DependencyType {
 		DepBlocks, DepParentChild, DepConditionalBlocks, DepWaitsFor, DepRelated, DepDiscoveredFrom,
 		DepRepliesTo, DepRelatesTo, DepDuplicates, DepSupersedes,
 		DepAuthoredBy, DepAssignedTo, DepApprovedBy, DepAttests, DepTracks,
-		DepUntil, DepCausedBy, DepValidates, DepDelegatedFrom,
+		DepUntil, DepCausedBy, DepValidates, DepDelegatedFrom, DepSuggests,
 	}
 }
 
@@ -978,6 +991,118 @@ func (c *Comment) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Reference source kinds and types recorded in IssueReference.
+const (
+	ReferenceSourceDescription = "description"
+	ReferenceSourceComment     = "comment"
+
+	ReferenceTypeIssue = "issue"
+	ReferenceTypeActor = "actor"
+)
+
+// IssueReference is one issue-ID reference or @actor mention parsed from an
+// issue's description or one of its comments.
+type IssueReference struct {
+	SourceID   string `json:"source_id"`            // issue whose text holds the reference
+	SourceKind string `json:"source_kind"`          // description or comment
+	SourceRef  string `json:"source_ref,omitempty"` // comment ID for comment sources
+	RefType    string `json:"ref_type"`             // issue or actor
+	Target     string `json:"target"`               // referenced issue ID or actor name
+}
+
+// IssueLock is an advisory lock on an issue (bd lock). While it is held and
+// unexpired, update, close, and delete by actors other than Holder are
+// refused unless forced.
+type IssueLock struct {
+	IssueID   string     `json:"issue_id"`
+	Holder    string     `json:"holder"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // nil = until unlocked
+}
+
+// Expired reports whether the lock has lapsed at now.
+func (l *IssueLock) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// CommitLink records a git commit that touched an issue: one whose message
+// referenced it (bd git scan, git hooks) or one linked by hand with
+// bd link commit.
+type CommitLink struct {
+	IssueID   string    `json:"issue_id"`
+	CommitSHA string    `json:"commit_sha"`
+	Action    string    `json:"action"`            // "closes", "linked" (closing ref, issue already closed), or "references"
+	Summary   string    `json:"summary,omitempty"` // commit subject line
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CI run statuses reported by bd ci report.
+const (
+	CIStatusSuccess   = "success"
+	CIStatusFailure   = "failure"
+	CIStatusPending   = "pending"
+	CIStatusCancelled = "cancelled"
+)
+
+// CIRun is a CI result reported against an issue for one workflow on one
+// commit (bd ci report). A later report for the same workflow and commit
+// replaces it.
+type CIRun struct {
+	IssueID    string    `json:"issue_id"`
+	Name       string    `json:"name"` // workflow or job name
+	CommitSHA  string    `json:"commit_sha"`
+	Branch     string    `json:"branch,omitempty"`
+	Status     string    `json:"status"` // success, failure, pending, or cancelled
+	URL        string    `json:"url,omitempty"`
+	ReportedBy string    `json:"reported_by,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// IsValidCIStatus reports whether s is a known CI run status.
+func IsValidCIStatus(s string) bool {
+	switch s {
+	case CIStatusSuccess, CIStatusFailure, CIStatusPending, CIStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// LatestCIRuns returns the runs for the most recently reported commit in
+// runs, which is how CI status is judged: older commits were superseded.
+func LatestCIRuns(runs []*CIRun) []*CIRun {
+	var latest *CIRun
+	for _, r := range runs {
+		if latest == nil || r.UpdatedAt.After(latest.UpdatedAt) {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	var out []*CIRun
+	for _, r := range runs {
+		if r.CommitSHA == latest.CommitSHA {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// CIStatusSummary rolls runs up into one status for their latest commit:
+// failure if any run failed, else pending if any is still running, else
+// cancelled if any was cancelled, else success. Empty when runs is empty.
+func CIStatusSummary(runs []*CIRun) string {
+	summary := ""
+	rank := map[string]int{CIStatusSuccess: 1, CIStatusCancelled: 2, CIStatusPending: 3, CIStatusFailure: 4}
+	for _, r := range LatestCIRuns(runs) {
+		if rank[r.Status] > rank[summary] {
+			summary = r.Status
+		}
+	}
+	return summary
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        string    `json:"id"`
@@ -1006,6 +1131,9 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventCIReported        EventType = "ci_reported"
+	EventPriorityAged      EventType = "priority_aged"
+	EventMoved             EventType = "moved"
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -1411,6 +1539,40 @@ type EpicStatus struct {
 	EligibleForClose bool   `json:"eligible_for_close"`
 }
 
+// ChildProgress rolls up an epic or molecule root's parent-child children.
+type ChildProgress struct {
+	Total   int `json:"total"`
+	Closed  int `json:"closed"`
+	Percent int `json:"percent"` // Closed*100/Total, rounded down
+}
+
+// NewChildProgress builds a ChildProgress with Percent filled in.
+func NewChildProgress(total, closed int) *ChildProgress {
+	p := &ChildProgress{Total: total, Closed: closed}
+	if total > 0 {
+		p.Percent = closed * 100 / total
+	}
+	return p
+}
+
+// HierarchyRollup aggregates every descendant of an issue across all
+// parent-child levels, for initiative/epic/story portfolios.
+type HierarchyRollup struct {
+	Total            int `json:"total"`
+	Closed           int `json:"closed"`
+	Percent          int `json:"percent"`           // Closed*100/Total, rounded down
+	EstimatedMinutes int `json:"estimated_minutes"` // Sum of descendant estimates
+	RemainingMinutes int `json:"remaining_minutes"` // Sum of estimates on open descendants
+}
+
+// UnblockImpact measures the open work waiting, directly or through further
+// blocking dependencies, on one open blocker.
+type UnblockImpact struct {
+	Unblocks         int `json:"unblocks"`          // Open issues blocked transitively
+	Direct           int `json:"direct"`            // Open issues blocked directly
+	EstimatedMinutes int `json:"estimated_minutes"` // Sum of estimates on the blocked issues
+}
+
 // BondRef tracks compound molecule lineage.
 // When protos or molecules are bonded together, BondRefs record
 // which sources were combined and how they were attached.
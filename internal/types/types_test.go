@@ -1612,3 +1612,28 @@ func TestBondRefUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestCIStatusSummary(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func(name, sha, status string, minutes int) *CIRun {
+		return &CIRun{Name: name, CommitSHA: sha, Status: status, UpdatedAt: t0.Add(time.Duration(minutes) * time.Minute)}
+	}
+	tests := []struct {
+		name string
+		runs []*CIRun
+		want string
+	}{
+		{"none", nil, ""},
+		{"all green", []*CIRun{run("build", "a", CIStatusSuccess, 1), run("lint", "a", CIStatusSuccess, 2)}, CIStatusSuccess},
+		{"one red", []*CIRun{run("build", "a", CIStatusFailure, 1), run("lint", "a", CIStatusSuccess, 2)}, CIStatusFailure},
+		{"still running", []*CIRun{run("build", "a", CIStatusPending, 1), run("lint", "a", CIStatusSuccess, 2)}, CIStatusPending},
+		{"newer commit supersedes", []*CIRun{run("build", "a", CIStatusFailure, 1), run("build", "b", CIStatusSuccess, 5)}, CIStatusSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CIStatusSummary(tt.runs); got != tt.want {
+				t.Errorf("CIStatusSummary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
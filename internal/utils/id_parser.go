@@ -50,6 +50,11 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return issues[0].ID, nil
 	}
 
+	// Aliases (bd alias) name an issue directly.
+	if issueID, ok := resolveAlias(ctx, store, input); ok {
+		return issueID, nil
+	}
+
 	// Get the configured prefix
 	prefix, err := store.GetConfig(ctx, "issue_prefix")
 	if err != nil || prefix == "" {
@@ -227,6 +232,26 @@ func looksLikePartialIDHash(input string) bool {
 	return true
 }
 
+// resolveAlias looks input up in the issue_aliases table when the store
+// supports aliases. Aliases always contain a hyphen and never a dot, so
+// other inputs skip the query.
+func resolveAlias(ctx context.Context, store storage.Storage, input string) (string, bool) {
+	if !strings.Contains(input, "-") || strings.Contains(input, ".") {
+		return "", false
+	}
+	var as storage.AliasStore
+	if ds, ok := store.(storage.DoltStorage); ok {
+		as, _ = storage.UnwrapStore(ds).(storage.AliasStore)
+	} else {
+		as, _ = store.(storage.AliasStore)
+	}
+	if as == nil {
+		return "", false
+	}
+	issueID, err := as.ResolveAlias(ctx, input)
+	return issueID, err == nil
+}
+
 // ResolvePartialIDs resolves multiple potentially partial issue IDs.
 // Returns the resolved IDs and any errors encountered.
 func ResolvePartialIDs(ctx context.Context, store storage.Storage, inputs []string) ([]string, error) {
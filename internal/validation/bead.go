@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -165,3 +166,27 @@ func ValidateIDPrefixAllowed(id, dbPrefix, allowedPrefixes string, force bool) e
 	}
 	return fmt.Errorf("prefix mismatch: database uses '%s-' but ID '%s' doesn't match (use --force to override)", dbPrefix, id)
 }
+
+// MaxAliasLength bounds issue aliases so they stay easy to type.
+const MaxAliasLength = 64
+
+// aliasPattern is the alias grammar: lowercase words of letters and digits
+// joined by single hyphens, starting with a letter, at least two words
+// (e.g. "infra-login-bug"). Dots are reserved for hierarchical IDs.
+var aliasPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(?:-[a-z0-9]+)+$`)
+
+// ValidateAlias checks that alias follows the alias grammar and stays out
+// of the issue ID namespace: an alias starting with the database prefix or
+// an allowed prefix could collide with a future generated ID.
+func ValidateAlias(alias, dbPrefix, allowedPrefixes string) error {
+	if len(alias) > MaxAliasLength {
+		return fmt.Errorf("alias %q is longer than %d characters", alias, MaxAliasLength)
+	}
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("invalid alias %q (expected lowercase words joined by hyphens, e.g. 'infra-login-bug')", alias)
+	}
+	if dbPrefix != "" && ValidateIDPrefixAllowed(alias, dbPrefix, allowedPrefixes, false) == nil {
+		return fmt.Errorf("alias %q uses an issue ID prefix; pick a name that doesn't start with '%s-' or an allowed prefix", alias, dbPrefix)
+	}
+	return nil
+}
@@ -385,3 +385,35 @@ func TestValidateIDPrefixAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		alias   string
+		wantErr string
+	}{
+		{"infra-login-bug", ""},
+		{"q3-launch", ""},
+		{"login", "invalid alias"},
+		{"Infra-login", "invalid alias"},
+		{"infra--login", "invalid alias"},
+		{"infra-login.1", "invalid alias"},
+		{"9-lives", "invalid alias"},
+		{"bd-login", "issue ID prefix"},
+		{"ops-rota", "issue ID prefix"},
+		{"a-" + strings.Repeat("b", MaxAliasLength), "longer than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			err := ValidateAlias(tt.alias, "bd", "ops")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
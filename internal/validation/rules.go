@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ContentRules are the configurable content checks bd lint applies on top
+// of the per-type template sections. The zero value checks nothing.
+type ContentRules struct {
+	// RequireAcceptanceCriteria lists issue types whose acceptance criteria
+	// field must be non-empty.
+	RequireAcceptanceCriteria []types.IssueType
+	// MinDescriptionLength is the minimum trimmed description length.
+	MinDescriptionLength int
+	// MaxTitleLength is the maximum title length.
+	MaxTitleLength int
+	// TitlePattern is a regular expression every title must match.
+	TitlePattern *regexp.Regexp
+	// ForbiddenText lists placeholder strings (e.g. "TODO", "TBD") that may
+	// not appear in the title, description, design, or acceptance criteria.
+	// Matching is case-insensitive.
+	ForbiddenText []string
+	// RequirePriority flags issues whose priority is outside P0-P4, as
+	// happens when an import omits or mangles it.
+	RequirePriority bool
+}
+
+// RuleViolation is one content rule an issue breaks.
+type RuleViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// CheckContentRules returns the rules in r that issue violates.
+func CheckContentRules(issue *types.Issue, r ContentRules) []RuleViolation {
+	if issue == nil {
+		return nil
+	}
+	var violations []RuleViolation
+	add := func(rule, format string, args ...interface{}) {
+		violations = append(violations, RuleViolation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, t := range r.RequireAcceptanceCriteria {
+		if issue.IssueType == t && strings.TrimSpace(issue.AcceptanceCriteria) == "" {
+			add("acceptance-criteria", "%s has no acceptance criteria", issue.IssueType)
+			break
+		}
+	}
+	if r.MinDescriptionLength > 0 {
+		if n := len(strings.TrimSpace(issue.Description)); n < r.MinDescriptionLength {
+			add("description-length", "description is %d chars; at least %d required", n, r.MinDescriptionLength)
+		}
+	}
+	if r.MaxTitleLength > 0 && len(issue.Title) > r.MaxTitleLength {
+		add("title-length", "title is %d chars; at most %d allowed", len(issue.Title), r.MaxTitleLength)
+	}
+	if r.TitlePattern != nil && !r.TitlePattern.MatchString(issue.Title) {
+		add("title-pattern", "title does not match %s", r.TitlePattern)
+	}
+	if len(r.ForbiddenText) > 0 {
+		fields := []struct{ name, text string }{
+			{"title", issue.Title},
+			{"description", issue.Description},
+			{"design", issue.Design},
+			{"acceptance criteria", issue.AcceptanceCriteria},
+		}
+		for _, forbidden := range r.ForbiddenText {
+			needle := strings.ToLower(forbidden)
+			if needle == "" {
+				continue
+			}
+			for _, f := range fields {
+				if strings.Contains(strings.ToLower(f.text), needle) {
+					add("forbidden-text", "%s contains placeholder %q", f.name, forbidden)
+				}
+			}
+		}
+	}
+	if r.RequirePriority && (issue.Priority < 0 || issue.Priority > 4) {
+		add("priority", "priority %d is missing or out of range (P0-P4)", issue.Priority)
+	}
+	return violations
+}
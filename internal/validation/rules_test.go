@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckContentRules(t *testing.T) {
+	rules := ContentRules{
+		RequireAcceptanceCriteria: []types.IssueType{types.TypeFeature},
+		MinDescriptionLength:      20,
+		MaxTitleLength:            30,
+		TitlePattern:              regexp.MustCompile(`^[A-Z]`),
+		ForbiddenText:             []string{"TODO", "lorem ipsum"},
+		RequirePriority:           true,
+	}
+
+	tests := []struct {
+		name      string
+		issue     *types.Issue
+		wantRules []string
+	}{
+		{
+			name: "clean feature",
+			issue: &types.Issue{
+				Title:              "Add dark mode",
+				Description:        "Users want a dark theme for night work.",
+				AcceptanceCriteria: "Toggle in settings",
+				IssueType:          types.TypeFeature,
+				Priority:           2,
+			},
+		},
+		{
+			name:      "feature without acceptance criteria",
+			issue:     &types.Issue{Title: "Add dark mode", Description: "Users want a dark theme for night work.", IssueType: types.TypeFeature},
+			wantRules: []string{"acceptance-criteria"},
+		},
+		{
+			name:      "task needs no acceptance criteria",
+			issue:     &types.Issue{Title: "Bump deps", Description: "Routine dependency upgrade.", IssueType: types.TypeTask},
+			wantRules: nil,
+		},
+		{
+			name:      "short description and bad title",
+			issue:     &types.Issue{Title: "fix the thing that is broken in prod", Description: "broken", IssueType: types.TypeBug},
+			wantRules: []string{"description-length", "title-length", "title-pattern"},
+		},
+		{
+			name:      "placeholders in two fields",
+			issue:     &types.Issue{Title: "TODO: name this", Description: "Lorem ipsum dolor sit amet, consectetur.", IssueType: types.TypeTask},
+			wantRules: []string{"forbidden-text", "forbidden-text"},
+		},
+		{
+			name:      "out of range priority",
+			issue:     &types.Issue{Title: "Bump deps", Description: "Routine dependency upgrade.", IssueType: types.TypeTask, Priority: 7},
+			wantRules: []string{"priority"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckContentRules(tt.issue, rules)
+			if len(got) != len(tt.wantRules) {
+				t.Fatalf("got %d violations %+v, want rules %v", len(got), got, tt.wantRules)
+			}
+			for i, v := range got {
+				if v.Rule != tt.wantRules[i] {
+					t.Errorf("violation %d rule = %q, want %q (%s)", i, v.Rule, tt.wantRules[i], v.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckContentRulesZeroValue(t *testing.T) {
+	if got := CheckContentRules(&types.Issue{Priority: -1}, ContentRules{}); len(got) != 0 {
+		t.Errorf("zero rules reported %+v", got)
+	}
+}
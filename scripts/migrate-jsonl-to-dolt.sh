@@ -115,6 +115,12 @@ sql_escape() {
     sed -e "s/\\\\/\\\\\\\\/g" -e "s/'/\\\\'/g"
 }
 
+# Succeed for the schema header line ({"_schema":...}) that
+# 'bd export --schema-header' writes first; it is not a row.
+is_header() {
+    [[ "$(echo "$1" | jq -r 'has("_schema")' 2>/dev/null)" == "true" ]]
+}
+
 # Counters
 issues_count=0
 labels_count=0
@@ -149,6 +155,7 @@ import_issues() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         # Extract columns and values from JSON
         local cols vals
         cols=$(echo "$line" | jq -r 'keys_unsorted | map(if . == "key" then "`key`" else . end) | join(", ")')
@@ -208,6 +215,7 @@ import_labels() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         local issue_id label
         issue_id=$(echo "$line" | jq -r '.issue_id' | sql_escape)
         label=$(echo "$line" | jq -r '.label' | sql_escape)
@@ -243,6 +251,7 @@ import_dependencies() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         local issue_id depends_on_id dep_type created_at created_by
         issue_id=$(echo "$line" | jq -r '.issue_id')
         depends_on_id=$(echo "$line" | jq -r '.depends_on_id')
@@ -286,6 +295,7 @@ import_events() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         local id issue_id event_type actor old_value new_value comment created_at
         id=$(echo "$line" | jq -r '.id')
         issue_id=$(echo "$line" | jq -r '.issue_id' | sql_escape)
@@ -330,6 +340,7 @@ import_comments() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         local id issue_id author text created_at
         id=$(echo "$line" | jq -r '.id')
         issue_id=$(echo "$line" | jq -r '.issue_id' | sql_escape)
@@ -363,6 +374,7 @@ import_config() {
 
     local sql="START TRANSACTION;\n"
     while IFS= read -r line; do
+        is_header "$line" && continue
         local key value
         key=$(echo "$line" | jq -r '.key' | sql_escape)
         value=$(echo "$line" | jq -r '.value' | sql_escape)